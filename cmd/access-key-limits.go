@@ -0,0 +1,227 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/minio/minio/internal/http/stats"
+	"golang.org/x/time/rate"
+)
+
+// AccessKeyLimits holds the limits of the S3 traffic of a user or a
+// service account across the cluster, zero means unlimited. Every
+// node enforces an equal share of the limits.
+type AccessKeyLimits struct {
+	// RequestsPerSecond is the number of requests per second,
+	// requests over the limit are rejected with SlowDown.
+	RequestsPerSecond int `json:"requestsPerSecond,omitempty"`
+	// Bandwidth is the number of bytes per second shared by
+	// the ingress and egress traffic.
+	Bandwidth int64 `json:"bandwidth,omitempty"`
+}
+
+// IsEmpty returns whether no limit is set.
+func (l AccessKeyLimits) IsEmpty() bool {
+	return l.RequestsPerSecond == 0 && l.Bandwidth == 0
+}
+
+// Validate returns an error if a limit is negative.
+func (l AccessKeyLimits) Validate() error {
+	if l.RequestsPerSecond < 0 || l.Bandwidth < 0 {
+		return errInvalidAccessKeyLimits
+	}
+	return nil
+}
+
+// accessKeyLimitsConfig is the IAM object holding
+// the limits of all the limited access keys.
+type accessKeyLimitsConfig struct {
+	Version int                        `json:"version"`
+	Limits  map[string]AccessKeyLimits `json:"limits"`
+}
+
+func getAccessKeyLimitsPath() string {
+	return iamConfigPrefix + SlashSeparator + iamAccessKeyLimitsFile
+}
+
+// accessKeyThrottle holds the limiters of an access key and
+// counts the requests rejected by its requests per second limit.
+type accessKeyThrottle struct {
+	// Atomic counter, placed first so alignment is guaranteed.
+	throttledRequests uint64
+
+	limits    AccessKeyLimits
+	requests  *rate.Limiter
+	bandwidth *bandwidthThrottle
+}
+
+// accessKeyThrottles holds the throttles of the access
+// keys with limits, the zero value is ready to use.
+type accessKeyThrottles struct {
+	mu   sync.RWMutex
+	keys map[string]*accessKeyThrottle
+}
+
+// replace applies limits to all access keys, the throttles of
+// an access key are kept as long as its limits are unchanged.
+func (t *accessKeyThrottles) replace(limits map[string]AccessKeyLimits) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	keys := make(map[string]*accessKeyThrottle, len(limits))
+	for accessKey, l := range limits {
+		if l.IsEmpty() {
+			continue
+		}
+		if current, ok := t.keys[accessKey]; ok && current.limits == l {
+			keys[accessKey] = current
+			continue
+		}
+		keys[accessKey] = &accessKeyThrottle{
+			limits:    l,
+			requests:  newRequestsLimiter(l.RequestsPerSecond),
+			bandwidth: newBandwidthThrottle(l.Bandwidth),
+		}
+	}
+	t.keys = keys
+}
+
+func (t *accessKeyThrottles) get(accessKey string) *accessKeyThrottle {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	return t.keys[accessKey]
+}
+
+// reserveRequest accounts a request of accessKey, if its limit is
+// exceeded it returns how long to wait before retrying and the
+// request is not accounted.
+func (t *accessKeyThrottles) reserveRequest(accessKey string) (retryAfter time.Duration) {
+	kt := t.get(accessKey)
+	if kt == nil || kt.requests == nil {
+		return 0
+	}
+	now := time.Now()
+	reservation := kt.requests.ReserveN(now, 1)
+	if retryAfter = reservation.DelayFrom(now); retryAfter > 0 {
+		reservation.CancelAt(now)
+		atomic.AddUint64(&kt.throttledRequests, 1)
+	}
+	return retryAfter
+}
+
+// throttle returns the throttle of the traffic of accessKey,
+// nil if its bandwidth is not limited.
+func (t *accessKeyThrottles) throttle(accessKey string) stats.Throttle {
+	kt := t.get(accessKey)
+	if kt == nil || kt.bandwidth == nil {
+		// Avoid returning typed nil pointers as non nil interfaces.
+		return nil
+	}
+	return kt.bandwidth
+}
+
+// accessKeyThrottleStats describes the throttle of an access key.
+type accessKeyThrottleStats struct {
+	accessKey         string
+	throttledRequests uint64
+}
+
+// load returns the state of all throttles.
+func (t *accessKeyThrottles) load() []accessKeyThrottleStats {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	result := make([]accessKeyThrottleStats, 0, len(t.keys))
+	for accessKey, kt := range t.keys {
+		result = append(result, accessKeyThrottleStats{
+			accessKey:         accessKey,
+			throttledRequests: atomic.LoadUint64(&kt.throttledRequests),
+		})
+	}
+	return result
+}
+
+// joinedThrottle passes the traffic through all of its throttles.
+type joinedThrottle []stats.Throttle
+
+// joinThrottles returns a throttle enforcing both a and b, either
+// of which may be nil.
+func joinThrottles(a, b stats.Throttle) stats.Throttle {
+	switch {
+	case a == nil:
+		return b
+	case b == nil:
+		return a
+	}
+	return joinedThrottle{a, b}
+}
+
+// Burst returns the smallest burst of the throttles.
+func (t joinedThrottle) Burst() int {
+	burst := t[0].Burst()
+	for _, throttle := range t[1:] {
+		if b := throttle.Burst(); b < burst {
+			burst = b
+		}
+	}
+	return burst
+}
+
+// WaitN blocks until n bytes may pass all throttles or ctx is done.
+func (t joinedThrottle) WaitN(ctx context.Context, n int) error {
+	for _, throttle := range t {
+		if err := throttle.WaitN(ctx, n); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// limitAuthenticatedRequest applies the limits of accessKey to the
+// request being served, once its signature is verified so that requests
+// naming the access key of another user cannot use up its limits. The
+// request is accounted once to the requests per second limit, ErrSlowDown
+// is returned over the limit, and its traffic is throttled from then on
+// by the bandwidth limit.
+func limitAuthenticatedRequest(ctx context.Context, accessKey string) APIErrorCode {
+	current, ok := ctx.Value(currentRequestCtxKey{}).(*currentRequest)
+	if ok {
+		if current.accessKeyLimited {
+			return ErrNone
+		}
+		current.accessKeyLimited = true
+	}
+
+	if retryAfter := globalAccessKeyThrottles.reserveRequest(accessKey); retryAfter > 0 {
+		var api string
+		if ok {
+			current.retryAfter = retryAfter
+			api = current.api
+		}
+		globalHTTPStats.totalS3RateLimited.Inc(api)
+		return ErrSlowDown
+	}
+
+	if throttle := globalAccessKeyThrottles.throttle(accessKey); throttle != nil && ok && current.meteredRequest != nil {
+		current.meteredRequest.Throttle = joinThrottles(current.meteredRequest.Throttle, throttle)
+		current.meteredResponse.Throttle = joinThrottles(current.meteredResponse.Throttle, throttle)
+	}
+	return ErrNone
+}
@@ -0,0 +1,128 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"net/http"
+	"testing"
+
+	xhttp "github.com/minio/minio/internal/http"
+)
+
+func TestAccessKeyThrottles(t *testing.T) {
+	var throttles accessKeyThrottles
+	if retryAfter := throttles.reserveRequest("user"); retryAfter != 0 {
+		t.Fatal("expected no limit for an access key without limits")
+	}
+	if throttles.throttle("user") != nil {
+		t.Fatal("expected no throttle for an access key without limits")
+	}
+
+	throttles.replace(map[string]AccessKeyLimits{
+		"user":  {RequestsPerSecond: 2},
+		"other": {Bandwidth: 1 << 20},
+		"empty": {},
+	})
+	if throttles.throttle("user") != nil {
+		t.Error("expected no bandwidth throttle for user")
+	}
+	if throttle := throttles.throttle("other"); throttle == nil || throttle.Burst() != 1<<20 {
+		t.Fatalf("expected a throttle of 1 MiB, got %v", throttle)
+	}
+	if throttles.get("empty") != nil {
+		t.Error("expected empty limits to be ignored")
+	}
+
+	for i := 0; i < 2; i++ {
+		if retryAfter := throttles.reserveRequest("user"); retryAfter != 0 {
+			t.Fatalf("expected request %d to be allowed, retry after %v", i, retryAfter)
+		}
+	}
+	if retryAfter := throttles.reserveRequest("user"); retryAfter <= 0 {
+		t.Fatal("expected the request over the limit to be rejected")
+	}
+
+	// Unchanged limits keep the state of the throttles.
+	throttles.replace(map[string]AccessKeyLimits{"user": {RequestsPerSecond: 2}})
+	loaded := throttles.load()
+	if len(loaded) != 1 || loaded[0].accessKey != "user" || loaded[0].throttledRequests != 1 {
+		t.Errorf("unexpected throttle stats %+v", loaded)
+	}
+
+	throttles.replace(nil)
+	if throttles.get("user") != nil {
+		t.Error("expected the throttles to be removed")
+	}
+}
+
+func TestJoinThrottles(t *testing.T) {
+	small, large := newBandwidthThrottle(1000), newBandwidthThrottle(2000)
+	if joinThrottles(nil, nil) != nil {
+		t.Error("expected no throttle")
+	}
+	if joinThrottles(small, nil) != small || joinThrottles(nil, large) != large {
+		t.Error("expected the only throttle to be returned")
+	}
+	if burst := joinThrottles(large, small).Burst(); burst != 1000 {
+		t.Errorf("expected the smallest burst 1000, got %d", burst)
+	}
+}
+
+// Tests that the limits of an access key are only used
+// up by the requests with a valid signature of the key.
+func TestAccessKeyLimitsAuthenticated(t *testing.T) {
+	testServer := StartTestServer(t, "FS")
+	defer testServer.Stop()
+
+	savedThrottles := globalAccessKeyThrottles
+	defer func() { globalAccessKeyThrottles = savedThrottles }()
+	globalAccessKeyThrottles = &accessKeyThrottles{}
+	globalAccessKeyThrottles.replace(map[string]AccessKeyLimits{
+		testServer.AccessKey: {RequestsPerSecond: 1},
+	})
+
+	listBuckets := func(secretKey string) *http.Response {
+		t.Helper()
+		req, err := newTestSignedRequestV4(http.MethodGet, getListBucketURL(testServer.Server.URL), 0, nil, testServer.AccessKey, secretKey, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		resp.Body.Close()
+		return resp
+	}
+
+	// Requests naming the access key with an invalid signature
+	// are rejected without using up the limit of the key.
+	for i := 0; i < 3; i++ {
+		if resp := listBuckets("invalid-secret-key"); resp.StatusCode != http.StatusForbidden {
+			t.Fatalf("expected an invalid signature to be rejected, got %d", resp.StatusCode)
+		}
+	}
+	if resp := listBuckets(testServer.SecretKey); resp.StatusCode != http.StatusOK {
+		t.Fatalf("expected the first valid request to be allowed, got %d", resp.StatusCode)
+	}
+	resp := listBuckets(testServer.SecretKey)
+	if resp.StatusCode != http.StatusServiceUnavailable || resp.Header.Get(xhttp.RetryAfter) != "1" {
+		t.Fatalf("expected the valid request over the limit to be rejected, got %d, Retry-After %q",
+			resp.StatusCode, resp.Header.Get(xhttp.RetryAfter))
+	}
+}
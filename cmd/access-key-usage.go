@@ -0,0 +1,256 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"path"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/cespare/xxhash/v2"
+	"github.com/minio/madmin-go"
+	"github.com/minio/minio/internal/auth"
+	"github.com/minio/minio/internal/logger"
+)
+
+const (
+	// Every node saves the usage of the access keys it
+	// authenticated below this prefix, in its own object.
+	accessKeyUsagePrefix = minioConfigPrefix + "/access-key-usage/"
+
+	accessKeyUsageSaveInterval = 5 * time.Minute
+
+	// An authentication within this period after the
+	// recorded one is not recorded.
+	accessKeyUsageResolution = time.Minute
+)
+
+// AccessKeyUsage describes the last successful
+// authentication of an access key.
+type AccessKeyUsage struct {
+	LastUsed time.Time `json:"lastUsed"`
+	Source   string    `json:"source,omitempty"`
+}
+
+// accessKeyUsageTracker records when the access keys were last
+// used on this node, the zero value is ready to use.
+type accessKeyUsageTracker struct {
+	mu    sync.RWMutex
+	keys  map[string]AccessKeyUsage
+	dirty bool
+}
+
+// record records the successful authentication of cred from
+// source, temporary credentials are not recorded.
+func (t *accessKeyUsageTracker) record(cred auth.Credentials, source string) {
+	if cred.AccessKey == "" || cred.IsTemp() {
+		return
+	}
+	now := UTCNow()
+
+	// Most authentications are within the resolution of the
+	// recorded one, avoid the write lock for them.
+	t.mu.RLock()
+	usage, ok := t.keys[cred.AccessKey]
+	t.mu.RUnlock()
+	if ok && now.Sub(usage.LastUsed) < accessKeyUsageResolution && usage.Source == source {
+		return
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.keys == nil {
+		t.keys = make(map[string]AccessKeyUsage)
+	}
+	t.keys[cred.AccessKey] = AccessKeyUsage{LastUsed: now, Source: source}
+	t.dirty = true
+}
+
+// merge adds the usage of m which is more recent than the recorded one.
+func (t *accessKeyUsageTracker) merge(m map[string]AccessKeyUsage) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.keys == nil {
+		t.keys = make(map[string]AccessKeyUsage, len(m))
+	}
+	mergeAccessKeyUsage(t.keys, m)
+}
+
+// snapshot returns a copy of the recorded usage.
+func (t *accessKeyUsageTracker) snapshot() map[string]AccessKeyUsage {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	m := make(map[string]AccessKeyUsage, len(t.keys))
+	for k, v := range t.keys {
+		m[k] = v
+	}
+	return m
+}
+
+// save saves the usage recorded on this node if it changed.
+func (t *accessKeyUsageTracker) save(ctx context.Context, objAPI ObjectLayer) error {
+	t.mu.Lock()
+	if !t.dirty {
+		t.mu.Unlock()
+		return nil
+	}
+	data, err := json.Marshal(t.keys)
+	t.dirty = false
+	t.mu.Unlock()
+	if err != nil {
+		return err
+	}
+
+	if err = saveConfig(ctx, objAPI, getAccessKeyUsagePath(), data); err != nil {
+		t.mu.Lock()
+		t.dirty = true
+		t.mu.Unlock()
+	}
+	return err
+}
+
+// mergeAccessKeyUsage adds the usage of src which is more recent than the one in dst.
+func mergeAccessKeyUsage(dst, src map[string]AccessKeyUsage) {
+	for accessKey, usage := range src {
+		if current, ok := dst[accessKey]; !ok || usage.LastUsed.After(current.LastUsed) {
+			dst[accessKey] = usage
+		}
+	}
+}
+
+// getAccessKeyUsagePath returns the path of the usage saved by this node.
+func getAccessKeyUsagePath() string {
+	return path.Join(accessKeyUsagePrefix, fmt.Sprintf("%016x.json", xxhash.Sum64String(globalLocalNodeName)))
+}
+
+// loadAccessKeyUsage returns the usage of the access keys across
+// the cluster, the usage saved by the other nodes may be behind
+// by up to accessKeyUsageSaveInterval.
+func loadAccessKeyUsage(ctx context.Context, objAPI ObjectLayer) (map[string]AccessKeyUsage, error) {
+	m := globalAccessKeyUsage.snapshot()
+	if objAPI == nil {
+		return m, nil
+	}
+	for item := range listIAMConfigItems(ctx, objAPI, accessKeyUsagePrefix) {
+		if item.Err != nil {
+			return nil, item.Err
+		}
+		data, err := readConfig(ctx, objAPI, accessKeyUsagePrefix+item.Item)
+		if err != nil {
+			if err == errConfigNotFound {
+				continue
+			}
+			return nil, err
+		}
+		var saved map[string]AccessKeyUsage
+		if err = json.Unmarshal(data, &saved); err != nil {
+			logger.LogIf(ctx, fmt.Errorf("Unable to parse access key usage %s: %w", item.Item, err))
+			continue
+		}
+		mergeAccessKeyUsage(m, saved)
+	}
+	return m, nil
+}
+
+// AccessKeyUsageEntry is an entry of the access key usage report.
+type AccessKeyUsageEntry struct {
+	AccessKey  string               `json:"accessKey"`
+	Type       string               `json:"type"`
+	ParentUser string               `json:"parentUser,omitempty"`
+	Status     madmin.AccountStatus `json:"status"`
+	LastUsed   *time.Time           `json:"lastUsed,omitempty"`
+	Source     string               `json:"source,omitempty"`
+}
+
+// accessKeyUsageReport returns the report entries of creds sorted by access
+// key, only the access keys not used since the dormant time are reported
+// unless it is zero. Access keys never used are always reported.
+func accessKeyUsageReport(creds []auth.Credentials, usage map[string]AccessKeyUsage, dormant time.Time) []AccessKeyUsageEntry {
+	entries := make([]AccessKeyUsageEntry, 0, len(creds))
+	for _, cred := range creds {
+		u, used := usage[cred.AccessKey]
+		if used && !dormant.IsZero() && !u.LastUsed.Before(dormant) {
+			continue
+		}
+		entry := AccessKeyUsageEntry{
+			AccessKey:  cred.AccessKey,
+			Type:       "user",
+			ParentUser: cred.ParentUser,
+			Status:     madmin.AccountEnabled,
+		}
+		if cred.IsServiceAccount() {
+			entry.Type = "service-account"
+		}
+		if !cred.IsValid() {
+			entry.Status = madmin.AccountDisabled
+		}
+		if used {
+			lastUsed := u.LastUsed
+			entry.LastUsed = &lastUsed
+			entry.Source = u.Source
+		}
+		entries = append(entries, entry)
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].AccessKey < entries[j].AccessKey
+	})
+	return entries
+}
+
+// getAccessKeyUsage returns the usage of an access key across the cluster.
+func getAccessKeyUsage(ctx context.Context, objAPI ObjectLayer, accessKey string) (AccessKeyUsage, bool) {
+	m, err := loadAccessKeyUsage(ctx, objAPI)
+	if err != nil {
+		logger.LogIf(ctx, err)
+		m = globalAccessKeyUsage.snapshot()
+	}
+	usage, ok := m[accessKey]
+	return usage, ok
+}
+
+// initAccessKeyUsage restores the usage recorded by this node before
+// a restart and saves the recorded usage periodically.
+func initAccessKeyUsage(ctx context.Context, objAPI ObjectLayer) {
+	go func() {
+		data, err := readConfig(ctx, objAPI, getAccessKeyUsagePath())
+		if err == nil {
+			var saved map[string]AccessKeyUsage
+			if err = json.Unmarshal(data, &saved); err == nil {
+				globalAccessKeyUsage.merge(saved)
+			}
+		}
+		if err != nil && err != errConfigNotFound {
+			logger.LogIf(ctx, fmt.Errorf("Unable to restore access key usage: %w", err))
+		}
+
+		t := time.NewTicker(accessKeyUsageSaveInterval)
+		defer t.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-t.C:
+				logger.LogIf(ctx, globalAccessKeyUsage.save(ctx, objAPI))
+			}
+		}
+	}()
+}
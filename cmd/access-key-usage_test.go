@@ -0,0 +1,93 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"testing"
+	"time"
+
+	"github.com/minio/madmin-go"
+	"github.com/minio/minio/internal/auth"
+)
+
+func TestAccessKeyUsageTracker(t *testing.T) {
+	var tracker accessKeyUsageTracker
+
+	tracker.record(auth.Credentials{AccessKey: "user", Status: auth.AccountOn}, "10.0.0.1")
+	tracker.record(auth.Credentials{AccessKey: "sts", SessionToken: "token", Expiration: UTCNow().Add(time.Hour)}, "10.0.0.2")
+	tracker.record(auth.Credentials{}, "10.0.0.3")
+
+	m := tracker.snapshot()
+	if len(m) != 1 {
+		t.Fatalf("expected only the user to be recorded, got %v", m)
+	}
+	if m["user"].Source != "10.0.0.1" || m["user"].LastUsed.IsZero() {
+		t.Fatalf("unexpected usage of the user %v", m["user"])
+	}
+
+	// A new source is recorded even within the resolution.
+	tracker.record(auth.Credentials{AccessKey: "user"}, "10.0.0.4")
+	if source := tracker.snapshot()["user"].Source; source != "10.0.0.4" {
+		t.Fatalf("expected the new source to be recorded, got %s", source)
+	}
+
+	old := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	tracker.merge(map[string]AccessKeyUsage{
+		"user":  {LastUsed: old, Source: "10.0.0.5"},
+		"other": {LastUsed: old, Source: "10.0.0.6"},
+	})
+	m = tracker.snapshot()
+	if m["user"].Source != "10.0.0.4" {
+		t.Fatalf("expected older usage not to replace the recorded one, got %v", m["user"])
+	}
+	if m["other"].Source != "10.0.0.6" {
+		t.Fatalf("expected the usage of other to be merged, got %v", m["other"])
+	}
+}
+
+func TestAccessKeyUsageReport(t *testing.T) {
+	now := UTCNow()
+	creds := []auth.Credentials{
+		{AccessKey: "svc", SecretKey: "svc-secret", ParentUser: "user", Status: auth.AccountOn},
+		{AccessKey: "user", SecretKey: "user-secret", Status: auth.AccountOn},
+		{AccessKey: "disabled", SecretKey: "disabled-secret", Status: auth.AccountOff},
+	}
+	usage := map[string]AccessKeyUsage{
+		"user": {LastUsed: now.Add(-time.Hour), Source: "10.0.0.1"},
+		"svc":  {LastUsed: now.Add(-48 * time.Hour), Source: "10.0.0.2"},
+	}
+
+	entries := accessKeyUsageReport(creds, usage, time.Time{})
+	if len(entries) != 3 {
+		t.Fatalf("expected all access keys to be reported, got %v", entries)
+	}
+	if entries[0].AccessKey != "disabled" || entries[0].Status != madmin.AccountDisabled || entries[0].LastUsed != nil {
+		t.Fatalf("unexpected entry %v", entries[0])
+	}
+	if entries[1].AccessKey != "svc" || entries[1].Type != "service-account" || entries[1].ParentUser != "user" || entries[1].Source != "10.0.0.2" {
+		t.Fatalf("unexpected entry %v", entries[1])
+	}
+	if entries[2].AccessKey != "user" || entries[2].Type != "user" || !entries[2].LastUsed.Equal(now.Add(-time.Hour)) {
+		t.Fatalf("unexpected entry %v", entries[2])
+	}
+
+	entries = accessKeyUsageReport(creds, usage, now.Add(-24*time.Hour))
+	if len(entries) != 2 || entries[0].AccessKey != "disabled" || entries[1].AccessKey != "svc" {
+		t.Fatalf("expected only the dormant access keys to be reported, got %v", entries)
+	}
+}
@@ -108,6 +108,20 @@ func toAdminAPIErr(ctx context.Context, err error) APIError {
 				Description:    err.Error(),
 				HTTPStatusCode: http.StatusBadRequest,
 			}
+		case errors.Is(err, errRebalanceAlreadyStarted),
+			errors.Is(err, errRebalanceNotNeeded),
+			errors.Is(err, errRebalanceDecomRunning):
+			apiErr = APIError{
+				Code:           "XMinioRebalanceNotAllowed",
+				Description:    err.Error(),
+				HTTPStatusCode: http.StatusBadRequest,
+			}
+		case errors.Is(err, errRebalanceNotStarted):
+			apiErr = APIError{
+				Code:           "XMinioRebalanceNotStarted",
+				Description:    err.Error(),
+				HTTPStatusCode: http.StatusNotFound,
+			}
 		case errors.Is(err, errConfigNotFound):
 			apiErr = APIError{
 				Code:           "XMinioConfigError",
@@ -132,6 +146,25 @@ func toAdminAPIErr(ctx context.Context, err error) APIError {
 				Description:    err.Error(),
 				HTTPStatusCode: http.StatusBadRequest,
 			}
+		case errors.Is(err, errInvalidServiceAccountExpiry):
+			apiErr = APIError{
+				Code:           "XMinioAdminInvalidServiceAccountExpiry",
+				Description:    err.Error(),
+				HTTPStatusCode: http.StatusBadRequest,
+			}
+		case errors.Is(err, errInvalidAccessKeyLimits):
+			apiErr = APIError{
+				Code:           "XMinioAdminInvalidAccessKeyLimits",
+				Description:    err.Error(),
+				HTTPStatusCode: http.StatusBadRequest,
+			}
+		case errors.Is(err, errIAMExportSigningKey), errors.Is(err, errIAMExportSignature),
+			errors.Is(err, errIAMExportVersion):
+			apiErr = APIError{
+				Code:           "XMinioAdminInvalidIAMExport",
+				Description:    err.Error(),
+				HTTPStatusCode: http.StatusBadRequest,
+			}
 		case errors.Is(err, errIAMNotInitialized):
 			apiErr = APIError{
 				Code:           "XMinioIAMNotInitialized",
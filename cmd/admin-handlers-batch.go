@@ -0,0 +1,169 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"context"
+	"crypto/ed25519"
+	"encoding/base64"
+	"encoding/json"
+	"io"
+	"net/http"
+
+	humanize "github.com/dustin/go-humanize"
+	"github.com/gorilla/mux"
+	"github.com/minio/minio/internal/logger"
+	iampolicy "github.com/minio/pkg/iam/policy"
+)
+
+// Maximum size of a batch job request sent to the StartBatchJob handler.
+const maxBatchJobRequestSize = 1 * humanize.MiByte
+
+// StartBatchJob - POST /minio/admin/v3/start-job
+// ----------
+// Starts the batch job described by the JSON request body and returns
+// it. Jobs operate on the objects of any bucket, there are no dedicated
+// policy actions for batch jobs so starting one requires the permission
+// to update the configuration of the server.
+func (a adminAPIHandlers) StartBatchJob(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "StartBatchJob")
+
+	defer logger.AuditLog(ctx, w, r, mustGetClaimsFromToken(r))
+
+	objectAPI, _ := validateAdminReq(ctx, w, r, iampolicy.ConfigUpdateAdminAction)
+	if objectAPI == nil {
+		return
+	}
+
+	var req BatchJobRequest
+	if err := json.NewDecoder(io.LimitReader(r.Body, maxBatchJobRequestSize)).Decode(&req); err != nil {
+		writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErrWithErr(ErrAdminConfigBadJSON, err), r.URL)
+		return
+	}
+
+	job, err := startBatchJob(ctx, objectAPI, req)
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+	writeBatchJobResponse(ctx, w, r, job)
+}
+
+// ListBatchJobs - GET /minio/admin/v3/list-jobs
+// ----------
+// Returns all batch jobs with their status and progress, oldest first.
+func (a adminAPIHandlers) ListBatchJobs(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "ListBatchJobs")
+
+	defer logger.AuditLog(ctx, w, r, mustGetClaimsFromToken(r))
+
+	objectAPI, _ := validateAdminReq(ctx, w, r, iampolicy.ServerInfoAdminAction)
+	if objectAPI == nil {
+		return
+	}
+
+	jobs, err := listBatchJobs(ctx, objectAPI)
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+	if jobs == nil {
+		jobs = []BatchJob{}
+	}
+	data, err := json.Marshal(jobs)
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+	writeSuccessResponseJSON(w, data)
+}
+
+// DescribeBatchJob - GET /minio/admin/v3/describe-job?jobId=<id>
+// ----------
+// Returns the batch job with the jobId query parameter.
+func (a adminAPIHandlers) DescribeBatchJob(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "DescribeBatchJob")
+
+	defer logger.AuditLog(ctx, w, r, mustGetClaimsFromToken(r))
+
+	objectAPI, _ := validateAdminReq(ctx, w, r, iampolicy.ServerInfoAdminAction)
+	if objectAPI == nil {
+		return
+	}
+
+	job, err := loadBatchJob(ctx, objectAPI, mux.Vars(r)["jobId"])
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+	writeBatchJobResponse(ctx, w, r, job)
+}
+
+// CancelBatchJob - POST /minio/admin/v3/cancel-job?jobId=<id>
+// ----------
+// Cancels the active batch job with the jobId query parameter, the
+// tasks run so far are kept in its completion report.
+func (a adminAPIHandlers) CancelBatchJob(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "CancelBatchJob")
+
+	defer logger.AuditLog(ctx, w, r, mustGetClaimsFromToken(r))
+
+	objectAPI, _ := validateAdminReq(ctx, w, r, iampolicy.ConfigUpdateAdminAction)
+	if objectAPI == nil {
+		return
+	}
+
+	job, err := cancelBatchJob(ctx, objectAPI, mux.Vars(r)["jobId"])
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+	writeBatchJobResponse(ctx, w, r, job)
+}
+
+// ComplianceAuditKey - GET /minio/admin/v3/compliance-audit-key
+// ----------
+// Returns the public key verifying the signatures of the compliance
+// audit reports of the cluster, encoded in base64.
+func (a adminAPIHandlers) ComplianceAuditKey(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "ComplianceAuditKey")
+
+	defer logger.AuditLog(ctx, w, r, mustGetClaimsFromToken(r))
+
+	objectAPI, _ := validateAdminReq(ctx, w, r, iampolicy.ServerInfoAdminAction)
+	if objectAPI == nil {
+		return
+	}
+
+	key := complianceAuditKey().Public().(ed25519.PublicKey)
+	data, err := json.Marshal(map[string]string{"publicKey": base64.StdEncoding.EncodeToString(key)})
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+	writeSuccessResponseJSON(w, data)
+}
+
+func writeBatchJobResponse(ctx context.Context, w http.ResponseWriter, r *http.Request, job *BatchJob) {
+	data, err := json.Marshal(job)
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+	writeSuccessResponseJSON(w, data)
+}
@@ -0,0 +1,159 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/minio/minio/internal/logger"
+	iampolicy "github.com/minio/pkg/iam/policy"
+)
+
+const (
+	defaultDrainTimeout   = time.Minute
+	drainProgressInterval = time.Second
+)
+
+// ServerDrainProgress holds the requests a node still has to serve.
+type ServerDrainProgress struct {
+	Node     string `json:"node"`
+	InFlight int    `json:"inFlight"`
+	Waiting  int32  `json:"waiting"`
+	Error    string `json:"error,omitempty"`
+}
+
+// DrainProgress is reported while the cluster drains, nodes which
+// could not be reached are reported with an error and never drain.
+type DrainProgress struct {
+	Time     time.Time             `json:"time"`
+	Servers  []ServerDrainProgress `json:"servers"`
+	Drained  bool                  `json:"drained"`
+	TimedOut bool                  `json:"timedOut"`
+}
+
+// newDrainProgress returns the drain progress of the nodes in stats.
+func newDrainProgress(stats []ServerLiveStats) DrainProgress {
+	progress := DrainProgress{Time: UTCNow(), Drained: true}
+	for _, s := range stats {
+		server := ServerDrainProgress{
+			Node:    s.Node,
+			Waiting: s.HTTPStats.S3RequestsInQueue,
+			Error:   s.Error,
+		}
+		for _, inflight := range s.HTTPStats.CurrentS3Requests.APIStats {
+			server.InFlight += inflight
+		}
+		if server.InFlight > 0 || server.Waiting > 0 || server.Error != "" {
+			progress.Drained = false
+		}
+		progress.Servers = append(progress.Servers, server)
+	}
+	return progress
+}
+
+// streamDrainProgress writes the drain progress returned by collect
+// every interval until all nodes are drained or timeout expires.
+func streamDrainProgress(ctx context.Context, w http.ResponseWriter, timeout, interval time.Duration, collect func(context.Context) []ServerLiveStats) {
+	end := time.Now().Add(timeout)
+	enc := json.NewEncoder(w)
+	for {
+		progress := newDrainProgress(collect(ctx))
+		progress.TimedOut = !progress.Drained && !time.Now().Before(end)
+		if err := enc.Encode(progress); err != nil {
+			return
+		}
+		w.(http.Flusher).Flush()
+		if progress.Drained || progress.TimedOut {
+			return
+		}
+
+		wait := interval
+		if remaining := time.Until(end); remaining < wait {
+			wait = remaining
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(wait):
+		}
+	}
+}
+
+// ServiceDrainHandler - POST /minio/admin/v3/service/drain?timeout=1m
+// ----------
+// Rejects new S3 API calls on all nodes and streams the progress of
+// serving the calls in flight or waiting in the queue, until all nodes
+// are drained or the timeout expires. Health readiness probes fail
+// while draining, so load balancers stop sending requests.
+func (a adminAPIHandlers) ServiceDrainHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "ServiceDrain")
+
+	defer logger.AuditLog(ctx, w, r, mustGetClaimsFromToken(r))
+
+	objectAPI, _ := validateAdminReq(ctx, w, r, iampolicy.ServiceFreezeAdminAction)
+	if objectAPI == nil {
+		return
+	}
+
+	timeout := defaultDrainTimeout
+	if v := r.Form.Get("timeout"); v != "" {
+		var err error
+		timeout, err = time.ParseDuration(v)
+		if err != nil || timeout <= 0 {
+			writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErr(ErrInvalidRequest), r.URL)
+			return
+		}
+	}
+
+	for _, nerr := range globalNotificationSys.SignalService(serviceDrain) {
+		if nerr.Err != nil {
+			logger.GetReqInfo(ctx).SetTags("peerAddress", nerr.Host.String())
+			logger.LogIf(ctx, nerr.Err)
+		}
+	}
+	drainServices()
+
+	streamDrainProgress(ctx, w, timeout, drainProgressInterval, globalNotificationSys.GetLiveStats)
+}
+
+// ServiceUndrainHandler - POST /minio/admin/v3/service/undrain
+// ----------
+// Accepts S3 API calls again on all nodes.
+func (a adminAPIHandlers) ServiceUndrainHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "ServiceUndrain")
+
+	defer logger.AuditLog(ctx, w, r, mustGetClaimsFromToken(r))
+
+	objectAPI, _ := validateAdminReq(ctx, w, r, iampolicy.ServiceFreezeAdminAction)
+	if objectAPI == nil {
+		return
+	}
+
+	for _, nerr := range globalNotificationSys.SignalService(serviceUnDrain) {
+		if nerr.Err != nil {
+			logger.GetReqInfo(ctx).SetTags("peerAddress", nerr.Host.String())
+			logger.LogIf(ctx, nerr.Err)
+		}
+	}
+	undrainServices()
+
+	writeSuccessResponseHeadersOnly(w)
+}
@@ -0,0 +1,98 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestStreamDrainProgress(t *testing.T) {
+	busy := func(inflight int) ServerLiveStats {
+		s := ServerLiveStats{Node: "node1"}
+		s.HTTPStats.CurrentS3Requests.APIStats = map[string]int{"getobject": inflight}
+		return s
+	}
+
+	// Requests in flight finish after two snapshots.
+	calls := 0
+	rec := httptest.NewRecorder()
+	streamDrainProgress(context.Background(), rec, time.Minute, time.Millisecond, func(ctx context.Context) []ServerLiveStats {
+		calls++
+		if calls > 2 {
+			return []ServerLiveStats{busy(0)}
+		}
+		return []ServerLiveStats{busy(2)}
+	})
+	dec := json.NewDecoder(rec.Body)
+	var progress []DrainProgress
+	for dec.More() {
+		var p DrainProgress
+		if err := dec.Decode(&p); err != nil {
+			t.Fatal(err)
+		}
+		progress = append(progress, p)
+	}
+	if len(progress) != 3 {
+		t.Fatalf("expected 3 progress reports, got %d", len(progress))
+	}
+	if progress[0].Drained || progress[0].Servers[0].InFlight != 2 {
+		t.Errorf("expected 2 requests in flight, got %+v", progress[0])
+	}
+	if last := progress[2]; !last.Drained || last.TimedOut {
+		t.Errorf("expected the cluster to be drained, got %+v", last)
+	}
+
+	// Unreachable nodes never drain.
+	rec = httptest.NewRecorder()
+	streamDrainProgress(context.Background(), rec, 20*time.Millisecond, 5*time.Millisecond, func(ctx context.Context) []ServerLiveStats {
+		return []ServerLiveStats{busy(0), {Node: "node2", Error: "offline"}}
+	})
+	var last DrainProgress
+	for dec = json.NewDecoder(rec.Body); dec.More(); {
+		if err := dec.Decode(&last); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if last.Drained || !last.TimedOut {
+		t.Errorf("expected the drain to time out, got %+v", last)
+	}
+}
+
+func TestServiceDraining(t *testing.T) {
+	drainServices()
+	defer undrainServices()
+
+	rec := httptest.NewRecorder()
+	maxClients(func(w http.ResponseWriter, r *http.Request) {
+		t.Error("expected the request to be rejected")
+	})(rec, httptest.NewRequest(http.MethodGet, "/bucket/object", nil))
+	if rec.Code != http.StatusServiceUnavailable || rec.Header().Get("Retry-After") != "1" {
+		t.Errorf("expected 503 with Retry-After 1, got %d %q", rec.Code, rec.Header().Get("Retry-After"))
+	}
+
+	rec = httptest.NewRecorder()
+	ReadinessCheckHandler(rec, httptest.NewRequest(http.MethodGet, "/minio/health/ready", nil))
+	if rec.Code != http.StatusServiceUnavailable {
+		t.Errorf("expected readiness to fail while draining, got %d", rec.Code)
+	}
+}
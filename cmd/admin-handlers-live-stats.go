@@ -0,0 +1,129 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/minio/minio/internal/logger"
+	iampolicy "github.com/minio/pkg/iam/policy"
+	gopsutilcpu "github.com/shirou/gopsutil/v3/cpu"
+	"github.com/shirou/gopsutil/v3/mem"
+)
+
+const (
+	defaultLiveStatsInterval = 5 * time.Second
+	minLiveStatsInterval     = time.Second
+)
+
+// Requests are authenticated by their signature, not by cookies,
+// so a cross origin page cannot open a stream on behalf of a user.
+var liveStatsUpgrader = websocket.Upgrader{
+	CheckOrigin: func(r *http.Request) bool { return true },
+}
+
+// getLocalLiveStats returns a snapshot of the stats of this node.
+func getLocalLiveStats() ServerLiveStats {
+	httpStats := globalHTTPStats.loadServerHTTPStats()
+	// Per bucket stats are left out to keep snapshots small.
+	httpStats.BucketStats = nil
+
+	stats := ServerLiveStats{
+		Node:      globalLocalNodeName,
+		Time:      UTCNow(),
+		HTTPStats: httpStats,
+		ConnStats: globalConnStats.toServerConnStats(),
+	}
+	if percent, err := gopsutilcpu.Percent(0, false); err == nil && len(percent) > 0 {
+		stats.CPUPercent = percent[0]
+	}
+	if vm, err := mem.VirtualMemory(); err == nil {
+		stats.MemTotal = vm.Total
+		stats.MemUsed = vm.Used
+	}
+	return stats
+}
+
+// LiveStatsHandler - GET /minio/admin/v3/live-stats?interval=5s
+// ----------
+// Upgrades the connection to a WebSocket on which a snapshot of the
+// stats of all nodes is sent as a JSON array every interval, until
+// the client closes the connection.
+func (a adminAPIHandlers) LiveStatsHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "LiveStats")
+
+	defer logger.AuditLog(ctx, w, r, mustGetClaimsFromToken(r))
+
+	objectAPI, _ := validateAdminReq(ctx, w, r, iampolicy.PrometheusAdminAction)
+	if objectAPI == nil {
+		return
+	}
+
+	interval, err := time.ParseDuration(r.Form.Get("interval"))
+	if err != nil {
+		interval = defaultLiveStatsInterval
+	}
+	if interval < minLiveStatsInterval {
+		interval = minLiveStatsInterval
+	}
+
+	// Upgrade writes an error response itself on failure.
+	conn, err := liveStatsUpgrader.Upgrade(w, r, nil)
+	if err != nil {
+		return
+	}
+	defer conn.Close()
+
+	streamLiveStats(ctx, conn, interval, globalNotificationSys.GetLiveStats)
+}
+
+// streamLiveStats sends the snapshots returned by collect on conn
+// every interval, until the connection is closed or ctx is done.
+func streamLiveStats(ctx context.Context, conn *websocket.Conn, interval time.Duration, collect func(context.Context) []ServerLiveStats) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	// Nothing is expected from the client, reading is
+	// required to process control frames and notice the
+	// connection being closed.
+	go func() {
+		defer cancel()
+		for {
+			if _, _, err := conn.NextReader(); err != nil {
+				return
+			}
+		}
+	}()
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		conn.SetWriteDeadline(time.Now().Add(interval))
+		if err := conn.WriteJSON(collect(ctx)); err != nil {
+			return
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,72 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/minio/minio/internal/http/stats"
+)
+
+func TestStreamLiveStats(t *testing.T) {
+	done := make(chan struct{})
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		defer close(done)
+		// Upgrading must work through the traffic meter.
+		w = &stats.OutgoingTrafficMeter{ResponseWriter: w}
+		conn, err := liveStatsUpgrader.Upgrade(w, r, nil)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		defer conn.Close()
+		streamLiveStats(context.Background(), conn, 10*time.Millisecond, func(ctx context.Context) []ServerLiveStats {
+			return []ServerLiveStats{{Node: "node1", CPUPercent: 42}}
+		})
+	}
+	server := httptest.NewServer(http.HandlerFunc(handler))
+	defer server.Close()
+
+	conn, _, err := websocket.DefaultDialer.Dial("ws"+strings.TrimPrefix(server.URL, "http"), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < 2; i++ {
+		var snapshot []ServerLiveStats
+		if err = conn.ReadJSON(&snapshot); err != nil {
+			t.Fatal(err)
+		}
+		if len(snapshot) != 1 || snapshot[0].Node != "node1" || snapshot[0].CPUPercent != 42 {
+			t.Fatalf("unexpected snapshot %+v", snapshot)
+		}
+	}
+
+	// Closing the connection stops the stream.
+	conn.Close()
+	select {
+	case <-done:
+	case <-time.After(10 * time.Second):
+		t.Fatal("expected stream to stop after the client closed the connection")
+	}
+}
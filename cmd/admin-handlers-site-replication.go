@@ -24,6 +24,7 @@ import (
 	"io"
 	"io/ioutil"
 	"net/http"
+	"time"
 
 	"github.com/gorilla/mux"
 	"github.com/minio/madmin-go"
@@ -318,6 +319,13 @@ func parseJSONBody(ctx context.Context, body io.Reader, v interface{}, encryptio
 	return json.Unmarshal(data, v)
 }
 
+// srStatusInfoResp extends madmin.SRStatusInfo with the replication
+// statistics of the buckets to every peer site, by deployment ID.
+type srStatusInfoResp struct {
+	madmin.SRStatusInfo
+	TargetStats map[string]ReplicationTargetStats `json:"targetStats,omitempty"`
+}
+
 // SiteReplicationStatus - GET /minio/admin/v3/site-replication/status
 func (a adminAPIHandlers) SiteReplicationStatus(w http.ResponseWriter, r *http.Request) {
 	ctx := newContext(r, w, "SiteReplicationStatus")
@@ -343,12 +351,123 @@ func (a adminAPIHandlers) SiteReplicationStatus(w http.ResponseWriter, r *http.R
 		return
 	}
 
+	resp := srStatusInfoResp{SRStatusInfo: info}
+	if opts.Buckets && info.Enabled {
+		resp.TargetStats = globalSiteReplicationSys.getTargetStats(ctx, objectAPI, info.Sites)
+	}
+
+	if err = json.NewEncoder(w).Encode(resp); err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+}
+
+// SiteReplicationHealth - GET /minio/admin/v3/site-replication/health
+// ----------
+// Returns the link health, clock skew, pending replication and the time
+// of the last replication of every site, as seen from this site.
+func (a adminAPIHandlers) SiteReplicationHealth(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "SiteReplicationHealth")
+
+	defer logger.AuditLog(ctx, w, r, mustGetClaimsFromToken(r))
+
+	objectAPI, _ := validateAdminReq(ctx, w, r, iampolicy.SiteReplicationInfoAction)
+	if objectAPI == nil {
+		return
+	}
+
+	info, err := globalSiteReplicationSys.SiteReplicationHealth(ctx, objectAPI)
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+
 	if err = json.NewEncoder(w).Encode(info); err != nil {
 		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
 		return
 	}
 }
 
+// SRPeerHealth - GET /minio/admin/v3/site-replication/peer/health
+// ----------
+// Returns the current time and the replication statistics of this site
+// to the site serving a SiteReplicationHealth request.
+func (a adminAPIHandlers) SRPeerHealth(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "SRPeerHealth")
+
+	defer logger.AuditLog(ctx, w, r, mustGetClaimsFromToken(r))
+
+	objectAPI, _ := validateAdminReq(ctx, w, r, iampolicy.SiteReplicationInfoAction)
+	if objectAPI == nil {
+		return
+	}
+
+	health := globalSiteReplicationSys.PeerHealth(ctx, objectAPI)
+	if err := json.NewEncoder(w).Encode(health); err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+}
+
+// SiteReplicationNetperf - POST /minio/admin/v3/site-replication/netperf?duration={duration}
+// ----------
+// Measures the latency and the throughput from this site to every peer
+// site over the site replication transport, one site after the other.
+func (a adminAPIHandlers) SiteReplicationNetperf(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "SiteReplicationNetperf")
+
+	defer logger.AuditLog(ctx, w, r, mustGetClaimsFromToken(r))
+
+	objectAPI, _ := validateAdminReq(ctx, w, r, iampolicy.HealthInfoAdminAction)
+	if objectAPI == nil {
+		return
+	}
+
+	nsLock := objectAPI.NewNSLock(minioMetaBucket, "site-replication-netperf")
+	lkctx, err := nsLock.GetLock(ctx, globalOperationTimeout)
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErr(toAPIErrorCode(ctx, err)), r.URL)
+		return
+	}
+	defer nsLock.Unlock(lkctx.Cancel)
+
+	duration, err := time.ParseDuration(r.Form.Get("duration"))
+	if err != nil || duration < globalNetPerfMinDuration {
+		duration = globalNetPerfMinDuration
+	}
+
+	result, err := globalSiteReplicationSys.Netperf(ctx, duration.Round(time.Second))
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+
+	if err = json.NewEncoder(w).Encode(result); err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+}
+
+// SRPeerNetperf - PUT /minio/admin/v3/site-replication/peer/netperf
+// ----------
+// Discards the data sent by a site serving a SiteReplicationNetperf
+// request.
+func (a adminAPIHandlers) SRPeerNetperf(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "SRPeerNetperf")
+
+	defer logger.AuditLog(ctx, w, r, mustGetClaimsFromToken(r))
+
+	objectAPI, _ := validateAdminReq(ctx, w, r, iampolicy.SiteReplicationOperationAction)
+	if objectAPI == nil {
+		return
+	}
+
+	if _, err := io.Copy(ioutil.Discard, r.Body); err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+}
+
 // SiteReplicationMetaInfo - GET /minio/admin/v3/site-replication/metainfo
 func (a adminAPIHandlers) SiteReplicationMetaInfo(w http.ResponseWriter, r *http.Request) {
 	ctx := newContext(r, w, "SiteReplicationMetaInfo")
@@ -25,6 +25,7 @@ import (
 	"io/ioutil"
 	"net/http"
 	"sort"
+	"time"
 
 	"github.com/gorilla/mux"
 	"github.com/minio/madmin-go"
@@ -205,7 +206,73 @@ func (a adminAPIHandlers) GetUserInfo(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	data, err := json.Marshal(userInfo)
+	infoResp := userInfoResp{UserInfo: userInfo}
+	if usage, ok := getAccessKeyUsage(ctx, objectAPI, name); ok {
+		infoResp.LastUsed = &usage
+	}
+
+	data, err := json.Marshal(infoResp)
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+
+	writeSuccessResponseJSON(w, data)
+}
+
+// GetUserStats - GET /minio/admin/v3/user-stats?accessKey=<access_key>
+// ----------
+// Get S3 requests, errors and bytes transferred per access key across
+// all nodes. With an access key only the stats of the access key and
+// its service accounts are returned.
+func (a adminAPIHandlers) GetUserStats(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "GetUserStats")
+
+	defer logger.AuditLog(ctx, w, r, mustGetClaimsFromToken(r))
+
+	name := r.Form.Get("accessKey")
+
+	// Get current object layer instance.
+	objectAPI := newObjectLayerFn()
+	if objectAPI == nil || globalNotificationSys == nil {
+		writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErr(ErrServerNotInitialized), r.URL)
+		return
+	}
+
+	cred, claims, owner, s3Err := validateAdminSignature(ctx, r, "")
+	if s3Err != ErrNone {
+		writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErr(s3Err), r.URL)
+		return
+	}
+
+	var action iampolicy.Action = iampolicy.ListUsersAdminAction
+	if name != "" {
+		action = iampolicy.GetUserAdminAction
+	}
+	if !globalIAMSys.IsAllowed(iampolicy.Args{
+		AccountName:     cred.AccessKey,
+		Groups:          cred.Groups,
+		Action:          action,
+		ConditionValues: getConditionValues(r, "", cred.AccessKey, claims),
+		IsOwner:         owner,
+		Claims:          claims,
+		// Users are allowed to view their own stats unless explicitly denied.
+		DenyOnly: name != "" && name == cred.AccessKey,
+	}) {
+		writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErr(ErrAccessDenied), r.URL)
+		return
+	}
+
+	stats := globalNotificationSys.GetAccessKeyStats(ctx)
+	if name != "" {
+		for accessKey, s := range stats {
+			if accessKey != name && s.ParentUser != name {
+				delete(stats, accessKey)
+			}
+		}
+	}
+
+	data, err := json.Marshal(stats)
 	if err != nil {
 		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
 		return
@@ -408,6 +475,115 @@ func (a adminAPIHandlers) SetUserStatus(w http.ResponseWriter, r *http.Request)
 	}
 }
 
+// SetAccessKeyLimits - PUT /minio/admin/v3/set-access-key-limits?accessKey=<access_key>
+// ----------
+// Limits the requests per second and the bandwidth of the S3 traffic
+// of a user or a service account, empty limits remove the throttling.
+func (a adminAPIHandlers) SetAccessKeyLimits(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "SetAccessKeyLimits")
+
+	defer logger.AuditLog(ctx, w, r, mustGetClaimsFromToken(r))
+
+	objectAPI, _ := validateAdminReq(ctx, w, r, iampolicy.CreateUserAdminAction)
+	if objectAPI == nil {
+		return
+	}
+
+	accessKey := mux.Vars(r)["accessKey"]
+
+	// The root user is never throttled.
+	if accessKey == globalActiveCred.AccessKey {
+		writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErr(ErrInvalidRequest), r.URL)
+		return
+	}
+
+	var limits AccessKeyLimits
+	if err := json.NewDecoder(io.LimitReader(r.Body, 1<<20)).Decode(&limits); err != nil {
+		writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErrWithErr(ErrInvalidRequest, err), r.URL)
+		return
+	}
+
+	if err := globalIAMSys.SetAccessKeyLimits(ctx, accessKey, limits); err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+
+	// Write success response.
+	writeSuccessResponseHeadersOnly(w)
+}
+
+// GetAccessKeyLimits - GET /minio/admin/v3/access-key-limits?accessKey=<access_key>
+func (a adminAPIHandlers) GetAccessKeyLimits(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "GetAccessKeyLimits")
+
+	defer logger.AuditLog(ctx, w, r, mustGetClaimsFromToken(r))
+
+	objectAPI, _ := validateAdminReq(ctx, w, r, iampolicy.GetUserAdminAction)
+	if objectAPI == nil {
+		return
+	}
+
+	limits, err := globalIAMSys.GetAccessKeyLimits(mux.Vars(r)["accessKey"])
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+
+	data, err := json.Marshal(limits)
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+
+	writeSuccessResponseJSON(w, data)
+}
+
+// AccessKeyUsageReport - GET /minio/admin/v3/access-key-usage?dormant=<duration>
+// ----------
+// Reports when the users and the service accounts were last used and
+// from where. With dormant, only the access keys not used for at least
+// that duration are reported.
+func (a adminAPIHandlers) AccessKeyUsageReport(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "AccessKeyUsageReport")
+
+	defer logger.AuditLog(ctx, w, r, mustGetClaimsFromToken(r))
+
+	objectAPI, _ := validateAdminReq(ctx, w, r, iampolicy.ListUsersAdminAction)
+	if objectAPI == nil {
+		return
+	}
+
+	var dormantSince time.Time
+	if v := r.Form.Get("dormant"); v != "" {
+		dormant, err := time.ParseDuration(v)
+		if err != nil || dormant <= 0 {
+			writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErr(ErrInvalidRequest), r.URL)
+			return
+		}
+		dormantSince = UTCNow().Add(-dormant)
+	}
+
+	creds, err := globalIAMSys.ListAccessKeys(ctx)
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+
+	usage, err := loadAccessKeyUsage(ctx, objectAPI)
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+
+	data, err := json.Marshal(accessKeyUsageReport(creds, usage, dormantSince))
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+
+	writeSuccessResponseJSON(w, data)
+}
+
 // AddUser - PUT /minio/admin/v3/add-user?accessKey=<access_key>
 func (a adminAPIHandlers) AddUser(w http.ResponseWriter, r *http.Request) {
 	ctx := newContext(r, w, "AddUser")
@@ -516,6 +692,42 @@ func (a adminAPIHandlers) AddUser(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+// addServiceAccountReq extends madmin.AddServiceAccountReq with
+// an optional expiry, after which the service account is rejected.
+type addServiceAccountReq struct {
+	madmin.AddServiceAccountReq
+	Expiration *time.Time `json:"expiration,omitempty"`
+}
+
+// updateServiceAccountReq extends madmin.UpdateServiceAccountReq
+// with the expiry of the service account, a zero time removes it.
+type updateServiceAccountReq struct {
+	madmin.UpdateServiceAccountReq
+	NewExpiration *time.Time `json:"newExpiration,omitempty"`
+}
+
+// userInfoResp extends madmin.UserInfo with the
+// last use of the access key, if it was ever used.
+type userInfoResp struct {
+	madmin.UserInfo
+	LastUsed *AccessKeyUsage `json:"lastUsed,omitempty"`
+}
+
+// infoServiceAccountResp extends madmin.InfoServiceAccountResp
+// with the expiry and the last use of the service account, if any.
+type infoServiceAccountResp struct {
+	madmin.InfoServiceAccountResp
+	Expiration *time.Time      `json:"expiration,omitempty"`
+	LastUsed   *AccessKeyUsage `json:"lastUsed,omitempty"`
+}
+
+// listServiceAccountsResp extends madmin.ListServiceAccountsResp
+// with the expiry of the listed service accounts that have one.
+type listServiceAccountsResp struct {
+	madmin.ListServiceAccountsResp
+	Expirations map[string]time.Time `json:"expirations,omitempty"`
+}
+
 // AddServiceAccount - PUT /minio/admin/v3/add-service-account
 func (a adminAPIHandlers) AddServiceAccount(w http.ResponseWriter, r *http.Request) {
 	ctx := newContext(r, w, "AddServiceAccount")
@@ -542,7 +754,7 @@ func (a adminAPIHandlers) AddServiceAccount(w http.ResponseWriter, r *http.Reque
 		return
 	}
 
-	var createReq madmin.AddServiceAccountReq
+	var createReq addServiceAccountReq
 	if err = json.Unmarshal(reqBytes, &createReq); err != nil {
 		writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErrWithErr(ErrAdminConfigBadJSON, err), r.URL)
 		return
@@ -567,9 +779,10 @@ func (a adminAPIHandlers) AddServiceAccount(w http.ResponseWriter, r *http.Reque
 	}
 
 	opts := newServiceAccountOpts{
-		accessKey: createReq.AccessKey,
-		secretKey: createReq.SecretKey,
-		claims:    make(map[string]interface{}),
+		accessKey:  createReq.AccessKey,
+		secretKey:  createReq.SecretKey,
+		expiration: createReq.Expiration,
+		claims:     make(map[string]interface{}),
 	}
 
 	// Find the user for the request sender (as it may be sent via a service
@@ -623,7 +836,7 @@ func (a adminAPIHandlers) AddServiceAccount(w http.ResponseWriter, r *http.Reque
 		// In case of LDAP/OIDC we need to set `opts.claims` to ensure
 		// it is associated with the LDAP/OIDC user properly.
 		for k, v := range cred.Claims {
-			if k == expClaim {
+			if k == expClaim || k == svcExpiryClaim {
 				continue
 			}
 			opts.claims[k] = v
@@ -701,6 +914,9 @@ func (a adminAPIHandlers) AddServiceAccount(w http.ResponseWriter, r *http.Reque
 	// Call hook for cluster-replication if the service account is not for a
 	// root user.
 	if newCred.ParentUser != globalActiveCred.AccessKey {
+		if createReq.Expiration != nil && !createReq.Expiration.IsZero() {
+			opts.claims[svcExpiryClaim] = createReq.Expiration.Unix()
+		}
 		err = globalSiteReplicationSys.IAMChangeHook(ctx, madmin.SRIAMItem{
 			Type: madmin.SRIAMItemSvcAcc,
 			SvcAccChange: &madmin.SRSvcAccChange{
@@ -778,7 +994,7 @@ func (a adminAPIHandlers) UpdateServiceAccount(w http.ResponseWriter, r *http.Re
 		return
 	}
 
-	var updateReq madmin.UpdateServiceAccountReq
+	var updateReq updateServiceAccountReq
 	if err = json.Unmarshal(reqBytes, &updateReq); err != nil {
 		writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErrWithErr(ErrAdminConfigBadJSON, err), r.URL)
 		return
@@ -796,6 +1012,7 @@ func (a adminAPIHandlers) UpdateServiceAccount(w http.ResponseWriter, r *http.Re
 		secretKey:     updateReq.NewSecretKey,
 		status:        updateReq.NewStatus,
 		sessionPolicy: sp,
+		expiration:    updateReq.NewExpiration,
 	}
 	err = globalIAMSys.UpdateServiceAccount(ctx, accessKey, opts)
 	if err != nil {
@@ -825,6 +1042,118 @@ func (a adminAPIHandlers) UpdateServiceAccount(w http.ResponseWriter, r *http.Re
 	writeSuccessNoContent(w)
 }
 
+// RotateServiceAccount - POST /minio/admin/v3/rotate-service-account
+//
+// Replaces the secret key of a service account with a newly generated
+// one, keeping its access key, policy and expiry. The new credentials
+// are returned encrypted as for AddServiceAccount.
+func (a adminAPIHandlers) RotateServiceAccount(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "RotateServiceAccount")
+
+	defer logger.AuditLog(ctx, w, r, mustGetClaimsFromToken(r))
+
+	// Get current object layer instance.
+	objectAPI := newObjectLayerFn()
+	if objectAPI == nil || globalNotificationSys == nil {
+		writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErr(ErrServerNotInitialized), r.URL)
+		return
+	}
+
+	cred, claims, owner, s3Err := validateAdminSignature(ctx, r, "")
+	if s3Err != ErrNone {
+		writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErr(s3Err), r.URL)
+		return
+	}
+
+	accessKey := mux.Vars(r)["accessKey"]
+	if accessKey == "" {
+		writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErr(ErrInvalidRequest), r.URL)
+		return
+	}
+
+	svcAccount, sp, err := globalIAMSys.GetServiceAccount(ctx, accessKey)
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+
+	if !globalIAMSys.IsAllowed(iampolicy.Args{
+		AccountName:     cred.AccessKey,
+		Action:          iampolicy.UpdateServiceAccountAdminAction,
+		ConditionValues: getConditionValues(r, "", cred.AccessKey, claims),
+		IsOwner:         owner,
+		Claims:          claims,
+	}) {
+		requestUser := cred.AccessKey
+		if cred.ParentUser != "" {
+			requestUser = cred.ParentUser
+		}
+
+		if requestUser != svcAccount.ParentUser {
+			writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErr(ErrAccessDenied), r.URL)
+			return
+		}
+	}
+
+	// The embedded policy is sent along with the new secret key
+	// to site replication peers, an update without it removes it.
+	var policyBuf []byte
+	if sp != nil {
+		policyBuf, err = json.Marshal(sp)
+		if err != nil {
+			writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+			return
+		}
+	}
+
+	newCred, err := globalIAMSys.RotateServiceAccount(ctx, accessKey)
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+
+	rotateResp := madmin.AddServiceAccountResp{
+		Credentials: madmin.Credentials{
+			AccessKey: newCred.AccessKey,
+			SecretKey: newCred.SecretKey,
+		},
+	}
+
+	data, err := json.Marshal(rotateResp)
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+
+	// Encrypt with the secret key the request was signed with, so
+	// that a service account rotating its own key can decrypt it.
+	encryptedData, err := madmin.EncryptData(cred.SecretKey, data)
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+
+	writeSuccessResponseJSON(w, encryptedData)
+
+	// Call site replication hook - non-root user accounts are replicated.
+	if svcAccount.ParentUser != globalActiveCred.AccessKey {
+		err = globalSiteReplicationSys.IAMChangeHook(ctx, madmin.SRIAMItem{
+			Type: madmin.SRIAMItemSvcAcc,
+			SvcAccChange: &madmin.SRSvcAccChange{
+				Update: &madmin.SRSvcAccUpdate{
+					AccessKey:     accessKey,
+					SecretKey:     newCred.SecretKey,
+					SessionPolicy: policyBuf,
+				},
+			},
+		})
+		if err != nil {
+			logger.LogIf(ctx, err)
+			return
+		}
+	}
+}
+
 // InfoServiceAccount - GET /minio/admin/v3/info-service-account
 func (a adminAPIHandlers) InfoServiceAccount(w http.ResponseWriter, r *http.Request) {
 	ctx := newContext(r, w, "InfoServiceAccount")
@@ -893,11 +1222,25 @@ func (a adminAPIHandlers) InfoServiceAccount(w http.ResponseWriter, r *http.Requ
 		return
 	}
 
-	infoResp := madmin.InfoServiceAccountResp{
-		ParentUser:    svcAccount.ParentUser,
-		AccountStatus: svcAccount.Status,
-		ImpliedPolicy: policy == nil,
-		Policy:        string(policyJSON),
+	expiration, err := globalIAMSys.GetServiceAccountExpiry(ctx, accessKey)
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+
+	infoResp := infoServiceAccountResp{
+		InfoServiceAccountResp: madmin.InfoServiceAccountResp{
+			ParentUser:    svcAccount.ParentUser,
+			AccountStatus: svcAccount.Status,
+			ImpliedPolicy: policy == nil,
+			Policy:        string(policyJSON),
+		},
+	}
+	if !expiration.IsZero() {
+		infoResp.Expiration = &expiration
+	}
+	if usage, ok := getAccessKeyUsage(ctx, objectAPI, accessKey); ok {
+		infoResp.LastUsed = &usage
 	}
 
 	data, err := json.Marshal(infoResp)
@@ -965,13 +1308,21 @@ func (a adminAPIHandlers) ListServiceAccounts(w http.ResponseWriter, r *http.Req
 	}
 
 	var serviceAccountsNames []string
+	expirations := make(map[string]time.Time)
 
 	for _, svc := range serviceAccounts {
 		serviceAccountsNames = append(serviceAccountsNames, svc.AccessKey)
+		expiration, err := globalIAMSys.GetServiceAccountExpiry(ctx, svc.AccessKey)
+		if err == nil && !expiration.IsZero() {
+			expirations[svc.AccessKey] = expiration
+		}
 	}
 
-	listResp := madmin.ListServiceAccountsResp{
-		Accounts: serviceAccountsNames,
+	listResp := listServiceAccountsResp{
+		ListServiceAccountsResp: madmin.ListServiceAccountsResp{
+			Accounts: serviceAccountsNames,
+		},
+		Expirations: expirations,
 	}
 
 	data, err := json.Marshal(listResp)
@@ -1227,7 +1578,7 @@ func (a adminAPIHandlers) AccountInfoHandler(w http.ResponseWriter, r *http.Requ
 					VersioningSuspended: globalBucketVersioningSys.Suspended(bucket.Name),
 					Replication:         rcfg != nil,
 					Locking:             lcfg.LockEnabled,
-					Quota:               quota,
+					Quota:               quota.adminQuota(),
 					Tagging:             tcfg,
 				},
 				Access: madmin.AccountAccess{
@@ -1512,3 +1863,109 @@ func (a adminAPIHandlers) SetPolicyForUserOrGroup(w http.ResponseWriter, r *http
 		return
 	}
 }
+
+// readIAMExportReq decrypts and parses the request body of the
+// export and import IAM admin APIs.
+func readIAMExportReq(r *http.Request, password string) (req iamExportReq, apiErr APIError) {
+	reqBytes, err := madmin.DecryptData(password, io.LimitReader(r.Body, r.ContentLength))
+	if err != nil {
+		return req, errorCodes.ToAPIErrWithErr(ErrAdminConfigBadJSON, err)
+	}
+	if err = json.Unmarshal(reqBytes, &req); err != nil {
+		return req, errorCodes.ToAPIErrWithErr(ErrAdminConfigBadJSON, err)
+	}
+	return req, noError
+}
+
+// ExportIAMHandler - POST /minio/admin/v3/export-iam
+//
+// Returns the users, groups, policies, service accounts and policy
+// mappings of the cluster as a bundle signed with the signing key
+// of the request, encrypted with the secret key of the requester.
+func (a adminAPIHandlers) ExportIAMHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "ExportIAM")
+
+	defer logger.AuditLog(ctx, w, r, mustGetClaimsFromToken(r))
+
+	objectAPI, cred := validateAdminReq(ctx, w, r, iampolicy.AllAdminActions)
+	if objectAPI == nil {
+		return
+	}
+
+	exportReq, apiErr := readIAMExportReq(r, cred.SecretKey)
+	if apiErr != noError {
+		writeErrorResponseJSON(ctx, w, apiErr, r.URL)
+		return
+	}
+
+	content, err := globalIAMSys.ExportIAM(ctx)
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+
+	bundle, err := newIAMExportBundle(content, exportReq.SigningKey)
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+
+	data, err := json.Marshal(bundle)
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+
+	encryptedData, err := madmin.EncryptData(cred.SecretKey, data)
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+
+	writeSuccessResponseJSON(w, encryptedData)
+}
+
+// ImportIAMHandler - PUT /minio/admin/v3/import-iam
+//
+// Creates or updates the IAM entities of a bundle returned by
+// ExportIAMHandler, after verifying its signature.
+func (a adminAPIHandlers) ImportIAMHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "ImportIAM")
+
+	defer logger.AuditLog(ctx, w, r, mustGetClaimsFromToken(r))
+
+	objectAPI, cred := validateAdminReq(ctx, w, r, iampolicy.AllAdminActions)
+	if objectAPI == nil {
+		return
+	}
+
+	importReq, apiErr := readIAMExportReq(r, cred.SecretKey)
+	if apiErr != noError {
+		writeErrorResponseJSON(ctx, w, apiErr, r.URL)
+		return
+	}
+	if importReq.Bundle == nil {
+		writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErr(ErrAdminConfigBadJSON), r.URL)
+		return
+	}
+
+	content, err := importReq.Bundle.verify(importReq.SigningKey)
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+
+	res, err := globalIAMSys.ImportIAM(ctx, content)
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+
+	data, err := json.Marshal(res)
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+
+	writeSuccessResponseJSON(w, data)
+}
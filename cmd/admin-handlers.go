@@ -275,6 +275,144 @@ type ServerConnStats struct {
 	Throughput       uint64 `json:"throughput,omitempty"`
 	S3InputBytes     uint64 `json:"transferredS3"`
 	S3OutputBytes    uint64 `json:"receivedS3"`
+	// Wraparounds is the number of times the byte counters
+	// above overflowed, each wraparound resets a counter to zero.
+	Wraparounds uint64 `json:"wraparounds,omitempty"`
+	// OpenConnections is the number of currently open client connections.
+	OpenConnections int64 `json:"openConnections"`
+	// Recent S3 traffic in bytes per second
+	S3InputRate  ServerRates `json:"transferredS3Rate"`
+	S3OutputRate ServerRates `json:"receivedS3Rate"`
+}
+
+// ServerRates holds per second rates averaged over
+// the last 1, 5 and 15 minutes.
+type ServerRates struct {
+	Avg1m  float64 `json:"avg1m"`
+	Avg5m  float64 `json:"avg5m"`
+	Avg15m float64 `json:"avg15m"`
+}
+
+// ServerBucketConnStats holds S3 bytes transferred from/to a bucket
+type ServerBucketConnStats struct {
+	S3InputBytes  uint64 `json:"transferredS3"`
+	S3OutputBytes uint64 `json:"receivedS3"`
+}
+
+// ServerBucketHTTPStats holds S3 requests and errors of a bucket,
+// the bytes transferred are in ServerBucketConnStats.
+type ServerBucketHTTPStats struct {
+	TotalS3Requests  uint64 `json:"totalS3Requests"`
+	TotalS34xxErrors uint64 `json:"totalS34xxErrors"`
+	TotalS35xxErrors uint64 `json:"totalS35xxErrors"`
+	TotalS3Canceled  uint64 `json:"totalS3Canceled"`
+}
+
+// ServerAccessKeyStats holds S3 requests, errors and bytes
+// transferred from/to the server by an access key.
+type ServerAccessKeyStats struct {
+	ParentUser      string `json:"parentUser,omitempty"`
+	TotalS3Requests uint64 `json:"totalS3Requests"`
+	TotalS3Errors   uint64 `json:"totalS3Errors"`
+	S3InputBytes    uint64 `json:"transferredS3"`
+	S3OutputBytes   uint64 `json:"receivedS3"`
+}
+
+// ServerTopClient holds S3 requests and bytes transferred
+// from/to the server by a client IP during the last minutes.
+type ServerTopClient struct {
+	Client   string `json:"client"`
+	Requests uint64 `json:"requests"`
+	Bytes    uint64 `json:"bytes"`
+}
+
+// ServerHotObject holds the estimated number of reads of an object or prefix.
+type ServerHotObject struct {
+	Name     string `json:"name"`
+	Requests uint64 `json:"requests"`
+}
+
+// ServerHotObjects holds the most frequently read objects and prefixes.
+type ServerHotObjects struct {
+	Objects  []ServerHotObject `json:"objects"`
+	Prefixes []ServerHotObject `json:"prefixes"`
+}
+
+// ServerAnonymousRequests holds the anonymous requests
+// to a bucket or prefix, denied requests are in neither
+// Reads nor Writes.
+type ServerAnonymousRequests struct {
+	Reads      uint64    `json:"reads"`
+	Writes     uint64    `json:"writes"`
+	Denied     uint64    `json:"denied"`
+	LastAccess time.Time `json:"lastAccess"`
+}
+
+// ServerAnonymousBucketRequests holds the anonymous requests
+// to a bucket and to the prefixes of its objects.
+type ServerAnonymousBucketRequests struct {
+	Requests ServerAnonymousRequests            `json:"requests"`
+	Prefixes map[string]ServerAnonymousRequests `json:"prefixes,omitempty"`
+}
+
+// ServerRejectedRequest holds a sample of a request
+// rejected because of its credentials or headers.
+type ServerRejectedRequest struct {
+	Time    time.Time         `json:"time"`
+	Node    string            `json:"node"`
+	Client  string            `json:"client"`
+	Reason  string            `json:"reason"`
+	Code    string            `json:"code"`
+	Method  string            `json:"method"`
+	Path    string            `json:"path"`
+	Headers map[string]string `json:"headers,omitempty"`
+}
+
+// ServerSlowRequest describes an S3 request served in
+// more than the configured slow request threshold.
+type ServerSlowRequest struct {
+	Time       time.Time     `json:"time"`
+	Node       string        `json:"node"`
+	API        string        `json:"api"`
+	Bucket     string        `json:"bucket,omitempty"`
+	Object     string        `json:"object,omitempty"`
+	Duration   time.Duration `json:"duration"`
+	TTFB       time.Duration `json:"ttfb"`
+	StatusCode int           `json:"statusCode"`
+	Client     string        `json:"client"`
+	AccessKey  string        `json:"accessKey,omitempty"`
+	RequestID  string        `json:"requestID,omitempty"`
+}
+
+// ServerNotifyTarget describes the delivery statistics
+// of a notification target on a server.
+type ServerNotifyTarget struct {
+	Node         string    `json:"node"`
+	TargetID     string    `json:"targetID"`
+	Online       bool      `json:"online"`
+	QueueLength  int       `json:"queueLength"`
+	EventsSent   uint64    `json:"eventsSent"`
+	EventsFailed uint64    `json:"eventsFailed"`
+	LastSuccess  time.Time `json:"lastSuccess,omitempty"`
+}
+
+// ServerLiveStats holds a snapshot of the HTTP and traffic
+// stats and the CPU and memory usage of a server.
+type ServerLiveStats struct {
+	Node       string          `json:"node"`
+	Time       time.Time       `json:"time"`
+	HTTPStats  ServerHTTPStats `json:"httpStats"`
+	ConnStats  ServerConnStats `json:"connStats"`
+	CPUPercent float64         `json:"cpuPercent"`
+	MemTotal   uint64          `json:"memTotal"`
+	MemUsed    uint64          `json:"memUsed"`
+	Error      string          `json:"error,omitempty"`
+}
+
+// ServerResetStats holds the outcome of resetting the stats of a server.
+type ServerResetStats struct {
+	Endpoint string `json:"endpoint"`
+	Error    string `json:"error,omitempty"`
 }
 
 // ServerHTTPAPIStats holds total number of HTTP operations from/to the server,
@@ -289,15 +427,48 @@ type ServerHTTPStats struct {
 	S3RequestsInQueue      int32              `json:"s3RequestsInQueue"`
 	S3RequestsIncoming     uint64             `json:"s3RequestsIncoming"`
 	CurrentS3Requests      ServerHTTPAPIStats `json:"currentS3Requests"`
+	PeakS3Requests         ServerHTTPAPIStats `json:"peakS3Requests"` // Highest in-flight requests since the last scrape
 	TotalS3Requests        ServerHTTPAPIStats `json:"totalS3Requests"`
 	TotalS3Errors          ServerHTTPAPIStats `json:"totalS3Errors"`
 	TotalS35xxErrors       ServerHTTPAPIStats `json:"totalS35xxErrors"`
 	TotalS34xxErrors       ServerHTTPAPIStats `json:"totalS34xxErrors"`
 	TotalS3Canceled        ServerHTTPAPIStats `json:"totalS3Canceled"`
+	TotalS33xx             ServerHTTPAPIStats `json:"totalS33xx"` // Redirects and 304 Not Modified responses
 	TotalS3RejectedAuth    uint64             `json:"totalS3RejectedAuth"`
 	TotalS3RejectedTime    uint64             `json:"totalS3RejectedTime"`
 	TotalS3RejectedHeader  uint64             `json:"totalS3RejectedHeader"`
 	TotalS3RejectedInvalid uint64             `json:"totalS3RejectedInvalid"`
+	TotalS3RejectedExpired uint64             `json:"totalS3RejectedExpired"`
+	TotalS3RejectedEarly   uint64             `json:"totalS3RejectedEarly"`
+	TotalS3RejectedLate    uint64             `json:"totalS3RejectedLate"`
+	// Mean size in bytes of objects successfully
+	// transferred by GetObject and PutObject.
+	AvgObjectSize map[string]uint64 `json:"avgObjectSize,omitempty"`
+	// Totals of requests by API direction, see apiDirection().
+	TotalS3ReadRequests     uint64 `json:"totalS3ReadRequests"`
+	TotalS3WriteRequests    uint64 `json:"totalS3WriteRequests"`
+	TotalS3MetadataRequests uint64 `json:"totalS3MetadataRequests"`
+	// Requests with valid credentials and anonymous requests
+	TotalS3AuthenticatedRequests ServerHTTPAPIStats `json:"totalS3AuthenticatedRequests"`
+	TotalS3AnonymousRequests     ServerHTTPAPIStats `json:"totalS3AnonymousRequests"`
+	// Successful object reads and writes by encryption type
+	TotalS3EncryptionRequests map[string]ServerHTTPAPIStats `json:"totalS3EncryptionRequests,omitempty"`
+	// Canceled requests by cause, and requests failed
+	// because a backend operation exceeded its deadline.
+	TotalS3CanceledClient   ServerHTTPAPIStats `json:"totalS3CanceledClient"`
+	TotalS3CanceledShutdown ServerHTTPAPIStats `json:"totalS3CanceledShutdown"`
+	TotalS3DeadlineExceeded ServerHTTPAPIStats `json:"totalS3DeadlineExceeded"`
+	// Requests rejected by the requests per second limits
+	TotalS3RateLimited ServerHTTPAPIStats `json:"totalS3RateLimited"`
+	// Requests rejected by the concurrent requests limits
+	TotalS3MaxedOut ServerHTTPAPIStats `json:"totalS3MaxedOut"`
+	// Responses per api and status code
+	TotalS3StatusCodes map[string]map[int]uint64 `json:"totalS3StatusCodes,omitempty"`
+	// Requests and errors per bucket
+	BucketStats map[string]ServerBucketHTTPStats `json:"bucketStats,omitempty"`
+	// Recent S3 requests and errors per second
+	RequestsRate ServerRates `json:"requestsRate"`
+	ErrorsRate   ServerRates `json:"errorsRate"`
 }
 
 // StorageInfoHandler - GET /minio/admin/v3/storageinfo
@@ -370,6 +541,54 @@ func (a adminAPIHandlers) DataUsageInfoHandler(w http.ResponseWriter, r *http.Re
 	writeSuccessResponseJSON(w, dataUsageInfoJSON)
 }
 
+// ScannerStatusHandler - GET /minio/admin/v3/scanner/status
+// ----------
+// Get the data scanner configuration in effect and the progress of the
+// current scanner cycle on all the nodes. The scanner is configured at
+// runtime with the scanner config sub-system.
+func (a adminAPIHandlers) ScannerStatusHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "ScannerStatus")
+
+	defer logger.AuditLog(ctx, w, r, mustGetClaimsFromToken(r))
+
+	objectAPI, _ := validateAdminReq(ctx, w, r, iampolicy.ServerInfoAdminAction)
+	if objectAPI == nil {
+		return
+	}
+
+	data, err := json.Marshal(getScannerStatus(ctx))
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+
+	writeSuccessResponseJSON(w, data)
+}
+
+// HealProgressHandler - GET /minio/admin/v3/heal/progress
+// ----------
+// Returns the healing progress of the drives being healed grouped by erasure
+// set, along with the heal parallelism and bandwidth in effect. Both are
+// adjusted at runtime with the heal config sub-system.
+func (a adminAPIHandlers) HealProgressHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "HealProgress")
+
+	defer logger.AuditLog(ctx, w, r, mustGetClaimsFromToken(r))
+
+	objectAPI, _ := validateAdminReq(ctx, w, r, iampolicy.HealAdminAction)
+	if objectAPI == nil {
+		return
+	}
+
+	data, err := json.Marshal(getHealProgress(ctx))
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+
+	writeSuccessResponseJSON(w, data)
+}
+
 func lriToLockEntry(l lockRequesterInfo, resource, server string) *madmin.LockEntry {
 	entry := &madmin.LockEntry{
 		Timestamp:  l.Timestamp,
@@ -461,6 +680,296 @@ func (a adminAPIHandlers) ForceUnlockHandler(w http.ResponseWriter, r *http.Requ
 	}
 }
 
+// TopClientsHandler - GET /minio/admin/v3/top/clients?count=10
+// ----------
+// Get the client IPs with the most S3 requests across all nodes
+// during the last 5 to 10 minutes.
+func (a adminAPIHandlers) TopClientsHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "TopClients")
+
+	defer logger.AuditLog(ctx, w, r, mustGetClaimsFromToken(r))
+
+	objectAPI, _ := validateAdminReq(ctx, w, r, iampolicy.TraceAdminAction)
+	if objectAPI == nil {
+		return
+	}
+
+	count := 10 // by default list only top 10 entries
+	if countStr := r.Form.Get("count"); countStr != "" {
+		var err error
+		count, err = strconv.Atoi(countStr)
+		if err != nil {
+			writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+			return
+		}
+	}
+
+	jsonBytes, err := json.Marshal(globalNotificationSys.GetTopClients(ctx, count))
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+
+	writeSuccessResponseJSON(w, jsonBytes)
+}
+
+// TopObjectsHandler - GET /minio/admin/v3/top/objects?count=10
+// ----------
+// Get the objects and prefixes read the most across all nodes
+// during the last hour, the counts are estimates.
+func (a adminAPIHandlers) TopObjectsHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "TopObjects")
+
+	defer logger.AuditLog(ctx, w, r, mustGetClaimsFromToken(r))
+
+	objectAPI, _ := validateAdminReq(ctx, w, r, iampolicy.TraceAdminAction)
+	if objectAPI == nil {
+		return
+	}
+
+	count := 10 // by default list only top 10 entries
+	if countStr := r.Form.Get("count"); countStr != "" {
+		var err error
+		count, err = strconv.Atoi(countStr)
+		if err != nil {
+			writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+			return
+		}
+	}
+
+	jsonBytes, err := json.Marshal(globalNotificationSys.GetHotObjects(ctx, count))
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+
+	writeSuccessResponseJSON(w, jsonBytes)
+}
+
+// AnonymousAccessHandler - GET /minio/admin/v3/anonymous-access?bucket=mybucket
+// ----------
+// Get the buckets and prefixes which can be read or written anonymously
+// according to the bucket policies, along with the anonymous requests
+// which hit them on all nodes since server start. All buckets are
+// reported if bucket is not specified.
+func (a adminAPIHandlers) AnonymousAccessHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "AnonymousAccess")
+
+	defer logger.AuditLog(ctx, w, r, mustGetClaimsFromToken(r))
+
+	objectAPI, _ := validateAdminReq(ctx, w, r, iampolicy.GetPolicyAdminAction)
+	if objectAPI == nil {
+		return
+	}
+
+	report, err := getAnonymousAccessReport(ctx, objectAPI, r.Form.Get("bucket"))
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+
+	jsonBytes, err := json.Marshal(report)
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+
+	writeSuccessResponseJSON(w, jsonBytes)
+}
+
+// RejectedRequestsHandler - GET /minio/admin/v3/rejected-requests?count=100
+// ----------
+// Get the most recent requests rejected because of their credentials
+// or headers across all nodes, the most recent first.
+func (a adminAPIHandlers) RejectedRequestsHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "RejectedRequests")
+
+	defer logger.AuditLog(ctx, w, r, mustGetClaimsFromToken(r))
+
+	objectAPI, _ := validateAdminReq(ctx, w, r, iampolicy.TraceAdminAction)
+	if objectAPI == nil {
+		return
+	}
+
+	count := 100 // by default list only the 100 most recent entries
+	if countStr := r.Form.Get("count"); countStr != "" {
+		var err error
+		count, err = strconv.Atoi(countStr)
+		if err != nil {
+			writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+			return
+		}
+	}
+
+	jsonBytes, err := json.Marshal(globalNotificationSys.GetRejectedRequests(ctx, count))
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+
+	writeSuccessResponseJSON(w, jsonBytes)
+}
+
+// SlowRequestsHandler - GET /minio/admin/v3/slow-requests?count=100
+// ----------
+// Get the most recent requests served in more than the configured
+// slow request threshold across all nodes, the most recent first.
+func (a adminAPIHandlers) SlowRequestsHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "SlowRequests")
+
+	defer logger.AuditLog(ctx, w, r, mustGetClaimsFromToken(r))
+
+	objectAPI, _ := validateAdminReq(ctx, w, r, iampolicy.TraceAdminAction)
+	if objectAPI == nil {
+		return
+	}
+
+	count := 100 // by default list only the 100 most recent entries
+	if countStr := r.Form.Get("count"); countStr != "" {
+		var err error
+		count, err = strconv.Atoi(countStr)
+		if err != nil {
+			writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+			return
+		}
+	}
+
+	jsonBytes, err := json.Marshal(globalNotificationSys.GetSlowRequests(ctx, count))
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+
+	writeSuccessResponseJSON(w, jsonBytes)
+}
+
+// NotifyTargetsHandler - GET /minio/admin/v3/notify-targets
+// ----------
+// Get the queue length and the delivery statistics of the
+// notification targets of all nodes.
+func (a adminAPIHandlers) NotifyTargetsHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "NotifyTargets")
+
+	defer logger.AuditLog(ctx, w, r, mustGetClaimsFromToken(r))
+
+	objectAPI, _ := validateAdminReq(ctx, w, r, iampolicy.ServerInfoAdminAction)
+	if objectAPI == nil {
+		return
+	}
+
+	jsonBytes, err := json.Marshal(globalNotificationSys.GetNotifyTargets(ctx))
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+
+	writeSuccessResponseJSON(w, jsonBytes)
+}
+
+// ResetStatsHandler - POST /minio/admin/v3/stats/reset
+// ----------
+// Zero the HTTP and traffic stats of all nodes, gauges such as the
+// in-flight requests are not affected. The outcome is reported
+// per node, nodes which could not be reached carry an error.
+func (a adminAPIHandlers) ResetStatsHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "ResetStats")
+
+	defer logger.AuditLog(ctx, w, r, mustGetClaimsFromToken(r))
+
+	objectAPI, _ := validateAdminReq(ctx, w, r, iampolicy.ServiceRestartAdminAction)
+	if objectAPI == nil {
+		return
+	}
+
+	resetRuntimeStats()
+	servers := []ServerResetStats{{Endpoint: globalLocalNodeName}}
+	for _, nerr := range globalNotificationSys.ResetStats(ctx) {
+		if nerr.Host.Name == "" {
+			continue
+		}
+		s := ServerResetStats{Endpoint: nerr.Host.String()}
+		if nerr.Err != nil {
+			s.Error = nerr.Err.Error()
+		}
+		servers = append(servers, s)
+	}
+
+	jsonBytes, err := json.Marshal(servers)
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+
+	writeSuccessResponseJSON(w, jsonBytes)
+}
+
+// ListCacheInfoHandler - GET /minio/admin/v3/list-cache?bucket={bucket}&prefix={prefix}
+// ----------
+// Describe the listing caches of all nodes, optionally limited to a bucket
+// and to the caches listing a prefix or below it.
+func (a adminAPIHandlers) ListCacheInfoHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "ListCacheInfo")
+
+	defer logger.AuditLog(ctx, w, r, mustGetClaimsFromToken(r))
+
+	objectAPI, _ := validateAdminReq(ctx, w, r, iampolicy.ServerInfoAdminAction)
+	if objectAPI == nil {
+		return
+	}
+
+	infos := getMetacacheInfo(ctx, r.Form.Get("bucket"), r.Form.Get("prefix"))
+	if infos == nil {
+		infos = []metacacheInfo{}
+	}
+	jsonBytes, err := json.Marshal(infos)
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+
+	writeSuccessResponseJSON(w, jsonBytes)
+}
+
+// listCachePurgeResult holds the outcome of purging listing caches.
+type listCachePurgeResult struct {
+	Purged int      `json:"purged"`
+	Errors []string `json:"errors,omitempty"`
+}
+
+// ListCachePurgeHandler - DELETE /minio/admin/v3/list-cache?bucket={bucket}&prefix={prefix}
+// ----------
+// Delete the listing caches of all nodes, optionally limited to a bucket
+// and to the caches listing a prefix or below it. Listings in progress
+// on a deleted cache continue by listing the drives.
+func (a adminAPIHandlers) ListCachePurgeHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "ListCachePurge")
+
+	defer logger.AuditLog(ctx, w, r, mustGetClaimsFromToken(r))
+
+	objectAPI, _ := validateAdminReq(ctx, w, r, iampolicy.ConfigUpdateAdminAction)
+	if objectAPI == nil {
+		return
+	}
+
+	bucket, prefix := r.Form.Get("bucket"), r.Form.Get("prefix")
+	result := listCachePurgeResult{Purged: localMetacacheMgr.purge(bucket, prefix)}
+	purged, nerrs := globalNotificationSys.PurgeMetacache(ctx, bucket, prefix)
+	result.Purged += purged
+	for _, nerr := range nerrs {
+		if nerr.Err != nil {
+			result.Errors = append(result.Errors, fmt.Sprintf("%s: %v", nerr.Host, nerr.Err))
+		}
+	}
+
+	jsonBytes, err := json.Marshal(result)
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+
+	writeSuccessResponseJSON(w, jsonBytes)
+}
+
 // TopLocksHandler Get list of locks in use
 func (a adminAPIHandlers) TopLocksHandler(w http.ResponseWriter, r *http.Request) {
 	ctx := newContext(r, w, "TopLocks")
@@ -719,6 +1228,70 @@ func (a adminAPIHandlers) DownloadProfilingHandler(w http.ResponseWriter, r *htt
 	}
 }
 
+// ContinuousProfilesHandler - GET /minio/admin/v3/profiles/continuous?since={time}&until={time}
+// ----------
+// Download the profiles captured by the continuous profiler of all
+// nodes between since and until, in RFC3339 format, in a zip file.
+func (a adminAPIHandlers) ContinuousProfilesHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "ContinuousProfiles")
+
+	defer logger.AuditLog(ctx, w, r, mustGetClaimsFromToken(r))
+
+	objectAPI, _ := validateAdminReq(ctx, w, r, iampolicy.ProfilingAdminAction)
+	if objectAPI == nil {
+		return
+	}
+
+	var since, until time.Time
+	var err error
+	if v := r.Form.Get("since"); v != "" {
+		if since, err = time.Parse(time.RFC3339, v); err != nil {
+			writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErr(ErrInvalidRequest), r.URL)
+			return
+		}
+	}
+	if v := r.Form.Get("until"); v != "" {
+		if until, err = time.Parse(time.RFC3339, v); err != nil {
+			writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErr(ErrInvalidRequest), r.URL)
+			return
+		}
+	}
+
+	zipWriter := zip.NewWriter(w)
+	defer zipWriter.Close()
+
+	prefix := continuousProfilesPrefix + SlashSeparator
+	err = listContinuousProfiles(ctx, objectAPI, prefix, func(obj ObjectInfo) error {
+		if obj.ModTime.Before(since) || (!until.IsZero() && obj.ModTime.After(until)) {
+			return nil
+		}
+		data, err := readConfig(ctx, objectAPI, obj.Name)
+		if err != nil {
+			if errors.Is(err, errConfigNotFound) {
+				return nil // Expired meanwhile.
+			}
+			return err
+		}
+		header, err := zip.FileInfoHeader(dummyFileInfo{
+			name:    strings.TrimPrefix(obj.Name, prefix),
+			size:    int64(len(data)),
+			mode:    0o600,
+			modTime: obj.ModTime,
+		})
+		if err != nil {
+			return err
+		}
+		header.Method = zip.Deflate
+		zwriter, err := zipWriter.CreateHeader(header)
+		if err != nil {
+			return err
+		}
+		_, err = zwriter.Write(data)
+		return err
+	})
+	logger.LogIf(ctx, err)
+}
+
 type healInitParams struct {
 	bucket, objPrefix     string
 	hs                    madmin.HealOpts
@@ -1314,7 +1887,7 @@ const (
 // - input entry is not of the type *madmin.TraceInfo*
 // - errOnly entries are to be traced, not status code 2xx, 3xx.
 // - madmin.TraceInfo type is asked by opts
-func mustTrace(entry interface{}, opts madmin.ServiceTraceOpts) (shouldTrace bool) {
+func mustTrace(entry interface{}, opts traceOptions) (shouldTrace bool) {
 	trcInfo, ok := entry.(madmin.TraceInfo)
 	if !ok {
 		return false
@@ -1342,6 +1915,10 @@ func mustTrace(entry interface{}, opts madmin.ServiceTraceOpts) (shouldTrace boo
 		}
 	}
 
+	if !opts.matchesFilters(trcInfo) {
+		return false
+	}
+
 	if opts.Internal && trcInfo.TraceType == madmin.TraceHTTP && HasPrefix(trcInfo.ReqInfo.Path, minioReservedBucketPath+SlashSeparator) {
 		return true
 	}
@@ -1357,7 +1934,7 @@ func mustTrace(entry interface{}, opts madmin.ServiceTraceOpts) (shouldTrace boo
 	return opts.OS && trcInfo.TraceType == madmin.TraceOS
 }
 
-func extractTraceOptions(r *http.Request) (opts madmin.ServiceTraceOpts, err error) {
+func extractTraceOptions(r *http.Request) (opts traceOptions, err error) {
 	q := r.Form
 
 	opts.OnlyErrors = q.Get("err") == "true"
@@ -1381,6 +1958,7 @@ func extractTraceOptions(r *http.Request) (opts madmin.ServiceTraceOpts, err err
 		}
 		opts.Threshold = d
 	}
+	err = opts.setFilters(q.Get("bucket"), q.Get("prefix"), q.Get("status"))
 	return
 }
 
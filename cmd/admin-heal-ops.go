@@ -176,6 +176,14 @@ func (ahs *allHealState) getHealLocalDiskEndpoints() Endpoints {
 	return endpoints
 }
 
+func (ahs *allHealState) isHealLocalDisk(ep Endpoint) bool {
+	ahs.RLock()
+	defer ahs.RUnlock()
+
+	_, ok := ahs.healLocalDisks[ep]
+	return ok
+}
+
 func (ahs *allHealState) pushHealLocalDisks(healLocalDisks ...Endpoint) {
 	ahs.Lock()
 	defer ahs.Unlock()
@@ -55,6 +55,9 @@ func registerAdminRouter(router *mux.Router, enableConfigOps bool) {
 	for _, adminVersion := range adminVersions {
 		// Restart and stop MinIO service.
 		adminRouter.Methods(http.MethodPost).Path(adminVersion+"/service").HandlerFunc(gz(httpTraceAll(adminAPI.ServiceHandler))).Queries("action", "{action:.*}")
+		// Drain S3 API calls before a restart.
+		adminRouter.Methods(http.MethodPost).Path(adminVersion + "/service/drain").HandlerFunc(httpTraceHdrs(adminAPI.ServiceDrainHandler))
+		adminRouter.Methods(http.MethodPost).Path(adminVersion + "/service/undrain").HandlerFunc(gz(httpTraceHdrs(adminAPI.ServiceUndrainHandler)))
 		// Update MinIO servers.
 		adminRouter.Methods(http.MethodPost).Path(adminVersion+"/update").HandlerFunc(gz(httpTraceAll(adminAPI.ServerUpdateHandler))).Queries("updateURL", "{updateURL:.*}")
 
@@ -67,9 +70,15 @@ func registerAdminRouter(router *mux.Router, enableConfigOps bool) {
 		// DataUsageInfo operations
 		adminRouter.Methods(http.MethodGet).Path(adminVersion + "/datausageinfo").HandlerFunc(gz(httpTraceAll(adminAPI.DataUsageInfoHandler)))
 
+		// Scanner status
+		adminRouter.Methods(http.MethodGet).Path(adminVersion + "/scanner/status").HandlerFunc(gz(httpTraceAll(adminAPI.ScannerStatusHandler)))
+
 		if globalIsDistErasure || globalIsErasure {
 			// Heal operations
 
+			// Heal progress of the drives being healed.
+			adminRouter.Methods(http.MethodGet).Path(adminVersion + "/heal/progress").HandlerFunc(gz(httpTraceAll(adminAPI.HealProgressHandler)))
+
 			// Heal processing endpoint.
 			adminRouter.Methods(http.MethodPost).Path(adminVersion + "/heal/").HandlerFunc(gz(httpTraceAll(adminAPI.HealHandler)))
 			adminRouter.Methods(http.MethodPost).Path(adminVersion + "/heal/{bucket}").HandlerFunc(gz(httpTraceAll(adminAPI.HealHandler)))
@@ -82,6 +91,10 @@ func registerAdminRouter(router *mux.Router, enableConfigOps bool) {
 
 			adminRouter.Methods(http.MethodPost).Path(adminVersion+"/pools/decommission").HandlerFunc(gz(httpTraceAll(adminAPI.StartDecommission))).Queries("pool", "{pool:.*}")
 			adminRouter.Methods(http.MethodPost).Path(adminVersion+"/pools/cancel").HandlerFunc(gz(httpTraceAll(adminAPI.CancelDecommission))).Queries("pool", "{pool:.*}")
+
+			adminRouter.Methods(http.MethodPost).Path(adminVersion + "/rebalance/start").HandlerFunc(gz(httpTraceAll(adminAPI.RebalanceStart)))
+			adminRouter.Methods(http.MethodGet).Path(adminVersion + "/rebalance/status").HandlerFunc(gz(httpTraceAll(adminAPI.RebalanceStatus)))
+			adminRouter.Methods(http.MethodPost).Path(adminVersion + "/rebalance/stop").HandlerFunc(gz(httpTraceAll(adminAPI.RebalanceStop)))
 		}
 
 		// Profiling operations - deprecated API
@@ -90,6 +103,7 @@ func registerAdminRouter(router *mux.Router, enableConfigOps bool) {
 		adminRouter.Methods(http.MethodGet).Path(adminVersion + "/profiling/download").HandlerFunc(gz(httpTraceAll(adminAPI.DownloadProfilingHandler)))
 		// Profiling operations
 		adminRouter.Methods(http.MethodPost).Path(adminVersion + "/profile").HandlerFunc(gz(httpTraceAll(adminAPI.ProfileHandler)))
+		adminRouter.Methods(http.MethodGet).Path(adminVersion + "/profiles/continuous").HandlerFunc(gz(httpTraceAll(adminAPI.ContinuousProfilesHandler)))
 
 		// Config KV operations.
 		if enableConfigOps {
@@ -128,13 +142,23 @@ func registerAdminRouter(router *mux.Router, enableConfigOps bool) {
 
 		adminRouter.Methods(http.MethodPut).Path(adminVersion+"/set-user-status").HandlerFunc(gz(httpTraceHdrs(adminAPI.SetUserStatus))).Queries("accessKey", "{accessKey:.*}").Queries("status", "{status:.*}")
 
+		// Request rate and bandwidth limits of users and service accounts
+		adminRouter.Methods(http.MethodPut).Path(adminVersion+"/set-access-key-limits").HandlerFunc(gz(httpTraceHdrs(adminAPI.SetAccessKeyLimits))).Queries("accessKey", "{accessKey:.*}")
+		adminRouter.Methods(http.MethodGet).Path(adminVersion+"/access-key-limits").HandlerFunc(gz(httpTraceHdrs(adminAPI.GetAccessKeyLimits))).Queries("accessKey", "{accessKey:.*}")
+		adminRouter.Methods(http.MethodGet).Path(adminVersion + "/access-key-usage").HandlerFunc(gz(httpTraceHdrs(adminAPI.AccessKeyUsageReport)))
+
 		// Service accounts ops
 		adminRouter.Methods(http.MethodPut).Path(adminVersion + "/add-service-account").HandlerFunc(gz(httpTraceHdrs(adminAPI.AddServiceAccount)))
 		adminRouter.Methods(http.MethodPost).Path(adminVersion+"/update-service-account").HandlerFunc(gz(httpTraceHdrs(adminAPI.UpdateServiceAccount))).Queries("accessKey", "{accessKey:.*}")
+		adminRouter.Methods(http.MethodPost).Path(adminVersion+"/rotate-service-account").HandlerFunc(gz(httpTraceHdrs(adminAPI.RotateServiceAccount))).Queries("accessKey", "{accessKey:.*}")
 		adminRouter.Methods(http.MethodGet).Path(adminVersion+"/info-service-account").HandlerFunc(gz(httpTraceHdrs(adminAPI.InfoServiceAccount))).Queries("accessKey", "{accessKey:.*}")
 		adminRouter.Methods(http.MethodGet).Path(adminVersion + "/list-service-accounts").HandlerFunc(gz(httpTraceHdrs(adminAPI.ListServiceAccounts)))
 		adminRouter.Methods(http.MethodDelete).Path(adminVersion+"/delete-service-account").HandlerFunc(gz(httpTraceHdrs(adminAPI.DeleteServiceAccount))).Queries("accessKey", "{accessKey:.*}")
 
+		// Export and import the IAM state
+		adminRouter.Methods(http.MethodPost).Path(adminVersion + "/export-iam").HandlerFunc(gz(httpTraceHdrs(adminAPI.ExportIAMHandler)))
+		adminRouter.Methods(http.MethodPut).Path(adminVersion + "/import-iam").HandlerFunc(gz(httpTraceHdrs(adminAPI.ImportIAMHandler)))
+
 		// Info policy IAM latest
 		adminRouter.Methods(http.MethodGet).Path(adminVersion+"/info-canned-policy").HandlerFunc(gz(httpTraceHdrs(adminAPI.InfoCannedPolicy))).Queries("name", "{name:.*}")
 		// List policies latest
@@ -158,6 +182,9 @@ func registerAdminRouter(router *mux.Router, enableConfigOps bool) {
 
 		// User info
 		adminRouter.Methods(http.MethodGet).Path(adminVersion+"/user-info").HandlerFunc(gz(httpTraceHdrs(adminAPI.GetUserInfo))).Queries("accessKey", "{accessKey:.*}")
+
+		// User stats
+		adminRouter.Methods(http.MethodGet).Path(adminVersion + "/user-stats").HandlerFunc(gz(httpTraceHdrs(adminAPI.GetUserStats)))
 		// Add/Remove members from group
 		adminRouter.Methods(http.MethodPut).Path(adminVersion + "/update-group-members").HandlerFunc(gz(httpTraceHdrs(adminAPI.UpdateGroupMembers)))
 
@@ -177,6 +204,43 @@ func registerAdminRouter(router *mux.Router, enableConfigOps bool) {
 		adminRouter.Methods(http.MethodPut).Path(adminVersion+"/set-bucket-quota").HandlerFunc(
 			gz(httpTraceHdrs(adminAPI.PutBucketQuotaConfigHandler))).Queries("bucket", "{bucket:.*}")
 
+		// GetBucketBandwidthLimits
+		adminRouter.Methods(http.MethodGet).Path(adminVersion+"/get-bucket-bandwidth").HandlerFunc(
+			gz(httpTraceHdrs(adminAPI.GetBucketBandwidthLimitsHandler))).Queries("bucket", "{bucket:.*}")
+		// PutBucketBandwidthLimits
+		adminRouter.Methods(http.MethodPut).Path(adminVersion+"/set-bucket-bandwidth").HandlerFunc(
+			gz(httpTraceHdrs(adminAPI.PutBucketBandwidthLimitsHandler))).Queries("bucket", "{bucket:.*}")
+		// PutReplicationBandwidthLimit
+		adminRouter.Methods(http.MethodPut).Path(adminVersion+"/set-replication-bandwidth").HandlerFunc(
+			gz(httpTraceHdrs(adminAPI.PutReplicationBandwidthLimitHandler))).Queries("endpoint", "{endpoint:.*}")
+		// GetReplicationBandwidthLimits
+		adminRouter.Methods(http.MethodGet).Path(adminVersion + "/get-replication-bandwidth").HandlerFunc(
+			gz(httpTraceHdrs(adminAPI.GetReplicationBandwidthLimitsHandler)))
+		// ListReplicationResync
+		adminRouter.Methods(http.MethodGet).Path(adminVersion + "/replication/resync/list").HandlerFunc(
+			gz(httpTraceHdrs(adminAPI.ListReplicationResyncHandler)))
+		// PauseReplicationResync
+		adminRouter.Methods(http.MethodPost).Path(adminVersion+"/replication/resync/pause").HandlerFunc(
+			gz(httpTraceHdrs(adminAPI.PauseReplicationResyncHandler))).Queries("bucket", "{bucket:.*}", "arn", "{arn:.*}")
+		// ResumeReplicationResync
+		adminRouter.Methods(http.MethodPost).Path(adminVersion+"/replication/resync/resume").HandlerFunc(
+			gz(httpTraceHdrs(adminAPI.ResumeReplicationResyncHandler))).Queries("bucket", "{bucket:.*}", "arn", "{arn:.*}")
+		// PreviewBucketLifecycle
+		adminRouter.Methods(http.MethodPost).Path(adminVersion+"/ilm/preview").HandlerFunc(
+			gz(httpTraceHdrs(adminAPI.PreviewBucketLifecycleHandler))).Queries("bucket", "{bucket:.*}")
+		// GetBucketTransforms
+		adminRouter.Methods(http.MethodGet).Path(adminVersion+"/get-bucket-transforms").HandlerFunc(
+			gz(httpTraceHdrs(adminAPI.GetBucketTransformsHandler))).Queries("bucket", "{bucket:.*}")
+		// PutBucketTransforms
+		adminRouter.Methods(http.MethodPut).Path(adminVersion+"/set-bucket-transforms").HandlerFunc(
+			gz(httpTraceHdrs(adminAPI.PutBucketTransformsHandler))).Queries("bucket", "{bucket:.*}")
+		// GetBucketDomains
+		adminRouter.Methods(http.MethodGet).Path(adminVersion+"/get-bucket-domains").HandlerFunc(
+			gz(httpTraceHdrs(adminAPI.GetBucketDomainsHandler))).Queries("bucket", "{bucket:.*}")
+		// PutBucketDomains
+		adminRouter.Methods(http.MethodPut).Path(adminVersion+"/set-bucket-domains").HandlerFunc(
+			gz(httpTraceHdrs(adminAPI.PutBucketDomainsHandler))).Queries("bucket", "{bucket:.*}")
+
 		// Bucket replication operations
 		// GetBucketTargetHandler
 		adminRouter.Methods(http.MethodGet).Path(adminVersion+"/list-remote-targets").HandlerFunc(
@@ -194,6 +258,9 @@ func registerAdminRouter(router *mux.Router, enableConfigOps bool) {
 		adminRouter.Methods(http.MethodGet).Path(adminVersion + "/tier").HandlerFunc(gz(httpTraceHdrs(adminAPI.ListTierHandler)))
 		adminRouter.Methods(http.MethodDelete).Path(adminVersion + "/tier/{tier}").HandlerFunc(gz(httpTraceHdrs(adminAPI.RemoveTierHandler)))
 		adminRouter.Methods(http.MethodGet).Path(adminVersion + "/tier/{tier}").HandlerFunc(gz(httpTraceHdrs(adminAPI.VerifyTierHandler)))
+		// Filesystem tier management operations
+		adminRouter.Methods(http.MethodPut).Path(adminVersion + "/tier-fs").HandlerFunc(gz(httpTraceHdrs(adminAPI.AddFSTierHandler)))
+		adminRouter.Methods(http.MethodGet).Path(adminVersion + "/tier-fs").HandlerFunc(gz(httpTraceHdrs(adminAPI.ListFSTierHandler)))
 		// Tier stats
 		adminRouter.Methods(http.MethodGet).Path(adminVersion + "/tier-stats").HandlerFunc(gz(httpTraceHdrs(adminAPI.TierStatsHandler)))
 
@@ -203,16 +270,44 @@ func registerAdminRouter(router *mux.Router, enableConfigOps bool) {
 		adminRouter.Methods(http.MethodGet).Path(adminVersion + "/site-replication/info").HandlerFunc(gz(httpTraceHdrs(adminAPI.SiteReplicationInfo)))
 		adminRouter.Methods(http.MethodGet).Path(adminVersion + "/site-replication/metainfo").HandlerFunc(gz(httpTraceHdrs(adminAPI.SiteReplicationMetaInfo)))
 		adminRouter.Methods(http.MethodGet).Path(adminVersion + "/site-replication/status").HandlerFunc(gz(httpTraceHdrs(adminAPI.SiteReplicationStatus)))
+		adminRouter.Methods(http.MethodGet).Path(adminVersion + "/site-replication/health").HandlerFunc(gz(httpTraceHdrs(adminAPI.SiteReplicationHealth)))
 
 		adminRouter.Methods(http.MethodPut).Path(adminVersion + "/site-replication/peer/join").HandlerFunc(gz(httpTraceHdrs(adminAPI.SRPeerJoin)))
 		adminRouter.Methods(http.MethodPut).Path(adminVersion+"/site-replication/peer/bucket-ops").HandlerFunc(gz(httpTraceHdrs(adminAPI.SRPeerBucketOps))).Queries("bucket", "{bucket:.*}").Queries("operation", "{operation:.*}")
 		adminRouter.Methods(http.MethodPut).Path(adminVersion + "/site-replication/peer/iam-item").HandlerFunc(gz(httpTraceHdrs(adminAPI.SRPeerReplicateIAMItem)))
 		adminRouter.Methods(http.MethodPut).Path(adminVersion + "/site-replication/peer/bucket-meta").HandlerFunc(gz(httpTraceHdrs(adminAPI.SRPeerReplicateBucketItem)))
 		adminRouter.Methods(http.MethodGet).Path(adminVersion + "/site-replication/peer/idp-settings").HandlerFunc(gz(httpTraceHdrs(adminAPI.SRPeerGetIDPSettings)))
+		adminRouter.Methods(http.MethodGet).Path(adminVersion + "/site-replication/peer/health").HandlerFunc(gz(httpTraceHdrs(adminAPI.SRPeerHealth)))
+		adminRouter.Methods(http.MethodPost).Path(adminVersion + "/site-replication/netperf").HandlerFunc(gz(httpTraceHdrs(adminAPI.SiteReplicationNetperf)))
+		adminRouter.Methods(http.MethodPut).Path(adminVersion + "/site-replication/peer/netperf").HandlerFunc(gz(httpTraceHdrs(adminAPI.SRPeerNetperf)))
 		adminRouter.Methods(http.MethodPut).Path(adminVersion + "/site-replication/edit").HandlerFunc(gz(httpTraceHdrs(adminAPI.SiteReplicationEdit)))
 		adminRouter.Methods(http.MethodPut).Path(adminVersion + "/site-replication/peer/edit").HandlerFunc(gz(httpTraceHdrs(adminAPI.SRPeerEdit)))
 		adminRouter.Methods(http.MethodPut).Path(adminVersion + "/site-replication/peer/remove").HandlerFunc(gz(httpTraceHdrs(adminAPI.SRPeerRemove)))
 
+		// Top clients
+		adminRouter.Methods(http.MethodGet).Path(adminVersion + "/top/clients").HandlerFunc(gz(httpTraceHdrs(adminAPI.TopClientsHandler)))
+		adminRouter.Methods(http.MethodGet).Path(adminVersion + "/top/objects").HandlerFunc(gz(httpTraceHdrs(adminAPI.TopObjectsHandler)))
+
+		// Anonymous access report
+		adminRouter.Methods(http.MethodGet).Path(adminVersion + "/anonymous-access").HandlerFunc(gz(httpTraceHdrs(adminAPI.AnonymousAccessHandler)))
+
+		// Rejected requests
+		adminRouter.Methods(http.MethodGet).Path(adminVersion + "/rejected-requests").HandlerFunc(gz(httpTraceHdrs(adminAPI.RejectedRequestsHandler)))
+		adminRouter.Methods(http.MethodGet).Path(adminVersion + "/slow-requests").HandlerFunc(gz(httpTraceHdrs(adminAPI.SlowRequestsHandler)))
+
+		// Notification targets queue length and delivery statistics
+		adminRouter.Methods(http.MethodGet).Path(adminVersion + "/notify-targets").HandlerFunc(gz(httpTraceHdrs(adminAPI.NotifyTargetsHandler)))
+
+		// Live stats, streamed over a WebSocket
+		adminRouter.Methods(http.MethodGet).Path(adminVersion + "/live-stats").HandlerFunc(adminAPI.LiveStatsHandler)
+
+		// Reset stats
+		adminRouter.Methods(http.MethodPost).Path(adminVersion + "/stats/reset").HandlerFunc(gz(httpTraceHdrs(adminAPI.ResetStatsHandler)))
+
+		// Listing cache
+		adminRouter.Methods(http.MethodGet).Path(adminVersion + "/list-cache").HandlerFunc(gz(httpTraceHdrs(adminAPI.ListCacheInfoHandler)))
+		adminRouter.Methods(http.MethodDelete).Path(adminVersion + "/list-cache").HandlerFunc(gz(httpTraceHdrs(adminAPI.ListCachePurgeHandler)))
+
 		if globalIsDistErasure {
 			// Top locks
 			adminRouter.Methods(http.MethodGet).Path(adminVersion + "/top/locks").HandlerFunc(gz(httpTraceHdrs(adminAPI.TopLocksHandler)))
@@ -247,6 +342,13 @@ func registerAdminRouter(router *mux.Router, enableConfigOps bool) {
 				HandlerFunc(gz(httpTraceHdrs(adminAPI.HealthInfoHandler)))
 			adminRouter.Methods(http.MethodGet).Path(adminVersion + "/bandwidth").
 				HandlerFunc(gz(httpTraceHdrs(adminAPI.BandwidthMonitorHandler)))
+
+			// Batch job operations
+			adminRouter.Methods(http.MethodPost).Path(adminVersion + "/start-job").HandlerFunc(gz(httpTraceHdrs(adminAPI.StartBatchJob)))
+			adminRouter.Methods(http.MethodGet).Path(adminVersion + "/list-jobs").HandlerFunc(gz(httpTraceAll(adminAPI.ListBatchJobs)))
+			adminRouter.Methods(http.MethodGet).Path(adminVersion+"/describe-job").HandlerFunc(gz(httpTraceAll(adminAPI.DescribeBatchJob))).Queries("jobId", "{jobId:.*}")
+			adminRouter.Methods(http.MethodPost).Path(adminVersion+"/cancel-job").HandlerFunc(gz(httpTraceAll(adminAPI.CancelBatchJob))).Queries("jobId", "{jobId:.*}")
+			adminRouter.Methods(http.MethodGet).Path(adminVersion + "/compliance-audit-key").HandlerFunc(gz(httpTraceAll(adminAPI.ComplianceAuditKey)))
 		}
 	}
 
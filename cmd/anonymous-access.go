@@ -0,0 +1,197 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"context"
+	"sort"
+	"strings"
+
+	"github.com/minio/pkg/bucket/policy"
+)
+
+// AnonymousAccessReport describes which buckets and prefixes can be
+// accessed anonymously and how often anonymous requests hit them.
+type AnonymousAccessReport struct {
+	Buckets []AnonymousBucketAccess `json:"buckets"`
+}
+
+// AnonymousBucketAccess holds the anonymous access to a bucket. ACL is
+// always private, ACLs are only emulated and only private ACLs are
+// accepted, hence anonymous access is only granted by bucket policies.
+type AnonymousBucketAccess struct {
+	Bucket   string                             `json:"bucket"`
+	ACL      string                             `json:"acl"`
+	Grants   []AnonymousAccessGrant             `json:"grants,omitempty"`
+	Requests ServerAnonymousRequests            `json:"requests"`
+	Prefixes map[string]ServerAnonymousRequests `json:"prefixes,omitempty"`
+}
+
+// AnonymousAccessGrant is a resource of a bucket policy granting
+// actions to anonymous requests. Requests are the anonymous requests
+// to the objects under Prefix, or to the bucket for bucket resources.
+type AnonymousAccessGrant struct {
+	Resource    string                  `json:"resource"`
+	Prefix      string                  `json:"prefix"`
+	List        bool                    `json:"list"`
+	Read        bool                    `json:"read"`
+	Write       bool                    `json:"write"`
+	Conditional bool                    `json:"conditional,omitempty"`
+	Actions     []string                `json:"actions"`
+	Requests    ServerAnonymousRequests `json:"requests"`
+}
+
+// The ACL reported for all buckets, see AnonymousBucketAccess.
+const emulatedBucketACL = "private"
+
+// anonymousPolicyGrants returns the resources of the bucket policy
+// granting actions to anonymous requests. Actions denied by the policy
+// to anonymous requests are left out, unless they are granted by a
+// statement with conditions which cannot be evaluated here.
+func anonymousPolicyGrants(bucket string, p *policy.Policy) []AnonymousAccessGrant {
+	if p == nil {
+		return nil
+	}
+	probe := strings.NewReplacer("*", "x", "?", "x")
+	grants := make(map[string]*AnonymousAccessGrant)
+	actions := make(map[string]map[string]struct{})
+	for _, st := range p.Statements {
+		if st.Effect != policy.Allow || !st.Principal.Match("") {
+			continue
+		}
+		conditional := len(st.Conditions) > 0
+		for _, res := range st.Resources.ToSlice() {
+			var object string
+			if i := strings.Index(res.Pattern, SlashSeparator); i >= 0 {
+				object = res.Pattern[i+1:]
+			}
+			// Object name matched by the resource pattern.
+			probeObject := probe.Replace(object)
+			for action := range st.Actions {
+				if !conditional && !p.IsAllowed(policy.Args{
+					Action:          action,
+					BucketName:      bucket,
+					ObjectName:      probeObject,
+					ConditionValues: map[string][]string{},
+				}) {
+					continue
+				}
+				g, ok := grants[res.Pattern]
+				if !ok {
+					prefix := object
+					if i := strings.IndexAny(prefix, "*?"); i >= 0 {
+						prefix = prefix[:i]
+					}
+					g = &AnonymousAccessGrant{
+						Resource: res.String(),
+						Prefix:   prefix,
+					}
+					grants[res.Pattern] = g
+					actions[res.Pattern] = make(map[string]struct{})
+				}
+				g.Conditional = g.Conditional || conditional
+				actions[res.Pattern][string(action)] = struct{}{}
+				switch {
+				case strings.HasPrefix(string(action), "s3:List"):
+					g.List = true
+				case strings.HasPrefix(string(action), "s3:Get"):
+					g.Read = true
+				default:
+					g.Write = true
+				}
+			}
+		}
+	}
+
+	result := make([]AnonymousAccessGrant, 0, len(grants))
+	for pattern, g := range grants {
+		for action := range actions[pattern] {
+			g.Actions = append(g.Actions, action)
+		}
+		sort.Strings(g.Actions)
+		result = append(result, *g)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Resource < result[j].Resource
+	})
+	return result
+}
+
+// anonymousBucketAccess returns the anonymous access to bucket
+// granted by its policy along with the anonymous requests to it.
+func anonymousBucketAccess(bucket string, p *policy.Policy, requests ServerAnonymousBucketRequests) AnonymousBucketAccess {
+	access := AnonymousBucketAccess{
+		Bucket:   bucket,
+		ACL:      emulatedBucketACL,
+		Grants:   anonymousPolicyGrants(bucket, p),
+		Requests: requests.Requests,
+		Prefixes: requests.Prefixes,
+	}
+	for i := range access.Grants {
+		g := &access.Grants[i]
+		if !strings.Contains(g.Resource, SlashSeparator) {
+			// Bucket resource
+			g.Requests = requests.Requests
+			continue
+		}
+		for prefix, r := range requests.Prefixes {
+			if strings.HasPrefix(prefix, g.Prefix) {
+				g.Requests.merge(r)
+			}
+		}
+	}
+	return access
+}
+
+// getAnonymousAccessReport returns the anonymous access to all buckets,
+// or only to bucket if not empty. Buckets which cannot be accessed
+// anonymously and were never hit by an anonymous request are left out.
+func getAnonymousAccessReport(ctx context.Context, objAPI ObjectLayer, bucket string) (AnonymousAccessReport, error) {
+	var buckets []BucketInfo
+	if bucket != "" {
+		bi, err := objAPI.GetBucketInfo(ctx, bucket)
+		if err != nil {
+			return AnonymousAccessReport{}, err
+		}
+		buckets = append(buckets, bi)
+	} else {
+		var err error
+		buckets, err = objAPI.ListBuckets(ctx)
+		if err != nil {
+			return AnonymousAccessReport{}, err
+		}
+	}
+
+	requests := globalNotificationSys.GetAnonymousRequests(ctx)
+	report := AnonymousAccessReport{Buckets: []AnonymousBucketAccess{}}
+	for _, bi := range buckets {
+		p, err := globalPolicySys.Get(bi.Name)
+		if err != nil {
+			if _, ok := err.(BucketPolicyNotFound); !ok {
+				return AnonymousAccessReport{}, err
+			}
+			p = nil
+		}
+		access := anonymousBucketAccess(bi.Name, p, requests[bi.Name])
+		if len(access.Grants) == 0 && access.Requests == (ServerAnonymousRequests{}) {
+			continue
+		}
+		report.Buckets = append(report.Buckets, access)
+	}
+	return report, nil
+}
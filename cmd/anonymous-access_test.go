@@ -0,0 +1,146 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/minio/pkg/bucket/policy"
+)
+
+func TestAnonymousAccessLog(t *testing.T) {
+	var log anonymousAccessLog
+	now := time.Now().UTC()
+
+	log.addAt(now, "bucket", "public/a.txt", false, http.StatusOK)
+	log.addAt(now, "bucket", "public/b.txt", false, http.StatusOK)
+	log.addAt(now, "bucket", "uploads/c.txt", true, http.StatusOK)
+	log.addAt(now.Add(time.Second), "bucket", "private/d.txt", false, http.StatusForbidden)
+	log.addAt(now, "bucket", "", false, http.StatusOK)
+	log.addAt(now, minioMetaBucket, "x", false, http.StatusOK)
+
+	report := log.report()
+	if len(report) != 1 {
+		t.Fatalf("expected 1 bucket, got %d", len(report))
+	}
+	b := report["bucket"]
+	want := ServerAnonymousRequests{Reads: 3, Writes: 1, Denied: 1, LastAccess: now.Add(time.Second)}
+	if b.Requests != want {
+		t.Errorf("expected %+v, got %+v", want, b.Requests)
+	}
+	if got := b.Prefixes["public/"]; got.Reads != 2 || got.Writes != 0 {
+		t.Errorf("unexpected public/ requests %+v", got)
+	}
+	if got := b.Prefixes["private/"]; got.Denied != 1 || got.Reads != 0 {
+		t.Errorf("unexpected private/ requests %+v", got)
+	}
+
+	merged := mergeAnonymousRequests([]map[string]ServerAnonymousBucketRequests{report, report})
+	if got := merged["bucket"].Prefixes["public/"].Reads; got != 4 {
+		t.Errorf("expected 4 merged reads, got %d", got)
+	}
+
+	log.reset()
+	if len(log.report()) != 0 {
+		t.Error("expected no requests after reset")
+	}
+}
+
+func TestAnonymousPolicyGrants(t *testing.T) {
+	p, err := policy.ParseConfig(strings.NewReader(`{
+  "Version": "2012-10-17",
+  "Statement": [
+    {
+      "Effect": "Allow",
+      "Principal": {"AWS": ["*"]},
+      "Action": ["s3:ListBucket"],
+      "Resource": ["arn:aws:s3:::bucket"]
+    },
+    {
+      "Effect": "Allow",
+      "Principal": {"AWS": ["*"]},
+      "Action": ["s3:GetObject"],
+      "Resource": ["arn:aws:s3:::bucket/public/*", "arn:aws:s3:::bucket/secret/*"]
+    },
+    {
+      "Effect": "Deny",
+      "Principal": {"AWS": ["*"]},
+      "Action": ["s3:GetObject"],
+      "Resource": ["arn:aws:s3:::bucket/secret/*"]
+    },
+    {
+      "Effect": "Allow",
+      "Principal": {"AWS": ["*"]},
+      "Action": ["s3:PutObject"],
+      "Resource": ["arn:aws:s3:::bucket/uploads/*"],
+      "Condition": {"IpAddress": {"aws:SourceIp": "192.168.1.0/24"}}
+    }
+  ]
+}`), "bucket")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	requests := ServerAnonymousBucketRequests{
+		Requests: ServerAnonymousRequests{Reads: 5, Writes: 1},
+		Prefixes: map[string]ServerAnonymousRequests{
+			"public/":      {Reads: 2},
+			"public/docs/": {Reads: 1},
+			"uploads/":     {Writes: 1},
+			"":             {Reads: 2},
+		},
+	}
+	access := anonymousBucketAccess("bucket", p, requests)
+	want := []AnonymousAccessGrant{
+		{
+			Resource: "arn:aws:s3:::bucket",
+			List:     true,
+			Actions:  []string{"s3:ListBucket"},
+			Requests: ServerAnonymousRequests{Reads: 5, Writes: 1},
+		},
+		{
+			Resource: "arn:aws:s3:::bucket/public/*",
+			Prefix:   "public/",
+			Read:     true,
+			Actions:  []string{"s3:GetObject"},
+			Requests: ServerAnonymousRequests{Reads: 3},
+		},
+		{
+			Resource:    "arn:aws:s3:::bucket/uploads/*",
+			Prefix:      "uploads/",
+			Write:       true,
+			Conditional: true,
+			Actions:     []string{"s3:PutObject"},
+			Requests:    ServerAnonymousRequests{Writes: 1},
+		},
+	}
+	if !reflect.DeepEqual(access.Grants, want) {
+		t.Errorf("expected grants %+v, got %+v", want, access.Grants)
+	}
+	if access.ACL != emulatedBucketACL {
+		t.Errorf("expected ACL %s, got %s", emulatedBucketACL, access.ACL)
+	}
+
+	if grants := anonymousPolicyGrants("bucket", nil); len(grants) != 0 {
+		t.Errorf("expected no grants without policy, got %+v", grants)
+	}
+}
@@ -33,7 +33,10 @@ import (
 	minio "github.com/minio/minio-go/v7"
 	"github.com/minio/minio-go/v7/pkg/tags"
 	"github.com/minio/minio/internal/auth"
+	bucketcors "github.com/minio/minio/internal/bucket/cors"
+	"github.com/minio/minio/internal/bucket/inventory"
 	"github.com/minio/minio/internal/bucket/lifecycle"
+	"github.com/minio/minio/internal/bucket/logging"
 	"github.com/minio/minio/internal/bucket/replication"
 	"github.com/minio/minio/internal/config/dns"
 	"github.com/minio/minio/internal/crypto"
@@ -41,6 +44,7 @@ import (
 
 	objectlock "github.com/minio/minio/internal/bucket/object/lock"
 	"github.com/minio/minio/internal/bucket/versioning"
+	"github.com/minio/minio/internal/bucket/website"
 	"github.com/minio/minio/internal/event"
 	"github.com/minio/minio/internal/hash"
 	"github.com/minio/pkg/bucket/policy"
@@ -389,6 +393,19 @@ const (
 	ErrAccountNotEligible
 	ErrAdminServiceAccountNotFound
 	ErrPostPolicyConditionInvalidFormat
+	ErrServerDraining
+	ErrInvalidAttributeName
+	ErrInvalidChecksum
+	ErrContentChecksumMismatch
+	ErrNoSuchInventoryConfiguration
+	ErrNoSuchAccessPoint
+	ErrObjectTransformFailed
+	ErrObjectNotAppendable
+	ErrInvalidMaxContentLength
+	ErrAccessKeyExpired
+	ErrAdminBucketObjectCountQuotaExceeded
+	ErrCORSForbidden
+	ErrInvalidTargetBucketForLogging
 )
 
 type errorCodeMap map[APIErrorCode]APIError
@@ -1856,6 +1873,71 @@ var errorCodes = errorCodeMap{
 		Description:    "Invalid according to Policy: Policy Condition failed",
 		HTTPStatusCode: http.StatusForbidden,
 	},
+	ErrServerDraining: {
+		Code:           "XMinioServerDraining",
+		Description:    "Server is draining before a restart, please try another server.",
+		HTTPStatusCode: http.StatusServiceUnavailable,
+	},
+	ErrInvalidAttributeName: {
+		Code:           "InvalidArgument",
+		Description:    "Invalid attribute name specified.",
+		HTTPStatusCode: http.StatusBadRequest,
+	},
+	ErrInvalidChecksum: {
+		Code:           "InvalidRequest",
+		Description:    "Invalid checksum provided.",
+		HTTPStatusCode: http.StatusBadRequest,
+	},
+	ErrContentChecksumMismatch: {
+		Code:           "BadDigest",
+		Description:    "The checksum you specified did not match what we received.",
+		HTTPStatusCode: http.StatusBadRequest,
+	},
+	ErrNoSuchInventoryConfiguration: {
+		Code:           "NoSuchConfiguration",
+		Description:    "The specified inventory configuration does not exist",
+		HTTPStatusCode: http.StatusNotFound,
+	},
+	ErrNoSuchAccessPoint: {
+		Code:           "NoSuchAccessPoint",
+		Description:    "The specified access point does not exist",
+		HTTPStatusCode: http.StatusNotFound,
+	},
+	ErrObjectTransformFailed: {
+		Code:           "XMinioObjectTransformFailed",
+		Description:    "The transform endpoint of the access point failed to transform the object",
+		HTTPStatusCode: http.StatusBadGateway,
+	},
+	ErrObjectNotAppendable: {
+		Code:           "XMinioObjectNotAppendable",
+		Description:    "The object cannot be appended to, it is compressed, encrypted, inlined, transitioned, versioned, replicated or under object lock",
+		HTTPStatusCode: http.StatusConflict,
+	},
+	ErrInvalidMaxContentLength: {
+		Code:           "InvalidArgument",
+		Description:    "The x-minio-max-content-length query parameter must be a non-negative integer",
+		HTTPStatusCode: http.StatusBadRequest,
+	},
+	ErrAccessKeyExpired: {
+		Code:           "XMinioAccessKeyExpired",
+		Description:    "The access key ID you provided has expired.",
+		HTTPStatusCode: http.StatusForbidden,
+	},
+	ErrAdminBucketObjectCountQuotaExceeded: {
+		Code:           "XMinioAdminBucketObjectCountQuotaExceeded",
+		Description:    "Bucket object count quota exceeded",
+		HTTPStatusCode: http.StatusBadRequest,
+	},
+	ErrCORSForbidden: {
+		Code:           "AccessForbidden",
+		Description:    "CORSResponse: This CORS request is not allowed. This is usually because the evalution of Origin, request method / Access-Control-Request-Method or Access-Control-Request-Headers are not whitelisted by the resource's CORS spec.",
+		HTTPStatusCode: http.StatusForbidden,
+	},
+	ErrInvalidTargetBucketForLogging: {
+		Code:           "InvalidTargetBucketForLogging",
+		Description:    "The target bucket for logging does not exist.",
+		HTTPStatusCode: http.StatusBadRequest,
+	},
 	// Add your error structure here.
 }
 
@@ -1867,6 +1949,13 @@ func toAPIErrorCode(ctx context.Context, err error) (apiErr APIErrorCode) {
 		return ErrNone
 	}
 
+	// Account requests failing because a backend operation, such as a
+	// drive or lock call, exceeded its deadline, rather than the client
+	// going away.
+	if errors.Is(err, context.DeadlineExceeded) && !contextCanceled(ctx) {
+		setRequestDeadlineExceeded(ctx)
+	}
+
 	// Only return ErrClientDisconnected if the provided context is actually canceled.
 	// This way downstream context.Canceled will still report ErrOperationTimedOut
 	if contextCanceled(ctx) {
@@ -1931,6 +2020,8 @@ func toAPIErrorCode(ctx context.Context, err error) (apiErr APIErrorCode) {
 		apiErr = ErrKMSNotConfigured
 	case errKMSKeyNotFound:
 		apiErr = ErrKMSKeyNotFoundException
+	case hash.ErrInvalidChecksum:
+		apiErr = ErrInvalidChecksum
 
 	case context.Canceled, context.DeadlineExceeded:
 		apiErr = ErrOperationTimedOut
@@ -2026,12 +2117,18 @@ func toAPIErrorCode(ctx context.Context, err error) (apiErr APIErrorCode) {
 		apiErr = ErrSignatureDoesNotMatch
 	case hash.SHA256Mismatch:
 		apiErr = ErrContentSHA256Mismatch
+	case hash.ChecksumMismatch:
+		apiErr = ErrContentChecksumMismatch
 	case ObjectTooLarge:
 		apiErr = ErrEntityTooLarge
 	case ObjectTooSmall:
 		apiErr = ErrEntityTooSmall
 	case NotImplemented:
 		apiErr = ErrNotImplemented
+	case PreConditionFailed:
+		apiErr = ErrPreconditionFailed
+	case ObjectNotAppendable:
+		apiErr = ErrObjectNotAppendable
 	case PartTooBig:
 		apiErr = ErrEntityTooLarge
 	case UnsupportedMetadata:
@@ -2040,6 +2137,12 @@ func toAPIErrorCode(ctx context.Context, err error) (apiErr APIErrorCode) {
 		apiErr = ErrNoSuchBucketPolicy
 	case BucketLifecycleNotFound:
 		apiErr = ErrNoSuchLifecycleConfiguration
+	case BucketInventoryConfigNotFound:
+		apiErr = ErrNoSuchInventoryConfiguration
+	case BucketCorsNotFound:
+		apiErr = ErrNoSuchCORSConfiguration
+	case BucketWebsiteNotFound:
+		apiErr = ErrNoSuchWebsiteConfiguration
 	case BucketSSEConfigNotFound:
 		apiErr = ErrNoSuchBucketSSEConfig
 	case BucketTaggingNotFound:
@@ -2077,6 +2180,8 @@ func toAPIErrorCode(ctx context.Context, err error) (apiErr APIErrorCode) {
 
 	case BucketQuotaExceeded:
 		apiErr = ErrAdminBucketQuotaExceeded
+	case BucketObjectCountQuotaExceeded:
+		apiErr = ErrAdminBucketObjectCountQuotaExceeded
 	case *event.ErrInvalidEventName:
 		apiErr = ErrEventNotification
 	case *event.ErrInvalidARN:
@@ -2211,6 +2316,30 @@ func toAPIError(ctx context.Context, err error) APIError {
 				Description:    e.Error(),
 				HTTPStatusCode: http.StatusBadRequest,
 			}
+		case inventory.Error:
+			apiErr = APIError{
+				Code:           "InvalidArgument",
+				Description:    e.Error(),
+				HTTPStatusCode: http.StatusBadRequest,
+			}
+		case bucketcors.Error:
+			apiErr = APIError{
+				Code:           "MalformedXML",
+				Description:    e.Error(),
+				HTTPStatusCode: http.StatusBadRequest,
+			}
+		case website.Error:
+			apiErr = APIError{
+				Code:           "MalformedXML",
+				Description:    e.Error(),
+				HTTPStatusCode: http.StatusBadRequest,
+			}
+		case logging.Error:
+			apiErr = APIError{
+				Code:           "MalformedXML",
+				Description:    e.Error(),
+				HTTPStatusCode: http.StatusBadRequest,
+			}
 		case replication.Error:
 			apiErr = APIError{
 				Code:           "MalformedXML",
@@ -38,6 +38,35 @@ func mustGetRequestID(t time.Time) string {
 	return fmt.Sprintf("%X", t.UnixNano())
 }
 
+// Maximum length of a request ID provided by a client.
+const maxClientRequestIDLength = 128
+
+// getRequestID returns the ID of the request r, the X-Request-ID
+// header if the client set a valid one or a new ID otherwise.
+func getRequestID(r *http.Request) string {
+	if id := r.Header.Get(xhttp.RequestID); isValidClientRequestID(id) {
+		return id
+	}
+	return mustGetRequestID(UTCNow())
+}
+
+// isValidClientRequestID returns whether id can be used as a request
+// ID, it must be short and only contain characters safe in logs.
+func isValidClientRequestID(id string) bool {
+	if id == "" || len(id) > maxClientRequestIDLength {
+		return false
+	}
+	for _, c := range id {
+		switch {
+		case 'a' <= c && c <= 'z', 'A' <= c && c <= 'Z', '0' <= c && c <= '9':
+		case strings.ContainsRune("-_.:/+=", c):
+		default:
+			return false
+		}
+	}
+	return true
+}
+
 // setEventStreamHeaders to allow proxies to avoid buffering proxy responses
 func setEventStreamHeaders(w http.ResponseWriter) {
 	w.Header().Set(xhttp.ContentType, "text/event-stream")
@@ -18,7 +18,12 @@
 package cmd
 
 import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
 	"testing"
+
+	xhttp "github.com/minio/minio/internal/http"
 )
 
 func TestNewRequestID(t *testing.T) {
@@ -39,3 +44,31 @@ func TestNewRequestID(t *testing.T) {
 		}
 	}
 }
+
+func TestGetRequestID(t *testing.T) {
+	testCases := []struct {
+		header string
+		valid  bool
+	}{
+		{"", false},
+		{"3f2a9c1e-7b4d-4e8f-a1b2-c3d4e5f60718", true},
+		{"client:worker-7/job.42", true},
+		{"has space", false},
+		{"line\nbreak", false},
+		{strings.Repeat("a", maxClientRequestIDLength), true},
+		{strings.Repeat("a", maxClientRequestIDLength+1), false},
+	}
+	for i, testCase := range testCases {
+		r := httptest.NewRequest(http.MethodGet, "/bucket", nil)
+		if testCase.header != "" {
+			r.Header.Set(xhttp.RequestID, testCase.header)
+		}
+		id := getRequestID(r)
+		if testCase.valid && id != testCase.header {
+			t.Errorf("Test %d: expected request ID %q, got %q", i+1, testCase.header, id)
+		}
+		if !testCase.valid && (id == testCase.header || len(id) != 16) {
+			t.Errorf("Test %d: expected a new request ID, got %q", i+1, id)
+		}
+	}
+}
@@ -29,6 +29,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/minio/minio/internal/bucket/inventory"
 	"github.com/minio/minio/internal/crypto"
 	"github.com/minio/minio/internal/handlers"
 	xhttp "github.com/minio/minio/internal/http"
@@ -187,6 +188,47 @@ type ListPartsResponse struct {
 	Parts []Part `xml:"Part"`
 }
 
+// GetObjectAttributesResponse - format for get object attributes response,
+// only the requested attributes are set.
+type GetObjectAttributesResponse struct {
+	XMLName xml.Name `xml:"http://s3.amazonaws.com/doc/2006-03-01/ GetObjectAttributesResponse" json:"-"`
+
+	ETag         string                    `xml:",omitempty"`
+	Checksum     *ObjectAttributesChecksum `xml:",omitempty"`
+	ObjectParts  *ObjectAttributesParts    `xml:",omitempty"`
+	StorageClass string                    `xml:",omitempty"`
+	ObjectSize   *int64                    `xml:",omitempty"`
+}
+
+// ObjectAttributesChecksum - additional checksum of an object or
+// part, only the one of the upload algorithm is set.
+type ObjectAttributesChecksum struct {
+	ChecksumCRC32  string `xml:",omitempty"`
+	ChecksumCRC32C string `xml:",omitempty"`
+	ChecksumSHA1   string `xml:",omitempty"`
+	ChecksumSHA256 string `xml:",omitempty"`
+}
+
+// ObjectAttributesParts - parts of a multipart object in a get object
+// attributes response.
+type ObjectAttributesParts struct {
+	PartsCount           int
+	PartNumberMarker     int
+	NextPartNumberMarker int
+	MaxParts             int
+	IsTruncated          bool
+
+	// List of parts.
+	Parts []ObjectAttributesPart `xml:"Part"`
+}
+
+// ObjectAttributesPart - part of a multipart object.
+type ObjectAttributesPart struct {
+	PartNumber int
+	Size       int64
+	ObjectAttributesChecksum
+}
+
 // ListMultipartUploadsResponse - format for list multipart uploads response.
 type ListMultipartUploadsResponse struct {
 	XMLName xml.Name `xml:"http://s3.amazonaws.com/doc/2006-03-01/ ListMultipartUploadsResult" json:"-"`
@@ -221,6 +263,17 @@ type ListBucketsResponse struct {
 	} // Buckets are nested
 }
 
+// ListBucketInventoryConfigurationsResponse - format for list bucket
+// inventory configurations response.
+type ListBucketInventoryConfigurationsResponse struct {
+	XMLName xml.Name `xml:"http://s3.amazonaws.com/doc/2006-03-01/ ListInventoryConfigurationsResult" json:"-"`
+
+	ContinuationToken       string             `xml:"ContinuationToken,omitempty"`
+	InventoryConfigurations []inventory.Config `xml:"InventoryConfiguration"`
+	IsTruncated             bool
+	NextContinuationToken   string `xml:"NextContinuationToken,omitempty"`
+}
+
 // Upload container for in progress multipart upload
 type Upload struct {
 	Key          string
@@ -402,6 +455,10 @@ func getObjectLocation(r *http.Request, domains []string, bucket, object string)
 		Path:   path.Join(SlashSeparator, bucket, object),
 		Scheme: proto,
 	}
+	if b, ok := globalBucketDomains.bucket(r.Host); ok && b == bucket {
+		u.Path = path.Join(SlashSeparator, object)
+		return u.String()
+	}
 	// If domain is set then we need to use bucket DNS style.
 	for _, domain := range domains {
 		if strings.HasPrefix(r.Host, bucket+"."+domain) {
@@ -757,6 +814,8 @@ const (
 	mimeJSON mimeType = "application/json"
 	// Means response type is XML.
 	mimeXML mimeType = "application/xml"
+	// Means response type is HTML.
+	mimeHTML mimeType = "text/html; charset=utf-8"
 )
 
 // writeSuccessResponseJSON writes success headers and response if any,
@@ -790,9 +849,16 @@ func writeSuccessResponseHeadersOnly(w http.ResponseWriter) {
 func writeErrorResponse(ctx context.Context, w http.ResponseWriter, err APIError, reqURL *url.URL) {
 	switch err.Code {
 	case "SlowDown", "XMinioServerNotInitialized", "XMinioReadQuorum", "XMinioWriteQuorum":
-		// Set retry-after header to indicate user-agents to retry request after 120secs.
+		// Set retry-after header to indicate user-agents to retry request after 120secs,
+		// unless the caller knows better.
 		// https://developer.mozilla.org/en-US/docs/Web/HTTP/Headers/Retry-After
-		w.Header().Set(xhttp.RetryAfter, "120")
+		if w.Header().Get(xhttp.RetryAfter) == "" {
+			if retryAfter := requestRetryAfter(ctx); retryAfter > 0 {
+				setRetryAfter(w, retryAfter)
+			} else {
+				w.Header().Set(xhttp.RetryAfter, "120")
+			}
+		}
 	case "InvalidRegion":
 		err.Description = fmt.Sprintf("Region does not match; expecting '%s'.", globalSite.Region)
 	case "AuthorizationHeaderMalformed":
@@ -805,6 +871,8 @@ func writeErrorResponse(ctx context.Context, w http.ResponseWriter, err APIError
 		err.HTTPStatusCode = http.StatusInternalServerError
 	}
 
+	setRequestErrorCode(ctx, err.Code)
+
 	// Generate error response.
 	errorResponse := getAPIErrorResponse(ctx, err, reqURL.Path,
 		w.Header().Get(xhttp.AmzRequestID), globalDeploymentID)
@@ -122,29 +122,14 @@ var rejectedObjAPIs = []rejectedAPI{
 }
 
 var rejectedBucketAPIs = []rejectedAPI{
-	{
-		api:     "inventory",
-		methods: []string{http.MethodGet, http.MethodPut, http.MethodDelete},
-		queries: []string{"inventory", ""},
-	},
-	{
-		api:     "cors",
-		methods: []string{http.MethodPut, http.MethodDelete},
-		queries: []string{"cors", ""},
-	},
 	{
 		api:     "metrics",
 		methods: []string{http.MethodGet, http.MethodPut, http.MethodDelete},
 		queries: []string{"metrics", ""},
 	},
-	{
-		api:     "website",
-		methods: []string{http.MethodPut},
-		queries: []string{"website", ""},
-	},
 	{
 		api:     "logging",
-		methods: []string{http.MethodPut, http.MethodDelete},
+		methods: []string{http.MethodDelete},
 		queries: []string{"logging", ""},
 	},
 	{
@@ -195,7 +180,33 @@ func registerAPIRouter(router *mux.Router) {
 	// API Router
 	apiRouter := router.PathPrefix(SlashSeparator).Subrouter()
 
+	// Requests sent to the website endpoint of a bucket are served as
+	// a static website, registered first to take precedence over the
+	// virtual-host style requests of overlapping domains.
+	websiteRoute := apiRouter.MatcherFunc(func(r *http.Request, match *mux.RouteMatch) bool {
+		bucket, ok := websiteBucket(getHost(r))
+		if ok {
+			if match.Vars == nil {
+				match.Vars = make(map[string]string)
+			}
+			match.Vars["bucket"] = bucket
+		}
+		return ok
+	})
+
 	var routers []*mux.Router
+	// Requests sent to the custom domain of a bucket, mapped at runtime
+	// by the admin API, are routed like virtual-host style requests.
+	routers = append(routers, apiRouter.MatcherFunc(func(r *http.Request, match *mux.RouteMatch) bool {
+		bucket, ok := globalBucketDomains.bucket(getHost(r))
+		if ok {
+			if match.Vars == nil {
+				match.Vars = make(map[string]string)
+			}
+			match.Vars["bucket"] = bucket
+		}
+		return ok
+	}).Subrouter())
 	for _, domainName := range globalDomainNames {
 		if IsKubernetes() {
 			routers = append(routers, apiRouter.MatcherFunc(func(r *http.Request, match *mux.RouteMatch) bool {
@@ -228,6 +239,17 @@ func registerAPIRouter(router *mux.Router) {
 		logger.Fatal(err, "Unable to initialize server")
 	}
 
+	// gzip compression writes through user space, keep it out
+	// of the GetObject path when objects are sent with sendfile.
+	getObjectGz := gz
+	if globalFSZeroCopy {
+		getObjectGz = func(h http.Handler) http.HandlerFunc {
+			return h.ServeHTTP
+		}
+	}
+
+	websiteRoute.HandlerFunc(collectAPIStats("website", maxClients(gz(httpTraceHdrs(api.WebsiteHandler)))))
+
 	for _, router := range routers {
 		// Register all rejected object APIs
 		for _, r := range rejectedObjAPIs {
@@ -238,7 +260,8 @@ func registerAPIRouter(router *mux.Router) {
 		}
 
 		// Object operations
-		// HeadObject
+		// HeadObject - stats are kept apart from "getobject" since
+		// metadata probes are much cheaper than full reads.
 		router.Methods(http.MethodHead).Path("/{object:.+}").HandlerFunc(
 			collectAPIStats("headobject", maxClients(gz(httpTraceAll(api.HeadObjectHandler)))))
 		// CopyObjectPart
@@ -285,9 +308,12 @@ func registerAPIRouter(router *mux.Router) {
 		// GetObjectLegalHold
 		router.Methods(http.MethodGet).Path("/{object:.+}").HandlerFunc(
 			collectAPIStats("getobjectlegalhold", maxClients(gz(httpTraceAll(api.GetObjectLegalHoldHandler))))).Queries("legal-hold", "")
+		// GetObjectAttributes
+		router.Methods(http.MethodGet).Path("/{object:.+}").HandlerFunc(
+			collectAPIStats("getobjectattributes", maxClients(gz(httpTraceHdrs(api.GetObjectAttributesHandler))))).Queries("attributes", "")
 		// GetObject - note gzip compression is *not* added due to Range requests.
 		router.Methods(http.MethodGet).Path("/{object:.+}").HandlerFunc(
-			collectAPIStats("getobject", maxClients(gz(httpTraceHdrs(api.GetObjectHandler)))))
+			collectAPIStats("getobject", maxClients(getObjectGz(httpTraceHdrs(api.GetObjectHandler)))))
 		// CopyObject
 		router.Methods(http.MethodPut).Path("/{object:.+}").HeadersRegexp(xhttp.AmzCopySource, ".*?(\\/|%2F).*?").HandlerFunc(
 			collectAPIStats("copyobject", maxClients(gz(httpTraceAll(api.CopyObjectHandler)))))
@@ -322,6 +348,15 @@ func registerAPIRouter(router *mux.Router) {
 		// GetBucketPolicy
 		router.Methods(http.MethodGet).HandlerFunc(
 			collectAPIStats("getbucketpolicy", maxClients(gz(httpTraceAll(api.GetBucketPolicyHandler))))).Queries("policy", "")
+		// GetBucketCors
+		router.Methods(http.MethodGet).HandlerFunc(
+			collectAPIStats("getbucketcors", maxClients(gz(httpTraceAll(api.GetBucketCorsHandler))))).Queries("cors", "")
+		// GetBucketWebsite
+		router.Methods(http.MethodGet).HandlerFunc(
+			collectAPIStats("getbucketwebsite", maxClients(gz(httpTraceAll(api.GetBucketWebsiteHandler))))).Queries("website", "")
+		// GetBucketLogging
+		router.Methods(http.MethodGet).HandlerFunc(
+			collectAPIStats("getbucketlogging", maxClients(gz(httpTraceAll(api.GetBucketLoggingHandler))))).Queries("logging", "")
 		// GetBucketLifecycle
 		router.Methods(http.MethodGet).HandlerFunc(
 			collectAPIStats("getbucketlifecycle", maxClients(gz(httpTraceAll(api.GetBucketLifecycleHandler))))).Queries("lifecycle", "")
@@ -346,6 +381,9 @@ func registerAPIRouter(router *mux.Router) {
 		// ResetBucketReplicationStatus - MinIO extension API
 		router.Methods(http.MethodGet).HandlerFunc(
 			collectAPIStats("resetbucketreplicationstatus", maxClients(gz(httpTraceAll(api.ResetBucketReplicationStatusHandler))))).Queries("replication-reset-status", "")
+		// GetBucketObjectLockBulk - MinIO extension API
+		router.Methods(http.MethodGet).HandlerFunc(
+			collectAPIStats("getbucketobjectlockbulk", maxClients(gz(httpTraceAll(api.GetBucketObjectLockBulkHandler))))).Queries("object-lock-bulk", "", "jobId", "{jobId:.*}")
 
 		// Dummy Bucket Calls
 		// GetBucketACL -- this is a dummy call.
@@ -354,21 +392,12 @@ func registerAPIRouter(router *mux.Router) {
 		// PutBucketACL -- this is a dummy call.
 		router.Methods(http.MethodPut).HandlerFunc(
 			collectAPIStats("putbucketacl", maxClients(gz(httpTraceAll(api.PutBucketACLHandler))))).Queries("acl", "")
-		// GetBucketCors - this is a dummy call.
-		router.Methods(http.MethodGet).HandlerFunc(
-			collectAPIStats("getbucketcors", maxClients(gz(httpTraceAll(api.GetBucketCorsHandler))))).Queries("cors", "")
-		// GetBucketWebsiteHandler - this is a dummy call.
-		router.Methods(http.MethodGet).HandlerFunc(
-			collectAPIStats("getbucketwebsite", maxClients(gz(httpTraceAll(api.GetBucketWebsiteHandler))))).Queries("website", "")
 		// GetBucketAccelerateHandler - this is a dummy call.
 		router.Methods(http.MethodGet).HandlerFunc(
 			collectAPIStats("getbucketaccelerate", maxClients(gz(httpTraceAll(api.GetBucketAccelerateHandler))))).Queries("accelerate", "")
 		// GetBucketRequestPaymentHandler - this is a dummy call.
 		router.Methods(http.MethodGet).HandlerFunc(
 			collectAPIStats("getbucketrequestpayment", maxClients(gz(httpTraceAll(api.GetBucketRequestPaymentHandler))))).Queries("requestPayment", "")
-		// GetBucketLoggingHandler - this is a dummy call.
-		router.Methods(http.MethodGet).HandlerFunc(
-			collectAPIStats("getbucketlogging", maxClients(gz(httpTraceAll(api.GetBucketLoggingHandler))))).Queries("logging", "")
 		// GetBucketTaggingHandler
 		router.Methods(http.MethodGet).HandlerFunc(
 			collectAPIStats("getbuckettagging", maxClients(gz(httpTraceAll(api.GetBucketTaggingHandler))))).Queries("tagging", "")
@@ -391,6 +420,12 @@ func registerAPIRouter(router *mux.Router) {
 		// ListObjectVersions
 		router.Methods(http.MethodGet).HandlerFunc(
 			collectAPIStats("listobjectversions", maxClients(gz(httpTraceAll(api.ListObjectVersionsHandler))))).Queries("versions", "")
+		// GetBucketInventoryConfiguration
+		router.Methods(http.MethodGet).HandlerFunc(
+			collectAPIStats("getbucketinventoryconfiguration", maxClients(gz(httpTraceAll(api.GetBucketInventoryConfigurationHandler))))).Queries("inventory", "", "id", "{id:.*}")
+		// ListBucketInventoryConfigurations
+		router.Methods(http.MethodGet).HandlerFunc(
+			collectAPIStats("listbucketinventoryconfigurations", maxClients(gz(httpTraceAll(api.ListBucketInventoryConfigurationsHandler))))).Queries("inventory", "")
 		// GetBucketPolicyStatus
 		router.Methods(http.MethodGet).HandlerFunc(
 			collectAPIStats("getpolicystatus", maxClients(gz(httpTraceAll(api.GetBucketPolicyStatusHandler))))).Queries("policyStatus", "")
@@ -404,10 +439,24 @@ func registerAPIRouter(router *mux.Router) {
 		router.Methods(http.MethodPut).HandlerFunc(
 			collectAPIStats("putbucketencryption", maxClients(gz(httpTraceAll(api.PutBucketEncryptionHandler))))).Queries("encryption", "")
 
+		// PutBucketInventoryConfiguration
+		router.Methods(http.MethodPut).HandlerFunc(
+			collectAPIStats("putbucketinventoryconfiguration", maxClients(gz(httpTraceAll(api.PutBucketInventoryConfigurationHandler))))).Queries("inventory", "", "id", "{id:.*}")
+
 		// PutBucketPolicy
 		router.Methods(http.MethodPut).HandlerFunc(
 			collectAPIStats("putbucketpolicy", maxClients(gz(httpTraceAll(api.PutBucketPolicyHandler))))).Queries("policy", "")
 
+		// PutBucketCors
+		router.Methods(http.MethodPut).HandlerFunc(
+			collectAPIStats("putbucketcors", maxClients(gz(httpTraceAll(api.PutBucketCorsHandler))))).Queries("cors", "")
+		// PutBucketWebsite
+		router.Methods(http.MethodPut).HandlerFunc(
+			collectAPIStats("putbucketwebsite", maxClients(gz(httpTraceAll(api.PutBucketWebsiteHandler))))).Queries("website", "")
+		// PutBucketLogging
+		router.Methods(http.MethodPut).HandlerFunc(
+			collectAPIStats("putbucketlogging", maxClients(gz(httpTraceAll(api.PutBucketLoggingHandler))))).Queries("logging", "")
+
 		// PutBucketObjectLockConfig
 		router.Methods(http.MethodPut).HandlerFunc(
 			collectAPIStats("putbucketobjectlockconfig", maxClients(gz(httpTraceAll(api.PutBucketObjectLockConfigHandler))))).Queries("object-lock", "")
@@ -436,9 +485,15 @@ func registerAPIRouter(router *mux.Router) {
 		// DeleteMultipleObjects
 		router.Methods(http.MethodPost).HandlerFunc(
 			collectAPIStats("deletemultipleobjects", maxClients(gz(httpTraceAll(api.DeleteMultipleObjectsHandler))))).Queries("delete", "")
+		// PutBucketObjectLockBulk - MinIO extension API
+		router.Methods(http.MethodPost).HandlerFunc(
+			collectAPIStats("putbucketobjectlockbulk", maxClients(gz(httpTraceAll(api.PutBucketObjectLockBulkHandler))))).Queries("object-lock-bulk", "")
 		// DeleteBucketPolicy
 		router.Methods(http.MethodDelete).HandlerFunc(
 			collectAPIStats("deletebucketpolicy", maxClients(gz(httpTraceAll(api.DeleteBucketPolicyHandler))))).Queries("policy", "")
+		// DeleteBucketCors
+		router.Methods(http.MethodDelete).HandlerFunc(
+			collectAPIStats("deletebucketcors", maxClients(gz(httpTraceAll(api.DeleteBucketCorsHandler))))).Queries("cors", "")
 		// DeleteBucketReplication
 		router.Methods(http.MethodDelete).HandlerFunc(
 			collectAPIStats("deletebucketreplicationconfiguration", maxClients(gz(httpTraceAll(api.DeleteBucketReplicationConfigHandler))))).Queries("replication", "")
@@ -448,6 +503,9 @@ func registerAPIRouter(router *mux.Router) {
 		// DeleteBucketEncryption
 		router.Methods(http.MethodDelete).HandlerFunc(
 			collectAPIStats("deletebucketencryption", maxClients(gz(httpTraceAll(api.DeleteBucketEncryptionHandler))))).Queries("encryption", "")
+		// DeleteBucketInventoryConfiguration
+		router.Methods(http.MethodDelete).HandlerFunc(
+			collectAPIStats("deletebucketinventoryconfiguration", maxClients(gz(httpTraceAll(api.DeleteBucketInventoryConfigurationHandler))))).Queries("inventory", "", "id", "{id:.*}")
 		// DeleteBucket
 		router.Methods(http.MethodDelete).HandlerFunc(
 			collectAPIStats("deletebucket", maxClients(gz(httpTraceAll(api.DeleteBucketHandler)))))
@@ -514,7 +572,7 @@ func corsHandler(handler http.Handler) http.Handler {
 		"*",
 	}
 
-	return cors.New(cors.Options{
+	globalCors := cors.New(cors.Options{
 		AllowOriginFunc: func(origin string) bool {
 			for _, allowedOrigin := range globalAPIConfig.getCorsAllowOrigins() {
 				if wildcard.MatchSimple(allowedOrigin, origin) {
@@ -536,4 +594,16 @@ func corsHandler(handler http.Handler) http.Handler {
 		ExposedHeaders:   commonS3Headers,
 		AllowCredentials: true,
 	}).Handler(handler)
+
+	// Cross-origin requests to buckets with a CORS configuration are
+	// evaluated against its rules instead of the global settings.
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get(xhttp.Origin) != "" {
+			if config := getRequestBucketCors(r); config != nil {
+				serveBucketCors(w, r, config, handler)
+				return
+			}
+		}
+		globalCors.ServeHTTP(w, r)
+	})
 }
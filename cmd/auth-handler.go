@@ -30,16 +30,17 @@ import (
 	"net/url"
 	"strconv"
 	"strings"
-	"sync/atomic"
 	"time"
 
 	"github.com/minio/minio/internal/auth"
 	objectlock "github.com/minio/minio/internal/bucket/object/lock"
 	"github.com/minio/minio/internal/etag"
+	"github.com/minio/minio/internal/handlers"
 	"github.com/minio/minio/internal/hash"
 	xhttp "github.com/minio/minio/internal/http"
 	xjwt "github.com/minio/minio/internal/jwt"
 	"github.com/minio/minio/internal/logger"
+	"github.com/minio/minio/internal/tracing"
 	"github.com/minio/pkg/bucket/policy"
 	iampolicy "github.com/minio/pkg/iam/policy"
 )
@@ -154,6 +155,7 @@ func validateAdminSignature(ctx context.Context, r *http.Request, region string)
 		logger.LogIf(ctx, errors.New(getAPIError(s3Err).Description), logger.Application)
 		return cred, nil, owner, s3Err
 	}
+	globalAccessKeyUsage.record(cred, handlers.GetSourceIP(r))
 
 	return cred, cred.Claims, owner, ErrNone
 }
@@ -304,6 +306,17 @@ func checkRequestAuthType(ctx context.Context, r *http.Request, action policy.Ac
 // returns APIErrorCode if any to be replied to the client.
 // Additionally returns the accessKey used in the request, and if this request is by an admin.
 func checkRequestAuthTypeCredential(ctx context.Context, r *http.Request, action policy.Action, bucketName, objectName string) (cred auth.Credentials, owner bool, s3Err APIErrorCode) {
+	if _, span := tracing.Start(ctx, "auth", tracing.KindInternal); span != nil {
+		span.SetAttributes("auth.action", string(action))
+		defer func() {
+			var err error
+			if s3Err != ErrNone {
+				err = errors.New(errorCodes.ToAPIErr(s3Err).Code)
+			}
+			span.Finish(err)
+		}()
+	}
+
 	switch getRequestAuthType(r) {
 	case authTypeUnknown, authTypeStreamingSigned:
 		return cred, owner, ErrSignatureVersionNotSupported
@@ -351,6 +364,10 @@ func checkRequestAuthTypeCredential(ctx context.Context, r *http.Request, action
 	}
 	if cred.AccessKey != "" {
 		logger.GetReqInfo(ctx).AccessKey = cred.AccessKey
+		setRequestAuthenticated(ctx, cred)
+		if s3Err = limitAuthenticatedRequest(ctx, cred.AccessKey); s3Err != ErrNone {
+			return cred, owner, s3Err
+		}
 	}
 
 	if action != policy.ListAllMyBucketsAction && cred.AccessKey == "" {
@@ -509,7 +526,7 @@ func setAuthHandler(h http.Handler) http.Handler {
 				// header, for all requests where Date header is not
 				// present we will reject such clients.
 				writeErrorResponse(r.Context(), w, errorCodes.ToAPIErr(errCode), r.URL)
-				atomic.AddUint64(&globalHTTPStats.rejectedRequestsTime, 1)
+				globalHTTPStats.rejectRequest(r, rejectedTime, errorCodes.ToAPIErr(errCode).Code)
 				return
 			}
 			// Verify if the request date header is shifted by less than globalMaxSkewTime parameter in the past
@@ -517,7 +534,7 @@ func setAuthHandler(h http.Handler) http.Handler {
 			curTime := UTCNow()
 			if curTime.Sub(amzDate) > globalMaxSkewTime || amzDate.Sub(curTime) > globalMaxSkewTime {
 				writeErrorResponse(r.Context(), w, errorCodes.ToAPIErr(ErrRequestTimeTooSkewed), r.URL)
-				atomic.AddUint64(&globalHTTPStats.rejectedRequestsTime, 1)
+				globalHTTPStats.rejectRequest(r, rejectedTime, errorCodes.ToAPIErr(ErrRequestTimeTooSkewed).Code)
 				return
 			}
 		}
@@ -526,7 +543,7 @@ func setAuthHandler(h http.Handler) http.Handler {
 			return
 		}
 		writeErrorResponse(r.Context(), w, errorCodes.ToAPIErr(ErrSignatureVersionNotSupported), r.URL)
-		atomic.AddUint64(&globalHTTPStats.rejectedRequestsAuth, 1)
+		globalHTTPStats.rejectRequest(r, rejectedAuth, errorCodes.ToAPIErr(ErrSignatureVersionNotSupported).Code)
 	})
 }
 
@@ -619,6 +636,10 @@ func isPutActionAllowed(ctx context.Context, atype authType, bucketName, objectN
 
 	if cred.AccessKey != "" {
 		logger.GetReqInfo(ctx).AccessKey = cred.AccessKey
+		setRequestAuthenticated(ctx, cred)
+		if s3Err = limitAuthenticatedRequest(ctx, cred.AccessKey); s3Err != ErrNone {
+			return s3Err
+		}
 	}
 
 	// Do not check for PutObjectRetentionAction permission,
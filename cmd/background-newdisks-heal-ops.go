@@ -299,6 +299,32 @@ func getLocalDisksToHeal() (disksToHeal Endpoints) {
 	return disksToHeal
 }
 
+// healReplacedLocalDisk queues a local drive found unformatted while the
+// server is running, i.e. a failed drive swapped for a new one, to be
+// formatted and healed by monitorLocalDisksAndHeal when the heal
+// 'drive_hotswap' setting is enabled.
+func healReplacedLocalDisk(endpoint Endpoint) {
+	if !globalHealConfig.GetDriveHotswap() {
+		logger.LogOnceIf(GlobalContext, fmt.Errorf("Drive %s was replaced by an unformatted drive, restart the server or enable 'mc admin config set alias/ heal drive_hotswap=on' to format and heal it", endpoint),
+			"drive-hotswap-"+endpoint.String())
+		return
+	}
+	if globalBackgroundHealState.isHealLocalDisk(endpoint) {
+		return
+	}
+
+	logger.Info(fmt.Sprintf("Replaced drive %s detected, proceeding to format and heal it", endpoint))
+
+	reqInfo := &logger.ReqInfo{}
+	reqInfo.SetTags("endpoint", endpoint.String())
+	auditLogInternal(logger.SetReqInfo(GlobalContext, reqInfo), "", "", AuditLogOptions{
+		Trigger: "drive-hotswap",
+		APIName: "FormatReplacedDrive",
+	})
+
+	globalBackgroundHealState.pushHealLocalDisks(endpoint)
+}
+
 // monitorLocalDisksAndHeal - ensures that detected new disks are healed
 //  1. Only the concerned erasure set will be listed and healed
 //  2. Only the node hosting the disk is responsible to perform the heal
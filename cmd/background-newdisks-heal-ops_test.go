@@ -0,0 +1,53 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+package cmd
+
+import "testing"
+
+func TestHealReplacedLocalDisk(t *testing.T) {
+	defer globalHealConfig.Update(globalHealConfig)
+
+	savedHealState := globalBackgroundHealState
+	defer func() { globalBackgroundHealState = savedHealState }()
+	globalBackgroundHealState = newHealState(false)
+
+	endpoint, err := NewEndpoint("/tmp/drive1")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cfg := globalHealConfig
+	cfg.DriveHotswap = false
+	globalHealConfig.Update(cfg)
+
+	healReplacedLocalDisk(endpoint)
+	if globalBackgroundHealState.isHealLocalDisk(endpoint) {
+		t.Fatal("replaced drive must not be queued when drive_hotswap is off")
+	}
+
+	cfg.DriveHotswap = true
+	globalHealConfig.Update(cfg)
+
+	healReplacedLocalDisk(endpoint)
+	healReplacedLocalDisk(endpoint)
+	if !globalBackgroundHealState.isHealLocalDisk(endpoint) {
+		t.Fatal("replaced drive must be queued when drive_hotswap is on")
+	}
+	if n := globalBackgroundHealState.healDriveCount(); n != 1 {
+		t.Fatalf("expected 1 drive to heal, got %d", n)
+	}
+}
@@ -0,0 +1,261 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+package cmd
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/csv"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"path"
+	"strings"
+	"time"
+
+	objectlock "github.com/minio/minio/internal/bucket/object/lock"
+)
+
+// Result message of the versions retained in compliance mode, followed
+// by their retain until date. The next audit reads it from the report.
+const complianceAuditRetained = "COMPLIANCE until "
+
+// complianceViolation fails the audit of an object version.
+type complianceViolation string
+
+func (v complianceViolation) Error() string {
+	return string(v)
+}
+
+// validate checks that the bucket of the audit defaults to retention in
+// compliance mode, and that the previous audit, if set, audited it too.
+func (a *BatchJobComplianceAudit) validate(ctx context.Context, objAPI ObjectLayer, report BatchJobReport) error {
+	if err := checkBatchJobBucket(ctx, objAPI, a.Bucket); err != nil {
+		return err
+	}
+	if rcfg, _ := globalBucketObjectLockSys.Get(a.Bucket); !rcfg.LockEnabled || rcfg.Mode != objectlock.RetCompliance {
+		return batchJobRequestError("the default retention of bucket %s is not in compliance mode", a.Bucket)
+	}
+	if report.Scope != BatchJobReportAllTasks {
+		return batchJobRequestError("the report of a compliance audit must list all tasks")
+	}
+	if a.PreviousJob != "" {
+		previous, err := loadBatchJob(ctx, objAPI, a.PreviousJob)
+		if err != nil {
+			return err
+		}
+		if !a.follows(previous) {
+			return batchJobRequestError("job %s is not a complete compliance audit of the same bucket and prefix", a.PreviousJob)
+		}
+	}
+	return nil
+}
+
+// follows returns true if job is a complete audit of the same
+// bucket and prefix, which can be compared with a.
+func (a *BatchJobComplianceAudit) follows(job *BatchJob) bool {
+	prev := job.Request.Operation.ComplianceAudit
+	return job.Status == BatchJobComplete && prev != nil && prev.Bucket == a.Bucket && prev.Prefix == a.Prefix
+}
+
+// findPreviousJob returns the ID of the latest complete
+// audit of the same bucket and prefix, if any.
+func (a *BatchJobComplianceAudit) findPreviousJob(ctx context.Context, objAPI ObjectLayer) (string, error) {
+	jobs, err := listBatchJobs(ctx, objAPI)
+	if err != nil {
+		return "", err
+	}
+	var id string
+	for i := range jobs {
+		if a.follows(&jobs[i]) {
+			id = jobs[i].ID
+		}
+	}
+	return id, nil
+}
+
+// readComplianceAuditTasks calls fn with each version of the audited
+// bucket, then with each version retained beyond the start of the job
+// according to the report of the previous audit.
+func (j *BatchJob) readComplianceAuditTasks(ctx context.Context, objAPI ObjectLayer, fn func(batchJobTask, BatchJobCheckpoint) error) error {
+	a := j.Request.Operation.ComplianceAudit
+	if j.Checkpoint.File == 0 {
+		err := walkBatchJobBucket(ctx, objAPI, a.Bucket, a.Prefix, j.Checkpoint, func(oi ObjectInfo) bool {
+			return !oi.DeleteMarker
+		}, fn)
+		if err != nil {
+			return err
+		}
+	}
+	if a.PreviousJob == "" {
+		return nil
+	}
+
+	previous, err := loadBatchJob(ctx, objAPI, a.PreviousJob)
+	if err != nil {
+		return err
+	}
+	for i, file := range previous.ReportFiles {
+		if i+1 < j.Checkpoint.File || file.TaskExecutionStatus != "succeeded" {
+			continue
+		}
+		skip := int64(0)
+		if i+1 == j.Checkpoint.File {
+			skip = j.Checkpoint.Row
+		}
+		err = readComplianceAuditFile(ctx, objAPI, file, skip, func(t batchJobTask, row int64) error {
+			if !t.RetainUntil.After(j.Created) {
+				return nil
+			}
+			return fn(t, BatchJobCheckpoint{File: i + 1, Row: row})
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// readComplianceAuditFile calls fn with the versions retained in compliance
+// mode listed by a results file of an audit after the first skip rows. The
+// file is read up to its end to verify its checksum.
+func readComplianceAuditFile(ctx context.Context, objAPI ObjectLayer, file BatchJobReportFile, skip int64, fn func(batchJobTask, int64) error) error {
+	gr, err := objAPI.GetObjectNInfo(ctx, file.Bucket, file.Key, nil, http.Header{}, readLock, ObjectOptions{})
+	if err != nil {
+		return err
+	}
+	defer gr.Close()
+
+	sum := sha256.New()
+	cr := csv.NewReader(io.TeeReader(gr, sum))
+	cr.FieldsPerRecord = -1
+	cr.ReuseRecord = true
+	for row := int64(1); ; row++ {
+		record, err := cr.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return fmt.Errorf("invalid compliance audit report %s: %w", file.Key, err)
+		}
+		if row <= skip || len(record) < 7 || !strings.HasPrefix(record[6], complianceAuditRetained) {
+			continue
+		}
+		var t batchJobTask
+		t.Bucket, t.VersionID = record[0], record[2]
+		if t.Object, err = url.QueryUnescape(record[1]); err != nil {
+			return fmt.Errorf("invalid compliance audit report %s: row %d: %w", file.Key, row, err)
+		}
+		if t.RetainUntil, err = time.Parse(time.RFC3339, strings.TrimPrefix(record[6], complianceAuditRetained)); err != nil {
+			return fmt.Errorf("invalid compliance audit report %s: row %d: %w", file.Key, row, err)
+		}
+		if err = fn(t, row); err != nil {
+			return err
+		}
+	}
+	if file.SHA256Checksum != "" && hex.EncodeToString(sum.Sum(nil)) != file.SHA256Checksum {
+		return fmt.Errorf("compliance audit report %s was modified", file.Key)
+	}
+	return nil
+}
+
+// auditBatchJobVersion checks that the object version of t is retained in
+// compliance mode, or for a version of the previous audit retained beyond
+// the start of the job, that it still exists.
+func auditBatchJobVersion(ctx context.Context, objAPI ObjectLayer, t *batchJobTask) error {
+	oi, err := objAPI.GetObjectInfo(ctx, t.Bucket, t.Object, ObjectOptions{VersionID: t.VersionID})
+	if !t.RetainUntil.IsZero() {
+		if isErrObjectNotFound(err) || isErrVersionNotFound(err) {
+			return complianceViolation(fmt.Sprintf("Deleted before its retention expired on %s", t.RetainUntil.Format(time.RFC3339)))
+		}
+		return err
+	}
+	if err != nil {
+		return err
+	}
+
+	ret := objectlock.GetObjectRetentionMeta(oi.UserDefined)
+	switch {
+	case ret.Mode == "":
+		return complianceViolation("No retention")
+	case ret.Mode != objectlock.RetCompliance:
+		return complianceViolation(fmt.Sprintf("Retention mode %s instead of %s", ret.Mode, objectlock.RetCompliance))
+	case ret.RetainUntilDate.IsZero():
+		return complianceViolation("Invalid retain until date")
+	}
+	t.Message = complianceAuditRetained + ret.RetainUntilDate.UTC().Format(time.RFC3339)
+	return nil
+}
+
+// complianceAuditReport summarizes a complete audit, it is signed with
+// the compliance audit key of the cluster and lists the checksums of
+// the results files.
+type complianceAuditReport struct {
+	JobID       string               `json:"jobId"`
+	Bucket      string               `json:"bucket"`
+	Prefix      string               `json:"prefix,omitempty"`
+	PreviousJob string               `json:"previousJob,omitempty"`
+	Started     time.Time            `json:"started"`
+	Completed   time.Time            `json:"completed"`
+	Progress    BatchJobProgress     `json:"progress"`
+	Results     []BatchJobReportFile `json:"results"`
+	PublicKey   string               `json:"publicKey"`
+}
+
+// writeComplianceAuditReport writes the audit.json summary of a
+// complete audit next to its manifest, with its signature encoded
+// in base64 in audit.json.sig.
+func (j *BatchJob) writeComplianceAuditReport(ctx context.Context, objAPI ObjectLayer) error {
+	a := j.Request.Operation.ComplianceAudit
+	key := complianceAuditKey()
+	report := complianceAuditReport{
+		JobID:       j.ID,
+		Bucket:      a.Bucket,
+		Prefix:      a.Prefix,
+		PreviousJob: a.PreviousJob,
+		Started:     j.Created,
+		Completed:   UTCNow(),
+		Progress:    j.Progress,
+		Results:     append([]BatchJobReportFile{}, j.ReportFiles...),
+		PublicKey:   base64.StdEncoding.EncodeToString(key.Public().(ed25519.PublicKey)),
+	}
+	data, err := json.Marshal(report)
+	if err != nil {
+		return err
+	}
+	if _, err = putBatchJobReport(ctx, objAPI, j.Request.Report.Bucket, path.Join(j.reportDir(), "audit.json"), data, "application/json"); err != nil {
+		return err
+	}
+	sig := base64.StdEncoding.EncodeToString(ed25519.Sign(key, data))
+	_, err = putBatchJobReport(ctx, objAPI, j.Request.Report.Bucket, path.Join(j.reportDir(), "audit.json.sig"), []byte(sig), "text/plain")
+	return err
+}
+
+// complianceAuditKey returns the key signing the compliance audit
+// reports, derived from the secret key of the root credentials so
+// that all the nodes sign with the same key.
+func complianceAuditKey() ed25519.PrivateKey {
+	mac := hmac.New(sha256.New, []byte(globalActiveCred.SecretKey))
+	mac.Write([]byte("compliance-audit-report"))
+	return ed25519.NewKeyFromSeed(mac.Sum(nil))
+}
@@ -0,0 +1,159 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+package cmd
+
+import (
+	"context"
+	"sync/atomic"
+	"time"
+
+	"github.com/minio/minio/internal/event"
+	"golang.org/x/time/rate"
+)
+
+// globalBatchPurgeStats counts the versions deleted by the
+// purge jobs run by this node, dry runs excluded.
+var globalBatchPurgeStats batchPurgeStats
+
+type batchPurgeStats struct {
+	versions      uint64
+	bytes         uint64
+	deleteMarkers uint64
+}
+
+// walkBatchJobPurge calls fn with each version of the bucket of p to
+// delete after the object of the checkpoint from. The versions of an
+// object are selected together, the position of a task is the
+// previous object like for walkBatchJobBucket.
+func walkBatchJobPurge(ctx context.Context, objAPI ObjectLayer, p *BatchJobPurgeVersions, from BatchJobCheckpoint, fn func(batchJobTask, BatchJobCheckpoint) error) error {
+	if p.MaxDeletesPerSecond > 0 && p.limiter == nil {
+		p.limiter = rate.NewLimiter(rate.Limit(p.MaxDeletesPerSecond), 1)
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	objInfoCh := make(chan ObjectInfo)
+	err := objAPI.Walk(ctx, p.Bucket, p.Prefix, objInfoCh, ObjectOptions{WalkAscending: true})
+	if err != nil {
+		return err
+	}
+	var versions []ObjectInfo
+	done := from.Object
+	flush := func() error {
+		for _, oi := range p.purgeable(ctx, versions, UTCNow()) {
+			t := batchJobTask{Bucket: oi.Bucket, Object: oi.Name, VersionID: oi.VersionID, Size: oi.Size, DeleteMarker: oi.DeleteMarker}
+			if err := fn(t, BatchJobCheckpoint{Object: done}); err != nil {
+				return err
+			}
+		}
+		if len(versions) > 0 {
+			done = versions[0].Name
+		}
+		versions = versions[:0]
+		return nil
+	}
+	for oi := range objInfoCh {
+		if err != nil || oi.Name <= from.Object {
+			// Drain the walk after an error.
+			continue
+		}
+		if len(versions) > 0 && versions[0].Name != oi.Name {
+			if err = flush(); err != nil {
+				cancel()
+				continue
+			}
+		}
+		versions = append(versions, oi)
+	}
+	if err == nil {
+		err = flush()
+	}
+	if err != nil {
+		return err
+	}
+	return ctx.Err()
+}
+
+// purgeable returns the versions of an object to delete. Delete markers
+// are only deleted when they are the only version left, so that deleting
+// them never makes a noncurrent version current again. The delete markers
+// left alone by a job are deleted by the next one.
+func (p *BatchJobPurgeVersions) purgeable(ctx context.Context, versions []ObjectInfo, now time.Time) []ObjectInfo {
+	if len(versions) == 1 {
+		if oi := versions[0]; oi.IsLatest && oi.DeleteMarker && p.DeleteMarkers {
+			return versions
+		}
+		return nil
+	}
+
+	var purge []ObjectInfo
+	for _, oi := range versions {
+		if oi.IsLatest || now.Sub(oi.SuccessorModTime) <= time.Duration(p.NoncurrentDays)*24*time.Hour {
+			continue
+		}
+		if !oi.DeleteMarker && enforceRetentionForDeletion(ctx, oi) {
+			continue
+		}
+		purge = append(purge, oi)
+	}
+	return purge
+}
+
+// batchJobPurgeVersion deletes an object version, unless it has been
+// placed under retention or legal hold since it was listed.
+func batchJobPurgeVersion(ctx context.Context, objAPI ObjectLayer, t batchJobTask, p *BatchJobPurgeVersions) error {
+	versionID := t.VersionID
+	if versionID == "" {
+		versionID = nullVersionID
+	}
+	if !t.DeleteMarker {
+		oi, err := objAPI.GetObjectInfo(ctx, t.Bucket, t.Object, ObjectOptions{VersionID: versionID})
+		if err != nil {
+			return err
+		}
+		if enforceRetentionForDeletion(ctx, oi) {
+			return ObjectLocked{Bucket: t.Bucket, Object: t.Object, VersionID: t.VersionID}
+		}
+	}
+	if p.DryRun {
+		return nil
+	}
+	if p.limiter != nil {
+		if err := p.limiter.Wait(ctx); err != nil {
+			return err
+		}
+	}
+
+	objInfo, err := objAPI.DeleteObject(ctx, t.Bucket, t.Object, ObjectOptions{VersionID: versionID})
+	if err != nil {
+		return err
+	}
+	if t.DeleteMarker {
+		atomic.AddUint64(&globalBatchPurgeStats.deleteMarkers, 1)
+	} else {
+		atomic.AddUint64(&globalBatchPurgeStats.versions, 1)
+		atomic.AddUint64(&globalBatchPurgeStats.bytes, uint64(t.Size))
+	}
+	sendEvent(eventArgs{
+		EventName:  event.ObjectRemovedDelete,
+		BucketName: t.Bucket,
+		Object:     objInfo,
+		Host:       "Internal: [Batch]",
+	})
+	return nil
+}
@@ -0,0 +1,1561 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"crypto/md5"
+	"crypto/sha256"
+	"encoding/csv"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"math/rand"
+	"net/http"
+	"net/url"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/klauspost/compress/gzip"
+	"github.com/minio/kes"
+	"github.com/minio/minio-go/v7/pkg/tags"
+	sse "github.com/minio/minio/internal/bucket/encryption"
+	"github.com/minio/minio/internal/bucket/lifecycle"
+	objectlock "github.com/minio/minio/internal/bucket/object/lock"
+	"github.com/minio/minio/internal/bucket/replication"
+	"github.com/minio/minio/internal/config/storageclass"
+	"github.com/minio/minio/internal/crypto"
+	"github.com/minio/minio/internal/etag"
+	"github.com/minio/minio/internal/event"
+	"github.com/minio/minio/internal/hash"
+	xhttp "github.com/minio/minio/internal/http"
+	"github.com/minio/minio/internal/kms"
+	"github.com/minio/minio/internal/logger"
+	"golang.org/x/time/rate"
+)
+
+const (
+	batchJobsPrefix = minioConfigPrefix + "/batch-jobs"
+
+	// batchJobPollInterval is the time between two checks for
+	// active jobs, jobs started on the node running them are
+	// picked up immediately.
+	batchJobPollInterval = time.Minute
+
+	// Number of tasks run between two checkpoints of a job.
+	batchJobCheckpointTasks = 1000
+
+	// Number of tasks of a job run concurrently.
+	batchJobWorkers = 16
+
+	// Format and schema of the completion reports.
+	batchJobReportFormat = "Report_CSV_20180820"
+	batchJobReportSchema = "Bucket, Key, VersionId, TaskStatus, ErrorCode, HTTPStatusCode, ResultMessage"
+)
+
+var (
+	batchJobLeaderLockTimeout = newDynamicTimeout(30*time.Second, 10*time.Second)
+	batchJobLockTimeout       = newDynamicTimeout(30*time.Second, 10*time.Second)
+
+	// globalBatchJobsNotify wakes up the batch job runner of this node.
+	globalBatchJobsNotify = make(chan struct{}, 1)
+
+	errNoSuchBatchJob    = AdminError{Code: "XMinioAdminNoSuchBatchJob", Message: "The specified batch job does not exist", StatusCode: http.StatusNotFound}
+	errBatchJobNotActive = AdminError{Code: "XMinioAdminBatchJobNotActive", Message: "The specified batch job is not active", StatusCode: http.StatusConflict}
+	errBatchJobCancelled = errors.New("batch job cancelled")
+)
+
+// BatchJobStatus is the status of a batch job.
+type BatchJobStatus string
+
+// Statuses of a batch job, only active jobs are run.
+const (
+	BatchJobActive    BatchJobStatus = "Active"
+	BatchJobComplete  BatchJobStatus = "Complete"
+	BatchJobCancelled BatchJobStatus = "Cancelled"
+	BatchJobFailed    BatchJobStatus = "Failed"
+)
+
+// Formats of the manifest of a batch job.
+const (
+	// BatchJobManifestCSV is a CSV object listing bucket, URL
+	// encoded key and optionally version ID of each object.
+	BatchJobManifestCSV = "CSV"
+	// BatchJobManifestInventory is the manifest.json of a CSV
+	// inventory report.
+	BatchJobManifestInventory = "Inventory"
+)
+
+// Scopes of the completion report of a batch job.
+const (
+	BatchJobReportAllTasks    = "AllTasks"
+	BatchJobReportFailedTasks = "FailedTasksOnly"
+)
+
+// BatchJobManifest is the object listing the objects a job operates on.
+type BatchJobManifest struct {
+	Format string `json:"format"`
+	Bucket string `json:"bucket"`
+	Object string `json:"object"`
+}
+
+// BatchJobCopy copies the objects to a bucket, below a prefix.
+type BatchJobCopy struct {
+	Bucket       string `json:"bucket"`
+	Prefix       string `json:"prefix,omitempty"`
+	StorageClass string `json:"storageClass,omitempty"`
+}
+
+// BatchJobTagging replaces the tags of the objects.
+type BatchJobTagging struct {
+	Tags map[string]string `json:"tags"`
+}
+
+// BatchJobRetention sets or removes the retention of the objects. When
+// Bucket is set, the objects of other buckets are failed, and without
+// a manifest the versions of Bucket below Prefix are listed instead.
+type BatchJobRetention struct {
+	Mode                      objectlock.RetMode `json:"mode,omitempty"`
+	RetainUntilDate           time.Time          `json:"retainUntilDate,omitempty"`
+	Remove                    bool               `json:"remove,omitempty"`
+	BypassGovernanceRetention bool               `json:"bypassGovernanceRetention,omitempty"`
+	Bucket                    string             `json:"bucket,omitempty"`
+	Prefix                    string             `json:"prefix,omitempty"`
+}
+
+// BatchJobLegalHold sets or removes the legal hold of the objects, the
+// objects are selected like for BatchJobRetention.
+type BatchJobLegalHold struct {
+	Status objectlock.LegalHoldStatus `json:"status"`
+	Bucket string                     `json:"bucket,omitempty"`
+	Prefix string                     `json:"prefix,omitempty"`
+}
+
+// BatchJobRestore restores transitioned objects for a number of days.
+type BatchJobRestore struct {
+	Days int `json:"days"`
+}
+
+// BatchJobReplicate replicates the object versions of a bucket which
+// existed before replication was enabled, to the targets of its existing
+// object replication rules. The versions replicated are listed from the
+// bucket rather than from a manifest, and filtered by prefix, tags, size
+// and modification time. A dry run only counts the versions and bytes
+// which would be replicated.
+type BatchJobReplicate struct {
+	Bucket         string            `json:"bucket"`
+	Prefix         string            `json:"prefix,omitempty"`
+	Tags           map[string]string `json:"tags,omitempty"`
+	MinSize        int64             `json:"minSize,omitempty"`
+	MaxSize        int64             `json:"maxSize,omitempty"`
+	ModifiedAfter  time.Time         `json:"modifiedAfter,omitempty"`
+	ModifiedBefore time.Time         `json:"modifiedBefore,omitempty"`
+	DryRun         bool              `json:"dryRun,omitempty"`
+}
+
+// match returns true if the object version passes the filters of r.
+func (r *BatchJobReplicate) match(oi ObjectInfo) bool {
+	if !strings.HasPrefix(oi.Name, r.Prefix) {
+		return false
+	}
+	if oi.Size < r.MinSize || (r.MaxSize > 0 && oi.Size > r.MaxSize) {
+		return false
+	}
+	if !r.ModifiedAfter.IsZero() && !oi.ModTime.After(r.ModifiedAfter) {
+		return false
+	}
+	if !r.ModifiedBefore.IsZero() && !oi.ModTime.Before(r.ModifiedBefore) {
+		return false
+	}
+	if len(r.Tags) > 0 {
+		t, err := tags.ParseObjectTags(oi.UserTags)
+		if err != nil {
+			return false
+		}
+		objTags := t.ToMap()
+		for k, v := range r.Tags {
+			if objTags[k] != v {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// BatchJobKeyRotate seals the object keys of the SSE-S3 and SSE-KMS
+// encrypted object versions of a bucket again, with new keys generated
+// by the KMS under the latest version of their KMS key, or under KeyID
+// for SSE-KMS encrypted versions if set. The object data is not
+// encrypted again. The versions are listed from the bucket rather than
+// from a manifest, and filtered by prefix.
+type BatchJobKeyRotate struct {
+	Bucket string `json:"bucket"`
+	Prefix string `json:"prefix,omitempty"`
+	KeyID  string `json:"keyId,omitempty"`
+}
+
+// match returns true if the object version is encrypted with a key
+// of the KMS and passes the filters of k.
+func (k *BatchJobKeyRotate) match(oi ObjectInfo) bool {
+	if !strings.HasPrefix(oi.Name, k.Prefix) {
+		return false
+	}
+	return crypto.S3.IsEncrypted(oi.UserDefined) || crypto.S3KMS.IsEncrypted(oi.UserDefined)
+}
+
+// BatchJobPurgeVersions deletes the versions of a bucket which have been
+// noncurrent for more than NoncurrentDays days, except the ones under
+// retention or legal hold, and with DeleteMarkers the delete markers left
+// without any other version. The versions are listed from the bucket
+// rather than from a manifest, and filtered by prefix. The versions
+// deleted per second are limited to MaxDeletesPerSecond if set. A dry
+// run only counts the versions and bytes which would be deleted.
+type BatchJobPurgeVersions struct {
+	Bucket              string `json:"bucket"`
+	Prefix              string `json:"prefix,omitempty"`
+	NoncurrentDays      int    `json:"noncurrentDays"`
+	DeleteMarkers       bool   `json:"deleteMarkers,omitempty"`
+	MaxDeletesPerSecond int    `json:"maxDeletesPerSecond,omitempty"`
+	DryRun              bool   `json:"dryRun,omitempty"`
+
+	limiter *rate.Limiter
+}
+
+// BatchJobComplianceAudit verifies that the object versions of a bucket
+// whose default retention is in compliance mode are still retained in
+// compliance mode, and that the versions retained at the time of the
+// previous audit of the same bucket and prefix were not deleted before
+// their retention expired. The versions are listed from the bucket rather
+// than from a manifest, and filtered by prefix. The violations are the
+// failed tasks of the report, which is signed when complete.
+type BatchJobComplianceAudit struct {
+	Bucket      string `json:"bucket"`
+	Prefix      string `json:"prefix,omitempty"`
+	PreviousJob string `json:"previousJob,omitempty"`
+}
+
+// BatchJobOperation is the operation run on each object, exactly one
+// of the operations is set.
+type BatchJobOperation struct {
+	Copy      *BatchJobCopy      `json:"copy,omitempty"`
+	Tagging   *BatchJobTagging   `json:"tagging,omitempty"`
+	Retention *BatchJobRetention `json:"retention,omitempty"`
+	LegalHold *BatchJobLegalHold `json:"legalHold,omitempty"`
+	Restore   *BatchJobRestore   `json:"restore,omitempty"`
+	Replicate *BatchJobReplicate `json:"replicate,omitempty"`
+	KeyRotate *BatchJobKeyRotate `json:"keyRotate,omitempty"`
+
+	PurgeVersions   *BatchJobPurgeVersions   `json:"purgeVersions,omitempty"`
+	ComplianceAudit *BatchJobComplianceAudit `json:"complianceAudit,omitempty"`
+}
+
+// dryRun returns true if the operation only counts the objects it
+// would operate on.
+func (op BatchJobOperation) dryRun() bool {
+	return (op.Replicate != nil && op.Replicate.DryRun) || (op.PurgeVersions != nil && op.PurgeVersions.DryRun)
+}
+
+// BatchJobReport is where the completion report of a job is written.
+type BatchJobReport struct {
+	Bucket string `json:"bucket"`
+	Prefix string `json:"prefix,omitempty"`
+	Scope  string `json:"scope"`
+}
+
+// BatchJobRequest describes a batch job to start, the manifest is not
+// used by the replicate, key rotation, purge and compliance audit operations.
+type BatchJobRequest struct {
+	Description string            `json:"description,omitempty"`
+	Manifest    BatchJobManifest  `json:"manifest"`
+	Operation   BatchJobOperation `json:"operation"`
+	Report      BatchJobReport    `json:"report"`
+}
+
+// lockBucket returns the bucket the retention, legal hold or
+// compliance audit operation of the request is limited to, if any.
+func (req BatchJobRequest) lockBucket() (bucket, prefix string) {
+	switch op := req.Operation; {
+	case op.Retention != nil:
+		return op.Retention.Bucket, op.Retention.Prefix
+	case op.LegalHold != nil:
+		return op.LegalHold.Bucket, op.LegalHold.Prefix
+	case op.ComplianceAudit != nil:
+		return op.ComplianceAudit.Bucket, op.ComplianceAudit.Prefix
+	}
+	return "", ""
+}
+
+// listsBucket returns true if the objects of the job are listed
+// from a bucket rather than read from its manifest.
+func (req BatchJobRequest) listsBucket() bool {
+	if op := req.Operation; op.Replicate != nil || op.KeyRotate != nil || op.PurgeVersions != nil || op.ComplianceAudit != nil {
+		return true
+	}
+	bucket, _ := req.lockBucket()
+	return bucket != "" && req.Manifest.Format == ""
+}
+
+// BatchJobProgress counts the tasks run by a job so far, and the size
+// of the objects of the succeeded tasks of the jobs listing them from a
+// bucket. The tasks remaining are counted by the jobs listing them from
+// a bucket, except replicate jobs, each time they start running.
+type BatchJobProgress struct {
+	Succeeded int64 `json:"succeeded"`
+	Failed    int64 `json:"failed"`
+	Bytes     int64 `json:"bytes,omitempty"`
+	Remaining int64 `json:"remaining,omitempty"`
+}
+
+// BatchJobCheckpoint is the position in the manifest files of the
+// next task of a job, or the last object of the bucket whose versions
+// were all run by a job listing them. Compliance audits checking the
+// versions of the previous audit count its report files from 1.
+type BatchJobCheckpoint struct {
+	File   int    `json:"file"`
+	Row    int64  `json:"row"`
+	Object string `json:"object,omitempty"`
+}
+
+// BatchJobReportFile is a file of the completion report of a job.
+type BatchJobReportFile struct {
+	TaskExecutionStatus string `json:"TaskExecutionStatus"`
+	Bucket              string `json:"Bucket"`
+	MD5Checksum         string `json:"MD5Checksum"`
+	SHA256Checksum      string `json:"SHA256Checksum,omitempty"`
+	Key                 string `json:"Key"`
+}
+
+// BatchJob is a batch job and its state.
+type BatchJob struct {
+	ID          string               `json:"id"`
+	Request     BatchJobRequest      `json:"request"`
+	Status      BatchJobStatus       `json:"status"`
+	Created     time.Time            `json:"created"`
+	Finished    time.Time            `json:"finished,omitempty"`
+	Progress    BatchJobProgress     `json:"progress"`
+	Checkpoint  BatchJobCheckpoint   `json:"checkpoint"`
+	ReportFiles []BatchJobReportFile `json:"reportFiles,omitempty"`
+	Error       string               `json:"error,omitempty"`
+}
+
+func batchJobPath(id string) string {
+	return path.Join(batchJobsPrefix, id+".json")
+}
+
+// reportDir returns the prefix of the completion report of the job.
+func (j *BatchJob) reportDir() string {
+	return path.Join(j.Request.Report.Prefix, "job-"+j.ID)
+}
+
+func batchJobRequestError(format string, a ...interface{}) error {
+	return AdminError{
+		Code:       "XMinioAdminInvalidBatchJob",
+		Message:    fmt.Sprintf(format, a...),
+		StatusCode: http.StatusBadRequest,
+	}
+}
+
+// validate checks the request and that the buckets and the manifest
+// it refers to exist.
+func (req BatchJobRequest) validate(ctx context.Context, objAPI ObjectLayer) error {
+	op := req.Operation
+	ops := 0
+	for _, set := range []bool{op.Copy != nil, op.Tagging != nil, op.Retention != nil, op.LegalHold != nil, op.Restore != nil, op.Replicate != nil, op.KeyRotate != nil, op.PurgeVersions != nil, op.ComplianceAudit != nil} {
+		if set {
+			ops++
+		}
+	}
+	if ops != 1 {
+		return batchJobRequestError("exactly one operation must be specified")
+	}
+
+	switch {
+	case op.Copy != nil:
+		if op.Copy.StorageClass != "" && !storageclass.IsValid(op.Copy.StorageClass) {
+			return batchJobRequestError("invalid storage class %s", op.Copy.StorageClass)
+		}
+		if err := checkBatchJobBucket(ctx, objAPI, op.Copy.Bucket); err != nil {
+			return err
+		}
+	case op.Tagging != nil:
+		if _, err := tags.MapToObjectTags(op.Tagging.Tags); err != nil {
+			return batchJobRequestError("invalid tags: %v", err)
+		}
+	case op.Retention != nil:
+		if op.Retention.Remove {
+			if op.Retention.Mode != "" || !op.Retention.RetainUntilDate.IsZero() {
+				return batchJobRequestError("no retention mode or retain until date can be set to remove the retention")
+			}
+		} else {
+			if !op.Retention.Mode.Valid() {
+				return batchJobRequestError("invalid retention mode %s", op.Retention.Mode)
+			}
+			if !op.Retention.RetainUntilDate.After(UTCNow()) {
+				return batchJobRequestError("the retain until date must be in the future")
+			}
+		}
+	case op.LegalHold != nil:
+		if !op.LegalHold.Status.Valid() {
+			return batchJobRequestError("invalid legal hold status %s", op.LegalHold.Status)
+		}
+	case op.Restore != nil:
+		if op.Restore.Days < 1 {
+			return batchJobRequestError("the number of days to restore objects for must be positive")
+		}
+	case op.Replicate != nil:
+		r := op.Replicate
+		if err := checkBatchJobBucket(ctx, objAPI, r.Bucket); err != nil {
+			return err
+		}
+		rcfg, _, err := globalBucketMetadataSys.GetReplicationConfig(ctx, r.Bucket)
+		if err != nil {
+			return err
+		}
+		if !rcfg.HasExistingObjectReplication("") {
+			return batchJobError(ErrReplicationNoExistingObjects)
+		}
+		if r.MinSize < 0 || r.MaxSize < 0 || (r.MaxSize > 0 && r.MinSize > r.MaxSize) {
+			return batchJobRequestError("invalid size range %d-%d", r.MinSize, r.MaxSize)
+		}
+		if _, err := tags.MapToObjectTags(r.Tags); err != nil {
+			return batchJobRequestError("invalid tags: %v", err)
+		}
+	case op.KeyRotate != nil:
+		k := op.KeyRotate
+		if err := checkBatchJobBucket(ctx, objAPI, k.Bucket); err != nil {
+			return err
+		}
+		if GlobalKMS == nil {
+			return errKMSNotConfigured
+		}
+		if k.KeyID != "" {
+			// Make sure the key exists and keys can be generated with it.
+			if _, err := GlobalKMS.GenerateKey(k.KeyID, kms.Context{k.Bucket: k.Bucket}); err != nil {
+				if errors.Is(err, kes.ErrKeyNotFound) {
+					return errKMSKeyNotFound
+				}
+				return err
+			}
+		}
+	case op.PurgeVersions != nil:
+		p := op.PurgeVersions
+		if err := checkBatchJobBucket(ctx, objAPI, p.Bucket); err != nil {
+			return err
+		}
+		if p.NoncurrentDays < 0 {
+			return batchJobRequestError("the number of noncurrent days must not be negative")
+		}
+		if p.MaxDeletesPerSecond < 0 {
+			return batchJobRequestError("the maximum number of deletes per second must not be negative")
+		}
+	case op.ComplianceAudit != nil:
+		if err := op.ComplianceAudit.validate(ctx, objAPI, req.Report); err != nil {
+			return err
+		}
+	}
+
+	switch req.Report.Scope {
+	case BatchJobReportAllTasks, BatchJobReportFailedTasks:
+	default:
+		return batchJobRequestError("invalid report scope %s", req.Report.Scope)
+	}
+	if err := checkBatchJobBucket(ctx, objAPI, req.Report.Bucket); err != nil {
+		return err
+	}
+
+	if bucket, _ := req.lockBucket(); bucket != "" {
+		if err := checkBatchJobBucket(ctx, objAPI, bucket); err != nil {
+			return err
+		}
+		if rcfg, _ := globalBucketObjectLockSys.Get(bucket); !rcfg.LockEnabled {
+			return batchJobError(ErrInvalidBucketObjectLockConfiguration)
+		}
+	}
+
+	if req.listsBucket() {
+		// The objects are listed from the bucket.
+		return nil
+	}
+
+	switch req.Manifest.Format {
+	case BatchJobManifestCSV, BatchJobManifestInventory:
+	default:
+		return batchJobRequestError("invalid manifest format %s", req.Manifest.Format)
+	}
+	if err := checkBatchJobBucket(ctx, objAPI, req.Manifest.Bucket); err != nil {
+		return err
+	}
+	_, err := objAPI.GetObjectInfo(ctx, req.Manifest.Bucket, req.Manifest.Object, ObjectOptions{})
+	return err
+}
+
+func checkBatchJobBucket(ctx context.Context, objAPI ObjectLayer, bucket string) error {
+	if bucket == "" || isMinioMetaBucketName(bucket) {
+		return BucketNameInvalid{Bucket: bucket}
+	}
+	_, err := objAPI.GetBucketInfo(ctx, bucket)
+	return err
+}
+
+// startBatchJob saves a new active job for req, it is run by the
+// node running the batch jobs of the cluster.
+func startBatchJob(ctx context.Context, objAPI ObjectLayer, req BatchJobRequest) (*BatchJob, error) {
+	if err := req.validate(ctx, objAPI); err != nil {
+		return nil, err
+	}
+	if a := req.Operation.ComplianceAudit; a != nil && a.PreviousJob == "" {
+		previous, err := a.findPreviousJob(ctx, objAPI)
+		if err != nil {
+			return nil, err
+		}
+		a.PreviousJob = previous
+	}
+	job := &BatchJob{
+		ID:      mustGetUUID(),
+		Request: req,
+		Status:  BatchJobActive,
+		Created: UTCNow(),
+	}
+	if err := saveBatchJob(ctx, objAPI, job); err != nil {
+		return nil, err
+	}
+	select {
+	case globalBatchJobsNotify <- struct{}{}:
+	default:
+	}
+	return job, nil
+}
+
+func saveBatchJob(ctx context.Context, objAPI ObjectLayer, job *BatchJob) error {
+	data, err := json.Marshal(job)
+	if err != nil {
+		return err
+	}
+	return saveConfig(ctx, objAPI, batchJobPath(job.ID), data)
+}
+
+func loadBatchJob(ctx context.Context, objAPI ObjectLayer, id string) (*BatchJob, error) {
+	if id == "" || id == ".." || strings.Contains(id, SlashSeparator) {
+		return nil, errNoSuchBatchJob
+	}
+	data, err := readConfig(ctx, objAPI, batchJobPath(id))
+	if err != nil {
+		if errors.Is(err, errConfigNotFound) {
+			return nil, errNoSuchBatchJob
+		}
+		return nil, err
+	}
+	var job BatchJob
+	if err = json.Unmarshal(data, &job); err != nil {
+		return nil, err
+	}
+	return &job, nil
+}
+
+// updateBatchJob atomically updates the saved job id with fn.
+func updateBatchJob(ctx context.Context, objAPI ObjectLayer, id string, fn func(*BatchJob) error) (*BatchJob, error) {
+	// Not the path of the job itself, which is locked to save it.
+	lk := objAPI.NewNSLock(minioMetaBucket, path.Join(batchJobsPrefix, id+".lock"))
+	lkctx, err := lk.GetLock(ctx, batchJobLockTimeout)
+	if err != nil {
+		return nil, err
+	}
+	ctx = lkctx.Context()
+	defer lk.Unlock(lkctx.Cancel)
+
+	job, err := loadBatchJob(ctx, objAPI, id)
+	if err != nil {
+		return nil, err
+	}
+	if err = fn(job); err != nil {
+		return nil, err
+	}
+	return job, saveBatchJob(ctx, objAPI, job)
+}
+
+// cancelBatchJob stops the active job id, the tasks run so far are
+// listed in the completion report written so far.
+func cancelBatchJob(ctx context.Context, objAPI ObjectLayer, id string) (*BatchJob, error) {
+	return updateBatchJob(ctx, objAPI, id, func(job *BatchJob) error {
+		if job.Status != BatchJobActive {
+			return errBatchJobNotActive
+		}
+		job.Status = BatchJobCancelled
+		job.Finished = UTCNow()
+		return nil
+	})
+}
+
+// listBatchJobs returns all jobs, oldest first.
+func listBatchJobs(ctx context.Context, objAPI ObjectLayer) ([]BatchJob, error) {
+	var jobs []BatchJob
+	marker := ""
+	for {
+		res, err := objAPI.ListObjects(ctx, minioMetaBucket, batchJobsPrefix+SlashSeparator, marker, "", maxObjectList)
+		if err != nil {
+			return nil, err
+		}
+		for _, obj := range res.Objects {
+			job, err := loadBatchJob(ctx, objAPI, strings.TrimSuffix(path.Base(obj.Name), ".json"))
+			if err != nil {
+				if err == errNoSuchBatchJob {
+					continue
+				}
+				return nil, err
+			}
+			jobs = append(jobs, *job)
+		}
+		if !res.IsTruncated {
+			break
+		}
+		marker = res.NextMarker
+	}
+	sort.Slice(jobs, func(i, j int) bool {
+		return jobs[i].Created.Before(jobs[j].Created)
+	})
+	return jobs, nil
+}
+
+// initBatchJobs starts running the active batch jobs of the cluster,
+// including the ones interrupted by a restart.
+func initBatchJobs(ctx context.Context, objAPI ObjectLayer) {
+	go func() {
+		r := rand.New(rand.NewSource(time.Now().UnixNano()))
+		for {
+			runBatchJobs(ctx, objAPI)
+			duration := time.Duration(r.Float64() * float64(batchJobPollInterval))
+			if duration < time.Second {
+				// Make sure to sleep atleast a second to avoid high CPU ticks.
+				duration = time.Second
+			}
+			time.Sleep(duration)
+		}
+	}()
+}
+
+func runBatchJobs(pctx context.Context, objAPI ObjectLayer) {
+	// Make sure only 1 node runs batch jobs on the cluster.
+	locker := objAPI.NewNSLock(minioMetaBucket, "batch-jobs/runBatchJobs.lock")
+	lkctx, err := locker.GetLock(pctx, batchJobLeaderLockTimeout)
+	if err != nil {
+		return
+	}
+	ctx := lkctx.Context()
+	defer lkctx.Cancel()
+	// No unlock for "leader" lock.
+
+	pollTimer := time.NewTimer(0)
+	defer pollTimer.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-globalBatchJobsNotify:
+		case <-pollTimer.C:
+			pollTimer.Reset(batchJobPollInterval)
+		}
+		runActiveBatchJobs(ctx, objAPI)
+	}
+}
+
+// runActiveBatchJobs runs the active jobs one after the other.
+func runActiveBatchJobs(ctx context.Context, objAPI ObjectLayer) {
+	jobs, err := listBatchJobs(ctx, objAPI)
+	if err != nil {
+		logger.LogIf(ctx, err)
+		return
+	}
+	for i := range jobs {
+		if jobs[i].Status != BatchJobActive {
+			continue
+		}
+		if err = runBatchJob(ctx, objAPI, &jobs[i]); err != nil {
+			if ctx.Err() != nil {
+				return
+			}
+			logger.LogIf(ctx, fmt.Errorf("Unable to run batch job %s: %w", jobs[i].ID, err))
+		}
+	}
+}
+
+// batchJobTask is an object a job operates on, the size is only
+// known to the jobs listing a bucket. The retain until date is set
+// by compliance audits checking a version of the previous audit.
+type batchJobTask struct {
+	Bucket       string
+	Object       string
+	VersionID    string
+	Size         int64
+	DeleteMarker bool
+	RetainUntil  time.Time
+
+	// Result message of the succeeded task, if not the default one.
+	Message string
+}
+
+// batchJobError fails a task with an S3 API error.
+type batchJobError APIErrorCode
+
+func (e batchJobError) Error() string {
+	return errorCodes.ToAPIErr(APIErrorCode(e)).Description
+}
+
+// runBatchJob runs the tasks of job from its last checkpoint on, until
+// it is complete or cancelled. An error is returned if the job could not
+// be updated, it is then run again from its last checkpoint.
+func runBatchJob(ctx context.Context, objAPI ObjectLayer, job *BatchJob) error {
+	var (
+		tasks []batchJobTask
+		next  BatchJobCheckpoint
+		err   error
+	)
+	runTasks := func() error {
+		results := make([]error, len(tasks))
+		var wg sync.WaitGroup
+		taskCh := make(chan int)
+		for w := 0; w < batchJobWorkers && w < len(tasks); w++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				for i := range taskCh {
+					results[i] = job.runTask(ctx, objAPI, &tasks[i])
+				}
+			}()
+		}
+		for i := range tasks {
+			taskCh <- i
+		}
+		close(taskCh)
+		wg.Wait()
+		// Tasks failed by the context are run again.
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		if err := job.writeResults(ctx, objAPI, tasks, results); err != nil {
+			return err
+		}
+		job.Checkpoint = next
+		tasks = tasks[:0]
+		return job.checkpoint(ctx, objAPI, nil)
+	}
+
+	if job.Request.listsBucket() && job.Request.Operation.Replicate == nil {
+		err = job.countRemaining(ctx, objAPI)
+	}
+	if err == nil {
+		err = job.readTasks(ctx, objAPI, func(t batchJobTask, pos BatchJobCheckpoint) error {
+			tasks = append(tasks, t)
+			next = pos
+			if len(tasks) == batchJobCheckpointTasks {
+				return runTasks()
+			}
+			return nil
+		})
+	}
+	if err == nil && len(tasks) > 0 {
+		err = runTasks()
+	}
+	switch {
+	case errors.Is(err, errBatchJobCancelled):
+		return nil
+	case ctx.Err() != nil:
+		return ctx.Err()
+	case err == nil:
+		err = job.writeReportManifest(ctx, objAPI)
+	}
+
+	return job.checkpoint(ctx, objAPI, func(stored *BatchJob) {
+		stored.Finished = UTCNow()
+		if err != nil {
+			stored.Status = BatchJobFailed
+			stored.Error = err.Error()
+		} else {
+			stored.Status = BatchJobComplete
+		}
+	})
+}
+
+// checkpoint saves the progress of job, unless it has been cancelled
+// meanwhile. finish updates the saved job after the progress.
+func (j *BatchJob) checkpoint(ctx context.Context, objAPI ObjectLayer, finish func(*BatchJob)) error {
+	_, err := updateBatchJob(ctx, objAPI, j.ID, func(stored *BatchJob) error {
+		if stored.Status != BatchJobActive {
+			return errBatchJobCancelled
+		}
+		stored.Progress = j.Progress
+		stored.Checkpoint = j.Checkpoint
+		stored.ReportFiles = j.ReportFiles
+		if finish != nil {
+			finish(stored)
+		}
+		return nil
+	})
+	return err
+}
+
+// countRemaining counts the tasks of the job from its checkpoint on, and
+// saves them as remaining in its progress.
+func (j *BatchJob) countRemaining(ctx context.Context, objAPI ObjectLayer) error {
+	j.Progress.Remaining = 0
+	err := j.readTasks(ctx, objAPI, func(batchJobTask, BatchJobCheckpoint) error {
+		j.Progress.Remaining++
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	return j.checkpoint(ctx, objAPI, nil)
+}
+
+// readTasks calls fn with each task of the job from its checkpoint on,
+// and the position of the following task.
+func (j *BatchJob) readTasks(ctx context.Context, objAPI ObjectLayer, fn func(batchJobTask, BatchJobCheckpoint) error) error {
+	op := j.Request.Operation
+	switch {
+	case op.Replicate != nil:
+		return walkBatchJobReplicate(ctx, objAPI, op.Replicate, j.Checkpoint, fn)
+	case op.KeyRotate != nil:
+		return walkBatchJobBucket(ctx, objAPI, op.KeyRotate.Bucket, op.KeyRotate.Prefix, j.Checkpoint, op.KeyRotate.match, fn)
+	case op.PurgeVersions != nil:
+		return walkBatchJobPurge(ctx, objAPI, op.PurgeVersions, j.Checkpoint, fn)
+	case op.ComplianceAudit != nil:
+		return j.readComplianceAuditTasks(ctx, objAPI, fn)
+	case j.Request.listsBucket():
+		bucket, prefix := j.Request.lockBucket()
+		return walkBatchJobBucket(ctx, objAPI, bucket, prefix, j.Checkpoint, func(oi ObjectInfo) bool {
+			return !oi.DeleteMarker
+		}, fn)
+	}
+	return readBatchJobManifest(ctx, objAPI, j.Request.Manifest, j.Checkpoint, fn)
+}
+
+// walkBatchJobReplicate calls fn with each object version of the bucket
+// of r to replicate after the object of the checkpoint from.
+func walkBatchJobReplicate(ctx context.Context, objAPI ObjectLayer, r *BatchJobReplicate, from BatchJobCheckpoint, fn func(batchJobTask, BatchJobCheckpoint) error) error {
+	cfg, err := getReplicationConfig(ctx, r.Bucket)
+	if err != nil {
+		return err
+	}
+	tgts, err := globalBucketTargetSys.ListBucketTargets(ctx, r.Bucket)
+	if err != nil {
+		return err
+	}
+	rcfg := replicationConfig{
+		Config:  cfg,
+		remotes: tgts,
+	}
+	return walkBatchJobBucket(ctx, objAPI, r.Bucket, r.Prefix, from, func(oi ObjectInfo) bool {
+		if oi.DeleteMarker || !r.match(oi) {
+			return false
+		}
+		roi := getHealReplicateObjectInfo(oi, rcfg)
+		return roi.ExistingObjResync.mustResync()
+	}, fn)
+}
+
+// walkBatchJobBucket calls fn with each object version of bucket below
+// prefix after the object of the checkpoint from, for which match returns
+// true. The position of a task is the previous object, so that a
+// checkpoint is always after all the versions of an object.
+func walkBatchJobBucket(ctx context.Context, objAPI ObjectLayer, bucket, prefix string, from BatchJobCheckpoint, match func(ObjectInfo) bool, fn func(batchJobTask, BatchJobCheckpoint) error) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	objInfoCh := make(chan ObjectInfo)
+	err := objAPI.Walk(ctx, bucket, prefix, objInfoCh, ObjectOptions{WalkAscending: true})
+	if err != nil {
+		return err
+	}
+	var prev string
+	done := from.Object
+	for oi := range objInfoCh {
+		if err != nil || oi.Name <= from.Object {
+			// Drain the walk after an error.
+			continue
+		}
+		if !match(oi) {
+			continue
+		}
+		if prev != "" && prev != oi.Name {
+			done = prev
+		}
+		prev = oi.Name
+		err = fn(batchJobTask{Bucket: oi.Bucket, Object: oi.Name, VersionID: oi.VersionID, Size: oi.Size}, BatchJobCheckpoint{Object: done})
+		if err != nil {
+			cancel()
+		}
+	}
+	if err != nil {
+		return err
+	}
+	return ctx.Err()
+}
+
+// readBatchJobManifest calls fn with each task of the manifest from
+// the checkpoint from on, and the position of the following task.
+func readBatchJobManifest(ctx context.Context, objAPI ObjectLayer, m BatchJobManifest, from BatchJobCheckpoint, fn func(batchJobTask, BatchJobCheckpoint) error) error {
+	if m.Format == BatchJobManifestCSV {
+		return readBatchJobManifestFile(ctx, objAPI, m.Bucket, m.Object, false, [3]int{0, 1, 2}, from.Row, func(t batchJobTask, row int64) error {
+			return fn(t, BatchJobCheckpoint{Row: row})
+		})
+	}
+
+	data, err := readBatchJobObject(ctx, objAPI, m.Bucket, m.Object)
+	if err != nil {
+		return err
+	}
+	var manifest inventoryManifest
+	if err = json.Unmarshal(data, &manifest); err != nil {
+		return batchJobRequestError("invalid inventory manifest: %v", err)
+	}
+	if manifest.FileFormat != "CSV" {
+		return batchJobRequestError("unsupported inventory format %s", manifest.FileFormat)
+	}
+	// Bucket, Key and VersionId columns.
+	columns := [3]int{-1, -1, -1}
+	for i, name := range strings.Split(manifest.FileSchema, ",") {
+		switch strings.TrimSpace(name) {
+		case "Bucket":
+			columns[0] = i
+		case "Key":
+			columns[1] = i
+		case "VersionId":
+			columns[2] = i
+		}
+	}
+	if columns[0] < 0 || columns[1] < 0 {
+		return batchJobRequestError("invalid inventory schema %s", manifest.FileSchema)
+	}
+	bucket := strings.TrimPrefix(manifest.DestinationBucket, "arn:aws:s3:::")
+	for i := from.File; i < len(manifest.Files); i++ {
+		skip := int64(0)
+		if i == from.File {
+			skip = from.Row
+		}
+		err = readBatchJobManifestFile(ctx, objAPI, bucket, manifest.Files[i].Key, true, columns, skip, func(t batchJobTask, row int64) error {
+			return fn(t, BatchJobCheckpoint{File: i, Row: row})
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// readBatchJobManifestFile calls fn with the tasks of a CSV file after
+// the first skip rows, columns are the indexes of the bucket, key and
+// version ID columns, the version ID is optional.
+func readBatchJobManifestFile(ctx context.Context, objAPI ObjectLayer, bucket, object string, gzipped bool, columns [3]int, skip int64, fn func(batchJobTask, int64) error) error {
+	gr, err := objAPI.GetObjectNInfo(ctx, bucket, object, nil, http.Header{}, readLock, ObjectOptions{})
+	if err != nil {
+		return err
+	}
+	defer gr.Close()
+
+	var r io.Reader = gr
+	if gzipped {
+		gz, err := gzip.NewReader(gr)
+		if err != nil {
+			return err
+		}
+		defer gz.Close()
+		r = gz
+	}
+	cr := csv.NewReader(r)
+	cr.FieldsPerRecord = -1
+	cr.ReuseRecord = true
+	for row := int64(1); ; row++ {
+		record, err := cr.Read()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return batchJobRequestError("invalid manifest %s: %v", object, err)
+		}
+		if row <= skip {
+			continue
+		}
+		if len(record) <= columns[1] {
+			return batchJobRequestError("invalid manifest %s: row %d has no key", object, row)
+		}
+		var t batchJobTask
+		t.Bucket = record[columns[0]]
+		if t.Object, err = url.QueryUnescape(record[columns[1]]); err != nil {
+			return batchJobRequestError("invalid manifest %s: row %d: %v", object, row, err)
+		}
+		if columns[2] >= 0 && len(record) > columns[2] {
+			t.VersionID = record[columns[2]]
+		}
+		if err = fn(t, row); err != nil {
+			return err
+		}
+	}
+}
+
+func readBatchJobObject(ctx context.Context, objAPI ObjectLayer, bucket, object string) ([]byte, error) {
+	gr, err := objAPI.GetObjectNInfo(ctx, bucket, object, nil, http.Header{}, readLock, ObjectOptions{})
+	if err != nil {
+		return nil, err
+	}
+	defer gr.Close()
+	return ioutil.ReadAll(gr)
+}
+
+// runTask runs the operation of the job on the object of t.
+func (j *BatchJob) runTask(ctx context.Context, objAPI ObjectLayer, t *batchJobTask) error {
+	if isMinioMetaBucketName(t.Bucket) {
+		return BucketNameInvalid{Bucket: t.Bucket}
+	}
+	if bucket, _ := j.Request.lockBucket(); bucket != "" && t.Bucket != bucket {
+		return batchJobError(ErrAccessDenied)
+	}
+	op := j.Request.Operation
+	switch {
+	case op.Copy != nil:
+		return batchJobCopy(ctx, objAPI, *t, op.Copy)
+	case op.Tagging != nil:
+		return batchJobPutTags(ctx, objAPI, *t, op.Tagging)
+	case op.Retention != nil:
+		return batchJobPutRetention(ctx, objAPI, *t, op.Retention)
+	case op.LegalHold != nil:
+		return batchJobPutLegalHold(ctx, objAPI, *t, op.LegalHold)
+	case op.Restore != nil:
+		return batchJobRestore(ctx, objAPI, *t, op.Restore)
+	case op.Replicate != nil:
+		return batchJobReplicate(ctx, objAPI, *t, op.Replicate)
+	case op.KeyRotate != nil:
+		return batchJobRotateKey(ctx, objAPI, *t, op.KeyRotate)
+	case op.PurgeVersions != nil:
+		return batchJobPurgeVersion(ctx, objAPI, *t, op.PurgeVersions)
+	case op.ComplianceAudit != nil:
+		return auditBatchJobVersion(ctx, objAPI, t)
+	}
+	return errInvalidArgument
+}
+
+// batchJobCopy copies an object, SSE-S3 and SSE-KMS encrypted objects
+// are encrypted again with the same kind of key, or the default
+// encryption of the target bucket. SSE-C encrypted objects cannot be
+// copied as their keys are not known.
+func batchJobCopy(ctx context.Context, objAPI ObjectLayer, t batchJobTask, c *BatchJobCopy) error {
+	srcInfo, err := objAPI.GetObjectInfo(ctx, t.Bucket, t.Object, ObjectOptions{VersionID: t.VersionID})
+	if err != nil {
+		return err
+	}
+	if crypto.SSEC.IsEncrypted(srcInfo.UserDefined) {
+		return batchJobError(ErrSSEEncryptedObject)
+	}
+	gr, err := objAPI.GetObjectNInfo(ctx, t.Bucket, t.Object, nil, http.Header{}, readLock, ObjectOptions{VersionID: t.VersionID})
+	if err != nil {
+		return err
+	}
+	defer gr.Close()
+	srcInfo = gr.ObjInfo
+	actualSize, err := srcInfo.GetActualSize()
+	if err != nil {
+		return err
+	}
+
+	dstObject := c.Prefix + t.Object
+	header := http.Header{}
+	switch {
+	case crypto.S3.IsEncrypted(srcInfo.UserDefined):
+		header.Set(xhttp.AmzServerSideEncryption, xhttp.AmzEncryptionAES)
+	case crypto.S3KMS.IsEncrypted(srcInfo.UserDefined):
+		keyID, _, _, _, err := crypto.S3KMS.ParseMetadata(srcInfo.UserDefined)
+		if err != nil {
+			return err
+		}
+		header.Set(xhttp.AmzServerSideEncryption, xhttp.AmzEncryptionKMS)
+		header.Set(xhttp.AmzServerSideEncryptionKmsID, keyID)
+	}
+	sseConfig, _ := globalBucketSSEConfigSys.Get(c.Bucket)
+	sseConfig.Apply(header, sse.ApplyOptions{
+		AutoEncrypt: globalAutoEncryption,
+	})
+
+	// Internal, encryption, lock and restore metadata of the
+	// source does not apply to the copy.
+	metadata := make(map[string]string, len(srcInfo.UserDefined))
+	for k, v := range srcInfo.UserDefined {
+		if strings.HasPrefix(strings.ToLower(k), ReservedMetadataPrefixLower) {
+			continue
+		}
+		metadata[k] = v
+	}
+	crypto.RemoveInternalEntries(metadata)
+	for _, k := range []string{
+		xhttp.AmzServerSideEncryption, xhttp.AmzServerSideEncryptionKmsID, xhttp.AmzServerSideEncryptionKmsContext,
+		xhttp.AmzObjectLockMode, xhttp.AmzObjectLockRetainUntilDate, xhttp.AmzObjectLockLegalHold,
+		xhttp.AmzRestore, xhttp.AmzRestoreExpiryDays, xhttp.AmzRestoreRequestDate,
+	} {
+		delete(metadata, k)
+		delete(metadata, strings.ToLower(k))
+	}
+	if c.StorageClass != "" {
+		metadata[xhttp.AmzStorageClass] = c.StorageClass
+	}
+	if srcInfo.UserTags != "" {
+		metadata[xhttp.AmzObjectTagging] = srcInfo.UserTags
+	}
+
+	hashReader, err := hash.NewReader(gr, actualSize, "", "", actualSize)
+	if err != nil {
+		return err
+	}
+	pReader := NewPutObjReader(hashReader)
+	if kind, ok := crypto.IsRequested(header); ok {
+		var (
+			keyID  string
+			kmsCtx kms.Context
+		)
+		if kind == crypto.S3KMS {
+			if keyID, kmsCtx, err = crypto.S3KMS.ParseHTTP(header); err != nil {
+				return err
+			}
+		}
+		reader, objectEncryptionKey, err := newEncryptReader(ctx, hashReader, kind, keyID, nil, c.Bucket, dstObject, metadata, kmsCtx)
+		if err != nil {
+			return err
+		}
+		info := ObjectInfo{Size: actualSize}
+		encReader, err := hash.NewReader(etag.Wrap(reader, hashReader), info.EncryptedSize(), "", "", actualSize)
+		if err != nil {
+			return err
+		}
+		if pReader, err = pReader.WithEncryption(encReader, &objectEncryptionKey); err != nil {
+			return err
+		}
+	}
+	crypto.RemoveSensitiveEntries(metadata)
+
+	opts := ObjectOptions{
+		UserDefined:      metadata,
+		Versioned:        globalBucketVersioningSys.PrefixEnabled(c.Bucket, dstObject),
+		VersionSuspended: globalBucketVersioningSys.PrefixSuspended(c.Bucket, dstObject),
+	}
+	dsc := mustReplicate(ctx, c.Bucket, dstObject, getMustReplicateOptions(ObjectInfo{
+		UserDefined: metadata,
+	}, replication.ObjectReplicationType, opts))
+	if dsc.ReplicateAny() {
+		metadata[ReservedMetadataPrefixLower+ReplicationTimestamp] = UTCNow().Format(time.RFC3339Nano)
+		metadata[ReservedMetadataPrefixLower+ReplicationStatus] = dsc.PendingStatus()
+	}
+	objInfo, err := objAPI.PutObject(ctx, c.Bucket, dstObject, pReader, opts)
+	if err != nil {
+		return err
+	}
+	if dsc.ReplicateAny() {
+		scheduleReplication(ctx, objInfo.Clone(), objAPI, dsc, replication.ObjectReplicationType)
+	}
+	sendEvent(eventArgs{
+		EventName:  event.ObjectCreatedCopy,
+		BucketName: c.Bucket,
+		Object:     objInfo,
+		Host:       "Internal: [Batch]",
+	})
+	return nil
+}
+
+// batchJobPutTags replaces the tags of an object.
+func batchJobPutTags(ctx context.Context, objAPI ObjectLayer, t batchJobTask, tg *BatchJobTagging) error {
+	objTags, err := tags.MapToObjectTags(tg.Tags)
+	if err != nil {
+		return err
+	}
+	opts := ObjectOptions{VersionID: t.VersionID}
+	oi, err := objAPI.GetObjectInfo(ctx, t.Bucket, t.Object, opts)
+	if err != nil {
+		return err
+	}
+	tagsStr := objTags.String()
+	oi.UserTags = tagsStr
+	dsc := mustReplicate(ctx, t.Bucket, t.Object, getMustReplicateOptions(oi, replication.MetadataReplicationType, opts))
+	if dsc.ReplicateAny() {
+		opts.UserDefined = map[string]string{
+			ReservedMetadataPrefixLower + ReplicationTimestamp: UTCNow().Format(time.RFC3339Nano),
+			ReservedMetadataPrefixLower + ReplicationStatus:    dsc.PendingStatus(),
+			ReservedMetadataPrefixLower + TaggingTimestamp:     UTCNow().Format(time.RFC3339Nano),
+		}
+	}
+	objInfo, err := objAPI.PutObjectTags(ctx, t.Bucket, t.Object, tagsStr, opts)
+	if err != nil {
+		return err
+	}
+	if dsc.ReplicateAny() {
+		scheduleReplication(ctx, objInfo.Clone(), objAPI, dsc, replication.MetadataReplicationType)
+	}
+	sendEvent(eventArgs{
+		EventName:  event.ObjectCreatedPutTagging,
+		BucketName: t.Bucket,
+		Object:     objInfo,
+		Host:       "Internal: [Batch]",
+	})
+	return nil
+}
+
+// batchJobPutRetention sets or removes the retention of an object,
+// following the rules of PutObjectRetention: the retention of objects in
+// compliance mode can only be extended, the one of objects in governance
+// mode can be shortened or removed with BypassGovernanceRetention.
+func batchJobPutRetention(ctx context.Context, objAPI ObjectLayer, t batchJobTask, r *BatchJobRetention) error {
+	if rcfg, _ := globalBucketObjectLockSys.Get(t.Bucket); !rcfg.LockEnabled {
+		return batchJobError(ErrInvalidBucketObjectLockConfiguration)
+	}
+	opts := ObjectOptions{
+		VersionID: t.VersionID,
+		EvalMetadataFn: func(oi ObjectInfo) error {
+			ret := objectlock.GetObjectRetentionMeta(oi.UserDefined)
+			if ret.Mode.Valid() && ret.RetainUntilDate.After(UTCNow()) {
+				shortened := r.Remove || r.Mode != ret.Mode || r.RetainUntilDate.Before(ret.RetainUntilDate.Time)
+				if shortened && (ret.Mode == objectlock.RetCompliance || !r.BypassGovernanceRetention) {
+					return ObjectLocked{Bucket: oi.Bucket, Object: oi.Name, VersionID: oi.VersionID}
+				}
+			}
+			if r.Remove {
+				oi.UserDefined[strings.ToLower(xhttp.AmzObjectLockMode)] = ""
+				oi.UserDefined[strings.ToLower(xhttp.AmzObjectLockRetainUntilDate)] = ""
+			} else {
+				oi.UserDefined[strings.ToLower(xhttp.AmzObjectLockMode)] = string(r.Mode)
+				oi.UserDefined[strings.ToLower(xhttp.AmzObjectLockRetainUntilDate)] = r.RetainUntilDate.UTC().Format(time.RFC3339)
+			}
+			oi.UserDefined[ReservedMetadataPrefixLower+ObjectLockRetentionTimestamp] = UTCNow().Format(time.RFC3339Nano)
+			dsc := mustReplicate(ctx, t.Bucket, t.Object, getMustReplicateOptions(oi, replication.MetadataReplicationType, ObjectOptions{}))
+			if dsc.ReplicateAny() {
+				oi.UserDefined[ReservedMetadataPrefixLower+ReplicationTimestamp] = UTCNow().Format(time.RFC3339Nano)
+				oi.UserDefined[ReservedMetadataPrefixLower+ReplicationStatus] = dsc.PendingStatus()
+			}
+			return nil
+		},
+	}
+	objInfo, err := objAPI.PutObjectMetadata(ctx, t.Bucket, t.Object, opts)
+	if err != nil {
+		return err
+	}
+	dsc := mustReplicate(ctx, t.Bucket, t.Object, getMustReplicateOptions(objInfo, replication.MetadataReplicationType, ObjectOptions{}))
+	if dsc.ReplicateAny() {
+		scheduleReplication(ctx, objInfo.Clone(), objAPI, dsc, replication.MetadataReplicationType)
+	}
+	sendEvent(eventArgs{
+		EventName:  event.ObjectCreatedPutRetention,
+		BucketName: t.Bucket,
+		Object:     objInfo,
+		Host:       "Internal: [Batch]",
+	})
+	return nil
+}
+
+// batchJobPutLegalHold sets or removes the legal hold of an object.
+func batchJobPutLegalHold(ctx context.Context, objAPI ObjectLayer, t batchJobTask, lh *BatchJobLegalHold) error {
+	if rcfg, _ := globalBucketObjectLockSys.Get(t.Bucket); !rcfg.LockEnabled {
+		return batchJobError(ErrInvalidBucketObjectLockConfiguration)
+	}
+	opts := ObjectOptions{
+		VersionID: t.VersionID,
+		EvalMetadataFn: func(oi ObjectInfo) error {
+			oi.UserDefined[strings.ToLower(xhttp.AmzObjectLockLegalHold)] = strings.ToUpper(string(lh.Status))
+			oi.UserDefined[ReservedMetadataPrefixLower+ObjectLockLegalHoldTimestamp] = UTCNow().Format(time.RFC3339Nano)
+			dsc := mustReplicate(ctx, t.Bucket, t.Object, getMustReplicateOptions(oi, replication.MetadataReplicationType, ObjectOptions{}))
+			if dsc.ReplicateAny() {
+				oi.UserDefined[ReservedMetadataPrefixLower+ReplicationTimestamp] = UTCNow().Format(time.RFC3339Nano)
+				oi.UserDefined[ReservedMetadataPrefixLower+ReplicationStatus] = dsc.PendingStatus()
+			}
+			return nil
+		},
+	}
+	objInfo, err := objAPI.PutObjectMetadata(ctx, t.Bucket, t.Object, opts)
+	if err != nil {
+		return err
+	}
+	dsc := mustReplicate(ctx, t.Bucket, t.Object, getMustReplicateOptions(objInfo, replication.MetadataReplicationType, ObjectOptions{}))
+	if dsc.ReplicateAny() {
+		scheduleReplication(ctx, objInfo.Clone(), objAPI, dsc, replication.MetadataReplicationType)
+	}
+	sendEvent(eventArgs{
+		EventName:  event.ObjectCreatedPutLegalHold,
+		BucketName: t.Bucket,
+		Object:     objInfo,
+		Host:       "Internal: [Batch]",
+	})
+	return nil
+}
+
+// batchJobRestore restores a transitioned object, or extends the
+// restore of an object restored already.
+func batchJobRestore(ctx context.Context, objAPI ObjectLayer, t batchJobTask, rs *BatchJobRestore) error {
+	objInfo, err := objAPI.GetObjectInfo(ctx, t.Bucket, t.Object, ObjectOptions{VersionID: t.VersionID})
+	if err != nil {
+		return err
+	}
+	if objInfo.TransitionedObject.Status != lifecycle.TransitionComplete {
+		return InvalidObjectState{Bucket: t.Bucket, Object: t.Object, VersionID: t.VersionID}
+	}
+	if objInfo.RestoreOngoing {
+		return batchJobError(ErrObjectRestoreAlreadyInProgress)
+	}
+	alreadyRestored := !objInfo.RestoreExpires.IsZero()
+	restoreExpiry := lifecycle.ExpectedExpiryTime(time.Now(), rs.Days)
+
+	metadata := cloneMSS(objInfo.UserDefined)
+	metadata[xhttp.AmzRestoreExpiryDays] = strconv.Itoa(rs.Days)
+	metadata[xhttp.AmzRestoreRequestDate] = time.Now().UTC().Format(http.TimeFormat)
+	if alreadyRestored {
+		metadata[xhttp.AmzRestore] = completedRestoreObj(restoreExpiry).String()
+	} else {
+		metadata[xhttp.AmzRestore] = ongoingRestoreObj().String()
+	}
+	objInfo.metadataOnly = true // Perform only metadata updates.
+	objInfo.UserDefined = metadata
+	if _, err = objAPI.CopyObject(ctx, t.Bucket, t.Object, t.Bucket, t.Object, objInfo, ObjectOptions{
+		VersionID: objInfo.VersionID,
+	}, ObjectOptions{
+		VersionID: objInfo.VersionID,
+	}); err != nil {
+		return err
+	}
+	if alreadyRestored {
+		return nil
+	}
+
+	sendEvent(eventArgs{
+		EventName:  event.ObjectRestorePostInitiated,
+		BucketName: t.Bucket,
+		Object:     objInfo,
+		Host:       "Internal: [Batch]",
+	})
+	if err = objAPI.RestoreTransitionedObject(ctx, t.Bucket, t.Object, ObjectOptions{
+		Transition: TransitionOptions{
+			RestoreRequest: &RestoreObjectRequest{Days: rs.Days},
+			RestoreExpiry:  restoreExpiry,
+		},
+		VersionID: objInfo.VersionID,
+	}); err != nil {
+		return err
+	}
+	sendEvent(eventArgs{
+		EventName:  event.ObjectRestorePostCompleted,
+		BucketName: t.Bucket,
+		Object:     objInfo,
+		Host:       "Internal: [Batch]",
+	})
+	return nil
+}
+
+// batchJobReplicate replicates an object version to the targets of the
+// existing object replication rules of its bucket, unless it has been
+// replicated meanwhile.
+func batchJobReplicate(ctx context.Context, objAPI ObjectLayer, t batchJobTask, r *BatchJobReplicate) error {
+	if r.DryRun {
+		return nil
+	}
+	oi, err := objAPI.GetObjectInfo(ctx, t.Bucket, t.Object, ObjectOptions{VersionID: t.VersionID})
+	if err != nil {
+		return err
+	}
+	cfg, err := getReplicationConfig(ctx, t.Bucket)
+	if err != nil {
+		return err
+	}
+	tgts, err := globalBucketTargetSys.ListBucketTargets(ctx, t.Bucket)
+	if err != nil {
+		return err
+	}
+	roi := getHealReplicateObjectInfo(oi, replicationConfig{
+		Config:  cfg,
+		remotes: tgts,
+	})
+	if !roi.ExistingObjResync.mustResync() {
+		return nil
+	}
+	roi.OpType = replication.ExistingObjectReplicationType
+	replicateObject(ctx, roi, objAPI, ReplicateExisting)
+
+	oi, err = objAPI.GetObjectInfo(ctx, t.Bucket, t.Object, ObjectOptions{VersionID: t.VersionID})
+	if err != nil {
+		return err
+	}
+	if oi.ReplicationStatus != replication.Completed {
+		return batchJobError(ErrReplicationRemoteConnectionError)
+	}
+	return nil
+}
+
+// batchJobRotateKey seals the object key of an SSE-S3 or SSE-KMS
+// encrypted object version again, with a new key generated by the KMS.
+func batchJobRotateKey(ctx context.Context, objAPI ObjectLayer, t batchJobTask, k *BatchJobKeyRotate) error {
+	if GlobalKMS == nil {
+		return errKMSNotConfigured
+	}
+	opts := ObjectOptions{
+		VersionID: t.VersionID,
+		EvalMetadataFn: func(oi ObjectInfo) error {
+			switch kind, _ := crypto.IsEncrypted(oi.UserDefined); kind {
+			case crypto.S3:
+				return rotateKey(ctx, nil, "", nil, oi.Bucket, oi.Name, oi.UserDefined, nil)
+			case crypto.S3KMS:
+				keyID := k.KeyID
+				if keyID == "" {
+					// Keep the key, its latest version seals the object key.
+					id, _, _, _, err := crypto.S3KMS.ParseMetadata(oi.UserDefined)
+					if err != nil {
+						return err
+					}
+					keyID = id
+				}
+				return rotateKey(ctx, nil, keyID, nil, oi.Bucket, oi.Name, oi.UserDefined, nil)
+			case crypto.SSEC:
+				return batchJobError(ErrSSEEncryptedObject)
+			}
+			return batchJobError(ErrInvalidEncryptionMethod)
+		},
+	}
+	_, err := objAPI.PutObjectMetadata(ctx, t.Bucket, t.Object, opts)
+	return err
+}
+
+// writeResults writes the results of tasks into the completion report
+// of the job, succeeded and failed tasks are written to separate files.
+func (j *BatchJob) writeResults(ctx context.Context, objAPI ObjectLayer, tasks []batchJobTask, results []error) error {
+	var succeeded, failed bytes.Buffer
+	sw, fw := csv.NewWriter(&succeeded), csv.NewWriter(&failed)
+	for i, t := range tasks {
+		if j.Progress.Remaining > 0 {
+			j.Progress.Remaining--
+		}
+		err := results[i]
+		if err == nil {
+			j.Progress.Succeeded++
+			j.Progress.Bytes += t.Size
+			// The versions of the previous compliance audit
+			// found again are already listed by this one.
+			if j.Request.Report.Scope == BatchJobReportAllTasks && t.RetainUntil.IsZero() {
+				msg := "Successful"
+				if j.Request.Operation.dryRun() {
+					msg = "Dry run"
+				}
+				if t.Message != "" {
+					msg = t.Message
+				}
+				sw.Write([]string{t.Bucket, url.QueryEscape(t.Object), t.VersionID, "succeeded", "", "200", msg})
+			}
+			continue
+		}
+		j.Progress.Failed++
+		apiErr := toAPIError(ctx, err)
+		switch e := err.(type) {
+		case batchJobError:
+			apiErr = errorCodes.ToAPIErr(APIErrorCode(e))
+		case complianceViolation:
+			apiErr = APIError{Code: "XMinioComplianceViolation", HTTPStatusCode: http.StatusConflict}
+		}
+		fw.Write([]string{t.Bucket, url.QueryEscape(t.Object), t.VersionID, "failed", apiErr.Code, strconv.Itoa(apiErr.HTTPStatusCode), err.Error()})
+	}
+	sw.Flush()
+	fw.Flush()
+
+	for _, file := range []struct {
+		status string
+		buf    *bytes.Buffer
+	}{{"succeeded", &succeeded}, {"failed", &failed}} {
+		if file.buf.Len() == 0 {
+			continue
+		}
+		key := path.Join(j.reportDir(), "results", mustGetUUID()+".csv")
+		sum, err := putBatchJobReport(ctx, objAPI, j.Request.Report.Bucket, key, file.buf.Bytes(), "text/csv")
+		if err != nil {
+			return err
+		}
+		reportFile := BatchJobReportFile{
+			TaskExecutionStatus: file.status,
+			Bucket:              j.Request.Report.Bucket,
+			MD5Checksum:         sum,
+			Key:                 key,
+		}
+		if j.Request.Operation.ComplianceAudit != nil {
+			// Signed by the audit report, unlike the MD5 checksum.
+			sha := sha256.Sum256(file.buf.Bytes())
+			reportFile.SHA256Checksum = hex.EncodeToString(sha[:])
+		}
+		j.ReportFiles = append(j.ReportFiles, reportFile)
+	}
+	return nil
+}
+
+// batchJobReportManifest lists the files of a completion report.
+type batchJobReportManifest struct {
+	Format             string               `json:"Format"`
+	ReportCreationDate string               `json:"ReportCreationDate"`
+	Results            []BatchJobReportFile `json:"Results"`
+	ReportSchema       string               `json:"ReportSchema"`
+}
+
+// writeReportManifest completes the report of a job.
+func (j *BatchJob) writeReportManifest(ctx context.Context, objAPI ObjectLayer) error {
+	manifest := batchJobReportManifest{
+		Format:             batchJobReportFormat,
+		ReportCreationDate: UTCNow().Format(time.RFC3339),
+		Results:            append([]BatchJobReportFile{}, j.ReportFiles...),
+		ReportSchema:       batchJobReportSchema,
+	}
+	data, err := json.Marshal(manifest)
+	if err != nil {
+		return err
+	}
+	_, err = putBatchJobReport(ctx, objAPI, j.Request.Report.Bucket, path.Join(j.reportDir(), "manifest.json"), data, "application/json")
+	if err != nil || j.Request.Operation.ComplianceAudit == nil {
+		return err
+	}
+	return j.writeComplianceAuditReport(ctx, objAPI)
+}
+
+// putBatchJobReport writes a file of a completion report, and
+// returns its MD5 checksum.
+func putBatchJobReport(ctx context.Context, objAPI ObjectLayer, bucket, object string, data []byte, contentType string) (string, error) {
+	sum := md5.Sum(data)
+	md5Hex := hex.EncodeToString(sum[:])
+	hashReader, err := hash.NewReader(bytes.NewReader(data), int64(len(data)), md5Hex, "", int64(len(data)))
+	if err != nil {
+		return "", err
+	}
+	opts := ObjectOptions{
+		UserDefined:      map[string]string{xhttp.ContentType: contentType},
+		Versioned:        globalBucketVersioningSys.PrefixEnabled(bucket, object),
+		VersionSuspended: globalBucketVersioningSys.PrefixSuspended(bucket, object),
+	}
+	objInfo, err := objAPI.PutObject(ctx, bucket, object, NewPutObjReader(hashReader), opts)
+	if err != nil {
+		return "", err
+	}
+	sendEvent(eventArgs{
+		EventName:  event.ObjectCreatedPut,
+		BucketName: bucket,
+		Object:     objInfo,
+		Host:       "Internal: [Batch]",
+	})
+	return md5Hex, nil
+}
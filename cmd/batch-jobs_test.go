@@ -0,0 +1,639 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"context"
+	"crypto/ed25519"
+	"encoding/base64"
+	"encoding/json"
+	"path"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/minio/minio/internal/bucket/inventory"
+	objectlock "github.com/minio/minio/internal/bucket/object/lock"
+	"github.com/minio/minio/internal/crypto"
+	xhttp "github.com/minio/minio/internal/http"
+	"github.com/minio/minio/internal/kms"
+)
+
+func TestBatchJobs(t *testing.T) {
+	testServer := StartTestServer(t, "ErasureSD")
+	defer testServer.Stop()
+
+	ctx := context.Background()
+	objAPI := testServer.Obj
+	for _, bucket := range []string{"src", "dst", "reports"} {
+		if err := objAPI.MakeBucketWithLocation(ctx, bucket, BucketOptions{}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	putObject := func(bucket, object, data string) {
+		t.Helper()
+		if _, err := objAPI.PutObject(ctx, bucket, object, mustGetPutObjReader(t, strings.NewReader(data), int64(len(data)), "", ""), ObjectOptions{}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	getObject := func(bucket, object string) string {
+		t.Helper()
+		data, err := readBatchJobObject(ctx, objAPI, bucket, object)
+		if err != nil {
+			t.Fatalf("unable to get %s/%s: %v", bucket, object, err)
+		}
+		return string(data)
+	}
+	putObject("src", "a", "object a")
+	putObject("src", "b/c d", "object c")
+	putObject("reports", "manifest.csv", "src,a\nsrc,b%2Fc+d\nsrc,missing\n")
+
+	csvManifest := BatchJobManifest{Format: BatchJobManifestCSV, Bucket: "reports", Object: "manifest.csv"}
+	report := BatchJobReport{Bucket: "reports", Prefix: "batch", Scope: BatchJobReportAllTasks}
+
+	invalid := []BatchJobRequest{
+		{Manifest: csvManifest, Report: report},
+		{Manifest: csvManifest, Report: report, Operation: BatchJobOperation{
+			Copy:    &BatchJobCopy{Bucket: "dst"},
+			Restore: &BatchJobRestore{Days: 1},
+		}},
+		{Manifest: csvManifest, Report: report, Operation: BatchJobOperation{Copy: &BatchJobCopy{Bucket: "unknown"}}},
+		{Manifest: csvManifest, Report: report, Operation: BatchJobOperation{Restore: &BatchJobRestore{}}},
+		{Manifest: csvManifest, Report: report, Operation: BatchJobOperation{Retention: &BatchJobRetention{Mode: "GOVERNANCE", RetainUntilDate: time.Now().Add(-time.Hour)}}},
+		{Manifest: csvManifest, Report: BatchJobReport{Bucket: "reports"}, Operation: BatchJobOperation{Restore: &BatchJobRestore{Days: 1}}},
+		{Manifest: BatchJobManifest{Format: BatchJobManifestCSV, Bucket: "reports", Object: "unknown.csv"}, Report: report, Operation: BatchJobOperation{Restore: &BatchJobRestore{Days: 1}}},
+		{Report: report, Operation: BatchJobOperation{Replicate: &BatchJobReplicate{Bucket: "src"}}},
+	}
+	for i, req := range invalid {
+		if _, err := startBatchJob(ctx, objAPI, req); err == nil {
+			t.Errorf("case %d: expected the job to be rejected", i+1)
+		}
+	}
+
+	// Copy the objects of the CSV manifest.
+	job, err := startBatchJob(ctx, objAPI, BatchJobRequest{
+		Manifest:  csvManifest,
+		Operation: BatchJobOperation{Copy: &BatchJobCopy{Bucket: "dst", Prefix: "copy/"}},
+		Report:    report,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	runActiveBatchJobs(ctx, objAPI)
+	if job, err = loadBatchJob(ctx, objAPI, job.ID); err != nil {
+		t.Fatal(err)
+	}
+	if job.Status != BatchJobComplete || job.Progress != (BatchJobProgress{Succeeded: 2, Failed: 1}) || len(job.ReportFiles) != 2 {
+		t.Fatalf("unexpected job %+v", job)
+	}
+	if data := getObject("dst", "copy/b/c d"); data != "object c" {
+		t.Errorf("unexpected copy %q", data)
+	}
+	for _, file := range job.ReportFiles {
+		rows := getObject("reports", file.Key)
+		switch file.TaskExecutionStatus {
+		case "succeeded":
+			if !strings.HasPrefix(rows, "src,a,,succeeded,,200,") || !strings.Contains(rows, "src,b%2Fc+d,,succeeded") {
+				t.Errorf("unexpected succeeded tasks %q", rows)
+			}
+		case "failed":
+			if !strings.HasPrefix(rows, "src,missing,,failed,NoSuchKey,404,") {
+				t.Errorf("unexpected failed tasks %q", rows)
+			}
+		}
+	}
+	var manifest batchJobReportManifest
+	if err = json.Unmarshal([]byte(getObject("reports", "batch/job-"+job.ID+"/manifest.json")), &manifest); err != nil {
+		t.Fatal(err)
+	}
+	if manifest.Format != batchJobReportFormat || len(manifest.Results) != 2 {
+		t.Errorf("unexpected report manifest %+v", manifest)
+	}
+
+	// Tag the objects of an inventory report.
+	cfg, err := inventory.ParseConfig(strings.NewReader(inventoryConfigXML("daily", "CSV", "Current")))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = generateInventoryReport(ctx, objAPI, "src", *cfg, time.Date(2022, 5, 1, 10, 30, 0, 0, time.UTC)); err != nil {
+		t.Fatal(err)
+	}
+	job, err = startBatchJob(ctx, objAPI, BatchJobRequest{
+		Manifest:  BatchJobManifest{Format: BatchJobManifestInventory, Bucket: "reports", Object: "inventory/src/daily/2022-05-01T10-30Z/manifest.json"},
+		Operation: BatchJobOperation{Tagging: &BatchJobTagging{Tags: map[string]string{"team": "data"}}},
+		Report:    BatchJobReport{Bucket: "reports", Scope: BatchJobReportFailedTasks},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	runActiveBatchJobs(ctx, objAPI)
+	if job, err = loadBatchJob(ctx, objAPI, job.ID); err != nil {
+		t.Fatal(err)
+	}
+	if job.Status != BatchJobComplete || job.Progress.Succeeded != 2 || len(job.ReportFiles) != 0 {
+		t.Fatalf("unexpected job %+v", job)
+	}
+	for _, object := range []string{"a", "b/c d"} {
+		oi, err := objAPI.GetObjectInfo(ctx, "src", object, ObjectOptions{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if oi.UserTags != "team=data" {
+			t.Errorf("unexpected tags %q of %s", oi.UserTags, object)
+		}
+	}
+
+	// Cancelled jobs are not run.
+	job, err = startBatchJob(ctx, objAPI, BatchJobRequest{
+		Manifest:  csvManifest,
+		Operation: BatchJobOperation{Retention: &BatchJobRetention{Mode: "GOVERNANCE", RetainUntilDate: time.Now().Add(time.Hour)}},
+		Report:    report,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if job, err = cancelBatchJob(ctx, objAPI, job.ID); err != nil || job.Status != BatchJobCancelled {
+		t.Fatalf("unable to cancel job %+v: %v", job, err)
+	}
+	if _, err = cancelBatchJob(ctx, objAPI, job.ID); err != errBatchJobNotActive {
+		t.Errorf("expected a cancelled job not to be cancelled again, got %v", err)
+	}
+	runActiveBatchJobs(ctx, objAPI)
+
+	jobs, err := listBatchJobs(ctx, objAPI)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(jobs) != 3 || jobs[2].ID != job.ID || jobs[2].Status != BatchJobCancelled || jobs[2].Progress.Failed != 0 {
+		t.Fatalf("unexpected jobs %+v", jobs)
+	}
+	if _, err = loadBatchJob(ctx, objAPI, "../config"); err != errNoSuchBatchJob {
+		t.Errorf("expected an invalid job ID not to be found, got %v", err)
+	}
+
+	// Jobs resume from their checkpoint.
+	var tasks []string
+	err = readBatchJobManifest(ctx, objAPI, csvManifest, BatchJobCheckpoint{Row: 1}, func(task batchJobTask, pos BatchJobCheckpoint) error {
+		tasks = append(tasks, task.Object)
+		return nil
+	})
+	if err != nil || len(tasks) != 2 || tasks[0] != "b/c d" {
+		t.Errorf("unexpected tasks %q: %v", tasks, err)
+	}
+}
+
+func TestBatchJobReplicateMatch(t *testing.T) {
+	modTime := time.Date(2022, 5, 1, 10, 30, 0, 0, time.UTC)
+	oi := ObjectInfo{Name: "logs/2022/app.log", Size: 1024, ModTime: modTime, UserTags: "team=data&env=prod"}
+	testCases := []struct {
+		filter BatchJobReplicate
+		match  bool
+	}{
+		{BatchJobReplicate{}, true},
+		{BatchJobReplicate{Prefix: "logs/"}, true},
+		{BatchJobReplicate{Prefix: "data/"}, false},
+		{BatchJobReplicate{MinSize: 1024, MaxSize: 2048}, true},
+		{BatchJobReplicate{MinSize: 1025}, false},
+		{BatchJobReplicate{MaxSize: 1023}, false},
+		{BatchJobReplicate{ModifiedAfter: modTime.Add(-time.Hour), ModifiedBefore: modTime.Add(time.Hour)}, true},
+		{BatchJobReplicate{ModifiedAfter: modTime}, false},
+		{BatchJobReplicate{ModifiedBefore: modTime}, false},
+		{BatchJobReplicate{Tags: map[string]string{"team": "data"}}, true},
+		{BatchJobReplicate{Tags: map[string]string{"team": "data", "env": "dev"}}, false},
+	}
+	for i, tc := range testCases {
+		if match := tc.filter.match(oi); match != tc.match {
+			t.Errorf("case %d: expected match %v, got %v", i+1, tc.match, match)
+		}
+	}
+}
+
+func TestBatchJobKeyRotate(t *testing.T) {
+	testServer := StartTestServer(t, "ErasureSD")
+	defer testServer.Stop()
+
+	savedKMS := GlobalKMS
+	defer func() { GlobalKMS = savedKMS }()
+	var err error
+	if GlobalKMS, err = kms.Parse("my-minio-key:5lF+0pJM0OWwlQrvK2S/I7W9mO4a6rJJI7wzj7v09cw="); err != nil {
+		t.Fatal(err)
+	}
+
+	ctx := context.Background()
+	objAPI := testServer.Obj
+	for _, bucket := range []string{"src", "reports"} {
+		if err = objAPI.MakeBucketWithLocation(ctx, bucket, BucketOptions{}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// Only the metadata of the objects is used, their data is not encrypted.
+	objectKeys := make(map[string]crypto.ObjectKey)
+	putObject := func(object string, kind crypto.Type) {
+		t.Helper()
+		metadata := make(map[string]string)
+		if kind != nil {
+			objectKey, err := newEncryptMetadata(context.Background(), kind, "my-minio-key", nil, "src", object, metadata, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			objectKeys[object] = objectKey
+		}
+		if _, err := objAPI.PutObject(ctx, "src", object, mustGetPutObjReader(t, strings.NewReader(object), int64(len(object)), "", ""), ObjectOptions{UserDefined: metadata}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	putObject("sse-s3", crypto.S3)
+	putObject("sse-kms", crypto.S3KMS)
+	putObject("plaintext", nil)
+	sealedKeys := make(map[string]string)
+	for object := range objectKeys {
+		oi, err := objAPI.GetObjectInfo(ctx, "src", object, ObjectOptions{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		sealedKeys[object] = oi.UserDefined[crypto.MetaSealedKeyS3] + oi.UserDefined[crypto.MetaSealedKeyKMS]
+	}
+
+	report := BatchJobReport{Bucket: "reports", Scope: BatchJobReportAllTasks}
+	if _, err = startBatchJob(ctx, objAPI, BatchJobRequest{
+		Operation: BatchJobOperation{KeyRotate: &BatchJobKeyRotate{Bucket: "src", KeyID: "unknown-key"}},
+		Report:    report,
+	}); err == nil {
+		t.Error("expected the job with an unknown key to be rejected")
+	}
+	job, err := startBatchJob(ctx, objAPI, BatchJobRequest{
+		Operation: BatchJobOperation{KeyRotate: &BatchJobKeyRotate{Bucket: "src"}},
+		Report:    report,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	runActiveBatchJobs(ctx, objAPI)
+	if job, err = loadBatchJob(ctx, objAPI, job.ID); err != nil {
+		t.Fatal(err)
+	}
+	if job.Status != BatchJobComplete || job.Progress.Succeeded != 2 || job.Progress.Failed != 0 || job.Progress.Remaining != 0 {
+		t.Fatalf("unexpected job %+v", job)
+	}
+	for object, objectKey := range objectKeys {
+		oi, err := objAPI.GetObjectInfo(ctx, "src", object, ObjectOptions{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if sealedKey := oi.UserDefined[crypto.MetaSealedKeyS3] + oi.UserDefined[crypto.MetaSealedKeyKMS]; sealedKey == sealedKeys[object] {
+			t.Errorf("expected the object key of %s to be sealed again", object)
+		}
+		var key crypto.ObjectKey
+		if crypto.S3.IsEncrypted(oi.UserDefined) {
+			key, err = crypto.S3.UnsealObjectKey(GlobalKMS, oi.UserDefined, "src", object)
+		} else {
+			key, err = crypto.S3KMS.UnsealObjectKey(GlobalKMS, oi.UserDefined, "src", object)
+		}
+		if err != nil || key != objectKey {
+			t.Errorf("unable to unseal the object key of %s: %v", object, err)
+		}
+	}
+}
+
+func TestBatchJobObjectLock(t *testing.T) {
+	testServer := StartTestServer(t, "ErasureSD")
+	defer testServer.Stop()
+
+	ctx := context.Background()
+	objAPI := testServer.Obj
+	if err := objAPI.MakeBucketWithLocation(ctx, "locked", BucketOptions{LockEnabled: true}); err != nil {
+		t.Fatal(err)
+	}
+	if err := objAPI.MakeBucketWithLocation(ctx, "unlocked", BucketOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	for _, object := range []string{"a/1", "a/2", "b/1"} {
+		if _, err := objAPI.PutObject(ctx, "locked", object, mustGetPutObjReader(t, strings.NewReader(object), int64(len(object)), "", ""), ObjectOptions{Versioned: true}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	manifest := "locked,b%2F1\nunlocked,a\n"
+	if _, err := objAPI.PutObject(ctx, "locked", "manifest.csv", mustGetPutObjReader(t, strings.NewReader(manifest), int64(len(manifest)), "", ""), ObjectOptions{Versioned: true}); err != nil {
+		t.Fatal(err)
+	}
+
+	runJob := func(req BulkObjectLockRequest) *BatchJob {
+		t.Helper()
+		job, err := startBatchJob(ctx, objAPI, req.batchJobRequest("locked"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		runActiveBatchJobs(ctx, objAPI)
+		if job, err = loadBatchJob(ctx, objAPI, job.ID); err != nil {
+			t.Fatal(err)
+		}
+		return job
+	}
+	objectLock := func(object string) (objectlock.ObjectRetention, objectlock.ObjectLegalHold) {
+		t.Helper()
+		oi, err := objAPI.GetObjectInfo(ctx, "locked", object, ObjectOptions{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return objectlock.GetObjectRetentionMeta(oi.UserDefined), objectlock.GetObjectLegalHoldMeta(oi.UserDefined)
+	}
+
+	invalid := []BatchJobRequest{
+		BulkObjectLockRequest{LegalHold: &BatchJobLegalHold{Status: "MAYBE"}}.batchJobRequest("locked"),
+		BulkObjectLockRequest{Retention: &BatchJobRetention{Mode: "GOVERNANCE", Remove: true}}.batchJobRequest("locked"),
+		BulkObjectLockRequest{LegalHold: &BatchJobLegalHold{Status: "ON"}}.batchJobRequest("unlocked"),
+	}
+	for i, req := range invalid {
+		if _, err := startBatchJob(ctx, objAPI, req); err == nil {
+			t.Errorf("case %d: expected the job to be rejected", i+1)
+		}
+	}
+
+	// Legal hold on all the versions below a prefix.
+	job := runJob(BulkObjectLockRequest{Prefix: "a/", LegalHold: &BatchJobLegalHold{Status: "ON"}})
+	if job.Status != BatchJobComplete || job.Progress.Succeeded != 2 || job.Progress.Failed != 0 || job.Progress.Remaining != 0 {
+		t.Fatalf("unexpected job %+v", job)
+	}
+	for object, status := range map[string]objectlock.LegalHoldStatus{"a/1": objectlock.LegalHoldOn, "a/2": objectlock.LegalHoldOn, "b/1": ""} {
+		if _, lh := objectLock(object); lh.Status != status {
+			t.Errorf("expected legal hold %q on %s, got %q", status, object, lh.Status)
+		}
+	}
+
+	// Retention of the objects of a manifest, only in the bucket.
+	job = runJob(BulkObjectLockRequest{
+		Manifest:  "manifest.csv",
+		Retention: &BatchJobRetention{Mode: objectlock.RetGovernance, RetainUntilDate: time.Now().Add(time.Hour)},
+	})
+	if job.Status != BatchJobComplete || job.Progress != (BatchJobProgress{Succeeded: 1, Failed: 1}) {
+		t.Fatalf("unexpected job %+v", job)
+	}
+	if ret, _ := objectLock("b/1"); ret.Mode != objectlock.RetGovernance {
+		t.Errorf("expected a governance retention on b/1, got %q", ret.Mode)
+	}
+
+	// Removing a governance retention needs to bypass it.
+	job = runJob(BulkObjectLockRequest{Prefix: "b/", Retention: &BatchJobRetention{Remove: true}})
+	if job.Progress.Succeeded != 0 || job.Progress.Failed != 1 {
+		t.Fatalf("unexpected job %+v", job)
+	}
+	job = runJob(BulkObjectLockRequest{Prefix: "b/", Retention: &BatchJobRetention{Remove: true, BypassGovernanceRetention: true}})
+	if job.Progress.Succeeded != 1 || job.Progress.Failed != 0 {
+		t.Fatalf("unexpected job %+v", job)
+	}
+	if ret, _ := objectLock("b/1"); ret.Mode.Valid() {
+		t.Errorf("expected the retention of b/1 to be removed, got %q", ret.Mode)
+	}
+}
+
+func TestBatchJobComplianceAudit(t *testing.T) {
+	testServer := StartTestServer(t, "ErasureSD")
+	defer testServer.Stop()
+
+	ctx := context.Background()
+	objAPI := testServer.Obj
+	for _, bucket := range []string{"audited", "unlocked"} {
+		if err := objAPI.MakeBucketWithLocation(ctx, bucket, BucketOptions{LockEnabled: bucket == "audited"}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := objAPI.MakeBucketWithLocation(ctx, "reports", BucketOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	lockConfig := `<ObjectLockConfiguration><ObjectLockEnabled>Enabled</ObjectLockEnabled><Rule><DefaultRetention><Mode>COMPLIANCE</Mode><Days>1</Days></DefaultRetention></Rule></ObjectLockConfiguration>`
+	if err := globalBucketMetadataSys.Update(ctx, "audited", objectLockConfig, []byte(lockConfig)); err != nil {
+		t.Fatal(err)
+	}
+
+	retainUntil := time.Now().Add(time.Hour).UTC().Format(time.RFC3339)
+	versions := make(map[string]string)
+	for object, mode := range map[string]objectlock.RetMode{"a": objectlock.RetCompliance, "b": objectlock.RetGovernance, "c": "", "d": objectlock.RetCompliance} {
+		meta := map[string]string{}
+		if mode != "" {
+			meta[strings.ToLower(xhttp.AmzObjectLockMode)] = string(mode)
+			meta[strings.ToLower(xhttp.AmzObjectLockRetainUntilDate)] = retainUntil
+		}
+		oi, err := objAPI.PutObject(ctx, "audited", object, mustGetPutObjReader(t, strings.NewReader(object), int64(len(object)), "", ""), ObjectOptions{Versioned: true, UserDefined: meta})
+		if err != nil {
+			t.Fatal(err)
+		}
+		versions[object] = oi.VersionID
+	}
+
+	audit := func(bucket, scope string) BatchJobRequest {
+		return BatchJobRequest{
+			Operation: BatchJobOperation{ComplianceAudit: &BatchJobComplianceAudit{Bucket: bucket}},
+			Report:    BatchJobReport{Bucket: "reports", Scope: scope},
+		}
+	}
+	for i, req := range []BatchJobRequest{audit("unlocked", BatchJobReportAllTasks), audit("audited", BatchJobReportFailedTasks)} {
+		if _, err := startBatchJob(ctx, objAPI, req); err == nil {
+			t.Errorf("case %d: expected the job to be rejected", i+1)
+		}
+	}
+	runAudit := func() *BatchJob {
+		t.Helper()
+		job, err := startBatchJob(ctx, objAPI, audit("audited", BatchJobReportAllTasks))
+		if err != nil {
+			t.Fatal(err)
+		}
+		runActiveBatchJobs(ctx, objAPI)
+		if job, err = loadBatchJob(ctx, objAPI, job.ID); err != nil {
+			t.Fatal(err)
+		}
+		if job.Status != BatchJobComplete {
+			t.Fatalf("unexpected job %+v", job)
+		}
+		return job
+	}
+	readResults := func(job *BatchJob, status string) string {
+		t.Helper()
+		var results string
+		for _, file := range job.ReportFiles {
+			if file.TaskExecutionStatus == status {
+				data, err := readBatchJobObject(ctx, objAPI, file.Bucket, file.Key)
+				if err != nil {
+					t.Fatal(err)
+				}
+				results += string(data)
+			}
+		}
+		return results
+	}
+
+	first := runAudit()
+	if first.Progress.Succeeded != 2 || first.Progress.Failed != 2 || first.Request.Operation.ComplianceAudit.PreviousJob != "" {
+		t.Fatalf("unexpected job %+v", first)
+	}
+	if failed := readResults(first, "failed"); !strings.Contains(failed, "Retention mode GOVERNANCE") || !strings.Contains(failed, "No retention") {
+		t.Errorf("unexpected violations %q", failed)
+	}
+
+	// The report is signed by the key of the cluster.
+	data, err := readBatchJobObject(ctx, objAPI, "reports", path.Join(first.reportDir(), "audit.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	sig, err := readBatchJobObject(ctx, objAPI, "reports", path.Join(first.reportDir(), "audit.json.sig"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	signature, err := base64.StdEncoding.DecodeString(string(sig))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !ed25519.Verify(complianceAuditKey().Public().(ed25519.PublicKey), data, signature) {
+		t.Error("invalid signature of the audit report")
+	}
+	var report complianceAuditReport
+	if err = json.Unmarshal(data, &report); err != nil {
+		t.Fatal(err)
+	}
+	if report.JobID != first.ID || report.Progress != first.Progress || len(report.Results) != 2 || report.Results[0].SHA256Checksum == "" {
+		t.Errorf("unexpected report %+v", report)
+	}
+
+	// A version retained by the first audit was deleted meanwhile.
+	if _, err = objAPI.DeleteObject(ctx, "audited", "d", ObjectOptions{VersionID: versions["d"], Versioned: true}); err != nil {
+		t.Fatal(err)
+	}
+	second := runAudit()
+	if second.Request.Operation.ComplianceAudit.PreviousJob != first.ID {
+		t.Fatalf("expected the audit to follow %s, got %+v", first.ID, second)
+	}
+	if second.Progress.Succeeded != 2 || second.Progress.Failed != 3 {
+		t.Fatalf("unexpected job %+v", second)
+	}
+	if failed := readResults(second, "failed"); !strings.Contains(failed, "audited,d,"+versions["d"]+",failed,XMinioComplianceViolation,409,Deleted before its retention expired") {
+		t.Errorf("unexpected violations %q", failed)
+	}
+	if succeeded := readResults(second, "succeeded"); strings.Count(succeeded, "\n") != 1 || !strings.Contains(succeeded, complianceAuditRetained+retainUntil) {
+		t.Errorf("unexpected results %q", succeeded)
+	}
+}
+
+func TestBatchJobPurgeVersions(t *testing.T) {
+	testServer := StartTestServer(t, "ErasureSD")
+	defer testServer.Stop()
+
+	ctx := context.Background()
+	objAPI := testServer.Obj
+	for _, bucket := range []string{"versioned", "reports"} {
+		if err := objAPI.MakeBucketWithLocation(ctx, bucket, BucketOptions{}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	putObject := func(object string, meta map[string]string) {
+		t.Helper()
+		if _, err := objAPI.PutObject(ctx, "versioned", object, mustGetPutObjReader(t, strings.NewReader(object), int64(len(object)), "", ""), ObjectOptions{Versioned: true, UserDefined: meta}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	deleteObject := func(object string) {
+		t.Helper()
+		if _, err := objAPI.DeleteObject(ctx, "versioned", object, ObjectOptions{Versioned: true}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// a: two noncurrent versions, b: a noncurrent version under a delete
+	// marker, c: a delete marker alone, d: a retained noncurrent version.
+	putObject("a", nil)
+	putObject("a", nil)
+	putObject("a", nil)
+	putObject("b", nil)
+	deleteObject("b")
+	putObject("c", nil)
+	oi, err := objAPI.GetObjectInfo(ctx, "versioned", "c", ObjectOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	deleteObject("c")
+	if _, err = objAPI.DeleteObject(ctx, "versioned", "c", ObjectOptions{VersionID: oi.VersionID}); err != nil {
+		t.Fatal(err)
+	}
+	putObject("d", map[string]string{
+		strings.ToLower(xhttp.AmzObjectLockMode):            string(objectlock.RetGovernance),
+		strings.ToLower(xhttp.AmzObjectLockRetainUntilDate): time.Now().Add(time.Hour).UTC().Format(time.RFC3339),
+	})
+	putObject("d", nil)
+
+	runJob := func(p BatchJobPurgeVersions) *BatchJob {
+		t.Helper()
+		job, err := startBatchJob(ctx, objAPI, BatchJobRequest{
+			Operation: BatchJobOperation{PurgeVersions: &p},
+			Report:    BatchJobReport{Bucket: "reports", Scope: BatchJobReportFailedTasks},
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		runActiveBatchJobs(ctx, objAPI)
+		if job, err = loadBatchJob(ctx, objAPI, job.ID); err != nil {
+			t.Fatal(err)
+		}
+		if job.Status != BatchJobComplete || job.Progress.Failed != 0 {
+			t.Fatalf("unexpected job %+v", job)
+		}
+		return job
+	}
+	countVersions := func() map[string]int {
+		t.Helper()
+		res, err := objAPI.ListObjectVersions(ctx, "versioned", "", "", "", "", maxObjectList)
+		if err != nil {
+			t.Fatal(err)
+		}
+		counts := make(map[string]int)
+		for _, oi := range res.Objects {
+			counts[oi.Name]++
+		}
+		return counts
+	}
+
+	if _, err = startBatchJob(ctx, objAPI, BatchJobRequest{
+		Operation: BatchJobOperation{PurgeVersions: &BatchJobPurgeVersions{Bucket: "versioned", NoncurrentDays: -1}},
+		Report:    BatchJobReport{Bucket: "reports", Scope: BatchJobReportFailedTasks},
+	}); err == nil {
+		t.Error("expected a negative number of days to be rejected")
+	}
+
+	job := runJob(BatchJobPurgeVersions{Bucket: "versioned", NoncurrentDays: 1, DeleteMarkers: true})
+	if job.Progress.Succeeded != 1 {
+		t.Fatalf("expected only the delete marker of c to be purged, got %+v", job)
+	}
+	job = runJob(BatchJobPurgeVersions{Bucket: "versioned", DeleteMarkers: true, DryRun: true})
+	if job.Progress.Succeeded != 3 || job.Progress.Bytes != 3 {
+		t.Fatalf("unexpected dry run %+v", job)
+	}
+	if counts := countVersions(); counts["a"] != 3 || counts["b"] != 2 || counts["d"] != 2 {
+		t.Fatalf("expected no version to be deleted by the dry run, got %v", counts)
+	}
+
+	versions := atomic.LoadUint64(&globalBatchPurgeStats.versions)
+	job = runJob(BatchJobPurgeVersions{Bucket: "versioned", DeleteMarkers: true, MaxDeletesPerSecond: 100})
+	if job.Progress.Succeeded != 3 || atomic.LoadUint64(&globalBatchPurgeStats.versions)-versions != 3 {
+		t.Fatalf("unexpected job %+v", job)
+	}
+	// The delete marker of b is alone now.
+	job = runJob(BatchJobPurgeVersions{Bucket: "versioned", DeleteMarkers: true})
+	if job.Progress.Succeeded != 1 {
+		t.Fatalf("unexpected job %+v", job)
+	}
+	if counts := countVersions(); len(counts) != 2 || counts["a"] != 1 || counts["d"] != 2 {
+		t.Fatalf("unexpected versions left %v", counts)
+	}
+}
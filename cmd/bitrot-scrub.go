@@ -0,0 +1,243 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"sync/atomic"
+	"time"
+
+	"github.com/minio/madmin-go"
+	"github.com/minio/minio/internal/logger"
+)
+
+const (
+	// bitrotScrubStateFile is the scrub progress saved on each drive.
+	bitrotScrubStateFile = ".scrub.json"
+
+	// bitrotScrubRecheck is how often the scrub schedule of a drive is checked.
+	bitrotScrubRecheck = time.Minute
+)
+
+// bitrotScrubStats are the bitrot scrub counters of this node.
+type bitrotScrubStats struct {
+	activeDrives    int64
+	objectsVerified uint64
+	bytesVerified   uint64
+	corrupted       uint64
+}
+
+var globalBitrotScrubStats bitrotScrubStats
+
+// bitrotScrubState is the progress of the bitrot scrub of a drive, a scrub
+// interrupted by a restart is resumed after the last object verified.
+type bitrotScrubState struct {
+	Started         time.Time `json:"started"`
+	Finished        time.Time `json:"finished"`
+	Bucket          string    `json:"bucket,omitempty"`
+	Object          string    `json:"object,omitempty"`
+	ObjectsVerified uint64    `json:"objectsVerified"`
+	Corrupted       uint64    `json:"corrupted"`
+}
+
+// due returns true when a scrub is in progress or the last scrub started
+// at least interval ago.
+func (st bitrotScrubState) due(interval time.Duration, now time.Time) bool {
+	if interval <= 0 {
+		return false
+	}
+	return st.Bucket != "" || now.Sub(st.Started) >= interval
+}
+
+func loadBitrotScrubState(ctx context.Context, disk StorageAPI) (st bitrotScrubState, err error) {
+	b, err := disk.ReadAll(ctx, minioMetaBucket, pathJoin(bucketMetaPrefix, bitrotScrubStateFile))
+	if err != nil {
+		if errors.Is(err, errFileNotFound) {
+			return st, nil
+		}
+		return st, err
+	}
+	err = json.Unmarshal(b, &st)
+	return st, err
+}
+
+func saveBitrotScrubState(ctx context.Context, disk StorageAPI, st bitrotScrubState) error {
+	b, err := json.Marshal(st)
+	if err != nil {
+		return err
+	}
+	return disk.WriteAll(ctx, minioMetaBucket, pathJoin(bucketMetaPrefix, bitrotScrubStateFile), b)
+}
+
+// initBitrotScrub starts scrubbing the local drives in the background,
+// following the heal scrub configuration.
+func initBitrotScrub(ctx context.Context, objAPI ObjectLayer) {
+	if _, ok := objAPI.(*erasureServerPools); !ok {
+		return
+	}
+	for _, disk := range globalLocalDrives {
+		go scrubDriveLoop(ctx, disk)
+	}
+}
+
+func scrubDriveLoop(ctx context.Context, disk StorageAPI) {
+	timer := time.NewTimer(bitrotScrubRecheck)
+	defer timer.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-timer.C:
+		}
+
+		interval := globalHealConfig.GetScrubInterval()
+		if interval > 0 && disk.IsOnline() && disk.Healing() == nil {
+			st, err := loadBitrotScrubState(ctx, disk)
+			if err == nil && st.due(interval, time.Now()) {
+				err = scrubDrive(ctx, disk, &st)
+			}
+			if err != nil && !errors.Is(err, context.Canceled) {
+				logger.LogIf(ctx, fmt.Errorf("unable to scrub drive %s: %w", disk, err))
+			}
+		}
+		timer.Reset(bitrotScrubRecheck)
+	}
+}
+
+// scrubDrive verifies the bitrot hashes of all the object versions on
+// disk, starting or resuming the scrub recorded in st. Object versions
+// found corrupted are queued for healing.
+func scrubDrive(ctx context.Context, disk StorageAPI, st *bitrotScrubState) error {
+	if st.Bucket == "" {
+		*st = bitrotScrubState{Started: time.Now().UTC(), Finished: st.Finished}
+	}
+
+	vols, err := disk.ListVols(ctx)
+	if err != nil {
+		return err
+	}
+	sort.Slice(vols, func(i, j int) bool { return vols[i].Name < vols[j].Name })
+
+	atomic.AddInt64(&globalBitrotScrubStats.activeDrives, 1)
+	defer atomic.AddInt64(&globalBitrotScrubStats.activeDrives, -1)
+
+	throttle := &dynamicBandwidth{limit: func() uint64 {
+		return globalHealConfig.GetScrubBandwidth()
+	}}
+	lastSave := time.Now()
+
+	for _, vol := range vols {
+		if isMinioMetaBucketName(vol.Name) || vol.Name < st.Bucket {
+			continue
+		}
+		var forwardTo string
+		if vol.Name == st.Bucket {
+			forwardTo = st.Object
+		}
+		st.Bucket, st.Object = vol.Name, forwardTo
+
+		scrubEntry := func(entry metaCacheEntry) {
+			if entry.isDir() {
+				return
+			}
+			fivs, err := entry.fileInfoVersions(vol.Name)
+			if err != nil {
+				return
+			}
+			for _, fi := range fivs.Versions {
+				size, err := scrubVersion(ctx, disk, vol.Name, fi)
+				if err != nil && !errors.Is(err, context.Canceled) {
+					st.Corrupted++
+					atomic.AddUint64(&globalBitrotScrubStats.corrupted, 1)
+					logger.LogIf(ctx, fmt.Errorf("bitrot scrub found %s/%s (%s) corrupted on %s: %w",
+						vol.Name, fi.Name, fi.VersionID, disk, err))
+					healObject(vol.Name, fi.Name, fi.VersionID, madmin.HealDeepScan)
+				}
+				st.ObjectsVerified++
+				atomic.AddUint64(&globalBitrotScrubStats.objectsVerified, 1)
+				atomic.AddUint64(&globalBitrotScrubStats.bytesVerified, uint64(size))
+				throttle.waitBytes(ctx, size)
+			}
+			st.Object = entry.name
+			if time.Since(lastSave) > time.Minute {
+				logger.LogIf(ctx, saveBitrotScrubState(ctx, disk, *st))
+				lastSave = time.Now()
+			}
+
+			// Wait and proceed if there are active requests
+			waitForLowHTTPReq()
+		}
+
+		err := listPathRaw(ctx, listPathRawOptions{
+			disks:          []StorageAPI{disk},
+			bucket:         vol.Name,
+			recursive:      true,
+			forwardTo:      forwardTo,
+			minDisks:       1,
+			reportNotFound: false,
+			agreed:         scrubEntry,
+			partial: func(entries metaCacheEntries, nAgreed int, errs []error) {
+				if entry, n := entries.firstFound(); n > 0 {
+					scrubEntry(*entry)
+				}
+			},
+		})
+		if err != nil {
+			logger.LogIf(ctx, saveBitrotScrubState(ctx, disk, *st))
+			return err
+		}
+	}
+
+	st.Bucket, st.Object = "", ""
+	st.Finished = time.Now().UTC()
+	return saveBitrotScrubState(ctx, disk, *st)
+}
+
+// scrubVersion verifies the bitrot hashes of the parts of an object
+// version on disk, it returns the number of bytes verified.
+func scrubVersion(ctx context.Context, disk StorageAPI, bucket string, fi FileInfo) (int64, error) {
+	if fi.Deleted || fi.IsRemote() || len(fi.Parts) == 0 {
+		return 0, nil
+	}
+	size := fi.Erasure.ShardFileSize(fi.Size)
+	if !fi.InlineData() {
+		return size, disk.VerifyFile(ctx, bucket, fi.Name, fi)
+	}
+
+	// Inlined data is only read on demand.
+	fi, err := disk.ReadVersion(ctx, bucket, fi.Name, fi.VersionID, true)
+	if err != nil {
+		if errors.Is(err, errFileNotFound) || errors.Is(err, errFileVersionNotFound) {
+			// Removed since listed.
+			return 0, nil
+		}
+		return 0, err
+	}
+	checksumInfo := fi.Erasure.GetChecksumInfo(fi.Parts[0].Number)
+	return size, bitrotVerify(bytes.NewReader(fi.Data),
+		int64(len(fi.Data)),
+		size,
+		checksumInfo.Algorithm,
+		checksumInfo.Hash, fi.Erasure.ShardSize())
+}
@@ -0,0 +1,116 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"testing"
+	"time"
+)
+
+func TestBitrotScrubStateDue(t *testing.T) {
+	now := time.Now()
+	testCases := []struct {
+		st       bitrotScrubState
+		interval time.Duration
+		due      bool
+	}{
+		// Scrubbing disabled.
+		{bitrotScrubState{}, 0, false},
+		{bitrotScrubState{Bucket: "bucket"}, 0, false},
+		// Never scrubbed.
+		{bitrotScrubState{}, 24 * time.Hour, true},
+		// Scrubbed recently.
+		{bitrotScrubState{Started: now.Add(-time.Hour), Finished: now}, 24 * time.Hour, false},
+		// Scrubbed a while ago.
+		{bitrotScrubState{Started: now.Add(-25 * time.Hour), Finished: now.Add(-24 * time.Hour)}, 24 * time.Hour, true},
+		// Scrub in progress.
+		{bitrotScrubState{Started: now.Add(-time.Hour), Bucket: "bucket"}, 24 * time.Hour, true},
+	}
+	for i, tc := range testCases {
+		if due := tc.st.due(tc.interval, now); due != tc.due {
+			t.Errorf("case %d: expected due %v, got %v", i+1, tc.due, due)
+		}
+	}
+}
+
+func TestScrubDrive(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	objLayer, fsDirs, err := prepareErasure16(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer objLayer.Shutdown(context.Background())
+	defer removeRoots(fsDirs)
+
+	bucket, object := "bucket", "object"
+	if err = objLayer.MakeBucketWithLocation(ctx, bucket, BucketOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	data := make([]byte, 4<<20)
+	rand.Read(data)
+	if _, err = objLayer.PutObject(ctx, bucket, object, mustGetPutObjReader(t, bytes.NewReader(data), int64(len(data)), "", ""), ObjectOptions{}); err != nil {
+		t.Fatal(err)
+	}
+
+	disk := objLayer.(*erasureServerPools).serverPools[0].sets[0].getDisks()[0]
+	fi, err := disk.ReadVersion(ctx, bucket, object, "", false)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var st bitrotScrubState
+	if err = scrubDrive(ctx, disk, &st); err != nil {
+		t.Fatal(err)
+	}
+	if st.ObjectsVerified != 1 || st.Corrupted != 0 || st.Bucket != "" || st.Finished.IsZero() {
+		t.Fatalf("unexpected scrub state %+v", st)
+	}
+
+	// Corrupt the part on the drive, keeping its size.
+	partPath := pathJoin(object, fi.DataDir, "part.1")
+	part, err := disk.ReadAll(ctx, bucket, partPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	part[len(part)/2] ^= 0xff
+	if err = disk.WriteAll(ctx, bucket, partPath, part); err != nil {
+		t.Fatal(err)
+	}
+
+	st = bitrotScrubState{}
+	if err = scrubDrive(ctx, disk, &st); err != nil {
+		t.Fatal(err)
+	}
+	if st.ObjectsVerified != 1 || st.Corrupted != 1 {
+		t.Fatalf("expected the object to be found corrupted, got %+v", st)
+	}
+
+	// The scrub state is saved on the drive.
+	saved, err := loadBitrotScrubState(ctx, disk)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if saved.Corrupted != 1 || !saved.Finished.Equal(st.Finished) {
+		t.Fatalf("unexpected saved scrub state %+v", saved)
+	}
+}
@@ -25,7 +25,10 @@ import (
 	"hash"
 	"io"
 
+	"github.com/cespare/xxhash/v2"
 	"github.com/minio/highwayhash"
+	"github.com/minio/minio/internal/config/storageclass"
+	"github.com/minio/minio/internal/hash/blake3"
 	"github.com/minio/minio/internal/hash/sha256"
 	"golang.org/x/crypto/blake2b"
 
@@ -41,6 +44,25 @@ var bitrotAlgorithms = map[BitrotAlgorithm]string{
 	BLAKE2b512:      "blake2b",
 	HighwayHash256:  "highwayhash256",
 	HighwayHash256S: "highwayhash256S",
+	XXHash64S:       "xxhash64S",
+	BLAKE3S:         "blake3S",
+}
+
+// bitrotConfigAlgorithms maps the bitrot algorithms of the storage
+// class config to the algorithms new objects are protected with.
+var bitrotConfigAlgorithms = map[string]BitrotAlgorithm{
+	storageclass.BitrotHighwayHash: HighwayHash256S,
+	storageclass.BitrotXXHash64:    XXHash64S,
+	storageclass.BitrotBLAKE3:      BLAKE3S,
+}
+
+// bitrotAlgorithmForSC returns the bitrot algorithm new objects
+// of the storage class sc are protected with.
+func bitrotAlgorithmForSC(sc string) BitrotAlgorithm {
+	if algo, ok := bitrotConfigAlgorithms[globalStorageClass.BitrotAlgorithm(sc)]; ok {
+		return algo
+	}
+	return DefaultBitrotAlgorithm
 }
 
 // New returns a new hash.Hash calculating the given bitrot algorithm.
@@ -57,6 +79,10 @@ func (a BitrotAlgorithm) New() hash.Hash {
 	case HighwayHash256S:
 		hh, _ := highwayhash.New(magicHighwayHash256Key) // New will never return error since key is 256 bit
 		return hh
+	case XXHash64S:
+		return xxhash.New()
+	case BLAKE3S:
+		return blake3.New()
 	default:
 		logger.CriticalIf(GlobalContext, errors.New("Unsupported bitrot algorithm"))
 		return nil
@@ -69,6 +95,16 @@ func (a BitrotAlgorithm) Available() bool {
 	return ok
 }
 
+// streaming reports whether the given algorithm protects each shard
+// with its own checksum stored ahead of the shard.
+func (a BitrotAlgorithm) streaming() bool {
+	switch a {
+	case HighwayHash256S, XXHash64S, BLAKE3S:
+		return true
+	}
+	return false
+}
+
 // String returns the string identifier for a given bitrot algorithm.
 // If the algorithm is not supported String panics.
 func (a BitrotAlgorithm) String() string {
@@ -103,14 +139,14 @@ func BitrotAlgorithmFromString(s string) (a BitrotAlgorithm) {
 }
 
 func newBitrotWriter(disk StorageAPI, volume, filePath string, length int64, algo BitrotAlgorithm, shardSize int64) io.Writer {
-	if algo == HighwayHash256S {
+	if algo.streaming() {
 		return newStreamingBitrotWriter(disk, volume, filePath, length, algo, shardSize)
 	}
 	return newWholeBitrotWriter(disk, volume, filePath, algo, shardSize)
 }
 
 func newBitrotReader(disk StorageAPI, data []byte, bucket string, filePath string, tillOffset int64, algo BitrotAlgorithm, sum []byte, shardSize int64) io.ReaderAt {
-	if algo == HighwayHash256S {
+	if algo.streaming() {
 		return newStreamingBitrotReader(disk, data, bucket, filePath, tillOffset, algo, shardSize)
 	}
 	return newWholeBitrotReader(disk, bucket, filePath, algo, tillOffset, sum)
@@ -148,7 +184,7 @@ func bitrotWriterSum(w io.Writer) []byte {
 
 // Returns the size of the file with bitrot protection
 func bitrotShardFileSize(size int64, shardSize int64, algo BitrotAlgorithm) int64 {
-	if algo != HighwayHash256S {
+	if !algo.streaming() {
 		return size
 	}
 	return ceilFrac(size, shardSize)*int64(algo.New().Size()) + size
@@ -156,7 +192,7 @@ func bitrotShardFileSize(size int64, shardSize int64, algo BitrotAlgorithm) int6
 
 // bitrotVerify a single stream of data.
 func bitrotVerify(r io.Reader, wantSize, partSize int64, algo BitrotAlgorithm, want []byte, shardSize int64) error {
-	if algo != HighwayHash256S {
+	if !algo.streaming() {
 		h := algo.New()
 		if n, err := io.Copy(h, r); err != nil || n != wantSize {
 			// Premature failure in reading the object, file is corrupt.
@@ -221,6 +257,8 @@ func bitrotSelfTest() {
 		BLAKE2b512:      "e519b7d84b1c3c917985f544773a35cf265dcab10948be3550320d156bab612124a5ae2ae5a8c73c0eea360f68b0e28136f26e858756dbfe7375a7389f26c669",
 		HighwayHash256:  "39c0407ed3f01b18d22c85db4aeff11e060ca5f43131b0126731ca197cd42313",
 		HighwayHash256S: "39c0407ed3f01b18d22c85db4aeff11e060ca5f43131b0126731ca197cd42313",
+		XXHash64S:       "1b842cd20edcd5ab",
+		BLAKE3S:         "59d11fa729e3b687a5352c7e65dc8e60a7e28100c95da6b0a3b9100e1ac6bd52",
 	}
 	for algorithm := range bitrotAlgorithms {
 		if !algorithm.Available() {
@@ -0,0 +1,182 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/minio/minio/internal/http/stats"
+	"golang.org/x/time/rate"
+)
+
+// BucketBandwidthLimits holds the bandwidth limits of the S3 traffic
+// of a bucket in bytes per second across the cluster, zero means
+// unlimited. Every node enforces an equal share of the limits.
+type BucketBandwidthLimits struct {
+	Ingress int64 `json:"ingress"`
+	Egress  int64 `json:"egress"`
+}
+
+// IsEmpty returns whether no limit is set.
+func (l BucketBandwidthLimits) IsEmpty() bool {
+	return l.Ingress == 0 && l.Egress == 0
+}
+
+// parseBucketBandwidthLimits parses the bandwidth limits of bucket.
+func parseBucketBandwidthLimits(bucket string, data []byte) (*BucketBandwidthLimits, error) {
+	limits := &BucketBandwidthLimits{}
+	if err := json.Unmarshal(data, limits); err != nil {
+		return limits, err
+	}
+	if limits.Ingress < 0 || limits.Egress < 0 {
+		return limits, fmt.Errorf("invalid bandwidth limits for bucket %s: limits cannot be negative", bucket)
+	}
+	return limits, nil
+}
+
+// bandwidthThrottle limits the rate of the traffic of a bucket
+// in one direction and accounts how long the traffic was delayed.
+type bandwidthThrottle struct {
+	// Atomic counters, placed first so alignment is guaranteed.
+	waitingRequests int64
+	throttledNanos  uint64
+
+	*rate.Limiter
+}
+
+// newBandwidthThrottle returns a throttle passing the node share
+// of limit bytes per second, or nil if limit is not set.
+func newBandwidthThrottle(limit int64) *bandwidthThrottle {
+	if limit <= 0 {
+		return nil
+	}
+	perNode := limit / int64(totalNodeCount())
+	if perNode < 1 {
+		perNode = 1
+	}
+	return &bandwidthThrottle{Limiter: rate.NewLimiter(rate.Limit(perNode), int(perNode))}
+}
+
+// WaitN blocks until n bytes may pass or ctx is done.
+func (t *bandwidthThrottle) WaitN(ctx context.Context, n int) error {
+	start := time.Now()
+	atomic.AddInt64(&t.waitingRequests, 1)
+	err := t.Limiter.WaitN(ctx, n)
+	atomic.AddInt64(&t.waitingRequests, -1)
+	atomic.AddUint64(&t.throttledNanos, uint64(time.Since(start)))
+	return err
+}
+
+// bucketThrottle holds the throttles of the limited directions of a bucket.
+type bucketThrottle struct {
+	limits  BucketBandwidthLimits
+	ingress *bandwidthThrottle
+	egress  *bandwidthThrottle
+}
+
+// bucketThrottles holds the throttles of the buckets
+// with bandwidth limits, the zero value is ready to use.
+type bucketThrottles struct {
+	mu      sync.RWMutex
+	buckets map[string]*bucketThrottle
+}
+
+// set applies the limits of bucket, the throttles of a bucket
+// are kept as long as its limits are unchanged.
+func (t *bucketThrottles) set(bucket string, limits *BucketBandwidthLimits) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if limits == nil || limits.IsEmpty() {
+		delete(t.buckets, bucket)
+		return
+	}
+	if current, ok := t.buckets[bucket]; ok && current.limits == *limits {
+		return
+	}
+	if t.buckets == nil {
+		t.buckets = make(map[string]*bucketThrottle)
+	}
+	t.buckets[bucket] = &bucketThrottle{
+		limits:  *limits,
+		ingress: newBandwidthThrottle(limits.Ingress),
+		egress:  newBandwidthThrottle(limits.Egress),
+	}
+}
+
+// remove discards the throttles of bucket.
+func (t *bucketThrottles) remove(bucket string) {
+	t.set(bucket, nil)
+}
+
+// throttles returns the throttles of the traffic to and from
+// bucket, a direction without limit has a nil throttle.
+func (t *bucketThrottles) throttles(bucket string) (ingress, egress stats.Throttle) {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	bt, ok := t.buckets[bucket]
+	if !ok {
+		return nil, nil
+	}
+	// Avoid returning typed nil pointers as non nil interfaces.
+	if bt.ingress != nil {
+		ingress = bt.ingress
+	}
+	if bt.egress != nil {
+		egress = bt.egress
+	}
+	return ingress, egress
+}
+
+// bucketThrottleStats describes the throttle of a bucket in one direction.
+type bucketThrottleStats struct {
+	bucket          string
+	direction       string
+	limit           float64
+	waitingRequests int64
+	throttled       time.Duration
+}
+
+// load returns the state of all throttles.
+func (t *bucketThrottles) load() []bucketThrottleStats {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	var result []bucketThrottleStats
+	for bucket, bt := range t.buckets {
+		for _, d := range []struct {
+			name     string
+			throttle *bandwidthThrottle
+		}{{"ingress", bt.ingress}, {"egress", bt.egress}} {
+			if d.throttle == nil {
+				continue
+			}
+			result = append(result, bucketThrottleStats{
+				bucket:          bucket,
+				direction:       d.name,
+				limit:           float64(d.throttle.Limit()),
+				waitingRequests: atomic.LoadInt64(&d.throttle.waitingRequests),
+				throttled:       time.Duration(atomic.LoadUint64(&d.throttle.throttledNanos)),
+			})
+		}
+	}
+	return result
+}
@@ -0,0 +1,116 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"io/ioutil"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/minio/minio/internal/http/stats"
+)
+
+func TestParseBucketBandwidthLimits(t *testing.T) {
+	testCases := []struct {
+		data     string
+		expected BucketBandwidthLimits
+		err      bool
+	}{
+		{`{"ingress": 1024, "egress": 2048}`, BucketBandwidthLimits{Ingress: 1024, Egress: 2048}, false},
+		{`{"egress": 2048}`, BucketBandwidthLimits{Egress: 2048}, false},
+		{`{"ingress": -1}`, BucketBandwidthLimits{}, true},
+		{`not json`, BucketBandwidthLimits{}, true},
+	}
+	for i, testCase := range testCases {
+		limits, err := parseBucketBandwidthLimits("bucket", []byte(testCase.data))
+		if (err != nil) != testCase.err {
+			t.Errorf("case %d: unexpected error %v", i+1, err)
+			continue
+		}
+		if err == nil && *limits != testCase.expected {
+			t.Errorf("case %d: expected %+v, got %+v", i+1, testCase.expected, *limits)
+		}
+	}
+}
+
+func TestBucketThrottles(t *testing.T) {
+	var throttles bucketThrottles
+	if ingress, egress := throttles.throttles("bucket"); ingress != nil || egress != nil {
+		t.Fatal("expected no throttles for a bucket without limits")
+	}
+
+	throttles.set("bucket", &BucketBandwidthLimits{Egress: 1 << 20})
+	ingress, egress := throttles.throttles("bucket")
+	if ingress != nil {
+		t.Error("expected no ingress throttle")
+	}
+	if egress == nil || egress.Burst() != 1<<20 {
+		t.Fatalf("expected an egress throttle of 1 MiB, got %v", egress)
+	}
+
+	// Unchanged limits keep the state of the throttles.
+	throttles.set("bucket", &BucketBandwidthLimits{Egress: 1 << 20})
+	if _, same := throttles.throttles("bucket"); same != egress {
+		t.Error("expected the throttle to be kept")
+	}
+
+	loaded := throttles.load()
+	if len(loaded) != 1 || loaded[0].direction != "egress" || loaded[0].limit != 1<<20 {
+		t.Errorf("unexpected throttle stats %+v", loaded)
+	}
+
+	throttles.remove("bucket")
+	if _, egress := throttles.throttles("bucket"); egress != nil {
+		t.Error("expected the throttles to be removed")
+	}
+}
+
+func TestOutgoingTrafficThrottle(t *testing.T) {
+	throttle := newBandwidthThrottle(1000)
+	w := &stats.OutgoingTrafficMeter{
+		ResponseWriter: httptest.NewRecorder(),
+		Throttle:       throttle,
+		Ctx:            context.Background(),
+	}
+	start := time.Now()
+	n, err := w.Write(bytes.Repeat([]byte("a"), 1500))
+	if err != nil || n != 1500 {
+		t.Fatalf("expected 1500 bytes written, got %d, %v", n, err)
+	}
+	// The first 1000 bytes pass at once, the rest within about half a second.
+	if elapsed := time.Since(start); elapsed < 400*time.Millisecond {
+		t.Errorf("expected writes to be throttled, took %v", elapsed)
+	}
+	if throttle.throttledNanos == 0 {
+		t.Error("expected throttled time to be accounted")
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	r := &stats.IncomingTrafficMeter{
+		ReadCloser: ioutil.NopCloser(bytes.NewReader(make([]byte, 100))),
+		Throttle:   newBandwidthThrottle(1000),
+		Ctx:        ctx,
+	}
+	if _, err := r.Read(make([]byte, 100)); err == nil {
+		t.Error("expected reads to fail once the context is canceled")
+	}
+}
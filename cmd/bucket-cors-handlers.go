@@ -0,0 +1,250 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+package cmd
+
+import (
+	"encoding/xml"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+
+	humanize "github.com/dustin/go-humanize"
+	"github.com/gorilla/mux"
+	bucketcors "github.com/minio/minio/internal/bucket/cors"
+	xhttp "github.com/minio/minio/internal/http"
+	"github.com/minio/minio/internal/logger"
+	"github.com/minio/pkg/bucket/policy"
+)
+
+const (
+	bucketCorsConfig = "cors.xml"
+
+	// Maximum size of bucket CORS configuration payload sent to the PutBucketCorsHandler.
+	maxBucketCorsConfigSize = 64 * humanize.KiByte
+)
+
+// PutBucketCorsHandler - PUT Bucket CORS.
+// ----------
+// Replaces the CORS configuration of a bucket. There are no dedicated
+// policy actions for CORS configurations, so the bucket policy actions
+// are required.
+func (api objectAPIHandlers) PutBucketCorsHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "PutBucketCors")
+
+	defer logger.AuditLog(ctx, w, r, mustGetClaimsFromToken(r))
+
+	vars := mux.Vars(r)
+	bucket := vars["bucket"]
+
+	objectAPI := api.ObjectAPI()
+	if objectAPI == nil {
+		writeErrorResponse(ctx, w, errorCodes.ToAPIErr(ErrServerNotInitialized), r.URL)
+		return
+	}
+
+	if s3Error := checkRequestAuthType(ctx, r, policy.PutBucketPolicyAction, bucket, ""); s3Error != ErrNone {
+		writeErrorResponse(ctx, w, errorCodes.ToAPIErr(s3Error), r.URL)
+		return
+	}
+
+	// Check if bucket exists.
+	if _, err := objectAPI.GetBucketInfo(ctx, bucket); err != nil {
+		writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL)
+		return
+	}
+
+	if r.ContentLength > maxBucketCorsConfigSize {
+		writeErrorResponse(ctx, w, errorCodes.ToAPIErr(ErrEntityTooLarge), r.URL)
+		return
+	}
+
+	config, err := bucketcors.ParseConfig(io.LimitReader(r.Body, maxBucketCorsConfigSize))
+	if err != nil {
+		writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL)
+		return
+	}
+
+	configData, err := xml.Marshal(config)
+	if err != nil {
+		writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL)
+		return
+	}
+
+	if err = globalBucketMetadataSys.Update(ctx, bucket, bucketCorsConfig, configData); err != nil {
+		writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL)
+		return
+	}
+
+	// Write success response.
+	writeSuccessResponseHeadersOnly(w)
+}
+
+// GetBucketCorsHandler - GET Bucket CORS.
+// ----------
+func (api objectAPIHandlers) GetBucketCorsHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "GetBucketCors")
+
+	defer logger.AuditLog(ctx, w, r, mustGetClaimsFromToken(r))
+
+	vars := mux.Vars(r)
+	bucket := vars["bucket"]
+
+	objectAPI := api.ObjectAPI()
+	if objectAPI == nil {
+		writeErrorResponse(ctx, w, errorCodes.ToAPIErr(ErrServerNotInitialized), r.URL)
+		return
+	}
+
+	if s3Error := checkRequestAuthType(ctx, r, policy.GetBucketPolicyAction, bucket, ""); s3Error != ErrNone {
+		writeErrorResponse(ctx, w, errorCodes.ToAPIErr(s3Error), r.URL)
+		return
+	}
+
+	// Check if bucket exists.
+	if _, err := objectAPI.GetBucketInfo(ctx, bucket); err != nil {
+		writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL)
+		return
+	}
+
+	config, _, err := globalBucketMetadataSys.GetCorsConfig(ctx, bucket)
+	if err != nil {
+		writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL)
+		return
+	}
+
+	configData, err := xml.Marshal(config)
+	if err != nil {
+		writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL)
+		return
+	}
+
+	// Write success response.
+	writeSuccessResponseXML(w, configData)
+}
+
+// DeleteBucketCorsHandler - DELETE Bucket CORS.
+// ----------
+func (api objectAPIHandlers) DeleteBucketCorsHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "DeleteBucketCors")
+
+	defer logger.AuditLog(ctx, w, r, mustGetClaimsFromToken(r))
+
+	vars := mux.Vars(r)
+	bucket := vars["bucket"]
+
+	objectAPI := api.ObjectAPI()
+	if objectAPI == nil {
+		writeErrorResponse(ctx, w, errorCodes.ToAPIErr(ErrServerNotInitialized), r.URL)
+		return
+	}
+
+	if s3Error := checkRequestAuthType(ctx, r, policy.DeleteBucketPolicyAction, bucket, ""); s3Error != ErrNone {
+		writeErrorResponse(ctx, w, errorCodes.ToAPIErr(s3Error), r.URL)
+		return
+	}
+
+	// Check if bucket exists.
+	if _, err := objectAPI.GetBucketInfo(ctx, bucket); err != nil {
+		writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL)
+		return
+	}
+
+	if err := globalBucketMetadataSys.Update(ctx, bucket, bucketCorsConfig, nil); err != nil {
+		writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL)
+		return
+	}
+
+	// Write success response.
+	writeSuccessNoContent(w)
+}
+
+// getRequestBucketCors returns the CORS configuration of the bucket of
+// the request, or nil if it has none. Only the bucket metadata already
+// loaded is looked up, so that requests to any path are cheap.
+func getRequestBucketCors(r *http.Request) *bucketcors.Config {
+	if globalBucketMetadataSys == nil {
+		return nil
+	}
+	resource, err := getResource(r.URL.Path, r.Host, globalDomainNames)
+	if err != nil {
+		return nil
+	}
+	bucket, _ := path2BucketObject(resource)
+	if bucket == "" {
+		return nil
+	}
+	meta, err := globalBucketMetadataSys.Get(bucket)
+	if err != nil {
+		return nil
+	}
+	return meta.corsConfig
+}
+
+// serveBucketCors evaluates the CORS configuration of a bucket for a
+// cross-origin request. Preflight requests are answered with the rule
+// allowing them, or rejected if there is none. Other requests are
+// passed to h, with the CORS headers of the rule allowing them if any.
+func serveBucketCors(w http.ResponseWriter, r *http.Request, config *bucketcors.Config, h http.Handler) {
+	origin := r.Header.Get(xhttp.Origin)
+	w.Header().Add(xhttp.Vary, xhttp.Origin)
+
+	method := r.Header.Get(xhttp.AccessControlRequestMethod)
+	if r.Method != http.MethodOptions || method == "" {
+		if rule := config.Match(origin, r.Method, nil); rule != nil {
+			setBucketCorsHeaders(w, rule, origin)
+		}
+		h.ServeHTTP(w, r)
+		return
+	}
+
+	w.Header().Add(xhttp.Vary, xhttp.AccessControlRequestMethod)
+	w.Header().Add(xhttp.Vary, xhttp.AccessControlRequestHeaders)
+	var headers []string
+	for _, header := range strings.Split(r.Header.Get(xhttp.AccessControlRequestHeaders), ",") {
+		if header = strings.TrimSpace(header); header != "" {
+			headers = append(headers, strings.ToLower(header))
+		}
+	}
+	rule := config.Match(origin, method, headers)
+	if rule == nil {
+		writeErrorResponse(r.Context(), w, errorCodes.ToAPIErr(ErrCORSForbidden), r.URL)
+		return
+	}
+	setBucketCorsHeaders(w, rule, origin)
+	if len(headers) > 0 {
+		w.Header().Set(xhttp.AccessControlAllowHeaders, strings.Join(headers, ", "))
+	}
+	if rule.MaxAgeSeconds > 0 {
+		w.Header().Set(xhttp.AccessControlMaxAge, strconv.Itoa(rule.MaxAgeSeconds))
+	}
+	writeSuccessResponseHeadersOnly(w)
+}
+
+// setBucketCorsHeaders sets the CORS headers of a request from origin
+// allowed by rule.
+func setBucketCorsHeaders(w http.ResponseWriter, rule *bucketcors.Rule, origin string) {
+	allowedOrigin := rule.MatchOrigin(origin)
+	w.Header().Set(xhttp.AccessControlAllowOrigin, allowedOrigin)
+	if allowedOrigin != "*" {
+		w.Header().Set(xhttp.AccessControlAllowCredentials, "true")
+	}
+	w.Header().Set(xhttp.AccessControlAllowMethods, strings.Join(rule.AllowedMethods, ", "))
+	if len(rule.ExposeHeaders) > 0 {
+		w.Header().Set(xhttp.AccessControlExposeHeaders, strings.Join(rule.ExposeHeaders, ", "))
+	}
+}
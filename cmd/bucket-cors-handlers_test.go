@@ -0,0 +1,115 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestBucketCors(t *testing.T) {
+	testServer := StartTestServer(t, "ErasureSD")
+	defer testServer.Stop()
+
+	ctx := context.Background()
+	if err := testServer.Obj.MakeBucketWithLocation(ctx, "assets", BucketOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	bucketURL := testServer.Server.URL + "/assets"
+
+	do := func(method, url, body string, headers map[string]string, sign bool) (*http.Response, []byte) {
+		req, err := newTestRequest(method, url, int64(len(body)), strings.NewReader(body))
+		if err != nil {
+			t.Fatal(err)
+		}
+		for k, v := range headers {
+			req.Header.Set(k, v)
+		}
+		if sign {
+			if err = signRequestV4(req, testServer.AccessKey, testServer.SecretKey); err != nil {
+				t.Fatal(err)
+			}
+		}
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		respBody, _ := ioutil.ReadAll(resp.Body)
+		resp.Body.Close()
+		return resp, respBody
+	}
+
+	if resp, body := do(http.MethodGet, bucketURL+"?cors", "", nil, true); resp.StatusCode != http.StatusNotFound || !bytes.Contains(body, []byte("NoSuchCORSConfiguration")) {
+		t.Fatalf("unexpected response without configuration %d %q", resp.StatusCode, body)
+	}
+
+	config := `<CORSConfiguration><CORSRule><AllowedOrigin>https://*.example.com</AllowedOrigin><AllowedMethod>GET</AllowedMethod><AllowedMethod>PUT</AllowedMethod><AllowedHeader>x-amz-*</AllowedHeader><ExposeHeader>ETag</ExposeHeader><MaxAgeSeconds>600</MaxAgeSeconds></CORSRule></CORSConfiguration>`
+	if resp, body := do(http.MethodPut, bucketURL+"?cors", `<CORSConfiguration></CORSConfiguration>`, nil, true); resp.StatusCode != http.StatusBadRequest {
+		t.Fatalf("unexpected response for an invalid configuration %d %q", resp.StatusCode, body)
+	}
+	if resp, body := do(http.MethodPut, bucketURL+"?cors", config, nil, true); resp.StatusCode != http.StatusOK {
+		t.Fatalf("unexpected response %d %q", resp.StatusCode, body)
+	}
+	if resp, body := do(http.MethodGet, bucketURL+"?cors", "", nil, true); resp.StatusCode != http.StatusOK || !bytes.Contains(body, []byte("<AllowedOrigin>https://*.example.com</AllowedOrigin>")) {
+		t.Fatalf("unexpected configuration %d %q", resp.StatusCode, body)
+	}
+
+	testCases := []struct {
+		method  string
+		headers map[string]string
+		status  int
+		origin  string
+		maxAge  string
+	}{
+		// Allowed preflight request.
+		{http.MethodOptions, map[string]string{"Origin": "https://www.example.com", "Access-Control-Request-Method": "PUT", "Access-Control-Request-Headers": "X-Amz-Date"}, http.StatusOK, "https://www.example.com", "600"},
+		// Method not allowed.
+		{http.MethodOptions, map[string]string{"Origin": "https://www.example.com", "Access-Control-Request-Method": "DELETE"}, http.StatusForbidden, "", ""},
+		// Header not allowed.
+		{http.MethodOptions, map[string]string{"Origin": "https://www.example.com", "Access-Control-Request-Method": "GET", "Access-Control-Request-Headers": "Authorization"}, http.StatusForbidden, "", ""},
+		// Origin not allowed.
+		{http.MethodOptions, map[string]string{"Origin": "https://www.example.org", "Access-Control-Request-Method": "GET"}, http.StatusForbidden, "", ""},
+		// Actual request from an allowed origin.
+		{http.MethodHead, map[string]string{"Origin": "https://www.example.com"}, http.StatusOK, "", ""},
+		{http.MethodGet, map[string]string{"Origin": "https://www.example.com"}, http.StatusOK, "https://www.example.com", ""},
+		// Actual request from another origin, without CORS headers.
+		{http.MethodGet, map[string]string{"Origin": "https://www.example.org"}, http.StatusOK, "", ""},
+	}
+	for i, testCase := range testCases {
+		resp, body := do(testCase.method, bucketURL+"/", "", testCase.headers, testCase.method != http.MethodOptions)
+		if resp.StatusCode != testCase.status {
+			t.Errorf("Test %d: unexpected response %d %q", i+1, resp.StatusCode, body)
+		}
+		if origin := resp.Header.Get("Access-Control-Allow-Origin"); origin != testCase.origin {
+			t.Errorf("Test %d: expected allowed origin %q, got %q", i+1, testCase.origin, origin)
+		}
+		if maxAge := resp.Header.Get("Access-Control-Max-Age"); maxAge != testCase.maxAge {
+			t.Errorf("Test %d: expected max age %q, got %q", i+1, testCase.maxAge, maxAge)
+		}
+	}
+
+	if resp, body := do(http.MethodDelete, bucketURL+"?cors", "", nil, true); resp.StatusCode != http.StatusNoContent {
+		t.Fatalf("unexpected response %d %q", resp.StatusCode, body)
+	}
+	if resp, body := do(http.MethodGet, bucketURL+"?cors", "", nil, true); resp.StatusCode != http.StatusNotFound {
+		t.Fatalf("unexpected response after delete %d %q", resp.StatusCode, body)
+	}
+}
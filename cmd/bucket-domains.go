@@ -0,0 +1,108 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"net"
+	"strings"
+	"sync"
+
+	xnet "github.com/minio/pkg/net"
+)
+
+// BucketDomains holds the custom domains of a bucket, the requests
+// sent to one of them are served by the bucket like virtual-host
+// style requests, with the key of the object as path.
+type BucketDomains struct {
+	Domains []string `json:"domains"`
+}
+
+// IsEmpty returns whether no domain is set.
+func (d BucketDomains) IsEmpty() bool {
+	return len(d.Domains) == 0
+}
+
+// parseBucketDomains parses the custom domains of bucket.
+func parseBucketDomains(bucket string, data []byte) (*BucketDomains, error) {
+	config := &BucketDomains{}
+	if err := json.Unmarshal(data, config); err != nil {
+		return config, err
+	}
+	for i, domain := range config.Domains {
+		domain = strings.ToLower(domain)
+		host, err := xnet.ParseHost(domain)
+		if err != nil || host.IsPortSet || host.Name != domain {
+			return config, fmt.Errorf("invalid domain %q for bucket %s", config.Domains[i], bucket)
+		}
+		for _, root := range globalDomainNames {
+			if domain == root || strings.HasSuffix(domain, "."+root) {
+				return config, fmt.Errorf("invalid domain %q for bucket %s: domains of the server cannot be mapped", domain, bucket)
+			}
+		}
+		config.Domains[i] = domain
+	}
+	return config, nil
+}
+
+// bucketDomainMap maps the custom domains to
+// their bucket, the zero value is ready to use.
+type bucketDomainMap struct {
+	mu      sync.RWMutex
+	buckets map[string]string
+}
+
+// set maps the domains of bucket to it, in place of its previous ones.
+func (m *bucketDomainMap) set(bucket string, config *BucketDomains) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for domain, b := range m.buckets {
+		if b == bucket {
+			delete(m.buckets, domain)
+		}
+	}
+	if config == nil || config.IsEmpty() {
+		return
+	}
+	if m.buckets == nil {
+		m.buckets = make(map[string]string)
+	}
+	for _, domain := range config.Domains {
+		m.buckets[domain] = bucket
+	}
+}
+
+// remove discards the domains of bucket.
+func (m *bucketDomainMap) remove(bucket string) {
+	m.set(bucket, nil)
+}
+
+// bucket returns the bucket mapped to the domain of host,
+// the value of a Host header with an optional port.
+func (m *bucketDomainMap) bucket(host string) (string, bool) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	if len(m.buckets) == 0 {
+		return "", false
+	}
+	if h, _, err := net.SplitHostPort(host); err == nil {
+		host = h
+	}
+	bucket, ok := m.buckets[strings.ToLower(strings.TrimSuffix(host, "."))]
+	return bucket, ok
+}
@@ -0,0 +1,149 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestParseBucketDomains(t *testing.T) {
+	savedDomainNames := globalDomainNames
+	defer func() { globalDomainNames = savedDomainNames }()
+	globalDomainNames = []string{"s3.example.com"}
+
+	testCases := []struct {
+		data    string
+		domains []string
+		valid   bool
+	}{
+		{`{"domains": []}`, nil, true},
+		{`{"domains": ["assets.example.com", "CDN.Example.com"]}`, []string{"assets.example.com", "cdn.example.com"}, true},
+		{`{"domains": ["assets.example.com:9000"]}`, nil, false},
+		{`{"domains": ["https://assets.example.com"]}`, nil, false},
+		{`{"domains": ["s3.example.com"]}`, nil, false},
+		{`{"domains": ["assets.s3.example.com"]}`, nil, false},
+		{`{"domains": [""]}`, nil, false},
+	}
+	for i, testCase := range testCases {
+		config, err := parseBucketDomains("bucket", []byte(testCase.data))
+		if (err == nil) != testCase.valid {
+			t.Errorf("Test %d: expected valid %v, got %v", i+1, testCase.valid, err)
+			continue
+		}
+		if err == nil && strings.Join(config.Domains, ",") != strings.Join(testCase.domains, ",") {
+			t.Errorf("Test %d: expected domains %v, got %v", i+1, testCase.domains, config.Domains)
+		}
+	}
+}
+
+func TestBucketDomainMap(t *testing.T) {
+	var m bucketDomainMap
+	if _, ok := m.bucket("assets.example.com"); ok {
+		t.Fatal("expected no bucket for an empty map")
+	}
+	m.set("assets", &BucketDomains{Domains: []string{"assets.example.com", "cdn.example.com"}})
+	m.set("media", &BucketDomains{Domains: []string{"media.example.com"}})
+	m.set("assets", &BucketDomains{Domains: []string{"assets.example.com"}})
+	m.remove("media")
+
+	testCases := []struct {
+		host   string
+		bucket string
+	}{
+		{"assets.example.com", "assets"},
+		{"Assets.Example.com:9000", "assets"},
+		{"assets.example.com.", "assets"},
+		{"cdn.example.com", ""},
+		{"media.example.com", ""},
+		{"example.com", ""},
+	}
+	for i, testCase := range testCases {
+		if bucket, _ := m.bucket(testCase.host); bucket != testCase.bucket {
+			t.Errorf("Test %d: expected bucket %q for %s, got %q", i+1, testCase.bucket, testCase.host, bucket)
+		}
+	}
+}
+
+func TestBucketDomainRequests(t *testing.T) {
+	testServer := StartTestServer(t, "ErasureSD")
+	defer testServer.Stop()
+	defer globalBucketDomains.remove("assets")
+
+	ctx := context.Background()
+	objAPI := testServer.Obj
+	if err := objAPI.MakeBucketWithLocation(ctx, "assets", BucketOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	data := "body { color: red }"
+	if _, err := objAPI.PutObject(ctx, "assets", "css/site.css", mustGetPutObjReader(t, strings.NewReader(data), int64(len(data)), "", ""), ObjectOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	if err := globalBucketMetadataSys.Update(ctx, "assets", bucketDomainsConfigFile, []byte(`{"domains": ["assets.example.com"]}`)); err != nil {
+		t.Fatal(err)
+	}
+
+	// Send the requests for the custom domain to the test server.
+	addr := testServer.Server.Listener.Addr().String()
+	_, port, _ := net.SplitHostPort(addr)
+	client := &http.Client{Transport: &http.Transport{
+		DialContext: func(ctx context.Context, network, _ string) (net.Conn, error) {
+			var d net.Dialer
+			return d.DialContext(ctx, network, addr)
+		},
+	}}
+	testCases := []struct {
+		method string
+		path   string
+		body   string
+		status int
+		resp   string
+	}{
+		{http.MethodGet, "/css/site.css", "", http.StatusOK, data},
+		{http.MethodPut, "/js/site.js", "alert(1)", http.StatusOK, ""},
+		{http.MethodGet, "/js/site.js", "", http.StatusOK, "alert(1)"},
+		{http.MethodGet, "/?list-type=2&prefix=css/", "", http.StatusOK, "<Key>css/site.css</Key>"},
+		{http.MethodGet, "/assets/css/site.css", "", http.StatusNotFound, "NoSuchKey"},
+	}
+	for i, testCase := range testCases {
+		req, err := newTestRequest(testCase.method, "http://assets.example.com:"+port+testCase.path, int64(len(testCase.body)), strings.NewReader(testCase.body))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err = signRequestV4(req, testServer.AccessKey, testServer.SecretKey); err != nil {
+			t.Fatal(err)
+		}
+		resp, err := client.Do(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		body, _ := ioutil.ReadAll(resp.Body)
+		resp.Body.Close()
+		if resp.StatusCode != testCase.status || !bytes.Contains(body, []byte(testCase.resp)) {
+			t.Errorf("Test %d: unexpected response %d %q", i+1, resp.StatusCode, body)
+		}
+	}
+
+	if _, err := objAPI.GetObjectInfo(ctx, "assets", "js/site.js", ObjectOptions{}); err != nil {
+		t.Errorf("expected the object uploaded through the custom domain in the bucket: %v", err)
+	}
+}
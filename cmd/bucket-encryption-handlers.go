@@ -113,6 +113,8 @@ func (api objectAPIHandlers) PutBucketEncryptionHandler(w http.ResponseWriter, r
 		return
 	}
 
+	propagateBucketMetadata(bucket, bucketSSEConfig)
+
 	// Call site replication hook.
 	//
 	// We encode the xml bytes as base64 to ensure there are no encoding
@@ -206,6 +208,9 @@ func (api objectAPIHandlers) DeleteBucketEncryptionHandler(w http.ResponseWriter
 		writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL)
 		return
 	}
+
+	propagateBucketMetadata(bucket, bucketSSEConfig)
+
 	// Call site replication hook.
 	//
 	if err = globalSiteReplicationSys.BucketMetaHook(ctx, madmin.SRBucketMeta{
@@ -1072,7 +1072,7 @@ func (api objectAPIHandlers) PostPolicyBucketHandler(w http.ResponseWriter, r *h
 					return
 				}
 			}
-			reader, objectEncryptionKey, err = newEncryptReader(hashReader, kind, keyID, key, bucket, object, metadata, kmsCtx)
+			reader, objectEncryptionKey, err = newEncryptReader(ctx, hashReader, kind, keyID, key, bucket, object, metadata, kmsCtx)
 			if err != nil {
 				writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL)
 				return
@@ -1110,6 +1110,8 @@ func (api objectAPIHandlers) PostPolicyBucketHandler(w http.ResponseWriter, r *h
 
 	w.Header().Set(xhttp.Location, getObjectLocation(r, globalDomainNames, bucket, object))
 
+	setRequestEncryption(ctx, objInfo.UserDefined)
+
 	// Notify object created event.
 	defer sendEvent(eventArgs{
 		EventName:    event.ObjectCreatedPost,
@@ -1501,6 +1503,8 @@ func (api objectAPIHandlers) PutBucketTaggingHandler(w http.ResponseWriter, r *h
 		return
 	}
 
+	propagateBucketMetadata(bucket, bucketTaggingConfig)
+
 	// Call site replication hook.
 	//
 	// We encode the xml bytes as base64 to ensure there are no encoding
@@ -1583,6 +1587,8 @@ func (api objectAPIHandlers) DeleteBucketTaggingHandler(w http.ResponseWriter, r
 		return
 	}
 
+	propagateBucketMetadata(bucket, bucketTaggingConfig)
+
 	if err := globalSiteReplicationSys.BucketMetaHook(ctx, madmin.SRBucketMeta{
 		Type:   madmin.SRBucketMetaTypeTags,
 		Bucket: bucket,
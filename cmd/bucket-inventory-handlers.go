@@ -0,0 +1,301 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"encoding/base64"
+	"encoding/xml"
+	"io"
+	"net/http"
+
+	humanize "github.com/dustin/go-humanize"
+	"github.com/gorilla/mux"
+	"github.com/minio/minio/internal/bucket/inventory"
+	"github.com/minio/minio/internal/logger"
+	"github.com/minio/pkg/bucket/policy"
+	iampolicy "github.com/minio/pkg/iam/policy"
+)
+
+const (
+	bucketInventoryConfig = "inventory.xml"
+
+	// Maximum size of bucket inventory configuration payload sent to the PutBucketInventoryConfigurationHandler.
+	maxBucketInventoryConfigSize = 1 * humanize.MiByte
+
+	// Limit number of configurations in a listBucketInventoryConfigurationsResponse.
+	maxInventoryConfigList = 100
+)
+
+// getInventoryConfigs returns a copy of the inventory configurations
+// of bucket, empty if it has none.
+func getInventoryConfigs(r *http.Request, bucket string) (*inventory.Configs, error) {
+	configs, _, err := globalBucketMetadataSys.GetInventoryConfigs(r.Context(), bucket)
+	if err != nil {
+		if _, ok := err.(BucketInventoryConfigNotFound); ok {
+			return &inventory.Configs{}, nil
+		}
+		return nil, err
+	}
+	return &inventory.Configs{Configs: append([]inventory.Config(nil), configs.Configs...)}, nil
+}
+
+// saveInventoryConfigs saves the inventory configurations of bucket,
+// or removes them if there are none left.
+func saveInventoryConfigs(r *http.Request, bucket string, configs *inventory.Configs) error {
+	if len(configs.Configs) == 0 {
+		return globalBucketMetadataSys.Update(r.Context(), bucket, bucketInventoryConfig, nil)
+	}
+	configData, err := xml.Marshal(configs)
+	if err != nil {
+		return err
+	}
+	return globalBucketMetadataSys.Update(r.Context(), bucket, bucketInventoryConfig, configData)
+}
+
+// PutBucketInventoryConfigurationHandler - PUT Bucket inventory configuration.
+// ----------
+// Adds or replaces the inventory configuration with the id query
+// parameter. There are no dedicated policy actions for inventory
+// configurations, so the bucket policy actions are required, as well
+// as permission to put objects into the destination bucket.
+func (api objectAPIHandlers) PutBucketInventoryConfigurationHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "PutBucketInventoryConfiguration")
+
+	defer logger.AuditLog(ctx, w, r, mustGetClaimsFromToken(r))
+
+	vars := mux.Vars(r)
+	bucket := vars["bucket"]
+
+	objectAPI := api.ObjectAPI()
+	if objectAPI == nil {
+		writeErrorResponse(ctx, w, errorCodes.ToAPIErr(ErrServerNotInitialized), r.URL)
+		return
+	}
+
+	if s3Error := checkRequestAuthType(ctx, r, policy.PutBucketPolicyAction, bucket, ""); s3Error != ErrNone {
+		writeErrorResponse(ctx, w, errorCodes.ToAPIErr(s3Error), r.URL)
+		return
+	}
+
+	// Check if bucket exists.
+	if _, err := objectAPI.GetBucketInfo(ctx, bucket); err != nil {
+		writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL)
+		return
+	}
+
+	cfg, err := inventory.ParseConfig(io.LimitReader(r.Body, maxBucketInventoryConfigSize))
+	if err != nil {
+		writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL)
+		return
+	}
+	if cfg.ID != vars["id"] {
+		writeErrorResponse(ctx, w, toAPIError(ctx, inventory.Errorf("inventory configuration ID does not match the id parameter")), r.URL)
+		return
+	}
+
+	dstBucket := cfg.Destination.S3BucketDestination.BucketName()
+	if isMinioMetaBucketName(dstBucket) {
+		writeErrorResponse(ctx, w, toAPIError(ctx, BucketNameInvalid{Bucket: dstBucket}), r.URL)
+		return
+	}
+	// The request is authenticated already, only check the permission.
+	if s3Error := isPutActionAllowed(ctx, getRequestAuthType(r), dstBucket, "", r, iampolicy.PutObjectAction); s3Error != ErrNone {
+		writeErrorResponse(ctx, w, errorCodes.ToAPIErr(s3Error), r.URL)
+		return
+	}
+	if _, err = objectAPI.GetBucketInfo(ctx, dstBucket); err != nil {
+		writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL)
+		return
+	}
+
+	configs, err := getInventoryConfigs(r, bucket)
+	if err != nil {
+		writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL)
+		return
+	}
+	cfg.XMLNS = ""
+	if err = configs.Set(*cfg); err != nil {
+		writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL)
+		return
+	}
+	if err = saveInventoryConfigs(r, bucket, configs); err != nil {
+		writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL)
+		return
+	}
+
+	writeSuccessResponseHeadersOnly(w)
+}
+
+// GetBucketInventoryConfigurationHandler - GET Bucket inventory configuration.
+// ----------
+// Returns the inventory configuration with the id query parameter.
+func (api objectAPIHandlers) GetBucketInventoryConfigurationHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "GetBucketInventoryConfiguration")
+
+	defer logger.AuditLog(ctx, w, r, mustGetClaimsFromToken(r))
+
+	vars := mux.Vars(r)
+	bucket := vars["bucket"]
+
+	objectAPI := api.ObjectAPI()
+	if objectAPI == nil {
+		writeErrorResponse(ctx, w, errorCodes.ToAPIErr(ErrServerNotInitialized), r.URL)
+		return
+	}
+
+	if s3Error := checkRequestAuthType(ctx, r, policy.GetBucketPolicyAction, bucket, ""); s3Error != ErrNone {
+		writeErrorResponse(ctx, w, errorCodes.ToAPIErr(s3Error), r.URL)
+		return
+	}
+
+	// Check if bucket exists.
+	if _, err := objectAPI.GetBucketInfo(ctx, bucket); err != nil {
+		writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL)
+		return
+	}
+
+	configs, err := getInventoryConfigs(r, bucket)
+	if err != nil {
+		writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL)
+		return
+	}
+	cfg, ok := configs.Get(vars["id"])
+	if !ok {
+		writeErrorResponse(ctx, w, errorCodes.ToAPIErr(ErrNoSuchInventoryConfiguration), r.URL)
+		return
+	}
+	cfg.XMLNS = "http://s3.amazonaws.com/doc/2006-03-01/"
+
+	configData, err := xml.Marshal(cfg)
+	if err != nil {
+		writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL)
+		return
+	}
+
+	// Write bucket inventory configuration to client
+	writeSuccessResponseXML(w, configData)
+}
+
+// DeleteBucketInventoryConfigurationHandler - DELETE Bucket inventory configuration.
+// ----------
+// Removes the inventory configuration with the id query parameter,
+// the reports generated so far are kept.
+func (api objectAPIHandlers) DeleteBucketInventoryConfigurationHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "DeleteBucketInventoryConfiguration")
+
+	defer logger.AuditLog(ctx, w, r, mustGetClaimsFromToken(r))
+
+	vars := mux.Vars(r)
+	bucket := vars["bucket"]
+
+	objectAPI := api.ObjectAPI()
+	if objectAPI == nil {
+		writeErrorResponse(ctx, w, errorCodes.ToAPIErr(ErrServerNotInitialized), r.URL)
+		return
+	}
+
+	if s3Error := checkRequestAuthType(ctx, r, policy.PutBucketPolicyAction, bucket, ""); s3Error != ErrNone {
+		writeErrorResponse(ctx, w, errorCodes.ToAPIErr(s3Error), r.URL)
+		return
+	}
+
+	// Check if bucket exists.
+	if _, err := objectAPI.GetBucketInfo(ctx, bucket); err != nil {
+		writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL)
+		return
+	}
+
+	configs, err := getInventoryConfigs(r, bucket)
+	if err != nil {
+		writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL)
+		return
+	}
+	if !configs.Delete(vars["id"]) {
+		writeErrorResponse(ctx, w, errorCodes.ToAPIErr(ErrNoSuchInventoryConfiguration), r.URL)
+		return
+	}
+	if err = saveInventoryConfigs(r, bucket, configs); err != nil {
+		writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL)
+		return
+	}
+	if err = deleteConfig(ctx, objectAPI, inventoryStatePath(bucket, vars["id"])); err != nil && err != errConfigNotFound {
+		logger.LogIf(ctx, err)
+	}
+
+	// Success.
+	writeSuccessNoContent(w)
+}
+
+// ListBucketInventoryConfigurationsHandler - GET Bucket inventory configurations.
+// ----------
+// Returns up to 100 inventory configurations sorted by ID, after
+// the one in the continuation-token query parameter.
+func (api objectAPIHandlers) ListBucketInventoryConfigurationsHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "ListBucketInventoryConfigurations")
+
+	defer logger.AuditLog(ctx, w, r, mustGetClaimsFromToken(r))
+
+	vars := mux.Vars(r)
+	bucket := vars["bucket"]
+
+	objectAPI := api.ObjectAPI()
+	if objectAPI == nil {
+		writeErrorResponse(ctx, w, errorCodes.ToAPIErr(ErrServerNotInitialized), r.URL)
+		return
+	}
+
+	if s3Error := checkRequestAuthType(ctx, r, policy.GetBucketPolicyAction, bucket, ""); s3Error != ErrNone {
+		writeErrorResponse(ctx, w, errorCodes.ToAPIErr(s3Error), r.URL)
+		return
+	}
+
+	// Check if bucket exists.
+	if _, err := objectAPI.GetBucketInfo(ctx, bucket); err != nil {
+		writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL)
+		return
+	}
+
+	token := r.Form.Get("continuation-token")
+	marker, err := base64.StdEncoding.DecodeString(token)
+	if err != nil {
+		writeErrorResponse(ctx, w, errorCodes.ToAPIErr(ErrIncorrectContinuationToken), r.URL)
+		return
+	}
+
+	configs, err := getInventoryConfigs(r, bucket)
+	if err != nil {
+		writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL)
+		return
+	}
+
+	response := ListBucketInventoryConfigurationsResponse{ContinuationToken: token}
+	for _, cfg := range configs.Configs {
+		if token != "" && cfg.ID <= string(marker) {
+			continue
+		}
+		if len(response.InventoryConfigurations) == maxInventoryConfigList {
+			response.IsTruncated = true
+			last := response.InventoryConfigurations[maxInventoryConfigList-1].ID
+			response.NextContinuationToken = base64.StdEncoding.EncodeToString([]byte(last))
+			break
+		}
+		response.InventoryConfigurations = append(response.InventoryConfigurations, cfg)
+	}
+
+	writeSuccessResponseXML(w, encodeResponse(response))
+}
@@ -0,0 +1,650 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"crypto/md5"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+	"math/rand"
+	"net/url"
+	"path"
+	"strconv"
+	"strings"
+	"time"
+
+	goparquet "github.com/fraugster/parquet-go"
+	"github.com/fraugster/parquet-go/parquet"
+	"github.com/fraugster/parquet-go/parquetschema"
+	"github.com/klauspost/compress/gzip"
+	"github.com/minio/minio/internal/bucket/inventory"
+	objectlock "github.com/minio/minio/internal/bucket/object/lock"
+	"github.com/minio/minio/internal/crypto"
+	"github.com/minio/minio/internal/event"
+	xhash "github.com/minio/minio/internal/hash"
+	xhttp "github.com/minio/minio/internal/http"
+	"github.com/minio/minio/internal/logger"
+)
+
+const (
+	// inventoryCycle is the time between two checks for due reports.
+	inventoryCycle = time.Hour
+
+	// Maximum number of objects listed in a single report file.
+	inventoryMaxFileObjects = 1000000
+
+	// Version of the inventory manifest format.
+	inventoryManifestVersion = "2016-11-30"
+)
+
+var inventoryLeaderLockTimeout = newDynamicTimeout(30*time.Second, 10*time.Second)
+
+// initBackgroundInventory starts generating the inventory
+// reports of all buckets on schedule.
+func initBackgroundInventory(ctx context.Context, objAPI ObjectLayer) {
+	go func() {
+		r := rand.New(rand.NewSource(time.Now().UnixNano()))
+		for {
+			runInventory(ctx, objAPI)
+			duration := time.Duration(r.Float64() * float64(inventoryCycle))
+			if duration < time.Second {
+				// Make sure to sleep atleast a second to avoid high CPU ticks.
+				duration = time.Second
+			}
+			time.Sleep(duration)
+		}
+	}()
+}
+
+func runInventory(pctx context.Context, objAPI ObjectLayer) {
+	// Make sure only 1 node generates reports on the cluster.
+	locker := objAPI.NewNSLock(minioMetaBucket, "inventory/runInventory.lock")
+	lkctx, err := locker.GetLock(pctx, inventoryLeaderLockTimeout)
+	if err != nil {
+		return
+	}
+	ctx := lkctx.Context()
+	defer lkctx.Cancel()
+	// No unlock for "leader" lock.
+
+	inventoryTimer := time.NewTimer(time.Minute)
+	defer inventoryTimer.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-inventoryTimer.C:
+			runInventoryCycle(ctx, objAPI, UTCNow())
+			inventoryTimer.Reset(inventoryCycle)
+		}
+	}
+}
+
+// inventoryState is the persisted state of an inventory configuration.
+type inventoryState struct {
+	LastReport time.Time `json:"lastReport"`
+}
+
+func inventoryStatePath(bucket, id string) string {
+	return path.Join(bucketMetaPrefix, bucket, "inventory", id+".json")
+}
+
+// runInventoryCycle generates all reports due at now.
+func runInventoryCycle(ctx context.Context, objAPI ObjectLayer, now time.Time) {
+	buckets, err := objAPI.ListBuckets(ctx)
+	if err != nil {
+		logger.LogIf(ctx, err)
+		return
+	}
+	for _, bucket := range buckets {
+		configs, _, err := globalBucketMetadataSys.GetInventoryConfigs(ctx, bucket.Name)
+		if err != nil {
+			continue
+		}
+		for _, cfg := range configs.Configs {
+			if !cfg.IsEnabled {
+				continue
+			}
+			var state inventoryState
+			statePath := inventoryStatePath(bucket.Name, cfg.ID)
+			data, err := readConfig(ctx, objAPI, statePath)
+			if err == nil {
+				err = json.Unmarshal(data, &state)
+			}
+			if err != nil && !errors.Is(err, errConfigNotFound) {
+				logger.LogIf(ctx, err)
+				continue
+			}
+			if now.Sub(state.LastReport) < cfg.Schedule.Frequency.Interval() {
+				continue
+			}
+			if err = generateInventoryReport(ctx, objAPI, bucket.Name, cfg, now); err != nil {
+				logger.LogIf(ctx, fmt.Errorf("Unable to generate inventory report %s of bucket %s: %w", cfg.ID, bucket.Name, err))
+				continue
+			}
+			state.LastReport = now
+			if data, err = json.Marshal(state); err == nil {
+				err = saveConfig(ctx, objAPI, statePath, data)
+			}
+			logger.LogIf(ctx, err)
+		}
+	}
+}
+
+// inventoryColumn - a column of inventory reports.
+type inventoryColumn struct {
+	name    string // Name in CSV file schemas.
+	parquet string // Definition in Parquet file schemas.
+	// Value of the column for an object, one of string, int64,
+	// bool, time.Time or nil if there is none.
+	value func(bucket string, o *ObjectInfo) interface{}
+	// Whether the column has values for delete markers.
+	deleteMarker bool
+}
+
+var (
+	inventoryBucketColumn = inventoryColumn{
+		name: "Bucket", parquet: "required binary bucket (STRING)", deleteMarker: true,
+		value: func(bucket string, o *ObjectInfo) interface{} { return bucket },
+	}
+	inventoryKeyColumn = inventoryColumn{
+		name: "Key", parquet: "required binary key (STRING)", deleteMarker: true,
+		value: func(bucket string, o *ObjectInfo) interface{} { return o.Name },
+	}
+	inventoryVersionColumns = []inventoryColumn{
+		{
+			name: "VersionId", parquet: "optional binary version_id (STRING)", deleteMarker: true,
+			value: func(bucket string, o *ObjectInfo) interface{} { return o.VersionID },
+		},
+		{
+			name: "IsLatest", parquet: "required boolean is_latest", deleteMarker: true,
+			value: func(bucket string, o *ObjectInfo) interface{} { return o.IsLatest },
+		},
+		{
+			name: "IsDeleteMarker", parquet: "required boolean is_delete_marker", deleteMarker: true,
+			value: func(bucket string, o *ObjectInfo) interface{} { return o.DeleteMarker },
+		},
+	}
+	inventoryFieldColumns = map[inventory.Field]inventoryColumn{
+		inventory.FieldSize: {
+			name: "Size", parquet: "optional int64 size",
+			value: func(bucket string, o *ObjectInfo) interface{} { return o.Size },
+		},
+		inventory.FieldLastModifiedDate: {
+			name: "LastModifiedDate", parquet: "optional int64 last_modified_date (TIMESTAMP(MILLIS, true))", deleteMarker: true,
+			value: func(bucket string, o *ObjectInfo) interface{} { return o.ModTime },
+		},
+		inventory.FieldETag: {
+			name: "ETag", parquet: "optional binary e_tag (STRING)",
+			value: func(bucket string, o *ObjectInfo) interface{} { return o.ETag },
+		},
+		inventory.FieldStorageClass: {
+			name: "StorageClass", parquet: "optional binary storage_class (STRING)",
+			value: func(bucket string, o *ObjectInfo) interface{} {
+				if o.StorageClass == "" {
+					return globalMinioDefaultStorageClass
+				}
+				return o.StorageClass
+			},
+		},
+		inventory.FieldIsMultipartUploaded: {
+			name: "IsMultipartUploaded", parquet: "optional boolean is_multipart_uploaded",
+			value: func(bucket string, o *ObjectInfo) interface{} { return o.isMultipart() },
+		},
+		inventory.FieldReplicationStatus: {
+			name: "ReplicationStatus", parquet: "optional binary replication_status (STRING)",
+			value: func(bucket string, o *ObjectInfo) interface{} { return string(o.ReplicationStatus) },
+		},
+		inventory.FieldEncryptionStatus: {
+			name: "EncryptionStatus", parquet: "optional binary encryption_status (STRING)",
+			value: func(bucket string, o *ObjectInfo) interface{} {
+				if kind, ok := crypto.IsEncrypted(o.UserDefined); ok {
+					return kind.String()
+				}
+				return "NOT-SSE"
+			},
+		},
+		inventory.FieldObjectLockRetainUntilDate: {
+			name: "ObjectLockRetainUntilDate", parquet: "optional int64 object_lock_retain_until_date (TIMESTAMP(MILLIS, true))",
+			value: func(bucket string, o *ObjectInfo) interface{} {
+				if ret := objectlock.GetObjectRetentionMeta(o.UserDefined); !ret.RetainUntilDate.IsZero() {
+					return ret.RetainUntilDate.Time
+				}
+				return nil
+			},
+		},
+		inventory.FieldObjectLockMode: {
+			name: "ObjectLockMode", parquet: "optional binary object_lock_mode (STRING)",
+			value: func(bucket string, o *ObjectInfo) interface{} {
+				return string(objectlock.GetObjectRetentionMeta(o.UserDefined).Mode)
+			},
+		},
+		inventory.FieldObjectLockLegalHoldStatus: {
+			name: "ObjectLockLegalHoldStatus", parquet: "optional binary object_lock_legal_hold_status (STRING)",
+			value: func(bucket string, o *ObjectInfo) interface{} {
+				return string(objectlock.GetObjectLegalHoldMeta(o.UserDefined).Status)
+			},
+		},
+		inventory.FieldChecksumAlgorithm: {
+			name: "ChecksumAlgorithm", parquet: "optional binary checksum_algorithm (STRING)",
+			value: func(bucket string, o *ObjectInfo) interface{} {
+				if c := o.checksum(); c != nil {
+					return c.Type.String()
+				}
+				return nil
+			},
+		},
+	}
+)
+
+// inventoryColumns returns the columns of the reports of cfg.
+func inventoryColumns(cfg inventory.Config) []inventoryColumn {
+	columns := []inventoryColumn{inventoryBucketColumn, inventoryKeyColumn}
+	if cfg.AllVersions() {
+		columns = append(columns, inventoryVersionColumns...)
+	}
+	for _, field := range cfg.Fields() {
+		columns = append(columns, inventoryFieldColumns[field])
+	}
+	return columns
+}
+
+// inventoryFileSchema returns the file schema of reports in
+// a format, as listed in their manifest.
+func inventoryFileSchema(format inventory.Format, columns []inventoryColumn) string {
+	if format == inventory.Parquet {
+		fields := make([]string, len(columns))
+		for i, column := range columns {
+			fields[i] = column.parquet + ";"
+		}
+		return "message s3.inventory { " + strings.Join(fields, " ") + " }"
+	}
+	names := make([]string, len(columns))
+	for i, column := range columns {
+		names[i] = column.name
+	}
+	return strings.Join(names, ", ")
+}
+
+// inventoryFileWriter writes the rows of a report file.
+type inventoryFileWriter interface {
+	Write(values []interface{}) error
+	Close() error
+}
+
+// csvInventoryWriter writes gzip compressed CSV files,
+// with all fields quoted and object keys URL encoded.
+type csvInventoryWriter struct {
+	gz  *gzip.Writer
+	buf []byte
+}
+
+func (w *csvInventoryWriter) Write(values []interface{}) error {
+	w.buf = w.buf[:0]
+	for i, value := range values {
+		if i > 0 {
+			w.buf = append(w.buf, ',')
+		}
+		var s string
+		switch v := value.(type) {
+		case string:
+			s = v
+			if i == 1 {
+				s = url.QueryEscape(v)
+			}
+		case int64:
+			s = strconv.FormatInt(v, 10)
+		case bool:
+			s = strconv.FormatBool(v)
+		case time.Time:
+			s = v.UTC().Format(iso8601TimeFormat)
+		}
+		w.buf = append(w.buf, '"')
+		w.buf = append(w.buf, strings.ReplaceAll(s, `"`, `""`)...)
+		w.buf = append(w.buf, '"')
+	}
+	w.buf = append(w.buf, '\n')
+	_, err := w.gz.Write(w.buf)
+	return err
+}
+
+func (w *csvInventoryWriter) Close() error {
+	return w.gz.Close()
+}
+
+// parquetInventoryWriter writes Snappy compressed Parquet files.
+type parquetInventoryWriter struct {
+	fw    *goparquet.FileWriter
+	names []string
+}
+
+func newParquetInventoryWriter(w io.Writer, schema string) (*parquetInventoryWriter, error) {
+	sd, err := parquetschema.ParseSchemaDefinition(schema)
+	if err != nil {
+		return nil, err
+	}
+	names := make([]string, len(sd.RootColumn.Children))
+	for i, column := range sd.RootColumn.Children {
+		names[i] = column.SchemaElement.Name
+	}
+	return &parquetInventoryWriter{
+		fw: goparquet.NewFileWriter(w,
+			goparquet.WithSchemaDefinition(sd),
+			goparquet.WithCompressionCodec(parquet.CompressionCodec_SNAPPY),
+			goparquet.WithCreator("MinIO"),
+		),
+		names: names,
+	}, nil
+}
+
+func (w *parquetInventoryWriter) Write(values []interface{}) error {
+	row := make(map[string]interface{}, len(values))
+	for i, value := range values {
+		switch v := value.(type) {
+		case string:
+			if v != "" || i < 2 {
+				row[w.names[i]] = []byte(v)
+			}
+		case time.Time:
+			row[w.names[i]] = v.UnixMilli()
+		case nil:
+		default:
+			row[w.names[i]] = v
+		}
+	}
+	return w.fw.AddData(row)
+}
+
+func (w *parquetInventoryWriter) Close() error {
+	return w.fw.Close()
+}
+
+// inventoryManifestFile - a report file listed in a manifest.
+type inventoryManifestFile struct {
+	Key         string `json:"key"`
+	Size        int64  `json:"size"`
+	MD5Checksum string `json:"MD5checksum"`
+}
+
+// inventoryManifest - the manifest of an inventory report.
+type inventoryManifest struct {
+	SourceBucket      string                  `json:"sourceBucket"`
+	DestinationBucket string                  `json:"destinationBucket"`
+	Version           string                  `json:"version"`
+	CreationTimestamp string                  `json:"creationTimestamp"`
+	FileFormat        string                  `json:"fileFormat"`
+	FileSchema        string                  `json:"fileSchema"`
+	Files             []inventoryManifestFile `json:"files"`
+}
+
+// inventoryFileSink computes the size and MD5 of report files
+// streamed into the destination bucket.
+type inventoryFileSink struct {
+	w    io.Writer
+	md5  hash.Hash
+	size int64
+}
+
+func (s *inventoryFileSink) Write(p []byte) (int, error) {
+	n, err := s.w.Write(p)
+	s.md5.Write(p[:n])
+	s.size += int64(n)
+	return n, err
+}
+
+// inventoryReport writes the files of an inventory report,
+// starting a new file every inventoryMaxFileObjects objects.
+type inventoryReport struct {
+	ctx       context.Context
+	objAPI    ObjectLayer
+	bucket    string
+	dstBucket string
+	dataDir   string
+	format    inventory.Format
+	schema    string
+	columns   []inventoryColumn
+
+	file    inventoryFileWriter
+	sink    *inventoryFileSink
+	pw      *io.PipeWriter
+	key     string
+	objects int
+	done    chan error
+	files   []inventoryManifestFile
+}
+
+func (r *inventoryReport) newFile() error {
+	ext := ".csv.gz"
+	if r.format == inventory.Parquet {
+		ext = ".parquet"
+	}
+	r.key = path.Join(r.dataDir, mustGetUUID()+ext)
+	pr, pw := io.Pipe()
+	r.pw = pw
+	r.sink = &inventoryFileSink{w: pw, md5: md5.New()}
+	r.done = make(chan error, 1)
+	go func(key string) {
+		err := putInventoryObject(r.ctx, r.objAPI, r.dstBucket, key, pr, -1, "application/octet-stream")
+		pr.CloseWithError(err)
+		r.done <- err
+	}(r.key)
+
+	if r.format == inventory.Parquet {
+		w, err := newParquetInventoryWriter(r.sink, r.schema)
+		if err != nil {
+			pw.CloseWithError(err)
+			<-r.done
+			return err
+		}
+		r.file = w
+	} else {
+		r.file = &csvInventoryWriter{gz: gzip.NewWriter(r.sink)}
+	}
+	r.objects = 0
+	return nil
+}
+
+func (r *inventoryReport) closeFile() error {
+	if r.file == nil {
+		return nil
+	}
+	err := r.file.Close()
+	r.file = nil
+	r.pw.CloseWithError(err)
+	if perr := <-r.done; err == nil {
+		err = perr
+	}
+	if err != nil {
+		return err
+	}
+	r.files = append(r.files, inventoryManifestFile{
+		Key:         r.key,
+		Size:        r.sink.size,
+		MD5Checksum: hex.EncodeToString(r.sink.md5.Sum(nil)),
+	})
+	return nil
+}
+
+func (r *inventoryReport) add(o *ObjectInfo) error {
+	if r.file != nil && r.objects >= inventoryMaxFileObjects {
+		if err := r.closeFile(); err != nil {
+			return err
+		}
+	}
+	if r.file == nil {
+		if err := r.newFile(); err != nil {
+			return err
+		}
+	}
+	values := make([]interface{}, len(r.columns))
+	for i, column := range r.columns {
+		if !o.DeleteMarker || column.deleteMarker {
+			values[i] = column.value(r.bucket, o)
+		}
+	}
+	r.objects++
+	return r.file.Write(values)
+}
+
+// abort stops writing the current file, it is not completed.
+func (r *inventoryReport) abort(err error) {
+	if r.file != nil {
+		r.pw.CloseWithError(err)
+		<-r.done
+		r.file = nil
+	}
+}
+
+// putInventoryObject writes an object of an inventory report into
+// the destination bucket, size is -1 if it is not known in advance.
+func putInventoryObject(ctx context.Context, objAPI ObjectLayer, bucket, object string, data io.Reader, size int64, contentType string) error {
+	hashReader, err := xhash.NewReader(data, size, "", "", size)
+	if err != nil {
+		return err
+	}
+	opts := ObjectOptions{
+		UserDefined:      map[string]string{xhttp.ContentType: contentType},
+		Versioned:        globalBucketVersioningSys.PrefixEnabled(bucket, object),
+		VersionSuspended: globalBucketVersioningSys.PrefixSuspended(bucket, object),
+	}
+	objInfo, err := objAPI.PutObject(ctx, bucket, object, NewPutObjReader(hashReader), opts)
+	if err != nil {
+		return err
+	}
+	sendEvent(eventArgs{
+		EventName:  event.ObjectCreatedPut,
+		BucketName: bucket,
+		Object:     objInfo,
+		Host:       "Internal: [Inventory]",
+	})
+	return nil
+}
+
+// generateInventoryReport lists the objects of bucket selected by cfg
+// into report files in the destination bucket, and writes the manifest
+// of the report created at now:
+//
+//	<prefix>/<bucket>/<id>/data/<uuid>.csv.gz or .parquet
+//	<prefix>/<bucket>/<id>/<YYYY-MM-DDTHH-MMZ>/manifest.json
+//	<prefix>/<bucket>/<id>/<YYYY-MM-DDTHH-MMZ>/manifest.checksum
+//	<prefix>/<bucket>/<id>/hive/dt=<YYYY-MM-DD-HH-MM>/symlink.txt
+func generateInventoryReport(ctx context.Context, objAPI ObjectLayer, bucket string, cfg inventory.Config, now time.Time) error {
+	dst := cfg.Destination.S3BucketDestination
+	base := path.Join(dst.Prefix, bucket, cfg.ID)
+	columns := inventoryColumns(cfg)
+	report := &inventoryReport{
+		ctx:       ctx,
+		objAPI:    objAPI,
+		bucket:    bucket,
+		dstBucket: dst.BucketName(),
+		dataDir:   path.Join(base, "data"),
+		format:    dst.Format,
+		schema:    inventoryFileSchema(dst.Format, columns),
+		columns:   columns,
+	}
+
+	err := listInventoryObjects(ctx, objAPI, bucket, cfg, report.add)
+	if err == nil {
+		err = report.closeFile()
+	}
+	if err != nil {
+		report.abort(err)
+		return err
+	}
+
+	now = now.UTC()
+	manifest := inventoryManifest{
+		SourceBucket:      bucket,
+		DestinationBucket: dst.Bucket,
+		Version:           inventoryManifestVersion,
+		CreationTimestamp: strconv.FormatInt(now.UnixMilli(), 10),
+		FileFormat:        string(dst.Format),
+		FileSchema:        report.schema,
+		Files:             report.files,
+	}
+	if manifest.Files == nil {
+		manifest.Files = []inventoryManifestFile{}
+	}
+	manifestData, err := json.Marshal(manifest)
+	if err != nil {
+		return err
+	}
+
+	var symlinks bytes.Buffer
+	for _, file := range report.files {
+		fmt.Fprintf(&symlinks, "s3://%s/%s\n", report.dstBucket, file.Key)
+	}
+	hiveKey := path.Join(base, "hive", "dt="+now.Format("2006-01-02-15-04"), "symlink.txt")
+	if err = putInventoryObject(ctx, objAPI, report.dstBucket, hiveKey, bytes.NewReader(symlinks.Bytes()), int64(symlinks.Len()), "text/plain"); err != nil {
+		return err
+	}
+
+	manifestDir := path.Join(base, now.Format("2006-01-02T15-04Z"))
+	if err = putInventoryObject(ctx, objAPI, report.dstBucket, path.Join(manifestDir, "manifest.json"), bytes.NewReader(manifestData), int64(len(manifestData)), "application/json"); err != nil {
+		return err
+	}
+	// The checksum is written last, it signals a complete report.
+	sum := md5.Sum(manifestData)
+	checksum := []byte(hex.EncodeToString(sum[:]))
+	return putInventoryObject(ctx, objAPI, report.dstBucket, path.Join(manifestDir, "manifest.checksum"), bytes.NewReader(checksum), int64(len(checksum)), "text/plain")
+}
+
+// listInventoryObjects calls fn for all objects, or all versions,
+// of bucket listed by the reports of cfg.
+func listInventoryObjects(ctx context.Context, objAPI ObjectLayer, bucket string, cfg inventory.Config, fn func(*ObjectInfo) error) error {
+	var marker, versionMarker string
+	for {
+		var (
+			objects   []ObjectInfo
+			truncated bool
+		)
+		if cfg.AllVersions() {
+			loi, err := objAPI.ListObjectVersions(ctx, bucket, cfg.Prefix(), marker, versionMarker, "", maxObjectList)
+			if err != nil {
+				return err
+			}
+			objects, truncated = loi.Objects, loi.IsTruncated
+			marker, versionMarker = loi.NextMarker, loi.NextVersionIDMarker
+		} else {
+			loi, err := objAPI.ListObjects(ctx, bucket, cfg.Prefix(), marker, "", maxObjectList)
+			if err != nil {
+				return err
+			}
+			objects, truncated = loi.Objects, loi.IsTruncated
+			marker = loi.NextMarker
+		}
+		if err := DecryptETags(ctx, GlobalKMS, objects); err != nil {
+			return err
+		}
+		for i := range objects {
+			if err := fn(&objects[i]); err != nil {
+				return err
+			}
+		}
+		if !truncated || marker == "" {
+			return nil
+		}
+	}
+}
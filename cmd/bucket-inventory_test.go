@@ -0,0 +1,224 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"crypto/md5"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"path"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	goparquet "github.com/fraugster/parquet-go"
+	"github.com/klauspost/compress/gzip"
+)
+
+func inventoryConfigXML(id, format, versions string) string {
+	return fmt.Sprintf(`<InventoryConfiguration xmlns="http://s3.amazonaws.com/doc/2006-03-01/">
+  <Id>%s</Id>
+  <IsEnabled>true</IsEnabled>
+  <Destination>
+    <S3BucketDestination>
+      <Bucket>arn:aws:s3:::reports</Bucket>
+      <Format>%s</Format>
+      <Prefix>inventory</Prefix>
+    </S3BucketDestination>
+  </Destination>
+  <IncludedObjectVersions>%s</IncludedObjectVersions>
+  <OptionalFields>
+    <Field>Size</Field>
+    <Field>ETag</Field>
+    <Field>EncryptionStatus</Field>
+  </OptionalFields>
+  <Schedule><Frequency>Daily</Frequency></Schedule>
+</InventoryConfiguration>`, id, format, versions)
+}
+
+func TestBucketInventory(t *testing.T) {
+	testServer := StartTestServer(t, "ErasureSD")
+	defer testServer.Stop()
+
+	do := func(method, path string, body []byte) (*http.Response, []byte) {
+		t.Helper()
+		req, err := newTestRequest(method, testServer.Server.URL+path, int64(len(body)), bytes.NewReader(body))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err = signRequestV4(req, testServer.AccessKey, testServer.SecretKey); err != nil {
+			t.Fatal(err)
+		}
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer resp.Body.Close()
+		data, err := ioutil.ReadAll(resp.Body)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return resp, data
+	}
+
+	for _, path := range []string{"/source", "/reports", "/source/a b.txt", "/source/dir/c"} {
+		var body []byte
+		if strings.Count(path, "/") > 1 {
+			body = []byte(path)
+		}
+		if resp, _ := do(http.MethodPut, path, body); resp.StatusCode != http.StatusOK {
+			t.Fatalf("unable to create %s: %s", path, resp.Status)
+		}
+	}
+
+	if resp, _ := do(http.MethodPut, "/source?inventory&id=other", []byte(inventoryConfigXML("daily", "CSV", "Current"))); resp.StatusCode != http.StatusBadRequest {
+		t.Errorf("expected a mismatching ID to be rejected, got %s", resp.Status)
+	}
+	if resp, _ := do(http.MethodPut, "/source?inventory&id=daily", []byte(inventoryConfigXML("daily", "ORC", "Current"))); resp.StatusCode != http.StatusBadRequest {
+		t.Errorf("expected the ORC format to be rejected, got %s", resp.Status)
+	}
+	for _, id := range []string{"daily", "parquet"} {
+		format := "CSV"
+		if id == "parquet" {
+			format = "Parquet"
+		}
+		if resp, body := do(http.MethodPut, "/source?inventory&id="+id, []byte(inventoryConfigXML(id, format, "Current"))); resp.StatusCode != http.StatusOK {
+			t.Fatalf("unable to put inventory configuration: %s %s", resp.Status, body)
+		}
+	}
+
+	resp, body := do(http.MethodGet, "/source?inventory&id=daily", nil)
+	if resp.StatusCode != http.StatusOK || !strings.Contains(string(body), "<Id>daily</Id>") {
+		t.Fatalf("unexpected inventory configuration: %s %s", resp.Status, body)
+	}
+	if resp, _ = do(http.MethodGet, "/source?inventory&id=unknown", nil); resp.StatusCode != http.StatusNotFound {
+		t.Errorf("expected an unknown configuration not to be found, got %s", resp.Status)
+	}
+	_, body = do(http.MethodGet, "/source?inventory", nil)
+	var list ListBucketInventoryConfigurationsResponse
+	if err := xml.Unmarshal(body, &list); err != nil {
+		t.Fatal(err)
+	}
+	if len(list.InventoryConfigurations) != 2 || list.InventoryConfigurations[0].ID != "daily" || list.IsTruncated {
+		t.Fatalf("unexpected inventory configurations %+v", list)
+	}
+
+	getObject := func(object string) []byte {
+		t.Helper()
+		resp, body := do(http.MethodGet, "/reports/"+object, nil)
+		if resp.StatusCode != http.StatusOK {
+			t.Fatalf("unable to get %s: %s", object, resp.Status)
+		}
+		return body
+	}
+
+	// Generate the reports, a following cycle has nothing to do.
+	now := time.Date(2022, 5, 1, 10, 30, 0, 0, time.UTC)
+	runInventoryCycle(context.Background(), testServer.Obj, now)
+	runInventoryCycle(context.Background(), testServer.Obj, now.Add(time.Hour))
+	objects, err := testServer.Obj.ListObjects(context.Background(), "reports", "inventory/source/daily/", "", "", 100)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(objects.Objects) != 4 {
+		t.Fatalf("expected one data file, a manifest, its checksum and a symlink file, got %+v", objects.Objects)
+	}
+
+	manifestData := getObject("inventory/source/daily/2022-05-01T10-30Z/manifest.json")
+	sum := md5.Sum(manifestData)
+	if checksum := getObject("inventory/source/daily/2022-05-01T10-30Z/manifest.checksum"); string(checksum) != hex.EncodeToString(sum[:]) {
+		t.Errorf("expected manifest checksum %x, got %s", sum, checksum)
+	}
+	var manifest inventoryManifest
+	if err = json.Unmarshal(manifestData, &manifest); err != nil {
+		t.Fatal(err)
+	}
+	if manifest.SourceBucket != "source" || manifest.DestinationBucket != "arn:aws:s3:::reports" || manifest.FileFormat != "CSV" ||
+		manifest.FileSchema != "Bucket, Key, Size, ETag, EncryptionStatus" || len(manifest.Files) != 1 {
+		t.Fatalf("unexpected manifest %+v", manifest)
+	}
+	data := getObject(manifest.Files[0].Key)
+	if sum := md5.Sum(data); hex.EncodeToString(sum[:]) != manifest.Files[0].MD5Checksum || int64(len(data)) != manifest.Files[0].Size {
+		t.Errorf("unexpected data file %+v", manifest.Files[0])
+	}
+	gz, err := gzip.NewReader(bytes.NewReader(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+	csv, err := ioutil.ReadAll(gz)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rows := strings.Split(strings.TrimSpace(string(csv)), "\n")
+	sort.Strings(rows)
+	info, err := testServer.Obj.GetObjectInfo(context.Background(), "source", "a b.txt", ObjectOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(rows) != 2 || rows[0] != fmt.Sprintf(`"source","a+b.txt","15","%s","NOT-SSE"`, info.ETag) || !strings.HasPrefix(rows[1], `"source","dir%2Fc","13",`) {
+		t.Errorf("unexpected report rows %q", rows)
+	}
+	symlinks := getObject("inventory/source/daily/hive/dt=2022-05-01-10-30/symlink.txt")
+	if string(symlinks) != "s3://reports/"+manifest.Files[0].Key+"\n" {
+		t.Errorf("unexpected symlinks %q", symlinks)
+	}
+
+	manifestData = getObject("inventory/source/parquet/2022-05-01T10-30Z/manifest.json")
+	if err = json.Unmarshal(manifestData, &manifest); err != nil {
+		t.Fatal(err)
+	}
+	if manifest.FileFormat != "Parquet" || len(manifest.Files) != 1 || path.Ext(manifest.Files[0].Key) != ".parquet" {
+		t.Fatalf("unexpected manifest %+v", manifest)
+	}
+	fr, err := goparquet.NewFileReader(bytes.NewReader(getObject(manifest.Files[0].Key)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var keys []string
+	for {
+		row, err := fr.NextRow()
+		if err != nil {
+			break
+		}
+		keys = append(keys, string(row["key"].([]byte)))
+		if row["size"] == nil || string(row["encryption_status"].([]byte)) != "NOT-SSE" {
+			t.Errorf("unexpected row %v", row)
+		}
+	}
+	sort.Strings(keys)
+	if len(keys) != 2 || keys[0] != "a b.txt" || keys[1] != "dir/c" {
+		t.Errorf("unexpected keys %v", keys)
+	}
+
+	if resp, _ = do(http.MethodDelete, "/source?inventory&id=daily", nil); resp.StatusCode != http.StatusNoContent {
+		t.Fatalf("unable to delete inventory configuration: %s", resp.Status)
+	}
+	if resp, _ = do(http.MethodGet, "/source?inventory&id=daily", nil); resp.StatusCode != http.StatusNotFound {
+		t.Errorf("expected the deleted configuration not to be found, got %s", resp.Status)
+	}
+	if resp, _ = do(http.MethodDelete, "/source?inventory&id=daily", nil); resp.StatusCode != http.StatusNotFound {
+		t.Errorf("expected a deleted configuration not to be found, got %s", resp.Status)
+	}
+}
@@ -96,6 +96,8 @@ func (api objectAPIHandlers) PutBucketLifecycleHandler(w http.ResponseWriter, r
 		return
 	}
 
+	propagateBucketMetadata(bucket, bucketLifecycleConfig)
+
 	// Success.
 	writeSuccessResponseHeadersOnly(w)
 }
@@ -173,6 +175,8 @@ func (api objectAPIHandlers) DeleteBucketLifecycleHandler(w http.ResponseWriter,
 		return
 	}
 
+	propagateBucketMetadata(bucket, bucketLifecycleConfig)
+
 	// Success.
 	writeSuccessNoContent(w)
 }
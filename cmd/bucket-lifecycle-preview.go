@@ -0,0 +1,153 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"context"
+	"time"
+
+	"github.com/minio/minio/internal/bucket/lifecycle"
+)
+
+// lifecyclePreviewRule reports the versions and bytes that a single
+// lifecycle rule would expire or transition if it were applied now.
+type lifecyclePreviewRule struct {
+	ID                 string `json:"id"`
+	ExpireVersions     int64  `json:"expireVersions"`
+	ExpireBytes        int64  `json:"expireBytes"`
+	TransitionVersions int64  `json:"transitionVersions"`
+	TransitionBytes    int64  `json:"transitionBytes"`
+}
+
+// lifecyclePreview is the result of evaluating a lifecycle configuration
+// against the current contents of a bucket, without acting on it.
+type lifecyclePreview struct {
+	Bucket          string                 `json:"bucket"`
+	ScannedVersions int64                  `json:"scannedVersions"`
+	ScannedBytes    int64                  `json:"scannedBytes"`
+	Rules           []lifecyclePreviewRule `json:"rules"`
+	Complete        bool                   `json:"complete"`
+}
+
+// lifecyclePreviewer attributes the actions computed for a lifecycle
+// configuration to the rule responsible for each of them.
+type lifecyclePreviewer struct {
+	lc    *lifecycle.Lifecycle
+	rules []lifecycle.Lifecycle
+	stats lifecyclePreview
+}
+
+func newLifecyclePreviewer(bucket string, lc *lifecycle.Lifecycle) *lifecyclePreviewer {
+	p := &lifecyclePreviewer{
+		lc:    lc,
+		rules: make([]lifecycle.Lifecycle, 0, len(lc.Rules)),
+		stats: lifecyclePreview{
+			Bucket: bucket,
+			Rules:  make([]lifecyclePreviewRule, 0, len(lc.Rules)),
+		},
+	}
+	for _, rule := range lc.Rules {
+		p.rules = append(p.rules, lifecycle.Lifecycle{Rules: []lifecycle.Rule{rule}})
+		p.stats.Rules = append(p.stats.Rules, lifecyclePreviewRule{ID: rule.ID})
+	}
+	return p
+}
+
+// add evaluates the configuration against a single object version. The
+// action is attributed to the first rule which yields it on its own.
+func (p *lifecyclePreviewer) add(oi ObjectInfo) {
+	p.stats.ScannedVersions++
+	p.stats.ScannedBytes += oi.Size
+
+	opts := oi.ToLifecycleOpts()
+	action := p.lc.ComputeAction(opts)
+	if action == lifecycle.NoneAction {
+		return
+	}
+	for i := range p.rules {
+		if p.rules[i].ComputeAction(opts) != action {
+			continue
+		}
+		stats := &p.stats.Rules[i]
+		switch action {
+		case lifecycle.DeleteAction, lifecycle.DeleteVersionAction:
+			stats.ExpireVersions++
+			stats.ExpireBytes += oi.Size
+		case lifecycle.TransitionAction, lifecycle.TransitionVersionAction:
+			stats.TransitionVersions++
+			stats.TransitionBytes += oi.Size
+		}
+		return
+	}
+}
+
+// snapshot returns a copy of the statistics gathered so far.
+func (p *lifecyclePreviewer) snapshot() lifecyclePreview {
+	stats := p.stats
+	stats.Rules = append([]lifecyclePreviewRule(nil), p.stats.Rules...)
+	return stats
+}
+
+// previewBucketLifecycle walks all the object versions of bucket and
+// reports what lc would expire or transition. Partial results are sent
+// periodically, the last result sent has Complete set.
+func previewBucketLifecycle(ctx context.Context, objAPI ObjectLayer, bucket string, lc *lifecycle.Lifecycle) (<-chan lifecyclePreview, error) {
+	objInfoCh := make(chan ObjectInfo, 100)
+	if err := objAPI.Walk(ctx, bucket, "", objInfoCh, ObjectOptions{}); err != nil {
+		return nil, err
+	}
+
+	resultCh := make(chan lifecyclePreview)
+	go func() {
+		defer close(resultCh)
+		// Drain the walk so that it does not block on cancellation.
+		defer func() {
+			for range objInfoCh {
+			}
+		}()
+
+		p := newLifecyclePreviewer(bucket, lc)
+		progressTicker := time.NewTicker(10 * time.Second)
+		defer progressTicker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-progressTicker.C:
+				select {
+				case resultCh <- p.snapshot():
+				case <-ctx.Done():
+					return
+				}
+			case oi, ok := <-objInfoCh:
+				if !ok {
+					stats := p.snapshot()
+					stats.Complete = true
+					select {
+					case resultCh <- stats:
+					case <-ctx.Done():
+					}
+					return
+				}
+				p.add(oi)
+			}
+		}
+	}()
+	return resultCh, nil
+}
@@ -0,0 +1,67 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/minio/minio/internal/bucket/lifecycle"
+)
+
+func TestLifecyclePreviewer(t *testing.T) {
+	lc, err := lifecycle.ParseLifecycleConfig(strings.NewReader(`<LifecycleConfiguration>
+<Rule><ID>logs</ID><Status>Enabled</Status><Filter><Prefix>logs/</Prefix></Filter><Expiration><Days>1</Days></Expiration></Rule>
+<Rule><ID>cold</ID><Status>Enabled</Status><Filter><Prefix>cold/</Prefix></Filter><Transition><Days>1</Days><StorageClass>WARM</StorageClass></Transition></Rule>
+<Rule><ID>all</ID><Status>Enabled</Status><Filter></Filter><Expiration><Days>30</Days></Expiration></Rule>
+</LifecycleConfiguration>`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	now := time.Now()
+	objects := []ObjectInfo{
+		{Name: "logs/a", Size: 1, ModTime: now.Add(-48 * time.Hour)},
+		{Name: "logs/b", Size: 2, ModTime: now.Add(-40 * 24 * time.Hour)},
+		{Name: "cold/c", Size: 4, ModTime: now.Add(-48 * time.Hour)},
+		{Name: "data/d", Size: 8, ModTime: now.Add(-40 * 24 * time.Hour)},
+		{Name: "data/e", Size: 16, ModTime: now},
+	}
+	p := newLifecyclePreviewer("bucket", lc)
+	for _, oi := range objects {
+		oi.IsLatest = true
+		oi.NumVersions = 1
+		p.add(oi)
+	}
+
+	stats := p.snapshot()
+	if stats.ScannedVersions != 5 || stats.ScannedBytes != 31 {
+		t.Fatalf("unexpected scanned totals: %d versions, %d bytes", stats.ScannedVersions, stats.ScannedBytes)
+	}
+	expected := []lifecyclePreviewRule{
+		{ID: "logs", ExpireVersions: 2, ExpireBytes: 3},
+		{ID: "cold", TransitionVersions: 1, TransitionBytes: 4},
+		{ID: "all", ExpireVersions: 1, ExpireBytes: 8},
+	}
+	for i, rule := range expected {
+		if stats.Rules[i] != rule {
+			t.Errorf("rule %d: expected %+v, got %+v", i, rule, stats.Rules[i])
+		}
+	}
+}
@@ -0,0 +1,154 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/minio/minio/internal/event"
+	"github.com/minio/minio/internal/logger"
+)
+
+// Restore tiers of a RestoreObject request, by decreasing priority.
+const (
+	restoreTierExpedited = "Expedited"
+	restoreTierStandard  = "Standard"
+	restoreTierBulk      = "Bulk"
+)
+
+// restoreWorkers is the number of restores from remote tiers run
+// concurrently on a node.
+const restoreWorkers = 4
+
+// restorePriority returns the index of the queue of a restore tier, the
+// queues with a lower index are served first.
+func restorePriority(tier string) (int, bool) {
+	switch tier {
+	case restoreTierExpedited:
+		return 0, true
+	case restoreTierStandard:
+		return 1, true
+	case restoreTierBulk:
+		return 2, true
+	}
+	return 0, false
+}
+
+// restoreTask is a restore of an object version from its remote tier,
+// waiting for a worker.
+type restoreTask struct {
+	bucket string
+	object string
+	opts   ObjectOptions
+	// event is sent, with its name set, when the restore is done.
+	event eventArgs
+	done  func()
+}
+
+// restoreState queues the restores of transitioned objects by priority.
+type restoreState struct {
+	mu       sync.Mutex
+	queues   [3][]restoreTask
+	notifyCh chan struct{}
+}
+
+var globalRestoreState *restoreState
+
+func newRestoreState() *restoreState {
+	return &restoreState{
+		notifyCh: make(chan struct{}, restoreWorkers),
+	}
+}
+
+// queue adds a restore, which is run after the restores of a higher
+// priority and of the same priority queued before it.
+func (r *restoreState) queue(t restoreTask, tier string) {
+	prio, _ := restorePriority(tier)
+	t.done = globalTierMetrics.restoreStarted(t.event.Object.TransitionedObject.Tier)
+
+	r.mu.Lock()
+	r.queues[prio] = append(r.queues[prio], t)
+	r.mu.Unlock()
+
+	select {
+	case r.notifyCh <- struct{}{}:
+	default:
+	}
+}
+
+// next returns the restore of the highest priority waiting, if any.
+func (r *restoreState) next() (restoreTask, bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for prio, q := range r.queues {
+		if len(q) == 0 {
+			continue
+		}
+		t := q[0]
+		q[0] = restoreTask{}
+		r.queues[prio] = q[1:]
+		return t, true
+	}
+	return restoreTask{}, false
+}
+
+// PendingTasks returns the number of restores waiting for a worker.
+func (r *restoreState) PendingTasks() int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var n int
+	for _, q := range r.queues {
+		n += len(q)
+	}
+	return n
+}
+
+func (r *restoreState) worker(ctx context.Context, objAPI ObjectLayer) {
+	for {
+		t, ok := r.next()
+		if !ok {
+			select {
+			case <-ctx.Done():
+				return
+			case <-r.notifyCh:
+			}
+			continue
+		}
+
+		err := objAPI.RestoreTransitionedObject(ctx, t.bucket, t.object, t.opts)
+		t.done()
+		if err != nil {
+			logger.LogIf(ctx, fmt.Errorf("Restore failed for %s/%s version:%s with %w", t.bucket, t.object, t.opts.VersionID, err))
+			t.event.EventName = event.ObjectRestoreFailed
+		} else {
+			t.event.EventName = event.ObjectRestorePostCompleted
+		}
+		sendEvent(t.event)
+	}
+}
+
+func initBackgroundRestore(ctx context.Context, objAPI ObjectLayer) {
+	globalRestoreState = newRestoreState()
+	for i := 0; i < restoreWorkers; i++ {
+		go globalRestoreState.worker(ctx, objAPI)
+	}
+}
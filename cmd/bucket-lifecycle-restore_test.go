@@ -0,0 +1,81 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+func TestRestoreStatePriority(t *testing.T) {
+	r := newRestoreState()
+	for _, tc := range []struct {
+		object string
+		tier   string
+	}{
+		{"bulk", restoreTierBulk},
+		{"standard", restoreTierStandard},
+		{"expedited", restoreTierExpedited},
+		{"standard-2", restoreTierStandard},
+	} {
+		r.queue(restoreTask{object: tc.object}, tc.tier)
+	}
+	if n := r.PendingTasks(); n != 4 {
+		t.Fatalf("expected 4 pending restores, got %d", n)
+	}
+
+	for _, expected := range []string{"expedited", "standard", "standard-2", "bulk"} {
+		task, ok := r.next()
+		if !ok {
+			t.Fatalf("expected restore of %s, got none", expected)
+		}
+		task.done()
+		if task.object != expected {
+			t.Fatalf("expected restore of %s, got %s", expected, task.object)
+		}
+	}
+	if _, ok := r.next(); ok {
+		t.Fatal("expected no pending restore")
+	}
+}
+
+func TestRestoreRequestTier(t *testing.T) {
+	for i, tc := range []struct {
+		params  string
+		tier    string
+		wantErr bool
+	}{
+		{"", restoreTierStandard, false},
+		{"<Tier>Bulk</Tier>", restoreTierBulk, false},
+		{"<GlacierJobParameters><Tier>Expedited</Tier></GlacierJobParameters>", restoreTierExpedited, false},
+		{"<GlacierJobParameters><Tier>Glacier</Tier></GlacierJobParameters>", "Glacier", true},
+	} {
+		body := `<RestoreRequest xmlns="http://s3.amazonaws.com/doc/2006-03-01/"><Days>1</Days>` + tc.params + `</RestoreRequest>`
+		rreq, err := parseRestoreRequest(strings.NewReader(body))
+		if err != nil {
+			t.Fatalf("Test %d: %v", i+1, err)
+		}
+		if tier := rreq.restoreTier(); tier != tc.tier {
+			t.Fatalf("Test %d: expected tier %q, got %q", i+1, tc.tier, tier)
+		}
+		if err = rreq.validate(context.Background(), nil); (err != nil) != tc.wantErr {
+			t.Fatalf("Test %d: expected error %v, got %v", i+1, tc.wantErr, err)
+		}
+	}
+}
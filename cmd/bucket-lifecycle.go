@@ -217,9 +217,13 @@ func (t *transitionState) worker(ctx context.Context, objectAPI ObjectLayer) {
 				return
 			}
 			atomic.AddInt32(&t.activeTasks, 1)
-			var tier string
-			var err error
-			if tier, err = transitionObject(ctx, objectAPI, oi); err != nil {
+			tier, err := transitionTier(oi)
+			if err == nil {
+				done := globalTierMetrics.transitionStarted(tier)
+				err = transitionObject(ctx, objectAPI, oi, tier)
+				done(oi.Size, err)
+			}
+			if err != nil {
 				logger.LogIf(ctx, fmt.Errorf("Transition failed for %s/%s version:%s with %w", oi.Bucket, oi.Name, oi.VersionID, err))
 			} else {
 				ts := tierStats{
@@ -402,12 +406,7 @@ func genTransitionObjName(bucket string) (string, error) {
 // storage specified by the transition ARN, the metadata is left behind on source cluster and original content
 // is moved to the transition tier. Note that in the case of encrypted objects, entire encrypted stream is moved
 // to the transition tier without decrypting or re-encrypting.
-func transitionObject(ctx context.Context, objectAPI ObjectLayer, oi ObjectInfo) (string, error) {
-	lc, err := globalLifecycleSys.Get(oi.Bucket)
-	if err != nil {
-		return "", err
-	}
-	tier := lc.TransitionTier(oi.ToLifecycleOpts())
+func transitionObject(ctx context.Context, objectAPI ObjectLayer, oi ObjectInfo, tier string) error {
 	opts := ObjectOptions{
 		Transition: TransitionOptions{
 			Status: lifecycle.TransitionPending,
@@ -419,7 +418,17 @@ func transitionObject(ctx context.Context, objectAPI ObjectLayer, oi ObjectInfo)
 		VersionSuspended: globalBucketVersioningSys.PrefixSuspended(oi.Bucket, oi.Name),
 		MTime:            oi.ModTime,
 	}
-	return tier, objectAPI.TransitionObject(ctx, oi.Bucket, oi.Name, opts)
+	return objectAPI.TransitionObject(ctx, oi.Bucket, oi.Name, opts)
+}
+
+// transitionTier returns the tier oi is to be transitioned to as per the
+// lifecycle configuration of its bucket.
+func transitionTier(oi ObjectInfo) (string, error) {
+	lc, err := globalLifecycleSys.Get(oi.Bucket)
+	if err != nil {
+		return "", err
+	}
+	return lc.TransitionTier(oi.ToLifecycleOpts()), nil
 }
 
 // getTransitionedObjectReader returns a reader from the transitioned tier.
@@ -513,15 +522,33 @@ func (sp *SelectParameters) UnmarshalXML(d *xml.Decoder, start xml.StartElement)
 	return sp.S3Select.UnmarshalXML(d, start)
 }
 
+// GlacierJobParameters specifies the restore tier of a restore object request
+type GlacierJobParameters struct {
+	Tier string `xml:"Tier"`
+}
+
 // RestoreObjectRequest - xml to restore a transitioned object
 type RestoreObjectRequest struct {
-	XMLName          xml.Name           `xml:"http://s3.amazonaws.com/doc/2006-03-01/ RestoreRequest" json:"-"`
-	Days             int                `xml:"Days,omitempty"`
-	Type             RestoreRequestType `xml:"Type,omitempty"`
-	Tier             string             `xml:"Tier,-"`
-	Description      string             `xml:"Description,omitempty"`
-	SelectParameters *SelectParameters  `xml:"SelectParameters,omitempty"`
-	OutputLocation   OutputLocation     `xml:"OutputLocation,omitempty"`
+	XMLName              xml.Name              `xml:"http://s3.amazonaws.com/doc/2006-03-01/ RestoreRequest" json:"-"`
+	Days                 int                   `xml:"Days,omitempty"`
+	GlacierJobParameters *GlacierJobParameters `xml:"GlacierJobParameters,omitempty"`
+	Type                 RestoreRequestType    `xml:"Type,omitempty"`
+	Tier                 string                `xml:"Tier,omitempty"`
+	Description          string                `xml:"Description,omitempty"`
+	SelectParameters     *SelectParameters     `xml:"SelectParameters,omitempty"`
+	OutputLocation       OutputLocation        `xml:"OutputLocation,omitempty"`
+}
+
+// restoreTier returns the restore tier of r, Standard if unspecified.
+func (r *RestoreObjectRequest) restoreTier() string {
+	tier := r.Tier
+	if r.GlacierJobParameters != nil && r.GlacierJobParameters.Tier != "" {
+		tier = r.GlacierJobParameters.Tier
+	}
+	if tier == "" {
+		return restoreTierStandard
+	}
+	return tier
 }
 
 // Maximum 2MiB size per restore object request.
@@ -558,6 +585,9 @@ func (r *RestoreObjectRequest) validate(ctx context.Context, objAPI ObjectLayer)
 	if r.Days == 0 && r.Type != SelectRestoreRequest {
 		return fmt.Errorf("restoration days should be at least 1")
 	}
+	if _, ok := restorePriority(r.restoreTier()); !ok {
+		return fmt.Errorf("Tier must be one of %s, %s or %s", restoreTierExpedited, restoreTierStandard, restoreTierBulk)
+	}
 	// Check if bucket exists.
 	if !r.OutputLocation.IsEmpty() {
 		if _, err := objAPI.GetBucketInfo(ctx, r.OutputLocation.S3.BucketName); err != nil {
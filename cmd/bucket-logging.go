@@ -0,0 +1,267 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	humanize "github.com/dustin/go-humanize"
+	"github.com/gorilla/mux"
+	"github.com/minio/minio/internal/bucket/logging"
+	"github.com/minio/minio/internal/event"
+	"github.com/minio/minio/internal/handlers"
+	xhash "github.com/minio/minio/internal/hash"
+	xhttp "github.com/minio/minio/internal/http"
+	"github.com/minio/minio/internal/logger"
+)
+
+const (
+	// Interval at which the buffered access logs are delivered.
+	bucketAccessLogFlushInterval = 5 * time.Minute
+
+	// Size above which the access logs buffered for a target
+	// prefix are delivered without waiting for the interval.
+	bucketAccessLogMaxBufferSize = 4 * humanize.MiByte
+)
+
+// Subresources named in the operations of access log entries,
+// e.g. REST.GET.TAGGING for GetObjectTagging.
+var bucketAccessLogSubresources = []string{
+	"acl", "cors", "encryption", "legal-hold", "lifecycle", "location",
+	"logging", "notification", "object-lock", "policy", "replication",
+	"retention", "tagging", "uploads", "versioning", "website",
+}
+
+// bucketAccessLogs buffers the server access log entries of the
+// buckets with logging enabled until they are delivered to their
+// target prefix, the zero value is ready to use.
+type bucketAccessLogs struct {
+	mu      sync.Mutex
+	buffers map[logging.LoggingEnabled]*bytes.Buffer
+}
+
+// initBucketAccessLogs starts delivering the buffered access logs
+// of the buckets on schedule.
+func initBucketAccessLogs(ctx context.Context, objAPI ObjectLayer) {
+	go func() {
+		ticker := time.NewTicker(bucketAccessLogFlushInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				globalBucketAccessLogs.flush(ctx, objAPI)
+			}
+		}
+	}()
+}
+
+// record adds the request r served by w to the access logs of its
+// bucket, if logging is enabled.
+func (l *bucketAccessLogs) record(r *http.Request, w *logger.ResponseWriter) {
+	bucket := mux.Vars(r)["bucket"]
+	if bucket == "" || globalBucketMetadataSys == nil {
+		return
+	}
+	meta, err := globalBucketMetadataSys.Get(bucket)
+	if err != nil || meta.loggingConfig == nil || !meta.loggingConfig.Enabled() {
+		return
+	}
+	target := *meta.loggingConfig.LoggingEnabled
+	line := newBucketAccessLogEntry(bucket, r, w).String() + "\n"
+
+	l.mu.Lock()
+	if l.buffers == nil {
+		l.buffers = make(map[logging.LoggingEnabled]*bytes.Buffer)
+	}
+	buf, ok := l.buffers[target]
+	if !ok {
+		buf = &bytes.Buffer{}
+		l.buffers[target] = buf
+	}
+	buf.WriteString(line)
+	full := buf.Len() >= bucketAccessLogMaxBufferSize
+	if full {
+		delete(l.buffers, target)
+	}
+	l.mu.Unlock()
+
+	if full {
+		if objAPI := newObjectLayerFn(); objAPI != nil {
+			go deliverBucketAccessLogs(GlobalContext, objAPI, target, buf.Bytes())
+		}
+	}
+}
+
+// flush delivers all the buffered access logs.
+func (l *bucketAccessLogs) flush(ctx context.Context, objAPI ObjectLayer) {
+	l.mu.Lock()
+	buffers := l.buffers
+	l.buffers = nil
+	l.mu.Unlock()
+
+	for target, buf := range buffers {
+		deliverBucketAccessLogs(ctx, objAPI, target, buf.Bytes())
+	}
+}
+
+// deliverBucketAccessLogs writes data, access log entries, as a new
+// object of the target prefix named like Amazon S3 log objects,
+// TargetPrefixYYYY-mm-DD-HH-MM-SS-UniqueString.
+func deliverBucketAccessLogs(ctx context.Context, objAPI ObjectLayer, target logging.LoggingEnabled, data []byte) {
+	unique := strings.ToUpper(strings.ReplaceAll(mustGetUUID(), "-", "")[:16])
+	object := target.TargetPrefix + UTCNow().Format("2006-01-02-15-04-05") + "-" + unique
+
+	hashReader, err := xhash.NewReader(bytes.NewReader(data), int64(len(data)), "", "", int64(len(data)))
+	if err != nil {
+		logger.LogIf(ctx, err)
+		return
+	}
+	opts := ObjectOptions{
+		UserDefined:      map[string]string{xhttp.ContentType: "text/plain"},
+		Versioned:        globalBucketVersioningSys.PrefixEnabled(target.TargetBucket, object),
+		VersionSuspended: globalBucketVersioningSys.PrefixSuspended(target.TargetBucket, object),
+	}
+	objInfo, err := objAPI.PutObject(ctx, target.TargetBucket, object, NewPutObjReader(hashReader), opts)
+	if err != nil {
+		logger.LogIf(ctx, err)
+		return
+	}
+	sendEvent(eventArgs{
+		EventName:  event.ObjectCreatedPut,
+		BucketName: target.TargetBucket,
+		Object:     objInfo,
+		Host:       "Internal: [Access Logs]",
+	})
+}
+
+// responseObjectSize returns the size of the object returned with
+// the headers h, the total of the range for partial content.
+func responseObjectSize(h http.Header) int64 {
+	if cr := h.Get(xhttp.ContentRange); cr != "" {
+		if i := strings.LastIndexByte(cr, '/'); i >= 0 {
+			if size, err := strconv.ParseInt(cr[i+1:], 10, 64); err == nil {
+				return size
+			}
+		}
+	}
+	size, err := strconv.ParseInt(h.Get(xhttp.ContentLength), 10, 64)
+	if err != nil {
+		return 0
+	}
+	return size
+}
+
+// newBucketAccessLogEntry describes the request r on bucket served by w.
+func newBucketAccessLogEntry(bucket string, r *http.Request, w *logger.ResponseWriter) logging.Entry {
+	object := likelyUnescapeGeneric(mux.Vars(r)["object"], url.PathUnescape)
+	start := requestStartTime(r, w)
+	entry := logging.Entry{
+		BucketOwner:    globalDeploymentID,
+		Bucket:         bucket,
+		Time:           start,
+		RemoteIP:       handlers.GetSourceIP(r),
+		RequestID:      w.Header().Get(xhttp.AmzRequestID),
+		Operation:      bucketAccessLogOperation(r, object),
+		Key:            s3URLEncode(object),
+		RequestURI:     r.Method + " " + r.RequestURI + " " + r.Proto,
+		HTTPStatus:     w.StatusCode,
+		ErrorCode:      requestErrorCode(r),
+		BytesSent:      int64(w.BodySize()),
+		TotalTime:      time.Since(start),
+		TurnAroundTime: w.TimeToFirstByte,
+		Referer:        r.Referer(),
+		UserAgent:      r.UserAgent(),
+		VersionID:      r.URL.Query().Get(xhttp.VersionID),
+		HostHeader:     r.Host,
+	}
+	switch {
+	case object == "":
+	case r.Method == http.MethodPut || r.Method == http.MethodPost:
+		entry.ObjectSize = r.ContentLength
+	case (r.Method == http.MethodGet || r.Method == http.MethodHead) && w.StatusCode < http.StatusMultipleChoices:
+		entry.ObjectSize = responseObjectSize(w.Header())
+	}
+	if accessKey, _, ok := requestCredentials(r); ok {
+		entry.Requester = accessKey
+	}
+	switch getRequestAuthType(r) {
+	case authTypeSigned, authTypeStreamingSigned:
+		entry.SignatureVersion, entry.AuthType = "SigV4", "AuthHeader"
+	case authTypePresigned:
+		entry.SignatureVersion, entry.AuthType = "SigV4", "QueryString"
+	case authTypeSignedV2:
+		entry.SignatureVersion, entry.AuthType = "SigV2", "AuthHeader"
+	case authTypePresignedV2:
+		entry.SignatureVersion, entry.AuthType = "SigV2", "QueryString"
+	}
+	if r.TLS != nil {
+		entry.CipherSuite = tls.CipherSuiteName(r.TLS.CipherSuite)
+		switch r.TLS.Version {
+		case tls.VersionTLS10:
+			entry.TLSVersion = "TLSv1"
+		case tls.VersionTLS11:
+			entry.TLSVersion = "TLSv1.1"
+		case tls.VersionTLS12:
+			entry.TLSVersion = "TLSv1.2"
+		case tls.VersionTLS13:
+			entry.TLSVersion = "TLSv1.3"
+		}
+	}
+	return entry
+}
+
+// bucketAccessLogOperation returns the operation of the request r on
+// object in the Amazon S3 format, e.g. REST.PUT.OBJECT or REST.GET.ACL.
+func bucketAccessLogOperation(r *http.Request, object string) string {
+	method, resource := r.Method, "BUCKET"
+	if object != "" {
+		resource = "OBJECT"
+	}
+	query := r.URL.Query()
+	switch {
+	case r.Method == http.MethodPut && r.Header.Get(xhttp.AmzCopySource) != "":
+		method = "COPY"
+		if query.Get(xhttp.UploadID) != "" {
+			resource = "PART"
+		}
+	case query.Get(xhttp.UploadID) != "":
+		resource = "UPLOAD"
+		if r.Method == http.MethodPut {
+			resource = "PART"
+		}
+	case object == "" && query.Has("versions"):
+		resource = "BUCKETVERSIONS"
+	default:
+		for _, subresource := range bucketAccessLogSubresources {
+			if query.Has(subresource) {
+				resource = strings.ToUpper(strings.ReplaceAll(subresource, "-", "_"))
+				break
+			}
+		}
+	}
+	return "REST." + method + "." + resource
+}
@@ -0,0 +1,140 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestBucketAccessLogOperation(t *testing.T) {
+	testCases := []struct {
+		method     string
+		target     string
+		copySource string
+		object     string
+		operation  string
+	}{
+		{http.MethodGet, "/photos/cat.jpg", "", "cat.jpg", "REST.GET.OBJECT"},
+		{http.MethodPut, "/photos/cat.jpg", "", "cat.jpg", "REST.PUT.OBJECT"},
+		{http.MethodPut, "/photos/cat.jpg", "/photos/dog.jpg", "cat.jpg", "REST.COPY.OBJECT"},
+		{http.MethodPut, "/photos/cat.jpg?partNumber=1&uploadId=abc", "", "cat.jpg", "REST.PUT.PART"},
+		{http.MethodPost, "/photos/cat.jpg?uploadId=abc", "", "cat.jpg", "REST.POST.UPLOAD"},
+		{http.MethodPost, "/photos/cat.jpg?uploads", "", "cat.jpg", "REST.POST.UPLOADS"},
+		{http.MethodGet, "/photos/cat.jpg?tagging", "", "cat.jpg", "REST.GET.TAGGING"},
+		{http.MethodPut, "/photos/cat.jpg?legal-hold", "", "cat.jpg", "REST.PUT.LEGAL_HOLD"},
+		{http.MethodGet, "/photos/", "", "", "REST.GET.BUCKET"},
+		{http.MethodGet, "/photos/?versions", "", "", "REST.GET.BUCKETVERSIONS"},
+		{http.MethodPut, "/photos/?logging", "", "", "REST.PUT.LOGGING"},
+	}
+	for i, testCase := range testCases {
+		r := httptest.NewRequest(testCase.method, testCase.target, nil)
+		if testCase.copySource != "" {
+			r.Header.Set("X-Amz-Copy-Source", testCase.copySource)
+		}
+		if operation := bucketAccessLogOperation(r, testCase.object); operation != testCase.operation {
+			t.Errorf("Test %d: expected %s, got %s", i+1, testCase.operation, operation)
+		}
+	}
+}
+
+func TestBucketLogging(t *testing.T) {
+	testServer := StartTestServer(t, "ErasureSD")
+	defer testServer.Stop()
+
+	ctx := context.Background()
+	objAPI := testServer.Obj
+	for _, bucket := range []string{"photos", "logs"} {
+		if err := objAPI.MakeBucketWithLocation(ctx, bucket, BucketOptions{}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	do := func(method, target, body string) (*http.Response, []byte) {
+		req, err := newTestSignedRequestV4(method, testServer.Server.URL+target, int64(len(body)), strings.NewReader(body),
+			testServer.AccessKey, testServer.SecretKey, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		respBody, _ := ioutil.ReadAll(resp.Body)
+		resp.Body.Close()
+		return resp, respBody
+	}
+
+	if resp, body := do(http.MethodGet, "/photos/?logging", ""); resp.StatusCode != http.StatusOK || bytes.Contains(body, []byte("LoggingEnabled")) {
+		t.Fatalf("unexpected logging status without configuration %d %q", resp.StatusCode, body)
+	}
+	missing := `<BucketLoggingStatus><LoggingEnabled><TargetBucket>missing</TargetBucket><TargetPrefix>photos/</TargetPrefix></LoggingEnabled></BucketLoggingStatus>`
+	if resp, body := do(http.MethodPut, "/photos/?logging", missing); resp.StatusCode != http.StatusBadRequest || !bytes.Contains(body, []byte("InvalidTargetBucketForLogging")) {
+		t.Fatalf("unexpected response for a missing target bucket %d %q", resp.StatusCode, body)
+	}
+	config := `<BucketLoggingStatus><LoggingEnabled><TargetBucket>logs</TargetBucket><TargetPrefix>photos/</TargetPrefix></LoggingEnabled></BucketLoggingStatus>`
+	if resp, body := do(http.MethodPut, "/photos/?logging", config); resp.StatusCode != http.StatusOK {
+		t.Fatalf("unexpected response %d %q", resp.StatusCode, body)
+	}
+	if resp, body := do(http.MethodGet, "/photos/?logging", ""); resp.StatusCode != http.StatusOK || !bytes.Contains(body, []byte("<TargetBucket>logs</TargetBucket>")) {
+		t.Fatalf("unexpected logging status %d %q", resp.StatusCode, body)
+	}
+
+	do(http.MethodPut, "/photos/cat.jpg", "meow")
+	do(http.MethodGet, "/photos/cat.jpg", "")
+	do(http.MethodGet, "/photos/dog.jpg", "")
+	globalBucketAccessLogs.flush(ctx, objAPI)
+
+	result, err := objAPI.ListObjects(ctx, "logs", "photos/", "", "", 1000)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var logs bytes.Buffer
+	for _, object := range result.Objects {
+		r, err := objAPI.GetObjectNInfo(ctx, "logs", object.Name, nil, http.Header{}, readLock, ObjectOptions{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		logs.ReadFrom(r)
+		r.Close()
+	}
+	for _, want := range []string{
+		" photos ",
+		` REST.PUT.OBJECT cat.jpg "PUT /photos/cat.jpg HTTP/1.1" 200 - - 4 `,
+		` REST.GET.OBJECT cat.jpg "GET /photos/cat.jpg HTTP/1.1" 200 - 4 4 `,
+		` REST.GET.OBJECT dog.jpg "GET /photos/dog.jpg HTTP/1.1" 404 NoSuchKey `,
+		" " + testServer.AccessKey + " ",
+		" SigV4 - AuthHeader ",
+	} {
+		if !strings.Contains(logs.String(), want) {
+			t.Errorf("expected %q in the access logs:\n%s", want, logs.String())
+		}
+	}
+
+	// Disable logging.
+	if resp, body := do(http.MethodPut, "/photos/?logging", `<BucketLoggingStatus></BucketLoggingStatus>`); resp.StatusCode != http.StatusOK {
+		t.Fatalf("unexpected response %d %q", resp.StatusCode, body)
+	}
+	if resp, body := do(http.MethodGet, "/photos/?logging", ""); resp.StatusCode != http.StatusOK || bytes.Contains(body, []byte("LoggingEnabled")) {
+		t.Fatalf("unexpected logging status after disabling %d %q", resp.StatusCode, body)
+	}
+}
@@ -27,11 +27,15 @@ import (
 
 	"github.com/minio/madmin-go"
 	"github.com/minio/minio-go/v7/pkg/tags"
+	bucketcors "github.com/minio/minio/internal/bucket/cors"
 	bucketsse "github.com/minio/minio/internal/bucket/encryption"
+	"github.com/minio/minio/internal/bucket/inventory"
 	"github.com/minio/minio/internal/bucket/lifecycle"
+	"github.com/minio/minio/internal/bucket/logging"
 	objectlock "github.com/minio/minio/internal/bucket/object/lock"
 	"github.com/minio/minio/internal/bucket/replication"
 	"github.com/minio/minio/internal/bucket/versioning"
+	"github.com/minio/minio/internal/bucket/website"
 	"github.com/minio/minio/internal/event"
 	"github.com/minio/minio/internal/kms"
 	"github.com/minio/minio/internal/logger"
@@ -53,6 +57,9 @@ func (sys *BucketMetadataSys) Remove(bucket string) {
 	sys.Lock()
 	delete(sys.metadataMap, bucket)
 	globalBucketMonitor.DeleteBucket(bucket)
+	globalBucketThrottles.remove(bucket)
+	globalBucketTransformStats.remove(bucket)
+	globalBucketDomains.remove(bucket)
 	sys.Unlock()
 }
 
@@ -70,6 +77,9 @@ func (sys *BucketMetadataSys) Set(bucket string, meta BucketMetadata) {
 		sys.Lock()
 		sys.metadataMap[bucket] = meta
 		sys.Unlock()
+
+		globalBucketThrottles.set(bucket, meta.bandwidthLimits)
+		globalBucketDomains.set(bucket, meta.domains)
 	}
 }
 
@@ -126,6 +136,27 @@ func (sys *BucketMetadataSys) Update(ctx context.Context, bucket string, configF
 	case bucketQuotaConfigFile:
 		meta.QuotaConfigJSON = configData
 		meta.QuotaConfigUpdatedAt = UTCNow()
+	case bucketBandwidthConfigFile:
+		meta.BandwidthConfigJSON = configData
+		meta.BandwidthConfigUpdatedAt = UTCNow()
+	case bucketInventoryConfig:
+		meta.InventoryConfigXML = configData
+		meta.InventoryConfigUpdatedAt = UTCNow()
+	case bucketTransformConfigFile:
+		meta.TransformConfigJSON = configData
+		meta.TransformConfigUpdatedAt = UTCNow()
+	case bucketDomainsConfigFile:
+		meta.DomainsConfigJSON = configData
+		meta.DomainsConfigUpdatedAt = UTCNow()
+	case bucketCorsConfig:
+		meta.CorsConfigXML = configData
+		meta.CorsConfigUpdatedAt = UTCNow()
+	case bucketWebsiteConfig:
+		meta.WebsiteConfigXML = configData
+		meta.WebsiteConfigUpdatedAt = UTCNow()
+	case bucketLoggingConfig:
+		meta.LoggingConfigXML = configData
+		meta.LoggingConfigUpdatedAt = UTCNow()
 	case objectLockConfig:
 		meta.ObjectLockConfigXML = configData
 		meta.ObjectLockConfigUpdatedAt = UTCNow()
@@ -183,6 +214,20 @@ func (sys *BucketMetadataSys) Get(bucket string) (BucketMetadata, error) {
 	return meta, nil
 }
 
+// Exists returns true if the metadata of bucket is loaded, it is
+// cheaper than Get which copies the metadata.
+func (sys *BucketMetadataSys) Exists(bucket string) bool {
+	if globalIsGateway || bucket == minioMetaBucket {
+		return false
+	}
+
+	sys.RLock()
+	defer sys.RUnlock()
+
+	_, ok := sys.metadataMap[bucket]
+	return ok
+}
+
 // GetVersioningConfig returns configured versioning config
 // The returned object may not be modified.
 func (sys *BucketMetadataSys) GetVersioningConfig(bucket string) (*versioning.Versioning, time.Time, error) {
@@ -335,7 +380,7 @@ func (sys *BucketMetadataSys) GetPolicyConfig(bucket string) (*policy.Policy, ti
 
 // GetQuotaConfig returns configured bucket quota
 // The returned object may not be modified.
-func (sys *BucketMetadataSys) GetQuotaConfig(ctx context.Context, bucket string) (*madmin.BucketQuota, time.Time, error) {
+func (sys *BucketMetadataSys) GetQuotaConfig(ctx context.Context, bucket string) (*BucketQuotaConfig, time.Time, error) {
 	meta, err := sys.GetConfig(ctx, bucket)
 	if err != nil {
 		return nil, time.Time{}, err
@@ -343,6 +388,110 @@ func (sys *BucketMetadataSys) GetQuotaConfig(ctx context.Context, bucket string)
 	return meta.quotaConfig, meta.QuotaConfigUpdatedAt, nil
 }
 
+// GetBandwidthLimits returns the configured bucket bandwidth limits
+// The returned object may not be modified.
+func (sys *BucketMetadataSys) GetBandwidthLimits(ctx context.Context, bucket string) (*BucketBandwidthLimits, time.Time, error) {
+	meta, err := sys.GetConfig(ctx, bucket)
+	if err != nil {
+		return nil, time.Time{}, err
+	}
+	if meta.bandwidthLimits == nil {
+		return &BucketBandwidthLimits{}, meta.BandwidthConfigUpdatedAt, nil
+	}
+	return meta.bandwidthLimits, meta.BandwidthConfigUpdatedAt, nil
+}
+
+// GetTransforms returns the configured bucket access points
+// The returned object may not be modified.
+func (sys *BucketMetadataSys) GetTransforms(ctx context.Context, bucket string) (*BucketTransforms, time.Time, error) {
+	meta, err := sys.GetConfig(ctx, bucket)
+	if err != nil {
+		return nil, time.Time{}, err
+	}
+	if meta.transforms == nil {
+		return &BucketTransforms{}, meta.TransformConfigUpdatedAt, nil
+	}
+	return meta.transforms, meta.TransformConfigUpdatedAt, nil
+}
+
+// GetDomains returns the configured bucket custom domains
+// The returned object may not be modified.
+func (sys *BucketMetadataSys) GetDomains(ctx context.Context, bucket string) (*BucketDomains, time.Time, error) {
+	meta, err := sys.GetConfig(ctx, bucket)
+	if err != nil {
+		return nil, time.Time{}, err
+	}
+	if meta.domains == nil {
+		return &BucketDomains{}, meta.DomainsConfigUpdatedAt, nil
+	}
+	return meta.domains, meta.DomainsConfigUpdatedAt, nil
+}
+
+// GetCorsConfig returns the configured bucket CORS configuration
+// The returned object may not be modified.
+func (sys *BucketMetadataSys) GetCorsConfig(ctx context.Context, bucket string) (*bucketcors.Config, time.Time, error) {
+	meta, err := sys.GetConfig(ctx, bucket)
+	if err != nil {
+		if errors.Is(err, errConfigNotFound) {
+			return nil, time.Time{}, BucketCorsNotFound{Bucket: bucket}
+		}
+		return nil, time.Time{}, err
+	}
+	if meta.corsConfig == nil {
+		return nil, time.Time{}, BucketCorsNotFound{Bucket: bucket}
+	}
+	return meta.corsConfig, meta.CorsConfigUpdatedAt, nil
+}
+
+// GetWebsiteConfig returns the configured bucket website configuration
+// The returned object may not be modified.
+func (sys *BucketMetadataSys) GetWebsiteConfig(ctx context.Context, bucket string) (*website.Config, time.Time, error) {
+	meta, err := sys.GetConfig(ctx, bucket)
+	if err != nil {
+		if errors.Is(err, errConfigNotFound) {
+			return nil, time.Time{}, BucketWebsiteNotFound{Bucket: bucket}
+		}
+		return nil, time.Time{}, err
+	}
+	if meta.websiteConfig == nil {
+		return nil, time.Time{}, BucketWebsiteNotFound{Bucket: bucket}
+	}
+	return meta.websiteConfig, meta.WebsiteConfigUpdatedAt, nil
+}
+
+// GetLoggingConfig returns the configured bucket access logging
+// configuration, logging is disabled if it is not set.
+// The returned object may not be modified.
+func (sys *BucketMetadataSys) GetLoggingConfig(ctx context.Context, bucket string) (*logging.Config, time.Time, error) {
+	meta, err := sys.GetConfig(ctx, bucket)
+	if err != nil {
+		if errors.Is(err, errConfigNotFound) {
+			return &logging.Config{}, time.Time{}, nil
+		}
+		return nil, time.Time{}, err
+	}
+	if meta.loggingConfig == nil {
+		return &logging.Config{}, meta.LoggingConfigUpdatedAt, nil
+	}
+	return meta.loggingConfig, meta.LoggingConfigUpdatedAt, nil
+}
+
+// GetInventoryConfigs returns the configured bucket inventory configurations
+// The returned object may not be modified.
+func (sys *BucketMetadataSys) GetInventoryConfigs(ctx context.Context, bucket string) (*inventory.Configs, time.Time, error) {
+	meta, err := sys.GetConfig(ctx, bucket)
+	if err != nil {
+		if errors.Is(err, errConfigNotFound) {
+			return nil, time.Time{}, BucketInventoryConfigNotFound{Bucket: bucket}
+		}
+		return nil, time.Time{}, err
+	}
+	if meta.inventoryConfigs == nil {
+		return nil, time.Time{}, BucketInventoryConfigNotFound{Bucket: bucket}
+	}
+	return meta.inventoryConfigs, meta.InventoryConfigUpdatedAt, nil
+}
+
 // GetReplicationConfig returns configured bucket replication config
 // The returned object may not be modified.
 func (sys *BucketMetadataSys) GetReplicationConfig(ctx context.Context, bucket string) (*replication.Config, time.Time, error) {
@@ -453,6 +602,10 @@ func (sys *BucketMetadataSys) concurrentLoad(ctx context.Context, buckets []Buck
 
 			globalBucketTargetSys.set(buckets[index], meta) // set remote replication targets
 
+			globalBucketThrottles.set(buckets[index].Name, meta.bandwidthLimits) // set bandwidth limits
+
+			globalBucketDomains.set(buckets[index].Name, meta.domains) // set custom domains
+
 			return nil
 		}, index)
 	}
@@ -31,11 +31,15 @@ import (
 
 	"github.com/minio/madmin-go"
 	"github.com/minio/minio-go/v7/pkg/tags"
+	bucketcors "github.com/minio/minio/internal/bucket/cors"
 	bucketsse "github.com/minio/minio/internal/bucket/encryption"
+	"github.com/minio/minio/internal/bucket/inventory"
 	"github.com/minio/minio/internal/bucket/lifecycle"
+	"github.com/minio/minio/internal/bucket/logging"
 	objectlock "github.com/minio/minio/internal/bucket/object/lock"
 	"github.com/minio/minio/internal/bucket/replication"
 	"github.com/minio/minio/internal/bucket/versioning"
+	"github.com/minio/minio/internal/bucket/website"
 	"github.com/minio/minio/internal/crypto"
 	"github.com/minio/minio/internal/event"
 	"github.com/minio/minio/internal/fips"
@@ -81,6 +85,13 @@ type BucketMetadata struct {
 	ReplicationConfigXML        []byte
 	BucketTargetsConfigJSON     []byte
 	BucketTargetsConfigMetaJSON []byte
+	BandwidthConfigJSON         []byte
+	InventoryConfigXML          []byte
+	TransformConfigJSON         []byte
+	DomainsConfigJSON           []byte
+	CorsConfigXML               []byte
+	WebsiteConfigXML            []byte
+	LoggingConfigXML            []byte
 	PolicyConfigUpdatedAt       time.Time
 	ObjectLockConfigUpdatedAt   time.Time
 	EncryptionConfigUpdatedAt   time.Time
@@ -88,6 +99,13 @@ type BucketMetadata struct {
 	QuotaConfigUpdatedAt        time.Time
 	ReplicationConfigUpdatedAt  time.Time
 	VersioningConfigUpdatedAt   time.Time
+	BandwidthConfigUpdatedAt    time.Time
+	InventoryConfigUpdatedAt    time.Time
+	TransformConfigUpdatedAt    time.Time
+	DomainsConfigUpdatedAt      time.Time
+	CorsConfigUpdatedAt         time.Time
+	WebsiteConfigUpdatedAt      time.Time
+	LoggingConfigUpdatedAt      time.Time
 
 	// Unexported fields. Must be updated atomically.
 	policyConfig           *policy.Policy
@@ -97,10 +115,17 @@ type BucketMetadata struct {
 	versioningConfig       *versioning.Versioning
 	sseConfig              *bucketsse.BucketSSEConfig
 	taggingConfig          *tags.Tags
-	quotaConfig            *madmin.BucketQuota
+	quotaConfig            *BucketQuotaConfig
 	replicationConfig      *replication.Config
 	bucketTargetConfig     *madmin.BucketTargets
 	bucketTargetConfigMeta map[string]string
+	bandwidthLimits        *BucketBandwidthLimits
+	inventoryConfigs       *inventory.Configs
+	transforms             *BucketTransforms
+	domains                *BucketDomains
+	corsConfig             *bucketcors.Config
+	websiteConfig          *website.Config
+	loggingConfig          *logging.Config
 }
 
 // newBucketMetadata creates BucketMetadata with the supplied name and Created to Now.
@@ -112,7 +137,7 @@ func newBucketMetadata(name string) BucketMetadata {
 		notificationConfig: &event.Config{
 			XMLNS: "http://s3.amazonaws.com/doc/2006-03-01/",
 		},
-		quotaConfig: &madmin.BucketQuota{},
+		quotaConfig: &BucketQuotaConfig{},
 		versioningConfig: &versioning.Versioning{
 			XMLNS: "http://s3.amazonaws.com/doc/2006-03-01/",
 		},
@@ -246,6 +271,69 @@ func (b *BucketMetadata) parseAllConfigs(ctx context.Context, objectAPI ObjectLa
 		}
 	}
 
+	if len(b.BandwidthConfigJSON) != 0 {
+		b.bandwidthLimits, err = parseBucketBandwidthLimits(b.Name, b.BandwidthConfigJSON)
+		if err != nil {
+			return err
+		}
+	} else {
+		b.bandwidthLimits = nil
+	}
+
+	if len(b.InventoryConfigXML) != 0 {
+		b.inventoryConfigs, err = inventory.ParseConfigs(bytes.NewReader(b.InventoryConfigXML))
+		if err != nil {
+			return err
+		}
+	} else {
+		b.inventoryConfigs = nil
+	}
+
+	if len(b.TransformConfigJSON) != 0 {
+		b.transforms, err = parseBucketTransforms(b.Name, b.TransformConfigJSON)
+		if err != nil {
+			return err
+		}
+	} else {
+		b.transforms = nil
+	}
+
+	if len(b.DomainsConfigJSON) != 0 {
+		b.domains, err = parseBucketDomains(b.Name, b.DomainsConfigJSON)
+		if err != nil {
+			return err
+		}
+	} else {
+		b.domains = nil
+	}
+
+	if len(b.CorsConfigXML) != 0 {
+		b.corsConfig, err = bucketcors.ParseConfig(bytes.NewReader(b.CorsConfigXML))
+		if err != nil {
+			return err
+		}
+	} else {
+		b.corsConfig = nil
+	}
+
+	if len(b.WebsiteConfigXML) != 0 {
+		b.websiteConfig, err = website.ParseConfig(bytes.NewReader(b.WebsiteConfigXML))
+		if err != nil {
+			return err
+		}
+	} else {
+		b.websiteConfig = nil
+	}
+
+	if len(b.LoggingConfigXML) != 0 {
+		b.loggingConfig, err = logging.ParseConfig(bytes.NewReader(b.LoggingConfigXML))
+		if err != nil {
+			return err
+		}
+	} else {
+		b.loggingConfig = nil
+	}
+
 	if len(b.ReplicationConfigXML) != 0 {
 		b.replicationConfig, err = replication.ParseConfig(bytes.NewReader(b.ReplicationConfigXML))
 		if err != nil {
@@ -389,6 +477,34 @@ func (b *BucketMetadata) defaultTimestamps() {
 	if b.VersioningConfigUpdatedAt.IsZero() {
 		b.VersioningConfigUpdatedAt = b.Created
 	}
+
+	if b.BandwidthConfigUpdatedAt.IsZero() {
+		b.BandwidthConfigUpdatedAt = b.Created
+	}
+
+	if b.InventoryConfigUpdatedAt.IsZero() {
+		b.InventoryConfigUpdatedAt = b.Created
+	}
+
+	if b.TransformConfigUpdatedAt.IsZero() {
+		b.TransformConfigUpdatedAt = b.Created
+	}
+
+	if b.DomainsConfigUpdatedAt.IsZero() {
+		b.DomainsConfigUpdatedAt = b.Created
+	}
+
+	if b.CorsConfigUpdatedAt.IsZero() {
+		b.CorsConfigUpdatedAt = b.Created
+	}
+
+	if b.WebsiteConfigUpdatedAt.IsZero() {
+		b.WebsiteConfigUpdatedAt = b.Created
+	}
+
+	if b.LoggingConfigUpdatedAt.IsZero() {
+		b.LoggingConfigUpdatedAt = b.Created
+	}
 }
 
 // Save config to supplied ObjectLayer api.
@@ -0,0 +1,171 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/minio/minio/internal/logger"
+	"github.com/minio/pkg/bucket/policy"
+)
+
+// BulkObjectLockRequest sets or removes the retention or the legal hold
+// of all the object versions of a bucket below Prefix, or of the objects
+// listed in the CSV manifest object Manifest of the bucket. The completion
+// report is written to the bucket below ReportPrefix.
+type BulkObjectLockRequest struct {
+	Prefix       string             `json:"prefix,omitempty"`
+	Manifest     string             `json:"manifest,omitempty"`
+	Retention    *BatchJobRetention `json:"retention,omitempty"`
+	LegalHold    *BatchJobLegalHold `json:"legalHold,omitempty"`
+	ReportPrefix string             `json:"reportPrefix,omitempty"`
+	ReportScope  string             `json:"reportScope,omitempty"`
+}
+
+// batchJobRequest returns the batch job running req on bucket.
+func (req BulkObjectLockRequest) batchJobRequest(bucket string) BatchJobRequest {
+	job := BatchJobRequest{
+		Description: "bulk object lock",
+		Report: BatchJobReport{
+			Bucket: bucket,
+			Prefix: req.ReportPrefix,
+			Scope:  req.ReportScope,
+		},
+	}
+	if job.Report.Scope == "" {
+		job.Report.Scope = BatchJobReportFailedTasks
+	}
+	if req.Manifest != "" {
+		job.Manifest = BatchJobManifest{
+			Format: BatchJobManifestCSV,
+			Bucket: bucket,
+			Object: req.Manifest,
+		}
+	}
+	if req.Retention != nil {
+		r := *req.Retention
+		r.Bucket, r.Prefix = bucket, req.Prefix
+		job.Operation.Retention = &r
+	}
+	if req.LegalHold != nil {
+		lh := *req.LegalHold
+		lh.Bucket, lh.Prefix = bucket, req.Prefix
+		job.Operation.LegalHold = &lh
+	}
+	return job
+}
+
+// PutBucketObjectLockBulkHandler - POST /bucket?object-lock-bulk
+// ----------
+// Starts a batch job setting or removing the retention or the legal hold
+// of the object versions described by the JSON request body, and returns
+// it. This API is a MinIO only extension, it only requires the permissions
+// of the S3 API calls it replaces on the bucket rather than the ones of
+// the admin batch job API.
+func (api objectAPIHandlers) PutBucketObjectLockBulkHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "PutBucketObjectLockBulk")
+	defer logger.AuditLog(ctx, w, r, mustGetClaimsFromToken(r))
+
+	bucket := mux.Vars(r)["bucket"]
+
+	objectAPI := api.ObjectAPI()
+	if objectAPI == nil {
+		writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErr(ErrServerNotInitialized), r.URL)
+		return
+	}
+
+	var req BulkObjectLockRequest
+	if err := json.NewDecoder(io.LimitReader(r.Body, maxBatchJobRequestSize)).Decode(&req); err != nil {
+		writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErrWithErr(ErrMalformedJSON, err), r.URL)
+		return
+	}
+	jobReq := req.batchJobRequest(bucket)
+
+	allowed := func(action policy.Action, object string) bool {
+		if s3Error := checkRequestAuthType(ctx, r, action, bucket, object); s3Error != ErrNone {
+			writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErr(s3Error), r.URL)
+			return false
+		}
+		return true
+	}
+	// The same permissions as the S3 API calls run by the job.
+	if !allowed(policy.PutObjectAction, jobReq.Report.Prefix) {
+		return
+	}
+	if req.Manifest != "" && !allowed(policy.GetObjectAction, req.Manifest) {
+		return
+	}
+	if req.Retention != nil {
+		if !allowed(policy.PutObjectRetentionAction, req.Prefix) {
+			return
+		}
+		if req.Retention.BypassGovernanceRetention && !allowed(policy.BypassGovernanceRetentionAction, req.Prefix) {
+			return
+		}
+	}
+	if req.LegalHold != nil && !allowed(policy.PutObjectLegalHoldAction, req.Prefix) {
+		return
+	}
+
+	job, err := startBatchJob(ctx, objectAPI, jobReq)
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+	writeBatchJobResponse(ctx, w, r, job)
+}
+
+// GetBucketObjectLockBulkHandler - GET /bucket?object-lock-bulk&jobId=<id>
+// ----------
+// Returns the bulk object lock job of the bucket with the jobId query
+// parameter and its progress. This API is a MinIO only extension, it
+// requires the permission to read the object lock configuration of
+// the bucket.
+func (api objectAPIHandlers) GetBucketObjectLockBulkHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "GetBucketObjectLockBulk")
+	defer logger.AuditLog(ctx, w, r, mustGetClaimsFromToken(r))
+
+	bucket := mux.Vars(r)["bucket"]
+
+	objectAPI := api.ObjectAPI()
+	if objectAPI == nil {
+		writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErr(ErrServerNotInitialized), r.URL)
+		return
+	}
+
+	if s3Error := checkRequestAuthType(ctx, r, policy.GetBucketObjectLockConfigurationAction, bucket, ""); s3Error != ErrNone {
+		writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErr(s3Error), r.URL)
+		return
+	}
+
+	job, err := loadBatchJob(ctx, objectAPI, r.URL.Query().Get("jobId"))
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+	// Only the jobs started on the bucket by PutBucketObjectLockBulk.
+	if jobBucket, _ := job.Request.lockBucket(); jobBucket != bucket {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, errNoSuchBatchJob), r.URL)
+		return
+	}
+
+	writeBatchJobResponse(ctx, w, r, job)
+}
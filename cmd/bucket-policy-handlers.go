@@ -108,6 +108,8 @@ func (api objectAPIHandlers) PutBucketPolicyHandler(w http.ResponseWriter, r *ht
 		return
 	}
 
+	propagateBucketMetadata(bucket, bucketPolicyConfig)
+
 	// Call site replication hook.
 	if err = globalSiteReplicationSys.BucketMetaHook(ctx, madmin.SRBucketMeta{
 		Type:   madmin.SRBucketMetaTypePolicy,
@@ -153,6 +155,8 @@ func (api objectAPIHandlers) DeleteBucketPolicyHandler(w http.ResponseWriter, r
 		return
 	}
 
+	propagateBucketMetadata(bucket, bucketPolicyConfig)
+
 	// Call site replication hook.
 	if err := globalSiteReplicationSys.BucketMetaHook(ctx, madmin.SRBucketMeta{
 		Type:   madmin.SRBucketMetaTypePolicy,
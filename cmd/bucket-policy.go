@@ -19,6 +19,7 @@ package cmd
 
 import (
 	"encoding/json"
+	"net"
 	"net/http"
 	"net/url"
 	"strconv"
@@ -108,7 +109,6 @@ func getConditionValues(r *http.Request, lc string, username string, claims map[
 		"CurrentTime":      {currTime.Format(time.RFC3339)},
 		"EpochTime":        {strconv.FormatInt(currTime.Unix(), 10)},
 		"SecureTransport":  {strconv.FormatBool(r.TLS != nil)},
-		"SourceIp":         {handlers.GetSourceIP(r)},
 		"UserAgent":        {r.UserAgent()},
 		"Referer":          {r.Referer()},
 		"principaltype":    {principalType},
@@ -119,6 +119,12 @@ func getConditionValues(r *http.Request, lc string, username string, claims map[
 		"authType":         {authtype},
 	}
 
+	// IpAddress conditions only match when a valid source IP is set,
+	// they would fail on any other value.
+	if sourceIP, ok := conditionSourceIP(r); ok {
+		args["SourceIp"] = []string{sourceIP}
+	}
+
 	if lc != "" {
 		args["LocationConstraint"] = []string{lc}
 	}
@@ -184,9 +190,67 @@ func getConditionValues(r *http.Request, lc string, username string, claims map[
 		}
 	}
 
+	// Session tags override the claims of the same name
+	// and may have several values.
+	for tag, values := range sessionTags(claims) {
+		args[tag] = values
+	}
+
 	return args
 }
 
+// sessionTags returns the session tags of the STS credentials
+// with claims, as set by AssumeRoleWithWebIdentity.
+func sessionTags(claims map[string]interface{}) map[string][]string {
+	switch tags := claims[sessionTagsClaim].(type) {
+	case map[string][]string:
+		return tags
+	case map[string]interface{}:
+		result := make(map[string][]string, len(tags))
+		for tag, v := range tags {
+			vs, ok := v.([]interface{})
+			if !ok {
+				continue
+			}
+			values := make([]string, 0, len(vs))
+			for _, value := range vs {
+				if s, ok := value.(string); ok {
+					values = append(values, s)
+				}
+			}
+			if len(values) > 0 {
+				result[tag] = values
+			}
+		}
+		return result
+	}
+	return nil
+}
+
+// conditionSourceIP returns the client IP address of r for the
+// aws:SourceIp condition key. Addresses taken from forwarding
+// headers may carry a port, brackets, an IPv6 zone or further
+// proxies, which are removed here.
+func conditionSourceIP(r *http.Request) (string, bool) {
+	addr := handlers.GetSourceIP(r)
+	if i := strings.IndexByte(addr, ','); i >= 0 {
+		addr = addr[:i]
+	}
+	addr = strings.TrimSpace(addr)
+	if host, _, err := net.SplitHostPort(addr); err == nil {
+		addr = host
+	}
+	addr = strings.TrimSuffix(strings.TrimPrefix(addr, "["), "]")
+	if i := strings.IndexByte(addr, '%'); i >= 0 {
+		addr = addr[:i]
+	}
+	ip := net.ParseIP(addr)
+	if ip == nil {
+		return "", false
+	}
+	return ip.String(), true
+}
+
 // PolicyToBucketAccessPolicy converts a MinIO policy into a minio-go policy data structure.
 func PolicyToBucketAccessPolicy(bucketPolicy *policy.Policy) (*miniogopolicy.BucketAccessPolicy, error) {
 	// Return empty BucketAccessPolicy for empty bucket policy.
@@ -22,25 +22,96 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"path"
+	"strconv"
+	"sync"
 	"time"
 
+	humanize "github.com/dustin/go-humanize"
 	"github.com/minio/madmin-go"
+	"github.com/minio/minio/internal/event"
 	"github.com/minio/minio/internal/logger"
 )
 
+// bucketQuotaThresholdFile saves the highest usage threshold crossed
+// by a bucket, so that each crossing is only notified once.
+const bucketQuotaThresholdFile = "quota-threshold.json"
+
+// SoftQuota is the type of the quotas which are not enforced,
+// only the crossing of their thresholds is notified.
+const SoftQuota madmin.QuotaType = "soft"
+
+// Usage thresholds of the soft quotas configured without thresholds.
+var defaultSoftQuotaThresholds = []int{100}
+
+// BucketQuotaConfig is the quota configuration of a bucket. The usage of
+// the bucket crossing one of the thresholds, percentages of the quota, is
+// notified with a bucket event and a server log, writes exceeding soft
+// quotas are not rejected. The object and version counts are always
+// enforced, whatever the type of the quota.
+type BucketQuotaConfig struct {
+	madmin.BucketQuota
+	Thresholds  []int  `json:"thresholds,omitempty"`
+	MaxObjects  uint64 `json:"maxObjects,omitempty"`
+	MaxVersions uint64 `json:"maxVersions,omitempty"`
+}
+
+// IsValid returns false if the quota is invalid, quotas
+// without a size are valid if they have no thresholds.
+func (q BucketQuotaConfig) IsValid() bool {
+	if q.Quota == 0 {
+		return len(q.Thresholds) == 0
+	}
+	if q.Type != SoftQuota && !q.Type.IsValid() {
+		return false
+	}
+	for _, t := range q.Thresholds {
+		if t <= 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// isEmpty returns true if the quota limits neither
+// the size nor the object and version counts.
+func (q BucketQuotaConfig) isEmpty() bool {
+	return q.Quota == 0 && q.MaxObjects == 0 && q.MaxVersions == 0
+}
+
+// thresholds returns the usage thresholds notified for the quota.
+func (q BucketQuotaConfig) thresholds() []int {
+	if len(q.Thresholds) == 0 && q.Type == SoftQuota {
+		return defaultSoftQuotaThresholds
+	}
+	return q.Thresholds
+}
+
+// adminQuota returns the quota as reported by the admin API.
+func (q *BucketQuotaConfig) adminQuota() *madmin.BucketQuota {
+	if q == nil {
+		return nil
+	}
+	return &q.BucketQuota
+}
+
 // BucketQuotaSys - map of bucket and quota configuration.
 type BucketQuotaSys struct {
 	bucketStorageCache timedValue
+
+	// Cache of the bucketQuotaThresholdFile of the buckets.
+	mu      sync.Mutex
+	crossed map[string]bucketQuotaThreshold
 }
 
 // Get - Get quota configuration.
-func (sys *BucketQuotaSys) Get(ctx context.Context, bucketName string) (*madmin.BucketQuota, error) {
+func (sys *BucketQuotaSys) Get(ctx context.Context, bucketName string) (*BucketQuotaConfig, error) {
 	if globalIsGateway {
 		objAPI := newObjectLayerFn()
 		if objAPI == nil {
 			return nil, errServerNotInitialized
 		}
-		return &madmin.BucketQuota{}, nil
+		return &BucketQuotaConfig{}, nil
 	}
 	qCfg, _, err := globalBucketMetadataSys.GetQuotaConfig(ctx, bucketName)
 	return qCfg, err
@@ -48,7 +119,7 @@ func (sys *BucketQuotaSys) Get(ctx context.Context, bucketName string) (*madmin.
 
 // NewBucketQuotaSys returns initialized BucketQuotaSys
 func NewBucketQuotaSys() *BucketQuotaSys {
-	return &BucketQuotaSys{}
+	return &BucketQuotaSys{crossed: make(map[string]bucketQuotaThreshold)}
 }
 
 // Init initialize bucket quota.
@@ -81,8 +152,8 @@ func (sys *BucketQuotaSys) GetBucketUsageInfo(bucket string) (BucketUsageInfo, e
 }
 
 // parseBucketQuota parses BucketQuota from json
-func parseBucketQuota(bucket string, data []byte) (quotaCfg *madmin.BucketQuota, err error) {
-	quotaCfg = &madmin.BucketQuota{}
+func parseBucketQuota(bucket string, data []byte) (quotaCfg *BucketQuotaConfig, err error) {
+	quotaCfg = &BucketQuotaConfig{}
 	if err = json.Unmarshal(data, quotaCfg); err != nil {
 		return quotaCfg, err
 	}
@@ -106,7 +177,11 @@ func (sys *BucketQuotaSys) enforceQuotaHard(ctx context.Context, bucket string,
 		return err
 	}
 
-	if q != nil && q.Type == madmin.HardQuota && q.Quota > 0 {
+	if q == nil {
+		return nil
+	}
+
+	if q.Type == madmin.HardQuota && q.Quota > 0 {
 		bui, err := sys.GetBucketUsageInfo(bucket)
 		if err != nil {
 			return err
@@ -117,6 +192,20 @@ func (sys *BucketQuotaSys) enforceQuotaHard(ctx context.Context, bucket string,
 		}
 	}
 
+	if q.MaxObjects > 0 || q.MaxVersions > 0 {
+		bui, err := sys.GetBucketUsageInfo(bucket)
+		if err != nil {
+			return err
+		}
+
+		// The counts are those of the last scan, every write
+		// is assumed to add a new object and a new version.
+		if (q.MaxObjects > 0 && bui.ObjectsCount >= q.MaxObjects) ||
+			(q.MaxVersions > 0 && bui.VersionsCount >= q.MaxVersions) {
+			return BucketObjectCountQuotaExceeded{Bucket: bucket}
+		}
+	}
+
 	return nil
 }
 
@@ -126,3 +215,103 @@ func enforceBucketQuotaHard(ctx context.Context, bucket string, size int64) erro
 	}
 	return globalBucketQuotaSys.enforceQuotaHard(ctx, bucket, size)
 }
+
+// bucketQuotaThreshold is the highest usage threshold crossed
+// by a bucket, for its quota at the time.
+type bucketQuotaThreshold struct {
+	Quota     uint64 `json:"quota"`
+	Threshold int    `json:"threshold"`
+}
+
+// crossedThreshold returns the threshold of quota last crossed by the
+// usage of bucket, 0 if none or if it was crossed for another quota.
+func (sys *BucketQuotaSys) crossedThreshold(ctx context.Context, objAPI ObjectLayer, bucket string, quota uint64) (int, error) {
+	sys.mu.Lock()
+	defer sys.mu.Unlock()
+	t, ok := sys.crossed[bucket]
+	if !ok {
+		data, err := readConfig(ctx, objAPI, path.Join(bucketMetaPrefix, bucket, bucketQuotaThresholdFile))
+		if err != nil && !errors.Is(err, errConfigNotFound) {
+			return 0, err
+		}
+		if err == nil {
+			if err = json.Unmarshal(data, &t); err != nil {
+				return 0, err
+			}
+		}
+		sys.crossed[bucket] = t
+	}
+	if t.Quota != quota {
+		return 0, nil
+	}
+	return t.Threshold, nil
+}
+
+func (sys *BucketQuotaSys) saveCrossedThreshold(ctx context.Context, objAPI ObjectLayer, bucket string, t bucketQuotaThreshold) error {
+	data, err := json.Marshal(t)
+	if err != nil {
+		return err
+	}
+	if err = saveConfig(ctx, objAPI, path.Join(bucketMetaPrefix, bucket, bucketQuotaThresholdFile), data); err != nil {
+		return err
+	}
+	sys.mu.Lock()
+	sys.crossed[bucket] = t
+	sys.mu.Unlock()
+	return nil
+}
+
+// checkThresholds notifies the buckets whose usage crossed a higher
+// threshold of their quota since the previous check, it is called with
+// each data usage saved by the scanner.
+func (sys *BucketQuotaSys) checkThresholds(ctx context.Context, objAPI ObjectLayer, dui DataUsageInfo) {
+	for bucket, usage := range dui.BucketsUsage {
+		q, err := sys.Get(ctx, bucket)
+		if err != nil || q == nil || q.Quota == 0 {
+			continue
+		}
+		var crossed int
+		for _, t := range q.thresholds() {
+			if t > crossed && float64(usage.Size)*100 >= float64(t)*float64(q.Quota) {
+				crossed = t
+			}
+		}
+		prev, err := sys.crossedThreshold(ctx, objAPI, bucket, q.Quota)
+		if err != nil {
+			logger.LogIf(ctx, err)
+			continue
+		}
+		if crossed == prev {
+			continue
+		}
+		if err = sys.saveCrossedThreshold(ctx, objAPI, bucket, bucketQuotaThreshold{Quota: q.Quota, Threshold: crossed}); err != nil {
+			logger.LogIf(ctx, err)
+			continue
+		}
+		if crossed > prev {
+			notifyBucketQuotaThreshold(ctx, bucket, q, usage.Size, crossed)
+		}
+	}
+}
+
+// notifyBucketQuotaThreshold sends the s3:BucketQuota:ThresholdCrossed
+// event of the bucket, and logs the crossing for the administrators.
+func notifyBucketQuotaThreshold(ctx context.Context, bucket string, q *BucketQuotaConfig, usage uint64, threshold int) {
+	quotaType := q.Type
+	if quotaType == "" {
+		quotaType = madmin.HardQuota
+	}
+	logger.LogIf(ctx, fmt.Errorf("Bucket %s uses %s, %d%% of its %s quota of %s", bucket,
+		humanize.IBytes(usage), threshold, quotaType, humanize.IBytes(q.Quota)))
+	sendEvent(eventArgs{
+		EventName:  event.BucketQuotaThresholdCrossed,
+		BucketName: bucket,
+		ReqParams: map[string]string{
+			"quota":     strconv.FormatUint(q.Quota, 10),
+			"quotaType": string(quotaType),
+			"threshold": strconv.Itoa(threshold),
+			"usage":     strconv.FormatUint(usage, 10),
+		},
+		Host: "Internal: [Quota]",
+	})
+}
@@ -0,0 +1,140 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+package cmd
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestParseBucketQuota(t *testing.T) {
+	testCases := []struct {
+		data       string
+		thresholds []int
+		valid      bool
+	}{
+		{`{"quota": 1024, "quotatype": "hard"}`, nil, true},
+		{`{"quota": 1024, "quotatype": "soft"}`, []int{100}, true},
+		{`{"quota": 1024, "quotatype": "soft", "thresholds": [80, 95]}`, []int{80, 95}, true},
+		{`{"quota": 1024, "quotatype": "hard", "thresholds": [90]}`, []int{90}, true},
+		{`{"quota": 1024, "quotatype": "soft", "thresholds": [0]}`, nil, false},
+		{`{"quota": 1024, "quotatype": "lazy"}`, nil, false},
+		{`{"quota": 0, "thresholds": [80]}`, nil, false},
+		{`{"quota": 0}`, nil, true},
+		{`{"maxObjects": 1000, "maxVersions": 5000}`, nil, true},
+	}
+	for i, testCase := range testCases {
+		q, err := parseBucketQuota("bucket", []byte(testCase.data))
+		if (err == nil) != testCase.valid {
+			t.Errorf("Test %d: expected valid %v, got %v", i+1, testCase.valid, err)
+			continue
+		}
+		if err == nil && len(q.thresholds()) != len(testCase.thresholds) {
+			t.Errorf("Test %d: expected thresholds %v, got %v", i+1, testCase.thresholds, q.thresholds())
+		}
+	}
+}
+
+func TestBucketQuotaThresholds(t *testing.T) {
+	testServer := StartTestServer(t, "ErasureSD")
+	defer testServer.Stop()
+
+	ctx := context.Background()
+	objAPI := testServer.Obj
+	if err := objAPI.MakeBucketWithLocation(ctx, "bucket", BucketOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	setQuota := func(data string) {
+		t.Helper()
+		if err := globalBucketMetadataSys.Update(ctx, "bucket", bucketQuotaConfigFile, []byte(data)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	setQuota(`{"quota": 1000, "quotatype": "soft", "thresholds": [80, 95]}`)
+
+	sys := NewBucketQuotaSys()
+	testCases := []struct {
+		quota   uint64
+		usage   uint64
+		crossed int
+	}{
+		{1000, 500, 0},
+		{1000, 850, 80},
+		{1000, 990, 95},
+		{1000, 1200, 95},
+		{1000, 900, 80},
+		{1000, 100, 0},
+		{2000, 1900, 95},
+	}
+	for i, testCase := range testCases {
+		if i == len(testCases)-1 {
+			setQuota(`{"quota": 2000, "quotatype": "soft", "thresholds": [80, 95]}`)
+		}
+		sys.checkThresholds(ctx, objAPI, DataUsageInfo{
+			BucketsUsage: map[string]BucketUsageInfo{"bucket": {Size: testCase.usage}},
+		})
+		// Read the threshold saved to the backend rather than the cached one.
+		crossed, err := NewBucketQuotaSys().crossedThreshold(ctx, objAPI, "bucket", testCase.quota)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if crossed != testCase.crossed {
+			t.Errorf("Test %d: expected threshold %d crossed, got %d", i+1, testCase.crossed, crossed)
+		}
+	}
+}
+
+func TestBucketQuotaObjectCount(t *testing.T) {
+	testServer := StartTestServer(t, "ErasureSD")
+	defer testServer.Stop()
+
+	ctx := context.Background()
+	if err := testServer.Obj.MakeBucketWithLocation(ctx, "bucket", BucketOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	if err := globalBucketMetadataSys.Update(ctx, "bucket", bucketQuotaConfigFile, []byte(`{"maxObjects": 100, "maxVersions": 150}`)); err != nil {
+		t.Fatal(err)
+	}
+
+	var usage BucketUsageInfo
+	sys := NewBucketQuotaSys()
+	sys.bucketStorageCache.TTL = time.Nanosecond
+	sys.bucketStorageCache.Update = func() (interface{}, error) {
+		return DataUsageInfo{BucketsUsage: map[string]BucketUsageInfo{"bucket": usage}}, nil
+	}
+	testCases := []struct {
+		objects, versions uint64
+		exceeded          bool
+	}{
+		{0, 0, false},
+		{99, 140, false},
+		{100, 140, true},
+		{50, 150, true},
+	}
+	for i, testCase := range testCases {
+		usage = BucketUsageInfo{ObjectsCount: testCase.objects, VersionsCount: testCase.versions}
+		err := sys.enforceQuotaHard(ctx, "bucket", 1)
+		if exceeded := errors.As(err, &BucketObjectCountQuotaExceeded{}); exceeded != testCase.exceeded {
+			t.Errorf("Test %d: expected exceeded %v, got %v", i+1, testCase.exceeded, err)
+		}
+		if testCase.exceeded && toAPIError(ctx, err).Code != "XMinioAdminBucketObjectCountQuotaExceeded" {
+			t.Errorf("Test %d: unexpected API error %v", i+1, toAPIError(ctx, err))
+		}
+	}
+}
@@ -91,6 +91,8 @@ func (api objectAPIHandlers) PutBucketReplicationConfigHandler(w http.ResponseWr
 		return
 	}
 
+	propagateBucketMetadata(bucket, replicatedBucketMetadata...)
+
 	// Write success response.
 	writeSuccessResponseHeadersOnly(w)
 }
@@ -375,23 +377,12 @@ func (api objectAPIHandlers) ResetBucketReplicationStatusHandler(w http.Response
 	}
 
 	var rinfo ResyncTargetsInfo
+	now := UTCNow()
 	for tarn, st := range brs.TargetsMap {
 		if arn != "" && tarn != arn {
 			continue
 		}
-		rinfo.Targets = append(rinfo.Targets, ResyncTarget{
-			Arn:             tarn,
-			ResetID:         st.ResyncID,
-			StartTime:       st.StartTime,
-			EndTime:         st.EndTime,
-			ResyncStatus:    st.ResyncStatus.String(),
-			ReplicatedSize:  st.ReplicatedSize,
-			ReplicatedCount: st.ReplicatedCount,
-			FailedSize:      st.FailedSize,
-			FailedCount:     st.FailedCount,
-			Bucket:          st.Bucket,
-			Object:          st.Object,
-		})
+		rinfo.Targets = append(rinfo.Targets, newResyncTarget(tarn, st, now))
 	}
 	data, err := json.Marshal(rinfo)
 	if err != nil {
@@ -0,0 +1,250 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"strings"
+
+	"github.com/minio/madmin-go"
+	miniogo "github.com/minio/minio-go/v7"
+	"github.com/minio/minio-go/v7/pkg/lifecycle"
+	"github.com/minio/minio-go/v7/pkg/sse"
+	"github.com/minio/minio-go/v7/pkg/tags"
+	"github.com/minio/minio/internal/logger"
+	"github.com/minio/pkg/bucket/policy"
+)
+
+// replicatedBucketMetadata is the bucket metadata propagated
+// to the remote targets of the bucket replication.
+var replicatedBucketMetadata = []string{
+	bucketPolicyConfig,
+	bucketTaggingConfig,
+	bucketLifecycleConfig,
+	bucketSSEConfig,
+}
+
+// propagateBucketMetadata propagates in the background the configFiles
+// of bucket, changed by a bucket API call, to the remote targets of the
+// bucket replication.
+func propagateBucketMetadata(bucket string, configFiles ...string) {
+	go replicateBucketMetadata(GlobalContext, bucket, configFiles...)
+}
+
+// replicateBucketMetadata propagates the bucket metadata configFiles of
+// bucket to the remote targets of its replication, so that the remote
+// buckets are usable as is on failover. The remote bucket metadata is
+// only updated if it differs, which stops active-active replication
+// from sending the same change back and forth.
+func replicateBucketMetadata(ctx context.Context, bucket string, configFiles ...string) {
+	if globalSiteReplicationSys.isEnabled() {
+		// Site replication already replicates the bucket metadata.
+		return
+	}
+
+	rcfg, _, err := globalBucketMetadataSys.GetReplicationConfig(ctx, bucket)
+	if err != nil || !rcfg.HasActiveRules("", true) {
+		return
+	}
+
+	meta, err := globalBucketMetadataSys.GetConfig(ctx, bucket)
+	if err != nil {
+		logger.LogIf(ctx, err)
+		return
+	}
+
+	tgts, err := globalBucketTargetSys.ListBucketTargets(ctx, bucket)
+	if err != nil {
+		logger.LogIf(ctx, err)
+		return
+	}
+
+	for _, t := range tgts.Targets {
+		if t.Type != madmin.ReplicationService {
+			continue
+		}
+		tgt := globalBucketTargetSys.GetRemoteTargetClient(ctx, t.Arn)
+		if tgt == nil || tgt.IsOffline() {
+			logger.LogIf(ctx, fmt.Errorf("Unable to replicate the metadata of bucket %s - remote target %s is offline", bucket, t.Arn))
+			continue
+		}
+		for _, configFile := range configFiles {
+			if err := replicateBucketConfig(ctx, tgt, meta, configFile); err != nil {
+				logger.LogIf(ctx, fmt.Errorf("Unable to replicate %s of bucket %s to remote target %s: %w", configFile, bucket, t.Arn, err))
+			}
+		}
+	}
+}
+
+// isRemoteConfigNotFound returns true if the remote bucket has no such config.
+func isRemoteConfigNotFound(err error) bool {
+	switch miniogo.ToErrorResponse(err).Code {
+	case "NoSuchTagSet", "NoSuchLifecycleConfiguration", "ServerSideEncryptionConfigurationNotFoundError":
+		return true
+	}
+	return false
+}
+
+// replicateBucketConfig updates the bucket metadata configFile of
+// the remote bucket of tgt from meta, if it differs.
+func replicateBucketConfig(ctx context.Context, tgt *TargetClient, meta BucketMetadata, configFile string) error {
+	switch configFile {
+	case bucketPolicyConfig:
+		var local *policy.Policy
+		if len(meta.PolicyConfigJSON) != 0 {
+			p, err := policy.ParseConfig(bytes.NewReader(meta.PolicyConfigJSON), meta.Name)
+			if err != nil {
+				return err
+			}
+			local = rewritePolicyBucket(p, meta.Name, tgt.Bucket)
+		}
+		data, err := tgt.GetBucketPolicy(ctx, tgt.Bucket)
+		if err != nil {
+			return err
+		}
+		var remote *policy.Policy
+		if data != "" {
+			if remote, err = policy.ParseConfig(strings.NewReader(data), tgt.Bucket); err != nil {
+				return err
+			}
+		}
+		if policiesEqual(local, remote) {
+			return nil
+		}
+		if local == nil {
+			return tgt.SetBucketPolicy(ctx, tgt.Bucket, "")
+		}
+		buf, err := json.Marshal(local)
+		if err != nil {
+			return err
+		}
+		return tgt.SetBucketPolicy(ctx, tgt.Bucket, string(buf))
+	case bucketTaggingConfig:
+		remote, err := tgt.GetBucketTagging(ctx, tgt.Bucket)
+		if err != nil && !isRemoteConfigNotFound(err) {
+			return err
+		}
+		if len(meta.TaggingConfigXML) == 0 {
+			if remote == nil || remote.String() == "" {
+				return nil
+			}
+			return tgt.RemoveBucketTagging(ctx, tgt.Bucket)
+		}
+		local, err := tags.ParseBucketXML(bytes.NewReader(meta.TaggingConfigXML))
+		if err != nil {
+			return err
+		}
+		if remote != nil && remote.String() == local.String() {
+			return nil
+		}
+		return tgt.SetBucketTagging(ctx, tgt.Bucket, local)
+	case bucketLifecycleConfig:
+		remote, err := tgt.GetBucketLifecycle(ctx, tgt.Bucket)
+		if err != nil && !isRemoteConfigNotFound(err) {
+			return err
+		}
+		local := lifecycle.NewConfiguration()
+		if len(meta.LifecycleConfigXML) != 0 {
+			if err = xml.Unmarshal(meta.LifecycleConfigXML, local); err != nil {
+				return err
+			}
+		}
+		if remote == nil {
+			remote = lifecycle.NewConfiguration()
+		}
+		local.XMLName, remote.XMLName = xml.Name{}, xml.Name{}
+		if equal, err := xmlEqual(local, remote); err != nil || equal {
+			return err
+		}
+		return tgt.SetBucketLifecycle(ctx, tgt.Bucket, local)
+	case bucketSSEConfig:
+		remote, err := tgt.GetBucketEncryption(ctx, tgt.Bucket)
+		if err != nil && !isRemoteConfigNotFound(err) {
+			return err
+		}
+		if len(meta.EncryptionConfigXML) == 0 {
+			if remote == nil || len(remote.Rules) == 0 {
+				return nil
+			}
+			return tgt.RemoveBucketEncryption(ctx, tgt.Bucket)
+		}
+		local := &sse.Configuration{}
+		if err = xml.Unmarshal(meta.EncryptionConfigXML, local); err != nil {
+			return err
+		}
+		if remote != nil {
+			local.XMLName, remote.XMLName = xml.Name{}, xml.Name{}
+			if equal, err := xmlEqual(local, remote); err != nil || equal {
+				return err
+			}
+		}
+		return tgt.SetBucketEncryption(ctx, tgt.Bucket, local)
+	}
+	return nil
+}
+
+// rewritePolicyBucket returns a copy of the bucket policy p with
+// the resources of bucket rewritten to the remote bucket.
+func rewritePolicyBucket(p *policy.Policy, bucket, remoteBucket string) *policy.Policy {
+	np := &policy.Policy{ID: p.ID, Version: p.Version}
+	for _, st := range p.Statements {
+		resources := policy.NewResourceSet()
+		for r := range st.Resources {
+			if r.BucketName == bucket {
+				r = policy.NewResource(remoteBucket, strings.TrimPrefix(r.Pattern, bucket))
+			}
+			resources.Add(r)
+		}
+		st.Resources = resources
+		np.Statements = append(np.Statements, st)
+	}
+	return np
+}
+
+// policiesEqual returns true if both bucket policies have the same statements.
+func policiesEqual(p1, p2 *policy.Policy) bool {
+	if p1 == nil || p2 == nil {
+		return p1 == p2
+	}
+	if len(p1.Statements) != len(p2.Statements) {
+		return false
+	}
+	for i := range p1.Statements {
+		if !p1.Statements[i].Equals(p2.Statements[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+// xmlEqual returns true if both values have the same XML encoding.
+func xmlEqual(v1, v2 interface{}) (bool, error) {
+	b1, err := xml.Marshal(v1)
+	if err != nil {
+		return false, err
+	}
+	b2, err := xml.Marshal(v2)
+	if err != nil {
+		return false, err
+	}
+	return bytes.Equal(b1, b2), nil
+}
@@ -0,0 +1,56 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/minio/pkg/bucket/policy"
+)
+
+func TestRewritePolicyBucket(t *testing.T) {
+	parse := func(data, bucket string) *policy.Policy {
+		t.Helper()
+		p, err := policy.ParseConfig(strings.NewReader(data), bucket)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return p
+	}
+
+	local := parse(`{"Version":"2012-10-17","Statement":[{"Effect":"Allow","Principal":{"AWS":["*"]},"Action":["s3:GetObject"],"Resource":["arn:aws:s3:::source/public/*"]},{"Effect":"Allow","Principal":{"AWS":["*"]},"Action":["s3:ListBucket"],"Resource":["arn:aws:s3:::source"]}]}`, "source")
+	remote := parse(`{"Version":"2012-10-17","Statement":[{"Effect":"Allow","Principal":{"AWS":["*"]},"Action":["s3:GetObject"],"Resource":["arn:aws:s3:::target/public/*"]},{"Effect":"Allow","Principal":{"AWS":["*"]},"Action":["s3:ListBucket"],"Resource":["arn:aws:s3:::target"]}]}`, "target")
+
+	if policiesEqual(local, remote) {
+		t.Fatal("expected the policies of different buckets to differ")
+	}
+	rewritten := rewritePolicyBucket(local, "source", "target")
+	if !policiesEqual(rewritten, remote) {
+		t.Fatalf("expected the rewritten policy %v to equal %v", rewritten, remote)
+	}
+	if err := rewritten.Validate("target"); err != nil {
+		t.Fatalf("expected the rewritten policy to be valid for the remote bucket: %v", err)
+	}
+	if !policiesEqual(rewritePolicyBucket(local, "source", "source"), local) {
+		t.Fatal("expected the policy to be unchanged for a remote bucket of the same name")
+	}
+	if policiesEqual(local, nil) || !policiesEqual(nil, nil) {
+		t.Fatal("unexpected comparison of a missing policy")
+	}
+}
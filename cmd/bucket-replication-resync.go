@@ -0,0 +1,239 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/minio/minio/internal/logger"
+)
+
+// resyncKey returns the key of the resync of a bucket to a remote target.
+func resyncKey(bucket, arn string) string {
+	return bucket + "/" + arn
+}
+
+// runResync runs the resync of bucket to the remote target arn on this
+// node until it completes or is paused, it returns at once if the resync
+// is already running on this node.
+func (p *ReplicationPool) runResync(ctx context.Context, bucket, arn string, heal bool, objAPI ObjectLayer) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	key := resyncKey(bucket, arn)
+	p.resyncState.Lock()
+	if p.resyncState.cancels == nil {
+		p.resyncState.cancels = make(map[string]context.CancelFunc)
+	}
+	if _, ok := p.resyncState.cancels[key]; ok {
+		p.resyncState.Unlock()
+		return
+	}
+	p.resyncState.cancels[key] = cancel
+	p.resyncState.Unlock()
+
+	defer func() {
+		p.resyncState.Lock()
+		delete(p.resyncState.cancels, key)
+		p.resyncState.Unlock()
+	}()
+
+	resyncBucket(ctx, bucket, arn, heal, objAPI)
+}
+
+// isResyncRunning returns true if the resync of bucket to
+// the remote target arn is running on this node.
+func (p *ReplicationPool) isResyncRunning(bucket, arn string) bool {
+	p.resyncState.RLock()
+	defer p.resyncState.RUnlock()
+	_, ok := p.resyncState.cancels[resyncKey(bucket, arn)]
+	return ok
+}
+
+// getResyncStatus returns the resync status of bucket, from memory if
+// loaded, the progress of the resync running on this node is the latest.
+func (p *ReplicationPool) getResyncStatus(ctx context.Context, bucket string, objAPI ObjectLayer) (BucketReplicationResyncStatus, error) {
+	p.resyncState.RLock()
+	brs, ok := p.resyncState.statusMap[bucket]
+	p.resyncState.RUnlock()
+	if ok {
+		return brs.clone(), nil
+	}
+	return loadBucketResyncMetadata(ctx, bucket, objAPI)
+}
+
+// updateResyncStatus updates the resync status of bucket to the remote target
+// arn with update if the status is one of from, saves it and notifies the peers.
+func (p *ReplicationPool) updateResyncStatus(ctx context.Context, bucket, arn string, objAPI ObjectLayer, from ResyncStatusType, update func(*TargetReplicationResyncStatus)) error {
+	brs, err := p.getResyncStatus(ctx, bucket, objAPI)
+	if err != nil {
+		return err
+	}
+
+	p.resyncState.Lock()
+	if current, ok := p.resyncState.statusMap[bucket]; ok {
+		// Use the latest progress.
+		brs = current.clone()
+	}
+	st, ok := brs.TargetsMap[arn]
+	if !ok {
+		p.resyncState.Unlock()
+		return fmt.Errorf("No replication resync of bucket %s to remote target %s", bucket, arn)
+	}
+	if st.ResyncStatus != from {
+		p.resyncState.Unlock()
+		return fmt.Errorf("Replication resync of bucket %s to remote target %s is %s", bucket, arn, st.ResyncStatus)
+	}
+	update(&st)
+	brs.TargetsMap[arn] = st
+	brs.LastUpdate = UTCNow()
+	p.resyncState.statusMap[bucket] = brs
+	brs = brs.clone()
+	cancel := p.resyncState.cancels[resyncKey(bucket, arn)]
+	p.resyncState.Unlock()
+
+	if err = saveResyncStatus(ctx, bucket, brs, objAPI); err != nil {
+		return err
+	}
+	if cancel != nil && st.ResyncStatus == ResyncPaused {
+		// The resync saves its progress as it stops.
+		cancel()
+	}
+	globalNotificationSys.ReloadReplicationResync(ctx, bucket)
+	return nil
+}
+
+// pauseReplicationResync pauses the resync of bucket to the remote
+// target arn, on whichever node it runs.
+func pauseReplicationResync(ctx context.Context, bucket, arn string, objAPI ObjectLayer) error {
+	return globalReplicationPool.updateResyncStatus(ctx, bucket, arn, objAPI, ResyncStarted, func(st *TargetReplicationResyncStatus) {
+		st.ResyncStatus = ResyncPaused
+		st.PauseTime = UTCNow()
+	})
+}
+
+// resumeReplicationResync resumes the paused resync of bucket to the remote
+// target arn on this node, from the last object replicated.
+func resumeReplicationResync(ctx context.Context, bucket, arn string, objAPI ObjectLayer) error {
+	err := globalReplicationPool.updateResyncStatus(ctx, bucket, arn, objAPI, ResyncPaused, func(st *TargetReplicationResyncStatus) {
+		st.ResyncStatus = ResyncStarted
+		st.PausedDuration += int64(UTCNow().Sub(st.PauseTime))
+		st.PauseTime = time.Time{}
+	})
+	if err != nil {
+		return err
+	}
+	go globalReplicationPool.runResync(GlobalContext, bucket, arn, true, objAPI)
+	return nil
+}
+
+// reloadResync reloads the resync status of bucket saved by a peer, the
+// resync running on this node is stopped if it was paused.
+func (p *ReplicationPool) reloadResync(ctx context.Context, bucket string, objAPI ObjectLayer) error {
+	brs, err := loadBucketResyncMetadata(ctx, bucket, objAPI)
+	if err != nil {
+		return err
+	}
+
+	p.resyncState.Lock()
+	current := p.resyncState.statusMap[bucket]
+	var cancels []context.CancelFunc
+	for arn, st := range brs.TargetsMap {
+		cancel, running := p.resyncState.cancels[resyncKey(bucket, arn)]
+		if !running {
+			continue
+		}
+		// The progress of the resync running on this node is the latest.
+		if local, ok := current.TargetsMap[arn]; ok {
+			local.ResyncStatus = st.ResyncStatus
+			local.PauseTime = st.PauseTime
+			brs.TargetsMap[arn] = local
+		}
+		if st.ResyncStatus == ResyncPaused {
+			cancels = append(cancels, cancel)
+		}
+	}
+	p.resyncState.statusMap[bucket] = brs
+	p.resyncState.Unlock()
+
+	for _, cancel := range cancels {
+		cancel()
+	}
+	return nil
+}
+
+// newResyncTarget returns the resync info of a remote target.
+func newResyncTarget(arn string, st TargetReplicationResyncStatus, now time.Time) ResyncTarget {
+	return ResyncTarget{
+		Arn:             arn,
+		ResetID:         st.ResyncID,
+		StartTime:       st.StartTime,
+		EndTime:         st.EndTime,
+		ResyncStatus:    st.ResyncStatus.String(),
+		ReplicatedSize:  st.ReplicatedSize,
+		ReplicatedCount: st.ReplicatedCount,
+		FailedSize:      st.FailedSize,
+		FailedCount:     st.FailedCount,
+		Bucket:          st.Bucket,
+		Object:          st.Object,
+		ScannedCount:    st.ScannedCount,
+		TotalCount:      st.TotalCount,
+		ETA:             int64(st.eta(now) / time.Second),
+	}
+}
+
+// listReplicationResyncs returns the resyncs of bucket, or of all
+// the buckets if it is empty, sorted by bucket and target.
+func listReplicationResyncs(ctx context.Context, bucket string, objAPI ObjectLayer) ([]ResyncTarget, error) {
+	buckets := []string{bucket}
+	if bucket == "" {
+		bucketsInfo, err := objAPI.ListBuckets(ctx)
+		if err != nil {
+			return nil, err
+		}
+		buckets = buckets[:0]
+		for _, bi := range bucketsInfo {
+			buckets = append(buckets, bi.Name)
+		}
+	}
+
+	now := UTCNow()
+	targets := []ResyncTarget{}
+	for _, b := range buckets {
+		brs, err := globalReplicationPool.getResyncStatus(ctx, b, objAPI)
+		if err != nil {
+			logger.LogIf(ctx, err)
+			continue
+		}
+		for arn, st := range brs.TargetsMap {
+			t := newResyncTarget(arn, st, now)
+			t.Bucket = b
+			targets = append(targets, t)
+		}
+	}
+	sort.Slice(targets, func(i, j int) bool {
+		if targets[i].Bucket != targets[j].Bucket {
+			return targets[i].Bucket < targets[j].Bucket
+		}
+		return targets[i].Arn < targets[j].Arn
+	})
+	return targets, nil
+}
@@ -93,6 +93,7 @@ func (r *ReplicationStats) Update(bucket string, arn string, n int64, duration t
 	}
 	switch status {
 	case replication.Completed:
+		b.LastReplicated = UTCNow()
 		switch prevStatus { // adjust counters based on previous state
 		case replication.Failed:
 			b.FailedCount--
@@ -121,6 +122,28 @@ func (r *ReplicationStats) Update(bucket string, arn string, n int64, duration t
 	}
 }
 
+// UpdateLag records the lag of an object replicated to a target
+// after it was written.
+func (r *ReplicationStats) UpdateLag(bucket, arn string, lag time.Duration) {
+	if r == nil {
+		return
+	}
+	r.Lock()
+	defer r.Unlock()
+
+	bs, ok := r.Cache[bucket]
+	if !ok {
+		bs = &BucketReplicationStats{Stats: make(map[string]*BucketReplicationStat)}
+		r.Cache[bucket] = bs
+	}
+	b, ok := bs.Stats[arn]
+	if !ok {
+		b = &BucketReplicationStat{}
+		bs.Stats[arn] = b
+	}
+	b.Lag.update(lag)
+}
+
 // GetInitialUsage get replication metrics available at the time of cluster initialization
 func (r *ReplicationStats) GetInitialUsage(bucket string) BucketReplicationStats {
 	if r == nil {
@@ -222,3 +245,49 @@ outer:
 	defer r.ulock.Unlock()
 	r.UsageCache = m
 }
+
+// ReplicationTargetStats aggregates the replication statistics of
+// all the buckets replicating to the same remote endpoint.
+type ReplicationTargetStats struct {
+	PendingSize    int64             `json:"pendingReplicationSize"`
+	PendingCount   int64             `json:"pendingReplicationCount"`
+	FailedSize     int64             `json:"failedReplicationSize"`
+	FailedCount    int64             `json:"failedReplicationCount"`
+	ReplicatedSize int64             `json:"completedReplicationSize"`
+	LagMillis      map[string]uint64 `json:"replicationLagMillis,omitempty"`
+	LastReplicated time.Time         `json:"lastReplicated,omitempty"`
+
+	lag ReplicationLag
+}
+
+// getReplicationTargetStats aggregates the replication statistics of the
+// buckets by remote endpoint, the targets no longer configured are skipped.
+func getReplicationTargetStats(ctx context.Context, bucketsStats map[string]BucketReplicationStats) map[string]*ReplicationTargetStats {
+	targets := make(map[string]*ReplicationTargetStats)
+	for bucket, bs := range bucketsStats {
+		for arn, st := range bs.Stats {
+			endpoint := globalBucketTargetSys.GetRemoteBucketTargetByArn(ctx, bucket, arn).Endpoint
+			if endpoint == "" {
+				continue
+			}
+			ts, ok := targets[endpoint]
+			if !ok {
+				ts = &ReplicationTargetStats{}
+				targets[endpoint] = ts
+			}
+			ts.PendingSize += st.PendingSize
+			ts.PendingCount += st.PendingCount
+			ts.FailedSize += st.FailedSize
+			ts.FailedCount += st.FailedCount
+			ts.ReplicatedSize += st.ReplicatedSize
+			ts.lag = ts.lag.merge(st.Lag)
+			if st.LastReplicated.After(ts.LastReplicated) {
+				ts.LastReplicated = st.LastReplicated
+			}
+		}
+	}
+	for _, ts := range targets {
+		ts.LagMillis = ts.lag.getLagMillis()
+	}
+	return targets
+}
@@ -19,6 +19,7 @@ package cmd
 
 import (
 	"bytes"
+	"context"
 	"fmt"
 	"net/http"
 	"regexp"
@@ -596,6 +597,12 @@ type ResyncTarget struct {
 	// Last bucket/object replicated.
 	Bucket string `json:"bucket,omitempty"`
 	Object string `json:"object,omitempty"`
+	// Total number of versions scanned
+	ScannedCount int64 `json:"scannedCount"`
+	// Estimated number of versions to scan
+	TotalCount int64 `json:"totalCount,omitempty"`
+	// Estimated time left to complete the resync, in seconds
+	ETA int64 `json:"etaSeconds,omitempty"`
 }
 
 // VersionPurgeStatusType represents status of a versioned delete or permanent delete w.r.t bucket replication
@@ -625,6 +632,8 @@ func (v VersionPurgeStatusType) Pending() bool {
 type replicationResyncState struct {
 	// map of bucket to their resync status
 	statusMap map[string]BucketReplicationResyncStatus
+	// map of bucket/arn to the cancellation of the resync running on this node
+	cancels map[string]context.CancelFunc
 	sync.RWMutex
 }
 
@@ -648,6 +657,8 @@ const (
 	ResyncCompleted
 	// ResyncFailed -  resync failed
 	ResyncFailed
+	// ResyncPaused - resync paused, to be resumed from the last object replicated
+	ResyncPaused
 )
 
 func (rt ResyncStatusType) String() string {
@@ -658,6 +669,8 @@ func (rt ResyncStatusType) String() string {
 		return "Completed"
 	case ResyncFailed:
 		return "Failed"
+	case ResyncPaused:
+		return "Paused"
 	default:
 		return ""
 	}
@@ -684,6 +697,33 @@ type TargetReplicationResyncStatus struct {
 	// Last bucket/object replicated.
 	Bucket string `json:"-" msg:"bkt"`
 	Object string `json:"-" msg:"obj"`
+	// Total number of versions scanned
+	ScannedCount int64 `json:"scannedCount" msg:"scc"`
+	// Estimated number of versions to scan, as of the last scan
+	// of the bucket when the resync started
+	TotalCount int64 `json:"totalCount" msg:"tc"`
+	// Time of the last pause and the duration of the previous pauses in nanoseconds
+	PauseTime      time.Time `json:"pauseTime" msg:"pt"`
+	PausedDuration int64     `json:"pausedDuration" msg:"pd"`
+}
+
+// eta returns the estimated time left to complete the resync from the
+// rate of the versions scanned, zero if it cannot be estimated.
+func (st TargetReplicationResyncStatus) eta(now time.Time) time.Duration {
+	if st.ResyncStatus != ResyncStarted && st.ResyncStatus != ResyncPaused {
+		return 0
+	}
+	if st.ScannedCount <= 0 || st.TotalCount <= st.ScannedCount {
+		return 0
+	}
+	elapsed := now.Sub(st.StartTime) - time.Duration(st.PausedDuration)
+	if st.ResyncStatus == ResyncPaused {
+		elapsed -= now.Sub(st.PauseTime)
+	}
+	if elapsed <= 0 {
+		return 0
+	}
+	return time.Duration(float64(elapsed) / float64(st.ScannedCount) * float64(st.TotalCount-st.ScannedCount))
 }
 
 // BucketReplicationResyncStatus captures current replication resync status
@@ -702,6 +742,17 @@ func newBucketResyncStatus(bucket string) BucketReplicationResyncStatus {
 	}
 }
 
+// clone returns a copy of the resync status which is safe to
+// save while the resync status is updated.
+func (brs BucketReplicationResyncStatus) clone() BucketReplicationResyncStatus {
+	c := brs
+	c.TargetsMap = make(map[string]TargetReplicationResyncStatus, len(brs.TargetsMap))
+	for arn, st := range brs.TargetsMap {
+		c.TargetsMap[arn] = st
+	}
+	return c
+}
+
 var contentRangeRegexp = regexp.MustCompile(`bytes ([0-9]+)-([0-9]+)/([0-9]+|\\*)`)
 
 // parse size from content-range header
@@ -1118,6 +1118,24 @@ func (z *ResyncTarget) DecodeMsg(dc *msgp.Reader) (err error) {
 				err = msgp.WrapError(err, "Object")
 				return
 			}
+		case "ScannedCount":
+			z.ScannedCount, err = dc.ReadInt64()
+			if err != nil {
+				err = msgp.WrapError(err, "ScannedCount")
+				return
+			}
+		case "TotalCount":
+			z.TotalCount, err = dc.ReadInt64()
+			if err != nil {
+				err = msgp.WrapError(err, "TotalCount")
+				return
+			}
+		case "ETA":
+			z.ETA, err = dc.ReadInt64()
+			if err != nil {
+				err = msgp.WrapError(err, "ETA")
+				return
+			}
 		default:
 			err = dc.Skip()
 			if err != nil {
@@ -1131,9 +1149,9 @@ func (z *ResyncTarget) DecodeMsg(dc *msgp.Reader) (err error) {
 
 // EncodeMsg implements msgp.Encodable
 func (z *ResyncTarget) EncodeMsg(en *msgp.Writer) (err error) {
-	// map header, size 11
+	// map header, size 14
 	// write "Arn"
-	err = en.Append(0x8b, 0xa3, 0x41, 0x72, 0x6e)
+	err = en.Append(0x8e, 0xa3, 0x41, 0x72, 0x6e)
 	if err != nil {
 		return
 	}
@@ -1242,15 +1260,45 @@ func (z *ResyncTarget) EncodeMsg(en *msgp.Writer) (err error) {
 		err = msgp.WrapError(err, "Object")
 		return
 	}
+	// write "ScannedCount"
+	err = en.Append(0xac, 0x53, 0x63, 0x61, 0x6e, 0x6e, 0x65, 0x64, 0x43, 0x6f, 0x75, 0x6e, 0x74)
+	if err != nil {
+		return
+	}
+	err = en.WriteInt64(z.ScannedCount)
+	if err != nil {
+		err = msgp.WrapError(err, "ScannedCount")
+		return
+	}
+	// write "TotalCount"
+	err = en.Append(0xaa, 0x54, 0x6f, 0x74, 0x61, 0x6c, 0x43, 0x6f, 0x75, 0x6e, 0x74)
+	if err != nil {
+		return
+	}
+	err = en.WriteInt64(z.TotalCount)
+	if err != nil {
+		err = msgp.WrapError(err, "TotalCount")
+		return
+	}
+	// write "ETA"
+	err = en.Append(0xa3, 0x45, 0x54, 0x41)
+	if err != nil {
+		return
+	}
+	err = en.WriteInt64(z.ETA)
+	if err != nil {
+		err = msgp.WrapError(err, "ETA")
+		return
+	}
 	return
 }
 
 // MarshalMsg implements msgp.Marshaler
 func (z *ResyncTarget) MarshalMsg(b []byte) (o []byte, err error) {
 	o = msgp.Require(b, z.Msgsize())
-	// map header, size 11
+	// map header, size 14
 	// string "Arn"
-	o = append(o, 0x8b, 0xa3, 0x41, 0x72, 0x6e)
+	o = append(o, 0x8e, 0xa3, 0x41, 0x72, 0x6e)
 	o = msgp.AppendString(o, z.Arn)
 	// string "ResetID"
 	o = append(o, 0xa7, 0x52, 0x65, 0x73, 0x65, 0x74, 0x49, 0x44)
@@ -1282,6 +1330,15 @@ func (z *ResyncTarget) MarshalMsg(b []byte) (o []byte, err error) {
 	// string "Object"
 	o = append(o, 0xa6, 0x4f, 0x62, 0x6a, 0x65, 0x63, 0x74)
 	o = msgp.AppendString(o, z.Object)
+	// string "ScannedCount"
+	o = append(o, 0xac, 0x53, 0x63, 0x61, 0x6e, 0x6e, 0x65, 0x64, 0x43, 0x6f, 0x75, 0x6e, 0x74)
+	o = msgp.AppendInt64(o, z.ScannedCount)
+	// string "TotalCount"
+	o = append(o, 0xaa, 0x54, 0x6f, 0x74, 0x61, 0x6c, 0x43, 0x6f, 0x75, 0x6e, 0x74)
+	o = msgp.AppendInt64(o, z.TotalCount)
+	// string "ETA"
+	o = append(o, 0xa3, 0x45, 0x54, 0x41)
+	o = msgp.AppendInt64(o, z.ETA)
 	return
 }
 
@@ -1369,6 +1426,24 @@ func (z *ResyncTarget) UnmarshalMsg(bts []byte) (o []byte, err error) {
 				err = msgp.WrapError(err, "Object")
 				return
 			}
+		case "ScannedCount":
+			z.ScannedCount, bts, err = msgp.ReadInt64Bytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "ScannedCount")
+				return
+			}
+		case "TotalCount":
+			z.TotalCount, bts, err = msgp.ReadInt64Bytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "TotalCount")
+				return
+			}
+		case "ETA":
+			z.ETA, bts, err = msgp.ReadInt64Bytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "ETA")
+				return
+			}
 		default:
 			bts, err = msgp.Skip(bts)
 			if err != nil {
@@ -1383,7 +1458,7 @@ func (z *ResyncTarget) UnmarshalMsg(bts []byte) (o []byte, err error) {
 
 // Msgsize returns an upper bound estimate of the number of bytes occupied by the serialized message
 func (z *ResyncTarget) Msgsize() (s int) {
-	s = 1 + 4 + msgp.StringPrefixSize + len(z.Arn) + 8 + msgp.StringPrefixSize + len(z.ResetID) + 10 + msgp.TimeSize + 8 + msgp.TimeSize + 13 + msgp.StringPrefixSize + len(z.ResyncStatus) + 15 + msgp.Int64Size + 11 + msgp.Int64Size + 12 + msgp.Int64Size + 16 + msgp.Int64Size + 7 + msgp.StringPrefixSize + len(z.Bucket) + 7 + msgp.StringPrefixSize + len(z.Object)
+	s = 1 + 4 + msgp.StringPrefixSize + len(z.Arn) + 8 + msgp.StringPrefixSize + len(z.ResetID) + 10 + msgp.TimeSize + 8 + msgp.TimeSize + 13 + msgp.StringPrefixSize + len(z.ResyncStatus) + 15 + msgp.Int64Size + 11 + msgp.Int64Size + 12 + msgp.Int64Size + 16 + msgp.Int64Size + 7 + msgp.StringPrefixSize + len(z.Bucket) + 7 + msgp.StringPrefixSize + len(z.Object) + 13 + msgp.Int64Size + 11 + msgp.Int64Size + 4 + msgp.Int64Size
 	return
 }
 
@@ -1774,6 +1849,30 @@ func (z *TargetReplicationResyncStatus) DecodeMsg(dc *msgp.Reader) (err error) {
 				err = msgp.WrapError(err, "Object")
 				return
 			}
+		case "scc":
+			z.ScannedCount, err = dc.ReadInt64()
+			if err != nil {
+				err = msgp.WrapError(err, "ScannedCount")
+				return
+			}
+		case "tc":
+			z.TotalCount, err = dc.ReadInt64()
+			if err != nil {
+				err = msgp.WrapError(err, "TotalCount")
+				return
+			}
+		case "pt":
+			z.PauseTime, err = dc.ReadTime()
+			if err != nil {
+				err = msgp.WrapError(err, "PauseTime")
+				return
+			}
+		case "pd":
+			z.PausedDuration, err = dc.ReadInt64()
+			if err != nil {
+				err = msgp.WrapError(err, "PausedDuration")
+				return
+			}
 		default:
 			err = dc.Skip()
 			if err != nil {
@@ -1787,9 +1886,9 @@ func (z *TargetReplicationResyncStatus) DecodeMsg(dc *msgp.Reader) (err error) {
 
 // EncodeMsg implements msgp.Encodable
 func (z *TargetReplicationResyncStatus) EncodeMsg(en *msgp.Writer) (err error) {
-	// map header, size 11
+	// map header, size 15
 	// write "st"
-	err = en.Append(0x8b, 0xa2, 0x73, 0x74)
+	err = en.Append(0x8f, 0xa2, 0x73, 0x74)
 	if err != nil {
 		return
 	}
@@ -1898,15 +1997,55 @@ func (z *TargetReplicationResyncStatus) EncodeMsg(en *msgp.Writer) (err error) {
 		err = msgp.WrapError(err, "Object")
 		return
 	}
+	// write "scc"
+	err = en.Append(0xa3, 0x73, 0x63, 0x63)
+	if err != nil {
+		return
+	}
+	err = en.WriteInt64(z.ScannedCount)
+	if err != nil {
+		err = msgp.WrapError(err, "ScannedCount")
+		return
+	}
+	// write "tc"
+	err = en.Append(0xa2, 0x74, 0x63)
+	if err != nil {
+		return
+	}
+	err = en.WriteInt64(z.TotalCount)
+	if err != nil {
+		err = msgp.WrapError(err, "TotalCount")
+		return
+	}
+	// write "pt"
+	err = en.Append(0xa2, 0x70, 0x74)
+	if err != nil {
+		return
+	}
+	err = en.WriteTime(z.PauseTime)
+	if err != nil {
+		err = msgp.WrapError(err, "PauseTime")
+		return
+	}
+	// write "pd"
+	err = en.Append(0xa2, 0x70, 0x64)
+	if err != nil {
+		return
+	}
+	err = en.WriteInt64(z.PausedDuration)
+	if err != nil {
+		err = msgp.WrapError(err, "PausedDuration")
+		return
+	}
 	return
 }
 
 // MarshalMsg implements msgp.Marshaler
 func (z *TargetReplicationResyncStatus) MarshalMsg(b []byte) (o []byte, err error) {
 	o = msgp.Require(b, z.Msgsize())
-	// map header, size 11
+	// map header, size 15
 	// string "st"
-	o = append(o, 0x8b, 0xa2, 0x73, 0x74)
+	o = append(o, 0x8f, 0xa2, 0x73, 0x74)
 	o = msgp.AppendTime(o, z.StartTime)
 	// string "et"
 	o = append(o, 0xa2, 0x65, 0x74)
@@ -1938,6 +2077,18 @@ func (z *TargetReplicationResyncStatus) MarshalMsg(b []byte) (o []byte, err erro
 	// string "obj"
 	o = append(o, 0xa3, 0x6f, 0x62, 0x6a)
 	o = msgp.AppendString(o, z.Object)
+	// string "scc"
+	o = append(o, 0xa3, 0x73, 0x63, 0x63)
+	o = msgp.AppendInt64(o, z.ScannedCount)
+	// string "tc"
+	o = append(o, 0xa2, 0x74, 0x63)
+	o = msgp.AppendInt64(o, z.TotalCount)
+	// string "pt"
+	o = append(o, 0xa2, 0x70, 0x74)
+	o = msgp.AppendTime(o, z.PauseTime)
+	// string "pd"
+	o = append(o, 0xa2, 0x70, 0x64)
+	o = msgp.AppendInt64(o, z.PausedDuration)
 	return
 }
 
@@ -2029,6 +2180,30 @@ func (z *TargetReplicationResyncStatus) UnmarshalMsg(bts []byte) (o []byte, err
 				err = msgp.WrapError(err, "Object")
 				return
 			}
+		case "scc":
+			z.ScannedCount, bts, err = msgp.ReadInt64Bytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "ScannedCount")
+				return
+			}
+		case "tc":
+			z.TotalCount, bts, err = msgp.ReadInt64Bytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "TotalCount")
+				return
+			}
+		case "pt":
+			z.PauseTime, bts, err = msgp.ReadTimeBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "PauseTime")
+				return
+			}
+		case "pd":
+			z.PausedDuration, bts, err = msgp.ReadInt64Bytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "PausedDuration")
+				return
+			}
 		default:
 			bts, err = msgp.Skip(bts)
 			if err != nil {
@@ -2043,7 +2218,7 @@ func (z *TargetReplicationResyncStatus) UnmarshalMsg(bts []byte) (o []byte, err
 
 // Msgsize returns an upper bound estimate of the number of bytes occupied by the serialized message
 func (z *TargetReplicationResyncStatus) Msgsize() (s int) {
-	s = 1 + 3 + msgp.TimeSize + 3 + msgp.TimeSize + 3 + msgp.StringPrefixSize + len(z.ResyncID) + 4 + msgp.TimeSize + 4 + msgp.IntSize + 3 + msgp.Int64Size + 4 + msgp.Int64Size + 3 + msgp.Int64Size + 4 + msgp.Int64Size + 4 + msgp.StringPrefixSize + len(z.Bucket) + 4 + msgp.StringPrefixSize + len(z.Object)
+	s = 1 + 3 + msgp.TimeSize + 3 + msgp.TimeSize + 3 + msgp.StringPrefixSize + len(z.ResyncID) + 4 + msgp.TimeSize + 4 + msgp.IntSize + 3 + msgp.Int64Size + 4 + msgp.Int64Size + 3 + msgp.Int64Size + 4 + msgp.Int64Size + 4 + msgp.StringPrefixSize + len(z.Bucket) + 4 + msgp.StringPrefixSize + len(z.Object) + 4 + msgp.Int64Size + 3 + msgp.Int64Size + 3 + msgp.TimeSize + 3 + msgp.Int64Size
 	return
 }
 
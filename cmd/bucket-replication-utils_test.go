@@ -19,6 +19,7 @@ package cmd
 
 import (
 	"testing"
+	"time"
 
 	"github.com/minio/minio/internal/bucket/replication"
 )
@@ -245,3 +246,54 @@ func TestCompositeReplicationStatus(t *testing.T) {
 		}
 	}
 }
+
+func TestResyncETA(t *testing.T) {
+	now := time.Date(2022, time.January, 1, 12, 0, 0, 0, time.UTC)
+	testCases := []struct {
+		name   string
+		st     TargetReplicationResyncStatus
+		expETA time.Duration
+	}{
+		{
+			name:   "nothing scanned",
+			st:     TargetReplicationResyncStatus{ResyncStatus: ResyncStarted, StartTime: now.Add(-time.Minute), TotalCount: 100},
+			expETA: 0,
+		},
+		{
+			name:   "no estimate of the versions to scan",
+			st:     TargetReplicationResyncStatus{ResyncStatus: ResyncStarted, StartTime: now.Add(-time.Minute), ScannedCount: 10},
+			expETA: 0,
+		},
+		{
+			name:   "resync in progress",
+			st:     TargetReplicationResyncStatus{ResyncStatus: ResyncStarted, StartTime: now.Add(-time.Minute), ScannedCount: 25, TotalCount: 100},
+			expETA: 3 * time.Minute,
+		},
+		{
+			name: "resync resumed",
+			st: TargetReplicationResyncStatus{
+				ResyncStatus: ResyncStarted, StartTime: now.Add(-2 * time.Minute), PausedDuration: int64(time.Minute),
+				ScannedCount: 25, TotalCount: 100,
+			},
+			expETA: 3 * time.Minute,
+		},
+		{
+			name: "resync paused",
+			st: TargetReplicationResyncStatus{
+				ResyncStatus: ResyncPaused, StartTime: now.Add(-3 * time.Minute), PauseTime: now.Add(-2 * time.Minute),
+				ScannedCount: 50, TotalCount: 100,
+			},
+			expETA: time.Minute,
+		},
+		{
+			name:   "resync completed",
+			st:     TargetReplicationResyncStatus{ResyncStatus: ResyncCompleted, StartTime: now.Add(-time.Minute), ScannedCount: 50, TotalCount: 100},
+			expETA: 0,
+		},
+	}
+	for i, test := range testCases {
+		if eta := test.st.eta(now); eta != test.expETA {
+			t.Errorf("Test%d (%s): expected ETA %s, got %s", i+1, test.name, test.expETA, eta)
+		}
+	}
+}
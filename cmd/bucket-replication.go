@@ -976,6 +976,12 @@ func replicateObject(ctx context.Context, ri ReplicateObjectInfo, objectAPI Obje
 			if rinfo.ReplicationStatus != rinfo.PrevReplicationStatus {
 				globalReplicationStats.Update(bucket, rinfo.Arn, rinfo.Size, rinfo.Duration, rinfo.ReplicationStatus, rinfo.PrevReplicationStatus, opType)
 			}
+			// Existing objects are not replicated as they are written, their
+			// lag would hide the lag of the objects replicated as they are.
+			if rinfo.ReplicationStatus == replication.Completed && opType == replication.ObjectReplicationType &&
+				ri.OpType != replication.ExistingObjectReplicationType {
+				globalReplicationStats.UpdateLag(bucket, rinfo.Arn, UTCNow().Sub(objInfo.ModTime))
+			}
 		}
 	}
 
@@ -1180,10 +1186,11 @@ func replicateObjectToTarget(ctx context.Context, ri ReplicateObjectInfo, object
 
 		opts := &bandwidth.MonitorReaderOptions{
 			Bucket:     objInfo.Bucket,
+			Endpoint:   tgt.EndpointURL().Host,
 			HeaderSize: headerSize,
 		}
 		newCtx := ctx
-		if globalBucketMonitor.IsThrottled(bucket) {
+		if globalBucketMonitor.IsThrottled(bucket) || globalBucketMonitor.IsTargetThrottled(opts.Endpoint) {
 			var cancel context.CancelFunc
 			newCtx, cancel = context.WithTimeout(ctx, throttleDeadline)
 			defer cancel()
@@ -1892,11 +1899,17 @@ func calculateBucketReplicationStats(bucket string, u BucketUsageInfo, bucketSta
 			if oldst == nil {
 				oldst = &BucketReplicationStat{}
 			}
+			lastReplicated := stat.LastReplicated
+			if oldst.LastReplicated.After(lastReplicated) {
+				lastReplicated = oldst.LastReplicated
+			}
 			stats[arn] = &BucketReplicationStat{
 				FailedCount:    stat.FailedCount + oldst.FailedCount,
 				FailedSize:     stat.FailedSize + oldst.FailedSize,
 				ReplicatedSize: stat.ReplicatedSize + oldst.ReplicatedSize,
 				Latency:        stat.Latency.merge(oldst.Latency),
+				Lag:            stat.Lag.merge(oldst.Lag),
+				LastReplicated: lastReplicated,
 			}
 		}
 	}
@@ -1918,8 +1931,13 @@ func calculateBucketReplicationStats(bucket string, u BucketUsageInfo, bucketSta
 		Stats: make(map[string]*BucketReplicationStat, len(stats)),
 	}
 	var latestTotReplicatedSize int64
-	for _, st := range u.ReplicationInfo {
+	for arn, st := range u.ReplicationInfo {
 		latestTotReplicatedSize += int64(st.ReplicatedSize)
+		// pending replication is only known from the usage info, report
+		// the targets which have nothing else than pending replication.
+		if _, ok := stats[arn]; !ok && (st.ReplicationPendingCount > 0 || st.ReplicationPendingSize > 0) {
+			stats[arn] = &BucketReplicationStat{}
+		}
 	}
 	// normalize computed real time stats with latest usage stat
 	for arn, tgtstat := range stats {
@@ -1939,10 +1957,16 @@ func calculateBucketReplicationStats(bucket string, u BucketUsageInfo, bucketSta
 		st.FailedSize = int64(math.Max(float64(tgtstat.FailedSize), 0))
 		st.FailedCount = int64(math.Max(float64(tgtstat.FailedCount), 0))
 		st.Latency = tgtstat.Latency
+		st.Lag = tgtstat.Lag
+		st.LastReplicated = tgtstat.LastReplicated
+		st.PendingSize = int64(bu.ReplicationPendingSize)
+		st.PendingCount = int64(bu.ReplicationPendingCount)
 
 		s.Stats[arn] = &st
 		s.FailedSize += st.FailedSize
 		s.FailedCount += st.FailedCount
+		s.PendingSize += st.PendingSize
+		s.PendingCount += st.PendingCount
 	}
 	// normalize overall stats
 	s.ReplicaSize = int64(math.Max(float64(totReplicaSize), float64(u.ReplicaSize)))
@@ -1970,9 +1994,10 @@ func (p *ReplicationPool) periodicResyncMetaSave(ctx context.Context, objectAPI
 			p.resyncState.RLock()
 			for bucket, brs := range p.resyncState.statusMap {
 				var updt bool
-				for _, st := range brs.TargetsMap {
-					// if resync in progress or just ended, needs to save to disk
-					if st.EndTime.Equal(timeSentinel) || now.Sub(st.EndTime) <= resyncTimeInterval {
+				for arn, st := range brs.TargetsMap {
+					// if resync in progress on this node or just ended, needs to save to disk
+					_, running := p.resyncState.cancels[resyncKey(bucket, arn)]
+					if running || now.Sub(st.EndTime) <= resyncTimeInterval {
 						updt = true
 						break
 					}
@@ -2004,11 +2029,23 @@ func resyncBucket(ctx context.Context, bucket, arn string, heal bool, objectAPI
 		globalReplicationPool.resyncState.Lock()
 		m := globalReplicationPool.resyncState.statusMap[bucket]
 		st := m.TargetsMap[arn]
-		st.EndTime = UTCNow()
-		st.ResyncStatus = resyncStatus
+		paused := st.ResyncStatus == ResyncPaused
+		if ctx.Err() == nil {
+			st.EndTime = UTCNow()
+			st.ResyncStatus = resyncStatus
+		}
+		// Otherwise the resync was paused, or the server is stopping
+		// and the resync is resumed from the checkpoint on restart.
 		m.TargetsMap[arn] = st
 		globalReplicationPool.resyncState.statusMap[bucket] = m
+		m = m.clone()
 		globalReplicationPool.resyncState.Unlock()
+
+		if paused {
+			// Save the progress as of the pause.
+			m.LastUpdate = UTCNow()
+			logger.LogIf(GlobalContext, saveResyncStatus(GlobalContext, bucket, m, objectAPI))
+		}
 	}()
 	// Allocate new results channel to receive ObjectInfo.
 	objInfoCh := make(chan ObjectInfo)
@@ -2062,6 +2099,13 @@ func resyncBucket(ctx context.Context, bucket, arn string, heal bool, objectAPI
 		}
 		lastCheckpoint = ""
 
+		globalReplicationPool.resyncState.Lock()
+		m = globalReplicationPool.resyncState.statusMap[bucket]
+		st = m.TargetsMap[arn]
+		st.ScannedCount++
+		m.TargetsMap[arn] = st
+		globalReplicationPool.resyncState.Unlock()
+
 		roi := getHealReplicateObjectInfo(obj, rcfg)
 		if !roi.ExistingObjResync.mustResync() {
 			continue
@@ -2099,6 +2143,10 @@ func resyncBucket(ctx context.Context, bucket, arn string, heal bool, objectAPI
 				ReplicationProxyRequest: "false",
 			},
 		})
+		if ctx.Err() != nil {
+			// Paused, the object is replicated again on resume.
+			return
+		}
 		globalReplicationPool.resyncState.Lock()
 		m = globalReplicationPool.resyncState.statusMap[bucket]
 		st = m.TargetsMap[arn]
@@ -2117,6 +2165,9 @@ func resyncBucket(ctx context.Context, bucket, arn string, heal bool, objectAPI
 		globalReplicationPool.resyncState.statusMap[bucket] = m
 		globalReplicationPool.resyncState.Unlock()
 	}
+	if ctx.Err() != nil {
+		return
+	}
 	resyncStatus = ResyncCompleted
 }
 
@@ -2161,6 +2212,15 @@ func startReplicationResync(ctx context.Context, bucket, arn, resyncID string, r
 		ResyncStatus:     ResyncStarted,
 		Bucket:           bucket,
 	}
+	// Estimate the versions to scan for the progress of the resync.
+	if dui, err := loadDataUsageFromBackend(ctx, objAPI); err == nil {
+		if u, ok := dui.BucketsUsage[bucket]; ok {
+			status.TotalCount = int64(u.VersionsCount)
+			if status.TotalCount == 0 {
+				status.TotalCount = int64(u.ObjectsCount)
+			}
+		}
+	}
 	data.TargetsMap[arn] = status
 	if err = saveResyncStatus(ctx, bucket, data, objAPI); err != nil {
 		return err
@@ -2176,7 +2236,7 @@ func startReplicationResync(ctx context.Context, bucket, arn, resyncID string, r
 	}
 	brs.TargetsMap[arn] = status
 	globalReplicationPool.resyncState.statusMap[bucket] = brs
-	go resyncBucket(GlobalContext, bucket, arn, false, objAPI)
+	go globalReplicationPool.runResync(GlobalContext, bucket, arn, false, objAPI)
 	return nil
 }
 
@@ -2220,7 +2280,7 @@ func (p *ReplicationPool) loadResync(ctx context.Context, buckets []BucketInfo,
 		if ok {
 			for arn, st := range m.TargetsMap {
 				if st.ResyncStatus == ResyncFailed || st.ResyncStatus == ResyncStarted {
-					go resyncBucket(ctx, bucket, arn, true, objAPI)
+					go p.runResync(ctx, bucket, arn, true, objAPI)
 				}
 			}
 		}
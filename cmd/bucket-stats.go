@@ -18,6 +18,9 @@
 package cmd
 
 import (
+	"math"
+	"sort"
+	"strconv"
 	"time"
 )
 
@@ -51,6 +54,110 @@ func (rl *ReplicationLatency) update(size int64, duration time.Duration) {
 	rl.UploadHistogram.Add(size, duration)
 }
 
+// replicationLagBuckets is the number of buckets of the replication
+// lag histogram, the last one is unbounded.
+const replicationLagBuckets = 10
+
+// replicationLagBounds are the upper bounds of the bounded
+// buckets of the replication lag histogram.
+var replicationLagBounds = [replicationLagBuckets - 1]time.Duration{
+	time.Second,
+	5 * time.Second,
+	30 * time.Second,
+	time.Minute,
+	5 * time.Minute,
+	15 * time.Minute,
+	time.Hour,
+	6 * time.Hour,
+	24 * time.Hour,
+}
+
+// replicationLagQuantiles are the quantiles of the replication lag reported.
+var replicationLagQuantiles = []float64{0.5, 0.9, 0.99}
+
+// ReplicationLag is a histogram of the time the objects replicated during
+// the current and the previous minute took to reach the remote target
+// after they were written.
+type ReplicationLag struct {
+	Counts     [replicationLagBuckets]int64
+	PrevCounts [replicationLagBuckets]int64
+	Minute     int64
+}
+
+// forwardTo moves the histogram to the given minute, the counts
+// of the minutes before the previous one are dropped.
+func (l *ReplicationLag) forwardTo(minute int64) {
+	if minute <= l.Minute {
+		return
+	}
+	if minute == l.Minute+1 {
+		l.PrevCounts = l.Counts
+	} else {
+		l.PrevCounts = [replicationLagBuckets]int64{}
+	}
+	l.Counts = [replicationLagBuckets]int64{}
+	l.Minute = minute
+}
+
+// update records an object replicated lag after it was written.
+func (l *ReplicationLag) update(lag time.Duration) {
+	l.forwardTo(time.Now().Unix() / 60)
+	i := sort.Search(len(replicationLagBounds), func(i int) bool {
+		return lag <= replicationLagBounds[i]
+	})
+	l.Counts[i]++
+}
+
+// merge two replication lag histograms into a new one.
+func (l ReplicationLag) merge(o ReplicationLag) (merged ReplicationLag) {
+	if l.Minute > o.Minute {
+		o.forwardTo(l.Minute)
+	} else {
+		l.forwardTo(o.Minute)
+	}
+	merged.Minute = l.Minute
+	for i := range merged.Counts {
+		merged.Counts[i] = l.Counts[i] + o.Counts[i]
+		merged.PrevCounts[i] = l.PrevCounts[i] + o.PrevCounts[i]
+	}
+	return merged
+}
+
+// quantile returns the upper bound of the bucket holding the quantile
+// q of the recent replication lag, the lag beyond the last bound is
+// reported as the last bound. Zero is returned without recent data.
+func (l ReplicationLag) quantile(q float64) time.Duration {
+	l.forwardTo(time.Now().Unix() / 60)
+	var counts [replicationLagBuckets]int64
+	var total int64
+	for i := range counts {
+		counts[i] = l.Counts[i] + l.PrevCounts[i]
+		total += counts[i]
+	}
+	if total == 0 {
+		return 0
+	}
+	rank := int64(math.Ceil(q * float64(total)))
+	var n int64
+	for i, count := range counts {
+		n += count
+		if n >= rank && i < len(replicationLagBounds) {
+			return replicationLagBounds[i]
+		}
+	}
+	return replicationLagBounds[len(replicationLagBounds)-1]
+}
+
+// getLagMillis returns the reported quantiles of the
+// replication lag in milliseconds, keyed by quantile.
+func (l ReplicationLag) getLagMillis() map[string]uint64 {
+	ret := make(map[string]uint64, len(replicationLagQuantiles))
+	for _, q := range replicationLagQuantiles {
+		ret[strconv.FormatFloat(q, 'f', -1, 64)] = uint64(l.quantile(q) / time.Millisecond)
+	}
+	return ret
+}
+
 // BucketStatsMap captures bucket statistics for all buckets
 type BucketStatsMap map[string]BucketStats
 
@@ -116,6 +223,10 @@ type BucketReplicationStat struct {
 	FailedCount int64 `json:"failedReplicationCount"`
 	// Replication latency information
 	Latency ReplicationLatency `json:"replicationLatency"`
+	// Replication lag of the recently replicated objects
+	Lag ReplicationLag `json:"replicationLag"`
+	// Time of the last successful replication
+	LastReplicated time.Time `json:"lastReplicated"`
 }
 
 func (bs *BucketReplicationStat) hasReplicationUsage() bool {
@@ -89,6 +89,18 @@ func (z *BucketReplicationStat) DecodeMsg(dc *msgp.Reader) (err error) {
 					}
 				}
 			}
+		case "Lag":
+			err = z.Lag.DecodeMsg(dc)
+			if err != nil {
+				err = msgp.WrapError(err, "Lag")
+				return
+			}
+		case "LastReplicated":
+			z.LastReplicated, err = dc.ReadTime()
+			if err != nil {
+				err = msgp.WrapError(err, "LastReplicated")
+				return
+			}
 		default:
 			err = dc.Skip()
 			if err != nil {
@@ -102,9 +114,9 @@ func (z *BucketReplicationStat) DecodeMsg(dc *msgp.Reader) (err error) {
 
 // EncodeMsg implements msgp.Encodable
 func (z *BucketReplicationStat) EncodeMsg(en *msgp.Writer) (err error) {
-	// map header, size 7
+	// map header, size 9
 	// write "PendingSize"
-	err = en.Append(0x87, 0xab, 0x50, 0x65, 0x6e, 0x64, 0x69, 0x6e, 0x67, 0x53, 0x69, 0x7a, 0x65)
+	err = en.Append(0x89, 0xab, 0x50, 0x65, 0x6e, 0x64, 0x69, 0x6e, 0x67, 0x53, 0x69, 0x7a, 0x65)
 	if err != nil {
 		return
 	}
@@ -179,15 +191,35 @@ func (z *BucketReplicationStat) EncodeMsg(en *msgp.Writer) (err error) {
 		err = msgp.WrapError(err, "Latency", "UploadHistogram")
 		return
 	}
+	// write "Lag"
+	err = en.Append(0xa3, 0x4c, 0x61, 0x67)
+	if err != nil {
+		return
+	}
+	err = z.Lag.EncodeMsg(en)
+	if err != nil {
+		err = msgp.WrapError(err, "Lag")
+		return
+	}
+	// write "LastReplicated"
+	err = en.Append(0xae, 0x4c, 0x61, 0x73, 0x74, 0x52, 0x65, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x74, 0x65, 0x64)
+	if err != nil {
+		return
+	}
+	err = en.WriteTime(z.LastReplicated)
+	if err != nil {
+		err = msgp.WrapError(err, "LastReplicated")
+		return
+	}
 	return
 }
 
 // MarshalMsg implements msgp.Marshaler
 func (z *BucketReplicationStat) MarshalMsg(b []byte) (o []byte, err error) {
 	o = msgp.Require(b, z.Msgsize())
-	// map header, size 7
+	// map header, size 9
 	// string "PendingSize"
-	o = append(o, 0x87, 0xab, 0x50, 0x65, 0x6e, 0x64, 0x69, 0x6e, 0x67, 0x53, 0x69, 0x7a, 0x65)
+	o = append(o, 0x89, 0xab, 0x50, 0x65, 0x6e, 0x64, 0x69, 0x6e, 0x67, 0x53, 0x69, 0x7a, 0x65)
 	o = msgp.AppendInt64(o, z.PendingSize)
 	// string "ReplicatedSize"
 	o = append(o, 0xae, 0x52, 0x65, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x74, 0x65, 0x64, 0x53, 0x69, 0x7a, 0x65)
@@ -214,6 +246,16 @@ func (z *BucketReplicationStat) MarshalMsg(b []byte) (o []byte, err error) {
 		err = msgp.WrapError(err, "Latency", "UploadHistogram")
 		return
 	}
+	// string "Lag"
+	o = append(o, 0xa3, 0x4c, 0x61, 0x67)
+	o, err = z.Lag.MarshalMsg(o)
+	if err != nil {
+		err = msgp.WrapError(err, "Lag")
+		return
+	}
+	// string "LastReplicated"
+	o = append(o, 0xae, 0x4c, 0x61, 0x73, 0x74, 0x52, 0x65, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x74, 0x65, 0x64)
+	o = msgp.AppendTime(o, z.LastReplicated)
 	return
 }
 
@@ -300,6 +342,18 @@ func (z *BucketReplicationStat) UnmarshalMsg(bts []byte) (o []byte, err error) {
 					}
 				}
 			}
+		case "Lag":
+			bts, err = z.Lag.UnmarshalMsg(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "Lag")
+				return
+			}
+		case "LastReplicated":
+			z.LastReplicated, bts, err = msgp.ReadTimeBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "LastReplicated")
+				return
+			}
 		default:
 			bts, err = msgp.Skip(bts)
 			if err != nil {
@@ -314,7 +368,7 @@ func (z *BucketReplicationStat) UnmarshalMsg(bts []byte) (o []byte, err error) {
 
 // Msgsize returns an upper bound estimate of the number of bytes occupied by the serialized message
 func (z *BucketReplicationStat) Msgsize() (s int) {
-	s = 1 + 12 + msgp.Int64Size + 15 + msgp.Int64Size + 12 + msgp.Int64Size + 11 + msgp.Int64Size + 13 + msgp.Int64Size + 12 + msgp.Int64Size + 8 + 1 + 16 + z.Latency.UploadHistogram.Msgsize()
+	s = 1 + 12 + msgp.Int64Size + 15 + msgp.Int64Size + 12 + msgp.Int64Size + 11 + msgp.Int64Size + 13 + msgp.Int64Size + 12 + msgp.Int64Size + 8 + 1 + 16 + z.Latency.UploadHistogram.Msgsize() + 4 + z.Lag.Msgsize() + 15 + msgp.TimeSize
 	return
 }
 
@@ -969,6 +1023,227 @@ func (z BucketStatsMap) Msgsize() (s int) {
 	return
 }
 
+// DecodeMsg implements msgp.Decodable
+func (z *ReplicationLag) DecodeMsg(dc *msgp.Reader) (err error) {
+	var field []byte
+	_ = field
+	var zb0001 uint32
+	zb0001, err = dc.ReadMapHeader()
+	if err != nil {
+		err = msgp.WrapError(err)
+		return
+	}
+	for zb0001 > 0 {
+		zb0001--
+		field, err = dc.ReadMapKeyPtr()
+		if err != nil {
+			err = msgp.WrapError(err)
+			return
+		}
+		switch msgp.UnsafeString(field) {
+		case "Counts":
+			var zb0002 uint32
+			zb0002, err = dc.ReadArrayHeader()
+			if err != nil {
+				err = msgp.WrapError(err, "Counts")
+				return
+			}
+			if zb0002 != uint32(replicationLagBuckets) {
+				err = msgp.ArrayError{Wanted: uint32(replicationLagBuckets), Got: zb0002}
+				return
+			}
+			for za0001 := range z.Counts {
+				z.Counts[za0001], err = dc.ReadInt64()
+				if err != nil {
+					err = msgp.WrapError(err, "Counts", za0001)
+					return
+				}
+			}
+		case "PrevCounts":
+			var zb0003 uint32
+			zb0003, err = dc.ReadArrayHeader()
+			if err != nil {
+				err = msgp.WrapError(err, "PrevCounts")
+				return
+			}
+			if zb0003 != uint32(replicationLagBuckets) {
+				err = msgp.ArrayError{Wanted: uint32(replicationLagBuckets), Got: zb0003}
+				return
+			}
+			for za0002 := range z.PrevCounts {
+				z.PrevCounts[za0002], err = dc.ReadInt64()
+				if err != nil {
+					err = msgp.WrapError(err, "PrevCounts", za0002)
+					return
+				}
+			}
+		case "Minute":
+			z.Minute, err = dc.ReadInt64()
+			if err != nil {
+				err = msgp.WrapError(err, "Minute")
+				return
+			}
+		default:
+			err = dc.Skip()
+			if err != nil {
+				err = msgp.WrapError(err)
+				return
+			}
+		}
+	}
+	return
+}
+
+// EncodeMsg implements msgp.Encodable
+func (z *ReplicationLag) EncodeMsg(en *msgp.Writer) (err error) {
+	// map header, size 3
+	// write "Counts"
+	err = en.Append(0x83, 0xa6, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x73)
+	if err != nil {
+		return
+	}
+	err = en.WriteArrayHeader(uint32(replicationLagBuckets))
+	if err != nil {
+		err = msgp.WrapError(err, "Counts")
+		return
+	}
+	for za0001 := range z.Counts {
+		err = en.WriteInt64(z.Counts[za0001])
+		if err != nil {
+			err = msgp.WrapError(err, "Counts", za0001)
+			return
+		}
+	}
+	// write "PrevCounts"
+	err = en.Append(0xaa, 0x50, 0x72, 0x65, 0x76, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x73)
+	if err != nil {
+		return
+	}
+	err = en.WriteArrayHeader(uint32(replicationLagBuckets))
+	if err != nil {
+		err = msgp.WrapError(err, "PrevCounts")
+		return
+	}
+	for za0002 := range z.PrevCounts {
+		err = en.WriteInt64(z.PrevCounts[za0002])
+		if err != nil {
+			err = msgp.WrapError(err, "PrevCounts", za0002)
+			return
+		}
+	}
+	// write "Minute"
+	err = en.Append(0xa6, 0x4d, 0x69, 0x6e, 0x75, 0x74, 0x65)
+	if err != nil {
+		return
+	}
+	err = en.WriteInt64(z.Minute)
+	if err != nil {
+		err = msgp.WrapError(err, "Minute")
+		return
+	}
+	return
+}
+
+// MarshalMsg implements msgp.Marshaler
+func (z *ReplicationLag) MarshalMsg(b []byte) (o []byte, err error) {
+	o = msgp.Require(b, z.Msgsize())
+	// map header, size 3
+	// string "Counts"
+	o = append(o, 0x83, 0xa6, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x73)
+	o = msgp.AppendArrayHeader(o, uint32(replicationLagBuckets))
+	for za0001 := range z.Counts {
+		o = msgp.AppendInt64(o, z.Counts[za0001])
+	}
+	// string "PrevCounts"
+	o = append(o, 0xaa, 0x50, 0x72, 0x65, 0x76, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x73)
+	o = msgp.AppendArrayHeader(o, uint32(replicationLagBuckets))
+	for za0002 := range z.PrevCounts {
+		o = msgp.AppendInt64(o, z.PrevCounts[za0002])
+	}
+	// string "Minute"
+	o = append(o, 0xa6, 0x4d, 0x69, 0x6e, 0x75, 0x74, 0x65)
+	o = msgp.AppendInt64(o, z.Minute)
+	return
+}
+
+// UnmarshalMsg implements msgp.Unmarshaler
+func (z *ReplicationLag) UnmarshalMsg(bts []byte) (o []byte, err error) {
+	var field []byte
+	_ = field
+	var zb0001 uint32
+	zb0001, bts, err = msgp.ReadMapHeaderBytes(bts)
+	if err != nil {
+		err = msgp.WrapError(err)
+		return
+	}
+	for zb0001 > 0 {
+		zb0001--
+		field, bts, err = msgp.ReadMapKeyZC(bts)
+		if err != nil {
+			err = msgp.WrapError(err)
+			return
+		}
+		switch msgp.UnsafeString(field) {
+		case "Counts":
+			var zb0002 uint32
+			zb0002, bts, err = msgp.ReadArrayHeaderBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "Counts")
+				return
+			}
+			if zb0002 != uint32(replicationLagBuckets) {
+				err = msgp.ArrayError{Wanted: uint32(replicationLagBuckets), Got: zb0002}
+				return
+			}
+			for za0001 := range z.Counts {
+				z.Counts[za0001], bts, err = msgp.ReadInt64Bytes(bts)
+				if err != nil {
+					err = msgp.WrapError(err, "Counts", za0001)
+					return
+				}
+			}
+		case "PrevCounts":
+			var zb0003 uint32
+			zb0003, bts, err = msgp.ReadArrayHeaderBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "PrevCounts")
+				return
+			}
+			if zb0003 != uint32(replicationLagBuckets) {
+				err = msgp.ArrayError{Wanted: uint32(replicationLagBuckets), Got: zb0003}
+				return
+			}
+			for za0002 := range z.PrevCounts {
+				z.PrevCounts[za0002], bts, err = msgp.ReadInt64Bytes(bts)
+				if err != nil {
+					err = msgp.WrapError(err, "PrevCounts", za0002)
+					return
+				}
+			}
+		case "Minute":
+			z.Minute, bts, err = msgp.ReadInt64Bytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "Minute")
+				return
+			}
+		default:
+			bts, err = msgp.Skip(bts)
+			if err != nil {
+				err = msgp.WrapError(err)
+				return
+			}
+		}
+	}
+	o = bts
+	return
+}
+
+// Msgsize returns an upper bound estimate of the number of bytes occupied by the serialized message
+func (z *ReplicationLag) Msgsize() (s int) {
+	s = 1 + 7 + msgp.ArrayHeaderSize + (replicationLagBuckets * (msgp.Int64Size)) + 11 + msgp.ArrayHeaderSize + (replicationLagBuckets * (msgp.Int64Size)) + 7 + msgp.Int64Size
+	return
+}
+
 // DecodeMsg implements msgp.Decodable
 func (z *ReplicationLatency) DecodeMsg(dc *msgp.Reader) (err error) {
 	var field []byte
@@ -0,0 +1,101 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"testing"
+	"time"
+)
+
+func TestReplicationLag(t *testing.T) {
+	var lag ReplicationLag
+	if q := lag.quantile(0.5); q != 0 {
+		t.Fatalf("expected no lag without data, got %s", q)
+	}
+
+	for i := 0; i < 90; i++ {
+		lag.update(500 * time.Millisecond)
+	}
+	for i := 0; i < 9; i++ {
+		lag.update(2 * time.Minute)
+	}
+	lag.update(48 * time.Hour)
+
+	testCases := []struct {
+		q    float64
+		want time.Duration
+	}{
+		{0.5, time.Second},
+		{0.9, time.Second},
+		{0.95, 5 * time.Minute},
+		{0.99, 5 * time.Minute},
+		{1, 24 * time.Hour},
+	}
+	for _, tc := range testCases {
+		if got := lag.quantile(tc.q); got != tc.want {
+			t.Errorf("quantile %v: expected %s, got %s", tc.q, tc.want, got)
+		}
+	}
+
+	millis := lag.getLagMillis()
+	if millis["0.5"] != 1000 || millis["0.99"] != 300000 {
+		t.Errorf("unexpected lag in milliseconds %v", millis)
+	}
+
+	// The counts of the previous minute are kept, older ones are dropped.
+	old := lag
+	old.Minute -= 2
+	if merged := old.merge(ReplicationLag{Minute: old.Minute + 1}); merged.PrevCounts != lag.Counts {
+		t.Errorf("expected the counts of the previous minute to be kept, got %v", merged.PrevCounts)
+	}
+	if q := old.quantile(0.5); q != 0 {
+		t.Errorf("expected the old lag to be dropped, got %s", q)
+	}
+
+	merged := lag.merge(lag)
+	if merged.Counts[0] != 180 || merged.Minute != lag.Minute {
+		t.Errorf("unexpected merged lag %v", merged)
+	}
+}
+
+func TestCalculateBucketReplicationStatsPending(t *testing.T) {
+	u := BucketUsageInfo{
+		ReplicationInfo: map[string]BucketTargetUsageInfo{
+			"arn1": {ReplicationPendingSize: 100, ReplicationPendingCount: 2, ReplicatedSize: 50},
+			"arn2": {ReplicationPendingSize: 10, ReplicationPendingCount: 1},
+		},
+	}
+	bucketStats := []BucketStats{{
+		ReplicationStats: BucketReplicationStats{
+			Stats: map[string]*BucketReplicationStat{
+				"arn1": {FailedCount: 1, FailedSize: 20},
+			},
+		},
+	}}
+
+	s := calculateBucketReplicationStats("bucket", u, bucketStats)
+	if s.PendingSize != 110 || s.PendingCount != 3 {
+		t.Fatalf("unexpected pending replication %d bytes, %d objects", s.PendingSize, s.PendingCount)
+	}
+	if st := s.Stats["arn1"]; st == nil || st.PendingSize != 100 || st.PendingCount != 2 || st.FailedCount != 1 {
+		t.Fatalf("unexpected stats of arn1 %v", st)
+	}
+	if st := s.Stats["arn2"]; st == nil || st.PendingSize != 10 || st.PendingCount != 1 {
+		t.Fatalf("expected the pending replication of arn2 to be reported, got %v", st)
+	}
+}
@@ -20,6 +20,7 @@ package cmd
 import (
 	"context"
 	"net/http"
+	"sort"
 	"sync"
 	"time"
 
@@ -243,6 +244,33 @@ func (sys *BucketTargetSys) GetRemoteTargetClient(ctx context.Context, arn strin
 	return sys.arnRemotesMap[arn]
 }
 
+// TargetHealth is the reachability of a remote target.
+type TargetHealth struct {
+	Arn      string `json:"arn"`
+	Endpoint string `json:"endpoint"`
+	Online   bool   `json:"online"`
+}
+
+// TargetsHealth returns the reachability of all remote targets
+// as reported by their periodic health checks.
+func (sys *BucketTargetSys) TargetsHealth() []TargetHealth {
+	if sys == nil {
+		return nil
+	}
+	sys.RLock()
+	defer sys.RUnlock()
+	targets := make([]TargetHealth, 0, len(sys.arnRemotesMap))
+	for arn, tgt := range sys.arnRemotesMap {
+		targets = append(targets, TargetHealth{
+			Arn:      arn,
+			Endpoint: tgt.EndpointURL().Host,
+			Online:   !tgt.IsOffline(),
+		})
+	}
+	sort.Slice(targets, func(i, j int) bool { return targets[i].Arn < targets[j].Arn })
+	return targets
+}
+
 // GetRemoteBucketTargetByArn returns BucketTarget for a ARN
 func (sys *BucketTargetSys) GetRemoteBucketTargetByArn(ctx context.Context, bucket, arn string) madmin.BucketTarget {
 	sys.RLock()
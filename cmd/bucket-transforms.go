@@ -0,0 +1,294 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	xhttp "github.com/minio/minio/internal/http"
+)
+
+// Timeout of transform endpoints without configured timeout.
+const defaultBucketTransformTimeout = 30 * time.Second
+
+var validAccessPointName = regexp.MustCompile(`^[a-z0-9][a-z0-9-]{0,62}$`)
+
+// BucketTransform is an access point of a bucket, objects read
+// through it are sent to its endpoint and the response of the
+// endpoint is returned to the client instead of the object.
+type BucketTransform struct {
+	Name      string `json:"name"`
+	Endpoint  string `json:"endpoint"`
+	AuthToken string `json:"authToken,omitempty"`
+	Payload   string `json:"payload,omitempty"`
+	Timeout   string `json:"timeout,omitempty"`
+
+	timeout time.Duration
+}
+
+// BucketTransforms holds the access points of a bucket.
+type BucketTransforms struct {
+	AccessPoints []BucketTransform `json:"accessPoints"`
+}
+
+// IsEmpty returns whether no access point is configured.
+func (t BucketTransforms) IsEmpty() bool {
+	return len(t.AccessPoints) == 0
+}
+
+// Get returns the access point with name, nil if there is none.
+func (t BucketTransforms) Get(name string) *BucketTransform {
+	for i := range t.AccessPoints {
+		if t.AccessPoints[i].Name == name {
+			return &t.AccessPoints[i]
+		}
+	}
+	return nil
+}
+
+// parseBucketTransforms parses the access points of bucket.
+func parseBucketTransforms(bucket string, data []byte) (*BucketTransforms, error) {
+	transforms := &BucketTransforms{}
+	if err := json.Unmarshal(data, transforms); err != nil {
+		return transforms, err
+	}
+	names := make(map[string]struct{}, len(transforms.AccessPoints))
+	for i := range transforms.AccessPoints {
+		t := &transforms.AccessPoints[i]
+		if !validAccessPointName.MatchString(t.Name) {
+			return transforms, fmt.Errorf("invalid access point name %q for bucket %s", t.Name, bucket)
+		}
+		if _, ok := names[t.Name]; ok {
+			return transforms, fmt.Errorf("duplicate access point %s for bucket %s", t.Name, bucket)
+		}
+		names[t.Name] = struct{}{}
+		u, err := url.Parse(t.Endpoint)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return transforms, fmt.Errorf("invalid endpoint %q of access point %s: an http or https URL is expected", t.Endpoint, t.Name)
+		}
+		t.timeout = defaultBucketTransformTimeout
+		if t.Timeout != "" {
+			if t.timeout, err = time.ParseDuration(t.Timeout); err != nil || t.timeout <= 0 {
+				return transforms, fmt.Errorf("invalid timeout %q of access point %s", t.Timeout, t.Name)
+			}
+		}
+	}
+	return transforms, nil
+}
+
+// getBucketTransform returns the access point of bucket with
+// name, or nil when name is empty.
+func getBucketTransform(ctx context.Context, bucket, name string) (*BucketTransform, APIErrorCode) {
+	if name == "" {
+		return nil, ErrNone
+	}
+	transforms, _, err := globalBucketMetadataSys.GetTransforms(ctx, bucket)
+	if err != nil {
+		return nil, toAPIErrorCode(ctx, err)
+	}
+	t := transforms.Get(name)
+	if t == nil {
+		return nil, ErrNoSuchAccessPoint
+	}
+	return t, ErrNone
+}
+
+var (
+	transformClientOnce sync.Once
+	transformClient     *http.Client
+)
+
+func getTransformClient() *http.Client {
+	transformClientOnce.Do(func() {
+		transformClient = &http.Client{Transport: NewRemoteTargetHTTPTransport()}
+	})
+	return transformClient
+}
+
+// transformedBody cancels the request to the transform
+// endpoint once the response body is closed.
+type transformedBody struct {
+	io.ReadCloser
+	cancel context.CancelFunc
+}
+
+func (b transformedBody) Close() error {
+	err := b.ReadCloser.Close()
+	b.cancel()
+	return err
+}
+
+// transform sends the object read from r to the endpoint of the access
+// point and returns the successful response of the endpoint. The timeout
+// bounds the time until the endpoint responds, not the transfer of the
+// transformed object. The response body must be closed by the caller.
+func (t BucketTransform) transform(ctx context.Context, bucket string, objInfo ObjectInfo, r io.Reader) (*http.Response, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, t.Endpoint, r)
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+	if objInfo.ContentType != "" {
+		req.Header.Set(xhttp.ContentType, objInfo.ContentType)
+	}
+	if t.AuthToken != "" {
+		req.Header.Set(xhttp.Authorization, "Bearer "+t.AuthToken)
+	}
+	req.Header.Set(xhttp.MinIOTransformAccessPoint, t.Name)
+	req.Header.Set(xhttp.MinIOTransformBucket, bucket)
+	req.Header.Set(xhttp.MinIOTransformObject, objInfo.Name)
+	if objInfo.VersionID != "" {
+		req.Header.Set(xhttp.MinIOTransformVersionID, objInfo.VersionID)
+	}
+	if t.Payload != "" {
+		req.Header.Set(xhttp.MinIOTransformPayload, t.Payload)
+	}
+
+	timeout := t.timeout
+	if timeout <= 0 {
+		timeout = defaultBucketTransformTimeout
+	}
+	timer := time.AfterFunc(timeout, cancel)
+	resp, err := getTransformClient().Do(req)
+	if !timer.Stop() {
+		if err == nil {
+			resp.Body.Close()
+		}
+		cancel()
+		return nil, fmt.Errorf("transform endpoint of access point %s did not respond within %s", t.Name, timeout)
+	}
+	if err != nil {
+		cancel()
+		return nil, fmt.Errorf("transform endpoint of access point %s failed: %w", t.Name, err)
+	}
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		msg, _ := ioutil.ReadAll(io.LimitReader(resp.Body, 1024))
+		resp.Body.Close()
+		cancel()
+		return nil, fmt.Errorf("transform endpoint of access point %s returned %s: %s", t.Name, resp.Status, strings.TrimSpace(string(msg)))
+	}
+	resp.Body = transformedBody{ReadCloser: resp.Body, cancel: cancel}
+	return resp, nil
+}
+
+// setTransformedHeaders replaces the response headers describing the
+// object by those of the transformed object returned by the endpoint.
+func setTransformedHeaders(w http.ResponseWriter, resp *http.Response) {
+	h := w.Header()
+	// Some headers are set with non canonical keys.
+	for _, k := range []string{xhttp.ETag, xhttp.ContentLength, xhttp.ContentRange, xhttp.AcceptRanges} {
+		h.Del(k)
+		delete(h, k)
+	}
+	for k := range h {
+		if strings.HasPrefix(http.CanonicalHeaderKey(k), "X-Amz-Checksum-") {
+			delete(h, k)
+		}
+	}
+	for _, k := range []string{xhttp.ContentType, xhttp.ContentEncoding} {
+		if v := resp.Header.Get(k); v != "" {
+			h.Set(k, v)
+		}
+	}
+	if resp.ContentLength >= 0 {
+		h.Set(xhttp.ContentLength, fmt.Sprint(resp.ContentLength))
+	}
+}
+
+// bucketTransformCounters counts the requests to an access point.
+type bucketTransformCounters struct {
+	requests uint64
+	failures uint64
+}
+
+// bucketTransformStats holds the counters of the access points
+// which served requests, the zero value is ready to use.
+type bucketTransformStats struct {
+	mu       sync.RWMutex
+	counters map[string]map[string]*bucketTransformCounters
+}
+
+// record counts a request to the access point name of bucket.
+func (s *bucketTransformStats) record(bucket, name string, failed bool) {
+	s.mu.RLock()
+	c, ok := s.counters[bucket][name]
+	s.mu.RUnlock()
+	if !ok {
+		s.mu.Lock()
+		if s.counters == nil {
+			s.counters = make(map[string]map[string]*bucketTransformCounters)
+		}
+		if s.counters[bucket] == nil {
+			s.counters[bucket] = make(map[string]*bucketTransformCounters)
+		}
+		if c, ok = s.counters[bucket][name]; !ok {
+			c = &bucketTransformCounters{}
+			s.counters[bucket][name] = c
+		}
+		s.mu.Unlock()
+	}
+	atomic.AddUint64(&c.requests, 1)
+	if failed {
+		atomic.AddUint64(&c.failures, 1)
+	}
+}
+
+// remove discards the counters of bucket.
+func (s *bucketTransformStats) remove(bucket string) {
+	s.mu.Lock()
+	delete(s.counters, bucket)
+	s.mu.Unlock()
+}
+
+// bucketTransformStat describes the requests to an access point.
+type bucketTransformStat struct {
+	bucket      string
+	accessPoint string
+	requests    uint64
+	failures    uint64
+}
+
+// load returns the counters of all access points.
+func (s *bucketTransformStats) load() []bucketTransformStat {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	var result []bucketTransformStat
+	for bucket, counters := range s.counters {
+		for name, c := range counters {
+			result = append(result, bucketTransformStat{
+				bucket:      bucket,
+				accessPoint: name,
+				requests:    atomic.LoadUint64(&c.requests),
+				failures:    atomic.LoadUint64(&c.failures),
+			})
+		}
+	}
+	return result
+}
@@ -0,0 +1,145 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	xhttp "github.com/minio/minio/internal/http"
+)
+
+func TestParseBucketTransforms(t *testing.T) {
+	testCases := []struct {
+		data    string
+		timeout time.Duration
+		err     bool
+	}{
+		{`{"accessPoints": [{"name": "redact", "endpoint": "http://localhost:8080/redact"}]}`, defaultBucketTransformTimeout, false},
+		{`{"accessPoints": [{"name": "resize", "endpoint": "https://lambda/resize", "timeout": "5s"}]}`, 5 * time.Second, false},
+		{`{"accessPoints": [{"name": "Resize", "endpoint": "https://lambda/resize"}]}`, 0, true},
+		{`{"accessPoints": [{"name": "a", "endpoint": "http://a"}, {"name": "a", "endpoint": "http://b"}]}`, 0, true},
+		{`{"accessPoints": [{"name": "a", "endpoint": "ftp://a"}]}`, 0, true},
+		{`{"accessPoints": [{"name": "a", "endpoint": "http://a", "timeout": "-1s"}]}`, 0, true},
+		{`not json`, 0, true},
+	}
+	for i, testCase := range testCases {
+		transforms, err := parseBucketTransforms("bucket", []byte(testCase.data))
+		if (err != nil) != testCase.err {
+			t.Errorf("case %d: unexpected error %v", i+1, err)
+			continue
+		}
+		if err == nil && transforms.AccessPoints[0].timeout != testCase.timeout {
+			t.Errorf("case %d: expected timeout %s, got %s", i+1, testCase.timeout, transforms.AccessPoints[0].timeout)
+		}
+	}
+}
+
+func TestGetObjectAccessPoint(t *testing.T) {
+	endpoint := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get(xhttp.Authorization) != "Bearer token" || r.Header.Get(xhttp.MinIOTransformPayload) != "ssn" {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		if r.URL.Path == "/slow" {
+			time.Sleep(time.Second)
+		}
+		data, _ := ioutil.ReadAll(r.Body)
+		w.Header().Set(xhttp.ContentType, "text/plain")
+		fmt.Fprintf(w, "%s/%s: %s", r.Header.Get(xhttp.MinIOTransformBucket), r.Header.Get(xhttp.MinIOTransformObject), strings.ToUpper(string(data)))
+	}))
+	defer endpoint.Close()
+
+	testServer := StartTestServer(t, "ErasureSD")
+	defer testServer.Stop()
+
+	ctx := context.Background()
+	objAPI := testServer.Obj
+	if err := objAPI.MakeBucketWithLocation(ctx, "bucket", BucketOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	data := "hello world"
+	if _, err := objAPI.PutObject(ctx, "bucket", "object", mustGetPutObjReader(t, strings.NewReader(data), int64(len(data)), "", ""), ObjectOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	config := fmt.Sprintf(`{"accessPoints": [
+		{"name": "upper", "endpoint": "%[1]s/upper", "authToken": "token", "payload": "ssn"},
+		{"name": "denied", "endpoint": "%[1]s/upper", "payload": "ssn"},
+		{"name": "slow", "endpoint": "%[1]s/slow", "authToken": "token", "payload": "ssn", "timeout": "100ms"}
+	]}`, endpoint.URL)
+	if err := globalBucketMetadataSys.Update(ctx, "bucket", bucketTransformConfigFile, []byte(config)); err != nil {
+		t.Fatal(err)
+	}
+
+	testCases := []struct {
+		query  string
+		header map[string]string
+		status int
+		body   string
+	}{
+		{"", nil, http.StatusOK, data},
+		{"?accessPoint=upper", nil, http.StatusOK, "bucket/object: HELLO WORLD"},
+		{"?accessPoint=upper", map[string]string{"Range": "bytes=0-1"}, http.StatusBadRequest, "InvalidRequest"},
+		{"?accessPoint=unknown", nil, http.StatusNotFound, "NoSuchAccessPoint"},
+		{"?accessPoint=denied", nil, http.StatusBadGateway, "XMinioObjectTransformFailed"},
+		{"?accessPoint=slow", nil, http.StatusBadGateway, "did not respond within 100ms"},
+	}
+	client := &http.Client{}
+	for i, testCase := range testCases {
+		req, err := newTestRequest(http.MethodGet, testServer.Server.URL+"/bucket/object"+testCase.query, 0, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for k, v := range testCase.header {
+			req.Header.Set(k, v)
+		}
+		if err = signRequestV4(req, testServer.AccessKey, testServer.SecretKey); err != nil {
+			t.Fatal(err)
+		}
+		resp, err := client.Do(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		body, _ := ioutil.ReadAll(resp.Body)
+		resp.Body.Close()
+		if resp.StatusCode != testCase.status || !bytes.Contains(body, []byte(testCase.body)) {
+			t.Errorf("case %d: unexpected response %d %q", i+1, resp.StatusCode, body)
+		}
+		if testCase.status == http.StatusOK && testCase.query != "" && resp.Header.Get(xhttp.ETag) != "" {
+			t.Errorf("case %d: expected no ETag for a transformed object", i+1)
+		}
+	}
+
+	var requests, failures uint64
+	for _, stat := range globalBucketTransformStats.load() {
+		if stat.bucket == "bucket" {
+			requests += stat.requests
+			failures += stat.failures
+		}
+	}
+	if requests != 3 || failures != 2 {
+		t.Errorf("expected 3 requests and 2 failures, got %d and %d", requests, failures)
+	}
+}
@@ -90,6 +90,14 @@ func (api objectAPIHandlers) PutBucketVersioningHandler(w http.ResponseWriter, r
 		}, r.URL)
 		return
 	}
+	if packConfig().Packed(bucket) {
+		writeErrorResponse(ctx, w, APIError{
+			Code:           "InvalidBucketState",
+			Description:    "Small objects of this bucket are packed, versioning cannot be configured.",
+			HTTPStatusCode: http.StatusBadRequest,
+		}, r.URL)
+		return
+	}
 
 	configData, err := xml.Marshal(v)
 	if err != nil {
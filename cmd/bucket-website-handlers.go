@@ -0,0 +1,337 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+package cmd
+
+import (
+	"context"
+	"encoding/xml"
+	"fmt"
+	"html"
+	"io"
+	"net"
+	"net/http"
+	"strings"
+
+	humanize "github.com/dustin/go-humanize"
+	"github.com/gorilla/mux"
+	"github.com/minio/minio/internal/bucket/website"
+	xhttp "github.com/minio/minio/internal/http"
+	xioutil "github.com/minio/minio/internal/ioutil"
+	"github.com/minio/minio/internal/logger"
+	"github.com/minio/pkg/bucket/policy"
+)
+
+const (
+	bucketWebsiteConfig = "website.xml"
+
+	// Maximum size of bucket website configuration payload sent to the PutBucketWebsiteHandler.
+	maxBucketWebsiteConfigSize = 128 * humanize.KiByte
+)
+
+// PutBucketWebsiteHandler - PUT Bucket website.
+// ----------
+// Replaces the website configuration of a bucket. There are no dedicated
+// policy actions for website configurations, so the bucket policy actions
+// are required.
+func (api objectAPIHandlers) PutBucketWebsiteHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "PutBucketWebsite")
+
+	defer logger.AuditLog(ctx, w, r, mustGetClaimsFromToken(r))
+
+	vars := mux.Vars(r)
+	bucket := vars["bucket"]
+
+	objectAPI := api.ObjectAPI()
+	if objectAPI == nil {
+		writeErrorResponse(ctx, w, errorCodes.ToAPIErr(ErrServerNotInitialized), r.URL)
+		return
+	}
+
+	if s3Error := checkRequestAuthType(ctx, r, policy.PutBucketPolicyAction, bucket, ""); s3Error != ErrNone {
+		writeErrorResponse(ctx, w, errorCodes.ToAPIErr(s3Error), r.URL)
+		return
+	}
+
+	// Check if bucket exists.
+	if _, err := objectAPI.GetBucketInfo(ctx, bucket); err != nil {
+		writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL)
+		return
+	}
+
+	if r.ContentLength > maxBucketWebsiteConfigSize {
+		writeErrorResponse(ctx, w, errorCodes.ToAPIErr(ErrEntityTooLarge), r.URL)
+		return
+	}
+
+	config, err := website.ParseConfig(io.LimitReader(r.Body, maxBucketWebsiteConfigSize))
+	if err != nil {
+		writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL)
+		return
+	}
+
+	configData, err := xml.Marshal(config)
+	if err != nil {
+		writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL)
+		return
+	}
+
+	if err = globalBucketMetadataSys.Update(ctx, bucket, bucketWebsiteConfig, configData); err != nil {
+		writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL)
+		return
+	}
+
+	// Write success response.
+	writeSuccessResponseHeadersOnly(w)
+}
+
+// GetBucketWebsiteHandler - GET Bucket website.
+// ----------
+func (api objectAPIHandlers) GetBucketWebsiteHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "GetBucketWebsite")
+
+	defer logger.AuditLog(ctx, w, r, mustGetClaimsFromToken(r))
+
+	vars := mux.Vars(r)
+	bucket := vars["bucket"]
+
+	objectAPI := api.ObjectAPI()
+	if objectAPI == nil {
+		writeErrorResponse(ctx, w, errorCodes.ToAPIErr(ErrServerNotInitialized), r.URL)
+		return
+	}
+
+	if s3Error := checkRequestAuthType(ctx, r, policy.GetBucketPolicyAction, bucket, ""); s3Error != ErrNone {
+		writeErrorResponse(ctx, w, errorCodes.ToAPIErr(s3Error), r.URL)
+		return
+	}
+
+	// Check if bucket exists.
+	if _, err := objectAPI.GetBucketInfo(ctx, bucket); err != nil {
+		writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL)
+		return
+	}
+
+	config, _, err := globalBucketMetadataSys.GetWebsiteConfig(ctx, bucket)
+	if err != nil {
+		writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL)
+		return
+	}
+
+	configData, err := xml.Marshal(config)
+	if err != nil {
+		writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL)
+		return
+	}
+
+	// Write success response.
+	writeSuccessResponseXML(w, configData)
+}
+
+// DeleteBucketWebsiteHandler - DELETE Bucket website.
+// ----------
+func (api objectAPIHandlers) DeleteBucketWebsiteHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "DeleteBucketWebsite")
+
+	defer logger.AuditLog(ctx, w, r, mustGetClaimsFromToken(r))
+
+	vars := mux.Vars(r)
+	bucket := vars["bucket"]
+
+	objectAPI := api.ObjectAPI()
+	if objectAPI == nil {
+		writeErrorResponse(ctx, w, errorCodes.ToAPIErr(ErrServerNotInitialized), r.URL)
+		return
+	}
+
+	if s3Error := checkRequestAuthType(ctx, r, policy.DeleteBucketPolicyAction, bucket, ""); s3Error != ErrNone {
+		writeErrorResponse(ctx, w, errorCodes.ToAPIErr(s3Error), r.URL)
+		return
+	}
+
+	// Check if bucket exists.
+	if _, err := objectAPI.GetBucketInfo(ctx, bucket); err != nil {
+		writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL)
+		return
+	}
+
+	if err := globalBucketMetadataSys.Update(ctx, bucket, bucketWebsiteConfig, nil); err != nil {
+		writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL)
+		return
+	}
+
+	// Write success response.
+	writeSuccessNoContent(w)
+}
+
+// websiteBucket returns the bucket whose website endpoint is host,
+// the value of a Host header with an optional port.
+func websiteBucket(host string) (string, bool) {
+	if len(globalWebsiteDomainNames) == 0 {
+		return "", false
+	}
+	if h, _, err := net.SplitHostPort(host); err == nil {
+		host = h
+	}
+	host = strings.ToLower(strings.TrimSuffix(host, "."))
+	for _, domain := range globalWebsiteDomainNames {
+		if bucket := strings.TrimSuffix(host, "."+domain); bucket != host && bucket != "" {
+			return bucket, true
+		}
+	}
+	return "", false
+}
+
+// WebsiteHandler - serves the requests sent to the website endpoint of
+// a bucket, `<bucket>.<website domain>`, as a static website.
+// ----------
+// Objects are returned to anonymous GET and HEAD requests allowed by
+// the bucket policy, with the index document of the website in place
+// of folders. Errors are returned as HTML pages, or the error document
+// of the website, and requests may be redirected by its routing rules.
+func (api objectAPIHandlers) WebsiteHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "Website")
+
+	defer logger.AuditLog(ctx, w, r, nil)
+
+	bucket := mux.Vars(r)["bucket"]
+
+	objectAPI := api.ObjectAPI()
+	if objectAPI == nil {
+		writeWebsiteError(ctx, w, r, errorCodes.ToAPIErr(ErrServerNotInitialized))
+		return
+	}
+
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		writeWebsiteError(ctx, w, r, errorCodes.ToAPIErr(ErrMethodNotAllowed))
+		return
+	}
+
+	config, _, err := globalBucketMetadataSys.GetWebsiteConfig(ctx, bucket)
+	if err != nil {
+		writeWebsiteError(ctx, w, r, toAPIError(ctx, err))
+		return
+	}
+
+	key := strings.TrimPrefix(r.URL.Path, SlashSeparator)
+	protocol := getURLScheme(r.TLS != nil)
+	if location, ok := config.RedirectAll(key, protocol); ok {
+		http.Redirect(w, r, location, http.StatusMovedPermanently)
+		return
+	}
+	if rule := config.MatchRoutingRule(key, 0); rule != nil {
+		location, code := rule.Location(key, getHost(r), protocol)
+		http.Redirect(w, r, location, code)
+		return
+	}
+
+	err = api.serveWebsiteObject(ctx, objectAPI, w, r, bucket, config.IndexKey(key), http.StatusOK)
+	if err == nil {
+		return
+	}
+	apiErr := toAPIError(ctx, err)
+
+	// Like the folders of web servers, a key without trailing slash
+	// whose index document exists is redirected to the folder.
+	if apiErr.HTTPStatusCode == http.StatusNotFound && key != "" && !strings.HasSuffix(key, SlashSeparator) && config.IndexDocument != nil {
+		if _, err := objectAPI.GetObjectInfo(ctx, bucket, config.IndexKey(key+SlashSeparator), ObjectOptions{}); err == nil {
+			http.Redirect(w, r, SlashSeparator+key+SlashSeparator, http.StatusFound)
+			return
+		}
+	}
+
+	if rule := config.MatchRoutingRule(key, apiErr.HTTPStatusCode); rule != nil {
+		location, code := rule.Location(key, getHost(r), protocol)
+		http.Redirect(w, r, location, code)
+		return
+	}
+	if config.ErrorDocument != nil {
+		if err := api.serveWebsiteObject(ctx, objectAPI, w, r, bucket, config.ErrorDocument.Key, apiErr.HTTPStatusCode); err == nil {
+			return
+		}
+	}
+	writeWebsiteError(ctx, w, r, apiErr)
+}
+
+// serveWebsiteObject writes the object of the website at key to w
+// with statusCode, if the bucket policy allows anonymous requests to
+// read it. It returns an error if nothing was written.
+func (api objectAPIHandlers) serveWebsiteObject(ctx context.Context, objectAPI ObjectLayer, w http.ResponseWriter, r *http.Request, bucket, key string, statusCode int) error {
+	if key == "" {
+		return ObjectNameInvalid{Bucket: bucket, Object: key}
+	}
+	if !globalPolicySys.IsAllowed(policy.Args{
+		Action:          policy.GetObjectAction,
+		BucketName:      bucket,
+		ObjectName:      key,
+		ConditionValues: getConditionValues(r, "", "", nil),
+		IsOwner:         false,
+	}) {
+		return PrefixAccessDenied{Bucket: bucket, Object: key}
+	}
+
+	var opts ObjectOptions
+	if statusCode == http.StatusOK {
+		opts.CheckPrecondFn = func(oi ObjectInfo) bool {
+			return checkPreconditions(ctx, w, r, oi, opts)
+		}
+	}
+
+	// Objects encrypted with customer keys cannot be served, the
+	// request headers are not passed so that they are not decrypted.
+	gr, err := objectAPI.GetObjectNInfo(ctx, bucket, key, nil, http.Header{}, readLock, opts)
+	if err != nil {
+		if isErrPreconditionFailed(err) {
+			return nil
+		}
+		return err
+	}
+	defer gr.Close()
+
+	if gr.ObjInfo.DeleteMarker {
+		return ObjectNotFound{Bucket: bucket, Object: key}
+	}
+	if err = setObjectHeaders(w, gr.ObjInfo, nil, opts); err != nil {
+		return err
+	}
+	w.WriteHeader(statusCode)
+	if r.Method == http.MethodHead {
+		return nil
+	}
+
+	httpWriter := xioutil.WriteOnClose(w)
+	if _, err = xioutil.Copy(httpWriter, gr); err == nil {
+		err = httpWriter.Close()
+	}
+	if err != nil {
+		logger.LogIf(ctx, fmt.Errorf("Unable to write all the data to client %w", err))
+	}
+	return nil
+}
+
+// writeWebsiteError writes err as the HTML error page of a website.
+func writeWebsiteError(ctx context.Context, w http.ResponseWriter, r *http.Request, err APIError) {
+	setRequestErrorCode(ctx, err.Code)
+
+	var body []byte
+	if r.Method != http.MethodHead {
+		status := fmt.Sprintf("%d %s", err.HTTPStatusCode, http.StatusText(err.HTTPStatusCode))
+		body = []byte(fmt.Sprintf("<html>\n<head><title>%s</title></head>\n<body>\n<h1>%s</h1>\n<ul>\n<li>Code: %s</li>\n<li>Message: %s</li>\n<li>RequestId: %s</li>\n</ul>\n</body>\n</html>\n",
+			status, status, html.EscapeString(err.Code), html.EscapeString(err.Description),
+			html.EscapeString(w.Header().Get(xhttp.AmzRequestID))))
+	}
+	writeResponse(w, err.HTTPStatusCode, body, mimeHTML)
+}
@@ -0,0 +1,174 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestWebsiteBucket(t *testing.T) {
+	savedDomainNames := globalWebsiteDomainNames
+	defer func() { globalWebsiteDomainNames = savedDomainNames }()
+
+	globalWebsiteDomainNames = nil
+	if _, ok := websiteBucket("site.website.example.com"); ok {
+		t.Fatal("expected no website endpoint without website domains")
+	}
+
+	globalWebsiteDomainNames = []string{"website.example.com"}
+	testCases := []struct {
+		host   string
+		bucket string
+		ok     bool
+	}{
+		{"site.website.example.com", "site", true},
+		{"Site.Website.Example.com:9000", "site", true},
+		{"my.site.website.example.com.", "my.site", true},
+		{"website.example.com", "", false},
+		{".website.example.com", "", false},
+		{"site.example.com", "", false},
+	}
+	for i, testCase := range testCases {
+		bucket, ok := websiteBucket(testCase.host)
+		if bucket != testCase.bucket || ok != testCase.ok {
+			t.Errorf("Test %d: expected %q %v, got %q %v", i+1, testCase.bucket, testCase.ok, bucket, ok)
+		}
+	}
+}
+
+func TestBucketWebsiteRequests(t *testing.T) {
+	savedDomainNames := globalWebsiteDomainNames
+	defer func() { globalWebsiteDomainNames = savedDomainNames }()
+	globalWebsiteDomainNames = []string{"website.example.com"}
+
+	testServer := StartTestServer(t, "ErasureSD")
+	defer testServer.Stop()
+
+	ctx := context.Background()
+	objAPI := testServer.Obj
+	if err := objAPI.MakeBucketWithLocation(ctx, "site", BucketOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	for object, data := range map[string]string{
+		"index.html":       "home",
+		"docs/index.html":  "docs",
+		"404.html":         "not found",
+		"private/key.html": "secret",
+	} {
+		if _, err := objAPI.PutObject(ctx, "site", object, mustGetPutObjReader(t, strings.NewReader(data), int64(len(data)), "", ""), ObjectOptions{}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	policy := `{"Version": "2012-10-17", "Statement": [
+		{"Effect": "Allow", "Principal": {"AWS": ["*"]}, "Action": ["s3:GetObject"], "Resource": ["arn:aws:s3:::site/*"]},
+		{"Effect": "Deny", "Principal": {"AWS": ["*"]}, "Action": ["s3:GetObject"], "Resource": ["arn:aws:s3:::site/private/*"]}
+	]}`
+	if err := globalBucketMetadataSys.Update(ctx, "site", bucketPolicyConfig, []byte(policy)); err != nil {
+		t.Fatal(err)
+	}
+
+	// Set the website configuration through the S3 API.
+	config := `<WebsiteConfiguration>
+		<IndexDocument><Suffix>index.html</Suffix></IndexDocument>
+		<ErrorDocument><Key>404.html</Key></ErrorDocument>
+		<RoutingRules>
+			<RoutingRule>
+				<Condition><KeyPrefixEquals>old/</KeyPrefixEquals></Condition>
+				<Redirect><ReplaceKeyPrefixWith>docs/</ReplaceKeyPrefixWith></Redirect>
+			</RoutingRule>
+		</RoutingRules>
+	</WebsiteConfiguration>`
+	for _, method := range []string{http.MethodPut, http.MethodGet} {
+		var body string
+		if method == http.MethodPut {
+			body = config
+		}
+		req, err := newTestSignedRequestV4(method, testServer.Server.URL+"/site/?website",
+			int64(len(body)), strings.NewReader(body), testServer.AccessKey, testServer.SecretKey, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		respBody, _ := ioutil.ReadAll(resp.Body)
+		resp.Body.Close()
+		if resp.StatusCode != http.StatusOK {
+			t.Fatalf("%s website: unexpected response %d %q", method, resp.StatusCode, respBody)
+		}
+		if method == http.MethodGet && !bytes.Contains(respBody, []byte("<Suffix>index.html</Suffix>")) {
+			t.Fatalf("unexpected website configuration %q", respBody)
+		}
+	}
+
+	// Send the requests for the website endpoint to the test server.
+	addr := testServer.Server.Listener.Addr().String()
+	_, port, _ := net.SplitHostPort(addr)
+	client := &http.Client{
+		Transport: &http.Transport{
+			DialContext: func(ctx context.Context, network, _ string) (net.Conn, error) {
+				var d net.Dialer
+				return d.DialContext(ctx, network, addr)
+			},
+		},
+		CheckRedirect: func(*http.Request, []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+	host := "site.website.example.com:" + port
+	testCases := []struct {
+		method   string
+		path     string
+		status   int
+		resp     string
+		location string
+	}{
+		{http.MethodGet, "/", http.StatusOK, "home", ""},
+		{http.MethodGet, "/docs/", http.StatusOK, "docs", ""},
+		{http.MethodGet, "/docs", http.StatusFound, "", "/docs/"},
+		{http.MethodGet, "/old/index.html", http.StatusMovedPermanently, "", "http://" + host + "/docs/index.html"},
+		{http.MethodGet, "/missing.html", http.StatusNotFound, "not found", ""},
+		{http.MethodGet, "/private/key.html", http.StatusForbidden, "not found", ""},
+		{http.MethodHead, "/index.html", http.StatusOK, "", ""},
+		{http.MethodPut, "/index.html", http.StatusMethodNotAllowed, "MethodNotAllowed", ""},
+	}
+	for i, testCase := range testCases {
+		req, err := http.NewRequest(testCase.method, "http://"+host+testCase.path, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		resp, err := client.Do(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		body, _ := ioutil.ReadAll(resp.Body)
+		resp.Body.Close()
+		if resp.StatusCode != testCase.status || !bytes.Contains(body, []byte(testCase.resp)) {
+			t.Errorf("Test %d: unexpected response %d %q", i+1, resp.StatusCode, body)
+		}
+		if location := resp.Header.Get("Location"); location != testCase.location {
+			t.Errorf("Test %d: expected location %q, got %q", i+1, testCase.location, location)
+		}
+	}
+}
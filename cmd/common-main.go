@@ -396,6 +396,18 @@ func handleCommonCmdArgs(ctx *cli.Context) {
 		logger.EnableJSON()
 	}
 
+	// Get "log-format" flag from command line argument, the json
+	// flag takes precedence. The structured formats enable the
+	// quiet mode like the json flag.
+	logFormat := ctx.GlobalString("log-format")
+	if ctx.IsSet("log-format") {
+		logFormat = ctx.String("log-format")
+	}
+	if !globalCLIContext.JSON && logFormat != "" {
+		logger.FatalIf(logger.SetFormat(logFormat), "Invalid --log-format")
+		globalCLIContext.JSON = logger.IsStructured()
+	}
+
 	// Get quiet flag from command line argument.
 	globalCLIContext.Quiet = ctx.IsSet("quiet") || ctx.GlobalIsSet("quiet")
 	if globalCLIContext.Quiet {
@@ -676,6 +688,17 @@ func handleCommonEnvVars() {
 		logger.Fatal(config.ErrInvalidFSOSyncValue(err), "Invalid MINIO_FS_OSYNC value in environment variable")
 	}
 
+	globalFSZeroCopy, err = config.ParseBool(env.Get(config.EnvFSZeroCopy, config.EnableOff))
+	if err != nil {
+		logger.Fatal(config.ErrInvalidFSZeroCopyValue(err), "Invalid MINIO_FS_ZERO_COPY value in environment variable")
+	}
+
+	globalListCacheReuse, err = config.ParseBool(env.Get(EnvListCacheReuse, config.EnableOff))
+	logger.FatalIf(err, "Invalid %s value in environment variable", EnvListCacheReuse)
+
+	globalRangePrefetch, err = config.ParseBool(env.Get(EnvRangePrefetch, config.EnableOn))
+	logger.FatalIf(err, "Invalid %s value in environment variable", EnvRangePrefetch)
+
 	if rootDiskSize := env.Get(config.EnvRootDiskThresholdSize, ""); rootDiskSize != "" {
 		size, err := humanize.ParseBytes(rootDiskSize)
 		if err != nil {
@@ -703,6 +726,23 @@ func handleCommonEnvVars() {
 		}
 	}
 
+	websiteDomains := env.Get(config.EnvWebsiteDomain, "")
+	if len(websiteDomains) != 0 {
+		for _, domainName := range strings.Split(websiteDomains, config.ValueSeparator) {
+			if _, ok := dns2.IsDomainName(domainName); !ok {
+				logger.Fatal(config.ErrInvalidDomainValue(nil).Msg("Unknown value `%s`", domainName),
+					"Invalid MINIO_WEBSITE_DOMAIN value in environment variable")
+			}
+			for _, root := range globalDomainNames {
+				if domainName == root {
+					logger.Fatal(config.ErrOverlappingDomainValue(nil).Msg("Website domain `%s` is also set in MINIO_DOMAIN", domainName),
+						"Invalid MINIO_WEBSITE_DOMAIN value in environment variable")
+				}
+			}
+			globalWebsiteDomainNames = append(globalWebsiteDomainNames, strings.ToLower(domainName))
+		}
+	}
+
 	publicIPs := env.Get(config.EnvPublicIPs, "")
 	if len(publicIPs) != 0 {
 		minioEndpoints := strings.Split(publicIPs, config.ValueSeparator)
@@ -785,9 +825,14 @@ func handleCommonEnvVars() {
 		globalActiveCred = cred
 	}
 
-	switch {
-	case env.IsSet(config.EnvKMSSecretKey) && env.IsSet(config.EnvKESEndpoint):
-		logger.Fatal(errors.New("ambigious KMS configuration"), fmt.Sprintf("The environment contains %q as well as %q", config.EnvKMSSecretKey, config.EnvKESEndpoint))
+	var kmsEnvs []string
+	for _, kmsEnv := range []string{config.EnvKMSSecretKey, config.EnvKESEndpoint, config.EnvKMSVaultEndpoint, config.EnvKMSAWSRegion} {
+		if env.IsSet(kmsEnv) {
+			kmsEnvs = append(kmsEnvs, strconv.Quote(kmsEnv))
+		}
+	}
+	if len(kmsEnvs) > 1 {
+		logger.Fatal(errors.New("ambigious KMS configuration"), fmt.Sprintf("The environment contains %s", strings.Join(kmsEnvs, " as well as ")))
 	}
 
 	if env.IsSet(config.EnvKMSSecretKey) {
@@ -851,6 +896,7 @@ func handleCommonEnvVars() {
 			DefaultKeyID: defaultKeyID,
 			Certificate:  certificate,
 			RootCAs:      rootCAs,
+			Observer:     globalKMSStats.observe,
 		})
 		if err != nil {
 			logger.Fatal(err, "Unable to initialize a connection to KES as specified by the shell environment")
@@ -865,6 +911,52 @@ func handleCommonEnvVars() {
 		}
 		GlobalKMS = KMS
 	}
+	if env.IsSet(config.EnvKMSVaultEndpoint) {
+		rootCAs, err := certs.GetRootCAs(env.Get(config.EnvKMSVaultCAPath, globalCertsCADir.Get()))
+		if err != nil {
+			logger.Fatal(err, fmt.Sprintf("Unable to load X.509 root CAs for Vault from %q", env.Get(config.EnvKMSVaultCAPath, globalCertsCADir.Get())))
+		}
+		defaultKeyID := env.Get(config.EnvKMSVaultKeyName, "")
+		KMS, err := kms.NewVault(kms.VaultConfig{
+			Endpoint:     env.Get(config.EnvKMSVaultEndpoint, ""),
+			Mount:        env.Get(config.EnvKMSVaultMount, ""),
+			Namespace:    env.Get(config.EnvKMSVaultNamespace, ""),
+			Token:        env.Get(config.EnvKMSVaultToken, ""),
+			DefaultKeyID: defaultKeyID,
+			RootCAs:      rootCAs,
+			Observer:     globalKMSStats.observe,
+		})
+		if err != nil {
+			logger.Fatal(err, "Unable to initialize Vault as specified by the shell environment")
+		}
+
+		// As for KES, the default key is created unless it exists or
+		// MinIO is not allowed to create keys.
+		if err = KMS.CreateKey(defaultKeyID); err != nil && !errors.Is(err, kes.ErrKeyExists) && !errors.Is(err, kes.ErrNotAllowed) {
+			logger.Fatal(err, "Unable to initialize a connection to Vault as specified by the shell environment")
+		}
+		GlobalKMS = KMS
+	}
+	if env.IsSet(config.EnvKMSAWSRegion) {
+		KMS, err := kms.NewAWS(kms.AWSConfig{
+			Region:       env.Get(config.EnvKMSAWSRegion, ""),
+			Endpoint:     env.Get(config.EnvKMSAWSEndpoint, ""),
+			AccessKey:    env.Get(config.EnvKMSAWSAccessKey, env.Get("AWS_ACCESS_KEY_ID", "")),
+			SecretKey:    env.Get(config.EnvKMSAWSSecretKey, env.Get("AWS_SECRET_ACCESS_KEY", "")),
+			SessionToken: env.Get(config.EnvKMSAWSSessionToken, env.Get("AWS_SESSION_TOKEN", "")),
+			DefaultKeyID: env.Get(config.EnvKMSAWSKeyName, ""),
+			Observer:     globalKMSStats.observe,
+		})
+		if err != nil {
+			logger.Fatal(err, "Unable to initialize AWS KMS as specified by the shell environment")
+		}
+		// AWS KMS keys cannot be created by MinIO, check that
+		// the default key can be used instead.
+		if _, err = KMS.GenerateKey("", kms.Context{}); err != nil {
+			logger.Fatal(err, "Unable to generate a key with the default AWS KMS key as specified by the shell environment")
+		}
+		GlobalKMS = KMS
+	}
 }
 
 func getTLSConfig() (x509Certs []*x509.Certificate, manager *certs.Manager, secureConn bool, err error) {
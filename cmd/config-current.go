@@ -27,22 +27,28 @@ import (
 	"github.com/minio/madmin-go"
 	"github.com/minio/minio/internal/config"
 	"github.com/minio/minio/internal/config/api"
+	"github.com/minio/minio/internal/config/auditfilter"
 	"github.com/minio/minio/internal/config/cache"
 	"github.com/minio/minio/internal/config/callhome"
 	"github.com/minio/minio/internal/config/compress"
 	"github.com/minio/minio/internal/config/dns"
+	"github.com/minio/minio/internal/config/drive"
 	"github.com/minio/minio/internal/config/etcd"
+	"github.com/minio/minio/internal/config/eventdelivery"
 	"github.com/minio/minio/internal/config/heal"
 	xldap "github.com/minio/minio/internal/config/identity/ldap"
 	"github.com/minio/minio/internal/config/identity/openid"
 	idplugin "github.com/minio/minio/internal/config/identity/plugin"
 	xtls "github.com/minio/minio/internal/config/identity/tls"
+	"github.com/minio/minio/internal/config/metricsauth"
 	"github.com/minio/minio/internal/config/notify"
+	"github.com/minio/minio/internal/config/pack"
 	"github.com/minio/minio/internal/config/policy/opa"
 	polplugin "github.com/minio/minio/internal/config/policy/plugin"
 	"github.com/minio/minio/internal/config/scanner"
 	"github.com/minio/minio/internal/config/storageclass"
 	"github.com/minio/minio/internal/config/subnet"
+	"github.com/minio/minio/internal/config/usageexport"
 	"github.com/minio/minio/internal/crypto"
 	xhttp "github.com/minio/minio/internal/http"
 	"github.com/minio/minio/internal/kms"
@@ -68,10 +74,16 @@ func initHelp() {
 		config.LoggerWebhookSubSys:  logger.DefaultLoggerWebhookKVS,
 		config.AuditWebhookSubSys:   logger.DefaultAuditWebhookKVS,
 		config.AuditKafkaSubSys:     logger.DefaultAuditKafkaKVS,
+		config.AuditFilterSubSys:    auditfilter.DefaultKVS,
 		config.HealSubSys:           heal.DefaultKVS,
 		config.ScannerSubSys:        scanner.DefaultKVS,
 		config.SubnetSubSys:         subnet.DefaultKVS,
 		config.CallhomeSubSys:       callhome.DefaultKVS,
+		config.UsageExportSubSys:    usageexport.DefaultKVS,
+		config.EventDeliverySubSys:  eventdelivery.DefaultKVS,
+		config.PackSubSys:           pack.DefaultKVS,
+		config.DriveSubSys:          drive.DefaultKVS,
+		config.MetricsAuthSubSys:    metricsauth.DefaultKVS,
 	}
 	for k, v := range notify.DefaultNotificationKVS {
 		kvs[k] = v
@@ -147,6 +159,11 @@ func initHelp() {
 			Description:     "send audit logs to kafka endpoints",
 			MultipleTargets: true,
 		},
+		config.HelpKV{
+			Key:         config.AuditFilterSubSys,
+			Description: "redact and sample the audit logs",
+			Optional:    true,
+		},
 		config.HelpKV{
 			Key:             config.NotifyWebhookSubSys,
 			Description:     "publish bucket notifications to webhook endpoints",
@@ -177,6 +194,16 @@ func initHelp() {
 			Description:     "publish bucket notifications to NSQ endpoints",
 			MultipleTargets: true,
 		},
+		config.HelpKV{
+			Key:             config.NotifyPubSubSubSys,
+			Description:     "publish bucket notifications to Google Cloud Pub/Sub topics",
+			MultipleTargets: true,
+		},
+		config.HelpKV{
+			Key:             config.NotifyPulsarSubSys,
+			Description:     "publish bucket notifications to Pulsar topics",
+			MultipleTargets: true,
+		},
 		config.HelpKV{
 			Key:             config.NotifyMySQLSubSys,
 			Description:     "publish bucket notifications to MySQL databases",
@@ -209,6 +236,31 @@ func initHelp() {
 			Description: "enable callhome for the cluster",
 			Optional:    true,
 		},
+		config.HelpKV{
+			Key:         config.UsageExportSubSys,
+			Description: "periodically export the data usage breakdown to a bucket",
+			Optional:    true,
+		},
+		config.HelpKV{
+			Key:         config.EventDeliverySubSys,
+			Description: "retry and dead-letter the events notification targets failed to accept",
+			Optional:    true,
+		},
+		config.HelpKV{
+			Key:         config.PackSubSys,
+			Description: "pack small objects into containers",
+			Optional:    true,
+		},
+		config.HelpKV{
+			Key:         config.DriveSubSys,
+			Description: "tune O_DIRECT and readahead of NVMe and HDD drives",
+			Optional:    true,
+		},
+		config.HelpKV{
+			Key:         config.MetricsAuthSubSys,
+			Description: "allow static tokens and client certificates to scrape metrics",
+			Optional:    true,
+		},
 	}
 
 	if globalIsErasure {
@@ -240,6 +292,7 @@ func initHelp() {
 		config.LoggerWebhookSubSys:  logger.Help,
 		config.AuditWebhookSubSys:   logger.HelpWebhook,
 		config.AuditKafkaSubSys:     logger.HelpKafka,
+		config.AuditFilterSubSys:    auditfilter.Help,
 		config.NotifyAMQPSubSys:     notify.HelpAMQP,
 		config.NotifyKafkaSubSys:    notify.HelpKafka,
 		config.NotifyMQTTSubSys:     notify.HelpMQTT,
@@ -250,8 +303,15 @@ func initHelp() {
 		config.NotifyRedisSubSys:    notify.HelpRedis,
 		config.NotifyWebhookSubSys:  notify.HelpWebhook,
 		config.NotifyESSubSys:       notify.HelpES,
+		config.NotifyPubSubSubSys:   notify.HelpPubSub,
+		config.NotifyPulsarSubSys:   notify.HelpPulsar,
 		config.SubnetSubSys:         subnet.HelpSubnet,
 		config.CallhomeSubSys:       callhome.HelpCallhome,
+		config.UsageExportSubSys:    usageexport.Help,
+		config.EventDeliverySubSys:  eventdelivery.Help,
+		config.PackSubSys:           pack.Help,
+		config.DriveSubSys:          drive.Help,
+		config.MetricsAuthSubSys:    metricsauth.Help,
 	}
 
 	config.RegisterHelpSubSys(helpMap)
@@ -371,6 +431,36 @@ func validateSubSysConfig(s config.Config, subSys string, objAPI ObjectLayer) er
 		if _, err := callhome.LookupConfig(s[config.CallhomeSubSys][config.Default]); err != nil {
 			return err
 		}
+	case config.UsageExportSubSys:
+		if _, err := usageexport.LookupConfig(s[config.UsageExportSubSys][config.Default]); err != nil {
+			return err
+		}
+	case config.AuditFilterSubSys:
+		if _, err := auditfilter.LookupConfig(s[config.AuditFilterSubSys][config.Default]); err != nil {
+			return err
+		}
+	case config.EventDeliverySubSys:
+		if _, err := eventdelivery.LookupConfig(s[config.EventDeliverySubSys][config.Default]); err != nil {
+			return err
+		}
+	case config.PackSubSys:
+		packCfg, err := pack.LookupConfig(s[config.PackSubSys][config.Default])
+		if err != nil {
+			return err
+		}
+		if z, ok := objAPI.(*erasureServerPools); ok {
+			if err = z.checkPackConfig(GlobalContext, packCfg); err != nil {
+				return err
+			}
+		}
+	case config.DriveSubSys:
+		if _, err := drive.LookupConfig(s[config.DriveSubSys][config.Default]); err != nil {
+			return err
+		}
+	case config.MetricsAuthSubSys:
+		if _, err := lookupMetricsAuthConfig(s[config.MetricsAuthSubSys][config.Default]); err != nil {
+			return err
+		}
 	case config.PolicyOPASubSys:
 		// In case legacy OPA config is being set, we treat it as if the
 		// AuthZPlugin is being set.
@@ -656,6 +746,18 @@ func applyDynamicConfigForSubSys(ctx context.Context, objAPI ObjectLayer, s conf
 			return fmt.Errorf("Unable to apply heal config: %w", err)
 		}
 		globalHealConfig.Update(healCfg)
+	case config.DriveSubSys:
+		driveCfg, err := drive.LookupConfig(s[config.DriveSubSys][config.Default])
+		if err != nil {
+			return fmt.Errorf("Unable to apply drive config: %w", err)
+		}
+		globalDriveConfig.Update(driveCfg)
+	case config.MetricsAuthSubSys:
+		metricsAuthCfg, err := lookupMetricsAuthConfig(s[config.MetricsAuthSubSys][config.Default])
+		if err != nil {
+			return fmt.Errorf("Unable to apply metrics auth config: %w", err)
+		}
+		updateMetricsAuthParams(metricsAuthCfg)
 	case config.ScannerSubSys:
 		scannerCfg, err := scanner.LookupConfig(s[config.ScannerSubSys][config.Default])
 		if err != nil {
@@ -664,6 +766,7 @@ func applyDynamicConfigForSubSys(ctx context.Context, objAPI ObjectLayer, s conf
 		// update dynamic scanner values.
 		scannerCycle.Store(scannerCfg.Cycle)
 		logger.LogIf(ctx, scannerSleeper.Update(scannerCfg.Delay, scannerCfg.MaxWait))
+		globalScannerControl.Update(scannerCfg)
 	case config.LoggerWebhookSubSys:
 		loggerCfg, err := logger.LookupConfigForSubSys(s, config.LoggerWebhookSubSys)
 		if err != nil {
@@ -716,6 +819,13 @@ func applyDynamicConfigForSubSys(ctx context.Context, objAPI ObjectLayer, s conf
 		if err != nil {
 			logger.LogIf(ctx, fmt.Errorf("Unable to update audit kafka targets: %w", err))
 		}
+	case config.AuditFilterSubSys:
+		auditFilterCfg, err := auditfilter.LookupConfig(s[config.AuditFilterSubSys][config.Default])
+		if err != nil {
+			logger.LogIf(ctx, fmt.Errorf("Unable to load audit filter config: %w", err))
+		} else {
+			logger.SetAuditFilter(auditFilterCfg)
+		}
 	case config.StorageClassSubSys:
 		if globalIsErasure && objAPI != nil {
 			setDriveCounts := objAPI.SetDriveCounts()
@@ -740,6 +850,27 @@ func applyDynamicConfigForSubSys(ctx context.Context, objAPI ObjectLayer, s conf
 			globalCallhomeConfig = callhomeCfg
 			updateCallhomeParams(ctx, objAPI)
 		}
+	case config.UsageExportSubSys:
+		usageExportCfg, err := usageexport.LookupConfig(s[config.UsageExportSubSys][config.Default])
+		if err != nil {
+			logger.LogIf(ctx, fmt.Errorf("Unable to load usage export config: %w", err))
+		} else {
+			updateUsageExportParams(objAPI, usageExportCfg)
+		}
+	case config.EventDeliverySubSys:
+		deliveryCfg, err := eventdelivery.LookupConfig(s[config.EventDeliverySubSys][config.Default])
+		if err != nil {
+			logger.LogIf(ctx, fmt.Errorf("Unable to load event delivery config: %w", err))
+		} else {
+			updateEventDeliveryParams(deliveryCfg)
+		}
+	case config.PackSubSys:
+		packCfg, err := pack.LookupConfig(s[config.PackSubSys][config.Default])
+		if err != nil {
+			logger.LogIf(ctx, fmt.Errorf("Unable to load pack config: %w", err))
+		} else {
+			updatePackParams(objAPI, packCfg)
+		}
 	}
 	globalServerConfigMu.Lock()
 	defer globalServerConfigMu.Unlock()
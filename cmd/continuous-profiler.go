@@ -0,0 +1,290 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path"
+	"runtime"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/minio/madmin-go"
+	"github.com/minio/minio/internal/logger"
+	"github.com/minio/pkg/env"
+)
+
+// Environment variables configuring continuous profiling, profiles
+// are captured on a schedule and when a threshold is exceeded.
+const (
+	// EnvProfileContinuousInterval captures profiles at the given interval.
+	EnvProfileContinuousInterval = "MINIO_PROFILE_CONTINUOUS_INTERVAL"
+
+	// EnvProfileContinuousLatencyThreshold captures profiles when
+	// an S3 request takes longer than the given duration.
+	EnvProfileContinuousLatencyThreshold = "MINIO_PROFILE_CONTINUOUS_LATENCY_THRESHOLD"
+
+	// EnvProfileContinuousGoroutineThreshold captures profiles when
+	// the number of goroutines exceeds the given number.
+	EnvProfileContinuousGoroutineThreshold = "MINIO_PROFILE_CONTINUOUS_GOROUTINE_THRESHOLD"
+
+	// EnvProfileContinuousTypes are the comma separated profiler types captured.
+	EnvProfileContinuousTypes = "MINIO_PROFILE_CONTINUOUS_TYPES"
+
+	// EnvProfileContinuousDuration is how long every capture lasts.
+	EnvProfileContinuousDuration = "MINIO_PROFILE_CONTINUOUS_DURATION"
+
+	// EnvProfileContinuousRetention is how long captured profiles are kept.
+	EnvProfileContinuousRetention = "MINIO_PROFILE_CONTINUOUS_RETENTION"
+)
+
+const (
+	continuousProfilesPrefix = "profiles"
+
+	defaultContinuousProfileTypes     = "cpu,mem,goroutines"
+	defaultContinuousProfileDuration  = 10 * time.Second
+	defaultContinuousProfileRetention = 24 * time.Hour
+
+	minContinuousProfileInterval = time.Minute
+
+	// Threshold triggered captures are made at most once per cooldown.
+	continuousProfileCooldown = 5 * time.Minute
+
+	// Interval between two checks of the goroutine threshold.
+	continuousProfileCheckInterval = 10 * time.Second
+)
+
+// Triggers of a continuous profile capture.
+const (
+	continuousProfileSchedule   = "schedule"
+	continuousProfileLatency    = "latency"
+	continuousProfileGoroutines = "goroutines"
+)
+
+// continuousProfileTrigger receives threshold triggered captures,
+// triggers are dropped while a capture is pending.
+var continuousProfileTrigger = make(chan string, 1)
+
+// triggerContinuousProfile requests a capture without blocking.
+func triggerContinuousProfile(trigger string) {
+	select {
+	case continuousProfileTrigger <- trigger:
+	default:
+	}
+}
+
+// continuousProfileLatencyThreshold returns the threshold configured
+// by EnvProfileContinuousLatencyThreshold, zero if unset or invalid.
+func continuousProfileLatencyThreshold() time.Duration {
+	threshold, err := time.ParseDuration(env.Get(EnvProfileContinuousLatencyThreshold, ""))
+	if err != nil || threshold < 0 {
+		return 0
+	}
+	return threshold
+}
+
+// continuousProfiler captures profiles of this node and
+// stores them in the backend for post-incident analysis.
+type continuousProfiler struct {
+	objAPI ObjectLayer
+
+	types     []string
+	interval  time.Duration
+	duration  time.Duration
+	retention time.Duration
+
+	goroutineThreshold int
+}
+
+// continuousProfilesNodePrefix returns the prefix of the profiles
+// of node, ':' is replaced since it is not a valid path character
+// on all platforms.
+func continuousProfilesNodePrefix(node string) string {
+	return path.Join(continuousProfilesPrefix, strings.ReplaceAll(node, ":", "_")) + SlashSeparator
+}
+
+// initContinuousProfiler starts capturing profiles if configured.
+func initContinuousProfiler(ctx context.Context, objAPI ObjectLayer) {
+	p := &continuousProfiler{objAPI: objAPI}
+
+	var err error
+	if v := env.Get(EnvProfileContinuousInterval, ""); v != "" {
+		if p.interval, err = time.ParseDuration(v); err != nil {
+			logger.Fatal(err, "Invalid %s value in environment variable", EnvProfileContinuousInterval)
+		}
+		if p.interval < minContinuousProfileInterval {
+			logger.Fatal(fmt.Errorf("interval must be at least %s", minContinuousProfileInterval),
+				"Invalid %s value in environment variable", EnvProfileContinuousInterval)
+		}
+	}
+	if v := env.Get(EnvProfileContinuousLatencyThreshold, ""); v != "" {
+		if threshold, err := time.ParseDuration(v); err != nil || threshold <= 0 {
+			logger.Fatal(fmt.Errorf("invalid latency threshold '%s'", v),
+				"Invalid %s value in environment variable", EnvProfileContinuousLatencyThreshold)
+		}
+	}
+	if v := env.Get(EnvProfileContinuousGoroutineThreshold, ""); v != "" {
+		if p.goroutineThreshold, err = strconv.Atoi(v); err != nil || p.goroutineThreshold <= 0 {
+			logger.Fatal(fmt.Errorf("invalid goroutine threshold '%s'", v),
+				"Invalid %s value in environment variable", EnvProfileContinuousGoroutineThreshold)
+		}
+	}
+	if p.interval == 0 && p.goroutineThreshold == 0 && continuousProfileLatencyThreshold() == 0 {
+		return
+	}
+
+	for _, typ := range strings.Split(env.Get(EnvProfileContinuousTypes, defaultContinuousProfileTypes), ",") {
+		switch madmin.ProfilerType(typ) {
+		case madmin.ProfilerCPU, madmin.ProfilerCPUIO, madmin.ProfilerMEM, madmin.ProfilerBlock,
+			madmin.ProfilerMutex, madmin.ProfilerThreads, madmin.ProfilerGoroutines:
+			p.types = append(p.types, typ)
+		default:
+			logger.Fatal(fmt.Errorf("unsupported profiler type '%s'", typ),
+				"Invalid %s value in environment variable", EnvProfileContinuousTypes)
+		}
+	}
+	if p.duration, err = time.ParseDuration(env.Get(EnvProfileContinuousDuration, defaultContinuousProfileDuration.String())); err != nil || p.duration <= 0 {
+		logger.Fatal(fmt.Errorf("invalid duration"), "Invalid %s value in environment variable", EnvProfileContinuousDuration)
+	}
+	if p.retention, err = time.ParseDuration(env.Get(EnvProfileContinuousRetention, defaultContinuousProfileRetention.String())); err != nil || p.retention <= 0 {
+		logger.Fatal(fmt.Errorf("invalid retention"), "Invalid %s value in environment variable", EnvProfileContinuousRetention)
+	}
+
+	go p.run(ctx)
+}
+
+// run captures profiles on schedule and when triggered by a
+// threshold until ctx is canceled.
+func (p *continuousProfiler) run(ctx context.Context) {
+	var scheduleCh <-chan time.Time
+	if p.interval > 0 {
+		ticker := time.NewTicker(p.interval)
+		defer ticker.Stop()
+		scheduleCh = ticker.C
+	}
+	var checkCh <-chan time.Time
+	if p.goroutineThreshold > 0 {
+		ticker := time.NewTicker(continuousProfileCheckInterval)
+		defer ticker.Stop()
+		checkCh = ticker.C
+	}
+
+	var lastTriggered time.Time
+	for {
+		var trigger string
+		select {
+		case <-ctx.Done():
+			return
+		case <-scheduleCh:
+			trigger = continuousProfileSchedule
+		case trigger = <-continuousProfileTrigger:
+		case <-checkCh:
+			if runtime.NumGoroutine() <= p.goroutineThreshold {
+				continue
+			}
+			trigger = continuousProfileGoroutines
+		}
+		if trigger != continuousProfileSchedule {
+			if time.Since(lastTriggered) < continuousProfileCooldown {
+				continue
+			}
+			lastTriggered = time.Now()
+		}
+
+		if err := p.capture(ctx, trigger); err != nil {
+			logger.LogOnceIf(ctx, fmt.Errorf("Unable to capture continuous profiles: %w", err), "continuous-profiler")
+		}
+		if err := p.expire(ctx, UTCNow().Add(-p.retention)); err != nil {
+			logger.LogOnceIf(ctx, fmt.Errorf("Unable to remove expired profiles: %w", err), "continuous-profiler-expire")
+		}
+	}
+}
+
+// capture profiles the node for the configured duration and saves
+// the profiles below a prefix named after the time and the trigger.
+func (p *continuousProfiler) capture(ctx context.Context, trigger string) error {
+	profilers := make(map[string]minioProfiler, len(p.types))
+	for _, typ := range p.types {
+		// Fails while the same profiler is started
+		// through the admin API, skip it this time.
+		prof, err := startProfiler(typ)
+		if err != nil {
+			continue
+		}
+		profilers[typ] = prof
+	}
+	if len(profilers) == 0 {
+		return errors.New("no profiler could be started")
+	}
+
+	timer := time.NewTimer(p.duration)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		for _, prof := range profilers {
+			prof.Stop()
+		}
+		return ctx.Err()
+	case <-timer.C:
+	}
+
+	prefix := path.Join(continuousProfilesNodePrefix(globalLocalNodeName), UTCNow().Format("20060102T150405Z")+"-"+trigger)
+	for typ, prof := range profilers {
+		data, err := prof.Stop()
+		if err != nil {
+			return err
+		}
+		if err = saveConfig(ctx, p.objAPI, path.Join(prefix, typ+"."+prof.Extension()), data); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// expire removes the profiles of this node captured before t.
+func (p *continuousProfiler) expire(ctx context.Context, t time.Time) error {
+	return listContinuousProfiles(ctx, p.objAPI, continuousProfilesNodePrefix(globalLocalNodeName), func(obj ObjectInfo) error {
+		if obj.ModTime.Before(t) {
+			return deleteConfig(ctx, p.objAPI, obj.Name)
+		}
+		return nil
+	})
+}
+
+// listContinuousProfiles calls fn with every profile below prefix.
+func listContinuousProfiles(ctx context.Context, objAPI ObjectLayer, prefix string, fn func(ObjectInfo) error) error {
+	marker := ""
+	for {
+		res, err := objAPI.ListObjects(ctx, minioMetaBucket, prefix, marker, "", maxObjectList)
+		if err != nil {
+			return err
+		}
+		for _, obj := range res.Objects {
+			if err = fn(obj); err != nil {
+				return err
+			}
+		}
+		if !res.IsTruncated {
+			return nil
+		}
+		marker = res.NextMarker
+	}
+}
@@ -0,0 +1,95 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestContinuousProfiler(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	objLayer, fsDirs, err := prepareErasure16(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer objLayer.Shutdown(context.Background())
+	defer removeRoots(fsDirs)
+
+	p := &continuousProfiler{
+		objAPI:   objLayer,
+		types:    []string{"mem", "goroutines"},
+		duration: 10 * time.Millisecond,
+	}
+	if err = p.capture(ctx, continuousProfileLatency); err != nil {
+		t.Fatal(err)
+	}
+
+	var names []string
+	list := func() {
+		names = nil
+		err := listContinuousProfiles(ctx, objLayer, continuousProfilesPrefix+SlashSeparator, func(obj ObjectInfo) error {
+			names = append(names, obj.Name)
+			return nil
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	list()
+	if len(names) != 2 {
+		t.Fatalf("expected 2 profiles, got %v", names)
+	}
+	for _, name := range names {
+		if !strings.HasPrefix(name, continuousProfilesNodePrefix(globalLocalNodeName)) || !strings.Contains(name, "-latency/") {
+			t.Fatalf("unexpected profile %s", name)
+		}
+	}
+
+	// Profiles captured after the retention limit are kept.
+	if err = p.expire(ctx, UTCNow().Add(-time.Hour)); err != nil {
+		t.Fatal(err)
+	}
+	if list(); len(names) != 2 {
+		t.Fatalf("expected 2 profiles, got %v", names)
+	}
+	if err = p.expire(ctx, UTCNow().Add(time.Minute)); err != nil {
+		t.Fatal(err)
+	}
+	if list(); len(names) != 0 {
+		t.Fatalf("expected profiles to be expired, got %v", names)
+	}
+}
+
+func TestTriggerContinuousProfile(t *testing.T) {
+	// Triggers are not queued while a capture is pending.
+	triggerContinuousProfile(continuousProfileLatency)
+	triggerContinuousProfile(continuousProfileGoroutines)
+	if trigger := <-continuousProfileTrigger; trigger != continuousProfileLatency {
+		t.Fatalf("expected the first trigger, got %s", trigger)
+	}
+	select {
+	case trigger := <-continuousProfileTrigger:
+		t.Fatalf("unexpected trigger %s", trigger)
+	default:
+	}
+}
@@ -0,0 +1,169 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/minio/minio/internal/config/scanner"
+	"golang.org/x/time/rate"
+)
+
+// scannerControl throttles and schedules the data scanner as per the
+// scanner configuration, which can be updated at runtime.
+type scannerControl struct {
+	mu      sync.RWMutex
+	cfg     scanner.Config
+	limiter *rate.Limiter
+}
+
+var globalScannerControl = &scannerControl{}
+
+// Update applies a new scanner configuration.
+func (c *scannerControl) Update(cfg scanner.Config) {
+	var limiter *rate.Limiter
+	if cfg.IOLimit > 0 {
+		limiter = rate.NewLimiter(rate.Limit(cfg.IOLimit), cfg.IOLimit)
+	}
+
+	c.mu.Lock()
+	c.cfg = cfg
+	c.limiter = limiter
+	c.mu.Unlock()
+}
+
+// Config returns the scanner configuration in effect.
+func (c *scannerControl) Config() scanner.Config {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.cfg
+}
+
+// poolDisabled returns true if the pool at index idx must not be scanned.
+func (c *scannerControl) poolDisabled(idx int) bool {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.cfg.PoolDisabled(idx)
+}
+
+// paused returns true if the scanner is outside of its time windows.
+func (c *scannerControl) paused() bool {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return !c.cfg.Allowed(time.Now())
+}
+
+// waitAllowed blocks until the scanner is within its time windows. The
+// configuration is checked again every minute, in case it is updated.
+func (c *scannerControl) waitAllowed(ctx context.Context) {
+	for {
+		c.mu.RLock()
+		wait := c.cfg.NextAllowed(time.Now())
+		c.mu.RUnlock()
+		if wait == 0 {
+			return
+		}
+		if wait > time.Minute {
+			wait = time.Minute
+		}
+		t := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			t.Stop()
+			return
+		case <-t.C:
+		}
+	}
+}
+
+// waitIO blocks until an object can be scanned within the IO limit.
+func (c *scannerControl) waitIO(ctx context.Context) {
+	c.mu.RLock()
+	limiter := c.limiter
+	c.mu.RUnlock()
+	if limiter != nil {
+		limiter.Wait(ctx)
+	}
+}
+
+// scannerStatus is the scanner status of a node.
+type scannerStatus struct {
+	Node                     string    `json:"node"`
+	Paused                   bool      `json:"paused"`
+	Cycle                    uint64    `json:"cycle"`
+	CycleStarted             time.Time `json:"cycleStarted,omitempty"`
+	CycleObjectsScanned      uint64    `json:"cycleObjectsScanned"`
+	CycleBucketScansFinished uint64    `json:"cycleBucketScansFinished"`
+	ActiveBucketScans        uint64    `json:"activeBucketScans"`
+	Error                    string    `json:"error,omitempty"`
+}
+
+// scannerStatusInfo is the scanner configuration and the scanner status
+// of all the nodes.
+type scannerStatusInfo struct {
+	Delay         float64         `json:"delay"`
+	MaxWait       string          `json:"maxWait"`
+	Cycle         string          `json:"cycle"`
+	IOLimit       int             `json:"ioLimit"`
+	Windows       []string        `json:"windows"`
+	DisabledPools []int           `json:"disabledPools"`
+	Nodes         []scannerStatus `json:"nodes"`
+}
+
+// getLocalScannerStatus returns the scanner status of this node.
+func getLocalScannerStatus() scannerStatus {
+	st := scannerStatus{
+		Node:                     globalLocalNodeName,
+		Paused:                   globalScannerControl.paused(),
+		Cycle:                    atomic.LoadUint64(&globalScannerStats.cycle),
+		CycleObjectsScanned:      atomic.LoadUint64(&globalScannerStats.cycleObjects),
+		CycleBucketScansFinished: atomic.LoadUint64(&globalScannerStats.cycleBucketsFinished),
+	}
+	if started := atomic.LoadInt64(&globalScannerStats.cycleStarted); started > 0 {
+		st.CycleStarted = time.Unix(0, started).UTC()
+	}
+	if bs, bf := atomic.LoadUint64(&globalScannerStats.bucketsStarted), atomic.LoadUint64(&globalScannerStats.bucketsFinished); bs > bf {
+		st.ActiveBucketScans = bs - bf
+	}
+	return st
+}
+
+// getScannerStatus returns the scanner configuration in effect and the
+// scanner status of all the nodes.
+func getScannerStatus(ctx context.Context) scannerStatusInfo {
+	cfg := globalScannerControl.Config()
+	info := scannerStatusInfo{
+		Delay:         cfg.Delay,
+		MaxWait:       cfg.MaxWait.String(),
+		Cycle:         cfg.Cycle.String(),
+		IOLimit:       cfg.IOLimit,
+		Windows:       make([]string, 0, len(cfg.Windows)),
+		DisabledPools: cfg.DisabledPools,
+	}
+	for _, w := range cfg.Windows {
+		info.Windows = append(info.Windows, w.String())
+	}
+	if info.DisabledPools == nil {
+		info.DisabledPools = []int{}
+	}
+	info.Nodes = append([]scannerStatus{getLocalScannerStatus()}, globalNotificationSys.GetScannerStatus(ctx)...)
+	return info
+}
@@ -0,0 +1,81 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/minio/minio/internal/config/scanner"
+)
+
+func TestScannerStatsCycle(t *testing.T) {
+	var s scannerStats
+	s.startCycle(3)
+	s.objectScanned()
+	s.objectScanned()
+	if s.cycle != 3 || s.cycleObjects != 2 || s.accTotalObjects != 2 {
+		t.Fatalf("unexpected stats after 2 objects: %+v", s)
+	}
+
+	// An older or the same cycle keeps the progress.
+	s.startCycle(2)
+	s.startCycle(3)
+	if s.cycle != 3 || s.cycleObjects != 2 {
+		t.Fatalf("unexpected stats after an older cycle: %+v", s)
+	}
+
+	s.startCycle(4)
+	if s.cycle != 4 || s.cycleObjects != 0 || s.accTotalObjects != 2 || s.cycleStarted == 0 {
+		t.Fatalf("unexpected stats after a new cycle: %+v", s)
+	}
+}
+
+func TestScannerControl(t *testing.T) {
+	c := &scannerControl{}
+	if c.paused() || c.poolDisabled(0) {
+		t.Fatal("expected the scanner to run on all pools by default")
+	}
+
+	// A window which started an hour ago and ends in an hour.
+	now := time.Now().UTC()
+	start := now.Add(-time.Hour)
+	end := now.Add(time.Hour)
+	open := scanner.Window{
+		Start: time.Duration(start.Hour())*time.Hour + time.Duration(start.Minute())*time.Minute,
+		End:   time.Duration(end.Hour())*time.Hour + time.Duration(end.Minute())*time.Minute,
+	}
+	c.Update(scanner.Config{Windows: []scanner.Window{open}, DisabledPools: []int{1}})
+	if c.paused() || !c.poolDisabled(1) {
+		t.Fatal("expected the scanner to run on pool 0 only")
+	}
+	c.waitAllowed(context.Background())
+
+	closed := scanner.Window{Start: open.End, End: open.Start}
+	c.Update(scanner.Config{Windows: []scanner.Window{closed}})
+	if !c.paused() {
+		t.Fatal("expected the scanner to be paused outside of its window")
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	c.waitAllowed(ctx)
+	if ctx.Err() == nil {
+		t.Fatal("expected the scanner to wait for its window")
+	}
+}
@@ -256,12 +256,42 @@ type scannerStats struct {
 	bucketsFinished  uint64
 	ilmChecks        uint64
 
+	// cycle is the latest scanner cycle seen on this node, the cycle
+	// fields below are reset when it changes.
+	cycle                uint64
+	cycleStarted         int64
+	cycleObjects         uint64
+	cycleBucketsFinished uint64
+
 	// actions records actions performed.
 	actions [lifecycle.ActionCount]uint64
 }
 
 var globalScannerStats scannerStats
 
+// startCycle resets the progress of the current cycle when a newer
+// cycle starts.
+func (s *scannerStats) startCycle(cycle uint32) {
+	for {
+		current := atomic.LoadUint64(&s.cycle)
+		if uint64(cycle) <= current {
+			return
+		}
+		if atomic.CompareAndSwapUint64(&s.cycle, current, uint64(cycle)) {
+			atomic.StoreInt64(&s.cycleStarted, time.Now().UnixNano())
+			atomic.StoreUint64(&s.cycleObjects, 0)
+			atomic.StoreUint64(&s.cycleBucketsFinished, 0)
+			return
+		}
+	}
+}
+
+// objectScanned records an object scanned.
+func (s *scannerStats) objectScanned() {
+	atomic.AddUint64(&s.accTotalObjects, 1)
+	atomic.AddUint64(&s.cycleObjects, 1)
+}
+
 // Cache structure and compaction:
 //
 // A cache structure will be kept with a tree of usages.
@@ -309,9 +339,11 @@ func scanDataFolder(ctx context.Context, poolIdx, setIdx int, basePath string, c
 
 	logPrefix := color.Green("data-usage: ")
 	logSuffix := color.Blue("- %v + %v", basePath, cache.Info.Name)
+	globalScannerStats.startCycle(cache.Info.NextCycle)
 	atomic.AddUint64(&globalScannerStats.bucketsStarted, 1)
 	defer func() {
 		atomic.AddUint64(&globalScannerStats.bucketsFinished, 1)
+		atomic.AddUint64(&globalScannerStats.cycleBucketsFinished, 1)
 	}()
 	if intDataUpdateTracker.debug {
 		defer func() {
@@ -473,6 +505,7 @@ func (f *folderScanner) scanFolder(ctx context.Context, folder cachedFolder, int
 				folder.objectHealProbDiv = f.healFolderInclude
 			}
 		}
+		globalScannerControl.waitAllowed(ctx)
 		scannerSleeper.Sleep(ctx, dataScannerSleepPerFolder)
 
 		var existingFolders, newFolders []cachedFolder
@@ -524,7 +557,8 @@ func (f *folderScanner) scanFolder(ctx context.Context, folder cachedFolder, int
 				return nil
 			}
 
-			// Dynamic time delay.
+			// Stay within the IO limit, then dynamic time delay.
+			globalScannerControl.waitIO(ctx)
 			wait := scannerSleeper.Timer(ctx)
 
 			// Get file size, ignore errors.
@@ -889,6 +923,7 @@ type scannerItem struct {
 type sizeSummary struct {
 	totalSize       int64
 	versions        uint64
+	deleteMarkers   uint64
 	replicatedSize  int64
 	pendingSize     int64
 	failedSize      int64
@@ -897,6 +932,7 @@ type sizeSummary struct {
 	failedCount     uint64
 	replTargetStats map[string]replTargetSizeSummary
 	tiers           map[string]tierStats
+	encryption      map[string]encryptionStats
 }
 
 // replTargetSizeSummary holds summary of replication stats by target
@@ -680,4 +680,7 @@ func NSUpdated(bucket, prefix string) {
 	if intDataUpdateTracker != nil {
 		intDataUpdateTracker.markDirty(bucket, prefix)
 	}
+	if globalListCacheReuse {
+		globalMetacacheInvalidator.add(bucket, prefix)
+	}
 }
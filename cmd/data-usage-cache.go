@@ -52,9 +52,11 @@ type dataUsageEntry struct {
 	Size             int64                `msg:"sz"`
 	Objects          uint64               `msg:"os"`
 	Versions         uint64               `msg:"vs"` // Versions that are not delete markers.
+	DeleteMarkers    uint64               `msg:"dms"`
 	ObjSizes         sizeHistogram        `msg:"szs"`
 	ReplicationStats *replicationAllStats `msg:"rs,omitempty"`
 	AllTierStats     *allTierStats        `msg:"ats,omitempty"`
+	EncryptionStats  *allEncryptionStats  `msg:"es,omitempty"`
 	Compacted        bool                 `msg:"c"`
 }
 
@@ -112,6 +114,37 @@ func (ts tierStats) add(u tierStats) tierStats {
 	return ts
 }
 
+// allEncryptionStats is a collection of per encryption type stats,
+// see encryptionTypes.
+type allEncryptionStats struct {
+	Types map[string]encryptionStats `msg:"ts"`
+}
+
+func newAllEncryptionStats() *allEncryptionStats {
+	return &allEncryptionStats{
+		Types: make(map[string]encryptionStats),
+	}
+}
+
+func (aes *allEncryptionStats) merge(other *allEncryptionStats) {
+	for typ, st := range other.Types {
+		aes.Types[typ] = aes.Types[typ].add(st)
+	}
+}
+
+// encryptionStats holds the size and number of versions
+// stored with an encryption type.
+type encryptionStats struct {
+	Size     uint64 `msg:"sz"`
+	Versions uint64 `msg:"vs"`
+}
+
+func (es encryptionStats) add(u encryptionStats) encryptionStats {
+	es.Size += u.Size
+	es.Versions += u.Versions
+	return es
+}
+
 //msgp:tuple replicationStatsV1
 type replicationStatsV1 struct {
 	PendingSize          uint64
@@ -290,6 +323,7 @@ type dataUsageCacheInfo struct {
 func (e *dataUsageEntry) addSizes(summary sizeSummary) {
 	e.Size += summary.totalSize
 	e.Versions += summary.versions
+	e.DeleteMarkers += summary.deleteMarkers
 	e.ObjSizes.add(summary.totalSize)
 
 	if e.ReplicationStats == nil {
@@ -321,12 +355,21 @@ func (e *dataUsageEntry) addSizes(summary sizeSummary) {
 		}
 		e.AllTierStats.addSizes(summary)
 	}
+	if summary.encryption != nil {
+		if e.EncryptionStats == nil {
+			e.EncryptionStats = newAllEncryptionStats()
+		}
+		for typ, st := range summary.encryption {
+			e.EncryptionStats.Types[typ] = e.EncryptionStats.Types[typ].add(st)
+		}
+	}
 }
 
 // merge other data usage entry into this, excluding children.
 func (e *dataUsageEntry) merge(other dataUsageEntry) {
 	e.Objects += other.Objects
 	e.Versions += other.Versions
+	e.DeleteMarkers += other.DeleteMarkers
 	e.Size += other.Size
 	if other.ReplicationStats != nil {
 		if e.ReplicationStats == nil {
@@ -357,6 +400,13 @@ func (e *dataUsageEntry) merge(other dataUsageEntry) {
 		}
 		e.AllTierStats.merge(other.AllTierStats)
 	}
+
+	if other.EncryptionStats != nil {
+		if e.EncryptionStats == nil {
+			e.EncryptionStats = newAllEncryptionStats()
+		}
+		e.EncryptionStats.merge(other.EncryptionStats)
+	}
 }
 
 // mod returns true if the hash mod cycles == cycle.
@@ -419,6 +469,11 @@ func (e dataUsageEntry) clone() dataUsageEntry {
 		ats.merge(e.AllTierStats)
 		e.AllTierStats = ats
 	}
+	if e.EncryptionStats != nil {
+		es := newAllEncryptionStats()
+		es.merge(e.EncryptionStats)
+		e.EncryptionStats = es
+	}
 	return e
 }
 
@@ -724,6 +779,41 @@ func (d *dataUsageCache) flattenChildrens(root dataUsageEntry) (m map[string]dat
 	return m
 }
 
+// prefixUsage returns the usage of bucket broken down by prefix, down to
+// depth levels under the bucket. Deeper prefixes are accounted in their
+// parent at depth, objects above depth in their own prefix. Prefixes are
+// relative to the bucket, the bucket itself is the empty prefix.
+func (d *dataUsageCache) prefixUsage(bucket string, depth int) map[string]dataUsageEntry {
+	m := make(map[string]dataUsageEntry)
+	root := d.find(bucket)
+	if root == nil {
+		return m
+	}
+	var add func(prefix string, e dataUsageEntry, level int)
+	add = func(prefix string, e dataUsageEntry, level int) {
+		if level >= depth || len(e.Children) == 0 {
+			e = d.flatten(e)
+		} else {
+			for id := range e.Children {
+				child, ok := d.Cache[id]
+				if !ok {
+					continue
+				}
+				add(decodeDirObject(strings.TrimPrefix(id, bucket+slashSeparator))+slashSeparator, child, level+1)
+			}
+			e.Children = nil
+			if e.Objects == 0 && e.Versions == 0 && e.DeleteMarkers == 0 {
+				return
+			}
+		}
+		u := m[prefix]
+		u.merge(e)
+		m[prefix] = u
+	}
+	add("", *root, 0)
+	return m
+}
+
 // flatten all children of the root into the root element and return it.
 func (d *dataUsageCache) flatten(root dataUsageEntry) dataUsageEntry {
 	for id := range root.Children {
@@ -806,6 +896,15 @@ func (d *dataUsageCache) bucketsUsageInfo(buckets []BucketInfo) map[string]Bucke
 				}
 			}
 		}
+		if flat.EncryptionStats != nil {
+			bui.EncryptionInfo = make(map[string]BucketEncryptionUsageInfo, len(flat.EncryptionStats.Types))
+			for typ, stat := range flat.EncryptionStats.Types {
+				bui.EncryptionInfo[typ] = BucketEncryptionUsageInfo{
+					Size:          stat.Size,
+					VersionsCount: stat.Versions,
+				}
+			}
+		}
 		dst[bucket.Name] = bui
 	}
 	return dst
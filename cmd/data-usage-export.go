@@ -0,0 +1,295 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/rand"
+	"path"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/klauspost/compress/gzip"
+	"github.com/minio/minio/internal/config/usageexport"
+	"github.com/minio/minio/internal/logger"
+)
+
+const (
+	// usageExportCycle is the time between two checks for a due export.
+	usageExportCycle = 10 * time.Minute
+
+	// usageExportStatePath is the persisted state of usage exports.
+	usageExportStatePath = bucketMetaPrefix + SlashSeparator + ".usage-export.json"
+)
+
+var (
+	usageExportMu                sync.RWMutex
+	usageExportCfg               usageexport.Config
+	usageExportOnce              sync.Once
+	usageExportLeaderLockTimeout = newDynamicTimeout(30*time.Second, 10*time.Second)
+)
+
+// usageExportConfig returns the usage export configuration in effect.
+func usageExportConfig() usageexport.Config {
+	usageExportMu.RLock()
+	defer usageExportMu.RUnlock()
+	return usageExportCfg
+}
+
+func updateUsageExportParams(objAPI ObjectLayer, cfg usageexport.Config) {
+	usageExportMu.Lock()
+	usageExportCfg = cfg
+	usageExportMu.Unlock()
+
+	// Start exporting the first time exports are enabled,
+	// exports disabled later are skipped until enabled again.
+	if cfg.Enable && objAPI != nil {
+		usageExportOnce.Do(func() {
+			initUsageExport(GlobalContext, objAPI)
+		})
+	}
+}
+
+// initUsageExport starts exporting the data usage in the background.
+func initUsageExport(ctx context.Context, objAPI ObjectLayer) {
+	if _, ok := objAPI.(*erasureServerPools); !ok {
+		return
+	}
+	go func() {
+		r := rand.New(rand.NewSource(time.Now().UnixNano()))
+		for {
+			runUsageExport(ctx, objAPI)
+			duration := time.Duration(r.Float64() * float64(usageExportCycle))
+			if duration < time.Second {
+				// Make sure to sleep atleast a second to avoid high CPU ticks.
+				duration = time.Second
+			}
+			time.Sleep(duration)
+		}
+	}()
+}
+
+func runUsageExport(pctx context.Context, objAPI ObjectLayer) {
+	// Make sure only 1 node exports the data usage of the cluster.
+	locker := objAPI.NewNSLock(minioMetaBucket, "usage-export/runUsageExport.lock")
+	lkctx, err := locker.GetLock(pctx, usageExportLeaderLockTimeout)
+	if err != nil {
+		return
+	}
+	ctx := lkctx.Context()
+	defer lkctx.Cancel()
+	// No unlock for "leader" lock.
+
+	exportTimer := time.NewTimer(time.Minute)
+	defer exportTimer.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-exportTimer.C:
+			runUsageExportCycle(ctx, objAPI, UTCNow())
+			exportTimer.Reset(usageExportCycle)
+		}
+	}
+}
+
+// usageExportState is the persisted state of usage exports.
+type usageExportState struct {
+	LastExport time.Time `json:"lastExport"`
+}
+
+// runUsageExportCycle exports the data usage if an export is due at now.
+func runUsageExportCycle(ctx context.Context, objAPI ObjectLayer, now time.Time) {
+	cfg := usageExportConfig()
+	if !cfg.Enable {
+		return
+	}
+	var state usageExportState
+	data, err := readConfig(ctx, objAPI, usageExportStatePath)
+	if err == nil {
+		err = json.Unmarshal(data, &state)
+	}
+	if err != nil && !errors.Is(err, errConfigNotFound) {
+		logger.LogIf(ctx, err)
+		return
+	}
+	if now.Sub(state.LastExport) < cfg.Frequency {
+		return
+	}
+	if err = exportDataUsage(ctx, objAPI, cfg, now); err != nil {
+		logger.LogIf(ctx, fmt.Errorf("Unable to export the data usage to bucket %s: %w", cfg.Bucket, err))
+		return
+	}
+	state.LastExport = now
+	if data, err = json.Marshal(state); err == nil {
+		err = saveConfig(ctx, objAPI, usageExportStatePath, data)
+	}
+	logger.LogIf(ctx, err)
+}
+
+// usageExportRow is the usage of a prefix of a bucket.
+type usageExportRow struct {
+	bucket string
+	prefix string
+	usage  dataUsageEntry
+}
+
+// loadUsageExportRows returns the usage of all buckets broken down by
+// prefix down to depth, from the latest usage scanned on all erasure sets.
+func loadUsageExportRows(ctx context.Context, z *erasureServerPools, buckets []BucketInfo, depth int) []usageExportRow {
+	var rows []usageExportRow
+	for _, bucket := range buckets {
+		prefixes := make(map[string]dataUsageEntry)
+		for _, pool := range z.serverPools {
+			for _, er := range pool.sets {
+				var cache dataUsageCache
+				if err := cache.load(ctx, er, bucket.Name+slashSeparator+dataUsageCacheName); err != nil {
+					continue
+				}
+				for prefix, e := range cache.prefixUsage(bucket.Name, depth) {
+					u := prefixes[prefix]
+					u.merge(e)
+					prefixes[prefix] = u
+				}
+			}
+		}
+		if len(prefixes) == 0 {
+			// Always list buckets, even empty ones.
+			prefixes[""] = dataUsageEntry{}
+		}
+		names := make([]string, 0, len(prefixes))
+		for prefix := range prefixes {
+			names = append(names, prefix)
+		}
+		sort.Strings(names)
+		for _, prefix := range names {
+			rows = append(rows, usageExportRow{bucket: bucket.Name, prefix: prefix, usage: prefixes[prefix]})
+		}
+	}
+	return rows
+}
+
+// usageExportColumns returns the column names of an export, tier
+// columns are added for the tiers with usage.
+func usageExportColumns(rows []usageExportRow) (columns, tiers []string) {
+	columns = []string{"bucket", "prefix", "objects", "versions", "delete_markers", "size"}
+	seen := make(map[string]struct{})
+	for _, row := range rows {
+		if row.usage.AllTierStats == nil {
+			continue
+		}
+		for tier := range row.usage.AllTierStats.Tiers {
+			if _, ok := seen[tier]; !ok {
+				seen[tier] = struct{}{}
+				tiers = append(tiers, tier)
+			}
+		}
+	}
+	sort.Strings(tiers)
+	for _, tier := range tiers {
+		name := "tier_" + strings.ToLower(tier)
+		columns = append(columns, name+"_objects", name+"_versions", name+"_size")
+	}
+	return columns, tiers
+}
+
+// values returns the values of the columns of an export for row.
+func (row usageExportRow) values(tiers []string) []interface{} {
+	u := row.usage
+	values := []interface{}{
+		row.bucket, row.prefix,
+		int64(u.Objects), int64(u.Versions), int64(u.DeleteMarkers), u.Size,
+	}
+	for _, tier := range tiers {
+		var st tierStats
+		if u.AllTierStats != nil {
+			st = u.AllTierStats.Tiers[tier]
+		}
+		values = append(values, int64(st.NumObjects), int64(st.NumVersions), int64(st.TotalSize))
+	}
+	return values
+}
+
+// exportDataUsage writes the data usage of all buckets, as last scanned,
+// to the bucket configured in cfg under
+//
+//	<prefix>/dt=<YYYY-MM-DD-HH-MM>/usage.csv.gz or usage.parquet
+func exportDataUsage(ctx context.Context, objAPI ObjectLayer, cfg usageexport.Config, now time.Time) error {
+	z, ok := objAPI.(*erasureServerPools)
+	if !ok {
+		return NotImplemented{}
+	}
+	buckets, err := objAPI.ListBuckets(ctx)
+	if err != nil {
+		return err
+	}
+	rows := loadUsageExportRows(ctx, z, buckets, cfg.Depth)
+	columns, tiers := usageExportColumns(rows)
+
+	var buf bytes.Buffer
+	var file inventoryFileWriter
+	name := "usage.csv.gz"
+	if cfg.Format == usageexport.FormatParquet {
+		name = "usage.parquet"
+		fields := make([]string, len(columns))
+		for i, column := range columns {
+			if i < 2 {
+				fields[i] = "required binary " + column + " (STRING);"
+			} else {
+				fields[i] = "required int64 " + column + ";"
+			}
+		}
+		w, err := newParquetInventoryWriter(&buf, "message minio.usage { "+strings.Join(fields, " ")+" }")
+		if err != nil {
+			return err
+		}
+		file = w
+	} else {
+		w := &csvInventoryWriter{gz: gzip.NewWriter(&buf)}
+		header := make([]interface{}, len(columns))
+		for i, column := range columns {
+			header[i] = column
+		}
+		if err = w.Write(header); err != nil {
+			return err
+		}
+		file = w
+	}
+	for _, row := range rows {
+		if err = file.Write(row.values(tiers)); err != nil {
+			return err
+		}
+	}
+	if err = file.Close(); err != nil {
+		return err
+	}
+
+	object := path.Join(cfg.Prefix, "dt="+now.UTC().Format("2006-01-02-15-04"), name)
+	contentType := "application/gzip"
+	if cfg.Format == usageexport.FormatParquet {
+		contentType = "application/octet-stream"
+	}
+	return putInventoryObject(ctx, objAPI, cfg.Bucket, object, bytes.NewReader(buf.Bytes()), int64(buf.Len()), contentType)
+}
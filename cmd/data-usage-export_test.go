@@ -0,0 +1,147 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"io/ioutil"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/klauspost/compress/gzip"
+	"github.com/minio/minio/internal/config/usageexport"
+)
+
+// newUsageExportTestCache returns the usage of a bucket with
+//
+//	bucket: 1 object, 1 delete marker
+//	bucket/a: 2 objects
+//	bucket/a/b: 3 objects on the WARM tier
+//	bucket/c: 4 objects
+func newUsageExportTestCache(bucket string) dataUsageCache {
+	var d dataUsageCache
+	d.Info.Name = dataUsageRoot
+	d.replace(bucket, dataUsageRoot, dataUsageEntry{Objects: 1, Versions: 1, DeleteMarkers: 1, Size: 10})
+	d.replace(bucket+"/a", bucket, dataUsageEntry{Objects: 2, Versions: 2, Size: 20})
+	d.replace(bucket+"/a/b", bucket+"/a", dataUsageEntry{
+		Objects: 3, Versions: 3, Size: 30,
+		AllTierStats: &allTierStats{Tiers: map[string]tierStats{
+			"WARM": {NumObjects: 3, NumVersions: 3, TotalSize: 30},
+		}},
+	})
+	d.replace(bucket+"/c", bucket, dataUsageEntry{Objects: 4, Versions: 4, Size: 40})
+	return d
+}
+
+func TestDataUsageCachePrefixUsage(t *testing.T) {
+	d := newUsageExportTestCache("bucket")
+	objects := func(m map[string]dataUsageEntry) map[string]uint64 {
+		res := make(map[string]uint64, len(m))
+		for prefix, e := range m {
+			res[prefix] = e.Objects
+		}
+		return res
+	}
+
+	testCases := []struct {
+		depth int
+		want  map[string]uint64
+	}{
+		{0, map[string]uint64{"": 10}},
+		{1, map[string]uint64{"": 1, "a/": 5, "c/": 4}},
+		{2, map[string]uint64{"": 1, "a/": 2, "a/b/": 3, "c/": 4}},
+		{5, map[string]uint64{"": 1, "a/": 2, "a/b/": 3, "c/": 4}},
+	}
+	for _, tc := range testCases {
+		if got := objects(d.prefixUsage("bucket", tc.depth)); !reflect.DeepEqual(got, tc.want) {
+			t.Errorf("depth %d: expected %v, got %v", tc.depth, tc.want, got)
+		}
+	}
+
+	if got := d.prefixUsage("bucket", 0)[""]; got.DeleteMarkers != 1 || got.Size != 100 {
+		t.Errorf("unexpected bucket usage %+v", got)
+	}
+	if got := d.prefixUsage("missing", 1); len(got) != 0 {
+		t.Errorf("expected no usage for a missing bucket, got %v", got)
+	}
+}
+
+func TestExportDataUsage(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	objLayer, fsDirs, err := prepareErasure16(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer objLayer.Shutdown(context.Background())
+	defer removeRoots(fsDirs)
+	initAllTestSubsystems(t)
+	setObjectLayer(objLayer)
+
+	for _, bucket := range []string{"bucket", "reports"} {
+		if err = objLayer.MakeBucketWithLocation(ctx, bucket, BucketOptions{}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	cache := newUsageExportTestCache("bucket")
+	er := objLayer.(*erasureServerPools).serverPools[0].sets[0]
+	if err = cache.save(ctx, er, "bucket"+slashSeparator+dataUsageCacheName); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg := usageexport.Config{
+		Enable: true,
+		Bucket: "reports",
+		Prefix: "usage",
+		Format: usageexport.FormatCSV,
+		Depth:  1,
+	}
+	now := time.Date(2022, 3, 1, 10, 30, 0, 0, time.UTC)
+	if err = exportDataUsage(ctx, objLayer, cfg, now); err != nil {
+		t.Fatal(err)
+	}
+
+	r, err := objLayer.GetObjectNInfo(ctx, "reports", "usage/dt=2022-03-01-10-30/usage.csv.gz", nil, http.Header{}, readLock, ObjectOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer r.Close()
+	gr, err := gzip.NewReader(r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := ioutil.ReadAll(gr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := strings.Join([]string{
+		`"bucket","prefix","objects","versions","delete_markers","size","tier_warm_objects","tier_warm_versions","tier_warm_size"`,
+		`"bucket","","1","1","1","10","0","0","0"`,
+		`"bucket","a%2F","5","5","0","50","3","3","30"`,
+		`"bucket","c%2F","4","4","0","40","0","0","0"`,
+		`"reports","","0","0","0","0","0","0","0"`,
+	}, "\n") + "\n"
+	if !bytes.Equal(data, []byte(want)) {
+		t.Errorf("expected export\n%s\ngot\n%s", want, data)
+	}
+}
@@ -40,6 +40,14 @@ type BucketTargetUsageInfo struct {
 	ReplicationFailedCount  uint64 `json:"objectsFailedReplicationCount"`
 }
 
+// BucketEncryptionUsageInfo - bucket encryption usage info provides
+// - total size of the object versions stored with an encryption type
+// - total number of object versions stored with an encryption type
+type BucketEncryptionUsageInfo struct {
+	Size          uint64 `json:"size"`
+	VersionsCount uint64 `json:"versionsCount"`
+}
+
 // BucketUsageInfo - bucket usage info provides
 // - total size of the bucket
 // - total objects in a bucket
@@ -63,6 +71,8 @@ type BucketUsageInfo struct {
 	VersionsCount        uint64                           `json:"versionsCount"`
 	ReplicaSize          uint64                           `json:"objectReplicaTotalSize"`
 	ReplicationInfo      map[string]BucketTargetUsageInfo `json:"objectsReplicationInfo"`
+	// Usage by the encryption type of the object versions
+	EncryptionInfo map[string]BucketEncryptionUsageInfo `json:"objectsEncryptionInfo,omitempty"`
 }
 
 // DataUsageInfo represents data usage stats of the underlying Object API
@@ -101,11 +111,12 @@ func (dui DataUsageInfo) tierStats() []madmin.TierInfo {
 	}
 
 	cfgs := globalTierConfigMgr.ListTiers()
-	if len(cfgs) == 0 {
+	fsCfgs := globalTierConfigMgr.ListFSTiers()
+	if len(cfgs) == 0 && len(fsCfgs) == 0 {
 		return nil
 	}
 
-	ts := make(map[string]madmin.TierStats, len(cfgs)+1)
+	ts := make(map[string]madmin.TierStats, len(cfgs)+len(fsCfgs)+1)
 	infos := make([]madmin.TierInfo, 0, len(ts))
 
 	// Add STANDARD (hot-tier)
@@ -122,6 +133,13 @@ func (dui DataUsageInfo) tierStats() []madmin.TierInfo {
 			Type: cfg.Type.String(),
 		})
 	}
+	for _, cfg := range fsCfgs {
+		ts[cfg.Name] = madmin.TierStats{}
+		infos = append(infos, madmin.TierInfo{
+			Name: cfg.Name,
+			Type: "fs",
+		})
+	}
 
 	ts = dui.TierStats.adminStats(ts)
 	for i := range infos {
@@ -52,6 +52,9 @@ func storeDataUsageInBackend(ctx context.Context, objAPI ObjectLayer, dui <-chan
 		if err = saveConfig(ctx, objAPI, dataUsageObjNamePath, dataUsageJSON); err != nil {
 			logger.LogIf(ctx, err)
 		}
+		if globalBucketQuotaSys != nil {
+			globalBucketQuotaSys.checkThresholds(ctx, objAPI, dataUsageInfo)
+		}
 	}
 }
 
@@ -21,10 +21,12 @@ import (
 	"context"
 	"errors"
 	"io"
+	"strconv"
 	"sync"
 	"sync/atomic"
 
 	"github.com/minio/minio/internal/logger"
+	"github.com/minio/minio/internal/tracing"
 )
 
 // Reads in parallel from readers.
@@ -204,6 +206,23 @@ func (p *parallelReader) Read(dst [][]byte) ([][]byte, error) {
 // Decode reads from readers, reconstructs data if needed and writes the data to the writer.
 // A set of preferred drives can be supplied. In that case they will be used and the data reconstructed.
 func (e Erasure) Decode(ctx context.Context, writer io.Writer, readers []io.ReaderAt, offset, length, totalLength int64, prefer []bool) (written int64, derr error) {
+	ctx, span := tracing.Start(ctx, "erasure.decode", tracing.KindInternal)
+	if span != nil {
+		span.SetAttributes("erasure.data_blocks", strconv.Itoa(e.dataBlocks),
+			"erasure.parity_blocks", strconv.Itoa(e.parityBlocks),
+			"erasure.offset", strconv.FormatInt(offset, 10),
+			"erasure.length", strconv.FormatInt(length, 10))
+		defer func() {
+			// Missing or corrupt shards which could be reconstructed
+			// do not fail the read.
+			if written == length {
+				span.Finish(nil)
+			} else {
+				span.Finish(derr)
+			}
+		}()
+	}
+
 	if offset < 0 || length < 0 {
 		logger.LogIf(ctx, errInvalidArgument)
 		return -1, errInvalidArgument
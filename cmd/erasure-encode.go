@@ -20,9 +20,11 @@ package cmd
 import (
 	"context"
 	"io"
+	"strconv"
 	"sync"
 
 	"github.com/minio/minio/internal/logger"
+	"github.com/minio/minio/internal/tracing"
 )
 
 // Writes in parallel to writers
@@ -74,6 +76,16 @@ func (p *parallelWriter) Write(ctx context.Context, blocks [][]byte) error {
 
 // Encode reads from the reader, erasure-encodes the data and writes to the writers.
 func (e *Erasure) Encode(ctx context.Context, src io.Reader, writers []io.Writer, buf []byte, quorum int) (total int64, err error) {
+	ctx, span := tracing.Start(ctx, "erasure.encode", tracing.KindInternal)
+	if span != nil {
+		span.SetAttributes("erasure.data_blocks", strconv.Itoa(e.dataBlocks),
+			"erasure.parity_blocks", strconv.Itoa(e.parityBlocks))
+		defer func() {
+			span.SetAttributes("erasure.bytes", strconv.FormatInt(total, 10))
+			span.Finish(err)
+		}()
+	}
+
 	writer := &parallelWriter{
 		writers:     writers,
 		writeQuorum: quorum,
@@ -496,6 +496,12 @@ func (er erasureObjects) healObject(ctx context.Context, bucket string, object s
 			tillOffset := erasure.ShardFileOffset(0, partSize, partSize)
 			readers := make([]io.ReaderAt, len(latestDisks))
 			checksumAlgo := erasureInfo.GetChecksumInfo(partNumber).Algorithm
+			// Healed parts keep the streaming algorithm of the object,
+			// all parts of an object must share the same algorithm.
+			healAlgo := checksumAlgo
+			if !healAlgo.streaming() {
+				healAlgo = DefaultBitrotAlgorithm
+			}
 			for i, disk := range latestDisks {
 				if disk == OfflineDisk {
 					continue
@@ -513,10 +519,10 @@ func (er erasureObjects) healObject(ctx context.Context, bucket string, object s
 				partPath := pathJoin(tmpID, dstDataDir, fmt.Sprintf("part.%d", partNumber))
 				if len(inlineBuffers) > 0 {
 					inlineBuffers[i] = bytes.NewBuffer(make([]byte, 0, erasure.ShardFileSize(latestMeta.Size)+32))
-					writers[i] = newStreamingBitrotWriterBuffer(inlineBuffers[i], DefaultBitrotAlgorithm, erasure.ShardSize())
+					writers[i] = newStreamingBitrotWriterBuffer(inlineBuffers[i], healAlgo, erasure.ShardSize())
 				} else {
 					writers[i] = newBitrotWriter(disk, minioMetaTmpBucket, partPath,
-						tillOffset, DefaultBitrotAlgorithm, erasure.ShardSize())
+						tillOffset, healAlgo, erasure.ShardSize())
 				}
 			}
 			err = erasure.Heal(ctx, writers, readers, partSize)
@@ -545,7 +551,7 @@ func (er erasureObjects) healObject(ctx context.Context, bucket string, object s
 				partsMetadata[i].AddObjectPart(partNumber, "", partSize, partActualSize)
 				partsMetadata[i].Erasure.AddChecksumInfo(ChecksumInfo{
 					PartNumber: partNumber,
-					Algorithm:  checksumAlgo,
+					Algorithm:  healAlgo,
 					Hash:       bitrotWriterSum(writers[i]),
 				})
 				if len(inlineBuffers) > 0 && inlineBuffers[i] != nil {
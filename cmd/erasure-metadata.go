@@ -36,6 +36,17 @@ import (
 // Object was stored with additional erasure codes due to degraded system at upload time
 const minIOErasureUpgraded = "x-minio-internal-erasure-upgraded"
 
+// Multipart upload was initiated for this bucket/object at the given time,
+// used by the stale uploads cleanup to apply per bucket lifecycle rules.
+const (
+	minIOMultipartObject    = "x-minio-internal-multipart-object"
+	minIOMultipartInitiated = "x-minio-internal-multipart-initiated"
+)
+
+// Bitrot algorithm of the parts of a multipart upload, chosen when the
+// upload is initiated so that all its parts use the same algorithm.
+const minIOMultipartBitrot = "x-minio-internal-multipart-bitrot"
+
 const erasureAlgorithm = "rs-vandermonde"
 
 // byObjectPartNumber is a collection satisfying sort.Interface.
@@ -56,6 +67,25 @@ func (e *ErasureInfo) AddChecksumInfo(ckSumInfo ChecksumInfo) {
 	e.Checksums = append(e.Checksums, ckSumInfo)
 }
 
+// bitrotAlgorithm returns the streaming bitrot algorithm the parts are
+// protected with, new parts of an object must use the same algorithm.
+func (e ErasureInfo) bitrotAlgorithm() BitrotAlgorithm {
+	if len(e.Checksums) > 0 && e.Checksums[0].Algorithm.streaming() {
+		return e.Checksums[0].Algorithm
+	}
+	return DefaultBitrotAlgorithm
+}
+
+// multipartBitrotAlgorithm returns the bitrot algorithm of the parts of
+// the multipart upload with the given metadata.
+func multipartBitrotAlgorithm(metadata map[string]string) BitrotAlgorithm {
+	// Uploads initiated before the algorithm was recorded use the default.
+	if algo := BitrotAlgorithmFromString(metadata[minIOMultipartBitrot]); algo.streaming() {
+		return algo
+	}
+	return DefaultBitrotAlgorithm
+}
+
 // GetChecksumInfo - get checksum of a part.
 func (e ErasureInfo) GetChecksumInfo(partNumber int) (ckSum ChecksumInfo) {
 	for _, sum := range e.Checksums {
@@ -101,17 +101,20 @@ func (er erasureObjects) removeObjectPart(bucket, object, uploadID, dataDir stri
 // Clean-up the old multipart uploads. Should be run in a Go routine.
 func (er erasureObjects) cleanupStaleUploads(ctx context.Context, expiry time.Duration) {
 	// run multiple cleanup's local to this server.
+	sweep := newStaleUploadsSweep()
 	var wg sync.WaitGroup
 	for _, disk := range er.getLoadBalancedLocalDisks() {
 		if disk != nil {
 			wg.Add(1)
 			go func(disk StorageAPI) {
 				defer wg.Done()
-				er.cleanupStaleUploadsOnDisk(ctx, disk, expiry)
+				er.cleanupStaleUploadsOnDisk(ctx, disk, expiry, sweep)
 			}(disk)
 		}
 	}
 	wg.Wait()
+
+	globalStaleUploadsStats.setActive(fmt.Sprintf("%d-%d", er.poolIndex, er.setIndex), sweep.activeCount())
 }
 
 func (er erasureObjects) renameAll(ctx context.Context, bucket, prefix string) {
@@ -145,20 +148,28 @@ func (er erasureObjects) deleteAll(ctx context.Context, bucket, prefix string) {
 }
 
 // Remove the old multipart uploads on the given disk.
-func (er erasureObjects) cleanupStaleUploadsOnDisk(ctx context.Context, disk StorageAPI, expiry time.Duration) {
+func (er erasureObjects) cleanupStaleUploadsOnDisk(ctx context.Context, disk StorageAPI, expiry time.Duration, sweep *staleUploadsSweep) {
 	now := time.Now()
 	diskPath := disk.Endpoint().Path
 
 	readDirFn(pathJoin(diskPath, minioMetaMultipartBucket), func(shaDir string, typ os.FileMode) error {
 		return readDirFn(pathJoin(diskPath, minioMetaMultipartBucket, shaDir), func(uploadIDDir string, typ os.FileMode) error {
 			uploadIDPath := pathJoin(shaDir, uploadIDDir)
+			// Other local disks of this set may have already
+			// looked at this upload during this sweep.
+			if !sweep.visit(uploadIDPath) {
+				return nil
+			}
 			fi, err := disk.ReadVersion(ctx, minioMetaMultipartBucket, uploadIDPath, "", false)
 			if err != nil {
 				return nil
 			}
 			wait := er.deletedCleanupSleeper.Timer(ctx)
-			if now.Sub(fi.ModTime) > expiry {
+			if isStaleUpload(fi, now, expiry) {
 				er.renameAll(ctx, minioMetaMultipartBucket, uploadIDPath)
+				globalStaleUploadsStats.aborted(fi)
+			} else {
+				sweep.active()
 			}
 			wait()
 			return nil
@@ -347,6 +358,12 @@ func (er erasureObjects) newMultipartUpload(ctx context.Context, bucket string,
 		modTime = UTCNow()
 	}
 
+	// Remember the object and initiation time of this upload
+	// for the stale uploads cleanup.
+	userDefined[minIOMultipartObject] = pathJoin(bucket, object)
+	userDefined[minIOMultipartInitiated] = modTime.Format(time.RFC3339Nano)
+	userDefined[minIOMultipartBitrot] = bitrotAlgorithmForSC(userDefined[xhttp.AmzStorageClass]).String()
+
 	onlineDisks, partsMetadata = shuffleDisksAndPartsMetadata(onlineDisks, partsMetadata, fi)
 
 	// Fill all the necessary metadata.
@@ -565,12 +582,13 @@ func (er erasureObjects) PutObjectPart(ctx context.Context, bucket, object, uplo
 	if len(buffer) > int(fi.Erasure.BlockSize) {
 		buffer = buffer[:fi.Erasure.BlockSize]
 	}
+	bitrotAlgo := multipartBitrotAlgorithm(fi.Metadata)
 	writers := make([]io.Writer, len(onlineDisks))
 	for i, disk := range onlineDisks {
 		if disk == nil {
 			continue
 		}
-		writers[i] = newBitrotWriter(disk, minioMetaTmpBucket, tmpPartPath, erasure.ShardFileSize(data.Size()), DefaultBitrotAlgorithm, erasure.ShardSize())
+		writers[i] = newBitrotWriter(disk, minioMetaTmpBucket, tmpPartPath, erasure.ShardFileSize(data.Size()), bitrotAlgo, erasure.ShardSize())
 	}
 
 	toEncode := io.Reader(data)
@@ -658,9 +676,14 @@ func (er erasureObjects) PutObjectPart(ctx context.Context, bucket, object, uplo
 		partsMetadata[i].Size = fi.Size
 		partsMetadata[i].ModTime = fi.ModTime
 		partsMetadata[i].Parts = fi.Parts
+		if opts.WantChecksum != nil && opts.WantChecksum.Encoded != "" {
+			partsMetadata[i].Metadata[uploadPartChecksumKey(partID)] = opts.WantChecksum.String()
+		} else {
+			delete(partsMetadata[i].Metadata, uploadPartChecksumKey(partID))
+		}
 		partsMetadata[i].Erasure.AddChecksumInfo(ChecksumInfo{
 			PartNumber: partID,
-			Algorithm:  DefaultBitrotAlgorithm,
+			Algorithm:  bitrotAlgo,
 			Hash:       bitrotWriterSum(writers[i]),
 		})
 	}
@@ -951,6 +974,16 @@ func (er erasureObjects) CompleteMultipartUpload(ctx context.Context, bucket str
 		fi.ModTime = UTCNow()
 	}
 
+	// Save the composite checksum if the upload has a checksum algorithm.
+	if err = completeMultipartChecksum(fi.Metadata, currentFI.Parts, fi.Parts); err != nil {
+		return oi, err
+	}
+
+	// Upload tracking information is not part of the final object.
+	delete(fi.Metadata, minIOMultipartObject)
+	delete(fi.Metadata, minIOMultipartInitiated)
+	delete(fi.Metadata, minIOMultipartBitrot)
+
 	// Save successfully calculated md5sum.
 	fi.Metadata["etag"] = opts.UserDefined["etag"]
 	if fi.Metadata["etag"] == "" {
@@ -971,14 +1004,20 @@ func (er erasureObjects) CompleteMultipartUpload(ctx context.Context, bucket str
 		}
 	}
 
-	// Hold namespace to complete the transaction
-	lk := er.NewNSLock(bucket, object)
-	lkctx, err := lk.GetLock(ctx, globalOperationTimeout)
-	if err != nil {
+	if !opts.NoLock {
+		// Hold namespace to complete the transaction
+		lk := er.NewNSLock(bucket, object)
+		lkctx, err := lk.GetLock(ctx, globalOperationTimeout)
+		if err != nil {
+			return oi, err
+		}
+		ctx = lkctx.Context()
+		defer lk.Unlock(lkctx.Cancel)
+	}
+
+	if err = er.checkWritePrecondition(ctx, bucket, object, opts); err != nil {
 		return oi, err
 	}
-	ctx = lkctx.Context()
-	defer lk.Unlock(lkctx.Cancel)
 
 	// Write final `xl.meta` at uploadID location
 	onlineDisks, err = writeUniqueFileInfo(ctx, onlineDisks, minioMetaMultipartBucket, uploadIDPath, partsMetadata, writeQuorum)
@@ -1004,6 +1043,7 @@ func (er erasureObjects) CompleteMultipartUpload(ctx context.Context, bucket str
 		partsMetadata, bucket, object, writeQuorum); err != nil {
 		return oi, toObjectErr(err, bucket, object)
 	}
+	globalInlineObjectStats.record(false)
 
 	// Check if there is any offline disk and add it to the MRF list
 	for _, disk := range onlineDisks {
@@ -28,6 +28,7 @@ import (
 	"strconv"
 	"strings"
 	"sync"
+	"sync/atomic"
 
 	"github.com/klauspost/readahead"
 	"github.com/minio/madmin-go"
@@ -35,6 +36,7 @@ import (
 	"github.com/minio/minio/internal/bucket/lifecycle"
 	"github.com/minio/minio/internal/bucket/object/lock"
 	"github.com/minio/minio/internal/bucket/replication"
+	"github.com/minio/minio/internal/crypto"
 	"github.com/minio/minio/internal/event"
 	"github.com/minio/minio/internal/hash"
 	xhttp "github.com/minio/minio/internal/http"
@@ -48,6 +50,23 @@ import (
 // list all errors which can be ignored in object operations.
 var objectOpIgnoredErrs = append(baseIgnoredErrs, errDiskAccessDenied, errUnformattedDisk)
 
+// inlineObjectStats counts the objects written with their data inlined
+// in xl.meta and the objects written with a separate data directory.
+type inlineObjectStats struct {
+	inline    uint64 // Must be accessed atomically
+	nonInline uint64 // Must be accessed atomically
+}
+
+var globalInlineObjectStats inlineObjectStats
+
+func (s *inlineObjectStats) record(inlined bool) {
+	if inlined {
+		atomic.AddUint64(&s.inline, 1)
+	} else {
+		atomic.AddUint64(&s.nonInline, 1)
+	}
+}
+
 // Object Operations
 
 func countOnlineDisks(onlineDisks []StorageAPI) (online int) {
@@ -274,6 +293,12 @@ func (er erasureObjects) GetObjectNInfo(ctx context.Context, bucket, object stri
 
 	pr, pw := xioutil.WaitPipe()
 	go func() {
+		if rs != nil && globalRangePrefetch {
+			pw.CloseWithError(globalRangePrefetcher.readRange(ctx, bucket, object, fi, off, length, pw, func(ctx context.Context, offset, length int64, w io.Writer) error {
+				return er.getObjectWithFileInfo(ctx, bucket, object, offset, length, w, fi, metaArr, onlineDisks)
+			}))
+			return
+		}
 		pw.CloseWithError(er.getObjectWithFileInfo(ctx, bucket, object, off, length, pw, fi, metaArr, onlineDisks))
 	}()
 
@@ -656,6 +681,15 @@ func (er erasureObjects) getObjectInfo(ctx context.Context, bucket, object strin
 	return objInfo, nil
 }
 
+func (er erasureObjects) checkWritePrecondition(ctx context.Context, bucket, object string, opts ObjectOptions) error {
+	return checkWritePrecondition(opts, func() (ObjectInfo, error) {
+		return er.getObjectInfo(ctx, bucket, object, ObjectOptions{
+			Versioned:        opts.Versioned,
+			VersionSuspended: opts.VersionSuspended,
+		})
+	})
+}
+
 // getObjectInfoAndQuroum - wrapper for reading object metadata and constructs ObjectInfo, additionally returns write quorum for the object.
 func (er erasureObjects) getObjectInfoAndQuorum(ctx context.Context, bucket, object string, opts ObjectOptions) (objInfo ObjectInfo, wquorum int, err error) {
 	fi, _, _, err := er.getObjectFileInfo(ctx, bucket, object, opts, false)
@@ -868,9 +902,198 @@ func (er erasureObjects) putMetacacheObject(ctx context.Context, key string, r *
 // writes `xl.meta` which carries the necessary metadata for future
 // object operations.
 func (er erasureObjects) PutObject(ctx context.Context, bucket string, object string, data *PutObjReader, opts ObjectOptions) (objInfo ObjectInfo, err error) {
+	if opts.Append {
+		return er.appendObject(ctx, bucket, object, data, opts)
+	}
 	return er.putObject(ctx, bucket, object, data, opts)
 }
 
+// appendObject erasure codes the incoming data as a new part in the
+// data directory of the latest version of object and adds that part
+// to `xl.meta`, leaving the existing parts untouched. When the object
+// does not exist yet it is created with a regular putObject.
+func (er erasureObjects) appendObject(ctx context.Context, bucket string, object string, r *PutObjReader, opts ObjectOptions) (objInfo ObjectInfo, err error) {
+	auditObjectErasureSet(ctx, object, &er)
+
+	if !opts.NoLock {
+		lk := er.NewNSLock(bucket, object)
+		lkctx, err := lk.GetLock(ctx, globalOperationTimeout)
+		if err != nil {
+			return ObjectInfo{}, err
+		}
+		ctx = lkctx.Context()
+		defer lk.Unlock(lkctx.Cancel)
+		opts.NoLock = true
+	}
+
+	fi, metaArr, onlineDisks, err := er.getObjectFileInfo(ctx, bucket, object, ObjectOptions{
+		Versioned:        opts.Versioned,
+		VersionSuspended: opts.VersionSuspended,
+	}, false)
+	if err == nil && fi.Deleted {
+		err = errFileNotFound
+	}
+	if err != nil {
+		err = toObjectErr(err, bucket, object)
+		if isErrObjectNotFound(err) || isErrVersionNotFound(err) {
+			return er.putObject(ctx, bucket, object, r, opts)
+		}
+		return ObjectInfo{}, err
+	}
+
+	if err = er.checkWritePrecondition(ctx, bucket, object, opts); err != nil {
+		return ObjectInfo{}, err
+	}
+
+	// Only plain objects whose parts live in a data directory can
+	// be extended without rewriting the existing data.
+	_, encrypted := crypto.IsEncrypted(fi.Metadata)
+	_, compressed := fi.Metadata[ReservedMetadataPrefix+"compression"]
+	if encrypted || compressed || fi.InlineData() || fi.IsRemote() || fi.DataDir == "" || len(fi.Parts) >= globalMaxPartID {
+		return ObjectInfo{}, ObjectNotAppendable{Bucket: bucket, Object: object}
+	}
+
+	data := r.Reader
+	if data.Size() < -1 {
+		logger.LogIf(ctx, errInvalidArgument, logger.Application)
+		return ObjectInfo{}, toObjectErr(errInvalidArgument)
+	}
+
+	writeQuorum := fi.Erasure.DataBlocks
+	if fi.Erasure.DataBlocks == fi.Erasure.ParityBlocks {
+		writeQuorum++
+	}
+
+	onlineDisks, partsMetadata := shuffleDisksAndPartsMetadataByIndex(onlineDisks, metaArr, fi)
+
+	erasure, err := NewErasure(ctx, fi.Erasure.DataBlocks, fi.Erasure.ParityBlocks, fi.Erasure.BlockSize)
+	if err != nil {
+		return ObjectInfo{}, toObjectErr(err, bucket, object)
+	}
+
+	var buffer []byte
+	switch size := data.Size(); {
+	case size == 0:
+		buffer = make([]byte, 1) // Allocate atleast a byte to reach EOF
+	case size == -1 || size >= fi.Erasure.BlockSize:
+		buffer = er.bp.Get()
+		defer er.bp.Put(buffer)
+	case size < fi.Erasure.BlockSize:
+		buffer = make([]byte, size, 2*size+int64(fi.Erasure.ParityBlocks+fi.Erasure.DataBlocks-1))
+	}
+
+	if len(buffer) > int(fi.Erasure.BlockSize) {
+		buffer = buffer[:fi.Erasure.BlockSize]
+	}
+
+	partNumber := 1
+	for _, part := range fi.Parts {
+		if part.Number >= partNumber {
+			partNumber = part.Number + 1
+		}
+	}
+	partName := fmt.Sprintf("part.%d", partNumber)
+
+	tmpPart := mustGetUUID()
+	tmpPartPath := pathJoin(tmpPart, partName)
+	defer er.renameAll(context.Background(), minioMetaTmpBucket, tmpPart)
+
+	// All the parts of an object share the same bitrot algorithm.
+	bitrotAlgo := fi.Erasure.bitrotAlgorithm()
+	writers := make([]io.Writer, len(onlineDisks))
+	for i, disk := range onlineDisks {
+		if disk == nil || !disk.IsOnline() {
+			continue
+		}
+		writers[i] = newBitrotWriter(disk, minioMetaTmpBucket, tmpPartPath, erasure.ShardFileSize(data.Size()), bitrotAlgo, erasure.ShardSize())
+	}
+
+	n, err := erasure.Encode(ctx, data, writers, buffer, writeQuorum)
+	closeBitrotWriters(writers)
+	if err != nil {
+		return ObjectInfo{}, toObjectErr(err, bucket, object)
+	}
+
+	// Should return IncompleteBody{} error when reader has fewer bytes
+	// than specified in request header.
+	if n < data.Size() {
+		return ObjectInfo{}, IncompleteBody{Bucket: bucket, Object: object}
+	}
+
+	for i := range writers {
+		if writers[i] == nil {
+			onlineDisks[i] = nil
+		}
+	}
+
+	// Move the new part next to the existing ones.
+	g := errgroup.WithNErrs(len(onlineDisks))
+	for index := range onlineDisks {
+		index := index
+		g.Go(func() error {
+			if onlineDisks[index] == nil {
+				return errDiskNotFound
+			}
+			return onlineDisks[index].RenameFile(ctx, minioMetaTmpBucket, tmpPartPath, bucket, pathJoin(object, fi.DataDir, partName))
+		}, index)
+	}
+	errs := g.Wait()
+	if err = reduceWriteQuorumErrs(ctx, errs, objectOpIgnoredErrs, writeQuorum); err != nil {
+		return ObjectInfo{}, toObjectErr(err, bucket, object)
+	}
+	onlineDisks = evalDisks(onlineDisks, errs)
+
+	// The ETag of an appended object changes with every append, it is
+	// derived from the previous ETag and the MD5 of the appended data.
+	appendedETag := getMD5Hash(append([]byte(fi.Metadata["etag"]), r.MD5CurrentHexString()...))
+
+	metadata := cloneMSS(fi.Metadata)
+	metadata["etag"] = fmt.Sprintf("%s-%d", appendedETag, len(fi.Parts)+1)
+	delete(metadata, objectChecksumKey)
+
+	modTime := opts.MTime
+	if opts.MTime.IsZero() {
+		modTime = UTCNow()
+	}
+
+	for i := range partsMetadata {
+		if onlineDisks[i] == nil {
+			continue
+		}
+		partsMetadata[i].AddObjectPart(partNumber, "", n, data.ActualSize())
+		partsMetadata[i].Erasure.AddChecksumInfo(ChecksumInfo{
+			PartNumber: partNumber,
+			Algorithm:  bitrotAlgo,
+			Hash:       bitrotWriterSum(writers[i]),
+		})
+		partsMetadata[i].Size = fi.Size + n
+		partsMetadata[i].ModTime = modTime
+		partsMetadata[i].Metadata = metadata
+	}
+
+	if onlineDisks, err = writeUniqueFileInfo(ctx, onlineDisks, bucket, object, partsMetadata, writeQuorum); err != nil {
+		return ObjectInfo{}, toObjectErr(err, bucket, object)
+	}
+
+	for i := 0; i < len(onlineDisks); i++ {
+		if onlineDisks[i] != nil && onlineDisks[i].IsOnline() {
+			fi = partsMetadata[i]
+			break
+		}
+	}
+
+	for i := 0; i < len(onlineDisks); i++ {
+		if onlineDisks[i] != nil && onlineDisks[i].IsOnline() {
+			continue
+		}
+		er.addPartial(bucket, object, fi.VersionID, fi.Size)
+		break
+	}
+
+	fi.IsLatest = true
+	return fi.ToObjectInfo(bucket, object, opts.Versioned || opts.VersionSuspended), nil
+}
+
 // putObject wrapper for erasureObjects PutObject
 func (er erasureObjects) putObject(ctx context.Context, bucket string, object string, r *PutObjReader, opts ObjectOptions) (objInfo ObjectInfo, err error) {
 	auditObjectErasureSet(ctx, object, &er)
@@ -1008,21 +1231,23 @@ func (er erasureObjects) putObject(ctx context.Context, bucket string, object st
 	writers := make([]io.Writer, len(onlineDisks))
 	var inlineBuffers []*bytes.Buffer
 	if shardFileSize >= 0 {
-		if !opts.Versioned && shardFileSize < smallFileThreshold {
-			inlineBuffers = make([]*bytes.Buffer, len(onlineDisks))
-		} else if shardFileSize < smallFileThreshold/8 {
+		if globalStorageClass.ShouldInline(shardFileSize, opts.Versioned) {
 			inlineBuffers = make([]*bytes.Buffer, len(onlineDisks))
 		}
 	} else {
 		// If compressed, use actual size to determine.
 		if sz := erasure.ShardFileSize(data.ActualSize()); sz > 0 {
-			if !opts.Versioned && sz < smallFileThreshold {
-				inlineBuffers = make([]*bytes.Buffer, len(onlineDisks))
-			} else if sz < smallFileThreshold/8 {
+			if globalStorageClass.ShouldInline(sz, opts.Versioned) {
 				inlineBuffers = make([]*bytes.Buffer, len(onlineDisks))
 			}
 		}
 	}
+	if opts.Append {
+		// Objects created by an append must keep their
+		// parts in a data directory to be extended later.
+		inlineBuffers = nil
+	}
+	bitrotAlgo := bitrotAlgorithmForSC(userDefined[xhttp.AmzStorageClass])
 	for i, disk := range onlineDisks {
 		if disk == nil {
 			continue
@@ -1038,11 +1263,11 @@ func (er erasureObjects) putObject(ctx context.Context, bucket string, object st
 				sz = data.ActualSize()
 			}
 			inlineBuffers[i] = bytes.NewBuffer(make([]byte, 0, sz))
-			writers[i] = newStreamingBitrotWriterBuffer(inlineBuffers[i], DefaultBitrotAlgorithm, erasure.ShardSize())
+			writers[i] = newStreamingBitrotWriterBuffer(inlineBuffers[i], bitrotAlgo, erasure.ShardSize())
 			continue
 		}
 
-		writers[i] = newBitrotWriter(disk, minioMetaTmpBucket, tempErasureObj, shardFileSize, DefaultBitrotAlgorithm, erasure.ShardSize())
+		writers[i] = newBitrotWriter(disk, minioMetaTmpBucket, tempErasureObj, shardFileSize, bitrotAlgo, erasure.ShardSize())
 	}
 
 	toEncode := io.Reader(data)
@@ -1081,6 +1306,10 @@ func (er erasureObjects) putObject(ctx context.Context, bucket string, object st
 		defer lk.Unlock(lkctx.Cancel)
 	}
 
+	if err = er.checkWritePrecondition(ctx, bucket, object, opts); err != nil {
+		return ObjectInfo{}, err
+	}
+
 	for i, w := range writers {
 		if w == nil {
 			onlineDisks[i] = nil
@@ -1094,7 +1323,7 @@ func (er erasureObjects) putObject(ctx context.Context, bucket string, object st
 		partsMetadata[i].AddObjectPart(1, "", n, data.ActualSize())
 		partsMetadata[i].Erasure.AddChecksumInfo(ChecksumInfo{
 			PartNumber: 1,
-			Algorithm:  DefaultBitrotAlgorithm,
+			Algorithm:  bitrotAlgo,
 			Hash:       bitrotWriterSum(w),
 		})
 	}
@@ -1102,6 +1331,11 @@ func (er erasureObjects) putObject(ctx context.Context, bucket string, object st
 		userDefined["etag"] = r.MD5CurrentHexString()
 	}
 
+	// Save the additional checksum verified while reading.
+	if opts.WantChecksum != nil && opts.WantChecksum.Encoded != "" {
+		userDefined[objectChecksumKey] = opts.WantChecksum.String()
+	}
+
 	// Guess content-type from the extension if possible.
 	if userDefined["content-type"] == "" {
 		userDefined["content-type"] = mimedb.TypeByExtension(path.Ext(object))
@@ -1135,6 +1369,7 @@ func (er erasureObjects) putObject(ctx context.Context, bucket string, object st
 		logger.LogIf(ctx, err)
 		return ObjectInfo{}, toObjectErr(err, bucket, object)
 	}
+	globalInlineObjectStats.record(len(inlineBuffers) > 0)
 
 	for i := 0; i < len(onlineDisks); i++ {
 		if onlineDisks[i] != nil && onlineDisks[i].IsOnline() {
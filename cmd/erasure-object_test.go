@@ -29,10 +29,12 @@ import (
 	"os"
 	"path/filepath"
 	"strconv"
+	"strings"
 	"testing"
 
 	humanize "github.com/dustin/go-humanize"
 	"github.com/minio/minio/internal/config/storageclass"
+	xhttp "github.com/minio/minio/internal/http"
 )
 
 func TestRepeatPutObjectPart(t *testing.T) {
@@ -1205,3 +1207,152 @@ func TestGetObjectWithOutdatedDisks(t *testing.T) {
 		}
 	}
 }
+
+func TestErasureAppendObject(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	obj, fsDirs, err := prepareErasure16(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer obj.Shutdown(context.Background())
+	defer removeRoots(fsDirs)
+
+	bucket := "bucket"
+	if err = obj.MakeBucketWithLocation(ctx, bucket, BucketOptions{}); err != nil {
+		t.Fatal(err)
+	}
+
+	first := bytes.Repeat([]byte("a"), 1<<10)
+	second := bytes.Repeat([]byte("b"), 2<<10)
+
+	// The first append creates the object.
+	_, err = obj.PutObject(ctx, bucket, "log", mustGetPutObjReader(t, bytes.NewReader(first), int64(len(first)), "", ""), ObjectOptions{Append: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	oi, err := obj.PutObject(ctx, bucket, "log", mustGetPutObjReader(t, bytes.NewReader(second), int64(len(second)), "", ""), ObjectOptions{Append: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if oi.Size != int64(len(first)+len(second)) {
+		t.Fatalf("expected size %d, got %d", len(first)+len(second), oi.Size)
+	}
+	if !strings.HasSuffix(oi.ETag, "-2") {
+		t.Fatalf("expected ETag of an object with 2 parts, got %s", oi.ETag)
+	}
+
+	var buf bytes.Buffer
+	if err = GetObject(ctx, obj, bucket, "log", 0, oi.Size, &buf, "", ObjectOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(buf.Bytes(), append(first, second...)) {
+		t.Fatal("appended object content mismatch")
+	}
+
+	// Inlined objects cannot be appended to.
+	_, err = obj.PutObject(ctx, bucket, "small", mustGetPutObjReader(t, bytes.NewReader(first), int64(len(first)), "", ""), ObjectOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = obj.PutObject(ctx, bucket, "small", mustGetPutObjReader(t, bytes.NewReader(second), int64(len(second)), "", ""), ObjectOptions{Append: true})
+	if _, ok := err.(ObjectNotAppendable); !ok {
+		t.Fatalf("expected ObjectNotAppendable, got %v", err)
+	}
+}
+
+func TestPutObjectBitrotAlgorithm(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	obj, fsDirs, err := prepareErasure16(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer obj.Shutdown(context.Background())
+	defer removeRoots(fsDirs)
+
+	savedStorageClass := globalStorageClass
+	defer func() { globalStorageClass = savedStorageClass }()
+	setBitrot := func(standard, rrs string) {
+		kvs := storageclass.DefaultKVS.Clone()
+		kvs.Set(storageclass.StandardBitrot, standard)
+		kvs.Set(storageclass.RRSBitrot, rrs)
+		cfg, err := storageclass.LookupConfig(kvs, 16)
+		if err != nil {
+			t.Fatal(err)
+		}
+		globalStorageClass = cfg
+	}
+
+	z := obj.(*erasureServerPools)
+	bucket := "bucket"
+	if err = obj.MakeBucketWithLocation(ctx, bucket, BucketOptions{}); err != nil {
+		t.Fatal(err)
+	}
+
+	objects := make(map[string][]byte)
+	put := func(object string, size int, opts ObjectOptions) {
+		data := bytes.Repeat([]byte(object[:1]), size)
+		if _, err := obj.PutObject(ctx, bucket, object, mustGetPutObjReader(t, bytes.NewReader(data), int64(len(data)), "", ""), opts); err != nil {
+			t.Fatal(err)
+		}
+		objects[object] = data
+	}
+	check := func(object string, want BitrotAlgorithm) {
+		fi, _, _, err := z.serverPools[0].getHashedSet(object).getObjectFileInfo(ctx, bucket, object, ObjectOptions{}, false)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, sum := range fi.Erasure.Checksums {
+			if sum.Algorithm != want {
+				t.Fatalf("%s: expected part %d protected with %v, got %v", object, sum.PartNumber, want, sum.Algorithm)
+			}
+		}
+	}
+
+	setBitrot(storageclass.BitrotXXHash64, "")
+	put("inline", 1<<10, ObjectOptions{})
+	put("large", 2<<20, ObjectOptions{})
+	check("inline", XXHash64S)
+	check("large", XXHash64S)
+
+	// Parts of an upload use the algorithm in effect when it was initiated.
+	uploadID, err := obj.NewMultipartUpload(ctx, bucket, "multipart", ObjectOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	setBitrot(storageclass.BitrotBLAKE3, storageclass.BitrotHighwayHash)
+	put("blake3", 2<<20, ObjectOptions{})
+	check("blake3", BLAKE3S)
+	put("rrs", 2<<20, ObjectOptions{UserDefined: map[string]string{xhttp.AmzStorageClass: storageclass.RRS}})
+	check("rrs", HighwayHash256S)
+
+	data := bytes.Repeat([]byte("m"), 1<<20)
+	pi, err := obj.PutObjectPart(ctx, bucket, "multipart", uploadID, 1, mustGetPutObjReader(t, bytes.NewReader(data), int64(len(data)), "", ""), ObjectOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	oi, err := obj.CompleteMultipartUpload(ctx, bucket, "multipart", uploadID, []CompletePart{{PartNumber: pi.PartNumber, ETag: pi.ETag}}, ObjectOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := oi.UserDefined[minIOMultipartBitrot]; ok {
+		t.Fatal("expected the upload bitrot algorithm to be removed from the object metadata")
+	}
+	objects["multipart"] = data
+	check("multipart", XXHash64S)
+
+	// Objects keep decoding with their recorded algorithm.
+	for object, data := range objects {
+		var buf bytes.Buffer
+		if err = GetObject(ctx, obj, bucket, object, 0, int64(len(data)), &buf, "", ObjectOptions{}); err != nil {
+			t.Fatalf("%s: %v", object, err)
+		}
+		if !bytes.Equal(buf.Bytes(), data) {
+			t.Fatalf("%s: content mismatch", object)
+		}
+	}
+}
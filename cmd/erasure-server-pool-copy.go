@@ -0,0 +1,189 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"context"
+	"sync"
+
+	"github.com/dustin/go-humanize"
+	"github.com/minio/minio/internal/crypto"
+	"github.com/minio/minio/internal/hash"
+	"github.com/minio/minio/internal/logger"
+)
+
+// copyObjectPartSize is the size of the parts copied in parallel
+// by a server side copy, grown for objects needing more parts
+// than a multipart upload allows.
+var copyObjectPartSize int64 = 128 * humanize.MiByte
+
+// copyPartSize returns the part size used to copy an object of
+// the given size in parallel.
+func copyPartSize(size int64) int64 {
+	partSize := copyObjectPartSize
+	if n := ceilFrac(size, globalMaxPartID); n > partSize {
+		partSize = n
+	}
+	return partSize
+}
+
+// canCopyObjectParallel returns true when the source can be copied
+// as is, in ranges, to the destination. Objects which are encrypted,
+// compressed or rewritten on their way to the destination are copied
+// sequentially through the reader prepared by the handler.
+func (z *erasureServerPools) canCopyObjectParallel(ctx context.Context, srcBucket, srcObject string, srcInfo ObjectInfo, srcOpts, dstOpts ObjectOptions) bool {
+	if globalAPIConfig.getCopyConcurrency() < 2 || srcInfo.Size <= copyObjectPartSize {
+		return false
+	}
+	if dstOpts.ServerSideEncryption != nil {
+		return false
+	}
+	if _, ok := crypto.IsEncrypted(srcInfo.UserDefined); ok {
+		return false
+	}
+	if _, ok := srcInfo.UserDefined[ReservedMetadataPrefix+"compression"]; ok {
+		return false
+	}
+
+	// The handler strips the source encryption metadata,
+	// look at the stored source object instead.
+	oi, err := z.GetObjectInfo(ctx, srcBucket, srcObject, ObjectOptions{
+		VersionID:        srcOpts.VersionID,
+		Versioned:        srcOpts.Versioned,
+		VersionSuspended: srcOpts.VersionSuspended,
+		NoLock:           true,
+	})
+	if err != nil {
+		return false
+	}
+	if _, ok := crypto.IsEncrypted(oi.UserDefined); ok || oi.IsCompressed() {
+		return false
+	}
+	return oi.ETag == srcInfo.ETag && oi.Size == srcInfo.Size
+}
+
+// copyObjectParallel copies the source object into the destination pool
+// as a multipart upload, reading and writing part sized ranges of the
+// source concurrently. The source may live in any pool or be transitioned
+// to a remote tier, the destination keeps the source ETag. The caller
+// holds the destination lock and the read lock of the source.
+func (z *erasureServerPools) copyObjectParallel(ctx context.Context, poolIdx int, srcBucket, srcObject, dstBucket, dstObject string, srcInfo ObjectInfo, srcOpts, dstOpts ObjectOptions) (objInfo ObjectInfo, err error) {
+	pool := z.serverPools[poolIdx]
+
+	uploadID, err := pool.NewMultipartUpload(ctx, dstBucket, dstObject, ObjectOptions{
+		UserDefined: cloneMSS(srcInfo.UserDefined),
+		Versioned:   dstOpts.Versioned,
+		VersionID:   dstOpts.VersionID,
+		MTime:       dstOpts.MTime,
+	})
+	if err != nil {
+		return objInfo, err
+	}
+	defer func() {
+		if err != nil {
+			// Use a fresh context, ctx may be canceled by now.
+			if aerr := pool.AbortMultipartUpload(context.Background(), dstBucket, dstObject, uploadID, ObjectOptions{}); aerr != nil {
+				logger.LogIf(ctx, aerr)
+			}
+		}
+	}()
+
+	partSize := copyPartSize(srcInfo.Size)
+	parts := make([]CompletePart, ceilFrac(srcInfo.Size, partSize))
+
+	getOpts := ObjectOptions{
+		VersionID:        srcOpts.VersionID,
+		Versioned:        srcOpts.Versioned,
+		VersionSuspended: srcOpts.VersionSuspended,
+		NoLock:           true,
+	}
+
+	copyPart := func(ctx context.Context, idx int) error {
+		start := int64(idx) * partSize
+		length := partSize
+		if start+length > srcInfo.Size {
+			length = srcInfo.Size - start
+		}
+		gr, err := z.GetObjectNInfo(ctx, srcBucket, srcObject, &HTTPRangeSpec{Start: start, End: start + length - 1}, nil, noLock, getOpts)
+		if err != nil {
+			return err
+		}
+		defer gr.Close()
+
+		hr, err := hash.NewReader(gr, length, "", "", length)
+		if err != nil {
+			return err
+		}
+		pi, err := pool.PutObjectPart(ctx, dstBucket, dstObject, uploadID, idx+1, NewPutObjReader(hr), ObjectOptions{})
+		if err != nil {
+			return err
+		}
+		parts[idx] = CompletePart{PartNumber: pi.PartNumber, ETag: pi.ETag}
+		return nil
+	}
+
+	cctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	partsCh := make(chan int, len(parts))
+	for idx := range parts {
+		partsCh <- idx
+	}
+	close(partsCh)
+
+	var (
+		wg       sync.WaitGroup
+		errOnce  sync.Once
+		firstErr error
+	)
+	workers := globalAPIConfig.getCopyConcurrency()
+	if workers > len(parts) {
+		workers = len(parts)
+	}
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for idx := range partsCh {
+				if cctx.Err() != nil {
+					return
+				}
+				if err := copyPart(cctx, idx); err != nil {
+					errOnce.Do(func() {
+						firstErr = err
+						cancel()
+					})
+					return
+				}
+			}
+		}()
+	}
+	wg.Wait()
+	if firstErr != nil {
+		return objInfo, firstErr
+	}
+
+	return pool.CompleteMultipartUpload(ctx, dstBucket, dstObject, uploadID, parts, ObjectOptions{
+		UserDefined:    map[string]string{"etag": srcInfo.ETag},
+		Versioned:      dstOpts.Versioned,
+		VersionID:      dstOpts.VersionID,
+		MTime:          dstOpts.MTime,
+		CheckPrecondFn: dstOpts.CheckPrecondFn,
+		NoLock:         true,
+	})
+}
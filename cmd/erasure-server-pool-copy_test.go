@@ -0,0 +1,92 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/dustin/go-humanize"
+)
+
+func TestCopyObjectParallel(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	obj, fsDirs, err := prepareErasure16(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer obj.Shutdown(context.Background())
+	defer removeRoots(fsDirs)
+
+	defer func(size int64, concurrency int) {
+		copyObjectPartSize = size
+		globalAPIConfig.copyConcurrency = concurrency
+	}(copyObjectPartSize, globalAPIConfig.copyConcurrency)
+	copyObjectPartSize = 5 * humanize.MiByte
+	globalAPIConfig.copyConcurrency = 4
+
+	bucket := "bucket"
+	if err = obj.MakeBucketWithLocation(ctx, bucket, BucketOptions{}); err != nil {
+		t.Fatal(err)
+	}
+
+	data := bytes.Repeat([]byte("a1b2c3d4"), 12*humanize.MiByte/8+1)
+	srcInfo, err := obj.PutObject(ctx, bucket, "src", mustGetPutObjReader(t, bytes.NewReader(data), int64(len(data)), "", ""), ObjectOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	dstInfo, err := obj.CopyObject(ctx, bucket, "src", bucket, "dst", srcInfo, ObjectOptions{}, ObjectOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if dstInfo.ETag != srcInfo.ETag {
+		t.Errorf("Expected ETag %s, got %s", srcInfo.ETag, dstInfo.ETag)
+	}
+	if dstInfo.Size != srcInfo.Size {
+		t.Errorf("Expected size %d, got %d", srcInfo.Size, dstInfo.Size)
+	}
+	if len(dstInfo.Parts) != 3 {
+		t.Errorf("Expected the copy to have 3 parts, got %d", len(dstInfo.Parts))
+	}
+
+	var buf bytes.Buffer
+	gr, err := obj.GetObjectNInfo(ctx, bucket, "dst", nil, nil, readLock, ObjectOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer gr.Close()
+	if _, err = buf.ReadFrom(gr); err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(buf.Bytes(), data) {
+		t.Error("Copied object content does not match the source")
+	}
+
+	// No multipart upload must be left behind.
+	lmi, err := obj.ListMultipartUploads(ctx, bucket, "", "", "", "", 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(lmi.Uploads) != 0 {
+		t.Errorf("Expected no pending uploads, got %d", len(lmi.Uploads))
+	}
+}
@@ -518,6 +518,13 @@ func (z *erasureServerPools) Init(ctx context.Context) error {
 			}
 		}
 
+		// Resume a rebalance interrupted by a restart.
+		if err = z.loadRebalanceMeta(ctx); err != nil {
+			logger.LogIf(ctx, fmt.Errorf("Unable to resume rebalance: %w", err))
+		} else {
+			go z.StartRebalance()
+		}
+
 		return nil
 	}
 
@@ -1026,6 +1033,18 @@ func (z *erasureServerPools) StartDecommission(ctx context.Context, idx int) (er
 		return errInvalidArgument
 	}
 
+	if z.IsRebalanceStarted() {
+		return decomError{
+			Err: "Rebalance is in progress: decommission is not allowed, stop the rebalance first",
+		}
+	}
+
+	if len(packConfig().Buckets) > 0 {
+		return decomError{
+			Err: "Small objects are packed: decommission is not allowed while 'pack' buckets are configured",
+		}
+	}
+
 	buckets, err := z.ListBuckets(ctx)
 	if err != nil {
 		return err
@@ -0,0 +1,1016 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand"
+	"net/http"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/minio/minio/internal/config/pack"
+	"github.com/minio/minio/internal/crypto"
+	"github.com/minio/minio/internal/hash"
+	"github.com/minio/minio/internal/logger"
+	"github.com/minio/pkg/env"
+)
+
+//go:generate msgp -file $GOFILE -unexported
+
+// packEntry locates the data of a packed object in its container.
+type packEntry struct {
+	Container string            `msg:"c"`
+	Offset    int64             `msg:"o"`
+	Size      int64             `msg:"s"`
+	ModTime   time.Time         `msg:"mt"`
+	Metadata  map[string]string `msg:"m"`
+}
+
+// packIndex is a shard of the index of the objects packed out of an
+// erasure set, objects are spread across the shards by name.
+type packIndex struct {
+	Entries map[string]packEntry `msg:"e"`
+}
+
+const (
+	packMetaPrefix     = ".pack"
+	packIndexShards    = 16
+	packIndexFormat    = 1
+	packIndexVersionV1 = 1
+	packIndexVersion   = packIndexVersionV1
+)
+
+//msgp:ignore packStats
+
+// packStats counts the work done by the packer of this node.
+type packStats struct {
+	objectsPacked       uint64
+	bytesPacked         uint64
+	containersWritten   uint64
+	containersCompacted uint64
+	bytesReclaimed      uint64
+}
+
+var globalPackStats packStats
+
+var (
+	packMu   sync.RWMutex
+	packCfg  pack.Config
+	packOnce sync.Once
+)
+
+// packConfig returns the small object packing configuration in effect.
+func packConfig() pack.Config {
+	packMu.RLock()
+	defer packMu.RUnlock()
+	return packCfg
+}
+
+func updatePackParams(objAPI ObjectLayer, cfg pack.Config) {
+	packMu.Lock()
+	packCfg = cfg
+	packMu.Unlock()
+
+	// Start packing the first time packing is enabled,
+	// runs are skipped while packing is disabled later.
+	if cfg.Enable && objAPI != nil {
+		packOnce.Do(func() {
+			initPacker(GlobalContext, objAPI)
+		})
+	}
+}
+
+// packedBucket returns true if bucket may hold packed objects, only
+// buckets which were never versioned are packed.
+func packedBucket(bucket string) bool {
+	if !packConfig().Packed(bucket) {
+		return false
+	}
+	return !globalBucketVersioningSys.Enabled(bucket) && !globalBucketVersioningSys.Suspended(bucket)
+}
+
+// packedLookup returns true if object is looked up in the packed
+// objects of bucket after the erasure sets returned err for it.
+func packedLookup(bucket string, opts ObjectOptions, err error) bool {
+	if !isErrObjectNotFound(err) && !isErrVersionNotFound(err) {
+		return false
+	}
+	if opts.VersionID != "" && opts.VersionID != nullVersionID {
+		return false
+	}
+	return packedBucket(bucket)
+}
+
+// packSetDir returns the directory of the containers and of the index
+// of the objects of bucket packed out of the set setIdx of a pool.
+func packSetDir(bucket string, setIdx int) string {
+	return pathJoin(bucketMetaPrefix, bucket, packMetaPrefix, strconv.Itoa(setIdx))
+}
+
+func packIndexPath(bucket string, setIdx, shard int) string {
+	return pathJoin(packSetDir(bucket, setIdx), "index", fmt.Sprintf("%02x.bin", shard))
+}
+
+func packContainerPath(bucket string, setIdx int, id string) string {
+	return pathJoin(packSetDir(bucket, setIdx), "containers", id)
+}
+
+// packShard returns the index shard of object.
+func packShard(object string) int {
+	return crcHashMod(object, packIndexShards)
+}
+
+func loadPackIndex(ctx context.Context, store objectIO, indexPath string) (*packIndex, error) {
+	idx := &packIndex{Entries: make(map[string]packEntry)}
+	data, err := readConfig(ctx, store, indexPath)
+	if err != nil {
+		if errors.Is(err, errConfigNotFound) {
+			return idx, nil
+		}
+		return nil, err
+	}
+	if len(data) <= 4 {
+		return nil, fmt.Errorf("packIndex: no data")
+	}
+	// Read header
+	switch binary.LittleEndian.Uint16(data[0:2]) {
+	case packIndexFormat:
+	default:
+		return nil, fmt.Errorf("packIndex: unknown format: %d", binary.LittleEndian.Uint16(data[0:2]))
+	}
+	switch binary.LittleEndian.Uint16(data[2:4]) {
+	case packIndexVersion:
+	default:
+		return nil, fmt.Errorf("packIndex: unknown version: %d", binary.LittleEndian.Uint16(data[2:4]))
+	}
+
+	// OK, parse data.
+	if _, err = idx.UnmarshalMsg(data[4:]); err != nil {
+		return nil, err
+	}
+	if idx.Entries == nil {
+		idx.Entries = make(map[string]packEntry)
+	}
+	return idx, nil
+}
+
+func (idx *packIndex) save(ctx context.Context, pool *erasureSets, indexPath string) error {
+	if len(idx.Entries) == 0 {
+		if err := deleteConfig(ctx, pool, indexPath); err != nil && !errors.Is(err, errConfigNotFound) {
+			return err
+		}
+		return nil
+	}
+
+	data := make([]byte, 4, idx.Msgsize()+4)
+
+	// Initialize the header.
+	binary.LittleEndian.PutUint16(data[0:2], packIndexFormat)
+	binary.LittleEndian.PutUint16(data[2:4], packIndexVersion)
+
+	buf, err := idx.MarshalMsg(data)
+	if err != nil {
+		return err
+	}
+	return saveConfig(ctx, pool, indexPath, buf)
+}
+
+// updatePackIndex calls fn with the index shard at indexPath under lock
+// and saves the shard when fn returns true.
+func updatePackIndex(ctx context.Context, pool *erasureSets, indexPath string, fn func(idx *packIndex) bool) error {
+	// The shard itself is read and saved under its own lock.
+	lk := pool.NewNSLock(minioMetaBucket, indexPath+".lock")
+	lkctx, err := lk.GetLock(ctx, globalOperationTimeout)
+	if err != nil {
+		return err
+	}
+	ctx = lkctx.Context()
+	defer lk.Unlock(lkctx.Cancel)
+
+	idx, err := loadPackIndex(ctx, pool, indexPath)
+	if err != nil {
+		return err
+	}
+	if !fn(idx) {
+		return nil
+	}
+	return idx.save(ctx, pool, indexPath)
+}
+
+func (e packEntry) toObjectInfo(bucket, object string) ObjectInfo {
+	fi := FileInfo{
+		Volume:      bucket,
+		Name:        object,
+		Size:        e.Size,
+		ModTime:     e.ModTime,
+		Metadata:    e.Metadata,
+		IsLatest:    true,
+		NumVersions: 1,
+	}
+	return fi.ToObjectInfo(bucket, object, false)
+}
+
+// getPackEntry returns the pool and set indexes and the index entry of
+// a packed object.
+func (z *erasureServerPools) getPackEntry(ctx context.Context, bucket, object string) (poolIdx, setIdx int, e packEntry, err error) {
+	for poolIdx, pool := range z.serverPools {
+		setIdx := pool.getHashedSetIndex(object)
+		idx, err := loadPackIndex(ctx, pool, packIndexPath(bucket, setIdx, packShard(object)))
+		if err != nil {
+			return -1, -1, e, err
+		}
+		if e, ok := idx.Entries[object]; ok {
+			return poolIdx, setIdx, e, nil
+		}
+	}
+	return -1, -1, e, ObjectNotFound{Bucket: bucket, Object: decodeDirObject(object)}
+}
+
+func (z *erasureServerPools) getPackedObjectInfo(ctx context.Context, bucket, object string) (ObjectInfo, error) {
+	_, _, e, err := z.getPackEntry(ctx, bucket, object)
+	if err != nil {
+		return ObjectInfo{}, err
+	}
+	return e.toObjectInfo(bucket, object), nil
+}
+
+func (z *erasureServerPools) getPackedObjectNInfo(ctx context.Context, bucket, object string, rs *HTTPRangeSpec, h http.Header, opts ObjectOptions) (*GetObjectReader, error) {
+	// A compaction may move the object to another container
+	// between the index lookup and the read of the container.
+	for retry := false; ; retry = true {
+		poolIdx, setIdx, e, err := z.getPackEntry(ctx, bucket, object)
+		if err != nil {
+			return nil, err
+		}
+		fn, off, length, err := NewGetObjectReader(rs, e.toObjectInfo(bucket, object), opts)
+		if err != nil {
+			return nil, err
+		}
+		if length == 0 {
+			return fn(bytes.NewReader(nil), h)
+		}
+		crs := &HTTPRangeSpec{Start: e.Offset + off, End: e.Offset + off + length - 1}
+		gr, err := z.serverPools[poolIdx].GetObjectNInfo(ctx, minioMetaBucket, packContainerPath(bucket, setIdx, e.Container), crs, h, readLock, ObjectOptions{})
+		if err != nil {
+			if isErrObjectNotFound(err) && !retry {
+				continue
+			}
+			return nil, err
+		}
+		return fn(gr, h, func() { gr.Close() })
+	}
+}
+
+// removePackedObject removes object from the packed objects of bucket,
+// it returns true if object was packed.
+func (z *erasureServerPools) removePackedObject(ctx context.Context, bucket, object string) (removed bool, err error) {
+	for _, pool := range z.serverPools {
+		indexPath := packIndexPath(bucket, pool.getHashedSetIndex(object), packShard(object))
+		err = updatePackIndex(ctx, pool, indexPath, func(idx *packIndex) bool {
+			if _, ok := idx.Entries[object]; !ok {
+				return false
+			}
+			delete(idx.Entries, object)
+			removed = true
+			return true
+		})
+		if err != nil {
+			return removed, err
+		}
+	}
+	return removed, nil
+}
+
+// deletePackedObject removes the packed copy of object once object was
+// deleted from the erasure sets with the result objInfo, err.
+func (z *erasureServerPools) deletePackedObject(ctx context.Context, bucket, object string, opts ObjectOptions, objInfo ObjectInfo, err error) (ObjectInfo, error) {
+	if err != nil && !packedLookup(bucket, opts, err) {
+		return objInfo, err
+	}
+	if err == nil && (!packedBucket(bucket) || (opts.VersionID != "" && opts.VersionID != nullVersionID)) {
+		return objInfo, nil
+	}
+	removed, rerr := z.removePackedObject(ctx, bucket, object)
+	if rerr != nil {
+		return objInfo, rerr
+	}
+	if removed && err != nil {
+		return ObjectInfo{Bucket: bucket, Name: decodeDirObject(object)}, nil
+	}
+	return objInfo, err
+}
+
+// deletePackedObjects removes the packed copies of objects once they
+// were deleted from the erasure sets with the results dobjects, derrs.
+func (z *erasureServerPools) deletePackedObjects(ctx context.Context, bucket string, objects []ObjectToDelete, dobjects []DeletedObject, derrs []error) {
+	if !packedBucket(bucket) {
+		return
+	}
+	for i, obj := range objects {
+		if obj.VersionID != "" && obj.VersionID != nullVersionID {
+			continue
+		}
+		if derrs[i] != nil && !isErrObjectNotFound(derrs[i]) && !isErrVersionNotFound(derrs[i]) {
+			continue
+		}
+		removed, err := z.removePackedObject(ctx, bucket, obj.ObjectName)
+		switch {
+		case err != nil:
+			derrs[i] = err
+		case removed && derrs[i] != nil:
+			derrs[i] = nil
+			dobjects[i] = DeletedObject{ObjectName: decodeDirObject(obj.ObjectName)}
+		}
+	}
+}
+
+// listPackedObjects returns the packed objects of bucket under prefix
+// and after marker sorted by name, objects past the delimiter are
+// returned as directories named after their common prefix.
+func (z *erasureServerPools) listPackedObjects(ctx context.Context, bucket, prefix, marker, delimiter string) ([]ObjectInfo, error) {
+	var objects []ObjectInfo
+	prefixes := make(map[string]struct{})
+	for _, pool := range z.serverPools {
+		for setIdx := range pool.sets {
+			for shard := 0; shard < packIndexShards; shard++ {
+				idx, err := loadPackIndex(ctx, pool, packIndexPath(bucket, setIdx, shard))
+				if err != nil {
+					return nil, err
+				}
+				for name, e := range idx.Entries {
+					if !strings.HasPrefix(name, prefix) {
+						continue
+					}
+					if delimiter != "" {
+						if i := strings.Index(name[len(prefix):], delimiter); i >= 0 {
+							if p := name[:len(prefix)+i+len(delimiter)]; p > marker {
+								prefixes[p] = struct{}{}
+							}
+							continue
+						}
+					}
+					if name > marker {
+						objects = append(objects, e.toObjectInfo(bucket, name))
+					}
+				}
+			}
+		}
+	}
+	for p := range prefixes {
+		objects = append(objects, ObjectInfo{Bucket: bucket, Name: p, IsDir: true})
+	}
+	sort.Slice(objects, func(i, j int) bool {
+		return objects[i].Name < objects[j].Name
+	})
+	return objects, nil
+}
+
+// mergePackedEntries merges the packed entries into the sorted entries
+// listed from the erasure sets, a listed entry wins over a packed entry
+// of the same name. When the listing was truncated, the packed entries
+// past its last entry are left for the next page.
+func mergePackedEntries(listed, packed []ObjectInfo, truncated bool, maxKeys int) ([]ObjectInfo, bool) {
+	names := make(map[string]struct{}, len(listed))
+	for _, oi := range listed {
+		names[oi.Name] = struct{}{}
+	}
+	merged := append([]ObjectInfo{}, listed...)
+	for _, oi := range packed {
+		if truncated && len(listed) > 0 && oi.Name > listed[len(listed)-1].Name {
+			break
+		}
+		if _, ok := names[oi.Name]; !ok {
+			merged = append(merged, oi)
+		}
+	}
+	sort.SliceStable(merged, func(i, j int) bool {
+		return merged[i].Name < merged[j].Name
+	})
+	if limit := maxKeysPlusOne(maxKeys, false); len(merged) > limit {
+		merged = merged[:limit]
+		truncated = true
+	}
+	return merged, truncated
+}
+
+// packedListEntries returns the objects and prefixes of a listing page
+// as a single list of entries sorted by name.
+func packedListEntries(objects []ObjectInfo, prefixes []string) []ObjectInfo {
+	entries := make([]ObjectInfo, 0, len(objects)+len(prefixes))
+	entries = append(entries, objects...)
+	for _, p := range prefixes {
+		entries = append(entries, ObjectInfo{Name: p, IsDir: true})
+	}
+	sort.SliceStable(entries, func(i, j int) bool {
+		return entries[i].Name < entries[j].Name
+	})
+	return entries
+}
+
+// plainListMarker returns marker without the listing id it may carry.
+func plainListMarker(marker string) string {
+	o := listPathOptions{Marker: marker}
+	o.parseMarker()
+	return o.Marker
+}
+
+// mergePackedObjects adds the packed objects of bucket to a page of
+// ListObjects results.
+func (z *erasureServerPools) mergePackedObjects(ctx context.Context, bucket, prefix, marker, delimiter string, maxKeys int, loi ListObjectsInfo) (ListObjectsInfo, error) {
+	if maxKeys == 0 {
+		return loi, nil
+	}
+	packed, err := z.listPackedObjects(ctx, bucket, prefix, plainListMarker(marker), delimiter)
+	if err != nil || len(packed) == 0 {
+		return loi, err
+	}
+
+	listed := packedListEntries(loi.Objects, loi.Prefixes)
+	entries, truncated := mergePackedEntries(listed, packed, loi.IsTruncated, maxKeys)
+
+	res := ListObjectsInfo{IsTruncated: truncated}
+	for _, oi := range entries {
+		if oi.IsDir && oi.ModTime.IsZero() && delimiter != "" {
+			res.Prefixes = append(res.Prefixes, oi.Name)
+		} else {
+			res.Objects = append(res.Objects, oi)
+		}
+	}
+	if truncated {
+		res.NextMarker = entries[len(entries)-1].Name
+		if loi.IsTruncated && len(listed) > 0 && listed[len(listed)-1].Name == res.NextMarker {
+			res.NextMarker = loi.NextMarker
+		}
+	}
+	return res, nil
+}
+
+// mergePackedObjectVersions adds the packed objects of bucket to a page
+// of ListObjectVersions results, packed objects are null versions.
+func (z *erasureServerPools) mergePackedObjectVersions(ctx context.Context, bucket, prefix, marker, delimiter string, maxKeys int, loi ListObjectVersionsInfo) (ListObjectVersionsInfo, error) {
+	if maxKeys == 0 {
+		return loi, nil
+	}
+	packed, err := z.listPackedObjects(ctx, bucket, prefix, plainListMarker(marker), delimiter)
+	if err != nil || len(packed) == 0 {
+		return loi, err
+	}
+
+	listed := packedListEntries(loi.Objects, loi.Prefixes)
+	entries, truncated := mergePackedEntries(listed, packed, loi.IsTruncated, maxKeys)
+
+	res := ListObjectVersionsInfo{IsTruncated: truncated}
+	for _, oi := range entries {
+		if oi.IsDir && oi.ModTime.IsZero() && delimiter != "" {
+			res.Prefixes = append(res.Prefixes, oi.Name)
+		} else {
+			res.Objects = append(res.Objects, oi)
+		}
+	}
+	if truncated {
+		last := entries[len(entries)-1]
+		res.NextMarker = last.Name
+		res.NextVersionIDMarker = last.VersionID
+		if loi.IsTruncated && len(listed) > 0 && listed[len(listed)-1].Name == last.Name {
+			res.NextMarker = loi.NextMarker
+			res.NextVersionIDMarker = loi.NextVersionIDMarker
+		}
+	}
+	return res, nil
+}
+
+// hasPackedObjects returns true if bucket holds packed objects.
+func (z *erasureServerPools) hasPackedObjects(ctx context.Context, bucket string) (bool, error) {
+	for _, pool := range z.serverPools {
+		for setIdx := range pool.sets {
+			for shard := 0; shard < packIndexShards; shard++ {
+				idx, err := loadPackIndex(ctx, pool, packIndexPath(bucket, setIdx, shard))
+				if err != nil {
+					return false, err
+				}
+				if len(idx.Entries) > 0 {
+					return true, nil
+				}
+			}
+		}
+	}
+	return false, nil
+}
+
+// checkPackConfig returns an error if cfg no longer lists a bucket
+// which holds packed objects, its packed objects would not be served
+// anymore. Buckets set through the environment are not checked.
+func (z *erasureServerPools) checkPackConfig(ctx context.Context, cfg pack.Config) error {
+	if v, _, _, _ := env.LookupEnv(pack.EnvBuckets); v != "" {
+		return nil
+	}
+	for _, bucket := range packConfig().Buckets {
+		if cfg.Packed(bucket) {
+			continue
+		}
+		packed, err := z.hasPackedObjects(ctx, bucket)
+		if err != nil {
+			return err
+		}
+		if packed {
+			return fmt.Errorf("'pack:buckets' value invalid: bucket %s holds packed objects and must remain listed", bucket)
+		}
+	}
+	return nil
+}
+
+// addPackedUsage adds the objects packed out of the erasure sets to the
+// data usage dui of the buckets, the scanner only walks the erasure sets.
+func (z *erasureServerPools) addPackedUsage(ctx context.Context, buckets []BucketInfo, dui *DataUsageInfo) {
+	for _, bucket := range buckets {
+		if !packedBucket(bucket.Name) {
+			continue
+		}
+		var (
+			objects, size uint64
+			sizes         sizeHistogram
+		)
+		for _, pool := range z.serverPools {
+			for setIdx := range pool.sets {
+				for shard := 0; shard < packIndexShards; shard++ {
+					idx, err := loadPackIndex(ctx, pool, packIndexPath(bucket.Name, setIdx, shard))
+					if err != nil {
+						logger.LogIf(ctx, fmt.Errorf("unable to load the packed objects of %s: %w", bucket.Name, err))
+						continue
+					}
+					for _, entry := range idx.Entries {
+						objects++
+						size += uint64(entry.Size)
+						sizes.add(entry.Size)
+					}
+				}
+			}
+		}
+		if objects == 0 {
+			continue
+		}
+		if dui.BucketsUsage == nil {
+			dui.BucketsUsage = make(map[string]BucketUsageInfo)
+		}
+		bui := dui.BucketsUsage[bucket.Name]
+		bui.Size += size
+		bui.ObjectsCount += objects
+		bui.VersionsCount += objects
+		if bui.ObjectSizesHistogram == nil {
+			bui.ObjectSizesHistogram = make(map[string]uint64)
+		}
+		for interval, count := range sizes.toMap() {
+			bui.ObjectSizesHistogram[interval] += count
+		}
+		dui.BucketsUsage[bucket.Name] = bui
+		dui.ObjectsTotalSize += size
+		dui.ObjectsTotalCount += objects
+		dui.VersionsTotalCount += objects
+	}
+}
+
+// initPacker starts packing the small objects of the erasure sets
+// owned by this node in the background.
+func initPacker(ctx context.Context, objAPI ObjectLayer) {
+	z, ok := objAPI.(*erasureServerPools)
+	if !ok {
+		return
+	}
+	go func() {
+		r := rand.New(rand.NewSource(time.Now().UnixNano()))
+		for {
+			interval := packConfig().Interval
+			// Spread the runs of the nodes over the interval.
+			duration := interval/2 + time.Duration(r.Float64()*float64(interval))
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(duration):
+			}
+			if cfg := packConfig(); cfg.Enable {
+				z.runPacker(ctx, cfg)
+			}
+		}
+	}()
+}
+
+// runPacker compacts the containers and packs the small objects of the
+// configured buckets in every erasure set whose first drive is local.
+func (z *erasureServerPools) runPacker(ctx context.Context, cfg pack.Config) {
+	for poolIdx, pool := range z.serverPools {
+		if z.IsSuspended(poolIdx) {
+			continue
+		}
+		for setIdx, set := range pool.sets {
+			if endpoints := set.getEndpoints(); len(endpoints) == 0 || !endpoints[0].IsLocal {
+				continue
+			}
+			for _, bucket := range cfg.Buckets {
+				if _, err := z.GetBucketInfo(ctx, bucket); err != nil || !packedBucket(bucket) {
+					continue
+				}
+				// Containers are compacted before packing, so that
+				// containers being written are never compacted.
+				if err := z.compactPackedSet(ctx, cfg, poolIdx, setIdx, bucket); err != nil {
+					logger.LogIf(ctx, fmt.Errorf("unable to compact the packed objects of %s: %w", bucket, err))
+				}
+				if err := z.packSet(ctx, cfg, poolIdx, setIdx, bucket); err != nil {
+					logger.LogIf(ctx, fmt.Errorf("unable to pack the objects of %s: %w", bucket, err))
+				}
+			}
+		}
+	}
+}
+
+// packable returns true if the object version fi can be packed.
+func packable(cfg pack.Config, fi FileInfo, now time.Time) bool {
+	if fi.Deleted || fi.VersionID != "" || fi.IsRemote() || len(fi.Parts) > 1 {
+		return false
+	}
+	if strings.HasSuffix(fi.Name, globalDirSuffix) || fi.Size > cfg.MaxObjectSize || now.Sub(fi.ModTime) < cfg.MinAge {
+		return false
+	}
+	if _, encrypted := crypto.IsEncrypted(fi.Metadata); encrypted {
+		return false
+	}
+	_, compressed := fi.Metadata[ReservedMetadataPrefix+"compression"]
+	return !compressed
+}
+
+// packSet packs the small objects of bucket stored in the set setIdx
+// of the pool poolIdx into containers.
+func (z *erasureServerPools) packSet(ctx context.Context, cfg pack.Config, poolIdx, setIdx int, bucket string) error {
+	set := z.serverPools[poolIdx].sets[setIdx]
+	disks := set.getOnlineDisks()
+	if len(disks) == 0 {
+		return fmt.Errorf("no online disks found for set with endpoints %s", set.getEndpoints())
+	}
+
+	var (
+		batch     []FileInfo
+		batchSize int64
+		now       = UTCNow()
+	)
+	addEntry := func(entry metaCacheEntry) {
+		if entry.isDir() {
+			return
+		}
+		fivs, err := entry.fileInfoVersions(bucket)
+		if err != nil || len(fivs.Versions) != 1 || !packable(cfg, fivs.Versions[0], now) {
+			return
+		}
+		batch = append(batch, fivs.Versions[0])
+		batchSize += fivs.Versions[0].Size
+		if batchSize >= cfg.ContainerSize {
+			z.packObjects(ctx, poolIdx, setIdx, bucket, batch)
+			batch, batchSize = nil, 0
+		}
+	}
+
+	// How to resolve partial results.
+	resolver := metadataResolutionParams{
+		dirQuorum: len(disks) / 2, // make sure to capture all quorum ratios
+		objQuorum: len(disks) / 2, // make sure to capture all quorum ratios
+		bucket:    bucket,
+	}
+	err := listPathRaw(ctx, listPathRawOptions{
+		disks:          disks,
+		bucket:         bucket,
+		recursive:      true,
+		minDisks:       len(disks) / 2, // to capture all quorum ratios
+		reportNotFound: false,
+		agreed:         addEntry,
+		partial: func(entries metaCacheEntries, nAgreed int, errs []error) {
+			if entry, ok := entries.resolve(&resolver); ok {
+				addEntry(*entry)
+			}
+		},
+	})
+	if err != nil {
+		return err
+	}
+	// A single object is not worth a container.
+	if len(batch) > 1 {
+		z.packObjects(ctx, poolIdx, setIdx, bucket, batch)
+	}
+	return nil
+}
+
+// putPackContainer writes a container made of data.
+func putPackContainer(ctx context.Context, pool *erasureSets, containerPath string, data []byte) error {
+	hr, err := hash.NewReader(bytes.NewReader(data), int64(len(data)), "", "", int64(len(data)))
+	if err != nil {
+		return err
+	}
+	_, err = pool.PutObject(ctx, minioMetaBucket, containerPath, NewPutObjReader(hr), ObjectOptions{})
+	return err
+}
+
+// addPackEntries adds entries to the index of the objects packed out
+// of a set. With replace set, an entry only replaces the entry of the
+// same object found in container at the same offset.
+func addPackEntries(ctx context.Context, pool *erasureSets, bucket string, setIdx int, entries map[string]packEntry, replace map[string]packEntry) error {
+	shards := make(map[int][]string)
+	for name := range entries {
+		shard := packShard(name)
+		shards[shard] = append(shards[shard], name)
+	}
+	for shard, names := range shards {
+		err := updatePackIndex(ctx, pool, packIndexPath(bucket, setIdx, shard), func(idx *packIndex) bool {
+			var changed bool
+			for _, name := range names {
+				if old, ok := replace[name]; ok {
+					if cur, ok := idx.Entries[name]; !ok || cur.Container != old.Container || cur.Offset != old.Offset {
+						continue
+					}
+				}
+				idx.Entries[name] = entries[name]
+				changed = true
+			}
+			return changed
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// removePackEntries removes the entries of names found in container
+// from the index of the objects packed out of a set.
+func removePackEntries(ctx context.Context, pool *erasureSets, bucket string, setIdx int, container string, names []string) error {
+	shards := make(map[int][]string)
+	for _, name := range names {
+		shard := packShard(name)
+		shards[shard] = append(shards[shard], name)
+	}
+	for shard, names := range shards {
+		err := updatePackIndex(ctx, pool, packIndexPath(bucket, setIdx, shard), func(idx *packIndex) bool {
+			var changed bool
+			for _, name := range names {
+				if cur, ok := idx.Entries[name]; ok && cur.Container == container {
+					delete(idx.Entries, name)
+					changed = true
+				}
+			}
+			return changed
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// packObjects packs the object versions batch of a set into a new
+// container and removes them from the set. Objects modified since they
+// were listed are left in place.
+func (z *erasureServerPools) packObjects(ctx context.Context, poolIdx, setIdx int, bucket string, batch []FileInfo) {
+	pool := z.serverPools[poolIdx]
+	set := pool.sets[setIdx]
+
+	id := mustGetUUID()
+	var buf bytes.Buffer
+	entries := make(map[string]packEntry, len(batch))
+	versions := make(map[string]FileInfo, len(batch))
+	for _, fi := range batch {
+		gr, err := set.GetObjectNInfo(ctx, bucket, fi.Name, nil, http.Header{}, noLock, ObjectOptions{})
+		if err != nil {
+			// Removed since it was listed.
+			continue
+		}
+		off := buf.Len()
+		n, err := io.Copy(&buf, gr)
+		gr.Close()
+		if err != nil || n != fi.Size || !gr.ObjInfo.ModTime.Equal(fi.ModTime) {
+			buf.Truncate(off)
+			continue
+		}
+		entries[fi.Name] = packEntry{
+			Container: id,
+			Offset:    int64(off),
+			Size:      n,
+			ModTime:   fi.ModTime,
+			Metadata:  fi.Metadata,
+		}
+		versions[fi.Name] = fi
+	}
+	if len(entries) == 0 {
+		return
+	}
+
+	if err := putPackContainer(ctx, pool, packContainerPath(bucket, setIdx, id), buf.Bytes()); err != nil {
+		logger.LogIf(ctx, fmt.Errorf("unable to write pack container of %s: %w", bucket, err))
+		return
+	}
+	atomic.AddUint64(&globalPackStats.containersWritten, 1)
+
+	// The objects are indexed before being removed from the set,
+	// so that they are always found in either place.
+	if err := addPackEntries(ctx, pool, bucket, setIdx, entries, nil); err != nil {
+		logger.LogIf(ctx, fmt.Errorf("unable to index packed objects of %s: %w", bucket, err))
+		return
+	}
+
+	var stale []string
+	for name, e := range entries {
+		if !z.removePackedOriginal(ctx, set, bucket, versions[name]) {
+			stale = append(stale, name)
+			continue
+		}
+		atomic.AddUint64(&globalPackStats.objectsPacked, 1)
+		atomic.AddUint64(&globalPackStats.bytesPacked, uint64(e.Size))
+	}
+	// Objects modified meanwhile are served from the set, their
+	// entries are removed and their data left to the compaction.
+	if err := removePackEntries(ctx, pool, bucket, setIdx, id, stale); err != nil {
+		logger.LogIf(ctx, fmt.Errorf("unable to remove stale packed objects of %s: %w", bucket, err))
+	}
+}
+
+// removePackedOriginal removes the packed object version fi from set
+// unless it was modified since it was packed, it returns true if the
+// version was removed.
+func (z *erasureServerPools) removePackedOriginal(ctx context.Context, set *erasureObjects, bucket string, fi FileInfo) bool {
+	lk := z.NewNSLock(bucket, fi.Name)
+	lkctx, err := lk.GetLock(ctx, globalOperationTimeout)
+	if err != nil {
+		logger.LogIf(ctx, err)
+		return false
+	}
+	ctx = lkctx.Context()
+	defer lk.Unlock(lkctx.Cancel)
+
+	cur, _, _, err := set.getObjectFileInfo(ctx, bucket, fi.Name, ObjectOptions{NoLock: true}, false)
+	if err != nil || cur.Deleted || !cur.ModTime.Equal(fi.ModTime) || cur.DataDir != fi.DataDir {
+		return false
+	}
+	err = set.deleteObjectVersion(ctx, bucket, fi.Name, set.defaultWQuorum(), FileInfo{
+		Name:      fi.Name,
+		VersionID: fi.VersionID,
+	}, false)
+	if err != nil {
+		logger.LogIf(ctx, err)
+		return false
+	}
+	NSUpdated(bucket, fi.Name)
+	return true
+}
+
+// listPackContainers returns the size of every container of the objects
+// of bucket packed out of the set setIdx of the pool poolIdx.
+func (z *erasureServerPools) listPackContainers(ctx context.Context, poolIdx, setIdx int, bucket string) (map[string]int64, error) {
+	containers := make(map[string]int64)
+	dir := pathJoin(packSetDir(bucket, setIdx), "containers") + SlashSeparator
+	addEntry := func(entry metaCacheEntry) {
+		if entry.isDir() {
+			return
+		}
+		if fi, err := entry.fileInfo(minioMetaBucket); err == nil && !fi.Deleted {
+			containers[path.Base(entry.name)] = fi.Size
+		}
+	}
+	// Containers are spread across all the sets of the pool.
+	for _, set := range z.serverPools[poolIdx].sets {
+		disks := set.getOnlineDisks()
+		if len(disks) == 0 {
+			return nil, fmt.Errorf("no online disks found for set with endpoints %s", set.getEndpoints())
+		}
+		resolver := metadataResolutionParams{
+			dirQuorum: len(disks) / 2,
+			objQuorum: len(disks) / 2,
+			bucket:    minioMetaBucket,
+		}
+		err := listPathRaw(ctx, listPathRawOptions{
+			disks:          disks,
+			bucket:         minioMetaBucket,
+			path:           dir,
+			recursive:      false,
+			minDisks:       len(disks) / 2,
+			reportNotFound: false,
+			agreed:         addEntry,
+			partial: func(entries metaCacheEntries, nAgreed int, errs []error) {
+				if entry, ok := entries.resolve(&resolver); ok {
+					addEntry(*entry)
+				}
+			},
+		})
+		if err != nil && !errors.Is(err, errFileNotFound) && !errors.Is(err, errVolumeNotFound) {
+			return nil, err
+		}
+	}
+	return containers, nil
+}
+
+// compactPackedSet removes the containers of bucket left without any
+// packed object and rewrites the containers whose fraction of deleted
+// bytes reached the compaction ratio.
+func (z *erasureServerPools) compactPackedSet(ctx context.Context, cfg pack.Config, poolIdx, setIdx int, bucket string) error {
+	pool := z.serverPools[poolIdx]
+
+	live := make(map[string]map[string]packEntry)
+	for shard := 0; shard < packIndexShards; shard++ {
+		idx, err := loadPackIndex(ctx, pool, packIndexPath(bucket, setIdx, shard))
+		if err != nil {
+			return err
+		}
+		for name, e := range idx.Entries {
+			if live[e.Container] == nil {
+				live[e.Container] = make(map[string]packEntry)
+			}
+			live[e.Container][name] = e
+		}
+	}
+
+	containers, err := z.listPackContainers(ctx, poolIdx, setIdx, bucket)
+	if err != nil {
+		return err
+	}
+	for id, size := range containers {
+		entries := live[id]
+		if len(entries) == 0 {
+			if err := deleteConfig(ctx, pool, packContainerPath(bucket, setIdx, id)); err != nil && !errors.Is(err, errConfigNotFound) {
+				return err
+			}
+			atomic.AddUint64(&globalPackStats.bytesReclaimed, uint64(size))
+			continue
+		}
+		var liveSize int64
+		for _, e := range entries {
+			liveSize += e.Size
+		}
+		if size == 0 || float64(size-liveSize)/float64(size) < cfg.CompactRatio {
+			continue
+		}
+		if err := z.compactPackContainer(ctx, poolIdx, setIdx, bucket, id, entries); err != nil {
+			return err
+		}
+		atomic.AddUint64(&globalPackStats.containersCompacted, 1)
+		atomic.AddUint64(&globalPackStats.bytesReclaimed, uint64(size-liveSize))
+	}
+	return nil
+}
+
+// compactPackContainer moves the packed objects entries out of the
+// container id to a new container and removes the container.
+func (z *erasureServerPools) compactPackContainer(ctx context.Context, poolIdx, setIdx int, bucket, id string, entries map[string]packEntry) error {
+	pool := z.serverPools[poolIdx]
+
+	data, err := readConfig(ctx, pool, packContainerPath(bucket, setIdx, id))
+	if err != nil {
+		return err
+	}
+
+	newID := mustGetUUID()
+	var buf bytes.Buffer
+	moved := make(map[string]packEntry, len(entries))
+	for name, e := range entries {
+		if e.Offset+e.Size > int64(len(data)) {
+			return fmt.Errorf("packed object %s/%s is out of the bounds of its container %s", bucket, name, id)
+		}
+		ne := e
+		ne.Container = newID
+		ne.Offset = int64(buf.Len())
+		buf.Write(data[e.Offset : e.Offset+e.Size])
+		moved[name] = ne
+	}
+	if err = putPackContainer(ctx, pool, packContainerPath(bucket, setIdx, newID), buf.Bytes()); err != nil {
+		return err
+	}
+	atomic.AddUint64(&globalPackStats.containersWritten, 1)
+
+	// Objects removed or packed again meanwhile keep their entries.
+	if err = addPackEntries(ctx, pool, bucket, setIdx, moved, entries); err != nil {
+		return err
+	}
+	if err = deleteConfig(ctx, pool, packContainerPath(bucket, setIdx, id)); err != nil && !errors.Is(err, errConfigNotFound) {
+		return err
+	}
+	return nil
+}
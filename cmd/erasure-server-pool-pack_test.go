@@ -0,0 +1,225 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"testing"
+
+	"github.com/minio/minio/internal/config/pack"
+)
+
+func TestPackObjects(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	objLayer, fsDirs, err := prepareErasurePools()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer objLayer.Shutdown(context.Background())
+	defer removeRoots(fsDirs)
+	setObjectLayer(objLayer)
+	defer setObjectLayer(nil)
+	initAllSubsystems()
+
+	bucket := "pack-bucket"
+	cfg := pack.Config{
+		Enable:        true,
+		Buckets:       []string{bucket},
+		MaxObjectSize: 64,
+		ContainerSize: 1 << 20,
+		CompactRatio:  0.25,
+	}
+	savedPackCfg := packConfig()
+	defer func() {
+		packMu.Lock()
+		packCfg = savedPackCfg
+		packMu.Unlock()
+	}()
+	packMu.Lock()
+	packCfg = cfg
+	packMu.Unlock()
+
+	z := objLayer.(*erasureServerPools)
+	if err = z.MakeBucketWithLocation(ctx, bucket, BucketOptions{}); err != nil {
+		t.Fatal(err)
+	}
+
+	src := z.serverPools[0]
+	objects := make(map[string][]byte)
+	for i := 0; i < 10; i++ {
+		object := fmt.Sprintf("dir%d/object-%d", i%2, i)
+		objects[object] = []byte(fmt.Sprintf("packed data of %s", object))
+		_, err = src.PutObject(ctx, bucket, object, mustGetPutObjReader(t, bytes.NewReader(objects[object]), int64(len(objects[object])), "", ""), ObjectOptions{})
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	// Too large to be packed.
+	large := bytes.Repeat([]byte("a"), 128)
+	_, err = src.PutObject(ctx, bucket, "large", mustGetPutObjReader(t, bytes.NewReader(large), int64(len(large)), "", ""), ObjectOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err = z.packSet(ctx, cfg, 0, 0, bucket); err != nil {
+		t.Fatal(err)
+	}
+
+	for object, data := range objects {
+		if _, err = src.GetObjectInfo(ctx, bucket, object, ObjectOptions{}); !isErrObjectNotFound(err) {
+			t.Fatalf("%s: expected object to be packed, got %v", object, err)
+		}
+		oi, err := z.GetObjectInfo(ctx, bucket, object, ObjectOptions{})
+		if err != nil {
+			t.Fatalf("%s: expected packed object info, got %v", object, err)
+		}
+		if oi.Size != int64(len(data)) {
+			t.Fatalf("%s: expected size %d, got %d", object, len(data), oi.Size)
+		}
+		gr, err := z.GetObjectNInfo(ctx, bucket, object, &HTTPRangeSpec{Start: 7, End: 10}, http.Header{}, readLock, ObjectOptions{})
+		if err != nil {
+			t.Fatalf("%s: expected packed object, got %v", object, err)
+		}
+		got, err := ioutil.ReadAll(gr)
+		gr.Close()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(got, data[7:11]) {
+			t.Fatalf("%s: expected range %q, got %q", object, data[7:11], got)
+		}
+	}
+	if _, err = src.GetObjectInfo(ctx, bucket, "large", ObjectOptions{}); err != nil {
+		t.Fatalf("expected large object to be left in place, got %v", err)
+	}
+
+	loi, err := z.ListObjects(ctx, bucket, "", "", "", 1000)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(loi.Objects) != len(objects)+1 || loi.IsTruncated {
+		t.Fatalf("expected %d objects, got %d", len(objects)+1, len(loi.Objects))
+	}
+	loi, err = z.ListObjects(ctx, bucket, "", "", SlashSeparator, 1000)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(loi.Prefixes) != 2 || len(loi.Objects) != 1 {
+		t.Fatalf("expected 2 prefixes and 1 object, got %v and %d objects", loi.Prefixes, len(loi.Objects))
+	}
+
+	// Page through the packed objects.
+	var (
+		marker string
+		listed int
+	)
+	for {
+		loi, err = z.ListObjects(ctx, bucket, "dir1/", marker, "", 2)
+		if err != nil {
+			t.Fatal(err)
+		}
+		listed += len(loi.Objects)
+		if !loi.IsTruncated {
+			break
+		}
+		marker = loi.NextMarker
+	}
+	if listed != len(objects)/2 {
+		t.Fatalf("expected %d objects under dir1/, got %d", len(objects)/2, listed)
+	}
+
+	var deleted []string
+	for object := range objects {
+		if len(deleted) == len(objects)/2 {
+			break
+		}
+		if _, err = z.DeleteObject(ctx, bucket, object, ObjectOptions{}); err != nil {
+			t.Fatalf("%s: expected packed object to be deleted, got %v", object, err)
+		}
+		if _, err = z.GetObjectInfo(ctx, bucket, object, ObjectOptions{}); !isErrObjectNotFound(err) {
+			t.Fatalf("%s: expected deleted object to be not found, got %v", object, err)
+		}
+		deleted = append(deleted, object)
+	}
+	for _, object := range deleted {
+		delete(objects, object)
+	}
+
+	if err = z.DeleteBucket(ctx, bucket, DeleteBucketOptions{}); !errors.As(err, &BucketNotEmpty{}) {
+		t.Fatalf("expected bucket with packed objects to be not empty, got %v", err)
+	}
+
+	// Buckets holding packed objects cannot be removed from the configuration.
+	if err = z.checkPackConfig(ctx, pack.Config{}); err == nil {
+		t.Fatal("expected a configuration without the bucket of the packed objects to be rejected")
+	}
+	if err = z.checkPackConfig(ctx, pack.Config{Buckets: []string{bucket}}); err != nil {
+		t.Fatalf("expected a configuration listing the bucket to be accepted, got %v", err)
+	}
+
+	// Packed objects are part of the data usage of the bucket.
+	var (
+		dui        = DataUsageInfo{BucketsUsage: map[string]BucketUsageInfo{bucket: {Size: 128, ObjectsCount: 1, VersionsCount: 1}}}
+		packedSize uint64
+	)
+	for _, data := range objects {
+		packedSize += uint64(len(data))
+	}
+	z.addPackedUsage(ctx, []BucketInfo{{Name: bucket}}, &dui)
+	if bui := dui.BucketsUsage[bucket]; bui.Size != 128+packedSize || bui.ObjectsCount != uint64(len(objects)+1) {
+		t.Fatalf("expected %d objects of %d bytes, got %d objects of %d bytes", len(objects)+1, 128+packedSize, bui.ObjectsCount, bui.Size)
+	}
+
+	if err = z.compactPackedSet(ctx, cfg, 0, 0, bucket); err != nil {
+		t.Fatal(err)
+	}
+	containers, err := z.listPackContainers(ctx, 0, 0, bucket)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var size int64
+	for _, containerSize := range containers {
+		size += containerSize
+	}
+	var liveSize int64
+	for object, data := range objects {
+		liveSize += int64(len(data))
+		gr, err := z.GetObjectNInfo(ctx, bucket, object, nil, http.Header{}, readLock, ObjectOptions{})
+		if err != nil {
+			t.Fatalf("%s: expected compacted object, got %v", object, err)
+		}
+		got, err := ioutil.ReadAll(gr)
+		gr.Close()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(got, data) {
+			t.Fatalf("%s: expected %q, got %q", object, data, got)
+		}
+	}
+	if len(containers) != 1 || size != liveSize {
+		t.Fatalf("expected a single container of %d bytes, got %d containers of %d bytes", liveSize, len(containers), size)
+	}
+}
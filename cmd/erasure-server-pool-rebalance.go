@@ -0,0 +1,915 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/minio/minio/internal/hash"
+	"github.com/minio/minio/internal/logger"
+	"github.com/minio/pkg/console"
+	"github.com/minio/pkg/env"
+)
+
+//go:generate msgp -file $GOFILE -unexported
+
+// rebalStatus is the status of the rebalance of a pool.
+type rebalStatus uint8
+
+const (
+	rebalNone rebalStatus = iota
+	rebalStarted
+	rebalCompleted
+	rebalStopped
+	rebalFailed
+)
+
+func (s rebalStatus) String() string {
+	switch s {
+	case rebalStarted:
+		return "Started"
+	case rebalCompleted:
+		return "Completed"
+	case rebalStopped:
+		return "Stopped"
+	case rebalFailed:
+		return "Failed"
+	default:
+		return "None"
+	}
+}
+
+// rebalanceStats tracks the rebalance of a pool.
+type rebalanceStats struct {
+	InitFreeSpace uint64 `msg:"ifs"` // Pool free space at the start of rebalance
+	InitCapacity  uint64 `msg:"ic"`  // Pool capacity at the start of rebalance
+
+	Participating bool        `msg:"par"`
+	Status        rebalStatus `msg:"st"`
+	StartTime     time.Time   `msg:"stt"`
+	EndTime       time.Time   `msg:"et"`
+
+	// Buckets left to rebalance and buckets rebalanced.
+	Buckets           []string `msg:"bus"`
+	RebalancedBuckets []string `msg:"rbs"`
+
+	// Last bucket/object rebalanced.
+	Bucket string `msg:"bu"`
+	Object string `msg:"ob"`
+
+	NumObjects    uint64 `msg:"no"`
+	NumVersions   uint64 `msg:"nv"`
+	Bytes         uint64 `msg:"bs"`
+	FailedObjects uint64 `msg:"fo"`
+}
+
+// bucketDone moves bucket from the buckets left to rebalance to the
+// rebalanced buckets.
+func (rs *rebalanceStats) bucketDone(bucket string) {
+	for i, b := range rs.Buckets {
+		if b == bucket {
+			rs.Buckets = append(rs.Buckets[:i], rs.Buckets[i+1:]...)
+			rs.RebalancedBuckets = append(rs.RebalancedBuckets, bucket)
+			return
+		}
+	}
+}
+
+// percentFree returns the estimated fraction of free space of the pool,
+// counting the bytes moved out of it since the start of the rebalance.
+func (rs *rebalanceStats) percentFree() float64 {
+	if rs.InitCapacity == 0 {
+		return 0
+	}
+	return float64(rs.InitFreeSpace+rs.Bytes) / float64(rs.InitCapacity)
+}
+
+type rebalanceMeta struct {
+	cancel context.CancelFunc `msg:"-"` // to stop the rebalance running on this node
+
+	ID        string    `msg:"id"`
+	StartedAt time.Time `msg:"sta"`
+	StoppedAt time.Time `msg:"stp"`
+
+	// Fraction of free space every pool is rebalanced to.
+	PercentFreeGoal float64 `msg:"pf"`
+	// Maximum bytes per second moved out of each pool, 0 for no limit.
+	Bandwidth uint64 `msg:"bw"`
+
+	PoolStats []*rebalanceStats `msg:"rss"`
+}
+
+const (
+	rebalMetaName      = "rebalance.bin"
+	rebalMetaFormat    = 1
+	rebalMetaVersionV1 = 1
+	rebalMetaVersion   = rebalMetaVersionV1
+)
+
+var (
+	errRebalanceNotStarted     = errors.New("rebalance is not started")
+	errRebalanceAlreadyStarted = errors.New("rebalance is already in progress")
+	errRebalanceNotNeeded      = errors.New("pools are already balanced, rebalance is not needed")
+	errRebalanceDecomRunning   = errors.New("decommission is in progress, rebalance is not allowed")
+)
+
+func (r *rebalanceMeta) load(ctx context.Context, store objectIO) error {
+	data, err := readConfig(ctx, store, rebalMetaName)
+	if err != nil {
+		if errors.Is(err, errConfigNotFound) {
+			return errRebalanceNotStarted
+		}
+		return err
+	}
+	if len(data) <= 4 {
+		return fmt.Errorf("rebalanceMeta: no data")
+	}
+	// Read header
+	switch binary.LittleEndian.Uint16(data[0:2]) {
+	case rebalMetaFormat:
+	default:
+		return fmt.Errorf("rebalanceMeta: unknown format: %d", binary.LittleEndian.Uint16(data[0:2]))
+	}
+	switch binary.LittleEndian.Uint16(data[2:4]) {
+	case rebalMetaVersion:
+	default:
+		return fmt.Errorf("rebalanceMeta: unknown version: %d", binary.LittleEndian.Uint16(data[2:4]))
+	}
+
+	// OK, parse data.
+	_, err = r.UnmarshalMsg(data[4:])
+	return err
+}
+
+func (r *rebalanceMeta) save(ctx context.Context, store objectIO) error {
+	data := make([]byte, 4, r.Msgsize()+4)
+
+	// Initialize the header.
+	binary.LittleEndian.PutUint16(data[0:2], rebalMetaFormat)
+	binary.LittleEndian.PutUint16(data[2:4], rebalMetaVersion)
+
+	buf, err := r.MarshalMsg(data)
+	if err != nil {
+		return err
+	}
+	return saveConfig(ctx, store, rebalMetaName, buf)
+}
+
+// running returns true when the rebalance was not stopped and some pool
+// is still being rebalanced.
+func (r *rebalanceMeta) running() bool {
+	if !r.StoppedAt.IsZero() {
+		return false
+	}
+	for _, ps := range r.PoolStats {
+		if ps.Participating && ps.Status == rebalStarted {
+			return true
+		}
+	}
+	return false
+}
+
+// rebalancePoolSpaceInfo returns the usable capacity and free space of a pool.
+func (z *erasureServerPools) rebalancePoolSpaceInfo(ctx context.Context, idx int) poolSpaceInfo {
+	info, _ := z.serverPools[idx].StorageInfo(ctx)
+	info.Backend = z.BackendInfo()
+
+	usableTotal := int64(GetTotalUsableCapacity(info.Disks, info))
+	usableFree := int64(GetTotalUsableCapacityFree(info.Disks, info))
+	return poolSpaceInfo{
+		Total: usableTotal,
+		Free:  usableFree,
+		Used:  usableTotal - usableFree,
+	}
+}
+
+// initRebalanceMeta computes the fraction of free space every pool is to
+// be rebalanced to and saves a new rebalance of the pools below it.
+func (z *erasureServerPools) initRebalanceMeta(ctx context.Context, buckets []string, bandwidth uint64) (id string, err error) {
+	if z.SinglePool() {
+		return "", errInvalidArgument
+	}
+
+	for idx := range z.serverPools {
+		if z.IsSuspended(idx) {
+			return "", errRebalanceDecomRunning
+		}
+	}
+
+	var totalCap, totalFree uint64
+	spaceInfos := make([]poolSpaceInfo, len(z.serverPools))
+	for idx := range z.serverPools {
+		spaceInfos[idx] = z.rebalancePoolSpaceInfo(ctx, idx)
+		totalCap += uint64(spaceInfos[idx].Total)
+		totalFree += uint64(spaceInfos[idx].Free)
+	}
+	if totalCap == 0 {
+		return "", errInvalidArgument
+	}
+
+	r := &rebalanceMeta{
+		ID:              mustGetUUID(),
+		StartedAt:       UTCNow(),
+		PercentFreeGoal: float64(totalFree) / float64(totalCap),
+		Bandwidth:       bandwidth,
+		PoolStats:       make([]*rebalanceStats, len(z.serverPools)),
+	}
+
+	var participating bool
+	for idx, pi := range spaceInfos {
+		ps := &rebalanceStats{
+			InitFreeSpace: uint64(pi.Free),
+			InitCapacity:  uint64(pi.Total),
+		}
+		// Only pools with less free space than the goal move
+		// objects out, to the pools with more free space.
+		if ps.percentFree() < r.PercentFreeGoal {
+			ps.Participating = true
+			ps.Status = rebalStarted
+			ps.StartTime = r.StartedAt
+			ps.Buckets = append(ps.Buckets, buckets...)
+			participating = true
+		}
+		r.PoolStats[idx] = ps
+	}
+	if !participating {
+		return "", errRebalanceNotNeeded
+	}
+
+	if err = r.save(ctx, z.serverPools[0]); err != nil {
+		return "", err
+	}
+
+	z.rebalMu.Lock()
+	z.rebalMeta = r
+	z.rebalMu.Unlock()
+	return r.ID, nil
+}
+
+// loadRebalanceMeta loads the rebalance saved on disk, a running
+// rebalance on this node keeps running.
+func (z *erasureServerPools) loadRebalanceMeta(ctx context.Context) error {
+	r := &rebalanceMeta{}
+	if err := r.load(ctx, z.serverPools[0]); err != nil {
+		if errors.Is(err, errRebalanceNotStarted) {
+			return nil
+		}
+		return err
+	}
+	if len(r.PoolStats) != len(z.serverPools) {
+		// Pools were added or removed since, the rebalance
+		// can't be resumed.
+		return nil
+	}
+
+	z.rebalMu.Lock()
+	defer z.rebalMu.Unlock()
+
+	if z.rebalMeta != nil && z.rebalMeta.ID == r.ID {
+		// Keep the stats of the pools rebalanced by this node,
+		// which are ahead of the ones on disk.
+		z.rebalMeta.keepLocalStats(r, -1)
+		r.cancel = z.rebalMeta.cancel
+	}
+	z.rebalMeta = r
+	return nil
+}
+
+// keepLocalStats copies to nr the stats of the pools being rebalanced by
+// this node, except for the pool skipIdx.
+func (r *rebalanceMeta) keepLocalStats(nr *rebalanceMeta, skipIdx int) {
+	for idx, ps := range r.PoolStats {
+		if idx != skipIdx && r.cancel != nil && ps.Status == rebalStarted && globalEndpoints[idx].Endpoints[0].IsLocal {
+			nr.PoolStats[idx] = ps
+		}
+	}
+}
+
+// IsRebalanceStarted returns true when a rebalance is in progress.
+func (z *erasureServerPools) IsRebalanceStarted() bool {
+	z.rebalMu.RLock()
+	defer z.rebalMu.RUnlock()
+
+	return z.rebalMeta != nil && z.rebalMeta.running()
+}
+
+// StartRebalance starts rebalancing the pools whose first endpoint is
+// local, every other pool is rebalanced by the node hosting its first
+// endpoint.
+func (z *erasureServerPools) StartRebalance() {
+	z.rebalMu.Lock()
+	if z.rebalMeta == nil || !z.rebalMeta.running() || z.rebalMeta.cancel != nil {
+		z.rebalMu.Unlock()
+		return
+	}
+	ctx, cancel := context.WithCancel(GlobalContext)
+	z.rebalMeta.cancel = cancel
+
+	var pools []int
+	for idx, ps := range z.rebalMeta.PoolStats {
+		if ps.Participating && ps.Status == rebalStarted && globalEndpoints[idx].Endpoints[0].IsLocal {
+			pools = append(pools, idx)
+		}
+	}
+	z.rebalMu.Unlock()
+
+	// Generate an empty request info so it can be directly modified later by audit
+	ctx = logger.SetReqInfo(ctx, &logger.ReqInfo{})
+
+	for _, idx := range pools {
+		go z.rebalanceBuckets(ctx, idx)
+	}
+}
+
+// StopRebalance stops the rebalance running on this node.
+func (z *erasureServerPools) StopRebalance() error {
+	z.rebalMu.Lock()
+	defer z.rebalMu.Unlock()
+
+	r := z.rebalMeta
+	if r == nil {
+		return errRebalanceNotStarted
+	}
+	if r.cancel != nil {
+		r.cancel()
+		r.cancel = nil
+	}
+	return nil
+}
+
+//msgp:ignore rebalSaveOpt
+type rebalSaveOpt uint8
+
+const (
+	rebalSaveStats rebalSaveOpt = iota
+	rebalSaveStoppedAt
+)
+
+// saveRebalanceStats saves the stats of the pool rebalanced by this node,
+// or the time the rebalance was stopped at, to the rebalance on disk
+// which is shared with the nodes rebalancing the other pools.
+func (z *erasureServerPools) saveRebalanceStats(ctx context.Context, poolIdx int, opt rebalSaveOpt) error {
+	// readConfig and saveConfig lock rebalMetaName itself.
+	lock := z.serverPools[0].NewNSLock(minioMetaBucket, rebalMetaName+".lock")
+	lkCtx, err := lock.GetLock(ctx, globalOperationTimeout)
+	if err != nil {
+		return err
+	}
+	defer lock.Unlock(lkCtx.Cancel)
+
+	ctx = lkCtx.Context()
+	r := &rebalanceMeta{}
+	if err = r.load(ctx, z.serverPools[0]); err != nil {
+		return err
+	}
+
+	z.rebalMu.Lock()
+	defer z.rebalMu.Unlock()
+
+	if z.rebalMeta == nil || z.rebalMeta.ID != r.ID || len(r.PoolStats) != len(z.serverPools) {
+		// A newer rebalance was started meanwhile.
+		return errRebalanceNotStarted
+	}
+
+	switch opt {
+	case rebalSaveStats:
+		ps := *z.rebalMeta.PoolStats[poolIdx]
+		r.PoolStats[poolIdx] = &ps
+	case rebalSaveStoppedAt:
+		r.StoppedAt = UTCNow()
+	}
+	if err = r.save(ctx, z.serverPools[0]); err != nil {
+		return err
+	}
+
+	// Keep the stats of the other pools rebalanced by this node,
+	// which are ahead of the ones on disk.
+	z.rebalMeta.keepLocalStats(r, poolIdx)
+	r.cancel = z.rebalMeta.cancel
+	z.rebalMeta = r
+	return nil
+}
+
+// nextRebalBucket returns the next bucket to rebalance out of a pool.
+func (z *erasureServerPools) nextRebalBucket(poolIdx int) (string, bool) {
+	z.rebalMu.RLock()
+	defer z.rebalMu.RUnlock()
+
+	ps := z.rebalMeta.PoolStats[poolIdx]
+	if ps.Status != rebalStarted || len(ps.Buckets) == 0 {
+		return "", false
+	}
+	return ps.Buckets[0], true
+}
+
+// checkIfRebalanceDone returns true when a pool reached the fraction of
+// free space it is rebalanced to.
+func (z *erasureServerPools) checkIfRebalanceDone(poolIdx int) bool {
+	z.rebalMu.RLock()
+	defer z.rebalMu.RUnlock()
+
+	return z.rebalMeta.PoolStats[poolIdx].percentFree() >= z.rebalMeta.PercentFreeGoal
+}
+
+func (z *erasureServerPools) setRebalanceStatus(poolIdx int, status rebalStatus) {
+	z.rebalMu.Lock()
+	defer z.rebalMu.Unlock()
+
+	ps := z.rebalMeta.PoolStats[poolIdx]
+	ps.Status = status
+	ps.EndTime = UTCNow()
+}
+
+func (z *erasureServerPools) rebalanceBuckets(ctx context.Context, poolIdx int) {
+	status := rebalCompleted
+	for {
+		if z.checkIfRebalanceDone(poolIdx) {
+			break
+		}
+		bucket, ok := z.nextRebalBucket(poolIdx)
+		if !ok {
+			break
+		}
+		if serverDebugLog {
+			console.Debugln("rebalance: currently on bucket", bucket)
+		}
+		if err := z.rebalanceBucket(ctx, bucket, poolIdx); err != nil {
+			if errors.Is(err, context.Canceled) {
+				status = rebalStopped
+			} else {
+				logger.LogIf(GlobalContext, err)
+				status = rebalFailed
+			}
+			break
+		}
+
+		z.rebalMu.Lock()
+		z.rebalMeta.PoolStats[poolIdx].bucketDone(bucket)
+		z.rebalMu.Unlock()
+		logger.LogIf(GlobalContext, z.saveRebalanceStats(GlobalContext, poolIdx, rebalSaveStats))
+	}
+
+	z.setRebalanceStatus(poolIdx, status)
+	logger.LogIf(GlobalContext, z.saveRebalanceStats(GlobalContext, poolIdx, rebalSaveStats))
+	globalNotificationSys.LoadRebalanceMeta(GlobalContext, false)
+}
+
+// getRebalanceDestPool returns the pool an object rebalanced out of
+// poolIdx is moved to, among the pools not being rebalanced, -1 when
+// none of them has space for it.
+func (z *erasureServerPools) getRebalanceDestPool(ctx context.Context, poolIdx int, bucket, object string, size int64) int {
+	z.rebalMu.RLock()
+	participating := make([]bool, len(z.rebalMeta.PoolStats))
+	for idx, ps := range z.rebalMeta.PoolStats {
+		participating[idx] = ps.Participating
+	}
+	z.rebalMu.RUnlock()
+
+	serverPools := z.getServerPoolsAvailableSpace(ctx, bucket, object, size)
+	for i := range serverPools {
+		if i == poolIdx || participating[i] {
+			serverPools[i].Available = 0
+		}
+	}
+	serverPools.FilterMaxUsed(100 - (100 * diskReserveFraction))
+	return serverPools.randomIdx(ctx)
+}
+
+func (z *erasureServerPools) rebalanceBucket(ctx context.Context, bucket string, poolIdx int) error {
+	pool := z.serverPools[poolIdx]
+
+	wStr := env.Get("_MINIO_REBALANCE_WORKERS", strconv.Itoa(len(pool.sets)))
+	workerSize, err := strconv.Atoi(wStr)
+	if err != nil {
+		return err
+	}
+
+	z.rebalMu.RLock()
+	bandwidth := z.rebalMeta.Bandwidth
+	z.rebalMu.RUnlock()
+	throttle := &dynamicBandwidth{limit: func() uint64 {
+		return bandwidth
+	}}
+
+	// Canceled as soon as the pool reached its goal.
+	rctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var wg sync.WaitGroup
+	parallelWorkers := make(chan struct{}, workerSize)
+	for _, set := range pool.sets {
+		set := set
+		disks := set.getOnlineDisks()
+		if len(disks) == 0 {
+			logger.LogIf(GlobalContext, fmt.Errorf("no online disks found for set with endpoints %s",
+				set.getEndpoints()))
+			continue
+		}
+
+		rebalanceEntry := func(entry metaCacheEntry) {
+			defer func() {
+				<-parallelWorkers
+				wg.Done()
+			}()
+
+			if entry.isDir() {
+				return
+			}
+			if z.checkIfRebalanceDone(poolIdx) {
+				cancel()
+				return
+			}
+
+			fivs, err := entry.fileInfoVersions(bucket)
+			if err != nil {
+				return
+			}
+			size := z.rebalanceEntry(rctx, poolIdx, set, bucket, fivs)
+			throttle.waitBytes(rctx, size)
+		}
+
+		// How to resolve partial results.
+		resolver := metadataResolutionParams{
+			dirQuorum: len(disks) / 2, // make sure to capture all quorum ratios
+			objQuorum: len(disks) / 2, // make sure to capture all quorum ratios
+			bucket:    bucket,
+		}
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			err := listPathRaw(rctx, listPathRawOptions{
+				disks:          disks,
+				bucket:         bucket,
+				recursive:      true,
+				minDisks:       len(disks) / 2, // to capture all quorum ratios
+				reportNotFound: false,
+				agreed: func(entry metaCacheEntry) {
+					parallelWorkers <- struct{}{}
+					wg.Add(1)
+					go rebalanceEntry(entry)
+				},
+				partial: func(entries metaCacheEntries, nAgreed int, errs []error) {
+					entry, ok := entries.resolve(&resolver)
+					if ok {
+						parallelWorkers <- struct{}{}
+						wg.Add(1)
+						go rebalanceEntry(*entry)
+					}
+				},
+				finished: nil,
+			})
+			if err != nil && !errors.Is(err, context.Canceled) {
+				logger.LogIf(ctx, err)
+			}
+		}()
+	}
+	wg.Wait()
+	return ctx.Err()
+}
+
+// rebalanceEntry moves all the versions of an object out of the set
+// of the pool being rebalanced to another pool and returns the number
+// of bytes moved.
+func (z *erasureServerPools) rebalanceEntry(ctx context.Context, poolIdx int, set *erasureObjects, bucket string, fivs FileInfoVersions) (moved int64) {
+	if len(fivs.Versions) == 0 {
+		return 0
+	}
+
+	var size int64
+	for _, version := range fivs.Versions {
+		// Transitioned versions have their content on the remote
+		// tier, all versions of an object are kept together.
+		if version.IsRemote() {
+			return 0
+		}
+		size += version.Size
+	}
+
+	object := encodeDirObject(fivs.Name)
+	dstIdx := z.getRebalanceDestPool(ctx, poolIdx, bucket, object, size)
+	if dstIdx < 0 {
+		logger.LogIf(ctx, fmt.Errorf("no pool has space left to rebalance %s/%s", bucket, fivs.Name))
+		z.countRebalanceFailure(poolIdx)
+		return 0
+	}
+	dstSet := z.serverPools[dstIdx].getHashedSet(object)
+
+	latest := fivs.Versions[0]
+	versions := make([]FileInfo, len(fivs.Versions))
+	copy(versions, fivs.Versions)
+
+	// We need a reversed order for rebalancing,
+	// to create the appropriate stack.
+	versionsSorter(versions).reverse()
+
+	var copied []FileInfo
+	err := func() error {
+		for _, version := range versions {
+			if version.Deleted {
+				dm := FileInfo{
+					Name:             object,
+					VersionID:        version.VersionID,
+					Deleted:          true,
+					ModTime:          version.ModTime,
+					ReplicationState: version.ReplicationState,
+				}
+				if err := dstSet.deleteObjectVersion(ctx, bucket, object, dstSet.defaultWQuorum(), dm, true); err != nil {
+					return err
+				}
+				copied = append(copied, version)
+				continue
+			}
+
+			versionID := version.VersionID
+			if versionID == "" {
+				versionID = nullVersionID
+			}
+			// gr.Close() is ensured by rebalanceObject().
+			gr, err := set.GetObjectNInfo(ctx,
+				bucket,
+				object,
+				nil,
+				http.Header{},
+				noLock, // the versions are only removed once verified under lock.
+				ObjectOptions{
+					VersionID: versionID,
+				})
+			if err != nil {
+				return err
+			}
+			if err = z.rebalanceObject(ctx, dstIdx, bucket, gr); err != nil {
+				return err
+			}
+			copied = append(copied, version)
+		}
+		return nil
+	}()
+
+	// Remove the versions from the pool they were copied out of, unless
+	// the object was modified meanwhile, then remove the copies instead.
+	lk := z.NewNSLock(bucket, object)
+	lkctx, lerr := lk.GetLock(ctx, globalOperationTimeout)
+	if lerr != nil {
+		logger.LogIf(ctx, lerr)
+		z.countRebalanceFailure(poolIdx)
+		return 0
+	}
+	defer lk.Unlock(lkctx.Cancel)
+
+	if err == nil {
+		fi, _, _, ferr := set.getObjectFileInfo(lkctx.Context(), bucket, object, ObjectOptions{NoLock: true}, false)
+		switch {
+		case ferr != nil:
+			err = ferr
+		case fi.VersionID != latest.VersionID || !fi.ModTime.Equal(latest.ModTime):
+			err = fmt.Errorf("%s/%s was modified while being rebalanced", bucket, fivs.Name)
+		}
+	}
+
+	delSet := set
+	if err != nil {
+		// Leave the object in place and remove the copies.
+		delSet = dstSet
+	}
+	for _, version := range copied {
+		derr := delSet.deleteObjectVersion(lkctx.Context(), bucket, object, delSet.defaultWQuorum(), FileInfo{
+			Name:      object,
+			VersionID: version.VersionID,
+		}, false)
+		if derr != nil && !isErrObjectNotFound(derr) && !isErrVersionNotFound(derr) {
+			logger.LogIf(ctx, derr)
+		}
+	}
+	if len(copied) > 0 {
+		NSUpdated(bucket, object)
+	}
+	auditLogRebalance(ctx, "Rebalance", bucket, fivs.Name, err)
+
+	if err != nil {
+		if !isErrObjectNotFound(err) && !isErrVersionNotFound(err) {
+			logger.LogIf(ctx, err)
+			z.countRebalanceFailure(poolIdx)
+		}
+		return 0
+	}
+
+	z.rebalMu.Lock()
+	ps := z.rebalMeta.PoolStats[poolIdx]
+	ps.NumObjects++
+	ps.NumVersions += uint64(len(versions))
+	ps.Bytes += uint64(size)
+	ps.Bucket = bucket
+	ps.Object = fivs.Name
+	z.rebalMu.Unlock()
+	return size
+}
+
+func (z *erasureServerPools) countRebalanceFailure(poolIdx int) {
+	z.rebalMu.Lock()
+	z.rebalMeta.PoolStats[poolIdx].FailedObjects++
+	z.rebalMu.Unlock()
+}
+
+// rebalanceObject copies an object version to the pool dstIdx.
+func (z *erasureServerPools) rebalanceObject(ctx context.Context, dstIdx int, bucket string, gr *GetObjectReader) error {
+	defer gr.Close()
+
+	objInfo := gr.ObjInfo
+	object := encodeDirObject(objInfo.Name)
+	pool := z.serverPools[dstIdx]
+
+	if objInfo.isMultipart() {
+		uploadID, err := pool.NewMultipartUpload(ctx, bucket, object, ObjectOptions{
+			VersionID:   objInfo.VersionID,
+			MTime:       objInfo.ModTime,
+			UserDefined: objInfo.UserDefined,
+		})
+		if err != nil {
+			return err
+		}
+		defer pool.AbortMultipartUpload(ctx, bucket, object, uploadID, ObjectOptions{})
+		parts := make([]CompletePart, len(objInfo.Parts))
+		for i, part := range objInfo.Parts {
+			hr, err := hash.NewReader(gr, part.Size, "", "", part.Size)
+			if err != nil {
+				return err
+			}
+			pi, err := pool.PutObjectPart(ctx, bucket, object, uploadID,
+				part.Number,
+				NewPutObjReader(hr),
+				ObjectOptions{})
+			if err != nil {
+				return err
+			}
+			parts[i] = CompletePart{
+				ETag:       pi.ETag,
+				PartNumber: pi.PartNumber,
+			}
+		}
+		_, err = pool.CompleteMultipartUpload(ctx, bucket, object, uploadID, parts, ObjectOptions{
+			MTime: objInfo.ModTime,
+		})
+		return err
+	}
+
+	hr, err := hash.NewReader(gr, objInfo.Size, "", "", objInfo.Size)
+	if err != nil {
+		return err
+	}
+	_, err = pool.PutObject(ctx,
+		bucket,
+		object,
+		NewPutObjReader(hr),
+		ObjectOptions{
+			VersionID:   objInfo.VersionID,
+			MTime:       objInfo.ModTime,
+			UserDefined: objInfo.UserDefined,
+		})
+	return err
+}
+
+func auditLogRebalance(ctx context.Context, apiName, bucket, object string, err error) {
+	errStr := ""
+	if err != nil {
+		errStr = err.Error()
+	}
+	auditLogInternal(ctx, bucket, object, AuditLogOptions{
+		Trigger: "rebalance",
+		APIName: apiName,
+		Error:   errStr,
+	})
+}
+
+// localRebalanceStats returns a copy of the stats of the pools
+// rebalanced by this node, indexed by pool.
+func (z *erasureServerPools) localRebalanceStats() map[int]rebalanceStats {
+	z.rebalMu.RLock()
+	defer z.rebalMu.RUnlock()
+
+	if z.rebalMeta == nil {
+		return nil
+	}
+	stats := make(map[int]rebalanceStats)
+	for idx, ps := range z.rebalMeta.PoolStats {
+		if ps.Participating && globalEndpoints[idx].Endpoints[0].IsLocal {
+			stats[idx] = *ps
+		}
+	}
+	return stats
+}
+
+//msgp:ignore rebalancePoolStatus rebalancePoolProgress rebalanceStatus
+
+// rebalancePoolStatus is the rebalance status of a pool returned by the
+// rebalance status admin API.
+type rebalancePoolStatus struct {
+	ID          int                    `json:"id"`
+	Status      string                 `json:"status"`
+	Used        float64                `json:"used"`
+	PercentFree float64                `json:"percentFree"`
+	Progress    *rebalancePoolProgress `json:"progress,omitempty"`
+}
+
+type rebalancePoolProgress struct {
+	NumObjects    uint64  `json:"objects"`
+	NumVersions   uint64  `json:"versions"`
+	Bytes         uint64  `json:"bytes"`
+	FailedObjects uint64  `json:"failedObjects"`
+	Bucket        string  `json:"bucket"`
+	Object        string  `json:"object"`
+	Elapsed       float64 `json:"elapsedSeconds"`
+	ETA           float64 `json:"etaSeconds"`
+}
+
+type rebalanceStatus struct {
+	ID              string                `json:"id"`
+	PercentFreeGoal float64               `json:"percentFreeGoal"`
+	Bandwidth       uint64                `json:"bandwidth"`
+	StoppedAt       *time.Time            `json:"stoppedAt,omitempty"`
+	Pools           []rebalancePoolStatus `json:"pools"`
+}
+
+// RebalanceStatus returns the progress of the rebalance of each pool.
+func (z *erasureServerPools) RebalanceStatus(ctx context.Context) (st rebalanceStatus, err error) {
+	r := &rebalanceMeta{}
+	if err = r.load(ctx, z.serverPools[0]); err != nil {
+		return st, err
+	}
+	if len(r.PoolStats) != len(z.serverPools) {
+		return st, errRebalanceNotStarted
+	}
+
+	st = rebalanceStatus{
+		ID:              r.ID,
+		PercentFreeGoal: r.PercentFreeGoal,
+		Bandwidth:       r.Bandwidth,
+		Pools:           make([]rebalancePoolStatus, len(r.PoolStats)),
+	}
+	if !r.StoppedAt.IsZero() {
+		st.StoppedAt = &r.StoppedAt
+	}
+
+	now := UTCNow()
+	for idx, ps := range r.PoolStats {
+		pi := z.rebalancePoolSpaceInfo(ctx, idx)
+		pst := rebalancePoolStatus{
+			ID:     idx,
+			Status: ps.Status.String(),
+		}
+		if pi.Total > 0 {
+			pst.Used = float64(pi.Used) / float64(pi.Total)
+			pst.PercentFree = float64(pi.Free) / float64(pi.Total)
+		}
+		if ps.Participating {
+			if ps.Status == rebalStarted && !r.StoppedAt.IsZero() {
+				pst.Status = rebalStopped.String()
+			}
+			end := now
+			if ps.Status != rebalStarted {
+				end = ps.EndTime
+			}
+			elapsed := end.Sub(ps.StartTime).Seconds()
+			pst.Progress = &rebalancePoolProgress{
+				NumObjects:    ps.NumObjects,
+				NumVersions:   ps.NumVersions,
+				Bytes:         ps.Bytes,
+				FailedObjects: ps.FailedObjects,
+				Bucket:        ps.Bucket,
+				Object:        ps.Object,
+				Elapsed:       elapsed,
+			}
+			if ps.Status == rebalStarted && ps.Bytes > 0 && elapsed > 0 {
+				// Estimate from the bytes left to move at the rate
+				// they were moved at so far.
+				left := r.PercentFreeGoal*float64(ps.InitCapacity) - float64(ps.InitFreeSpace+ps.Bytes)
+				pst.Progress.ETA = math.Max(0, left/(float64(ps.Bytes)/elapsed))
+			}
+		}
+		st.Pools[idx] = pst
+	}
+	return st, nil
+}
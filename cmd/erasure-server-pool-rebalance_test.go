@@ -0,0 +1,159 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"testing"
+)
+
+func TestRebalanceStatsPercentFree(t *testing.T) {
+	rs := rebalanceStats{
+		InitFreeSpace: 20,
+		InitCapacity:  100,
+		Buckets:       []string{"a", "b"},
+	}
+	if pf := rs.percentFree(); pf != 0.2 {
+		t.Fatalf("expected 0.2 free, got %v", pf)
+	}
+	rs.Bytes = 30
+	if pf := rs.percentFree(); pf != 0.5 {
+		t.Fatalf("expected 0.5 free, got %v", pf)
+	}
+
+	rs.bucketDone("a")
+	if len(rs.Buckets) != 1 || rs.Buckets[0] != "b" {
+		t.Fatalf("unexpected buckets left %v", rs.Buckets)
+	}
+	if len(rs.RebalancedBuckets) != 1 || rs.RebalancedBuckets[0] != "a" {
+		t.Fatalf("unexpected rebalanced buckets %v", rs.RebalancedBuckets)
+	}
+}
+
+func TestRebalanceBuckets(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	objLayer, fsDirs, err := prepareErasurePools()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer objLayer.Shutdown(context.Background())
+	defer removeRoots(fsDirs)
+
+	initAllTestSubsystems(t)
+	savedNotificationSys := globalNotificationSys
+	defer func() { globalNotificationSys = savedNotificationSys }()
+	globalNotificationSys = NewNotificationSys(EndpointServerPools{})
+
+	z := objLayer.(*erasureServerPools)
+	bucket := "rebalance-bucket"
+	if err = z.MakeBucketWithLocation(ctx, bucket, BucketOptions{}); err != nil {
+		t.Fatal(err)
+	}
+
+	data := []byte("rebalance")
+	src := z.serverPools[0]
+	for i := 0; i < 10; i++ {
+		object := fmt.Sprintf("object-%d", i)
+		_, err = src.PutObject(ctx, bucket, object, mustGetPutObjReader(t, bytes.NewReader(data), int64(len(data)), "", ""), ObjectOptions{})
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// An object with two versions and a delete marker.
+	var versionIDs []string
+	for i := 0; i < 2; i++ {
+		oi, err := src.PutObject(ctx, bucket, "versioned", mustGetPutObjReader(t, bytes.NewReader(data), int64(len(data)), "", ""), ObjectOptions{Versioned: true})
+		if err != nil {
+			t.Fatal(err)
+		}
+		versionIDs = append(versionIDs, oi.VersionID)
+	}
+	if _, err = src.DeleteObject(ctx, bucket, "versioned", ObjectOptions{Versioned: true}); err != nil {
+		t.Fatal(err)
+	}
+
+	r := &rebalanceMeta{
+		ID:              mustGetUUID(),
+		StartedAt:       UTCNow(),
+		PercentFreeGoal: 0.5,
+		PoolStats: []*rebalanceStats{
+			{
+				InitCapacity:  1 << 40,
+				Participating: true,
+				Status:        rebalStarted,
+				StartTime:     UTCNow(),
+				Buckets:       []string{bucket},
+			},
+			{
+				InitFreeSpace: 1 << 40,
+				InitCapacity:  1 << 40,
+			},
+		},
+	}
+	if err = r.save(ctx, src); err != nil {
+		t.Fatal(err)
+	}
+	z.rebalMeta = r
+
+	z.rebalanceBuckets(ctx, 0)
+
+	dst := z.serverPools[1]
+	for i := 0; i < 10; i++ {
+		object := fmt.Sprintf("object-%d", i)
+		if _, err = src.GetObjectInfo(ctx, bucket, object, ObjectOptions{}); !isErrObjectNotFound(err) {
+			t.Fatalf("%s: expected object to be moved out of the first pool, got %v", object, err)
+		}
+		if _, err = dst.GetObjectInfo(ctx, bucket, object, ObjectOptions{}); err != nil {
+			t.Fatalf("%s: expected object to be moved to the second pool, got %v", object, err)
+		}
+	}
+	for _, versionID := range versionIDs {
+		if _, err = src.GetObjectInfo(ctx, bucket, "versioned", ObjectOptions{VersionID: versionID}); err == nil {
+			t.Fatalf("version %s: expected version to be moved out of the first pool", versionID)
+		}
+		if _, err = dst.GetObjectInfo(ctx, bucket, "versioned", ObjectOptions{VersionID: versionID}); err != nil {
+			t.Fatalf("version %s: expected version to be moved to the second pool, got %v", versionID, err)
+		}
+	}
+	if oi, err := dst.GetObjectInfo(ctx, bucket, "versioned", ObjectOptions{}); !isErrObjectNotFound(err) || !oi.DeleteMarker {
+		t.Fatalf("expected the delete marker to be moved to the second pool, got %v", err)
+	}
+
+	st, err := z.RebalanceStatus(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if st.ID != r.ID || len(st.Pools) != 2 {
+		t.Fatalf("unexpected rebalance status %#v", st)
+	}
+	pst := st.Pools[0]
+	if pst.Status != rebalCompleted.String() || pst.Progress == nil {
+		t.Fatalf("expected first pool rebalance to be completed, got %#v", pst)
+	}
+	if pst.Progress.NumObjects != 11 || pst.Progress.NumVersions != 13 || pst.Progress.FailedObjects != 0 {
+		t.Fatalf("unexpected rebalance progress %#v", pst.Progress)
+	}
+	if st.Pools[1].Status != rebalNone.String() || st.Pools[1].Progress != nil {
+		t.Fatalf("expected second pool not to be rebalanced, got %#v", st.Pools[1])
+	}
+}
@@ -53,6 +53,9 @@ type erasureServerPools struct {
 
 	// Active decommission canceler
 	decommissionCancelers []context.CancelFunc
+
+	rebalMu   sync.RWMutex
+	rebalMeta *rebalanceMeta
 }
 
 func (z *erasureServerPools) SinglePool() bool {
@@ -298,14 +301,20 @@ func (p serverPoolsAvailableSpace) FilterMaxUsed(max int) {
 func (z *erasureServerPools) getAvailablePoolIdx(ctx context.Context, bucket, object string, size int64) int {
 	serverPools := z.getServerPoolsAvailableSpace(ctx, bucket, object, size)
 	serverPools.FilterMaxUsed(100 - (100 * diskReserveFraction))
-	total := serverPools.TotalAvailable()
+	return serverPools.randomIdx(ctx)
+}
+
+// randomIdx returns the index of a pool chosen at random, weighted by
+// its available space, -1 is returned if no pool has available space.
+func (p serverPoolsAvailableSpace) randomIdx(ctx context.Context) int {
+	total := p.TotalAvailable()
 	if total == 0 {
 		return -1
 	}
 	// choose when we reach this many
 	choose := rand.Uint64() % total
 	atTotal := uint64(0)
-	for _, pool := range serverPools {
+	for _, pool := range p {
 		atTotal += pool.Available
 		if atTotal > choose && pool.Available > 0 {
 			return pool.Index
@@ -682,7 +691,9 @@ func (z *erasureServerPools) NSScanner(ctx context.Context, bf *bloomFilter, upd
 				allMerged.merge(info)
 			}
 			if allMerged.root() != nil && allMerged.Info.LastUpdate.After(lastUpdate) {
-				updates <- allMerged.dui(allMerged.Info.Name, allBuckets)
+				dui := allMerged.dui(allMerged.Info.Name, allBuckets)
+				z.addPackedUsage(ctx, allBuckets, &dui)
+				updates <- dui
 				lastUpdate = allMerged.Info.LastUpdate
 			}
 		}
@@ -783,7 +794,11 @@ func (z *erasureServerPools) GetObjectNInfo(ctx context.Context, bucket, object
 	object = encodeDirObject(object)
 
 	if z.SinglePool() {
-		return z.serverPools[0].GetObjectNInfo(ctx, bucket, object, rs, h, lockType, opts)
+		gr, err = z.serverPools[0].GetObjectNInfo(ctx, bucket, object, rs, h, lockType, opts)
+		if packedLookup(bucket, opts, err) {
+			return z.getPackedObjectNInfo(ctx, bucket, object, rs, h, opts)
+		}
+		return gr, err
 	}
 
 	var unlockOnDefer bool
@@ -832,6 +847,18 @@ func (z *erasureServerPools) GetObjectNInfo(ctx context.Context, bucket, object
 				ObjInfo: objInfo,
 			}, toObjectErr(errMethodNotAllowed, bucket, object)
 		}
+		if packedLookup(bucket, opts, err) {
+			opts.CheckPrecondFn = checkPrecondFn
+			gr, err = z.getPackedObjectNInfo(ctx, bucket, object, rs, h, opts)
+			if err != nil {
+				return nil, err
+			}
+			if unlockOnDefer {
+				unlockOnDefer = false
+				return gr.WithCleanupFuncs(nsUnlocker), nil
+			}
+			return gr, nil
+		}
 		return nil, err
 	}
 
@@ -919,7 +946,11 @@ func (z *erasureServerPools) GetObjectInfo(ctx context.Context, bucket, object s
 	object = encodeDirObject(object)
 
 	if z.SinglePool() {
-		return z.serverPools[0].GetObjectInfo(ctx, bucket, object, opts)
+		objInfo, err = z.serverPools[0].GetObjectInfo(ctx, bucket, object, opts)
+		if packedLookup(bucket, opts, err) {
+			return z.getPackedObjectInfo(ctx, bucket, object)
+		}
+		return objInfo, err
 	}
 
 	if !opts.NoLock {
@@ -936,6 +967,9 @@ func (z *erasureServerPools) GetObjectInfo(ctx context.Context, bucket, object s
 	}
 
 	objInfo, _, err = z.getLatestObjectInfoWithIdx(ctx, bucket, object, opts)
+	if packedLookup(bucket, opts, err) && !objInfo.DeleteMarker {
+		return z.getPackedObjectInfo(ctx, bucket, object)
+	}
 	return objInfo, err
 }
 
@@ -1000,16 +1034,18 @@ func (z *erasureServerPools) DeleteObject(ctx context.Context, bucket string, ob
 
 	object = encodeDirObject(object)
 	if z.SinglePool() {
-		return z.serverPools[0].DeleteObject(ctx, bucket, object, opts)
+		objInfo, err = z.serverPools[0].DeleteObject(ctx, bucket, object, opts)
+		return z.deletePackedObject(ctx, bucket, object, opts, objInfo, err)
 	}
 
 	opts.Mutate = true
 	idx, err := z.getPoolIdxExistingWithOpts(ctx, bucket, object, opts)
 	if err != nil {
-		return objInfo, err
+		return z.deletePackedObject(ctx, bucket, object, opts, objInfo, err)
 	}
 
-	return z.serverPools[idx].DeleteObject(ctx, bucket, object, opts)
+	objInfo, err = z.serverPools[idx].DeleteObject(ctx, bucket, object, opts)
+	return z.deletePackedObject(ctx, bucket, object, opts, objInfo, err)
 }
 
 func (z *erasureServerPools) DeleteObjects(ctx context.Context, bucket string, objects []ObjectToDelete, opts ObjectOptions) ([]DeletedObject, []error) {
@@ -1037,6 +1073,7 @@ func (z *erasureServerPools) DeleteObjects(ctx context.Context, bucket string, o
 
 	if z.SinglePool() {
 		deleteObjects, dErrs := z.serverPools[0].DeleteObjects(ctx, bucket, objects, opts)
+		z.deletePackedObjects(ctx, bucket, objects, deleteObjects, dErrs)
 		for i := range deleteObjects {
 			deleteObjects[i].ObjectName = decodeDirObject(deleteObjects[i].ObjectName)
 		}
@@ -1092,6 +1129,7 @@ func (z *erasureServerPools) DeleteObjects(ctx context.Context, bucket string, o
 	}
 	wg.Wait()
 
+	z.deletePackedObjects(ctx, bucket, objects, dobjects, derrs)
 	return dobjects, derrs
 }
 
@@ -1139,6 +1177,10 @@ func (z *erasureServerPools) CopyObject(ctx context.Context, srcBucket, srcObjec
 		}
 	}
 
+	if !cpSrcDstSame && z.canCopyObjectParallel(ctx, srcBucket, srcObject, srcInfo, srcOpts, dstOpts) {
+		return z.copyObjectParallel(ctx, poolIdx, srcBucket, srcObject, dstBucket, dstObject, srcInfo, srcOpts, dstOpts)
+	}
+
 	putOpts := ObjectOptions{
 		ServerSideEncryption: dstOpts.ServerSideEncryption,
 		UserDefined:          srcInfo.UserDefined,
@@ -1219,6 +1261,9 @@ func (z *erasureServerPools) ListObjectVersions(ctx context.Context, bucket, pre
 		loi.NextMarker = opts.encodeMarker(last.Name)
 		loi.NextVersionIDMarker = last.VersionID
 	}
+	if packedBucket(bucket) {
+		return z.mergePackedObjectVersions(ctx, bucket, prefix, marker, delimiter, maxKeys, loi)
+	}
 	return loi, nil
 }
 
@@ -1306,6 +1351,9 @@ func (z *erasureServerPools) ListObjects(ctx context.Context, bucket, prefix, ma
 		last := objects[len(objects)-1]
 		loi.NextMarker = opts.encodeMarker(last.Name)
 	}
+	if packedBucket(bucket) {
+		return z.mergePackedObjects(ctx, bucket, prefix, marker, delimiter, maxKeys, loi)
+	}
 	return loi, nil
 }
 
@@ -1579,6 +1627,16 @@ func (z *erasureServerPools) IsTaggingSupported() bool {
 // even if one of the serverPools fail to delete buckets, we proceed to
 // undo a successful operation.
 func (z *erasureServerPools) DeleteBucket(ctx context.Context, bucket string, opts DeleteBucketOptions) error {
+	if !opts.Force && packedBucket(bucket) {
+		packed, err := z.hasPackedObjects(ctx, bucket)
+		if err != nil {
+			return err
+		}
+		if packed {
+			return BucketNotEmpty{Bucket: bucket}
+		}
+	}
+
 	g := errgroup.WithNErrs(len(z.serverPools))
 
 	// Delete buckets in parallel across all serverPools.
@@ -2196,6 +2254,63 @@ func (z *erasureServerPools) Health(ctx context.Context, opts HealthOptions) Hea
 	}
 }
 
+// SetHealth is the state of a single erasure set.
+type SetHealth struct {
+	Pool         int  `json:"pool"`
+	Set          int  `json:"set"`
+	OnlineDrives int  `json:"onlineDrives"`
+	TotalDrives  int  `json:"totalDrives"`
+	ReadQuorum   int  `json:"readQuorum"`
+	WriteQuorum  int  `json:"writeQuorum"`
+	Healthy      bool `json:"healthy"`
+}
+
+// SetsHealth returns the number of online drives and the read and
+// write quorum of every erasure set, a set is healthy when it has
+// write quorum.
+func (z *erasureServerPools) SetsHealth(ctx context.Context) []SetHealth {
+	erasureSetUpCount := make([][]int, len(z.serverPools))
+	for i := range z.serverPools {
+		erasureSetUpCount[i] = make([]int, len(z.serverPools[i].sets))
+	}
+
+	diskIDs := globalNotificationSys.GetLocalDiskIDs(ctx)
+	diskIDs = append(diskIDs, getLocalDiskIDs(z))
+
+	for _, localDiskIDs := range diskIDs {
+		for _, id := range localDiskIDs {
+			poolIdx, setIdx, _, err := z.getPoolAndSet(id)
+			if err != nil {
+				logger.LogIf(ctx, err)
+				continue
+			}
+			erasureSetUpCount[poolIdx][setIdx]++
+		}
+	}
+
+	b := z.BackendInfo()
+	var sets []SetHealth
+	for poolIdx := range erasureSetUpCount {
+		readQuorum := b.StandardSCData[poolIdx]
+		writeQuorum := readQuorum
+		if readQuorum == b.StandardSCParity {
+			writeQuorum++
+		}
+		for setIdx, online := range erasureSetUpCount[poolIdx] {
+			sets = append(sets, SetHealth{
+				Pool:         poolIdx,
+				Set:          setIdx,
+				OnlineDrives: online,
+				TotalDrives:  z.serverPools[poolIdx].SetDriveCount(),
+				ReadQuorum:   readQuorum,
+				WriteQuorum:  writeQuorum,
+				Healthy:      online >= writeQuorum,
+			})
+		}
+	}
+	return sets
+}
+
 // PutObjectMetadata - replace or add tags to an existing object
 func (z *erasureServerPools) PutObjectMetadata(ctx context.Context, bucket, object string, opts ObjectOptions) (ObjectInfo, error) {
 	object = encodeDirObject(object)
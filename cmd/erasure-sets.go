@@ -220,7 +220,11 @@ func (s *erasureSets) connectDisks() {
 			disk, format, err := connectEndpoint(endpoint)
 			if err != nil {
 				if endpoint.IsLocal && errors.Is(err, errUnformattedDisk) {
-					globalBackgroundHealState.pushHealLocalDisks(endpoint)
+					if s.lastConnectDisksOpTime.IsZero() {
+						globalBackgroundHealState.pushHealLocalDisks(endpoint)
+					} else {
+						healReplacedLocalDisk(endpoint)
+					}
 				} else {
 					printEndpointError(endpoint, err, true)
 				}
@@ -143,7 +143,9 @@ func (es *erasureSingle) cleanupStaleUploads(ctx context.Context) {
 		case <-ctx.Done():
 			return
 		case <-timer.C:
-			es.cleanupStaleUploadsOnDisk(ctx, es.disk, globalAPIConfig.getStaleUploadsExpiry())
+			sweep := newStaleUploadsSweep()
+			es.cleanupStaleUploadsOnDisk(ctx, es.disk, globalAPIConfig.getStaleUploadsExpiry(), sweep)
+			globalStaleUploadsStats.setActive("0-0", sweep.activeCount())
 
 			// Reset for the next interval
 			timer.Reset(globalAPIConfig.getStaleUploadsCleanupInterval())
@@ -545,6 +547,12 @@ func (es *erasureSingle) GetObjectNInfo(ctx context.Context, bucket, object stri
 
 	pr, pw := xioutil.WaitPipe()
 	go func() {
+		if rs != nil && globalRangePrefetch {
+			pw.CloseWithError(globalRangePrefetcher.readRange(ctx, bucket, object, fi, off, length, pw, func(ctx context.Context, offset, length int64, w io.Writer) error {
+				return es.getObjectWithFileInfo(ctx, bucket, object, offset, length, w, fi, metaArr, onlineDisks)
+			}))
+			return
+		}
 		pw.CloseWithError(es.getObjectWithFileInfo(ctx, bucket, object, off, length, pw, fi, metaArr, onlineDisks))
 	}()
 
@@ -710,6 +718,15 @@ func (es *erasureSingle) getObjectFileInfo(ctx context.Context, bucket, object s
 	return fi, metaArr, onlineDisks, nil
 }
 
+func (es *erasureSingle) checkWritePrecondition(ctx context.Context, bucket, object string, opts ObjectOptions) error {
+	return checkWritePrecondition(opts, func() (ObjectInfo, error) {
+		return es.getObjectInfo(ctx, bucket, object, ObjectOptions{
+			Versioned:        opts.Versioned,
+			VersionSuspended: opts.VersionSuspended,
+		})
+	})
+}
+
 // getObjectInfo - wrapper for reading object metadata and constructs ObjectInfo.
 func (es *erasureSingle) getObjectInfo(ctx context.Context, bucket, object string, opts ObjectOptions) (objInfo ObjectInfo, err error) {
 	fi, _, _, err := es.getObjectFileInfo(ctx, bucket, object, opts, false)
@@ -897,6 +914,10 @@ func (es *erasureSingle) putMetacacheObject(ctx context.Context, key string, r *
 // writes `xl.meta` which carries the necessary metadata for future
 // object operations.
 func (es *erasureSingle) PutObject(ctx context.Context, bucket string, object string, data *PutObjReader, opts ObjectOptions) (objInfo ObjectInfo, err error) {
+	if opts.Append {
+		return ObjectInfo{}, NotImplemented{}
+	}
+
 	// Validate put object input args.
 	if err := checkPutObjectArgs(ctx, bucket, object, es); err != nil {
 		return ObjectInfo{}, err
@@ -1005,21 +1026,18 @@ func (es *erasureSingle) putObject(ctx context.Context, bucket string, object st
 	writers := make([]io.Writer, len(onlineDisks))
 	var inlineBuffers []*bytes.Buffer
 	if shardFileSize >= 0 {
-		if !opts.Versioned && shardFileSize < smallFileThreshold {
-			inlineBuffers = make([]*bytes.Buffer, len(onlineDisks))
-		} else if shardFileSize < smallFileThreshold/8 {
+		if globalStorageClass.ShouldInline(shardFileSize, opts.Versioned) {
 			inlineBuffers = make([]*bytes.Buffer, len(onlineDisks))
 		}
 	} else {
 		// If compressed, use actual size to determine.
 		if sz := erasure.ShardFileSize(data.ActualSize()); sz > 0 {
-			if !opts.Versioned && sz < smallFileThreshold {
-				inlineBuffers = make([]*bytes.Buffer, len(onlineDisks))
-			} else if sz < smallFileThreshold/8 {
+			if globalStorageClass.ShouldInline(sz, opts.Versioned) {
 				inlineBuffers = make([]*bytes.Buffer, len(onlineDisks))
 			}
 		}
 	}
+	bitrotAlgo := bitrotAlgorithmForSC(opts.UserDefined[xhttp.AmzStorageClass])
 	for i, disk := range onlineDisks {
 		if disk == nil {
 			continue
@@ -1035,11 +1053,11 @@ func (es *erasureSingle) putObject(ctx context.Context, bucket string, object st
 				sz = data.ActualSize()
 			}
 			inlineBuffers[i] = bytes.NewBuffer(make([]byte, 0, sz))
-			writers[i] = newStreamingBitrotWriterBuffer(inlineBuffers[i], DefaultBitrotAlgorithm, erasure.ShardSize())
+			writers[i] = newStreamingBitrotWriterBuffer(inlineBuffers[i], bitrotAlgo, erasure.ShardSize())
 			continue
 		}
 
-		writers[i] = newBitrotWriter(disk, minioMetaTmpBucket, tempErasureObj, shardFileSize, DefaultBitrotAlgorithm, erasure.ShardSize())
+		writers[i] = newBitrotWriter(disk, minioMetaTmpBucket, tempErasureObj, shardFileSize, bitrotAlgo, erasure.ShardSize())
 	}
 
 	toEncode := io.Reader(data)
@@ -1078,6 +1096,10 @@ func (es *erasureSingle) putObject(ctx context.Context, bucket string, object st
 		defer lk.Unlock(lkctx.Cancel)
 	}
 
+	if err = es.checkWritePrecondition(ctx, bucket, object, opts); err != nil {
+		return ObjectInfo{}, err
+	}
+
 	for i, w := range writers {
 		if w == nil {
 			onlineDisks[i] = nil
@@ -1091,7 +1113,7 @@ func (es *erasureSingle) putObject(ctx context.Context, bucket string, object st
 		partsMetadata[i].AddObjectPart(1, "", n, data.ActualSize())
 		partsMetadata[i].Erasure.AddChecksumInfo(ChecksumInfo{
 			PartNumber: 1,
-			Algorithm:  DefaultBitrotAlgorithm,
+			Algorithm:  bitrotAlgo,
 			Hash:       bitrotWriterSum(w),
 		})
 	}
@@ -1099,6 +1121,11 @@ func (es *erasureSingle) putObject(ctx context.Context, bucket string, object st
 		opts.UserDefined["etag"] = r.MD5CurrentHexString()
 	}
 
+	// Save the additional checksum verified while reading.
+	if opts.WantChecksum != nil && opts.WantChecksum.Encoded != "" {
+		opts.UserDefined[objectChecksumKey] = opts.WantChecksum.String()
+	}
+
 	// Guess content-type from the extension if possible.
 	if opts.UserDefined["content-type"] == "" {
 		opts.UserDefined["content-type"] = mimedb.TypeByExtension(path.Ext(object))
@@ -1132,6 +1159,7 @@ func (es *erasureSingle) putObject(ctx context.Context, bucket string, object st
 		logger.LogIf(ctx, err)
 		return ObjectInfo{}, toObjectErr(err, bucket, object)
 	}
+	globalInlineObjectStats.record(len(inlineBuffers) > 0)
 
 	for i := 0; i < len(onlineDisks); i++ {
 		if onlineDisks[i] != nil && onlineDisks[i].IsOnline() {
@@ -1913,7 +1941,7 @@ func (es *erasureSingle) removeObjectPart(bucket, object, uploadID, dataDir stri
 }
 
 // Remove the old multipart uploads on the given disk.
-func (es *erasureSingle) cleanupStaleUploadsOnDisk(ctx context.Context, disk StorageAPI, expiry time.Duration) {
+func (es *erasureSingle) cleanupStaleUploadsOnDisk(ctx context.Context, disk StorageAPI, expiry time.Duration, sweep *staleUploadsSweep) {
 	now := time.Now()
 	diskPath := disk.Endpoint().Path
 
@@ -1925,8 +1953,11 @@ func (es *erasureSingle) cleanupStaleUploadsOnDisk(ctx context.Context, disk Sto
 				return nil
 			}
 			wait := es.deletedCleanupSleeper.Timer(ctx)
-			if now.Sub(fi.ModTime) > expiry {
+			if isStaleUpload(fi, now, expiry) {
 				es.disk.RenameFile(context.Background(), minioMetaMultipartBucket, uploadIDPath, minioMetaTmpDeletedBucket, mustGetUUID())
+				globalStaleUploadsStats.aborted(fi)
+			} else {
+				sweep.active()
 			}
 			wait()
 			return nil
@@ -2084,6 +2115,12 @@ func (es *erasureSingle) newMultipartUpload(ctx context.Context, bucket string,
 		modTime = UTCNow()
 	}
 
+	// Remember the object and initiation time of this upload
+	// for the stale uploads cleanup.
+	opts.UserDefined[minIOMultipartObject] = pathJoin(bucket, object)
+	opts.UserDefined[minIOMultipartInitiated] = modTime.Format(time.RFC3339Nano)
+	opts.UserDefined[minIOMultipartBitrot] = bitrotAlgorithmForSC(opts.UserDefined[xhttp.AmzStorageClass]).String()
+
 	onlineDisks, partsMetadata = shuffleDisksAndPartsMetadata(onlineDisks, partsMetadata, fi)
 
 	// Fill all the necessary metadata.
@@ -2263,12 +2300,13 @@ func (es *erasureSingle) PutObjectPart(ctx context.Context, bucket, object, uplo
 	if len(buffer) > int(fi.Erasure.BlockSize) {
 		buffer = buffer[:fi.Erasure.BlockSize]
 	}
+	bitrotAlgo := multipartBitrotAlgorithm(fi.Metadata)
 	writers := make([]io.Writer, len(onlineDisks))
 	for i, disk := range onlineDisks {
 		if disk == nil {
 			continue
 		}
-		writers[i] = newBitrotWriter(disk, minioMetaTmpBucket, tmpPartPath, erasure.ShardFileSize(data.Size()), DefaultBitrotAlgorithm, erasure.ShardSize())
+		writers[i] = newBitrotWriter(disk, minioMetaTmpBucket, tmpPartPath, erasure.ShardFileSize(data.Size()), bitrotAlgo, erasure.ShardSize())
 	}
 
 	toEncode := io.Reader(data)
@@ -2356,9 +2394,14 @@ func (es *erasureSingle) PutObjectPart(ctx context.Context, bucket, object, uplo
 		partsMetadata[i].Size = fi.Size
 		partsMetadata[i].ModTime = fi.ModTime
 		partsMetadata[i].Parts = fi.Parts
+		if opts.WantChecksum != nil && opts.WantChecksum.Encoded != "" {
+			partsMetadata[i].Metadata[uploadPartChecksumKey(partID)] = opts.WantChecksum.String()
+		} else {
+			delete(partsMetadata[i].Metadata, uploadPartChecksumKey(partID))
+		}
 		partsMetadata[i].Erasure.AddChecksumInfo(ChecksumInfo{
 			PartNumber: partID,
-			Algorithm:  DefaultBitrotAlgorithm,
+			Algorithm:  bitrotAlgo,
 			Hash:       bitrotWriterSum(writers[i]),
 		})
 	}
@@ -2655,6 +2698,16 @@ func (es *erasureSingle) CompleteMultipartUpload(ctx context.Context, bucket str
 		fi.ModTime = UTCNow()
 	}
 
+	// Save the composite checksum if the upload has a checksum algorithm.
+	if err = completeMultipartChecksum(fi.Metadata, currentFI.Parts, fi.Parts); err != nil {
+		return oi, err
+	}
+
+	// Upload tracking information is not part of the final object.
+	delete(fi.Metadata, minIOMultipartObject)
+	delete(fi.Metadata, minIOMultipartInitiated)
+	delete(fi.Metadata, minIOMultipartBitrot)
+
 	// Save successfully calculated md5sum.
 	fi.Metadata["etag"] = opts.UserDefined["etag"]
 	if fi.Metadata["etag"] == "" {
@@ -2684,6 +2737,10 @@ func (es *erasureSingle) CompleteMultipartUpload(ctx context.Context, bucket str
 	ctx = lkctx.Context()
 	defer lk.Unlock(lkctx.Cancel)
 
+	if err = es.checkWritePrecondition(ctx, bucket, object, opts); err != nil {
+		return oi, err
+	}
+
 	// Write final `xl.meta` at uploadID location
 	onlineDisks, err = writeUniqueFileInfo(ctx, onlineDisks, minioMetaMultipartBucket, uploadIDPath, partsMetadata, writeQuorum)
 	if err != nil {
@@ -222,6 +222,11 @@ func getDisksInfo(disks []StorageAPI, endpoints []Endpoint) (disksInfo []madmin.
 			for k, v := range info.Metrics.APICalls {
 				di.Metrics.APICalls[k] = v
 			}
+			// The IO stats of the drive are carried as a single entry,
+			// read and write latencies are the last minute p99 in ms.
+			di.Metrics.APILatencies[driveIOStatsKey] = info.Metrics.IO
+			di.ReadLatency = float64(info.Metrics.IO.ReadLatencyP99) / float64(time.Millisecond)
+			di.WriteLatency = float64(info.Metrics.IO.WriteLatencyP99) / float64(time.Millisecond)
 			if info.Total > 0 {
 				di.Utilization = float64(info.Used / info.Total * 100)
 			}
@@ -391,6 +396,16 @@ func (er erasureObjects) nsScanner(ctx context.Context, buckets []BucketInfo, bf
 	}
 
 	close(bucketCh)
+
+	if globalScannerControl.poolDisabled(er.poolIndex) {
+		// Report the usage found by the last scan, without scanning.
+		if cache.Info.LastUpdate = oldCache.Info.LastUpdate; cache.Info.LastUpdate.IsZero() {
+			cache.Info.LastUpdate = time.Now()
+		}
+		updates <- cache
+		return nil
+	}
+
 	bucketResults := make(chan dataUsageEntryInfo, len(disks))
 
 	// Start async collector/saver.
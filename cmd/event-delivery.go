@@ -0,0 +1,232 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"path"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/minio/minio/internal/config/eventdelivery"
+	"github.com/minio/minio/internal/event"
+	"github.com/minio/minio/internal/hash"
+	xhttp "github.com/minio/minio/internal/http"
+	"github.com/minio/minio/internal/logger"
+)
+
+// maxEventRedeliveries is the maximum number of events waiting
+// to be delivered again, further undelivered events are
+// dead-lettered right away.
+const maxEventRedeliveries = 10000
+
+var (
+	eventDeliveryMu  sync.RWMutex
+	eventDeliveryCfg = eventdelivery.Config{
+		MaxAttempts: 1,
+		Backoff:     time.Second,
+		MaxBackoff:  time.Minute,
+	}
+
+	// Number of events waiting to be delivered again.
+	eventRedeliveries int64
+)
+
+// eventDeliveryConfig returns the event delivery policy in effect.
+func eventDeliveryConfig() eventdelivery.Config {
+	eventDeliveryMu.RLock()
+	defer eventDeliveryMu.RUnlock()
+	return eventDeliveryCfg
+}
+
+func updateEventDeliveryParams(cfg eventdelivery.Config) {
+	eventDeliveryMu.Lock()
+	defer eventDeliveryMu.Unlock()
+	eventDeliveryCfg = cfg
+}
+
+// eventDeliveryStats counts per target the events which were
+// delivered again, dead-lettered or dropped by this node.
+type eventDeliveryStats struct {
+	mu           sync.Mutex
+	retried      map[string]uint64
+	deadLettered map[string]uint64
+	dropped      map[string]uint64
+}
+
+var globalEventDeliveryStats = eventDeliveryStats{
+	retried:      make(map[string]uint64),
+	deadLettered: make(map[string]uint64),
+	dropped:      make(map[string]uint64),
+}
+
+func (s *eventDeliveryStats) add(counts map[string]uint64, id event.TargetID) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	counts[id.String()]++
+}
+
+// load returns the retried, dead-lettered and dropped events per target.
+func (s *eventDeliveryStats) load() (retried, deadLettered, dropped map[string]uint64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	clone := func(counts map[string]uint64) map[string]uint64 {
+		c := make(map[string]uint64, len(counts))
+		for id, n := range counts {
+			c[id] = n
+		}
+		return c
+	}
+	return clone(s.retried), clone(s.deadLettered), clone(s.dropped)
+}
+
+// getLocalNotifyTargets returns the queue length and the delivery
+// statistics of the notification targets of this node.
+func getLocalNotifyTargets() []ServerNotifyTarget {
+	stats := globalConfigTargetList.Stats()
+	targets := make([]ServerNotifyTarget, 0, len(stats))
+	for _, target := range globalConfigTargetList.Targets() {
+		st := stats[target.ID()]
+		online, _ := target.IsActive()
+		targets = append(targets, ServerNotifyTarget{
+			Node:         globalLocalNodeName,
+			TargetID:     target.ID().String(),
+			Online:       online,
+			QueueLength:  st.QueueLength,
+			EventsSent:   st.EventsSent,
+			EventsFailed: st.EventsFailed,
+			LastSuccess:  st.LastSuccess,
+		})
+	}
+	sort.Slice(targets, func(i, j int) bool {
+		return targets[i].TargetID < targets[j].TargetID
+	})
+	return targets
+}
+
+// redeliverEvent delivers the event the target failed to accept
+// again according to the event delivery policy, the event is
+// dead-lettered once all attempts failed.
+func (sys *NotificationSys) redeliverEvent(ctx context.Context, id event.TargetID, ev event.Event, err error) {
+	cfg := eventDeliveryConfig()
+	if cfg.MaxAttempts > 1 {
+		if atomic.AddInt64(&eventRedeliveries, 1) > maxEventRedeliveries {
+			atomic.AddInt64(&eventRedeliveries, -1)
+			sys.deadLetterEvent(ctx, cfg, id, ev, err)
+			return
+		}
+		defer atomic.AddInt64(&eventRedeliveries, -1)
+	}
+
+	backoff := cfg.Backoff
+	for attempt := 1; attempt < cfg.MaxAttempts; attempt++ {
+		timer := time.NewTimer(backoff)
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		}
+		backoff = cfg.NextBackoff(backoff)
+
+		target, ok := sys.targetList.Lookup(id)
+		if !ok {
+			// The target has been removed meanwhile.
+			break
+		}
+		globalEventDeliveryStats.add(globalEventDeliveryStats.retried, id)
+		err = target.Save(ev)
+		event.RecordSave(target, err)
+		if err == nil {
+			return
+		}
+	}
+	sys.deadLetterEvent(ctx, cfg, id, ev, err)
+}
+
+// deadLetterEvent sends the event the target failed to accept to the
+// dead-letter target, or writes it to the dead-letter bucket. The
+// event is dropped if neither is configured or both fail.
+func (sys *NotificationSys) deadLetterEvent(ctx context.Context, cfg eventdelivery.Config, id event.TargetID, ev event.Event, cause error) {
+	if cfg.DeadLetterTarget != nil && *cfg.DeadLetterTarget != id {
+		if target, ok := sys.targetList.Lookup(*cfg.DeadLetterTarget); ok {
+			err := target.Save(ev)
+			event.RecordSave(target, err)
+			if err == nil {
+				globalEventDeliveryStats.add(globalEventDeliveryStats.deadLettered, id)
+				return
+			}
+			logger.LogOnceIf(ctx, fmt.Errorf("Unable to send event to dead-letter target %s: %w", cfg.DeadLetterTarget, err), "event-dead-letter-target")
+		}
+	}
+	if cfg.DeadLetterBucket != "" {
+		if objAPI := newObjectLayerFn(); objAPI != nil {
+			err := putDeadLetterEvent(ctx, objAPI, cfg, id, ev, cause, UTCNow())
+			if err == nil {
+				globalEventDeliveryStats.add(globalEventDeliveryStats.deadLettered, id)
+				return
+			}
+			logger.LogOnceIf(ctx, fmt.Errorf("Unable to write event to dead-letter bucket %s: %w", cfg.DeadLetterBucket, err), "event-dead-letter-bucket")
+		}
+	}
+	globalEventDeliveryStats.add(globalEventDeliveryStats.dropped, id)
+}
+
+// deadLetterRecord is the object written to the dead-letter bucket
+// for an event a target failed to accept.
+type deadLetterRecord struct {
+	Target string      `json:"target"`
+	Error  string      `json:"error"`
+	Time   time.Time   `json:"time"`
+	Event  event.Event `json:"event"`
+}
+
+// putDeadLetterEvent writes the event the target failed to accept at
+// now to the dead-letter bucket. No event is sent for the object such
+// that undelivered events can not loop.
+func putDeadLetterEvent(ctx context.Context, objAPI ObjectLayer, cfg eventdelivery.Config, id event.TargetID, ev event.Event, cause error, now time.Time) error {
+	record := deadLetterRecord{
+		Target: id.ToARN(globalSite.Region).String(),
+		Time:   now,
+		Event:  ev,
+	}
+	if cause != nil {
+		record.Error = cause.Error()
+	}
+	data, err := json.Marshal(record)
+	if err != nil {
+		return err
+	}
+
+	object := path.Join(cfg.DeadLetterPrefix, id.Name, id.ID, now.Format("2006-01-02"), now.Format("150405.000000000")+"-"+mustGetUUID()+".json")
+	hashReader, err := hash.NewReader(bytes.NewReader(data), int64(len(data)), "", "", int64(len(data)))
+	if err != nil {
+		return err
+	}
+	opts := ObjectOptions{
+		UserDefined:      map[string]string{xhttp.ContentType: "application/json"},
+		Versioned:        globalBucketVersioningSys.PrefixEnabled(cfg.DeadLetterBucket, object),
+		VersionSuspended: globalBucketVersioningSys.PrefixSuspended(cfg.DeadLetterBucket, object),
+	}
+	_, err = objAPI.PutObject(ctx, cfg.DeadLetterBucket, object, NewPutObjReader(hashReader), opts)
+	return err
+}
@@ -0,0 +1,121 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+package cmd
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/minio/minio/internal/config/eventdelivery"
+	"github.com/minio/minio/internal/event"
+)
+
+// testEventTarget accepts events once failures is exhausted.
+type testEventTarget struct {
+	id       event.TargetID
+	mu       sync.Mutex
+	failures int
+	events   []event.Event
+}
+
+func (t *testEventTarget) ID() event.TargetID      { return t.id }
+func (t *testEventTarget) IsActive() (bool, error) { return true, nil }
+func (t *testEventTarget) Send(string) error       { return nil }
+func (t *testEventTarget) Close() error            { return nil }
+func (t *testEventTarget) HasQueueStore() bool     { return false }
+func (t *testEventTarget) received() []event.Event {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.events
+}
+
+func (t *testEventTarget) Save(ev event.Event) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.failures > 0 {
+		t.failures--
+		return errors.New("target offline")
+	}
+	t.events = append(t.events, ev)
+	return nil
+}
+
+func TestRedeliverEvent(t *testing.T) {
+	defer updateEventDeliveryParams(eventDeliveryConfig())
+
+	dlqID := event.TargetID{ID: "dlq", Name: "webhook"}
+	testCases := []struct {
+		failures     int
+		maxAttempts  int
+		deadLetter   bool
+		delivered    int
+		deadLettered int
+		dropped      int
+	}{
+		{failures: 2, maxAttempts: 3, delivered: 1},
+		{failures: 3, maxAttempts: 3, deadLetter: true, deadLettered: 1},
+		{failures: 3, maxAttempts: 3, dropped: 1},
+		{failures: 1, maxAttempts: 1, dropped: 1},
+	}
+	for i, testCase := range testCases {
+		id := event.TargetID{ID: "test" + string(rune('0'+i)), Name: "webhook"}
+		target := &testEventTarget{id: id, failures: testCase.failures}
+		dlq := &testEventTarget{id: dlqID}
+		sys := &NotificationSys{targetList: event.NewTargetList()}
+		if err := sys.targetList.Add(target, dlq); err != nil {
+			t.Fatal(err)
+		}
+
+		cfg := eventdelivery.Config{
+			MaxAttempts: testCase.maxAttempts,
+			Backoff:     time.Millisecond,
+			MaxBackoff:  2 * time.Millisecond,
+		}
+		if testCase.deadLetter {
+			cfg.DeadLetterTarget = &dlqID
+		}
+		updateEventDeliveryParams(cfg)
+
+		ev := event.Event{EventName: event.ObjectCreatedPut}
+		// The first attempt is made by the notification system.
+		err := target.Save(ev)
+		if err == nil {
+			t.Fatalf("Test %d: expected the first delivery to fail", i)
+		}
+		sys.redeliverEvent(context.Background(), id, ev, err)
+
+		if n := len(target.received()); n != testCase.delivered {
+			t.Errorf("Test %d: expected %d delivered events, got %d", i, testCase.delivered, n)
+		}
+		if n := len(dlq.received()); n != testCase.deadLettered {
+			t.Errorf("Test %d: expected %d dead-lettered events, got %d", i, testCase.deadLettered, n)
+		}
+		retried, deadLettered, dropped := globalEventDeliveryStats.load()
+		if n := retried[id.String()]; n != uint64(testCase.maxAttempts-1) && testCase.delivered == 0 {
+			t.Errorf("Test %d: expected %d retries, got %d", i, testCase.maxAttempts-1, n)
+		}
+		if n := deadLettered[id.String()]; n != uint64(testCase.deadLettered) {
+			t.Errorf("Test %d: expected %d dead-lettered events counted, got %d", i, testCase.deadLettered, n)
+		}
+		if n := dropped[id.String()]; n != uint64(testCase.dropped) {
+			t.Errorf("Test %d: expected %d dropped events counted, got %d", i, testCase.dropped, n)
+		}
+	}
+}
@@ -394,13 +394,18 @@ func (fs *FSObjects) scanBucket(ctx context.Context, bucket string, cache dataUs
 
 		oi := fsMeta.ToObjectInfo(bucket, object, fi)
 		atomic.AddUint64(&globalScannerStats.accTotalVersions, 1)
-		atomic.AddUint64(&globalScannerStats.accTotalObjects, 1)
+		globalScannerStats.objectScanned()
 		sz := item.applyActions(ctx, fs, oi, &sizeSummary{})
-		if sz >= 0 {
-			return sizeSummary{totalSize: sz, versions: 1}, nil
+		if sz < 0 {
+			sz = fi.Size()
 		}
-
-		return sizeSummary{totalSize: fi.Size(), versions: 1}, nil
+		summary := sizeSummary{totalSize: sz, versions: 1}
+		if typ := encryptionType(oi.UserDefined); typ != "" {
+			summary.encryption = map[string]encryptionStats{
+				typ: {Size: uint64(sz), Versions: 1},
+			}
+		}
+		return summary, nil
 	}, 0)
 
 	return cache, err
@@ -994,7 +999,7 @@ func (fs *FSObjects) GetObjectInfo(ctx context.Context, bucket, object string, o
 // Additionally writes `fs.json` which carries the necessary metadata
 // for future object operations.
 func (fs *FSObjects) PutObject(ctx context.Context, bucket string, object string, r *PutObjReader, opts ObjectOptions) (objInfo ObjectInfo, err error) {
-	if opts.Versioned {
+	if opts.Versioned || opts.Append {
 		return objInfo, NotImplemented{}
 	}
 
@@ -1014,9 +1019,20 @@ func (fs *FSObjects) PutObject(ctx context.Context, bucket string, object string
 	ctx = lkctx.Context()
 	defer lk.Unlock(lkctx.Cancel)
 
+	if err = fs.checkWritePrecondition(ctx, bucket, object, opts); err != nil {
+		return objInfo, err
+	}
+
 	return fs.putObject(ctx, bucket, object, r, opts)
 }
 
+func (fs *FSObjects) checkWritePrecondition(ctx context.Context, bucket, object string, opts ObjectOptions) error {
+	return checkWritePrecondition(opts, func() (ObjectInfo, error) {
+		oi, err := fs.getObjectInfo(ctx, bucket, object)
+		return oi, toObjectErr(err, bucket, object)
+	})
+}
+
 // putObject - wrapper for PutObject
 func (fs *FSObjects) putObject(ctx context.Context, bucket string, object string, r *PutObjReader, opts ObjectOptions) (objInfo ObjectInfo, retErr error) {
 	data := r.Reader
@@ -319,3 +319,66 @@ func TestFSHealObjects(t *testing.T) {
 		t.Fatalf("Heal Object should return NotImplemented error ")
 	}
 }
+
+// TestFSGetObjectZeroCopy - tests that plain objects expose a file reader limited to the requested range.
+func TestFSGetObjectZeroCopy(t *testing.T) {
+	disk := filepath.Join(globalTestTmpDir, "minio-"+nextSuffix())
+	defer os.RemoveAll(disk)
+
+	// Initialize the FS format so that a FS object layer is
+	// created instead of a single drive erasure one.
+	if err := initMetaVolumeFS(disk, mustGetUUID()); err != nil {
+		t.Fatal(err)
+	}
+	rlk, err := initFormatFS(GlobalContext, disk)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rlk.Close()
+
+	obj, err := NewFSObjectLayer(disk)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer obj.Shutdown(GlobalContext)
+	newTestConfig(globalMinioDefaultRegion, obj)
+	initAllTestSubsystems(t)
+
+	bucketName := "bucket"
+	objectName := "object"
+	data := []byte("abcdefghijklmnopqrstuvwxyz")
+
+	if err = obj.MakeBucketWithLocation(GlobalContext, bucketName, BucketOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err = obj.PutObject(GlobalContext, bucketName, objectName, mustGetPutObjReader(t, bytes.NewReader(data), int64(len(data)), "", ""), ObjectOptions{}); err != nil {
+		t.Fatal(err)
+	}
+
+	rs := &HTTPRangeSpec{Start: 3, End: 9}
+	gr, err := obj.GetObjectNInfo(GlobalContext, bucketName, objectName, rs, nil, readLock, ObjectOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer gr.Close()
+
+	zr := gr.zeroCopyReader()
+	if zr == nil {
+		t.Fatal("Expected a zero copy reader for a plain object")
+	}
+	var buf bytes.Buffer
+	if _, err = buf.ReadFrom(zr); err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(buf.Bytes(), data[3:10]) {
+		t.Fatalf("Expected %q, got %q", data[3:10], buf.Bytes())
+	}
+
+	r, err := NewGetObjectReaderFromReader(bytes.NewReader(data), ObjectInfo{}, ObjectOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r.zeroCopyReader() != nil {
+		t.Fatal("Expected no zero copy reader for an in-memory reader")
+	}
+}
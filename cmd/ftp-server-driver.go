@@ -0,0 +1,149 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"errors"
+	"os"
+	"time"
+
+	ftp "github.com/fclairamb/ftpserverlib"
+	"github.com/minio/minio-go/v7"
+	"github.com/spf13/afero"
+)
+
+var errFTPResume = errors.New("appending to or resuming uploads is not supported")
+
+// ftpDriver serves the FTP commands of a session with the S3
+// client and the namespace of SFTP sessions, buckets are the top
+// level directories and prefixes the others.
+type ftpDriver struct {
+	s3 *sftpDriver
+}
+
+var (
+	_ ftp.ClientDriverExtensionFileList     = &ftpDriver{}
+	_ ftp.ClientDriverExtentionFileTransfer = &ftpDriver{}
+	_ ftp.ClientDriverExtensionRemoveDir    = &ftpDriver{}
+)
+
+// newFTPDriver returns the driver of a session served by s3.
+func newFTPDriver(s3 *sftpDriver) *ftpDriver {
+	return &ftpDriver{s3: s3}
+}
+
+// Name returns the name of the file system.
+func (d *ftpDriver) Name() string { return "minio" }
+
+// Create is not supported, files are transferred with GetHandle.
+func (d *ftpDriver) Create(name string) (afero.File, error) { return nil, errSFTPUnsupported }
+
+// Open is not supported, files are transferred with GetHandle.
+func (d *ftpDriver) Open(name string) (afero.File, error) { return nil, errSFTPUnsupported }
+
+// OpenFile is not supported, files are transferred with GetHandle.
+func (d *ftpDriver) OpenFile(name string, flag int, perm os.FileMode) (afero.File, error) {
+	return nil, errSFTPUnsupported
+}
+
+// Mkdir creates a bucket or a directory.
+func (d *ftpDriver) Mkdir(name string, perm os.FileMode) error {
+	return d.s3.mkdir(GlobalContext, name)
+}
+
+// MkdirAll creates a bucket or a directory, parents need not exist
+// as prefixes are implicit.
+func (d *ftpDriver) MkdirAll(path string, perm os.FileMode) error {
+	return d.s3.mkdir(GlobalContext, path)
+}
+
+// Remove removes a file.
+func (d *ftpDriver) Remove(name string) error {
+	return d.s3.remove(GlobalContext, name)
+}
+
+// RemoveDir removes an empty bucket or directory.
+func (d *ftpDriver) RemoveDir(name string) error {
+	return d.s3.rmdir(GlobalContext, name)
+}
+
+// RemoveAll is not supported.
+func (d *ftpDriver) RemoveAll(path string) error { return errSFTPUnsupported }
+
+// Rename renames a file.
+func (d *ftpDriver) Rename(oldname, newname string) error {
+	return d.s3.rename(GlobalContext, oldname, newname)
+}
+
+// Stat describes a bucket, directory or file.
+func (d *ftpDriver) Stat(name string) (os.FileInfo, error) {
+	return d.s3.stat(GlobalContext, name)
+}
+
+// ReadDir lists the buckets or the entries of a directory.
+func (d *ftpDriver) ReadDir(name string) ([]os.FileInfo, error) {
+	return d.s3.readDir(GlobalContext, name)
+}
+
+// Chmod is ignored, modes are not kept.
+func (d *ftpDriver) Chmod(name string, mode os.FileMode) error { return nil }
+
+// Chown is ignored, owners are not kept.
+func (d *ftpDriver) Chown(name string, uid, gid int) error { return nil }
+
+// Chtimes is ignored, times are not kept.
+func (d *ftpDriver) Chtimes(name string, atime time.Time, mtime time.Time) error { return nil }
+
+// ftpDownload is the transfer of an object to an FTP client.
+type ftpDownload struct {
+	*minio.Object
+}
+
+func (f ftpDownload) Write(b []byte) (int, error) { return 0, os.ErrInvalid }
+
+// ftpUpload is the transfer of an object from an FTP client.
+type ftpUpload struct {
+	*sftpWriterAt
+}
+
+func (f ftpUpload) Write(b []byte) (int, error) { return f.WriteAt(b, f.offset) }
+
+func (f ftpUpload) Read(b []byte) (int, error) { return 0, os.ErrInvalid }
+
+func (f ftpUpload) Seek(offset int64, whence int) (int64, error) { return 0, errFTPResume }
+
+// GetHandle returns the transfer of the object at name, downloads
+// may start at offset, uploads always replace the object.
+func (d *ftpDriver) GetHandle(name string, flags int, offset int64) (ftp.FileTransfer, error) {
+	if flags&os.O_WRONLY == 0 {
+		obj, err := d.s3.newReader(GlobalContext, name)
+		if err != nil {
+			return nil, err
+		}
+		return ftpDownload{obj}, nil
+	}
+
+	if flags&os.O_APPEND != 0 || offset != 0 {
+		return nil, errFTPResume
+	}
+	w, err := d.s3.newWriter(GlobalContext, name)
+	if err != nil {
+		return nil, err
+	}
+	return ftpUpload{w}, nil
+}
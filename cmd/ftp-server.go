@@ -0,0 +1,164 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+
+	ftp "github.com/fclairamb/ftpserverlib"
+	"github.com/minio/minio/internal/logger"
+)
+
+const ftpDefaultAddress = ":8021"
+
+// ftpConfig holds the arguments of the --ftp server flag.
+type ftpConfig struct {
+	address          string
+	passivePortRange *ftp.PortRange
+	tlsPrivateKey    string
+	tlsPublicCert    string
+}
+
+// parseFTPArgs parses "key=value" arguments of the --ftp flag, FTP
+// is only served over TLS with the given certificate or the one of
+// the S3 API.
+func parseFTPArgs(args []string) (cfg ftpConfig, err error) {
+	cfg.address = ftpDefaultAddress
+	for _, arg := range args {
+		tokens := strings.SplitN(arg, "=", 2)
+		if len(tokens) != 2 {
+			return cfg, fmt.Errorf("invalid argument --ftp=%s, expected key=value", arg)
+		}
+		switch tokens[0] {
+		case "address":
+			if _, _, err = net.SplitHostPort(tokens[1]); err != nil {
+				return cfg, fmt.Errorf("invalid argument --ftp=%s: %w", arg, err)
+			}
+			cfg.address = tokens[1]
+		case "passive-port-range":
+			ports := strings.SplitN(tokens[1], "-", 2)
+			if len(ports) != 2 {
+				return cfg, fmt.Errorf("invalid argument --ftp=%s, expected a range such as 30000-40000", arg)
+			}
+			start, err1 := strconv.Atoi(ports[0])
+			end, err2 := strconv.Atoi(ports[1])
+			if err1 != nil || err2 != nil || start <= 0 || start > end || end > 65535 {
+				return cfg, fmt.Errorf("invalid argument --ftp=%s, expected a range such as 30000-40000", arg)
+			}
+			cfg.passivePortRange = &ftp.PortRange{Start: start, End: end}
+		case "tls-private-key":
+			cfg.tlsPrivateKey = tokens[1]
+		case "tls-public-cert":
+			cfg.tlsPublicCert = tokens[1]
+		default:
+			return cfg, fmt.Errorf("invalid argument --ftp=%s, unknown key %s", arg, tokens[0])
+		}
+	}
+	if (cfg.tlsPrivateKey == "") != (cfg.tlsPublicCert == "") {
+		return cfg, errors.New("--ftp=tls-private-key and --ftp=tls-public-cert must be set together")
+	}
+	return cfg, nil
+}
+
+// ftpMainDriver authenticates FTP clients like SFTP clients.
+type ftpMainDriver struct {
+	cfg       ftpConfig
+	tlsConfig *tls.Config
+}
+
+// GetSettings returns the settings of the FTP server.
+func (m *ftpMainDriver) GetSettings() (*ftp.Settings, error) {
+	return &ftp.Settings{
+		ListenAddr:               m.cfg.address,
+		PassiveTransferPortRange: m.cfg.passivePortRange,
+		TLSRequired:              ftp.MandatoryEncryption,
+		// Modification times of objects cannot be changed.
+		DisableMFMT: true,
+		Banner:      "MinIO FTP Server",
+	}, nil
+}
+
+// ClientConnected returns the welcome message.
+func (m *ftpMainDriver) ClientConnected(cc ftp.ClientContext) (string, error) {
+	return "MinIO FTP Server", nil
+}
+
+// ClientDisconnected is called when a client disconnects.
+func (m *ftpMainDriver) ClientDisconnected(cc ftp.ClientContext) {}
+
+// AuthUser authenticates user with the SFTP credentials checks.
+func (m *ftpMainDriver) AuthUser(cc ftp.ClientContext, user, pass string) (ftp.ClientDriver, error) {
+	permissions, err := sftpAuthenticate(user, []byte(pass))
+	if err != nil {
+		return nil, err
+	}
+	return newFTPDriver(&sftpDriver{permissions: permissions, endpoint: globalLocalNodeName}), nil
+}
+
+// GetTLSConfig returns the TLS configuration of control and data connections.
+func (m *ftpMainDriver) GetTLSConfig() (*tls.Config, error) {
+	return m.tlsConfig, nil
+}
+
+// newFTPTLSConfig returns the TLS configuration of the certificate
+// of cfg, or of the certificates of the S3 API.
+func newFTPTLSConfig(cfg ftpConfig) (*tls.Config, error) {
+	tlsConfig := &tls.Config{MinVersion: tls.VersionTLS12}
+	switch {
+	case cfg.tlsPublicCert != "":
+		cert, err := tls.LoadX509KeyPair(cfg.tlsPublicCert, cfg.tlsPrivateKey)
+		if err != nil {
+			return nil, err
+		}
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	case globalTLSCerts != nil:
+		tlsConfig.GetCertificate = globalTLSCerts.GetCertificate
+	default:
+		return nil, errors.New("FTP is only served over TLS, set --ftp=tls-private-key and --ftp=tls-public-cert or configure TLS certificates")
+	}
+	return tlsConfig, nil
+}
+
+// startFTPServer serves FTP over TLS on the address of cfg, every
+// session acts with the credentials of the authenticated user.
+func startFTPServer(cfg ftpConfig) error {
+	tlsConfig, err := newFTPTLSConfig(cfg)
+	if err != nil {
+		return err
+	}
+
+	server := ftp.NewFtpServer(&ftpMainDriver{cfg: cfg, tlsConfig: tlsConfig})
+	if err = server.Listen(); err != nil {
+		return err
+	}
+	go func() {
+		<-GlobalContext.Done()
+		server.Stop()
+	}()
+	go func() {
+		if err := server.Serve(); err != nil && GlobalContext.Err() == nil {
+			logger.LogIf(GlobalContext, fmt.Errorf("ftp: %w", err))
+		}
+	}()
+	return nil
+}
@@ -0,0 +1,147 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"io"
+	"io/ioutil"
+	"net/url"
+	"os"
+	"testing"
+
+	ftp "github.com/fclairamb/ftpserverlib"
+	"golang.org/x/crypto/ssh"
+)
+
+func TestParseFTPArgs(t *testing.T) {
+	testCases := []struct {
+		args     []string
+		expected ftpConfig
+		err      bool
+	}{
+		{nil, ftpConfig{address: ":8021"}, false},
+		{[]string{"address=:2021"}, ftpConfig{address: ":2021"}, false},
+		{
+			[]string{"passive-port-range=30000-40000", "tls-private-key=key.pem", "tls-public-cert=cert.pem"},
+			ftpConfig{address: ":8021", passivePortRange: &ftp.PortRange{Start: 30000, End: 40000}, tlsPrivateKey: "key.pem", tlsPublicCert: "cert.pem"},
+			false,
+		},
+		{[]string{"passive-port-range=40000-30000"}, ftpConfig{}, true},
+		{[]string{"passive-port-range=30000"}, ftpConfig{}, true},
+		{[]string{"tls-private-key=key.pem"}, ftpConfig{}, true},
+		{[]string{"address=2021"}, ftpConfig{}, true},
+		{[]string{"port=2021"}, ftpConfig{}, true},
+	}
+	for i, testCase := range testCases {
+		cfg, err := parseFTPArgs(testCase.args)
+		if (err != nil) != testCase.err {
+			t.Errorf("case %d: unexpected error %v", i+1, err)
+			continue
+		}
+		if err != nil {
+			continue
+		}
+		if cfg.address != testCase.expected.address || cfg.tlsPrivateKey != testCase.expected.tlsPrivateKey ||
+			cfg.tlsPublicCert != testCase.expected.tlsPublicCert {
+			t.Errorf("case %d: expected %+v, got %+v", i+1, testCase.expected, cfg)
+		}
+		if (cfg.passivePortRange == nil) != (testCase.expected.passivePortRange == nil) ||
+			cfg.passivePortRange != nil && *cfg.passivePortRange != *testCase.expected.passivePortRange {
+			t.Errorf("case %d: expected ports %v, got %v", i+1, testCase.expected.passivePortRange, cfg.passivePortRange)
+		}
+	}
+}
+
+func TestFTPDriver(t *testing.T) {
+	testServer := StartTestServer(t, "ErasureSD")
+	defer testServer.Stop()
+
+	u, err := url.Parse(testServer.Server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	d := newFTPDriver(&sftpDriver{
+		permissions: &ssh.Permissions{CriticalOptions: map[string]string{sftpAccessKey: testServer.AccessKey}},
+		endpoint:    u.Host,
+	})
+
+	if err = d.Mkdir("/bucket", 0o755); err != nil {
+		t.Fatal(err)
+	}
+	f, err := d.GetHandle("/bucket/dir/file.txt", os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err = f.Write([]byte("hello ")); err != nil {
+		t.Fatal(err)
+	}
+	if _, err = f.Write([]byte("ftp")); err != nil {
+		t.Fatal(err)
+	}
+	if err = f.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if _, err = d.GetHandle("/bucket/dir/file.txt", os.O_WRONLY|os.O_APPEND, 0); err != errFTPResume {
+		t.Errorf("expected appends to be rejected, got %v", err)
+	}
+
+	f, err = d.GetHandle("/bucket/dir/file.txt", os.O_RDONLY, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err = f.Seek(6, io.SeekStart); err != nil {
+		t.Fatal(err)
+	}
+	data, err := ioutil.ReadAll(f)
+	f.Close()
+	if err != nil || string(data) != "ftp" {
+		t.Fatalf("expected to read the end of the uploaded file, got %q, %v", data, err)
+	}
+
+	if fi, err := d.Stat("/bucket/dir"); err != nil || !fi.IsDir() {
+		t.Errorf("expected a directory, got %v, %v", fi, err)
+	}
+	if fi, err := d.Stat("/bucket/dir/file.txt"); err != nil || fi.Size() != 9 {
+		t.Errorf("expected a file of 9 bytes, got %v, %v", fi, err)
+	}
+	if err = d.RemoveDir("/bucket/dir"); err == nil {
+		t.Error("expected non empty directories not to be removed")
+	}
+
+	if err = d.Rename("/bucket/dir/file.txt", "/bucket/renamed.txt"); err != nil {
+		t.Fatal(err)
+	}
+	entries, err := d.ReadDir("/bucket")
+	if err != nil || len(entries) != 1 || entries[0].Name() != "renamed.txt" {
+		t.Errorf("unexpected entries %v, %v", entries, err)
+	}
+	buckets, err := d.ReadDir("/")
+	if err != nil || len(buckets) != 1 || buckets[0].Name() != "bucket" || !buckets[0].IsDir() {
+		t.Errorf("unexpected buckets %v, %v", buckets, err)
+	}
+
+	if err = d.Remove("/bucket/renamed.txt"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err = d.Stat("/bucket/renamed.txt"); !os.IsNotExist(err) {
+		t.Errorf("expected removed files not to exist, got %v", err)
+	}
+	if err = d.RemoveDir("/bucket"); err != nil {
+		t.Fatal(err)
+	}
+}
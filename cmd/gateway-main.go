@@ -281,6 +281,7 @@ func StartGateway(ctx *cli.Context, gw Gateway) {
 		UseTLSConfig(newTLSConfig(getCert)).
 		UseShutdownTimeout(ctx.Duration("shutdown-timeout")).
 		UseBaseContext(GlobalContext).
+		UseConnState(globalConnStats.trackConnState).
 		UseCustomLogger(log.New(ioutil.Discard, "", 0)) // Turn-off random logging by Go stdlib
 
 	go func() {
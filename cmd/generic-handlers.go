@@ -18,13 +18,15 @@
 package cmd
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"net"
 	"net/http"
 	"path"
 	"runtime/debug"
+	"strconv"
 	"strings"
-	"sync/atomic"
 	"time"
 
 	"github.com/minio/minio-go/v7/pkg/set"
@@ -36,6 +38,7 @@ import (
 	xhttp "github.com/minio/minio/internal/http"
 	"github.com/minio/minio/internal/http/stats"
 	"github.com/minio/minio/internal/logger"
+	"github.com/minio/minio/internal/tracing"
 )
 
 const (
@@ -104,7 +107,7 @@ func setRequestLimitHandler(h http.Handler) http.Handler {
 		}
 		if isHTTPHeaderSizeTooLarge(r.Header) {
 			writeErrorResponse(r.Context(), w, errorCodes.ToAPIErr(ErrMetadataTooLarge), r.URL)
-			atomic.AddUint64(&globalHTTPStats.rejectedRequestsHeader, 1)
+			globalHTTPStats.rejectRequest(r, rejectedHeader, errorCodes.ToAPIErr(ErrMetadataTooLarge).Code)
 			return
 		}
 		// Restricting read data to a given maximum length
@@ -196,6 +199,7 @@ func guessIsHealthCheckReq(req *http.Request) bool {
 	return aType == authTypeAnonymous && (req.Method == http.MethodGet || req.Method == http.MethodHead) &&
 		(req.URL.Path == healthCheckPathPrefix+healthCheckLivenessPath ||
 			req.URL.Path == healthCheckPathPrefix+healthCheckReadinessPath ||
+			req.URL.Path == healthCheckPathPrefix+healthCheckReadinessDetail ||
 			req.URL.Path == healthCheckPathPrefix+healthCheckClusterPath ||
 			req.URL.Path == healthCheckPathPrefix+healthCheckClusterReadPath)
 }
@@ -278,18 +282,42 @@ func setHTTPStatsHandler(h http.Handler) http.Handler {
 		meteredRequest := &stats.IncomingTrafficMeter{ReadCloser: r.Body}
 		meteredResponse := &stats.OutgoingTrafficMeter{ResponseWriter: w}
 
+		// Let collectAPIStats name the request for the size histograms.
+		current := &currentRequest{start: UTCNow()}
+		r = r.WithContext(context.WithValue(r.Context(), currentRequestCtxKey{}, current))
+
+		internode := strings.HasPrefix(r.URL.Path, storageRESTPrefix) ||
+			strings.HasPrefix(r.URL.Path, peerRESTPrefix) ||
+			strings.HasPrefix(r.URL.Path, lockRESTPrefix)
+
+		if !internode {
+			bucket, _ := request2BucketObjectName(r)
+			// Throttle the S3 traffic of buckets with bandwidth limits, access
+			// keys with limits are throttled once the request is authenticated.
+			meteredRequest.Throttle, meteredResponse.Throttle = globalBucketThrottles.throttles(bucket)
+			meteredRequest.Ctx, meteredResponse.Ctx = r.Context(), r.Context()
+			current.meteredRequest, current.meteredResponse = meteredRequest, meteredResponse
+		}
+
 		// Execute the request
 		r.Body = meteredRequest
 		h.ServeHTTP(meteredResponse, r)
 
-		if strings.HasPrefix(r.URL.Path, storageRESTPrefix) ||
-			strings.HasPrefix(r.URL.Path, peerRESTPrefix) ||
-			strings.HasPrefix(r.URL.Path, lockRESTPrefix) {
+		if internode {
 			globalConnStats.incInputBytes(meteredRequest.BytesRead())
 			globalConnStats.incOutputBytes(meteredResponse.BytesWritten())
 		} else {
 			globalConnStats.incS3InputBytes(meteredRequest.BytesRead())
 			globalConnStats.incS3OutputBytes(meteredResponse.BytesWritten())
+
+			// Traffic is attributed to a bucket only once the
+			// request is known to be authorized to an existing one.
+			globalConnStats.incBucketInputBytes(current.trafficBucket, meteredRequest.BytesRead())
+			globalConnStats.incBucketOutputBytes(current.trafficBucket, meteredResponse.BytesWritten())
+
+			if current.api != "" && !strings.HasSuffix(r.URL.Path, minioReservedBucketPathWithSlash) {
+				observeRequestSizes(current.api, meteredRequest.BytesRead(), meteredResponse.BytesWritten())
+			}
 		}
 	})
 }
@@ -347,14 +375,14 @@ func setRequestValidityHandler(h http.Handler) http.Handler {
 			invalidReq := errorCodes.ToAPIErr(ErrInvalidRequest)
 			invalidReq.Description = fmt.Sprintf("%s (%s)", invalidReq.Description, err)
 			writeErrorResponse(r.Context(), w, invalidReq, r.URL)
-			atomic.AddUint64(&globalHTTPStats.rejectedRequestsInvalid, 1)
+			globalHTTPStats.rejectRequest(r, rejectedInvalid, invalidReq.Code)
 			return
 		}
 
 		// Check for bad components in URL path.
 		if hasBadPathComponent(r.URL.Path) {
 			writeErrorResponse(r.Context(), w, errorCodes.ToAPIErr(ErrInvalidResourceName), r.URL)
-			atomic.AddUint64(&globalHTTPStats.rejectedRequestsInvalid, 1)
+			globalHTTPStats.rejectRequest(r, rejectedInvalid, errorCodes.ToAPIErr(ErrInvalidResourceName).Code)
 			return
 		}
 		// Check for bad components in URL query values.
@@ -362,7 +390,7 @@ func setRequestValidityHandler(h http.Handler) http.Handler {
 			for _, v := range vv {
 				if hasBadPathComponent(v) {
 					writeErrorResponse(r.Context(), w, errorCodes.ToAPIErr(ErrInvalidResourceName), r.URL)
-					atomic.AddUint64(&globalHTTPStats.rejectedRequestsInvalid, 1)
+					globalHTTPStats.rejectRequest(r, rejectedInvalid, errorCodes.ToAPIErr(ErrInvalidResourceName).Code)
 					return
 				}
 			}
@@ -371,7 +399,7 @@ func setRequestValidityHandler(h http.Handler) http.Handler {
 			invalidReq := errorCodes.ToAPIErr(ErrInvalidRequest)
 			invalidReq.Description = fmt.Sprintf("%s (request has multiple authentication types, please use one)", invalidReq.Description)
 			writeErrorResponse(r.Context(), w, invalidReq, r.URL)
-			atomic.AddUint64(&globalHTTPStats.rejectedRequestsInvalid, 1)
+			globalHTTPStats.rejectRequest(r, rejectedInvalid, invalidReq.Code)
 			return
 		}
 		// For all other requests reject access to reserved buckets
@@ -477,7 +505,11 @@ func addCustomHeaders(h http.Handler) http.Handler {
 		// value. This is set here so that this header can be logged as
 		// part of the log entry, Error response XML and auditing.
 		// Set custom headers such as x-amz-request-id for each request.
-		w.Header().Set(xhttp.AmzRequestID, mustGetRequestID(UTCNow()))
+		// A request ID provided by the client with X-Request-ID is used
+		// instead of a new one, so that client logs can be correlated.
+		requestID := getRequestID(r)
+		w.Header().Set(xhttp.AmzRequestID, requestID)
+		w.Header().Set(xhttp.RequestID, requestID)
 		h.ServeHTTP(logger.NewResponseWriter(w), r)
 	})
 }
@@ -505,3 +537,44 @@ func setCriticalErrorHandler(h http.Handler) http.Handler {
 		h.ServeHTTP(w, r)
 	})
 }
+
+// setTracingHandler continues the trace of requests carrying a
+// traceparent header. Inter-node calls made on behalf of a traced
+// request are recorded as server spans here, S3 requests are
+// recorded by collectAPIStats once their API is known.
+func setTracingHandler(h http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !tracing.Enabled() {
+			h.ServeHTTP(w, r)
+			return
+		}
+		ctx := tracing.Extract(r.Context(), r.Header)
+		if !guessIsRPCReq(r) || isAdminReq(r) {
+			h.ServeHTTP(w, r.WithContext(ctx))
+			return
+		}
+		ctx, span := tracing.StartRemote(ctx, "internode/"+path.Base(r.URL.Path), tracing.KindServer)
+		if span == nil {
+			h.ServeHTTP(w, r.WithContext(ctx))
+			return
+		}
+		span.SetAttributes("http.method", r.Method, "http.target", r.URL.Path)
+		rw := logger.NewResponseWriter(w)
+		h.ServeHTTP(rw, r.WithContext(ctx))
+		finishHTTPSpan(span, rw)
+	})
+}
+
+// finishHTTPSpan ends the span of a request with the response
+// status, only server errors mark the span as failed.
+func finishHTTPSpan(span *tracing.Span, w *logger.ResponseWriter) {
+	if span == nil {
+		return
+	}
+	span.SetAttributes("http.status_code", strconv.Itoa(w.StatusCode))
+	if w.StatusCode >= http.StatusInternalServerError {
+		span.Finish(errors.New(http.StatusText(w.StatusCode)))
+		return
+	}
+	span.Finish(nil)
+}
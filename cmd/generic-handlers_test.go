@@ -22,6 +22,7 @@ import (
 	"net/http/httptest"
 	"net/url"
 	"strconv"
+	"strings"
 	"testing"
 
 	"github.com/minio/minio/internal/crypto"
@@ -166,3 +167,32 @@ func TestSSETLSHandler(t *testing.T) {
 		}
 	}
 }
+
+func TestAddCustomHeadersRequestID(t *testing.T) {
+	var errHandler http.HandlerFunc = func(w http.ResponseWriter, r *http.Request) {
+		writeErrorResponse(r.Context(), w, errorCodes.ToAPIErr(ErrNoSuchBucket), r.URL)
+	}
+	h := addCustomHeaders(errHandler)
+
+	const requestID = "3f2a9c1e-7b4d-4e8f-a1b2-c3d4e5f60718"
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest(http.MethodGet, "/bucket", nil)
+	r.Header.Set(xhttp.RequestID, requestID)
+	h.ServeHTTP(w, r)
+	if id := w.Header().Get(xhttp.AmzRequestID); id != requestID {
+		t.Errorf("expected %s %q, got %q", xhttp.AmzRequestID, requestID, id)
+	}
+	if id := w.Header().Get(xhttp.RequestID); id != requestID {
+		t.Errorf("expected %s %q, got %q", xhttp.RequestID, requestID, id)
+	}
+	if !strings.Contains(w.Body.String(), "<RequestId>"+requestID+"</RequestId>") {
+		t.Errorf("expected the request ID in the error response, got %s", w.Body.String())
+	}
+
+	w = httptest.NewRecorder()
+	h.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/bucket", nil))
+	id := w.Header().Get(xhttp.AmzRequestID)
+	if id == "" || w.Header().Get(xhttp.RequestID) != id {
+		t.Errorf("expected the same new request ID in both headers, got %q and %q", id, w.Header().Get(xhttp.RequestID))
+	}
+}
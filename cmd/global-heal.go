@@ -21,6 +21,7 @@ import (
 	"context"
 	"fmt"
 	"sort"
+	"sync"
 	"time"
 
 	"github.com/dustin/go-humanize"
@@ -30,6 +31,7 @@ import (
 	"github.com/minio/minio/internal/logger"
 	"github.com/minio/pkg/console"
 	"github.com/minio/pkg/wildcard"
+	"golang.org/x/time/rate"
 )
 
 const (
@@ -161,10 +163,100 @@ func mustGetHealSequence(ctx context.Context) *healSequence {
 	}
 }
 
+// dynamicBandwidth limits the bytes processed per second to the
+// bandwidth returned by limit, 0 meaning no limit. The bandwidth is
+// looked up on every wait to follow configuration changes.
+type dynamicBandwidth struct {
+	limit func() uint64
+
+	mu        sync.Mutex
+	bandwidth uint64
+	limiter   *rate.Limiter
+}
+
+// waitBytes waits until size more bytes may be processed.
+func (t *dynamicBandwidth) waitBytes(ctx context.Context, size int64) {
+	t.mu.Lock()
+	if bw := t.limit(); bw != t.bandwidth {
+		t.bandwidth = bw
+		t.limiter = nil
+		if bw > 0 {
+			t.limiter = rate.NewLimiter(rate.Limit(bw), int(bw))
+		}
+	}
+	limiter, burst := t.limiter, int64(t.bandwidth)
+	t.mu.Unlock()
+	if limiter == nil {
+		return
+	}
+	for size > 0 {
+		n := size
+		if n > burst {
+			n = burst
+		}
+		if limiter.WaitN(ctx, int(n)) != nil {
+			return
+		}
+		size -= n
+	}
+}
+
+// healDriveThrottle limits the number of objects healed in parallel and
+// the bytes healed per second on a drive being healed, both follow the
+// heal configuration as it changes.
+type healDriveThrottle struct {
+	dynamicBandwidth
+
+	mu     sync.Mutex
+	cond   *sync.Cond
+	active int
+}
+
+func newHealDriveThrottle() *healDriveThrottle {
+	t := &healDriveThrottle{
+		dynamicBandwidth: dynamicBandwidth{limit: func() uint64 {
+			return globalHealConfig.GetDriveBandwidth()
+		}},
+	}
+	t.cond = sync.NewCond(&t.mu)
+	return t
+}
+
+// take waits until one more object may be healed.
+func (t *healDriveThrottle) take() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	for t.active >= globalHealConfig.GetDriveWorkers() {
+		t.cond.Wait()
+	}
+	t.active++
+}
+
+// give releases an object healing slot taken with take.
+func (t *healDriveThrottle) give() {
+	t.mu.Lock()
+	t.active--
+	t.mu.Unlock()
+	t.cond.Broadcast()
+}
+
+// wait waits until all the objects being healed are done.
+func (t *healDriveThrottle) wait() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	for t.active > 0 {
+		t.cond.Wait()
+	}
+}
+
 // healErasureSet lists and heals all objects in a specific erasure set
 func (er *erasureObjects) healErasureSet(ctx context.Context, buckets []string, tracker *healingTracker) error {
 	bgSeq := mustGetHealSequence(ctx)
 	scanMode := madmin.HealNormalScan
+	throttle := newHealDriveThrottle()
+
+	// Protects tracker, objects are healed in parallel.
+	var trackerMu sync.Mutex
 
 	// Make sure to copy since `buckets slice`
 	// is modified in place by tracker.
@@ -248,22 +340,26 @@ func (er *erasureObjects) healErasureSet(ctx context.Context, buckets []string,
 					object:    entry.name,
 					versionID: "",
 				}, madmin.HealItemObject)
+				trackerMu.Lock()
 				if err != nil {
 					tracker.ItemsFailed++
 					logger.LogIf(ctx, fmt.Errorf("unable to heal object %s/%s: %w", bucket, entry.name, err))
 				} else {
 					tracker.ItemsHealed++
 				}
+				trackerMu.Unlock()
 				bgSeq.logHeal(madmin.HealItemObject)
 				return
 			}
 
 			for _, version := range fivs.Versions {
-				if _, err := er.HealObject(ctx, bucket, version.Name,
+				_, err := er.HealObject(ctx, bucket, version.Name,
 					version.VersionID, madmin.HealOpts{
 						ScanMode: scanMode,
 						Remove:   healDeleteDangling,
-					}); err != nil {
+					})
+				trackerMu.Lock()
+				if err != nil {
 					// If not deleted, assume they failed.
 					tracker.ItemsFailed++
 					tracker.BytesFailed += uint64(version.Size)
@@ -276,17 +372,31 @@ func (er *erasureObjects) healErasureSet(ctx context.Context, buckets []string,
 					tracker.ItemsHealed++
 					tracker.BytesDone += uint64(version.Size)
 				}
+				trackerMu.Unlock()
 				bgSeq.logHeal(madmin.HealItemObject)
+				throttle.waitBytes(ctx, version.Size)
 			}
+			trackerMu.Lock()
 			tracker.Object = entry.name
 			if time.Since(tracker.LastUpdate) > time.Minute {
 				logger.LogIf(ctx, tracker.update(ctx))
 			}
+			trackerMu.Unlock()
 
 			// Wait and proceed if there are active requests
 			waitForLowHTTPReq()
 		}
 
+		// Heal entries in parallel, as many as the heal
+		// configuration allows for a drive.
+		healEntryAsync := func(entry metaCacheEntry) {
+			throttle.take()
+			go func() {
+				defer throttle.give()
+				healEntry(entry)
+			}()
+		}
+
 		// How to resolve partial results.
 		resolver := metadataResolutionParams{
 			dirQuorum: 1,
@@ -301,7 +411,7 @@ func (er *erasureObjects) healErasureSet(ctx context.Context, buckets []string,
 			forwardTo:      forwardTo,
 			minDisks:       1,
 			reportNotFound: false,
-			agreed:         healEntry,
+			agreed:         healEntryAsync,
 			partial: func(entries metaCacheEntries, nAgreed int, errs []error) {
 				entry, ok := entries.resolve(&resolver)
 				if !ok {
@@ -309,10 +419,11 @@ func (er *erasureObjects) healErasureSet(ctx context.Context, buckets []string,
 					// proceed to heal nonetheless.
 					entry, _ = entries.firstFound()
 				}
-				healEntry(*entry)
+				healEntryAsync(*entry)
 			},
 			finished: nil,
 		})
+		throttle.wait()
 		if err != nil {
 			// Set this such that when we return this function
 			// we let the caller retry this disk again for the
@@ -40,6 +40,7 @@ import (
 	"github.com/minio/minio/internal/config/callhome"
 	"github.com/minio/minio/internal/config/compress"
 	"github.com/minio/minio/internal/config/dns"
+	"github.com/minio/minio/internal/config/drive"
 	xldap "github.com/minio/minio/internal/config/identity/ldap"
 	"github.com/minio/minio/internal/config/identity/openid"
 	idplugin "github.com/minio/minio/internal/config/identity/plugin"
@@ -207,6 +208,10 @@ var (
 
 	globalStorageClass storageclass.Config
 
+	// globalDriveConfig controls O_DIRECT and readahead
+	// of the local NVMe and HDD drives.
+	globalDriveConfig = drive.Config{NVMeODirect: true, HDDODirect: true}
+
 	globalLDAPConfig   xldap.Config
 	globalOpenIDConfig openid.Config
 	globalSTSTLSConfig xtls.Config
@@ -221,6 +226,9 @@ var (
 
 	globalTLSCerts *certs.Manager
 
+	// Read-only WebDAV endpoint, nil unless enabled with --webdav.
+	globalWebDAVConfig *webdavConfig
+
 	globalHTTPServer        *xhttp.Server
 	globalHTTPServerErrorCh = make(chan error)
 	globalOSSignalCh        = make(chan os.Signal, 1)
@@ -255,6 +263,27 @@ var (
 	// Global HTTP request statisitics
 	globalHTTPStats = newHTTPStats()
 
+	// Global per bucket bandwidth throttles
+	globalBucketThrottles = &bucketThrottles{}
+
+	// Global map of the custom domains of the buckets
+	globalBucketDomains = &bucketDomainMap{}
+
+	// Global buffer of the server access logs of the buckets
+	globalBucketAccessLogs = &bucketAccessLogs{}
+
+	// Global cache of the metrics collected from the peers for the cluster metrics
+	globalPeerMetricsCache = newPeerMetricsCache(peerMetricsCacheTTL())
+
+	// Global per access key request rate and bandwidth throttles
+	globalAccessKeyThrottles = &accessKeyThrottles{}
+
+	// Global record of the last use of the access keys
+	globalAccessKeyUsage = &accessKeyUsageTracker{}
+
+	// Global per bucket access point stats
+	globalBucketTransformStats = &bucketTransformStats{}
+
 	// Time when the server is started
 	globalBootTime = UTCNow()
 
@@ -265,6 +294,8 @@ var (
 	globalDomainNames []string      // Root domains for virtual host style requests
 	globalDomainIPs   set.StringSet // Root domain IP address(s) for a distributed MinIO deployment
 
+	globalWebsiteDomainNames []string // Root domains of the website endpoints of buckets
+
 	globalOperationTimeout       = newDynamicTimeout(10*time.Minute, 5*time.Minute) // default timeout for general ops
 	globalDeleteOperationTimeout = newDynamicTimeout(5*time.Minute, 1*time.Minute)  // default time for delete ops
 
@@ -329,10 +360,16 @@ var (
 	// If writes to FS backend should be O_SYNC.
 	globalFSOSync bool
 
+	// If plain objects on FS backend should be sent with sendfile.
+	globalFSZeroCopy bool
+
 	globalProxyEndpoints []ProxyEndpoint
 
 	globalInternodeTransport http.RoundTripper
 
+	// Connections opened by globalInternodeTransport
+	globalInternodeDialStats xhttp.DialStats
+
 	globalProxyTransport http.RoundTripper
 
 	globalDNSCache = &dnscache.Resolver{
@@ -354,6 +391,9 @@ var (
 	globalServiceFreezeCnt int32
 	globalServiceFreezeMu  sync.Mutex // Updates.
 
+	// Set while new S3 API calls are rejected, see drainServices.
+	globalServiceDraining int32
+
 	// List of local drives to this node, this is only set during server startup.
 	globalLocalDrives []StorageAPI
 
@@ -19,15 +19,19 @@ package cmd
 
 import (
 	"io/ioutil"
+	"math"
 	"net/http"
+	"reflect"
 	"runtime"
 	"strconv"
 	"sync"
 	"time"
 
 	"github.com/shirou/gopsutil/v3/mem"
+	"golang.org/x/time/rate"
 
 	"github.com/minio/minio/internal/config/api"
+	xhttp "github.com/minio/minio/internal/http"
 	xioutil "github.com/minio/minio/internal/ioutil"
 	"github.com/minio/minio/internal/logger"
 )
@@ -51,6 +55,22 @@ type apiConfig struct {
 	deleteCleanupInterval       time.Duration
 	disableODirect              bool
 	gzipObjects                 bool
+
+	// Requests per second limiters of this node,
+	// overall and per api, nil if unlimited.
+	requestsRate        int
+	requestsRateAPI     map[string]int
+	requestsLimiter     *rate.Limiter
+	apiRequestsLimiters map[string]*rate.Limiter
+
+	// Concurrent requests pools of this node per api.
+	requestsMaxAPI   map[string]int
+	apiRequestsPools map[string]chan struct{}
+
+	// Reserved for priority requests, see requestPriority.
+	priorityRequestsPool chan struct{}
+
+	copyConcurrency int
 }
 
 const cgroupLimitFile = "/sys/fs/cgroup/memory/memory.limit_in_bytes"
@@ -131,6 +151,7 @@ func (t *apiConfig) init(cfg api.Config, setDriveCounts []int) {
 		// There will be a short overlap window,
 		// but this shouldn't last long.
 		t.requestsPool = make(chan struct{}, apiRequestsMaxPerNode)
+		t.priorityRequestsPool = make(chan struct{}, priorityRequestsMax(apiRequestsMaxPerNode))
 	}
 	t.requestsDeadline = cfg.RequestsDeadline
 	t.listQuorum = cfg.ListQuorum
@@ -151,6 +172,78 @@ func (t *apiConfig) init(cfg api.Config, setDriveCounts []int) {
 	t.deleteCleanupInterval = cfg.DeleteCleanupInterval
 	t.disableODirect = cfg.DisableODirect
 	t.gzipObjects = cfg.GzipObjects
+
+	if cfg.RequestsRate != t.requestsRate || !reflect.DeepEqual(cfg.RequestsRateAPI, t.requestsRateAPI) {
+		// Only replace if needed, so the
+		// limiters keep their state.
+		t.requestsLimiter = newRequestsLimiter(cfg.RequestsRate)
+		t.apiRequestsLimiters = make(map[string]*rate.Limiter, len(cfg.RequestsRateAPI))
+		for api, limit := range cfg.RequestsRateAPI {
+			t.apiRequestsLimiters[api] = newRequestsLimiter(limit)
+		}
+	}
+	t.requestsRate = cfg.RequestsRate
+	t.requestsRateAPI = cfg.RequestsRateAPI
+
+	if !reflect.DeepEqual(cfg.RequestsMaxAPI, t.requestsMaxAPI) {
+		// Like the requests pool, existing requests
+		// release their slots to the previous pools.
+		t.apiRequestsPools = make(map[string]chan struct{}, len(cfg.RequestsMaxAPI))
+		for api, limit := range cfg.RequestsMaxAPI {
+			if len(globalEndpoints.Hostnames()) > 0 {
+				limit /= len(globalEndpoints.Hostnames())
+			}
+			if limit < 1 {
+				limit = 1
+			}
+			t.apiRequestsPools[api] = make(chan struct{}, limit)
+		}
+	}
+	t.requestsMaxAPI = cfg.RequestsMaxAPI
+	t.copyConcurrency = cfg.CopyConcurrency
+}
+
+// newRequestsLimiter returns a limiter of the node share of
+// limit requests per second, nil if limit is not set.
+func newRequestsLimiter(limit int) *rate.Limiter {
+	if limit <= 0 {
+		return nil
+	}
+	if len(globalEndpoints.Hostnames()) > 0 {
+		limit /= len(globalEndpoints.Hostnames())
+	}
+	if limit < 1 {
+		limit = 1
+	}
+	return rate.NewLimiter(rate.Limit(limit), limit)
+}
+
+// reserveRequest accounts a request of api with the requests per
+// second limiters, if a limit is exceeded it returns how long to
+// wait before retrying and the request is not accounted.
+func (t *apiConfig) reserveRequest(api string) (retryAfter time.Duration) {
+	t.mu.RLock()
+	limiters := [...]*rate.Limiter{t.requestsLimiter, t.apiRequestsLimiters[api]}
+	t.mu.RUnlock()
+
+	now := time.Now()
+	reservations := make([]*rate.Reservation, 0, len(limiters))
+	for _, limiter := range limiters {
+		if limiter == nil {
+			continue
+		}
+		reservation := limiter.ReserveN(now, 1)
+		reservations = append(reservations, reservation)
+		if delay := reservation.DelayFrom(now); delay > retryAfter {
+			retryAfter = delay
+		}
+	}
+	if retryAfter > 0 {
+		for _, reservation := range reservations {
+			reservation.CancelAt(now)
+		}
+	}
+	return retryAfter
 }
 
 func (t *apiConfig) isDisableODirect() bool {
@@ -227,7 +320,32 @@ func (t *apiConfig) getClusterDeadline() time.Duration {
 	return t.clusterDeadline
 }
 
-func (t *apiConfig) getRequestsPool() (chan struct{}, time.Duration) {
+// priorityRequestsMax returns the number of concurrent priority
+// requests allowed besides requestsMax regular requests.
+func priorityRequestsMax(requestsMax int) int {
+	if requestsMax < 4 {
+		return 1
+	}
+	return requestsMax / 4
+}
+
+// requestPriority classifies the requests served by maxClients,
+// priority requests are those of other clusters replicating to
+// or proxying through this one. Admin APIs, health probes and
+// inter-node calls are not throttled by maxClients at all.
+func requestPriority(r *http.Request) bool {
+	if _, ok := r.Header[xhttp.MinIOSourceReplicationRequest]; ok {
+		return true
+	}
+	_, ok := r.Header[xhttp.MinIOSourceProxyRequest]
+	return ok
+}
+
+// getRequestsPools returns the concurrent requests pools a request
+// of api waits for, the pool of api if limited first. Priority
+// requests only wait for a reserved pool so they are not queued
+// behind regular requests.
+func (t *apiConfig) getRequestsPools(api string, priority bool) ([]chan struct{}, time.Duration) {
 	t.mu.RLock()
 	defer t.mu.RUnlock()
 
@@ -235,7 +353,13 @@ func (t *apiConfig) getRequestsPool() (chan struct{}, time.Duration) {
 		return nil, time.Duration(0)
 	}
 
-	return t.requestsPool, t.requestsDeadline
+	if priority {
+		return []chan struct{}{t.priorityRequestsPool}, t.requestsDeadline
+	}
+	if pool, ok := t.apiRequestsPools[api]; ok {
+		return []chan struct{}{pool, t.requestsPool}, t.requestsDeadline
+	}
+	return []chan struct{}{t.requestsPool}, t.requestsDeadline
 }
 
 // maxClients throttles the S3 API calls
@@ -257,8 +381,21 @@ func maxClients(f http.HandlerFunc) http.HandlerFunc {
 			}
 		}
 
-		pool, deadline := globalAPIConfig.getRequestsPool()
-		if pool == nil {
+		if r.Header.Get(globalObjectPerfUserMetadata) == "" {
+			if isServiceDraining() {
+				// Let clients retry on another server right away.
+				w.Header().Set(xhttp.RetryAfter, "1")
+				writeErrorResponse(r.Context(), w, errorCodes.ToAPIErr(ErrServerDraining), r.URL)
+				return
+			}
+			if !allowRequestRate(w, r) {
+				return
+			}
+		}
+
+		api := requestAPIName(r)
+		pools, deadline := globalAPIConfig.getRequestsPools(api, requestPriority(r))
+		if pools == nil {
 			f.ServeHTTP(w, r)
 			return
 		}
@@ -268,23 +405,59 @@ func maxClients(f http.HandlerFunc) http.HandlerFunc {
 		deadlineTimer := time.NewTimer(deadline)
 		defer deadlineTimer.Stop()
 
-		select {
-		case pool <- struct{}{}:
-			defer func() { <-pool }()
-			globalHTTPStats.addRequestsInQueue(-1)
-			f.ServeHTTP(w, r)
-		case <-deadlineTimer.C:
-			// Send a http timeout message
-			writeErrorResponse(r.Context(), w,
-				errorCodes.ToAPIErr(ErrOperationMaxedOut),
-				r.URL)
-			globalHTTPStats.addRequestsInQueue(-1)
-			return
-		case <-r.Context().Done():
-			globalHTTPStats.addRequestsInQueue(-1)
-			return
+		for _, pool := range pools {
+			select {
+			case pool <- struct{}{}:
+				defer func(pool chan struct{}) { <-pool }(pool)
+			case <-deadlineTimer.C:
+				// Send a http timeout message
+				writeErrorResponse(r.Context(), w,
+					errorCodes.ToAPIErr(ErrOperationMaxedOut),
+					r.URL)
+				globalHTTPStats.totalS3MaxedOut.Inc(api)
+				globalHTTPStats.addRequestsInQueue(-1)
+				return
+			case <-r.Context().Done():
+				globalHTTPStats.addRequestsInQueue(-1)
+				return
+			}
 		}
+		globalHTTPStats.addRequestsInQueue(-1)
+		f.ServeHTTP(w, r)
+	}
+}
+
+// allowRequestRate returns true if the request r is within the
+// requests per second limits, otherwise it replies "SlowDown"
+// with the number of seconds to wait before retrying.
+func allowRequestRate(w http.ResponseWriter, r *http.Request) bool {
+	api := requestAPIName(r)
+	retryAfter := globalAPIConfig.reserveRequest(api)
+	if retryAfter <= 0 {
+		return true
+	}
+	writeSlowDownResponse(w, r, retryAfter)
+	return false
+}
+
+// writeSlowDownResponse replies "SlowDown" to a request rejected
+// by a requests per second limit, asking the client to retry after
+// retryAfter rounded up to whole seconds.
+func writeSlowDownResponse(w http.ResponseWriter, r *http.Request, retryAfter time.Duration) {
+	globalHTTPStats.totalS3RateLimited.Inc(requestAPIName(r))
+
+	setRetryAfter(w, retryAfter)
+	writeErrorResponse(r.Context(), w, errorCodes.ToAPIErr(ErrSlowDown), r.URL)
+}
+
+// setRetryAfter sets the Retry-After header to retryAfter
+// rounded up to whole seconds.
+func setRetryAfter(w http.ResponseWriter, retryAfter time.Duration) {
+	seconds := int(math.Ceil(retryAfter.Seconds()))
+	if seconds < 1 {
+		seconds = 1
 	}
+	w.Header().Set(xhttp.RetryAfter, strconv.Itoa(seconds))
 }
 
 func (t *apiConfig) getReplicationFailedWorkers() int {
@@ -307,3 +480,13 @@ func (t *apiConfig) getTransitionWorkers() int {
 
 	return t.transitionWorkers
 }
+
+func (t *apiConfig) getCopyConcurrency() int {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	if t.copyConcurrency < 1 {
+		return 1
+	}
+	return t.copyConcurrency
+}
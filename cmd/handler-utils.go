@@ -36,6 +36,7 @@ import (
 	"github.com/minio/minio/internal/handlers"
 	xhttp "github.com/minio/minio/internal/http"
 	"github.com/minio/minio/internal/logger"
+	"github.com/minio/minio/internal/tracing"
 	xnet "github.com/minio/pkg/net"
 )
 
@@ -383,19 +384,54 @@ func httpTraceHdrs(f http.HandlerFunc) http.HandlerFunc {
 
 func collectAPIStats(api string, f http.HandlerFunc) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
-		globalHTTPStats.currentS3Requests.Inc(api)
-		defer globalHTTPStats.currentS3Requests.Dec(api)
+		// Only the outermost collectAPIStats names the request and
+		// accounts it as in-flight, reserved bucket requests are not
+		// accounted the same way updateStats ignores them.
+		current, ok := r.Context().Value(currentRequestCtxKey{}).(*currentRequest)
+		if !ok {
+			current = &currentRequest{start: UTCNow()}
+			r = r.WithContext(context.WithValue(r.Context(), currentRequestCtxKey{}, current))
+		}
+		var span *tracing.Span
+		outermost := current.api == ""
+		if outermost {
+			current.api = api
+			if !strings.HasSuffix(r.URL.Path, minioReservedBucketPathWithSlash) {
+				current.inc(&globalHTTPStats.currentS3Requests, &globalHTTPStats.peakS3Requests)
+			}
+			defer current.dec(&globalHTTPStats.currentS3Requests)
+
+			var ctx context.Context
+			if ctx, span = tracing.StartRoot(r.Context(), "s3."+api, tracing.KindServer); span != nil {
+				span.SetAttributes("http.method", r.Method, "http.target", r.URL.Path,
+					"minio.request_id", w.Header().Get(xhttp.AmzRequestID))
+				r = r.WithContext(ctx)
+			}
+		}
 
 		statsWriter := logger.NewResponseWriter(w)
 
 		f.ServeHTTP(statsWriter, r)
 
+		finishHTTPSpan(span, statsWriter)
+
 		globalHTTPStats.updateStats(api, r, statsWriter)
+
+		if outermost {
+			setRequestTrafficBucket(r, statsWriter)
+			globalBucketAccessLogs.record(r, statsWriter)
+		}
 	}
 }
 
 // Returns "/bucketName/objectName" for path-style or virtual-host-style requests.
 func getResource(path string, host string, domains []string) (string, error) {
+	if bucket, ok := websiteBucket(host); ok {
+		return SlashSeparator + pathJoin(bucket, path), nil
+	}
+	if bucket, ok := globalBucketDomains.bucket(host); ok {
+		return SlashSeparator + pathJoin(bucket, path), nil
+	}
 	if len(domains) == 0 {
 		return path, nil
 	}
@@ -0,0 +1,167 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"context"
+	"sort"
+	"time"
+)
+
+// healDriveProgress is the healing progress of a drive being healed.
+type healDriveProgress struct {
+	Node           string    `json:"node"`
+	Endpoint       string    `json:"endpoint"`
+	PoolIndex      int       `json:"pool"`
+	SetIndex       int       `json:"set"`
+	DiskIndex      int       `json:"disk"`
+	Started        time.Time `json:"started"`
+	LastUpdate     time.Time `json:"lastUpdate"`
+	ObjectsTotal   uint64    `json:"objectsTotal"`
+	ObjectsHealed  uint64    `json:"objectsHealed"`
+	ObjectsFailed  uint64    `json:"objectsFailed"`
+	BytesTotal     uint64    `json:"bytesTotal"`
+	BytesDone      uint64    `json:"bytesDone"`
+	BytesFailed    uint64    `json:"bytesFailed"`
+	BytesRemaining uint64    `json:"bytesRemaining"`
+	BytesPerSecond uint64    `json:"bytesPerSecond"`
+	ETASeconds     int64     `json:"etaSeconds,omitempty"`
+	Bucket         string    `json:"bucket,omitempty"`
+	Object         string    `json:"object,omitempty"`
+}
+
+// healSetProgress is the healing progress of all the drives being healed
+// in an erasure set.
+type healSetProgress struct {
+	PoolIndex      int                 `json:"pool"`
+	SetIndex       int                 `json:"set"`
+	ObjectsHealed  uint64              `json:"objectsHealed"`
+	ObjectsFailed  uint64              `json:"objectsFailed"`
+	BytesRemaining uint64              `json:"bytesRemaining"`
+	ETASeconds     int64               `json:"etaSeconds,omitempty"`
+	Drives         []healDriveProgress `json:"drives"`
+}
+
+// healProgressInfo is the heal throttling in effect and the healing
+// progress of all the erasure sets with drives being healed.
+type healProgressInfo struct {
+	DriveWorkers   int               `json:"driveWorkers"`
+	DriveBandwidth uint64            `json:"driveBandwidth"`
+	Sets           []healSetProgress `json:"sets"`
+	Errors         []string          `json:"errors,omitempty"`
+}
+
+// newHealDriveProgress returns the progress of the drive healed by h,
+// the remaining time is estimated from the bytes healed so far.
+func newHealDriveProgress(h healingTracker, now time.Time) healDriveProgress {
+	p := healDriveProgress{
+		Node:          globalLocalNodeName,
+		Endpoint:      h.Endpoint,
+		PoolIndex:     h.PoolIndex,
+		SetIndex:      h.SetIndex,
+		DiskIndex:     h.DiskIndex,
+		Started:       h.Started.UTC(),
+		LastUpdate:    h.LastUpdate.UTC(),
+		ObjectsTotal:  h.ObjectsTotalCount,
+		ObjectsHealed: h.ItemsHealed,
+		ObjectsFailed: h.ItemsFailed,
+		BytesTotal:    h.ObjectsTotalSize,
+		BytesDone:     h.BytesDone,
+		BytesFailed:   h.BytesFailed,
+		Bucket:        h.Bucket,
+		Object:        h.Object,
+	}
+	processed := h.BytesDone + h.BytesFailed
+	if processed < h.ObjectsTotalSize {
+		p.BytesRemaining = h.ObjectsTotalSize - processed
+	}
+	if elapsed := now.Sub(h.Started); elapsed >= time.Second && processed > 0 {
+		p.BytesPerSecond = uint64(float64(processed) / elapsed.Seconds())
+	}
+	if p.BytesPerSecond > 0 {
+		p.ETASeconds = int64(p.BytesRemaining / p.BytesPerSecond)
+	}
+	return p
+}
+
+// getLocalHealProgress returns the healing progress of the local drives
+// being healed.
+func (ahs *allHealState) getLocalHealProgress() []healDriveProgress {
+	ahs.RLock()
+	defer ahs.RUnlock()
+	now := time.Now()
+	drives := make([]healDriveProgress, 0, len(ahs.healStatus))
+	for _, h := range ahs.healStatus {
+		drives = append(drives, newHealDriveProgress(h, now))
+	}
+	return drives
+}
+
+// getLocalHealProgress returns the healing progress of the local drives
+// being healed, if any.
+func getLocalHealProgress() []healDriveProgress {
+	if globalBackgroundHealState == nil {
+		return nil
+	}
+	return globalBackgroundHealState.getLocalHealProgress()
+}
+
+// groupHealProgress groups the drives being healed by erasure set, an
+// erasure set is healed once its slowest drive is.
+func groupHealProgress(drives []healDriveProgress) []healSetProgress {
+	sort.Slice(drives, func(i, j int) bool {
+		a, b := drives[i], drives[j]
+		if a.PoolIndex != b.PoolIndex {
+			return a.PoolIndex < b.PoolIndex
+		}
+		if a.SetIndex != b.SetIndex {
+			return a.SetIndex < b.SetIndex
+		}
+		return a.DiskIndex < b.DiskIndex
+	})
+	sets := []healSetProgress{}
+	for _, d := range drives {
+		if n := len(sets); n == 0 || sets[n-1].PoolIndex != d.PoolIndex || sets[n-1].SetIndex != d.SetIndex {
+			sets = append(sets, healSetProgress{PoolIndex: d.PoolIndex, SetIndex: d.SetIndex})
+		}
+		set := &sets[len(sets)-1]
+		set.ObjectsHealed += d.ObjectsHealed
+		set.ObjectsFailed += d.ObjectsFailed
+		set.BytesRemaining += d.BytesRemaining
+		if d.ETASeconds > set.ETASeconds {
+			set.ETASeconds = d.ETASeconds
+		}
+		set.Drives = append(set.Drives, d)
+	}
+	return sets
+}
+
+// getHealProgress returns the heal throttling in effect and the healing
+// progress of the drives being healed on all the nodes.
+func getHealProgress(ctx context.Context) healProgressInfo {
+	info := healProgressInfo{
+		DriveWorkers:   globalHealConfig.GetDriveWorkers(),
+		DriveBandwidth: globalHealConfig.GetDriveBandwidth(),
+	}
+	drives := getLocalHealProgress()
+	peerDrives, errs := globalNotificationSys.GetHealProgress(ctx)
+	drives = append(drives, peerDrives...)
+	info.Errors = errs
+	info.Sets = groupHealProgress(drives)
+	return info
+}
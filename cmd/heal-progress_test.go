@@ -0,0 +1,110 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"testing"
+	"time"
+)
+
+func TestHealDriveProgress(t *testing.T) {
+	now := time.Now()
+	h := healingTracker{
+		Endpoint:          "http://server1:9000/data1",
+		PoolIndex:         0,
+		SetIndex:          1,
+		DiskIndex:         2,
+		Started:           now.Add(-100 * time.Second),
+		ObjectsTotalCount: 100,
+		ObjectsTotalSize:  10000,
+		ItemsHealed:       20,
+		ItemsFailed:       1,
+		BytesDone:         1900,
+		BytesFailed:       100,
+	}
+	p := newHealDriveProgress(h, now)
+	if p.BytesRemaining != 8000 {
+		t.Errorf("expected 8000 bytes remaining, got %d", p.BytesRemaining)
+	}
+	if p.BytesPerSecond != 20 {
+		t.Errorf("expected 20 bytes per second, got %d", p.BytesPerSecond)
+	}
+	if p.ETASeconds != 400 {
+		t.Errorf("expected an ETA of 400 seconds, got %d", p.ETASeconds)
+	}
+
+	// Nothing healed yet, no ETA.
+	h.BytesDone, h.BytesFailed = 0, 0
+	if p = newHealDriveProgress(h, now); p.ETASeconds != 0 || p.BytesRemaining != 10000 {
+		t.Errorf("unexpected progress %+v", p)
+	}
+}
+
+func TestGroupHealProgress(t *testing.T) {
+	drives := []healDriveProgress{
+		{PoolIndex: 1, SetIndex: 0, DiskIndex: 3, ObjectsHealed: 5, BytesRemaining: 50, ETASeconds: 10},
+		{PoolIndex: 0, SetIndex: 2, DiskIndex: 1, ObjectsHealed: 1, BytesRemaining: 10, ETASeconds: 30},
+		{PoolIndex: 1, SetIndex: 0, DiskIndex: 1, ObjectsHealed: 2, BytesRemaining: 20, ETASeconds: 60},
+	}
+	sets := groupHealProgress(drives)
+	if len(sets) != 2 {
+		t.Fatalf("expected 2 sets, got %d", len(sets))
+	}
+	if sets[0].PoolIndex != 0 || sets[0].SetIndex != 2 || len(sets[0].Drives) != 1 {
+		t.Errorf("unexpected first set %+v", sets[0])
+	}
+	set := sets[1]
+	if set.PoolIndex != 1 || set.SetIndex != 0 || len(set.Drives) != 2 {
+		t.Fatalf("unexpected second set %+v", set)
+	}
+	if set.Drives[0].DiskIndex != 1 || set.Drives[1].DiskIndex != 3 {
+		t.Errorf("expected drives sorted by index, got %+v", set.Drives)
+	}
+	if set.ObjectsHealed != 7 || set.BytesRemaining != 70 || set.ETASeconds != 60 {
+		t.Errorf("unexpected totals %+v", set)
+	}
+}
+
+func TestHealDriveThrottle(t *testing.T) {
+	defer globalHealConfig.Update(globalHealConfig)
+
+	cfg := globalHealConfig
+	cfg.DriveWorkers = 2
+	globalHealConfig.Update(cfg)
+
+	throttle := newHealDriveThrottle()
+	throttle.take()
+	throttle.take()
+
+	taken := make(chan struct{})
+	go func() {
+		throttle.take()
+		close(taken)
+	}()
+	select {
+	case <-taken:
+		t.Fatal("expected only 2 objects healed in parallel")
+	case <-time.After(50 * time.Millisecond):
+	}
+	throttle.give()
+	<-taken
+
+	throttle.give()
+	throttle.give()
+	throttle.wait()
+}
@@ -19,14 +19,19 @@ package cmd
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
+	"fmt"
 	"net/http"
 	"strconv"
 
 	xhttp "github.com/minio/minio/internal/http"
 )
 
-const unavailable = "offline"
+const (
+	unavailable = "offline"
+	draining    = "draining"
+)
 
 func shouldProxy() bool {
 	return newObjectLayerFn() == nil
@@ -104,8 +109,14 @@ func ClusterReadCheckHandler(w http.ResponseWriter, r *http.Request) {
 	writeResponse(w, http.StatusOK, nil, mimeNone)
 }
 
-// ReadinessCheckHandler Checks if the process is up. Always returns success.
+// ReadinessCheckHandler Checks if the process is up. Returns an
+// error while draining so load balancers stop sending requests.
 func ReadinessCheckHandler(w http.ResponseWriter, r *http.Request) {
+	if isServiceDraining() {
+		w.Header().Set(xhttp.MinIOServerStatus, draining)
+		writeResponse(w, http.StatusServiceUnavailable, nil, mimeNone)
+		return
+	}
 	LivenessCheckHandler(w, r)
 }
 
@@ -163,3 +174,120 @@ func LivenessCheckHandler(w http.ResponseWriter, r *http.Request) {
 
 	writeResponse(w, http.StatusOK, nil, mimeNone)
 }
+
+// subsystemHealth is the readiness of a single subsystem.
+type subsystemHealth struct {
+	Healthy bool   `json:"healthy"`
+	Error   string `json:"error,omitempty"`
+}
+
+// erasureHealth is the readiness of the erasure sets.
+type erasureHealth struct {
+	subsystemHealth
+	Sets []SetHealth `json:"sets,omitempty"`
+}
+
+// replicationHealth is the reachability of the remote targets.
+type replicationHealth struct {
+	subsystemHealth
+	Targets []TargetHealth `json:"targets"`
+}
+
+// readinessDetail is the per-subsystem readiness of the server,
+// replication targets are reported but never make it unready.
+type readinessDetail struct {
+	Healthy     bool               `json:"healthy"`
+	Draining    bool               `json:"draining"`
+	IAM         subsystemHealth    `json:"iam"`
+	Erasure     *erasureHealth     `json:"erasure,omitempty"`
+	KMS         *subsystemHealth   `json:"kms,omitempty"`
+	Replication *replicationHealth `json:"replication,omitempty"`
+}
+
+// getReadinessDetail checks the IAM, erasure, KMS and replication
+// subsystems of this server.
+func getReadinessDetail(ctx context.Context, objLayer ObjectLayer) readinessDetail {
+	detail := readinessDetail{Draining: isServiceDraining()}
+
+	detail.IAM.Healthy = globalIAMSys.loaded()
+	if !detail.IAM.Healthy {
+		detail.IAM.Error = "IAM sub-system is not loaded yet"
+	}
+
+	switch z := objLayer.(type) {
+	case nil:
+		detail.Erasure = &erasureHealth{subsystemHealth: subsystemHealth{Error: errServerNotInitialized.Error()}}
+	case *erasureServerPools:
+		detail.Erasure = &erasureHealth{subsystemHealth: subsystemHealth{Healthy: true}, Sets: z.SetsHealth(ctx)}
+		for _, set := range detail.Erasure.Sets {
+			if !set.Healthy {
+				detail.Erasure.Healthy = false
+				detail.Erasure.Error = fmt.Sprintf("Write quorum lost on pool: %d, set: %d", set.Pool, set.Set)
+				break
+			}
+		}
+	}
+
+	if GlobalKMS != nil {
+		detail.KMS = &subsystemHealth{}
+		errCh := make(chan error, 1)
+		go func() {
+			_, err := GlobalKMS.Stat()
+			errCh <- err
+		}()
+		select {
+		case err := <-errCh:
+			if err != nil {
+				detail.KMS.Error = err.Error()
+			}
+		case <-ctx.Done():
+			detail.KMS.Error = "KMS did not respond in time"
+		}
+		detail.KMS.Healthy = detail.KMS.Error == ""
+	}
+
+	if targets := globalBucketTargetSys.TargetsHealth(); len(targets) > 0 {
+		detail.Replication = &replicationHealth{subsystemHealth: subsystemHealth{Healthy: true}, Targets: targets}
+		for _, tgt := range targets {
+			if !tgt.Online {
+				detail.Replication.Healthy = false
+				detail.Replication.Error = "Remote target " + tgt.Endpoint + " is offline"
+				break
+			}
+		}
+	}
+
+	detail.Healthy = !detail.Draining && detail.IAM.Healthy &&
+		(detail.Erasure == nil || detail.Erasure.Healthy) &&
+		(detail.KMS == nil || detail.KMS.Healthy)
+	return detail
+}
+
+// ReadinessDetailCheckHandler returns the readiness of every subsystem
+// as JSON, the status is '200 OK' when the server is ready and
+// '503 Service Unavailable' otherwise.
+func ReadinessDetailCheckHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "ReadinessDetailCheckHandler")
+
+	ctx, cancel := context.WithTimeout(ctx, globalAPIConfig.getClusterDeadline())
+	defer cancel()
+
+	detail := getReadinessDetail(ctx, newObjectLayerFn())
+	data, err := json.Marshal(detail)
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toAPIError(ctx, err), r.URL)
+		return
+	}
+
+	statusCode := http.StatusOK
+	if !detail.Healthy {
+		statusCode = http.StatusServiceUnavailable
+		switch {
+		case detail.Draining:
+			w.Header().Set(xhttp.MinIOServerStatus, draining)
+		case shouldProxy():
+			w.Header().Set(xhttp.MinIOServerStatus, unavailable)
+		}
+	}
+	writeResponse(w, statusCode, data, mimeJSON)
+}
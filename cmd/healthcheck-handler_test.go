@@ -0,0 +1,67 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+package cmd
+
+import (
+	"context"
+	"testing"
+)
+
+func TestGetReadinessDetail(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	objLayer, fsDirs, err := prepareErasurePools()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer objLayer.Shutdown(context.Background())
+	defer removeRoots(fsDirs)
+
+	initAllTestSubsystems(t)
+	savedNotificationSys := globalNotificationSys
+	defer func() { globalNotificationSys = savedNotificationSys }()
+	globalNotificationSys = NewNotificationSys(EndpointServerPools{})
+
+	detail := getReadinessDetail(ctx, objLayer)
+	if detail.Healthy || detail.IAM.Healthy {
+		t.Fatal("expected server to be unready before IAM is loaded")
+	}
+	if detail.Erasure == nil || !detail.Erasure.Healthy {
+		t.Fatalf("expected healthy erasure sets, got %+v", detail.Erasure)
+	}
+	if len(detail.Erasure.Sets) != 2 {
+		t.Fatalf("expected 2 erasure sets, got %d", len(detail.Erasure.Sets))
+	}
+	for _, set := range detail.Erasure.Sets {
+		if set.OnlineDrives != set.TotalDrives || set.WriteQuorum > set.OnlineDrives {
+			t.Errorf("unexpected set health %+v", set)
+		}
+	}
+	if detail.KMS != nil || detail.Replication != nil {
+		t.Error("expected no KMS and replication detail without configuration")
+	}
+
+	close(globalIAMSys.configLoaded)
+	if detail = getReadinessDetail(ctx, objLayer); !detail.Healthy {
+		t.Fatalf("expected server to be ready, got %+v", detail)
+	}
+
+	if detail = getReadinessDetail(ctx, nil); detail.Healthy || detail.Erasure == nil || detail.Erasure.Healthy {
+		t.Fatalf("expected server to be unready without object layer, got %+v", detail)
+	}
+}
@@ -27,6 +27,7 @@ const (
 	healthCheckPath            = "/health"
 	healthCheckLivenessPath    = "/live"
 	healthCheckReadinessPath   = "/ready"
+	healthCheckReadinessDetail = "/ready/detail"
 	healthCheckClusterPath     = "/cluster"
 	healthCheckClusterReadPath = "/cluster/read"
 	healthCheckPathPrefix      = minioReservedBucketPath + healthCheckPath
@@ -50,4 +51,7 @@ func registerHealthCheckRouter(router *mux.Router) {
 	// Readiness handler
 	healthRouter.Methods(http.MethodGet).Path(healthCheckReadinessPath).HandlerFunc(httpTraceAll(ReadinessCheckHandler))
 	healthRouter.Methods(http.MethodHead).Path(healthCheckReadinessPath).HandlerFunc(httpTraceAll(ReadinessCheckHandler))
+
+	// Readiness handler with the state of every subsystem
+	healthRouter.Methods(http.MethodGet).Path(healthCheckReadinessDetail).HandlerFunc(httpTraceAll(ReadinessDetailCheckHandler))
 }
@@ -0,0 +1,161 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Maximum number of prefixes tracked per bucket, requests
+// to other prefixes are only accounted to the bucket.
+const maxAnonymousPrefixes = 1000
+
+// add accounts a request to r.
+func (r *ServerAnonymousRequests) add(write, denied bool, now time.Time) {
+	switch {
+	case denied:
+		r.Denied++
+	case write:
+		r.Writes++
+	default:
+		r.Reads++
+	}
+	if now.After(r.LastAccess) {
+		r.LastAccess = now
+	}
+}
+
+// merge sums the requests of o into r.
+func (r *ServerAnonymousRequests) merge(o ServerAnonymousRequests) {
+	r.Reads += o.Reads
+	r.Writes += o.Writes
+	r.Denied += o.Denied
+	if o.LastAccess.After(r.LastAccess) {
+		r.LastAccess = o.LastAccess
+	}
+}
+
+// anonymousAccessLog tracks the anonymous requests per bucket
+// and per prefix, the prefix of an object is its parent directory.
+type anonymousAccessLog struct {
+	mu      sync.Mutex
+	buckets map[string]*ServerAnonymousBucketRequests
+}
+
+// addAt accounts an anonymous request to object in bucket at now
+// along with its status code.
+func (l *anonymousAccessLog) addAt(now time.Time, bucket, object string, write bool, code int) {
+	if bucket == "" || isMinioReservedBucket(bucket) || isMinioMetaBucket(bucket) {
+		return
+	}
+	denied := code == http.StatusForbidden
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.buckets == nil {
+		l.buckets = make(map[string]*ServerAnonymousBucketRequests)
+	}
+	b, ok := l.buckets[bucket]
+	if !ok {
+		if len(l.buckets) >= maxBucketConnStats {
+			var (
+				oldest     string
+				oldestTime time.Time
+			)
+			for k, v := range l.buckets {
+				if oldest == "" || v.Requests.LastAccess.Before(oldestTime) {
+					oldest, oldestTime = k, v.Requests.LastAccess
+				}
+			}
+			delete(l.buckets, oldest)
+		}
+		b = &ServerAnonymousBucketRequests{Prefixes: make(map[string]ServerAnonymousRequests)}
+		l.buckets[bucket] = b
+	}
+	b.Requests.add(write, denied, now)
+
+	if object == "" {
+		return
+	}
+	var prefix string
+	if i := strings.LastIndex(object, SlashSeparator); i >= 0 {
+		prefix = object[:i+1]
+	}
+	p, ok := b.Prefixes[prefix]
+	if !ok && len(b.Prefixes) >= maxAnonymousPrefixes {
+		return
+	}
+	p.add(write, denied, now)
+	b.Prefixes[prefix] = p
+}
+
+// add accounts an anonymous request to object in bucket.
+func (l *anonymousAccessLog) add(bucket, object string, write bool, code int) {
+	l.addAt(UTCNow(), bucket, object, write, code)
+}
+
+// report returns a copy of the anonymous requests per bucket.
+func (l *anonymousAccessLog) report() map[string]ServerAnonymousBucketRequests {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	report := make(map[string]ServerAnonymousBucketRequests, len(l.buckets))
+	for bucket, b := range l.buckets {
+		prefixes := make(map[string]ServerAnonymousRequests, len(b.Prefixes))
+		for prefix, p := range b.Prefixes {
+			prefixes[prefix] = p
+		}
+		report[bucket] = ServerAnonymousBucketRequests{
+			Requests: b.Requests,
+			Prefixes: prefixes,
+		}
+	}
+	return report
+}
+
+// reset discards all accounted requests.
+func (l *anonymousAccessLog) reset() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.buckets = nil
+}
+
+// mergeAnonymousRequests sums the anonymous requests
+// to the same buckets and prefixes reported by different nodes.
+func mergeAnonymousRequests(reports []map[string]ServerAnonymousBucketRequests) map[string]ServerAnonymousBucketRequests {
+	merged := make(map[string]ServerAnonymousBucketRequests)
+	for _, report := range reports {
+		for bucket, b := range report {
+			m, ok := merged[bucket]
+			if !ok {
+				m.Prefixes = make(map[string]ServerAnonymousRequests)
+			}
+			m.Requests.merge(b.Requests)
+			for prefix, p := range b.Prefixes {
+				mp := m.Prefixes[prefix]
+				mp.merge(p)
+				m.Prefixes[prefix] = mp
+			}
+			merged[bucket] = m
+		}
+	}
+	return merged
+}
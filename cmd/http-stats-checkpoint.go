@@ -0,0 +1,238 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"path"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/minio/minio/internal/config"
+	"github.com/minio/minio/internal/logger"
+	"github.com/minio/pkg/env"
+)
+
+// Environment variables configuring the checkpointing of
+// cumulative HTTP and traffic stats in the backend.
+const (
+	// EnvStatsCheckpointInterval enables checkpointing of the stats
+	// at the given interval, stats are reloaded at the next startup.
+	EnvStatsCheckpointInterval = "MINIO_STATS_CHECKPOINT_INTERVAL"
+
+	// EnvStatsCheckpointReset discards the last checkpoint at startup.
+	EnvStatsCheckpointReset = "MINIO_STATS_CHECKPOINT_RESET"
+)
+
+const (
+	statsCheckpointPrefix  = "stats"
+	statsCheckpointVersion = 1
+
+	minStatsCheckpointInterval = time.Minute
+)
+
+// statsCheckpoint holds the cumulative counters of a node, gauges
+// and per bucket stats are not checkpointed.
+type statsCheckpoint struct {
+	Version  int                       `json:"version"`
+	Node     string                    `json:"node"`
+	Time     time.Time                 `json:"time"`
+	Conn     ServerConnStats           `json:"conn"`
+	Counters map[string]uint64         `json:"counters"`
+	APIStats map[string]map[string]int `json:"apiStats"`
+
+	StatusCodes map[string]map[int]uint64 `json:"statusCodes,omitempty"`
+}
+
+// statsCheckpointPath returns the path of the checkpoint of
+// the node, node names are hashed since they contain ':'.
+func statsCheckpointPath(node string) string {
+	return path.Join(statsCheckpointPrefix, getSHA256Hash([]byte(node))[:16]+".json")
+}
+
+// checkpointCounters returns the cumulative atomic counters by name.
+func (st *HTTPStats) checkpointCounters() map[string]*uint64 {
+	return map[string]*uint64{
+		"rejectedAuth":     &st.rejectedRequestsAuth,
+		"rejectedTime":     &st.rejectedRequestsTime,
+		"rejectedHeader":   &st.rejectedRequestsHeader,
+		"rejectedInvalid":  &st.rejectedRequestsInvalid,
+		"rejectedExpired":  &st.rejectedRequestsExpired,
+		"getObjectBytes":   &st.getObjectBytes,
+		"getObjectCount":   &st.getObjectCount,
+		"putObjectBytes":   &st.putObjectBytes,
+		"putObjectCount":   &st.putObjectCount,
+		"readRequests":     &st.readRequests,
+		"writeRequests":    &st.writeRequests,
+		"metadataRequests": &st.metadataRequests,
+	}
+}
+
+// checkpointAPIStats returns the cumulative per api counters by name.
+func (st *HTTPStats) checkpointAPIStats() map[string]*HTTPAPIStats {
+	stats := map[string]*HTTPAPIStats{
+		"requests":          &st.totalS3Requests,
+		"errors":            &st.totalS3Errors,
+		"4xx":               &st.totalS34xxErrors,
+		"5xx":               &st.totalS35xxErrors,
+		"canceled":          &st.totalS3Canceled,
+		"canceled-client":   &st.totalS3CanceledClient,
+		"canceled-shutdown": &st.totalS3CanceledShutdown,
+		"deadline-exceeded": &st.totalS3DeadlineExceeded,
+		"rate-limited":      &st.totalS3RateLimited,
+		"maxed-out":         &st.totalS3MaxedOut,
+		"3xx":               &st.totalS33xx,
+		"authenticated":     &st.authenticatedRequests,
+		"anonymous":         &st.anonymousRequests,
+	}
+	for i, typ := range encryptionTypes {
+		stats["encryption-"+strings.ToLower(typ)] = &st.encryptionRequests[i]
+	}
+	return stats
+}
+
+// newStatsCheckpoint takes a checkpoint of the cumulative counters.
+func newStatsCheckpoint(st *HTTPStats, cs *ConnStats) statsCheckpoint {
+	c := statsCheckpoint{
+		Version:  statsCheckpointVersion,
+		Node:     globalLocalNodeName,
+		Time:     UTCNow(),
+		Conn:     cs.toServerConnStats(),
+		Counters: make(map[string]uint64),
+		APIStats: make(map[string]map[string]int),
+	}
+	for name, counter := range st.checkpointCounters() {
+		c.Counters[name] = atomic.LoadUint64(counter)
+	}
+	for name, stats := range st.checkpointAPIStats() {
+		c.APIStats[name] = stats.Load()
+	}
+	c.StatusCodes = st.statusCodes.load()
+	return c
+}
+
+// restore adds the checkpointed counters to the current ones, so
+// requests served before the checkpoint was loaded are kept.
+func (c statsCheckpoint) restore(st *HTTPStats, cs *ConnStats) {
+	atomic.AddUint64(&cs.totalInputBytes, c.Conn.TotalInputBytes)
+	atomic.AddUint64(&cs.totalOutputBytes, c.Conn.TotalOutputBytes)
+	atomic.AddUint64(&cs.s3InputBytes, c.Conn.S3InputBytes)
+	atomic.AddUint64(&cs.s3OutputBytes, c.Conn.S3OutputBytes)
+	atomic.AddUint64(&cs.wraparounds, c.Conn.Wraparounds)
+
+	counters := st.checkpointCounters()
+	for name, value := range c.Counters {
+		if counter, ok := counters[name]; ok {
+			atomic.AddUint64(counter, value)
+		}
+	}
+	apiStats := st.checkpointAPIStats()
+	for name, values := range c.APIStats {
+		stats, ok := apiStats[name]
+		if !ok {
+			continue
+		}
+		for api, value := range values {
+			stats.add(api, value)
+		}
+	}
+	for api, codes := range c.StatusCodes {
+		for code, n := range codes {
+			st.statusCodes.add(api, code, n)
+		}
+	}
+}
+
+// initStatsCheckpoint reloads the last checkpoint of the node and
+// starts checkpointing the stats periodically, if configured.
+func initStatsCheckpoint(ctx context.Context, objAPI ObjectLayer) {
+	value := env.Get(EnvStatsCheckpointInterval, "")
+	if value == "" {
+		return
+	}
+	interval, err := time.ParseDuration(value)
+	if err != nil {
+		logger.Fatal(err, "Invalid %s value in environment variable", EnvStatsCheckpointInterval)
+	}
+	if interval < minStatsCheckpointInterval {
+		logger.Fatal(fmt.Errorf("interval must be at least %s", minStatsCheckpointInterval),
+			"Invalid %s value in environment variable", EnvStatsCheckpointInterval)
+	}
+	reset, err := config.ParseBool(env.Get(EnvStatsCheckpointReset, config.EnableOff))
+	if err != nil {
+		logger.Fatal(err, "Invalid %s value in environment variable", EnvStatsCheckpointReset)
+	}
+
+	configFile := statsCheckpointPath(globalLocalNodeName)
+	if reset {
+		if err = deleteConfig(ctx, objAPI, configFile); err != nil && !errors.Is(err, errConfigNotFound) {
+			logger.LogIf(ctx, err)
+		}
+	} else if err = loadStatsCheckpoint(ctx, objAPI, configFile); err != nil {
+		logger.LogIf(ctx, fmt.Errorf("Unable to load stats checkpoint: %w", err))
+	}
+
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				if err := saveStatsCheckpoint(ctx, objAPI, configFile); err != nil {
+					logger.LogIf(ctx, fmt.Errorf("Unable to save stats checkpoint: %w", err))
+				}
+			}
+		}
+	}()
+}
+
+// loadStatsCheckpoint restores the checkpoint from the backend,
+// a missing checkpoint is not an error.
+func loadStatsCheckpoint(ctx context.Context, objAPI ObjectLayer, configFile string) error {
+	data, err := readConfig(ctx, objAPI, configFile)
+	if err != nil {
+		if errors.Is(err, errConfigNotFound) {
+			return nil
+		}
+		return err
+	}
+	var c statsCheckpoint
+	if err = json.Unmarshal(data, &c); err != nil {
+		return err
+	}
+	if c.Version != statsCheckpointVersion {
+		return fmt.Errorf("unsupported stats checkpoint version %d", c.Version)
+	}
+	c.restore(globalHTTPStats, globalConnStats)
+	return nil
+}
+
+// saveStatsCheckpoint saves a checkpoint of the current stats to the backend.
+func saveStatsCheckpoint(ctx context.Context, objAPI ObjectLayer, configFile string) error {
+	data, err := json.Marshal(newStatsCheckpoint(globalHTTPStats, globalConnStats))
+	if err != nil {
+		return err
+	}
+	return saveConfig(ctx, objAPI, configFile, data)
+}
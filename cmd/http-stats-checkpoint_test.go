@@ -0,0 +1,71 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestStatsCheckpointRestore(t *testing.T) {
+	st, cs := newHTTPStats(), newConnStats()
+	st.totalS3Requests.Inc("getobject")
+	st.totalS3Requests.Inc("getobject")
+	st.totalS34xxErrors.Inc("getobject")
+	st.statusCodes.add("getobject", 404, 1)
+	st.rejectedRequestsAuth = 3
+	st.currentS3Requests.Inc("getobject")
+	cs.incS3InputBytes(100)
+	cs.incOutputBytes(200)
+
+	data, err := json.Marshal(newStatsCheckpoint(st, cs))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var c statsCheckpoint
+	if err = json.Unmarshal(data, &c); err != nil {
+		t.Fatal(err)
+	}
+
+	// Requests served before the checkpoint is loaded are kept.
+	restoredHTTP, restoredConn := newHTTPStats(), newConnStats()
+	restoredHTTP.totalS3Requests.Inc("getobject")
+	c.restore(restoredHTTP, restoredConn)
+
+	if got := restoredHTTP.totalS3Requests.Load()["getobject"]; got != 3 {
+		t.Errorf("expected 3 requests, got %d", got)
+	}
+	if got := restoredHTTP.totalS34xxErrors.Load()["getobject"]; got != 1 {
+		t.Errorf("expected 1 4xx error, got %d", got)
+	}
+	if got := restoredHTTP.statusCodes.load()["getobject"][404]; got != 1 {
+		t.Errorf("expected 1 404 response, got %d", got)
+	}
+	if got := restoredHTTP.rejectedRequestsAuth; got != 3 {
+		t.Errorf("expected 3 rejected requests, got %d", got)
+	}
+	if got := restoredHTTP.currentS3Requests.Load()["getobject"]; got != 0 {
+		t.Errorf("expected gauges not to be restored, got %d", got)
+	}
+	if got := restoredConn.getS3InputBytes(); got != 100 {
+		t.Errorf("expected 100 S3 input bytes, got %d", got)
+	}
+	if got := restoredConn.getTotalOutputBytes(); got != 200 {
+		t.Errorf("expected 200 output bytes, got %d", got)
+	}
+}
@@ -0,0 +1,163 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"sort"
+	"sync"
+	"time"
+)
+
+const (
+	// Client requests are counted in windows of this duration, the
+	// top clients cover the current and the previous window.
+	topClientsWindow = 5 * time.Minute
+
+	// Maximum number of clients tracked in a window.
+	maxTopClients = 500
+)
+
+// clientStats holds the requests and bytes of a single client.
+type clientStats struct {
+	requests uint64
+	bytes    uint64
+}
+
+// topClients keeps a bounded table of the clients with the most
+// requests. Once the table is full a new client replaces the client
+// with the least requests and inherits its counts, so the counts of
+// clients are over estimated by at most the counts they inherited,
+// heavy hitters are never missed. The zero value is ready to use.
+type topClients struct {
+	mu       sync.Mutex
+	start    time.Time
+	current  map[string]*clientStats
+	previous map[string]*clientStats
+}
+
+// rotate starts a new window if the current one is over,
+// must be called with mu held.
+func (t *topClients) rotate(now time.Time) {
+	if t.current == nil {
+		t.current = make(map[string]*clientStats)
+		t.start = now
+		return
+	}
+	elapsed := now.Sub(t.start)
+	if elapsed < topClientsWindow {
+		return
+	}
+	t.previous = t.current
+	if elapsed >= 2*topClientsWindow {
+		t.previous = nil
+	}
+	t.current = make(map[string]*clientStats)
+	t.start = now
+}
+
+// addAt accounts a request of the client transferring n bytes at now.
+func (t *topClients) addAt(now time.Time, client string, n uint64) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.rotate(now)
+
+	cs, ok := t.current[client]
+	if !ok {
+		cs = &clientStats{}
+		if len(t.current) >= maxTopClients {
+			var (
+				min      string
+				minStats *clientStats
+			)
+			for c, s := range t.current {
+				if minStats == nil || s.requests < minStats.requests {
+					min, minStats = c, s
+				}
+			}
+			delete(t.current, min)
+			*cs = *minStats
+		}
+		t.current[client] = cs
+	}
+	cs.requests++
+	cs.bytes += n
+}
+
+// add accounts a request of the client transferring n bytes.
+func (t *topClients) add(client string, n uint64) {
+	t.addAt(UTCNow(), client, n)
+}
+
+// reportAt returns the clients of the current and previous window at now.
+func (t *topClients) reportAt(now time.Time) []ServerTopClient {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.rotate(now)
+
+	merged := make(map[string]ServerTopClient, len(t.current)+len(t.previous))
+	for _, window := range []map[string]*clientStats{t.previous, t.current} {
+		for client, cs := range window {
+			c := merged[client]
+			c.Client = client
+			c.Requests += cs.requests
+			c.Bytes += cs.bytes
+			merged[client] = c
+		}
+	}
+	clients := make([]ServerTopClient, 0, len(merged))
+	for _, c := range merged {
+		clients = append(clients, c)
+	}
+	return clients
+}
+
+// report returns the clients of the current and previous window.
+func (t *topClients) report() []ServerTopClient {
+	return t.reportAt(UTCNow())
+}
+
+// mergeTopClients sums the requests and bytes of the same clients
+// reported by different nodes and returns at most count clients,
+// those with the most requests first. All clients are returned
+// if count is not positive.
+func mergeTopClients(reports [][]ServerTopClient, count int) []ServerTopClient {
+	merged := make(map[string]ServerTopClient)
+	for _, report := range reports {
+		for _, c := range report {
+			m := merged[c.Client]
+			m.Client = c.Client
+			m.Requests += c.Requests
+			m.Bytes += c.Bytes
+			merged[c.Client] = m
+		}
+	}
+	clients := make([]ServerTopClient, 0, len(merged))
+	for _, c := range merged {
+		clients = append(clients, c)
+	}
+	sort.Slice(clients, func(i, j int) bool {
+		if clients[i].Requests != clients[j].Requests {
+			return clients[i].Requests > clients[j].Requests
+		}
+		return clients[i].Client < clients[j].Client
+	})
+	if count > 0 && len(clients) > count {
+		clients = clients[:count]
+	}
+	return clients
+}
@@ -0,0 +1,76 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"reflect"
+	"strconv"
+	"testing"
+	"time"
+)
+
+func TestTopClients(t *testing.T) {
+	var tc topClients
+	now := time.Unix(1000000, 0)
+
+	for i := 0; i < 100; i++ {
+		tc.addAt(now, "10.0.0.1", 10)
+	}
+	// Many clients with a single request each fill up the table.
+	for i := 0; i < 2*maxTopClients; i++ {
+		tc.addAt(now, "192.168.0."+strconv.Itoa(i), 1)
+	}
+
+	clients := mergeTopClients([][]ServerTopClient{tc.reportAt(now)}, 1)
+	expected := []ServerTopClient{{Client: "10.0.0.1", Requests: 100, Bytes: 1000}}
+	if !reflect.DeepEqual(clients, expected) {
+		t.Errorf("expected %+v, got %+v", expected, clients)
+	}
+	if got := len(tc.reportAt(now)); got != maxTopClients {
+		t.Errorf("expected %d tracked clients, got %d", maxTopClients, got)
+	}
+
+	// The previous window is still reported after a rotation.
+	now = now.Add(topClientsWindow)
+	tc.addAt(now, "10.0.0.1", 10)
+	clients = mergeTopClients([][]ServerTopClient{tc.reportAt(now)}, 1)
+	if clients[0].Requests != 101 {
+		t.Errorf("expected 101 requests, got %d", clients[0].Requests)
+	}
+
+	// Two windows later nothing is left.
+	now = now.Add(2 * topClientsWindow)
+	if got := tc.reportAt(now); len(got) != 0 {
+		t.Errorf("expected no clients, got %+v", got)
+	}
+}
+
+func TestMergeTopClients(t *testing.T) {
+	clients := mergeTopClients([][]ServerTopClient{
+		{{Client: "a", Requests: 1, Bytes: 1}, {Client: "b", Requests: 3, Bytes: 3}},
+		{{Client: "a", Requests: 4, Bytes: 4}},
+		nil,
+	}, 0)
+	expected := []ServerTopClient{
+		{Client: "a", Requests: 5, Bytes: 5},
+		{Client: "b", Requests: 3, Bytes: 3},
+	}
+	if !reflect.DeepEqual(clients, expected) {
+		t.Errorf("expected %+v, got %+v", expected, clients)
+	}
+}
@@ -0,0 +1,254 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/cespare/xxhash/v2"
+)
+
+const (
+	// Accesses are counted in windows of this duration,
+	// the hot objects cover the last hotObjectsWindows.
+	hotObjectsWindow  = 10 * time.Minute
+	hotObjectsWindows = 6
+
+	// Dimensions of the count-min sketch of a window, estimates
+	// are over by at most 2/hotSketchWidth of the accesses in the
+	// window with a probability of 1-(1/2)^hotSketchDepth.
+	hotSketchDepth = 4
+	hotSketchWidth = 2048
+
+	// Maximum number of hot candidates tracked in a window.
+	maxHotObjects = 100
+)
+
+// countMinSketch estimates how often keys were added
+// in a fixed amount of memory, estimates are never
+// under the actual counts. The zero value is ready to use.
+type countMinSketch struct {
+	counts [hotSketchDepth][hotSketchWidth]uint32
+}
+
+// indexes returns the counter of key in each row of the sketch.
+func (s *countMinSketch) indexes(key string) (idx [hotSketchDepth]uint32) {
+	h := xxhash.Sum64String(key)
+	h1, h2 := uint32(h), uint32(h>>32)
+	for i := range idx {
+		idx[i] = (h1 + uint32(i)*h2) % hotSketchWidth
+	}
+	return idx
+}
+
+// add counts an occurrence of key and returns its new estimate.
+func (s *countMinSketch) add(key string) uint32 {
+	var min uint32
+	for i, j := range s.indexes(key) {
+		s.counts[i][j]++
+		if i == 0 || s.counts[i][j] < min {
+			min = s.counts[i][j]
+		}
+	}
+	return min
+}
+
+// estimate returns the estimated number of occurrences of key.
+func (s *countMinSketch) estimate(key string) uint32 {
+	var min uint32
+	for i, j := range s.indexes(key) {
+		if i == 0 || s.counts[i][j] < min {
+			min = s.counts[i][j]
+		}
+	}
+	return min
+}
+
+// hotWindow holds the accesses of a single window, candidates
+// are the keys with the highest estimates seen in the window.
+type hotWindow struct {
+	sketch     countMinSketch
+	candidates map[string]uint32
+}
+
+// add accounts an access to key.
+func (w *hotWindow) add(key string) {
+	estimate := w.sketch.add(key)
+	if _, ok := w.candidates[key]; ok || len(w.candidates) < maxHotObjects {
+		w.candidates[key] = estimate
+		return
+	}
+	var (
+		min      string
+		minCount uint32
+	)
+	for k, v := range w.candidates {
+		if min == "" || v < minCount {
+			min, minCount = k, v
+		}
+	}
+	if estimate > minCount {
+		delete(w.candidates, min)
+		w.candidates[key] = estimate
+	}
+}
+
+// hotTracker tracks the most frequently accessed keys of the last
+// hotObjectsWindows windows. The zero value is ready to use.
+type hotTracker struct {
+	mu      sync.Mutex
+	start   time.Time
+	current int
+	windows [hotObjectsWindows]*hotWindow
+}
+
+// rotate moves to the window of now, discarding the windows
+// which are too old, must be called with mu held.
+func (t *hotTracker) rotate(now time.Time) {
+	if t.windows[t.current] != nil {
+		elapsed := int(now.Sub(t.start) / hotObjectsWindow)
+		if elapsed <= 0 {
+			return
+		}
+		if elapsed > hotObjectsWindows {
+			elapsed = hotObjectsWindows
+		}
+		for i := 0; i < elapsed; i++ {
+			t.current = (t.current + 1) % hotObjectsWindows
+			t.windows[t.current] = nil
+		}
+	}
+	t.windows[t.current] = &hotWindow{candidates: make(map[string]uint32)}
+	t.start = now
+}
+
+// addAt accounts an access to key at now.
+func (t *hotTracker) addAt(now time.Time, key string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.rotate(now)
+	t.windows[t.current].add(key)
+}
+
+// add accounts an access to key.
+func (t *hotTracker) add(key string) {
+	t.addAt(UTCNow(), key)
+}
+
+// reportAt returns the candidates of all windows at now
+// along with their estimated accesses over all windows.
+func (t *hotTracker) reportAt(now time.Time) []ServerHotObject {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.rotate(now)
+
+	names := make(map[string]struct{})
+	for _, w := range t.windows {
+		if w == nil {
+			continue
+		}
+		for name := range w.candidates {
+			names[name] = struct{}{}
+		}
+	}
+	hot := make([]ServerHotObject, 0, len(names))
+	for name := range names {
+		var requests uint64
+		for _, w := range t.windows {
+			if w != nil {
+				requests += uint64(w.sketch.estimate(name))
+			}
+		}
+		hot = append(hot, ServerHotObject{Name: name, Requests: requests})
+	}
+	return hot
+}
+
+// report returns the hot candidates of the last hotObjectsWindows.
+func (t *hotTracker) report() []ServerHotObject {
+	return t.reportAt(UTCNow())
+}
+
+// reset discards all accounted accesses.
+func (t *hotTracker) reset() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.windows = [hotObjectsWindows]*hotWindow{}
+	t.current = 0
+}
+
+// hotObjects tracks the most frequently read objects and prefixes.
+type hotObjects struct {
+	objects  hotTracker
+	prefixes hotTracker
+}
+
+// add accounts a read of object in bucket, the prefix
+// of an object is its parent directory.
+func (h *hotObjects) add(bucket, object string) {
+	h.objects.add(bucket + SlashSeparator + object)
+	prefix := bucket + SlashSeparator
+	if i := strings.LastIndex(object, SlashSeparator); i >= 0 {
+		prefix += object[:i+1]
+	}
+	h.prefixes.add(prefix)
+}
+
+// report returns the hot objects and prefixes.
+func (h *hotObjects) report() ServerHotObjects {
+	return ServerHotObjects{
+		Objects:  h.objects.report(),
+		Prefixes: h.prefixes.report(),
+	}
+}
+
+// reset discards all accounted reads.
+func (h *hotObjects) reset() {
+	h.objects.reset()
+	h.prefixes.reset()
+}
+
+// mergeHotObjects sums the requests of the same names reported
+// by different nodes and returns at most count names, those
+// with the most requests first. All names are returned if
+// count is not positive.
+func mergeHotObjects(reports [][]ServerHotObject, count int) []ServerHotObject {
+	merged := make(map[string]uint64)
+	for _, report := range reports {
+		for _, h := range report {
+			merged[h.Name] += h.Requests
+		}
+	}
+	hot := make([]ServerHotObject, 0, len(merged))
+	for name, requests := range merged {
+		hot = append(hot, ServerHotObject{Name: name, Requests: requests})
+	}
+	sort.Slice(hot, func(i, j int) bool {
+		if hot[i].Requests != hot[j].Requests {
+			return hot[i].Requests > hot[j].Requests
+		}
+		return hot[i].Name < hot[j].Name
+	})
+	if count > 0 && len(hot) > count {
+		hot = hot[:count]
+	}
+	return hot
+}
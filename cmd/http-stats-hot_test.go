@@ -0,0 +1,101 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/gorilla/mux"
+)
+
+func TestCountMinSketch(t *testing.T) {
+	var s countMinSketch
+	for i := 0; i < 1000; i++ {
+		s.add(strconv.Itoa(i))
+	}
+	for i := 0; i < 50; i++ {
+		s.add("hot")
+	}
+	if got := s.estimate("hot"); got < 50 {
+		t.Errorf("expected estimate of at least 50, got %d", got)
+	}
+	if got := s.estimate("never-added"); got > 2 {
+		t.Errorf("expected estimate close to 0, got %d", got)
+	}
+}
+
+func TestHotTracker(t *testing.T) {
+	var tracker hotTracker
+	now := time.Now()
+
+	// Many keys read once do not evict the keys read the most.
+	for i := 0; i < 10; i++ {
+		tracker.addAt(now, "bucket/hot")
+	}
+	for i := 0; i < 10*maxHotObjects; i++ {
+		tracker.addAt(now, "bucket/cold-"+strconv.Itoa(i))
+	}
+	tracker.addAt(now.Add(hotObjectsWindow), "bucket/hot")
+
+	hot := mergeHotObjects([][]ServerHotObject{tracker.reportAt(now.Add(hotObjectsWindow))}, 1)
+	if len(hot) != 1 || hot[0].Name != "bucket/hot" || hot[0].Requests < 11 {
+		t.Errorf("expected bucket/hot read at least 11 times, got %+v", hot)
+	}
+
+	// Reads older than all windows are forgotten.
+	if hot := tracker.reportAt(now.Add((hotObjectsWindows + 1) * hotObjectsWindow)); len(hot) != 0 {
+		t.Errorf("expected no hot objects after an hour, got %+v", hot)
+	}
+}
+
+func TestHTTPStatsHotObjects(t *testing.T) {
+	saved := globalHTTPStats
+	defer func() { globalHTTPStats = saved }()
+	globalHTTPStats = newHTTPStats()
+
+	handler := collectAPIStats("getobject", func(w http.ResponseWriter, r *http.Request) {})
+	for _, object := range []string{"dir/a", "dir/a", "dir/b", "c"} {
+		r := httptest.NewRequest(http.MethodGet, "/bucket/"+object, nil)
+		handler(httptest.NewRecorder(), mux.SetURLVars(r, map[string]string{"bucket": "bucket", "object": object}))
+	}
+
+	report := globalHTTPStats.hot.report()
+	objects := mergeHotObjects([][]ServerHotObject{report.Objects}, 0)
+	if len(objects) != 3 || objects[0].Name != "bucket/dir/a" || objects[0].Requests != 2 {
+		t.Errorf("unexpected hot objects %+v", objects)
+	}
+	prefixes := mergeHotObjects([][]ServerHotObject{report.Prefixes}, 0)
+	if len(prefixes) != 2 || prefixes[0].Name != "bucket/dir/" || prefixes[0].Requests != 3 || prefixes[1].Name != "bucket/" {
+		t.Errorf("unexpected hot prefixes %+v", prefixes)
+	}
+}
+
+func TestMergeHotObjects(t *testing.T) {
+	reports := [][]ServerHotObject{
+		{{Name: "bucket/a", Requests: 2}, {Name: "bucket/b", Requests: 5}},
+		{{Name: "bucket/a", Requests: 4}},
+	}
+	merged := mergeHotObjects(reports, 1)
+	if len(merged) != 1 || merged[0].Name != "bucket/a" || merged[0].Requests != 6 {
+		t.Errorf("expected bucket/a with 6 requests, got %+v", merged)
+	}
+}
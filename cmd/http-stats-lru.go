@@ -0,0 +1,78 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import "container/list"
+
+// bucketStatsLRU holds the stats of at most maxBucketConnStats
+// buckets, the least recently active bucket is evicted in
+// constant time to make room for a new one. It is not safe
+// for concurrent use.
+type bucketStatsLRU struct {
+	// Most recently active bucket first
+	entries *list.List
+	index   map[string]*list.Element
+}
+
+type bucketStatsEntry struct {
+	bucket string
+	stats  interface{}
+}
+
+func newBucketStatsLRU() *bucketStatsLRU {
+	return &bucketStatsLRU{
+		entries: list.New(),
+		index:   make(map[string]*list.Element),
+	}
+}
+
+// get returns the stats of the bucket, created with newStats
+// if needed, and marks the bucket as the most recently active.
+func (l *bucketStatsLRU) get(bucket string, newStats func() interface{}) interface{} {
+	if e, ok := l.index[bucket]; ok {
+		l.entries.MoveToFront(e)
+		return e.Value.(*bucketStatsEntry).stats
+	}
+	if l.entries.Len() >= maxBucketConnStats {
+		oldest := l.entries.Back()
+		l.entries.Remove(oldest)
+		delete(l.index, oldest.Value.(*bucketStatsEntry).bucket)
+	}
+	entry := &bucketStatsEntry{bucket: bucket, stats: newStats()}
+	l.index[bucket] = l.entries.PushFront(entry)
+	return entry.stats
+}
+
+// len returns the number of buckets with stats.
+func (l *bucketStatsLRU) len() int {
+	if l == nil {
+		return 0
+	}
+	return l.entries.Len()
+}
+
+// forEach calls f with the stats of every bucket.
+func (l *bucketStatsLRU) forEach(f func(bucket string, stats interface{})) {
+	if l == nil {
+		return
+	}
+	for e := l.entries.Front(); e != nil; e = e.Next() {
+		entry := e.Value.(*bucketStatsEntry)
+		f(entry.bucket, entry.stats)
+	}
+}
@@ -0,0 +1,98 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"sync"
+	"time"
+)
+
+// Number of one second slots kept by a rateWindow,
+// enough to compute the rate over the last 15 minutes.
+const rateWindowSlots = 15 * 60
+
+// rateWindow counts events in one second slots over a sliding
+// window of 15 minutes, the zero value is ready to use.
+type rateWindow struct {
+	mu    sync.Mutex
+	slots [rateWindowSlots]uint64
+	last  int64 // unix second of the most recent slot
+}
+
+// advance clears the slots that fell out of the window
+// since the last update, must be called with mu held.
+func (w *rateWindow) advance(now int64) {
+	if now <= w.last {
+		return
+	}
+	if now-w.last >= rateWindowSlots {
+		w.slots = [rateWindowSlots]uint64{}
+	} else {
+		for s := w.last + 1; s <= now; s++ {
+			w.slots[s%rateWindowSlots] = 0
+		}
+	}
+	w.last = now
+}
+
+// addAt counts n events at the unix second now, events from the
+// past, e.g. after the clock was set back, count in the latest slot.
+func (w *rateWindow) addAt(now int64, n uint64) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.advance(now)
+	if now < w.last {
+		now = w.last
+	}
+	w.slots[now%rateWindowSlots] += n
+}
+
+// add counts n events at the current time.
+func (w *rateWindow) add(n uint64) {
+	w.addAt(UTCNow().Unix(), n)
+}
+
+// rateAt returns the events per second over the window ending at the
+// unix second now, windows are truncated to whole seconds and 15 minutes.
+func (w *rateWindow) rateAt(now int64, window time.Duration) float64 {
+	seconds := int64(window / time.Second)
+	if seconds <= 0 {
+		return 0
+	}
+	if seconds > rateWindowSlots {
+		seconds = rateWindowSlots
+	}
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.advance(now)
+	var sum uint64
+	for s := now - seconds + 1; s <= now; s++ {
+		sum += w.slots[((s%rateWindowSlots)+rateWindowSlots)%rateWindowSlots]
+	}
+	return float64(sum) / float64(seconds)
+}
+
+// toServerRates returns the per second rates over the last 1, 5 and 15 minutes.
+func (w *rateWindow) toServerRates() ServerRates {
+	now := UTCNow().Unix()
+	return ServerRates{
+		Avg1m:  w.rateAt(now, time.Minute),
+		Avg5m:  w.rateAt(now, 5*time.Minute),
+		Avg15m: w.rateAt(now, 15*time.Minute),
+	}
+}
@@ -0,0 +1,56 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"testing"
+	"time"
+)
+
+func TestRateWindow(t *testing.T) {
+	var w rateWindow
+	const start = 1000000
+
+	// 60 events per second during the first minute.
+	for s := int64(0); s < 60; s++ {
+		w.addAt(start+s, 60)
+	}
+	now := int64(start + 59)
+	if got := w.rateAt(now, time.Minute); got != 60 {
+		t.Errorf("expected 1m rate of 60, got %f", got)
+	}
+	if got := w.rateAt(now, 5*time.Minute); got != 12 {
+		t.Errorf("expected 5m rate of 12, got %f", got)
+	}
+
+	// Two minutes later the first minute fell out of the 1m window.
+	now += 120
+	if got := w.rateAt(now, time.Minute); got != 0 {
+		t.Errorf("expected 1m rate of 0, got %f", got)
+	}
+	if got := w.rateAt(now, 5*time.Minute); got != 12 {
+		t.Errorf("expected 5m rate of 12, got %f", got)
+	}
+
+	// After an idle period longer than the window nothing is left.
+	now += rateWindowSlots
+	w.addAt(now, 900)
+	if got := w.rateAt(now, 15*time.Minute); got != 1 {
+		t.Errorf("expected 15m rate of 1, got %f", got)
+	}
+}
@@ -0,0 +1,217 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"context"
+	"net/http"
+	"regexp"
+	"sort"
+	"sync"
+	"sync/atomic"
+
+	"github.com/minio/minio/internal/handlers"
+	xhttp "github.com/minio/minio/internal/http"
+)
+
+// Reasons of rejected requests, all but rejectedSignature
+// match the rejected requests counters of HTTPStats.
+const (
+	rejectedAuth      = "auth"
+	rejectedTime      = "time"
+	rejectedHeader    = "header"
+	rejectedInvalid   = "invalid"
+	rejectedExpired   = "expired"
+	rejectedSignature = "signature"
+)
+
+const (
+	// Number of most recent rejected requests kept by a node.
+	maxRejectedRequests = 256
+
+	// Maximum length of a sampled header value.
+	maxRejectedHeaderLen = 256
+)
+
+// Headers sampled from rejected requests, relevant for
+// debugging clients with misconfigured signatures or clocks.
+var rejectedRequestHeaders = []string{
+	xhttp.Authorization,
+	xhttp.AmzDate,
+	xhttp.Date,
+	xhttp.AmzContentSha256,
+	xhttp.ContentLength,
+	"Host",
+	"User-Agent",
+}
+
+// Error codes of failed signature and credential checks
+// done by the API handlers, recorded as rejectedSignature.
+var signatureErrorCodes = map[string]bool{
+	"SignatureDoesNotMatch":             true,
+	"InvalidAccessKeyId":                true,
+	"AuthorizationHeaderMalformed":      true,
+	"AuthorizationQueryParametersError": true,
+	"RequestTimeTooSkewed":              true,
+	"XAmzContentSHA256Mismatch":         true,
+	"ExpiredToken":                      true,
+	"InvalidTokenId":                    true,
+}
+
+var authSignatureRegex = regexp.MustCompile(`(Signature=|^AWS [^:]*:)[^,\s]*`)
+
+// redactAuthorization removes the signature from an
+// Authorization header, the credential is kept.
+func redactAuthorization(s string) string {
+	return authSignatureRegex.ReplaceAllString(s, "${1}*REDACTED*")
+}
+
+// rejectionLog keeps the most recent rejected requests
+// in a ring buffer, the zero value is ready to use.
+type rejectionLog struct {
+	mu      sync.Mutex
+	entries [maxRejectedRequests]ServerRejectedRequest
+	next    int
+	full    bool
+}
+
+// newRejectedRequest samples the rejected request r.
+func newRejectedRequest(r *http.Request, reason, code string) ServerRejectedRequest {
+	headers := make(map[string]string)
+	for _, name := range rejectedRequestHeaders {
+		v := r.Header.Get(name)
+		if name == "Host" {
+			v = r.Host
+		}
+		if v == "" {
+			continue
+		}
+		if name == xhttp.Authorization {
+			v = redactAuthorization(v)
+		}
+		if len(v) > maxRejectedHeaderLen {
+			v = v[:maxRejectedHeaderLen]
+		}
+		headers[name] = v
+	}
+	if v := r.URL.Query().Get(xhttp.AmzCredential); v != "" {
+		headers[xhttp.AmzCredential] = v
+	}
+	return ServerRejectedRequest{
+		Time:    UTCNow(),
+		Node:    globalLocalNodeName,
+		Client:  handlers.GetSourceIP(r),
+		Reason:  reason,
+		Code:    code,
+		Method:  r.Method,
+		Path:    r.URL.Path,
+		Headers: headers,
+	}
+}
+
+// add records the rejected request r.
+func (l *rejectionLog) add(r *http.Request, reason, code string) {
+	entry := newRejectedRequest(r, reason, code)
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.entries[l.next] = entry
+	l.next = (l.next + 1) % maxRejectedRequests
+	if l.next == 0 {
+		l.full = true
+	}
+}
+
+// load returns the recorded rejected requests, the most recent first.
+func (l *rejectionLog) load() []ServerRejectedRequest {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	n := l.next
+	if l.full {
+		n = maxRejectedRequests
+	}
+	entries := make([]ServerRejectedRequest, 0, n)
+	for i := 1; i <= n; i++ {
+		entries = append(entries, l.entries[(l.next-i+maxRejectedRequests)%maxRejectedRequests])
+	}
+	return entries
+}
+
+// reset discards all recorded rejected requests.
+func (l *rejectionLog) reset() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.entries = [maxRejectedRequests]ServerRejectedRequest{}
+	l.next, l.full = 0, false
+}
+
+// rejectRequest accounts the request r rejected for reason
+// with the S3 error code before reaching the API handlers,
+// or while checking the credentials of the request.
+func (st *HTTPStats) rejectRequest(r *http.Request, reason, code string) {
+	st.resetMu.RLock()
+	defer st.resetMu.RUnlock()
+
+	switch reason {
+	case rejectedAuth:
+		atomic.AddUint64(&st.rejectedRequestsAuth, 1)
+	case rejectedTime:
+		atomic.AddUint64(&st.rejectedRequestsTime, 1)
+	case rejectedHeader:
+		atomic.AddUint64(&st.rejectedRequestsHeader, 1)
+	case rejectedInvalid:
+		atomic.AddUint64(&st.rejectedRequestsInvalid, 1)
+	case rejectedExpired:
+		atomic.AddUint64(&st.rejectedRequestsExpired, 1)
+	}
+	st.rejections.add(r, reason, code)
+}
+
+// setRequestErrorCode records the S3 error code
+// sent in response to the request being served.
+func setRequestErrorCode(ctx context.Context, code string) {
+	if current, ok := ctx.Value(currentRequestCtxKey{}).(*currentRequest); ok {
+		current.errorCode = code
+	}
+}
+
+// requestErrorCode returns the S3 error code recorded
+// by setRequestErrorCode, if any.
+func requestErrorCode(r *http.Request) string {
+	if current, ok := r.Context().Value(currentRequestCtxKey{}).(*currentRequest); ok {
+		return current.errorCode
+	}
+	return ""
+}
+
+// mergeRejectedRequests returns at most count of the rejected
+// requests reported by different nodes, the most recent first.
+// All requests are returned if count is not positive.
+func mergeRejectedRequests(reports [][]ServerRejectedRequest, count int) []ServerRejectedRequest {
+	var merged []ServerRejectedRequest
+	for _, report := range reports {
+		merged = append(merged, report...)
+	}
+	sort.SliceStable(merged, func(i, j int) bool {
+		return merged[i].Time.After(merged[j].Time)
+	})
+	if count > 0 && len(merged) > count {
+		merged = merged[:count]
+	}
+	return merged
+}
@@ -0,0 +1,103 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+)
+
+func TestRejectionLog(t *testing.T) {
+	var l rejectionLog
+	for i := 0; i < maxRejectedRequests+10; i++ {
+		l.add(httptest.NewRequest(http.MethodGet, "/bucket/"+strconv.Itoa(i), nil), rejectedTime, "RequestTimeTooSkewed")
+	}
+	entries := l.load()
+	if len(entries) != maxRejectedRequests {
+		t.Fatalf("expected %d entries, got %d", maxRejectedRequests, len(entries))
+	}
+	if entries[0].Path != "/bucket/"+strconv.Itoa(maxRejectedRequests+9) {
+		t.Errorf("expected most recent entry first, got %s", entries[0].Path)
+	}
+	if entries[len(entries)-1].Path != "/bucket/10" {
+		t.Errorf("expected oldest entries to be overwritten, got %s", entries[len(entries)-1].Path)
+	}
+}
+
+func TestRedactAuthorization(t *testing.T) {
+	testCases := []struct {
+		auth     string
+		expected string
+	}{
+		{
+			"AWS4-HMAC-SHA256 Credential=minio/20220101/us-east-1/s3/aws4_request, SignedHeaders=host;x-amz-date, Signature=abcdef",
+			"AWS4-HMAC-SHA256 Credential=minio/20220101/us-east-1/s3/aws4_request, SignedHeaders=host;x-amz-date, Signature=*REDACTED*",
+		},
+		{"AWS minio:abcdef", "AWS minio:*REDACTED*"},
+	}
+	for _, testCase := range testCases {
+		if got := redactAuthorization(testCase.auth); got != testCase.expected {
+			t.Errorf("expected %q, got %q", testCase.expected, got)
+		}
+	}
+}
+
+func TestHTTPStatsSignatureRejections(t *testing.T) {
+	saved := globalHTTPStats
+	defer func() { globalHTTPStats = saved }()
+	globalHTTPStats = newHTTPStats()
+
+	handler := collectAPIStats("getobject", func(w http.ResponseWriter, r *http.Request) {
+		writeErrorResponse(r.Context(), w, errorCodes.ToAPIErr(ErrSignatureDoesNotMatch), r.URL)
+	})
+	r := httptest.NewRequest(http.MethodGet, "/bucket/object", nil)
+	r.Header.Set("Authorization", "AWS minio:abcdef")
+	handler(httptest.NewRecorder(), r)
+
+	// Errors unrelated to credentials are not recorded.
+	handler = collectAPIStats("getobject", func(w http.ResponseWriter, r *http.Request) {
+		writeErrorResponse(r.Context(), w, errorCodes.ToAPIErr(ErrNoSuchKey), r.URL)
+	})
+	handler(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/bucket/object", nil))
+
+	entries := globalHTTPStats.rejections.load()
+	if len(entries) != 1 {
+		t.Fatalf("expected 1 rejected request, got %v", entries)
+	}
+	if entries[0].Reason != rejectedSignature || entries[0].Code != "SignatureDoesNotMatch" {
+		t.Errorf("unexpected rejected request %+v", entries[0])
+	}
+	if got := entries[0].Headers["Authorization"]; got != "AWS minio:*REDACTED*" {
+		t.Errorf("expected redacted authorization, got %q", got)
+	}
+}
+
+func TestMergeRejectedRequests(t *testing.T) {
+	now := time.Now()
+	reports := [][]ServerRejectedRequest{
+		{{Node: "a", Time: now}, {Node: "a", Time: now.Add(-2 * time.Second)}},
+		{{Node: "b", Time: now.Add(-time.Second)}},
+	}
+	merged := mergeRejectedRequests(reports, 2)
+	if len(merged) != 2 || !merged[0].Time.Equal(now) || merged[1].Node != "b" {
+		t.Errorf("expected the 2 most recent requests, got %+v", merged)
+	}
+}
@@ -0,0 +1,124 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"sync/atomic"
+)
+
+// reset zeroes the counters of all apis.
+func (stats *HTTPAPIStats) reset() {
+	stats.Lock()
+	defer stats.Unlock()
+	stats.apiStats = nil
+}
+
+// reset zeroes the responses of all apis and status codes.
+func (s *httpStatusStats) reset() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.counts = nil
+}
+
+// reset discards all counted events.
+func (w *rateWindow) reset() {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.slots = [rateWindowSlots]uint64{}
+}
+
+// reset discards all accounted clients.
+func (t *topClients) reset() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.current, t.previous = nil, nil
+}
+
+// reset zeroes the accounted handshakes, connections
+// whose handshake is pending are still accounted.
+func (s *tlsStats) reset() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.handshakes = nil
+	s.failures = 0
+}
+
+// reset zeroes the traffic counters, the open
+// connections gauge is left untouched.
+func (s *ConnStats) reset() {
+	atomic.StoreUint64(&s.totalInputBytes, 0)
+	atomic.StoreUint64(&s.totalOutputBytes, 0)
+	atomic.StoreUint64(&s.s3InputBytes, 0)
+	atomic.StoreUint64(&s.s3OutputBytes, 0)
+	atomic.StoreUint64(&s.wraparounds, 0)
+
+	s.bucketsMu.Lock()
+	s.buckets = nil
+	s.bucketsMu.Unlock()
+
+	s.s3InputRate.reset()
+	s.s3OutputRate.reset()
+	s.tls.reset()
+}
+
+// reset zeroes the request counters, the gauges of
+// in-flight and queued requests are left untouched
+// and their peaks start again from them.
+func (st *HTTPStats) reset() {
+	st.resetMu.Lock()
+	defer st.resetMu.Unlock()
+
+	atomic.StoreUint64(&st.s3RequestsIncoming, 0)
+	for _, counter := range st.checkpointCounters() {
+		atomic.StoreUint64(counter, 0)
+	}
+	for _, stats := range st.checkpointAPIStats() {
+		stats.reset()
+	}
+
+	st.currentS3Requests.swapPeaks(&st.peakS3Requests)
+	st.statusCodes.reset()
+
+	st.bucketsMu.Lock()
+	st.buckets = nil
+	st.bucketsMu.Unlock()
+
+	st.accessKeysMu.Lock()
+	st.accessKeys = nil
+	st.accessKeysMu.Unlock()
+
+	st.requestsRate.reset()
+	st.errorsRate.reset()
+	st.clients.reset()
+	st.rejections.reset()
+	st.slowRequests.reset()
+	st.hot.reset()
+	st.anonymous.reset()
+}
+
+// resetRuntimeStats zeroes the HTTP and traffic stats
+// and the request histograms of this node.
+func resetRuntimeStats() {
+	globalHTTPStats.reset()
+	globalConnStats.reset()
+	httpRequestsDuration.Reset()
+	httpRequestsTotalDuration.Reset()
+	httpRequestsSize.Reset()
+	httpResponsesSize.Reset()
+	tlsHandshakeDuration.Reset()
+}
@@ -0,0 +1,90 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"net/http"
+	"sync/atomic"
+	"testing"
+)
+
+func TestHTTPStatsReset(t *testing.T) {
+	st := newHTTPStats()
+	st.totalS3Requests.Inc("getobject")
+	st.currentS3Requests.Inc("getobject")
+	st.peakS3Requests.Inc("putobject")
+	atomic.AddUint64(&st.rejectedRequestsAuth, 1)
+	atomic.AddInt32(&st.s3RequestsInQueue, 1)
+	st.updateBucketStats("bucket", http.StatusNotFound)
+	st.updateAccessKeyStats("minio", "", http.StatusOK, 1, 1)
+	st.requestsRate.add(1)
+	st.clients.add("127.0.0.1", 1)
+
+	st.reset()
+
+	if got := st.totalS3Requests.Load(); len(got) != 0 {
+		t.Errorf("expected no requests after reset, got %v", got)
+	}
+	if got := atomic.LoadUint64(&st.rejectedRequestsAuth); got != 0 {
+		t.Errorf("expected no rejected requests after reset, got %d", got)
+	}
+	if got := st.toServerBucketHTTPStats(); len(got) != 0 {
+		t.Errorf("expected no bucket stats after reset, got %v", got)
+	}
+	if got := st.toServerAccessKeyStats(); len(got) != 0 {
+		t.Errorf("expected no access key stats after reset, got %v", got)
+	}
+	if got := st.requestsRate.toServerRates(); got.Avg1m != 0 {
+		t.Errorf("expected no request rate after reset, got %v", got)
+	}
+	if got := st.clients.report(); len(got) != 0 {
+		t.Errorf("expected no clients after reset, got %v", got)
+	}
+
+	// Gauges describe the present and are kept.
+	if got := st.currentS3Requests.Load()["getobject"]; got != 1 {
+		t.Errorf("expected in-flight request to be kept, got %d", got)
+	}
+	if got := atomic.LoadInt32(&st.s3RequestsInQueue); got != 1 {
+		t.Errorf("expected queued request to be kept, got %d", got)
+	}
+	// Peaks start again from the in-flight requests.
+	if got := st.peakS3Requests.Load(); len(got) != 1 || got["getobject"] != 1 {
+		t.Errorf("expected the peaks of the in-flight requests after reset, got %v", got)
+	}
+}
+
+func TestConnStatsReset(t *testing.T) {
+	cs := newConnStats()
+	cs.incS3InputBytes(10)
+	cs.incInputBytes(10)
+	cs.incBucketInputBytes("bucket", 10)
+	atomic.AddInt64(&cs.openConnections, 1)
+
+	cs.reset()
+
+	if got := cs.toServerConnStats(); got.S3InputBytes != 0 || got.TotalInputBytes != 0 || got.S3InputRate.Avg1m != 0 {
+		t.Errorf("expected no traffic after reset, got %+v", got)
+	}
+	if got := cs.toServerBucketConnStats(); len(got) != 0 {
+		t.Errorf("expected no bucket traffic after reset, got %v", got)
+	}
+	if got := cs.getOpenConnections(); got != 1 {
+		t.Errorf("expected open connection to be kept, got %d", got)
+	}
+}
@@ -0,0 +1,133 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"net/http"
+	"net/url"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/minio/minio/internal/handlers"
+	xhttp "github.com/minio/minio/internal/http"
+	"github.com/minio/minio/internal/logger"
+	"github.com/minio/pkg/env"
+)
+
+// EnvSlowRequestThreshold configures the duration above which S3
+// requests are recorded in the slow request log, e.g. "5s". Slow
+// requests are not recorded unless it is set.
+const EnvSlowRequestThreshold = "MINIO_LOG_SLOW_REQUEST"
+
+// Number of most recent slow requests kept by a node.
+const maxSlowRequests = 256
+
+// slowRequestThreshold returns the threshold configured by
+// EnvSlowRequestThreshold, zero if unset or invalid.
+func slowRequestThreshold() time.Duration {
+	threshold, err := time.ParseDuration(env.Get(EnvSlowRequestThreshold, ""))
+	if err != nil || threshold < 0 {
+		return 0
+	}
+	return threshold
+}
+
+// slowRequestLog keeps the most recent slow requests
+// in a ring buffer, the zero value is ready to use.
+type slowRequestLog struct {
+	mu      sync.Mutex
+	entries [maxSlowRequests]ServerSlowRequest
+	next    int
+	full    bool
+}
+
+// newSlowRequest describes the request r of api served by w in duration.
+func newSlowRequest(api string, r *http.Request, w *logger.ResponseWriter, duration time.Duration) ServerSlowRequest {
+	vars := mux.Vars(r)
+	entry := ServerSlowRequest{
+		Time:       UTCNow(),
+		Node:       globalLocalNodeName,
+		API:        api,
+		Bucket:     vars["bucket"],
+		Object:     likelyUnescapeGeneric(vars["object"], url.PathUnescape),
+		Duration:   duration,
+		TTFB:       w.TimeToFirstByte,
+		StatusCode: w.StatusCode,
+		Client:     handlers.GetSourceIP(r),
+		RequestID:  w.Header().Get(xhttp.AmzRequestID),
+	}
+	if accessKey, _, ok := requestCredentials(r); ok {
+		entry.AccessKey = accessKey
+	}
+	return entry
+}
+
+// add records the request r of api served by w in duration.
+func (l *slowRequestLog) add(api string, r *http.Request, w *logger.ResponseWriter, duration time.Duration) {
+	entry := newSlowRequest(api, r, w, duration)
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.entries[l.next] = entry
+	l.next = (l.next + 1) % maxSlowRequests
+	if l.next == 0 {
+		l.full = true
+	}
+}
+
+// load returns the recorded slow requests, the most recent first.
+func (l *slowRequestLog) load() []ServerSlowRequest {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	n := l.next
+	if l.full {
+		n = maxSlowRequests
+	}
+	entries := make([]ServerSlowRequest, 0, n)
+	for i := 1; i <= n; i++ {
+		entries = append(entries, l.entries[(l.next-i+maxSlowRequests)%maxSlowRequests])
+	}
+	return entries
+}
+
+// reset discards all recorded slow requests.
+func (l *slowRequestLog) reset() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.entries = [maxSlowRequests]ServerSlowRequest{}
+	l.next, l.full = 0, false
+}
+
+// mergeSlowRequests returns at most count of the slow requests
+// reported by different nodes, the most recent first.
+// All requests are returned if count is not positive.
+func mergeSlowRequests(reports [][]ServerSlowRequest, count int) []ServerSlowRequest {
+	var merged []ServerSlowRequest
+	for _, report := range reports {
+		merged = append(merged, report...)
+	}
+	sort.SliceStable(merged, func(i, j int) bool {
+		return merged[i].Time.After(merged[j].Time)
+	})
+	if count > 0 && len(merged) > count {
+		merged = merged[:count]
+	}
+	return merged
+}
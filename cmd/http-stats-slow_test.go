@@ -0,0 +1,96 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gorilla/mux"
+	xhttp "github.com/minio/minio/internal/http"
+	"github.com/minio/minio/internal/logger"
+)
+
+func TestSlowRequestThreshold(t *testing.T) {
+	testCases := []struct {
+		value    string
+		expected time.Duration
+	}{
+		{"", 0},
+		{"5s", 5 * time.Second},
+		{"500ms", 500 * time.Millisecond},
+		{"invalid", 0},
+		{"-1s", 0},
+	}
+	for _, testCase := range testCases {
+		t.Setenv(EnvSlowRequestThreshold, testCase.value)
+		if got := slowRequestThreshold(); got != testCase.expected {
+			t.Errorf("%q: expected %s, got %s", testCase.value, testCase.expected, got)
+		}
+	}
+}
+
+func TestHTTPStatsSlowRequests(t *testing.T) {
+	saved := globalHTTPStats
+	defer func() { globalHTTPStats = saved }()
+	globalHTTPStats = newHTTPStats()
+	globalHTTPStats.slowRequestThreshold = 50 * time.Millisecond
+
+	delay := 100 * time.Millisecond
+	handler := collectAPIStats("listobjectsv2", func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(delay)
+		w.Write([]byte("<ListBucketResult/>"))
+	})
+	r := httptest.NewRequest(http.MethodGet, "/bucket/a%2Fb", nil)
+	w := httptest.NewRecorder()
+	w.Header().Set(xhttp.AmzRequestID, "16E2B4A7C0D1F2A3")
+	handler(w, mux.SetURLVars(r, map[string]string{"bucket": "bucket", "object": "a%2Fb"}))
+
+	// Requests served faster than the threshold are not recorded.
+	delay = 0
+	handler(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/bucket", nil))
+
+	entries := globalHTTPStats.slowRequests.load()
+	if len(entries) != 1 {
+		t.Fatalf("expected 1 slow request, got %v", entries)
+	}
+	entry := entries[0]
+	if entry.API != "listobjectsv2" || entry.Bucket != "bucket" || entry.Object != "a/b" || entry.StatusCode != http.StatusOK || entry.RequestID != "16E2B4A7C0D1F2A3" {
+		t.Errorf("unexpected slow request %+v", entry)
+	}
+	if entry.Duration < 100*time.Millisecond || entry.TTFB < 100*time.Millisecond {
+		t.Errorf("expected duration and TTFB of at least 100ms, got %+v", entry)
+	}
+}
+
+func TestSlowRequestLog(t *testing.T) {
+	var l slowRequestLog
+	w := logger.NewResponseWriter(httptest.NewRecorder())
+	for i := 0; i < maxSlowRequests+10; i++ {
+		l.add("getobject", httptest.NewRequest(http.MethodGet, "/bucket/object", nil), w, time.Duration(i))
+	}
+	entries := l.load()
+	if len(entries) != maxSlowRequests {
+		t.Fatalf("expected %d entries, got %d", maxSlowRequests, len(entries))
+	}
+	if entries[0].Duration != maxSlowRequests+9 || entries[len(entries)-1].Duration != 10 {
+		t.Errorf("expected most recent entries first, got %s and %s", entries[0].Duration, entries[len(entries)-1].Duration)
+	}
+}
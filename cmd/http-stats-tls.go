@@ -0,0 +1,117 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"crypto/tls"
+	"net"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// tlsHandshake identifies the negotiated parameters of a TLS handshake.
+type tlsHandshake struct {
+	version string
+	cipher  string
+}
+
+// tlsStats accounts the TLS handshakes of client connections,
+// the zero value is ready to use.
+type tlsStats struct {
+	mu sync.Mutex
+
+	// Connections accepted but not yet accounted, by accept time.
+	pending map[net.Conn]time.Time
+
+	handshakes map[tlsHandshake]uint64
+	failures   uint64
+}
+
+// tlsVersionName returns the name of a TLS protocol version.
+func tlsVersionName(version uint16) string {
+	switch version {
+	case tls.VersionTLS10:
+		return "TLS 1.0"
+	case tls.VersionTLS11:
+		return "TLS 1.1"
+	case tls.VersionTLS12:
+		return "TLS 1.2"
+	case tls.VersionTLS13:
+		return "TLS 1.3"
+	}
+	return "0x" + strconv.FormatUint(uint64(version), 16)
+}
+
+// track accounts the handshake of a TLS connection once it served
+// its first request or was closed, whichever comes first. The server
+// completes the handshake before reading the first request, so the
+// latency until then is dominated by the handshake. HTTP/2 connections
+// do not report their first request and are accounted without latency
+// when closed. Connections closed before completing the handshake
+// count as failures.
+func (s *tlsStats) track(conn net.Conn, state http.ConnState) {
+	tlsConn, ok := conn.(*tls.Conn)
+	if !ok {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if state == http.StateNew {
+		if s.pending == nil {
+			s.pending = make(map[net.Conn]time.Time)
+		}
+		s.pending[conn] = time.Now()
+		return
+	}
+	start, ok := s.pending[conn]
+	if !ok {
+		return
+	}
+	delete(s.pending, conn)
+
+	cs := tlsConn.ConnectionState()
+	if !cs.HandshakeComplete {
+		s.failures++
+		return
+	}
+	if s.handshakes == nil {
+		s.handshakes = make(map[tlsHandshake]uint64)
+	}
+	s.handshakes[tlsHandshake{
+		version: tlsVersionName(cs.Version),
+		cipher:  tls.CipherSuiteName(cs.CipherSuite),
+	}]++
+	if state == http.StateActive {
+		tlsHandshakeDuration.WithLabelValues().Observe(time.Since(start).Seconds())
+	}
+}
+
+// load returns the completed handshakes and the failures.
+func (s *tlsStats) load() (handshakes map[tlsHandshake]uint64, failures uint64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	handshakes = make(map[tlsHandshake]uint64, len(s.handshakes))
+	for h, n := range s.handshakes {
+		handshakes[h] = n
+	}
+	return handshakes, s.failures
+}
@@ -0,0 +1,148 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"net/http"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/minio/pkg/env"
+)
+
+// EnvAccessKeyMetricsLimit enables per access key Prometheus metrics
+// for at most the given number of access keys with the most requests,
+// per access key metrics are disabled by default.
+const EnvAccessKeyMetricsLimit = "MINIO_PROMETHEUS_ACCESS_KEY_METRICS_LIMIT"
+
+// Maximum number of access keys for which S3 requests are accounted,
+// the least recently active access key is evicted beyond this.
+const maxAccessKeyStats = 10000
+
+// accessKeyStats holds S3 requests, errors and bytes of a single access key.
+type accessKeyStats struct {
+	parentUser  string
+	requests    uint64
+	errors      uint64
+	inputBytes  uint64
+	outputBytes uint64
+	lastUpdate  time.Time
+}
+
+// updateAccessKeyStats accounts an authenticated request to its access key,
+// must not be called for anonymous requests.
+func (st *HTTPStats) updateAccessKeyStats(accessKey, parentUser string, code int, inputBytes, outputBytes int64) {
+	st.accessKeysMu.Lock()
+	defer st.accessKeysMu.Unlock()
+
+	if st.accessKeys == nil {
+		st.accessKeys = make(map[string]*accessKeyStats)
+	}
+	as, ok := st.accessKeys[accessKey]
+	if !ok {
+		if len(st.accessKeys) >= maxAccessKeyStats {
+			var (
+				oldest     string
+				oldestTime time.Time
+			)
+			for k, v := range st.accessKeys {
+				if oldest == "" || v.lastUpdate.Before(oldestTime) {
+					oldest, oldestTime = k, v.lastUpdate
+				}
+			}
+			delete(st.accessKeys, oldest)
+		}
+		as = &accessKeyStats{}
+		st.accessKeys[accessKey] = as
+	}
+	as.parentUser = parentUser
+	as.lastUpdate = UTCNow()
+	as.requests++
+	if code >= http.StatusBadRequest && code != 499 {
+		as.errors++
+	}
+	if inputBytes > 0 {
+		as.inputBytes += uint64(inputBytes)
+	}
+	if outputBytes > 0 {
+		as.outputBytes += uint64(outputBytes)
+	}
+}
+
+// Return S3 requests, errors and bytes per access key
+func (st *HTTPStats) toServerAccessKeyStats() map[string]ServerAccessKeyStats {
+	st.accessKeysMu.Lock()
+	defer st.accessKeysMu.Unlock()
+	stats := make(map[string]ServerAccessKeyStats, len(st.accessKeys))
+	for accessKey, as := range st.accessKeys {
+		stats[accessKey] = ServerAccessKeyStats{
+			ParentUser:      as.parentUser,
+			TotalS3Requests: as.requests,
+			TotalS3Errors:   as.errors,
+			S3InputBytes:    as.inputBytes,
+			S3OutputBytes:   as.outputBytes,
+		}
+	}
+	return stats
+}
+
+// mergeAccessKeyStats adds the per access key stats of src to dst.
+func mergeAccessKeyStats(dst, src map[string]ServerAccessKeyStats) {
+	for accessKey, s := range src {
+		d := dst[accessKey]
+		if d.ParentUser == "" {
+			d.ParentUser = s.ParentUser
+		}
+		d.TotalS3Requests += s.TotalS3Requests
+		d.TotalS3Errors += s.TotalS3Errors
+		d.S3InputBytes += s.S3InputBytes
+		d.S3OutputBytes += s.S3OutputBytes
+		dst[accessKey] = d
+	}
+}
+
+// topAccessKeys returns at most n access keys of stats,
+// those with the most requests first.
+func topAccessKeys(stats map[string]ServerAccessKeyStats, n int) []string {
+	accessKeys := make([]string, 0, len(stats))
+	for accessKey := range stats {
+		accessKeys = append(accessKeys, accessKey)
+	}
+	sort.Slice(accessKeys, func(i, j int) bool {
+		ri, rj := stats[accessKeys[i]].TotalS3Requests, stats[accessKeys[j]].TotalS3Requests
+		if ri != rj {
+			return ri > rj
+		}
+		return accessKeys[i] < accessKeys[j]
+	})
+	if len(accessKeys) > n {
+		accessKeys = accessKeys[:n]
+	}
+	return accessKeys
+}
+
+// accessKeyMetricsLimit returns the number of access keys
+// exported to Prometheus, see EnvAccessKeyMetricsLimit.
+func accessKeyMetricsLimit() int {
+	limit, err := strconv.Atoi(env.Get(EnvAccessKeyMetricsLimit, "0"))
+	if err != nil || limit < 0 {
+		return 0
+	}
+	return limit
+}
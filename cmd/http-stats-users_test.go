@@ -0,0 +1,81 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/minio/minio/internal/auth"
+)
+
+func TestHTTPStatsAccessKeys(t *testing.T) {
+	saved := globalHTTPStats
+	defer func() { globalHTTPStats = saved }()
+	globalHTTPStats = newHTTPStats()
+
+	handler := func(cred auth.Credentials, code int) http.HandlerFunc {
+		return collectAPIStats("putobject", func(w http.ResponseWriter, r *http.Request) {
+			setRequestAuthenticated(r.Context(), cred)
+			w.WriteHeader(code)
+			w.Write([]byte("hello"))
+		})
+	}
+	user := auth.Credentials{AccessKey: "user"}
+	svc := auth.Credentials{AccessKey: "svc", ParentUser: "user"}
+
+	handler(user, http.StatusOK)(httptest.NewRecorder(), httptest.NewRequest(http.MethodPut, "/bucket/object", strings.NewReader("12345678")))
+	handler(user, http.StatusForbidden)(httptest.NewRecorder(), httptest.NewRequest(http.MethodPut, "/bucket/object", nil))
+	handler(svc, http.StatusOK)(httptest.NewRecorder(), httptest.NewRequest(http.MethodPut, "/bucket/object", nil))
+	// Anonymous requests are not accounted.
+	collectAPIStats("putobject", func(w http.ResponseWriter, r *http.Request) {})(httptest.NewRecorder(),
+		httptest.NewRequest(http.MethodPut, "/bucket/object", nil))
+
+	stats := globalHTTPStats.toServerAccessKeyStats()
+	if len(stats) != 2 {
+		t.Fatalf("expected 2 access keys, got %+v", stats)
+	}
+	// Response sizes include the status line and headers.
+	for accessKey, expected := range map[string]ServerAccessKeyStats{
+		"user": {TotalS3Requests: 2, TotalS3Errors: 1, S3InputBytes: 8},
+		"svc":  {ParentUser: "user", TotalS3Requests: 1},
+	} {
+		got := stats[accessKey]
+		if got.S3OutputBytes < 5*got.TotalS3Requests {
+			t.Errorf("%s: expected at least %d output bytes, got %d", accessKey, 5*got.TotalS3Requests, got.S3OutputBytes)
+		}
+		got.S3OutputBytes = 0
+		if got != expected {
+			t.Errorf("%s: expected %+v, got %+v", accessKey, expected, got)
+		}
+	}
+
+	mergeAccessKeyStats(stats, map[string]ServerAccessKeyStats{
+		"svc":   {ParentUser: "user", TotalS3Requests: 4},
+		"other": {TotalS3Requests: 1},
+	})
+	if got := stats["svc"].TotalS3Requests; got != 5 {
+		t.Errorf("expected 5 merged requests, got %d", got)
+	}
+	if got := topAccessKeys(stats, 2); !reflect.DeepEqual(got, []string{"svc", "user"}) {
+		t.Errorf("unexpected top access keys %v", got)
+	}
+}
@@ -18,15 +18,61 @@
 package cmd
 
 import (
+	"context"
+	"math"
+	"net"
 	"net/http"
+	"net/url"
+	"strconv"
 	"strings"
 	"sync"
 	"sync/atomic"
+	"time"
 
+	"github.com/gorilla/mux"
+	"github.com/minio/minio-go/v7/pkg/set"
+	"github.com/minio/minio/internal/auth"
+	"github.com/minio/minio/internal/handlers"
+	xhttp "github.com/minio/minio/internal/http"
+	"github.com/minio/minio/internal/http/stats"
 	"github.com/minio/minio/internal/logger"
+	"github.com/minio/pkg/env"
 	"github.com/prometheus/client_golang/prometheus"
 )
 
+// EnvTTFBSampleRate configures the TTFB and request duration histograms
+// to observe only 1 in N requests, "1" (the default) observes every request.
+const EnvTTFBSampleRate = "MINIO_PROMETHEUS_TTFB_SAMPLE_RATE"
+
+// EnvRequestDurationBuckets configures the upper bounds in seconds of
+// the request duration histogram buckets as a comma separated list.
+const EnvRequestDurationBuckets = "MINIO_PROMETHEUS_REQUEST_DURATION_BUCKETS"
+
+// Default upper bounds in seconds of the request duration histogram.
+var defaultRequestDurationBuckets = []float64{.05, .1, .25, .5, 1, 2.5, 5, 10, 30, 60, 300}
+
+// parseRequestDurationBuckets parses a comma separated list of
+// strictly increasing positive durations in seconds, the defaults
+// are returned if the list is empty or invalid.
+func parseRequestDurationBuckets(s string) []float64 {
+	if strings.TrimSpace(s) == "" {
+		return defaultRequestDurationBuckets
+	}
+	fields := strings.Split(s, ",")
+	buckets := make([]float64, 0, len(fields))
+	for _, field := range fields {
+		bucket, err := strconv.ParseFloat(strings.TrimSpace(field), 64)
+		if err != nil || bucket <= 0 || math.IsInf(bucket, 0) || math.IsNaN(bucket) {
+			return defaultRequestDurationBuckets
+		}
+		if len(buckets) > 0 && bucket <= buckets[len(buckets)-1] {
+			return defaultRequestDurationBuckets
+		}
+		buckets = append(buckets, bucket)
+	}
+	return buckets
+}
+
 // ConnStats - Network statistics
 // Count total input/output transferred bytes during
 // the server's life.
@@ -35,16 +81,110 @@ type ConnStats struct {
 	totalOutputBytes uint64
 	s3InputBytes     uint64
 	s3OutputBytes    uint64
+
+	// Number of times any of the byte counters
+	// above overflowed and wrapped around to zero.
+	wraparounds uint64
+
+	// Number of currently open client connections.
+	openConnections int64
+
+	// S3 traffic per bucket
+	bucketsMu sync.Mutex
+	buckets   *bucketStatsLRU
+
+	// Recent S3 traffic
+	s3InputRate  rateWindow
+	s3OutputRate rateWindow
+
+	// TLS handshakes of client connections
+	tls tlsStats
+}
+
+const (
+	// Maximum number of buckets for which S3 traffic is tracked,
+	// the least recently active bucket is evicted beyond this.
+	maxBucketConnStats = 10000
+
+	// Traffic not addressed to any bucket (health, metrics,
+	// admin and ListBuckets calls) is attributed to this
+	// sentinel, which can never be a valid bucket name.
+	nonBucketConnStats = "_"
+)
+
+// bucketConnStats holds S3 traffic of a single bucket.
+type bucketConnStats struct {
+	inputBytes  uint64
+	outputBytes uint64
+}
+
+// bucket returns the traffic stats of the bucket, creating
+// them if needed, must be called with bucketsMu held.
+func (s *ConnStats) bucket(bucket string) *bucketConnStats {
+	if bucket == "" || isMinioReservedBucket(bucket) || isMinioMetaBucket(bucket) {
+		bucket = nonBucketConnStats
+	}
+	if s.buckets == nil {
+		s.buckets = newBucketStatsLRU()
+	}
+	return s.buckets.get(bucket, func() interface{} { return &bucketConnStats{} }).(*bucketConnStats)
+}
+
+// Increase input bytes of a bucket
+func (s *ConnStats) incBucketInputBytes(bucket string, n int64) {
+	if n <= 0 {
+		return
+	}
+	s.bucketsMu.Lock()
+	s.bucket(bucket).inputBytes += uint64(n)
+	s.bucketsMu.Unlock()
+}
+
+// Increase output bytes of a bucket
+func (s *ConnStats) incBucketOutputBytes(bucket string, n int64) {
+	if n <= 0 {
+		return
+	}
+	s.bucketsMu.Lock()
+	s.bucket(bucket).outputBytes += uint64(n)
+	s.bucketsMu.Unlock()
+}
+
+// Return S3 traffic per bucket
+func (s *ConnStats) toServerBucketConnStats() map[string]ServerBucketConnStats {
+	s.bucketsMu.Lock()
+	defer s.bucketsMu.Unlock()
+	stats := make(map[string]ServerBucketConnStats, s.buckets.len())
+	s.buckets.forEach(func(bucket string, v interface{}) {
+		bs := v.(*bucketConnStats)
+		stats[bucket] = ServerBucketConnStats{
+			S3InputBytes:  bs.inputBytes,
+			S3OutputBytes: bs.outputBytes,
+		}
+	})
+	return stats
+}
+
+// addBytes adds n to the counter, byte counters wrap around to
+// zero on overflow which is accounted in wraparounds so that
+// consumers may treat it as a counter reset.
+func (s *ConnStats) addBytes(counter *uint64, n int64) {
+	if n <= 0 {
+		return
+	}
+	if atomic.AddUint64(counter, uint64(n)) < uint64(n) {
+		atomic.AddUint64(&s.wraparounds, 1)
+	}
 }
 
 // Increase total input bytes
 func (s *ConnStats) incInputBytes(n int64) {
-	atomic.AddUint64(&s.totalInputBytes, uint64(n))
+	s.addBytes(&s.totalInputBytes, n)
 }
 
 // Increase total output bytes
 func (s *ConnStats) incOutputBytes(n int64) {
-	atomic.AddUint64(&s.totalOutputBytes, uint64(n))
+	s.addBytes(&s.totalOutputBytes, n)
 }
 
 // Return total input bytes
@@ -59,12 +199,18 @@ func (s *ConnStats) getTotalOutputBytes() uint64 {
 
 // Increase outbound input bytes
 func (s *ConnStats) incS3InputBytes(n int64) {
-	atomic.AddUint64(&s.s3InputBytes, uint64(n))
+	s.addBytes(&s.s3InputBytes, n)
+	if n > 0 {
+		s.s3InputRate.add(uint64(n))
+	}
 }
 
 // Increase outbound output bytes
 func (s *ConnStats) incS3OutputBytes(n int64) {
-	atomic.AddUint64(&s.s3OutputBytes, uint64(n))
+	s.addBytes(&s.s3OutputBytes, n)
+	if n > 0 {
+		s.s3OutputRate.add(uint64(n))
+	}
 }
 
 // Return outbound input bytes
@@ -77,6 +223,32 @@ func (s *ConnStats) getS3OutputBytes() uint64 {
 	return atomic.LoadUint64(&s.s3OutputBytes)
 }
 
+// Return number of byte counter wraparounds
+func (s *ConnStats) getWraparounds() uint64 {
+	return atomic.LoadUint64(&s.wraparounds)
+}
+
+// Return number of currently open client connections
+func (s *ConnStats) getOpenConnections() int64 {
+	return atomic.LoadInt64(&s.openConnections)
+}
+
+// trackConnState is meant to be used as http.Server ConnState hook to
+// maintain the open connections gauge. net/http always reports either
+// StateClosed or StateHijacked exactly once for every new connection,
+// including connections torn down by errors or panics, so the gauge
+// cannot leak. Hijacked connections are handed off to their handler
+// and are no longer accounted here.
+func (s *ConnStats) trackConnState(conn net.Conn, state http.ConnState) {
+	s.tls.track(conn, state)
+	switch state {
+	case http.StateNew:
+		atomic.AddInt64(&s.openConnections, 1)
+	case http.StateClosed, http.StateHijacked:
+		atomic.AddInt64(&s.openConnections, -1)
+	}
+}
+
 // Return connection stats (total input/output bytes and total s3 input/output bytes)
 func (s *ConnStats) toServerConnStats() ServerConnStats {
 	return ServerConnStats{
@@ -84,6 +256,10 @@ func (s *ConnStats) toServerConnStats() ServerConnStats {
 		TotalOutputBytes: s.getTotalOutputBytes(), // Traffic including reserved bucket
 		S3InputBytes:     s.getS3InputBytes(),     // Traffic for client buckets
 		S3OutputBytes:    s.getS3OutputBytes(),    // Traffic for client buckets
+		Wraparounds:      s.getWraparounds(),
+		OpenConnections:  s.getOpenConnections(),
+		S3InputRate:      s.s3InputRate.toServerRates(),
+		S3OutputRate:     s.s3OutputRate.toServerRates(),
 	}
 }
 
@@ -112,6 +288,59 @@ func (stats *HTTPAPIStats) Inc(api string) {
 	stats.apiStats[api]++
 }
 
+// add adds n to the api stats counter.
+func (stats *HTTPAPIStats) add(api string, n int) {
+	if stats == nil || n <= 0 {
+		return
+	}
+	stats.Lock()
+	defer stats.Unlock()
+	if stats.apiStats == nil {
+		stats.apiStats = make(map[string]int)
+	}
+	stats.apiStats[api] += n
+}
+
+// incPeak increments the api stats counter and raises the
+// counter of api in peaks to the incremented value if lower.
+func (stats *HTTPAPIStats) incPeak(api string, peaks *HTTPAPIStats) {
+	stats.Lock()
+	defer stats.Unlock()
+	if stats.apiStats == nil {
+		stats.apiStats = make(map[string]int)
+	}
+	stats.apiStats[api]++
+
+	peaks.Lock()
+	defer peaks.Unlock()
+	if peaks.apiStats == nil {
+		peaks.apiStats = make(map[string]int)
+	}
+	if peaks.apiStats[api] < stats.apiStats[api] {
+		peaks.apiStats[api] = stats.apiStats[api]
+	}
+}
+
+// swapPeaks returns the peaks raised by incPeak since the previous
+// call, the peaks start again from the current counters of stats.
+func (stats *HTTPAPIStats) swapPeaks(peaks *HTTPAPIStats) map[string]int {
+	stats.Lock()
+	defer stats.Unlock()
+	peaks.Lock()
+	defer peaks.Unlock()
+	swapped := peaks.apiStats
+	if swapped == nil {
+		swapped = make(map[string]int)
+	}
+	peaks.apiStats = make(map[string]int, len(stats.apiStats))
+	for api, v := range stats.apiStats {
+		if v > 0 {
+			peaks.apiStats[api] = v
+		}
+	}
+	return swapped
+}
+
 // Dec increments the api stats counter.
 func (stats *HTTPAPIStats) Dec(api string) {
 	if stats == nil {
@@ -124,6 +353,252 @@ func (stats *HTTPAPIStats) Dec(api string) {
 	}
 }
 
+// httpStatusStats holds the number of responses
+// per api and status code.
+type httpStatusStats struct {
+	mu     sync.Mutex
+	counts map[string]map[int]uint64
+}
+
+// add adds n responses to api with the status code.
+func (s *httpStatusStats) add(api string, code int, n uint64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.counts == nil {
+		s.counts = make(map[string]map[int]uint64)
+	}
+	codes, ok := s.counts[api]
+	if !ok {
+		codes = make(map[int]uint64)
+		s.counts[api] = codes
+	}
+	codes[code] += n
+}
+
+// load returns the responses per api and status code.
+func (s *httpStatusStats) load() map[string]map[int]uint64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	counts := make(map[string]map[int]uint64, len(s.counts))
+	for api, codes := range s.counts {
+		counts[api] = make(map[int]uint64, len(codes))
+		for code, n := range codes {
+			counts[api][code] = n
+		}
+	}
+	return counts
+}
+
+// currentRequestCtxKey is the context key for the
+// currentRequest of an S3 request being served.
+type currentRequestCtxKey struct{}
+
+// currentRequest pairs the increment and decrement of the
+// in-flight gauge of a single request, the gauge is only
+// decremented if this request incremented it and only once.
+// It also records when the request started to be served.
+type currentRequest struct {
+	api           string
+	start         time.Time
+	incremented   int32
+	authenticated int32
+
+	// Credentials of an authenticated request
+	accessKey  string
+	parentUser string
+
+	// S3 error code of the response, if any
+	errorCode string
+
+	// Set if serving the request failed because
+	// a backend operation exceeded its deadline
+	deadlineExceeded bool
+
+	// Encryption type of the object read or written
+	encryption string
+
+	// Bucket the S3 traffic of the request is attributed to,
+	// set once served by the outermost collectAPIStats
+	trafficBucket string
+
+	// Traffic meters of the request, throttled by
+	// the limits of its access key once authenticated
+	meteredRequest  *stats.IncomingTrafficMeter
+	meteredResponse *stats.OutgoingTrafficMeter
+
+	// Set once the limits of the access key are applied, with
+	// the time to wait before retrying if they were exceeded
+	accessKeyLimited bool
+	retryAfter       time.Duration
+}
+
+// setRequestAuthenticated marks the request being served
+// as carrying the valid credentials cred.
+func setRequestAuthenticated(ctx context.Context, cred auth.Credentials) {
+	if current, ok := ctx.Value(currentRequestCtxKey{}).(*currentRequest); ok {
+		current.accessKey = cred.AccessKey
+		current.parentUser = cred.ParentUser
+		atomic.StoreInt32(&current.authenticated, 1)
+	}
+	var source string
+	if reqInfo := logger.GetReqInfo(ctx); reqInfo != nil {
+		source = reqInfo.RemoteHost
+	}
+	globalAccessKeyUsage.record(cred, source)
+}
+
+// requestCredentials returns the access key and parent user
+// of the request if it was marked by setRequestAuthenticated.
+func requestCredentials(r *http.Request) (accessKey, parentUser string, ok bool) {
+	current, ok := r.Context().Value(currentRequestCtxKey{}).(*currentRequest)
+	if !ok || atomic.LoadInt32(&current.authenticated) != 1 {
+		return "", "", false
+	}
+	return current.accessKey, current.parentUser, true
+}
+
+// isRequestAuthenticated returns true if the request
+// was marked by setRequestAuthenticated.
+func isRequestAuthenticated(r *http.Request) bool {
+	current, ok := r.Context().Value(currentRequestCtxKey{}).(*currentRequest)
+	return ok && atomic.LoadInt32(&current.authenticated) == 1
+}
+
+// setRequestDeadlineExceeded marks the request being served as
+// failed because a backend operation exceeded its deadline.
+func setRequestDeadlineExceeded(ctx context.Context) {
+	if current, ok := ctx.Value(currentRequestCtxKey{}).(*currentRequest); ok {
+		current.deadlineExceeded = true
+	}
+}
+
+// requestDeadlineExceeded returns true if the request
+// was marked by setRequestDeadlineExceeded.
+func requestDeadlineExceeded(r *http.Request) bool {
+	current, ok := r.Context().Value(currentRequestCtxKey{}).(*currentRequest)
+	return ok && current.deadlineExceeded
+}
+
+// setRequestEncryption records the encryption type of the
+// object read or written by the request being served, the
+// request is accounted by this type once it succeeds.
+func setRequestEncryption(ctx context.Context, metadata map[string]string) {
+	if current, ok := ctx.Value(currentRequestCtxKey{}).(*currentRequest); ok {
+		current.encryption = encryptionType(metadata)
+	}
+}
+
+// requestEncryption returns the encryption type recorded
+// by setRequestEncryption, if any.
+func requestEncryption(r *http.Request) string {
+	if current, ok := r.Context().Value(currentRequestCtxKey{}).(*currentRequest); ok {
+		return current.encryption
+	}
+	return ""
+}
+
+// servedBucket returns the bucket of the served request if the bucket
+// exists and the request was not rejected as unauthorized, "" otherwise.
+// Stats are never kept for the bucket names of arbitrary requests, which
+// would let unauthenticated clients create stats for any of them.
+func servedBucket(r *http.Request, w *logger.ResponseWriter) string {
+	if w.StatusCode == http.StatusUnauthorized || w.StatusCode == http.StatusForbidden {
+		return ""
+	}
+	bucket := mux.Vars(r)["bucket"]
+	if bucket == "" || !statsBucketExists(bucket) {
+		return ""
+	}
+	return bucket
+}
+
+// gatewayBuckets caches the buckets of the gateway backend,
+// whose metadata is not kept by the bucket metadata system.
+var gatewayBuckets = timedValue{TTL: time.Minute}
+
+// statsBucketExists returns true if bucket exists, without
+// querying the backend for each request.
+func statsBucketExists(bucket string) bool {
+	if !globalIsGateway {
+		return globalBucketMetadataSys != nil && globalBucketMetadataSys.Exists(bucket)
+	}
+	gatewayBuckets.Once.Do(func() {
+		gatewayBuckets.Update = func() (interface{}, error) {
+			objAPI := newObjectLayerFn()
+			if objAPI == nil {
+				return nil, errServerNotInitialized
+			}
+			buckets, err := objAPI.ListBuckets(GlobalContext)
+			if err != nil {
+				return nil, err
+			}
+			names := set.NewStringSet()
+			for _, bi := range buckets {
+				names.Add(bi.Name)
+			}
+			return names, nil
+		}
+	})
+	v, err := gatewayBuckets.Get()
+	if err != nil {
+		return false
+	}
+	return v.(set.StringSet).Contains(bucket)
+}
+
+// setRequestTrafficBucket records the served bucket of the
+// request as the bucket its S3 traffic is attributed to.
+func setRequestTrafficBucket(r *http.Request, w *logger.ResponseWriter) {
+	if current, ok := r.Context().Value(currentRequestCtxKey{}).(*currentRequest); ok {
+		current.trafficBucket = servedBucket(r, w)
+	}
+}
+
+// requestRetryAfter returns how long the client should wait
+// before retrying the request rejected by the limits of its
+// access key, zero if it was not rejected by them.
+func requestRetryAfter(ctx context.Context) time.Duration {
+	if current, ok := ctx.Value(currentRequestCtxKey{}).(*currentRequest); ok {
+		return current.retryAfter
+	}
+	return 0
+}
+
+// requestAPIName returns the name of the api serving
+// the request, as set by the outermost collectAPIStats.
+func requestAPIName(r *http.Request) string {
+	if current, ok := r.Context().Value(currentRequestCtxKey{}).(*currentRequest); ok {
+		return current.api
+	}
+	return ""
+}
+
+// requestStartTime returns the time the outermost API stats
+// middleware started serving the request, defaults to the
+// creation time of the response writer.
+func requestStartTime(r *http.Request, w *logger.ResponseWriter) time.Time {
+	if current, ok := r.Context().Value(currentRequestCtxKey{}).(*currentRequest); ok && !current.start.IsZero() {
+		return current.start
+	}
+	return w.StartTime
+}
+
+// inc increments the in-flight gauge for the request
+// and raises its peak to the new value if lower.
+func (c *currentRequest) inc(stats, peaks *HTTPAPIStats) {
+	if atomic.CompareAndSwapInt32(&c.incremented, 0, 1) {
+		stats.incPeak(c.api, peaks)
+	}
+}
+
+// dec decrements the in-flight gauge if it was incremented
+// by this request, calling dec more than once is a no-op.
+func (c *currentRequest) dec(stats *HTTPAPIStats) {
+	if atomic.CompareAndSwapInt32(&c.incremented, 1, 0) {
+		stats.Dec(c.api)
+	}
+}
+
 // Load returns the recorded stats.
 func (stats *HTTPAPIStats) Load() map[string]int {
 	stats.Lock()
@@ -145,12 +620,153 @@ type HTTPStats struct {
 	rejectedRequestsTime    uint64
 	rejectedRequestsHeader  uint64
 	rejectedRequestsInvalid uint64
-	currentS3Requests       HTTPAPIStats
-	totalS3Requests         HTTPAPIStats
-	totalS3Errors           HTTPAPIStats
-	totalS34xxErrors        HTTPAPIStats
-	totalS35xxErrors        HTTPAPIStats
-	totalS3Canceled         HTTPAPIStats
+	rejectedRequestsExpired uint64
+
+	// Held for writing while the stats are reset, for reading
+	// while a request is accounted, so that the request is
+	// accounted entirely either before or after the reset.
+	resetMu sync.RWMutex
+
+	// Only 1 in ttfbSampleRate requests is observed by the
+	// TTFB and duration histograms, see EnvTTFBSampleRate.
+	ttfbSampleRate  uint64
+	ttfbSampleCount uint64
+
+	// Accumulated sizes of successfully transferred
+	// objects, used to report the mean object size.
+	getObjectBytes uint64
+	getObjectCount uint64
+	putObjectBytes uint64
+	putObjectCount uint64
+
+	// Requests by apiDirection
+	readRequests     uint64
+	writeRequests    uint64
+	metadataRequests uint64
+
+	currentS3Requests HTTPAPIStats
+	totalS3Requests   HTTPAPIStats
+	totalS3Errors     HTTPAPIStats
+	totalS34xxErrors  HTTPAPIStats
+	totalS35xxErrors  HTTPAPIStats
+	totalS3Canceled   HTTPAPIStats
+	totalS33xx        HTTPAPIStats
+
+	// Highest currentS3Requests per api since the last scrape
+	peakS3Requests HTTPAPIStats
+
+	// Causes of failed requests: canceled by the client, canceled
+	// by the server shutting down, and backend operations
+	// exceeding their deadline.
+	totalS3CanceledClient   HTTPAPIStats
+	totalS3CanceledShutdown HTTPAPIStats
+	totalS3DeadlineExceeded HTTPAPIStats
+
+	// Requests rejected by the requests per second limits
+	totalS3RateLimited HTTPAPIStats
+
+	// Requests rejected after waiting too long for
+	// the concurrent requests limits
+	totalS3MaxedOut HTTPAPIStats
+
+	// Requests with valid credentials and anonymous requests,
+	// requests failing authentication are in neither.
+	authenticatedRequests HTTPAPIStats
+	anonymousRequests     HTTPAPIStats
+
+	// Successful object reads and writes by the
+	// encryption type of the object, see encryptionTypes.
+	encryptionRequests [len(encryptionTypes)]HTTPAPIStats
+
+	// Responses per api and status code
+	statusCodes httpStatusStats
+
+	// Requests and errors per bucket
+	bucketsMu sync.Mutex
+	buckets   *bucketStatsLRU
+
+	// Recent S3 requests and errors
+	requestsRate rateWindow
+	errorsRate   rateWindow
+
+	// Requests per access key
+	accessKeysMu sync.Mutex
+	accessKeys   map[string]*accessKeyStats
+
+	// Clients with the most requests
+	clients topClients
+
+	// Most recent rejected requests
+	rejections rejectionLog
+
+	// Most frequently read objects and prefixes
+	hot hotObjects
+
+	// Anonymous requests per bucket and prefix
+	anonymous anonymousAccessLog
+
+	// Most recent requests served in more than
+	// slowRequestThreshold, disabled if zero.
+	slowRequestThreshold time.Duration
+	slowRequests         slowRequestLog
+
+	// Requests served in more than profileLatencyThreshold
+	// trigger a continuous profile capture, disabled if zero.
+	profileLatencyThreshold time.Duration
+}
+
+// bucketHTTPStats holds S3 requests and errors of a single bucket.
+type bucketHTTPStats struct {
+	requests  uint64
+	errors4xx uint64
+	errors5xx uint64
+	canceled  uint64
+}
+
+// bucket returns the request stats of the bucket, creating them
+// if needed, must be called with bucketsMu held. The number of
+// buckets is bounded the same way as ConnStats bucket traffic.
+func (st *HTTPStats) bucket(bucket string) *bucketHTTPStats {
+	if bucket == "" || isMinioReservedBucket(bucket) || isMinioMetaBucket(bucket) {
+		bucket = nonBucketConnStats
+	}
+	if st.buckets == nil {
+		st.buckets = newBucketStatsLRU()
+	}
+	return st.buckets.get(bucket, func() interface{} { return &bucketHTTPStats{} }).(*bucketHTTPStats)
+}
+
+// updateBucketStats accounts the request and its status code to the bucket.
+func (st *HTTPStats) updateBucketStats(bucket string, code int) {
+	st.bucketsMu.Lock()
+	defer st.bucketsMu.Unlock()
+	bs := st.bucket(bucket)
+	bs.requests++
+	switch {
+	case code == 499:
+		bs.canceled++
+	case code >= http.StatusInternalServerError:
+		bs.errors5xx++
+	case code >= http.StatusBadRequest:
+		bs.errors4xx++
+	}
+}
+
+// Return S3 requests and errors per bucket
+func (st *HTTPStats) toServerBucketHTTPStats() map[string]ServerBucketHTTPStats {
+	st.bucketsMu.Lock()
+	defer st.bucketsMu.Unlock()
+	stats := make(map[string]ServerBucketHTTPStats, st.buckets.len())
+	st.buckets.forEach(func(bucket string, v interface{}) {
+		bs := v.(*bucketHTTPStats)
+		stats[bucket] = ServerBucketHTTPStats{
+			TotalS3Requests:  bs.requests,
+			TotalS34xxErrors: bs.errors4xx,
+			TotalS35xxErrors: bs.errors5xx,
+			TotalS3Canceled:  bs.canceled,
+		}
+	})
+	return stats
 }
 
 func (st *HTTPStats) addRequestsInQueue(i int32) {
@@ -162,18 +778,126 @@ func (st *HTTPStats) incS3RequestsIncoming() {
 	atomic.AddUint64(&st.s3RequestsIncoming, 1)
 }
 
-// Converts http stats into struct to be sent back to the client.
+// apiDirectionType classifies APIs by the kind of work they do.
+type apiDirectionType string
+
+const (
+	// APIs reading object data
+	apiDirectionRead apiDirectionType = "read"
+	// APIs writing or removing object data
+	apiDirectionWrite apiDirectionType = "write"
+	// All other APIs, which only deal with metadata
+	// of objects and buckets, listings and configuration.
+	apiDirectionMetadata apiDirectionType = "metadata"
+)
+
+// apiDirection returns the direction of the API, APIs
+// not known to move object data are considered metadata.
+func apiDirection(api string) apiDirectionType {
+	switch api {
+	case "getobject", "selectobjectcontent":
+		return apiDirectionRead
+	case "putobject", "putobjectpart", "copyobject", "copyobjectpart",
+		"completemultipartupload", "postpolicybucket", "deleteobject",
+		"deletemultipleobjects", "abortmultipartupload":
+		return apiDirectionWrite
+	}
+	return apiDirectionMetadata
+}
+
+// addObjectSize accounts the object size of a successful
+// GetObject or PutObject request.
+func (st *HTTPStats) addObjectSize(api string, r *http.Request, w *logger.ResponseWriter) {
+	if w.StatusCode < http.StatusOK || w.StatusCode >= http.StatusMultipleChoices {
+		return
+	}
+	switch api {
+	case "getobject":
+		size, err := strconv.ParseInt(w.Header().Get(xhttp.ContentLength), 10, 64)
+		if err != nil || size < 0 {
+			return
+		}
+		atomic.AddUint64(&st.getObjectBytes, uint64(size))
+		atomic.AddUint64(&st.getObjectCount, 1)
+	case "putobject":
+		size := r.ContentLength
+		if v := r.Header.Get(xhttp.AmzDecodedContentLength); v != "" {
+			size, _ = strconv.ParseInt(v, 10, 64)
+		}
+		if size < 0 {
+			return
+		}
+		atomic.AddUint64(&st.putObjectBytes, uint64(size))
+		atomic.AddUint64(&st.putObjectCount, 1)
+	}
+}
+
+// avgObjectSize returns the mean object size per api.
+func (st *HTTPStats) avgObjectSize() map[string]uint64 {
+	avg := make(map[string]uint64, 2)
+	if count := atomic.LoadUint64(&st.getObjectCount); count > 0 {
+		avg["getobject"] = atomic.LoadUint64(&st.getObjectBytes) / count
+	}
+	if count := atomic.LoadUint64(&st.putObjectCount); count > 0 {
+		avg["putobject"] = atomic.LoadUint64(&st.putObjectBytes) / count
+	}
+	return avg
+}
+
+// errorRatios returns the ratio of errors to requests per api,
+// apis with less than minSamples requests are left out since
+// their ratios are too noisy to be meaningful.
+func (st *HTTPStats) errorRatios(minSamples int) map[string]float64 {
+	requests := st.totalS3Requests.Load()
+	errors := st.totalS3Errors.Load()
+
+	ratios := make(map[string]float64, len(requests))
+	for api, count := range requests {
+		if count == 0 || count < minSamples {
+			continue
+		}
+		ratios[api] = float64(errors[api]) / float64(count)
+	}
+	return ratios
+}
+
+// Converts http stats into struct to be sent back to the client,
+// the incoming requests are reset so they are counted per call
+// and the peaks of in-flight requests start again.
 func (st *HTTPStats) toServerHTTPStats() ServerHTTPStats {
-	serverStats := ServerHTTPStats{}
+	serverStats := st.loadServerHTTPStats()
 	serverStats.S3RequestsIncoming = atomic.SwapUint64(&st.s3RequestsIncoming, 0)
+	serverStats.PeakS3Requests = ServerHTTPAPIStats{
+		APIStats: st.currentS3Requests.swapPeaks(&st.peakS3Requests),
+	}
+	return serverStats
+}
+
+// loadServerHTTPStats converts http stats into struct to be sent
+// back to the client, leaving the incoming requests untouched.
+func (st *HTTPStats) loadServerHTTPStats() ServerHTTPStats {
+	serverStats := ServerHTTPStats{}
+	serverStats.S3RequestsIncoming = atomic.LoadUint64(&st.s3RequestsIncoming)
 	serverStats.S3RequestsInQueue = atomic.LoadInt32(&st.s3RequestsInQueue)
 	serverStats.TotalS3RejectedAuth = atomic.LoadUint64(&st.rejectedRequestsAuth)
 	serverStats.TotalS3RejectedTime = atomic.LoadUint64(&st.rejectedRequestsTime)
 	serverStats.TotalS3RejectedHeader = atomic.LoadUint64(&st.rejectedRequestsHeader)
 	serverStats.TotalS3RejectedInvalid = atomic.LoadUint64(&st.rejectedRequestsInvalid)
+	serverStats.TotalS3RejectedExpired = atomic.LoadUint64(&st.rejectedRequestsExpired)
+	// Auth, timestamp and header rejections happen in the outermost
+	// handlers before the request is processed any further, invalid
+	// requests are rejected only after parsing the path and query,
+	// expired credentials only when checking the access key.
+	serverStats.TotalS3RejectedEarly = serverStats.TotalS3RejectedAuth +
+		serverStats.TotalS3RejectedTime + serverStats.TotalS3RejectedHeader
+	serverStats.TotalS3RejectedLate = serverStats.TotalS3RejectedInvalid +
+		serverStats.TotalS3RejectedExpired
 	serverStats.CurrentS3Requests = ServerHTTPAPIStats{
 		APIStats: st.currentS3Requests.Load(),
 	}
+	serverStats.PeakS3Requests = ServerHTTPAPIStats{
+		APIStats: st.peakS3Requests.Load(),
+	}
 	serverStats.TotalS3Requests = ServerHTTPAPIStats{
 		APIStats: st.totalS3Requests.Load(),
 	}
@@ -189,9 +913,58 @@ func (st *HTTPStats) toServerHTTPStats() ServerHTTPStats {
 	serverStats.TotalS3Canceled = ServerHTTPAPIStats{
 		APIStats: st.totalS3Canceled.Load(),
 	}
+	serverStats.TotalS3CanceledClient = ServerHTTPAPIStats{
+		APIStats: st.totalS3CanceledClient.Load(),
+	}
+	serverStats.TotalS3CanceledShutdown = ServerHTTPAPIStats{
+		APIStats: st.totalS3CanceledShutdown.Load(),
+	}
+	serverStats.TotalS3DeadlineExceeded = ServerHTTPAPIStats{
+		APIStats: st.totalS3DeadlineExceeded.Load(),
+	}
+	serverStats.TotalS3RateLimited = ServerHTTPAPIStats{
+		APIStats: st.totalS3RateLimited.Load(),
+	}
+	serverStats.TotalS3MaxedOut = ServerHTTPAPIStats{
+		APIStats: st.totalS3MaxedOut.Load(),
+	}
+	serverStats.TotalS33xx = ServerHTTPAPIStats{
+		APIStats: st.totalS33xx.Load(),
+	}
+	serverStats.TotalS3AuthenticatedRequests = ServerHTTPAPIStats{
+		APIStats: st.authenticatedRequests.Load(),
+	}
+	serverStats.TotalS3AnonymousRequests = ServerHTTPAPIStats{
+		APIStats: st.anonymousRequests.Load(),
+	}
+	serverStats.TotalS3EncryptionRequests = make(map[string]ServerHTTPAPIStats, len(encryptionTypes))
+	for i, typ := range encryptionTypes {
+		serverStats.TotalS3EncryptionRequests[typ] = ServerHTTPAPIStats{
+			APIStats: st.encryptionRequests[i].Load(),
+		}
+	}
+	serverStats.TotalS3StatusCodes = st.statusCodes.load()
+	serverStats.AvgObjectSize = st.avgObjectSize()
+	serverStats.TotalS3ReadRequests = atomic.LoadUint64(&st.readRequests)
+	serverStats.TotalS3WriteRequests = atomic.LoadUint64(&st.writeRequests)
+	serverStats.TotalS3MetadataRequests = atomic.LoadUint64(&st.metadataRequests)
+	serverStats.BucketStats = st.toServerBucketHTTPStats()
+	serverStats.RequestsRate = st.requestsRate.toServerRates()
+	serverStats.ErrorsRate = st.errorsRate.toServerRates()
 	return serverStats
 }
 
+// Upper bounds of the request and response size histograms,
+// i.e. <1KiB, 1-64KiB, 64KiB-1MiB, 1-16MiB and >16MiB.
+var requestSizeBuckets = []float64{1 << 10, 64 << 10, 1 << 20, 16 << 20}
+
+// observeRequestSizes records the request body and response
+// sizes, as metered on the wire, of an S3 request to api.
+func observeRequestSizes(api string, inputBytes, outputBytes int64) {
+	httpRequestsSize.With(prometheus.Labels{"api": api}).Observe(float64(inputBytes))
+	httpResponsesSize.With(prometheus.Labels{"api": api}).Observe(float64(outputBytes))
+}
+
 // Update statistics from http request and response data
 func (st *HTTPStats) updateStats(api string, r *http.Request, w *logger.ResponseWriter) {
 	// Ignore non S3 requests
@@ -199,29 +972,123 @@ func (st *HTTPStats) updateStats(api string, r *http.Request, w *logger.Response
 		return
 	}
 
+	st.resetMu.RLock()
+	defer st.resetMu.RUnlock()
+
 	st.totalS3Requests.Inc(api)
+	st.requestsRate.add(1)
+	st.addObjectSize(api, r, w)
+
+	switch {
+	case isRequestAuthenticated(r):
+		st.authenticatedRequests.Inc(api)
+	case getRequestAuthType(r) == authTypeAnonymous:
+		st.anonymousRequests.Inc(api)
+		vars := mux.Vars(r)
+		st.anonymous.add(vars["bucket"], likelyUnescapeGeneric(vars["object"], url.PathUnescape),
+			apiDirection(api) == apiDirectionWrite, w.StatusCode)
+	}
 
-	// Increment the prometheus http request response histogram with appropriate label
-	httpRequestsDuration.With(prometheus.Labels{"api": api}).Observe(w.TimeToFirstByte.Seconds())
+	switch apiDirection(api) {
+	case apiDirectionRead:
+		atomic.AddUint64(&st.readRequests, 1)
+	case apiDirectionWrite:
+		atomic.AddUint64(&st.writeRequests, 1)
+	default:
+		atomic.AddUint64(&st.metadataRequests, 1)
+	}
+
+	duration := time.Since(requestStartTime(r, w))
+
+	// Increment the prometheus http request response histograms with appropriate label
+	if st.observeTTFB() {
+		httpRequestsDuration.With(prometheus.Labels{"api": api}).Observe(w.TimeToFirstByte.Seconds())
+		httpRequestsTotalDuration.With(prometheus.Labels{"api": api}).Observe(duration.Seconds())
+	}
+	if st.slowRequestThreshold > 0 && duration > st.slowRequestThreshold {
+		st.slowRequests.add(api, r, w, duration)
+	}
+	if st.profileLatencyThreshold > 0 && duration > st.profileLatencyThreshold {
+		triggerContinuousProfile(continuousProfileLatency)
+	}
 
 	code := w.StatusCode
 
+	st.updateBucketStats(servedBucket(r, w), code)
+	if accessKey, parentUser, ok := requestCredentials(r); ok {
+		st.updateAccessKeyStats(accessKey, parentUser, code, r.ContentLength, int64(w.Size()))
+	}
+	transferred := uint64(w.Size())
+	if r.ContentLength > 0 {
+		transferred += uint64(r.ContentLength)
+	}
+	st.clients.add(handlers.GetSourceIP(r), transferred)
+	if code != 0 {
+		st.statusCodes.add(api, code, 1)
+	}
+	if errCode := requestErrorCode(r); signatureErrorCodes[errCode] {
+		st.rejections.add(r, rejectedSignature, errCode)
+	}
+	if requestDeadlineExceeded(r) {
+		st.totalS3DeadlineExceeded.Inc(api)
+	}
+	if typ := requestEncryption(r); typ != "" && code < http.StatusBadRequest {
+		for i := range encryptionTypes {
+			if encryptionTypes[i] == typ {
+				st.encryptionRequests[i].Inc(api)
+			}
+		}
+	}
+	if apiDirection(api) == apiDirectionRead && code < http.StatusBadRequest {
+		if vars := mux.Vars(r); vars["bucket"] != "" && vars["object"] != "" {
+			st.hot.add(vars["bucket"], likelyUnescapeGeneric(vars["object"], url.PathUnescape))
+		}
+	}
+
 	switch {
 	case code == 0:
 	case code == 499:
 		// 499 is a good error, shall be counted as canceled.
 		st.totalS3Canceled.Inc(api)
+		// The server cancels all requests when shutting down.
+		if contextCanceled(GlobalContext) {
+			st.totalS3CanceledShutdown.Inc(api)
+		} else {
+			st.totalS3CanceledClient.Inc(api)
+		}
 	case code >= http.StatusBadRequest:
 		st.totalS3Errors.Inc(api)
+		st.errorsRate.add(1)
 		if code >= http.StatusInternalServerError {
 			st.totalS35xxErrors.Inc(api)
 		} else {
 			st.totalS34xxErrors.Inc(api)
 		}
+	case code >= http.StatusMultipleChoices:
+		// Redirects and 304 Not Modified responses.
+		st.totalS33xx.Inc(api)
 	}
 }
 
+// observeTTFB reports whether the current request should be
+// observed by the TTFB and duration histograms. With sampling enabled
+// the histogram counts are roughly 1/N of the actual request counts.
+func (st *HTTPStats) observeTTFB() bool {
+	if st.ttfbSampleRate <= 1 {
+		return true
+	}
+	return atomic.AddUint64(&st.ttfbSampleCount, 1)%st.ttfbSampleRate == 0
+}
+
 // Prepare new HTTPStats structure
 func newHTTPStats() *HTTPStats {
-	return &HTTPStats{}
+	sampleRate, err := strconv.ParseUint(env.Get(EnvTTFBSampleRate, "1"), 10, 64)
+	if err != nil || sampleRate == 0 {
+		sampleRate = 1
+	}
+	return &HTTPStats{
+		ttfbSampleRate:          sampleRate,
+		slowRequestThreshold:    slowRequestThreshold(),
+		profileLatencyThreshold: continuousProfileLatencyThreshold(),
+	}
 }
@@ -0,0 +1,801 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"reflect"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/minio/minio/internal/auth"
+	"github.com/minio/minio/internal/crypto"
+	xhttp "github.com/minio/minio/internal/http"
+	"github.com/minio/minio/internal/logger"
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"golang.org/x/time/rate"
+)
+
+// Tests that HEAD and GET object reads are accounted under distinct APIs.
+func TestHTTPStatsHeadGetObject(t *testing.T) {
+	saved := globalHTTPStats
+	defer func() { globalHTTPStats = saved }()
+	globalHTTPStats = newHTTPStats()
+
+	// Requests are rejected before reaching the object layer.
+	savedObjAPI := newObjectLayerFn()
+	defer setObjectLayer(savedObjAPI)
+	setObjectLayer(nil)
+
+	router := mux.NewRouter().SkipClean(true).UseEncodedPath()
+	registerAPIRouter(router)
+
+	for _, method := range []string{http.MethodHead, http.MethodGet, http.MethodGet} {
+		req := httptest.NewRequest(method, "/bucket/object", nil)
+		router.ServeHTTP(httptest.NewRecorder(), req)
+	}
+
+	apiStats := globalHTTPStats.totalS3Requests.Load()
+	if apiStats["headobject"] != 1 {
+		t.Errorf("expected 1 headobject request, got %d", apiStats["headobject"])
+	}
+	if apiStats["getobject"] != 2 {
+		t.Errorf("expected 2 getobject requests, got %d", apiStats["getobject"])
+	}
+}
+
+// Tests that byte counters wrap around to zero on overflow
+// and that every wraparound is accounted.
+func TestConnStatsWraparound(t *testing.T) {
+	s := newConnStats()
+	s.s3InputBytes = math.MaxUint64 - 10
+
+	s.incS3InputBytes(5)
+	if got := s.toServerConnStats(); got.S3InputBytes != math.MaxUint64-5 || got.Wraparounds != 0 {
+		t.Fatalf("unexpected stats before overflow: %+v", got)
+	}
+
+	s.incS3InputBytes(20)
+	got := s.toServerConnStats()
+	if got.S3InputBytes != 14 {
+		t.Errorf("expected counter to wrap around to 14, got %d", got.S3InputBytes)
+	}
+	if got.Wraparounds != 1 {
+		t.Errorf("expected 1 wraparound, got %d", got.Wraparounds)
+	}
+
+	// Negative and zero deltas must not be mistaken for an overflow.
+	s.incS3InputBytes(0)
+	s.incS3InputBytes(-1)
+	if got := s.toServerConnStats(); got.S3InputBytes != 14 || got.Wraparounds != 1 {
+		t.Errorf("unexpected stats after empty updates: %+v", got)
+	}
+}
+
+// Tests per bucket traffic accounting and its cardinality cap.
+func TestConnStatsBuckets(t *testing.T) {
+	s := newConnStats()
+	s.incBucketInputBytes("bucket", 10)
+	s.incBucketOutputBytes("bucket", 20)
+	s.incBucketOutputBytes("", 5)
+	s.incBucketOutputBytes(minioReservedBucket, 5)
+
+	stats := s.toServerBucketConnStats()
+	if got := stats["bucket"]; got.S3InputBytes != 10 || got.S3OutputBytes != 20 {
+		t.Errorf("unexpected bucket stats: %+v", got)
+	}
+	if got := stats[nonBucketConnStats]; got.S3InputBytes != 0 || got.S3OutputBytes != 10 {
+		t.Errorf("unexpected non-bucket stats: %+v", got)
+	}
+
+	// Make "bucket" the least recently active, it must be evicted first.
+	s.incBucketInputBytes(nonBucketConnStats, 1)
+	for i := 0; i < maxBucketConnStats-1; i++ {
+		s.incBucketInputBytes("bucket-"+strconv.Itoa(i), 1)
+	}
+	stats = s.toServerBucketConnStats()
+	if len(stats) != maxBucketConnStats {
+		t.Errorf("expected %d buckets, got %d", maxBucketConnStats, len(stats))
+	}
+	if _, ok := stats["bucket"]; ok {
+		t.Error("expected least recently active bucket to be evicted")
+	}
+}
+
+// Tests that traffic is attributed only to existing buckets
+// the requests are not rejected as unauthorized to.
+func TestConnStatsTrafficBucket(t *testing.T) {
+	savedConnStats, savedMetadataSys := globalConnStats, globalBucketMetadataSys
+	defer func() { globalConnStats, globalBucketMetadataSys = savedConnStats, savedMetadataSys }()
+	globalConnStats = newConnStats()
+	globalBucketMetadataSys = NewBucketMetadataSys()
+	globalBucketMetadataSys.Set("bucket", newBucketMetadata("bucket"))
+
+	testCases := []struct {
+		bucket string
+		status int
+	}{
+		{"bucket", http.StatusOK},
+		{"bucket", http.StatusNotFound},
+		{"bucket", http.StatusForbidden},
+		{"unknown", http.StatusOK},
+		{"", http.StatusOK},
+	}
+	for _, testCase := range testCases {
+		status := testCase.status
+		handler := setHTTPStatsHandler(collectAPIStats("getobject", func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(status)
+			w.Write([]byte("hello"))
+		}))
+		r := httptest.NewRequest(http.MethodGet, "/"+testCase.bucket+"/object", nil)
+		handler.ServeHTTP(httptest.NewRecorder(), mux.SetURLVars(r, map[string]string{"bucket": testCase.bucket}))
+	}
+
+	stats := globalConnStats.toServerBucketConnStats()
+	if len(stats) != 2 {
+		t.Errorf("expected traffic of 2 buckets, got %v", stats)
+	}
+	if got := stats["bucket"].S3OutputBytes; got != 10 {
+		t.Errorf("expected 10 bytes sent for bucket, got %d", got)
+	}
+	if got := stats[nonBucketConnStats].S3OutputBytes; got != 15 {
+		t.Errorf("expected 15 bytes sent for no bucket, got %d", got)
+	}
+}
+
+// Tests classification of responses by status code.
+func TestHTTPStatsStatusCodes(t *testing.T) {
+	st := newHTTPStats()
+	for _, code := range []int{
+		http.StatusOK,
+		http.StatusNotModified,
+		http.StatusTemporaryRedirect,
+		http.StatusNotFound,
+		http.StatusInternalServerError,
+		499,
+	} {
+		w := logger.NewResponseWriter(httptest.NewRecorder())
+		w.WriteHeader(code)
+		st.updateStats("getobject", httptest.NewRequest(http.MethodGet, "/bucket/object", nil), w)
+	}
+
+	stats := st.toServerHTTPStats()
+	testCases := []struct {
+		name     string
+		apiStats map[string]int
+		expected int
+	}{
+		{"requests", stats.TotalS3Requests.APIStats, 6},
+		{"3xx", stats.TotalS33xx.APIStats, 2},
+		{"errors", stats.TotalS3Errors.APIStats, 2},
+		{"4xx", stats.TotalS34xxErrors.APIStats, 1},
+		{"5xx", stats.TotalS35xxErrors.APIStats, 1},
+		{"canceled", stats.TotalS3Canceled.APIStats, 1},
+	}
+	for _, testCase := range testCases {
+		if got := testCase.apiStats["getobject"]; got != testCase.expected {
+			t.Errorf("%s: expected %d, got %d", testCase.name, testCase.expected, got)
+		}
+	}
+
+	expectedCodes := map[int]uint64{200: 1, 304: 1, 307: 1, 404: 1, 500: 1, 499: 1}
+	if got := stats.TotalS3StatusCodes["getobject"]; !reflect.DeepEqual(got, expectedCodes) {
+		t.Errorf("expected status codes %v, got %v", expectedCodes, got)
+	}
+}
+
+// Tests that the in-flight gauge can not desync when a request
+// skipped its increment or runs its decrement more than once.
+func TestHTTPStatsCurrentRequests(t *testing.T) {
+	stats := &HTTPAPIStats{}
+	stats.Inc("getobject")
+
+	// Increment was skipped, decrement must not touch the gauge.
+	skipped := &currentRequest{api: "getobject"}
+	skipped.dec(stats)
+	if got := stats.Load()["getobject"]; got != 1 {
+		t.Fatalf("expected 1 in-flight request after skipped increment, got %d", got)
+	}
+
+	// Decrement running twice must only decrement once.
+	current := &currentRequest{api: "getobject"}
+	current.inc(stats, &HTTPAPIStats{})
+	current.dec(stats)
+	current.dec(stats)
+	if got := stats.Load()["getobject"]; got != 1 {
+		t.Fatalf("expected 1 in-flight request after double decrement, got %d", got)
+	}
+
+	saved := globalHTTPStats
+	defer func() { globalHTTPStats = saved }()
+	globalHTTPStats = newHTTPStats()
+
+	var inflight map[string]int
+	handler := collectAPIStats("outer", collectAPIStats("inner", func(w http.ResponseWriter, r *http.Request) {
+		inflight = globalHTTPStats.currentS3Requests.Load()
+	}))
+	handler(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/bucket/object", nil))
+	if inflight["outer"] != 1 || inflight["inner"] != 0 {
+		t.Errorf("expected request to be in-flight once, got %v", inflight)
+	}
+	handler(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/bucket/minio/", nil))
+	if inflight["outer"] != 0 {
+		t.Errorf("expected reserved bucket request not to be in-flight, got %v", inflight)
+	}
+	if got := globalHTTPStats.currentS3Requests.Load(); got["outer"] != 0 || got["inner"] != 0 {
+		t.Errorf("expected no in-flight requests, got %v", got)
+	}
+}
+
+// Tests that peaks of in-flight requests are kept until swapped
+// and start again from the requests still in flight.
+func TestHTTPStatsPeakRequests(t *testing.T) {
+	st := newHTTPStats()
+	requests := make([]*currentRequest, 3)
+	for i := range requests {
+		requests[i] = &currentRequest{api: "listobjectsv2"}
+		requests[i].inc(&st.currentS3Requests, &st.peakS3Requests)
+	}
+	for _, current := range requests[1:] {
+		current.dec(&st.currentS3Requests)
+	}
+
+	if got := st.loadServerHTTPStats().PeakS3Requests.APIStats["listobjectsv2"]; got != 3 {
+		t.Errorf("expected peak of 3 requests, got %d", got)
+	}
+	if got := st.toServerHTTPStats().PeakS3Requests.APIStats["listobjectsv2"]; got != 3 {
+		t.Errorf("expected peak of 3 requests at scrape, got %d", got)
+	}
+	if got := st.toServerHTTPStats().PeakS3Requests.APIStats["listobjectsv2"]; got != 1 {
+		t.Errorf("expected peak of 1 in-flight request after scrape, got %d", got)
+	}
+}
+
+// Tests mean object size accounting of GetObject and PutObject.
+func TestRequestSizeHistograms(t *testing.T) {
+	handler := setHTTPStatsHandler(collectAPIStats("sizes-outer", collectAPIStats("sizes-inner", func(w http.ResponseWriter, r *http.Request) {
+		io.Copy(io.Discard, r.Body)
+		w.Write(make([]byte, 100<<10))
+	})))
+	body := bytes.NewReader(make([]byte, 2<<20))
+	handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodPut, "/bucket/object", body))
+
+	histogram := func(h *prometheus.HistogramVec, api string) *dto.Histogram {
+		m := &dto.Metric{}
+		if err := h.WithLabelValues(api).(prometheus.Metric).Write(m); err != nil {
+			t.Fatal(err)
+		}
+		return m.GetHistogram()
+	}
+	// Cumulative counts of the <1KiB, <64KiB, <1MiB and <16MiB buckets.
+	for _, tc := range []struct {
+		h        *prometheus.HistogramVec
+		expected []uint64
+	}{
+		{httpRequestsSize, []uint64{0, 0, 0, 1}},
+		{httpResponsesSize, []uint64{0, 0, 1, 1}},
+	} {
+		h := histogram(tc.h, "sizes-outer")
+		var counts []uint64
+		for _, b := range h.GetBucket() {
+			counts = append(counts, b.GetCumulativeCount())
+		}
+		if !reflect.DeepEqual(counts, tc.expected) {
+			t.Errorf("expected bucket counts %v, got %v", tc.expected, counts)
+		}
+	}
+	if n := histogram(httpRequestsSize, "sizes-inner").GetSampleCount(); n != 0 {
+		t.Errorf("expected the request to be observed once by the outermost api, got %d inner samples", n)
+	}
+}
+
+func TestHTTPStatsAvgObjectSize(t *testing.T) {
+	st := newHTTPStats()
+	for _, size := range []string{"100", "300"} {
+		w := logger.NewResponseWriter(httptest.NewRecorder())
+		w.Header().Set("Content-Length", size)
+		w.WriteHeader(http.StatusOK)
+		st.updateStats("getobject", httptest.NewRequest(http.MethodGet, "/bucket/object", nil), w)
+	}
+	// Failed requests are not accounted.
+	w := logger.NewResponseWriter(httptest.NewRecorder())
+	w.Header().Set("Content-Length", "1000")
+	w.WriteHeader(http.StatusNotFound)
+	st.updateStats("getobject", httptest.NewRequest(http.MethodGet, "/bucket/object", nil), w)
+
+	req := httptest.NewRequest(http.MethodPut, "/bucket/object", nil)
+	req.ContentLength = 50
+	st.updateStats("putobject", req, logger.NewResponseWriter(httptest.NewRecorder()))
+
+	avg := st.toServerHTTPStats().AvgObjectSize
+	if avg["getobject"] != 200 {
+		t.Errorf("expected mean getobject size of 200, got %d", avg["getobject"])
+	}
+	if avg["putobject"] != 50 {
+		t.Errorf("expected mean putobject size of 50, got %d", avg["putobject"])
+	}
+}
+
+// Tests classification of APIs by direction.
+func TestAPIDirection(t *testing.T) {
+	testCases := []struct {
+		api       string
+		direction apiDirectionType
+	}{
+		{"getobject", apiDirectionRead},
+		{"selectobjectcontent", apiDirectionRead},
+		{"putobject", apiDirectionWrite},
+		{"putobjectpart", apiDirectionWrite},
+		{"deletemultipleobjects", apiDirectionWrite},
+		{"headobject", apiDirectionMetadata},
+		{"listobjectsv2", apiDirectionMetadata},
+		{"putbuckettagging", apiDirectionMetadata},
+		{"notfound", apiDirectionMetadata},
+	}
+	for _, testCase := range testCases {
+		if got := apiDirection(testCase.api); got != testCase.direction {
+			t.Errorf("%s: expected %s, got %s", testCase.api, testCase.direction, got)
+		}
+	}
+
+	st := newHTTPStats()
+	for _, api := range []string{"getobject", "putobject", "headobject", "listobjectsv2"} {
+		st.updateStats(api, httptest.NewRequest(http.MethodGet, "/bucket/object", nil), logger.NewResponseWriter(httptest.NewRecorder()))
+	}
+	stats := st.toServerHTTPStats()
+	if stats.TotalS3ReadRequests != 1 || stats.TotalS3WriteRequests != 1 || stats.TotalS3MetadataRequests != 2 {
+		t.Errorf("unexpected read/write/metadata totals: %d/%d/%d",
+			stats.TotalS3ReadRequests, stats.TotalS3WriteRequests, stats.TotalS3MetadataRequests)
+	}
+}
+
+// Tests the early/late split of rejected requests.
+func TestHTTPStatsRejected(t *testing.T) {
+	st := newHTTPStats()
+	st.rejectedRequestsAuth = 1
+	st.rejectedRequestsTime = 2
+	st.rejectedRequestsHeader = 3
+	st.rejectedRequestsInvalid = 4
+
+	stats := st.toServerHTTPStats()
+	if stats.TotalS3RejectedEarly != 6 {
+		t.Errorf("expected 6 early rejections, got %d", stats.TotalS3RejectedEarly)
+	}
+	if stats.TotalS3RejectedLate != 4 {
+		t.Errorf("expected 4 late rejections, got %d", stats.TotalS3RejectedLate)
+	}
+}
+
+// Tests the anonymous vs authenticated split of requests.
+func TestHTTPStatsAuthenticated(t *testing.T) {
+	saved := globalHTTPStats
+	defer func() { globalHTTPStats = saved }()
+	globalHTTPStats = newHTTPStats()
+
+	authenticated := collectAPIStats("getobject", func(w http.ResponseWriter, r *http.Request) {
+		setRequestAuthenticated(r.Context(), auth.Credentials{AccessKey: "minio"})
+	})
+	unauthenticated := collectAPIStats("getobject", func(w http.ResponseWriter, r *http.Request) {})
+
+	authenticated(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/bucket/object", nil))
+	unauthenticated(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/bucket/object", nil))
+	unauthenticated(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/bucket/object", nil))
+
+	// Signed request failing authentication is in neither.
+	req := httptest.NewRequest(http.MethodGet, "/bucket/object", nil)
+	req.Header.Set("Authorization", signV4Algorithm+" Credential=invalid")
+	unauthenticated(httptest.NewRecorder(), req)
+
+	stats := globalHTTPStats.toServerHTTPStats()
+	if got := stats.TotalS3AuthenticatedRequests.APIStats["getobject"]; got != 1 {
+		t.Errorf("expected 1 authenticated request, got %d", got)
+	}
+	if got := stats.TotalS3AnonymousRequests.APIStats["getobject"]; got != 2 {
+		t.Errorf("expected 2 anonymous requests, got %d", got)
+	}
+}
+
+// Tests requests are accounted by the encryption type of the object.
+func TestHTTPStatsEncryption(t *testing.T) {
+	saved := globalHTTPStats
+	defer func() { globalHTTPStats = saved }()
+	globalHTTPStats = newHTTPStats()
+
+	withMetadata := func(metadata map[string]string, code int) http.HandlerFunc {
+		return collectAPIStats("putobject", func(w http.ResponseWriter, r *http.Request) {
+			setRequestEncryption(r.Context(), metadata)
+			w.WriteHeader(code)
+		})
+	}
+	sseS3 := map[string]string{crypto.MetaSealedKeyS3: "key"}
+	sseC := map[string]string{crypto.MetaSealedKeySSEC: "key"}
+	partial := map[string]string{crypto.MetaIV: "iv"}
+
+	withMetadata(sseS3, http.StatusOK)(httptest.NewRecorder(), httptest.NewRequest(http.MethodPut, "/bucket/object", nil))
+	withMetadata(sseC, http.StatusOK)(httptest.NewRecorder(), httptest.NewRequest(http.MethodPut, "/bucket/object", nil))
+	withMetadata(nil, http.StatusOK)(httptest.NewRecorder(), httptest.NewRequest(http.MethodPut, "/bucket/object", nil))
+	withMetadata(nil, http.StatusOK)(httptest.NewRecorder(), httptest.NewRequest(http.MethodPut, "/bucket/object", nil))
+	// Failed requests and unknown encryption types are not accounted.
+	withMetadata(sseS3, http.StatusForbidden)(httptest.NewRecorder(), httptest.NewRequest(http.MethodPut, "/bucket/object", nil))
+	withMetadata(partial, http.StatusOK)(httptest.NewRecorder(), httptest.NewRequest(http.MethodPut, "/bucket/object", nil))
+
+	stats := globalHTTPStats.toServerHTTPStats()
+	want := map[string]int{
+		crypto.S3.String():    1,
+		crypto.S3KMS.String(): 0,
+		crypto.SSEC.String():  1,
+		plaintextEncryption:   2,
+	}
+	for typ, n := range want {
+		if got := stats.TotalS3EncryptionRequests[typ].APIStats["putobject"]; got != n {
+			t.Errorf("expected %d %s requests, got %d", n, typ, got)
+		}
+	}
+}
+
+// Tests the causes of canceled and timed out requests.
+func TestHTTPStatsCanceledCauses(t *testing.T) {
+	saved, savedCtx := globalHTTPStats, GlobalContext
+	defer func() { globalHTTPStats, GlobalContext = saved, savedCtx }()
+	globalHTTPStats = newHTTPStats()
+
+	failWith := func(err error) http.HandlerFunc {
+		return collectAPIStats("getobject", func(w http.ResponseWriter, r *http.Request) {
+			writeErrorResponse(r.Context(), w, toAPIError(r.Context(), err), r.URL)
+		})
+	}
+	canceledRequest := func() *http.Request {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		return httptest.NewRequest(http.MethodGet, "/bucket/object", nil).WithContext(ctx)
+	}
+
+	failWith(context.Canceled)(httptest.NewRecorder(), canceledRequest())
+	failWith(context.DeadlineExceeded)(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/bucket/object", nil))
+
+	shutdownCtx, cancel := context.WithCancel(context.Background())
+	cancel()
+	GlobalContext = shutdownCtx
+	failWith(context.Canceled)(httptest.NewRecorder(), canceledRequest())
+
+	stats := globalHTTPStats.toServerHTTPStats()
+	if got := stats.TotalS3Canceled.APIStats["getobject"]; got != 2 {
+		t.Errorf("expected 2 canceled requests, got %d", got)
+	}
+	if got := stats.TotalS3CanceledClient.APIStats["getobject"]; got != 1 {
+		t.Errorf("expected 1 request canceled by the client, got %d", got)
+	}
+	if got := stats.TotalS3CanceledShutdown.APIStats["getobject"]; got != 1 {
+		t.Errorf("expected 1 request canceled by shutdown, got %d", got)
+	}
+	if got := stats.TotalS3DeadlineExceeded.APIStats["getobject"]; got != 1 {
+		t.Errorf("expected 1 request exceeding a deadline, got %d", got)
+	}
+}
+
+// Tests that requests beyond the requests per second limits
+// are rejected with SlowDown and counted.
+func TestHTTPStatsRateLimited(t *testing.T) {
+	saved := globalHTTPStats
+	defer func() { globalHTTPStats = saved }()
+	globalHTTPStats = newHTTPStats()
+
+	globalAPIConfig.mu.Lock()
+	globalAPIConfig.apiRequestsLimiters = map[string]*rate.Limiter{"listobjectsv2": newRequestsLimiter(1)}
+	globalAPIConfig.requestsRateAPI = map[string]int{"listobjectsv2": 1}
+	globalAPIConfig.mu.Unlock()
+	defer func() {
+		globalAPIConfig.mu.Lock()
+		globalAPIConfig.apiRequestsLimiters, globalAPIConfig.requestsRateAPI = nil, nil
+		globalAPIConfig.mu.Unlock()
+	}()
+
+	handler := func(api string) http.HandlerFunc {
+		return collectAPIStats(api, maxClients(func(w http.ResponseWriter, r *http.Request) {
+			writeSuccessResponseHeadersOnly(w)
+		}))
+	}
+	serve := func(api string) *httptest.ResponseRecorder {
+		rec := httptest.NewRecorder()
+		handler(api)(rec, httptest.NewRequest(http.MethodGet, "/bucket", nil))
+		return rec
+	}
+
+	if rec := serve("listobjectsv2"); rec.Code != http.StatusOK {
+		t.Fatalf("expected the first request to pass, got %d", rec.Code)
+	}
+	rec := serve("listobjectsv2")
+	if rec.Code != http.StatusServiceUnavailable || !bytes.Contains(rec.Body.Bytes(), []byte("SlowDown")) {
+		t.Fatalf("expected SlowDown, got %d: %s", rec.Code, rec.Body.String())
+	}
+	if got := rec.Header().Get(xhttp.RetryAfter); got != "1" {
+		t.Errorf("expected to retry after 1 second, got %q", got)
+	}
+	// Other apis are not limited.
+	if rec := serve("listobjectsv1"); rec.Code != http.StatusOK {
+		t.Errorf("expected unlimited api to pass, got %d", rec.Code)
+	}
+
+	stats := globalHTTPStats.toServerHTTPStats()
+	if got := stats.TotalS3RateLimited.APIStats; len(got) != 1 || got["listobjectsv2"] != 1 {
+		t.Errorf("expected 1 rate limited request, got %v", got)
+	}
+}
+
+func TestHTTPStatsMaxedOut(t *testing.T) {
+	saved := globalHTTPStats
+	defer func() { globalHTTPStats = saved }()
+	globalHTTPStats = newHTTPStats()
+
+	listPool := make(chan struct{}, 1)
+	globalAPIConfig.mu.Lock()
+	savedPool, savedDeadline := globalAPIConfig.requestsPool, globalAPIConfig.requestsDeadline
+	globalAPIConfig.requestsPool = make(chan struct{}, 10)
+	globalAPIConfig.requestsDeadline = 100 * time.Millisecond
+	globalAPIConfig.apiRequestsPools = map[string]chan struct{}{"listobjectsv2": listPool}
+	globalAPIConfig.mu.Unlock()
+	defer func() {
+		globalAPIConfig.mu.Lock()
+		globalAPIConfig.requestsPool, globalAPIConfig.requestsDeadline = savedPool, savedDeadline
+		globalAPIConfig.apiRequestsPools = nil
+		globalAPIConfig.mu.Unlock()
+	}()
+
+	serve := func(api string) *httptest.ResponseRecorder {
+		rec := httptest.NewRecorder()
+		collectAPIStats(api, maxClients(func(w http.ResponseWriter, r *http.Request) {
+			writeSuccessResponseHeadersOnly(w)
+		}))(rec, httptest.NewRequest(http.MethodGet, "/bucket", nil))
+		return rec
+	}
+
+	// Occupy the only listing slot.
+	listPool <- struct{}{}
+	rec := serve("listobjectsv2")
+	if rec.Code != http.StatusServiceUnavailable || !bytes.Contains(rec.Body.Bytes(), []byte("SlowDown")) {
+		t.Fatalf("expected SlowDown, got %d: %s", rec.Code, rec.Body.String())
+	}
+	// Other apis only wait for the overall pool.
+	if rec := serve("putobject"); rec.Code != http.StatusOK {
+		t.Errorf("expected unlimited api to pass, got %d", rec.Code)
+	}
+	<-listPool
+	if rec := serve("listobjectsv2"); rec.Code != http.StatusOK {
+		t.Errorf("expected the request to pass once a slot is free, got %d", rec.Code)
+	}
+
+	stats := globalHTTPStats.toServerHTTPStats()
+	if got := stats.TotalS3MaxedOut.APIStats; len(got) != 1 || got["listobjectsv2"] != 1 {
+		t.Errorf("expected 1 maxed out request, got %v", got)
+	}
+	if stats.S3RequestsInQueue != 0 {
+		t.Errorf("expected no requests left in the queue, got %d", stats.S3RequestsInQueue)
+	}
+	if len(listPool) != 0 {
+		t.Error("expected the listing slot to be released")
+	}
+}
+
+// Tests per api error ratios with a minimum sample guard.
+func TestHTTPStatsErrorRatios(t *testing.T) {
+	st := newHTTPStats()
+	for i := 0; i < 10; i++ {
+		st.totalS3Requests.Inc("getobject")
+	}
+	st.totalS3Errors.Inc("getobject")
+	st.totalS3Requests.Inc("listbuckets")
+	st.totalS3Requests.Inc("listbuckets")
+	st.totalS3Errors.Inc("listbuckets")
+
+	ratios := st.errorRatios(5)
+	if len(ratios) != 1 {
+		t.Fatalf("expected only getobject ratio, got %v", ratios)
+	}
+	if ratios["getobject"] != 0.1 {
+		t.Errorf("expected getobject ratio of 0.1, got %f", ratios["getobject"])
+	}
+
+	ratios = st.errorRatios(0)
+	if ratios["listbuckets"] != 0.5 {
+		t.Errorf("expected listbuckets ratio of 0.5, got %f", ratios["listbuckets"])
+	}
+}
+
+// Tests that the open connections gauge follows the
+// connection life cycle and returns to zero on close.
+func TestConnStatsOpenConnections(t *testing.T) {
+	stats := newConnStats()
+	ts := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if got := stats.getOpenConnections(); got != 1 {
+			t.Errorf("expected 1 open connection, got %d", got)
+		}
+	}))
+	ts.Config.ConnState = stats.trackConnState
+	ts.Start()
+
+	resp, err := http.Get(ts.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+
+	// Closing the server tears down all idle connections.
+	ts.Close()
+	if got := stats.getOpenConnections(); got != 0 {
+		t.Errorf("expected no open connections, got %d", got)
+	}
+}
+
+// Tests per bucket requests and errors.
+func TestHTTPStatsBuckets(t *testing.T) {
+	savedMetadataSys := globalBucketMetadataSys
+	defer func() { globalBucketMetadataSys = savedMetadataSys }()
+	globalBucketMetadataSys = NewBucketMetadataSys()
+	for _, bucket := range []string{"bucket", "other"} {
+		globalBucketMetadataSys.Set(bucket, newBucketMetadata(bucket))
+	}
+
+	st := newHTTPStats()
+	for _, testCase := range []struct {
+		bucket string
+		code   int
+	}{
+		{"bucket", http.StatusOK},
+		{"bucket", http.StatusNotFound},
+		{"bucket", http.StatusServiceUnavailable},
+		{"bucket", 499},
+		{"other", http.StatusOK},
+		{"", http.StatusOK},
+		// Requests rejected as unauthorized or to unknown
+		// buckets are not accounted to the bucket.
+		{"bucket", http.StatusForbidden},
+		{"unknown", http.StatusOK},
+	} {
+		w := logger.NewResponseWriter(httptest.NewRecorder())
+		w.WriteHeader(testCase.code)
+		r := httptest.NewRequest(http.MethodGet, "/"+testCase.bucket, nil)
+		if testCase.bucket != "" {
+			r = mux.SetURLVars(r, map[string]string{"bucket": testCase.bucket})
+		}
+		st.updateStats("getobject", r, w)
+	}
+
+	stats := st.toServerHTTPStats().BucketStats
+	expected := ServerBucketHTTPStats{
+		TotalS3Requests:  4,
+		TotalS34xxErrors: 1,
+		TotalS35xxErrors: 1,
+		TotalS3Canceled:  1,
+	}
+	if stats["bucket"] != expected {
+		t.Errorf("expected %+v, got %+v", expected, stats["bucket"])
+	}
+	if stats["other"].TotalS3Requests != 1 {
+		t.Errorf("expected 1 request for other, got %d", stats["other"].TotalS3Requests)
+	}
+	if stats[nonBucketConnStats].TotalS3Requests != 3 {
+		t.Errorf("expected 3 requests not addressed to a bucket, got %d", stats[nonBucketConnStats].TotalS3Requests)
+	}
+	if _, ok := stats["unknown"]; ok {
+		t.Error("expected no stats for an unknown bucket")
+	}
+}
+
+func TestStatsBucketExistsGateway(t *testing.T) {
+	obj, fsDir, err := prepareFS()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer obj.Shutdown(context.Background())
+	defer os.RemoveAll(fsDir)
+	if err = obj.MakeBucketWithLocation(context.Background(), "bucket", BucketOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	setObjectLayer(obj)
+	defer setObjectLayer(nil)
+
+	defer func(isGateway bool) {
+		globalIsGateway = isGateway
+		gatewayBuckets = timedValue{TTL: time.Minute}
+	}(globalIsGateway)
+	globalIsGateway = true
+	gatewayBuckets = timedValue{TTL: time.Minute}
+
+	// The bucket metadata system does not know the buckets of gateways.
+	if !statsBucketExists("bucket") {
+		t.Error("expected the bucket of the gateway backend to exist")
+	}
+	if statsBucketExists("unknown") {
+		t.Error("expected an unknown bucket not to exist")
+	}
+}
+
+// Tests parsing of request duration histogram buckets.
+func TestParseRequestDurationBuckets(t *testing.T) {
+	testCases := []struct {
+		value    string
+		expected []float64
+	}{
+		{"", defaultRequestDurationBuckets},
+		{"0.5,1, 5,600", []float64{0.5, 1, 5, 600}},
+		{"1,1", defaultRequestDurationBuckets},
+		{"5,1", defaultRequestDurationBuckets},
+		{"-1,1", defaultRequestDurationBuckets},
+		{"1,Inf", defaultRequestDurationBuckets},
+		{"1,abc", defaultRequestDurationBuckets},
+	}
+	for i, testCase := range testCases {
+		got := parseRequestDurationBuckets(testCase.value)
+		if !reflect.DeepEqual(got, testCase.expected) {
+			t.Errorf("Test %d: expected %v, got %v", i+1, testCase.expected, got)
+		}
+	}
+}
+
+func TestMaxClientsPriorityRequests(t *testing.T) {
+	saved := globalHTTPStats
+	defer func() { globalHTTPStats = saved }()
+	globalHTTPStats = newHTTPStats()
+
+	pool := make(chan struct{}, 1)
+	globalAPIConfig.mu.Lock()
+	savedPool, savedPriorityPool, savedDeadline := globalAPIConfig.requestsPool, globalAPIConfig.priorityRequestsPool, globalAPIConfig.requestsDeadline
+	globalAPIConfig.requestsPool = pool
+	globalAPIConfig.priorityRequestsPool = make(chan struct{}, priorityRequestsMax(cap(pool)))
+	globalAPIConfig.requestsDeadline = 100 * time.Millisecond
+	globalAPIConfig.mu.Unlock()
+	defer func() {
+		globalAPIConfig.mu.Lock()
+		globalAPIConfig.requestsPool, globalAPIConfig.priorityRequestsPool = savedPool, savedPriorityPool
+		globalAPIConfig.requestsDeadline = savedDeadline
+		globalAPIConfig.mu.Unlock()
+	}()
+
+	serve := func(header string) *httptest.ResponseRecorder {
+		rec := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodPut, "/bucket/object", nil)
+		if header != "" {
+			req.Header.Set(header, "true")
+		}
+		collectAPIStats("putobject", maxClients(func(w http.ResponseWriter, r *http.Request) {
+			writeSuccessResponseHeadersOnly(w)
+		}))(rec, req)
+		return rec
+	}
+
+	// Saturate the regular requests pool.
+	pool <- struct{}{}
+	defer func() { <-pool }()
+	if rec := serve(""); rec.Code != http.StatusServiceUnavailable {
+		t.Errorf("expected regular requests to time out, got %d", rec.Code)
+	}
+	for _, header := range []string{xhttp.MinIOSourceReplicationRequest, xhttp.MinIOSourceProxyRequest} {
+		if rec := serve(header); rec.Code != http.StatusOK {
+			t.Errorf("expected %s requests to bypass the queue, got %d", header, rec.Code)
+		}
+	}
+}
@@ -0,0 +1,257 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+package cmd
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/minio/madmin-go"
+	"github.com/minio/minio/internal/auth"
+	iampolicy "github.com/minio/pkg/iam/policy"
+)
+
+// Version of the IAM export bundle format.
+const iamExportVersion = 1
+
+var (
+	errIAMExportSigningKey = errors.New("A signing key is required to export or import IAM")
+	errIAMExportSignature  = errors.New("IAM export bundle signature does not match")
+	errIAMExportVersion    = errors.New("IAM export bundle version is not supported")
+)
+
+// iamExportReq is the request body of the export and import
+// IAM admin APIs, the bundle is set only for imports.
+type iamExportReq struct {
+	SigningKey string           `json:"signingKey"`
+	Bundle     *iamExportBundle `json:"bundle,omitempty"`
+}
+
+// iamExportBundle is the IAM state of a cluster, signed with
+// HMAC-SHA256 using the signing key given at export, so that
+// the bundle can only be imported with the same key.
+type iamExportBundle struct {
+	Version    int             `json:"version"`
+	ExportedAt time.Time       `json:"exportedAt"`
+	Content    json.RawMessage `json:"content"`
+	Signature  string          `json:"signature"`
+}
+
+// iamExportContent holds the exported IAM entities, temporary
+// credentials are not exported as they expire on their own.
+type iamExportContent struct {
+	Policies        map[string]iampolicy.Policy `json:"policies,omitempty"`
+	Users           map[string]auth.Credentials `json:"users,omitempty"`
+	Groups          map[string]GroupInfo        `json:"groups,omitempty"`
+	ServiceAccounts map[string]auth.Credentials `json:"serviceAccounts,omitempty"`
+
+	UserPolicyMappings  map[string]string `json:"userPolicyMappings,omitempty"`
+	GroupPolicyMappings map[string]string `json:"groupPolicyMappings,omitempty"`
+	// Policy mappings of STS users, e.g. LDAP user DNs.
+	STSPolicyMappings map[string]string `json:"stsPolicyMappings,omitempty"`
+}
+
+// IAMImportResult reports the IAM entities imported from a bundle.
+type IAMImportResult struct {
+	Policies        int `json:"policies"`
+	Users           int `json:"users"`
+	Groups          int `json:"groups"`
+	ServiceAccounts int `json:"serviceAccounts"`
+	PolicyMappings  int `json:"policyMappings"`
+	// Existing service accounts are left unchanged.
+	SkippedServiceAccounts []string `json:"skippedServiceAccounts,omitempty"`
+}
+
+func signIAMExport(content []byte, signingKey string) string {
+	mac := hmac.New(sha256.New, []byte(signingKey))
+	mac.Write(content)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// newIAMExportBundle returns the bundle of content signed with signingKey.
+func newIAMExportBundle(content iamExportContent, signingKey string) (iamExportBundle, error) {
+	if signingKey == "" {
+		return iamExportBundle{}, errIAMExportSigningKey
+	}
+	buf, err := json.Marshal(content)
+	if err != nil {
+		return iamExportBundle{}, err
+	}
+	return iamExportBundle{
+		Version:    iamExportVersion,
+		ExportedAt: UTCNow(),
+		Content:    buf,
+		Signature:  signIAMExport(buf, signingKey),
+	}, nil
+}
+
+// verify checks the signature of the bundle and returns its content.
+func (b iamExportBundle) verify(signingKey string) (content iamExportContent, err error) {
+	if signingKey == "" {
+		return content, errIAMExportSigningKey
+	}
+	if b.Version != iamExportVersion {
+		return content, errIAMExportVersion
+	}
+	if !hmac.Equal([]byte(b.Signature), []byte(signIAMExport(b.Content, signingKey))) {
+		return content, errIAMExportSignature
+	}
+	err = json.Unmarshal(b.Content, &content)
+	return content, err
+}
+
+// exportIAM returns the IAM entities in the cache.
+func (store *IAMStoreSys) exportIAM() iamExportContent {
+	cache := store.rlock()
+	defer store.runlock()
+
+	content := iamExportContent{
+		Policies:            make(map[string]iampolicy.Policy, len(cache.iamPolicyDocsMap)),
+		Users:               make(map[string]auth.Credentials),
+		Groups:              make(map[string]GroupInfo, len(cache.iamGroupsMap)),
+		ServiceAccounts:     make(map[string]auth.Credentials),
+		UserPolicyMappings:  make(map[string]string),
+		GroupPolicyMappings: make(map[string]string, len(cache.iamGroupPolicyMap)),
+		STSPolicyMappings:   make(map[string]string),
+	}
+	for name, doc := range cache.iamPolicyDocsMap {
+		content.Policies[name] = doc.Policy
+	}
+	for accessKey, cred := range cache.iamUsersMap {
+		switch {
+		case cred.IsTemp():
+		case cred.IsServiceAccount():
+			content.ServiceAccounts[accessKey] = cred
+		default:
+			content.Users[accessKey] = cred
+		}
+	}
+	for group, gi := range cache.iamGroupsMap {
+		content.Groups[group] = gi
+	}
+	for name, mp := range cache.iamUserPolicyMap {
+		cred, ok := cache.iamUsersMap[name]
+		switch {
+		case ok && cred.IsTemp():
+		case ok && !cred.IsServiceAccount():
+			content.UserPolicyMappings[name] = mp.Policies
+		case !ok && store.getUsersSysType() == LDAPUsersSysType:
+			content.STSPolicyMappings[name] = mp.Policies
+		}
+	}
+	for group, mp := range cache.iamGroupPolicyMap {
+		content.GroupPolicyMappings[group] = mp.Policies
+	}
+	return content
+}
+
+// ExportIAM - returns the IAM state of the cluster.
+func (sys *IAMSys) ExportIAM(ctx context.Context) (iamExportContent, error) {
+	if !sys.Initialized() {
+		return iamExportContent{}, errServerNotInitialized
+	}
+
+	select {
+	case <-sys.configLoaded:
+		return sys.store.exportIAM(), nil
+	case <-ctx.Done():
+		return iamExportContent{}, ctx.Err()
+	}
+}
+
+// ImportIAM - creates or updates the IAM entities of content,
+// peers are notified of every change as for the individual APIs.
+func (sys *IAMSys) ImportIAM(ctx context.Context, content iamExportContent) (res IAMImportResult, err error) {
+	if !sys.Initialized() {
+		return res, errServerNotInitialized
+	}
+
+	// Policies first, users, groups and service accounts
+	// next, the policy mappings refer to all of them.
+	for name, policy := range content.Policies {
+		if err = sys.SetPolicy(ctx, name, policy); err != nil {
+			return res, err
+		}
+		res.Policies++
+	}
+
+	if sys.usersSysType == MinIOUsersSysType {
+		for accessKey, cred := range content.Users {
+			status := madmin.AccountEnabled
+			if cred.Status == auth.AccountOff {
+				status = madmin.AccountDisabled
+			}
+			if err = sys.CreateUser(ctx, accessKey, madmin.AddOrUpdateUserReq{
+				SecretKey: cred.SecretKey,
+				Status:    status,
+			}); err != nil {
+				return res, err
+			}
+			res.Users++
+		}
+
+		for group, gi := range content.Groups {
+			if err = sys.AddUsersToGroup(ctx, group, gi.Members); err != nil {
+				return res, err
+			}
+			if err = sys.SetGroupStatus(ctx, group, gi.Status != statusDisabled); err != nil {
+				return res, err
+			}
+			res.Groups++
+		}
+	}
+
+	for accessKey, cred := range content.ServiceAccounts {
+		if _, ok := sys.store.GetUser(accessKey); ok {
+			res.SkippedServiceAccounts = append(res.SkippedServiceAccounts, accessKey)
+			continue
+		}
+		if err = sys.store.AddServiceAccount(ctx, cred); err != nil {
+			return res, err
+		}
+		sys.notifyForServiceAccount(ctx, accessKey)
+		res.ServiceAccounts++
+	}
+
+	for name, policy := range content.UserPolicyMappings {
+		if err = sys.PolicyDBSet(ctx, name, policy, false); err != nil {
+			return res, err
+		}
+		res.PolicyMappings++
+	}
+	for group, policy := range content.GroupPolicyMappings {
+		if err = sys.PolicyDBSet(ctx, group, policy, true); err != nil {
+			return res, err
+		}
+		res.PolicyMappings++
+	}
+	if sys.usersSysType == LDAPUsersSysType {
+		for name, policy := range content.STSPolicyMappings {
+			if err = sys.PolicyDBSet(ctx, name, policy, false); err != nil {
+				return res, err
+			}
+			res.PolicyMappings++
+		}
+	}
+
+	return res, nil
+}
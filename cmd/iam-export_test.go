@@ -0,0 +1,136 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+package cmd
+
+import (
+	"context"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/minio/madmin-go"
+)
+
+func TestIAMExportBundle(t *testing.T) {
+	content := iamExportContent{
+		UserPolicyMappings: map[string]string{"myuser1": "readwrite"},
+	}
+	bundle, err := newIAMExportBundle(content, "signing-key")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err = newIAMExportBundle(content, ""); err != errIAMExportSigningKey {
+		t.Fatalf("Expected error %v, found %v", errIAMExportSigningKey, err)
+	}
+
+	got, err := bundle.verify("signing-key")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.UserPolicyMappings["myuser1"] != "readwrite" {
+		t.Fatalf("Unexpected content %v", got)
+	}
+	if _, err = bundle.verify("other-key"); err != errIAMExportSignature {
+		t.Fatalf("Expected error %v, found %v", errIAMExportSignature, err)
+	}
+
+	bundle.Content = []byte(`{"userPolicyMappings":{"myuser1":"consoleAdmin"}}`)
+	if _, err = bundle.verify("signing-key"); err != errIAMExportSignature {
+		t.Fatalf("Expected error %v for modified content, found %v", errIAMExportSignature, err)
+	}
+}
+
+func TestIAMExportImport(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	objLayer, fsDir, err := prepareFS()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(fsDir)
+	if err = newTestConfig(globalMinioDefaultRegion, objLayer); err != nil {
+		t.Fatalf("unable initialize config file, %s", err)
+	}
+
+	initAllTestSubsystems(t)
+
+	initConfigSubsystem(ctx, objLayer)
+
+	globalIAMSys.Init(ctx, objLayer, globalEtcdClient, 2*time.Second)
+
+	if err = globalIAMSys.CreateUser(ctx, "myuser1", madmin.AddOrUpdateUserReq{
+		SecretKey: "mypassword1",
+		Status:    madmin.AccountEnabled,
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if err = globalIAMSys.AddUsersToGroup(ctx, "mygroup", []string{"myuser1"}); err != nil {
+		t.Fatal(err)
+	}
+	if err = globalIAMSys.PolicyDBSet(ctx, "mygroup", "readwrite", true); err != nil {
+		t.Fatal(err)
+	}
+	svc, err := globalIAMSys.NewServiceAccount(ctx, "myuser1", nil, newServiceAccountOpts{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	content, err := globalIAMSys.ExportIAM(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := content.Users["myuser1"]; !ok {
+		t.Fatalf("Expected user myuser1 to be exported, found %v", content.Users)
+	}
+	if _, ok := content.ServiceAccounts[svc.AccessKey]; !ok {
+		t.Fatalf("Expected service account %s to be exported", svc.AccessKey)
+	}
+	if content.GroupPolicyMappings["mygroup"] != "readwrite" {
+		t.Fatalf("Expected group policy mapping to be exported, found %v", content.GroupPolicyMappings)
+	}
+
+	// Deleting the user deletes its service accounts as well.
+	if err = globalIAMSys.DeleteUser(ctx, "myuser1", false); err != nil {
+		t.Fatal(err)
+	}
+
+	res, err := globalIAMSys.ImportIAM(ctx, content)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.Users != 1 || res.ServiceAccounts != 1 || res.Groups != 1 {
+		t.Fatalf("Unexpected import result %+v", res)
+	}
+
+	cred, ok := globalIAMSys.GetUser(ctx, svc.AccessKey)
+	if !ok || cred.SecretKey != svc.SecretKey || cred.ParentUser != "myuser1" {
+		t.Fatalf("Expected service account %s to be imported, found %v", svc.AccessKey, cred)
+	}
+	if _, ok = globalIAMSys.GetUser(ctx, "myuser1"); !ok {
+		t.Fatal("Expected user myuser1 to be imported")
+	}
+
+	// Importing again leaves existing service accounts unchanged.
+	res, err = globalIAMSys.ImportIAM(ctx, content)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(res.SkippedServiceAccounts) != 1 {
+		t.Fatalf("Expected the service account to be skipped, found %+v", res)
+	}
+}
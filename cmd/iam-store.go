@@ -74,6 +74,9 @@ const (
 	// IAM format file
 	iamFormatFile = "format.json"
 
+	// IAM access key limits file
+	iamAccessKeyLimitsFile = "access-key-limits.json"
+
 	iamFormatVersion1 = 1
 )
 
@@ -258,6 +261,8 @@ type iamCache struct {
 	iamUserPolicyMap map[string]MappedPolicy
 	// map of group names to policy names
 	iamGroupPolicyMap map[string]MappedPolicy
+	// map of access keys to their limits
+	iamAccessKeyLimits map[string]AccessKeyLimits
 }
 
 func newIamCache() *iamCache {
@@ -268,6 +273,7 @@ func newIamCache() *iamCache {
 		iamUserGroupMemberships: map[string]set.StringSet{},
 		iamUserPolicyMap:        map[string]MappedPolicy{},
 		iamGroupPolicyMap:       map[string]MappedPolicy{},
+		iamAccessKeyLimits:      map[string]AccessKeyLimits{},
 	}
 }
 
@@ -487,6 +493,11 @@ func (store *IAMStoreSys) LoadIAMCache(ctx context.Context) error {
 		newCache.buildUserGroupMemberships()
 	}
 
+	// load access key limits
+	if err := store.loadAccessKeyLimits(ctx, newCache.iamAccessKeyLimits); err != nil {
+		return err
+	}
+
 	cache := store.lock()
 	defer store.unlock()
 
@@ -507,6 +518,7 @@ func (store *IAMStoreSys) LoadIAMCache(ctx context.Context) error {
 		cache.iamUserGroupMemberships = newCache.iamUserGroupMemberships
 		cache.iamUserPolicyMap = newCache.iamUserPolicyMap
 		cache.iamUsersMap = newCache.iamUsersMap
+		cache.iamAccessKeyLimits = newCache.iamAccessKeyLimits
 		cache.updatedAt = time.Now()
 	}
 
@@ -1774,6 +1786,12 @@ func (store *IAMStoreSys) UpdateServiceAccount(ctx context.Context, accessKey st
 		m[iamPolicyClaimNameSA()] = embeddedPolicyType
 	}
 
+	if opts.expiration != nil {
+		if err := setServiceAccountExpiry(m, *opts.expiration); err != nil {
+			return err
+		}
+	}
+
 	cr.SessionToken, err = auth.JWTSignWithAccessKey(accessKey, m, cr.SecretKey)
 	if err != nil {
 		return err
@@ -1790,6 +1808,146 @@ func (store *IAMStoreSys) UpdateServiceAccount(ctx context.Context, accessKey st
 	return nil
 }
 
+// RotateServiceAccount - replaces the secret key of a service account
+// on storage, all of its claims are signed again with the new secret.
+func (store *IAMStoreSys) RotateServiceAccount(ctx context.Context, accessKey, secretKey string) (auth.Credentials, error) {
+	cache := store.lock()
+	defer store.unlock()
+
+	cr, ok := cache.iamUsersMap[accessKey]
+	if !ok || !cr.IsServiceAccount() {
+		return auth.Credentials{}, errNoSuchServiceAccount
+	}
+
+	if !auth.IsSecretKeyValid(secretKey) {
+		return auth.Credentials{}, auth.ErrInvalidSecretKeyLength
+	}
+
+	m, err := getClaimsFromTokenWithSecret(cr.SessionToken, cr.SecretKey)
+	if err != nil {
+		return auth.Credentials{}, fmt.Errorf("unable to get svc acc claims: %v", err)
+	}
+	delete(m, sessionPolicyNameExtracted)
+
+	cr.SecretKey = secretKey
+	cr.SessionToken, err = auth.JWTSignWithAccessKey(accessKey, m, cr.SecretKey)
+	if err != nil {
+		return auth.Credentials{}, err
+	}
+
+	u := newUserIdentity(cr)
+	if err := store.saveUserIdentity(ctx, u.Credentials.AccessKey, svcUser, u); err != nil {
+		return auth.Credentials{}, err
+	}
+
+	cache.iamUsersMap[u.Credentials.AccessKey] = u.Credentials
+	cache.updatedAt = time.Now()
+
+	return u.Credentials, nil
+}
+
+// loadAccessKeyLimits - loads the limits of all access keys into m.
+func (store *IAMStoreSys) loadAccessKeyLimits(ctx context.Context, m map[string]AccessKeyLimits) error {
+	var cfg accessKeyLimitsConfig
+	if err := store.loadIAMConfig(ctx, &cfg, getAccessKeyLimitsPath()); err != nil {
+		if err == errConfigNotFound {
+			return nil
+		}
+		return err
+	}
+	for accessKey, limits := range cfg.Limits {
+		m[accessKey] = limits
+	}
+	return nil
+}
+
+// AccessKeyLimitsNotificationHandler - reloads the limits of all access
+// keys from storage and returns them.
+func (store *IAMStoreSys) AccessKeyLimitsNotificationHandler(ctx context.Context) (map[string]AccessKeyLimits, error) {
+	m := map[string]AccessKeyLimits{}
+	if err := store.loadAccessKeyLimits(ctx, m); err != nil {
+		return nil, err
+	}
+
+	cache := store.lock()
+	defer store.unlock()
+
+	cache.iamAccessKeyLimits = m
+	cache.updatedAt = time.Now()
+	return m, nil
+}
+
+// SetAccessKeyLimits - sets the limits of a user or a service account on
+// storage, empty limits remove them. The limits of all access keys are
+// returned.
+func (store *IAMStoreSys) SetAccessKeyLimits(ctx context.Context, accessKey string, limits AccessKeyLimits) (map[string]AccessKeyLimits, error) {
+	cache := store.lock()
+	defer store.unlock()
+
+	cred, ok := cache.iamUsersMap[accessKey]
+	if !ok {
+		return nil, errNoSuchUser
+	}
+	if cred.IsTemp() {
+		return nil, errIAMActionNotAllowed
+	}
+
+	// The cached map is replaced, never modified, so that
+	// it can be shared with the callers.
+	m := make(map[string]AccessKeyLimits, len(cache.iamAccessKeyLimits)+1)
+	for k, v := range cache.iamAccessKeyLimits {
+		m[k] = v
+	}
+	if limits.IsEmpty() {
+		delete(m, accessKey)
+	} else {
+		m[accessKey] = limits
+	}
+
+	if err := store.saveIAMConfig(ctx, accessKeyLimitsConfig{Version: 1, Limits: m}, getAccessKeyLimitsPath()); err != nil {
+		return nil, err
+	}
+
+	cache.iamAccessKeyLimits = m
+	cache.updatedAt = time.Now()
+	return m, nil
+}
+
+// GetAccessKeyLimits - returns the limits of a user or a service account.
+func (store *IAMStoreSys) GetAccessKeyLimits(accessKey string) (AccessKeyLimits, error) {
+	cache := store.rlock()
+	defer store.runlock()
+
+	if _, ok := cache.iamUsersMap[accessKey]; !ok {
+		return AccessKeyLimits{}, errNoSuchUser
+	}
+	return cache.iamAccessKeyLimits[accessKey], nil
+}
+
+// AccessKeyLimits - returns the limits of all access keys from the cache.
+func (store *IAMStoreSys) AccessKeyLimits() map[string]AccessKeyLimits {
+	cache := store.rlock()
+	defer store.runlock()
+
+	return cache.iamAccessKeyLimits
+}
+
+// ListAccessKeys - lists the credentials of all users and service
+// accounts, temporary accounts are not listed.
+func (store *IAMStoreSys) ListAccessKeys() []auth.Credentials {
+	cache := store.rlock()
+	defer store.runlock()
+
+	creds := make([]auth.Credentials, 0, len(cache.iamUsersMap))
+	for _, v := range cache.iamUsersMap {
+		if v.IsTemp() {
+			continue
+		}
+		creds = append(creds, v)
+	}
+	return creds
+}
+
 // ListTempAccounts - lists only temporary accounts from the cache.
 func (store *IAMStoreSys) ListTempAccounts(ctx context.Context, accessKey string) ([]auth.Credentials, error) {
 	cache := store.rlock()
@@ -149,7 +149,15 @@ func (sys *IAMSys) LoadUser(ctx context.Context, objAPI ObjectLayer, accessKey s
 		return errServerNotInitialized
 	}
 
-	return sys.store.UserNotificationHandler(ctx, accessKey, userType)
+	if err := sys.store.UserNotificationHandler(ctx, accessKey, userType); err != nil {
+		return err
+	}
+
+	if userType == stsUser {
+		return nil
+	}
+	// Limits of users are changed along with a user notification.
+	return sys.loadAccessKeyLimits(ctx)
 }
 
 // LoadServiceAccount - reloads a specific service account from backend disks or etcd.
@@ -158,7 +166,24 @@ func (sys *IAMSys) LoadServiceAccount(ctx context.Context, accessKey string) err
 		return errServerNotInitialized
 	}
 
-	return sys.store.UserNotificationHandler(ctx, accessKey, svcUser)
+	if err := sys.store.UserNotificationHandler(ctx, accessKey, svcUser); err != nil {
+		return err
+	}
+
+	// Limits of service accounts are changed along with a
+	// service account notification.
+	return sys.loadAccessKeyLimits(ctx)
+}
+
+// loadAccessKeyLimits - reloads the access key limits from backend
+// disks or etcd and applies them.
+func (sys *IAMSys) loadAccessKeyLimits(ctx context.Context) error {
+	limits, err := sys.store.AccessKeyLimitsNotificationHandler(ctx)
+	if err != nil {
+		return err
+	}
+	globalAccessKeyThrottles.replace(limits)
+	return nil
 }
 
 // Perform IAM configuration migration.
@@ -199,6 +224,20 @@ func (sys *IAMSys) Initialized() bool {
 	return sys.store != nil
 }
 
+// loaded checks if the IAM data has been loaded at least once,
+// unlike Initialized it does not wait for Init to finish.
+func (sys *IAMSys) loaded() bool {
+	if sys == nil {
+		return false
+	}
+	select {
+	case <-sys.configLoaded:
+		return true
+	default:
+		return false
+	}
+}
+
 // Load - loads all credentials, policies and policy mappings.
 func (sys *IAMSys) Load(ctx context.Context) error {
 	loadStartTime := time.Now()
@@ -213,6 +252,8 @@ func (sys *IAMSys) Load(ctx context.Context) error {
 	atomic.StoreUint64(&sys.LastRefreshTimeUnixNano, uint64(loadStartTime.Add(loadDuration).UnixNano()))
 	atomic.AddUint64(&sys.TotalRefreshSuccesses, 1)
 
+	globalAccessKeyThrottles.replace(sys.store.AccessKeyLimits())
+
 	select {
 	case <-sys.configLoaded:
 	default:
@@ -473,6 +514,7 @@ func (sys *IAMSys) loadWatchedEvent(ctx context.Context, event iamWatchEvent) (e
 	policyDBUsersPrefix := strings.HasPrefix(event.keyPath, iamConfigPolicyDBUsersPrefix)
 	policyDBSTSUsersPrefix := strings.HasPrefix(event.keyPath, iamConfigPolicyDBSTSUsersPrefix)
 	policyDBGroupsPrefix := strings.HasPrefix(event.keyPath, iamConfigPolicyDBGroupsPrefix)
+	accessKeyLimits := event.keyPath == getAccessKeyLimitsPath()
 
 	ctx, cancel := context.WithTimeout(ctx, defaultContextTimeout)
 	defer cancel()
@@ -505,6 +547,8 @@ func (sys *IAMSys) loadWatchedEvent(ctx context.Context, event iamWatchEvent) (e
 		policyMapFile := strings.TrimPrefix(event.keyPath, iamConfigPolicyDBGroupsPrefix)
 		user := strings.TrimSuffix(policyMapFile, ".json")
 		err = sys.store.PolicyMappingNotificationHandler(ctx, user, true, regUser)
+	case accessKeyLimits:
+		err = sys.loadAccessKeyLimits(ctx)
 	}
 	return err
 }
@@ -869,10 +913,46 @@ type newServiceAccountOpts struct {
 	sessionPolicy *iampolicy.Policy
 	accessKey     string
 	secretKey     string
+	expiration    *time.Time
 
 	claims map[string]interface{}
 }
 
+// serviceAccountExpiry returns the expiry set in the claims
+// of a service account, if any.
+func serviceAccountExpiry(claims map[string]interface{}) (time.Time, bool) {
+	var sec int64
+	switch v := claims[svcExpiryClaim].(type) {
+	case int64:
+		sec = v
+	case float64:
+		sec = int64(v)
+	case json.Number:
+		n, err := v.Int64()
+		if err != nil {
+			return time.Time{}, false
+		}
+		sec = n
+	default:
+		return time.Time{}, false
+	}
+	return time.Unix(sec, 0).UTC(), true
+}
+
+// setServiceAccountExpiry sets the expiry of a service account in
+// its claims, a zero expiration removes any previously set expiry.
+func setServiceAccountExpiry(claims map[string]interface{}, expiration time.Time) error {
+	if expiration.IsZero() {
+		delete(claims, svcExpiryClaim)
+		return nil
+	}
+	if !expiration.After(time.Now()) {
+		return errInvalidServiceAccountExpiry
+	}
+	claims[svcExpiryClaim] = expiration.Unix()
+	return nil
+}
+
 // NewServiceAccount - create a new service account
 func (sys *IAMSys) NewServiceAccount(ctx context.Context, parentUser string, groups []string, opts newServiceAccountOpts) (auth.Credentials, error) {
 	if !sys.Initialized() {
@@ -914,6 +994,12 @@ func (sys *IAMSys) NewServiceAccount(ctx context.Context, parentUser string, gro
 		m[iamPolicyClaimNameSA()] = inheritedPolicyType
 	}
 
+	if opts.expiration != nil {
+		if err := setServiceAccountExpiry(m, *opts.expiration); err != nil {
+			return auth.Credentials{}, err
+		}
+	}
+
 	// Add all the necessary claims for the service accounts.
 	for k, v := range opts.claims {
 		_, ok := m[k]
@@ -953,6 +1039,7 @@ type updateServiceAccountOpts struct {
 	sessionPolicy *iampolicy.Policy
 	secretKey     string
 	status        string
+	expiration    *time.Time
 }
 
 // UpdateServiceAccount - edit a service account
@@ -970,6 +1057,100 @@ func (sys *IAMSys) UpdateServiceAccount(ctx context.Context, accessKey string, o
 	return nil
 }
 
+// RotateServiceAccount - generates a new secret key for a service
+// account, the access key, policy and expiry are kept unchanged.
+func (sys *IAMSys) RotateServiceAccount(ctx context.Context, accessKey string) (auth.Credentials, error) {
+	if !sys.Initialized() {
+		return auth.Credentials{}, errServerNotInitialized
+	}
+
+	_, secretKey, err := auth.GenerateCredentials()
+	if err != nil {
+		return auth.Credentials{}, err
+	}
+
+	cred, err := sys.store.RotateServiceAccount(ctx, accessKey, secretKey)
+	if err != nil {
+		return auth.Credentials{}, err
+	}
+
+	sys.notifyForServiceAccount(ctx, accessKey)
+	return cred, nil
+}
+
+// GetServiceAccountExpiry - returns the expiry of a service account,
+// the returned time is zero if the service account does not expire.
+func (sys *IAMSys) GetServiceAccountExpiry(ctx context.Context, accessKey string) (time.Time, error) {
+	if !sys.Initialized() {
+		return time.Time{}, errServerNotInitialized
+	}
+
+	sa, ok := sys.store.GetUser(accessKey)
+	if !ok || !sa.IsServiceAccount() {
+		return time.Time{}, errNoSuchServiceAccount
+	}
+
+	claims, err := getClaimsFromTokenWithSecret(sa.SessionToken, sa.SecretKey)
+	if err != nil {
+		return time.Time{}, err
+	}
+	expiration, _ := serviceAccountExpiry(claims)
+	return expiration, nil
+}
+
+// SetAccessKeyLimits - sets the request rate and bandwidth limits of
+// a user or a service account, empty limits remove them.
+func (sys *IAMSys) SetAccessKeyLimits(ctx context.Context, accessKey string, limits AccessKeyLimits) error {
+	if !sys.Initialized() {
+		return errServerNotInitialized
+	}
+
+	if err := limits.Validate(); err != nil {
+		return err
+	}
+
+	cred, ok := sys.store.GetUser(accessKey)
+	if !ok {
+		return errNoSuchUser
+	}
+
+	all, err := sys.store.SetAccessKeyLimits(ctx, accessKey, limits)
+	if err != nil {
+		return err
+	}
+	globalAccessKeyThrottles.replace(all)
+
+	if cred.IsServiceAccount() {
+		sys.notifyForServiceAccount(ctx, accessKey)
+	} else {
+		sys.notifyForUser(ctx, accessKey, false)
+	}
+	return nil
+}
+
+// GetAccessKeyLimits - returns the limits of a user or a service account.
+func (sys *IAMSys) GetAccessKeyLimits(accessKey string) (AccessKeyLimits, error) {
+	if !sys.Initialized() {
+		return AccessKeyLimits{}, errServerNotInitialized
+	}
+
+	return sys.store.GetAccessKeyLimits(accessKey)
+}
+
+// ListAccessKeys - lists the credentials of all users and service accounts.
+func (sys *IAMSys) ListAccessKeys(ctx context.Context) ([]auth.Credentials, error) {
+	if !sys.Initialized() {
+		return nil, errServerNotInitialized
+	}
+
+	select {
+	case <-sys.configLoaded:
+		return sys.store.ListAccessKeys(), nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
 // ListServiceAccounts - lists all services accounts associated to a specific user
 func (sys *IAMSys) ListServiceAccounts(ctx context.Context, accessKey string) ([]auth.Credentials, error) {
 	if !sys.Initialized() {
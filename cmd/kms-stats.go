@@ -0,0 +1,62 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"sync"
+	"time"
+)
+
+// kmsStats counts the requests sent by this node to each KMS endpoint.
+type kmsStats struct {
+	mu       sync.Mutex
+	requests map[string]uint64
+	errors   map[string]uint64
+}
+
+var globalKMSStats = kmsStats{
+	requests: make(map[string]uint64),
+	errors:   make(map[string]uint64),
+}
+
+// observe records a request sent to a KMS endpoint.
+func (s *kmsStats) observe(endpoint string, latency time.Duration, err error) {
+	kmsRequestDuration.WithLabelValues(endpoint).Observe(latency.Seconds())
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.requests[endpoint]++
+	if err != nil {
+		s.errors[endpoint]++
+	}
+}
+
+// load returns the requests and the failed requests per endpoint.
+func (s *kmsStats) load() (requests, errors map[string]uint64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	requests = make(map[string]uint64, len(s.requests))
+	for endpoint, n := range s.requests {
+		requests[endpoint] = n
+	}
+	errors = make(map[string]uint64, len(s.errors))
+	for endpoint, n := range s.errors {
+		errors[endpoint] = n
+	}
+	return requests, errors
+}
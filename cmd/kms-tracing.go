@@ -0,0 +1,65 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"context"
+
+	"github.com/minio/minio/internal/kms"
+	"github.com/minio/minio/internal/tracing"
+)
+
+// tracedKMS records the operations of a KMS as
+// spans of the request traced by ctx.
+type tracedKMS struct {
+	kms.KMS
+	ctx context.Context
+}
+
+// kmsWithTracing returns a KMS recording its operations as part
+// of the trace of ctx, or KMS itself if ctx is not traced.
+func kmsWithTracing(ctx context.Context, KMS kms.KMS) kms.KMS {
+	if KMS == nil || tracing.FromContext(ctx) == nil {
+		return KMS
+	}
+	return tracedKMS{KMS: KMS, ctx: ctx}
+}
+
+func (k tracedKMS) GenerateKey(keyID string, cryptoCtx kms.Context) (kms.DEK, error) {
+	_, span := tracing.Start(k.ctx, "kms.GenerateKey", tracing.KindClient)
+	span.SetAttributes("kms.key_id", keyID)
+	key, err := k.KMS.GenerateKey(keyID, cryptoCtx)
+	span.Finish(err)
+	return key, err
+}
+
+func (k tracedKMS) DecryptKey(keyID string, ciphertext []byte, cryptoCtx kms.Context) ([]byte, error) {
+	_, span := tracing.Start(k.ctx, "kms.DecryptKey", tracing.KindClient)
+	span.SetAttributes("kms.key_id", keyID)
+	key, err := k.KMS.DecryptKey(keyID, ciphertext, cryptoCtx)
+	span.Finish(err)
+	return key, err
+}
+
+func (k tracedKMS) DecryptAll(ctx context.Context, keyID string, ciphertexts [][]byte, contexts []kms.Context) ([][]byte, error) {
+	_, span := tracing.Start(k.ctx, "kms.DecryptAll", tracing.KindClient)
+	span.SetAttributes("kms.key_id", keyID)
+	keys, err := k.KMS.DecryptAll(ctx, keyID, ciphertexts, contexts)
+	span.Finish(err)
+	return keys, err
+}
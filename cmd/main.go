@@ -24,6 +24,7 @@ import (
 	"sort"
 
 	"github.com/minio/cli"
+	"github.com/minio/minio/internal/logger"
 	"github.com/minio/pkg/console"
 	"github.com/minio/pkg/trie"
 	"github.com/minio/pkg/words"
@@ -55,6 +56,12 @@ var GlobalFlags = []cli.Flag{
 		Name:  "json",
 		Usage: "output logs in JSON format",
 	},
+	cli.StringFlag{
+		Name:   "log-format",
+		Value:  logger.FormatText,
+		Usage:  "output logs in 'text', 'json', 'logfmt' or 'compact' format",
+		EnvVar: "MINIO_LOG_FORMAT",
+	},
 	// Deprecated flag, so its hidden now, existing deployments will keep working.
 	cli.BoolFlag{
 		Name:   "compat",
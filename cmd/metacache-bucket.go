@@ -39,6 +39,10 @@ type bucketMetacache struct {
 	caches map[string]metacache
 	// cache ids indexed by root paths
 	cachesRoot map[string][]string `msg:"-"`
+	// ids of caches which must not be reused
+	dirty map[string]struct{} `msg:"-"`
+	// variants of caches saved for reuse indexed by id
+	variants map[string]string `msg:"-"`
 
 	// Internal state
 	mu      sync.RWMutex `msg:"-"`
@@ -63,6 +67,8 @@ func newBucketMetacache(bucket string, cleanup bool) *bucketMetacache {
 		bucket:     bucket,
 		caches:     make(map[string]metacache, 10),
 		cachesRoot: make(map[string][]string, 10),
+		dirty:      make(map[string]struct{}),
+		variants:   make(map[string]string),
 	}
 }
 
@@ -111,6 +117,9 @@ func (b *bucketMetacache) findCache(o listPathOptions) metacache {
 	best := o.newMetacache()
 	b.caches[o.ID] = best
 	b.cachesRoot[best.root] = append(b.cachesRoot[best.root], best.id)
+	if globalListCacheReuse {
+		b.variants[best.id] = o.metacacheVariant()
+	}
 	b.updated = true
 	b.debugf("returning new cache %s, bucket: %v", best.id, best.bucket)
 	return best
@@ -126,7 +135,7 @@ func (b *bucketMetacache) cleanup() {
 	caches, _ := b.cloneCaches()
 
 	for id, cache := range caches {
-		if !cache.worthKeeping() {
+		if !cache.worthKeeping() && !b.reusable(cache) {
 			b.debugf("cache %s not worth keeping", id)
 			remove[id] = struct{}{}
 			continue
@@ -229,6 +238,8 @@ func (b *bucketMetacache) deleteAll() {
 	ez.renameAll(ctx, minioMetaBucket, metacachePrefixForID(b.bucket, slashSeparator))
 	b.caches = make(map[string]metacache, 10)
 	b.cachesRoot = make(map[string][]string, 10)
+	b.dirty = make(map[string]struct{})
+	b.variants = make(map[string]string)
 }
 
 // deleteCache will delete a specific cache and all files related to it across the cluster.
@@ -246,6 +257,8 @@ func (b *bucketMetacache) deleteCache(id string) {
 		}
 		b.cachesRoot[c.root] = list
 		delete(b.caches, id)
+		delete(b.dirty, id)
+		delete(b.variants, id)
 		b.updated = true
 	}
 	b.mu.Unlock()
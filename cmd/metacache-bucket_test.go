@@ -20,6 +20,7 @@ package cmd
 import (
 	"fmt"
 	"testing"
+	"time"
 )
 
 func Benchmark_bucketMetacache_findCache(b *testing.B) {
@@ -66,3 +67,51 @@ func Benchmark_bucketMetacache_findCache(b *testing.B) {
 		})
 	}
 }
+
+func Test_bucketMetacache_reuse(t *testing.T) {
+	defer func(reuse bool) { globalListCacheReuse = reuse }(globalListCacheReuse)
+	globalListCacheReuse = true
+
+	bm := newBucketMetacache("bucket", false)
+	o := listPathOptions{
+		ID:           mustGetUUID(),
+		Bucket:       "bucket",
+		BaseDir:      "prefix/",
+		Prefix:       "prefix/a",
+		FilterPrefix: "a",
+		Recursive:    true,
+		Separator:    slashSeparator,
+		Create:       true,
+	}
+	c := bm.findCache(o)
+	if _, ok := bm.findReusableCache(o); ok {
+		t.Fatal("unfinished cache must not be reused")
+	}
+
+	c.status = scanStateSuccess
+	c.ended = time.Now()
+	if _, err := bm.updateCacheEntry(c); err != nil {
+		t.Fatal(err)
+	}
+	other := o
+	other.FilterPrefix = "b"
+	if _, ok := bm.findReusableCache(other); ok {
+		t.Fatal("cache of another prefix must not be reused")
+	}
+	got, ok := bm.findReusableCache(o)
+	if !ok || got.id != o.ID {
+		t.Fatalf("expected cache %s to be reused, got %q", o.ID, got.id)
+	}
+
+	bm.invalidate([]string{"prefix/b/object", "other/a"})
+	if _, ok := bm.findReusableCache(o); !ok {
+		t.Fatal("update outside of the listing must not invalidate the cache")
+	}
+	bm.invalidate([]string{"prefix/a/object"})
+	if _, ok := bm.findReusableCache(o); ok {
+		t.Fatal("update within the listing must invalidate the cache")
+	}
+	if infos := bm.info("prefix/"); len(infos) != 1 || infos[0].Reusable || infos[0].Status != "success" {
+		t.Fatalf("unexpected cache info %+v", infos)
+	}
+}
@@ -0,0 +1,377 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/minio/minio/internal/logger"
+)
+
+// EnvListCacheReuse lets listings without a continuation reuse the
+// finished listing cache of the same bucket and prefix. Writes
+// invalidate the caches of the node serving them synchronously and
+// the caches of the other nodes within metacacheInvalidateInterval,
+// so a listing served from a cache of another node may miss the
+// writes of up to the last interval.
+const EnvListCacheReuse = "MINIO_LIST_CACHE_REUSE"
+
+const (
+	// Finished listings are kept for reuse until they have
+	// not been handed out for this long.
+	metacacheReuseMaxAge = time.Hour
+
+	// Interval at which namespace updates are sent to all the nodes.
+	metacacheInvalidateInterval = time.Second
+
+	// Updated objects of a bucket within one interval above
+	// which all the caches of the bucket are invalidated.
+	metacacheInvalidateMaxObjects = 1000
+)
+
+var (
+	// If finished listings should be reused, set by EnvListCacheReuse.
+	globalListCacheReuse bool
+
+	globalMetacacheInvalidator = &metacacheInvalidator{
+		pending: make(map[string][]string),
+	}
+)
+
+// metacacheInvalidator invalidates the local caches listing the
+// updated objects right away, and collects the updated objects to
+// periodically invalidate the caches listing them on the other nodes.
+type metacacheInvalidator struct {
+	once sync.Once
+	mu   sync.Mutex
+	// Updated objects by bucket, an empty list
+	// invalidates all the caches of the bucket.
+	pending map[string][]string
+}
+
+// add records an update of object, an empty object
+// or the slash separator stand for the whole bucket.
+func (m *metacacheInvalidator) add(bucket, object string) {
+	m.once.Do(func() {
+		go m.run(GlobalContext)
+	})
+
+	wholeBucket := object == "" || object == slashSeparator
+	if wholeBucket {
+		localMetacacheMgr.invalidate(map[string][]string{bucket: nil})
+	} else {
+		localMetacacheMgr.invalidate(map[string][]string{bucket: {object}})
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	objects, ok := m.pending[bucket]
+	switch {
+	case ok && len(objects) == 0:
+		// Whole bucket already.
+	case wholeBucket || len(objects) >= metacacheInvalidateMaxObjects:
+		m.pending[bucket] = nil
+	default:
+		m.pending[bucket] = append(objects, object)
+	}
+}
+
+func (m *metacacheInvalidator) run(ctx context.Context) {
+	t := time.NewTicker(metacacheInvalidateInterval)
+	defer t.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-t.C:
+		}
+
+		m.mu.Lock()
+		updates := m.pending
+		m.pending = make(map[string][]string)
+		m.mu.Unlock()
+		if len(updates) == 0 {
+			continue
+		}
+
+		for _, nerr := range globalNotificationSys.InvalidateMetacache(ctx, updates) {
+			if nerr.Err != nil {
+				logger.LogOnceIf(ctx, fmt.Errorf("unable to invalidate listing caches on %s: %w", nerr.Host, nerr.Err), "metacache-invalidate-"+nerr.Host.String())
+			}
+		}
+	}
+}
+
+func (s scanStatus) String() string {
+	switch s {
+	case scanStateStarted:
+		return "started"
+	case scanStateSuccess:
+		return "success"
+	case scanStateError:
+		return "error"
+	}
+	return "none"
+}
+
+// covers returns true if object is or may be part of the listing.
+func (m *metacache) covers(object string) bool {
+	return strings.HasPrefix(object, m.root+m.filter)
+}
+
+// metacacheVariant returns the options shaping the saved entries, a
+// cache is only reused by listings of the same variant.
+func (o listPathOptions) metacacheVariant() string {
+	return fmt.Sprintf("versioned:%t,deleted:%t", o.Versioned, o.InclDeleted)
+}
+
+// reusable returns true if the cache can be handed out to new listings.
+func (b *bucketMetacache) reusable(c metacache) bool {
+	if !globalListCacheReuse {
+		return false
+	}
+	if c.status != scanStateSuccess || !c.finished() || c.dataVersion != metacacheStreamVersion {
+		return false
+	}
+	if time.Since(c.lastHandout) > metacacheReuseMaxAge {
+		return false
+	}
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	_, dirty := b.dirty[c.id]
+	_, saved := b.variants[c.id]
+	return saved && !dirty
+}
+
+// findReusableCache returns the most recent finished cache matching the
+// listing options which has not been invalidated since it was started.
+func (b *bucketMetacache) findReusableCache(o listPathOptions) (metacache, bool) {
+	var best metacache
+	for _, id := range b.cacheIDsForRoot(o.BaseDir) {
+		b.mu.RLock()
+		c, ok := b.caches[id]
+		variant := b.variants[id]
+		b.mu.RUnlock()
+		if !ok || variant != o.metacacheVariant() || c.filter != o.FilterPrefix || c.recursive != o.Recursive || !b.reusable(c) {
+			continue
+		}
+		if c.started.After(best.started) {
+			best = c
+		}
+	}
+	if best.id == "" {
+		return best, false
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if c, ok := b.caches[best.id]; ok {
+		c.lastHandout = time.Now()
+		b.caches[best.id] = c
+		best = c
+	}
+	b.debugf("reusing cache %s, bucket: %v", best.id, best.bucket)
+	return best, true
+}
+
+func (b *bucketMetacache) cacheIDsForRoot(root string) []string {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	return append([]string(nil), b.cachesRoot[root]...)
+}
+
+// invalidate prevents the reuse of all caches covering any of
+// the objects, an empty list invalidates all the caches.
+func (b *bucketMetacache) invalidate(objects []string) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	for id, c := range b.caches {
+		if _, ok := b.dirty[id]; ok {
+			continue
+		}
+		covered := len(objects) == 0
+		for _, object := range objects {
+			if c.covers(object) {
+				covered = true
+				break
+			}
+		}
+		if covered {
+			b.dirty[id] = struct{}{}
+		}
+	}
+}
+
+// purge deletes all caches listing the prefix or below it.
+func (b *bucketMetacache) purge(prefix string) int {
+	caches, _ := b.cloneCaches()
+	var n int
+	for id, c := range caches {
+		if strings.HasPrefix(c.root+c.filter, prefix) {
+			b.deleteCache(id)
+			n++
+		}
+	}
+	return n
+}
+
+// info returns a description of all caches of the bucket
+// listing the prefix or below it.
+func (b *bucketMetacache) info(prefix string) []metacacheInfo {
+	caches, _ := b.cloneCaches()
+	infos := make([]metacacheInfo, 0, len(caches))
+	for _, c := range caches {
+		if !strings.HasPrefix(c.root+c.filter, prefix) {
+			continue
+		}
+		infos = append(infos, metacacheInfo{
+			Node:        globalLocalNodeName,
+			ID:          c.id,
+			Bucket:      c.bucket,
+			Prefix:      c.root + c.filter,
+			Recursive:   c.recursive,
+			Status:      c.status.String(),
+			Error:       c.error,
+			Started:     c.started,
+			Ended:       c.ended,
+			LastHandout: c.lastHandout,
+			Reusable:    b.reusable(c),
+		})
+	}
+	return infos
+}
+
+// invalidate prevents the reuse of the caches covering the updated objects.
+func (m *metacacheManager) invalidate(updates map[string][]string) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	for bucket, objects := range updates {
+		if b, ok := m.buckets[bucket]; ok {
+			b.invalidate(objects)
+		}
+	}
+}
+
+// selectBuckets returns the bucket caches of bucket, or all of them if bucket is empty.
+func (m *metacacheManager) selectBuckets(bucket string) []*bucketMetacache {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	var buckets []*bucketMetacache
+	for name, b := range m.buckets {
+		if bucket == "" || name == bucket {
+			buckets = append(buckets, b)
+		}
+	}
+	return buckets
+}
+
+// purge deletes the caches of bucket listing the prefix or below it.
+func (m *metacacheManager) purge(bucket, prefix string) int {
+	var n int
+	for _, b := range m.selectBuckets(bucket) {
+		n += b.purge(prefix)
+	}
+	return n
+}
+
+// info describes the caches of bucket listing the prefix or below it.
+func (m *metacacheManager) info(bucket, prefix string) []metacacheInfo {
+	var infos []metacacheInfo
+	for _, b := range m.selectBuckets(bucket) {
+		infos = append(infos, b.info(prefix)...)
+	}
+	return infos
+}
+
+// metacacheInfo describes a listing cache on a node.
+type metacacheInfo struct {
+	Node        string    `json:"node"`
+	ID          string    `json:"id"`
+	Bucket      string    `json:"bucket"`
+	Prefix      string    `json:"prefix"`
+	Recursive   bool      `json:"recursive"`
+	Status      string    `json:"status"`
+	Error       string    `json:"error,omitempty"`
+	Started     time.Time `json:"started"`
+	Ended       time.Time `json:"ended,omitempty"`
+	LastHandout time.Time `json:"lastHandout"`
+	Reusable    bool      `json:"reusable"`
+}
+
+// getMetacacheInfo describes the listing caches of all the nodes.
+func getMetacacheInfo(ctx context.Context, bucket, prefix string) []metacacheInfo {
+	infos := append(localMetacacheMgr.info(bucket, prefix), globalNotificationSys.GetMetacacheInfo(ctx, bucket, prefix)...)
+	sort.Slice(infos, func(i, j int) bool {
+		if infos[i].Bucket != infos[j].Bucket {
+			return infos[i].Bucket < infos[j].Bucket
+		}
+		if infos[i].Prefix != infos[j].Prefix {
+			return infos[i].Prefix < infos[j].Prefix
+		}
+		return infos[i].Started.Before(infos[j].Started)
+	})
+	return infos
+}
+
+// reuseMetacache points the listing options at a finished cache of the
+// same listing if there is one, the listing then streams from it.
+func (z *erasureServerPools) reuseMetacache(ctx context.Context, o *listPathOptions) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+	rpc := globalNotificationSys.restClientFromHash(pathJoin(o.Bucket, o.Prefix))
+	c, err := rpc.FindMetacacheListing(ctx, *o)
+	if err != nil || c.id == "" {
+		return
+	}
+
+	// The pool of the cache is not tracked, look for its first block.
+	r := *o
+	r.ID = c.id
+	for pool := range z.serverPools {
+		r.set = z.serverPools[pool].getHashedSetIndex(r.ID)
+		if len(z.serverPools) > 1 {
+			_, _, _, err = z.serverPools[pool].sets[r.set].getObjectFileInfo(ctx, minioMetaBucket, r.objectPath(0), ObjectOptions{}, false)
+			if err != nil {
+				continue
+			}
+		}
+		o.ID, o.pool, o.set = r.ID, pool, r.set
+		o.debugln("listPath: reusing cache", o.ID)
+		return
+	}
+}
+
+// reuseMetacache points the listing options at a finished cache of the
+// same listing if there is one, the listing then streams from it.
+func (es *erasureSingle) reuseMetacache(ctx context.Context, o *listPathOptions) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+	rpc := globalNotificationSys.restClientFromHash(pathJoin(o.Bucket, o.Prefix))
+	c, err := rpc.FindMetacacheListing(ctx, *o)
+	if err != nil || c.id == "" {
+		return
+	}
+	o.ID, o.pool, o.set = c.id, 0, 0
+	o.debugln("listPath: reusing cache", o.ID)
+}
@@ -104,6 +104,11 @@ func (z *erasureServerPools) listPath(ctx context.Context, o *listPathOptions) (
 		o.Create = false
 	}
 
+	// Continue from a finished listing of the same prefix if allowed.
+	if o.ID == "" && !o.Transient && globalListCacheReuse {
+		z.reuseMetacache(ctx, o)
+	}
+
 	// We have 2 cases:
 	// 1) Cold listing, just list.
 	// 2) Returning, but with no id. Start async listing.
@@ -312,6 +317,11 @@ func (es *erasureSingle) listPath(ctx context.Context, o *listPathOptions) (entr
 		o.Create = false
 	}
 
+	// Continue from a finished listing of the same prefix if allowed.
+	if o.ID == "" && !o.Transient && globalListCacheReuse {
+		es.reuseMetacache(ctx, o)
+	}
+
 	// We have 2 cases:
 	// 1) Cold listing, just list.
 	// 2) Returning, but with no id. Start async listing.
@@ -708,6 +718,10 @@ func (es *erasureSingle) listAndSave(ctx context.Context, o *listPathOptions) (e
 
 	// Do listing...
 	go func(o listPathOptions) {
+		if globalListCacheReuse {
+			// Save from the start so that new listings can reuse it.
+			o.Marker = ""
+		}
 		err := es.listMerged(listCtx, o, inCh)
 		if err != nil {
 			meta.setErr(err.Error())
@@ -783,6 +797,10 @@ func (z *erasureServerPools) listAndSave(ctx context.Context, o *listPathOptions
 
 	// Do listing...
 	go func(o listPathOptions) {
+		if globalListCacheReuse {
+			// Save from the start so that new listings can reuse it.
+			o.Marker = ""
+		}
 		err := z.listMerged(listCtx, o, inCh)
 		if err != nil {
 			meta.setErr(err.Error())
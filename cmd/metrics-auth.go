@@ -0,0 +1,176 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+
+	"github.com/minio/minio/internal/config"
+	"github.com/minio/minio/internal/config/metricsauth"
+	xhttp "github.com/minio/minio/internal/http"
+)
+
+var (
+	metricsAuthMu  sync.RWMutex
+	metricsAuthCfg metricsauth.Config
+)
+
+// lookupMetricsAuthConfig looks up the metrics auth config
+// and checks the metrics groups of its grants.
+func lookupMetricsAuthConfig(kvs config.KVS) (metricsauth.Config, error) {
+	cfg, err := metricsauth.LookupConfig(kvs)
+	if err != nil {
+		return cfg, err
+	}
+	for _, grants := range [][]metricsauth.Grant{cfg.Tokens, cfg.ClientCerts} {
+		for _, g := range grants {
+			for _, name := range g.Groups {
+				if !metricsGroupNames.Contains(name) {
+					return cfg, fmt.Errorf("'%s' value invalid: unknown metrics group '%s'", config.MetricsAuthSubSys, name)
+				}
+			}
+		}
+	}
+	return cfg, nil
+}
+
+func updateMetricsAuthParams(cfg metricsauth.Config) {
+	metricsAuthMu.Lock()
+	defer metricsAuthMu.Unlock()
+	metricsAuthCfg = cfg
+}
+
+func getMetricsAuthConfig() metricsauth.Config {
+	metricsAuthMu.RLock()
+	defer metricsAuthMu.RUnlock()
+	return metricsAuthCfg
+}
+
+// metricsGetConfigForClient returns the TLS config requesting the
+// client certificates issued by the CAs of the metrics auth config
+// for handshakes with the metrics server name while some are allowed
+// to scrape metrics, nil to keep the server config otherwise.
+func metricsGetConfigForClient(serverConfig *tls.Config) func(*tls.ClientHelloInfo) (*tls.Config, error) {
+	var (
+		mu           sync.Mutex
+		rootCAs      *x509.CertPool
+		clientConfig *tls.Config
+	)
+	return func(hello *tls.ClientHelloInfo) (*tls.Config, error) {
+		cfg := getMetricsAuthConfig()
+		if len(cfg.ClientCerts) == 0 || cfg.RootCAs == nil {
+			return nil, nil
+		}
+		if cfg.ServerName == "" || !strings.EqualFold(hello.ServerName, cfg.ServerName) {
+			return nil, nil
+		}
+		mu.Lock()
+		defer mu.Unlock()
+		if rootCAs != cfg.RootCAs {
+			rootCAs = cfg.RootCAs
+			clientConfig = serverConfig.Clone()
+			clientConfig.GetConfigForClient = nil
+			clientConfig.ClientAuth = tls.RequestClientCert
+			clientConfig.ClientCAs = rootCAs
+		}
+		return clientConfig, nil
+	}
+}
+
+// metricsClientCertCommonName returns the subject common name of the
+// client certificate of r, if issued by one of the rootCAs for client auth.
+func metricsClientCertCommonName(r *http.Request, rootCAs *x509.CertPool) (string, bool) {
+	if r.TLS == nil || len(r.TLS.PeerCertificates) == 0 {
+		return "", false
+	}
+	intermediates := x509.NewCertPool()
+	for _, cert := range r.TLS.PeerCertificates[1:] {
+		intermediates.AddCert(cert)
+	}
+	leaf := r.TLS.PeerCertificates[0]
+	if _, err := leaf.Verify(x509.VerifyOptions{
+		Roots:         rootCAs,
+		Intermediates: intermediates,
+		KeyUsages:     []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}); err != nil {
+		return "", false
+	}
+	return leaf.Subject.CommonName, leaf.Subject.CommonName != ""
+}
+
+// metricsRequestGrant returns the grant of the static token
+// or the client certificate authenticating r, if any.
+func metricsRequestGrant(r *http.Request) (metricsauth.Grant, bool) {
+	cfg := getMetricsAuthConfig()
+	if auth := r.Header.Get(xhttp.Authorization); len(cfg.Tokens) > 0 && strings.HasPrefix(auth, "Bearer ") {
+		if g, ok := cfg.Token(strings.TrimPrefix(auth, "Bearer ")); ok {
+			return g, true
+		}
+	}
+	if len(cfg.ClientCerts) > 0 && cfg.RootCAs != nil {
+		if commonName, ok := metricsClientCertCommonName(r, cfg.RootCAs); ok {
+			return cfg.ClientCert(commonName)
+		}
+	}
+	return metricsauth.Grant{}, false
+}
+
+// metricsAuthMiddleware serves the metrics requests authenticated by
+// a static token or a client certificate allowed by the metrics auth
+// config, limited to the metrics groups of its grant, and the requests
+// authenticated by a JWT bearer token with AuthMiddleware otherwise.
+// Grants limited to metrics groups cannot scrape the legacy endpoint.
+func metricsAuthMiddleware(h http.Handler, legacy bool) http.Handler {
+	jwtHandler := AuthMiddleware(h)
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		grant, ok := metricsRequestGrant(r)
+		if !ok {
+			jwtHandler.ServeHTTP(w, r)
+			return
+		}
+		if len(grant.Groups) > 0 {
+			if legacy {
+				w.WriteHeader(http.StatusForbidden)
+				return
+			}
+			q := r.URL.Query()
+			names, err := parseMetricsGroups(q.Get("groups"))
+			if err != nil {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+			for _, name := range names {
+				if !grant.Allows(name) {
+					w.WriteHeader(http.StatusForbidden)
+					return
+				}
+			}
+			// Scrapes of all groups are limited to the groups of the grant.
+			if len(names) == 0 {
+				q.Set("groups", strings.Join(grant.Groups, ","))
+				r.URL.RawQuery = q.Encode()
+			}
+		}
+		h.ServeHTTP(w, r)
+	})
+}
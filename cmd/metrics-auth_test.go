@@ -0,0 +1,299 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/minio/minio/internal/config"
+	"github.com/minio/minio/internal/config/metricsauth"
+)
+
+// newTestClientCert returns a self-signed client certificate with the common name.
+func newTestClientCert(t *testing.T, commonName string) *x509.Certificate {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: commonName},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return cert
+}
+
+// writeTestCACerts writes the certificates to a PEM file
+// and returns the path of the file.
+func writeTestCACerts(t *testing.T, certs ...*x509.Certificate) string {
+	t.Helper()
+	var data []byte
+	for _, cert := range certs {
+		data = append(data, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: cert.Raw})...)
+	}
+	file := filepath.Join(t.TempDir(), "ca.crt")
+	if err := os.WriteFile(file, data, 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return file
+}
+
+func TestLookupMetricsAuthConfig(t *testing.T) {
+	if _, err := lookupMetricsAuthConfig(config.KVS{{Key: metricsauth.Tokens, Value: "token:capacity+bucket"}}); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if _, err := lookupMetricsAuthConfig(config.KVS{
+		{Key: metricsauth.ClientCerts, Value: "prometheus:unknown"},
+		{Key: metricsauth.CACerts, Value: writeTestCACerts(t, newTestClientCert(t, "ca"))},
+		{Key: metricsauth.ServerName, Value: "metrics.example.com"},
+	}); err == nil {
+		t.Error("expected an error for an unknown metrics group")
+	}
+}
+
+func TestMetricsAuthMiddleware(t *testing.T) {
+	saved := getMetricsAuthConfig()
+	defer updateMetricsAuthParams(saved)
+	savedRootCAs := globalRootCAs
+	defer func() { globalRootCAs = savedRootCAs }()
+
+	trusted := newTestClientCert(t, "prometheus")
+	untrusted := newTestClientCert(t, "prometheus")
+	// The CAs of the server are not trusted to issue client certificates.
+	globalRootCAs = x509.NewCertPool()
+	globalRootCAs.AddCert(untrusted)
+
+	cfg, err := lookupMetricsAuthConfig(config.KVS{
+		{Key: metricsauth.Tokens, Value: "all-groups,bucket-only:bucket"},
+		{Key: metricsauth.ClientCerts, Value: "prometheus:disk+capacity"},
+		{Key: metricsauth.CACerts, Value: writeTestCACerts(t, trusted)},
+		{Key: metricsauth.ServerName, Value: "metrics.example.com"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	updateMetricsAuthParams(cfg)
+
+	var groups string
+	okHandler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		groups = r.URL.Query().Get("groups")
+	})
+	testCases := []struct {
+		token  string
+		cert   *x509.Certificate
+		legacy bool
+		query  string
+		status int
+		groups string
+	}{
+		// Requests without credentials fall back to JWT auth.
+		{status: http.StatusForbidden},
+		{token: "unknown", status: http.StatusForbidden},
+		{token: "all-groups", status: http.StatusOK},
+		{token: "all-groups", legacy: true, status: http.StatusOK},
+		{token: "all-groups", query: "?groups=disk", status: http.StatusOK, groups: "disk"},
+		{token: "bucket-only", status: http.StatusOK, groups: "bucket"},
+		{token: "bucket-only", query: "?groups=bucket", status: http.StatusOK, groups: "bucket"},
+		{token: "bucket-only", query: "?groups=bucket,disk", status: http.StatusForbidden},
+		{token: "bucket-only", legacy: true, status: http.StatusForbidden},
+		{cert: trusted, status: http.StatusOK, groups: "disk,capacity"},
+		{cert: trusted, query: "?groups=bucket", status: http.StatusForbidden},
+		{cert: untrusted, status: http.StatusForbidden},
+	}
+	for i, testCase := range testCases {
+		groups = ""
+		r := httptest.NewRequest(http.MethodGet, "/minio/v2/metrics/cluster"+testCase.query, nil)
+		if testCase.token != "" {
+			r.Header.Set("Authorization", "Bearer "+testCase.token)
+		}
+		if testCase.cert != nil {
+			r.TLS = &tls.ConnectionState{PeerCertificates: []*x509.Certificate{testCase.cert}}
+		}
+		w := httptest.NewRecorder()
+		metricsAuthMiddleware(okHandler, testCase.legacy).ServeHTTP(w, r)
+		if w.Code != testCase.status {
+			t.Errorf("Test %d: expected status %d, got %d", i+1, testCase.status, w.Code)
+		}
+		if groups != testCase.groups {
+			t.Errorf("Test %d: expected groups %q, got %q", i+1, testCase.groups, groups)
+		}
+	}
+}
+
+func TestMetricsGetConfigForClient(t *testing.T) {
+	saved := getMetricsAuthConfig()
+	defer updateMetricsAuthParams(saved)
+
+	serverConfig := &tls.Config{MinVersion: tls.VersionTLS12}
+	getConfigForClient := metricsGetConfigForClient(serverConfig)
+	serverConfig.GetConfigForClient = getConfigForClient
+
+	updateMetricsAuthParams(metricsauth.Config{})
+	if c, err := getConfigForClient(&tls.ClientHelloInfo{}); err != nil || c != nil {
+		t.Fatalf("expected the server config without client certificates, got %v, %v", c, err)
+	}
+
+	cfg, err := lookupMetricsAuthConfig(config.KVS{
+		{Key: metricsauth.ClientCerts, Value: "prometheus"},
+		{Key: metricsauth.CACerts, Value: writeTestCACerts(t, newTestClientCert(t, "prometheus"))},
+		{Key: metricsauth.ServerName, Value: "metrics.example.com"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	updateMetricsAuthParams(cfg)
+	for _, serverName := range []string{"", "minio.example.com"} {
+		if c, err := getConfigForClient(&tls.ClientHelloInfo{ServerName: serverName}); err != nil || c != nil {
+			t.Fatalf("expected the server config for %q, got %v, %v", serverName, c, err)
+		}
+	}
+	c, err := getConfigForClient(&tls.ClientHelloInfo{ServerName: "metrics.example.com"})
+	if err != nil || c == nil {
+		t.Fatalf("expected a config requesting client certificates, got %v, %v", c, err)
+	}
+	if c.ClientAuth != tls.RequestClientCert || c.ClientCAs != cfg.RootCAs || c.GetConfigForClient != nil {
+		t.Errorf("expected client certificates of the metrics auth CAs to be requested, got %+v", c)
+	}
+	if serverConfig.ClientAuth != tls.NoClientCert {
+		t.Error("expected the server config not to be changed")
+	}
+}
+
+// newTestServerCert returns a self-signed server certificate
+// for localhost and the DNS names.
+func newTestServerCert(t *testing.T, dnsNames ...string) (tls.Certificate, *x509.Certificate) {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "minio"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+		DNSNames:              dnsNames,
+		IPAddresses:           []net.IP{net.IPv4(127, 0, 0, 1)},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return tls.Certificate{Certificate: [][]byte{der}, PrivateKey: key}, cert
+}
+
+// Tests that the clients connecting to other server names than the
+// metrics server name are not asked for a client certificate.
+func TestMetricsClientCertRequest(t *testing.T) {
+	saved := getMetricsAuthConfig()
+	defer updateMetricsAuthParams(saved)
+
+	cfg, err := lookupMetricsAuthConfig(config.KVS{
+		{Key: metricsauth.ClientCerts, Value: "prometheus"},
+		{Key: metricsauth.CACerts, Value: writeTestCACerts(t, newTestClientCert(t, "prometheus"))},
+		{Key: metricsauth.ServerName, Value: "metrics.example.com"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	updateMetricsAuthParams(cfg)
+
+	serverCert, cert := newTestServerCert(t, "minio.example.com", "metrics.example.com")
+	serverConfig := &tls.Config{Certificates: []tls.Certificate{serverCert}}
+	serverConfig.GetConfigForClient = metricsGetConfigForClient(serverConfig)
+	l, err := tls.Listen("tcp", "127.0.0.1:0", serverConfig)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer l.Close()
+	go func() {
+		for {
+			conn, err := l.Accept()
+			if err != nil {
+				return
+			}
+			go func() {
+				conn.(*tls.Conn).Handshake()
+				conn.Close()
+			}()
+		}
+	}()
+
+	rootCAs := x509.NewCertPool()
+	rootCAs.AddCert(cert)
+	certRequested := func(serverName string) bool {
+		t.Helper()
+		var requested bool
+		conn, err := tls.Dial("tcp", l.Addr().String(), &tls.Config{
+			ServerName: serverName,
+			RootCAs:    rootCAs,
+			GetClientCertificate: func(*tls.CertificateRequestInfo) (*tls.Certificate, error) {
+				requested = true
+				return &tls.Certificate{}, nil
+			},
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		conn.Close()
+		return requested
+	}
+
+	for _, serverName := range []string{"", "minio.example.com"} {
+		if certRequested(serverName) {
+			t.Errorf("expected no client certificate to be requested for %q", serverName)
+		}
+	}
+	if !certRequested("metrics.example.com") {
+		t.Error("expected a client certificate to be requested for the metrics server name")
+	}
+}
@@ -0,0 +1,319 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"sort"
+	"strconv"
+	"time"
+
+	xhttp "github.com/minio/minio/internal/http"
+	"github.com/minio/minio/internal/logger"
+	"github.com/minio/pkg/env"
+	xnet "github.com/minio/pkg/net"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// Environment variables configuring the push based OTLP metrics exporter.
+const (
+	// EnvMetricsOTLPEndpoint is the OTLP/HTTP metrics endpoint of the
+	// collector, e.g. http://otel-collector:4318/v1/metrics, the
+	// exporter is disabled if not set.
+	EnvMetricsOTLPEndpoint = "MINIO_METRICS_OTLP_ENDPOINT"
+
+	// EnvMetricsOTLPInterval is the interval between two exports.
+	EnvMetricsOTLPInterval = "MINIO_METRICS_OTLP_INTERVAL"
+
+	// EnvMetricsOTLPAuthToken is an optional bearer token sent to the collector.
+	EnvMetricsOTLPAuthToken = "MINIO_METRICS_OTLP_AUTH_TOKEN"
+)
+
+const (
+	defaultOTLPExportInterval = time.Minute
+	minOTLPExportInterval     = 5 * time.Second
+
+	// OTLP AGGREGATION_TEMPORALITY_CUMULATIVE
+	otlpCumulativeTemporality = 2
+)
+
+// otlpMetricsExporter periodically pushes the metrics v2 groups
+// to an OTLP collector using the OTLP/HTTP JSON encoding.
+type otlpMetricsExporter struct {
+	endpoint  string
+	authToken string
+	interval  time.Duration
+	client    *http.Client
+
+	// metrics groups exported by this node
+	metricsGroups []*MetricsGroup
+}
+
+// initOTLPMetricsExporter starts the OTLP metrics exporter if configured.
+// Every node exports its own node metrics, cluster wide metrics are only
+// exported by the first node so that they are not reported multiple times.
+func initOTLPMetricsExporter(ctx context.Context) {
+	endpoint := env.Get(EnvMetricsOTLPEndpoint, "")
+	if endpoint == "" {
+		return
+	}
+	u, err := xnet.ParseHTTPURL(endpoint)
+	if err != nil {
+		logger.Fatal(err, "Invalid %s value in environment variable", EnvMetricsOTLPEndpoint)
+	}
+	interval, err := time.ParseDuration(env.Get(EnvMetricsOTLPInterval, defaultOTLPExportInterval.String()))
+	if err != nil {
+		logger.Fatal(err, "Invalid %s value in environment variable", EnvMetricsOTLPInterval)
+	}
+	if interval < minOTLPExportInterval {
+		logger.Fatal(fmt.Errorf("interval must be at least %s", minOTLPExportInterval),
+			"Invalid %s value in environment variable", EnvMetricsOTLPInterval)
+	}
+
+	metricsGroups := peerMetricsGroups
+	if !globalIsDistErasure || globalEndpoints.FirstLocal() {
+		metricsGroups = append(append([]*MetricsGroup{}, clusterMetricsGroups...), metricsGroups...)
+	}
+
+	exporter := &otlpMetricsExporter{
+		endpoint:  u.String(),
+		authToken: env.Get(EnvMetricsOTLPAuthToken, ""),
+		interval:  interval,
+		client: &http.Client{
+			Transport: NewRemoteTargetHTTPTransport(),
+			Timeout:   interval,
+		},
+		metricsGroups: metricsGroups,
+	}
+	go exporter.run(ctx)
+}
+
+// run exports metrics every interval until ctx is canceled.
+func (e *otlpMetricsExporter) run(ctx context.Context) {
+	timer := time.NewTimer(e.interval)
+	defer timer.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-timer.C:
+			if err := e.export(ctx); err != nil {
+				logger.LogIf(ctx, fmt.Errorf("Unable to export metrics to %s: %w", e.endpoint, err))
+			}
+			timer.Reset(e.interval)
+		}
+	}
+}
+
+// export pushes the current value of all metrics to the collector.
+func (e *otlpMetricsExporter) export(ctx context.Context) error {
+	var metrics []Metric
+	populateAndPublish(e.metricsGroups, func(m Metric) bool {
+		metrics = append(metrics, m)
+		return true
+	})
+
+	buf, err := json.Marshal(newOTLPMetricsRequest(metrics, UTCNow()))
+	if err != nil {
+		return err
+	}
+	return postOTLP(ctx, e.client, e.endpoint, e.authToken, buf)
+}
+
+// postOTLP sends the JSON encoded OTLP export request buf to the collector.
+func postOTLP(ctx context.Context, client *http.Client, endpoint, authToken string, buf []byte) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(buf))
+	if err != nil {
+		return err
+	}
+	req.Header.Set(xhttp.ContentType, "application/json")
+	if authToken != "" {
+		req.Header.Set(xhttp.Authorization, "Bearer "+authToken)
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer xhttp.DrainBody(resp.Body)
+	if resp.StatusCode != http.StatusOK {
+		msg, _ := ioutil.ReadAll(io.LimitReader(resp.Body, 1024))
+		return fmt.Errorf("unexpected response %s: %s", resp.Status, bytes.TrimSpace(msg))
+	}
+	return nil
+}
+
+// The types below are the subset of the OTLP/HTTP JSON encoding
+// of ExportMetricsServiceRequest needed to export gauges and counters.
+type otlpMetricsRequest struct {
+	ResourceMetrics []otlpResourceMetrics `json:"resourceMetrics"`
+}
+
+type otlpResourceMetrics struct {
+	Resource     otlpResource       `json:"resource"`
+	ScopeMetrics []otlpScopeMetrics `json:"scopeMetrics"`
+}
+
+type otlpResource struct {
+	Attributes []otlpKeyValue `json:"attributes"`
+}
+
+type otlpScopeMetrics struct {
+	Scope   otlpScope    `json:"scope"`
+	Metrics []otlpMetric `json:"metrics"`
+}
+
+type otlpScope struct {
+	Name    string `json:"name"`
+	Version string `json:"version,omitempty"`
+}
+
+type otlpMetric struct {
+	Name        string     `json:"name"`
+	Description string     `json:"description,omitempty"`
+	Gauge       *otlpGauge `json:"gauge,omitempty"`
+	Sum         *otlpSum   `json:"sum,omitempty"`
+}
+
+type otlpGauge struct {
+	DataPoints []otlpDataPoint `json:"dataPoints"`
+}
+
+type otlpSum struct {
+	DataPoints             []otlpDataPoint `json:"dataPoints"`
+	AggregationTemporality int             `json:"aggregationTemporality"`
+	IsMonotonic            bool            `json:"isMonotonic"`
+}
+
+type otlpDataPoint struct {
+	Attributes        []otlpKeyValue `json:"attributes,omitempty"`
+	StartTimeUnixNano string         `json:"startTimeUnixNano,omitempty"`
+	TimeUnixNano      string         `json:"timeUnixNano"`
+	AsDouble          float64        `json:"asDouble"`
+}
+
+type otlpKeyValue struct {
+	Key   string       `json:"key"`
+	Value otlpAnyValue `json:"value"`
+}
+
+type otlpAnyValue struct {
+	StringValue string `json:"stringValue"`
+}
+
+// otlpAttributes merges labels into OTLP attributes sorted by
+// key, later labels override earlier ones with the same key.
+func otlpAttributes(labels ...map[string]string) []otlpKeyValue {
+	merged := make(map[string]string)
+	for _, l := range labels {
+		for k, v := range l {
+			merged[k] = v
+		}
+	}
+	attrs := make([]otlpKeyValue, 0, len(merged))
+	for k, v := range merged {
+		attrs = append(attrs, otlpKeyValue{Key: k, Value: otlpAnyValue{StringValue: v}})
+	}
+	sort.Slice(attrs, func(i, j int) bool {
+		return attrs[i].Key < attrs[j].Key
+	})
+	return attrs
+}
+
+// newOTLPMetricsRequest converts metrics to an OTLP export request, data
+// points of the same metric are grouped together. Counters are exported
+// as cumulative monotonic sums starting at server boot, histograms the
+// same way as for Prometheus, as one gauge per bucket.
+func newOTLPMetricsRequest(metrics []Metric, now time.Time) otlpMetricsRequest {
+	timestamp := strconv.FormatInt(now.UnixNano(), 10)
+	server := map[string]string{serverName: globalLocalNodeName}
+	startTimestamp := strconv.FormatInt(globalBootTime.UnixNano(), 10)
+
+	var (
+		names   []string
+		grouped = make(map[string]*otlpMetric)
+	)
+	add := func(md MetricDescription, point otlpDataPoint) {
+		name := prometheus.BuildFQName(string(md.Namespace), string(md.Subsystem), string(md.Name))
+		m, ok := grouped[name]
+		if !ok {
+			m = &otlpMetric{Name: name, Description: md.Help}
+			if md.Type == counterMetric {
+				m.Sum = &otlpSum{
+					AggregationTemporality: otlpCumulativeTemporality,
+					IsMonotonic:            true,
+				}
+			} else {
+				m.Gauge = &otlpGauge{}
+			}
+			grouped[name] = m
+			names = append(names, name)
+		}
+		point.TimeUnixNano = timestamp
+		if m.Sum != nil {
+			point.StartTimeUnixNano = startTimestamp
+			m.Sum.DataPoints = append(m.Sum.DataPoints, point)
+		} else {
+			m.Gauge.DataPoints = append(m.Gauge.DataPoints, point)
+		}
+	}
+
+	for _, metric := range metrics {
+		if metric.Description.Type == histogramMetric {
+			for bucket, count := range metric.Histogram {
+				add(metric.Description, otlpDataPoint{
+					Attributes: otlpAttributes(metric.StaticLabels, metric.VariableLabels, server,
+						map[string]string{metric.HistogramBucketLabel: bucket}),
+					AsDouble: float64(count),
+				})
+			}
+			continue
+		}
+		add(metric.Description, otlpDataPoint{
+			Attributes: otlpAttributes(metric.StaticLabels, metric.VariableLabels, server),
+			AsDouble:   metric.Value,
+		})
+	}
+
+	scopeMetrics := otlpScopeMetrics{
+		Scope:   otlpScope{Name: "minio", Version: Version},
+		Metrics: make([]otlpMetric, 0, len(names)),
+	}
+	for _, name := range names {
+		scopeMetrics.Metrics = append(scopeMetrics.Metrics, *grouped[name])
+	}
+
+	return otlpMetricsRequest{
+		ResourceMetrics: []otlpResourceMetrics{{
+			Resource: otlpResource{
+				Attributes: otlpAttributes(map[string]string{
+					"service.name":        "minio",
+					"service.instance.id": globalLocalNodeName,
+				}),
+			},
+			ScopeMetrics: []otlpScopeMetrics{scopeMetrics},
+		}},
+	}
+}
@@ -0,0 +1,115 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestNewOTLPMetricsRequest(t *testing.T) {
+	metrics := []Metric{
+		{
+			Description:    getS3RequestsTotalMD(),
+			Value:          10,
+			VariableLabels: map[string]string{"api": "getobject"},
+		},
+		{
+			Description:    getS3RequestsTotalMD(),
+			Value:          5,
+			VariableLabels: map[string]string{"api": "putobject"},
+		},
+		{
+			Description: getS3RequestsInQueueMD(),
+			Value:       2,
+		},
+	}
+
+	req := newOTLPMetricsRequest(metrics, time.Unix(1, 0))
+	if len(req.ResourceMetrics) != 1 || len(req.ResourceMetrics[0].ScopeMetrics) != 1 {
+		t.Fatalf("unexpected request layout: %+v", req)
+	}
+	exported := req.ResourceMetrics[0].ScopeMetrics[0].Metrics
+	if len(exported) != 2 {
+		t.Fatalf("expected 2 metrics, got %d", len(exported))
+	}
+
+	total := exported[0]
+	if total.Name != "minio_s3_requests_total" {
+		t.Errorf("unexpected metric name %s", total.Name)
+	}
+	if total.Sum == nil || !total.Sum.IsMonotonic || total.Sum.AggregationTemporality != otlpCumulativeTemporality {
+		t.Fatalf("expected a cumulative monotonic sum, got %+v", total)
+	}
+	if len(total.Sum.DataPoints) != 2 {
+		t.Fatalf("expected 2 data points, got %d", len(total.Sum.DataPoints))
+	}
+	point := total.Sum.DataPoints[0]
+	if point.AsDouble != 10 || point.TimeUnixNano != "1000000000" {
+		t.Errorf("unexpected data point %+v", point)
+	}
+	if len(point.Attributes) != 2 || point.Attributes[0].Key != "api" || point.Attributes[0].Value.StringValue != "getobject" ||
+		point.Attributes[1].Key != serverName {
+		t.Errorf("unexpected attributes %+v", point.Attributes)
+	}
+
+	if queue := exported[1]; queue.Gauge == nil || queue.Sum != nil || len(queue.Gauge.DataPoints) != 1 {
+		t.Errorf("expected a gauge with a single data point, got %+v", queue)
+	}
+}
+
+func TestOTLPMetricsExporter(t *testing.T) {
+	var received otlpMetricsRequest
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		if err := json.NewDecoder(r.Body).Decode(&received); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+	}))
+	defer ts.Close()
+
+	mg := &MetricsGroup{}
+	mg.RegisterRead(func(ctx context.Context) []Metric {
+		return []Metric{{Description: getS3RequestsInQueueMD(), Value: 1}}
+	})
+	exporter := &otlpMetricsExporter{
+		endpoint:      ts.URL,
+		authToken:     "token",
+		client:        ts.Client(),
+		metricsGroups: []*MetricsGroup{mg},
+	}
+	if err := exporter.export(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if len(received.ResourceMetrics) != 1 {
+		t.Fatalf("expected metrics to be received, got %+v", received)
+	}
+
+	exporter.authToken = ""
+	if err := exporter.export(context.Background()); err == nil {
+		t.Fatal("expected export to fail on unauthorized response")
+	}
+}
@@ -0,0 +1,138 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/minio/pkg/env"
+)
+
+// EnvPeerMetricsCacheTTL configures how long the metrics collected
+// from the peers by the cluster metrics endpoint are served again to
+// other scrapes, e.g. "10s". The cache is disabled with "0s".
+const EnvPeerMetricsCacheTTL = "MINIO_PROMETHEUS_PEER_CACHE_TTL"
+
+const (
+	defaultPeerMetricsCacheTTL = 10 * time.Second
+
+	// The last metrics collected from a peer are served for
+	// this long while the peer cannot be reached.
+	maxPeerMetricsStaleness = 5 * time.Minute
+)
+
+// peerMetricsCacheTTL returns the TTL configured by
+// EnvPeerMetricsCacheTTL, the default if unset or invalid.
+func peerMetricsCacheTTL() time.Duration {
+	ttl, err := time.ParseDuration(env.Get(EnvPeerMetricsCacheTTL, ""))
+	if err != nil || ttl < 0 {
+		return defaultPeerMetricsCacheTTL
+	}
+	return ttl
+}
+
+// peerMetricsCache keeps the metrics last collected from each
+// peer for each selection of metrics groups.
+type peerMetricsCache struct {
+	ttl time.Duration
+
+	mu      sync.Mutex
+	entries map[string]*peerMetricsEntry
+}
+
+// peerMetricsEntry holds the metrics collected from a peer,
+// mu is held while collecting them again so that concurrent
+// scrapes share a single call to the peer.
+type peerMetricsEntry struct {
+	// Last time the entry was looked up, guarded by the cache mu.
+	used time.Time
+
+	mu        sync.Mutex
+	metrics   []Metric
+	collected time.Time
+}
+
+func newPeerMetricsCache(ttl time.Duration) *peerMetricsCache {
+	return &peerMetricsCache{
+		ttl:     ttl,
+		entries: make(map[string]*peerMetricsEntry),
+	}
+}
+
+// entry returns the entry of the peer for the metrics groups.
+func (c *peerMetricsCache) entry(peer string, groups []string) *peerMetricsEntry {
+	groups = append([]string(nil), groups...)
+	sort.Strings(groups)
+	key := peer + "?" + strings.Join(groups, ",")
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	e, ok := c.entries[key]
+	if !ok {
+		// Drop the entries of the selections not scraped
+		// anymore, or of the peers removed.
+		for k, old := range c.entries {
+			if time.Since(old.used) > maxPeerMetricsStaleness {
+				delete(c.entries, k)
+			}
+		}
+		e = &peerMetricsEntry{}
+		c.entries[key] = e
+	}
+	e.used = time.Now()
+	return e
+}
+
+// get returns the metrics groups of the peer collected by collect,
+// or collected less than the TTL ago, along with the time they were
+// collected at. If collect fails, the metrics last collected are
+// returned along with the error, unless they are too old.
+func (c *peerMetricsCache) get(peer string, groups []string, collect func() ([]Metric, error)) ([]Metric, time.Time, error) {
+	e := c.entry(peer, groups)
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if !e.collected.IsZero() && time.Since(e.collected) < c.ttl {
+		return e.metrics, e.collected, nil
+	}
+	metrics, err := collect()
+	if err != nil {
+		if time.Since(e.collected) > maxPeerMetricsStaleness {
+			return nil, time.Time{}, err
+		}
+		return e.metrics, e.collected, err
+	}
+	e.metrics, e.collected = metrics, time.Now()
+	return e.metrics, e.collected, nil
+}
+
+// collectPeerMetrics returns the metrics groups of the peer.
+func collectPeerMetrics(ctx context.Context, client *peerRESTClient, groups []string) ([]Metric, error) {
+	ch, err := client.GetPeerMetrics(ctx, groups)
+	if err != nil {
+		return nil, err
+	}
+	var metrics []Metric
+	for m := range ch {
+		metrics = append(metrics, m)
+	}
+	return metrics, nil
+}
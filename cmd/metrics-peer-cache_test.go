@@ -0,0 +1,102 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"errors"
+	"os"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestPeerMetricsCacheTTL(t *testing.T) {
+	testCases := []struct {
+		value    string
+		expected time.Duration
+	}{
+		{"", defaultPeerMetricsCacheTTL},
+		{"30s", 30 * time.Second},
+		{"0s", 0},
+		{"-1s", defaultPeerMetricsCacheTTL},
+		{"invalid", defaultPeerMetricsCacheTTL},
+	}
+	defer os.Unsetenv(EnvPeerMetricsCacheTTL)
+	for i, testCase := range testCases {
+		os.Setenv(EnvPeerMetricsCacheTTL, testCase.value)
+		if got := peerMetricsCacheTTL(); got != testCase.expected {
+			t.Errorf("Test %d: expected %v, got %v", i+1, testCase.expected, got)
+		}
+	}
+}
+
+func TestPeerMetricsCache(t *testing.T) {
+	cache := newPeerMetricsCache(time.Hour)
+
+	var calls int32
+	collect := func() ([]Metric, error) {
+		atomic.AddInt32(&calls, 1)
+		time.Sleep(10 * time.Millisecond)
+		return []Metric{{Value: 1}}, nil
+	}
+
+	// Concurrent scrapes share a single call to the peer.
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			metrics, collected, err := cache.get("node1:9000", []string{"http", "disk"}, collect)
+			if err != nil || len(metrics) != 1 || collected.IsZero() {
+				t.Errorf("unexpected metrics %v collected at %v: %v", metrics, collected, err)
+			}
+		}()
+	}
+	wg.Wait()
+	if calls != 1 {
+		t.Errorf("expected 1 call to the peer, got %d", calls)
+	}
+
+	// The order of the groups does not matter, other groups are collected.
+	cache.get("node1:9000", []string{"disk", "http"}, collect)
+	if calls != 1 {
+		t.Errorf("expected 1 call to the peer, got %d", calls)
+	}
+	cache.get("node1:9000", nil, collect)
+	if calls != 2 {
+		t.Errorf("expected 2 calls to the peer, got %d", calls)
+	}
+
+	// Expired metrics are collected again, and served
+	// with the time they were collected at on errors.
+	cache.ttl = 0
+	errPeer := errors.New("peer offline")
+	_, collected, _ := cache.get("node1:9000", nil, collect)
+	metrics, stale, err := cache.get("node1:9000", nil, func() ([]Metric, error) { return nil, errPeer })
+	if err != errPeer || len(metrics) != 1 || !stale.Equal(collected) {
+		t.Errorf("expected the stale metrics collected at %v, got %v collected at %v: %v", collected, metrics, stale, err)
+	}
+
+	// Metrics too old are not served anymore.
+	cache.entry("node1:9000", nil).collected = time.Now().Add(-2 * maxPeerMetricsStaleness)
+	metrics, stale, err = cache.get("node1:9000", nil, func() ([]Metric, error) { return nil, errPeer })
+	if err != errPeer || metrics != nil || !stale.IsZero() {
+		t.Errorf("expected no metrics, got %v collected at %v: %v", metrics, stale, err)
+	}
+}
@@ -53,8 +53,8 @@ func registerMetricsRouter(router *mux.Router) {
 		metricsRouter.Handle(prometheusMetricsV2ClusterPath, metricsServerHandler())
 		metricsRouter.Handle(prometheusMetricsV2NodePath, metricsNodeHandler())
 	case prometheusJWT:
-		metricsRouter.Handle(prometheusMetricsPathLegacy, AuthMiddleware(metricsHandler()))
-		metricsRouter.Handle(prometheusMetricsV2ClusterPath, AuthMiddleware(metricsServerHandler()))
-		metricsRouter.Handle(prometheusMetricsV2NodePath, AuthMiddleware(metricsNodeHandler()))
+		metricsRouter.Handle(prometheusMetricsPathLegacy, metricsAuthMiddleware(metricsHandler(), true))
+		metricsRouter.Handle(prometheusMetricsV2ClusterPath, metricsAuthMiddleware(metricsServerHandler(), false))
+		metricsRouter.Handle(prometheusMetricsV2NodePath, metricsAuthMiddleware(metricsNodeHandler(), false))
 	}
 }
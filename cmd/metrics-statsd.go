@@ -0,0 +1,241 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"sort"
+	"strconv"
+	"sync/atomic"
+	"time"
+
+	"github.com/minio/minio/internal/config"
+	"github.com/minio/minio/internal/logger"
+	"github.com/minio/pkg/env"
+)
+
+// Environment variables configuring the StatsD metrics sink.
+const (
+	// EnvMetricsStatsDAddress is the host:port of the StatsD
+	// agent listening on UDP, the sink is disabled if not set.
+	EnvMetricsStatsDAddress = "MINIO_METRICS_STATSD_ADDRESS"
+
+	// EnvMetricsStatsDInterval is the interval between two flushes.
+	EnvMetricsStatsDInterval = "MINIO_METRICS_STATSD_INTERVAL"
+
+	// EnvMetricsStatsDPrefix is prepended to all metric names.
+	EnvMetricsStatsDPrefix = "MINIO_METRICS_STATSD_PREFIX"
+
+	// EnvMetricsStatsDDogStatsD enables DogStatsD tags, otherwise
+	// the api is appended to the metric name.
+	EnvMetricsStatsDDogStatsD = "MINIO_METRICS_STATSD_DOGSTATSD"
+)
+
+const (
+	defaultStatsDInterval = 10 * time.Second
+	defaultStatsDPrefix   = "minio."
+
+	// Maximum size of a single UDP packet, small enough
+	// to not be fragmented on common network MTUs.
+	maxStatsDPacketSize = 1432
+)
+
+// statsdSink periodically flushes HTTPStats and ConnStats to a StatsD
+// agent. Lifetime counters are sent as the difference since the last
+// flush, as StatsD expects for counters.
+type statsdSink struct {
+	prefix    string
+	dogstatsd bool
+	interval  time.Duration
+	conn      io.Writer
+
+	// last flushed value of every counter
+	counters map[string]uint64
+}
+
+// initStatsDSink starts the StatsD metrics sink if configured.
+func initStatsDSink(ctx context.Context) {
+	address := env.Get(EnvMetricsStatsDAddress, "")
+	if address == "" {
+		return
+	}
+	interval, err := time.ParseDuration(env.Get(EnvMetricsStatsDInterval, defaultStatsDInterval.String()))
+	if err != nil || interval <= 0 {
+		logger.Fatal(fmt.Errorf("invalid interval %q", env.Get(EnvMetricsStatsDInterval, "")),
+			"Invalid %s value in environment variable", EnvMetricsStatsDInterval)
+	}
+	dogstatsd, err := config.ParseBool(env.Get(EnvMetricsStatsDDogStatsD, config.EnableOff))
+	if err != nil {
+		logger.Fatal(err, "Invalid %s value in environment variable", EnvMetricsStatsDDogStatsD)
+	}
+	conn, err := net.Dial("udp", address)
+	if err != nil {
+		logger.Fatal(err, "Invalid %s value in environment variable", EnvMetricsStatsDAddress)
+	}
+
+	sink := &statsdSink{
+		prefix:    env.Get(EnvMetricsStatsDPrefix, defaultStatsDPrefix),
+		dogstatsd: dogstatsd,
+		interval:  interval,
+		conn:      conn,
+		counters:  make(map[string]uint64),
+	}
+	go func() {
+		defer conn.Close()
+		sink.run(ctx)
+	}()
+}
+
+// run flushes metrics every interval until ctx is canceled.
+func (s *statsdSink) run(ctx context.Context) {
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			// Errors are only logged once in a while since
+			// UDP writes fail as long as no agent is listening.
+			if err := s.flush(globalHTTPStats, globalConnStats); err != nil {
+				logger.LogOnceIf(ctx, fmt.Errorf("Unable to send metrics to StatsD: %w", err), "statsd-flush")
+			}
+		}
+	}
+}
+
+// statsdLine is a single StatsD metric.
+type statsdLine struct {
+	name  string
+	api   string
+	value uint64
+	gauge bool
+}
+
+// flush sends the current values of the stats to the agent.
+func (s *statsdSink) flush(st *HTTPStats, cs *ConnStats) error {
+	var lines []statsdLine
+	counters := func(name string, stats *HTTPAPIStats) {
+		values := stats.Load()
+		apis := make([]string, 0, len(values))
+		for api := range values {
+			apis = append(apis, api)
+		}
+		sort.Strings(apis)
+		for _, api := range apis {
+			lines = append(lines, statsdLine{name: name, api: api, value: uint64(values[api])})
+		}
+	}
+	counters("s3.requests", &st.totalS3Requests)
+	counters("s3.errors", &st.totalS3Errors)
+	counters("s3.errors.4xx", &st.totalS34xxErrors)
+	counters("s3.errors.5xx", &st.totalS35xxErrors)
+	counters("s3.canceled", &st.totalS3Canceled)
+	counters("s3.canceled.client", &st.totalS3CanceledClient)
+	counters("s3.canceled.shutdown", &st.totalS3CanceledShutdown)
+	counters("s3.deadline_exceeded", &st.totalS3DeadlineExceeded)
+	counters("s3.rate_limited", &st.totalS3RateLimited)
+	counters("s3.maxed_out", &st.totalS3MaxedOut)
+
+	for api, inflight := range st.currentS3Requests.Load() {
+		lines = append(lines, statsdLine{name: "s3.requests.inflight", api: api, value: uint64(inflight), gauge: true})
+	}
+	waiting := atomic.LoadInt32(&st.s3RequestsInQueue)
+	if waiting < 0 {
+		waiting = 0
+	}
+	lines = append(lines, statsdLine{name: "s3.requests.waiting", value: uint64(waiting), gauge: true})
+
+	connStats := cs.toServerConnStats()
+	lines = append(lines,
+		statsdLine{name: "s3.traffic.received_bytes", value: connStats.S3InputBytes},
+		statsdLine{name: "s3.traffic.sent_bytes", value: connStats.S3OutputBytes},
+		statsdLine{name: "traffic.received_bytes", value: connStats.TotalInputBytes},
+		statsdLine{name: "traffic.sent_bytes", value: connStats.TotalOutputBytes},
+		statsdLine{name: "traffic.open_connections", value: uint64(connStats.OpenConnections), gauge: true},
+	)
+
+	var packet bytes.Buffer
+	for _, line := range lines {
+		b := s.format(line)
+		if b == nil {
+			continue
+		}
+		if packet.Len() > 0 && packet.Len()+len(b) > maxStatsDPacketSize {
+			if _, err := s.conn.Write(packet.Bytes()); err != nil {
+				return err
+			}
+			packet.Reset()
+		}
+		packet.Write(b)
+	}
+	if packet.Len() > 0 {
+		if _, err := s.conn.Write(packet.Bytes()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// format returns the StatsD representation of the line terminated by a
+// new line, or nil if a counter did not change since the last flush.
+func (s *statsdSink) format(line statsdLine) []byte {
+	name := s.prefix + line.name
+	if line.api != "" && !s.dogstatsd {
+		name += "." + line.api
+	}
+
+	value, kind := line.value, "g"
+	if !line.gauge {
+		key := name + "\x00" + line.api
+		last, ok := s.counters[key]
+		s.counters[key] = line.value
+		if ok {
+			if line.value == last {
+				return nil
+			}
+			// A counter smaller than before has wrapped around
+			// or was reset, send its whole value in that case.
+			if line.value > last {
+				value = line.value - last
+			}
+		}
+		kind = "c"
+	}
+
+	b := make([]byte, 0, len(name)+32)
+	b = append(b, name...)
+	b = append(b, ':')
+	b = strconv.AppendUint(b, value, 10)
+	b = append(b, '|')
+	b = append(b, kind...)
+	if s.dogstatsd {
+		b = append(b, "|#server:"...)
+		b = append(b, globalLocalNodeName...)
+		if line.api != "" {
+			b = append(b, ",api:"...)
+			b = append(b, line.api...)
+		}
+	}
+	return append(b, '\n')
+}
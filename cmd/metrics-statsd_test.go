@@ -0,0 +1,77 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestStatsDSinkFlush(t *testing.T) {
+	st := newHTTPStats()
+	cs := newConnStats()
+	st.totalS3Requests.Inc("getobject")
+	st.totalS3Requests.Inc("getobject")
+	st.currentS3Requests.Inc("putobject")
+	cs.incS3InputBytes(100)
+
+	var buf bytes.Buffer
+	sink := &statsdSink{
+		prefix:   defaultStatsDPrefix,
+		conn:     &buf,
+		counters: make(map[string]uint64),
+	}
+	if err := sink.flush(st, cs); err != nil {
+		t.Fatal(err)
+	}
+	for _, expected := range []string{
+		"minio.s3.requests.getobject:2|c\n",
+		"minio.s3.requests.inflight.putobject:1|g\n",
+		"minio.s3.requests.waiting:0|g\n",
+		"minio.s3.traffic.received_bytes:100|c\n",
+	} {
+		if !strings.Contains(buf.String(), expected) {
+			t.Errorf("expected %q in %q", expected, buf.String())
+		}
+	}
+
+	// Only the difference since the last flush is sent for
+	// counters, unchanged counters are not sent at all.
+	buf.Reset()
+	st.totalS3Requests.Inc("getobject")
+	if err := sink.flush(st, cs); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(buf.String(), "minio.s3.requests.getobject:1|c\n") {
+		t.Errorf("expected counter difference in %q", buf.String())
+	}
+	if strings.Contains(buf.String(), "received_bytes") {
+		t.Errorf("expected unchanged counters to be skipped in %q", buf.String())
+	}
+
+	// With tags the metric name changes, so the whole value is sent.
+	buf.Reset()
+	sink.dogstatsd = true
+	if err := sink.flush(st, cs); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(buf.String(), "minio.s3.requests:3|c|#server:"+globalLocalNodeName+",api:getobject\n") {
+		t.Errorf("expected DogStatsD tags in %q", buf.String())
+	}
+}
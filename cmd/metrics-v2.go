@@ -22,13 +22,16 @@ import (
 	"fmt"
 	"net/http"
 	"runtime"
+	"strconv"
 	"strings"
 	"sync"
 	"sync/atomic"
 	"time"
 
 	"github.com/minio/madmin-go"
+	"github.com/minio/minio-go/v7/pkg/set"
 	"github.com/minio/minio/internal/bucket/lifecycle"
+	"github.com/minio/minio/internal/kms"
 	"github.com/minio/minio/internal/logger"
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
@@ -37,13 +40,14 @@ import (
 )
 
 var (
-	nodeCollector     *minioNodeCollector
-	clusterCollector  *minioClusterCollector
-	peerMetricsGroups []*MetricsGroup
+	nodeCollector        *minioNodeCollector
+	clusterCollector     *minioClusterCollector
+	peerMetricsGroups    []*MetricsGroup
+	clusterMetricsGroups []*MetricsGroup
 )
 
 func init() {
-	clusterMetricsGroups := []*MetricsGroup{
+	clusterMetricsGroups = []*MetricsGroup{
 		getBucketUsageMetrics(),
 		getMinioHealingMetrics(),
 		getNodeHealthMetrics(),
@@ -60,9 +64,25 @@ func init() {
 		getMinioVersionMetrics(),
 		getNetworkMetrics(),
 		getS3TTFBMetric(),
+		getS3RequestDurationMetric(),
+		getS3RequestSizeMetric(),
+		getS3ResponseSizeMetric(),
+		getTLSHandshakeDurationMetric(),
 		getILMNodeMetrics(),
 		getScannerNodeMetrics(),
+		getBitrotScrubNodeMetrics(),
+		getRebalanceNodeMetrics(),
 		getIAMNodeMetrics(),
+		getMultipartNodeMetrics(),
+		getInlineObjectNodeMetrics(),
+		getRangePrefetchNodeMetrics(),
+		getPackNodeMetrics(),
+		getKMSNodeMetrics(),
+		getKMSRequestDurationMetric(),
+		getNotifyNodeMetrics(),
+		getAuditNodeMetrics(),
+		getBatchPurgeNodeMetrics(),
+		getAccessKeyMetrics(),
 	}
 
 	allMetricsGroups := func() (allMetrics []*MetricsGroup) {
@@ -71,15 +91,12 @@ func init() {
 		return allMetrics
 	}()
 
-	nodeCollector = newMinioCollectorNode([]*MetricsGroup{
+	// The node endpoint serves all the metrics of the node it is
+	// scraped from, without calling the other nodes.
+	nodeCollector = newMinioCollectorNode(append([]*MetricsGroup{
 		getNodeHealthMetrics(),
 		getLocalDiskStorageMetrics(),
-		getCacheMetrics(),
-		getHTTPMetrics(),
-		getNetworkMetrics(),
-		getMinioVersionMetrics(),
-		getS3TTFBMetric(),
-	})
+	}, peerMetricsGroups...))
 
 	clusterCollector = newMinioClusterCollector(allMetricsGroups)
 }
@@ -108,6 +125,7 @@ const (
 	fileDescriptorSubsystem   MetricSubsystem = "file_descriptor"
 	goRoutines                MetricSubsystem = "go_routine"
 	ioSubsystem               MetricSubsystem = "io"
+	multipartSubsystem        MetricSubsystem = "multipart"
 	nodesSubsystem            MetricSubsystem = "nodes"
 	objectsSubsystem          MetricSubsystem = "objects"
 	processSubsystem          MetricSubsystem = "process"
@@ -122,7 +140,18 @@ const (
 	quotaSubsystem            MetricSubsystem = "quota"
 	ilmSubsystem              MetricSubsystem = "ilm"
 	scannerSubsystem          MetricSubsystem = "scanner"
+	scrubSubsystem            MetricSubsystem = "scrub"
+	rebalanceSubsystem        MetricSubsystem = "rebalance"
 	iamSubsystem              MetricSubsystem = "iam"
+	accessKeySubsystem        MetricSubsystem = "access_key"
+	tlsSubsystem              MetricSubsystem = "tls"
+	transformSubsystem        MetricSubsystem = "transform"
+	packSubsystem             MetricSubsystem = "pack"
+	prefetchSubsystem         MetricSubsystem = "prefetch"
+	kmsSubsystem              MetricSubsystem = "kms"
+	notifySubsystem           MetricSubsystem = "notify"
+	auditSubsystem            MetricSubsystem = "audit"
+	batchPurgeSubsystem       MetricSubsystem = "batch_purge"
 )
 
 // MetricName are the individual names for the metric.
@@ -132,6 +161,7 @@ const (
 	authTotal      MetricName = "auth_total"
 	canceledTotal  MetricName = "canceled_total"
 	errorsTotal    MetricName = "errors_total"
+	expiredTotal   MetricName = "expired_total"
 	headerTotal    MetricName = "header_total"
 	healTotal      MetricName = "heal_total"
 	hitsTotal      MetricName = "hits_total"
@@ -151,13 +181,39 @@ const (
 	total          MetricName = "total"
 	freeInodes     MetricName = "free_inodes"
 
+	statusCodesTotal MetricName = "status_codes_total"
+	wraparoundsTotal MetricName = "wraparounds_total"
+	openConnections  MetricName = "open_connections"
+
+	connectionsOpenedTotal MetricName = "connections_opened_total"
+	dialErrorsTotal        MetricName = "dial_errors_total"
+
+	inflightPeakTotal       MetricName = "inflight_peak_total"
+	canceledCausesTotal     MetricName = "canceled_causes_total"
+	rateLimitedTotal        MetricName = "rate_limited_total"
+	accessKeyThrottledTotal MetricName = "access_key_throttled_total"
+	maxedOutTotal           MetricName = "maxed_out_total"
+
+	handshakesTotal        MetricName = "handshakes_total"
+	handshakeFailuresTotal MetricName = "handshake_failures_total"
+
+	authenticatedTotal MetricName = "authenticated_total"
+	anonymousTotal     MetricName = "anonymous_total"
+	encryptionTotal    MetricName = "encryption_total"
+
+	encryptionVersionsTotal MetricName = "encryption_versions_total"
+	encryptionTotalBytes    MetricName = "encryption_total_bytes"
+
 	failedCount     MetricName = "failed_count"
 	failedBytes     MetricName = "failed_bytes"
 	freeBytes       MetricName = "free_bytes"
+	pendingCount    MetricName = "pending_count"
+	pendingBytes    MetricName = "pending_bytes"
 	readBytes       MetricName = "read_bytes"
 	rcharBytes      MetricName = "rchar_bytes"
 	receivedBytes   MetricName = "received_bytes"
 	latencyMilliSec MetricName = "latency_ms"
+	lagMilliSec     MetricName = "lag_ms"
 	sentBytes       MetricName = "sent_bytes"
 	totalBytes      MetricName = "total_bytes"
 	usedBytes       MetricName = "used_bytes"
@@ -166,14 +222,32 @@ const (
 
 	apiLatencyMicroSec MetricName = "latency_us"
 
+	ioOpsTotal      MetricName = "io_ops_total"
+	ioOpsPerSec     MetricName = "io_iops"
+	ioLatencyMicro  MetricName = "io_latency_us"
+	ioQueueDepth    MetricName = "io_queue_depth"
+	ioErrorsTotal   MetricName = "io_errors_total"
+	ioTimeoutsTotal MetricName = "io_timeouts_total"
+
+	limitBytes              MetricName = "limit_bytes"
+	throttledSecondsTotal   MetricName = "throttled_seconds_total"
+	throttledRequestsQueued MetricName = "throttled_requests"
+
+	requestsTotal MetricName = "requests_total"
+	failuresTotal MetricName = "failures_total"
+
 	usagePercent MetricName = "update_percent"
 
 	commitInfo  MetricName = "commit_info"
 	usageInfo   MetricName = "usage_info"
 	versionInfo MetricName = "version_info"
 
-	sizeDistribution = "size_distribution"
-	ttfbDistribution = "ttfb_seconds_distribution"
+	sizeDistribution          = "size_distribution"
+	ttfbDistribution          = "ttfb_seconds_distribution"
+	reqDistribution           = "request_seconds_distribution"
+	receivedBytesDistribution = "received_bytes_distribution"
+	sentBytesDistribution     = "sent_bytes_distribution"
+	handshakeDistribution     = "handshake_seconds_distribution"
 
 	lastActivityTime = "last_activity_nano_seconds"
 	startTime        = "starttime_seconds"
@@ -185,6 +259,12 @@ const (
 	transitionPendingTasks MetricName = "transition_pending_tasks"
 	transitionActiveTasks  MetricName = "transition_active_tasks"
 
+	tierTransitionedTotal      MetricName = "tier_transitioned_total"
+	tierTransitionedBytesTotal MetricName = "tier_transitioned_bytes_total"
+	tierTransitionFailedTotal  MetricName = "tier_transition_failed_total"
+	tierTransitionActiveTasks  MetricName = "tier_transition_active_tasks"
+	tierRestorePendingTasks    MetricName = "tier_restore_pending_tasks"
+
 	transitionedBytes    MetricName = "transitioned_bytes"
 	transitionedObjects  MetricName = "transitioned_objects"
 	transitionedVersions MetricName = "transitioned_versions"
@@ -224,10 +304,84 @@ type Metric struct {
 
 // MetricsGroup are a group of metrics that are initialized together.
 type MetricsGroup struct {
+	// name selects the group in the groups query
+	// parameter of the metrics endpoints.
+	name          string
 	metricsCache  timedValue
 	cacheInterval time.Duration
 }
 
+// Names of the metrics groups, several groups can share a name.
+const (
+	accessKeyMetricsGroup = "access_key"
+	auditMetricsGroup     = "audit"
+	batchMetricsGroup     = "batch"
+	bucketMetricsGroup    = "bucket"
+	cacheMetricsGroup     = "cache"
+	capacityMetricsGroup  = "capacity"
+	diskMetricsGroup      = "disk"
+	goMetricsGroup        = "go"
+	healMetricsGroup      = "heal"
+	healthMetricsGroup    = "health"
+	httpMetricsGroup      = "http"
+	iamMetricsGroup       = "iam"
+	ilmMetricsGroup       = "ilm"
+	kmsMetricsGroup       = "kms"
+	multipartMetricsGroup = "multipart"
+	networkMetricsGroup   = "network"
+	notifyMetricsGroup    = "notify"
+	objectsMetricsGroup   = "objects"
+	packMetricsGroup      = "pack"
+	processMetricsGroup   = "process"
+	rebalanceMetricsGroup = "rebalance"
+	scannerMetricsGroup   = "scanner"
+	tierMetricsGroup      = "tier"
+	versionMetricsGroup   = "version"
+)
+
+var metricsGroupNames = set.CreateStringSet(
+	accessKeyMetricsGroup, auditMetricsGroup, batchMetricsGroup, bucketMetricsGroup, cacheMetricsGroup,
+	capacityMetricsGroup, diskMetricsGroup, goMetricsGroup,
+	healMetricsGroup, healthMetricsGroup, httpMetricsGroup,
+	iamMetricsGroup, ilmMetricsGroup, kmsMetricsGroup, multipartMetricsGroup,
+	networkMetricsGroup, notifyMetricsGroup, objectsMetricsGroup, packMetricsGroup,
+	processMetricsGroup, rebalanceMetricsGroup, scannerMetricsGroup,
+	tierMetricsGroup, versionMetricsGroup,
+)
+
+// parseMetricsGroups parses a comma separated list of
+// metrics group names, an empty list selects all groups.
+func parseMetricsGroups(s string) ([]string, error) {
+	var names []string
+	for _, name := range strings.Split(s, ",") {
+		name = strings.TrimSpace(name)
+		if name == "" {
+			continue
+		}
+		if !metricsGroupNames.Contains(name) {
+			return nil, fmt.Errorf("unknown metrics group %q", name)
+		}
+		names = append(names, name)
+	}
+	return names, nil
+}
+
+// selectMetricsGroups returns the groups of metricsGroups
+// called by one of names, all of them if names is empty.
+func selectMetricsGroups(metricsGroups []*MetricsGroup, names []string) []*MetricsGroup {
+	if len(names) == 0 {
+		return metricsGroups
+	}
+	selected := set.CreateStringSet(names...)
+	var groups []*MetricsGroup
+	for _, mg := range metricsGroups {
+		if mg != nil && selected.Contains(mg.name) {
+			groups = append(groups, mg)
+		}
+	}
+	return groups
+}
+
 // RegisterRead register the metrics populator function to be used
 // to populate new values upon cache invalidation.
 func (g *MetricsGroup) RegisterRead(read func(ctx context.Context) []Metric) {
@@ -338,6 +492,66 @@ func getNodeDiskAPILatencyMD() MetricDescription {
 	}
 }
 
+func getNodeDiskIOOpsMD() MetricDescription {
+	return MetricDescription{
+		Namespace: nodeMetricNamespace,
+		Subsystem: diskSubsystem,
+		Name:      ioOpsTotal,
+		Help:      "Total number of successful read and write operations on a disk.",
+		Type:      counterMetric,
+	}
+}
+
+func getNodeDiskIOPSMD() MetricDescription {
+	return MetricDescription{
+		Namespace: nodeMetricNamespace,
+		Subsystem: diskSubsystem,
+		Name:      ioOpsPerSec,
+		Help:      "Average last minute read and write operations per second on a disk.",
+		Type:      gaugeMetric,
+	}
+}
+
+func getNodeDiskIOLatencyMD() MetricDescription {
+	return MetricDescription{
+		Namespace: nodeMetricNamespace,
+		Subsystem: diskSubsystem,
+		Name:      ioLatencyMicro,
+		Help:      "Last minute latency percentiles in µs of read and write operations on a disk.",
+		Type:      gaugeMetric,
+	}
+}
+
+func getNodeDiskIOQueueDepthMD() MetricDescription {
+	return MetricDescription{
+		Namespace: nodeMetricNamespace,
+		Subsystem: diskSubsystem,
+		Name:      ioQueueDepth,
+		Help:      "Number of running and waiting operations on a disk.",
+		Type:      gaugeMetric,
+	}
+}
+
+func getNodeDiskIOErrorsMD() MetricDescription {
+	return MetricDescription{
+		Namespace: nodeMetricNamespace,
+		Subsystem: diskSubsystem,
+		Name:      ioErrorsTotal,
+		Help:      "Total number of operations on a disk which failed with an unexpected error.",
+		Type:      counterMetric,
+	}
+}
+
+func getNodeDiskIOTimeoutsMD() MetricDescription {
+	return MetricDescription{
+		Namespace: nodeMetricNamespace,
+		Subsystem: diskSubsystem,
+		Name:      ioTimeoutsTotal,
+		Help:      "Total number of operations on a disk which timed out.",
+		Type:      counterMetric,
+	}
+}
+
 func getNodeDiskUsedBytesMD() MetricDescription {
 	return MetricDescription{
 		Namespace: nodeMetricNamespace,
@@ -428,6 +642,16 @@ func getBucketUsageQuotaTotalBytesMD() MetricDescription {
 	}
 }
 
+func getBucketQuotaUsageRatioMD() MetricDescription {
+	return MetricDescription{
+		Namespace: bucketMetricNamespace,
+		Subsystem: quotaSubsystem,
+		Name:      "usage_ratio",
+		Help:      "Ratio of the bucket usage to its quota, above 1 once the quota is exceeded",
+		Type:      gaugeMetric,
+	}
+}
+
 func getBucketUsageTotalBytesMD() MetricDescription {
 	return MetricDescription{
 		Namespace: bucketMetricNamespace,
@@ -448,6 +672,56 @@ func getBucketUsageObjectsTotalMD() MetricDescription {
 	}
 }
 
+func getBucketUsageVersionsTotalMD() MetricDescription {
+	return MetricDescription{
+		Namespace: bucketMetricNamespace,
+		Subsystem: usageSubsystem,
+		Name:      "version_total",
+		Help:      "Total number of versions of versioned objects, including delete markers",
+		Type:      gaugeMetric,
+	}
+}
+
+func getBucketQuotaObjectsTotalMD() MetricDescription {
+	return MetricDescription{
+		Namespace: bucketMetricNamespace,
+		Subsystem: quotaSubsystem,
+		Name:      "objects_total",
+		Help:      "Maximum number of objects of the bucket",
+		Type:      gaugeMetric,
+	}
+}
+
+func getBucketQuotaVersionsTotalMD() MetricDescription {
+	return MetricDescription{
+		Namespace: bucketMetricNamespace,
+		Subsystem: quotaSubsystem,
+		Name:      "versions_total",
+		Help:      "Maximum number of object versions of the bucket",
+		Type:      gaugeMetric,
+	}
+}
+
+func getBucketUsageEncryptionVersionsMD() MetricDescription {
+	return MetricDescription{
+		Namespace: bucketMetricNamespace,
+		Subsystem: usageSubsystem,
+		Name:      encryptionVersionsTotal,
+		Help:      "Total number of object versions by encryption type",
+		Type:      gaugeMetric,
+	}
+}
+
+func getBucketUsageEncryptionBytesMD() MetricDescription {
+	return MetricDescription{
+		Namespace: bucketMetricNamespace,
+		Subsystem: usageSubsystem,
+		Name:      encryptionTotalBytes,
+		Help:      "Total size in bytes of object versions by encryption type",
+		Type:      gaugeMetric,
+	}
+}
+
 func getBucketRepLatencyMD() MetricDescription {
 	return MetricDescription{
 		Namespace: bucketMetricNamespace,
@@ -498,6 +772,86 @@ func getBucketRepFailedOperationsMD() MetricDescription {
 	}
 }
 
+func getBucketRepPendingBytesMD() MetricDescription {
+	return MetricDescription{
+		Namespace: bucketMetricNamespace,
+		Subsystem: replicationSubsystem,
+		Name:      pendingBytes,
+		Help:      "Total number of bytes pending replication to the target bucket, as of the last scan.",
+		Type:      gaugeMetric,
+	}
+}
+
+func getBucketRepPendingOperationsMD() MetricDescription {
+	return MetricDescription{
+		Namespace: bucketMetricNamespace,
+		Subsystem: replicationSubsystem,
+		Name:      pendingCount,
+		Help:      "Total number of objects pending replication to the target bucket, as of the last scan.",
+		Type:      gaugeMetric,
+	}
+}
+
+func getBucketRepLagMD() MetricDescription {
+	return MetricDescription{
+		Namespace: bucketMetricNamespace,
+		Subsystem: replicationSubsystem,
+		Name:      lagMilliSec,
+		Help:      "Quantiles of the replication lag over the last two minutes, the time objects took to reach the target bucket.",
+		Type:      histogramMetric,
+	}
+}
+
+func getClusterRepPendingBytesMD() MetricDescription {
+	return MetricDescription{
+		Namespace: clusterMetricNamespace,
+		Subsystem: replicationSubsystem,
+		Name:      pendingBytes,
+		Help:      "Total number of bytes pending replication to the remote endpoint, as of the last scan.",
+		Type:      gaugeMetric,
+	}
+}
+
+func getClusterRepPendingOperationsMD() MetricDescription {
+	return MetricDescription{
+		Namespace: clusterMetricNamespace,
+		Subsystem: replicationSubsystem,
+		Name:      pendingCount,
+		Help:      "Total number of objects pending replication to the remote endpoint, as of the last scan.",
+		Type:      gaugeMetric,
+	}
+}
+
+func getClusterRepFailedBytesMD() MetricDescription {
+	return MetricDescription{
+		Namespace: clusterMetricNamespace,
+		Subsystem: replicationSubsystem,
+		Name:      failedBytes,
+		Help:      "Total number of bytes failed at least once to replicate to the remote endpoint.",
+		Type:      gaugeMetric,
+	}
+}
+
+func getClusterRepFailedOperationsMD() MetricDescription {
+	return MetricDescription{
+		Namespace: clusterMetricNamespace,
+		Subsystem: replicationSubsystem,
+		Name:      failedCount,
+		Help:      "Total number of objects which failed replication to the remote endpoint.",
+		Type:      gaugeMetric,
+	}
+}
+
+func getClusterRepLagMD() MetricDescription {
+	return MetricDescription{
+		Namespace: clusterMetricNamespace,
+		Subsystem: replicationSubsystem,
+		Name:      lagMilliSec,
+		Help:      "Quantiles of the replication lag over the last two minutes, the time objects took to reach the remote endpoint.",
+		Type:      histogramMetric,
+	}
+}
+
 func getBucketObjectDistributionMD() MetricDescription {
 	return MetricDescription{
 		Namespace: bucketMetricNamespace,
@@ -528,417 +882,797 @@ func getInterNodeSentBytesMD() MetricDescription {
 	}
 }
 
-func getInterNodeReceivedBytesMD() MetricDescription {
+func getInterNodeConnectionsOpenedMD() MetricDescription {
 	return MetricDescription{
 		Namespace: interNodeMetricNamespace,
 		Subsystem: trafficSubsystem,
-		Name:      receivedBytes,
-		Help:      "Total number of bytes received from other peer nodes.",
+		Name:      connectionsOpenedTotal,
+		Help:      "Total number of connections opened to the other peer nodes, including reconnections.",
 		Type:      counterMetric,
 	}
 }
 
-func getS3SentBytesMD() MetricDescription {
+func getInterNodeDialErrorsMD() MetricDescription {
 	return MetricDescription{
-		Namespace: s3MetricNamespace,
+		Namespace: interNodeMetricNamespace,
 		Subsystem: trafficSubsystem,
-		Name:      sentBytes,
-		Help:      "Total number of s3 bytes sent",
+		Name:      dialErrorsTotal,
+		Help:      "Total number of failed attempts to connect to the other peer nodes.",
 		Type:      counterMetric,
 	}
 }
 
-func getS3ReceivedBytesMD() MetricDescription {
+func getInterNodeOpenConnectionsMD() MetricDescription {
 	return MetricDescription{
-		Namespace: s3MetricNamespace,
+		Namespace: interNodeMetricNamespace,
 		Subsystem: trafficSubsystem,
-		Name:      receivedBytes,
-		Help:      "Total number of s3 bytes received.",
-		Type:      counterMetric,
-	}
-}
-
-func getS3RequestsInFlightMD() MetricDescription {
-	return MetricDescription{
-		Namespace: s3MetricNamespace,
-		Subsystem: requestsSubsystem,
-		Name:      inflightTotal,
-		Help:      "Total number of S3 requests currently in flight",
+		Name:      openConnections,
+		Help:      "Total number of currently open connections to the other peer nodes.",
 		Type:      gaugeMetric,
 	}
 }
 
-func getS3RequestsInQueueMD() MetricDescription {
+func getInterNodeReceivedBytesMD() MetricDescription {
 	return MetricDescription{
-		Namespace: s3MetricNamespace,
-		Subsystem: requestsSubsystem,
-		Name:      waitingTotal,
-		Help:      "Number of S3 requests in the waiting queue",
-		Type:      gaugeMetric,
+		Namespace: interNodeMetricNamespace,
+		Subsystem: trafficSubsystem,
+		Name:      receivedBytes,
+		Help:      "Total number of bytes received from other peer nodes.",
+		Type:      counterMetric,
 	}
 }
 
-func getIncomingS3RequestsMD() MetricDescription {
+func getS3SentBytesMD() MetricDescription {
 	return MetricDescription{
 		Namespace: s3MetricNamespace,
-		Subsystem: requestsSubsystem,
-		Name:      incomingTotal,
-		Help:      "Volatile number of total incoming S3 requests",
-		Type:      gaugeMetric,
+		Subsystem: trafficSubsystem,
+		Name:      sentBytes,
+		Help:      "Total number of s3 bytes sent",
+		Type:      counterMetric,
 	}
 }
 
-func getS3RequestsTotalMD() MetricDescription {
+func getAccessKeyRequestsTotalMD() MetricDescription {
 	return MetricDescription{
 		Namespace: s3MetricNamespace,
-		Subsystem: requestsSubsystem,
-		Name:      total,
-		Help:      "Total number S3 requests",
+		Subsystem: accessKeySubsystem,
+		Name:      "requests_" + total,
+		Help:      "Total number S3 requests for an access key",
 		Type:      counterMetric,
 	}
 }
 
-func getS3RequestsErrorsMD() MetricDescription {
+func getAccessKeyErrorsTotalMD() MetricDescription {
 	return MetricDescription{
 		Namespace: s3MetricNamespace,
-		Subsystem: requestsSubsystem,
+		Subsystem: accessKeySubsystem,
 		Name:      errorsTotal,
-		Help:      "Total number S3 requests with (4xx and 5xx) errors",
+		Help:      "Total number S3 requests with (4xx) and (5xx) errors for an access key",
 		Type:      counterMetric,
 	}
 }
 
-func getS3Requests4xxErrorsMD() MetricDescription {
+func getAccessKeyReceivedBytesMD() MetricDescription {
 	return MetricDescription{
 		Namespace: s3MetricNamespace,
-		Subsystem: requestsSubsystem,
-		Name:      "4xx_" + errorsTotal,
-		Help:      "Total number S3 requests with (4xx) errors",
+		Subsystem: accessKeySubsystem,
+		Name:      receivedBytes,
+		Help:      "Total number of s3 bytes received for an access key",
 		Type:      counterMetric,
 	}
 }
 
-func getS3Requests5xxErrorsMD() MetricDescription {
+func getAccessKeySentBytesMD() MetricDescription {
 	return MetricDescription{
 		Namespace: s3MetricNamespace,
-		Subsystem: requestsSubsystem,
-		Name:      "5xx_" + errorsTotal,
-		Help:      "Total number S3 requests with (5xx) errors",
+		Subsystem: accessKeySubsystem,
+		Name:      sentBytes,
+		Help:      "Total number of s3 bytes sent for an access key",
 		Type:      counterMetric,
 	}
 }
 
-func getS3RequestsCanceledMD() MetricDescription {
+func getS3ReceivedBytesMD() MetricDescription {
 	return MetricDescription{
 		Namespace: s3MetricNamespace,
-		Subsystem: requestsSubsystem,
-		Name:      canceledTotal,
-		Help:      "Total number S3 requests that were canceled from the client while processing",
+		Subsystem: trafficSubsystem,
+		Name:      receivedBytes,
+		Help:      "Total number of s3 bytes received.",
 		Type:      counterMetric,
 	}
 }
 
-func getS3RejectedAuthRequestsTotalMD() MetricDescription {
+func getTrafficWraparoundsMD() MetricDescription {
 	return MetricDescription{
-		Namespace: s3MetricNamespace,
-		Subsystem: requestsRejectedSubsystem,
-		Name:      authTotal,
-		Help:      "Total number S3 requests rejected for auth failure.",
+		Namespace: nodeMetricNamespace,
+		Subsystem: trafficSubsystem,
+		Name:      wraparoundsTotal,
+		Help:      "Total number of times the traffic byte counters overflowed and restarted from zero",
 		Type:      counterMetric,
 	}
 }
 
-func getS3RejectedHeaderRequestsTotalMD() MetricDescription {
+func getTrafficOpenConnectionsMD() MetricDescription {
 	return MetricDescription{
-		Namespace: s3MetricNamespace,
-		Subsystem: requestsRejectedSubsystem,
-		Name:      headerTotal,
-		Help:      "Total number S3 requests rejected for invalid header.",
+		Namespace: nodeMetricNamespace,
+		Subsystem: trafficSubsystem,
+		Name:      openConnections,
+		Help:      "Total number of currently open client connections",
+		Type:      gaugeMetric,
+	}
+}
+
+func getBucketRequestsTotalMD() MetricDescription {
+	return MetricDescription{
+		Namespace: bucketMetricNamespace,
+		Subsystem: requestsSubsystem,
+		Name:      total,
+		Help:      "Total number S3 requests for a bucket",
 		Type:      counterMetric,
 	}
 }
 
-func getS3RejectedTimestampRequestsTotalMD() MetricDescription {
+func getBucketRequests4xxErrorsMD() MetricDescription {
 	return MetricDescription{
-		Namespace: s3MetricNamespace,
-		Subsystem: requestsRejectedSubsystem,
-		Name:      timestampTotal,
-		Help:      "Total number S3 requests rejected for invalid timestamp.",
+		Namespace: bucketMetricNamespace,
+		Subsystem: requestsSubsystem,
+		Name:      "4xx_" + errorsTotal,
+		Help:      "Total number S3 requests with (4xx) errors for a bucket",
 		Type:      counterMetric,
 	}
 }
 
-func getS3RejectedInvalidRequestsTotalMD() MetricDescription {
+func getBucketRequests5xxErrorsMD() MetricDescription {
 	return MetricDescription{
-		Namespace: s3MetricNamespace,
-		Subsystem: requestsRejectedSubsystem,
-		Name:      invalidTotal,
-		Help:      "Total number S3 invalid requests.",
+		Namespace: bucketMetricNamespace,
+		Subsystem: requestsSubsystem,
+		Name:      "5xx_" + errorsTotal,
+		Help:      "Total number S3 requests with (5xx) errors for a bucket",
 		Type:      counterMetric,
 	}
 }
 
-func getCacheHitsTotalMD() MetricDescription {
+func getBucketRequestsCanceledMD() MetricDescription {
 	return MetricDescription{
-		Namespace: minioNamespace,
-		Subsystem: cacheSubsystem,
-		Name:      hitsTotal,
-		Help:      "Total number of disk cache hits",
+		Namespace: bucketMetricNamespace,
+		Subsystem: requestsSubsystem,
+		Name:      canceledTotal,
+		Help:      "Total number S3 requests that were canceled from the client while processing for a bucket",
 		Type:      counterMetric,
 	}
 }
 
-func getCacheHitsMissedTotalMD() MetricDescription {
+func getBucketTrafficSentBytesMD() MetricDescription {
 	return MetricDescription{
-		Namespace: minioNamespace,
-		Subsystem: cacheSubsystem,
-		Name:      missedTotal,
-		Help:      "Total number of disk cache misses",
+		Namespace: bucketMetricNamespace,
+		Subsystem: trafficSubsystem,
+		Name:      sentBytes,
+		Help:      "Total number of s3 bytes sent for a bucket",
 		Type:      counterMetric,
 	}
 }
 
-func getCacheUsagePercentMD() MetricDescription {
+func getBucketTrafficReceivedBytesMD() MetricDescription {
 	return MetricDescription{
-		Namespace: minioNamespace,
-		Subsystem: minioNamespace,
-		Name:      usagePercent,
-		Help:      "Total percentage cache usage",
-		Type:      gaugeMetric,
+		Namespace: bucketMetricNamespace,
+		Subsystem: trafficSubsystem,
+		Name:      receivedBytes,
+		Help:      "Total number of s3 bytes received for a bucket",
+		Type:      counterMetric,
 	}
 }
 
-func getCacheUsageInfoMD() MetricDescription {
+func getBucketTrafficLimitMD() MetricDescription {
 	return MetricDescription{
-		Namespace: minioNamespace,
-		Subsystem: cacheSubsystem,
-		Name:      usageInfo,
-		Help:      "Total percentage cache usage, value of 1 indicates high and 0 low, label level is set as well",
+		Namespace: bucketMetricNamespace,
+		Subsystem: trafficSubsystem,
+		Name:      limitBytes,
+		Help:      "Bandwidth limit in bytes per second enforced by this node for a bucket",
 		Type:      gaugeMetric,
 	}
 }
 
-func getCacheUsedBytesMD() MetricDescription {
+func getBucketTrafficThrottledSecondsMD() MetricDescription {
 	return MetricDescription{
-		Namespace: minioNamespace,
-		Subsystem: cacheSubsystem,
-		Name:      usedBytes,
-		Help:      "Current cache usage in bytes",
-		Type:      gaugeMetric,
+		Namespace: bucketMetricNamespace,
+		Subsystem: trafficSubsystem,
+		Name:      throttledSecondsTotal,
+		Help:      "Total time s3 traffic of a bucket was delayed by its bandwidth limit",
+		Type:      counterMetric,
 	}
 }
 
-func getCacheTotalBytesMD() MetricDescription {
+func getBucketTrafficThrottledRequestsMD() MetricDescription {
 	return MetricDescription{
-		Namespace: minioNamespace,
-		Subsystem: cacheSubsystem,
-		Name:      totalBytes,
-		Help:      "Total size of cache disk in bytes",
+		Namespace: bucketMetricNamespace,
+		Subsystem: trafficSubsystem,
+		Name:      throttledRequestsQueued,
+		Help:      "Number of requests currently delayed by the bandwidth limit of a bucket",
 		Type:      gaugeMetric,
 	}
 }
 
-func getCacheSentBytesMD() MetricDescription {
+func getBucketTransformRequestsMD() MetricDescription {
 	return MetricDescription{
-		Namespace: minioNamespace,
-		Subsystem: cacheSubsystem,
-		Name:      sentBytes,
-		Help:      "Total number of bytes served from cache",
+		Namespace: bucketMetricNamespace,
+		Subsystem: transformSubsystem,
+		Name:      requestsTotal,
+		Help:      "Total number of objects sent to the transform endpoint of an access point",
 		Type:      counterMetric,
 	}
 }
 
-func getHealObjectsTotalMD() MetricDescription {
+func getBucketTransformFailuresMD() MetricDescription {
 	return MetricDescription{
-		Namespace: healMetricNamespace,
-		Subsystem: objectsSubsystem,
-		Name:      total,
-		Help:      "Objects scanned in current self healing run",
-		Type:      gaugeMetric,
+		Namespace: bucketMetricNamespace,
+		Subsystem: transformSubsystem,
+		Name:      failuresTotal,
+		Help:      "Total number of objects the transform endpoint of an access point failed to transform",
+		Type:      counterMetric,
 	}
 }
 
-func getHealObjectsHealTotalMD() MetricDescription {
+func getS3RequestsInFlightMD() MetricDescription {
 	return MetricDescription{
-		Namespace: healMetricNamespace,
-		Subsystem: objectsSubsystem,
-		Name:      healTotal,
-		Help:      "Objects healed in current self healing run",
+		Namespace: s3MetricNamespace,
+		Subsystem: requestsSubsystem,
+		Name:      inflightTotal,
+		Help:      "Total number of S3 requests currently in flight",
 		Type:      gaugeMetric,
 	}
 }
 
-func getHealObjectsFailTotalMD() MetricDescription {
+func getS3RequestsInFlightPeakMD() MetricDescription {
 	return MetricDescription{
-		Namespace: healMetricNamespace,
-		Subsystem: objectsSubsystem,
-		Name:      errorsTotal,
-		Help:      "Objects for which healing failed in current self healing run",
+		Namespace: s3MetricNamespace,
+		Subsystem: requestsSubsystem,
+		Name:      inflightPeakTotal,
+		Help:      "Highest number of S3 requests in flight since the last scrape",
 		Type:      gaugeMetric,
 	}
 }
 
-func getHealLastActivityTimeMD() MetricDescription {
+func getS3RequestsInQueueMD() MetricDescription {
 	return MetricDescription{
-		Namespace: healMetricNamespace,
-		Subsystem: timeSubsystem,
-		Name:      lastActivityTime,
-		Help:      "Time elapsed (in nano seconds) since last self healing activity. This is set to -1 until initial self heal activity",
+		Namespace: s3MetricNamespace,
+		Subsystem: requestsSubsystem,
+		Name:      waitingTotal,
+		Help:      "Number of S3 requests in the waiting queue",
 		Type:      gaugeMetric,
 	}
 }
 
-func getNodeOnlineTotalMD() MetricDescription {
+func getIncomingS3RequestsMD() MetricDescription {
 	return MetricDescription{
-		Namespace: clusterMetricNamespace,
-		Subsystem: nodesSubsystem,
-		Name:      onlineTotal,
-		Help:      "Total number of MinIO nodes online.",
+		Namespace: s3MetricNamespace,
+		Subsystem: requestsSubsystem,
+		Name:      incomingTotal,
+		Help:      "Volatile number of total incoming S3 requests",
 		Type:      gaugeMetric,
 	}
 }
 
-func getNodeOfflineTotalMD() MetricDescription {
+func getS3RequestsTotalMD() MetricDescription {
 	return MetricDescription{
-		Namespace: clusterMetricNamespace,
-		Subsystem: nodesSubsystem,
-		Name:      offlineTotal,
-		Help:      "Total number of MinIO nodes offline.",
-		Type:      gaugeMetric,
+		Namespace: s3MetricNamespace,
+		Subsystem: requestsSubsystem,
+		Name:      total,
+		Help:      "Total number S3 requests",
+		Type:      counterMetric,
 	}
 }
 
-func getMinIOVersionMD() MetricDescription {
+func getS3RequestsStatusCodesMD() MetricDescription {
 	return MetricDescription{
-		Namespace: minioMetricNamespace,
-		Subsystem: softwareSubsystem,
-		Name:      versionInfo,
-		Help:      "MinIO Release tag for the server",
-		Type:      gaugeMetric,
+		Namespace: s3MetricNamespace,
+		Subsystem: requestsSubsystem,
+		Name:      statusCodesTotal,
+		Help:      "Total number S3 responses by status code",
+		Type:      counterMetric,
 	}
 }
 
-func getMinIOCommitMD() MetricDescription {
+func getS3RequestsErrorsMD() MetricDescription {
 	return MetricDescription{
-		Namespace: minioMetricNamespace,
-		Subsystem: softwareSubsystem,
-		Name:      commitInfo,
-		Help:      "Git commit hash for the MinIO release.",
-		Type:      gaugeMetric,
+		Namespace: s3MetricNamespace,
+		Subsystem: requestsSubsystem,
+		Name:      errorsTotal,
+		Help:      "Total number S3 requests with (4xx and 5xx) errors",
+		Type:      counterMetric,
 	}
 }
 
-func getS3TTFBDistributionMD() MetricDescription {
+func getS3Requests4xxErrorsMD() MetricDescription {
 	return MetricDescription{
 		Namespace: s3MetricNamespace,
-		Subsystem: timeSubsystem,
-		Name:      ttfbDistribution,
-		Help:      "Distribution of the time to first byte across API calls.",
-		Type:      gaugeMetric,
+		Subsystem: requestsSubsystem,
+		Name:      "4xx_" + errorsTotal,
+		Help:      "Total number S3 requests with (4xx) errors",
+		Type:      counterMetric,
 	}
 }
 
-func getMinioFDOpenMD() MetricDescription {
+func getS3Requests5xxErrorsMD() MetricDescription {
 	return MetricDescription{
-		Namespace: nodeMetricNamespace,
-		Subsystem: fileDescriptorSubsystem,
-		Name:      openTotal,
-		Help:      "Total number of open file descriptors by the MinIO Server process.",
-		Type:      gaugeMetric,
+		Namespace: s3MetricNamespace,
+		Subsystem: requestsSubsystem,
+		Name:      "5xx_" + errorsTotal,
+		Help:      "Total number S3 requests with (5xx) errors",
+		Type:      counterMetric,
 	}
 }
 
-func getMinioFDLimitMD() MetricDescription {
+func getS3Requests3xxMD() MetricDescription {
 	return MetricDescription{
-		Namespace: nodeMetricNamespace,
-		Subsystem: fileDescriptorSubsystem,
-		Name:      limitTotal,
-		Help:      "Limit on total number of open file descriptors for the MinIO Server process.",
-		Type:      gaugeMetric,
+		Namespace: s3MetricNamespace,
+		Subsystem: requestsSubsystem,
+		Name:      "3xx_" + total,
+		Help:      "Total number S3 requests with (3xx) redirect and not modified responses",
+		Type:      counterMetric,
 	}
 }
 
-func getMinioProcessIOWriteBytesMD() MetricDescription {
+func getS3AuthenticatedRequestsMD() MetricDescription {
 	return MetricDescription{
-		Namespace: nodeMetricNamespace,
-		Subsystem: ioSubsystem,
-		Name:      writeBytes,
-		Help:      "Total bytes written by the process to the underlying storage system, /proc/[pid]/io write_bytes",
+		Namespace: s3MetricNamespace,
+		Subsystem: requestsSubsystem,
+		Name:      authenticatedTotal,
+		Help:      "Total number S3 requests with valid credentials",
 		Type:      counterMetric,
 	}
 }
 
-func getMinioProcessIOReadBytesMD() MetricDescription {
+func getS3AnonymousRequestsMD() MetricDescription {
 	return MetricDescription{
-		Namespace: nodeMetricNamespace,
-		Subsystem: ioSubsystem,
-		Name:      readBytes,
-		Help:      "Total bytes read by the process from the underlying storage system, /proc/[pid]/io read_bytes",
+		Namespace: s3MetricNamespace,
+		Subsystem: requestsSubsystem,
+		Name:      anonymousTotal,
+		Help:      "Total number of anonymous S3 requests",
 		Type:      counterMetric,
 	}
 }
 
-func getMinioProcessIOWriteCachedBytesMD() MetricDescription {
+func getS3EncryptionRequestsMD() MetricDescription {
 	return MetricDescription{
-		Namespace: nodeMetricNamespace,
-		Subsystem: ioSubsystem,
-		Name:      wcharBytes,
-		Help:      "Total bytes written by the process to the underlying storage system including page cache, /proc/[pid]/io wchar",
+		Namespace: s3MetricNamespace,
+		Subsystem: requestsSubsystem,
+		Name:      encryptionTotal,
+		Help:      "Total number of successful S3 object reads and writes by encryption type",
 		Type:      counterMetric,
 	}
 }
 
-func getMinioProcessIOReadCachedBytesMD() MetricDescription {
+func getS3RequestsCanceledCausesMD() MetricDescription {
 	return MetricDescription{
-		Namespace: nodeMetricNamespace,
-		Subsystem: ioSubsystem,
-		Name:      rcharBytes,
-		Help:      "Total bytes read by the process from the underlying storage system including cache, /proc/[pid]/io rchar",
+		Namespace: s3MetricNamespace,
+		Subsystem: requestsSubsystem,
+		Name:      canceledCausesTotal,
+		Help:      "Total number S3 requests canceled by the client or the server shutting down, or failed because a backend operation exceeded its deadline",
 		Type:      counterMetric,
 	}
 }
 
-func getMinIOProcessSysCallRMD() MetricDescription {
+func getS3RequestsRateLimitedMD() MetricDescription {
 	return MetricDescription{
-		Namespace: nodeMetricNamespace,
-		Subsystem: sysCallSubsystem,
-		Name:      readTotal,
-		Help:      "Total read SysCalls to the kernel. /proc/[pid]/io syscr",
+		Namespace: s3MetricNamespace,
+		Subsystem: requestsSubsystem,
+		Name:      rateLimitedTotal,
+		Help:      "Total number S3 requests rejected with SlowDown by the requests per second limits",
 		Type:      counterMetric,
 	}
 }
 
-func getMinIOProcessSysCallWMD() MetricDescription {
+func getS3RequestsAccessKeyThrottledMD() MetricDescription {
 	return MetricDescription{
-		Namespace: nodeMetricNamespace,
-		Subsystem: sysCallSubsystem,
-		Name:      writeTotal,
-		Help:      "Total write SysCalls to the kernel. /proc/[pid]/io syscw",
+		Namespace: s3MetricNamespace,
+		Subsystem: requestsSubsystem,
+		Name:      accessKeyThrottledTotal,
+		Help:      "Total number S3 requests rejected with SlowDown by the requests per second limit of an access key",
 		Type:      counterMetric,
 	}
 }
 
-func getMinIOGORoutineCountMD() MetricDescription {
+func getS3RequestsMaxedOutMD() MetricDescription {
 	return MetricDescription{
-		Namespace: nodeMetricNamespace,
-		Subsystem: goRoutines,
-		Name:      total,
-		Help:      "Total number of go routines running.",
-		Type:      gaugeMetric,
+		Namespace: s3MetricNamespace,
+		Subsystem: requestsSubsystem,
+		Name:      maxedOutTotal,
+		Help:      "Total number S3 requests rejected after waiting for the concurrent requests limits",
+		Type:      counterMetric,
 	}
 }
 
-func getMinIOProcessStartTimeMD() MetricDescription {
+func getS3RequestsCanceledMD() MetricDescription {
 	return MetricDescription{
-		Namespace: nodeMetricNamespace,
-		Subsystem: processSubsystem,
-		Name:      startTime,
-		Help:      "Start time for MinIO process per node, time in seconds since Unix epoc.",
-		Type:      gaugeMetric,
+		Namespace: s3MetricNamespace,
+		Subsystem: requestsSubsystem,
+		Name:      canceledTotal,
+		Help:      "Total number S3 requests that were canceled from the client while processing",
+		Type:      counterMetric,
 	}
 }
 
-func getMinIOProcessUptimeMD() MetricDescription {
+func getS3RejectedAuthRequestsTotalMD() MetricDescription {
+	return MetricDescription{
+		Namespace: s3MetricNamespace,
+		Subsystem: requestsRejectedSubsystem,
+		Name:      authTotal,
+		Help:      "Total number S3 requests rejected for auth failure.",
+		Type:      counterMetric,
+	}
+}
+
+func getS3RejectedHeaderRequestsTotalMD() MetricDescription {
+	return MetricDescription{
+		Namespace: s3MetricNamespace,
+		Subsystem: requestsRejectedSubsystem,
+		Name:      headerTotal,
+		Help:      "Total number S3 requests rejected for invalid header.",
+		Type:      counterMetric,
+	}
+}
+
+func getS3RejectedTimestampRequestsTotalMD() MetricDescription {
+	return MetricDescription{
+		Namespace: s3MetricNamespace,
+		Subsystem: requestsRejectedSubsystem,
+		Name:      timestampTotal,
+		Help:      "Total number S3 requests rejected for invalid timestamp.",
+		Type:      counterMetric,
+	}
+}
+
+func getS3RejectedInvalidRequestsTotalMD() MetricDescription {
+	return MetricDescription{
+		Namespace: s3MetricNamespace,
+		Subsystem: requestsRejectedSubsystem,
+		Name:      invalidTotal,
+		Help:      "Total number S3 invalid requests.",
+		Type:      counterMetric,
+	}
+}
+
+func getS3RejectedExpiredRequestsTotalMD() MetricDescription {
+	return MetricDescription{
+		Namespace: s3MetricNamespace,
+		Subsystem: requestsRejectedSubsystem,
+		Name:      expiredTotal,
+		Help:      "Total number S3 requests rejected for using an expired service account.",
+		Type:      counterMetric,
+	}
+}
+
+func getCacheHitsTotalMD() MetricDescription {
+	return MetricDescription{
+		Namespace: minioNamespace,
+		Subsystem: cacheSubsystem,
+		Name:      hitsTotal,
+		Help:      "Total number of disk cache hits",
+		Type:      counterMetric,
+	}
+}
+
+func getCacheHitsMissedTotalMD() MetricDescription {
+	return MetricDescription{
+		Namespace: minioNamespace,
+		Subsystem: cacheSubsystem,
+		Name:      missedTotal,
+		Help:      "Total number of disk cache misses",
+		Type:      counterMetric,
+	}
+}
+
+func getCacheUsagePercentMD() MetricDescription {
+	return MetricDescription{
+		Namespace: minioNamespace,
+		Subsystem: minioNamespace,
+		Name:      usagePercent,
+		Help:      "Total percentage cache usage",
+		Type:      gaugeMetric,
+	}
+}
+
+func getCacheUsageInfoMD() MetricDescription {
+	return MetricDescription{
+		Namespace: minioNamespace,
+		Subsystem: cacheSubsystem,
+		Name:      usageInfo,
+		Help:      "Total percentage cache usage, value of 1 indicates high and 0 low, label level is set as well",
+		Type:      gaugeMetric,
+	}
+}
+
+func getCacheUsedBytesMD() MetricDescription {
+	return MetricDescription{
+		Namespace: minioNamespace,
+		Subsystem: cacheSubsystem,
+		Name:      usedBytes,
+		Help:      "Current cache usage in bytes",
+		Type:      gaugeMetric,
+	}
+}
+
+func getCacheTotalBytesMD() MetricDescription {
+	return MetricDescription{
+		Namespace: minioNamespace,
+		Subsystem: cacheSubsystem,
+		Name:      totalBytes,
+		Help:      "Total size of cache disk in bytes",
+		Type:      gaugeMetric,
+	}
+}
+
+func getCacheSentBytesMD() MetricDescription {
+	return MetricDescription{
+		Namespace: minioNamespace,
+		Subsystem: cacheSubsystem,
+		Name:      sentBytes,
+		Help:      "Total number of bytes served from cache",
+		Type:      counterMetric,
+	}
+}
+
+func getHealObjectsTotalMD() MetricDescription {
+	return MetricDescription{
+		Namespace: healMetricNamespace,
+		Subsystem: objectsSubsystem,
+		Name:      total,
+		Help:      "Objects scanned in current self healing run",
+		Type:      gaugeMetric,
+	}
+}
+
+func getHealObjectsHealTotalMD() MetricDescription {
+	return MetricDescription{
+		Namespace: healMetricNamespace,
+		Subsystem: objectsSubsystem,
+		Name:      healTotal,
+		Help:      "Objects healed in current self healing run",
+		Type:      gaugeMetric,
+	}
+}
+
+func getHealObjectsFailTotalMD() MetricDescription {
+	return MetricDescription{
+		Namespace: healMetricNamespace,
+		Subsystem: objectsSubsystem,
+		Name:      errorsTotal,
+		Help:      "Objects for which healing failed in current self healing run",
+		Type:      gaugeMetric,
+	}
+}
+
+func getHealLastActivityTimeMD() MetricDescription {
+	return MetricDescription{
+		Namespace: healMetricNamespace,
+		Subsystem: timeSubsystem,
+		Name:      lastActivityTime,
+		Help:      "Time elapsed (in nano seconds) since last self healing activity. This is set to -1 until initial self heal activity",
+		Type:      gaugeMetric,
+	}
+}
+
+func getNodeOnlineTotalMD() MetricDescription {
+	return MetricDescription{
+		Namespace: clusterMetricNamespace,
+		Subsystem: nodesSubsystem,
+		Name:      onlineTotal,
+		Help:      "Total number of MinIO nodes online.",
+		Type:      gaugeMetric,
+	}
+}
+
+func getClusterNodeMetricsCollectedMD() MetricDescription {
+	return MetricDescription{
+		Namespace: clusterMetricNamespace,
+		Subsystem: nodesSubsystem,
+		Name:      "metrics_collected_timestamp_seconds",
+		Help:      "Time the metrics of the MinIO node were collected at, in seconds since the Unix epoch.",
+		Type:      gaugeMetric,
+	}
+}
+
+func getNodeOfflineTotalMD() MetricDescription {
+	return MetricDescription{
+		Namespace: clusterMetricNamespace,
+		Subsystem: nodesSubsystem,
+		Name:      offlineTotal,
+		Help:      "Total number of MinIO nodes offline.",
+		Type:      gaugeMetric,
+	}
+}
+
+func getMinIOVersionMD() MetricDescription {
+	return MetricDescription{
+		Namespace: minioMetricNamespace,
+		Subsystem: softwareSubsystem,
+		Name:      versionInfo,
+		Help:      "MinIO Release tag for the server",
+		Type:      gaugeMetric,
+	}
+}
+
+func getMinIOCommitMD() MetricDescription {
+	return MetricDescription{
+		Namespace: minioMetricNamespace,
+		Subsystem: softwareSubsystem,
+		Name:      commitInfo,
+		Help:      "Git commit hash for the MinIO release.",
+		Type:      gaugeMetric,
+	}
+}
+
+func getS3TTFBDistributionMD() MetricDescription {
+	return MetricDescription{
+		Namespace: s3MetricNamespace,
+		Subsystem: timeSubsystem,
+		Name:      ttfbDistribution,
+		Help:      "Distribution of the time to first byte across API calls.",
+		Type:      gaugeMetric,
+	}
+}
+
+func getS3RequestDurationDistributionMD() MetricDescription {
+	return MetricDescription{
+		Namespace: s3MetricNamespace,
+		Subsystem: timeSubsystem,
+		Name:      reqDistribution,
+		Help:      "Distribution of the time taken to fully serve requests across API calls",
+		Type:      gaugeMetric,
+	}
+}
+
+func getS3RequestSizeDistributionMD() MetricDescription {
+	return MetricDescription{
+		Namespace: s3MetricNamespace,
+		Subsystem: trafficSubsystem,
+		Name:      receivedBytesDistribution,
+		Help:      "Distribution of the size of request bodies received across API calls",
+		Type:      gaugeMetric,
+	}
+}
+
+func getS3ResponseSizeDistributionMD() MetricDescription {
+	return MetricDescription{
+		Namespace: s3MetricNamespace,
+		Subsystem: trafficSubsystem,
+		Name:      sentBytesDistribution,
+		Help:      "Distribution of the size of responses sent across API calls",
+		Type:      gaugeMetric,
+	}
+}
+
+func getTLSHandshakeDurationDistributionMD() MetricDescription {
+	return MetricDescription{
+		Namespace: nodeMetricNamespace,
+		Subsystem: tlsSubsystem,
+		Name:      handshakeDistribution,
+		Help:      "Distribution of the time taken by TLS handshakes of client connections",
+		Type:      gaugeMetric,
+	}
+}
+
+func getTLSHandshakesMD() MetricDescription {
+	return MetricDescription{
+		Namespace: nodeMetricNamespace,
+		Subsystem: tlsSubsystem,
+		Name:      handshakesTotal,
+		Help:      "Total number of TLS handshakes of client connections by protocol version and cipher suite",
+		Type:      counterMetric,
+	}
+}
+
+func getTLSHandshakeFailuresMD() MetricDescription {
+	return MetricDescription{
+		Namespace: nodeMetricNamespace,
+		Subsystem: tlsSubsystem,
+		Name:      handshakeFailuresTotal,
+		Help:      "Total number of client connections closed before completing the TLS handshake",
+		Type:      counterMetric,
+	}
+}
+
+func getMinioFDOpenMD() MetricDescription {
+	return MetricDescription{
+		Namespace: nodeMetricNamespace,
+		Subsystem: fileDescriptorSubsystem,
+		Name:      openTotal,
+		Help:      "Total number of open file descriptors by the MinIO Server process.",
+		Type:      gaugeMetric,
+	}
+}
+
+func getMinioFDLimitMD() MetricDescription {
+	return MetricDescription{
+		Namespace: nodeMetricNamespace,
+		Subsystem: fileDescriptorSubsystem,
+		Name:      limitTotal,
+		Help:      "Limit on total number of open file descriptors for the MinIO Server process.",
+		Type:      gaugeMetric,
+	}
+}
+
+func getMinioProcessIOWriteBytesMD() MetricDescription {
+	return MetricDescription{
+		Namespace: nodeMetricNamespace,
+		Subsystem: ioSubsystem,
+		Name:      writeBytes,
+		Help:      "Total bytes written by the process to the underlying storage system, /proc/[pid]/io write_bytes",
+		Type:      counterMetric,
+	}
+}
+
+func getMinioProcessIOReadBytesMD() MetricDescription {
+	return MetricDescription{
+		Namespace: nodeMetricNamespace,
+		Subsystem: ioSubsystem,
+		Name:      readBytes,
+		Help:      "Total bytes read by the process from the underlying storage system, /proc/[pid]/io read_bytes",
+		Type:      counterMetric,
+	}
+}
+
+func getMinioProcessIOWriteCachedBytesMD() MetricDescription {
+	return MetricDescription{
+		Namespace: nodeMetricNamespace,
+		Subsystem: ioSubsystem,
+		Name:      wcharBytes,
+		Help:      "Total bytes written by the process to the underlying storage system including page cache, /proc/[pid]/io wchar",
+		Type:      counterMetric,
+	}
+}
+
+func getMinioProcessIOReadCachedBytesMD() MetricDescription {
+	return MetricDescription{
+		Namespace: nodeMetricNamespace,
+		Subsystem: ioSubsystem,
+		Name:      rcharBytes,
+		Help:      "Total bytes read by the process from the underlying storage system including cache, /proc/[pid]/io rchar",
+		Type:      counterMetric,
+	}
+}
+
+func getMinIOProcessSysCallRMD() MetricDescription {
+	return MetricDescription{
+		Namespace: nodeMetricNamespace,
+		Subsystem: sysCallSubsystem,
+		Name:      readTotal,
+		Help:      "Total read SysCalls to the kernel. /proc/[pid]/io syscr",
+		Type:      counterMetric,
+	}
+}
+
+func getMinIOProcessSysCallWMD() MetricDescription {
+	return MetricDescription{
+		Namespace: nodeMetricNamespace,
+		Subsystem: sysCallSubsystem,
+		Name:      writeTotal,
+		Help:      "Total write SysCalls to the kernel. /proc/[pid]/io syscw",
+		Type:      counterMetric,
+	}
+}
+
+func getMinIOGORoutineCountMD() MetricDescription {
+	return MetricDescription{
+		Namespace: nodeMetricNamespace,
+		Subsystem: goRoutines,
+		Name:      total,
+		Help:      "Total number of go routines running.",
+		Type:      gaugeMetric,
+	}
+}
+
+func getMinIOProcessStartTimeMD() MetricDescription {
+	return MetricDescription{
+		Namespace: nodeMetricNamespace,
+		Subsystem: processSubsystem,
+		Name:      startTime,
+		Help:      "Start time for MinIO process per node, time in seconds since Unix epoc.",
+		Type:      gaugeMetric,
+	}
+}
+
+func getMinIOProcessUptimeMD() MetricDescription {
 	return MetricDescription{
 		Namespace: nodeMetricNamespace,
 		Subsystem: processSubsystem,
@@ -969,7 +1703,9 @@ func getMinIOProcessCPUTime() MetricDescription {
 }
 
 func getMinioProcMetrics() *MetricsGroup {
-	mg := &MetricsGroup{}
+	mg := &MetricsGroup{
+		name: processMetricsGroup,
+	}
 	mg.RegisterRead(func(ctx context.Context) (metrics []Metric) {
 		if runtime.GOOS == "windows" {
 			return nil
@@ -1089,7 +1825,9 @@ func getMinioProcMetrics() *MetricsGroup {
 }
 
 func getGoMetrics() *MetricsGroup {
-	mg := &MetricsGroup{}
+	mg := &MetricsGroup{
+		name: goMetricsGroup,
+	}
 	mg.RegisterRead(func(ctx context.Context) (metrics []Metric) {
 		metrics = append(metrics, Metric{
 			Description: getMinIOGORoutineCountMD(),
@@ -1101,7 +1839,31 @@ func getGoMetrics() *MetricsGroup {
 }
 
 func getS3TTFBMetric() *MetricsGroup {
-	mg := &MetricsGroup{}
+	return getHistogramMetrics(httpMetricsGroup, httpRequestsDuration, getS3TTFBDistributionMD())
+}
+
+func getS3RequestDurationMetric() *MetricsGroup {
+	return getHistogramMetrics(httpMetricsGroup, httpRequestsTotalDuration, getS3RequestDurationDistributionMD())
+}
+
+func getS3RequestSizeMetric() *MetricsGroup {
+	return getHistogramMetrics(httpMetricsGroup, httpRequestsSize, getS3RequestSizeDistributionMD())
+}
+
+func getS3ResponseSizeMetric() *MetricsGroup {
+	return getHistogramMetrics(httpMetricsGroup, httpResponsesSize, getS3ResponseSizeDistributionMD())
+}
+
+func getTLSHandshakeDurationMetric() *MetricsGroup {
+	return getHistogramMetrics(networkMetricsGroup, tlsHandshakeDuration, getTLSHandshakeDurationDistributionMD())
+}
+
+// getHistogramMetrics converts the buckets of a prometheus histogram
+// into metrics of the given description, in the group called name.
+func getHistogramMetrics(name string, histogram *prometheus.HistogramVec, md MetricDescription) *MetricsGroup {
+	mg := &MetricsGroup{
+		name: name,
+	}
 	mg.RegisterRead(func(ctx context.Context) (metrics []Metric) {
 		// Read prometheus metric on this channel
 		ch := make(chan prometheus.Metric)
@@ -1126,7 +1888,7 @@ func getS3TTFBMetric() *MetricsGroup {
 					}
 					labels["le"] = fmt.Sprintf("%.3f", *b.UpperBound)
 					metric := Metric{
-						Description:    getS3TTFBDistributionMD(),
+						Description:    md,
 						VariableLabels: labels,
 						Value:          float64(b.GetCumulativeCount()),
 					}
@@ -1135,7 +1897,7 @@ func getS3TTFBMetric() *MetricsGroup {
 			}
 		}()
 
-		httpRequestsDuration.Collect(ch)
+		histogram.Collect(ch)
 		close(ch)
 		wg.Wait()
 		return
@@ -1173,8 +1935,60 @@ func getExpiryPendingTasksMD() MetricDescription {
 	}
 }
 
+func getTierTransitionedMD() MetricDescription {
+	return MetricDescription{
+		Namespace: nodeMetricNamespace,
+		Subsystem: ilmSubsystem,
+		Name:      tierTransitionedTotal,
+		Help:      "Total number of object versions transitioned to a tier since server start.",
+		Type:      counterMetric,
+	}
+}
+
+func getTierTransitionedBytesMD() MetricDescription {
+	return MetricDescription{
+		Namespace: nodeMetricNamespace,
+		Subsystem: ilmSubsystem,
+		Name:      tierTransitionedBytesTotal,
+		Help:      "Total bytes transitioned to a tier since server start.",
+		Type:      counterMetric,
+	}
+}
+
+func getTierTransitionFailedMD() MetricDescription {
+	return MetricDescription{
+		Namespace: nodeMetricNamespace,
+		Subsystem: ilmSubsystem,
+		Name:      tierTransitionFailedTotal,
+		Help:      "Total number of failed transitions to a tier since server start.",
+		Type:      counterMetric,
+	}
+}
+
+func getTierTransitionActiveMD() MetricDescription {
+	return MetricDescription{
+		Namespace: nodeMetricNamespace,
+		Subsystem: ilmSubsystem,
+		Name:      tierTransitionActiveTasks,
+		Help:      "Number of transitions to a tier in progress.",
+		Type:      gaugeMetric,
+	}
+}
+
+func getTierRestorePendingMD() MetricDescription {
+	return MetricDescription{
+		Namespace: nodeMetricNamespace,
+		Subsystem: ilmSubsystem,
+		Name:      tierRestorePendingTasks,
+		Help:      "Number of restores from a tier in progress.",
+		Type:      gaugeMetric,
+	}
+}
+
 func getILMNodeMetrics() *MetricsGroup {
-	mg := &MetricsGroup{}
+	mg := &MetricsGroup{
+		name: ilmMetricsGroup,
+	}
 	mg.RegisterRead(func(_ context.Context) []Metric {
 		expPendingTasks := Metric{
 			Description: getExpiryPendingTasksMD(),
@@ -1192,18 +2006,24 @@ func getILMNodeMetrics() *MetricsGroup {
 			trPendingTasks.Value = float64(globalTransitionState.PendingTasks())
 			trActiveTasks.Value = float64(globalTransitionState.ActiveTasks())
 		}
-		return []Metric{
+		return append([]Metric{
 			expPendingTasks,
 			trPendingTasks,
 			trActiveTasks,
-		}
+		}, globalTierMetrics.metrics()...)
 	})
 	return mg
 }
 
 func getScannerNodeMetrics() *MetricsGroup {
-	mg := &MetricsGroup{}
+	mg := &MetricsGroup{
+		name: scannerMetricsGroup,
+	}
 	mg.RegisterRead(func(_ context.Context) []Metric {
+		var paused float64
+		if globalScannerControl.paused() {
+			paused = 1
+		}
 		metrics := []Metric{
 			{
 				Description: MetricDescription{
@@ -1233,27 +2053,67 @@ func getScannerNodeMetrics() *MetricsGroup {
 					Help:      "Total number of directories scanned since server start.",
 					Type:      counterMetric,
 				},
-				Value: float64(atomic.LoadUint64(&globalScannerStats.accFolders)),
+				Value: float64(atomic.LoadUint64(&globalScannerStats.accFolders)),
+			},
+			{
+				Description: MetricDescription{
+					Namespace: nodeMetricNamespace,
+					Subsystem: scannerSubsystem,
+					Name:      "bucket_scans_started",
+					Help:      "Total number of bucket scans started since server start",
+					Type:      counterMetric,
+				},
+				Value: float64(atomic.LoadUint64(&globalScannerStats.bucketsStarted)),
+			},
+			{
+				Description: MetricDescription{
+					Namespace: nodeMetricNamespace,
+					Subsystem: scannerSubsystem,
+					Name:      "bucket_scans_finished",
+					Help:      "Total number of bucket scans finished since server start",
+					Type:      counterMetric,
+				},
+				Value: float64(atomic.LoadUint64(&globalScannerStats.bucketsFinished)),
+			},
+			{
+				Description: MetricDescription{
+					Namespace: nodeMetricNamespace,
+					Subsystem: scannerSubsystem,
+					Name:      "cycle",
+					Help:      "Latest scanner cycle started on this node",
+					Type:      gaugeMetric,
+				},
+				Value: float64(atomic.LoadUint64(&globalScannerStats.cycle)),
+			},
+			{
+				Description: MetricDescription{
+					Namespace: nodeMetricNamespace,
+					Subsystem: scannerSubsystem,
+					Name:      "cycle_objects_scanned",
+					Help:      "Number of objects scanned in the current scanner cycle",
+					Type:      gaugeMetric,
+				},
+				Value: float64(atomic.LoadUint64(&globalScannerStats.cycleObjects)),
 			},
 			{
 				Description: MetricDescription{
 					Namespace: nodeMetricNamespace,
 					Subsystem: scannerSubsystem,
-					Name:      "bucket_scans_started",
-					Help:      "Total number of bucket scans started since server start",
-					Type:      counterMetric,
+					Name:      "cycle_bucket_scans_finished",
+					Help:      "Number of bucket scans finished in the current scanner cycle",
+					Type:      gaugeMetric,
 				},
-				Value: float64(atomic.LoadUint64(&globalScannerStats.bucketsStarted)),
+				Value: float64(atomic.LoadUint64(&globalScannerStats.cycleBucketsFinished)),
 			},
 			{
 				Description: MetricDescription{
 					Namespace: nodeMetricNamespace,
 					Subsystem: scannerSubsystem,
-					Name:      "bucket_scans_finished",
-					Help:      "Total number of bucket scans finished since server start",
-					Type:      counterMetric,
+					Name:      "paused",
+					Help:      "1 if the scanner is paused outside of its time windows, 0 otherwise",
+					Type:      gaugeMetric,
 				},
-				Value: float64(atomic.LoadUint64(&globalScannerStats.bucketsFinished)),
+				Value: paused,
 			},
 			{
 				Description: MetricDescription{
@@ -1289,7 +2149,9 @@ func getScannerNodeMetrics() *MetricsGroup {
 }
 
 func getIAMNodeMetrics() *MetricsGroup {
-	mg := &MetricsGroup{}
+	mg := &MetricsGroup{
+		name: iamMetricsGroup,
+	}
 	mg.RegisterRead(func(_ context.Context) (metrics []Metric) {
 		lastSyncTime := atomic.LoadUint64(&globalIAMSys.LastRefreshTimeUnixNano)
 		var sinceLastSyncMillis uint64
@@ -1344,8 +2206,375 @@ func getIAMNodeMetrics() *MetricsGroup {
 	return mg
 }
 
+func getMultipartNodeMetrics() *MetricsGroup {
+	mg := &MetricsGroup{
+		name: multipartMetricsGroup,
+	}
+	mg.RegisterRead(func(_ context.Context) []Metric {
+		return []Metric{
+			{
+				Description: MetricDescription{
+					Namespace: nodeMetricNamespace,
+					Subsystem: multipartSubsystem,
+					Name:      "incomplete_uploads",
+					Help:      "Number of incomplete multipart uploads found during the last stale uploads cleanup",
+					Type:      gaugeMetric,
+				},
+				Value: float64(globalStaleUploadsStats.activeUploads()),
+			},
+			{
+				Description: MetricDescription{
+					Namespace: nodeMetricNamespace,
+					Subsystem: multipartSubsystem,
+					Name:      "aborted_uploads_total",
+					Help:      "Total number of stale multipart uploads aborted since server start",
+					Type:      counterMetric,
+				},
+				Value: float64(atomic.LoadUint64(&globalStaleUploadsStats.abortedUploads)),
+			},
+			{
+				Description: MetricDescription{
+					Namespace: nodeMetricNamespace,
+					Subsystem: multipartSubsystem,
+					Name:      "reclaimed_bytes_total",
+					Help:      "Total number of bytes reclaimed by aborting stale multipart uploads since server start",
+					Type:      counterMetric,
+				},
+				Value: float64(atomic.LoadUint64(&globalStaleUploadsStats.reclaimedBytes)),
+			},
+		}
+	})
+	return mg
+}
+
+func getInlineObjectNodeMetrics() *MetricsGroup {
+	mg := &MetricsGroup{
+		name: objectsMetricsGroup,
+	}
+	mg.RegisterRead(func(_ context.Context) []Metric {
+		return []Metric{
+			{
+				Description: MetricDescription{
+					Namespace: nodeMetricNamespace,
+					Subsystem: objectsSubsystem,
+					Name:      "inline_total",
+					Help:      "Total number of objects written with their data inlined in the object metadata since server start",
+					Type:      counterMetric,
+				},
+				Value: float64(atomic.LoadUint64(&globalInlineObjectStats.inline)),
+			},
+			{
+				Description: MetricDescription{
+					Namespace: nodeMetricNamespace,
+					Subsystem: objectsSubsystem,
+					Name:      "non_inline_total",
+					Help:      "Total number of objects written with their data stored apart from the object metadata since server start",
+					Type:      counterMetric,
+				},
+				Value: float64(atomic.LoadUint64(&globalInlineObjectStats.nonInline)),
+			},
+		}
+	})
+	return mg
+}
+
+func getRangePrefetchNodeMetrics() *MetricsGroup {
+	mg := &MetricsGroup{
+		name: objectsMetricsGroup,
+	}
+	mg.RegisterRead(func(_ context.Context) []Metric {
+		return []Metric{
+			{
+				Description: MetricDescription{
+					Namespace: nodeMetricNamespace,
+					Subsystem: prefetchSubsystem,
+					Name:      hitsTotal,
+					Help:      "Total number of sequential range reads served from prefetched data since server start",
+					Type:      counterMetric,
+				},
+				Value: float64(atomic.LoadUint64(&globalRangePrefetcher.hits)),
+			},
+			{
+				Description: MetricDescription{
+					Namespace: nodeMetricNamespace,
+					Subsystem: prefetchSubsystem,
+					Name:      missedTotal,
+					Help:      "Total number of sequential range reads not served from prefetched data since server start",
+					Type:      counterMetric,
+				},
+				Value: float64(atomic.LoadUint64(&globalRangePrefetcher.misses)),
+			},
+			{
+				Description: MetricDescription{
+					Namespace: nodeMetricNamespace,
+					Subsystem: prefetchSubsystem,
+					Name:      "bytes_total",
+					Help:      "Total number of bytes prefetched for sequential range reads since server start",
+					Type:      counterMetric,
+				},
+				Value: float64(atomic.LoadUint64(&globalRangePrefetcher.bytes)),
+			},
+			{
+				Description: MetricDescription{
+					Namespace: nodeMetricNamespace,
+					Subsystem: prefetchSubsystem,
+					Name:      "discarded_bytes_total",
+					Help:      "Total number of prefetched bytes discarded without being read since server start",
+					Type:      counterMetric,
+				},
+				Value: float64(atomic.LoadUint64(&globalRangePrefetcher.discardedBytes)),
+			},
+		}
+	})
+	return mg
+}
+
+func getPackNodeMetrics() *MetricsGroup {
+	mg := &MetricsGroup{
+		name: packMetricsGroup,
+	}
+	mg.RegisterRead(func(_ context.Context) []Metric {
+		return []Metric{
+			{
+				Description: MetricDescription{
+					Namespace: nodeMetricNamespace,
+					Subsystem: packSubsystem,
+					Name:      "objects_packed_total",
+					Help:      "Total number of objects packed into containers by this node since server start",
+					Type:      counterMetric,
+				},
+				Value: float64(atomic.LoadUint64(&globalPackStats.objectsPacked)),
+			},
+			{
+				Description: MetricDescription{
+					Namespace: nodeMetricNamespace,
+					Subsystem: packSubsystem,
+					Name:      "bytes_packed_total",
+					Help:      "Total number of bytes of objects packed into containers by this node since server start",
+					Type:      counterMetric,
+				},
+				Value: float64(atomic.LoadUint64(&globalPackStats.bytesPacked)),
+			},
+			{
+				Description: MetricDescription{
+					Namespace: nodeMetricNamespace,
+					Subsystem: packSubsystem,
+					Name:      "containers_written_total",
+					Help:      "Total number of pack containers written by this node since server start",
+					Type:      counterMetric,
+				},
+				Value: float64(atomic.LoadUint64(&globalPackStats.containersWritten)),
+			},
+			{
+				Description: MetricDescription{
+					Namespace: nodeMetricNamespace,
+					Subsystem: packSubsystem,
+					Name:      "containers_compacted_total",
+					Help:      "Total number of pack containers compacted by this node since server start",
+					Type:      counterMetric,
+				},
+				Value: float64(atomic.LoadUint64(&globalPackStats.containersCompacted)),
+			},
+			{
+				Description: MetricDescription{
+					Namespace: nodeMetricNamespace,
+					Subsystem: packSubsystem,
+					Name:      "bytes_reclaimed_total",
+					Help:      "Total number of bytes of deleted packed objects reclaimed by this node since server start",
+					Type:      counterMetric,
+				},
+				Value: float64(atomic.LoadUint64(&globalPackStats.bytesReclaimed)),
+			},
+		}
+	})
+	return mg
+}
+
+func getKMSNodeMetrics() *MetricsGroup {
+	mg := &MetricsGroup{
+		name: kmsMetricsGroup,
+	}
+	mg.RegisterRead(func(_ context.Context) (metrics []Metric) {
+		requests, errors := globalKMSStats.load()
+		for endpoint, n := range requests {
+			metrics = append(metrics, Metric{
+				Description: MetricDescription{
+					Namespace: nodeMetricNamespace,
+					Subsystem: kmsSubsystem,
+					Name:      requestsTotal,
+					Help:      "Total number of requests sent to the KMS endpoint by this node since server start",
+					Type:      counterMetric,
+				},
+				VariableLabels: map[string]string{"endpoint": endpoint},
+				Value:          float64(n),
+			})
+			metrics = append(metrics, Metric{
+				Description: MetricDescription{
+					Namespace: nodeMetricNamespace,
+					Subsystem: kmsSubsystem,
+					Name:      errorsTotal,
+					Help:      "Total number of failed requests sent to the KMS endpoint by this node since server start",
+					Type:      counterMetric,
+				},
+				VariableLabels: map[string]string{"endpoint": endpoint},
+				Value:          float64(errors[endpoint]),
+			})
+		}
+		if hc, ok := GlobalKMS.(kms.HealthChecker); ok {
+			for endpoint, online := range hc.Health() {
+				var value float64
+				if online {
+					value = 1
+				}
+				metrics = append(metrics, Metric{
+					Description: MetricDescription{
+						Namespace: nodeMetricNamespace,
+						Subsystem: kmsSubsystem,
+						Name:      "online",
+						Help:      "Is 1 if the KMS endpoint is reachable from this node, 0 otherwise",
+						Type:      gaugeMetric,
+					},
+					VariableLabels: map[string]string{"endpoint": endpoint},
+					Value:          value,
+				})
+			}
+		}
+		return metrics
+	})
+	return mg
+}
+
+func getNotifyNodeMetrics() *MetricsGroup {
+	mg := &MetricsGroup{
+		name: notifyMetricsGroup,
+	}
+	mg.RegisterRead(func(_ context.Context) (metrics []Metric) {
+		retried, deadLettered, dropped := globalEventDeliveryStats.load()
+		for _, m := range []struct {
+			name   MetricName
+			help   string
+			counts map[string]uint64
+		}{
+			{"events_retried_total", "Total number of attempts to deliver again events the notification target failed to accept", retried},
+			{"events_dead_lettered_total", "Total number of events the notification target failed to accept sent to the dead-letter target or bucket", deadLettered},
+			{"events_dropped_total", "Total number of events the notification target failed to accept which were dropped", dropped},
+		} {
+			for id, n := range m.counts {
+				metrics = append(metrics, Metric{
+					Description: MetricDescription{
+						Namespace: nodeMetricNamespace,
+						Subsystem: notifySubsystem,
+						Name:      m.name,
+						Help:      m.help,
+						Type:      counterMetric,
+					},
+					VariableLabels: map[string]string{"target_id": id},
+					Value:          float64(n),
+				})
+			}
+		}
+		for id, st := range globalConfigTargetList.Stats() {
+			labels := map[string]string{"target_id": id.String()}
+			metrics = append(metrics, Metric{
+				Description: MetricDescription{
+					Namespace: nodeMetricNamespace,
+					Subsystem: notifySubsystem,
+					Name:      "target_queue_length",
+					Help:      "Number of events waiting in the queue store of the notification target",
+					Type:      gaugeMetric,
+				},
+				VariableLabels: labels,
+				Value:          float64(st.QueueLength),
+			}, Metric{
+				Description: MetricDescription{
+					Namespace: nodeMetricNamespace,
+					Subsystem: notifySubsystem,
+					Name:      "target_events_sent_total",
+					Help:      "Total number of events delivered to the notification target",
+					Type:      counterMetric,
+				},
+				VariableLabels: labels,
+				Value:          float64(st.EventsSent),
+			}, Metric{
+				Description: MetricDescription{
+					Namespace: nodeMetricNamespace,
+					Subsystem: notifySubsystem,
+					Name:      "target_events_failed_total",
+					Help:      "Total number of events the notification target failed to accept",
+					Type:      counterMetric,
+				},
+				VariableLabels: labels,
+				Value:          float64(st.EventsFailed),
+			})
+			if !st.LastSuccess.IsZero() {
+				metrics = append(metrics, Metric{
+					Description: MetricDescription{
+						Namespace: nodeMetricNamespace,
+						Subsystem: notifySubsystem,
+						Name:      "target_last_success_timestamp_seconds",
+						Help:      "Unix time of the last event delivered to the notification target",
+						Type:      gaugeMetric,
+					},
+					VariableLabels: labels,
+					Value:          float64(st.LastSuccess.Unix()),
+				})
+			}
+		}
+		return metrics
+	})
+	return mg
+}
+
+func getAuditNodeMetrics() *MetricsGroup {
+	mg := &MetricsGroup{
+		name: auditMetricsGroup,
+	}
+	mg.RegisterRead(func(_ context.Context) (metrics []Metric) {
+		for name, st := range logger.AuditTargetStats() {
+			labels := map[string]string{"target_id": name}
+			for _, m := range []struct {
+				name  MetricName
+				help  string
+				typ   MetricType
+				value float64
+			}{
+				{"target_queue_length", "Number of audit logs waiting to be sent to the audit target", gaugeMetric, float64(st.QueueLength)},
+				{"target_messages_total", "Total number of audit logs sent to the audit target", counterMetric, float64(st.TotalMessages)},
+				{"target_failed_messages_total", "Total number of audit logs the audit target failed to accept", counterMetric, float64(st.FailedMessages)},
+				{"target_dropped_messages_total", "Total number of audit logs dropped as the queue of the audit target was full", counterMetric, float64(st.DroppedMessages)},
+			} {
+				metrics = append(metrics, Metric{
+					Description: MetricDescription{
+						Namespace: nodeMetricNamespace,
+						Subsystem: auditSubsystem,
+						Name:      m.name,
+						Help:      m.help,
+						Type:      m.typ,
+					},
+					VariableLabels: labels,
+					Value:          m.value,
+				})
+			}
+		}
+		return metrics
+	})
+	return mg
+}
+
+func getKMSRequestDurationMetric() *MetricsGroup {
+	return getHistogramMetrics(kmsMetricsGroup, kmsRequestDuration, MetricDescription{
+		Namespace: nodeMetricNamespace,
+		Subsystem: kmsSubsystem,
+		Name:      reqDistribution,
+		Help:      "Distribution of the time taken by requests to the KMS endpoint",
+		Type:      gaugeMetric,
+	})
+}
+
 func getMinioVersionMetrics() *MetricsGroup {
-	mg := &MetricsGroup{}
+	mg := &MetricsGroup{
+		name: versionMetricsGroup,
+	}
 	mg.RegisterRead(func(_ context.Context) (metrics []Metric) {
 		metrics = append(metrics, Metric{
 			Description:    getMinIOCommitMD(),
@@ -1360,29 +2589,173 @@ func getMinioVersionMetrics() *MetricsGroup {
 	return mg
 }
 
-func getNodeHealthMetrics() *MetricsGroup {
-	mg := &MetricsGroup{}
+func getNodeHealthMetrics() *MetricsGroup {
+	mg := &MetricsGroup{
+		name: healthMetricsGroup,
+	}
+	mg.RegisterRead(func(_ context.Context) (metrics []Metric) {
+		if globalIsGateway {
+			return
+		}
+		metrics = make([]Metric, 0, 16)
+		nodesUp, nodesDown := globalNotificationSys.GetPeerOnlineCount()
+		metrics = append(metrics, Metric{
+			Description: getNodeOnlineTotalMD(),
+			Value:       float64(nodesUp),
+		})
+		metrics = append(metrics, Metric{
+			Description: getNodeOfflineTotalMD(),
+			Value:       float64(nodesDown),
+		})
+		return
+	})
+	return mg
+}
+
+func getBitrotScrubNodeMetrics() *MetricsGroup {
+	mg := &MetricsGroup{
+		name: healMetricsGroup,
+	}
+	mg.RegisterRead(func(_ context.Context) []Metric {
+		return []Metric{
+			{
+				Description: MetricDescription{
+					Namespace: nodeMetricNamespace,
+					Subsystem: scrubSubsystem,
+					Name:      "active_drives",
+					Help:      "Number of drives being scrubbed for bitrot",
+					Type:      gaugeMetric,
+				},
+				Value: float64(atomic.LoadInt64(&globalBitrotScrubStats.activeDrives)),
+			},
+			{
+				Description: MetricDescription{
+					Namespace: nodeMetricNamespace,
+					Subsystem: scrubSubsystem,
+					Name:      "objects_verified_total",
+					Help:      "Total number of object versions verified by bitrot scrubbing since server start",
+					Type:      counterMetric,
+				},
+				Value: float64(atomic.LoadUint64(&globalBitrotScrubStats.objectsVerified)),
+			},
+			{
+				Description: MetricDescription{
+					Namespace: nodeMetricNamespace,
+					Subsystem: scrubSubsystem,
+					Name:      "bytes_verified_total",
+					Help:      "Total number of bytes verified by bitrot scrubbing since server start",
+					Type:      counterMetric,
+				},
+				Value: float64(atomic.LoadUint64(&globalBitrotScrubStats.bytesVerified)),
+			},
+			{
+				Description: MetricDescription{
+					Namespace: nodeMetricNamespace,
+					Subsystem: scrubSubsystem,
+					Name:      "corrupted_total",
+					Help:      "Total number of corrupted object versions found by bitrot scrubbing and queued for healing since server start",
+					Type:      counterMetric,
+				},
+				Value: float64(atomic.LoadUint64(&globalBitrotScrubStats.corrupted)),
+			},
+		}
+	})
+	return mg
+}
+
+func getRebalanceNodeMetrics() *MetricsGroup {
+	mg := &MetricsGroup{
+		name: rebalanceMetricsGroup,
+	}
 	mg.RegisterRead(func(_ context.Context) (metrics []Metric) {
-		if globalIsGateway {
+		pools, ok := newObjectLayerFn().(*erasureServerPools)
+		if !ok {
 			return
 		}
-		metrics = make([]Metric, 0, 16)
-		nodesUp, nodesDown := globalNotificationSys.GetPeerOnlineCount()
-		metrics = append(metrics, Metric{
-			Description: getNodeOnlineTotalMD(),
-			Value:       float64(nodesUp),
-		})
-		metrics = append(metrics, Metric{
-			Description: getNodeOfflineTotalMD(),
-			Value:       float64(nodesDown),
-		})
+		for idx, ps := range pools.localRebalanceStats() {
+			labels := map[string]string{"pool": strconv.Itoa(idx)}
+			var active float64
+			if ps.Status == rebalStarted {
+				active = 1
+			}
+			metrics = append(metrics, []Metric{
+				{
+					Description: MetricDescription{
+						Namespace: nodeMetricNamespace,
+						Subsystem: rebalanceSubsystem,
+						Name:      "active",
+						Help:      "1 while the pool is being rebalanced, 0 otherwise",
+						Type:      gaugeMetric,
+					},
+					Value:          active,
+					VariableLabels: labels,
+				},
+				{
+					Description: MetricDescription{
+						Namespace: nodeMetricNamespace,
+						Subsystem: rebalanceSubsystem,
+						Name:      "objects_moved_total",
+						Help:      "Total number of objects moved out of the pool by the current rebalance",
+						Type:      counterMetric,
+					},
+					Value:          float64(ps.NumObjects),
+					VariableLabels: labels,
+				},
+				{
+					Description: MetricDescription{
+						Namespace: nodeMetricNamespace,
+						Subsystem: rebalanceSubsystem,
+						Name:      "versions_moved_total",
+						Help:      "Total number of object versions moved out of the pool by the current rebalance",
+						Type:      counterMetric,
+					},
+					Value:          float64(ps.NumVersions),
+					VariableLabels: labels,
+				},
+				{
+					Description: MetricDescription{
+						Namespace: nodeMetricNamespace,
+						Subsystem: rebalanceSubsystem,
+						Name:      "bytes_moved_total",
+						Help:      "Total number of bytes moved out of the pool by the current rebalance",
+						Type:      counterMetric,
+					},
+					Value:          float64(ps.Bytes),
+					VariableLabels: labels,
+				},
+				{
+					Description: MetricDescription{
+						Namespace: nodeMetricNamespace,
+						Subsystem: rebalanceSubsystem,
+						Name:      "failed_objects_total",
+						Help:      "Total number of objects which failed to be moved out of the pool by the current rebalance",
+						Type:      counterMetric,
+					},
+					Value:          float64(ps.FailedObjects),
+					VariableLabels: labels,
+				},
+				{
+					Description: MetricDescription{
+						Namespace: nodeMetricNamespace,
+						Subsystem: rebalanceSubsystem,
+						Name:      "free_ratio",
+						Help:      "Estimated fraction of free space of the pool being rebalanced",
+						Type:      gaugeMetric,
+					},
+					Value:          ps.percentFree(),
+					VariableLabels: labels,
+				},
+			}...)
+		}
 		return
 	})
 	return mg
 }
 
 func getMinioHealingMetrics() *MetricsGroup {
-	mg := &MetricsGroup{}
+	mg := &MetricsGroup{
+		name: healMetricsGroup,
+	}
 	mg.RegisterRead(func(_ context.Context) (metrics []Metric) {
 		metrics = make([]Metric, 0, 5)
 		if globalIsGateway {
@@ -1453,6 +2826,7 @@ func getObjectsScanned(seq *healSequence) (m []Metric) {
 
 func getCacheMetrics() *MetricsGroup {
 	mg := &MetricsGroup{
+		name:          cacheMetricsGroup,
 		cacheInterval: 10 * time.Second,
 	}
 	mg.RegisterRead(func(ctx context.Context) (metrics []Metric) {
@@ -1502,11 +2876,14 @@ func getCacheMetrics() *MetricsGroup {
 }
 
 func getHTTPMetrics() *MetricsGroup {
-	mg := &MetricsGroup{}
+	mg := &MetricsGroup{
+		name: httpMetricsGroup,
+	}
 	mg.RegisterRead(func(ctx context.Context) (metrics []Metric) {
 		httpStats := globalHTTPStats.toServerHTTPStats()
 		metrics = make([]Metric, 0, 3+
 			len(httpStats.CurrentS3Requests.APIStats)+
+			len(httpStats.PeakS3Requests.APIStats)+
 			len(httpStats.TotalS3Requests.APIStats)+
 			len(httpStats.TotalS3Errors.APIStats)+
 			len(httpStats.TotalS35xxErrors.APIStats)+
@@ -1527,6 +2904,10 @@ func getHTTPMetrics() *MetricsGroup {
 			Description: getS3RejectedInvalidRequestsTotalMD(),
 			Value:       float64(httpStats.TotalS3RejectedInvalid),
 		})
+		metrics = append(metrics, Metric{
+			Description: getS3RejectedExpiredRequestsTotalMD(),
+			Value:       float64(httpStats.TotalS3RejectedExpired),
+		})
 		metrics = append(metrics, Metric{
 			Description: getS3RequestsInQueueMD(),
 			Value:       float64(httpStats.S3RequestsInQueue),
@@ -1543,6 +2924,13 @@ func getHTTPMetrics() *MetricsGroup {
 				VariableLabels: map[string]string{"api": api},
 			})
 		}
+		for api, value := range httpStats.PeakS3Requests.APIStats {
+			metrics = append(metrics, Metric{
+				Description:    getS3RequestsInFlightPeakMD(),
+				Value:          float64(value),
+				VariableLabels: map[string]string{"api": api},
+			})
+		}
 		for api, value := range httpStats.TotalS3Requests.APIStats {
 			metrics = append(metrics, Metric{
 				Description:    getS3RequestsTotalMD(),
@@ -1578,13 +2966,166 @@ func getHTTPMetrics() *MetricsGroup {
 				VariableLabels: map[string]string{"api": api},
 			})
 		}
+		for cause, stats := range map[string]ServerHTTPAPIStats{
+			"client":   httpStats.TotalS3CanceledClient,
+			"shutdown": httpStats.TotalS3CanceledShutdown,
+			"deadline": httpStats.TotalS3DeadlineExceeded,
+		} {
+			for api, value := range stats.APIStats {
+				metrics = append(metrics, Metric{
+					Description:    getS3RequestsCanceledCausesMD(),
+					Value:          float64(value),
+					VariableLabels: map[string]string{"api": api, "cause": cause},
+				})
+			}
+		}
+		for api, value := range httpStats.TotalS3RateLimited.APIStats {
+			metrics = append(metrics, Metric{
+				Description:    getS3RequestsRateLimitedMD(),
+				Value:          float64(value),
+				VariableLabels: map[string]string{"api": api},
+			})
+		}
+		for _, throttle := range globalAccessKeyThrottles.load() {
+			metrics = append(metrics, Metric{
+				Description:    getS3RequestsAccessKeyThrottledMD(),
+				Value:          float64(throttle.throttledRequests),
+				VariableLabels: map[string]string{"access_key": throttle.accessKey},
+			})
+		}
+		for api, value := range httpStats.TotalS3MaxedOut.APIStats {
+			metrics = append(metrics, Metric{
+				Description:    getS3RequestsMaxedOutMD(),
+				Value:          float64(value),
+				VariableLabels: map[string]string{"api": api},
+			})
+		}
+		for api, value := range httpStats.TotalS33xx.APIStats {
+			metrics = append(metrics, Metric{
+				Description:    getS3Requests3xxMD(),
+				Value:          float64(value),
+				VariableLabels: map[string]string{"api": api},
+			})
+		}
+		for api, value := range httpStats.TotalS3AuthenticatedRequests.APIStats {
+			metrics = append(metrics, Metric{
+				Description:    getS3AuthenticatedRequestsMD(),
+				Value:          float64(value),
+				VariableLabels: map[string]string{"api": api},
+			})
+		}
+		for api, value := range httpStats.TotalS3AnonymousRequests.APIStats {
+			metrics = append(metrics, Metric{
+				Description:    getS3AnonymousRequestsMD(),
+				Value:          float64(value),
+				VariableLabels: map[string]string{"api": api},
+			})
+		}
+		for typ, stats := range httpStats.TotalS3EncryptionRequests {
+			for api, value := range stats.APIStats {
+				metrics = append(metrics, Metric{
+					Description:    getS3EncryptionRequestsMD(),
+					Value:          float64(value),
+					VariableLabels: map[string]string{"api": api, "encryption": typ},
+				})
+			}
+		}
+		for api, codes := range httpStats.TotalS3StatusCodes {
+			for code, value := range codes {
+				metrics = append(metrics, Metric{
+					Description:    getS3RequestsStatusCodesMD(),
+					Value:          float64(value),
+					VariableLabels: map[string]string{"api": api, "code": strconv.Itoa(code)},
+				})
+			}
+		}
+		for bucket, stats := range httpStats.BucketStats {
+			metrics = append(metrics, Metric{
+				Description:    getBucketRequestsTotalMD(),
+				Value:          float64(stats.TotalS3Requests),
+				VariableLabels: map[string]string{"bucket": bucket},
+			})
+			metrics = append(metrics, Metric{
+				Description:    getBucketRequests4xxErrorsMD(),
+				Value:          float64(stats.TotalS34xxErrors),
+				VariableLabels: map[string]string{"bucket": bucket},
+			})
+			metrics = append(metrics, Metric{
+				Description:    getBucketRequests5xxErrorsMD(),
+				Value:          float64(stats.TotalS35xxErrors),
+				VariableLabels: map[string]string{"bucket": bucket},
+			})
+			metrics = append(metrics, Metric{
+				Description:    getBucketRequestsCanceledMD(),
+				Value:          float64(stats.TotalS3Canceled),
+				VariableLabels: map[string]string{"bucket": bucket},
+			})
+		}
+		for _, stat := range globalBucketTransformStats.load() {
+			labels := map[string]string{"bucket": stat.bucket, "access_point": stat.accessPoint}
+			metrics = append(metrics, Metric{
+				Description:    getBucketTransformRequestsMD(),
+				Value:          float64(stat.requests),
+				VariableLabels: labels,
+			})
+			metrics = append(metrics, Metric{
+				Description:    getBucketTransformFailuresMD(),
+				Value:          float64(stat.failures),
+				VariableLabels: labels,
+			})
+		}
+		return
+	})
+	return mg
+}
+
+// getAccessKeyMetrics reports the access keys with the most requests,
+// limited by EnvAccessKeyMetricsLimit to bound the number of series.
+func getAccessKeyMetrics() *MetricsGroup {
+	mg := &MetricsGroup{
+		name: accessKeyMetricsGroup,
+	}
+	mg.RegisterRead(func(ctx context.Context) (metrics []Metric) {
+		limit := accessKeyMetricsLimit()
+		if limit == 0 {
+			return
+		}
+		stats := globalHTTPStats.toServerAccessKeyStats()
+		accessKeys := topAccessKeys(stats, limit)
+		metrics = make([]Metric, 0, 4*len(accessKeys))
+		for _, accessKey := range accessKeys {
+			s := stats[accessKey]
+			labels := map[string]string{"access_key": accessKey}
+			metrics = append(metrics, Metric{
+				Description:    getAccessKeyRequestsTotalMD(),
+				Value:          float64(s.TotalS3Requests),
+				VariableLabels: labels,
+			})
+			metrics = append(metrics, Metric{
+				Description:    getAccessKeyErrorsTotalMD(),
+				Value:          float64(s.TotalS3Errors),
+				VariableLabels: labels,
+			})
+			metrics = append(metrics, Metric{
+				Description:    getAccessKeyReceivedBytesMD(),
+				Value:          float64(s.S3InputBytes),
+				VariableLabels: labels,
+			})
+			metrics = append(metrics, Metric{
+				Description:    getAccessKeySentBytesMD(),
+				Value:          float64(s.S3OutputBytes),
+				VariableLabels: labels,
+			})
+		}
 		return
 	})
 	return mg
 }
 
 func getNetworkMetrics() *MetricsGroup {
-	mg := &MetricsGroup{}
+	mg := &MetricsGroup{
+		name: networkMetricsGroup,
+	}
 	mg.RegisterRead(func(ctx context.Context) (metrics []Metric) {
 		metrics = make([]Metric, 0, 10)
 		connStats := globalConnStats.toServerConnStats()
@@ -1601,6 +3142,18 @@ func getNetworkMetrics() *MetricsGroup {
 				Description: getInterNodeReceivedBytesMD(),
 				Value:       float64(connStats.TotalInputBytes),
 			})
+			metrics = append(metrics, Metric{
+				Description: getInterNodeConnectionsOpenedMD(),
+				Value:       float64(globalInternodeDialStats.Opened()),
+			})
+			metrics = append(metrics, Metric{
+				Description: getInterNodeDialErrorsMD(),
+				Value:       float64(globalInternodeDialStats.Failed()),
+			})
+			metrics = append(metrics, Metric{
+				Description: getInterNodeOpenConnectionsMD(),
+				Value:       float64(globalInternodeDialStats.Open()),
+			})
 		}
 		metrics = append(metrics, Metric{
 			Description: getS3SentBytesMD(),
@@ -1610,6 +3163,56 @@ func getNetworkMetrics() *MetricsGroup {
 			Description: getS3ReceivedBytesMD(),
 			Value:       float64(connStats.S3InputBytes),
 		})
+		metrics = append(metrics, Metric{
+			Description: getTrafficWraparoundsMD(),
+			Value:       float64(connStats.Wraparounds),
+		})
+		metrics = append(metrics, Metric{
+			Description: getTrafficOpenConnectionsMD(),
+			Value:       float64(connStats.OpenConnections),
+		})
+		handshakes, failures := globalConnStats.tls.load()
+		for h, n := range handshakes {
+			metrics = append(metrics, Metric{
+				Description:    getTLSHandshakesMD(),
+				Value:          float64(n),
+				VariableLabels: map[string]string{"version": h.version, "cipher": h.cipher},
+			})
+		}
+		metrics = append(metrics, Metric{
+			Description: getTLSHandshakeFailuresMD(),
+			Value:       float64(failures),
+		})
+		for bucket, stats := range globalConnStats.toServerBucketConnStats() {
+			metrics = append(metrics, Metric{
+				Description:    getBucketTrafficSentBytesMD(),
+				Value:          float64(stats.S3OutputBytes),
+				VariableLabels: map[string]string{"bucket": bucket},
+			})
+			metrics = append(metrics, Metric{
+				Description:    getBucketTrafficReceivedBytesMD(),
+				Value:          float64(stats.S3InputBytes),
+				VariableLabels: map[string]string{"bucket": bucket},
+			})
+		}
+		for _, throttle := range globalBucketThrottles.load() {
+			labels := map[string]string{"bucket": throttle.bucket, "direction": throttle.direction}
+			metrics = append(metrics, Metric{
+				Description:    getBucketTrafficLimitMD(),
+				Value:          throttle.limit,
+				VariableLabels: labels,
+			})
+			metrics = append(metrics, Metric{
+				Description:    getBucketTrafficThrottledSecondsMD(),
+				Value:          throttle.throttled.Seconds(),
+				VariableLabels: labels,
+			})
+			metrics = append(metrics, Metric{
+				Description:    getBucketTrafficThrottledRequestsMD(),
+				Value:          float64(throttle.waitingRequests),
+				VariableLabels: labels,
+			})
+		}
 		return
 	})
 	return mg
@@ -1617,6 +3220,7 @@ func getNetworkMetrics() *MetricsGroup {
 
 func getBucketUsageMetrics() *MetricsGroup {
 	mg := &MetricsGroup{
+		name:          bucketMetricsGroup,
 		cacheInterval: 10 * time.Second,
 	}
 	mg.RegisterRead(func(ctx context.Context) (metrics []Metric) {
@@ -1660,6 +3264,25 @@ func getBucketUsageMetrics() *MetricsGroup {
 				VariableLabels: map[string]string{"bucket": bucket},
 			})
 
+			metrics = append(metrics, Metric{
+				Description:    getBucketUsageVersionsTotalMD(),
+				Value:          float64(usage.VersionsCount),
+				VariableLabels: map[string]string{"bucket": bucket},
+			})
+
+			for typ, stat := range usage.EncryptionInfo {
+				metrics = append(metrics, Metric{
+					Description:    getBucketUsageEncryptionVersionsMD(),
+					Value:          float64(stat.VersionsCount),
+					VariableLabels: map[string]string{"bucket": bucket, "encryption": typ},
+				})
+				metrics = append(metrics, Metric{
+					Description:    getBucketUsageEncryptionBytesMD(),
+					Value:          float64(stat.Size),
+					VariableLabels: map[string]string{"bucket": bucket, "encryption": typ},
+				})
+			}
+
 			metrics = append(metrics, Metric{
 				Description:    getBucketRepReceivedBytesMD(),
 				Value:          float64(stats.ReplicaSize),
@@ -1672,6 +3295,25 @@ func getBucketUsageMetrics() *MetricsGroup {
 					Value:          float64(quota.Quota),
 					VariableLabels: map[string]string{"bucket": bucket},
 				})
+				metrics = append(metrics, Metric{
+					Description:    getBucketQuotaUsageRatioMD(),
+					Value:          float64(usage.Size) / float64(quota.Quota),
+					VariableLabels: map[string]string{"bucket": bucket},
+				})
+			}
+			if quota != nil && quota.MaxObjects > 0 {
+				metrics = append(metrics, Metric{
+					Description:    getBucketQuotaObjectsTotalMD(),
+					Value:          float64(quota.MaxObjects),
+					VariableLabels: map[string]string{"bucket": bucket},
+				})
+			}
+			if quota != nil && quota.MaxVersions > 0 {
+				metrics = append(metrics, Metric{
+					Description:    getBucketQuotaVersionsTotalMD(),
+					Value:          float64(quota.MaxVersions),
+					VariableLabels: map[string]string{"bucket": bucket},
+				})
 			}
 
 			if stats.hasReplicationUsage() {
@@ -1697,7 +3339,22 @@ func getBucketUsageMetrics() *MetricsGroup {
 						Histogram:            stat.Latency.getUploadLatency(),
 						VariableLabels:       map[string]string{"bucket": bucket, "operation": "upload", "targetArn": arn},
 					})
-
+					metrics = append(metrics, Metric{
+						Description:    getBucketRepPendingBytesMD(),
+						Value:          float64(stat.PendingSize),
+						VariableLabels: map[string]string{"bucket": bucket, "targetArn": arn},
+					})
+					metrics = append(metrics, Metric{
+						Description:    getBucketRepPendingOperationsMD(),
+						Value:          float64(stat.PendingCount),
+						VariableLabels: map[string]string{"bucket": bucket, "targetArn": arn},
+					})
+					metrics = append(metrics, Metric{
+						Description:          getBucketRepLagMD(),
+						HistogramBucketLabel: "quantile",
+						Histogram:            stat.Lag.getLagMillis(),
+						VariableLabels:       map[string]string{"bucket": bucket, "targetArn": arn},
+					})
 				}
 			}
 
@@ -1708,6 +3365,35 @@ func getBucketUsageMetrics() *MetricsGroup {
 				VariableLabels:       map[string]string{"bucket": bucket},
 			})
 		}
+
+		for endpoint, stat := range getReplicationTargetStats(ctx, bucketReplStats) {
+			metrics = append(metrics, Metric{
+				Description:    getClusterRepPendingBytesMD(),
+				Value:          float64(stat.PendingSize),
+				VariableLabels: map[string]string{"endpoint": endpoint},
+			})
+			metrics = append(metrics, Metric{
+				Description:    getClusterRepPendingOperationsMD(),
+				Value:          float64(stat.PendingCount),
+				VariableLabels: map[string]string{"endpoint": endpoint},
+			})
+			metrics = append(metrics, Metric{
+				Description:    getClusterRepFailedBytesMD(),
+				Value:          float64(stat.FailedSize),
+				VariableLabels: map[string]string{"endpoint": endpoint},
+			})
+			metrics = append(metrics, Metric{
+				Description:    getClusterRepFailedOperationsMD(),
+				Value:          float64(stat.FailedCount),
+				VariableLabels: map[string]string{"endpoint": endpoint},
+			})
+			metrics = append(metrics, Metric{
+				Description:          getClusterRepLagMD(),
+				HistogramBucketLabel: "quantile",
+				Histogram:            stat.LagMillis,
+				VariableLabels:       map[string]string{"endpoint": endpoint},
+			})
+		}
 		return
 	})
 	return mg
@@ -1745,6 +3431,7 @@ func getClusterTransitionedVersionsMD() MetricDescription {
 
 func getClusterTierMetrics() *MetricsGroup {
 	mg := &MetricsGroup{
+		name:          tierMetricsGroup,
 		cacheInterval: 10 * time.Second,
 	}
 	mg.RegisterRead(func(ctx context.Context) (metrics []Metric) {
@@ -1772,6 +3459,7 @@ func getClusterTierMetrics() *MetricsGroup {
 
 func getLocalStorageMetrics() *MetricsGroup {
 	mg := &MetricsGroup{
+		name:          diskMetricsGroup,
 		cacheInterval: 10 * time.Second,
 	}
 	mg.RegisterRead(func(ctx context.Context) (metrics []Metric) {
@@ -1816,6 +3504,7 @@ func getLocalStorageMetrics() *MetricsGroup {
 
 func getLocalDiskStorageMetrics() *MetricsGroup {
 	mg := &MetricsGroup{
+		name:          diskMetricsGroup,
 		cacheInterval: 3 * time.Second,
 	}
 	mg.RegisterRead(func(ctx context.Context) (metrics []Metric) {
@@ -1835,21 +3524,79 @@ func getLocalDiskStorageMetrics() *MetricsGroup {
 				continue
 			}
 			for apiName, latency := range disk.Metrics.APILatencies {
-				val := latency.(uint64)
+				val, ok := latency.(uint64)
+				if !ok {
+					continue
+				}
 				metrics = append(metrics, Metric{
 					Description:    getNodeDiskAPILatencyMD(),
 					Value:          float64(val / 1000),
 					VariableLabels: map[string]string{"disk": disk.DrivePath, "api": "storage." + apiName},
 				})
 			}
+			if stats, ok := disk.Metrics.APILatencies[driveIOStatsKey].(DriveIOStats); ok {
+				metrics = append(metrics, getDiskIOMetrics(disk.DrivePath, stats)...)
+			}
 		}
 		return
 	})
 	return mg
 }
 
+// getDiskIOMetrics returns the metrics of the IO stats of the disk at path.
+func getDiskIOMetrics(path string, stats DriveIOStats) []Metric {
+	metrics := []Metric{
+		{
+			Description:    getNodeDiskIOQueueDepthMD(),
+			Value:          float64(stats.QueueDepth),
+			VariableLabels: map[string]string{"disk": path},
+		},
+		{
+			Description:    getNodeDiskIOErrorsMD(),
+			Value:          float64(stats.Errors),
+			VariableLabels: map[string]string{"disk": path},
+		},
+		{
+			Description:    getNodeDiskIOTimeoutsMD(),
+			Value:          float64(stats.Timeouts),
+			VariableLabels: map[string]string{"disk": path},
+		},
+	}
+	for _, op := range []struct {
+		name          string
+		ops           uint64
+		iops          float64
+		p50, p90, p99 uint64
+	}{
+		{"read", stats.ReadOps, stats.ReadIOPS, stats.ReadLatencyP50, stats.ReadLatencyP90, stats.ReadLatencyP99},
+		{"write", stats.WriteOps, stats.WriteIOPS, stats.WriteLatencyP50, stats.WriteLatencyP90, stats.WriteLatencyP99},
+	} {
+		metrics = append(metrics,
+			Metric{
+				Description:    getNodeDiskIOOpsMD(),
+				Value:          float64(op.ops),
+				VariableLabels: map[string]string{"disk": path, "op": op.name},
+			},
+			Metric{
+				Description:    getNodeDiskIOPSMD(),
+				Value:          op.iops,
+				VariableLabels: map[string]string{"disk": path, "op": op.name},
+			},
+		)
+		for i, latency := range []uint64{op.p50, op.p90, op.p99} {
+			metrics = append(metrics, Metric{
+				Description:    getNodeDiskIOLatencyMD(),
+				Value:          float64(latency / 1000),
+				VariableLabels: map[string]string{"disk": path, "op": op.name, "percentile": []string{"50", "90", "99"}[i]},
+			})
+		}
+	}
+	return metrics
+}
+
 func getClusterStorageMetrics() *MetricsGroup {
 	mg := &MetricsGroup{
+		name:          capacityMetricsGroup,
 		cacheInterval: 10 * time.Second,
 	}
 	mg.RegisterRead(func(ctx context.Context) (metrics []Metric) {
@@ -1906,7 +3653,9 @@ func getClusterStorageMetrics() *MetricsGroup {
 
 type minioClusterCollector struct {
 	metricsGroups []*MetricsGroup
-	desc          *prometheus.Desc
+	// names of the groups requested from peers, all if empty.
+	groups []string
+	desc   *prometheus.Desc
 }
 
 func newMinioClusterCollector(metricsGroups []*MetricsGroup) *minioClusterCollector {
@@ -1972,7 +3721,7 @@ func (c *minioClusterCollector) Collect(out chan<- prometheus.Metric) {
 	// Call peer api to fetch metrics
 	wg.Add(2)
 	go publish(ReportMetrics(GlobalContext, c.metricsGroups))
-	go publish(globalNotificationSys.GetClusterMetrics(GlobalContext))
+	go publish(globalNotificationSys.GetClusterMetrics(GlobalContext, c.groups))
 	wg.Wait()
 }
 
@@ -2099,11 +3848,44 @@ func newMinioCollectorNode(metricsGroups []*MetricsGroup) *minioNodeCollector {
 	}
 }
 
+// withGroups returns a collector of the groups of c called by one of names.
+func (c *minioClusterCollector) withGroups(names []string) *minioClusterCollector {
+	return &minioClusterCollector{
+		metricsGroups: selectMetricsGroups(c.metricsGroups, names),
+		groups:        names,
+		desc:          c.desc,
+	}
+}
+
+// metricsGroupsHandler serves requests selecting metrics groups
+// with the groups query parameter by the handler returned by
+// newHandler for these groups, and all other requests by h.
+func metricsGroupsHandler(h http.Handler, newHandler func(names []string) http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		names, err := parseMetricsGroups(r.URL.Query().Get("groups"))
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		if len(names) == 0 {
+			h.ServeHTTP(w, r)
+			return
+		}
+		newHandler(names).ServeHTTP(w, r)
+	})
+}
+
 func metricsServerHandler() http.Handler {
+	return metricsGroupsHandler(newMetricsServerHandler(clusterCollector), func(names []string) http.Handler {
+		return newMetricsServerHandler(clusterCollector.withGroups(names))
+	})
+}
+
+func newMetricsServerHandler(collector *minioClusterCollector) http.Handler {
 	registry := prometheus.NewRegistry()
 
 	// Report all other metrics
-	err := registry.Register(clusterCollector)
+	err := registry.Register(collector)
 	if err != nil {
 		logger.CriticalIf(GlobalContext, err)
 	}
@@ -2124,22 +3906,36 @@ func metricsServerHandler() http.Handler {
 }
 
 func metricsNodeHandler() http.Handler {
+	return metricsGroupsHandler(newMetricsNodeHandler(nodeCollector, nil), func(names []string) http.Handler {
+		return newMetricsNodeHandler(newMinioCollectorNode(selectMetricsGroups(nodeCollector.metricsGroups, names)), names)
+	})
+}
+
+// newMetricsNodeHandler serves the metrics of collector, along with
+// the process and go runtime metrics if their group is in names or
+// names is empty.
+func newMetricsNodeHandler(collector *minioNodeCollector, names []string) http.Handler {
 	registry := prometheus.NewRegistry()
 
-	err := registry.Register(nodeCollector)
+	err := registry.Register(collector)
 	if err != nil {
 		logger.CriticalIf(GlobalContext, err)
 	}
-	err = registry.Register(prometheus.NewProcessCollector(prometheus.ProcessCollectorOpts{
-		Namespace:    minioNamespace,
-		ReportErrors: true,
-	}))
-	if err != nil {
-		logger.CriticalIf(GlobalContext, err)
+	selected := set.CreateStringSet(names...)
+	if len(names) == 0 || selected.Contains(processMetricsGroup) {
+		err = registry.Register(prometheus.NewProcessCollector(prometheus.ProcessCollectorOpts{
+			Namespace:    minioNamespace,
+			ReportErrors: true,
+		}))
+		if err != nil {
+			logger.CriticalIf(GlobalContext, err)
+		}
 	}
-	err = registry.Register(prometheus.NewGoCollector())
-	if err != nil {
-		logger.CriticalIf(GlobalContext, err)
+	if len(names) == 0 || selected.Contains(goMetricsGroup) {
+		err = registry.Register(prometheus.NewGoCollector())
+		if err != nil {
+			logger.CriticalIf(GlobalContext, err)
+		}
 	}
 	gatherers := prometheus.Gatherers{
 		registry,
@@ -2176,3 +3972,44 @@ func toSnake(camel string) (snake string) {
 	}
 	return b.String()
 }
+
+func getBatchPurgeNodeMetrics() *MetricsGroup {
+	mg := &MetricsGroup{
+		name: batchMetricsGroup,
+	}
+	mg.RegisterRead(func(_ context.Context) []Metric {
+		return []Metric{
+			{
+				Description: MetricDescription{
+					Namespace: nodeMetricNamespace,
+					Subsystem: batchPurgeSubsystem,
+					Name:      "versions_total",
+					Help:      "Total number of noncurrent versions deleted by batch purge jobs since server start",
+					Type:      counterMetric,
+				},
+				Value: float64(atomic.LoadUint64(&globalBatchPurgeStats.versions)),
+			},
+			{
+				Description: MetricDescription{
+					Namespace: nodeMetricNamespace,
+					Subsystem: batchPurgeSubsystem,
+					Name:      "bytes_total",
+					Help:      "Total number of bytes of the noncurrent versions deleted by batch purge jobs since server start",
+					Type:      counterMetric,
+				},
+				Value: float64(atomic.LoadUint64(&globalBatchPurgeStats.bytes)),
+			},
+			{
+				Description: MetricDescription{
+					Namespace: nodeMetricNamespace,
+					Subsystem: batchPurgeSubsystem,
+					Name:      "delete_markers_total",
+					Help:      "Total number of delete markers deleted by batch purge jobs since server start",
+					Type:      counterMetric,
+				},
+				Value: float64(atomic.LoadUint64(&globalBatchPurgeStats.deleteMarkers)),
+			},
+		}
+	})
+	return mg
+}
@@ -0,0 +1,88 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParseMetricsGroups(t *testing.T) {
+	testCases := []struct {
+		groups   string
+		expected []string
+		success  bool
+	}{
+		{"", nil, true},
+		{"http", []string{"http"}, true},
+		{"http, disk,,network", []string{"http", "disk", "network"}, true},
+		{"http,unknown", nil, false},
+	}
+	for _, testCase := range testCases {
+		names, err := parseMetricsGroups(testCase.groups)
+		if (err == nil) != testCase.success {
+			t.Errorf("%q: unexpected error %v", testCase.groups, err)
+			continue
+		}
+		if !reflect.DeepEqual(names, testCase.expected) {
+			t.Errorf("%q: expected %v, got %v", testCase.groups, testCase.expected, names)
+		}
+	}
+}
+
+func TestSelectMetricsGroups(t *testing.T) {
+	groups := []*MetricsGroup{
+		{name: httpMetricsGroup},
+		{name: diskMetricsGroup},
+		{name: httpMetricsGroup},
+		nil,
+	}
+	if got := selectMetricsGroups(groups, nil); len(got) != len(groups) {
+		t.Errorf("expected all groups, got %d", len(got))
+	}
+	got := selectMetricsGroups(groups, []string{httpMetricsGroup})
+	if len(got) != 2 || got[0] != groups[0] || got[1] != groups[2] {
+		t.Errorf("expected the http groups, got %v", got)
+	}
+}
+
+func TestMetricsNodeHandlerGroups(t *testing.T) {
+	handler := metricsNodeHandler()
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, minioReservedBucketPath+prometheusMetricsV2NodePath+"?groups=http", nil))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d", rec.Code)
+	}
+	body := rec.Body.String()
+	if !strings.Contains(body, "minio_s3_requests_waiting_total") {
+		t.Errorf("expected http metrics, got %s", body)
+	}
+	if strings.Contains(body, "go_goroutines") || strings.Contains(body, "minio_node_process_") {
+		t.Errorf("expected no metrics of other groups, got %s", body)
+	}
+
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, minioReservedBucketPath+prometheusMetricsV2NodePath+"?groups=unknown", nil))
+	if rec.Code != http.StatusBadRequest {
+		t.Errorf("expected status 400 for an unknown group, got %d", rec.Code)
+	}
+}
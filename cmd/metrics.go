@@ -24,6 +24,7 @@ import (
 	"time"
 
 	"github.com/minio/minio/internal/logger"
+	"github.com/minio/pkg/env"
 	iampolicy "github.com/minio/pkg/iam/policy"
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
@@ -38,6 +39,46 @@ var (
 		},
 		[]string{"api"},
 	)
+	httpRequestsTotalDuration = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    "s3_request_duration_seconds",
+			Help:    "Time taken by requests to be fully served by current MinIO server instance",
+			Buckets: parseRequestDurationBuckets(env.Get(EnvRequestDurationBuckets, "")),
+		},
+		[]string{"api"},
+	)
+	httpRequestsSize = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    "s3_request_size_bytes",
+			Help:    "Size of the request bodies received by current MinIO server instance",
+			Buckets: requestSizeBuckets,
+		},
+		[]string{"api"},
+	)
+	httpResponsesSize = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    "s3_response_size_bytes",
+			Help:    "Size of the responses sent by current MinIO server instance",
+			Buckets: requestSizeBuckets,
+		},
+		[]string{"api"},
+	)
+	tlsHandshakeDuration = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    "tls_handshake_seconds",
+			Help:    "Time taken by TLS handshakes of client connections to current MinIO server instance",
+			Buckets: []float64{.001, .005, .01, .025, .05, .1, .25, .5, 1},
+		},
+		[]string{},
+	)
+	kmsRequestDuration = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    "kms_request_seconds",
+			Help:    "Time taken by requests of current MinIO server instance to KMS endpoints",
+			Buckets: []float64{.001, .005, .01, .025, .05, .1, .25, .5, 1, 2.5, 5},
+		},
+		[]string{"endpoint"},
+	)
 	minioVersionInfo = prometheus.NewGaugeVec(
 		prometheus.GaugeOpts{
 			Namespace: "minio",
@@ -66,6 +107,11 @@ const (
 
 func init() {
 	prometheus.MustRegister(httpRequestsDuration)
+	prometheus.MustRegister(httpRequestsTotalDuration)
+	prometheus.MustRegister(httpRequestsSize)
+	prometheus.MustRegister(httpResponsesSize)
+	prometheus.MustRegister(tlsHandshakeDuration)
+	prometheus.MustRegister(kmsRequestDuration)
 	prometheus.MustRegister(newMinioCollector())
 	prometheus.MustRegister(minioVersionInfo)
 }
@@ -389,6 +435,48 @@ func httpMetricsPrometheus(ch chan<- prometheus.Metric) {
 			api,
 		)
 	}
+
+	// Requests rejected before reaching the API handlers
+	ch <- prometheus.MustNewConstMetric(
+		prometheus.NewDesc(
+			prometheus.BuildFQName(s3Namespace, "requests_rejected", "auth_total"),
+			"Total number of s3 requests rejected for auth failure in current MinIO server instance",
+			nil, nil),
+		prometheus.CounterValue,
+		float64(httpStats.TotalS3RejectedAuth),
+	)
+	ch <- prometheus.MustNewConstMetric(
+		prometheus.NewDesc(
+			prometheus.BuildFQName(s3Namespace, "requests_rejected", "timestamp_total"),
+			"Total number of s3 requests rejected for invalid timestamp in current MinIO server instance",
+			nil, nil),
+		prometheus.CounterValue,
+		float64(httpStats.TotalS3RejectedTime),
+	)
+	ch <- prometheus.MustNewConstMetric(
+		prometheus.NewDesc(
+			prometheus.BuildFQName(s3Namespace, "requests_rejected", "header_total"),
+			"Total number of s3 requests rejected for invalid header in current MinIO server instance",
+			nil, nil),
+		prometheus.CounterValue,
+		float64(httpStats.TotalS3RejectedHeader),
+	)
+	ch <- prometheus.MustNewConstMetric(
+		prometheus.NewDesc(
+			prometheus.BuildFQName(s3Namespace, "requests_rejected", "invalid_total"),
+			"Total number of invalid s3 requests rejected in current MinIO server instance",
+			nil, nil),
+		prometheus.CounterValue,
+		float64(httpStats.TotalS3RejectedInvalid),
+	)
+	ch <- prometheus.MustNewConstMetric(
+		prometheus.NewDesc(
+			prometheus.BuildFQName(s3Namespace, "requests_rejected", "expired_total"),
+			"Total number of s3 requests rejected for expired service accounts in current MinIO server instance",
+			nil, nil),
+		prometheus.CounterValue,
+		float64(httpStats.TotalS3RejectedExpired),
+	)
 }
 
 // collects network metrics for MinIO server in Prometheus specific format
@@ -654,6 +742,18 @@ func metricsHandler() http.Handler {
 	err = registry.Register(httpRequestsDuration)
 	logger.LogIf(GlobalContext, err)
 
+	err = registry.Register(httpRequestsTotalDuration)
+	logger.LogIf(GlobalContext, err)
+
+	err = registry.Register(httpRequestsSize)
+	logger.LogIf(GlobalContext, err)
+
+	err = registry.Register(httpResponsesSize)
+	logger.LogIf(GlobalContext, err)
+
+	err = registry.Register(tlsHandshakeDuration)
+	logger.LogIf(GlobalContext, err)
+
 	err = registry.Register(newMinioCollector())
 	logger.LogIf(GlobalContext, err)
 
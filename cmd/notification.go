@@ -36,6 +36,7 @@ import (
 	"github.com/klauspost/compress/zip"
 	"github.com/minio/madmin-go"
 	"github.com/minio/minio-go/v7/pkg/set"
+	"github.com/minio/minio-go/v7/pkg/tags"
 	bucketBandwidth "github.com/minio/minio/internal/bucket/bandwidth"
 	"github.com/minio/minio/internal/crypto"
 	"github.com/minio/minio/internal/event"
@@ -690,6 +691,47 @@ func (sys *NotificationSys) ReloadPoolMeta(ctx context.Context) {
 	}
 }
 
+// LoadRebalanceMeta notifies the peers to load the rebalance saved on
+// disk, and to start rebalancing their pools when startRebalance is true.
+func (sys *NotificationSys) LoadRebalanceMeta(ctx context.Context, startRebalance bool) {
+	ng := WithNPeers(len(sys.peerClients))
+	for idx, client := range sys.peerClients {
+		if client == nil {
+			continue
+		}
+		client := client
+		ng.Go(ctx, func() error {
+			return client.LoadRebalanceMeta(ctx, startRebalance)
+		}, idx, *client.host)
+	}
+	for _, nErr := range ng.Wait() {
+		reqInfo := (&logger.ReqInfo{}).AppendTags("peerAddress", nErr.Host.String())
+		if nErr.Err != nil {
+			logger.LogIf(logger.SetReqInfo(ctx, reqInfo), nErr.Err)
+		}
+	}
+}
+
+// StopRebalance notifies the peers to stop the rebalance running on them.
+func (sys *NotificationSys) StopRebalance(ctx context.Context) {
+	ng := WithNPeers(len(sys.peerClients))
+	for idx, client := range sys.peerClients {
+		if client == nil {
+			continue
+		}
+		client := client
+		ng.Go(ctx, func() error {
+			return client.StopRebalance(ctx)
+		}, idx, *client.host)
+	}
+	for _, nErr := range ng.Wait() {
+		reqInfo := (&logger.ReqInfo{}).AppendTags("peerAddress", nErr.Host.String())
+		if nErr.Err != nil {
+			logger.LogIf(logger.SetReqInfo(ctx, reqInfo), nErr.Err)
+		}
+	}
+}
+
 // LoadTransitionTierConfig notifies remote peers to load their remote tier
 // configs from config store.
 func (sys *NotificationSys) LoadTransitionTierConfig(ctx context.Context) {
@@ -711,6 +753,48 @@ func (sys *NotificationSys) LoadTransitionTierConfig(ctx context.Context) {
 	}
 }
 
+// LoadReplicationBandwidthLimits notifies remote peers to load the bandwidth
+// limits of the remote target endpoints from config store.
+func (sys *NotificationSys) LoadReplicationBandwidthLimits(ctx context.Context) {
+	ng := WithNPeers(len(sys.peerClients))
+	for idx, client := range sys.peerClients {
+		if client == nil {
+			continue
+		}
+		client := client
+		ng.Go(ctx, func() error {
+			return client.LoadReplicationBandwidthLimits(ctx)
+		}, idx, *client.host)
+	}
+	for _, nErr := range ng.Wait() {
+		reqInfo := (&logger.ReqInfo{}).AppendTags("peerAddress", nErr.Host.String())
+		if nErr.Err != nil {
+			logger.LogIf(logger.SetReqInfo(ctx, reqInfo), nErr.Err)
+		}
+	}
+}
+
+// ReloadReplicationResync notifies remote peers to reload the
+// replication resync status of bucket from the backend.
+func (sys *NotificationSys) ReloadReplicationResync(ctx context.Context, bucket string) {
+	ng := WithNPeers(len(sys.peerClients))
+	for idx, client := range sys.peerClients {
+		if client == nil {
+			continue
+		}
+		client := client
+		ng.Go(ctx, func() error {
+			return client.ReloadReplicationResync(ctx, bucket)
+		}, idx, *client.host)
+	}
+	for _, nErr := range ng.Wait() {
+		reqInfo := (&logger.ReqInfo{}).AppendTags("peerAddress", nErr.Host.String())
+		if nErr.Err != nil {
+			logger.LogIf(logger.SetReqInfo(ctx, reqInfo), nErr.Err)
+		}
+	}
+}
+
 // Loads notification policies for all buckets into NotificationSys.
 func (sys *NotificationSys) set(bucket BucketInfo, meta BucketMetadata) {
 	config := meta.notificationConfig
@@ -745,6 +829,7 @@ func (sys *NotificationSys) InitBucketTargets(ctx context.Context, objAPI Object
 				reqInfo := &logger.ReqInfo{}
 				reqInfo.AppendTags("targetID", res.ID.Name)
 				logger.LogOnceIf(logger.SetReqInfo(GlobalContext, reqInfo), res.Err, res.ID)
+				go sys.redeliverEvent(GlobalContext, res.ID, res.Event, res.Err)
 			}
 		}
 	}()
@@ -845,7 +930,7 @@ func (sys *NotificationSys) RemoveAllRemoteTargets() {
 // Send - sends event data to all matching targets.
 func (sys *NotificationSys) Send(args eventArgs) {
 	sys.RLock()
-	targetIDSet := sys.bucketRulesMap[args.BucketName].Match(args.EventName, args.Object.Name)
+	targetIDSet := sys.bucketRulesMap[args.BucketName].MatchObject(args.EventName, args.objectProperties())
 	sys.RUnlock()
 
 	if len(targetIDSet) == 0 {
@@ -1467,6 +1552,30 @@ func (args eventArgs) ToEvent(escape bool) event.Event {
 	return newEvent
 }
 
+// objectProperties - returns the object properties matched by
+// the object filters of the event rules.
+func (args eventArgs) objectProperties() event.ObjectProperties {
+	props := event.ObjectProperties{
+		Name:        args.Object.Name,
+		Size:        args.Object.Size,
+		ContentType: args.Object.ContentType,
+	}
+	for k, v := range args.Object.UserDefined {
+		if strings.HasPrefix(strings.ToLower(k), "x-amz-meta-") {
+			if props.UserMetadata == nil {
+				props.UserMetadata = make(map[string]string)
+			}
+			props.UserMetadata[k] = v
+		}
+	}
+	if args.Object.UserTags != "" {
+		if t, err := tags.ParseObjectTags(args.Object.UserTags); err == nil {
+			props.Tags = t.ToMap()
+		}
+	}
+	return props
+}
+
 func sendEvent(args eventArgs) {
 	args.Object.Size, _ = args.Object.GetActualSize()
 
@@ -1537,13 +1646,42 @@ func (sys *NotificationSys) GetBandwidthReports(ctx context.Context, buckets ...
 	return consolidatedReport
 }
 
-// GetClusterMetrics - gets the cluster metrics from all nodes excluding self.
-func (sys *NotificationSys) GetClusterMetrics(ctx context.Context) <-chan Metric {
-	if sys == nil {
-		return nil
+// GetAccessKeyStats - gets the S3 requests per access key of all nodes including self.
+func (sys *NotificationSys) GetAccessKeyStats(ctx context.Context) map[string]ServerAccessKeyStats {
+	replies := make([]map[string]ServerAccessKeyStats, len(sys.peerClients))
+	g := errgroup.WithNErrs(len(sys.peerClients))
+	for index := range sys.peerClients {
+		if sys.peerClients[index] == nil {
+			continue
+		}
+		index := index
+		g.Go(func() error {
+			var err error
+			replies[index], err = sys.peerClients[index].GetAccessKeyStats(ctx)
+			return err
+		}, index)
 	}
+
+	for index, err := range g.Wait() {
+		if err != nil {
+			reqInfo := (&logger.ReqInfo{}).AppendTags("peerAddress",
+				sys.peerClients[index].host.String())
+			ctx := logger.SetReqInfo(ctx, reqInfo)
+			logger.LogOnceIf(ctx, err, sys.peerClients[index].host.String())
+		}
+	}
+
+	stats := globalHTTPStats.toServerAccessKeyStats()
+	for _, reply := range replies {
+		mergeAccessKeyStats(stats, reply)
+	}
+	return stats
+}
+
+// GetTopClients - gets the count clients with the most S3 requests of all nodes including self.
+func (sys *NotificationSys) GetTopClients(ctx context.Context, count int) []ServerTopClient {
+	reports := make([][]ServerTopClient, len(sys.peerClients))
 	g := errgroup.WithNErrs(len(sys.peerClients))
-	peerChannels := make([]<-chan Metric, len(sys.peerClients))
 	for index := range sys.peerClients {
 		if sys.peerClients[index] == nil {
 			continue
@@ -1551,44 +1689,143 @@ func (sys *NotificationSys) GetClusterMetrics(ctx context.Context) <-chan Metric
 		index := index
 		g.Go(func() error {
 			var err error
-			peerChannels[index], err = sys.peerClients[index].GetPeerMetrics(ctx)
+			reports[index], err = sys.peerClients[index].GetTopClients(ctx)
 			return err
 		}, index)
 	}
 
-	ch := make(chan Metric)
-	var wg sync.WaitGroup
 	for index, err := range g.Wait() {
 		if err != nil {
-			if sys.peerClients[index] != nil {
-				reqInfo := (&logger.ReqInfo{}).AppendTags("peerAddress",
-					sys.peerClients[index].host.String())
-				logger.LogOnceIf(logger.SetReqInfo(ctx, reqInfo), err, sys.peerClients[index].host.String())
-			} else {
-				logger.LogOnceIf(ctx, err, "peer-offline")
-			}
+			reqInfo := (&logger.ReqInfo{}).AppendTags("peerAddress",
+				sys.peerClients[index].host.String())
+			ctx := logger.SetReqInfo(ctx, reqInfo)
+			logger.LogOnceIf(ctx, err, sys.peerClients[index].host.String())
+		}
+	}
+
+	reports = append(reports, globalHTTPStats.clients.report())
+	return mergeTopClients(reports, count)
+}
+
+// GetHotObjects - gets the count objects and prefixes read the most of all nodes including self.
+func (sys *NotificationSys) GetHotObjects(ctx context.Context, count int) ServerHotObjects {
+	reports := make([]ServerHotObjects, len(sys.peerClients))
+	g := errgroup.WithNErrs(len(sys.peerClients))
+	for index := range sys.peerClients {
+		if sys.peerClients[index] == nil {
+			continue
+		}
+		index := index
+		g.Go(func() error {
+			var err error
+			reports[index], err = sys.peerClients[index].GetHotObjects(ctx)
+			return err
+		}, index)
+	}
+
+	for index, err := range g.Wait() {
+		if err != nil {
+			reqInfo := (&logger.ReqInfo{}).AppendTags("peerAddress",
+				sys.peerClients[index].host.String())
+			ctx := logger.SetReqInfo(ctx, reqInfo)
+			logger.LogOnceIf(ctx, err, sys.peerClients[index].host.String())
+		}
+	}
+
+	reports = append(reports, globalHTTPStats.hot.report())
+	objects := make([][]ServerHotObject, 0, len(reports))
+	prefixes := make([][]ServerHotObject, 0, len(reports))
+	for _, report := range reports {
+		objects = append(objects, report.Objects)
+		prefixes = append(prefixes, report.Prefixes)
+	}
+	return ServerHotObjects{
+		Objects:  mergeHotObjects(objects, count),
+		Prefixes: mergeHotObjects(prefixes, count),
+	}
+}
+
+// GetAnonymousRequests - gets the anonymous requests per bucket of all nodes including self.
+func (sys *NotificationSys) GetAnonymousRequests(ctx context.Context) map[string]ServerAnonymousBucketRequests {
+	reports := make([]map[string]ServerAnonymousBucketRequests, len(sys.peerClients))
+	g := errgroup.WithNErrs(len(sys.peerClients))
+	for index := range sys.peerClients {
+		if sys.peerClients[index] == nil {
+			continue
+		}
+		index := index
+		g.Go(func() error {
+			var err error
+			reports[index], err = sys.peerClients[index].GetAnonymousRequests(ctx)
+			return err
+		}, index)
+	}
+
+	for index, err := range g.Wait() {
+		if err != nil {
+			reqInfo := (&logger.ReqInfo{}).AppendTags("peerAddress",
+				sys.peerClients[index].host.String())
+			ctx := logger.SetReqInfo(ctx, reqInfo)
+			logger.LogOnceIf(ctx, err, sys.peerClients[index].host.String())
+		}
+	}
+
+	reports = append(reports, globalHTTPStats.anonymous.report())
+	return mergeAnonymousRequests(reports)
+}
+
+// GetClusterMetrics - gets the cluster metrics from all nodes excluding self,
+// limited to the metrics groups called by one of groups if not empty.
+// The metrics of a node are served from globalPeerMetricsCache if they were
+// collected recently, along with the time they were collected at.
+func (sys *NotificationSys) GetClusterMetrics(ctx context.Context, groups []string) <-chan Metric {
+	if sys == nil {
+		return nil
+	}
+	ch := make(chan Metric)
+	var wg sync.WaitGroup
+	for _, client := range sys.peerClients {
+		if client == nil {
 			continue
 		}
 		wg.Add(1)
-		go func(ctx context.Context, peerChannel <-chan Metric, wg *sync.WaitGroup) {
+		go func(client *peerRESTClient) {
 			defer wg.Done()
-			for {
+			peer := client.host.String()
+			metrics, collected, err := globalPeerMetricsCache.get(peer, groups, func() ([]Metric, error) {
+				return collectPeerMetrics(ctx, client, groups)
+			})
+			if err != nil {
+				reqInfo := (&logger.ReqInfo{}).AppendTags("peerAddress", peer)
+				logger.LogOnceIf(logger.SetReqInfo(ctx, reqInfo), err, peer)
+			}
+			if collected.IsZero() {
+				return
+			}
+			publish := func(m Metric) bool {
 				select {
-				case m, ok := <-peerChannel:
-					if !ok {
-						return
-					}
-					ch <- m
+				case ch <- m:
+					return true
 				case <-ctx.Done():
+					return false
+				}
+			}
+			for _, m := range metrics {
+				if !publish(m) {
 					return
 				}
 			}
-		}(ctx, peerChannels[index], &wg)
+			publish(Metric{
+				Description:    getClusterNodeMetricsCollectedMD(),
+				Value:          float64(collected.UnixNano()) / float64(time.Second),
+				VariableLabels: map[string]string{serverName: peer},
+			})
+		}(client)
 	}
-	go func(wg *sync.WaitGroup, ch chan Metric) {
+	go func() {
 		wg.Wait()
 		close(ch)
-	}(&wg, ch)
+	}()
 	return ch
 }
 
@@ -1815,3 +2052,278 @@ func (sys *NotificationSys) GetLastDayTierStats(ctx context.Context) DailyAllTie
 	}
 	return merged
 }
+
+// GetScannerStatus returns the data scanner status of all the peers.
+func (sys *NotificationSys) GetScannerStatus(ctx context.Context) []scannerStatus {
+	statuses := make([]scannerStatus, len(sys.peerClients))
+	var wg sync.WaitGroup
+	for index, client := range sys.peerClients {
+		if client == nil {
+			continue
+		}
+		wg.Add(1)
+		go func(index int, client *peerRESTClient) {
+			defer wg.Done()
+			st, err := client.GetScannerStatus(ctx)
+			if err != nil {
+				st = scannerStatus{Error: err.Error()}
+			}
+			st.Node = client.host.String()
+			statuses[index] = st
+		}(index, client)
+	}
+	wg.Wait()
+
+	result := statuses[:0]
+	for i, client := range sys.peerClients {
+		if client != nil {
+			result = append(result, statuses[i])
+		}
+	}
+	return result
+}
+
+// GetHealProgress returns the healing progress of the drives being healed
+// on all the peers, along with the peers that could not be reached.
+func (sys *NotificationSys) GetHealProgress(ctx context.Context) ([]healDriveProgress, []string) {
+	progress := make([][]healDriveProgress, len(sys.peerClients))
+	errs := make([]error, len(sys.peerClients))
+	var wg sync.WaitGroup
+	for index, client := range sys.peerClients {
+		if client == nil {
+			continue
+		}
+		wg.Add(1)
+		go func(index int, client *peerRESTClient) {
+			defer wg.Done()
+			progress[index], errs[index] = client.GetHealProgress(ctx)
+		}(index, client)
+	}
+	wg.Wait()
+
+	var drives []healDriveProgress
+	var errStrs []string
+	for index, client := range sys.peerClients {
+		if client == nil {
+			continue
+		}
+		if errs[index] != nil {
+			errStrs = append(errStrs, fmt.Sprintf("%s: %v", client.host, errs[index]))
+			continue
+		}
+		drives = append(drives, progress[index]...)
+	}
+	return drives, errStrs
+}
+
+// GetRejectedRequests - gets the count most recent rejected requests of all nodes including self.
+func (sys *NotificationSys) GetRejectedRequests(ctx context.Context, count int) []ServerRejectedRequest {
+	reports := make([][]ServerRejectedRequest, len(sys.peerClients))
+	g := errgroup.WithNErrs(len(sys.peerClients))
+	for index := range sys.peerClients {
+		if sys.peerClients[index] == nil {
+			continue
+		}
+		index := index
+		g.Go(func() error {
+			var err error
+			reports[index], err = sys.peerClients[index].GetRejectedRequests(ctx)
+			return err
+		}, index)
+	}
+
+	for index, err := range g.Wait() {
+		if err != nil {
+			reqInfo := (&logger.ReqInfo{}).AppendTags("peerAddress",
+				sys.peerClients[index].host.String())
+			ctx := logger.SetReqInfo(ctx, reqInfo)
+			logger.LogOnceIf(ctx, err, sys.peerClients[index].host.String())
+		}
+	}
+
+	reports = append(reports, globalHTTPStats.rejections.load())
+	return mergeRejectedRequests(reports, count)
+}
+
+// GetNotifyTargets - gets the delivery statistics of the notification targets of all nodes including self.
+func (sys *NotificationSys) GetNotifyTargets(ctx context.Context) []ServerNotifyTarget {
+	reports := make([][]ServerNotifyTarget, len(sys.peerClients))
+	g := errgroup.WithNErrs(len(sys.peerClients))
+	for index := range sys.peerClients {
+		if sys.peerClients[index] == nil {
+			continue
+		}
+		index := index
+		g.Go(func() error {
+			var err error
+			reports[index], err = sys.peerClients[index].GetNotifyTargets(ctx)
+			return err
+		}, index)
+	}
+
+	for index, err := range g.Wait() {
+		if err != nil {
+			reqInfo := (&logger.ReqInfo{}).AppendTags("peerAddress",
+				sys.peerClients[index].host.String())
+			ctx := logger.SetReqInfo(ctx, reqInfo)
+			logger.LogOnceIf(ctx, err, sys.peerClients[index].host.String())
+		}
+	}
+
+	targets := getLocalNotifyTargets()
+	for _, report := range reports {
+		targets = append(targets, report...)
+	}
+	return targets
+}
+
+// GetSlowRequests - gets the count most recent slow requests of all nodes including self.
+func (sys *NotificationSys) GetSlowRequests(ctx context.Context, count int) []ServerSlowRequest {
+	reports := make([][]ServerSlowRequest, len(sys.peerClients))
+	g := errgroup.WithNErrs(len(sys.peerClients))
+	for index := range sys.peerClients {
+		if sys.peerClients[index] == nil {
+			continue
+		}
+		index := index
+		g.Go(func() error {
+			var err error
+			reports[index], err = sys.peerClients[index].GetSlowRequests(ctx)
+			return err
+		}, index)
+	}
+
+	for index, err := range g.Wait() {
+		if err != nil {
+			reqInfo := (&logger.ReqInfo{}).AppendTags("peerAddress",
+				sys.peerClients[index].host.String())
+			ctx := logger.SetReqInfo(ctx, reqInfo)
+			logger.LogOnceIf(ctx, err, sys.peerClients[index].host.String())
+		}
+	}
+
+	reports = append(reports, globalHTTPStats.slowRequests.load())
+	return mergeSlowRequests(reports, count)
+}
+
+// GetLiveStats - gets a snapshot of the stats of all nodes including self,
+// nodes which could not be reached are reported with an error.
+func (sys *NotificationSys) GetLiveStats(ctx context.Context) []ServerLiveStats {
+	replies := make([]ServerLiveStats, len(sys.peerClients))
+	g := errgroup.WithNErrs(len(sys.peerClients))
+	for index := range sys.peerClients {
+		if sys.peerClients[index] == nil {
+			continue
+		}
+		index := index
+		g.Go(func() error {
+			var err error
+			replies[index], err = sys.peerClients[index].GetLiveStats(ctx)
+			return err
+		}, index)
+	}
+
+	stats := []ServerLiveStats{getLocalLiveStats()}
+	for index, err := range g.Wait() {
+		if sys.peerClients[index] == nil {
+			continue
+		}
+		if err != nil {
+			reqInfo := (&logger.ReqInfo{}).AppendTags("peerAddress",
+				sys.peerClients[index].host.String())
+			ctx := logger.SetReqInfo(ctx, reqInfo)
+			logger.LogOnceIf(ctx, err, sys.peerClients[index].host.String())
+			replies[index] = ServerLiveStats{
+				Node:  sys.peerClients[index].host.String(),
+				Time:  UTCNow(),
+				Error: err.Error(),
+			}
+		}
+		stats = append(stats, replies[index])
+	}
+	return stats
+}
+
+// InvalidateMetacache - prevents the reuse of the listing caches of all
+// peers listing the updated objects.
+func (sys *NotificationSys) InvalidateMetacache(ctx context.Context, updates map[string][]string) []NotificationPeerErr {
+	ng := WithNPeers(len(sys.peerClients))
+	for idx, client := range sys.peerClients {
+		if client == nil {
+			continue
+		}
+		client := client
+		ng.Go(ctx, func() error {
+			return client.InvalidateMetacache(ctx, updates)
+		}, idx, *client.host)
+	}
+	return ng.Wait()
+}
+
+// GetMetacacheInfo - describes the listing caches of all peers.
+func (sys *NotificationSys) GetMetacacheInfo(ctx context.Context, bucket, prefix string) []metacacheInfo {
+	replies := make([][]metacacheInfo, len(sys.peerClients))
+	var wg sync.WaitGroup
+	for index, client := range sys.peerClients {
+		if client == nil {
+			continue
+		}
+		wg.Add(1)
+		go func(index int, client *peerRESTClient) {
+			defer wg.Done()
+			infos, err := client.GetMetacacheInfo(ctx, bucket, prefix)
+			if err != nil {
+				reqInfo := (&logger.ReqInfo{}).AppendTags("peerAddress", client.host.String())
+				ctx := logger.SetReqInfo(ctx, reqInfo)
+				logger.LogIf(ctx, err)
+				return
+			}
+			replies[index] = infos
+		}(index, client)
+	}
+	wg.Wait()
+
+	var infos []metacacheInfo
+	for _, reply := range replies {
+		infos = append(infos, reply...)
+	}
+	return infos
+}
+
+// PurgeMetacache - deletes the listing caches of all peers listing the
+// prefix of bucket, returns the number of deleted caches.
+func (sys *NotificationSys) PurgeMetacache(ctx context.Context, bucket, prefix string) (int, []NotificationPeerErr) {
+	purged := make([]int, len(sys.peerClients))
+	ng := WithNPeers(len(sys.peerClients))
+	for idx, client := range sys.peerClients {
+		if client == nil {
+			continue
+		}
+		idx, client := idx, client
+		ng.Go(ctx, func() (err error) {
+			purged[idx], err = client.PurgeMetacache(ctx, bucket, prefix)
+			return err
+		}, idx, *client.host)
+	}
+	errs := ng.Wait()
+	var n int
+	for _, p := range purged {
+		n += p
+	}
+	return n, errs
+}
+
+// ResetStats - zeroes the HTTP and traffic stats of all peers.
+func (sys *NotificationSys) ResetStats(ctx context.Context) []NotificationPeerErr {
+	ng := WithNPeers(len(sys.peerClients))
+	for idx, client := range sys.peerClients {
+		if client == nil {
+			continue
+		}
+		client := client
+		ng.Go(ctx, func() error {
+			return client.ResetStats(ctx)
+		}, idx, *client.host)
+	}
+	return ng.Wait()
+}
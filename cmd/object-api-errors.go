@@ -243,6 +243,13 @@ func (e InvalidArgument) Error() string {
 	return "Invalid arguments provided for " + e.Bucket + "/" + e.Object
 }
 
+// ObjectNotAppendable object exists but its layout does not permit appending data in place.
+type ObjectNotAppendable GenericError
+
+func (e ObjectNotAppendable) Error() string {
+	return "Object cannot be appended to: " + e.Bucket + "/" + e.Object
+}
+
 // BucketNotFound bucket does not exist.
 type BucketNotFound GenericError
 
@@ -366,6 +373,27 @@ func (e BucketLifecycleNotFound) Error() string {
 	return "No bucket lifecycle configuration found for bucket : " + e.Bucket
 }
 
+// BucketInventoryConfigNotFound - no bucket inventory configuration found.
+type BucketInventoryConfigNotFound GenericError
+
+func (e BucketInventoryConfigNotFound) Error() string {
+	return "No bucket inventory configuration found for bucket : " + e.Bucket
+}
+
+// BucketCorsNotFound - no bucket CORS configuration found.
+type BucketCorsNotFound GenericError
+
+func (e BucketCorsNotFound) Error() string {
+	return "No bucket CORS configuration found for bucket : " + e.Bucket
+}
+
+// BucketWebsiteNotFound - no bucket website configuration found.
+type BucketWebsiteNotFound GenericError
+
+func (e BucketWebsiteNotFound) Error() string {
+	return "No bucket website configuration found for bucket : " + e.Bucket
+}
+
 // BucketSSEConfigNotFound - no bucket encryption found
 type BucketSSEConfigNotFound GenericError
 
@@ -401,6 +429,13 @@ func (e BucketQuotaExceeded) Error() string {
 	return "Bucket quota exceeded for bucket: " + e.Bucket
 }
 
+// BucketObjectCountQuotaExceeded - bucket object or version count quota exceeded.
+type BucketObjectCountQuotaExceeded GenericError
+
+func (e BucketObjectCountQuotaExceeded) Error() string {
+	return "Bucket object count quota exceeded for bucket: " + e.Bucket
+}
+
 // BucketReplicationConfigNotFound - no bucket replication config found
 type BucketReplicationConfigNotFound GenericError
 
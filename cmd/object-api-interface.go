@@ -29,6 +29,7 @@ import (
 	"github.com/minio/pkg/bucket/policy"
 
 	"github.com/minio/minio/internal/bucket/replication"
+	"github.com/minio/minio/internal/hash"
 	xioutil "github.com/minio/minio/internal/ioutil"
 )
 
@@ -53,7 +54,7 @@ type ObjectOptions struct {
 	DeleteMarker      bool                // Is only set in DELETE operations for delete marker replication
 	UserDefined       map[string]string   // only set in case of POST/PUT operations
 	PartNumber        int                 // only useful in case of GetObject/HeadObject
-	CheckPrecondFn    CheckPreconditionFn // only set during GetObject/HeadObject/CopyObjectPart preconditional valuation
+	CheckPrecondFn    CheckPreconditionFn // only set during GetObject/HeadObject/CopyObjectPart preconditional valuation and conditional PutObject/CompleteMultipartUpload
 	EvalMetadataFn    EvalMetadataFn      // only set for retention settings, meant to be used only when updating metadata in-place.
 	DeleteReplication ReplicationState    // Represents internal replication state needed for Delete replication
 	Transition        TransitionOptions
@@ -78,6 +79,14 @@ type ObjectOptions struct {
 	WalkAscending bool // return Walk results in ascending order of versions
 
 	PrefixEnabledFn func(prefix string) bool // function which returns true if versioning is enabled on prefix
+
+	// Additional checksum of the content, only set in PUT operations.
+	// Its value is complete once all content has been read.
+	WantChecksum *hash.Checksum
+
+	// Append set to 'true' if PutObject should add the data as a new
+	// part at the end of the latest version of an existing object.
+	Append bool
 }
 
 // ExpirationOptions represents object options for object expiration at objectLayer.
@@ -28,6 +28,7 @@ import (
 	"strconv"
 	"strings"
 	"testing"
+	"time"
 )
 
 func TestListObjectsVersionedFolders(t *testing.T) {
@@ -1921,3 +1922,94 @@ func BenchmarkListObjects(b *testing.B) {
 		}
 	}
 }
+
+// TestListObjectsReuseCache - tests that finished listings are reused
+// by new listings until an object below their prefix is updated.
+func TestListObjectsReuseCache(t *testing.T) {
+	defer func(reuse bool) { globalListCacheReuse = reuse }(globalListCacheReuse)
+	globalListCacheReuse = true
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	obj, fsDirs, err := prepareErasure16(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer removeRoots(fsDirs)
+
+	bucket := "reuse-bucket"
+	if err = obj.MakeBucketWithLocation(ctx, bucket, BucketOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	defer localMetacacheMgr.deleteBucketCache(bucket)
+	putObject := func(name string) {
+		t.Helper()
+		_, err := obj.PutObject(ctx, bucket, name, mustGetPutObjReader(t, bytes.NewReader([]byte(name)), int64(len(name)), "", ""), ObjectOptions{})
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	for i := 0; i < 10; i++ {
+		putObject(fmt.Sprintf("obj%02d", i))
+	}
+
+	listAll := func() (names []string, firstMarker string) {
+		t.Helper()
+		marker := ""
+		for {
+			result, err := obj.ListObjects(ctx, bucket, "", marker, "", 3)
+			if err != nil {
+				t.Fatal(err)
+			}
+			for _, o := range result.Objects {
+				names = append(names, o.Name)
+			}
+			if firstMarker == "" {
+				firstMarker = result.NextMarker
+			}
+			if !result.IsTruncated {
+				return names, firstMarker
+			}
+			marker = result.NextMarker
+		}
+	}
+	waitReusable := func(reusable bool) {
+		t.Helper()
+		deadline := time.Now().Add(10 * time.Second)
+		for time.Now().Before(deadline) {
+			for _, info := range localMetacacheMgr.info(bucket, "") {
+				if info.Reusable == reusable {
+					return
+				}
+			}
+			time.Sleep(100 * time.Millisecond)
+		}
+		t.Fatalf("no cache with reusable %v", reusable)
+	}
+
+	// The first listing is saved from its second page on.
+	if names, _ := listAll(); len(names) != 10 {
+		t.Fatalf("expected 10 objects, got %v", names)
+	}
+	waitReusable(true)
+
+	// A new listing continues from the saved listing.
+	names, marker := listAll()
+	if len(names) != 10 {
+		t.Fatalf("expected 10 objects, got %v", names)
+	}
+	if !strings.Contains(marker, ",id:") {
+		t.Fatalf("expected the listing to reuse the cache, got marker %q", marker)
+	}
+
+	// Updates invalidate the saved listing of the node right away.
+	putObject("obj10")
+	for _, info := range localMetacacheMgr.info(bucket, "") {
+		if info.Reusable {
+			t.Fatalf("expected the update to invalidate cache %s", info.ID)
+		}
+	}
+	if names, _ = listAll(); len(names) != 11 {
+		t.Fatalf("expected 11 objects, got %v", names)
+	}
+}
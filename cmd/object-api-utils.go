@@ -27,6 +27,7 @@ import (
 	"math/rand"
 	"net"
 	"net/http"
+	"os"
 	"path"
 	"runtime"
 	"strconv"
@@ -550,6 +551,20 @@ func (g *GetObjectReader) WithCleanupFuncs(fns ...func()) *GetObjectReader {
 	return g
 }
 
+// zeroCopyReader returns the object data as a length limited plain
+// file which the HTTP server can send with sendfile, nil is returned
+// when the data has to be decrypted or decompressed in user space.
+func (g *GetObjectReader) zeroCopyReader() io.Reader {
+	lr, ok := g.Reader.(*io.LimitedReader)
+	if !ok {
+		return nil
+	}
+	if _, ok = lr.R.(*os.File); !ok {
+		return nil
+	}
+	return lr
+}
+
 // NewGetObjectReaderFromReader sets up a GetObjectReader with a given
 // reader. This ignores any object properties.
 func NewGetObjectReaderFromReader(r io.Reader, oi ObjectInfo, opts ObjectOptions, cleanupFns ...func()) (*GetObjectReader, error) {
@@ -568,6 +583,27 @@ func NewGetObjectReaderFromReader(r io.Reader, oi ObjectInfo, opts ObjectOptions
 	}, nil
 }
 
+// checkWritePrecondition evaluates the preconditions of a conditional write
+// against the latest version of the object returned by latest, or against
+// an empty ObjectInfo when there is none. The caller must hold the lock of
+// the object, so that it is not written between the check and the write.
+func checkWritePrecondition(opts ObjectOptions, latest func() (ObjectInfo, error)) error {
+	if opts.CheckPrecondFn == nil {
+		return nil
+	}
+	oi, err := latest()
+	if err != nil {
+		if !isErrObjectNotFound(err) && !isErrVersionNotFound(err) {
+			return err
+		}
+		oi = ObjectInfo{}
+	}
+	if opts.CheckPrecondFn(oi) {
+		return PreConditionFailed{}
+	}
+	return nil
+}
+
 // ObjReaderFn is a function type that takes a reader and returns
 // GetObjectReader and an error. Request headers are passed to provide
 // encryption parameters. cleanupFns allow cleanup funcs to be
@@ -664,3 +664,30 @@ func TestS2CompressReader(t *testing.T) {
 		})
 	}
 }
+
+func TestCheckWritePrecondition(t *testing.T) {
+	existing := ObjectInfo{ETag: "etag"}
+	ifMatch := func(oi ObjectInfo) bool { return oi.ETag != "etag" }
+	testCases := []struct {
+		precond   func(ObjectInfo) bool
+		latest    ObjectInfo
+		latestErr error
+		expected  error
+	}{
+		{nil, existing, errServerNotInitialized, nil},
+		{ifMatch, existing, nil, nil},
+		{ifMatch, ObjectInfo{ETag: "other"}, nil, PreConditionFailed{}},
+		// A missing object is evaluated as an empty ObjectInfo.
+		{ifMatch, existing, ObjectNotFound{}, PreConditionFailed{}},
+		{ifMatch, existing, VersionNotFound{}, PreConditionFailed{}},
+		{ifMatch, existing, errServerNotInitialized, errServerNotInitialized},
+	}
+	for i, testCase := range testCases {
+		err := checkWritePrecondition(ObjectOptions{CheckPrecondFn: testCase.precond}, func() (ObjectInfo, error) {
+			return testCase.latest, testCase.latestErr
+		})
+		if err != testCase.expected {
+			t.Errorf("Test %d: expected %v, got %v", i+1, testCase.expected, err)
+		}
+	}
+}
@@ -0,0 +1,176 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/minio/minio/internal/hash"
+	xhttp "github.com/minio/minio/internal/http"
+)
+
+// Internal metadata keys of additional checksums.
+const (
+	// Checksum of the object content, composite for multipart objects.
+	objectChecksumKey = ReservedMetadataPrefixLower + "checksum"
+	// Comma separated checksums of the parts of multipart objects.
+	objectChecksumPartsKey = ReservedMetadataPrefixLower + "checksum-parts"
+	// Checksum algorithm of a multipart upload.
+	uploadChecksumTypeKey = ReservedMetadataPrefixLower + "checksum-type"
+)
+
+// checksumModeEnabled is the x-amz-checksum-mode header value
+// requesting checksums in GET and HEAD responses.
+const checksumModeEnabled = "ENABLED"
+
+// uploadPartChecksumKey returns the internal metadata key of
+// the checksum of a part of a multipart upload.
+func uploadPartChecksumKey(partID int) string {
+	return ReservedMetadataPrefixLower + "checksum-part-" + strconv.Itoa(partID)
+}
+
+// newChecksumReader returns a hash.Reader verifying the additional
+// checksum requested by the headers of r on reading, and the checksum.
+// If no checksum is requested, the checksum of type defaultType is
+// computed, unless it is hash.ChecksumNone, then reader is returned as is.
+func newChecksumReader(r *http.Request, reader io.Reader, size int64, md5hex, sha256hex string, defaultType hash.ChecksumType) (io.Reader, *hash.Checksum, error) {
+	checksum, err := hash.ChecksumFromHeaders(r.Header)
+	if err != nil {
+		return nil, nil, err
+	}
+	if checksum == nil {
+		if !defaultType.IsSet() {
+			return reader, nil, nil
+		}
+		checksum = &hash.Checksum{Type: defaultType}
+	}
+	if defaultType.IsSet() && checksum.Type != defaultType {
+		return nil, nil, hash.ErrInvalidChecksum
+	}
+	// Trailing checksums are only supported with unsigned aws-chunked payloads.
+	if checksum.Trailing && !isRequestUnsignedTrailer(r) {
+		return nil, nil, hash.ErrInvalidChecksum
+	}
+	hashReader, err := hash.NewReader(reader, size, md5hex, sha256hex, size)
+	if err != nil {
+		return nil, nil, err
+	}
+	if err = hashReader.AddChecksum(checksum, r.Trailer); err != nil {
+		return nil, nil, err
+	}
+	return hashReader, checksum, nil
+}
+
+// setChecksumHeader sets the header of the checksum c, if any.
+func setChecksumHeader(w http.ResponseWriter, c *hash.Checksum) {
+	if c != nil && c.Encoded != "" {
+		w.Header()[c.Type.Key()] = []string{c.Encoded}
+	}
+}
+
+// setObjectChecksumHeader sets the checksum header of GET and HEAD
+// responses, if requested and the full object is returned.
+func setObjectChecksumHeader(w http.ResponseWriter, r *http.Request, objInfo ObjectInfo, rs *HTTPRangeSpec, opts ObjectOptions) {
+	if !strings.EqualFold(r.Header.Get(xhttp.AmzChecksumMode), checksumModeEnabled) || rs != nil || opts.PartNumber > 0 {
+		return
+	}
+	setChecksumHeader(w, objInfo.checksum())
+}
+
+// checksum returns the additional checksum of the object,
+// or nil if it was uploaded without.
+func (o ObjectInfo) checksum() *hash.Checksum {
+	return hash.ParseChecksum(o.UserDefined[objectChecksumKey])
+}
+
+// partChecksums returns the checksums of the parts of a multipart
+// object in the order of o.Parts, or nil if it was uploaded without.
+func (o ObjectInfo) partChecksums() []*hash.Checksum {
+	c := o.checksum()
+	v := o.UserDefined[objectChecksumPartsKey]
+	if c == nil || v == "" {
+		return nil
+	}
+	values := strings.Split(v, ",")
+	if len(values) != len(o.Parts) {
+		return nil
+	}
+	checksums := make([]*hash.Checksum, len(values))
+	for i, value := range values {
+		checksums[i] = hash.NewChecksumString(c.Type, value)
+	}
+	return checksums
+}
+
+// completeMultipartChecksum sets the composite checksum and the part
+// checksums of a multipart object completed with parts, if the upload
+// declared a checksum algorithm. The part checksums of the upload are
+// removed from metadata.
+func completeMultipartChecksum(metadata map[string]string, uploadParts []ObjectPartInfo, parts []ObjectPartInfo) error {
+	t := hash.NewChecksumType(metadata[uploadChecksumTypeKey])
+	checksums := make([]*hash.Checksum, 0, len(parts))
+	for _, part := range parts {
+		c := hash.ParseChecksum(metadata[uploadPartChecksumKey(part.Number)])
+		if t.IsSet() && (c == nil || c.Type != t) {
+			return InvalidPart{PartNumber: part.Number}
+		}
+		checksums = append(checksums, c)
+	}
+	for _, part := range uploadParts {
+		delete(metadata, uploadPartChecksumKey(part.Number))
+	}
+	delete(metadata, uploadChecksumTypeKey)
+	if !t.IsSet() {
+		return nil
+	}
+
+	composite, err := hash.CompositeChecksum(t, checksums)
+	if err != nil {
+		return err
+	}
+	values := make([]string, len(checksums))
+	for i, c := range checksums {
+		values[i] = c.Encoded
+	}
+	metadata[objectChecksumKey] = composite.String()
+	metadata[objectChecksumPartsKey] = strings.Join(values, ",")
+	return nil
+}
+
+// newObjectAttributesChecksum returns c in the format of get
+// object attributes responses.
+func newObjectAttributesChecksum(c *hash.Checksum) ObjectAttributesChecksum {
+	var checksum ObjectAttributesChecksum
+	if c == nil {
+		return checksum
+	}
+	switch c.Type {
+	case hash.ChecksumCRC32:
+		checksum.ChecksumCRC32 = c.Encoded
+	case hash.ChecksumCRC32C:
+		checksum.ChecksumCRC32C = c.Encoded
+	case hash.ChecksumSHA1:
+		checksum.ChecksumSHA1 = c.Encoded
+	case hash.ChecksumSHA256:
+		checksum.ChecksumSHA256 = c.Encoded
+	}
+	return checksum
+}
@@ -0,0 +1,202 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/xml"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/minio/minio/internal/hash"
+	xhttp "github.com/minio/minio/internal/http"
+)
+
+func checksumCRC32C(data []byte) string {
+	h := hash.ChecksumCRC32C.Hasher()
+	h.Write(data)
+	return base64.StdEncoding.EncodeToString(h.Sum(nil))
+}
+
+func TestUnsignedV4ChunkedReader(t *testing.T) {
+	body := "5\r\nhello\r\n6;ext=1\r\n world\r\n0\r\nx-amz-checksum-crc32c:" + checksumCRC32C([]byte("hello world")) + "\r\n\r\n"
+	req, err := http.NewRequest(http.MethodPut, "http://localhost/bucket/object", strings.NewReader(body))
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := ioutil.ReadAll(newUnsignedV4ChunkedReader(req))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != "hello world" {
+		t.Errorf("expected %q, got %q", "hello world", data)
+	}
+	if v := req.Trailer.Get(hash.ChecksumCRC32C.Key()); v != checksumCRC32C([]byte("hello world")) {
+		t.Errorf("unexpected trailing checksum %q", v)
+	}
+
+	for _, body := range []string{"5\r\nhello\r\n", "5\r\nhelloX\r\n0\r\n\r\n", "z\r\n\r\n"} {
+		req, err = http.NewRequest(http.MethodPut, "http://localhost/bucket/object", strings.NewReader(body))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err = ioutil.ReadAll(newUnsignedV4ChunkedReader(req)); err == nil {
+			t.Errorf("expected malformed payload %q to be rejected", body)
+		}
+	}
+}
+
+func TestCompleteMultipartChecksum(t *testing.T) {
+	a := &hash.Checksum{Type: hash.ChecksumCRC32C, Encoded: checksumCRC32C([]byte("a"))}
+	b := &hash.Checksum{Type: hash.ChecksumCRC32C, Encoded: checksumCRC32C([]byte("b"))}
+	uploadParts := []ObjectPartInfo{{Number: 1}, {Number: 2}, {Number: 3}}
+	metadata := map[string]string{
+		uploadChecksumTypeKey:    "CRC32C",
+		uploadPartChecksumKey(1): a.String(),
+		uploadPartChecksumKey(2): a.String(),
+		uploadPartChecksumKey(3): b.String(),
+	}
+	if err := completeMultipartChecksum(metadata, uploadParts, []ObjectPartInfo{{Number: 1}, {Number: 3}}); err != nil {
+		t.Fatal(err)
+	}
+	composite, _ := hash.CompositeChecksum(hash.ChecksumCRC32C, []*hash.Checksum{a, b})
+	if metadata[objectChecksumKey] != composite.String() || metadata[objectChecksumPartsKey] != a.Encoded+","+b.Encoded {
+		t.Errorf("unexpected checksums %v", metadata)
+	}
+	if len(metadata) != 2 {
+		t.Errorf("expected the upload checksums to be removed, got %v", metadata)
+	}
+
+	metadata = map[string]string{uploadChecksumTypeKey: "CRC32C", uploadPartChecksumKey(1): a.String()}
+	if err := completeMultipartChecksum(metadata, uploadParts, []ObjectPartInfo{{Number: 1}, {Number: 2}}); err == nil {
+		t.Error("expected parts without checksum to be rejected")
+	}
+}
+
+func TestPutObjectChecksum(t *testing.T) {
+	testServer := StartTestServer(t, "ErasureSD")
+	defer testServer.Stop()
+
+	// Headers with empty values are removed.
+	do := func(method, path string, body []byte, headers map[string]string) (*http.Response, []byte) {
+		t.Helper()
+		req, err := newTestRequest(method, testServer.Server.URL+path, int64(len(body)), bytes.NewReader(body))
+		if err != nil {
+			t.Fatal(err)
+		}
+		for k, v := range headers {
+			if v == "" {
+				req.Header.Del(k)
+			} else {
+				req.Header.Set(k, v)
+			}
+		}
+		if err = signRequestV4(req, testServer.AccessKey, testServer.SecretKey); err != nil {
+			t.Fatal(err)
+		}
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer resp.Body.Close()
+		data, err := ioutil.ReadAll(resp.Body)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return resp, data
+	}
+
+	if resp, _ := do(http.MethodPut, "/bucket", nil, nil); resp.StatusCode != http.StatusOK {
+		t.Fatalf("unable to create bucket: %s", resp.Status)
+	}
+
+	data := []byte("hello world")
+	want := checksumCRC32C(data)
+	resp, _ := do(http.MethodPut, "/bucket/object", data, map[string]string{hash.ChecksumCRC32C.Key(): want})
+	if resp.StatusCode != http.StatusOK || resp.Header.Get(hash.ChecksumCRC32C.Key()) != want {
+		t.Fatalf("expected the checksum to be accepted, got %s, %v", resp.Status, resp.Header)
+	}
+	resp, _ = do(http.MethodPut, "/bucket/object", data, map[string]string{hash.ChecksumCRC32C.Key(): checksumCRC32C([]byte("other"))})
+	if resp.StatusCode != http.StatusBadRequest {
+		t.Fatalf("expected a wrong checksum to be rejected, got %s", resp.Status)
+	}
+
+	if resp, _ = do(http.MethodHead, "/bucket/object", nil, nil); resp.Header.Get(hash.ChecksumCRC32C.Key()) != "" {
+		t.Error("expected the checksum only to be returned on request")
+	}
+	resp, _ = do(http.MethodHead, "/bucket/object", nil, map[string]string{"x-amz-checksum-mode": "ENABLED"})
+	if resp.Header.Get(hash.ChecksumCRC32C.Key()) != want {
+		t.Errorf("expected checksum %s, got %v", want, resp.Header)
+	}
+
+	// Upload with a trailing checksum.
+	body := []byte("b\r\nhello world\r\n0\r\nx-amz-checksum-crc32c:" + want + "\r\n\r\n")
+	resp, _ = do(http.MethodPut, "/bucket/trailer", body, map[string]string{
+		"x-amz-content-sha256":         unsignedPayloadTrailer,
+		"x-amz-decoded-content-length": strconv.Itoa(len(data)),
+		"x-amz-trailer":                "x-amz-checksum-crc32c",
+		"content-encoding":             "aws-chunked",
+		"content-md5":                  "",
+	})
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("expected the trailing checksum to be accepted, got %s", resp.Status)
+	}
+
+	getAttributes := func(object string) GetObjectAttributesResponse {
+		t.Helper()
+		_, body := do(http.MethodGet, "/bucket/"+object+"?attributes", nil, map[string]string{"x-amz-object-attributes": "Checksum,ObjectParts"})
+		var attrs GetObjectAttributesResponse
+		if err := xml.Unmarshal(body, &attrs); err != nil {
+			t.Fatal(err)
+		}
+		return attrs
+	}
+	if attrs := getAttributes("trailer"); attrs.Checksum == nil || attrs.Checksum.ChecksumCRC32C != want {
+		t.Errorf("expected checksum %s, got %+v", want, attrs.Checksum)
+	}
+
+	// Multipart upload with a checksum algorithm, the part checksum is computed.
+	resp, body = do(http.MethodPost, "/bucket/multipart?uploads", nil, map[string]string{"x-amz-checksum-algorithm": "CRC32C"})
+	if resp.StatusCode != http.StatusOK || resp.Header.Get(xhttp.AmzChecksumAlgorithm) != "CRC32C" {
+		t.Fatalf("unable to create multipart upload: %s, %v", resp.Status, resp.Header)
+	}
+	var upload InitiateMultipartUploadResponse
+	if err := xml.Unmarshal(body, &upload); err != nil {
+		t.Fatal(err)
+	}
+	resp, _ = do(http.MethodPut, "/bucket/multipart?partNumber=1&uploadId="+upload.UploadID, data, nil)
+	if resp.StatusCode != http.StatusOK || resp.Header.Get(hash.ChecksumCRC32C.Key()) != want {
+		t.Fatalf("expected the part checksum to be computed, got %s, %v", resp.Status, resp.Header)
+	}
+	complete := "<CompleteMultipartUpload><Part><PartNumber>1</PartNumber><ETag>" + resp.Header.Get(xhttp.ETag) + "</ETag></Part></CompleteMultipartUpload>"
+	if resp, _ = do(http.MethodPost, "/bucket/multipart?uploadId="+upload.UploadID, []byte(complete), nil); resp.StatusCode != http.StatusOK {
+		t.Fatalf("unable to complete multipart upload: %s", resp.Status)
+	}
+	composite, _ := hash.CompositeChecksum(hash.ChecksumCRC32C, []*hash.Checksum{{Type: hash.ChecksumCRC32C, Encoded: want}})
+	attrs := getAttributes("multipart")
+	if attrs.Checksum == nil || attrs.Checksum.ChecksumCRC32C != composite.Encoded {
+		t.Errorf("expected checksum %s, got %+v", composite.Encoded, attrs.Checksum)
+	}
+	if attrs.ObjectParts == nil || len(attrs.ObjectParts.Parts) != 1 || attrs.ObjectParts.Parts[0].ChecksumCRC32C != want {
+		t.Errorf("expected part checksum %s, got %+v", want, attrs.ObjectParts)
+	}
+}
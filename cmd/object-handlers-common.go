@@ -219,6 +219,62 @@ func checkPreconditions(ctx context.Context, w http.ResponseWriter, r *http.Requ
 	return false
 }
 
+// Validates the preconditions of a conditional PutObject or
+// CompleteMultipartUpload against objInfo, the latest version of the
+// object or an empty ObjectInfo when it does not exist. Returns true if
+// the preconditions failed. Preconditions supported are:
+//
+//	If-Match
+//	If-None-Match
+func checkPreconditionsPUT(r *http.Request, objInfo ObjectInfo) bool {
+	exists := objInfo.Name != ""
+
+	// If-Match : Write the object only if it exists with the specified
+	// ETag, or exists at all for "*".
+	if ifMatchETagHeader := r.Header.Get(xhttp.IfMatch); ifMatchETagHeader != "" {
+		if !exists || (ifMatchETagHeader != "*" && !isETagEqual(objInfo.ETag, ifMatchETagHeader)) {
+			return true
+		}
+	}
+
+	// If-None-Match : Write the object only if it does not exist for "*",
+	// or exists with a different ETag.
+	if ifNoneMatchETagHeader := r.Header.Get(xhttp.IfNoneMatch); ifNoneMatchETagHeader != "" && exists {
+		if ifNoneMatchETagHeader == "*" || isETagEqual(objInfo.ETag, ifNoneMatchETagHeader) {
+			return true
+		}
+	}
+	return false
+}
+
+// putPreconditionFn returns the function evaluating the preconditions
+// of a conditional write, nil when the request has none.
+func putPreconditionFn(r *http.Request) CheckPreconditionFn {
+	if r.Header.Get(xhttp.IfMatch) == "" && r.Header.Get(xhttp.IfNoneMatch) == "" {
+		return nil
+	}
+	return func(oi ObjectInfo) bool {
+		return checkPreconditionsPUT(r, oi)
+	}
+}
+
+// isBucketAppendable returns whether objects of the bucket can be
+// appended to. An append rewrites the latest version in place, which
+// is not allowed for buckets with object lock, versioning or
+// replication configured.
+func isBucketAppendable(ctx context.Context, bucket string) bool {
+	if rcfg, _ := globalBucketObjectLockSys.Get(bucket); rcfg.LockEnabled {
+		return false
+	}
+	if globalBucketVersioningSys.Enabled(bucket) || globalBucketVersioningSys.Suspended(bucket) {
+		return false
+	}
+	if _, err := getReplicationConfig(ctx, bucket); err == nil {
+		return false
+	}
+	return true
+}
+
 // returns true if object was modified after givenTime.
 func ifModifiedSince(objTime time.Time, givenTime time.Time) bool {
 	// The Date-Modified header truncates sub-second precision, so
@@ -249,6 +305,10 @@ func setPutObjHeaders(w http.ResponseWriter, objInfo ObjectInfo, delete bool) {
 		w.Header()[xhttp.ETag] = []string{`"` + objInfo.ETag + `"`}
 	}
 
+	if !delete {
+		setChecksumHeader(w, objInfo.checksum())
+	}
+
 	// Set the relevant version ID as part of the response header.
 	if objInfo.VersionID != "" {
 		w.Header()[xhttp.AmzVersionID] = []string{objInfo.VersionID}
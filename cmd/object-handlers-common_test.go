@@ -18,7 +18,13 @@
 package cmd
 
 import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"strings"
 	"testing"
+
+	xhttp "github.com/minio/minio/internal/http"
 )
 
 // Tests - canonicalizeETag()
@@ -51,3 +57,125 @@ func TestCanonicalizeETag(t *testing.T) {
 		}
 	}
 }
+
+func TestConditionalPutObject(t *testing.T) {
+	for _, instanceType := range []string{ErasureSDStr, ErasureTestStr, "FS"} {
+		t.Run(instanceType, func(t *testing.T) {
+			testConditionalPutObject(t, instanceType)
+		})
+	}
+}
+
+func testConditionalPutObject(t *testing.T, instanceType string) {
+	testServer := StartTestServer(t, instanceType)
+	defer testServer.Stop()
+
+	ctx := context.Background()
+	objAPI := testServer.Obj
+	if err := objAPI.MakeBucketWithLocation(ctx, "bucket", BucketOptions{}); err != nil {
+		t.Fatal(err)
+	}
+
+	var etag string
+	put := func(object, header, value string) int {
+		t.Helper()
+		data := "data of " + object
+		req, err := newTestSignedRequestV4(http.MethodPut, testServer.Server.URL+"/bucket/"+object, int64(len(data)),
+			strings.NewReader(data), testServer.AccessKey, testServer.SecretKey, map[string]string{header: value})
+		if err != nil {
+			t.Fatal(err)
+		}
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer resp.Body.Close()
+		ioutil.ReadAll(resp.Body)
+		if resp.StatusCode == http.StatusOK {
+			etag = resp.Header.Get(xhttp.ETag)
+		}
+		return resp.StatusCode
+	}
+
+	testCases := []struct {
+		object string
+		header string
+		value  func() string
+		status int
+	}{
+		{"object", xhttp.IfNoneMatch, func() string { return "*" }, http.StatusOK},
+		{"object", xhttp.IfNoneMatch, func() string { return "*" }, http.StatusPreconditionFailed},
+		{"object", xhttp.IfMatch, func() string { return `"0123456789abcdef0123456789abcdef"` }, http.StatusPreconditionFailed},
+		{"object", xhttp.IfMatch, func() string { return etag }, http.StatusOK},
+		{"object", xhttp.IfMatch, func() string { return "*" }, http.StatusOK},
+		{"object", xhttp.IfNoneMatch, func() string { return etag }, http.StatusPreconditionFailed},
+		{"missing", xhttp.IfMatch, func() string { return "*" }, http.StatusPreconditionFailed},
+	}
+	for i, testCase := range testCases {
+		if status := put(testCase.object, testCase.header, testCase.value()); status != testCase.status {
+			t.Errorf("case %d: expected status %d, got %d", i+1, testCase.status, status)
+		}
+	}
+
+	// Multipart uploads are conditional on completion.
+	completeOpts := ObjectOptions{CheckPrecondFn: func(oi ObjectInfo) bool { return oi.Name != "" }}
+	for i, object := range []string{"object", "multipart"} {
+		uploadID, err := objAPI.NewMultipartUpload(ctx, "bucket", object, ObjectOptions{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		data := "part"
+		part, err := objAPI.PutObjectPart(ctx, "bucket", object, uploadID, 1, mustGetPutObjReader(t, strings.NewReader(data), int64(len(data)), "", ""), ObjectOptions{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		_, err = objAPI.CompleteMultipartUpload(ctx, "bucket", object, uploadID, []CompletePart{{PartNumber: 1, ETag: part.ETag}}, completeOpts)
+		if exists := i == 0; exists != isErrPreconditionFailed(err) {
+			t.Errorf("unexpected result completing %s: %v", object, err)
+		}
+	}
+}
+
+// Tests that appends are rejected in versioned buckets.
+func TestAppendObjectVersionedBucket(t *testing.T) {
+	testServer := StartTestServer(t, ErasureTestStr)
+	defer testServer.Stop()
+
+	ctx := context.Background()
+	objAPI := testServer.Obj
+	if err := objAPI.MakeBucketWithLocation(ctx, "bucket", BucketOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	if err := objAPI.MakeBucketWithLocation(ctx, "versioned", BucketOptions{VersioningEnabled: true}); err != nil {
+		t.Fatal(err)
+	}
+
+	appendObject := func(bucket string) int {
+		t.Helper()
+		data := "data appended to " + bucket
+		req, err := newTestSignedRequestV4(http.MethodPut, testServer.Server.URL+"/"+bucket+"/object", int64(len(data)),
+			strings.NewReader(data), testServer.AccessKey, testServer.SecretKey, map[string]string{xhttp.MinIOAppendObject: "true"})
+		if err != nil {
+			t.Fatal(err)
+		}
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer resp.Body.Close()
+		ioutil.ReadAll(resp.Body)
+		return resp.StatusCode
+	}
+
+	for i := 0; i < 2; i++ {
+		if status := appendObject("bucket"); status != http.StatusOK {
+			t.Fatalf("expected append %d to an unversioned bucket to succeed, got %d", i+1, status)
+		}
+	}
+	if status := appendObject("versioned"); status != http.StatusConflict {
+		t.Fatalf("expected append to a versioned bucket to be rejected, got %d", status)
+	}
+	if _, err := objAPI.GetObjectInfo(ctx, "versioned", "object", ObjectOptions{}); !isErrObjectNotFound(err) {
+		t.Fatalf("expected no object to be created in the versioned bucket, got %v", err)
+	}
+}
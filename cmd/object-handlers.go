@@ -406,6 +406,19 @@ func (api objectAPIHandlers) getObjectHandler(ctx context.Context, objectAPI Obj
 		}
 	}
 
+	// Objects read through an access point are transformed by its endpoint,
+	// only the whole object can be transformed.
+	transform, s3Error := getBucketTransform(ctx, bucket, r.Form.Get(xhttp.AccessPoint))
+	if s3Error != ErrNone {
+		writeErrorResponse(ctx, w, errorCodes.ToAPIErr(s3Error), r.URL)
+		return
+	}
+	if transform != nil && (rs != nil || opts.PartNumber > 0) {
+		writeErrorResponse(ctx, w, errorCodes.ToAPIErrWithErr(ErrInvalidRequest,
+			errors.New("range and partNumber are not supported with an access point")), r.URL)
+		return
+	}
+
 	// Validate pre-conditions if any.
 	opts.CheckPrecondFn = func(oi ObjectInfo) bool {
 		if objectAPI.IsEncryptionSupported() {
@@ -495,6 +508,26 @@ func (api objectAPIHandlers) getObjectHandler(ctx context.Context, objectAPI Obj
 	// filter object lock metadata if permission does not permit
 	objInfo.UserDefined = objectlock.FilterObjectLockMetadata(objInfo.UserDefined, getRetPerms != ErrNone, legalHoldPerms != ErrNone)
 
+	var body io.Reader = gr
+	if globalFSZeroCopy {
+		// Bypass the object reader so that the data may
+		// be sent straight from the file with sendfile.
+		if zr := gr.zeroCopyReader(); zr != nil {
+			body = zr
+		}
+	}
+	var transformed *http.Response
+	if transform != nil {
+		transformed, err = transform.transform(ctx, bucket, objInfo, gr)
+		globalBucketTransformStats.record(bucket, transform.Name, err != nil)
+		if err != nil {
+			writeErrorResponse(ctx, w, errorCodes.ToAPIErrWithErr(ErrObjectTransformFailed, err), r.URL)
+			return
+		}
+		defer transformed.Body.Close()
+		body = transformed.Body
+	}
+
 	// Set encryption response headers
 	if objectAPI.IsEncryptionSupported() {
 		switch kind, _ := crypto.IsEncrypted(objInfo.UserDefined); kind {
@@ -522,8 +555,15 @@ func (api objectAPIHandlers) getObjectHandler(ctx context.Context, objectAPI Obj
 		setPartsCountHeaders(w, objInfo)
 	}
 
+	// Set the additional checksum, if requested.
+	setObjectChecksumHeader(w, r, objInfo, rs, opts)
+
 	setHeadGetRespHeaders(w, r.Form)
 
+	if transformed != nil {
+		setTransformedHeaders(w, transformed)
+	}
+
 	statusCodeWritten := false
 	httpWriter := xioutil.WriteOnClose(w)
 	if rs != nil || opts.PartNumber > 0 {
@@ -532,7 +572,7 @@ func (api objectAPIHandlers) getObjectHandler(ctx context.Context, objectAPI Obj
 	}
 
 	// Write object content to response body
-	if _, err = xioutil.Copy(httpWriter, gr); err != nil {
+	if _, err = xioutil.Copy(httpWriter, body); err != nil {
 		if !httpWriter.HasWritten() && !statusCodeWritten {
 			// write error response only if no data or headers has been written to client yet
 			writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL)
@@ -555,6 +595,8 @@ func (api objectAPIHandlers) getObjectHandler(ctx context.Context, objectAPI Obj
 		return
 	}
 
+	setRequestEncryption(ctx, objInfo.UserDefined)
+
 	// Notify object accessed via a GET request.
 	sendEvent(eventArgs{
 		EventName:    event.ObjectAccessedGet,
@@ -783,6 +825,9 @@ func (api objectAPIHandlers) headObjectHandler(ctx context.Context, objectAPI Ob
 		setPartsCountHeaders(w, objInfo)
 	}
 
+	// Set the additional checksum, if requested.
+	setObjectChecksumHeader(w, r, objInfo, rs, opts)
+
 	// Set any additional requested response headers.
 	setHeadGetRespHeaders(w, r.Form)
 
@@ -833,6 +878,195 @@ func (api objectAPIHandlers) HeadObjectHandler(w http.ResponseWriter, r *http.Re
 	}
 }
 
+// Attributes which can be requested by GetObjectAttributes.
+const (
+	objectAttributeETag         = "ETag"
+	objectAttributeChecksum     = "Checksum"
+	objectAttributeObjectParts  = "ObjectParts"
+	objectAttributeStorageClass = "StorageClass"
+	objectAttributeObjectSize   = "ObjectSize"
+)
+
+// parseObjectAttributes parses the comma separated attributes of the
+// X-Amz-Object-Attributes header values, at least one is required.
+func parseObjectAttributes(values []string) (map[string]struct{}, APIErrorCode) {
+	attributes := make(map[string]struct{})
+	for _, value := range values {
+		for _, attribute := range strings.Split(value, ",") {
+			attribute = strings.TrimSpace(attribute)
+			switch attribute {
+			case objectAttributeETag, objectAttributeChecksum, objectAttributeObjectParts,
+				objectAttributeStorageClass, objectAttributeObjectSize:
+				attributes[attribute] = struct{}{}
+			default:
+				return nil, ErrInvalidAttributeName
+			}
+		}
+	}
+	if len(attributes) == 0 {
+		return nil, ErrInvalidAttributeName
+	}
+	return attributes, ErrNone
+}
+
+// getObjectAttributesParts returns at most maxParts parts of objInfo
+// following the part number marker.
+func getObjectAttributesParts(objInfo ObjectInfo, partNumberMarker, maxParts int) *ObjectAttributesParts {
+	parts := &ObjectAttributesParts{
+		PartsCount:       len(objInfo.Parts),
+		PartNumberMarker: partNumberMarker,
+		MaxParts:         maxParts,
+	}
+	checksums := objInfo.partChecksums()
+	for i, part := range objInfo.Parts {
+		if part.Number <= partNumberMarker {
+			continue
+		}
+		if len(parts.Parts) == maxParts {
+			parts.IsTruncated = true
+			break
+		}
+		size := part.ActualSize
+		if size <= 0 {
+			size = part.Size
+		}
+		attrPart := ObjectAttributesPart{PartNumber: part.Number, Size: size}
+		if checksums != nil {
+			attrPart.ObjectAttributesChecksum = newObjectAttributesChecksum(checksums[i])
+		}
+		parts.Parts = append(parts.Parts, attrPart)
+		parts.NextPartNumberMarker = part.Number
+	}
+	return parts
+}
+
+// GetObjectAttributesHandler - GET Object?attributes
+// -----------
+// Returns the requested attributes of an object without its content,
+// the parts of multipart objects are listed with
+// X-Amz-Max-Parts and X-Amz-Part-Number-Marker. The Checksum attribute
+// is only returned for objects uploaded with an additional checksum.
+func (api objectAPIHandlers) GetObjectAttributesHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "GetObjectAttributes")
+
+	defer logger.AuditLog(ctx, w, r, mustGetClaimsFromToken(r))
+
+	objectAPI := api.ObjectAPI()
+	if objectAPI == nil {
+		writeErrorResponse(ctx, w, errorCodes.ToAPIErr(ErrServerNotInitialized), r.URL)
+		return
+	}
+
+	vars := mux.Vars(r)
+	bucket := vars["bucket"]
+	object, err := unescapePath(vars["object"])
+	if err != nil {
+		writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL)
+		return
+	}
+
+	attributes, s3Error := parseObjectAttributes(r.Header.Values(xhttp.AmzObjectAttributes))
+	if s3Error != ErrNone {
+		writeErrorResponse(ctx, w, errorCodes.ToAPIErr(s3Error), r.URL)
+		return
+	}
+
+	maxParts := maxPartsList
+	if v := r.Header.Get(xhttp.AmzMaxParts); v != "" {
+		if maxParts, err = strconv.Atoi(v); err != nil || maxParts < 0 {
+			writeErrorResponse(ctx, w, errorCodes.ToAPIErr(ErrInvalidMaxParts), r.URL)
+			return
+		}
+		if maxParts > maxPartsList {
+			maxParts = maxPartsList
+		}
+	}
+	var partNumberMarker int
+	if v := r.Header.Get(xhttp.AmzPartNumberMarker); v != "" {
+		if partNumberMarker, err = strconv.Atoi(v); err != nil || partNumberMarker < 0 {
+			writeErrorResponse(ctx, w, errorCodes.ToAPIErr(ErrInvalidPartNumberMarker), r.URL)
+			return
+		}
+	}
+
+	if s3Error := checkRequestAuthType(ctx, r, policy.GetObjectAction, bucket, object); s3Error != ErrNone {
+		writeErrorResponse(ctx, w, errorCodes.ToAPIErr(s3Error), r.URL)
+		return
+	}
+
+	opts, err := getOpts(ctx, r, bucket, object)
+	if err != nil {
+		writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL)
+		return
+	}
+
+	getObjectInfo := objectAPI.GetObjectInfo
+	if api.CacheAPI() != nil {
+		getObjectInfo = api.CacheAPI().GetObjectInfo
+	}
+
+	objInfo, err := getObjectInfo(ctx, bucket, object, opts)
+	if err != nil {
+		if objInfo.VersionID != "" && objInfo.DeleteMarker {
+			w.Header()[xhttp.AmzVersionID] = []string{objInfo.VersionID}
+			w.Header()[xhttp.AmzDeleteMarker] = []string{strconv.FormatBool(objInfo.DeleteMarker)}
+		}
+		writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL)
+		return
+	}
+
+	if objectAPI.IsEncryptionSupported() {
+		if _, err = DecryptObjectInfo(&objInfo, r); err != nil {
+			writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL)
+			return
+		}
+		if crypto.SSEC.IsEncrypted(objInfo.UserDefined) {
+			// Validate the SSE-C Key set in the header.
+			if _, err = crypto.SSEC.UnsealObjectKey(r.Header, objInfo.UserDefined, bucket, object); err != nil {
+				writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL)
+				return
+			}
+		}
+	}
+
+	var response GetObjectAttributesResponse
+	if _, ok := attributes[objectAttributeETag]; ok {
+		response.ETag = objInfo.ETag
+	}
+	if _, ok := attributes[objectAttributeChecksum]; ok {
+		if c := objInfo.checksum(); c != nil {
+			checksum := newObjectAttributesChecksum(c)
+			response.Checksum = &checksum
+		}
+	}
+	if _, ok := attributes[objectAttributeObjectParts]; ok && strings.Contains(objInfo.ETag, "-") && len(objInfo.Parts) > 0 {
+		response.ObjectParts = getObjectAttributesParts(objInfo, partNumberMarker, maxParts)
+	}
+	if _, ok := attributes[objectAttributeStorageClass]; ok {
+		response.StorageClass = objInfo.StorageClass
+		if response.StorageClass == "" {
+			response.StorageClass = globalMinioDefaultStorageClass
+		}
+	}
+	if _, ok := attributes[objectAttributeObjectSize]; ok {
+		size, err := objInfo.GetActualSize()
+		if err != nil {
+			writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL)
+			return
+		}
+		response.ObjectSize = &size
+	}
+
+	if !objInfo.ModTime.IsZero() {
+		w.Header().Set(xhttp.LastModified, objInfo.ModTime.UTC().Format(http.TimeFormat))
+	}
+	if objInfo.VersionID != "" {
+		w.Header()[xhttp.AmzVersionID] = []string{objInfo.VersionID}
+	}
+
+	writeSuccessResponseXML(w, encodeResponse(response))
+}
+
 // Extract metadata relevant for an CopyObject operation based on conditional
 // header values specified in X-Amz-Metadata-Directive.
 func getCpObjMetadataFromHeader(ctx context.Context, r *http.Request, userMeta map[string]string) (map[string]string, error) {
@@ -1240,7 +1474,7 @@ func (api objectAPIHandlers) CopyObjectHandler(w http.ResponseWriter, r *http.Re
 				}
 			}
 
-			if err = rotateKey(oldKey, newKeyID, newKey, srcBucket, srcObject, encMetadata, kmsCtx); err != nil {
+			if err = rotateKey(ctx, oldKey, newKeyID, newKey, srcBucket, srcObject, encMetadata, kmsCtx); err != nil {
 				writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL)
 				return
 			}
@@ -1278,7 +1512,7 @@ func (api objectAPIHandlers) CopyObjectHandler(w http.ResponseWriter, r *http.Re
 			if isTargetEncrypted {
 				var encReader io.Reader
 				kind, _ := crypto.IsRequested(r.Header)
-				encReader, objEncKey, err = newEncryptReader(srcInfo.Reader, kind, newKeyID, newKey, dstBucket, dstObject, encMetadata, kmsCtx)
+				encReader, objEncKey, err = newEncryptReader(ctx, srcInfo.Reader, kind, newKeyID, newKey, dstBucket, dstObject, encMetadata, kmsCtx)
 				if err != nil {
 					writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL)
 					return
@@ -1533,6 +1767,8 @@ func (api objectAPIHandlers) CopyObjectHandler(w http.ResponseWriter, r *http.Re
 	// Write success response.
 	writeSuccessResponseXML(w, encodedSuccessResponse)
 
+	setRequestEncryption(ctx, objInfo.UserDefined)
+
 	// Notify object created event.
 	sendEvent(eventArgs{
 		EventName:    event.ObjectCreatedCopy,
@@ -1603,6 +1839,20 @@ func (api objectAPIHandlers) PutObjectHandler(w http.ResponseWriter, r *http.Req
 		}
 	}
 
+	// Appends extend the object in place, which neither gateways
+	// nor buckets keeping or replicating versions can allow.
+	appendObject := r.Header.Get(xhttp.MinIOAppendObject) == "true"
+	if appendObject {
+		if globalIsGateway {
+			writeErrorResponse(ctx, w, errorCodes.ToAPIErr(ErrNotImplemented), r.URL)
+			return
+		}
+		if !isBucketAppendable(ctx, bucket) {
+			writeErrorResponse(ctx, w, errorCodes.ToAPIErr(ErrObjectNotAppendable), r.URL)
+			return
+		}
+	}
+
 	clientETag, err := etag.FromContentMD5(r.Header)
 	if err != nil {
 		writeErrorResponse(ctx, w, errorCodes.ToAPIErr(ErrInvalidDigest), r.URL)
@@ -1612,7 +1862,7 @@ func (api objectAPIHandlers) PutObjectHandler(w http.ResponseWriter, r *http.Req
 	// if Content-Length is unknown/missing, deny the request
 	size := r.ContentLength
 	rAuthType := getRequestAuthType(r)
-	if rAuthType == authTypeStreamingSigned {
+	if rAuthType == authTypeStreamingSigned || isRequestUnsignedTrailer(r) {
 		if sizeStr, ok := r.Header[xhttp.AmzDecodedContentLength]; ok {
 			if sizeStr[0] == "" {
 				writeErrorResponse(ctx, w, errorCodes.ToAPIErr(ErrMissingContentLength), r.URL)
@@ -1636,6 +1886,12 @@ func (api objectAPIHandlers) PutObjectHandler(w http.ResponseWriter, r *http.Req
 		return
 	}
 
+	// maximum Upload size allowed by the presigned URL
+	if s3Err := checkPresignedMaxContentLength(r, size); s3Err != ErrNone {
+		writeErrorResponse(ctx, w, errorCodes.ToAPIErr(s3Err), r.URL)
+		return
+	}
+
 	metadata, err := extractMetadata(ctx, r)
 	if err != nil {
 		writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL)
@@ -1693,6 +1949,15 @@ func (api objectAPIHandlers) PutObjectHandler(w http.ResponseWriter, r *http.Req
 		if !skipContentSha256Cksum(r) {
 			sha256hex = getContentSha256Cksum(r, serviceS3)
 		}
+		if isRequestUnsignedTrailer(r) {
+			reader = newUnsignedV4ChunkedReader(r)
+		}
+	}
+
+	var checksum *hash.Checksum
+	if reader, checksum, err = newChecksumReader(r, reader, size, md5hex, sha256hex, hash.ChecksumNone); err != nil {
+		writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL)
+		return
 	}
 
 	if err := enforceBucketQuotaHard(ctx, bucket, size); err != nil {
@@ -1715,9 +1980,13 @@ func (api objectAPIHandlers) PutObjectHandler(w http.ResponseWriter, r *http.Req
 		AutoEncrypt: globalAutoEncryption,
 		Passthrough: globalIsGateway && globalGatewayName == S3BackendGateway,
 	})
+	if _, ok := crypto.IsRequested(r.Header); ok && appendObject {
+		writeErrorResponse(ctx, w, errorCodes.ToAPIErr(ErrObjectNotAppendable), r.URL)
+		return
+	}
 
 	actualSize := size
-	if objectAPI.IsCompressionSupported() && isCompressible(r.Header, object) && size > 0 {
+	if !appendObject && objectAPI.IsCompressionSupported() && isCompressible(r.Header, object) && size > 0 {
 		// Storing the compression metadata.
 		metadata[ReservedMetadataPrefix+"compression"] = compressionAlgorithmV2
 		metadata[ReservedMetadataPrefix+"actual-size"] = strconv.FormatInt(size, 10)
@@ -1753,8 +2022,17 @@ func (api objectAPIHandlers) PutObjectHandler(w http.ResponseWriter, r *http.Req
 		writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL)
 		return
 	}
+	opts.WantChecksum = checksum
+	opts.Append = appendObject
 
-	if api.CacheAPI() != nil {
+	// Conditional writes are evaluated under the object lock,
+	// which gateways do not support.
+	if opts.CheckPrecondFn = putPreconditionFn(r); opts.CheckPrecondFn != nil && globalIsGateway {
+		writeErrorResponse(ctx, w, errorCodes.ToAPIErr(ErrNotImplemented), r.URL)
+		return
+	}
+
+	if api.CacheAPI() != nil && !appendObject {
 		putObject = api.CacheAPI().PutObject
 	}
 
@@ -1878,6 +2156,8 @@ func (api objectAPIHandlers) PutObjectHandler(w http.ResponseWriter, r *http.Req
 
 	writeSuccessResponseHeadersOnly(w)
 
+	setRequestEncryption(ctx, objInfo.UserDefined)
+
 	// Notify object created event.
 	sendEvent(eventArgs{
 		EventName:    event.ObjectCreatedPut,
@@ -2257,6 +2537,13 @@ func (api objectAPIHandlers) NewMultipartUploadHandler(w http.ResponseWriter, r
 		}
 	}
 
+	// Validate the checksum algorithm of the parts if present
+	checksumType := hash.NewChecksumType(r.Header.Get(xhttp.AmzChecksumAlgorithm))
+	if r.Header.Get(xhttp.AmzChecksumAlgorithm) != "" && !checksumType.IsSet() {
+		writeErrorResponse(ctx, w, errorCodes.ToAPIErr(ErrInvalidChecksum), r.URL)
+		return
+	}
+
 	encMetadata := map[string]string{}
 
 	if objectAPI.IsEncryptionSupported() {
@@ -2318,6 +2605,10 @@ func (api objectAPIHandlers) NewMultipartUploadHandler(w http.ResponseWriter, r
 		metadata[ReservedMetadataPrefix+"compression"] = compressionAlgorithmV2
 	}
 
+	if checksumType.IsSet() {
+		metadata[uploadChecksumTypeKey] = checksumType.String()
+	}
+
 	opts, err := putOpts(ctx, r, bucket, object, metadata)
 	if err != nil {
 		writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL)
@@ -2334,6 +2625,10 @@ func (api objectAPIHandlers) NewMultipartUploadHandler(w http.ResponseWriter, r
 		return
 	}
 
+	if checksumType.IsSet() {
+		w.Header().Set(xhttp.AmzChecksumAlgorithm, checksumType.String())
+	}
+
 	response := generateInitiateMultipartUploadResponse(bucket, object, uploadID)
 	encodedSuccessResponse := encodeResponse(response)
 
@@ -2723,7 +3018,7 @@ func (api objectAPIHandlers) PutObjectPartHandler(w http.ResponseWriter, r *http
 
 	rAuthType := getRequestAuthType(r)
 	// For auth type streaming signature, we need to gather a different content length.
-	if rAuthType == authTypeStreamingSigned {
+	if rAuthType == authTypeStreamingSigned || isRequestUnsignedTrailer(r) {
 		if sizeStr, ok := r.Header[xhttp.AmzDecodedContentLength]; ok {
 			if sizeStr[0] == "" {
 				writeErrorResponse(ctx, w, errorCodes.ToAPIErr(ErrMissingContentLength), r.URL)
@@ -2747,6 +3042,12 @@ func (api objectAPIHandlers) PutObjectPartHandler(w http.ResponseWriter, r *http
 		return
 	}
 
+	// maximum Upload size allowed by the presigned URL
+	if s3Err := checkPresignedMaxContentLength(r, size); s3Err != ErrNone {
+		writeErrorResponse(ctx, w, errorCodes.ToAPIErr(s3Err), r.URL)
+		return
+	}
+
 	uploadID := r.Form.Get(xhttp.UploadID)
 	partIDString := r.Form.Get(xhttp.PartNumber)
 
@@ -2795,6 +3096,9 @@ func (api objectAPIHandlers) PutObjectPartHandler(w http.ResponseWriter, r *http
 		if !skipContentSha256Cksum(r) {
 			sha256hex = getContentSha256Cksum(r, serviceS3)
 		}
+		if isRequestUnsignedTrailer(r) {
+			reader = newUnsignedV4ChunkedReader(r)
+		}
 	}
 
 	if err := enforceBucketQuotaHard(ctx, bucket, size); err != nil {
@@ -2820,6 +3124,15 @@ func (api objectAPIHandlers) PutObjectPartHandler(w http.ResponseWriter, r *http
 		return
 	}
 
+	// Verify the additional checksum of the part, the checksum of the
+	// algorithm of the upload is computed if it is not sent.
+	var checksum *hash.Checksum
+	checksumType := hash.NewChecksumType(mi.UserDefined[uploadChecksumTypeKey])
+	if reader, checksum, err = newChecksumReader(r, reader, size, md5hex, sha256hex, checksumType); err != nil {
+		writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL)
+		return
+	}
+
 	// Read compression metadata preserved in the init multipart for the decision.
 	_, isCompressed := mi.UserDefined[ReservedMetadataPrefix+"compression"]
 
@@ -2913,6 +3226,7 @@ func (api objectAPIHandlers) PutObjectPartHandler(w http.ResponseWriter, r *http
 		putObjectPart = api.CacheAPI().PutObjectPart
 	}
 
+	opts.WantChecksum = checksum
 	partInfo, err := putObjectPart(ctx, bucket, object, uploadID, partID, pReader, opts)
 	if err != nil {
 		// Verify if the underlying error is signature mismatch.
@@ -2920,6 +3234,8 @@ func (api objectAPIHandlers) PutObjectPartHandler(w http.ResponseWriter, r *http
 		return
 	}
 
+	setChecksumHeader(w, checksum)
+
 	etag := partInfo.ETag
 	if kind, encrypted := crypto.IsEncrypted(mi.UserDefined); encrypted {
 		switch kind {
@@ -3230,14 +3546,21 @@ func (api objectAPIHandlers) CompleteMultipartUploadHandler(w http.ResponseWrite
 		}
 	}
 
-	setEventStreamHeaders(w)
-
 	opts, err := completeMultipartOpts(ctx, r, bucket, object)
 	if err != nil {
 		writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL)
 		return
 	}
 
+	// Conditional writes are evaluated under the object lock,
+	// which gateways do not support.
+	if opts.CheckPrecondFn = putPreconditionFn(r); opts.CheckPrecondFn != nil && globalIsGateway {
+		writeErrorResponse(ctx, w, errorCodes.ToAPIErr(ErrNotImplemented), r.URL)
+		return
+	}
+
+	setEventStreamHeaders(w)
+
 	// First, we compute the ETag of the multipart object.
 	// The ETag of a multi-part object is always:
 	//   ETag := MD5(ETag_p1, ETag_p2, ...)+"-N"   (N being the number of parts)
@@ -3347,6 +3670,8 @@ func (api objectAPIHandlers) CompleteMultipartUploadHandler(w http.ResponseWrite
 	// Write success response.
 	writeSuccessResponseXML(w, encodedSuccessResponse)
 
+	setRequestEncryption(ctx, objInfo.UserDefined)
+
 	// Notify object created event.
 	sendEvent(eventArgs{
 		EventName:    event.ObjectCreatedCompleteMultipartUpload,
@@ -4200,74 +4525,74 @@ func (api objectAPIHandlers) PostRestoreObjectHandler(w http.ResponseWriter, r *
 		UserAgent:  r.UserAgent(),
 		Host:       handlers.GetSourceIP(r),
 	})
-	// now process the restore in background
+	if rreq.SelectParameters.IsEmpty() {
+		// Queue the restore by the priority of its restore tier, the
+		// completion or failure is notified once it is done.
+		globalRestoreState.queue(restoreTask{
+			bucket: bucket,
+			object: object,
+			opts: ObjectOptions{
+				Transition: TransitionOptions{
+					RestoreRequest: rreq,
+					RestoreExpiry:  restoreExpiry,
+				},
+				VersionID: objInfo.VersionID,
+			},
+			event: eventArgs{
+				BucketName: bucket,
+				Object:     objInfo,
+				ReqParams:  extractReqParams(r),
+				UserAgent:  r.UserAgent(),
+				Host:       handlers.GetSourceIP(r),
+			},
+		}, rreq.restoreTier())
+		return
+	}
+
+	// now process the select restore in background
 	go func() {
 		rctx := GlobalContext
-		if !rreq.SelectParameters.IsEmpty() {
-			actualSize, err := objInfo.GetActualSize()
-			if err != nil {
-				writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL)
-				return
-			}
-
-			objectRSC := s3select.NewObjectReadSeekCloser(
-				func(offset int64) (io.ReadCloser, error) {
-					rs := &HTTPRangeSpec{
-						IsSuffixLength: false,
-						Start:          offset,
-						End:            -1,
-					}
-					return getTransitionedObjectReader(rctx, bucket, object, rs, r.Header,
-						objInfo, ObjectOptions{VersionID: objInfo.VersionID})
-				},
-				actualSize,
-			)
-
-			if err = rreq.SelectParameters.Open(objectRSC); err != nil {
-				if serr, ok := err.(s3select.SelectError); ok {
-					encodedErrorResponse := encodeResponse(APIErrorResponse{
-						Code:       serr.ErrorCode(),
-						Message:    serr.ErrorMessage(),
-						BucketName: bucket,
-						Key:        object,
-						Resource:   r.URL.Path,
-						RequestID:  w.Header().Get(xhttp.AmzRequestID),
-						HostID:     globalDeploymentID,
-					})
-					writeResponse(w, serr.HTTPStatusCode(), encodedErrorResponse, mimeXML)
-				} else {
-					writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL)
-				}
-				return
-			}
-			nr := httptest.NewRecorder()
-			rw := logger.NewResponseWriter(nr)
-			rw.LogErrBody = true
-			rw.LogAllBody = true
-			rreq.SelectParameters.Evaluate(rw)
-			rreq.SelectParameters.Close()
+		actualSize, err := objInfo.GetActualSize()
+		if err != nil {
+			writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL)
 			return
 		}
-		opts := ObjectOptions{
-			Transition: TransitionOptions{
-				RestoreRequest: rreq,
-				RestoreExpiry:  restoreExpiry,
+
+		objectRSC := s3select.NewObjectReadSeekCloser(
+			func(offset int64) (io.ReadCloser, error) {
+				rs := &HTTPRangeSpec{
+					IsSuffixLength: false,
+					Start:          offset,
+					End:            -1,
+				}
+				return getTransitionedObjectReader(rctx, bucket, object, rs, r.Header,
+					objInfo, ObjectOptions{VersionID: objInfo.VersionID})
 			},
-			VersionID: objInfo.VersionID,
-		}
-		if err := objectAPI.RestoreTransitionedObject(rctx, bucket, object, opts); err != nil {
-			logger.LogIf(ctx, err)
+			actualSize,
+		)
+
+		if err = rreq.SelectParameters.Open(objectRSC); err != nil {
+			if serr, ok := err.(s3select.SelectError); ok {
+				encodedErrorResponse := encodeResponse(APIErrorResponse{
+					Code:       serr.ErrorCode(),
+					Message:    serr.ErrorMessage(),
+					BucketName: bucket,
+					Key:        object,
+					Resource:   r.URL.Path,
+					RequestID:  w.Header().Get(xhttp.AmzRequestID),
+					HostID:     globalDeploymentID,
+				})
+				writeResponse(w, serr.HTTPStatusCode(), encodedErrorResponse, mimeXML)
+			} else {
+				writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL)
+			}
 			return
 		}
-
-		// Notify object restore completed via a POST request.
-		sendEvent(eventArgs{
-			EventName:  event.ObjectRestorePostCompleted,
-			BucketName: bucket,
-			Object:     objInfo,
-			ReqParams:  extractReqParams(r),
-			UserAgent:  r.UserAgent(),
-			Host:       handlers.GetSourceIP(r),
-		})
+		nr := httptest.NewRecorder()
+		rw := logger.NewResponseWriter(nr)
+		rw.LogErrBody = true
+		rw.LogAllBody = true
+		rreq.SelectParameters.Evaluate(rw)
+		rreq.SelectParameters.Close()
 	}()
 }
@@ -38,6 +38,8 @@ import (
 	"testing"
 
 	humanize "github.com/dustin/go-humanize"
+	"github.com/minio/minio-go/v7"
+	"github.com/minio/minio-go/v7/pkg/credentials"
 	"github.com/minio/minio/internal/auth"
 	xhttp "github.com/minio/minio/internal/http"
 	ioutilx "github.com/minio/minio/internal/ioutil"
@@ -4045,3 +4047,127 @@ func testAPIListObjectPartsHandler(obj ObjectLayer, instanceType, bucketName str
 	// `ExecObjectLayerAPINilTest` sets the Object Layer to `nil` and calls the handler.
 	ExecObjectLayerAPINilTest(t, nilBucket, nilObject, instanceType, apiRouter, nilReq)
 }
+
+func TestParseObjectAttributes(t *testing.T) {
+	testCases := []struct {
+		values   []string
+		expected []string
+		err      APIErrorCode
+	}{
+		{[]string{"ETag"}, []string{"ETag"}, ErrNone},
+		{[]string{"ETag, ObjectSize", "StorageClass"}, []string{"ETag", "ObjectSize", "StorageClass"}, ErrNone},
+		{[]string{"Checksum,ObjectParts"}, []string{"Checksum", "ObjectParts"}, ErrNone},
+		{nil, nil, ErrInvalidAttributeName},
+		{[]string{"ETag,Size"}, nil, ErrInvalidAttributeName},
+		{[]string{""}, nil, ErrInvalidAttributeName},
+	}
+	for i, testCase := range testCases {
+		attributes, errCode := parseObjectAttributes(testCase.values)
+		if errCode != testCase.err {
+			t.Errorf("case %d: expected %v, got %v", i+1, testCase.err, errCode)
+			continue
+		}
+		if len(attributes) != len(testCase.expected) {
+			t.Errorf("case %d: expected %v, got %v", i+1, testCase.expected, attributes)
+		}
+		for _, attribute := range testCase.expected {
+			if _, ok := attributes[attribute]; !ok {
+				t.Errorf("case %d: expected attribute %s", i+1, attribute)
+			}
+		}
+	}
+}
+
+func TestGetObjectAttributesHandler(t *testing.T) {
+	testServer := StartTestServer(t, "ErasureSD")
+	defer testServer.Stop()
+
+	u, err := url.Parse(testServer.Server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	client, err := minio.New(u.Host, &minio.Options{
+		Creds: credentials.NewStaticV4(testServer.AccessKey, testServer.SecretKey, ""),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.Background()
+	if err = client.MakeBucket(ctx, "bucket", minio.MakeBucketOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err = client.PutObject(ctx, "bucket", "single", strings.NewReader("hello"), 5, minio.PutObjectOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	// 3 parts of 5 MiB, 5 MiB and 1 MiB.
+	data := bytes.Repeat([]byte("a"), 11<<20)
+	if _, err = client.PutObject(ctx, "bucket", "multipart", bytes.NewReader(data), int64(len(data)),
+		minio.PutObjectOptions{PartSize: 5 << 20}); err != nil {
+		t.Fatal(err)
+	}
+
+	getAttributes := func(object string, headers map[string]string) (int, GetObjectAttributesResponse, string) {
+		req, err := newTestSignedRequestV4(http.MethodGet, makeTestTargetURL(testServer.Server.URL, "bucket", object, url.Values{"attributes": {""}}),
+			0, nil, testServer.AccessKey, testServer.SecretKey, headers)
+		if err != nil {
+			t.Fatal(err)
+		}
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer resp.Body.Close()
+		body, _ := ioutil.ReadAll(resp.Body)
+		var response GetObjectAttributesResponse
+		if resp.StatusCode == http.StatusOK {
+			if err = xml.Unmarshal(body, &response); err != nil {
+				t.Fatal(err)
+			}
+		}
+		return resp.StatusCode, response, string(body)
+	}
+
+	status, response, body := getAttributes("single", map[string]string{
+		xhttp.AmzObjectAttributes: "ETag,ObjectSize,StorageClass,ObjectParts,Checksum",
+	})
+	if status != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", status, body)
+	}
+	if response.ETag != "5d41402abc4b2a76b9719d911017c592" || response.ObjectSize == nil || *response.ObjectSize != 5 ||
+		response.StorageClass != "STANDARD" || response.ObjectParts != nil {
+		t.Errorf("unexpected attributes of a single part object %s", body)
+	}
+
+	status, response, body = getAttributes("multipart", map[string]string{
+		xhttp.AmzObjectAttributes: "ObjectParts",
+		xhttp.AmzMaxParts:         "2",
+	})
+	if status != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", status, body)
+	}
+	parts := response.ObjectParts
+	if response.ETag != "" || response.ObjectSize != nil || parts == nil || parts.PartsCount != 3 || !parts.IsTruncated ||
+		parts.NextPartNumberMarker != 2 || len(parts.Parts) != 2 || parts.Parts[0].Size != 5<<20 {
+		t.Fatalf("unexpected parts of a multipart object %s", body)
+	}
+
+	status, response, body = getAttributes("multipart", map[string]string{
+		xhttp.AmzObjectAttributes: "ObjectParts",
+		xhttp.AmzPartNumberMarker: "2",
+	})
+	parts = response.ObjectParts
+	if status != http.StatusOK || parts == nil || parts.IsTruncated || len(parts.Parts) != 1 ||
+		parts.Parts[0].PartNumber != 3 || parts.Parts[0].Size != 1<<20 {
+		t.Errorf("unexpected last part of a multipart object %d: %s", status, body)
+	}
+
+	if status, _, body = getAttributes("multipart", map[string]string{xhttp.AmzObjectAttributes: "Size"}); status != http.StatusBadRequest {
+		t.Errorf("expected invalid attributes to be rejected, got %d: %s", status, body)
+	}
+	if status, _, body = getAttributes("multipart", nil); status != http.StatusBadRequest {
+		t.Errorf("expected missing attributes to be rejected, got %d: %s", status, body)
+	}
+	if status, _, body = getAttributes("missing", map[string]string{xhttp.AmzObjectAttributes: "ETag"}); status != http.StatusNotFound {
+		t.Errorf("expected missing objects not to be found, got %d: %s", status, body)
+	}
+}
@@ -781,6 +781,73 @@ func (client *peerRESTClient) UpdateMetacacheListing(ctx context.Context, m meta
 	return resp, msgp.Decode(respBody, &resp)
 }
 
+// FindMetacacheListing - find a finished metacache which can be reused for the listing.
+func (client *peerRESTClient) FindMetacacheListing(ctx context.Context, o listPathOptions) (*metacache, error) {
+	if client == nil {
+		resp, _ := localMetacacheMgr.getBucket(ctx, o.Bucket).findReusableCache(o)
+		return &resp, nil
+	}
+
+	var reader bytes.Buffer
+	err := gob.NewEncoder(&reader).Encode(o)
+	if err != nil {
+		return nil, err
+	}
+	respBody, err := client.callWithContext(ctx, peerRESTMethodFindMetacacheListing, nil, &reader, int64(reader.Len()))
+	if err != nil {
+		logger.LogIf(ctx, err)
+		return nil, err
+	}
+	var resp metacache
+	defer http.DrainBody(respBody)
+	return &resp, msgp.Decode(respBody, &resp)
+}
+
+// InvalidateMetacache - prevent the reuse of the metacaches listing the updated objects.
+func (client *peerRESTClient) InvalidateMetacache(ctx context.Context, updates map[string][]string) error {
+	var reader bytes.Buffer
+	err := gob.NewEncoder(&reader).Encode(updates)
+	if err != nil {
+		return err
+	}
+	respBody, err := client.callWithContext(ctx, peerRESTMethodInvalidateMetacache, nil, &reader, int64(reader.Len()))
+	if err != nil {
+		return err
+	}
+	defer http.DrainBody(respBody)
+	return nil
+}
+
+// GetMetacacheInfo - describe the metacaches of the peer listing the prefix of bucket.
+func (client *peerRESTClient) GetMetacacheInfo(ctx context.Context, bucket, prefix string) ([]metacacheInfo, error) {
+	values := make(url.Values)
+	values.Set(peerRESTBucket, bucket)
+	values.Set(peerRESTListPrefix, prefix)
+	respBody, err := client.callWithContext(ctx, peerRESTMethodGetMetacacheInfo, values, nil, -1)
+	if err != nil {
+		return nil, err
+	}
+	defer http.DrainBody(respBody)
+	var infos []metacacheInfo
+	err = gob.NewDecoder(respBody).Decode(&infos)
+	return infos, err
+}
+
+// PurgeMetacache - delete the metacaches of the peer listing the prefix of bucket.
+func (client *peerRESTClient) PurgeMetacache(ctx context.Context, bucket, prefix string) (int, error) {
+	values := make(url.Values)
+	values.Set(peerRESTBucket, bucket)
+	values.Set(peerRESTListPrefix, prefix)
+	respBody, err := client.callWithContext(ctx, peerRESTMethodPurgeMetacache, values, nil, -1)
+	if err != nil {
+		return 0, err
+	}
+	defer http.DrainBody(respBody)
+	var n int
+	err = gob.NewDecoder(respBody).Decode(&n)
+	return n, err
+}
+
 func (client *peerRESTClient) ReloadPoolMeta(ctx context.Context) error {
 	respBody, err := client.callWithContext(ctx, peerRESTMethodReloadPoolMeta, nil, nil, 0)
 	if err != nil {
@@ -801,7 +868,76 @@ func (client *peerRESTClient) LoadTransitionTierConfig(ctx context.Context) erro
 	return nil
 }
 
-func (client *peerRESTClient) doTrace(traceCh chan interface{}, doneCh <-chan struct{}, traceOpts madmin.ServiceTraceOpts) {
+// LoadReplicationBandwidthLimits - reloads the bandwidth limits of the remote target endpoints.
+func (client *peerRESTClient) LoadReplicationBandwidthLimits(ctx context.Context) error {
+	respBody, err := client.callWithContext(ctx, peerRESTMethodLoadReplicationBandwidth, nil, nil, -1)
+	if err != nil {
+		return err
+	}
+	defer http.DrainBody(respBody)
+	return nil
+}
+
+// ReloadReplicationResync - reloads the replication resync status of a bucket.
+func (client *peerRESTClient) ReloadReplicationResync(ctx context.Context, bucket string) error {
+	values := make(url.Values)
+	values.Set(peerRESTBucket, bucket)
+	respBody, err := client.callWithContext(ctx, peerRESTMethodReloadReplicationResync, values, nil, -1)
+	if err != nil {
+		return err
+	}
+	defer http.DrainBody(respBody)
+	return nil
+}
+
+// GetScannerStatus - returns the data scanner status of the peer.
+func (client *peerRESTClient) GetScannerStatus(ctx context.Context) (scannerStatus, error) {
+	var st scannerStatus
+	respBody, err := client.callWithContext(ctx, peerRESTMethodGetScannerStatus, nil, nil, -1)
+	if err != nil {
+		return st, err
+	}
+	defer http.DrainBody(respBody)
+	err = gob.NewDecoder(respBody).Decode(&st)
+	return st, err
+}
+
+// GetHealProgress - returns the healing progress of the drives being healed on the peer.
+func (client *peerRESTClient) GetHealProgress(ctx context.Context) ([]healDriveProgress, error) {
+	var drives []healDriveProgress
+	respBody, err := client.callWithContext(ctx, peerRESTMethodGetHealProgress, nil, nil, -1)
+	if err != nil {
+		return nil, err
+	}
+	defer http.DrainBody(respBody)
+	err = gob.NewDecoder(respBody).Decode(&drives)
+	return drives, err
+}
+
+// LoadRebalanceMeta - asks the peer to load the rebalance saved on disk,
+// and to start rebalancing its pools when startRebalance is true.
+func (client *peerRESTClient) LoadRebalanceMeta(ctx context.Context, startRebalance bool) error {
+	values := make(url.Values)
+	values.Set(peerRESTStartRebalance, strconv.FormatBool(startRebalance))
+	respBody, err := client.callWithContext(ctx, peerRESTMethodLoadRebalanceMeta, values, nil, -1)
+	if err != nil {
+		return err
+	}
+	defer http.DrainBody(respBody)
+	return nil
+}
+
+// StopRebalance - stops the rebalance running on the peer.
+func (client *peerRESTClient) StopRebalance(ctx context.Context) error {
+	respBody, err := client.callWithContext(ctx, peerRESTMethodStopRebalance, nil, nil, -1)
+	if err != nil {
+		return err
+	}
+	defer http.DrainBody(respBody)
+	return nil
+}
+
+func (client *peerRESTClient) doTrace(traceCh chan interface{}, doneCh <-chan struct{}, traceOpts traceOptions) {
 	values := make(url.Values)
 	values.Set(peerRESTTraceErr, strconv.FormatBool(traceOpts.OnlyErrors))
 	values.Set(peerRESTTraceS3, strconv.FormatBool(traceOpts.S3))
@@ -809,6 +945,7 @@ func (client *peerRESTClient) doTrace(traceCh chan interface{}, doneCh <-chan st
 	values.Set(peerRESTTraceOS, strconv.FormatBool(traceOpts.OS))
 	values.Set(peerRESTTraceInternal, strconv.FormatBool(traceOpts.Internal))
 	values.Set(peerRESTTraceThreshold, traceOpts.Threshold.String())
+	traceOpts.encodeFilters(values)
 
 	// To cancel the REST request in case doneCh gets closed.
 	ctx, cancel := context.WithCancel(GlobalContext)
@@ -902,7 +1039,7 @@ func (client *peerRESTClient) Listen(listenCh chan interface{}, doneCh <-chan st
 }
 
 // Trace - send http trace request to peer nodes
-func (client *peerRESTClient) Trace(traceCh chan interface{}, doneCh <-chan struct{}, traceOpts madmin.ServiceTraceOpts) {
+func (client *peerRESTClient) Trace(traceCh chan interface{}, doneCh <-chan struct{}, traceOpts traceOptions) {
 	go func() {
 		for {
 			client.doTrace(traceCh, doneCh, traceOpts)
@@ -1042,8 +1179,126 @@ func (client *peerRESTClient) MonitorBandwidth(ctx context.Context, buckets []st
 	return &bandwidthReport, err
 }
 
-func (client *peerRESTClient) GetPeerMetrics(ctx context.Context) (<-chan Metric, error) {
-	respBody, err := client.callWithContext(ctx, peerRESTMethodGetPeerMetrics, nil, nil, -1)
+// GetAccessKeyStats - fetch the S3 requests per access key of the peer node
+func (client *peerRESTClient) GetAccessKeyStats(ctx context.Context) (map[string]ServerAccessKeyStats, error) {
+	respBody, err := client.callWithContext(ctx, peerRESTMethodGetAccessKeyStats, nil, nil, -1)
+	if err != nil {
+		return nil, err
+	}
+	defer http.DrainBody(respBody)
+
+	var stats map[string]ServerAccessKeyStats
+	err = gob.NewDecoder(respBody).Decode(&stats)
+	return stats, err
+}
+
+// GetTopClients - fetch the clients with the most S3 requests of the peer node
+func (client *peerRESTClient) GetTopClients(ctx context.Context) ([]ServerTopClient, error) {
+	respBody, err := client.callWithContext(ctx, peerRESTMethodGetTopClients, nil, nil, -1)
+	if err != nil {
+		return nil, err
+	}
+	defer http.DrainBody(respBody)
+
+	var clients []ServerTopClient
+	err = gob.NewDecoder(respBody).Decode(&clients)
+	return clients, err
+}
+
+// GetHotObjects - fetch the objects and prefixes read the most on the peer node
+func (client *peerRESTClient) GetHotObjects(ctx context.Context) (ServerHotObjects, error) {
+	var hot ServerHotObjects
+	respBody, err := client.callWithContext(ctx, peerRESTMethodGetHotObjects, nil, nil, -1)
+	if err != nil {
+		return hot, err
+	}
+	defer http.DrainBody(respBody)
+
+	err = gob.NewDecoder(respBody).Decode(&hot)
+	return hot, err
+}
+
+// GetAnonymousRequests - fetch the anonymous requests per bucket of the peer node
+func (client *peerRESTClient) GetAnonymousRequests(ctx context.Context) (map[string]ServerAnonymousBucketRequests, error) {
+	respBody, err := client.callWithContext(ctx, peerRESTMethodGetAnonymousRequests, nil, nil, -1)
+	if err != nil {
+		return nil, err
+	}
+	defer http.DrainBody(respBody)
+
+	var requests map[string]ServerAnonymousBucketRequests
+	err = gob.NewDecoder(respBody).Decode(&requests)
+	return requests, err
+}
+
+// GetRejectedRequests - fetch the most recent rejected requests of the peer node
+func (client *peerRESTClient) GetRejectedRequests(ctx context.Context) ([]ServerRejectedRequest, error) {
+	respBody, err := client.callWithContext(ctx, peerRESTMethodGetRejectedRequests, nil, nil, -1)
+	if err != nil {
+		return nil, err
+	}
+	defer http.DrainBody(respBody)
+
+	var rejected []ServerRejectedRequest
+	err = gob.NewDecoder(respBody).Decode(&rejected)
+	return rejected, err
+}
+
+// GetNotifyTargets - fetch the delivery statistics of the notification targets of the peer node
+func (client *peerRESTClient) GetNotifyTargets(ctx context.Context) ([]ServerNotifyTarget, error) {
+	respBody, err := client.callWithContext(ctx, peerRESTMethodGetNotifyTargets, nil, nil, -1)
+	if err != nil {
+		return nil, err
+	}
+	defer http.DrainBody(respBody)
+
+	var targets []ServerNotifyTarget
+	err = gob.NewDecoder(respBody).Decode(&targets)
+	return targets, err
+}
+
+// GetSlowRequests - fetch the most recent slow requests of the peer node
+func (client *peerRESTClient) GetSlowRequests(ctx context.Context) ([]ServerSlowRequest, error) {
+	respBody, err := client.callWithContext(ctx, peerRESTMethodGetSlowRequests, nil, nil, -1)
+	if err != nil {
+		return nil, err
+	}
+	defer http.DrainBody(respBody)
+
+	var slow []ServerSlowRequest
+	err = gob.NewDecoder(respBody).Decode(&slow)
+	return slow, err
+}
+
+// GetLiveStats - fetch a snapshot of the stats of the peer node
+func (client *peerRESTClient) GetLiveStats(ctx context.Context) (ServerLiveStats, error) {
+	var stats ServerLiveStats
+	respBody, err := client.callWithContext(ctx, peerRESTMethodGetLiveStats, nil, nil, -1)
+	if err != nil {
+		return stats, err
+	}
+	defer http.DrainBody(respBody)
+
+	err = gob.NewDecoder(respBody).Decode(&stats)
+	return stats, err
+}
+
+// ResetStats - zero the HTTP and traffic stats of the peer node
+func (client *peerRESTClient) ResetStats(ctx context.Context) error {
+	respBody, err := client.callWithContext(ctx, peerRESTMethodResetStats, nil, nil, -1)
+	if err != nil {
+		return err
+	}
+	defer http.DrainBody(respBody)
+	return nil
+}
+
+func (client *peerRESTClient) GetPeerMetrics(ctx context.Context, groups []string) (<-chan Metric, error) {
+	values := make(url.Values)
+	if len(groups) > 0 {
+		values.Set(peerRESTMetricsGroups, strings.Join(groups, ","))
+	}
+	respBody, err := client.callWithContext(ctx, peerRESTMethodGetPeerMetrics, values, nil, -1)
 	if err != nil {
 		return nil, err
 	}
@@ -18,7 +18,7 @@
 package cmd
 
 const (
-	peerRESTVersion       = "v22" // Add bulk GetBucketStats
+	peerRESTVersion       = "v38" // Add trace filters
 	peerRESTVersionPrefix = SlashSeparator + peerRESTVersion
 	peerRESTPrefix        = minioReservedBucketPath + "/peer"
 	peerRESTPath          = peerRESTPrefix + peerRESTVersionPrefix
@@ -64,6 +64,10 @@ const (
 	peerRESTMethodGetBandwidth                = "/bandwidth"
 	peerRESTMethodGetMetacacheListing         = "/getmetacache"
 	peerRESTMethodUpdateMetacacheListing      = "/updatemetacache"
+	peerRESTMethodFindMetacacheListing        = "/findmetacache"
+	peerRESTMethodInvalidateMetacache         = "/invalidatemetacache"
+	peerRESTMethodGetMetacacheInfo            = "/metacacheinfo"
+	peerRESTMethodPurgeMetacache              = "/purgemetacache"
 	peerRESTMethodGetPeerMetrics              = "/peermetrics"
 	peerRESTMethodLoadTransitionTierConfig    = "/loadtransitiontierconfig"
 	peerRESTMethodSpeedtest                   = "/speedtest"
@@ -73,6 +77,21 @@ const (
 	peerRESTMethodGetLastDayTierStats         = "/getlastdaytierstats"
 	peerRESTMethodDevNull                     = "/devnull"
 	peerRESTMethodNetperf                     = "/netperf"
+	peerRESTMethodGetAccessKeyStats           = "/accesskeystats"
+	peerRESTMethodGetTopClients               = "/topclients"
+	peerRESTMethodResetStats                  = "/resetstats"
+	peerRESTMethodGetRejectedRequests         = "/rejectedrequests"
+	peerRESTMethodGetLiveStats                = "/livestats"
+	peerRESTMethodGetSlowRequests             = "/slowrequests"
+	peerRESTMethodGetHotObjects               = "/hotobjects"
+	peerRESTMethodGetAnonymousRequests        = "/anonymousrequests"
+	peerRESTMethodLoadReplicationBandwidth    = "/loadreplicationbandwidth"
+	peerRESTMethodReloadReplicationResync     = "/reloadreplicationresync"
+	peerRESTMethodGetScannerStatus            = "/getscannerstatus"
+	peerRESTMethodGetHealProgress             = "/gethealprogress"
+	peerRESTMethodLoadRebalanceMeta           = "/loadrebalancemeta"
+	peerRESTMethodStopRebalance               = "/stoprebalance"
+	peerRESTMethodGetNotifyTargets            = "/notifytargets"
 )
 
 const (
@@ -93,10 +112,16 @@ const (
 	peerRESTTraceS3        = "s3"
 	peerRESTTraceOS        = "os"
 	peerRESTTraceThreshold = "threshold"
+	peerRESTTraceBucket    = "bucket"
+	peerRESTTracePrefix    = "prefix"
+	peerRESTTraceStatus    = "status"
 	peerRESTSize           = "size"
 	peerRESTConcurrent     = "concurrent"
 	peerRESTDuration       = "duration"
 	peerRESTStorageClass   = "storage-class"
+	peerRESTMetricsGroups  = "metrics-groups"
+	peerRESTStartRebalance = "start-rebalance"
+	peerRESTListPrefix     = "prefix"
 
 	peerRESTListenBucket = "bucket"
 	peerRESTListenPrefix = "prefix"
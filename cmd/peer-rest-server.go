@@ -700,6 +700,63 @@ func (s *peerRESTServer) UpdateMetacacheListingHandler(w http.ResponseWriter, r
 	logger.LogIf(ctx, msgp.Encode(w, &cache))
 }
 
+// FindMetacacheListingHandler - returns a finished metacache which can be reused for the listing.
+func (s *peerRESTServer) FindMetacacheListingHandler(w http.ResponseWriter, r *http.Request) {
+	if !s.IsValid(w, r) {
+		s.writeErrorResponse(w, errors.New("Invalid request"))
+		return
+	}
+	ctx := newContext(r, w, "FindMetacacheListing")
+
+	var opts listPathOptions
+	err := gob.NewDecoder(r.Body).Decode(&opts)
+	if err != nil && err != io.EOF {
+		s.writeErrorResponse(w, err)
+		return
+	}
+	resp, _ := localMetacacheMgr.getBucket(ctx, opts.Bucket).findReusableCache(opts)
+	logger.LogIf(ctx, msgp.Encode(w, &resp))
+}
+
+// InvalidateMetacacheHandler - prevents the reuse of the metacaches listing the updated objects.
+func (s *peerRESTServer) InvalidateMetacacheHandler(w http.ResponseWriter, r *http.Request) {
+	if !s.IsValid(w, r) {
+		s.writeErrorResponse(w, errors.New("Invalid request"))
+		return
+	}
+
+	var updates map[string][]string
+	if err := gob.NewDecoder(r.Body).Decode(&updates); err != nil {
+		s.writeErrorResponse(w, err)
+		return
+	}
+	localMetacacheMgr.invalidate(updates)
+}
+
+// GetMetacacheInfoHandler - describes the metacaches of this server listing the prefix of bucket.
+func (s *peerRESTServer) GetMetacacheInfoHandler(w http.ResponseWriter, r *http.Request) {
+	if !s.IsValid(w, r) {
+		s.writeErrorResponse(w, errors.New("Invalid request"))
+		return
+	}
+
+	ctx := newContext(r, w, "GetMetacacheInfo")
+	infos := localMetacacheMgr.info(r.Form.Get(peerRESTBucket), r.Form.Get(peerRESTListPrefix))
+	logger.LogIf(ctx, gob.NewEncoder(w).Encode(infos))
+}
+
+// PurgeMetacacheHandler - deletes the metacaches of this server listing the prefix of bucket.
+func (s *peerRESTServer) PurgeMetacacheHandler(w http.ResponseWriter, r *http.Request) {
+	if !s.IsValid(w, r) {
+		s.writeErrorResponse(w, errors.New("Invalid request"))
+		return
+	}
+
+	ctx := newContext(r, w, "PurgeMetacache")
+	n := localMetacacheMgr.purge(r.Form.Get(peerRESTBucket), r.Form.Get(peerRESTListPrefix))
+	logger.LogIf(ctx, gob.NewEncoder(w).Encode(n))
+}
+
 // PutBucketNotificationHandler - Set bucket policy.
 func (s *peerRESTServer) PutBucketNotificationHandler(w http.ResponseWriter, r *http.Request) {
 	if !s.IsValid(w, r) {
@@ -844,6 +901,10 @@ func (s *peerRESTServer) SignalServiceHandler(w http.ResponseWriter, r *http.Req
 		freezeServices()
 	case serviceUnFreeze:
 		unfreezeServices()
+	case serviceDrain:
+		drainServices()
+	case serviceUnDrain:
+		undrainServices()
 	case serviceReloadDynamic:
 		objAPI := newObjectLayerFn()
 		if objAPI == nil {
@@ -974,7 +1035,7 @@ func (s *peerRESTServer) ListenHandler(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
-func extractTraceOptsFromPeerRequest(r *http.Request) (opts madmin.ServiceTraceOpts, err error) {
+func extractTraceOptsFromPeerRequest(r *http.Request) (opts traceOptions, err error) {
 	opts.S3 = r.Form.Get(peerRESTTraceS3) == "true"
 	opts.OS = r.Form.Get(peerRESTTraceOS) == "true"
 	opts.Storage = r.Form.Get(peerRESTTraceStorage) == "true"
@@ -988,6 +1049,7 @@ func extractTraceOptsFromPeerRequest(r *http.Request) (opts madmin.ServiceTraceO
 		}
 		opts.Threshold = d
 	}
+	err = opts.setFilters(r.Form.Get(peerRESTTraceBucket), r.Form.Get(peerRESTTracePrefix), r.Form.Get(peerRESTTraceStatus))
 	return
 }
 
@@ -1093,6 +1155,137 @@ func (s *peerRESTServer) LoadTransitionTierConfigHandler(w http.ResponseWriter,
 	}()
 }
 
+// LoadReplicationBandwidthLimitsHandler - reloads the bandwidth limits of the remote target endpoints.
+func (s *peerRESTServer) LoadReplicationBandwidthLimitsHandler(w http.ResponseWriter, r *http.Request) {
+	if !s.IsValid(w, r) {
+		s.writeErrorResponse(w, errors.New("Invalid request"))
+		return
+	}
+
+	objAPI := newObjectLayerFn()
+	if objAPI == nil {
+		s.writeErrorResponse(w, errServerNotInitialized)
+		return
+	}
+
+	if err := loadReplicationBandwidthLimits(r.Context(), objAPI); err != nil {
+		s.writeErrorResponse(w, err)
+		return
+	}
+}
+
+// GetScannerStatusHandler - returns the data scanner status of this server.
+func (s *peerRESTServer) GetScannerStatusHandler(w http.ResponseWriter, r *http.Request) {
+	if !s.IsValid(w, r) {
+		s.writeErrorResponse(w, errors.New("Invalid request"))
+		return
+	}
+
+	ctx := newContext(r, w, "GetScannerStatus")
+	logger.LogIf(ctx, gob.NewEncoder(w).Encode(getLocalScannerStatus()))
+}
+
+// GetHealProgressHandler - returns the healing progress of the drives being healed on this server.
+func (s *peerRESTServer) GetHealProgressHandler(w http.ResponseWriter, r *http.Request) {
+	if !s.IsValid(w, r) {
+		s.writeErrorResponse(w, errors.New("Invalid request"))
+		return
+	}
+
+	ctx := newContext(r, w, "GetHealProgress")
+	logger.LogIf(ctx, gob.NewEncoder(w).Encode(getLocalHealProgress()))
+}
+
+// LoadRebalanceMetaHandler - loads the rebalance saved on disk and
+// starts rebalancing the local pools if asked to.
+func (s *peerRESTServer) LoadRebalanceMetaHandler(w http.ResponseWriter, r *http.Request) {
+	if !s.IsValid(w, r) {
+		s.writeErrorResponse(w, errors.New("Invalid request"))
+		return
+	}
+
+	objAPI := newObjectLayerFn()
+	if objAPI == nil {
+		s.writeErrorResponse(w, errServerNotInitialized)
+		return
+	}
+
+	pools, ok := objAPI.(*erasureServerPools)
+	if !ok {
+		s.writeErrorResponse(w, errors.New("not a multiple pools setup"))
+		return
+	}
+
+	startRebalance, err := strconv.ParseBool(mux.Vars(r)[peerRESTStartRebalance])
+	if err != nil {
+		s.writeErrorResponse(w, err)
+		return
+	}
+
+	if err = pools.loadRebalanceMeta(r.Context()); err != nil {
+		s.writeErrorResponse(w, err)
+		return
+	}
+	if startRebalance {
+		go pools.StartRebalance()
+	}
+}
+
+// StopRebalanceHandler - stops the rebalance running on this server.
+func (s *peerRESTServer) StopRebalanceHandler(w http.ResponseWriter, r *http.Request) {
+	if !s.IsValid(w, r) {
+		s.writeErrorResponse(w, errors.New("Invalid request"))
+		return
+	}
+
+	objAPI := newObjectLayerFn()
+	if objAPI == nil {
+		s.writeErrorResponse(w, errServerNotInitialized)
+		return
+	}
+
+	pools, ok := objAPI.(*erasureServerPools)
+	if !ok {
+		s.writeErrorResponse(w, errors.New("not a multiple pools setup"))
+		return
+	}
+
+	if err := pools.StopRebalance(); err != nil && !errors.Is(err, errRebalanceNotStarted) {
+		s.writeErrorResponse(w, err)
+		return
+	}
+	if err := pools.loadRebalanceMeta(r.Context()); err != nil {
+		s.writeErrorResponse(w, err)
+		return
+	}
+}
+
+// ReloadReplicationResyncHandler - reloads the replication resync status of a bucket.
+func (s *peerRESTServer) ReloadReplicationResyncHandler(w http.ResponseWriter, r *http.Request) {
+	if !s.IsValid(w, r) {
+		s.writeErrorResponse(w, errors.New("Invalid request"))
+		return
+	}
+
+	vars := mux.Vars(r)
+	bucketName := vars[peerRESTBucket]
+	if bucketName == "" {
+		s.writeErrorResponse(w, errors.New("Bucket name is missing"))
+		return
+	}
+
+	objAPI := newObjectLayerFn()
+	if objAPI == nil {
+		s.writeErrorResponse(w, errServerNotInitialized)
+		return
+	}
+
+	if err := globalReplicationPool.reloadResync(r.Context(), bucketName, objAPI); err != nil {
+		s.writeErrorResponse(w, err)
+		return
+	}
+}
+
 // ConsoleLogHandler sends console logs of this node back to peer rest client
 func (s *peerRESTServer) ConsoleLogHandler(w http.ResponseWriter, r *http.Request) {
 	if !s.IsValid(w, r) {
@@ -1146,6 +1339,128 @@ func (s *peerRESTServer) IsValid(w http.ResponseWriter, r *http.Request) bool {
 	return true
 }
 
+// GetAccessKeyStats gets the S3 requests per access key of this node.
+func (s *peerRESTServer) GetAccessKeyStats(w http.ResponseWriter, r *http.Request) {
+	if !s.IsValid(w, r) {
+		s.writeErrorResponse(w, errors.New("invalid request"))
+		return
+	}
+
+	enc := gob.NewEncoder(w)
+	if err := enc.Encode(globalHTTPStats.toServerAccessKeyStats()); err != nil {
+		s.writeErrorResponse(w, errors.New("Encoding stats failed: "+err.Error()))
+		return
+	}
+}
+
+// GetTopClients gets the clients with the most S3 requests of this node.
+func (s *peerRESTServer) GetTopClients(w http.ResponseWriter, r *http.Request) {
+	if !s.IsValid(w, r) {
+		s.writeErrorResponse(w, errors.New("invalid request"))
+		return
+	}
+
+	enc := gob.NewEncoder(w)
+	if err := enc.Encode(globalHTTPStats.clients.report()); err != nil {
+		s.writeErrorResponse(w, errors.New("Encoding clients failed: "+err.Error()))
+		return
+	}
+}
+
+// GetHotObjects gets the objects and prefixes read the most on this node.
+func (s *peerRESTServer) GetHotObjects(w http.ResponseWriter, r *http.Request) {
+	if !s.IsValid(w, r) {
+		s.writeErrorResponse(w, errors.New("invalid request"))
+		return
+	}
+
+	enc := gob.NewEncoder(w)
+	if err := enc.Encode(globalHTTPStats.hot.report()); err != nil {
+		s.writeErrorResponse(w, errors.New("Encoding hot objects failed: "+err.Error()))
+		return
+	}
+}
+
+// GetAnonymousRequests gets the anonymous requests per bucket of this node.
+func (s *peerRESTServer) GetAnonymousRequests(w http.ResponseWriter, r *http.Request) {
+	if !s.IsValid(w, r) {
+		s.writeErrorResponse(w, errors.New("invalid request"))
+		return
+	}
+
+	enc := gob.NewEncoder(w)
+	if err := enc.Encode(globalHTTPStats.anonymous.report()); err != nil {
+		s.writeErrorResponse(w, errors.New("Encoding anonymous requests failed: "+err.Error()))
+		return
+	}
+}
+
+// GetRejectedRequests gets the most recent rejected requests of this node.
+func (s *peerRESTServer) GetRejectedRequests(w http.ResponseWriter, r *http.Request) {
+	if !s.IsValid(w, r) {
+		s.writeErrorResponse(w, errors.New("invalid request"))
+		return
+	}
+
+	enc := gob.NewEncoder(w)
+	if err := enc.Encode(globalHTTPStats.rejections.load()); err != nil {
+		s.writeErrorResponse(w, errors.New("Encoding rejected requests failed: "+err.Error()))
+		return
+	}
+}
+
+// GetNotifyTargets gets the delivery statistics of the notification targets of this node.
+func (s *peerRESTServer) GetNotifyTargets(w http.ResponseWriter, r *http.Request) {
+	if !s.IsValid(w, r) {
+		s.writeErrorResponse(w, errors.New("invalid request"))
+		return
+	}
+
+	enc := gob.NewEncoder(w)
+	if err := enc.Encode(getLocalNotifyTargets()); err != nil {
+		s.writeErrorResponse(w, errors.New("Encoding notification targets failed: "+err.Error()))
+		return
+	}
+}
+
+// GetSlowRequests gets the most recent slow requests of this node.
+func (s *peerRESTServer) GetSlowRequests(w http.ResponseWriter, r *http.Request) {
+	if !s.IsValid(w, r) {
+		s.writeErrorResponse(w, errors.New("invalid request"))
+		return
+	}
+
+	enc := gob.NewEncoder(w)
+	if err := enc.Encode(globalHTTPStats.slowRequests.load()); err != nil {
+		s.writeErrorResponse(w, errors.New("Encoding slow requests failed: "+err.Error()))
+		return
+	}
+}
+
+// GetLiveStats gets a snapshot of the stats of this node.
+func (s *peerRESTServer) GetLiveStats(w http.ResponseWriter, r *http.Request) {
+	if !s.IsValid(w, r) {
+		s.writeErrorResponse(w, errors.New("invalid request"))
+		return
+	}
+
+	enc := gob.NewEncoder(w)
+	if err := enc.Encode(getLocalLiveStats()); err != nil {
+		s.writeErrorResponse(w, errors.New("Encoding live stats failed: "+err.Error()))
+		return
+	}
+}
+
+// ResetStatsHandler zeroes the HTTP and traffic stats of this node.
+func (s *peerRESTServer) ResetStatsHandler(w http.ResponseWriter, r *http.Request) {
+	if !s.IsValid(w, r) {
+		s.writeErrorResponse(w, errors.New("invalid request"))
+		return
+	}
+
+	resetRuntimeStats()
+}
+
 // GetBandwidth gets the bandwidth for the buckets requested.
 func (s *peerRESTServer) GetBandwidth(w http.ResponseWriter, r *http.Request) {
 	if !s.IsValid(w, r) {
@@ -1174,9 +1489,15 @@ func (s *peerRESTServer) GetPeerMetrics(w http.ResponseWriter, r *http.Request)
 		s.writeErrorResponse(w, errors.New("invalid request"))
 	}
 
+	names, err := parseMetricsGroups(r.Form.Get(peerRESTMetricsGroups))
+	if err != nil {
+		s.writeErrorResponse(w, err)
+		return
+	}
+
 	enc := gob.NewEncoder(w)
 
-	for m := range ReportMetrics(r.Context(), peerMetricsGroups) {
+	for m := range ReportMetrics(r.Context(), selectMetricsGroups(peerMetricsGroups, names)) {
 		if err := enc.Encode(m); err != nil {
 			s.writeErrorResponse(w, errors.New("Encoding metric failed: "+err.Error()))
 			return
@@ -1369,10 +1690,29 @@ func registerPeerRESTHandlers(router *mux.Router) {
 	subrouter.Methods(http.MethodPost).Path(peerRESTVersionPrefix + peerRESTMethodLog).HandlerFunc(server.ConsoleLogHandler)
 	subrouter.Methods(http.MethodPost).Path(peerRESTVersionPrefix + peerRESTMethodGetLocalDiskIDs).HandlerFunc(httpTraceHdrs(server.GetLocalDiskIDs))
 	subrouter.Methods(http.MethodPost).Path(peerRESTVersionPrefix + peerRESTMethodGetBandwidth).HandlerFunc(httpTraceHdrs(server.GetBandwidth))
+	subrouter.Methods(http.MethodPost).Path(peerRESTVersionPrefix + peerRESTMethodGetAccessKeyStats).HandlerFunc(httpTraceHdrs(server.GetAccessKeyStats))
+	subrouter.Methods(http.MethodPost).Path(peerRESTVersionPrefix + peerRESTMethodGetTopClients).HandlerFunc(httpTraceHdrs(server.GetTopClients))
+	subrouter.Methods(http.MethodPost).Path(peerRESTVersionPrefix + peerRESTMethodGetHotObjects).HandlerFunc(httpTraceHdrs(server.GetHotObjects))
+	subrouter.Methods(http.MethodPost).Path(peerRESTVersionPrefix + peerRESTMethodGetAnonymousRequests).HandlerFunc(httpTraceHdrs(server.GetAnonymousRequests))
+	subrouter.Methods(http.MethodPost).Path(peerRESTVersionPrefix + peerRESTMethodResetStats).HandlerFunc(httpTraceHdrs(server.ResetStatsHandler))
+	subrouter.Methods(http.MethodPost).Path(peerRESTVersionPrefix + peerRESTMethodGetRejectedRequests).HandlerFunc(httpTraceHdrs(server.GetRejectedRequests))
+	subrouter.Methods(http.MethodPost).Path(peerRESTVersionPrefix + peerRESTMethodGetNotifyTargets).HandlerFunc(httpTraceHdrs(server.GetNotifyTargets))
+	subrouter.Methods(http.MethodPost).Path(peerRESTVersionPrefix + peerRESTMethodGetSlowRequests).HandlerFunc(httpTraceHdrs(server.GetSlowRequests))
+	subrouter.Methods(http.MethodPost).Path(peerRESTVersionPrefix + peerRESTMethodGetLiveStats).HandlerFunc(httpTraceHdrs(server.GetLiveStats))
 	subrouter.Methods(http.MethodPost).Path(peerRESTVersionPrefix + peerRESTMethodGetMetacacheListing).HandlerFunc(httpTraceHdrs(server.GetMetacacheListingHandler))
 	subrouter.Methods(http.MethodPost).Path(peerRESTVersionPrefix + peerRESTMethodUpdateMetacacheListing).HandlerFunc(httpTraceHdrs(server.UpdateMetacacheListingHandler))
+	subrouter.Methods(http.MethodPost).Path(peerRESTVersionPrefix + peerRESTMethodFindMetacacheListing).HandlerFunc(httpTraceHdrs(server.FindMetacacheListingHandler))
+	subrouter.Methods(http.MethodPost).Path(peerRESTVersionPrefix + peerRESTMethodInvalidateMetacache).HandlerFunc(httpTraceHdrs(server.InvalidateMetacacheHandler))
+	subrouter.Methods(http.MethodPost).Path(peerRESTVersionPrefix + peerRESTMethodGetMetacacheInfo).HandlerFunc(httpTraceHdrs(server.GetMetacacheInfoHandler))
+	subrouter.Methods(http.MethodPost).Path(peerRESTVersionPrefix + peerRESTMethodPurgeMetacache).HandlerFunc(httpTraceHdrs(server.PurgeMetacacheHandler))
 	subrouter.Methods(http.MethodPost).Path(peerRESTVersionPrefix + peerRESTMethodGetPeerMetrics).HandlerFunc(httpTraceHdrs(server.GetPeerMetrics))
 	subrouter.Methods(http.MethodPost).Path(peerRESTVersionPrefix + peerRESTMethodLoadTransitionTierConfig).HandlerFunc(httpTraceHdrs(server.LoadTransitionTierConfigHandler))
+	subrouter.Methods(http.MethodPost).Path(peerRESTVersionPrefix + peerRESTMethodLoadReplicationBandwidth).HandlerFunc(httpTraceHdrs(server.LoadReplicationBandwidthLimitsHandler))
+	subrouter.Methods(http.MethodPost).Path(peerRESTVersionPrefix + peerRESTMethodReloadReplicationResync).HandlerFunc(httpTraceHdrs(server.ReloadReplicationResyncHandler)).Queries(restQueries(peerRESTBucket)...)
+	subrouter.Methods(http.MethodPost).Path(peerRESTVersionPrefix + peerRESTMethodGetScannerStatus).HandlerFunc(httpTraceHdrs(server.GetScannerStatusHandler))
+	subrouter.Methods(http.MethodPost).Path(peerRESTVersionPrefix + peerRESTMethodGetHealProgress).HandlerFunc(httpTraceHdrs(server.GetHealProgressHandler))
+	subrouter.Methods(http.MethodPost).Path(peerRESTVersionPrefix + peerRESTMethodLoadRebalanceMeta).HandlerFunc(httpTraceHdrs(server.LoadRebalanceMetaHandler)).Queries(restQueries(peerRESTStartRebalance)...)
+	subrouter.Methods(http.MethodPost).Path(peerRESTVersionPrefix + peerRESTMethodStopRebalance).HandlerFunc(httpTraceHdrs(server.StopRebalanceHandler))
 	subrouter.Methods(http.MethodPost).Path(peerRESTVersionPrefix + peerRESTMethodSpeedtest).HandlerFunc(httpTraceHdrs(server.SpeedtestHandler))
 	subrouter.Methods(http.MethodPost).Path(peerRESTVersionPrefix + peerRESTMethodDriveSpeedTest).HandlerFunc(httpTraceHdrs(server.DriveSpeedTestHandler))
 	subrouter.Methods(http.MethodPost).Path(peerRESTVersionPrefix + peerRESTMethodNetperf).HandlerFunc(httpTraceHdrs(server.Netperf))
@@ -18,13 +18,18 @@
 package cmd
 
 import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
 	"reflect"
+	"strings"
 	"testing"
 
 	miniogopolicy "github.com/minio/minio-go/v7/pkg/policy"
 	"github.com/minio/minio-go/v7/pkg/set"
 	"github.com/minio/pkg/bucket/policy"
 	"github.com/minio/pkg/bucket/policy/condition"
+	iampolicy "github.com/minio/pkg/iam/policy"
 )
 
 func TestPolicySysIsAllowed(t *testing.T) {
@@ -301,3 +306,146 @@ func TestBucketAccessPolicyToPolicy(t *testing.T) {
 		}
 	}
 }
+
+func TestConditionSourceIP(t *testing.T) {
+	testCases := []struct {
+		remoteAddr string
+		header     http.Header
+		expected   string
+		ok         bool
+	}{
+		{remoteAddr: "192.168.1.10:9000", expected: "192.168.1.10", ok: true},
+		{remoteAddr: "[2001:db8::1]:9000", expected: "2001:db8::1", ok: true},
+		{remoteAddr: "[fe80::1%eth0]:9000", expected: "fe80::1", ok: true},
+		{header: http.Header{"X-Forwarded-For": {"10.1.2.3,172.16.0.1"}}, expected: "10.1.2.3", ok: true},
+		{header: http.Header{"X-Real-Ip": {"10.1.2.3:4567"}}, expected: "10.1.2.3", ok: true},
+		{header: http.Header{"Forwarded": {`for="[2001:db8::2]:4711"`}}, expected: "2001:db8::2", ok: true},
+		{header: http.Header{"X-Real-Ip": {"unknown"}}, ok: false},
+	}
+	for i, testCase := range testCases {
+		r := httptest.NewRequest(http.MethodGet, "/mybucket/myobject", nil)
+		if testCase.remoteAddr != "" {
+			r.RemoteAddr = testCase.remoteAddr
+		}
+		for k, v := range testCase.header {
+			r.Header[k] = v
+		}
+		ip, ok := conditionSourceIP(r)
+		if ok != testCase.ok || ip != testCase.expected {
+			t.Errorf("Test %d: expected %q (%t), got %q (%t)", i+1, testCase.expected, testCase.ok, ip, ok)
+		}
+	}
+}
+
+func TestPolicyRequestConditions(t *testing.T) {
+	p, err := policy.ParseConfig(strings.NewReader(`{
+  "Version": "2012-10-17",
+  "Statement": [
+    {
+      "Effect": "Allow",
+      "Principal": {"AWS": ["*"]},
+      "Action": ["s3:GetObject"],
+      "Resource": ["arn:aws:s3:::mybucket/*"],
+      "Condition": {
+        "IpAddress": {"aws:SourceIp": ["10.0.0.0/8", "192.168.1.0/24", "2001:db8::/32"]},
+        "StringLike": {"aws:UserAgent": "backup-app/*", "aws:Referer": "https://intranet.example.com/*"}
+      }
+    }
+  ]
+}`), "mybucket")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	newRequest := func(remoteAddr, userAgent, referer string) *http.Request {
+		r := httptest.NewRequest(http.MethodGet, "/mybucket/myobject", nil)
+		r.RemoteAddr = remoteAddr
+		r.Header.Set("User-Agent", userAgent)
+		r.Header.Set("Referer", referer)
+		return r
+	}
+
+	testCases := []struct {
+		r        *http.Request
+		expected bool
+	}{
+		{newRequest("10.1.2.3:1234", "backup-app/1.0", "https://intranet.example.com/files"), true},
+		{newRequest("192.168.1.20:1234", "backup-app/2.1", "https://intranet.example.com/"), true},
+		{newRequest("[2001:db8:1::5]:1234", "backup-app/1.0", "https://intranet.example.com/files"), true},
+		{newRequest("172.16.0.1:1234", "backup-app/1.0", "https://intranet.example.com/files"), false},
+		{newRequest("[2001:db9::5]:1234", "backup-app/1.0", "https://intranet.example.com/files"), false},
+		{newRequest("10.1.2.3:1234", "curl/7.79.1", "https://intranet.example.com/files"), false},
+		{newRequest("10.1.2.3:1234", "backup-app/1.0", "https://evil.example.com/"), false},
+		{newRequest("invalid", "backup-app/1.0", "https://intranet.example.com/files"), false},
+	}
+	for i, testCase := range testCases {
+		allowed := p.IsAllowed(policy.Args{
+			Action:          policy.GetObjectAction,
+			BucketName:      "mybucket",
+			ObjectName:      "myobject",
+			ConditionValues: getConditionValues(testCase.r, "", "", nil),
+		})
+		if allowed != testCase.expected {
+			t.Errorf("Test %d: expected %t, got %t", i+1, testCase.expected, allowed)
+		}
+	}
+}
+
+func TestSessionTagsPolicyVariables(t *testing.T) {
+	p, err := iampolicy.ParseConfig(strings.NewReader(`{
+  "Version": "2012-10-17",
+  "Statement": [
+    {
+      "Effect": "Allow",
+      "Action": ["s3:GetObject"],
+      "Resource": ["arn:aws:s3:::home/${jwt:preferred_username}/*"],
+      "Condition": {"ForAnyValue:StringEquals": {"jwt:groups": ["staff"]}}
+    }
+  ]
+}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The claims of STS credentials are decoded from JSON.
+	newClaims := func(tags map[string][]string) map[string]interface{} {
+		data, err := json.Marshal(map[string]interface{}{
+			"preferred_username": "ignored",
+			sessionTagsClaim:     tags,
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		var claims map[string]interface{}
+		if err = json.Unmarshal(data, &claims); err != nil {
+			t.Fatal(err)
+		}
+		return claims
+	}
+
+	testCases := []struct {
+		tags     map[string][]string
+		object   string
+		expected bool
+	}{
+		{map[string][]string{"preferred_username": {"alice"}, "groups": {"dev", "staff"}}, "alice/file", true},
+		{map[string][]string{"preferred_username": {"alice"}, "groups": {"dev", "staff"}}, "bob/file", false},
+		{map[string][]string{"preferred_username": {"alice"}, "groups": {"dev"}}, "alice/file", false},
+		{map[string][]string{"groups": {"staff"}}, "ignored/file", true},
+	}
+	for i, testCase := range testCases {
+		claims := newClaims(testCase.tags)
+		r := httptest.NewRequest(http.MethodGet, "/home/"+testCase.object, nil)
+		allowed := p.IsAllowed(iampolicy.Args{
+			AccountName:     "sts-user",
+			Action:          iampolicy.GetObjectAction,
+			BucketName:      "home",
+			ObjectName:      testCase.object,
+			ConditionValues: getConditionValues(r, "", "sts-user", claims),
+			Claims:          claims,
+		})
+		if allowed != testCase.expected {
+			t.Errorf("Test %d: expected %t, got %t", i+1, testCase.expected, allowed)
+		}
+	}
+}
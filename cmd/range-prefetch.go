@@ -0,0 +1,243 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/dustin/go-humanize"
+)
+
+// EnvRangePrefetch turns off prefetching for sequential range reads.
+const EnvRangePrefetch = "MINIO_RANGE_PREFETCH"
+
+const (
+	// Maximum memory held by prefetched ranges on this node.
+	rangePrefetchMaxBytes = 64 * humanize.MiByte
+
+	// Ranges larger than this are never prefetched.
+	rangePrefetchMaxLength = 8 * humanize.MiByte
+
+	// Maximum number of sequential readers tracked.
+	rangePrefetchMaxReaders = 1024
+
+	// Readers not seen for this long are forgotten.
+	rangePrefetchExpiry = time.Minute
+)
+
+var (
+	// If sequential range reads are prefetched, set by EnvRangePrefetch.
+	globalRangePrefetch = true
+
+	globalRangePrefetcher = newRangePrefetcher(rangePrefetchMaxBytes)
+)
+
+// rangeReadFn reads length bytes of an object starting at offset.
+type rangeReadFn func(ctx context.Context, offset, length int64, w io.Writer) error
+
+// rangePrefetch is the next range of a sequential reader,
+// read in the background.
+type rangePrefetch struct {
+	done chan struct{}
+	data []byte
+	err  error
+}
+
+// rangeReader is a reader of an object version expected to ask next
+// for the range starting at the offset it is tracked at.
+type rangeReader struct {
+	lastSeen   time.Time
+	sequential bool           // the reader already continued a range
+	prefetch   *rangePrefetch // nil when nothing was prefetched
+	size       int64          // bytes reserved by prefetch
+}
+
+// rangePrefetcher detects ascending consecutive range reads of the
+// same object version and reads the following range ahead of time,
+// into a small bounded amount of memory.
+type rangePrefetcher struct {
+	hits           uint64 // Must be accessed atomically
+	misses         uint64 // Must be accessed atomically
+	bytes          uint64 // Must be accessed atomically
+	discardedBytes uint64 // Must be accessed atomically
+
+	mu      sync.Mutex
+	readers map[string]*rangeReader
+	size    int64
+	maxSize int64
+}
+
+func newRangePrefetcher(maxSize int64) *rangePrefetcher {
+	return &rangePrefetcher{
+		readers: make(map[string]*rangeReader),
+		maxSize: maxSize,
+	}
+}
+
+// rangeReaderKey identifies a reader of one object version waiting
+// for the range starting at offset. The data directory and modtime
+// make sure that prefetched data is never served for an overwritten
+// object.
+func rangeReaderKey(bucket, object string, fi FileInfo, offset int64) string {
+	return fmt.Sprintf("%s/%s/%s/%s/%d/%d", bucket, object, fi.VersionID, fi.DataDir, fi.ModTime.UnixNano(), offset)
+}
+
+// readRange writes length bytes of the object starting at offset to w,
+// from a prefetched range when available. A read continuing the previous
+// range read of the object starts prefetching the range following it.
+func (p *rangePrefetcher) readRange(ctx context.Context, bucket, object string, fi FileInfo, offset, length int64, w io.Writer, read rangeReadFn) error {
+	if length <= 0 {
+		return read(ctx, offset, length, w)
+	}
+
+	p.mu.Lock()
+	r, sequential := p.readers[rangeReaderKey(bucket, object, fi, offset)]
+	if sequential {
+		p.removeLocked(rangeReaderKey(bucket, object, fi, offset), r)
+	}
+	p.mu.Unlock()
+
+	served := false
+	if sequential && r.prefetch != nil {
+		select {
+		case <-r.prefetch.done:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+		if r.prefetch.err == nil && int64(len(r.prefetch.data)) >= length {
+			if _, err := w.Write(r.prefetch.data[:length]); err != nil {
+				return err
+			}
+			atomic.AddUint64(&p.hits, 1)
+			served = true
+		}
+	}
+	if sequential && r.sequential && !served {
+		atomic.AddUint64(&p.misses, 1)
+	}
+
+	next := offset + length
+	p.track(bucket, object, fi, next, sequential, length, read)
+
+	if served {
+		return nil
+	}
+	return read(ctx, offset, length, w)
+}
+
+// track remembers a reader waiting for the range starting at next,
+// prefetching it when the reader is known to read sequentially.
+func (p *rangePrefetcher) track(bucket, object string, fi FileInfo, next int64, sequential bool, length int64, read rangeReadFn) {
+	if next >= fi.Size {
+		return
+	}
+
+	key := rangeReaderKey(bucket, object, fi, next)
+	r := &rangeReader{lastSeen: UTCNow(), sequential: sequential}
+
+	if length > fi.Size-next {
+		length = fi.Size - next
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.expireLocked(r.lastSeen)
+	if old, ok := p.readers[key]; ok {
+		p.removeLocked(key, old)
+	}
+
+	if sequential && length <= rangePrefetchMaxLength && p.reserveLocked(length) {
+		r.size = length
+		r.prefetch = &rangePrefetch{done: make(chan struct{})}
+		go func(pf *rangePrefetch) {
+			defer close(pf.done)
+			var buf bytes.Buffer
+			buf.Grow(int(length))
+			// The request context ends with the request,
+			// the prefetch has to outlive it.
+			pf.err = read(context.Background(), next, length, &buf)
+			pf.data = buf.Bytes()
+			if pf.err == nil {
+				atomic.AddUint64(&p.bytes, uint64(length))
+			}
+		}(r.prefetch)
+	}
+	p.readers[key] = r
+}
+
+// reserveLocked reserves size bytes for a prefetch, forgetting the
+// least recently seen readers holding prefetched data if needed.
+func (p *rangePrefetcher) reserveLocked(size int64) bool {
+	for p.size+size > p.maxSize {
+		var (
+			oldestKey string
+			oldest    *rangeReader
+		)
+		for key, r := range p.readers {
+			if r.size > 0 && (oldest == nil || r.lastSeen.Before(oldest.lastSeen)) {
+				oldestKey, oldest = key, r
+			}
+		}
+		if oldest == nil {
+			return false
+		}
+		p.discardLocked(oldestKey, oldest)
+	}
+	p.size += size
+	return true
+}
+
+// expireLocked forgets readers not seen recently and the least
+// recently seen readers above the maximum number of readers.
+func (p *rangePrefetcher) expireLocked(now time.Time) {
+	for key, r := range p.readers {
+		if now.Sub(r.lastSeen) > rangePrefetchExpiry {
+			p.discardLocked(key, r)
+		}
+	}
+	for len(p.readers) >= rangePrefetchMaxReaders {
+		var (
+			oldestKey string
+			oldest    *rangeReader
+		)
+		for key, r := range p.readers {
+			if oldest == nil || r.lastSeen.Before(oldest.lastSeen) {
+				oldestKey, oldest = key, r
+			}
+		}
+		p.discardLocked(oldestKey, oldest)
+	}
+}
+
+// discardLocked forgets a reader whose prefetched data was not used.
+func (p *rangePrefetcher) discardLocked(key string, r *rangeReader) {
+	atomic.AddUint64(&p.discardedBytes, uint64(r.size))
+	p.removeLocked(key, r)
+}
+
+func (p *rangePrefetcher) removeLocked(key string, r *rangeReader) {
+	delete(p.readers, key)
+	p.size -= r.size
+}
@@ -0,0 +1,110 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestRangePrefetcher(t *testing.T) {
+	data := make([]byte, 100)
+	for i := range data {
+		data[i] = byte(i)
+	}
+	fi := FileInfo{
+		VersionID: "v1",
+		DataDir:   "d1",
+		ModTime:   time.Unix(1, 0),
+		Size:      int64(len(data)),
+	}
+
+	var reads int64
+	read := func(ctx context.Context, offset, length int64, w io.Writer) error {
+		atomic.AddInt64(&reads, 1)
+		_, err := w.Write(data[offset : offset+length])
+		return err
+	}
+
+	p := newRangePrefetcher(25)
+	readRange := func(fi FileInfo, offset, length int64) {
+		t.Helper()
+		var buf bytes.Buffer
+		if err := p.readRange(context.Background(), "bucket", "object", fi, offset, length, &buf, read); err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(buf.Bytes(), data[offset:offset+length]) {
+			t.Fatalf("Unexpected data for range %d-%d", offset, offset+length-1)
+		}
+	}
+	waitPrefetch := func() {
+		t.Helper()
+		p.mu.Lock()
+		defer p.mu.Unlock()
+		for _, r := range p.readers {
+			if r.prefetch != nil {
+				<-r.prefetch.done
+			}
+		}
+	}
+
+	// The first read only tracks the reader, the second
+	// consecutive one prefetches the third range.
+	readRange(fi, 0, 10)
+	readRange(fi, 10, 10)
+	waitPrefetch()
+	if n := atomic.LoadInt64(&reads); n != 3 {
+		t.Fatalf("Expected 3 reads, got %d", n)
+	}
+
+	readRange(fi, 20, 10)
+	waitPrefetch()
+	if n := atomic.LoadUint64(&p.hits); n != 1 {
+		t.Fatalf("Expected 1 hit, got %d", n)
+	}
+	if n := atomic.LoadInt64(&reads); n != 4 {
+		t.Fatalf("Expected the hit to only prefetch the next range, got %d reads", n)
+	}
+
+	// A new version of the object never gets the prefetched data.
+	newFI := fi
+	newFI.DataDir = "d2"
+	readRange(newFI, 30, 10)
+	if n := atomic.LoadUint64(&p.hits); n != 1 {
+		t.Fatalf("Expected prefetched data of another version to be ignored, got %d hits", n)
+	}
+
+	// A longer range than prefetched is read from the drives,
+	// ranges above the memory limit are not prefetched.
+	readRange(fi, 30, 30)
+	readRange(fi, 60, 30)
+	waitPrefetch()
+	if n := atomic.LoadUint64(&p.misses); n != 2 {
+		t.Fatalf("Expected 2 misses, got %d", n)
+	}
+	p.mu.Lock()
+	size := p.size
+	p.mu.Unlock()
+	if size > 25 {
+		t.Fatalf("Expected at most 25 bytes prefetched, got %d", size)
+	}
+}
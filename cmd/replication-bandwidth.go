@@ -0,0 +1,126 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync"
+)
+
+const replicationBandwidthConfigVersion = 1
+
+// replicationBandwidthConfigFile holds the bandwidth limits of
+// the remote target endpoints of bucket replication.
+var replicationBandwidthConfigFile = minioConfigPrefix + "/replication-bandwidth.json"
+
+// ReplicationBandwidthLimit is the bandwidth limit in bytes per second of
+// the replication of all the buckets to a remote target endpoint, shared
+// by all the nodes of the cluster. Zero removes the limit.
+type ReplicationBandwidthLimit struct {
+	Limit int64 `json:"limit"`
+}
+
+// replicationBandwidthConfig is the persisted form of the
+// bandwidth limits of the remote target endpoints.
+type replicationBandwidthConfig struct {
+	Version int              `json:"version"`
+	Targets map[string]int64 `json:"targets"`
+}
+
+// replicationBandwidthMu serializes the updates of the limits on this node.
+var replicationBandwidthMu sync.Mutex
+
+// parseReplicationBandwidthLimit parses the bandwidth limit of endpoint.
+func parseReplicationBandwidthLimit(endpoint string, data []byte) (ReplicationBandwidthLimit, error) {
+	var limit ReplicationBandwidthLimit
+	if endpoint == "" || strings.ContainsAny(endpoint, "/?#") {
+		return limit, fmt.Errorf("invalid remote target endpoint '%s': expected host:port", endpoint)
+	}
+	if err := json.Unmarshal(data, &limit); err != nil {
+		return limit, err
+	}
+	if limit.Limit < 0 {
+		return limit, fmt.Errorf("invalid bandwidth limit for %s: limit cannot be negative", endpoint)
+	}
+	return limit, nil
+}
+
+// readReplicationBandwidthConfig returns the bandwidth
+// limits of the remote target endpoints on storage.
+func readReplicationBandwidthConfig(ctx context.Context, objAPI ObjectLayer) (replicationBandwidthConfig, error) {
+	cfg := replicationBandwidthConfig{
+		Version: replicationBandwidthConfigVersion,
+		Targets: make(map[string]int64),
+	}
+	data, err := readConfig(ctx, objAPI, replicationBandwidthConfigFile)
+	if err != nil {
+		if err == errConfigNotFound {
+			return cfg, nil
+		}
+		return cfg, err
+	}
+	if err = json.Unmarshal(data, &cfg); err != nil {
+		return cfg, err
+	}
+	if cfg.Targets == nil {
+		cfg.Targets = make(map[string]int64)
+	}
+	return cfg, nil
+}
+
+// loadReplicationBandwidthLimits applies the bandwidth limits of
+// the remote target endpoints on storage to this node.
+func loadReplicationBandwidthLimits(ctx context.Context, objAPI ObjectLayer) error {
+	cfg, err := readReplicationBandwidthConfig(ctx, objAPI)
+	if err != nil {
+		return err
+	}
+	globalBucketMonitor.SetTargetBandwidthLimits(cfg.Targets)
+	return nil
+}
+
+// setReplicationBandwidthLimit saves the bandwidth limit of a remote target
+// endpoint and applies it to all the nodes, a zero limit removes it.
+func setReplicationBandwidthLimit(ctx context.Context, objAPI ObjectLayer, endpoint string, limit int64) error {
+	replicationBandwidthMu.Lock()
+	defer replicationBandwidthMu.Unlock()
+
+	cfg, err := readReplicationBandwidthConfig(ctx, objAPI)
+	if err != nil {
+		return err
+	}
+	if limit > 0 {
+		cfg.Targets[endpoint] = limit
+	} else {
+		delete(cfg.Targets, endpoint)
+	}
+	data, err := json.Marshal(cfg)
+	if err != nil {
+		return err
+	}
+	if err = saveConfig(ctx, objAPI, replicationBandwidthConfigFile, data); err != nil {
+		return err
+	}
+
+	globalBucketMonitor.SetTargetBandwidthLimits(cfg.Targets)
+	globalNotificationSys.LoadReplicationBandwidthLimits(ctx)
+	return nil
+}
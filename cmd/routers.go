@@ -58,6 +58,8 @@ var globalHandlers = []mux.MiddlewareFunc{
 	setRequestValidityHandler,
 	// set x-amz-request-id header.
 	addCustomHeaders,
+	// Continue traces of requests with a traceparent header.
+	setTracingHandler,
 	// Add bucket forwarding handler
 	setBucketForwardingHandler,
 	// Add new handlers here.
@@ -86,6 +88,11 @@ func configureServerHandler(endpointServerPools EndpointServerPools) (http.Handl
 	// Add STS router always.
 	registerSTSRouter(router)
 
+	// Add WebDAV router if enabled, ahead of the API router.
+	if globalWebDAVConfig != nil {
+		registerWebDAVRouter(router, *globalWebDAVConfig)
+	}
+
 	// Add API router
 	registerAPIRouter(router)
 
@@ -66,6 +66,18 @@ var ServerFlags = []cli.Flag{
 		Usage:  "bind to a specific ADDRESS:PORT for embedded Console UI, ADDRESS can be an IP or hostname",
 		EnvVar: "MINIO_CONSOLE_ADDRESS",
 	},
+	cli.StringSliceFlag{
+		Name:  "sftp",
+		Usage: "enable and configure an SFTP server, e.g. --sftp=\"ssh-private-key=path/to/id_ed25519\" --sftp=\"address=:8022\"",
+	},
+	cli.StringSliceFlag{
+		Name:  "webdav",
+		Usage: "enable and configure a read-only WebDAV endpoint, e.g. --webdav=\"buckets=photos,docs\" --webdav=\"path=/webdav\"",
+	},
+	cli.StringSliceFlag{
+		Name:  "ftp",
+		Usage: "enable and configure an FTP over TLS server, e.g. --ftp=\"address=:8021\" --ftp=\"passive-port-range=30000-40000\"",
+	},
 	cli.DurationFlag{
 		Name:   "shutdown-timeout",
 		Value:  xhttp.DefaultShutdownTimeout,
@@ -211,12 +223,22 @@ func serverHandleCmdArgs(ctx *cli.Context) {
 		ClientSessionCache: tls.NewLRUClientSessionCache(tlsClientSessionCacheSize),
 	}, rest.DefaultTimeout)()
 	globalProxyEndpoints = GetProxyEndpoints(globalEndpoints)
-	globalInternodeTransport = newInternodeHTTPTransport(&tls.Config{
+	internodeTLSConfig := &tls.Config{
 		RootCAs:            globalRootCAs,
 		CipherSuites:       fips.CipherSuitesTLS(),
 		CurvePreferences:   fips.EllipticCurvesTLS(),
 		ClientSessionCache: tls.NewLRUClientSessionCache(tlsClientSessionCacheSize),
-	}, rest.DefaultTimeout)()
+	}
+	internodeHTTP2, err := config.ParseBool(env.Get(EnvInternodeHTTP2, config.EnableOff))
+	logger.FatalIf(err, "Invalid %s value in environment variable", EnvInternodeHTTP2)
+	if internodeHTTP2 {
+		if !globalIsTLS {
+			internodeTLSConfig = nil
+		}
+		globalInternodeTransport = newInternodeHTTP2Transport(internodeTLSConfig, rest.DefaultTimeout)()
+	} else {
+		globalInternodeTransport = newInternodeHTTPTransport(internodeTLSConfig, rest.DefaultTimeout)()
+	}
 
 	// On macOS, if a process already listens on LOCALIPADDR:PORT, net.Listen() falls back
 	// to IPv6 address ie minio will start listening on IPv6 address whereas another
@@ -230,6 +252,12 @@ func serverHandleCmdArgs(ctx *cli.Context) {
 		globalIsErasure = true
 	}
 	globalIsErasureSD = (setupType == ErasureSDSetupType)
+
+	if webdavArgs := ctx.StringSlice("webdav"); len(webdavArgs) > 0 {
+		webdavCfg, err := parseWebDAVArgs(webdavArgs)
+		logger.FatalIf(err, "Invalid WebDAV arguments")
+		globalWebDAVConfig = &webdavCfg
+	}
 }
 
 func serverHandleEnvVars() {
@@ -507,6 +535,7 @@ func serverMain(ctx *cli.Context) {
 		UseIdleTimeout(ctx.Duration("idle-timeout")).
 		UseReadHeaderTimeout(ctx.Duration("read-header-timeout")).
 		UseBaseContext(GlobalContext).
+		UseConnState(globalConnStats.trackConnState).
 		UseCustomLogger(log.New(ioutil.Discard, "", 0)) // Turn-off random logging by Go stdlib
 
 	go func() {
@@ -534,6 +563,19 @@ func serverMain(ctx *cli.Context) {
 	initAutoHeal(GlobalContext, newObject)
 	initHealMRF(GlobalContext, newObject)
 	initBackgroundExpiry(GlobalContext, newObject)
+	initBackgroundRestore(GlobalContext, newObject)
+	initBitrotScrub(GlobalContext, newObject)
+
+	// Push metrics and traces to an OTLP collector and StatsD agent if configured.
+	initOTLPMetricsExporter(GlobalContext)
+	initOTLPTraceExporter(GlobalContext)
+	initStatsDSink(GlobalContext)
+
+	// Reload and checkpoint cumulative stats if configured.
+	initStatsCheckpoint(GlobalContext, newObject)
+
+	// Capture profiles on a schedule or thresholds if configured.
+	initContinuousProfiler(GlobalContext, newObject)
 
 	if globalActiveCred.Equal(auth.DefaultCredentials) {
 		msg := fmt.Sprintf("WARNING: Detected default credentials '%s', we recommend that you change these values with 'MINIO_ROOT_USER' and 'MINIO_ROOT_PASSWORD' environment variables",
@@ -574,6 +616,18 @@ func serverMain(ctx *cli.Context) {
 		}()
 	}
 
+	if sftpArgs := ctx.StringSlice("sftp"); len(sftpArgs) > 0 {
+		sftpCfg, err := parseSFTPArgs(sftpArgs)
+		logger.FatalIf(err, "Unable to start SFTP server")
+		logger.FatalIf(startSFTPServer(sftpCfg), "Unable to start SFTP server")
+	}
+
+	if ftpArgs := ctx.StringSlice("ftp"); len(ftpArgs) > 0 {
+		ftpCfg, err := parseFTPArgs(ftpArgs)
+		logger.FatalIf(err, "Unable to start FTP server")
+		logger.FatalIf(startFTPServer(ftpCfg), "Unable to start FTP server")
+	}
+
 	// Initialize users credentials and policies in background right after config has initialized.
 	go globalIAMSys.Init(GlobalContext, newObject, globalEtcdClient, globalRefreshIAMInterval)
 
@@ -583,6 +637,11 @@ func serverMain(ctx *cli.Context) {
 		initBackgroundReplication(GlobalContext, newObject)
 		initBackgroundTransition(GlobalContext, newObject)
 
+		// Apply the bandwidth limits of the remote replication targets.
+		if err := loadReplicationBandwidthLimits(GlobalContext, newObject); err != nil {
+			logger.LogIf(GlobalContext, fmt.Errorf("Unable to load replication bandwidth limits: %w", err))
+		}
+
 		go func() {
 			if err := globalTierConfigMgr.Init(GlobalContext, newObject); err != nil {
 				logger.LogIf(GlobalContext, err)
@@ -602,6 +661,18 @@ func serverMain(ctx *cli.Context) {
 
 		initDataScanner(GlobalContext, newObject)
 
+		// Initialize bucket inventory reports.
+		initBackgroundInventory(GlobalContext, newObject)
+
+		// Initialize the delivery of the server access logs of buckets.
+		initBucketAccessLogs(GlobalContext, newObject)
+
+		// Initialize the tracking of the last use of the access keys.
+		initAccessKeyUsage(GlobalContext, newObject)
+
+		// Initialize batch jobs, resuming the active ones.
+		initBatchJobs(GlobalContext, newObject)
+
 		// List buckets to heal, and be re-used for loading configs.
 		buckets, err := newObject.ListBuckets(GlobalContext)
 		if err != nil {
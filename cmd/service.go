@@ -21,6 +21,7 @@ import (
 	"context"
 	"os"
 	"os/exec"
+	"sync/atomic"
 	"syscall"
 )
 
@@ -33,6 +34,8 @@ const (
 	serviceReloadDynamic                      // Reload dynamic config values.
 	serviceFreeze                             // Freeze all S3 API calls.
 	serviceUnFreeze                           // Un-Freeze previously frozen S3 API calls.
+	serviceDrain                              // Reject new S3 API calls.
+	serviceUnDrain                            // Accept S3 API calls again.
 	// Add new service requests here.
 )
 
@@ -102,3 +105,19 @@ func unfreezeServices() {
 	}
 	globalServiceFreezeMu.Unlock()
 }
+
+// drainServices rejects all new S3 API calls, calls
+// already in flight or waiting in the queue are served.
+func drainServices() {
+	atomic.StoreInt32(&globalServiceDraining, 1)
+}
+
+// undrainServices accepts S3 API calls again.
+func undrainServices() {
+	atomic.StoreInt32(&globalServiceDraining, 0)
+}
+
+// isServiceDraining returns whether new S3 API calls are rejected.
+func isServiceDraining() bool {
+	return atomic.LoadInt32(&globalServiceDraining) == 1
+}
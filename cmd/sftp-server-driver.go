@@ -0,0 +1,510 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/minio/madmin-go"
+	"github.com/minio/minio-go/v7"
+	"github.com/minio/minio-go/v7/pkg/credentials"
+	"github.com/minio/minio/internal/auth"
+	"github.com/minio/minio/internal/logger"
+	"github.com/pkg/sftp"
+	"golang.org/x/crypto/ssh"
+)
+
+// Size of the parts of uploads, which are buffered in memory
+// as their size is unknown, this caps objects at 640 GiB.
+const sftpPartSize = 64 << 20
+
+// Transport of the S3 calls made on behalf of SFTP users,
+// shared by all sessions.
+var (
+	sftpTransport     http.RoundTripper
+	sftpTransportOnce sync.Once
+)
+
+// sftpDriver serves the SFTP requests of a session by calling
+// the S3 API of this node with the credentials of the user, so
+// requests are authorized and served like any other S3 request.
+type sftpDriver struct {
+	permissions *ssh.Permissions
+	endpoint    string
+
+	mu     sync.Mutex
+	client *minio.Client
+	// Expiration of the credentials of client, zero if they do not expire.
+	expiration time.Time
+}
+
+// newSFTPDriver returns the handlers of a session of the
+// user authenticated with permissions.
+func newSFTPDriver(permissions *ssh.Permissions) sftp.Handlers {
+	d := &sftpDriver{permissions: permissions, endpoint: globalLocalNodeName}
+	return sftp.Handlers{
+		FileGet:  d,
+		FilePut:  d,
+		FileCmd:  d,
+		FileList: d,
+	}
+}
+
+// getClient returns an S3 client with the credentials of the
+// user, temporary credentials are renewed before they expire.
+func (d *sftpDriver) getClient(ctx context.Context) (*minio.Client, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if d.client != nil && (d.expiration.IsZero() || time.Until(d.expiration) > time.Minute) {
+		return d.client, nil
+	}
+
+	var cred auth.Credentials
+	if accessKey, ok := d.permissions.CriticalOptions[sftpAccessKey]; ok {
+		cred = globalActiveCred
+		if accessKey != cred.AccessKey {
+			if cred, ok = globalIAMSys.GetUser(ctx, accessKey); !ok {
+				return nil, errNoSuchUser
+			}
+		}
+	} else {
+		var err error
+		if cred, err = newSFTPLDAPCredentials(ctx, d.permissions.CriticalOptions[sftpLDAPUser]); err != nil {
+			return nil, err
+		}
+	}
+
+	sftpTransportOnce.Do(func() {
+		sftpTransport = NewRemoteTargetHTTPTransport()
+	})
+	client, err := minio.New(d.endpoint, &minio.Options{
+		Creds:     credentials.NewStaticV4(cred.AccessKey, cred.SecretKey, cred.SessionToken),
+		Secure:    globalIsTLS,
+		Transport: sftpTransport,
+	})
+	if err != nil {
+		return nil, err
+	}
+	d.client = client
+	d.expiration = time.Time{}
+	if cred.IsTemp() {
+		d.expiration = cred.Expiration
+	}
+	return client, nil
+}
+
+// newSFTPLDAPCredentials returns temporary credentials of the LDAP
+// user username, like those returned by AssumeRoleWithLDAPIdentity.
+func newSFTPLDAPCredentials(ctx context.Context, username string) (auth.Credentials, error) {
+	ldapUserDN, groupDistNames, err := globalLDAPConfig.LookupUserDN(username)
+	if err != nil {
+		return auth.Credentials{}, err
+	}
+	expiryDur, err := globalLDAPConfig.GetExpiryDuration("")
+	if err != nil {
+		return auth.Credentials{}, err
+	}
+
+	m := map[string]interface{}{
+		expClaim:  UTCNow().Add(expiryDur).Unix(),
+		ldapUser:  ldapUserDN,
+		ldapUserN: username,
+	}
+	cred, err := auth.GetNewCredentialsWithMetadata(m, globalActiveCred.SecretKey)
+	if err != nil {
+		return auth.Credentials{}, err
+	}
+	cred.ParentUser = ldapUserDN
+	cred.Groups = groupDistNames
+
+	// LDAP policies are applied using the user and group mappings.
+	if err = globalIAMSys.SetTempUser(ctx, cred.AccessKey, cred, ""); err != nil {
+		return auth.Credentials{}, err
+	}
+
+	// Call hook for site replication.
+	if err := globalSiteReplicationSys.IAMChangeHook(ctx, madmin.SRIAMItem{
+		Type: madmin.SRIAMItemSTSAcc,
+		STSCredential: &madmin.SRSTSCredential{
+			AccessKey:    cred.AccessKey,
+			SecretKey:    cred.SecretKey,
+			SessionToken: cred.SessionToken,
+			ParentUser:   cred.ParentUser,
+		},
+	}); err != nil {
+		logger.LogIf(ctx, err)
+	}
+	return cred, nil
+}
+
+// errSFTPUnsupported is returned for operations objects do not support.
+var errSFTPUnsupported = errors.New("operation not supported")
+
+// sftpError translates S3 errors into errors SFTP clients understand.
+func sftpError(err error) error {
+	switch minio.ToErrorResponse(err).Code {
+	case "NoSuchBucket", "NoSuchKey", "NoSuchVersion":
+		return os.ErrNotExist
+	case "AccessDenied":
+		return os.ErrPermission
+	}
+	return err
+}
+
+// newReader returns a reader of the object at p.
+func (d *sftpDriver) newReader(ctx context.Context, p string) (*minio.Object, error) {
+	bucket, object := path2BucketObject(p)
+	if bucket == "" || object == "" {
+		return nil, os.ErrInvalid
+	}
+	client, err := d.getClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	obj, err := client.GetObject(ctx, bucket, object, minio.GetObjectOptions{})
+	if err != nil {
+		return nil, sftpError(err)
+	}
+	if _, err = obj.Stat(); err != nil {
+		obj.Close()
+		return nil, sftpError(err)
+	}
+	return obj, nil
+}
+
+// Fileread returns a reader of the object at r.Filepath.
+func (d *sftpDriver) Fileread(r *sftp.Request) (io.ReaderAt, error) {
+	obj, err := d.newReader(r.Context(), r.Filepath)
+	if err != nil {
+		return nil, err
+	}
+	return obj, nil
+}
+
+// sftpWriterAt uploads sequential writes as one object.
+type sftpWriterAt struct {
+	w      *io.PipeWriter
+	offset int64
+	done   chan error
+}
+
+// WriteAt writes b, offset must follow the previous writes.
+func (w *sftpWriterAt) WriteAt(b []byte, offset int64) (int, error) {
+	if offset != w.offset {
+		return 0, fmt.Errorf("non-sequential write at offset %d, expected offset %d", offset, w.offset)
+	}
+	n, err := w.w.Write(b)
+	w.offset += int64(n)
+	return n, err
+}
+
+// Close completes the upload and returns its outcome.
+func (w *sftpWriterAt) Close() error {
+	w.w.Close()
+	return <-w.done
+}
+
+// TransferError aborts the upload.
+func (w *sftpWriterAt) TransferError(err error) {
+	w.w.CloseWithError(err)
+}
+
+// newWriter returns a writer uploading the object at p until
+// it is closed, ctx cancels the upload.
+func (d *sftpDriver) newWriter(ctx context.Context, p string) (*sftpWriterAt, error) {
+	bucket, object := path2BucketObject(p)
+	if bucket == "" || object == "" {
+		return nil, os.ErrInvalid
+	}
+	client, err := d.getClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	pr, pw := io.Pipe()
+	w := &sftpWriterAt{w: pw, done: make(chan error, 1)}
+	go func() {
+		_, err := client.PutObject(ctx, bucket, object, pr, -1, minio.PutObjectOptions{PartSize: sftpPartSize})
+		pr.CloseWithError(err)
+		w.done <- sftpError(err)
+	}()
+	return w, nil
+}
+
+// Filewrite returns a writer uploading the object at r.Filepath.
+func (d *sftpDriver) Filewrite(r *sftp.Request) (io.WriterAt, error) {
+	// The request context is canceled once the file is
+	// closed or the client disconnects.
+	w, err := d.newWriter(r.Context(), r.Filepath)
+	if err != nil {
+		return nil, err
+	}
+	return w, nil
+}
+
+// mkdir creates a bucket at the top level, an empty prefix
+// marker below it.
+func (d *sftpDriver) mkdir(ctx context.Context, p string) error {
+	bucket, object := path2BucketObject(p)
+	if bucket == "" {
+		return os.ErrInvalid
+	}
+	client, err := d.getClient(ctx)
+	if err != nil {
+		return err
+	}
+	if object == "" {
+		return sftpError(client.MakeBucket(ctx, bucket, minio.MakeBucketOptions{}))
+	}
+	_, err = client.PutObject(ctx, bucket, strings.TrimSuffix(object, SlashSeparator)+SlashSeparator,
+		bytes.NewReader(nil), 0, minio.PutObjectOptions{})
+	return sftpError(err)
+}
+
+// rmdir removes an empty bucket or directory.
+func (d *sftpDriver) rmdir(ctx context.Context, p string) error {
+	bucket, object := path2BucketObject(p)
+	if bucket == "" {
+		return os.ErrInvalid
+	}
+	client, err := d.getClient(ctx)
+	if err != nil {
+		return err
+	}
+	if object == "" {
+		return sftpError(client.RemoveBucket(ctx, bucket))
+	}
+	prefix := strings.TrimSuffix(object, SlashSeparator) + SlashSeparator
+	listCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	for obj := range client.ListObjects(listCtx, bucket, minio.ListObjectsOptions{Prefix: prefix}) {
+		if obj.Err != nil {
+			return sftpError(obj.Err)
+		}
+		if obj.Key != prefix {
+			return errors.New("directory not empty")
+		}
+	}
+	return sftpError(client.RemoveObject(ctx, bucket, prefix, minio.RemoveObjectOptions{}))
+}
+
+// remove removes the object at p.
+func (d *sftpDriver) remove(ctx context.Context, p string) error {
+	bucket, object := path2BucketObject(p)
+	if bucket == "" || object == "" {
+		return os.ErrInvalid
+	}
+	client, err := d.getClient(ctx)
+	if err != nil {
+		return err
+	}
+	return sftpError(client.RemoveObject(ctx, bucket, object, minio.RemoveObjectOptions{}))
+}
+
+// rename renames objects by copying them, directories cannot be renamed.
+func (d *sftpDriver) rename(ctx context.Context, source, target string) error {
+	bucket, object := path2BucketObject(source)
+	targetBucket, targetObject := path2BucketObject(target)
+	if bucket == "" || object == "" || targetBucket == "" || targetObject == "" {
+		return errSFTPUnsupported
+	}
+	client, err := d.getClient(ctx)
+	if err != nil {
+		return err
+	}
+	if _, err = client.ComposeObject(ctx, minio.CopyDestOptions{Bucket: targetBucket, Object: targetObject},
+		minio.CopySrcOptions{Bucket: bucket, Object: object}); err != nil {
+		return sftpError(err)
+	}
+	return sftpError(client.RemoveObject(ctx, bucket, object, minio.RemoveObjectOptions{}))
+}
+
+// Filecmd serves commands changing the namespace, buckets
+// are the top level directories and prefixes the others.
+func (d *sftpDriver) Filecmd(r *sftp.Request) error {
+	ctx := r.Context()
+	switch r.Method {
+	case "Setstat":
+		// Attributes such as modes and times are not kept.
+		return nil
+	case "Mkdir":
+		return d.mkdir(ctx, r.Filepath)
+	case "Rmdir":
+		return d.rmdir(ctx, r.Filepath)
+	case "Remove":
+		return d.remove(ctx, r.Filepath)
+	case "Rename":
+		if err := d.rename(ctx, r.Filepath, r.Target); err != errSFTPUnsupported {
+			return err
+		}
+	}
+	return sftp.ErrSSHFxOpUnsupported
+}
+
+// sftpFileInfo describes a bucket, prefix or object.
+type sftpFileInfo struct {
+	name    string
+	size    int64
+	modTime time.Time
+	isDir   bool
+}
+
+func (fi sftpFileInfo) Name() string       { return fi.name }
+func (fi sftpFileInfo) Size() int64        { return fi.size }
+func (fi sftpFileInfo) ModTime() time.Time { return fi.modTime }
+func (fi sftpFileInfo) IsDir() bool        { return fi.isDir }
+func (fi sftpFileInfo) Sys() interface{}   { return nil }
+
+func (fi sftpFileInfo) Mode() os.FileMode {
+	if fi.isDir {
+		return os.ModeDir | 0o755
+	}
+	return 0o644
+}
+
+// sftpListerAt lists a fixed set of entries.
+type sftpListerAt []os.FileInfo
+
+func (l sftpListerAt) ListAt(ls []os.FileInfo, offset int64) (int, error) {
+	if offset >= int64(len(l)) {
+		return 0, io.EOF
+	}
+	n := copy(ls, l[offset:])
+	if n < len(ls) {
+		return n, io.EOF
+	}
+	return n, nil
+}
+
+// readDir lists the buckets at the top level, the objects and
+// prefixes of a directory below it.
+func (d *sftpDriver) readDir(ctx context.Context, p string) ([]os.FileInfo, error) {
+	client, err := d.getClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+	bucket, object := path2BucketObject(p)
+	object = strings.TrimSuffix(object, SlashSeparator)
+
+	if bucket == "" {
+		buckets, err := client.ListBuckets(ctx)
+		if err != nil {
+			return nil, sftpError(err)
+		}
+		entries := make([]os.FileInfo, 0, len(buckets))
+		for _, b := range buckets {
+			entries = append(entries, sftpFileInfo{name: b.Name, modTime: b.CreationDate, isDir: true})
+		}
+		return entries, nil
+	}
+
+	prefix := ""
+	if object != "" {
+		prefix = object + SlashSeparator
+	}
+	var entries []os.FileInfo
+	for obj := range client.ListObjects(ctx, bucket, minio.ListObjectsOptions{Prefix: prefix}) {
+		if obj.Err != nil {
+			return nil, sftpError(obj.Err)
+		}
+		// Skip the directory marker of the listed prefix.
+		if obj.Key == prefix {
+			continue
+		}
+		name := strings.TrimPrefix(obj.Key, prefix)
+		if strings.HasSuffix(name, SlashSeparator) {
+			entries = append(entries, sftpFileInfo{name: strings.TrimSuffix(name, SlashSeparator), isDir: true})
+			continue
+		}
+		entries = append(entries, sftpFileInfo{name: name, size: obj.Size, modTime: obj.LastModified})
+	}
+	return entries, nil
+}
+
+// stat describes the entry at p, a prefix is a directory if
+// any object is below it.
+func (d *sftpDriver) stat(ctx context.Context, p string) (os.FileInfo, error) {
+	client, err := d.getClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+	bucket, object := path2BucketObject(p)
+	object = strings.TrimSuffix(object, SlashSeparator)
+
+	if bucket == "" {
+		return sftpFileInfo{name: SlashSeparator, isDir: true}, nil
+	}
+	if object == "" {
+		ok, err := client.BucketExists(ctx, bucket)
+		if err != nil {
+			return nil, sftpError(err)
+		}
+		if !ok {
+			return nil, os.ErrNotExist
+		}
+		return sftpFileInfo{name: bucket, isDir: true}, nil
+	}
+
+	name := object[strings.LastIndex(object, SlashSeparator)+1:]
+	info, err := client.StatObject(ctx, bucket, object, minio.StatObjectOptions{})
+	if err == nil {
+		return sftpFileInfo{name: name, size: info.Size, modTime: info.LastModified}, nil
+	}
+	if err = sftpError(err); err != os.ErrNotExist {
+		return nil, err
+	}
+	listCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	for obj := range client.ListObjects(listCtx, bucket, minio.ListObjectsOptions{Prefix: object + SlashSeparator, MaxKeys: 1}) {
+		if obj.Err != nil {
+			return nil, sftpError(obj.Err)
+		}
+		return sftpFileInfo{name: name, isDir: true}, nil
+	}
+	return nil, os.ErrNotExist
+}
+
+// Filelist lists the buckets, a directory or stats a single entry.
+func (d *sftpDriver) Filelist(r *sftp.Request) (sftp.ListerAt, error) {
+	switch r.Method {
+	case "List":
+		entries, err := d.readDir(r.Context(), r.Filepath)
+		if err != nil {
+			return nil, err
+		}
+		return sftpListerAt(entries), nil
+	case "Stat":
+		fi, err := d.stat(r.Context(), r.Filepath)
+		if err != nil {
+			return nil, err
+		}
+		return sftpListerAt{fi}, nil
+	}
+	return nil, sftp.ErrSSHFxOpUnsupported
+}
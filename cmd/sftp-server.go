@@ -0,0 +1,187 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"context"
+	"crypto/subtle"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"strings"
+
+	"github.com/minio/minio/internal/logger"
+	"github.com/pkg/sftp"
+	"golang.org/x/crypto/ssh"
+)
+
+const (
+	sftpDefaultAddress = ":8022"
+
+	// Keys of the permissions of authenticated SSH connections.
+	sftpAccessKey = "accessKey"
+	sftpLDAPUser  = "ldapUsername"
+)
+
+var errSFTPAuthentication = errors.New("sftp: invalid username or password")
+
+// sftpConfig holds the arguments of the --sftp server flag.
+type sftpConfig struct {
+	address       string
+	sshPrivateKey string
+}
+
+// parseSFTPArgs parses "key=value" arguments of the --sftp flag,
+// the SSH host private key is mandatory.
+func parseSFTPArgs(args []string) (cfg sftpConfig, err error) {
+	cfg.address = sftpDefaultAddress
+	for _, arg := range args {
+		tokens := strings.SplitN(arg, "=", 2)
+		if len(tokens) != 2 {
+			return cfg, fmt.Errorf("invalid argument --sftp=%s, expected key=value", arg)
+		}
+		switch tokens[0] {
+		case "address":
+			if _, _, err = net.SplitHostPort(tokens[1]); err != nil {
+				return cfg, fmt.Errorf("invalid argument --sftp=%s: %w", arg, err)
+			}
+			cfg.address = tokens[1]
+		case "ssh-private-key":
+			cfg.sshPrivateKey = tokens[1]
+		default:
+			return cfg, fmt.Errorf("invalid argument --sftp=%s, unknown key %s", arg, tokens[0])
+		}
+	}
+	if cfg.sshPrivateKey == "" {
+		return cfg, errors.New("--sftp=ssh-private-key=path/to/id_ed25519 is mandatory")
+	}
+	return cfg, nil
+}
+
+// sftpAuthenticate checks the password of user, which is either the
+// access key of the root user, of an IAM user or service account,
+// or the username of an LDAP user if LDAP is configured.
+func sftpAuthenticate(user string, pass []byte) (*ssh.Permissions, error) {
+	cred := globalActiveCred
+	ok := user == cred.AccessKey
+	if !ok {
+		cred, ok = globalIAMSys.GetUser(context.Background(), user)
+	}
+	if ok && !cred.IsTemp() {
+		if subtle.ConstantTimeCompare([]byte(cred.SecretKey), pass) != 1 {
+			return nil, errSFTPAuthentication
+		}
+		return &ssh.Permissions{CriticalOptions: map[string]string{sftpAccessKey: user}}, nil
+	}
+
+	if !globalLDAPConfig.Enabled {
+		return nil, errSFTPAuthentication
+	}
+	ldapUserDN, groupDistNames, err := globalLDAPConfig.Bind(user, string(pass))
+	if err != nil {
+		return nil, errSFTPAuthentication
+	}
+	if policies, _ := globalIAMSys.PolicyDBGet(ldapUserDN, false, groupDistNames...); len(policies) == 0 && newGlobalAuthZPluginFn() == nil {
+		return nil, fmt.Errorf("sftp: no policy is set for user %s or one of their groups", ldapUserDN)
+	}
+	return &ssh.Permissions{CriticalOptions: map[string]string{sftpLDAPUser: user}}, nil
+}
+
+// startSFTPServer serves SFTP on the address of cfg, every
+// session acts with the credentials of the authenticated user.
+func startSFTPServer(cfg sftpConfig) error {
+	privateBytes, err := ioutil.ReadFile(cfg.sshPrivateKey)
+	if err != nil {
+		return err
+	}
+	private, err := ssh.ParsePrivateKey(privateBytes)
+	if err != nil {
+		return err
+	}
+
+	sshConfig := &ssh.ServerConfig{
+		PasswordCallback: func(c ssh.ConnMetadata, pass []byte) (*ssh.Permissions, error) {
+			return sftpAuthenticate(c.User(), pass)
+		},
+	}
+	sshConfig.AddHostKey(private)
+
+	listener, err := net.Listen("tcp", cfg.address)
+	if err != nil {
+		return err
+	}
+	go func() {
+		<-GlobalContext.Done()
+		listener.Close()
+	}()
+
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				if GlobalContext.Err() == nil {
+					logger.LogIf(GlobalContext, fmt.Errorf("sftp: unable to accept connection: %w", err))
+				}
+				return
+			}
+			go serveSFTPConn(conn, sshConfig)
+		}
+	}()
+	return nil
+}
+
+// serveSFTPConn serves the SFTP sessions of an SSH connection.
+func serveSFTPConn(conn net.Conn, sshConfig *ssh.ServerConfig) {
+	defer conn.Close()
+
+	sconn, chans, reqs, err := ssh.NewServerConn(conn, sshConfig)
+	if err != nil {
+		// Failed handshakes, mostly bad passwords, are not logged.
+		return
+	}
+	defer sconn.Close()
+	go ssh.DiscardRequests(reqs)
+
+	for newChannel := range chans {
+		if newChannel.ChannelType() != "session" {
+			newChannel.Reject(ssh.UnknownChannelType, "unknown channel type")
+			continue
+		}
+		channel, requests, err := newChannel.Accept()
+		if err != nil {
+			logger.LogIf(GlobalContext, fmt.Errorf("sftp: unable to accept channel: %w", err))
+			return
+		}
+
+		// Only the "sftp" subsystem is supported.
+		go func(in <-chan *ssh.Request) {
+			for req := range in {
+				ok := req.Type == "subsystem" && len(req.Payload) > 4 && string(req.Payload[4:]) == "sftp"
+				req.Reply(ok, nil)
+			}
+		}(requests)
+
+		go func() {
+			defer channel.Close()
+			server := sftp.NewRequestServer(channel, newSFTPDriver(sconn.Permissions), sftp.WithRSAllocator())
+			defer server.Close()
+			server.Serve()
+		}()
+	}
+}
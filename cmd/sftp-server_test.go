@@ -0,0 +1,172 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"io"
+	"io/ioutil"
+	"net"
+	"net/url"
+	"os"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/pkg/sftp"
+	"golang.org/x/crypto/ssh"
+)
+
+func TestParseSFTPArgs(t *testing.T) {
+	testCases := []struct {
+		args     []string
+		expected sftpConfig
+		err      bool
+	}{
+		{[]string{"ssh-private-key=/tmp/key"}, sftpConfig{address: ":8022", sshPrivateKey: "/tmp/key"}, false},
+		{[]string{"address=127.0.0.1:2022", "ssh-private-key=/tmp/key"}, sftpConfig{address: "127.0.0.1:2022", sshPrivateKey: "/tmp/key"}, false},
+		{[]string{"address=:2022"}, sftpConfig{}, true},
+		{[]string{"address=2022", "ssh-private-key=/tmp/key"}, sftpConfig{}, true},
+		{[]string{"port=2022", "ssh-private-key=/tmp/key"}, sftpConfig{}, true},
+		{[]string{"ssh-private-key"}, sftpConfig{}, true},
+	}
+	for i, testCase := range testCases {
+		cfg, err := parseSFTPArgs(testCase.args)
+		if (err != nil) != testCase.err {
+			t.Errorf("case %d: unexpected error %v", i+1, err)
+			continue
+		}
+		if err == nil && cfg != testCase.expected {
+			t.Errorf("case %d: expected %+v, got %+v", i+1, testCase.expected, cfg)
+		}
+	}
+}
+
+func TestSFTPWriterAtSequential(t *testing.T) {
+	pr, pw := io.Pipe()
+	w := &sftpWriterAt{w: pw, done: make(chan error, 1)}
+	go func() {
+		_, err := ioutil.ReadAll(pr)
+		w.done <- err
+	}()
+	if _, err := w.WriteAt([]byte("abc"), 0); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := w.WriteAt([]byte("def"), 10); err == nil {
+		t.Error("expected non-sequential writes to fail")
+	}
+	if _, err := w.WriteAt([]byte("def"), 3); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestSFTPDriver(t *testing.T) {
+	testServer := StartTestServer(t, "ErasureSD")
+	defer testServer.Stop()
+
+	if _, err := sftpAuthenticate(testServer.AccessKey, []byte(testServer.SecretKey)); err != nil {
+		t.Fatalf("expected the root user to authenticate, got %v", err)
+	}
+	if _, err := sftpAuthenticate(testServer.AccessKey, []byte("wrong")); err != errSFTPAuthentication {
+		t.Fatalf("expected a wrong password to be rejected, got %v", err)
+	}
+
+	u, err := url.Parse(testServer.Server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	d := &sftpDriver{
+		permissions: &ssh.Permissions{CriticalOptions: map[string]string{sftpAccessKey: testServer.AccessKey}},
+		endpoint:    u.Host,
+	}
+
+	serverConn, clientConn := net.Pipe()
+	server := sftp.NewRequestServer(serverConn, sftp.Handlers{FileGet: d, FilePut: d, FileCmd: d, FileList: d})
+	go server.Serve()
+	defer server.Close()
+	client, err := sftp.NewClientPipe(clientConn, clientConn)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer client.Close()
+
+	if err = client.Mkdir("/bucket"); err != nil {
+		t.Fatal(err)
+	}
+	if err = client.Mkdir("/bucket/dir"); err != nil {
+		t.Fatal(err)
+	}
+	f, err := client.Create("/bucket/dir/file.txt")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err = f.Write([]byte("hello sftp")); err != nil {
+		t.Fatal(err)
+	}
+	if err = f.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	f, err = client.Open("/bucket/dir/file.txt")
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := ioutil.ReadAll(f)
+	f.Close()
+	if err != nil || string(data) != "hello sftp" {
+		t.Fatalf("expected to read the uploaded file, got %q, %v", data, err)
+	}
+
+	if fi, err := client.Stat("/bucket/dir"); err != nil || !fi.IsDir() {
+		t.Errorf("expected a directory, got %v, %v", fi, err)
+	}
+	if _, err = client.Stat("/bucket/missing"); !os.IsNotExist(err) {
+		t.Errorf("expected missing files not to exist, got %v", err)
+	}
+	if err = client.RemoveDirectory("/bucket/dir"); err == nil {
+		t.Error("expected non empty directories not to be removed")
+	}
+
+	if err = client.Rename("/bucket/dir/file.txt", "/bucket/renamed.txt"); err != nil {
+		t.Fatal(err)
+	}
+	entries, err := client.ReadDir("/bucket")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var names []string
+	for _, fi := range entries {
+		names = append(names, fi.Name())
+	}
+	sort.Strings(names)
+	if strings.Join(names, ",") != "dir,renamed.txt" {
+		t.Errorf("unexpected entries %v", names)
+	}
+
+	if err = client.Remove("/bucket/renamed.txt"); err != nil {
+		t.Fatal(err)
+	}
+	if err = client.RemoveDirectory("/bucket/dir"); err != nil {
+		t.Fatal(err)
+	}
+	if entries, err = client.ReadDir("/bucket"); err != nil || len(entries) != 0 {
+		t.Errorf("expected an empty bucket, got %v, %v", entries, err)
+	}
+}
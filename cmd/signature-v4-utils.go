@@ -62,7 +62,7 @@ func skipContentSha256Cksum(r *http.Request) bool {
 	// If x-amz-content-sha256 is set and the value is not
 	// 'UNSIGNED-PAYLOAD' we should validate the content sha256.
 	switch v[0] {
-	case unsignedPayload:
+	case unsignedPayload, unsignedPayloadTrailer:
 		return true
 	case emptySHA256:
 		// some broken clients set empty-sha256
@@ -170,6 +170,13 @@ func checkKeyValid(r *http.Request, accessKey string) (auth.Credentials, bool, A
 	}
 	cred.Claims = claims
 
+	if cred.IsServiceAccount() {
+		if expiration, ok := serviceAccountExpiry(claims); ok && UTCNow().After(expiration) {
+			globalHTTPStats.rejectRequest(r, rejectedExpired, errorCodes[ErrAccessKeyExpired].Code)
+			return cred, false, ErrAccessKeyExpired
+		}
+	}
+
 	owner := cred.AccessKey == globalActiveCred.AccessKey
 	return cred, owner, ErrNone
 }
@@ -247,3 +254,27 @@ func signV4TrimAll(input string) string {
 	// unicode.IsSpace() internally here) to one space and return
 	return strings.Join(strings.Fields(input), " ")
 }
+
+// checkPresignedMaxContentLength verifies the size of a presigned upload
+// against the limit embedded in the presigned URL, if any. The limit is
+// part of the signed query string for signature v4 presigned URLs, hence
+// it cannot be removed or altered by the holder of the URL.
+func checkPresignedMaxContentLength(r *http.Request, size int64) APIErrorCode {
+	switch getRequestAuthType(r) {
+	case authTypePresigned, authTypePresignedV2:
+	default:
+		return ErrNone
+	}
+	v := r.Form.Get(xhttp.MinIOMaxContentLength)
+	if v == "" {
+		return ErrNone
+	}
+	limit, err := strconv.ParseInt(v, 10, 64)
+	if err != nil || limit < 0 {
+		return ErrInvalidMaxContentLength
+	}
+	if size > limit {
+		return ErrEntityTooLarge
+	}
+	return ErrNone
+}
@@ -21,6 +21,7 @@ import (
 	"context"
 	"net/http"
 	"os"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -91,6 +92,87 @@ func TestCheckValid(t *testing.T) {
 	}
 }
 
+func TestCheckValidServiceAccountExpiry(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	objLayer, fsDir, err := prepareFS()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(fsDir)
+	if err = newTestConfig(globalMinioDefaultRegion, objLayer); err != nil {
+		t.Fatalf("unable initialize config file, %s", err)
+	}
+
+	initAllTestSubsystems(t)
+
+	initConfigSubsystem(ctx, objLayer)
+
+	globalIAMSys.Init(ctx, objLayer, globalEtcdClient, 2*time.Second)
+
+	req, err := newTestRequest(http.MethodGet, "http://example.com:9000/bucket/object", 0, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err = globalIAMSys.CreateUser(ctx, "myuser1", madmin.AddOrUpdateUserReq{
+		SecretKey: "mypassword1",
+		Status:    madmin.AccountEnabled,
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	past := UTCNow().Add(-time.Hour)
+	if _, err = globalIAMSys.NewServiceAccount(ctx, "myuser1", nil, newServiceAccountOpts{
+		expiration: &past,
+	}); err != errInvalidServiceAccountExpiry {
+		t.Fatalf("Expected error %v, found %v", errInvalidServiceAccountExpiry, err)
+	}
+
+	future := UTCNow().Add(time.Hour)
+	svc, err := globalIAMSys.NewServiceAccount(ctx, "myuser1", nil, newServiceAccountOpts{
+		expiration: &future,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, _, s3Err := checkKeyValid(req, svc.AccessKey); s3Err != ErrNone {
+		t.Fatalf("Unexpected failure with %v", errorCodes.ToAPIErr(s3Err))
+	}
+
+	rotated, err := globalIAMSys.RotateServiceAccount(ctx, svc.AccessKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rotated.AccessKey != svc.AccessKey || rotated.SecretKey == svc.SecretKey {
+		t.Fatalf("Expected a new secret key for %s, found %s/%s", svc.AccessKey, rotated.AccessKey, rotated.SecretKey)
+	}
+	expiration, err := globalIAMSys.GetServiceAccountExpiry(ctx, svc.AccessKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !expiration.Equal(time.Unix(future.Unix(), 0)) {
+		t.Fatalf("Expected expiry %v to be kept on rotation, found %v", future, expiration)
+	}
+
+	// Expired service accounts can only be loaded from storage,
+	// the expiry is set directly in the claims here.
+	expired, err := globalIAMSys.NewServiceAccount(ctx, "myuser1", nil, newServiceAccountOpts{
+		claims: map[string]interface{}{svcExpiryClaim: past.Unix()},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	before := atomic.LoadUint64(&globalHTTPStats.rejectedRequestsExpired)
+	if _, _, s3Err := checkKeyValid(req, expired.AccessKey); s3Err != ErrAccessKeyExpired {
+		t.Fatalf("Expected error 'ErrAccessKeyExpired', found %v", s3Err)
+	}
+	if got := atomic.LoadUint64(&globalHTTPStats.rejectedRequestsExpired); got != before+1 {
+		t.Fatalf("Expected %d expired rejections, found %d", before+1, got)
+	}
+}
+
 // TestSkipContentSha256Cksum - Test validate the logic which decides whether
 // to skip checksum validation based on the request header.
 func TestSkipContentSha256Cksum(t *testing.T) {
@@ -342,3 +424,32 @@ func TestGetContentSha256Cksum(t *testing.T) {
 		}
 	}
 }
+
+func TestCheckPresignedMaxContentLength(t *testing.T) {
+	testCases := []struct {
+		q        string // query string
+		size     int64  // upload size
+		expected APIErrorCode
+	}{
+		{"", 1024, ErrNone},
+		{"x-minio-max-content-length=10", 1024, ErrNone},
+		{"X-Amz-Credential=random", 1024, ErrNone},
+		{"X-Amz-Credential=random&x-minio-max-content-length=1024", 1024, ErrNone},
+		{"X-Amz-Credential=random&x-minio-max-content-length=1023", 1024, ErrEntityTooLarge},
+		{"X-Amz-Credential=random&x-minio-max-content-length=0", 0, ErrNone},
+		{"X-Amz-Credential=random&x-minio-max-content-length=-1", 0, ErrInvalidMaxContentLength},
+		{"X-Amz-Credential=random&x-minio-max-content-length=1MiB", 0, ErrInvalidMaxContentLength},
+		{"AWSAccessKeyId=random&x-minio-max-content-length=10", 1024, ErrEntityTooLarge},
+	}
+
+	for i, testCase := range testCases {
+		r, err := http.NewRequest(http.MethodPut, "http://localhost/bucket/object?"+testCase.q, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		r.ParseForm()
+		if got := checkPresignedMaxContentLength(r, testCase.size); got != testCase.expected {
+			t.Errorf("Test %d: got:%v expected:%v", i+1, got, testCase.expected)
+		}
+	}
+}
@@ -0,0 +1,172 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/minio/madmin-go"
+)
+
+// srPeerHealthTimeout is the time allowed to a peer site to report its health.
+const srPeerHealthTimeout = 10 * time.Second
+
+// srPeerHealth is the replication health of a site as reported by the
+// site itself.
+type srPeerHealth struct {
+	DeploymentID string `json:"deploymentID"`
+	// Current time of the site
+	Time time.Time `json:"time"`
+	// Replication statistics of the buckets to every peer site, by deployment ID
+	TargetStats map[string]ReplicationTargetStats `json:"targetStats,omitempty"`
+}
+
+// srSiteHealth is the health of a site participating in site replication
+// as seen from the site serving the request.
+type srSiteHealth struct {
+	Name         string `json:"name"`
+	Endpoint     string `json:"endpoint"`
+	DeploymentID string `json:"deploymentID"`
+	Online       bool   `json:"online"`
+	Error        string `json:"error,omitempty"`
+	// Round trip time of the health request to the site
+	LatencyMillis int64 `json:"latencyMillis"`
+	// Clock of the site minus the clock of the site serving the request,
+	// adjusted by half of the round trip time.
+	ClockSkewMillis int64 `json:"clockSkewMillis"`
+	// Replication statistics of the buckets to every peer site, by
+	// deployment ID, including the pending queue depth and the time of
+	// the last successful replication.
+	TargetStats map[string]ReplicationTargetStats `json:"targetStats,omitempty"`
+}
+
+// srHealthInfo is the health of all the sites participating in
+// site replication, by deployment ID.
+type srHealthInfo struct {
+	Enabled bool                    `json:"enabled"`
+	Sites   map[string]srSiteHealth `json:"sites,omitempty"`
+}
+
+// PeerHealth returns the replication health of this site.
+func (c *SiteReplicationSys) PeerHealth(ctx context.Context, objAPI ObjectLayer) srPeerHealth {
+	c.RLock()
+	sites := make(map[string]madmin.PeerInfo, len(c.state.Peers))
+	for dID, peer := range c.state.Peers {
+		sites[dID] = peer
+	}
+	c.RUnlock()
+
+	return srPeerHealth{
+		DeploymentID: globalDeploymentID,
+		Time:         UTCNow(),
+		TargetStats:  c.getTargetStats(ctx, objAPI, sites),
+	}
+}
+
+// getPeerHealth fetches the replication health of a peer site.
+func (c *SiteReplicationSys) getPeerHealth(ctx context.Context, deploymentID string) (health srPeerHealth, err error) {
+	c.RLock()
+	admClient, err := c.getAdminClient(ctx, deploymentID)
+	c.RUnlock()
+	if err != nil {
+		return health, err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, srPeerHealthTimeout)
+	defer cancel()
+
+	resp, err := admClient.ExecuteMethod(ctx, http.MethodGet, madmin.RequestData{
+		RelPath: adminAPIVersionPrefix + "/site-replication/peer/health",
+	})
+	if err != nil {
+		return health, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return health, fmt.Errorf("unexpected response from peer: %s", resp.Status)
+	}
+
+	b, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return health, err
+	}
+	err = json.Unmarshal(b, &health)
+	return health, err
+}
+
+// SiteReplicationHealth returns the link health, clock skew, pending
+// replication and the time of the last replication of every site.
+func (c *SiteReplicationSys) SiteReplicationHealth(ctx context.Context, objAPI ObjectLayer) (info srHealthInfo, err error) {
+	c.RLock()
+	if !c.enabled {
+		c.RUnlock()
+		return info, nil
+	}
+	peers := make(map[string]madmin.PeerInfo, len(c.state.Peers))
+	for dID, peer := range c.state.Peers {
+		peers[dID] = peer
+	}
+	c.RUnlock()
+
+	info.Enabled = true
+	info.Sites = make(map[string]srSiteHealth, len(peers))
+
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	for dID, peer := range peers {
+		wg.Add(1)
+		go func(dID string, peer madmin.PeerInfo) {
+			defer wg.Done()
+
+			sh := srSiteHealth{
+				Name:         peer.Name,
+				Endpoint:     peer.Endpoint,
+				DeploymentID: dID,
+			}
+			if dID == globalDeploymentID {
+				sh.Online = true
+				sh.TargetStats = c.PeerHealth(ctx, objAPI).TargetStats
+			} else {
+				start := UTCNow()
+				health, err := c.getPeerHealth(ctx, dID)
+				rtt := UTCNow().Sub(start)
+				if err != nil {
+					sh.Error = err.Error()
+				} else {
+					sh.Online = true
+					sh.LatencyMillis = rtt.Milliseconds()
+					sh.ClockSkewMillis = health.Time.Sub(start.Add(rtt / 2)).Milliseconds()
+					sh.TargetStats = health.TargetStats
+				}
+			}
+
+			mu.Lock()
+			info.Sites[dID] = sh
+			mu.Unlock()
+		}(dID, peer)
+	}
+	wg.Wait()
+	return info, nil
+}
@@ -0,0 +1,185 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+package cmd
+
+import (
+	"context"
+	"crypto/rand"
+	"fmt"
+	"net/http"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/dustin/go-humanize"
+	"github.com/minio/madmin-go"
+	xhttp "github.com/minio/minio/internal/http"
+)
+
+const (
+	// srNetperfPayloadSize is the size of each request sent to a peer
+	// site while measuring the throughput.
+	srNetperfPayloadSize = 4 * humanize.MiByte
+
+	// srNetperfConnections is the number of concurrent requests sent
+	// to a peer site while measuring the throughput.
+	srNetperfConnections = 16
+
+	// srNetperfPings is the number of empty requests sent to a peer
+	// site to measure the latency.
+	srNetperfPings = 10
+)
+
+// srNetperfSiteResult is the network performance between this site and
+// a peer site, measured over the site replication transport with the
+// site replicator credentials.
+type srNetperfSiteResult struct {
+	Name         string `json:"name"`
+	Endpoint     string `json:"endpoint"`
+	DeploymentID string `json:"deploymentID"`
+	Error        string `json:"error,omitempty"`
+	// Bytes per second sent to the site
+	TX uint64 `json:"tx"`
+	// Round trip times of empty requests to the site
+	LatencyMinMillis float64 `json:"latencyMinMillis"`
+	LatencyAvgMillis float64 `json:"latencyAvgMillis"`
+	LatencyMaxMillis float64 `json:"latencyMaxMillis"`
+}
+
+// srNetperfResult is the network performance between this site and
+// every peer site.
+type srNetperfResult struct {
+	Duration time.Duration         `json:"duration"`
+	Sites    []srNetperfSiteResult `json:"sites"`
+}
+
+// Netperf measures the latency and the throughput from this site to
+// every peer site. The sites are tested one after the other so that
+// they do not compete for the bandwidth of this site.
+func (c *SiteReplicationSys) Netperf(ctx context.Context, duration time.Duration) (result srNetperfResult, err error) {
+	c.RLock()
+	if !c.enabled {
+		c.RUnlock()
+		return result, errSRNotEnabled
+	}
+	peers := make([]madmin.PeerInfo, 0, len(c.state.Peers))
+	for dID, peer := range c.state.Peers {
+		if dID != globalDeploymentID {
+			peers = append(peers, peer)
+		}
+	}
+	c.RUnlock()
+	sort.Slice(peers, func(i, j int) bool { return peers[i].Name < peers[j].Name })
+
+	result.Duration = duration
+	for _, peer := range peers {
+		res := srNetperfSiteResult{
+			Name:         peer.Name,
+			Endpoint:     peer.Endpoint,
+			DeploymentID: peer.DeploymentID,
+		}
+		if err = c.netperfPeer(ctx, &res, duration); err != nil {
+			res.Error = err.Error()
+		}
+		result.Sites = append(result.Sites, res)
+		if ctx.Err() != nil {
+			return result, ctx.Err()
+		}
+	}
+	return result, nil
+}
+
+// netperfPeer measures the latency and the throughput to the peer
+// site of res.
+func (c *SiteReplicationSys) netperfPeer(ctx context.Context, res *srNetperfSiteResult, duration time.Duration) error {
+	c.RLock()
+	admClient, err := c.getAdminClient(ctx, res.DeploymentID)
+	c.RUnlock()
+	if err != nil {
+		return err
+	}
+
+	send := func(ctx context.Context, content []byte) error {
+		resp, err := admClient.ExecuteMethod(ctx, http.MethodPut, madmin.RequestData{
+			RelPath: adminAPIVersionPrefix + "/site-replication/peer/netperf",
+			Content: content,
+		})
+		if err != nil {
+			return err
+		}
+		defer xhttp.DrainBody(resp.Body)
+		if resp.StatusCode != http.StatusOK {
+			return fmt.Errorf("unexpected response from peer: %s", resp.Status)
+		}
+		return nil
+	}
+
+	var total time.Duration
+	for i := 0; i < srNetperfPings; i++ {
+		start := time.Now()
+		if err = send(ctx, nil); err != nil {
+			return err
+		}
+		rtt := time.Since(start)
+		total += rtt
+		ms := float64(rtt) / float64(time.Millisecond)
+		if i == 0 || ms < res.LatencyMinMillis {
+			res.LatencyMinMillis = ms
+		}
+		if ms > res.LatencyMaxMillis {
+			res.LatencyMaxMillis = ms
+		}
+	}
+	res.LatencyAvgMillis = float64(total) / float64(time.Millisecond) / srNetperfPings
+
+	payload := make([]byte, srNetperfPayloadSize)
+	rand.Read(payload)
+
+	tctx, cancel := context.WithTimeout(ctx, duration)
+	defer cancel()
+
+	var sent uint64
+	var sendErr error
+	var errOnce sync.Once
+	var wg sync.WaitGroup
+	for i := 0; i < srNetperfConnections; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for tctx.Err() == nil {
+				if err := send(tctx, payload); err != nil {
+					// Requests interrupted by the end of the test
+					// are not counted.
+					if tctx.Err() == nil {
+						errOnce.Do(func() { sendErr = err })
+						cancel()
+					}
+					return
+				}
+				atomic.AddUint64(&sent, uint64(len(payload)))
+			}
+		}()
+	}
+	wg.Wait()
+
+	if sendErr != nil {
+		return sendErr
+	}
+	res.TX = uint64(float64(atomic.LoadUint64(&sent)) / duration.Seconds())
+	return ctx.Err()
+}
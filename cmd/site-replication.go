@@ -1334,7 +1334,7 @@ func (c *SiteReplicationSys) PeerBucketSSEConfigHandler(ctx context.Context, buc
 }
 
 // PeerBucketQuotaConfigHandler - copies/deletes policy to local cluster.
-func (c *SiteReplicationSys) PeerBucketQuotaConfigHandler(ctx context.Context, bucket string, quota *madmin.BucketQuota) error {
+func (c *SiteReplicationSys) PeerBucketQuotaConfigHandler(ctx context.Context, bucket string, quota *BucketQuotaConfig) error {
 	if quota != nil {
 		quotaData, err := json.Marshal(quota)
 		if err != nil {
@@ -2159,6 +2159,33 @@ type srGroupDesc struct {
 	DeploymentID string
 }
 
+// getTargetStats returns the replication statistics of the buckets to
+// every peer site by deployment ID, the bucket targets are matched to the
+// sites by endpoint.
+func (c *SiteReplicationSys) getTargetStats(ctx context.Context, objAPI ObjectLayer, sites map[string]madmin.PeerInfo) map[string]ReplicationTargetStats {
+	dui, err := loadDataUsageFromBackend(ctx, objAPI)
+	if err != nil {
+		logger.LogIf(ctx, err)
+		return nil
+	}
+	targets := getReplicationTargetStats(ctx, getAllLatestReplicationStats(dui.BucketsUsage))
+
+	stats := make(map[string]ReplicationTargetStats, len(sites))
+	for dID, peer := range sites {
+		if dID == globalDeploymentID {
+			continue
+		}
+		u, err := url.Parse(peer.Endpoint)
+		if err != nil {
+			continue
+		}
+		if ts, ok := targets[u.Host]; ok {
+			stats[dID] = *ts
+		}
+	}
+	return stats
+}
+
 // SiteReplicationStatus returns the site replication status across clusters participating in site replication.
 func (c *SiteReplicationSys) SiteReplicationStatus(ctx context.Context, objAPI ObjectLayer, opts madmin.SRStatusOptions) (info madmin.SRStatusInfo, err error) {
 	sinfo, err := c.siteReplicationStatus(ctx, objAPI, opts)
@@ -2578,7 +2605,7 @@ func (c *SiteReplicationSys) siteReplicationStatus(ctx context.Context, objAPI O
 			olockConfigSet := set.NewStringSet()
 			policies := make([]*bktpolicy.Policy, numSites)
 			replCfgs := make([]*sreplication.Config, numSites)
-			quotaCfgs := make([]*madmin.BucketQuota, numSites)
+			quotaCfgs := make([]*BucketQuotaConfig, numSites)
 			sseCfgSet := set.NewStringSet()
 			versionCfgSet := set.NewStringSet()
 			var tagCount, olockCfgCount, sseCfgCount, versionCfgCount int
@@ -2692,7 +2719,7 @@ func (c *SiteReplicationSys) siteReplicationStatus(ctx context.Context, objAPI O
 				if bi, ok := sris[dIdx].Buckets[s.Bucket]; ok {
 					hasBucket = !bi.CreatedAt.Equal(timeSentinel)
 				}
-				quotaCfgSet := hasBucket && *quotaCfgs[i] != madmin.BucketQuota{}
+				quotaCfgSet := hasBucket && (quotaCfgs[i].BucketQuota != madmin.BucketQuota{} || !quotaCfgs[i].isEmpty())
 				ss := madmin.SRBucketStatsSummary{
 					DeploymentID:             s.DeploymentID,
 					HasBucket:                hasBucket,
@@ -2841,7 +2868,7 @@ func isGroupDescReplicated(cntReplicated, total int, gds []madmin.GroupDesc) boo
 	return true
 }
 
-func isBktQuotaCfgReplicated(total int, quotaCfgs []*madmin.BucketQuota) bool {
+func isBktQuotaCfgReplicated(total int, quotaCfgs []*BucketQuotaConfig) bool {
 	numquotaCfgs := 0
 	for _, q := range quotaCfgs {
 		if q == nil {
@@ -2853,7 +2880,7 @@ func isBktQuotaCfgReplicated(total int, quotaCfgs []*madmin.BucketQuota) bool {
 	if numquotaCfgs > 0 && numquotaCfgs != total {
 		return false
 	}
-	var prev *madmin.BucketQuota
+	var prev *BucketQuotaConfig
 	for i, q := range quotaCfgs {
 		if q == nil {
 			return false
@@ -2862,7 +2889,8 @@ func isBktQuotaCfgReplicated(total int, quotaCfgs []*madmin.BucketQuota) bool {
 			prev = q
 			continue
 		}
-		if prev.Quota != q.Quota || prev.Type != q.Type {
+		if prev.Quota != q.Quota || prev.Type != q.Type || !reflect.DeepEqual(prev.Thresholds, q.Thresholds) ||
+			prev.MaxObjects != q.MaxObjects || prev.MaxVersions != q.MaxVersions {
 			return false
 		}
 	}
@@ -0,0 +1,130 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// staleUploadsStats tracks the incomplete multipart uploads found and
+// aborted by the stale uploads cleanup of this node.
+type staleUploadsStats struct {
+	// Number of incomplete uploads found during the last sweep of each
+	// erasure set, keyed by "pool-set".
+	activeMu sync.Mutex
+	active   map[string]uint64
+
+	abortedUploads uint64 // Must be accessed atomically
+	reclaimedBytes uint64 // Must be accessed atomically
+}
+
+var globalStaleUploadsStats = &staleUploadsStats{
+	active: make(map[string]uint64),
+}
+
+func (s *staleUploadsStats) setActive(set string, n uint64) {
+	s.activeMu.Lock()
+	defer s.activeMu.Unlock()
+	s.active[set] = n
+}
+
+// activeUploads returns the number of incomplete uploads
+// found during the last sweep across all erasure sets.
+func (s *staleUploadsStats) activeUploads() (n uint64) {
+	s.activeMu.Lock()
+	defer s.activeMu.Unlock()
+	for _, v := range s.active {
+		n += v
+	}
+	return n
+}
+
+// aborted records the abort of the upload described by fi.
+func (s *staleUploadsStats) aborted(fi FileInfo) {
+	var size int64
+	for _, part := range fi.Parts {
+		size += part.Size
+	}
+	atomic.AddUint64(&s.abortedUploads, 1)
+	atomic.AddUint64(&s.reclaimedBytes, uint64(size))
+}
+
+// staleUploadsSweep holds the state of one stale uploads
+// cleanup pass running on several disks of the same set.
+type staleUploadsSweep struct {
+	mu      sync.Mutex
+	visited map[string]struct{}
+	found   uint64
+}
+
+func newStaleUploadsSweep() *staleUploadsSweep {
+	return &staleUploadsSweep{visited: make(map[string]struct{})}
+}
+
+// visit returns true if the upload was not seen before in this sweep.
+func (s *staleUploadsSweep) visit(uploadIDPath string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.visited[uploadIDPath]; ok {
+		return false
+	}
+	s.visited[uploadIDPath] = struct{}{}
+	return true
+}
+
+func (s *staleUploadsSweep) active() {
+	atomic.AddUint64(&s.found, 1)
+}
+
+func (s *staleUploadsSweep) activeCount() uint64 {
+	return atomic.LoadUint64(&s.found)
+}
+
+// isStaleUpload returns true if the multipart upload described by fi must
+// be aborted. An AbortIncompleteMultipartUpload lifecycle rule configured
+// on the bucket of the upload takes precedence over the server wide
+// expiry, which is otherwise applied to the last modification time.
+func isStaleUpload(fi FileInfo, now time.Time, expiry time.Duration) bool {
+	if after := abortIncompleteUploadAfter(fi); after > 0 {
+		initiated, err := time.Parse(time.RFC3339Nano, fi.Metadata[minIOMultipartInitiated])
+		if err != nil {
+			initiated = fi.ModTime
+		}
+		return now.Sub(initiated) > after
+	}
+	return now.Sub(fi.ModTime) > expiry
+}
+
+// abortIncompleteUploadAfter returns the age configured by the bucket
+// lifecycle for the upload described by fi, 0 if there is none.
+func abortIncompleteUploadAfter(fi FileInfo) time.Duration {
+	if globalLifecycleSys == nil || globalBucketMetadataSys == nil {
+		return 0
+	}
+	bucket, object := path2BucketObject(fi.Metadata[minIOMultipartObject])
+	if bucket == "" || object == "" {
+		return 0
+	}
+	lc, err := globalLifecycleSys.Get(bucket)
+	if err != nil {
+		return 0
+	}
+	return lc.AbortIncompleteMultipartUploadAfter(object)
+}
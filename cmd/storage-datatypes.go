@@ -49,6 +49,26 @@ type DiskInfo struct {
 type DiskMetrics struct {
 	APILatencies map[string]uint64 `json:"apiLatencies,omitempty"`
 	APICalls     map[string]uint64 `json:"apiCalls,omitempty"`
+	IO           DriveIOStats      `json:"io"`
+}
+
+// DriveIOStats has the IO statistics of a drive, the rates
+// and latency percentiles cover the last minute. Latencies
+// are in nanoseconds.
+type DriveIOStats struct {
+	ReadOps         uint64  `json:"readOps"`
+	WriteOps        uint64  `json:"writeOps"`
+	ReadIOPS        float64 `json:"readIOPS"`
+	WriteIOPS       float64 `json:"writeIOPS"`
+	ReadLatencyP50  uint64  `json:"readLatencyP50"`
+	ReadLatencyP90  uint64  `json:"readLatencyP90"`
+	ReadLatencyP99  uint64  `json:"readLatencyP99"`
+	WriteLatencyP50 uint64  `json:"writeLatencyP50"`
+	WriteLatencyP90 uint64  `json:"writeLatencyP90"`
+	WriteLatencyP99 uint64  `json:"writeLatencyP99"`
+	QueueDepth      int32   `json:"queueDepth"`
+	Errors          uint64  `json:"errors"`
+	Timeouts        uint64  `json:"timeouts"`
 }
 
 // VolsInfo is a collection of volume(bucket) information
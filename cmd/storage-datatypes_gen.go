@@ -351,6 +351,12 @@ func (z *DiskMetrics) DecodeMsg(dc *msgp.Reader) (err error) {
 				}
 				z.APICalls[za0003] = za0004
 			}
+		case "IO":
+			err = z.IO.DecodeMsg(dc)
+			if err != nil {
+				err = msgp.WrapError(err, "IO")
+				return
+			}
 		default:
 			err = dc.Skip()
 			if err != nil {
@@ -364,9 +370,9 @@ func (z *DiskMetrics) DecodeMsg(dc *msgp.Reader) (err error) {
 
 // EncodeMsg implements msgp.Encodable
 func (z *DiskMetrics) EncodeMsg(en *msgp.Writer) (err error) {
-	// map header, size 2
+	// map header, size 3
 	// write "APILatencies"
-	err = en.Append(0x82, 0xac, 0x41, 0x50, 0x49, 0x4c, 0x61, 0x74, 0x65, 0x6e, 0x63, 0x69, 0x65, 0x73)
+	err = en.Append(0x83, 0xac, 0x41, 0x50, 0x49, 0x4c, 0x61, 0x74, 0x65, 0x6e, 0x63, 0x69, 0x65, 0x73)
 	if err != nil {
 		return
 	}
@@ -409,15 +415,25 @@ func (z *DiskMetrics) EncodeMsg(en *msgp.Writer) (err error) {
 			return
 		}
 	}
+	// write "IO"
+	err = en.Append(0xa2, 0x49, 0x4f)
+	if err != nil {
+		return
+	}
+	err = z.IO.EncodeMsg(en)
+	if err != nil {
+		err = msgp.WrapError(err, "IO")
+		return
+	}
 	return
 }
 
 // MarshalMsg implements msgp.Marshaler
 func (z *DiskMetrics) MarshalMsg(b []byte) (o []byte, err error) {
 	o = msgp.Require(b, z.Msgsize())
-	// map header, size 2
+	// map header, size 3
 	// string "APILatencies"
-	o = append(o, 0x82, 0xac, 0x41, 0x50, 0x49, 0x4c, 0x61, 0x74, 0x65, 0x6e, 0x63, 0x69, 0x65, 0x73)
+	o = append(o, 0x83, 0xac, 0x41, 0x50, 0x49, 0x4c, 0x61, 0x74, 0x65, 0x6e, 0x63, 0x69, 0x65, 0x73)
 	o = msgp.AppendMapHeader(o, uint32(len(z.APILatencies)))
 	for za0001, za0002 := range z.APILatencies {
 		o = msgp.AppendString(o, za0001)
@@ -430,6 +446,13 @@ func (z *DiskMetrics) MarshalMsg(b []byte) (o []byte, err error) {
 		o = msgp.AppendString(o, za0003)
 		o = msgp.AppendUint64(o, za0004)
 	}
+	// string "IO"
+	o = append(o, 0xa2, 0x49, 0x4f)
+	o, err = z.IO.MarshalMsg(o)
+	if err != nil {
+		err = msgp.WrapError(err, "IO")
+		return
+	}
 	return
 }
 
@@ -511,6 +534,12 @@ func (z *DiskMetrics) UnmarshalMsg(bts []byte) (o []byte, err error) {
 				}
 				z.APICalls[za0003] = za0004
 			}
+		case "IO":
+			bts, err = z.IO.UnmarshalMsg(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "IO")
+				return
+			}
 		default:
 			bts, err = msgp.Skip(bts)
 			if err != nil {
@@ -539,6 +568,410 @@ func (z *DiskMetrics) Msgsize() (s int) {
 			s += msgp.StringPrefixSize + len(za0003) + msgp.Uint64Size
 		}
 	}
+	s += 3 + z.IO.Msgsize()
+	return
+}
+
+// DecodeMsg implements msgp.Decodable
+func (z *DriveIOStats) DecodeMsg(dc *msgp.Reader) (err error) {
+	var field []byte
+	_ = field
+	var zb0001 uint32
+	zb0001, err = dc.ReadMapHeader()
+	if err != nil {
+		err = msgp.WrapError(err)
+		return
+	}
+	for zb0001 > 0 {
+		zb0001--
+		field, err = dc.ReadMapKeyPtr()
+		if err != nil {
+			err = msgp.WrapError(err)
+			return
+		}
+		switch msgp.UnsafeString(field) {
+		case "ReadOps":
+			z.ReadOps, err = dc.ReadUint64()
+			if err != nil {
+				err = msgp.WrapError(err, "ReadOps")
+				return
+			}
+		case "WriteOps":
+			z.WriteOps, err = dc.ReadUint64()
+			if err != nil {
+				err = msgp.WrapError(err, "WriteOps")
+				return
+			}
+		case "ReadIOPS":
+			z.ReadIOPS, err = dc.ReadFloat64()
+			if err != nil {
+				err = msgp.WrapError(err, "ReadIOPS")
+				return
+			}
+		case "WriteIOPS":
+			z.WriteIOPS, err = dc.ReadFloat64()
+			if err != nil {
+				err = msgp.WrapError(err, "WriteIOPS")
+				return
+			}
+		case "ReadLatencyP50":
+			z.ReadLatencyP50, err = dc.ReadUint64()
+			if err != nil {
+				err = msgp.WrapError(err, "ReadLatencyP50")
+				return
+			}
+		case "ReadLatencyP90":
+			z.ReadLatencyP90, err = dc.ReadUint64()
+			if err != nil {
+				err = msgp.WrapError(err, "ReadLatencyP90")
+				return
+			}
+		case "ReadLatencyP99":
+			z.ReadLatencyP99, err = dc.ReadUint64()
+			if err != nil {
+				err = msgp.WrapError(err, "ReadLatencyP99")
+				return
+			}
+		case "WriteLatencyP50":
+			z.WriteLatencyP50, err = dc.ReadUint64()
+			if err != nil {
+				err = msgp.WrapError(err, "WriteLatencyP50")
+				return
+			}
+		case "WriteLatencyP90":
+			z.WriteLatencyP90, err = dc.ReadUint64()
+			if err != nil {
+				err = msgp.WrapError(err, "WriteLatencyP90")
+				return
+			}
+		case "WriteLatencyP99":
+			z.WriteLatencyP99, err = dc.ReadUint64()
+			if err != nil {
+				err = msgp.WrapError(err, "WriteLatencyP99")
+				return
+			}
+		case "QueueDepth":
+			z.QueueDepth, err = dc.ReadInt32()
+			if err != nil {
+				err = msgp.WrapError(err, "QueueDepth")
+				return
+			}
+		case "Errors":
+			z.Errors, err = dc.ReadUint64()
+			if err != nil {
+				err = msgp.WrapError(err, "Errors")
+				return
+			}
+		case "Timeouts":
+			z.Timeouts, err = dc.ReadUint64()
+			if err != nil {
+				err = msgp.WrapError(err, "Timeouts")
+				return
+			}
+		default:
+			err = dc.Skip()
+			if err != nil {
+				err = msgp.WrapError(err)
+				return
+			}
+		}
+	}
+	return
+}
+
+// EncodeMsg implements msgp.Encodable
+func (z *DriveIOStats) EncodeMsg(en *msgp.Writer) (err error) {
+	// map header, size 13
+	// write "ReadOps"
+	err = en.Append(0x8d, 0xa7, 0x52, 0x65, 0x61, 0x64, 0x4f, 0x70, 0x73)
+	if err != nil {
+		return
+	}
+	err = en.WriteUint64(z.ReadOps)
+	if err != nil {
+		err = msgp.WrapError(err, "ReadOps")
+		return
+	}
+	// write "WriteOps"
+	err = en.Append(0xa8, 0x57, 0x72, 0x69, 0x74, 0x65, 0x4f, 0x70, 0x73)
+	if err != nil {
+		return
+	}
+	err = en.WriteUint64(z.WriteOps)
+	if err != nil {
+		err = msgp.WrapError(err, "WriteOps")
+		return
+	}
+	// write "ReadIOPS"
+	err = en.Append(0xa8, 0x52, 0x65, 0x61, 0x64, 0x49, 0x4f, 0x50, 0x53)
+	if err != nil {
+		return
+	}
+	err = en.WriteFloat64(z.ReadIOPS)
+	if err != nil {
+		err = msgp.WrapError(err, "ReadIOPS")
+		return
+	}
+	// write "WriteIOPS"
+	err = en.Append(0xa9, 0x57, 0x72, 0x69, 0x74, 0x65, 0x49, 0x4f, 0x50, 0x53)
+	if err != nil {
+		return
+	}
+	err = en.WriteFloat64(z.WriteIOPS)
+	if err != nil {
+		err = msgp.WrapError(err, "WriteIOPS")
+		return
+	}
+	// write "ReadLatencyP50"
+	err = en.Append(0xae, 0x52, 0x65, 0x61, 0x64, 0x4c, 0x61, 0x74, 0x65, 0x6e, 0x63, 0x79, 0x50, 0x35, 0x30)
+	if err != nil {
+		return
+	}
+	err = en.WriteUint64(z.ReadLatencyP50)
+	if err != nil {
+		err = msgp.WrapError(err, "ReadLatencyP50")
+		return
+	}
+	// write "ReadLatencyP90"
+	err = en.Append(0xae, 0x52, 0x65, 0x61, 0x64, 0x4c, 0x61, 0x74, 0x65, 0x6e, 0x63, 0x79, 0x50, 0x39, 0x30)
+	if err != nil {
+		return
+	}
+	err = en.WriteUint64(z.ReadLatencyP90)
+	if err != nil {
+		err = msgp.WrapError(err, "ReadLatencyP90")
+		return
+	}
+	// write "ReadLatencyP99"
+	err = en.Append(0xae, 0x52, 0x65, 0x61, 0x64, 0x4c, 0x61, 0x74, 0x65, 0x6e, 0x63, 0x79, 0x50, 0x39, 0x39)
+	if err != nil {
+		return
+	}
+	err = en.WriteUint64(z.ReadLatencyP99)
+	if err != nil {
+		err = msgp.WrapError(err, "ReadLatencyP99")
+		return
+	}
+	// write "WriteLatencyP50"
+	err = en.Append(0xaf, 0x57, 0x72, 0x69, 0x74, 0x65, 0x4c, 0x61, 0x74, 0x65, 0x6e, 0x63, 0x79, 0x50, 0x35, 0x30)
+	if err != nil {
+		return
+	}
+	err = en.WriteUint64(z.WriteLatencyP50)
+	if err != nil {
+		err = msgp.WrapError(err, "WriteLatencyP50")
+		return
+	}
+	// write "WriteLatencyP90"
+	err = en.Append(0xaf, 0x57, 0x72, 0x69, 0x74, 0x65, 0x4c, 0x61, 0x74, 0x65, 0x6e, 0x63, 0x79, 0x50, 0x39, 0x30)
+	if err != nil {
+		return
+	}
+	err = en.WriteUint64(z.WriteLatencyP90)
+	if err != nil {
+		err = msgp.WrapError(err, "WriteLatencyP90")
+		return
+	}
+	// write "WriteLatencyP99"
+	err = en.Append(0xaf, 0x57, 0x72, 0x69, 0x74, 0x65, 0x4c, 0x61, 0x74, 0x65, 0x6e, 0x63, 0x79, 0x50, 0x39, 0x39)
+	if err != nil {
+		return
+	}
+	err = en.WriteUint64(z.WriteLatencyP99)
+	if err != nil {
+		err = msgp.WrapError(err, "WriteLatencyP99")
+		return
+	}
+	// write "QueueDepth"
+	err = en.Append(0xaa, 0x51, 0x75, 0x65, 0x75, 0x65, 0x44, 0x65, 0x70, 0x74, 0x68)
+	if err != nil {
+		return
+	}
+	err = en.WriteInt32(z.QueueDepth)
+	if err != nil {
+		err = msgp.WrapError(err, "QueueDepth")
+		return
+	}
+	// write "Errors"
+	err = en.Append(0xa6, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x73)
+	if err != nil {
+		return
+	}
+	err = en.WriteUint64(z.Errors)
+	if err != nil {
+		err = msgp.WrapError(err, "Errors")
+		return
+	}
+	// write "Timeouts"
+	err = en.Append(0xa8, 0x54, 0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74, 0x73)
+	if err != nil {
+		return
+	}
+	err = en.WriteUint64(z.Timeouts)
+	if err != nil {
+		err = msgp.WrapError(err, "Timeouts")
+		return
+	}
+	return
+}
+
+// MarshalMsg implements msgp.Marshaler
+func (z *DriveIOStats) MarshalMsg(b []byte) (o []byte, err error) {
+	o = msgp.Require(b, z.Msgsize())
+	// map header, size 13
+	// string "ReadOps"
+	o = append(o, 0x8d, 0xa7, 0x52, 0x65, 0x61, 0x64, 0x4f, 0x70, 0x73)
+	o = msgp.AppendUint64(o, z.ReadOps)
+	// string "WriteOps"
+	o = append(o, 0xa8, 0x57, 0x72, 0x69, 0x74, 0x65, 0x4f, 0x70, 0x73)
+	o = msgp.AppendUint64(o, z.WriteOps)
+	// string "ReadIOPS"
+	o = append(o, 0xa8, 0x52, 0x65, 0x61, 0x64, 0x49, 0x4f, 0x50, 0x53)
+	o = msgp.AppendFloat64(o, z.ReadIOPS)
+	// string "WriteIOPS"
+	o = append(o, 0xa9, 0x57, 0x72, 0x69, 0x74, 0x65, 0x49, 0x4f, 0x50, 0x53)
+	o = msgp.AppendFloat64(o, z.WriteIOPS)
+	// string "ReadLatencyP50"
+	o = append(o, 0xae, 0x52, 0x65, 0x61, 0x64, 0x4c, 0x61, 0x74, 0x65, 0x6e, 0x63, 0x79, 0x50, 0x35, 0x30)
+	o = msgp.AppendUint64(o, z.ReadLatencyP50)
+	// string "ReadLatencyP90"
+	o = append(o, 0xae, 0x52, 0x65, 0x61, 0x64, 0x4c, 0x61, 0x74, 0x65, 0x6e, 0x63, 0x79, 0x50, 0x39, 0x30)
+	o = msgp.AppendUint64(o, z.ReadLatencyP90)
+	// string "ReadLatencyP99"
+	o = append(o, 0xae, 0x52, 0x65, 0x61, 0x64, 0x4c, 0x61, 0x74, 0x65, 0x6e, 0x63, 0x79, 0x50, 0x39, 0x39)
+	o = msgp.AppendUint64(o, z.ReadLatencyP99)
+	// string "WriteLatencyP50"
+	o = append(o, 0xaf, 0x57, 0x72, 0x69, 0x74, 0x65, 0x4c, 0x61, 0x74, 0x65, 0x6e, 0x63, 0x79, 0x50, 0x35, 0x30)
+	o = msgp.AppendUint64(o, z.WriteLatencyP50)
+	// string "WriteLatencyP90"
+	o = append(o, 0xaf, 0x57, 0x72, 0x69, 0x74, 0x65, 0x4c, 0x61, 0x74, 0x65, 0x6e, 0x63, 0x79, 0x50, 0x39, 0x30)
+	o = msgp.AppendUint64(o, z.WriteLatencyP90)
+	// string "WriteLatencyP99"
+	o = append(o, 0xaf, 0x57, 0x72, 0x69, 0x74, 0x65, 0x4c, 0x61, 0x74, 0x65, 0x6e, 0x63, 0x79, 0x50, 0x39, 0x39)
+	o = msgp.AppendUint64(o, z.WriteLatencyP99)
+	// string "QueueDepth"
+	o = append(o, 0xaa, 0x51, 0x75, 0x65, 0x75, 0x65, 0x44, 0x65, 0x70, 0x74, 0x68)
+	o = msgp.AppendInt32(o, z.QueueDepth)
+	// string "Errors"
+	o = append(o, 0xa6, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x73)
+	o = msgp.AppendUint64(o, z.Errors)
+	// string "Timeouts"
+	o = append(o, 0xa8, 0x54, 0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74, 0x73)
+	o = msgp.AppendUint64(o, z.Timeouts)
+	return
+}
+
+// UnmarshalMsg implements msgp.Unmarshaler
+func (z *DriveIOStats) UnmarshalMsg(bts []byte) (o []byte, err error) {
+	var field []byte
+	_ = field
+	var zb0001 uint32
+	zb0001, bts, err = msgp.ReadMapHeaderBytes(bts)
+	if err != nil {
+		err = msgp.WrapError(err)
+		return
+	}
+	for zb0001 > 0 {
+		zb0001--
+		field, bts, err = msgp.ReadMapKeyZC(bts)
+		if err != nil {
+			err = msgp.WrapError(err)
+			return
+		}
+		switch msgp.UnsafeString(field) {
+		case "ReadOps":
+			z.ReadOps, bts, err = msgp.ReadUint64Bytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "ReadOps")
+				return
+			}
+		case "WriteOps":
+			z.WriteOps, bts, err = msgp.ReadUint64Bytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "WriteOps")
+				return
+			}
+		case "ReadIOPS":
+			z.ReadIOPS, bts, err = msgp.ReadFloat64Bytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "ReadIOPS")
+				return
+			}
+		case "WriteIOPS":
+			z.WriteIOPS, bts, err = msgp.ReadFloat64Bytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "WriteIOPS")
+				return
+			}
+		case "ReadLatencyP50":
+			z.ReadLatencyP50, bts, err = msgp.ReadUint64Bytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "ReadLatencyP50")
+				return
+			}
+		case "ReadLatencyP90":
+			z.ReadLatencyP90, bts, err = msgp.ReadUint64Bytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "ReadLatencyP90")
+				return
+			}
+		case "ReadLatencyP99":
+			z.ReadLatencyP99, bts, err = msgp.ReadUint64Bytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "ReadLatencyP99")
+				return
+			}
+		case "WriteLatencyP50":
+			z.WriteLatencyP50, bts, err = msgp.ReadUint64Bytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "WriteLatencyP50")
+				return
+			}
+		case "WriteLatencyP90":
+			z.WriteLatencyP90, bts, err = msgp.ReadUint64Bytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "WriteLatencyP90")
+				return
+			}
+		case "WriteLatencyP99":
+			z.WriteLatencyP99, bts, err = msgp.ReadUint64Bytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "WriteLatencyP99")
+				return
+			}
+		case "QueueDepth":
+			z.QueueDepth, bts, err = msgp.ReadInt32Bytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "QueueDepth")
+				return
+			}
+		case "Errors":
+			z.Errors, bts, err = msgp.ReadUint64Bytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "Errors")
+				return
+			}
+		case "Timeouts":
+			z.Timeouts, bts, err = msgp.ReadUint64Bytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "Timeouts")
+				return
+			}
+		default:
+			bts, err = msgp.Skip(bts)
+			if err != nil {
+				err = msgp.WrapError(err)
+				return
+			}
+		}
+	}
+	o = bts
+	return
+}
+
+// Msgsize returns an upper bound estimate of the number of bytes occupied by the serialized message
+func (z *DriveIOStats) Msgsize() (s int) {
+	s = 1 + 8 + msgp.Uint64Size + 9 + msgp.Uint64Size + 9 + msgp.Float64Size + 10 + msgp.Float64Size + 15 + msgp.Uint64Size + 15 + msgp.Uint64Size + 15 + msgp.Uint64Size + 16 + msgp.Uint64Size + 16 + msgp.Uint64Size + 16 + msgp.Uint64Size + 11 + msgp.Int32Size + 7 + msgp.Uint64Size + 9 + msgp.Uint64Size
 	return
 }
 
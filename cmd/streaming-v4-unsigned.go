@@ -0,0 +1,149 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"bufio"
+	"bytes"
+	"io"
+	"net/http"
+	"net/textproto"
+
+	xhttp "github.com/minio/minio/internal/http"
+)
+
+// http Header "x-amz-content-sha256" == "STREAMING-UNSIGNED-PAYLOAD-TRAILER"
+// indicates an unsigned aws-chunked payload followed by trailing headers,
+// usually carrying an additional checksum of the content.
+const unsignedPayloadTrailer = "STREAMING-UNSIGNED-PAYLOAD-TRAILER"
+
+// isRequestUnsignedTrailer returns true if the payload of r
+// is aws-chunked with trailing headers.
+func isRequestUnsignedTrailer(r *http.Request) bool {
+	return r.Header.Get(xhttp.AmzContentSha256) == unsignedPayloadTrailer
+}
+
+// newUnsignedV4ChunkedReader returns a reader decoding the unsigned
+// aws-chunked payload of req. The trailing headers are parsed into
+// req.Trailer before the last byte of content is returned, so readers
+// limited to the decoded content length see them too.
+func newUnsignedV4ChunkedReader(req *http.Request) io.ReadCloser {
+	req.Trailer = make(http.Header)
+	return &s3UnsignedChunkedReader{
+		reader:  bufio.NewReader(req.Body),
+		trailer: req.Trailer,
+	}
+}
+
+// s3UnsignedChunkedReader decodes an unsigned aws-chunked payload:
+//
+//	<chunk-size-as-hex> + "\r\n" + <payload> + "\r\n"
+//
+// terminated by a 0-sized chunk and the trailing headers:
+//
+//	"0\r\n" + <header> + ":" + <value> + "\r\n" + ... + "\r\n"
+type s3UnsignedChunkedReader struct {
+	reader  *bufio.Reader
+	trailer http.Header
+
+	remaining int64 // Unread bytes of the current chunk.
+	started   bool
+	err       error
+}
+
+func (cr *s3UnsignedChunkedReader) Close() (err error) {
+	return nil
+}
+
+// Read - implements `io.Reader`, which transparently decodes
+// the incoming unsigned aws-chunked payload.
+func (cr *s3UnsignedChunkedReader) Read(buf []byte) (n int, err error) {
+	if !cr.started {
+		cr.started = true
+		cr.err = cr.nextChunk()
+	}
+	for n < len(buf) && cr.err == nil {
+		p := buf[n:]
+		if int64(len(p)) > cr.remaining {
+			p = p[:cr.remaining]
+		}
+		m, err := io.ReadFull(cr.reader, p)
+		n += m
+		cr.remaining -= int64(m)
+		if err != nil {
+			if err == io.EOF {
+				err = io.ErrUnexpectedEOF
+			}
+			cr.err = err
+			break
+		}
+		if cr.remaining == 0 {
+			if cr.err = readCRLF(cr.reader); cr.err == nil {
+				cr.err = cr.nextChunk()
+			}
+		}
+	}
+	if n > 0 && cr.err == io.EOF {
+		return n, nil
+	}
+	return n, cr.err
+}
+
+// nextChunk reads the size of the next chunk. After the last
+// chunk it reads the trailing headers and returns io.EOF.
+func (cr *s3UnsignedChunkedReader) nextChunk() error {
+	line, err := cr.reader.ReadSlice('\n')
+	if err != nil {
+		if err == io.EOF {
+			err = io.ErrUnexpectedEOF
+		} else if err == bufio.ErrBufferFull {
+			err = errLineTooLong
+		}
+		return err
+	}
+	if len(line) >= maxLineLength {
+		return errLineTooLong
+	}
+	line = trimTrailingWhitespace(line)
+	if i := bytes.IndexByte(line, ';'); i >= 0 {
+		line = line[:i] // Ignore chunk extensions.
+	}
+	size, err := parseHexUint(line)
+	if err != nil || len(line) == 0 {
+		return errMalformedEncoding
+	}
+	if size > maxChunkSize {
+		return errChunkTooBig
+	}
+	cr.remaining = int64(size)
+	if size > 0 {
+		return nil
+	}
+
+	trailer, err := textproto.NewReader(cr.reader).ReadMIMEHeader()
+	if err != nil {
+		if err == io.EOF {
+			err = io.ErrUnexpectedEOF
+		}
+		return err
+	}
+	for k, v := range trailer {
+		cr.trailer[k] = v
+	}
+	return io.EOF
+}
@@ -48,6 +48,7 @@ const (
 	stsPolicy                 = "Policy"
 	stsToken                  = "Token"
 	stsRoleArn                = "RoleArn"
+	stsProviderID             = "ProviderId"
 	stsWebIdentityToken       = "WebIdentityToken"
 	stsWebIdentityAccessToken = "WebIdentityAccessToken" // only valid if UserInfo is enabled.
 	stsDurationSeconds        = "DurationSeconds"
@@ -73,6 +74,12 @@ const (
 	// JWT claim to check the parent user
 	parentClaim = "parent"
 
+	// JWT claim to check the expiry of a service account
+	svcExpiryClaim = "sa-expiry"
+
+	// JWT claim holding the session tags taken from OpenID claims
+	sessionTagsClaim = "sessionTags"
+
 	// LDAP claim keys
 	ldapUser  = "ldapUser"
 	ldapUserN = "ldapUsername"
@@ -344,13 +351,23 @@ func (sts *stsAPIHandlers) AssumeRoleWithSSO(w http.ResponseWriter, r *http.Requ
 	if globalIAMSys.HasRolePolicy() {
 		var err error
 		roleArnStr := r.Form.Get(stsRoleArn)
-		roleArn, _, err = globalIAMSys.GetRolePolicy(roleArnStr)
-		if err != nil {
-			writeSTSErrorResponse(ctx, w, true, ErrSTSInvalidParameterValue,
-				fmt.Errorf("Error processing %s parameter: %v", stsRoleArn, err))
-			return
+		if roleArnStr == "" {
+			// With several OpenID providers configured, the provider
+			// is selected by the ProviderId hint or the token issuer.
+			roleArn, err = globalOpenIDConfig.LookupRoleArn(r.Form.Get(stsProviderID), token)
+			if err != nil {
+				writeSTSErrorResponse(ctx, w, true, ErrSTSInvalidParameterValue,
+					fmt.Errorf("Error processing %s parameter: %v", stsProviderID, err))
+				return
+			}
+		} else {
+			roleArn, _, err = globalIAMSys.GetRolePolicy(roleArnStr)
+			if err != nil {
+				writeSTSErrorResponse(ctx, w, true, ErrSTSInvalidParameterValue,
+					fmt.Errorf("Error processing %s parameter: %v", stsRoleArn, err))
+				return
+			}
 		}
-
 	}
 
 	// Validate JWT; check clientID in claims matches the one associated with the roleArn
@@ -373,8 +390,14 @@ func (sts *stsAPIHandlers) AssumeRoleWithSSO(w http.ResponseWriter, r *http.Requ
 		return
 	}
 
+	// Selected claims are propagated as session tags, usable as
+	// policy variables even when the claim has another name.
+	if tags := globalOpenIDConfig.GetSessionTags(roleArn, m); len(tags) > 0 {
+		m[sessionTagsClaim] = tags
+	}
+
 	var policyName string
-	if globalIAMSys.HasRolePolicy() {
+	if roleArn != openid.DummyRoleARN {
 		// If roleArn is used, we set it as a claim, and use the
 		// associated policy when credentials are used.
 		m[roleArnClaim] = roleArn.String()
@@ -718,11 +741,15 @@ func (sts *stsAPIHandlers) AssumeRoleWithCertificate(w http.ResponseWriter, r *h
 
 	certificate := r.TLS.PeerCertificates[0]
 	if !globalSTSTLSConfig.InsecureSkipVerify { // Verify whether the client certificate has been issued by a trusted CA.
+		roots := globalRootCAs
+		if globalSTSTLSConfig.RootCAs != nil {
+			roots = globalSTSTLSConfig.RootCAs
+		}
 		_, err := certificate.Verify(x509.VerifyOptions{
 			KeyUsages: []x509.ExtKeyUsage{
 				x509.ExtKeyUsageClientAuth,
 			},
-			Roots: globalRootCAs,
+			Roots: roots,
 		})
 		if err != nil {
 			writeSTSErrorResponse(ctx, w, true, ErrSTSInvalidClientCertificate, err)
@@ -755,17 +782,26 @@ func (sts *stsAPIHandlers) AssumeRoleWithCertificate(w http.ResponseWriter, r *h
 		}
 	}
 
-	// We map the X.509 subject common name to the policy. So, a client
-	// with the common name "foo" will be associated with the policy "foo".
-	// Other mapping functions - e.g. public-key hash based mapping - are
-	// possible but not implemented.
-	//
-	// Group mapping is not possible with standard X.509 certificates.
+	// The subject common name identifies the client, service
+	// accounts are associated to it.
 	if certificate.Subject.CommonName == "" {
 		writeSTSErrorResponse(ctx, w, true, ErrSTSMissingParameter, errors.New("certificate subject CN cannot be empty"))
 		return
 	}
 
+	// We map the values of the configured certificate attribute to
+	// policies. By default, a client with the common name "foo" will
+	// be associated with the policy "foo". A multi-valued attribute,
+	// e.g. the organizational units, maps to all of its values.
+	//
+	// Group mapping is not possible with standard X.509 certificates.
+	policies := globalSTSTLSConfig.PolicyNames(certificate)
+	if len(policies) == 0 {
+		writeSTSErrorResponse(ctx, w, true, ErrSTSMissingParameter,
+			fmt.Errorf("certificate has no '%s' attribute to map to a policy", globalSTSTLSConfig.PolicyAttribute))
+		return
+	}
+
 	expiry, err := globalSTSTLSConfig.GetExpiryDuration(r.Form.Get(stsDurationSeconds))
 	if err != nil {
 		writeSTSErrorResponse(ctx, w, true, ErrSTSMissingParameter, err)
@@ -796,7 +832,7 @@ func (sts *stsAPIHandlers) AssumeRoleWithCertificate(w http.ResponseWriter, r *h
 	}
 
 	tmpCredentials.ParentUser = parentUser
-	policyName := certificate.Subject.CommonName
+	policyName := strings.Join(policies, ",")
 	err = globalIAMSys.SetTempUser(ctx, tmpCredentials.AccessKey, tmpCredentials, policyName)
 	if err != nil {
 		writeSTSErrorResponse(ctx, w, true, ErrSTSInternalError, err)
@@ -513,6 +513,10 @@ func (testServer TestServer) Stop() {
 	testServer.cancel()
 	testServer.Server.Close()
 	testServer.Obj.Shutdown(context.Background())
+	// Do not leave the object layer of a stopped server to the next tests.
+	if newObjectLayerFn() == testServer.Obj {
+		setObjectLayer(nil)
+	}
 	os.RemoveAll(testServer.Root)
 	for _, ep := range testServer.Disks {
 		for _, disk := range ep.Endpoints {
@@ -521,6 +525,19 @@ func (testServer TestServer) Stop() {
 	}
 }
 
+// initAllTestSubsystems initializes all the subsystems with
+// initAllSubsystems and restores the object layer and the IAM and
+// notification systems once t is done, so that the systems set up
+// with the backend of t do not leak into the next tests.
+func initAllTestSubsystems(t *testing.T) {
+	objAPI, iamSys, notificationSys := newObjectLayerFn(), globalIAMSys, globalNotificationSys
+	t.Cleanup(func() {
+		setObjectLayer(objAPI)
+		globalIAMSys, globalNotificationSys = iamSys, notificationSys
+	})
+	initAllSubsystems()
+}
+
 // Truncate request to simulate unexpected EOF for a request signed using streaming signature v4.
 func truncateChunkByHalfSigv4(req *http.Request) (*http.Request, error) {
 	bufReader := bufio.NewReader(req.Body)
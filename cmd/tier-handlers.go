@@ -67,6 +67,18 @@ var (
 		Message:    "Cannot use reserved tier name",
 		StatusCode: http.StatusBadRequest,
 	}
+	// error returned when a filesystem tier path is not usable.
+	errTierFSInvalidPath = AdminError{
+		Code:       "XMinioAdminTierFSInvalidPath",
+		Message:    "Filesystem tier path must be an absolute path to an existing directory",
+		StatusCode: http.StatusBadRequest,
+	}
+	// error returned when editing the credentials of a filesystem tier.
+	errTierFSNoCredentials = AdminError{
+		Code:       "XMinioAdminTierFSNoCredentials",
+		Message:    "Filesystem tiers have no credentials to edit",
+		StatusCode: http.StatusBadRequest,
+	}
 )
 
 func (api adminAPIHandlers) AddTierHandler(w http.ResponseWriter, r *http.Request) {
@@ -127,6 +139,89 @@ func (api adminAPIHandlers) AddTierHandler(w http.ResponseWriter, r *http.Reques
 	writeSuccessNoContent(w)
 }
 
+// AddFSTierHandler - PUT /minio/admin/v3/tier-fs
+// ----------
+// Adds a remote tier on a filesystem mounted at the same path on all the
+// nodes, e.g a NFS export. It can be used as any other remote tier in
+// lifecycle transition rules.
+func (api adminAPIHandlers) AddFSTierHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "AddFSTier")
+
+	defer logger.AuditLog(ctx, w, r, mustGetClaimsFromToken(r))
+
+	if globalIsGateway {
+		writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErr(ErrNotImplemented), r.URL)
+		return
+	}
+
+	objAPI, _ := validateAdminReq(ctx, w, r, iampolicy.SetTierAction)
+	if objAPI == nil || globalNotificationSys == nil || globalTierConfigMgr == nil {
+		writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErr(ErrServerNotInitialized), r.URL)
+		return
+	}
+
+	var cfg TierFS
+	json := jsoniter.ConfigCompatibleWithStandardLibrary
+	if err := json.NewDecoder(io.LimitReader(r.Body, r.ContentLength)).Decode(&cfg); err != nil {
+		writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErrWithErr(ErrAdminConfigBadJSON, err), r.URL)
+		return
+	}
+
+	// Disallow remote tiers with internal storage class names
+	switch cfg.Name {
+	case storageclass.STANDARD, storageclass.RRS:
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, errTierReservedName), r.URL)
+		return
+	}
+	// Refresh from the disk in case we had missed notifications about edits from peers.
+	if err := globalTierConfigMgr.Reload(ctx, objAPI); err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+
+	if err := globalTierConfigMgr.AddFS(ctx, cfg); err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+
+	if err := globalTierConfigMgr.Save(ctx, objAPI); err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+	globalNotificationSys.LoadTransitionTierConfig(ctx)
+
+	writeSuccessNoContent(w)
+}
+
+// ListFSTierHandler - GET /minio/admin/v3/tier-fs
+// ----------
+// Lists the filesystem tiers, which are not part of the remote tiers
+// listed by ListTierHandler.
+func (api adminAPIHandlers) ListFSTierHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "ListFSTier")
+
+	defer logger.AuditLog(ctx, w, r, mustGetClaimsFromToken(r))
+
+	if globalIsGateway {
+		writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErr(ErrNotImplemented), r.URL)
+		return
+	}
+
+	objAPI, _ := validateAdminReq(ctx, w, r, iampolicy.ListTierAction)
+	if objAPI == nil || globalNotificationSys == nil || globalTierConfigMgr == nil {
+		writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErr(ErrServerNotInitialized), r.URL)
+		return
+	}
+
+	data, err := json.Marshal(globalTierConfigMgr.ListFSTiers())
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+
+	writeSuccessResponseJSON(w, data)
+}
+
 func (api adminAPIHandlers) ListTierHandler(w http.ResponseWriter, r *http.Request) {
 	ctx := newContext(r, w, "ListTier")
 
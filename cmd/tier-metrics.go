@@ -0,0 +1,136 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"sort"
+	"sync"
+	"sync/atomic"
+)
+
+// tierStatsCounters holds the ILM counters and gauges of a remote tier
+// since the server started.
+type tierStatsCounters struct {
+	transitioned      uint64
+	transitionedBytes uint64
+	transitionFailed  uint64
+	activeTransitions int64
+	pendingRestores   int64
+}
+
+// tierMetrics tracks transitions to and restores from remote tiers on
+// this node, by tier name.
+type tierMetrics struct {
+	mu    sync.RWMutex
+	tiers map[string]*tierStatsCounters
+}
+
+var globalTierMetrics = newTierMetrics()
+
+func newTierMetrics() *tierMetrics {
+	return &tierMetrics{
+		tiers: make(map[string]*tierStatsCounters),
+	}
+}
+
+func (t *tierMetrics) get(tier string) *tierStatsCounters {
+	t.mu.RLock()
+	c, ok := t.tiers[tier]
+	t.mu.RUnlock()
+	if ok {
+		return c
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if c, ok = t.tiers[tier]; !ok {
+		c = &tierStatsCounters{}
+		t.tiers[tier] = c
+	}
+	return c
+}
+
+// transitionStarted records a transition in progress to tier. The
+// returned function must be called with the outcome once it is done.
+func (t *tierMetrics) transitionStarted(tier string) func(size int64, err error) {
+	c := t.get(tier)
+	atomic.AddInt64(&c.activeTransitions, 1)
+	return func(size int64, err error) {
+		atomic.AddInt64(&c.activeTransitions, -1)
+		if err != nil {
+			atomic.AddUint64(&c.transitionFailed, 1)
+			return
+		}
+		atomic.AddUint64(&c.transitioned, 1)
+		atomic.AddUint64(&c.transitionedBytes, uint64(size))
+	}
+}
+
+// restoreStarted records a restore pending from tier. The returned
+// function must be called once the restore is done.
+func (t *tierMetrics) restoreStarted(tier string) func() {
+	c := t.get(tier)
+	atomic.AddInt64(&c.pendingRestores, 1)
+	return func() {
+		atomic.AddInt64(&c.pendingRestores, -1)
+	}
+}
+
+// metrics returns the per tier metrics of this node.
+func (t *tierMetrics) metrics() (metrics []Metric) {
+	t.mu.RLock()
+	tiers := make([]string, 0, len(t.tiers))
+	for tier := range t.tiers {
+		tiers = append(tiers, tier)
+	}
+	t.mu.RUnlock()
+	sort.Strings(tiers)
+
+	for _, tier := range tiers {
+		c := t.get(tier)
+		labels := map[string]string{"tier": tier}
+		metrics = append(metrics,
+			Metric{
+				Description:    getTierTransitionedMD(),
+				Value:          float64(atomic.LoadUint64(&c.transitioned)),
+				VariableLabels: labels,
+			},
+			Metric{
+				Description:    getTierTransitionedBytesMD(),
+				Value:          float64(atomic.LoadUint64(&c.transitionedBytes)),
+				VariableLabels: labels,
+			},
+			Metric{
+				Description:    getTierTransitionFailedMD(),
+				Value:          float64(atomic.LoadUint64(&c.transitionFailed)),
+				VariableLabels: labels,
+			},
+			Metric{
+				Description:    getTierTransitionActiveMD(),
+				Value:          float64(atomic.LoadInt64(&c.activeTransitions)),
+				VariableLabels: labels,
+			},
+			Metric{
+				Description:    getTierRestorePendingMD(),
+				Value:          float64(atomic.LoadInt64(&c.pendingRestores)),
+				VariableLabels: labels,
+			},
+		)
+	}
+	return metrics
+}
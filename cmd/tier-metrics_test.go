@@ -0,0 +1,74 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestTierMetrics(t *testing.T) {
+	m := newTierMetrics()
+
+	done := m.transitionStarted("WARM")
+	restored := m.restoreStarted("WARM")
+	m.transitionStarted("COLD")(0, errors.New("transition failed"))
+
+	expected := map[string]map[MetricName]float64{
+		"COLD": {
+			tierTransitionedTotal:      0,
+			tierTransitionedBytesTotal: 0,
+			tierTransitionFailedTotal:  1,
+			tierTransitionActiveTasks:  0,
+			tierRestorePendingTasks:    0,
+		},
+		"WARM": {
+			tierTransitionedTotal:      0,
+			tierTransitionedBytesTotal: 0,
+			tierTransitionFailedTotal:  0,
+			tierTransitionActiveTasks:  1,
+			tierRestorePendingTasks:    1,
+		},
+	}
+	checkTierMetrics(t, m, expected)
+
+	done(100, nil)
+	restored()
+	expected["WARM"] = map[MetricName]float64{
+		tierTransitionedTotal:      1,
+		tierTransitionedBytesTotal: 100,
+		tierTransitionFailedTotal:  0,
+		tierTransitionActiveTasks:  0,
+		tierRestorePendingTasks:    0,
+	}
+	checkTierMetrics(t, m, expected)
+}
+
+func checkTierMetrics(t *testing.T, m *tierMetrics, expected map[string]map[MetricName]float64) {
+	t.Helper()
+	metrics := m.metrics()
+	if len(metrics) != 5*len(expected) {
+		t.Fatalf("expected %d metrics, got %d", 5*len(expected), len(metrics))
+	}
+	for _, metric := range metrics {
+		tier := metric.VariableLabels["tier"]
+		if v := expected[tier][metric.Description.Name]; v != metric.Value {
+			t.Errorf("%s{tier=%q}: expected %v, got %v", metric.Description.Name, tier, v, metric.Value)
+		}
+	}
+}
@@ -25,6 +25,7 @@ import (
 	"errors"
 	"fmt"
 	"path"
+	"sort"
 	"strings"
 	"sync"
 
@@ -61,6 +62,18 @@ type TierConfigMgr struct {
 	drivercache  map[string]WarmBackend `msg:"-"`
 
 	Tiers map[string]madmin.TierConfig `json:"tiers"`
+
+	// FSTiers holds the remote tiers on a mounted filesystem, which
+	// have no madmin.TierType of their own.
+	FSTiers map[string]TierFS `json:"fsTiers" msg:"FSTiers,omitempty"`
+}
+
+// TierFS represents the configuration of a remote tier on a filesystem
+// mounted at the same path on all the nodes of the deployment.
+type TierFS struct {
+	Name   string `json:"name"`
+	Path   string `json:"path"`
+	Prefix string `json:"prefix,omitempty"`
 }
 
 // IsTierValid returns true if there exists a remote tier by name tierName,
@@ -80,6 +93,9 @@ func (config *TierConfigMgr) isTierNameInUse(tierName string) (madmin.TierType,
 	if t, ok := config.Tiers[tierName]; ok {
 		return t.Type, true
 	}
+	if _, ok := config.FSTiers[tierName]; ok {
+		return madmin.Unsupported, true
+	}
 	return madmin.Unsupported, false
 }
 
@@ -118,6 +134,44 @@ func (config *TierConfigMgr) Add(ctx context.Context, tier madmin.TierConfig) er
 	return nil
 }
 
+// AddFS adds a filesystem tier to config if it passes all validations.
+func (config *TierConfigMgr) AddFS(ctx context.Context, tier TierFS) error {
+	config.Lock()
+	defer config.Unlock()
+
+	tierName := tier.Name
+	if tierName == "" {
+		return madmin.ErrTierNameEmpty
+	}
+	if tierName != strings.ToUpper(tierName) {
+		return errTierNameNotUppercase
+	}
+
+	if _, exists := config.isTierNameInUse(tierName); exists {
+		return errTierAlreadyExists
+	}
+
+	d, err := newWarmBackendFS(tier)
+	if err != nil {
+		return err
+	}
+	if err = checkWarmBackend(ctx, d); err != nil {
+		return err
+	}
+	inUse, err := d.InUse(ctx)
+	if err != nil {
+		return err
+	}
+	if inUse {
+		return errTierBackendInUse
+	}
+
+	config.FSTiers[tierName] = tier
+	config.drivercache[tierName] = d
+
+	return nil
+}
+
 // Remove removes tier if it is empty.
 func (config *TierConfigMgr) Remove(ctx context.Context, tier string) error {
 	d, err := config.getDriver(tier)
@@ -131,6 +185,7 @@ func (config *TierConfigMgr) Remove(ctx context.Context, tier string) error {
 	} else {
 		config.Lock()
 		delete(config.Tiers, tier)
+		delete(config.FSTiers, tier)
 		delete(config.drivercache, tier)
 		config.Unlock()
 	}
@@ -152,7 +207,7 @@ func (config *TierConfigMgr) Empty() bool {
 	if config == nil {
 		return true
 	}
-	return len(config.ListTiers()) == 0
+	return len(config.ListTiers()) == 0 && len(config.ListFSTiers()) == 0
 }
 
 // ListTiers lists remote tiers configured in this deployment.
@@ -170,6 +225,21 @@ func (config *TierConfigMgr) ListTiers() []madmin.TierConfig {
 	return tierCfgs
 }
 
+// ListFSTiers lists the filesystem tiers configured in this deployment.
+func (config *TierConfigMgr) ListFSTiers() []TierFS {
+	config.RLock()
+	defer config.RUnlock()
+
+	tierCfgs := make([]TierFS, 0, len(config.FSTiers))
+	for _, tier := range config.FSTiers {
+		tierCfgs = append(tierCfgs, tier)
+	}
+	sort.Slice(tierCfgs, func(i, j int) bool {
+		return tierCfgs[i].Name < tierCfgs[j].Name
+	})
+	return tierCfgs
+}
+
 // Edit replaces the credentials of the remote tier specified by tierName with creds.
 func (config *TierConfigMgr) Edit(ctx context.Context, tierName string, creds madmin.TierCreds) error {
 	config.Lock()
@@ -180,6 +250,9 @@ func (config *TierConfigMgr) Edit(ctx context.Context, tierName string, creds ma
 	if !exists {
 		return errTierNotFound
 	}
+	if _, ok := config.FSTiers[tierName]; ok {
+		return errTierFSNoCredentials
+	}
 
 	cfg := config.Tiers[tierName]
 	switch tierType {
@@ -249,6 +322,13 @@ func (config *TierConfigMgr) getDriver(tierName string) (d WarmBackend, err erro
 	}
 
 	// Initialize driver from tier config matching tierName
+	if t, ok := config.FSTiers[tierName]; ok {
+		if d, err = newWarmBackendFS(t); err != nil {
+			return nil, err
+		}
+		config.drivercache[tierName] = d
+		return d, nil
+	}
 	t, ok := config.Tiers[tierName]
 	if !ok {
 		return nil, errTierNotFound
@@ -287,7 +367,7 @@ func (config *TierConfigMgr) configReader() (*PutObjReader, *ObjectOptions, erro
 
 	// Encrypt json encoded tier configurations
 	metadata := make(map[string]string)
-	encBr, oek, err := newEncryptReader(hr, crypto.S3, "", nil, minioMetaBucket, tierConfigPath, metadata, kms.Context{})
+	encBr, oek, err := newEncryptReader(GlobalContext, hr, crypto.S3, "", nil, minioMetaBucket, tierConfigPath, metadata, kms.Context{})
 	if err != nil {
 		return nil, nil, err
 	}
@@ -339,6 +419,12 @@ func (config *TierConfigMgr) Reload(ctx context.Context, objAPI ObjectLayer) err
 	for tier, cfg := range newConfig.Tiers {
 		config.Tiers[tier] = cfg
 	}
+	for k := range config.FSTiers {
+		delete(config.FSTiers, k)
+	}
+	for tier, cfg := range newConfig.FSTiers {
+		config.FSTiers[tier] = cfg
+	}
 
 	return nil
 }
@@ -363,6 +449,7 @@ func NewTierConfigMgr() *TierConfigMgr {
 	return &TierConfigMgr{
 		drivercache: make(map[string]WarmBackend),
 		Tiers:       make(map[string]madmin.TierConfig),
+		FSTiers:     make(map[string]TierFS),
 	}
 }
 
@@ -410,6 +497,9 @@ func (config *TierConfigMgr) Reset() {
 	for k := range config.Tiers {
 		delete(config.Tiers, k)
 	}
+	for k := range config.FSTiers {
+		delete(config.FSTiers, k)
+	}
 	config.Unlock()
 }
 
@@ -0,0 +1,171 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/minio/madmin-go"
+)
+
+// traceOptions are the options of a trace subscription, the
+// filters are applied by every node before sending entries.
+type traceOptions struct {
+	madmin.ServiceTraceOpts
+
+	// Bucket and Prefix restrict the trace to the S3 requests
+	// and storage calls of objects with the prefix in the bucket.
+	Bucket string
+	Prefix string
+
+	// StatusCodes restricts the trace to HTTP requests
+	// answered with a status code within one of the ranges.
+	StatusCodes statusCodeRanges
+}
+
+// statusCodeRange is an inclusive range of HTTP status codes.
+type statusCodeRange struct {
+	Min, Max int
+}
+
+type statusCodeRanges []statusCodeRange
+
+// parseStatusCodeRanges parses a comma separated list of status
+// codes, ranges like 500-504 and classes like 5xx.
+func parseStatusCodeRanges(s string) (statusCodeRanges, error) {
+	if s == "" {
+		return nil, nil
+	}
+	var ranges statusCodeRanges
+	for _, v := range strings.Split(s, ",") {
+		v = strings.TrimSpace(v)
+		var (
+			r   statusCodeRange
+			err error
+		)
+		switch {
+		case len(v) == 3 && strings.HasSuffix(strings.ToLower(v), "xx"):
+			r.Min, err = strconv.Atoi(v[:1])
+			r.Min *= 100
+			r.Max = r.Min + 99
+		case strings.Contains(v, "-"):
+			bounds := strings.SplitN(v, "-", 2)
+			if r.Min, err = strconv.Atoi(bounds[0]); err == nil {
+				r.Max, err = strconv.Atoi(bounds[1])
+			}
+		default:
+			r.Min, err = strconv.Atoi(v)
+			r.Max = r.Min
+		}
+		if err != nil || r.Min < 100 || r.Max > 599 || r.Min > r.Max {
+			return nil, fmt.Errorf("invalid status code range '%s'", v)
+		}
+		ranges = append(ranges, r)
+	}
+	return ranges, nil
+}
+
+// String returns the ranges in the format parsed by parseStatusCodeRanges.
+func (ranges statusCodeRanges) String() string {
+	s := make([]string, 0, len(ranges))
+	for _, r := range ranges {
+		if r.Min == r.Max {
+			s = append(s, strconv.Itoa(r.Min))
+		} else {
+			s = append(s, strconv.Itoa(r.Min)+"-"+strconv.Itoa(r.Max))
+		}
+	}
+	return strings.Join(s, ",")
+}
+
+func (ranges statusCodeRanges) contains(statusCode int) bool {
+	for _, r := range ranges {
+		if statusCode >= r.Min && statusCode <= r.Max {
+			return true
+		}
+	}
+	return false
+}
+
+// setFilters validates and sets the bucket, prefix and status code filters.
+func (opts *traceOptions) setFilters(bucket, prefix, statusCodes string) (err error) {
+	if prefix != "" && bucket == "" {
+		return fmt.Errorf("prefix requires a bucket")
+	}
+	opts.Bucket, opts.Prefix = bucket, prefix
+	opts.StatusCodes, err = parseStatusCodeRanges(statusCodes)
+	return err
+}
+
+// matchesFilters returns true if the entry passes the bucket,
+// prefix and status code filters. Entries which cannot be
+// attributed to a bucket or a status code never match
+// such a filter.
+func (opts traceOptions) matchesFilters(trcInfo madmin.TraceInfo) bool {
+	if len(opts.StatusCodes) > 0 {
+		if trcInfo.TraceType != madmin.TraceHTTP || !opts.StatusCodes.contains(trcInfo.RespInfo.StatusCode) {
+			return false
+		}
+	}
+	if opts.Bucket == "" {
+		return true
+	}
+
+	var bucket, object string
+	switch trcInfo.TraceType {
+	case madmin.TraceHTTP:
+		if HasPrefix(trcInfo.ReqInfo.Path, minioReservedBucketPath+SlashSeparator) {
+			return false
+		}
+		p, err := url.PathUnescape(trcInfo.ReqInfo.Path)
+		if err != nil {
+			return false
+		}
+		resource, err := getResource(p, trcInfo.ReqInfo.Headers.Get("Host"), globalDomainNames)
+		if err != nil {
+			return false
+		}
+		bucket, object = path2BucketObject(resource)
+		if object == "" && opts.Prefix != "" {
+			// Listings are matched by their prefix parameter.
+			if q, err := url.ParseQuery(trcInfo.ReqInfo.RawQuery); err == nil {
+				object = q.Get("prefix")
+			}
+		}
+	case madmin.TraceStorage:
+		// Storage calls are traced as "volume path".
+		paths := strings.SplitN(trcInfo.StorageStats.Path, " ", 2)
+		bucket = paths[0]
+		if len(paths) == 2 {
+			object = paths[1]
+		}
+	default:
+		return false
+	}
+	return bucket == opts.Bucket && strings.HasPrefix(object, opts.Prefix)
+}
+
+// encodeFilters sets the filters as query values of a peer trace request.
+func (opts traceOptions) encodeFilters(values url.Values) {
+	values.Set(peerRESTTraceBucket, opts.Bucket)
+	values.Set(peerRESTTracePrefix, opts.Prefix)
+	values.Set(peerRESTTraceStatus, opts.StatusCodes.String())
+}
@@ -0,0 +1,107 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/minio/madmin-go"
+)
+
+func TestParseStatusCodeRanges(t *testing.T) {
+	testCases := []struct {
+		value    string
+		expected string
+		ok       bool
+	}{
+		{"", "", true},
+		{"404", "404", true},
+		{"5xx", "500-599", true},
+		{"4XX, 503", "400-499,503", true},
+		{"500-504,404", "500-504,404", true},
+		{"504-500", "", false},
+		{"600", "", false},
+		{"x", "", false},
+		{"6xx", "", false},
+	}
+	for i, tc := range testCases {
+		ranges, err := parseStatusCodeRanges(tc.value)
+		if (err == nil) != tc.ok {
+			t.Fatalf("Test %d: expected ok %v, got %v", i+1, tc.ok, err)
+		}
+		if err == nil && ranges.String() != tc.expected {
+			t.Fatalf("Test %d: expected %s, got %s", i+1, tc.expected, ranges.String())
+		}
+	}
+}
+
+func TestTraceFilters(t *testing.T) {
+	httpTrace := func(path, rawQuery string, statusCode int) madmin.TraceInfo {
+		return madmin.TraceInfo{
+			TraceType: madmin.TraceHTTP,
+			ReqInfo:   madmin.TraceRequestInfo{Path: path, RawQuery: rawQuery, Headers: http.Header{}},
+			RespInfo:  madmin.TraceResponseInfo{StatusCode: statusCode},
+		}
+	}
+	storageTrace := func(path string) madmin.TraceInfo {
+		return madmin.TraceInfo{
+			TraceType:    madmin.TraceStorage,
+			StorageStats: madmin.TraceStorageStats{Path: path},
+		}
+	}
+
+	testCases := []struct {
+		bucket, prefix, status string
+		entry                  madmin.TraceInfo
+		matches                bool
+	}{
+		{"", "", "", httpTrace("/minio/peer/v38/trace", "", 200), true},
+		{"tenant", "", "", httpTrace("/tenant/photos/a.jpg", "", 200), true},
+		{"tenant", "", "", httpTrace("/tenant", "", 200), true},
+		{"tenant", "", "", httpTrace("/other/photos/a.jpg", "", 200), false},
+		{"tenant", "", "", httpTrace("/minio/storage/data/v48/readall", "", 200), false},
+		{"tenant", "photos/", "", httpTrace("/tenant/photos/a%20b.jpg", "", 200), true},
+		{"tenant", "photos/", "", httpTrace("/tenant/videos/a.mp4", "", 200), false},
+		{"tenant", "photos/", "", httpTrace("/tenant/", "list-type=2&prefix=photos%2F2022", 200), true},
+		{"tenant", "photos/", "", httpTrace("/tenant/", "list-type=2", 200), false},
+		{"tenant", "photos/", "", storageTrace("tenant photos/a.jpg/xl.meta"), true},
+		{"tenant", "", "", storageTrace(".minio.sys tmp/uuid"), false},
+		{"tenant", "", "", madmin.TraceInfo{TraceType: madmin.TraceOS}, false},
+		{"", "", "5xx", httpTrace("/tenant/a", "", 503), true},
+		{"", "", "5xx", httpTrace("/tenant/a", "", 404), false},
+		{"", "", "404,500-502", httpTrace("/tenant/a", "", 404), true},
+		{"", "", "5xx", storageTrace("tenant a/xl.meta"), false},
+		{"tenant", "", "4xx", httpTrace("/tenant/a", "", 403), true},
+		{"tenant", "", "4xx", httpTrace("/other/a", "", 403), false},
+	}
+	for i, tc := range testCases {
+		var opts traceOptions
+		if err := opts.setFilters(tc.bucket, tc.prefix, tc.status); err != nil {
+			t.Fatalf("Test %d: %v", i+1, err)
+		}
+		if matches := opts.matchesFilters(tc.entry); matches != tc.matches {
+			t.Fatalf("Test %d: expected %v, got %v", i+1, tc.matches, matches)
+		}
+	}
+
+	var opts traceOptions
+	if err := opts.setFilters("", "photos/", ""); err == nil {
+		t.Fatal("expected a prefix without bucket to be rejected")
+	}
+}
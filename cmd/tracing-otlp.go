@@ -0,0 +1,219 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/minio/minio/internal/logger"
+	"github.com/minio/minio/internal/tracing"
+	"github.com/minio/pkg/env"
+	xnet "github.com/minio/pkg/net"
+)
+
+// Environment variables configuring the OTLP trace exporter.
+const (
+	// EnvTracingOTLPEndpoint is the OTLP/HTTP traces endpoint of the
+	// collector, e.g. http://otel-collector:4318/v1/traces, tracing
+	// is disabled if not set.
+	EnvTracingOTLPEndpoint = "MINIO_TRACING_OTLP_ENDPOINT"
+
+	// EnvTracingOTLPSampleRatio is the ratio of the requests without
+	// a traceparent header which are traced.
+	EnvTracingOTLPSampleRatio = "MINIO_TRACING_OTLP_SAMPLE_RATIO"
+
+	// EnvTracingOTLPAuthToken is an optional bearer token sent to the collector.
+	EnvTracingOTLPAuthToken = "MINIO_TRACING_OTLP_AUTH_TOKEN"
+)
+
+const (
+	otlpSpanQueueSize      = 10000
+	otlpSpanBatchSize      = 512
+	otlpSpanExportInterval = 5 * time.Second
+
+	// OTLP STATUS_CODE_ERROR
+	otlpStatusError = 2
+)
+
+// otlpTraceExporter sends the finished spans in batches to an
+// OTLP collector using the OTLP/HTTP JSON encoding.
+type otlpTraceExporter struct {
+	endpoint  string
+	authToken string
+	client    *http.Client
+
+	// finished spans waiting to be exported, spans are
+	// dropped when the collector cannot keep up.
+	spans chan *tracing.Span
+}
+
+// initOTLPTraceExporter enables tracing of S3 requests if configured.
+func initOTLPTraceExporter(ctx context.Context) {
+	endpoint := env.Get(EnvTracingOTLPEndpoint, "")
+	if endpoint == "" {
+		return
+	}
+	u, err := xnet.ParseHTTPURL(endpoint)
+	if err != nil {
+		logger.Fatal(err, "Invalid %s value in environment variable", EnvTracingOTLPEndpoint)
+	}
+	ratio, err := strconv.ParseFloat(env.Get(EnvTracingOTLPSampleRatio, "1"), 64)
+	if err == nil && (ratio < 0 || ratio > 1) {
+		err = fmt.Errorf("sample ratio must be between 0 and 1")
+	}
+	if err != nil {
+		logger.Fatal(err, "Invalid %s value in environment variable", EnvTracingOTLPSampleRatio)
+	}
+
+	exporter := &otlpTraceExporter{
+		endpoint:  u.String(),
+		authToken: env.Get(EnvTracingOTLPAuthToken, ""),
+		client: &http.Client{
+			Transport: NewRemoteTargetHTTPTransport(),
+			Timeout:   otlpSpanExportInterval,
+		},
+		spans: make(chan *tracing.Span, otlpSpanQueueSize),
+	}
+	go exporter.run(ctx)
+	tracing.Enable(exporter.enqueue, ratio)
+}
+
+// enqueue queues a finished span for export without blocking.
+func (e *otlpTraceExporter) enqueue(span *tracing.Span) {
+	select {
+	case e.spans <- span:
+	default:
+	}
+}
+
+// run exports the queued spans once a batch is full or
+// every export interval until ctx is canceled.
+func (e *otlpTraceExporter) run(ctx context.Context) {
+	ticker := time.NewTicker(otlpSpanExportInterval)
+	defer ticker.Stop()
+
+	batch := make([]*tracing.Span, 0, otlpSpanBatchSize)
+	flush := func() {
+		if len(batch) == 0 {
+			return
+		}
+		if err := e.export(ctx, batch); err != nil {
+			logger.LogOnceIf(ctx, fmt.Errorf("Unable to export traces to %s: %w", e.endpoint, err), e.endpoint)
+		}
+		batch = batch[:0]
+	}
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case span := <-e.spans:
+			batch = append(batch, span)
+			if len(batch) == otlpSpanBatchSize {
+				flush()
+			}
+		case <-ticker.C:
+			flush()
+		}
+	}
+}
+
+// export sends spans to the collector.
+func (e *otlpTraceExporter) export(ctx context.Context, spans []*tracing.Span) error {
+	buf, err := json.Marshal(newOTLPTraceRequest(spans))
+	if err != nil {
+		return err
+	}
+	return postOTLP(ctx, e.client, e.endpoint, e.authToken, buf)
+}
+
+// The types below are the subset of the OTLP/HTTP JSON
+// encoding of ExportTraceServiceRequest used by MinIO.
+type otlpTraceRequest struct {
+	ResourceSpans []otlpResourceSpans `json:"resourceSpans"`
+}
+
+type otlpResourceSpans struct {
+	Resource   otlpResource     `json:"resource"`
+	ScopeSpans []otlpScopeSpans `json:"scopeSpans"`
+}
+
+type otlpScopeSpans struct {
+	Scope otlpScope  `json:"scope"`
+	Spans []otlpSpan `json:"spans"`
+}
+
+type otlpSpan struct {
+	TraceID           string         `json:"traceId"`
+	SpanID            string         `json:"spanId"`
+	ParentSpanID      string         `json:"parentSpanId,omitempty"`
+	Name              string         `json:"name"`
+	Kind              int            `json:"kind"`
+	StartTimeUnixNano string         `json:"startTimeUnixNano"`
+	EndTimeUnixNano   string         `json:"endTimeUnixNano"`
+	Attributes        []otlpKeyValue `json:"attributes,omitempty"`
+	Status            *otlpStatus    `json:"status,omitempty"`
+}
+
+type otlpStatus struct {
+	Code    int    `json:"code"`
+	Message string `json:"message,omitempty"`
+}
+
+// newOTLPTraceRequest converts spans to an OTLP export request.
+func newOTLPTraceRequest(spans []*tracing.Span) otlpTraceRequest {
+	scopeSpans := otlpScopeSpans{
+		Scope: otlpScope{Name: "minio", Version: Version},
+		Spans: make([]otlpSpan, 0, len(spans)),
+	}
+	for _, span := range spans {
+		s := otlpSpan{
+			TraceID:           hex.EncodeToString(span.Context.TraceID[:]),
+			SpanID:            hex.EncodeToString(span.Context.SpanID[:]),
+			Name:              span.Name,
+			Kind:              int(span.Kind),
+			StartTimeUnixNano: strconv.FormatInt(span.Start.UnixNano(), 10),
+			EndTimeUnixNano:   strconv.FormatInt(span.End.UnixNano(), 10),
+			Attributes:        otlpAttributes(span.Attributes()),
+		}
+		if span.ParentID != [8]byte{} {
+			s.ParentSpanID = hex.EncodeToString(span.ParentID[:])
+		}
+		if msg := span.Err(); msg != "" {
+			s.Status = &otlpStatus{Code: otlpStatusError, Message: msg}
+		}
+		scopeSpans.Spans = append(scopeSpans.Spans, s)
+	}
+
+	return otlpTraceRequest{
+		ResourceSpans: []otlpResourceSpans{{
+			Resource: otlpResource{
+				Attributes: otlpAttributes(map[string]string{
+					"service.name":        "minio",
+					"service.instance.id": globalLocalNodeName,
+				}),
+			},
+			ScopeSpans: []otlpScopeSpans{scopeSpans},
+		}},
+	}
+}
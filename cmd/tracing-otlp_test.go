@@ -0,0 +1,117 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/minio/minio/internal/tracing"
+)
+
+func TestNewOTLPTraceRequest(t *testing.T) {
+	start := time.Unix(1, 0)
+	root := &tracing.Span{
+		Name:    "s3.putobject",
+		Kind:    tracing.KindServer,
+		Context: tracing.SpanContext{TraceID: [16]byte{1}, SpanID: [8]byte{2}, Sampled: true},
+		Start:   start,
+	}
+	root.SetAttributes("http.method", http.MethodPut)
+	root.Finish(nil)
+	child := &tracing.Span{
+		Name:     "kms.GenerateKey",
+		Kind:     tracing.KindClient,
+		Context:  tracing.SpanContext{TraceID: [16]byte{1}, SpanID: [8]byte{3}, Sampled: true},
+		ParentID: [8]byte{2},
+		Start:    start,
+	}
+	child.Finish(errors.New("key not found"))
+
+	req := newOTLPTraceRequest([]*tracing.Span{root, child})
+	if len(req.ResourceSpans) != 1 || len(req.ResourceSpans[0].ScopeSpans) != 1 {
+		t.Fatalf("unexpected request %+v", req)
+	}
+	spans := req.ResourceSpans[0].ScopeSpans[0].Spans
+	if len(spans) != 2 {
+		t.Fatalf("expected 2 spans, got %d", len(spans))
+	}
+	if spans[0].TraceID != "01000000000000000000000000000000" || spans[0].SpanID != "0200000000000000" {
+		t.Fatalf("unexpected IDs %s %s", spans[0].TraceID, spans[0].SpanID)
+	}
+	if spans[0].ParentSpanID != "" || spans[0].Status != nil || spans[0].Kind != int(tracing.KindServer) {
+		t.Fatalf("unexpected root span %+v", spans[0])
+	}
+	if spans[0].StartTimeUnixNano != "1000000000" {
+		t.Fatalf("unexpected start time %s", spans[0].StartTimeUnixNano)
+	}
+	if len(spans[0].Attributes) != 1 || spans[0].Attributes[0].Key != "http.method" {
+		t.Fatalf("unexpected attributes %+v", spans[0].Attributes)
+	}
+	if spans[1].ParentSpanID != "0200000000000000" {
+		t.Fatalf("unexpected parent span ID %s", spans[1].ParentSpanID)
+	}
+	if spans[1].Status == nil || spans[1].Status.Code != otlpStatusError || spans[1].Status.Message != "key not found" {
+		t.Fatalf("unexpected status %+v", spans[1].Status)
+	}
+}
+
+func TestOTLPTraceExporter(t *testing.T) {
+	var received otlpTraceRequest
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		if err := json.NewDecoder(r.Body).Decode(&received); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+	}))
+	defer ts.Close()
+
+	exporter := &otlpTraceExporter{
+		endpoint:  ts.URL,
+		authToken: "token",
+		client:    ts.Client(),
+		spans:     make(chan *tracing.Span, 1),
+	}
+	span := &tracing.Span{Name: "s3.getobject", Kind: tracing.KindServer}
+	exporter.enqueue(span)
+	exporter.enqueue(span) // dropped, the queue is full
+	if len(exporter.spans) != 1 {
+		t.Fatalf("expected 1 queued span, got %d", len(exporter.spans))
+	}
+
+	if err := exporter.export(context.Background(), []*tracing.Span{<-exporter.spans}); err != nil {
+		t.Fatal(err)
+	}
+	if len(received.ResourceSpans) != 1 || len(received.ResourceSpans[0].ScopeSpans[0].Spans) != 1 {
+		t.Fatalf("expected spans to be received, got %+v", received)
+	}
+
+	exporter.authToken = ""
+	if err := exporter.export(context.Background(), []*tracing.Span{span}); err == nil {
+		t.Fatal("expected export to fail on unauthorized response")
+	}
+}
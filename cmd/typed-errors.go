@@ -71,6 +71,12 @@ var errNoSuchUser = errors.New("Specified user does not exist")
 // error returned when service account is not found
 var errNoSuchServiceAccount = errors.New("Specified service account does not exist")
 
+// error returned when a service account expiry is not in the future
+var errInvalidServiceAccountExpiry = errors.New("Service account expiration must be in the future")
+
+// error returned when the limits of an access key are negative
+var errInvalidAccessKeyLimits = errors.New("Access key limits cannot be negative")
+
 // error returned in IAM subsystem when groups doesn't exist.
 var errNoSuchGroup = errors.New("Specified group does not exist")
 
@@ -512,7 +512,7 @@ func newInternodeHTTPTransport(tlsConfig *tls.Config, dialTimeout time.Duration)
 	// https://golang.org/pkg/net/http/#Transport documentation
 	tr := &http.Transport{
 		Proxy:                 http.ProxyFromEnvironment,
-		DialContext:           xhttp.DialContextWithDNSCache(globalDNSCache, xhttp.NewInternodeDialContext(dialTimeout)),
+		DialContext:           globalInternodeDialStats.Wrap(xhttp.DialContextWithDNSCache(globalDNSCache, xhttp.NewInternodeDialContext(dialTimeout))),
 		MaxIdleConnsPerHost:   1024,
 		WriteBufferSize:       32 << 10, // 32KiB moving up from 4KiB default
 		ReadBufferSize:        32 << 10, // 32KiB moving up from 4KiB default
@@ -553,6 +553,24 @@ func newInternodeHTTPTransport(tlsConfig *tls.Config, dialTimeout time.Duration)
 	}
 }
 
+// EnvInternodeHTTP2 enables HTTP/2 between the nodes, all the nodes
+// accept HTTP/2 regardless of it.
+const EnvInternodeHTTP2 = "MINIO_INTERNODE_HTTP2"
+
+// newInternodeHTTP2Transport multiplexes the internode requests over
+// persistent HTTP/2 connections, with TLS if tlsConfig is not nil.
+func newInternodeHTTP2Transport(tlsConfig *tls.Config, dialTimeout time.Duration) func() http.RoundTripper {
+	// Idle connections are pinged every 15 seconds, broken connections
+	// are detected and replaced before they fail requests.
+	tr := xhttp.NewHTTP2Transport(
+		globalInternodeDialStats.Wrap(xhttp.DialContextWithDNSCache(globalDNSCache, xhttp.NewInternodeDialContext(dialTimeout))),
+		tlsConfig, dialTimeout, 15*time.Second)
+
+	return func() http.RoundTripper {
+		return tr
+	}
+}
+
 // Used by only proxied requests, specifically only supports HTTP/1.1
 func newCustomHTTPProxyTransport(tlsConfig *tls.Config, dialTimeout time.Duration) func() *http.Transport {
 	// For more details about various values used here refer
@@ -1078,6 +1096,13 @@ func newTLSConfig(getCert certs.GetCertificateFunc) *tls.Config {
 			tlsConfig.CipherSuites = append(tlsConfig.CipherSuites, cipher.ID)
 		}
 	}
+
+	// Client certificates issued by the CAs of the metrics auth config
+	// are requested from the clients connecting to the metrics server
+	// name while some may scrape metrics.
+	if tlsConfig.ClientAuth == tls.NoClientCert {
+		tlsConfig.GetConfigForClient = metricsGetConfigForClient(tlsConfig)
+	}
 	return tlsConfig
 }
 
@@ -467,6 +467,11 @@ func TestLCP(t *testing.T) {
 }
 
 func TestGetMinioMode(t *testing.T) {
+	defer func(isDistErasure, isErasure, isGateway bool, gatewayName string) {
+		globalIsDistErasure, globalIsErasure = isDistErasure, isErasure
+		globalIsGateway, globalGatewayName = isGateway, gatewayName
+	}(globalIsDistErasure, globalIsErasure, globalIsGateway, globalGatewayName)
+
 	testMinioMode := func(expected string) {
 		if mode := getMinioMode(); mode != expected {
 			t.Fatalf("Expected %s got %s", expected, mode)
@@ -0,0 +1,137 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"context"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/google/uuid"
+)
+
+// warmBackendFS transitions objects to a directory of a filesystem mounted
+// on all the nodes of the deployment, e.g a NFS export or a VTL gateway.
+type warmBackendFS struct {
+	root string
+}
+
+var _ WarmBackend = (*warmBackendFS)(nil)
+
+func newWarmBackendFS(conf TierFS) (*warmBackendFS, error) {
+	if !filepath.IsAbs(conf.Path) {
+		return nil, errTierFSInvalidPath
+	}
+	fi, err := os.Stat(conf.Path)
+	if err != nil || !fi.IsDir() {
+		return nil, errTierFSInvalidPath
+	}
+	return &warmBackendFS{
+		root: filepath.Join(conf.Path, filepath.FromSlash(conf.Prefix)),
+	}, nil
+}
+
+func (fs *warmBackendFS) getDest(object string) string {
+	return filepath.Join(fs.root, filepath.FromSlash(object))
+}
+
+func (fs *warmBackendFS) Put(ctx context.Context, object string, r io.Reader, length int64) (remoteVersionID, error) {
+	dest := fs.getDest(object)
+	if err := os.MkdirAll(filepath.Dir(dest), 0o777); err != nil {
+		return "", err
+	}
+
+	// Write to a temporary file first so that a partial upload is never
+	// visible under the object name.
+	tmp := dest + ".tmp-" + uuid.New().String()
+	f, err := os.OpenFile(tmp, os.O_CREATE|os.O_WRONLY|os.O_EXCL, 0o666)
+	if err != nil {
+		return "", err
+	}
+	n, err := io.Copy(f, r)
+	if err == nil && n != length {
+		err = io.ErrUnexpectedEOF
+	}
+	if err == nil {
+		err = f.Sync()
+	}
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+	if err == nil {
+		err = os.Rename(tmp, dest)
+	}
+	if err != nil {
+		os.Remove(tmp)
+		return "", err
+	}
+	return "", nil
+}
+
+func (fs *warmBackendFS) Get(ctx context.Context, object string, rv remoteVersionID, opts WarmBackendGetOpts) (io.ReadCloser, error) {
+	f, err := os.Open(fs.getDest(object))
+	if err != nil {
+		return nil, err
+	}
+	if opts.startOffset > 0 {
+		if _, err = f.Seek(opts.startOffset, io.SeekStart); err != nil {
+			f.Close()
+			return nil, err
+		}
+	}
+	if opts.length > 0 {
+		return struct {
+			io.Reader
+			io.Closer
+		}{io.LimitReader(f, opts.length), f}, nil
+	}
+	return f, nil
+}
+
+func (fs *warmBackendFS) Remove(ctx context.Context, object string, rv remoteVersionID) error {
+	dest := fs.getDest(object)
+	if err := os.Remove(dest); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	// Remove the parent directories left empty, up to the root of the tier.
+	for dir := filepath.Dir(dest); dir != fs.root && len(dir) > len(fs.root); dir = filepath.Dir(dir) {
+		if os.Remove(dir) != nil {
+			break
+		}
+	}
+	return nil
+}
+
+func (fs *warmBackendFS) InUse(ctx context.Context) (bool, error) {
+	f, err := os.Open(fs.root)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return false, nil
+		}
+		return false, err
+	}
+	defer f.Close()
+
+	names, err := f.Readdirnames(1)
+	if err != nil && err != io.EOF {
+		return false, err
+	}
+	return len(names) > 0, nil
+}
@@ -0,0 +1,72 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"io/ioutil"
+	"testing"
+)
+
+func TestWarmBackendFS(t *testing.T) {
+	ctx := context.Background()
+	if _, err := newWarmBackendFS(TierFS{Name: "FS", Path: "relative/path"}); err != errTierFSInvalidPath {
+		t.Fatalf("expected %v, got %v", errTierFSInvalidPath, err)
+	}
+
+	d, err := newWarmBackendFS(TierFS{Name: "FS", Path: t.TempDir(), Prefix: "archive"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = checkWarmBackend(ctx, d); err != nil {
+		t.Fatal(err)
+	}
+	if inUse, err := d.InUse(ctx); err != nil || inUse {
+		t.Fatalf("expected an unused tier, got %v, %v", inUse, err)
+	}
+
+	object := "deployment/bucket/ab/cd/object"
+	data := []byte("0123456789")
+	if _, err = d.Put(ctx, object, bytes.NewReader(data), int64(len(data))); err != nil {
+		t.Fatal(err)
+	}
+	if inUse, err := d.InUse(ctx); err != nil || !inUse {
+		t.Fatalf("expected a tier in use, got %v, %v", inUse, err)
+	}
+
+	rc, err := d.Get(ctx, object, "", WarmBackendGetOpts{startOffset: 2, length: 5})
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := ioutil.ReadAll(rc)
+	rc.Close()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(got, data[2:7]) {
+		t.Fatalf("expected %q, got %q", data[2:7], got)
+	}
+
+	if err = d.Remove(ctx, object, ""); err != nil {
+		t.Fatal(err)
+	}
+	if inUse, err := d.InUse(ctx); err != nil || inUse {
+		t.Fatalf("expected an unused tier after remove, got %v, %v", inUse, err)
+	}
+}
@@ -0,0 +1,482 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/minio/minio-go/v7/pkg/s3utils"
+	"github.com/minio/minio/internal/logger"
+	"github.com/minio/pkg/bucket/policy"
+	"golang.org/x/net/webdav"
+)
+
+const (
+	webdavDefaultPath = "/webdav"
+
+	// Methods of the read-only WebDAV endpoint.
+	webdavMethodPropfind = "PROPFIND"
+	webdavAllowedMethods = "OPTIONS, GET, HEAD, PROPFIND"
+)
+
+// webdavMaxDirEntries is the maximum number of entries of a directory
+// listed over WebDAV, the entries of a directory are held in memory.
+var webdavMaxDirEntries = 10000
+
+var errWebDAVTooManyEntries = errors.New("directory holds too many entries to be listed over WebDAV")
+
+// webdavConfig holds the arguments of the --webdav server flag.
+type webdavConfig struct {
+	path    string
+	buckets []string
+}
+
+// parseWebDAVArgs parses "key=value" arguments of the --webdav
+// flag, the buckets served over WebDAV are mandatory.
+func parseWebDAVArgs(args []string) (cfg webdavConfig, err error) {
+	cfg.path = webdavDefaultPath
+	for _, arg := range args {
+		tokens := strings.SplitN(arg, "=", 2)
+		if len(tokens) != 2 {
+			return cfg, fmt.Errorf("invalid argument --webdav=%s, expected key=value", arg)
+		}
+		switch tokens[0] {
+		case "path":
+			p := path.Clean(tokens[1])
+			if !strings.HasPrefix(p, SlashSeparator) || p == SlashSeparator || strings.Count(p, SlashSeparator) != 1 {
+				return cfg, fmt.Errorf("invalid argument --webdav=%s, expected a path such as /webdav", arg)
+			}
+			if bucket, _ := path2BucketObject(p); isMinioReservedBucket(bucket) || isMinioMetaBucket(bucket) {
+				return cfg, fmt.Errorf("invalid argument --webdav=%s, %s is reserved", arg, p)
+			}
+			cfg.path = p
+		case "buckets":
+			cfg.buckets = nil
+			for _, bucket := range strings.Split(tokens[1], ",") {
+				if err = s3utils.CheckValidBucketNameStrict(bucket); err != nil {
+					return cfg, fmt.Errorf("invalid argument --webdav=%s: %w", arg, err)
+				}
+				cfg.buckets = append(cfg.buckets, bucket)
+			}
+		default:
+			return cfg, fmt.Errorf("invalid argument --webdav=%s, unknown key %s", arg, tokens[0])
+		}
+	}
+	if len(cfg.buckets) == 0 {
+		return cfg, errors.New("--webdav=buckets=bucket1,bucket2 is mandatory")
+	}
+	return cfg, nil
+}
+
+// webdavHandler serves the buckets of cfg read-only over WebDAV,
+// requests are authorized like the S3 calls reading the same data
+// so bucket policies apply to anonymous and presigned requests.
+type webdavHandler struct {
+	cfg webdavConfig
+	dav *webdav.Handler
+}
+
+// registerWebDAVRouter registers the WebDAV endpoint of cfg, ahead
+// of the S3 API router which would take its path as a bucket.
+func registerWebDAVRouter(router *mux.Router, cfg webdavConfig) {
+	h := &webdavHandler{
+		cfg: cfg,
+		dav: &webdav.Handler{
+			Prefix:     cfg.path,
+			FileSystem: webdavFS{buckets: cfg.buckets},
+			// Locks are never taken as writes are rejected.
+			LockSystem: webdav.NewMemLS(),
+		},
+	}
+	handler := collectAPIStats("webdav", maxClients(httpTraceHdrs(h.ServeHTTP)))
+	router.Path(cfg.path).HandlerFunc(handler)
+	router.PathPrefix(cfg.path + SlashSeparator).HandlerFunc(handler)
+}
+
+// isSelected returns whether bucket is served over WebDAV.
+func (h *webdavHandler) isSelected(bucket string) bool {
+	for _, b := range h.cfg.buckets {
+		if b == bucket {
+			return true
+		}
+	}
+	return false
+}
+
+// ServeHTTP - OPTIONS, GET, HEAD and PROPFIND on the WebDAV path.
+func (h *webdavHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "WebDAV")
+
+	defer logger.AuditLog(ctx, w, r, mustGetClaimsFromToken(r))
+
+	if newObjectLayerFn() == nil {
+		writeErrorResponse(ctx, w, errorCodes.ToAPIErr(ErrServerNotInitialized), r.URL)
+		return
+	}
+
+	bucket, object := path2BucketObjectWithBasePath(h.cfg.path, r.URL.Path)
+	if bucket != "" && !h.isSelected(bucket) {
+		writeErrorResponse(ctx, w, errorCodes.ToAPIErr(ErrNoSuchBucket), r.URL)
+		return
+	}
+
+	var action policy.Action
+	switch r.Method {
+	case http.MethodOptions:
+		// Only the class 1 of WebDAV is supported, without locks.
+		w.Header().Set("DAV", "1")
+		w.Header().Set("Allow", webdavAllowedMethods)
+		w.Header().Set("MS-Author-Via", "DAV")
+		return
+	case webdavMethodPropfind:
+		// Walking whole buckets is as costly as listing them recursively.
+		if depth := r.Header.Get("Depth"); depth != "0" && depth != "1" {
+			writeErrorResponse(ctx, w, APIError{
+				Code:           "PropfindFiniteDepth",
+				Description:    "PROPFIND requests must have a Depth of 0 or 1",
+				HTTPStatusCode: http.StatusForbidden,
+			}, r.URL)
+			return
+		}
+		action = policy.ListBucketAction
+		object = ""
+	case http.MethodGet, http.MethodHead:
+		action = policy.GetObjectAction
+	default:
+		w.Header().Set("Allow", webdavAllowedMethods)
+		writeErrorResponse(ctx, w, errorCodes.ToAPIErr(ErrMethodNotAllowed), r.URL)
+		return
+	}
+
+	// The root lists the buckets served over WebDAV, which
+	// is authorized like listing the buckets with S3.
+	if bucket == "" {
+		action = policy.ListAllMyBucketsAction
+	}
+	if s3Error := checkRequestAuthType(ctx, r, action, bucket, object); s3Error != ErrNone {
+		if r.Method == http.MethodHead {
+			writeErrorResponseHeadersOnly(w, errorCodes.ToAPIErr(s3Error))
+			return
+		}
+		writeErrorResponse(ctx, w, errorCodes.ToAPIErr(s3Error), r.URL)
+		return
+	}
+
+	h.dav.ServeHTTP(w, r)
+}
+
+// webdavFileInfo describes a bucket, prefix or object.
+type webdavFileInfo struct {
+	name        string
+	size        int64
+	modTime     time.Time
+	isDir       bool
+	etag        string
+	contentType string
+}
+
+func (fi webdavFileInfo) Name() string       { return fi.name }
+func (fi webdavFileInfo) Size() int64        { return fi.size }
+func (fi webdavFileInfo) ModTime() time.Time { return fi.modTime }
+func (fi webdavFileInfo) IsDir() bool        { return fi.isDir }
+func (fi webdavFileInfo) Sys() interface{}   { return nil }
+
+func (fi webdavFileInfo) Mode() os.FileMode {
+	if fi.isDir {
+		return os.ModeDir | 0o555
+	}
+	return 0o444
+}
+
+// ETag returns the ETag of objects, so listings never read objects.
+func (fi webdavFileInfo) ETag(ctx context.Context) (string, error) {
+	if fi.etag == "" {
+		return "", webdav.ErrNotImplemented
+	}
+	return "\"" + fi.etag + "\"", nil
+}
+
+// ContentType returns the content type of objects, so listings never
+// read objects.
+func (fi webdavFileInfo) ContentType(ctx context.Context) (string, error) {
+	if fi.contentType == "" {
+		return "", webdav.ErrNotImplemented
+	}
+	return fi.contentType, nil
+}
+
+// newWebDAVFileInfo returns the file info of an object named name.
+func newWebDAVFileInfo(name string, objInfo ObjectInfo) webdavFileInfo {
+	size, err := objInfo.GetActualSize()
+	if err != nil {
+		size = objInfo.Size
+	}
+	return webdavFileInfo{
+		name:        name,
+		size:        size,
+		modTime:     objInfo.ModTime,
+		etag:        objInfo.ETag,
+		contentType: objInfo.ContentType,
+	}
+}
+
+// webdavFS is a read-only file system of the buckets, buckets are
+// the top level directories and prefixes the others.
+type webdavFS struct {
+	buckets []string
+}
+
+func (fs webdavFS) Mkdir(ctx context.Context, name string, perm os.FileMode) error {
+	return os.ErrPermission
+}
+
+func (fs webdavFS) RemoveAll(ctx context.Context, name string) error {
+	return os.ErrPermission
+}
+
+func (fs webdavFS) Rename(ctx context.Context, oldName, newName string) error {
+	return os.ErrPermission
+}
+
+// Stat describes the entry at name, a prefix is a directory if any
+// object is below it.
+func (fs webdavFS) Stat(ctx context.Context, name string) (os.FileInfo, error) {
+	objectAPI := newObjectLayerFn()
+	if objectAPI == nil {
+		return nil, errServerNotInitialized
+	}
+	bucket, object := path2BucketObject(name)
+	object = strings.TrimSuffix(object, SlashSeparator)
+
+	if bucket == "" {
+		return webdavFileInfo{name: SlashSeparator, isDir: true}, nil
+	}
+	if object == "" {
+		bucketInfo, err := objectAPI.GetBucketInfo(ctx, bucket)
+		if err != nil {
+			return nil, webdavError(err)
+		}
+		return webdavFileInfo{name: bucket, modTime: bucketInfo.Created, isDir: true}, nil
+	}
+
+	base := path.Base(object)
+	objInfo, err := objectAPI.GetObjectInfo(ctx, bucket, object, ObjectOptions{})
+	if err == nil {
+		return newWebDAVFileInfo(base, objInfo), nil
+	}
+	if err = webdavError(err); err != os.ErrNotExist {
+		return nil, err
+	}
+	result, err := objectAPI.ListObjects(ctx, bucket, object+SlashSeparator, "", SlashSeparator, 1)
+	if err != nil {
+		return nil, webdavError(err)
+	}
+	if len(result.Objects) == 0 && len(result.Prefixes) == 0 {
+		return nil, os.ErrNotExist
+	}
+	return webdavFileInfo{name: base, isDir: true}, nil
+}
+
+// OpenFile opens the entry at name for reading.
+func (fs webdavFS) OpenFile(ctx context.Context, name string, flag int, perm os.FileMode) (webdav.File, error) {
+	if flag&(os.O_WRONLY|os.O_RDWR|os.O_APPEND|os.O_CREATE|os.O_TRUNC) != 0 {
+		return nil, os.ErrPermission
+	}
+	fi, err := fs.Stat(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	bucket, object := path2BucketObject(name)
+	return &webdavFile{
+		ctx:     ctx,
+		buckets: fs.buckets,
+		bucket:  bucket,
+		object:  strings.TrimSuffix(object, SlashSeparator),
+		info:    fi,
+	}, nil
+}
+
+// webdavError translates object layer errors into file system errors.
+func webdavError(err error) error {
+	switch err.(type) {
+	case BucketNotFound, BucketNameInvalid, ObjectNotFound, VersionNotFound, ObjectNameInvalid:
+		return os.ErrNotExist
+	}
+	return err
+}
+
+// webdavFile is a bucket, prefix or object opened for reading,
+// objects are read lazily from the current offset.
+type webdavFile struct {
+	ctx     context.Context
+	buckets []string
+	bucket  string
+	object  string
+	info    os.FileInfo
+
+	offset int64
+	reader *GetObjectReader
+
+	// Entries left to return by Readdir, nil until listed.
+	entries []os.FileInfo
+}
+
+func (f *webdavFile) Stat() (os.FileInfo, error) { return f.info, nil }
+
+func (f *webdavFile) Write(p []byte) (int, error) { return 0, os.ErrPermission }
+
+func (f *webdavFile) Close() error {
+	if f.reader != nil {
+		f.reader.Close()
+		f.reader = nil
+	}
+	return nil
+}
+
+func (f *webdavFile) Read(p []byte) (int, error) {
+	if f.info.IsDir() {
+		return 0, os.ErrInvalid
+	}
+	if f.offset >= f.info.Size() {
+		return 0, io.EOF
+	}
+	if f.reader == nil {
+		objectAPI := newObjectLayerFn()
+		if objectAPI == nil {
+			return 0, errServerNotInitialized
+		}
+		rs := &HTTPRangeSpec{Start: f.offset, End: -1}
+		reader, err := objectAPI.GetObjectNInfo(f.ctx, f.bucket, f.object, rs, http.Header{}, readLock, ObjectOptions{})
+		if err != nil {
+			return 0, webdavError(err)
+		}
+		f.reader = reader
+	}
+	n, err := f.reader.Read(p)
+	f.offset += int64(n)
+	return n, err
+}
+
+func (f *webdavFile) Seek(offset int64, whence int) (int64, error) {
+	switch whence {
+	case io.SeekCurrent:
+		offset += f.offset
+	case io.SeekEnd:
+		offset += f.info.Size()
+	}
+	if offset < 0 {
+		return 0, os.ErrInvalid
+	}
+	if offset != f.offset {
+		f.Close()
+		f.offset = offset
+	}
+	return offset, nil
+}
+
+// Readdir lists the entries of a directory, all of them if count
+// is not positive.
+func (f *webdavFile) Readdir(count int) ([]os.FileInfo, error) {
+	if !f.info.IsDir() {
+		return nil, os.ErrInvalid
+	}
+	if f.entries == nil {
+		entries, err := f.list()
+		if err != nil {
+			return nil, err
+		}
+		f.entries = entries
+	}
+	if count <= 0 {
+		entries := f.entries
+		f.entries = f.entries[:0]
+		return entries, nil
+	}
+	if len(f.entries) == 0 {
+		return nil, io.EOF
+	}
+	if count > len(f.entries) {
+		count = len(f.entries)
+	}
+	entries := f.entries[:count]
+	f.entries = f.entries[count:]
+	return entries, nil
+}
+
+// list returns the buckets served over WebDAV at the top level,
+// the objects and prefixes of a directory below it.
+func (f *webdavFile) list() ([]os.FileInfo, error) {
+	objectAPI := newObjectLayerFn()
+	if objectAPI == nil {
+		return nil, errServerNotInitialized
+	}
+
+	entries := []os.FileInfo{}
+	if f.bucket == "" {
+		for _, bucket := range f.buckets {
+			bucketInfo, err := objectAPI.GetBucketInfo(f.ctx, bucket)
+			if err != nil {
+				if webdavError(err) == os.ErrNotExist {
+					continue
+				}
+				return nil, err
+			}
+			entries = append(entries, webdavFileInfo{name: bucket, modTime: bucketInfo.Created, isDir: true})
+		}
+		return entries, nil
+	}
+
+	prefix := ""
+	if f.object != "" {
+		prefix = f.object + SlashSeparator
+	}
+	marker := ""
+	for {
+		result, err := objectAPI.ListObjects(f.ctx, f.bucket, prefix, marker, SlashSeparator, maxObjectList)
+		if err != nil {
+			return nil, webdavError(err)
+		}
+		for _, p := range result.Prefixes {
+			name := strings.TrimSuffix(strings.TrimPrefix(p, prefix), SlashSeparator)
+			entries = append(entries, webdavFileInfo{name: name, isDir: true})
+		}
+		for _, objInfo := range result.Objects {
+			// Skip the directory marker of the listed prefix.
+			if objInfo.Name == prefix {
+				continue
+			}
+			entries = append(entries, newWebDAVFileInfo(strings.TrimPrefix(objInfo.Name, prefix), objInfo))
+		}
+		if len(entries) > webdavMaxDirEntries {
+			return nil, errWebDAVTooManyEntries
+		}
+		if !result.IsTruncated {
+			return entries, nil
+		}
+		marker = result.NextMarker
+	}
+}
@@ -0,0 +1,193 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/minio/minio-go/v7"
+	"github.com/minio/minio-go/v7/pkg/credentials"
+	"github.com/minio/minio-go/v7/pkg/signer"
+)
+
+func TestParseWebDAVArgs(t *testing.T) {
+	testCases := []struct {
+		args     []string
+		expected webdavConfig
+		err      bool
+	}{
+		{[]string{"buckets=photos"}, webdavConfig{path: "/webdav", buckets: []string{"photos"}}, false},
+		{[]string{"path=/dav/", "buckets=photos,docs"}, webdavConfig{path: "/dav", buckets: []string{"photos", "docs"}}, false},
+		{[]string{"path=/dav"}, webdavConfig{}, true},
+		{[]string{"path=/", "buckets=photos"}, webdavConfig{}, true},
+		{[]string{"path=/a/b", "buckets=photos"}, webdavConfig{}, true},
+		{[]string{"path=/minio", "buckets=photos"}, webdavConfig{}, true},
+		{[]string{"buckets=Invalid_Bucket"}, webdavConfig{}, true},
+		{[]string{"port=80", "buckets=photos"}, webdavConfig{}, true},
+	}
+	for i, testCase := range testCases {
+		cfg, err := parseWebDAVArgs(testCase.args)
+		if (err != nil) != testCase.err {
+			t.Errorf("case %d: unexpected error %v", i+1, err)
+			continue
+		}
+		if err == nil && !reflect.DeepEqual(cfg, testCase.expected) {
+			t.Errorf("case %d: expected %+v, got %+v", i+1, testCase.expected, cfg)
+		}
+	}
+}
+
+func TestWebDAVHandler(t *testing.T) {
+	globalWebDAVConfig = &webdavConfig{path: "/webdav", buckets: []string{"public", "private"}}
+	defer func() { globalWebDAVConfig = nil }()
+
+	testServer := StartTestServer(t, "ErasureSD")
+	defer testServer.Stop()
+
+	u, err := url.Parse(testServer.Server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	client, err := minio.New(u.Host, &minio.Options{
+		Creds: credentials.NewStaticV4(testServer.AccessKey, testServer.SecretKey, ""),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.Background()
+	for _, bucket := range []string{"public", "private", "other"} {
+		if err = client.MakeBucket(ctx, bucket, minio.MakeBucketOptions{}); err != nil {
+			t.Fatal(err)
+		}
+		if _, err = client.PutObject(ctx, bucket, "dir/file.txt", strings.NewReader("hello webdav"), -1, minio.PutObjectOptions{}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	readOnly := `{"Version":"2012-10-17","Statement":[{"Effect":"Allow","Principal":{"AWS":["*"]},"Action":["s3:GetObject","s3:ListBucket"],"Resource":["arn:aws:s3:::public","arn:aws:s3:::public/*"]}]}`
+	if err = client.SetBucketPolicy(ctx, "public", readOnly); err != nil {
+		t.Fatal(err)
+	}
+
+	do := func(method, path string, header http.Header) (*http.Response, string) {
+		req, err := http.NewRequest(method, testServer.Server.URL+path, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for k, v := range header {
+			req.Header[k] = v
+		}
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer resp.Body.Close()
+		body, _ := ioutil.ReadAll(resp.Body)
+		return resp, string(body)
+	}
+
+	testCases := []struct {
+		method string
+		path   string
+		header http.Header
+		status int
+		body   string
+	}{
+		{http.MethodOptions, "/webdav/public/", nil, http.StatusOK, ""},
+		{webdavMethodPropfind, "/webdav/", http.Header{"Depth": {"1"}}, http.StatusForbidden, "AccessDenied"},
+		{webdavMethodPropfind, "/webdav/public/", http.Header{"Depth": {"1"}}, http.StatusMultiStatus, "<D:href>/webdav/public/dir/</D:href>"},
+		{webdavMethodPropfind, "/webdav/public/dir/", http.Header{"Depth": {"1"}}, http.StatusMultiStatus, "<D:getcontentlength>12</D:getcontentlength>"},
+		{webdavMethodPropfind, "/webdav/public/", nil, http.StatusForbidden, "PropfindFiniteDepth"},
+		{webdavMethodPropfind, "/webdav/private/", http.Header{"Depth": {"1"}}, http.StatusForbidden, "AccessDenied"},
+		{http.MethodGet, "/webdav/public/dir/file.txt", nil, http.StatusOK, "hello webdav"},
+		{http.MethodGet, "/webdav/public/dir/file.txt", http.Header{"Range": {"bytes=6-"}}, http.StatusPartialContent, "webdav"},
+		{http.MethodGet, "/webdav/public/dir/missing.txt", nil, http.StatusNotFound, ""},
+		{http.MethodGet, "/webdav/private/dir/file.txt", nil, http.StatusForbidden, "AccessDenied"},
+		{http.MethodGet, "/webdav/other/dir/file.txt", nil, http.StatusNotFound, "NoSuchBucket"},
+		{http.MethodPut, "/webdav/public/dir/new.txt", nil, http.StatusMethodNotAllowed, ""},
+		{"MKCOL", "/webdav/public/new/", nil, http.StatusMethodNotAllowed, ""},
+	}
+	for i, testCase := range testCases {
+		resp, body := do(testCase.method, testCase.path, testCase.header)
+		if resp.StatusCode != testCase.status {
+			t.Errorf("case %d: expected status %d, got %d: %s", i+1, testCase.status, resp.StatusCode, body)
+			continue
+		}
+		if !strings.Contains(body, testCase.body) {
+			t.Errorf("case %d: expected %q in the response, got %s", i+1, testCase.body, body)
+		}
+	}
+
+	// Directories with more entries than the limit are not listed.
+	if _, err = client.PutObject(ctx, "public", "dir/other.txt", strings.NewReader("hello webdav"), -1, minio.PutObjectOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	defer func(maxEntries int) { webdavMaxDirEntries = maxEntries }(webdavMaxDirEntries)
+	for _, limit := range []int{1, 2} {
+		webdavMaxDirEntries = limit
+		f, err := webdavFS{buckets: globalWebDAVConfig.buckets}.OpenFile(ctx, "/public/dir/", os.O_RDONLY, 0)
+		if err != nil {
+			t.Fatal(err)
+		}
+		entries, err := f.Readdir(0)
+		if limit == 1 && err != errWebDAVTooManyEntries {
+			t.Errorf("expected too many entries to be listed, got %v", err)
+		}
+		if limit == 2 && (err != nil || len(entries) != 2) {
+			t.Errorf("expected 2 entries, got %d: %v", len(entries), err)
+		}
+	}
+
+	// Presigned requests are authorized with the policies of their signer.
+	req, err := http.NewRequest(http.MethodGet, testServer.Server.URL+"/webdav/private/dir/file.txt", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	req = signer.PreSignV4(*req, testServer.AccessKey, testServer.SecretKey, "", globalSite.Region, 60)
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	body, _ := ioutil.ReadAll(resp.Body)
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK || string(body) != "hello webdav" {
+		t.Errorf("expected presigned requests to be served, got %d: %s", resp.StatusCode, body)
+	}
+
+	// The buckets are only listed to requests allowed to list buckets.
+	req, err = http.NewRequest(webdavMethodPropfind, testServer.Server.URL+"/webdav/", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	req = signer.PreSignV4(*req, testServer.AccessKey, testServer.SecretKey, "", globalSite.Region, 60)
+	req.Header.Set("Depth", "1")
+	if resp, err = http.DefaultClient.Do(req); err != nil {
+		t.Fatal(err)
+	}
+	body, _ = ioutil.ReadAll(resp.Body)
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusMultiStatus || !strings.Contains(string(body), "<D:href>/webdav/private/</D:href>") {
+		t.Errorf("expected the buckets to be listed, got %d: %s", resp.StatusCode, body)
+	}
+}
@@ -78,12 +78,14 @@ type xlStorageDiskIDCheck struct {
 	diskID       string
 	storage      *xlStorage
 	health       *diskHealthTracker
+	ioStats      *driveIOStats
 }
 
 func (p *xlStorageDiskIDCheck) getMetrics() DiskMetrics {
 	diskMetric := DiskMetrics{
 		APILatencies: make(map[string]uint64),
 		APICalls:     make(map[string]uint64),
+		IO:           p.ioStats.load(p.health.queueDepth()),
 	}
 	for i, v := range p.apiLatencies {
 		diskMetric.APILatencies[storageMetric(i).String()] = v.value()
@@ -115,6 +117,7 @@ func newXLStorageDiskIDCheck(storage *xlStorage) *xlStorageDiskIDCheck {
 	xl := xlStorageDiskIDCheck{
 		storage: storage,
 		health:  newDiskHealthTracker(),
+		ioStats: &driveIOStats{},
 	}
 	for i := range xl.apiLatencies[:] {
 		xl.apiLatencies[i] = &lockedLastMinuteLatency{}
@@ -516,6 +519,7 @@ func (p *xlStorageDiskIDCheck) updateStorageMetrics(s storageMetric, paths ...st
 
 		atomic.AddUint64(&p.apiCalls[s], 1)
 		p.apiLatencies[s].add(duration)
+		p.ioStats.add(s, duration)
 
 		paths = append([]string{p.String()}, paths...)
 		if trace {
@@ -639,6 +643,7 @@ func (p *xlStorageDiskIDCheck) TrackDiskHealth(ctx context.Context, s storageMet
 		// We ran out of tokens, check health before blocking.
 		err = p.waitForToken(ctx)
 		if err != nil {
+			p.ioStats.failed(err)
 			return ctx, done, err
 		}
 	}
@@ -663,6 +668,7 @@ func (p *xlStorageDiskIDCheck) TrackDiskHealth(ctx context.Context, s storageMet
 			if errp != nil {
 				err := *errp
 				if err != nil && !errors.Is(err, io.EOF) {
+					p.ioStats.failed(err)
 					return
 				}
 				p.health.logSuccess()
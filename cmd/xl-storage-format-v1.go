@@ -118,6 +118,10 @@ const (
 	HighwayHash256S
 	// BLAKE2b512 represents the BLAKE2b-512 hash function
 	BLAKE2b512
+	// XXHash64S represents the Streaming xxHash64 hash function
+	XXHash64S
+	// BLAKE3S represents the Streaming BLAKE3-256 hash function
+	BLAKE3S
 )
 
 // DefaultBitrotAlgorithm is the default algorithm used for bitrot protection.
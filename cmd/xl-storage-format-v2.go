@@ -138,13 +138,42 @@ type ChecksumAlgo uint8
 const (
 	invalidChecksumAlgo ChecksumAlgo = 0
 	HighwayHash         ChecksumAlgo = 1
-	lastChecksumAlgo    ChecksumAlgo = 2
+	XXHash64            ChecksumAlgo = 2
+	BLAKE3              ChecksumAlgo = 3
+	lastChecksumAlgo    ChecksumAlgo = 4
 )
 
 func (e ChecksumAlgo) valid() bool {
 	return e > invalidChecksumAlgo && e < lastChecksumAlgo
 }
 
+// bitrotAlgorithm returns the streaming bitrot algorithm of e.
+func (e ChecksumAlgo) bitrotAlgorithm() (BitrotAlgorithm, bool) {
+	switch e {
+	case HighwayHash:
+		return HighwayHash256S, true
+	case XXHash64:
+		return XXHash64S, true
+	case BLAKE3:
+		return BLAKE3S, true
+	}
+	return 0, false
+}
+
+// checksumAlgoOf returns the checksum algorithm recorded for the parts
+// protected by the given bitrot checksums.
+func checksumAlgoOf(checksums []ChecksumInfo) ChecksumAlgo {
+	if len(checksums) > 0 {
+		switch checksums[0].Algorithm {
+		case XXHash64S:
+			return XXHash64
+		case BLAKE3S:
+			return BLAKE3
+		}
+	}
+	return HighwayHash
+}
+
 // xlMetaV2DeleteMarker defines the data struct for the delete marker journal type
 type xlMetaV2DeleteMarker struct {
 	VersionID [16]byte          `json:"ID" msg:"ID"`                               // Version ID for delete marker
@@ -571,13 +600,12 @@ func (j xlMetaV2Object) ToFileInfo(volume, path string) (FileInfo, error) {
 	fi.Erasure.Checksums = make([]ChecksumInfo, len(j.PartSizes))
 	for i := range fi.Parts {
 		fi.Erasure.Checksums[i].PartNumber = fi.Parts[i].Number
-		switch j.BitrotChecksumAlgo {
-		case HighwayHash:
-			fi.Erasure.Checksums[i].Algorithm = HighwayHash256S
-			fi.Erasure.Checksums[i].Hash = []byte{}
-		default:
+		algo, ok := j.BitrotChecksumAlgo.bitrotAlgorithm()
+		if !ok {
 			return FileInfo{}, fmt.Errorf("unknown BitrotChecksumAlgo: %v", j.BitrotChecksumAlgo)
 		}
+		fi.Erasure.Checksums[i].Algorithm = algo
+		fi.Erasure.Checksums[i].Hash = []byte{}
 	}
 	fi.Metadata = make(map[string]string, len(j.MetaUser)+len(j.MetaSys))
 	for k, v := range j.MetaUser {
@@ -1445,7 +1473,7 @@ func (x *xlMetaV2) AddVersion(fi FileInfo) error {
 			ErasureN:           fi.Erasure.ParityBlocks,
 			ErasureBlockSize:   fi.Erasure.BlockSize,
 			ErasureIndex:       fi.Erasure.Index,
-			BitrotChecksumAlgo: HighwayHash,
+			BitrotChecksumAlgo: checksumAlgoOf(fi.Erasure.Checksums),
 			ErasureDist:        make([]uint8, len(fi.Erasure.Distribution)),
 			PartNumbers:        make([]int, len(fi.Parts)),
 			PartETags:          nil,
@@ -0,0 +1,240 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"context"
+	"errors"
+	"io"
+	"math"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Key of the IO stats of a drive in the API latencies
+// of the drive metrics reported by the admin info API.
+const driveIOStatsKey = "io"
+
+// Upper bounds of the buckets of the drive latency histograms,
+// a last bucket holds the latencies above the highest bound.
+var driveLatencyBuckets = [...]time.Duration{
+	100 * time.Microsecond,
+	250 * time.Microsecond,
+	500 * time.Microsecond,
+	time.Millisecond,
+	2500 * time.Microsecond,
+	5 * time.Millisecond,
+	10 * time.Millisecond,
+	25 * time.Millisecond,
+	50 * time.Millisecond,
+	100 * time.Millisecond,
+	250 * time.Millisecond,
+	500 * time.Millisecond,
+	time.Second,
+	2500 * time.Millisecond,
+	5 * time.Second,
+	10 * time.Second,
+}
+
+// latencyHistogram counts latencies per driveLatencyBuckets.
+type latencyHistogram [len(driveLatencyBuckets) + 1]uint64
+
+// add counts the latency d.
+func (h *latencyHistogram) add(d time.Duration) {
+	i := 0
+	for i < len(driveLatencyBuckets) && d > driveLatencyBuckets[i] {
+		i++
+	}
+	h[i]++
+}
+
+// count returns the number of counted latencies.
+func (h latencyHistogram) count() (n uint64) {
+	for _, v := range h {
+		n += v
+	}
+	return n
+}
+
+// percentile returns the upper bound of the bucket holding
+// the q-th quantile, latencies above the highest bound are
+// reported as the highest bound.
+func (h latencyHistogram) percentile(q float64) time.Duration {
+	n := h.count()
+	if n == 0 {
+		return 0
+	}
+	rank := uint64(math.Ceil(q * float64(n)))
+	if rank < 1 {
+		rank = 1
+	}
+	var seen uint64
+	for i, v := range h[:len(driveLatencyBuckets)] {
+		seen += v
+		if seen >= rank {
+			return driveLatencyBuckets[i]
+		}
+	}
+	return driveLatencyBuckets[len(driveLatencyBuckets)-1]
+}
+
+// lastMinuteHistogram keeps the latencies of the last minute,
+// in one histogram per second.
+type lastMinuteHistogram struct {
+	histograms [60]latencyHistogram
+	lastSec    int64
+}
+
+// forwardTo time t, clearing any entries in between.
+func (l *lastMinuteHistogram) forwardTo(t int64) {
+	if l.lastSec >= t {
+		return
+	}
+	if t-l.lastSec >= 60 {
+		l.histograms = [60]latencyHistogram{}
+		l.lastSec = t
+		return
+	}
+	for l.lastSec != t {
+		// Clear next element.
+		idx := (l.lastSec + 1) % 60
+		l.histograms[idx] = latencyHistogram{}
+		l.lastSec++
+	}
+}
+
+// addAt counts the latency d at second sec.
+func (l *lastMinuteHistogram) addAt(sec int64, d time.Duration) {
+	l.forwardTo(sec)
+	l.histograms[sec%60].add(d)
+}
+
+// mergeAt merges the histograms of the minute before sec into one.
+func (l *lastMinuteHistogram) mergeAt(sec int64) (merged latencyHistogram) {
+	l.forwardTo(sec)
+	for _, h := range l.histograms {
+		for i, v := range h {
+			merged[i] += v
+		}
+	}
+	return merged
+}
+
+// isDriveWriteMetric returns whether the storage API s modifies the drive,
+// all other APIs are accounted as reads.
+func isDriveWriteMetric(s storageMetric) bool {
+	switch s {
+	case storageMetricMakeVolBulk, storageMetricMakeVol, storageMetricDeleteVol,
+		storageMetricAppendFile, storageMetricCreateFile, storageMetricRenameFile,
+		storageMetricRenameData, storageMetricDelete, storageMetricDeleteVersions,
+		storageMetricWriteAll, storageMetricDeleteVersion, storageMetricWriteMetadata,
+		storageMetricUpdateMetadata:
+		return true
+	}
+	return false
+}
+
+// Errors returned by storage APIs which are expected during normal
+// operation and say nothing about the health of a drive.
+var driveExpectedErrs = []error{
+	io.EOF,
+	context.Canceled,
+	errFileNotFound,
+	errFileVersionNotFound,
+	errFileNameTooLong,
+	errFileAccessDenied,
+	errVolumeNotFound,
+	errVolumeExists,
+	errVolumeNotEmpty,
+	errPathNotFound,
+	errIsNotRegular,
+}
+
+// driveIOStats accounts the operations of a drive.
+type driveIOStats struct {
+	// Counters should be placed first so alignment is guaranteed for atomic operations.
+	readOps  uint64
+	writeOps uint64
+	errors   uint64
+	timeouts uint64
+
+	mu     sync.Mutex
+	reads  lastMinuteHistogram
+	writes lastMinuteHistogram
+}
+
+// add accounts a successful call of api which took d.
+func (s *driveIOStats) add(api storageMetric, d time.Duration) {
+	sec := time.Now().Unix()
+	if isDriveWriteMetric(api) {
+		atomic.AddUint64(&s.writeOps, 1)
+		s.mu.Lock()
+		s.writes.addAt(sec, d)
+		s.mu.Unlock()
+		return
+	}
+	atomic.AddUint64(&s.readOps, 1)
+	s.mu.Lock()
+	s.reads.addAt(sec, d)
+	s.mu.Unlock()
+}
+
+// failed accounts a call which failed with err,
+// expected errors are ignored.
+func (s *driveIOStats) failed(err error) {
+	switch {
+	case err == nil || IsErr(err, driveExpectedErrs...):
+	case errors.Is(err, context.DeadlineExceeded):
+		atomic.AddUint64(&s.timeouts, 1)
+	default:
+		atomic.AddUint64(&s.errors, 1)
+	}
+}
+
+// load returns the stats of the drive, queueDepth
+// is the number of running and waiting operations.
+func (s *driveIOStats) load(queueDepth int32) DriveIOStats {
+	sec := time.Now().Unix()
+	s.mu.Lock()
+	reads := s.reads.mergeAt(sec)
+	writes := s.writes.mergeAt(sec)
+	s.mu.Unlock()
+
+	return DriveIOStats{
+		ReadOps:         atomic.LoadUint64(&s.readOps),
+		WriteOps:        atomic.LoadUint64(&s.writeOps),
+		ReadIOPS:        float64(reads.count()) / 60,
+		WriteIOPS:       float64(writes.count()) / 60,
+		ReadLatencyP50:  uint64(reads.percentile(0.5)),
+		ReadLatencyP90:  uint64(reads.percentile(0.9)),
+		ReadLatencyP99:  uint64(reads.percentile(0.99)),
+		WriteLatencyP50: uint64(writes.percentile(0.5)),
+		WriteLatencyP90: uint64(writes.percentile(0.9)),
+		WriteLatencyP99: uint64(writes.percentile(0.99)),
+		QueueDepth:      queueDepth,
+		Errors:          atomic.LoadUint64(&s.errors),
+		Timeouts:        atomic.LoadUint64(&s.timeouts),
+	}
+}
+
+// queueDepth returns the number of operations holding
+// a token plus those waiting for one.
+func (d *diskHealthTracker) queueDepth() int32 {
+	return int32(cap(d.tokens)-len(d.tokens)) + atomic.LoadInt32(&d.blocked)
+}
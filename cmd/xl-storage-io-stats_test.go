@@ -0,0 +1,107 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+)
+
+func TestLatencyHistogramPercentile(t *testing.T) {
+	var h latencyHistogram
+	if got := h.percentile(0.5); got != 0 {
+		t.Errorf("expected no latency for an empty histogram, got %v", got)
+	}
+	for i := 0; i < 98; i++ {
+		h.add(200 * time.Microsecond)
+	}
+	h.add(20 * time.Millisecond)
+	h.add(time.Minute)
+
+	testCases := []struct {
+		q        float64
+		expected time.Duration
+	}{
+		{0.5, 250 * time.Microsecond},
+		{0.98, 250 * time.Microsecond},
+		{0.99, 25 * time.Millisecond},
+		{1, 10 * time.Second},
+	}
+	for _, testCase := range testCases {
+		if got := h.percentile(testCase.q); got != testCase.expected {
+			t.Errorf("expected p%v to be %v, got %v", testCase.q*100, testCase.expected, got)
+		}
+	}
+}
+
+func TestLastMinuteHistogram(t *testing.T) {
+	var l lastMinuteHistogram
+	now := time.Now().Unix()
+	l.addAt(now-30, time.Millisecond)
+	l.addAt(now, time.Millisecond)
+	if n := l.mergeAt(now).count(); n != 2 {
+		t.Errorf("expected 2 latencies in the last minute, got %d", n)
+	}
+	if n := l.mergeAt(now + 45).count(); n != 1 {
+		t.Errorf("expected latencies older than a minute to be dropped, got %d", n)
+	}
+	if n := l.mergeAt(now + 120).count(); n != 0 {
+		t.Errorf("expected no latencies, got %d", n)
+	}
+}
+
+func TestDriveIOStats(t *testing.T) {
+	var s driveIOStats
+	s.add(storageMetricReadVersion, time.Millisecond)
+	s.add(storageMetricReadFile, time.Millisecond)
+	s.add(storageMetricCreateFile, time.Second)
+
+	s.failed(errFileNotFound)
+	s.failed(fmt.Errorf("read: %w", context.Canceled))
+	s.failed(errFaultyDisk)
+	s.failed(context.DeadlineExceeded)
+
+	stats := s.load(3)
+	if stats.ReadOps != 2 || stats.WriteOps != 1 {
+		t.Errorf("expected 2 reads and 1 write, got %+v", stats)
+	}
+	if stats.ReadLatencyP99 != uint64(time.Millisecond) || stats.WriteLatencyP50 != uint64(time.Second) {
+		t.Errorf("unexpected latencies %+v", stats)
+	}
+	if stats.ReadIOPS != 2.0/60 {
+		t.Errorf("expected the reads to be averaged over a minute, got %v", stats.ReadIOPS)
+	}
+	if stats.Errors != 1 || stats.Timeouts != 1 {
+		t.Errorf("expected 1 error and 1 timeout, got %+v", stats)
+	}
+	if stats.QueueDepth != 3 {
+		t.Errorf("expected queue depth 3, got %d", stats.QueueDepth)
+	}
+}
+
+func TestDiskHealthTrackerQueueDepth(t *testing.T) {
+	d := newDiskHealthTracker()
+	<-d.tokens
+	<-d.tokens
+	d.blocked = 1
+	if got := d.queueDepth(); got != 3 {
+		t.Errorf("expected 2 running and 1 waiting operations, got %d", got)
+	}
+}
@@ -96,6 +96,7 @@ type xlStorage struct {
 	globalSync bool
 	oDirect    bool // indicates if this disk supports ODirect
 	rootDisk   bool
+	rotational bool // indicates if this disk is a rotational drive (HDD)
 
 	diskID string
 
@@ -234,11 +235,15 @@ func newXLStorage(ep Endpoint) (s *xlStorage, err error) {
 		}
 	}
 
+	// Drives whose kind is not known are tuned as NVMe drives.
+	rotational, _ := disk.IsRotational(path)
+
 	s = &xlStorage{
 		diskPath:   path,
 		endpoint:   ep,
 		globalSync: globalFSOSync,
 		rootDisk:   rootDisk,
+		rotational: rotational,
 		poolIndex:  -1,
 		setIndex:   -1,
 		diskIndex:  -1,
@@ -502,7 +507,7 @@ func (s *xlStorage) NSScanner(ctx context.Context, cache dataUsageCache, updates
 		if noTiers = globalTierConfigMgr.Empty(); !noTiers {
 			sizeS.tiers = make(map[string]tierStats)
 		}
-		atomic.AddUint64(&globalScannerStats.accTotalObjects, 1)
+		globalScannerStats.objectScanned()
 		fivs.Versions, err = item.applyVersionActions(ctx, objAPI, fivs.Versions)
 		if err != nil {
 			if intDataUpdateTracker.debug {
@@ -520,8 +525,24 @@ func (s *xlStorage) NSScanner(ctx context.Context, cache dataUsageCache, updates
 			if oi.VersionID != "" && sz == oi.Size {
 				sizeS.versions++
 			}
+			if oi.DeleteMarker {
+				sizeS.deleteMarkers++
+			}
 			sizeS.totalSize += sz
 
+			// Account versions still present after applying
+			// actions by their encryption type.
+			if typ := encryptionType(oi.UserDefined); typ != "" && !oi.DeleteMarker &&
+				!oi.TransitionedObject.FreeVersion && sz == oi.Size {
+				if sizeS.encryption == nil {
+					sizeS.encryption = make(map[string]encryptionStats, 1)
+				}
+				sizeS.encryption[typ] = sizeS.encryption[typ].add(encryptionStats{
+					Size:     uint64(sz),
+					Versions: 1,
+				})
+			}
+
 			// Skip tier accounting if,
 			// 1. no tiers configured
 			// 2. object version is a delete-marker or a free-version
@@ -1385,7 +1406,7 @@ func (s *xlStorage) readAllData(ctx context.Context, volumeDir string, filePath
 		return nil, time.Time{}, ctx.Err()
 	}
 
-	odirectEnabled := !globalAPIConfig.isDisableODirect() && s.oDirect
+	odirectEnabled := s.odirectReads()
 	var f *os.File
 	if odirectEnabled {
 		f, err = OpenFileDirectIO(filePath, readMode, 0o666)
@@ -1586,6 +1607,16 @@ func (s *xlStorage) ReadFile(ctx context.Context, volume string, path string, of
 	return int64(len(buffer)), nil
 }
 
+// odirectReads returns true if large files are read with O_DIRECT.
+func (s *xlStorage) odirectReads() bool {
+	return s.oDirect && !globalAPIConfig.isDisableODirect() && globalDriveConfig.ODirect(s.rotational)
+}
+
+// odirectWrites returns true if large files are written with O_DIRECT.
+func (s *xlStorage) odirectWrites() bool {
+	return s.oDirect && globalDriveConfig.ODirect(s.rotational)
+}
+
 func (s *xlStorage) openFileDirect(path string, mode int) (f *os.File, err error) {
 	// Create top level directories if they don't exist.
 	// with mode 0o777 mkdir honors system umask.
@@ -1681,7 +1712,7 @@ func (s *xlStorage) ReadFileStream(ctx context.Context, volume, path string, off
 		return nil, err
 	}
 
-	odirectEnabled := !globalAPIConfig.isDisableODirect() && s.oDirect
+	odirectEnabled := s.odirectReads()
 
 	var file *os.File
 	if odirectEnabled {
@@ -1758,10 +1789,23 @@ func (s *xlStorage) ReadFileStream(ctx context.Context, volume, path string, off
 		}
 	}
 
+	var rd io.Reader = or
+	if window := globalDriveConfig.ReadAhead(s.rotational); window > 0 && (!alignment || !odirectEnabled) {
+		// reads are served from the page-cache, prefetch ahead of them.
+		rd = &readAheadReader{
+			Reader: or,
+			f:      file,
+			offset: offset,
+			end:    offset,
+			limit:  offset + length,
+			window: window,
+		}
+	}
+
 	r := struct {
 		io.Reader
 		io.Closer
-	}{Reader: io.LimitReader(diskHealthReader(ctx, or), length), Closer: closeWrapper(func() error {
+	}{Reader: io.LimitReader(diskHealthReader(ctx, rd), length), Closer: closeWrapper(func() error {
 		if (!alignment || offset+length%xioutil.DirectioAlignSize != 0) && odirectEnabled {
 			// invalidate page-cache for unaligned reads.
 			// skip removing from page-cache only
@@ -1774,6 +1818,32 @@ func (s *xlStorage) ReadFileStream(ctx context.Context, volume, path string, off
 	return r, nil
 }
 
+// readAheadReader hints the kernel to prefetch the next window bytes
+// of a file read through the page-cache ahead of the reads.
+type readAheadReader struct {
+	io.Reader
+	f      *os.File
+	offset int64 // offset of the next read
+	end    int64 // end of the range prefetched so far
+	limit  int64 // end of the range being read
+	window int64
+}
+
+func (r *readAheadReader) Read(p []byte) (int, error) {
+	// Prefetch the next window once half of the previous one was read.
+	if r.end < r.limit && r.offset >= r.end-r.window/2 {
+		n := r.window
+		if r.end+n > r.limit {
+			n = r.limit - r.end
+		}
+		disk.FadviseWillNeed(r.f, r.end, n)
+		r.end += n
+	}
+	n, err := r.Reader.Read(p)
+	r.offset += int64(n)
+	return n, err
+}
+
 // closeWrapper converts a function to an io.Closer
 type closeWrapper func() error
 
@@ -1838,7 +1908,7 @@ func (s *xlStorage) CreateFile(ctx context.Context, volume, path string, fileSiz
 		return osErrToFileErr(err)
 	}
 
-	odirectEnabled := s.oDirect
+	odirectEnabled := s.odirectWrites()
 	var w *os.File
 	if odirectEnabled {
 		w, err = OpenFileDirectIO(filePath, os.O_CREATE|os.O_WRONLY|os.O_EXCL, 0o666)
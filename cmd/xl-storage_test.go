@@ -32,6 +32,7 @@ import (
 	"testing"
 
 	"github.com/google/uuid"
+	"github.com/minio/minio/internal/config/drive"
 )
 
 func TestCheckPathLength(t *testing.T) {
@@ -1974,3 +1975,46 @@ func TestXLStorageReadMetadata(t *testing.T) {
 		t.Fatalf("Unexpected error from readMetadata - expect %v: got %v", errFileNameTooLong, err)
 	}
 }
+
+// TestXLStorageDriveConfig tests reads and writes with the drive IO settings.
+func TestXLStorageDriveConfig(t *testing.T) {
+	storage, path, err := newXLStorageTestSetup()
+	if err != nil {
+		t.Fatalf("Unable to create xlStorage test setup, %s", err)
+	}
+	defer os.RemoveAll(path)
+
+	defer globalDriveConfig.Update(globalDriveConfig)
+	globalDriveConfig.Update(drive.Config{NVMeODirect: false, HDDODirect: false, NVMeReadAhead: 64 << 10, HDDReadAhead: 64 << 10})
+	if storage.storage.odirectReads() || storage.storage.odirectWrites() {
+		t.Fatal("expected O_DIRECT to be disabled")
+	}
+
+	volume := "success-vol"
+	if err = storage.MakeVol(context.Background(), volume); err != nil {
+		t.Fatalf("Unable to create volume, %s", err)
+	}
+	data := make([]byte, 1<<20)
+	for i := range data {
+		data[i] = byte(i % 251)
+	}
+	if err = storage.CreateFile(context.Background(), volume, "file", int64(len(data)), bytes.NewReader(data)); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, offset := range []int64{0, 4096, 12345} {
+		length := int64(len(data)) - offset - 1
+		r, err := storage.ReadFileStream(context.Background(), volume, "file", offset, length)
+		if err != nil {
+			t.Fatal(err)
+		}
+		got, err := ioutil.ReadAll(r)
+		r.Close()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(got, data[offset:offset+length]) {
+			t.Fatalf("offset %d: unexpected data read", offset)
+		}
+	}
+}
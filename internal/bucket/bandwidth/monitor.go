@@ -33,8 +33,9 @@ type throttle struct {
 
 // Monitor holds the state of the global bucket monitor
 type Monitor struct {
-	tlock                 sync.RWMutex // mutex for bucketThrottle
+	tlock                 sync.RWMutex // mutex for bucketThrottle and targetThrottle
 	bucketThrottle        map[string]*throttle
+	targetThrottle        map[string]*throttle          // throttles by remote target endpoint
 	mlock                 sync.RWMutex                  // mutex for activeBuckets map
 	activeBuckets         map[string]*bucketMeasurement // Buckets with objects in flight
 	bucketMovingAvgTicker *time.Ticker                  // Ticker for calculating moving averages
@@ -47,6 +48,7 @@ func NewMonitor(ctx context.Context, numNodes uint64) *Monitor {
 	m := &Monitor{
 		activeBuckets:         make(map[string]*bucketMeasurement),
 		bucketThrottle:        make(map[string]*throttle),
+		targetThrottle:        make(map[string]*throttle),
 		bucketMovingAvgTicker: time.NewTicker(2 * time.Second),
 		ctx:                   ctx,
 		NodeCount:             numNodes,
@@ -164,6 +166,13 @@ func (m *Monitor) throttle(bucket string) *throttle {
 	return m.bucketThrottle[bucket]
 }
 
+// targetThrottleFor returns currently configured throttle for this remote target endpoint
+func (m *Monitor) targetThrottleFor(endpoint string) *throttle {
+	m.tlock.RLock()
+	defer m.tlock.RUnlock()
+	return m.targetThrottle[endpoint]
+}
+
 // SetBandwidthLimit sets the bandwidth limit for a bucket
 func (m *Monitor) SetBandwidthLimit(bucket string, limit int64) {
 	m.tlock.Lock()
@@ -181,6 +190,62 @@ func (m *Monitor) SetBandwidthLimit(bucket string, limit int64) {
 	m.bucketThrottle[bucket] = t
 }
 
+// SetTargetBandwidthLimits replaces the bandwidth limits of the remote
+// target endpoints, shared by the replication of all the buckets to them.
+// The limits apply to the replication already in progress as well.
+func (m *Monitor) SetTargetBandwidthLimits(limits map[string]int64) {
+	m.tlock.Lock()
+	defer m.tlock.Unlock()
+	for endpoint, t := range m.targetThrottle {
+		if limits[endpoint] <= 0 {
+			// Release the replication in progress.
+			t.SetLimit(rate.Inf)
+			delete(m.targetThrottle, endpoint)
+		}
+	}
+	for endpoint, limit := range limits {
+		if limit <= 0 {
+			continue
+		}
+		bw := limit / int64(m.NodeCount)
+		if bw <= 0 {
+			bw = 1
+		}
+		newlimit := rate.Every(time.Second / time.Duration(bw))
+		t, ok := m.targetThrottle[endpoint]
+		if !ok {
+			m.targetThrottle[endpoint] = &throttle{
+				Limiter:             rate.NewLimiter(newlimit, int(bw)),
+				NodeBandwidthPerSec: bw,
+			}
+			continue
+		}
+		t.NodeBandwidthPerSec = bw
+		t.SetLimit(newlimit)
+		t.SetBurst(int(bw))
+	}
+}
+
+// GetTargetBandwidthLimits returns the cluster wide bandwidth
+// limits of the remote target endpoints.
+func (m *Monitor) GetTargetBandwidthLimits() map[string]int64 {
+	m.tlock.RLock()
+	defer m.tlock.RUnlock()
+	limits := make(map[string]int64, len(m.targetThrottle))
+	for endpoint, t := range m.targetThrottle {
+		limits[endpoint] = t.NodeBandwidthPerSec * int64(m.NodeCount)
+	}
+	return limits
+}
+
+// IsTargetThrottled returns true if a remote target endpoint has bandwidth throttling enabled.
+func (m *Monitor) IsTargetThrottled(endpoint string) bool {
+	m.tlock.RLock()
+	defer m.tlock.RUnlock()
+	_, ok := m.targetThrottle[endpoint]
+	return ok
+}
+
 // IsThrottled returns true if a bucket has bandwidth throttling enabled.
 func (m *Monitor) IsThrottled(bucket string) bool {
 	m.tlock.RLock()
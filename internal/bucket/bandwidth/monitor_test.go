@@ -18,11 +18,14 @@
 package bandwidth
 
 import (
+	"bytes"
+	"context"
 	"reflect"
 	"testing"
 	"time"
 
 	"github.com/minio/madmin-go"
+	"golang.org/x/time/rate"
 )
 
 const (
@@ -111,3 +114,38 @@ func TestMonitor_GetReport(t *testing.T) {
 		})
 	}
 }
+
+func TestMonitor_SetTargetBandwidthLimits(t *testing.T) {
+	m := &Monitor{
+		bucketThrottle: make(map[string]*throttle),
+		targetThrottle: make(map[string]*throttle),
+		activeBuckets:  make(map[string]*bucketMeasurement),
+		NodeCount:      2,
+	}
+
+	m.SetTargetBandwidthLimits(map[string]int64{"dr:9000": 2 * 1024 * 1024, "site:9000": 0})
+	if !m.IsTargetThrottled("dr:9000") || m.IsTargetThrottled("site:9000") {
+		t.Fatalf("unexpected throttled targets %v", m.GetTargetBandwidthLimits())
+	}
+	if got := m.GetTargetBandwidthLimits(); !reflect.DeepEqual(got, map[string]int64{"dr:9000": 2 * 1024 * 1024}) {
+		t.Fatalf("GetTargetBandwidthLimits() = %v", got)
+	}
+
+	// The node share of the limit applies to the readers of both the bucket and the target.
+	m.SetBandwidthLimit("bucket", 4*1024*1024)
+	r := NewMonitoredReader(context.Background(), m, bytes.NewReader(make([]byte, 10)), &MonitorReaderOptions{Bucket: "bucket", Endpoint: "dr:9000"})
+	if b := r.burst(); b != 1024*1024 {
+		t.Fatalf("expected the burst of the target throttle, got %d", b)
+	}
+
+	// Updated limits apply to the readers in progress, removed ones release them.
+	old := m.targetThrottle["dr:9000"]
+	m.SetTargetBandwidthLimits(map[string]int64{"dr:9000": 1024 * 1024})
+	if m.targetThrottle["dr:9000"] != old || old.Burst() != 512*1024 {
+		t.Fatalf("expected the throttle to be updated in place, got burst %d", old.Burst())
+	}
+	m.SetTargetBandwidthLimits(nil)
+	if m.IsTargetThrottled("dr:9000") || old.Limit() != rate.Inf {
+		t.Fatal("expected the target throttle to be removed and released")
+	}
+}
@@ -25,30 +25,32 @@ import (
 
 // MonitoredReader represents a throttled reader subject to bandwidth monitoring
 type MonitoredReader struct {
-	r        io.Reader
-	throttle *throttle
-	ctx      context.Context // request context
-	lastErr  error           // last error reported, if this non-nil all reads will fail.
-	m        *Monitor
-	opts     *MonitorReaderOptions
+	r              io.Reader
+	throttle       *throttle
+	targetThrottle *throttle       // throttle of the remote target endpoint
+	ctx            context.Context // request context
+	lastErr        error           // last error reported, if this non-nil all reads will fail.
+	m              *Monitor
+	opts           *MonitorReaderOptions
 }
 
 // MonitorReaderOptions provides configurable options for monitor reader implementation.
 type MonitorReaderOptions struct {
 	Bucket     string
+	Endpoint   string // remote target endpoint, if any
 	HeaderSize int
 }
 
 // Read implements a throttled read
 func (r *MonitoredReader) Read(buf []byte) (n int, err error) {
-	if r.throttle == nil {
+	if r.throttle == nil && r.targetThrottle == nil {
 		return r.r.Read(buf)
 	}
 	if r.lastErr != nil {
 		err = r.lastErr
 		return
 	}
-	b := r.throttle.Burst()  // maximum available tokens
+	b := r.burst()           // maximum available tokens
 	need := len(buf)         // number of bytes requested by caller
 	hdr := r.opts.HeaderSize // remaining header bytes
 	var tokens int           // number of tokens to request
@@ -69,9 +71,13 @@ func (r *MonitoredReader) Read(buf []byte) (n int, err error) {
 		tokens = need
 	}
 
-	err = r.throttle.WaitN(r.ctx, tokens)
-	if err != nil {
-		return
+	for _, t := range []*throttle{r.throttle, r.targetThrottle} {
+		if t == nil {
+			continue
+		}
+		if err = t.WaitN(r.ctx, tokens); err != nil {
+			return
+		}
 	}
 
 	n, err = r.r.Read(buf[:need])
@@ -83,8 +89,19 @@ func (r *MonitoredReader) Read(buf []byte) (n int, err error) {
 	return
 }
 
+// burst returns the maximum tokens available from all the throttles.
+func (r *MonitoredReader) burst() int {
+	b := math.MaxInt32
+	for _, t := range []*throttle{r.throttle, r.targetThrottle} {
+		if t != nil && t.Burst() < b {
+			b = t.Burst()
+		}
+	}
+	return b
+}
+
 // NewMonitoredReader returns reference to a monitored reader that throttles reads to configured bandwidth for the
-// bucket.
+// bucket and the remote target endpoint.
 func NewMonitoredReader(ctx context.Context, m *Monitor, r io.Reader, opts *MonitorReaderOptions) *MonitoredReader {
 	reader := MonitoredReader{
 		r:        r,
@@ -93,6 +110,9 @@ func NewMonitoredReader(ctx context.Context, m *Monitor, r io.Reader, opts *Moni
 		opts:     opts,
 		ctx:      ctx,
 	}
+	if opts.Endpoint != "" {
+		reader.targetThrottle = m.targetThrottleFor(opts.Endpoint)
+	}
 	reader.m.track(opts.Bucket)
 	return &reader
 }
@@ -0,0 +1,196 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// # This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+package cors
+
+import (
+	"encoding/xml"
+	"io"
+	"net/http"
+	"strings"
+)
+
+const (
+	// MaxRules - maximum number of CORS rules per bucket.
+	MaxRules    = 100
+	maxIDLength = 255
+)
+
+var (
+	errNoRules           = Errorf("CORS configuration must have at least one CORSRule")
+	errTooManyRules      = Errorf("a bucket can have at most 100 CORS rules")
+	errInvalidID         = Errorf("CORS rule ID must be at most 255 characters")
+	errNoAllowedMethod   = Errorf("CORS rule must have at least one AllowedMethod")
+	errNoAllowedOrigin   = Errorf("CORS rule must have at least one AllowedOrigin")
+	errNegativeMaxAge    = Errorf("MaxAgeSeconds cannot be negative")
+	errWildcardInExposed = Errorf("ExposeHeader cannot contain wildcards")
+)
+
+// Methods allowed in CORS rules.
+var allowedMethods = map[string]struct{}{
+	http.MethodGet:    {},
+	http.MethodPut:    {},
+	http.MethodHead:   {},
+	http.MethodPost:   {},
+	http.MethodDelete: {},
+}
+
+// Rule - a CORS rule, the cross-origin requests it allows.
+type Rule struct {
+	ID             string   `xml:"ID,omitempty"`
+	AllowedHeaders []string `xml:"AllowedHeader,omitempty"`
+	AllowedMethods []string `xml:"AllowedMethod"`
+	AllowedOrigins []string `xml:"AllowedOrigin"`
+	ExposeHeaders  []string `xml:"ExposeHeader,omitempty"`
+	MaxAgeSeconds  int      `xml:"MaxAgeSeconds,omitempty"`
+}
+
+// Validate - validates the CORS rule.
+func (r Rule) Validate() error {
+	if len(r.ID) > maxIDLength {
+		return errInvalidID
+	}
+	if len(r.AllowedMethods) == 0 {
+		return errNoAllowedMethod
+	}
+	for _, m := range r.AllowedMethods {
+		if _, ok := allowedMethods[m]; !ok {
+			return Errorf("unsupported CORS method %s, only GET, PUT, HEAD, POST and DELETE are allowed", m)
+		}
+	}
+	if len(r.AllowedOrigins) == 0 {
+		return errNoAllowedOrigin
+	}
+	for _, o := range r.AllowedOrigins {
+		if strings.Count(o, "*") > 1 {
+			return Errorf("AllowedOrigin %q can have at most one wildcard", o)
+		}
+	}
+	for _, h := range r.AllowedHeaders {
+		if strings.Count(h, "*") > 1 {
+			return Errorf("AllowedHeader %q can have at most one wildcard", h)
+		}
+	}
+	for _, h := range r.ExposeHeaders {
+		if strings.Contains(h, "*") {
+			return errWildcardInExposed
+		}
+	}
+	if r.MaxAgeSeconds < 0 {
+		return errNegativeMaxAge
+	}
+	return nil
+}
+
+// matchWildcard returns whether s matches pattern,
+// with at most one '*' wildcard matching any characters.
+func matchWildcard(pattern, s string) bool {
+	i := strings.IndexByte(pattern, '*')
+	if i < 0 {
+		return pattern == s
+	}
+	return len(s) >= len(pattern)-1 && strings.HasPrefix(s, pattern[:i]) && strings.HasSuffix(s, pattern[i+1:])
+}
+
+// MatchOrigin returns the allowed origin of the rule matching origin,
+// "*" if all origins are allowed, or "" if origin is not allowed.
+func (r Rule) MatchOrigin(origin string) string {
+	for _, o := range r.AllowedOrigins {
+		if o == "*" {
+			return o
+		}
+		if matchWildcard(o, origin) {
+			return origin
+		}
+	}
+	return ""
+}
+
+// allowsMethod returns whether the rule allows method.
+func (r Rule) allowsMethod(method string) bool {
+	for _, m := range r.AllowedMethods {
+		if m == method {
+			return true
+		}
+	}
+	return false
+}
+
+// allowsHeader returns whether the rule allows the request header,
+// header names are compared case-insensitively.
+func (r Rule) allowsHeader(header string) bool {
+	header = strings.ToLower(header)
+	for _, h := range r.AllowedHeaders {
+		if matchWildcard(strings.ToLower(h), header) {
+			return true
+		}
+	}
+	return false
+}
+
+// Config - CORS configuration of a bucket.
+type Config struct {
+	XMLNS   string   `xml:"xmlns,attr,omitempty"`
+	XMLName xml.Name `xml:"CORSConfiguration"`
+	Rules   []Rule   `xml:"CORSRule"`
+}
+
+// Validate - validates the CORS configuration.
+func (c Config) Validate() error {
+	if len(c.Rules) == 0 {
+		return errNoRules
+	}
+	if len(c.Rules) > MaxRules {
+		return errTooManyRules
+	}
+	for _, r := range c.Rules {
+		if err := r.Validate(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Match returns the first rule allowing a request from origin with
+// method and headers, like the preflight requests announcing them,
+// or nil if no rule allows it.
+func (c Config) Match(origin, method string, headers []string) *Rule {
+rules:
+	for i, r := range c.Rules {
+		if r.MatchOrigin(origin) == "" || !r.allowsMethod(method) {
+			continue
+		}
+		for _, h := range headers {
+			if !r.allowsHeader(h) {
+				continue rules
+			}
+		}
+		return &c.Rules[i]
+	}
+	return nil
+}
+
+// ParseConfig - parses data in given reader to CORS configuration.
+func ParseConfig(reader io.Reader) (*Config, error) {
+	var c Config
+	if err := xml.NewDecoder(reader).Decode(&c); err != nil {
+		return nil, err
+	}
+	if err := c.Validate(); err != nil {
+		return nil, err
+	}
+	return &c, nil
+}
@@ -0,0 +1,127 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+package cors
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseConfig(t *testing.T) {
+	testCases := []struct {
+		input string
+		err   error
+	}{
+		{
+			input: `<CORSConfiguration>
+				<CORSRule>
+					<AllowedOrigin>https://*.example.com</AllowedOrigin>
+					<AllowedMethod>GET</AllowedMethod>
+					<AllowedMethod>PUT</AllowedMethod>
+					<AllowedHeader>*</AllowedHeader>
+					<ExposeHeader>ETag</ExposeHeader>
+					<MaxAgeSeconds>3000</MaxAgeSeconds>
+				</CORSRule>
+			</CORSConfiguration>`,
+		},
+		{
+			input: `<CORSConfiguration></CORSConfiguration>`,
+			err:   errNoRules,
+		},
+		{
+			input: `<CORSConfiguration><CORSRule><AllowedOrigin>*</AllowedOrigin></CORSRule></CORSConfiguration>`,
+			err:   errNoAllowedMethod,
+		},
+		{
+			input: `<CORSConfiguration><CORSRule><AllowedMethod>GET</AllowedMethod></CORSRule></CORSConfiguration>`,
+			err:   errNoAllowedOrigin,
+		},
+		{
+			input: `<CORSConfiguration><CORSRule><AllowedOrigin>*</AllowedOrigin><AllowedMethod>PATCH</AllowedMethod></CORSRule></CORSConfiguration>`,
+			err:   Errorf("unsupported CORS method PATCH, only GET, PUT, HEAD, POST and DELETE are allowed"),
+		},
+		{
+			input: `<CORSConfiguration><CORSRule><AllowedOrigin>https://*.*.com</AllowedOrigin><AllowedMethod>GET</AllowedMethod></CORSRule></CORSConfiguration>`,
+			err:   Errorf("AllowedOrigin %q can have at most one wildcard", "https://*.*.com"),
+		},
+		{
+			input: `<CORSConfiguration><CORSRule><AllowedOrigin>*</AllowedOrigin><AllowedMethod>GET</AllowedMethod><ExposeHeader>x-amz-*</ExposeHeader></CORSRule></CORSConfiguration>`,
+			err:   errWildcardInExposed,
+		},
+		{
+			input: `<CORSConfiguration><CORSRule><AllowedOrigin>*</AllowedOrigin><AllowedMethod>GET</AllowedMethod><MaxAgeSeconds>-1</MaxAgeSeconds></CORSRule></CORSConfiguration>`,
+			err:   errNegativeMaxAge,
+		},
+		{
+			input: `<CORSConfiguration>` + strings.Repeat(`<CORSRule><AllowedOrigin>*</AllowedOrigin><AllowedMethod>GET</AllowedMethod></CORSRule>`, MaxRules+1) + `</CORSConfiguration>`,
+			err:   errTooManyRules,
+		},
+	}
+	for i, tc := range testCases {
+		_, err := ParseConfig(strings.NewReader(tc.input))
+		if (err == nil) != (tc.err == nil) || (err != nil && err.Error() != tc.err.Error()) {
+			t.Errorf("Test %d: expected error %v, got %v", i+1, tc.err, err)
+		}
+	}
+}
+
+func TestMatch(t *testing.T) {
+	config, err := ParseConfig(strings.NewReader(`<CORSConfiguration>
+		<CORSRule>
+			<ID>app</ID>
+			<AllowedOrigin>https://*.example.com</AllowedOrigin>
+			<AllowedMethod>GET</AllowedMethod>
+			<AllowedMethod>PUT</AllowedMethod>
+			<AllowedHeader>Content-*</AllowedHeader>
+			<AllowedHeader>Authorization</AllowedHeader>
+		</CORSRule>
+		<CORSRule>
+			<ID>public</ID>
+			<AllowedOrigin>*</AllowedOrigin>
+			<AllowedMethod>GET</AllowedMethod>
+		</CORSRule>
+	</CORSConfiguration>`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testCases := []struct {
+		origin  string
+		method  string
+		headers []string
+		rule    string
+		allowed string
+	}{
+		{"https://app.example.com", "PUT", []string{"content-type", "AUTHORIZATION"}, "app", "https://app.example.com"},
+		{"https://app.example.com", "PUT", []string{"x-amz-date"}, "", ""},
+		{"https://app.example.com", "GET", []string{"x-amz-date"}, "", ""},
+		{"https://app.example.com", "GET", nil, "app", "https://app.example.com"},
+		{"https://example.com", "PUT", nil, "", ""},
+		{"http://app.example.com", "GET", nil, "public", "*"},
+		{"https://app.example.com", "DELETE", nil, "", ""},
+	}
+	for i, tc := range testCases {
+		rule := config.Match(tc.origin, tc.method, tc.headers)
+		var id, allowed string
+		if rule != nil {
+			id, allowed = rule.ID, rule.MatchOrigin(tc.origin)
+		}
+		if id != tc.rule || allowed != tc.allowed {
+			t.Errorf("Test %d: expected rule %q allowing %q, got %q allowing %q", i+1, tc.rule, tc.allowed, id, allowed)
+		}
+	}
+}
@@ -0,0 +1,308 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package inventory
+
+import (
+	"encoding/xml"
+	"io"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Format - format of inventory report files.
+type Format string
+
+// Supported inventory report formats.
+const (
+	CSV     Format = "CSV"
+	Parquet Format = "Parquet"
+	ORC     Format = "ORC" // Not supported yet.
+)
+
+// Frequency - how often inventory reports are generated.
+type Frequency string
+
+// Supported inventory report frequencies.
+const (
+	Daily  Frequency = "Daily"
+	Weekly Frequency = "Weekly"
+)
+
+// Interval returns the time between two reports.
+func (f Frequency) Interval() time.Duration {
+	if f == Weekly {
+		return 7 * 24 * time.Hour
+	}
+	return 24 * time.Hour
+}
+
+// Object versions listed by inventory reports.
+const (
+	AllVersions    = "All"
+	CurrentVersion = "Current"
+)
+
+// Field - an optional field of inventory reports.
+type Field string
+
+// Supported optional fields.
+const (
+	FieldSize                      Field = "Size"
+	FieldLastModifiedDate          Field = "LastModifiedDate"
+	FieldStorageClass              Field = "StorageClass"
+	FieldETag                      Field = "ETag"
+	FieldIsMultipartUploaded       Field = "IsMultipartUploaded"
+	FieldReplicationStatus         Field = "ReplicationStatus"
+	FieldEncryptionStatus          Field = "EncryptionStatus"
+	FieldObjectLockRetainUntilDate Field = "ObjectLockRetainUntilDate"
+	FieldObjectLockMode            Field = "ObjectLockMode"
+	FieldObjectLockLegalHoldStatus Field = "ObjectLockLegalHoldStatus"
+	FieldChecksumAlgorithm         Field = "ChecksumAlgorithm"
+)
+
+var supportedFields = map[Field]struct{}{
+	FieldSize:                      {},
+	FieldLastModifiedDate:          {},
+	FieldStorageClass:              {},
+	FieldETag:                      {},
+	FieldIsMultipartUploaded:       {},
+	FieldReplicationStatus:         {},
+	FieldEncryptionStatus:          {},
+	FieldObjectLockRetainUntilDate: {},
+	FieldObjectLockMode:            {},
+	FieldObjectLockLegalHoldStatus: {},
+	FieldChecksumAlgorithm:         {},
+}
+
+// bucketARNPrefix - prefix of destination bucket ARNs.
+const bucketARNPrefix = "arn:aws:s3:::"
+
+const (
+	maxIDLength = 64
+	// MaxConfigs - maximum number of inventory configurations per bucket.
+	MaxConfigs = 1000
+)
+
+var (
+	errInvalidID              = Errorf("inventory configuration ID must be 1 to 64 letters, digits, '-', '_' or '.'")
+	errInvalidBucketARN       = Errorf("destination bucket must be an ARN of the form arn:aws:s3:::bucket")
+	errEncryptionNotSupported = Errorf("encryption of inventory reports is not supported")
+	errTooManyConfigs         = Errorf("a bucket can have at most 1000 inventory configurations")
+)
+
+// Filter - selects the objects listed by inventory reports.
+type Filter struct {
+	Prefix string `xml:"Prefix,omitempty"`
+}
+
+// Encryption - encryption of inventory report files.
+type Encryption struct {
+	InnerXML string `xml:",innerxml"`
+}
+
+// S3BucketDestination - the bucket inventory reports are written to.
+type S3BucketDestination struct {
+	AccountID  string      `xml:"AccountId,omitempty"`
+	Bucket     string      `xml:"Bucket"`
+	Format     Format      `xml:"Format"`
+	Prefix     string      `xml:"Prefix,omitempty"`
+	Encryption *Encryption `xml:"Encryption,omitempty"`
+}
+
+// BucketName returns the name of the destination bucket.
+func (d S3BucketDestination) BucketName() string {
+	return strings.TrimPrefix(d.Bucket, bucketARNPrefix)
+}
+
+// Destination - where inventory reports are written.
+type Destination struct {
+	S3BucketDestination S3BucketDestination `xml:"S3BucketDestination"`
+}
+
+// Schedule - when inventory reports are generated.
+type Schedule struct {
+	Frequency Frequency `xml:"Frequency"`
+}
+
+// OptionalFields - the optional fields of inventory reports.
+type OptionalFields struct {
+	Fields []Field `xml:"Field"`
+}
+
+// Config - an inventory configuration of a bucket.
+type Config struct {
+	XMLNS                  string          `xml:"xmlns,attr,omitempty"`
+	XMLName                xml.Name        `xml:"InventoryConfiguration"`
+	ID                     string          `xml:"Id"`
+	IsEnabled              bool            `xml:"IsEnabled"`
+	Destination            Destination     `xml:"Destination"`
+	Filter                 *Filter         `xml:"Filter,omitempty"`
+	IncludedObjectVersions string          `xml:"IncludedObjectVersions"`
+	OptionalFields         *OptionalFields `xml:"OptionalFields,omitempty"`
+	Schedule               Schedule        `xml:"Schedule"`
+}
+
+// Validate - validates the inventory configuration.
+func (c Config) Validate() error {
+	if !validID(c.ID) {
+		return errInvalidID
+	}
+	dst := c.Destination.S3BucketDestination
+	if !strings.HasPrefix(dst.Bucket, bucketARNPrefix) || dst.BucketName() == "" {
+		return errInvalidBucketARN
+	}
+	switch dst.Format {
+	case CSV, Parquet:
+	case ORC:
+		return Errorf("inventory format %s is not supported", dst.Format)
+	default:
+		return Errorf("unknown inventory format %s", dst.Format)
+	}
+	if dst.Encryption != nil {
+		return errEncryptionNotSupported
+	}
+	switch c.IncludedObjectVersions {
+	case AllVersions, CurrentVersion:
+	default:
+		return Errorf("unknown included object versions %s", c.IncludedObjectVersions)
+	}
+	switch c.Schedule.Frequency {
+	case Daily, Weekly:
+	default:
+		return Errorf("unknown inventory frequency %s", c.Schedule.Frequency)
+	}
+	seen := make(map[Field]struct{})
+	for _, f := range c.Fields() {
+		if _, ok := supportedFields[f]; !ok {
+			return Errorf("unsupported inventory field %s", f)
+		}
+		if _, ok := seen[f]; ok {
+			return Errorf("duplicate inventory field %s", f)
+		}
+		seen[f] = struct{}{}
+	}
+	return nil
+}
+
+func validID(id string) bool {
+	if id == "" || len(id) > maxIDLength || id == "." || id == ".." {
+		return false
+	}
+	for _, r := range id {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9':
+		case r == '-', r == '_', r == '.':
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+// Prefix returns the prefix of the objects listed by reports.
+func (c Config) Prefix() string {
+	if c.Filter == nil {
+		return ""
+	}
+	return c.Filter.Prefix
+}
+
+// Fields returns the optional fields of reports in order.
+func (c Config) Fields() []Field {
+	if c.OptionalFields == nil {
+		return nil
+	}
+	return c.OptionalFields.Fields
+}
+
+// AllVersions returns true if reports list all object versions.
+func (c Config) AllVersions() bool {
+	return c.IncludedObjectVersions == AllVersions
+}
+
+// ParseConfig - parses data in given reader to an inventory configuration.
+func ParseConfig(reader io.Reader) (*Config, error) {
+	var c Config
+	if err := xml.NewDecoder(reader).Decode(&c); err != nil {
+		return nil, err
+	}
+	if err := c.Validate(); err != nil {
+		return nil, err
+	}
+	return &c, nil
+}
+
+// Configs - all inventory configurations of a bucket, sorted by ID.
+type Configs struct {
+	XMLName xml.Name `xml:"InventoryConfigurations"`
+	Configs []Config `xml:"InventoryConfiguration"`
+}
+
+// Get returns the configuration with the given ID.
+func (c Configs) Get(id string) (Config, bool) {
+	i := sort.Search(len(c.Configs), func(i int) bool { return c.Configs[i].ID >= id })
+	if i < len(c.Configs) && c.Configs[i].ID == id {
+		return c.Configs[i], true
+	}
+	return Config{}, false
+}
+
+// Set adds the configuration cfg, or replaces the
+// configuration with the same ID.
+func (c *Configs) Set(cfg Config) error {
+	i := sort.Search(len(c.Configs), func(i int) bool { return c.Configs[i].ID >= cfg.ID })
+	if i < len(c.Configs) && c.Configs[i].ID == cfg.ID {
+		c.Configs[i] = cfg
+		return nil
+	}
+	if len(c.Configs) >= MaxConfigs {
+		return errTooManyConfigs
+	}
+	c.Configs = append(c.Configs, Config{})
+	copy(c.Configs[i+1:], c.Configs[i:])
+	c.Configs[i] = cfg
+	return nil
+}
+
+// Delete removes the configuration with the given ID,
+// it returns false if there is none.
+func (c *Configs) Delete(id string) bool {
+	i := sort.Search(len(c.Configs), func(i int) bool { return c.Configs[i].ID >= id })
+	if i == len(c.Configs) || c.Configs[i].ID != id {
+		return false
+	}
+	c.Configs = append(c.Configs[:i], c.Configs[i+1:]...)
+	return true
+}
+
+// ParseConfigs - parses data in given reader to the inventory
+// configurations of a bucket.
+func ParseConfigs(reader io.Reader) (*Configs, error) {
+	var c Configs
+	if err := xml.NewDecoder(reader).Decode(&c); err != nil {
+		return nil, err
+	}
+	for _, cfg := range c.Configs {
+		if err := cfg.Validate(); err != nil {
+			return nil, err
+		}
+	}
+	sort.Slice(c.Configs, func(i, j int) bool { return c.Configs[i].ID < c.Configs[j].ID })
+	return &c, nil
+}
@@ -0,0 +1,139 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package inventory
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func configXML(id, bucket, format, versions, frequency, fields string) string {
+	return fmt.Sprintf(`<InventoryConfiguration xmlns="http://s3.amazonaws.com/doc/2006-03-01/">
+  <Id>%s</Id>
+  <IsEnabled>true</IsEnabled>
+  <Destination>
+    <S3BucketDestination>
+      <Bucket>%s</Bucket>
+      <Format>%s</Format>
+      <Prefix>reports</Prefix>
+    </S3BucketDestination>
+  </Destination>
+  <Filter><Prefix>photos/</Prefix></Filter>
+  <IncludedObjectVersions>%s</IncludedObjectVersions>
+  <OptionalFields>%s</OptionalFields>
+  <Schedule><Frequency>%s</Frequency></Schedule>
+</InventoryConfiguration>`, id, bucket, format, versions, fields, frequency)
+}
+
+func TestParseConfig(t *testing.T) {
+	const fields = "<Field>Size</Field><Field>ETag</Field>"
+	testCases := []struct {
+		input string
+		err   bool
+	}{
+		{configXML("report-1", "arn:aws:s3:::dest", "CSV", "Current", "Daily", fields), false},
+		{configXML("report.2", "arn:aws:s3:::dest", "Parquet", "All", "Weekly", ""), false},
+		{configXML("", "arn:aws:s3:::dest", "CSV", "Current", "Daily", fields), true},
+		{configXML("..", "arn:aws:s3:::dest", "CSV", "Current", "Daily", fields), true},
+		{configXML("report/1", "arn:aws:s3:::dest", "CSV", "Current", "Daily", fields), true},
+		{configXML(strings.Repeat("a", 65), "arn:aws:s3:::dest", "CSV", "Current", "Daily", fields), true},
+		{configXML("report", "dest", "CSV", "Current", "Daily", fields), true},
+		{configXML("report", "arn:aws:s3:::", "CSV", "Current", "Daily", fields), true},
+		{configXML("report", "arn:aws:s3:::dest", "ORC", "Current", "Daily", fields), true},
+		{configXML("report", "arn:aws:s3:::dest", "JSON", "Current", "Daily", fields), true},
+		{configXML("report", "arn:aws:s3:::dest", "CSV", "Latest", "Daily", fields), true},
+		{configXML("report", "arn:aws:s3:::dest", "CSV", "Current", "Hourly", fields), true},
+		{configXML("report", "arn:aws:s3:::dest", "CSV", "Current", "Daily", "<Field>Owner</Field>"), true},
+		{configXML("report", "arn:aws:s3:::dest", "CSV", "Current", "Daily", "<Field>Size</Field><Field>Size</Field>"), true},
+	}
+	for i, testCase := range testCases {
+		cfg, err := ParseConfig(strings.NewReader(testCase.input))
+		if (err != nil) != testCase.err {
+			t.Errorf("case %d: unexpected error %v", i+1, err)
+			continue
+		}
+		if err != nil {
+			continue
+		}
+		if cfg.Destination.S3BucketDestination.BucketName() != "dest" || cfg.Prefix() != "photos/" {
+			t.Errorf("case %d: unexpected config %+v", i+1, cfg)
+		}
+	}
+
+	cfg, err := ParseConfig(strings.NewReader(configXML("report", "arn:aws:s3:::dest", "CSV", "All", "Weekly", fields)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !cfg.AllVersions() || cfg.Schedule.Frequency.Interval() != Weekly.Interval() || len(cfg.Fields()) != 2 || cfg.Fields()[1] != FieldETag {
+		t.Errorf("unexpected config %+v", cfg)
+	}
+}
+
+func TestConfigs(t *testing.T) {
+	var configs Configs
+	for _, id := range []string{"b", "c", "a", "b"} {
+		if err := configs.Set(Config{ID: id, IncludedObjectVersions: CurrentVersion}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if len(configs.Configs) != 3 || configs.Configs[0].ID != "a" || configs.Configs[2].ID != "c" {
+		t.Fatalf("expected configurations sorted by ID, got %+v", configs.Configs)
+	}
+	if _, ok := configs.Get("b"); !ok {
+		t.Error("expected configuration b to be found")
+	}
+	if !configs.Delete("b") || configs.Delete("b") {
+		t.Error("expected configuration b to be deleted once")
+	}
+	if _, ok := configs.Get("b"); ok {
+		t.Error("expected configuration b to be deleted")
+	}
+
+	cfg, err := ParseConfig(strings.NewReader(configXML("report", "arn:aws:s3:::dest", "CSV", "Current", "Daily", "<Field>Size</Field>")))
+	if err != nil {
+		t.Fatal(err)
+	}
+	configs = Configs{}
+	if err = configs.Set(*cfg); err != nil {
+		t.Fatal(err)
+	}
+	data, err := xml.Marshal(configs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	parsed, err := ParseConfigs(bytes.NewReader(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, ok := parsed.Get("report"); !ok || got.Destination != cfg.Destination || got.Prefix() != cfg.Prefix() {
+		t.Errorf("expected %+v, got %+v", cfg, got)
+	}
+
+	configs = Configs{}
+	for i := 0; i < MaxConfigs; i++ {
+		if err = configs.Set(Config{ID: fmt.Sprint(i)}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err = configs.Set(Config{ID: "one-more"}); err == nil {
+		t.Error("expected too many configurations to be rejected")
+	}
+}
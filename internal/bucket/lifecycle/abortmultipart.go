@@ -0,0 +1,91 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package lifecycle
+
+import (
+	"encoding/xml"
+	"strings"
+	"time"
+)
+
+var errAbortMultipartWithTags = Errorf("AbortIncompleteMultipartUpload cannot be specified with Tags in a lifecycle rule filter")
+
+// AbortIncompleteMultipartUpload - an action for lifecycle configuration rule.
+type AbortIncompleteMultipartUpload struct {
+	XMLName             xml.Name       `xml:"AbortIncompleteMultipartUpload"`
+	DaysAfterInitiation ExpirationDays `xml:"DaysAfterInitiation,omitempty"`
+	set                 bool
+}
+
+// MarshalXML if days after initiation is not set to non zero value
+func (a AbortIncompleteMultipartUpload) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	if a.IsNull() {
+		return nil
+	}
+	type abortIncompleteMultipartUploadWrapper AbortIncompleteMultipartUpload
+	return e.EncodeElement(abortIncompleteMultipartUploadWrapper(a), start)
+}
+
+// UnmarshalXML decodes AbortIncompleteMultipartUpload
+func (a *AbortIncompleteMultipartUpload) UnmarshalXML(d *xml.Decoder, startElement xml.StartElement) error {
+	type abortIncompleteMultipartUploadWrapper AbortIncompleteMultipartUpload
+	var val abortIncompleteMultipartUploadWrapper
+	err := d.DecodeElement(&val, &startElement)
+	if err != nil {
+		return err
+	}
+	*a = AbortIncompleteMultipartUpload(val)
+	a.set = true
+	return nil
+}
+
+// IsNull returns true if DaysAfterInitiation is not set
+func (a AbortIncompleteMultipartUpload) IsNull() bool {
+	return a.DaysAfterInitiation == ExpirationDays(0)
+}
+
+// Validate returns an error with wrong value
+func (a AbortIncompleteMultipartUpload) Validate() error {
+	if !a.set {
+		return nil
+	}
+	if a.DaysAfterInitiation <= 0 {
+		return errXMLNotWellFormed
+	}
+	return nil
+}
+
+// AbortIncompleteMultipartUploadAfter returns the age after which an
+// incomplete multipart upload on the given object name must be aborted,
+// picking the earliest applicable DaysAfterInitiation among all enabled
+// rules. Returns 0 if no rule applies.
+func (lc Lifecycle) AbortIncompleteMultipartUploadAfter(objName string) time.Duration {
+	var days int
+	for _, rule := range lc.Rules {
+		if rule.Status == Disabled || rule.AbortIncompleteMultipartUpload.IsNull() {
+			continue
+		}
+		if !strings.HasPrefix(objName, rule.GetPrefix()) {
+			continue
+		}
+		if d := int(rule.AbortIncompleteMultipartUpload.DaysAfterInitiation); days == 0 || d < days {
+			days = d
+		}
+	}
+	return time.Duration(days) * 24 * time.Hour
+}
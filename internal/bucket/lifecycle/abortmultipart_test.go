@@ -0,0 +1,106 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package lifecycle
+
+import (
+	"bytes"
+	"testing"
+	"time"
+)
+
+func TestAbortIncompleteMultipartUpload(t *testing.T) {
+	testCases := []struct {
+		inputConfig           string
+		expectedParsingErr    error
+		expectedValidationErr error
+	}{
+		{ // Only an AbortIncompleteMultipartUpload action
+			inputConfig:           `<LifecycleConfiguration><Rule><ID>rule</ID><Status>Enabled</Status><Filter><Prefix>prefix</Prefix></Filter><AbortIncompleteMultipartUpload><DaysAfterInitiation>7</DaysAfterInitiation></AbortIncompleteMultipartUpload></Rule></LifecycleConfiguration>`,
+			expectedParsingErr:    nil,
+			expectedValidationErr: nil,
+		},
+		{ // Zero days
+			inputConfig:           `<LifecycleConfiguration><Rule><ID>rule</ID><Status>Enabled</Status><Filter><Prefix>prefix</Prefix></Filter><AbortIncompleteMultipartUpload><DaysAfterInitiation>0</DaysAfterInitiation></AbortIncompleteMultipartUpload></Rule></LifecycleConfiguration>`,
+			expectedParsingErr:    errLifecycleInvalidDays,
+			expectedValidationErr: nil,
+		},
+		{ // Missing days
+			inputConfig:           `<LifecycleConfiguration><Rule><ID>rule</ID><Status>Enabled</Status><Filter><Prefix>prefix</Prefix></Filter><AbortIncompleteMultipartUpload></AbortIncompleteMultipartUpload></Rule></LifecycleConfiguration>`,
+			expectedParsingErr:    nil,
+			expectedValidationErr: errXMLNotWellFormed,
+		},
+		{ // Tag filters never match incomplete uploads
+			inputConfig:           `<LifecycleConfiguration><Rule><ID>rule</ID><Status>Enabled</Status><Filter><Tag><Key>key</Key><Value>val</Value></Tag></Filter><AbortIncompleteMultipartUpload><DaysAfterInitiation>7</DaysAfterInitiation></AbortIncompleteMultipartUpload></Rule></LifecycleConfiguration>`,
+			expectedParsingErr:    nil,
+			expectedValidationErr: errAbortMultipartWithTags,
+		},
+	}
+
+	for i, tc := range testCases {
+		lc, err := ParseLifecycleConfig(bytes.NewReader([]byte(tc.inputConfig)))
+		if err != tc.expectedParsingErr {
+			t.Fatalf("%d: Expected parsing error %v but got %v", i+1, tc.expectedParsingErr, err)
+		}
+		if err != nil {
+			continue
+		}
+		if err = lc.Validate(); err != tc.expectedValidationErr {
+			t.Fatalf("%d: Expected validation error %v but got %v", i+1, tc.expectedValidationErr, err)
+		}
+	}
+}
+
+func TestAbortIncompleteMultipartUploadAfter(t *testing.T) {
+	lc := Lifecycle{
+		Rules: []Rule{
+			{
+				ID:                             "rule-1",
+				Status:                         Enabled,
+				Filter:                         Filter{Prefix: Prefix{string: "logs/", set: true}},
+				AbortIncompleteMultipartUpload: AbortIncompleteMultipartUpload{DaysAfterInitiation: 7, set: true},
+			},
+			{
+				ID:                             "rule-2",
+				Status:                         Enabled,
+				Filter:                         Filter{Prefix: Prefix{string: "logs/tmp/", set: true}},
+				AbortIncompleteMultipartUpload: AbortIncompleteMultipartUpload{DaysAfterInitiation: 1, set: true},
+			},
+			{
+				ID:                             "rule-3",
+				Status:                         Disabled,
+				Filter:                         Filter{Prefix: Prefix{string: "data/", set: true}},
+				AbortIncompleteMultipartUpload: AbortIncompleteMultipartUpload{DaysAfterInitiation: 1, set: true},
+			},
+		},
+	}
+
+	testCases := []struct {
+		object   string
+		expected time.Duration
+	}{
+		{"logs/a.log", 7 * 24 * time.Hour},
+		{"logs/tmp/a.log", 24 * time.Hour},
+		{"data/a.csv", 0},
+		{"other", 0},
+	}
+	for _, tc := range testCases {
+		if got := lc.AbortIncompleteMultipartUploadAfter(tc.object); got != tc.expected {
+			t.Errorf("%s: expected %v but got %v", tc.object, tc.expected, got)
+		}
+	}
+}
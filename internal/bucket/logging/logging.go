@@ -0,0 +1,153 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+package logging
+
+import (
+	"encoding/xml"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var errNoTargetBucket = Errorf("LoggingEnabled must have a TargetBucket")
+
+// LoggingEnabled - where the access logs of a bucket are delivered,
+// objects named with TargetPrefix in TargetBucket.
+type LoggingEnabled struct {
+	TargetBucket string `xml:"TargetBucket"`
+	TargetPrefix string `xml:"TargetPrefix"`
+}
+
+// Config - server access logging configuration of a bucket,
+// logging is disabled without LoggingEnabled.
+type Config struct {
+	XMLNS          string          `xml:"xmlns,attr,omitempty"`
+	XMLName        xml.Name        `xml:"BucketLoggingStatus"`
+	LoggingEnabled *LoggingEnabled `xml:"LoggingEnabled,omitempty"`
+}
+
+// Enabled returns whether access logging is enabled.
+func (c Config) Enabled() bool {
+	return c.LoggingEnabled != nil
+}
+
+// Validate - validates the logging configuration.
+func (c Config) Validate() error {
+	if c.LoggingEnabled != nil && c.LoggingEnabled.TargetBucket == "" {
+		return errNoTargetBucket
+	}
+	return nil
+}
+
+// ParseConfig - parses data in given reader to logging configuration.
+func ParseConfig(reader io.Reader) (*Config, error) {
+	var c Config
+	if err := xml.NewDecoder(reader).Decode(&c); err != nil {
+		return nil, err
+	}
+	if err := c.Validate(); err != nil {
+		return nil, err
+	}
+	return &c, nil
+}
+
+// Entry - a record of the access log of a bucket, for one request.
+type Entry struct {
+	BucketOwner      string
+	Bucket           string
+	Time             time.Time
+	RemoteIP         string
+	Requester        string
+	RequestID        string
+	Operation        string
+	Key              string
+	RequestURI       string
+	HTTPStatus       int
+	ErrorCode        string
+	BytesSent        int64
+	ObjectSize       int64
+	TotalTime        time.Duration
+	TurnAroundTime   time.Duration
+	Referer          string
+	UserAgent        string
+	VersionID        string
+	HostID           string
+	SignatureVersion string
+	CipherSuite      string
+	AuthType         string
+	HostHeader       string
+	TLSVersion       string
+}
+
+// field returns s, or "-" if it is empty.
+func field(s string) string {
+	if s == "" {
+		return "-"
+	}
+	return s
+}
+
+// quoted returns s as a quoted field, "-" if it is empty.
+func quoted(s string) string {
+	if s == "" {
+		return "-"
+	}
+	return `"` + strings.ReplaceAll(s, `"`, `\"`) + `"`
+}
+
+// number returns n, or "-" if it is not positive.
+func number(n int64) string {
+	if n <= 0 {
+		return "-"
+	}
+	return strconv.FormatInt(n, 10)
+}
+
+// String returns the entry in the Amazon S3 server access log format,
+// without trailing newline.
+func (e Entry) String() string {
+	fields := []string{
+		field(e.BucketOwner),
+		field(e.Bucket),
+		e.Time.UTC().Format("[02/Jan/2006:15:04:05 -0700]"),
+		field(e.RemoteIP),
+		field(e.Requester),
+		field(e.RequestID),
+		field(e.Operation),
+		field(e.Key),
+		quoted(e.RequestURI),
+		number(int64(e.HTTPStatus)),
+		field(e.ErrorCode),
+		number(e.BytesSent),
+		number(e.ObjectSize),
+		strconv.FormatInt(e.TotalTime.Milliseconds(), 10),
+		strconv.FormatInt(e.TurnAroundTime.Milliseconds(), 10),
+		quoted(e.Referer),
+		quoted(e.UserAgent),
+		field(e.VersionID),
+		field(e.HostID),
+		field(e.SignatureVersion),
+		field(e.CipherSuite),
+		field(e.AuthType),
+		field(e.HostHeader),
+		field(e.TLSVersion),
+		"-", // Access point ARN
+		"-", // ACL required
+	}
+	return strings.Join(fields, " ")
+}
@@ -0,0 +1,80 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+package logging
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseConfig(t *testing.T) {
+	testCases := []struct {
+		input   string
+		enabled bool
+		err     error
+	}{
+		{
+			input:   `<BucketLoggingStatus xmlns="http://doc.s3.amazonaws.com/2006-03-01"><LoggingEnabled><TargetBucket>logs</TargetBucket><TargetPrefix>photos/</TargetPrefix></LoggingEnabled></BucketLoggingStatus>`,
+			enabled: true,
+		},
+		{
+			input: `<BucketLoggingStatus xmlns="http://doc.s3.amazonaws.com/2006-03-01" />`,
+		},
+		{
+			input: `<BucketLoggingStatus><LoggingEnabled><TargetPrefix>photos/</TargetPrefix></LoggingEnabled></BucketLoggingStatus>`,
+			err:   errNoTargetBucket,
+		},
+	}
+	for i, tc := range testCases {
+		config, err := ParseConfig(strings.NewReader(tc.input))
+		if (err == nil) != (tc.err == nil) || (err != nil && err.Error() != tc.err.Error()) {
+			t.Errorf("Test %d: expected error %v, got %v", i+1, tc.err, err)
+			continue
+		}
+		if err == nil && config.Enabled() != tc.enabled {
+			t.Errorf("Test %d: expected enabled %v, got %v", i+1, tc.enabled, config.Enabled())
+		}
+	}
+}
+
+func TestEntryString(t *testing.T) {
+	entry := Entry{
+		BucketOwner:      "79a59df900b949e5",
+		Bucket:           "photos",
+		Time:             time.Date(2022, time.February, 6, 0, 0, 38, 0, time.UTC),
+		RemoteIP:         "192.0.2.3",
+		Requester:        "minio",
+		RequestID:        "16D1B5D3C3C2A0F1",
+		Operation:        "REST.GET.OBJECT",
+		Key:              "2022/cat.jpg",
+		RequestURI:       "GET /photos/2022/cat.jpg HTTP/1.1",
+		HTTPStatus:       200,
+		BytesSent:        2662992,
+		ObjectSize:       3462992,
+		TotalTime:        70 * time.Millisecond,
+		TurnAroundTime:   10 * time.Millisecond,
+		UserAgent:        `curl/7.79 "test"`,
+		SignatureVersion: "SigV4",
+		AuthType:         "AuthHeader",
+		HostHeader:       "minio.example.com:9000",
+	}
+	want := `79a59df900b949e5 photos [06/Feb/2022:00:00:38 +0000] 192.0.2.3 minio 16D1B5D3C3C2A0F1 REST.GET.OBJECT 2022/cat.jpg "GET /photos/2022/cat.jpg HTTP/1.1" 200 - 2662992 3462992 70 10 - "curl/7.79 \"test\"" - - SigV4 - AuthHeader minio.example.com:9000 - - -`
+	if got := entry.String(); got != want {
+		t.Errorf("expected\n%s\ngot\n%s", want, got)
+	}
+}
@@ -0,0 +1,251 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+package website
+
+import (
+	"encoding/xml"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+const (
+	// MaxRoutingRules - maximum number of routing rules per bucket.
+	MaxRoutingRules = 50
+)
+
+var (
+	errNoIndexDocument      = Errorf("website configuration must have an IndexDocument or RedirectAllRequestsTo")
+	errRedirectAllExclusive = Errorf("RedirectAllRequestsTo cannot be set with any other website configuration")
+	errInvalidSuffix        = Errorf("IndexDocument Suffix must be non-empty and cannot contain a slash")
+	errEmptyErrorKey        = Errorf("ErrorDocument Key cannot be empty")
+	errEmptyHostName        = Errorf("RedirectAllRequestsTo HostName cannot be empty")
+	errInvalidProtocol      = Errorf("Protocol must be http or https")
+	errTooManyRoutingRules  = Errorf("a bucket can have at most 50 routing rules")
+	errEmptyRedirect        = Errorf("routing rule Redirect must set at least one of HostName, HttpRedirectCode, Protocol, ReplaceKeyPrefixWith or ReplaceKeyWith")
+	errReplaceKeyExclusive  = Errorf("ReplaceKeyPrefixWith and ReplaceKeyWith cannot be both set")
+	errInvalidRedirectCode  = Errorf("HttpRedirectCode must be a 3xx status code")
+	errInvalidErrorCode     = Errorf("HttpErrorCodeReturnedEquals must be a 4xx or 5xx status code")
+)
+
+// IndexDocument - the object returned for requests to the root of
+// the website or to a folder, the key ending with a slash.
+type IndexDocument struct {
+	Suffix string `xml:"Suffix"`
+}
+
+// ErrorDocument - the object returned in place of the error page.
+type ErrorDocument struct {
+	Key string `xml:"Key"`
+}
+
+// RedirectAllRequestsTo - the host all requests are redirected to.
+type RedirectAllRequestsTo struct {
+	HostName string `xml:"HostName"`
+	Protocol string `xml:"Protocol,omitempty"`
+}
+
+// Condition - the requests a routing rule applies to.
+type Condition struct {
+	KeyPrefixEquals             string `xml:"KeyPrefixEquals,omitempty"`
+	HTTPErrorCodeReturnedEquals string `xml:"HttpErrorCodeReturnedEquals,omitempty"`
+}
+
+// Redirect - where a routing rule redirects requests to, the
+// fields not set are taken from the request.
+type Redirect struct {
+	HostName             string `xml:"HostName,omitempty"`
+	HTTPRedirectCode     string `xml:"HttpRedirectCode,omitempty"`
+	Protocol             string `xml:"Protocol,omitempty"`
+	ReplaceKeyPrefixWith string `xml:"ReplaceKeyPrefixWith,omitempty"`
+	ReplaceKeyWith       string `xml:"ReplaceKeyWith,omitempty"`
+}
+
+// RoutingRule - redirects the requests matching its condition.
+type RoutingRule struct {
+	Condition *Condition `xml:"Condition,omitempty"`
+	Redirect  Redirect   `xml:"Redirect"`
+}
+
+func validProtocol(protocol string) bool {
+	return protocol == "" || protocol == "http" || protocol == "https"
+}
+
+// Validate - validates the routing rule.
+func (r RoutingRule) Validate() error {
+	if r.Condition != nil && r.Condition.HTTPErrorCodeReturnedEquals != "" {
+		code, err := strconv.Atoi(r.Condition.HTTPErrorCodeReturnedEquals)
+		if err != nil || code < 400 || code > 599 {
+			return errInvalidErrorCode
+		}
+	}
+	rd := r.Redirect
+	if rd == (Redirect{}) {
+		return errEmptyRedirect
+	}
+	if rd.ReplaceKeyPrefixWith != "" && rd.ReplaceKeyWith != "" {
+		return errReplaceKeyExclusive
+	}
+	if rd.HTTPRedirectCode != "" {
+		code, err := strconv.Atoi(rd.HTTPRedirectCode)
+		if err != nil || code < 300 || code > 399 {
+			return errInvalidRedirectCode
+		}
+	}
+	if !validProtocol(rd.Protocol) {
+		return errInvalidProtocol
+	}
+	return nil
+}
+
+// matches returns whether the rule applies to a request for key
+// which failed with the errorCode status code, 0 if the object has
+// not been looked up yet. Rules without an error code condition
+// apply before the lookup, the others only to failed requests.
+func (r RoutingRule) matches(key string, errorCode int) bool {
+	var prefix, code string
+	if r.Condition != nil {
+		prefix, code = r.Condition.KeyPrefixEquals, r.Condition.HTTPErrorCodeReturnedEquals
+	}
+	if !strings.HasPrefix(key, prefix) {
+		return false
+	}
+	if code == "" {
+		return errorCode == 0
+	}
+	return code == strconv.Itoa(errorCode)
+}
+
+// Location returns the URL a request for key is redirected to, with
+// the host and protocol of the request used unless the rule sets them,
+// and the status code of the redirect.
+func (r RoutingRule) Location(key, host, protocol string) (string, int) {
+	rd := r.Redirect
+	if rd.HostName != "" {
+		host = rd.HostName
+	}
+	if rd.Protocol != "" {
+		protocol = rd.Protocol
+	}
+	switch {
+	case rd.ReplaceKeyWith != "":
+		key = rd.ReplaceKeyWith
+	case rd.ReplaceKeyPrefixWith != "":
+		var prefix string
+		if r.Condition != nil {
+			prefix = r.Condition.KeyPrefixEquals
+		}
+		key = rd.ReplaceKeyPrefixWith + strings.TrimPrefix(key, prefix)
+	}
+	code := http.StatusMovedPermanently
+	if rd.HTTPRedirectCode != "" {
+		code, _ = strconv.Atoi(rd.HTTPRedirectCode)
+	}
+	return (&url.URL{Scheme: protocol, Host: host, Path: "/" + key}).String(), code
+}
+
+// Config - website configuration of a bucket.
+type Config struct {
+	XMLNS                 string                 `xml:"xmlns,attr,omitempty"`
+	XMLName               xml.Name               `xml:"WebsiteConfiguration"`
+	IndexDocument         *IndexDocument         `xml:"IndexDocument,omitempty"`
+	ErrorDocument         *ErrorDocument         `xml:"ErrorDocument,omitempty"`
+	RedirectAllRequestsTo *RedirectAllRequestsTo `xml:"RedirectAllRequestsTo,omitempty"`
+	RoutingRules          []RoutingRule          `xml:"RoutingRules>RoutingRule,omitempty"`
+}
+
+// Validate - validates the website configuration.
+func (c Config) Validate() error {
+	if c.RedirectAllRequestsTo != nil {
+		if c.IndexDocument != nil || c.ErrorDocument != nil || len(c.RoutingRules) > 0 {
+			return errRedirectAllExclusive
+		}
+		if c.RedirectAllRequestsTo.HostName == "" {
+			return errEmptyHostName
+		}
+		if !validProtocol(c.RedirectAllRequestsTo.Protocol) {
+			return errInvalidProtocol
+		}
+		return nil
+	}
+	if c.IndexDocument == nil {
+		return errNoIndexDocument
+	}
+	if c.IndexDocument.Suffix == "" || strings.Contains(c.IndexDocument.Suffix, "/") {
+		return errInvalidSuffix
+	}
+	if c.ErrorDocument != nil && c.ErrorDocument.Key == "" {
+		return errEmptyErrorKey
+	}
+	if len(c.RoutingRules) > MaxRoutingRules {
+		return errTooManyRoutingRules
+	}
+	for _, r := range c.RoutingRules {
+		if err := r.Validate(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// IndexKey returns the key of the object returned for a request for
+// key, the index document of the folder if key is one.
+func (c Config) IndexKey(key string) string {
+	if c.IndexDocument != nil && (key == "" || strings.HasSuffix(key, "/")) {
+		return key + c.IndexDocument.Suffix
+	}
+	return key
+}
+
+// MatchRoutingRule returns the first routing rule applying to a
+// request for key which failed with the errorCode status code, 0 if
+// the object has not been looked up yet, or nil if none applies.
+func (c Config) MatchRoutingRule(key string, errorCode int) *RoutingRule {
+	for i, r := range c.RoutingRules {
+		if r.matches(key, errorCode) {
+			return &c.RoutingRules[i]
+		}
+	}
+	return nil
+}
+
+// RedirectAll returns the URL a request for key is redirected to when
+// all requests are redirected, with the protocol of the request used
+// unless the configuration sets one.
+func (c Config) RedirectAll(key, protocol string) (string, bool) {
+	if c.RedirectAllRequestsTo == nil {
+		return "", false
+	}
+	if c.RedirectAllRequestsTo.Protocol != "" {
+		protocol = c.RedirectAllRequestsTo.Protocol
+	}
+	return (&url.URL{Scheme: protocol, Host: c.RedirectAllRequestsTo.HostName, Path: "/" + key}).String(), true
+}
+
+// ParseConfig - parses data in given reader to website configuration.
+func ParseConfig(reader io.Reader) (*Config, error) {
+	var c Config
+	if err := xml.NewDecoder(reader).Decode(&c); err != nil {
+		return nil, err
+	}
+	if err := c.Validate(); err != nil {
+		return nil, err
+	}
+	return &c, nil
+}
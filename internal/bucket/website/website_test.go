@@ -0,0 +1,153 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+package website
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseConfig(t *testing.T) {
+	testCases := []struct {
+		input string
+		err   error
+	}{
+		{
+			input: `<WebsiteConfiguration>
+				<IndexDocument><Suffix>index.html</Suffix></IndexDocument>
+				<ErrorDocument><Key>error.html</Key></ErrorDocument>
+				<RoutingRules>
+					<RoutingRule>
+						<Condition><KeyPrefixEquals>docs/</KeyPrefixEquals></Condition>
+						<Redirect><ReplaceKeyPrefixWith>documents/</ReplaceKeyPrefixWith></Redirect>
+					</RoutingRule>
+				</RoutingRules>
+			</WebsiteConfiguration>`,
+		},
+		{
+			input: `<WebsiteConfiguration><RedirectAllRequestsTo><HostName>www.example.com</HostName><Protocol>https</Protocol></RedirectAllRequestsTo></WebsiteConfiguration>`,
+		},
+		{
+			input: `<WebsiteConfiguration></WebsiteConfiguration>`,
+			err:   errNoIndexDocument,
+		},
+		{
+			input: `<WebsiteConfiguration><IndexDocument><Suffix>a/index.html</Suffix></IndexDocument></WebsiteConfiguration>`,
+			err:   errInvalidSuffix,
+		},
+		{
+			input: `<WebsiteConfiguration><IndexDocument><Suffix>index.html</Suffix></IndexDocument><ErrorDocument><Key></Key></ErrorDocument></WebsiteConfiguration>`,
+			err:   errEmptyErrorKey,
+		},
+		{
+			input: `<WebsiteConfiguration><IndexDocument><Suffix>index.html</Suffix></IndexDocument><RedirectAllRequestsTo><HostName>www.example.com</HostName></RedirectAllRequestsTo></WebsiteConfiguration>`,
+			err:   errRedirectAllExclusive,
+		},
+		{
+			input: `<WebsiteConfiguration><RedirectAllRequestsTo><HostName>www.example.com</HostName><Protocol>ftp</Protocol></RedirectAllRequestsTo></WebsiteConfiguration>`,
+			err:   errInvalidProtocol,
+		},
+		{
+			input: `<WebsiteConfiguration><IndexDocument><Suffix>index.html</Suffix></IndexDocument><RoutingRules><RoutingRule><Redirect></Redirect></RoutingRule></RoutingRules></WebsiteConfiguration>`,
+			err:   errEmptyRedirect,
+		},
+		{
+			input: `<WebsiteConfiguration><IndexDocument><Suffix>index.html</Suffix></IndexDocument><RoutingRules><RoutingRule><Redirect><ReplaceKeyPrefixWith>a/</ReplaceKeyPrefixWith><ReplaceKeyWith>b</ReplaceKeyWith></Redirect></RoutingRule></RoutingRules></WebsiteConfiguration>`,
+			err:   errReplaceKeyExclusive,
+		},
+		{
+			input: `<WebsiteConfiguration><IndexDocument><Suffix>index.html</Suffix></IndexDocument><RoutingRules><RoutingRule><Redirect><HttpRedirectCode>200</HttpRedirectCode></Redirect></RoutingRule></RoutingRules></WebsiteConfiguration>`,
+			err:   errInvalidRedirectCode,
+		},
+		{
+			input: `<WebsiteConfiguration><IndexDocument><Suffix>index.html</Suffix></IndexDocument><RoutingRules><RoutingRule><Condition><HttpErrorCodeReturnedEquals>301</HttpErrorCodeReturnedEquals></Condition><Redirect><HostName>example.com</HostName></Redirect></RoutingRule></RoutingRules></WebsiteConfiguration>`,
+			err:   errInvalidErrorCode,
+		},
+		{
+			input: `<WebsiteConfiguration><IndexDocument><Suffix>index.html</Suffix></IndexDocument><RoutingRules>` + strings.Repeat(`<RoutingRule><Redirect><HostName>example.com</HostName></Redirect></RoutingRule>`, MaxRoutingRules+1) + `</RoutingRules></WebsiteConfiguration>`,
+			err:   errTooManyRoutingRules,
+		},
+	}
+	for i, tc := range testCases {
+		_, err := ParseConfig(strings.NewReader(tc.input))
+		if (err == nil) != (tc.err == nil) || (err != nil && err.Error() != tc.err.Error()) {
+			t.Errorf("Test %d: expected error %v, got %v", i+1, tc.err, err)
+		}
+	}
+}
+
+func TestRouting(t *testing.T) {
+	config, err := ParseConfig(strings.NewReader(`<WebsiteConfiguration>
+		<IndexDocument><Suffix>index.html</Suffix></IndexDocument>
+		<RoutingRules>
+			<RoutingRule>
+				<Condition><KeyPrefixEquals>docs/</KeyPrefixEquals></Condition>
+				<Redirect><ReplaceKeyPrefixWith>documents/</ReplaceKeyPrefixWith></Redirect>
+			</RoutingRule>
+			<RoutingRule>
+				<Condition><HttpErrorCodeReturnedEquals>404</HttpErrorCodeReturnedEquals></Condition>
+				<Redirect><HostName>fallback.example.com</HostName><Protocol>https</Protocol><ReplaceKeyWith>missing.html</ReplaceKeyWith><HttpRedirectCode>302</HttpRedirectCode></Redirect>
+			</RoutingRule>
+		</RoutingRules>
+	</WebsiteConfiguration>`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for key, indexKey := range map[string]string{"": "index.html", "about/": "about/index.html", "about": "about", "a.css": "a.css"} {
+		if got := config.IndexKey(key); got != indexKey {
+			t.Errorf("expected index key %q for %q, got %q", indexKey, key, got)
+		}
+	}
+
+	testCases := []struct {
+		key       string
+		errorCode int
+		location  string
+		code      int
+	}{
+		{"docs/guide.html", 0, "http://site.example.com:9000/documents/guide.html", 301},
+		{"docs/guide.html", 404, "https://fallback.example.com/missing.html", 302},
+		{"about.html", 0, "", 0},
+		{"about.html", 403, "", 0},
+		{"about.html", 404, "https://fallback.example.com/missing.html", 302},
+	}
+	for i, tc := range testCases {
+		rule := config.MatchRoutingRule(tc.key, tc.errorCode)
+		if rule == nil {
+			if tc.location != "" {
+				t.Errorf("Test %d: expected a routing rule", i+1)
+			}
+			continue
+		}
+		location, code := rule.Location(tc.key, "site.example.com:9000", "http")
+		if location != tc.location || code != tc.code {
+			t.Errorf("Test %d: expected redirect %d %s, got %d %s", i+1, tc.code, tc.location, code, location)
+		}
+	}
+
+	redirect, err := ParseConfig(strings.NewReader(`<WebsiteConfiguration><RedirectAllRequestsTo><HostName>www.example.com</HostName></RedirectAllRequestsTo></WebsiteConfiguration>`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if location, ok := redirect.RedirectAll("a b.html", "https"); !ok || location != "https://www.example.com/a%20b.html" {
+		t.Errorf("unexpected redirect %v %s", ok, location)
+	}
+	if _, ok := config.RedirectAll("", "http"); ok {
+		t.Error("expected no redirect of all requests")
+	}
+}
@@ -20,6 +20,7 @@ package api
 import (
 	"encoding/json"
 	"errors"
+	"fmt"
 	"runtime"
 	"strconv"
 	"strings"
@@ -45,6 +46,10 @@ const (
 	apiDeleteCleanupInterval       = "delete_cleanup_interval"
 	apiDisableODirect              = "disable_odirect"
 	apiGzipObjects                 = "gzip_objects"
+	apiRequestsRate                = "requests_rate"
+	apiRequestsRateAPI             = "requests_rate_api"
+	apiRequestsMaxAPI              = "requests_max_api"
+	apiCopyConcurrency             = "copy_concurrency"
 
 	EnvAPIRequestsMax              = "MINIO_API_REQUESTS_MAX"
 	EnvAPIRequestsDeadline         = "MINIO_API_REQUESTS_DEADLINE"
@@ -63,6 +68,10 @@ const (
 	EnvDeleteCleanupInterval          = "MINIO_DELETE_CLEANUP_INTERVAL"
 	EnvAPIDisableODirect              = "MINIO_API_DISABLE_ODIRECT"
 	EnvAPIGzipObjects                 = "MINIO_API_GZIP_OBJECTS"
+	EnvAPIRequestsRate                = "MINIO_API_REQUESTS_RATE"
+	EnvAPIRequestsRateAPI             = "MINIO_API_REQUESTS_RATE_API"
+	EnvAPIRequestsMaxAPI              = "MINIO_API_REQUESTS_MAX_API"
+	EnvAPICopyConcurrency             = "MINIO_API_COPY_CONCURRENCY"
 )
 
 // Deprecated key and ENVs
@@ -130,6 +139,22 @@ var (
 			Key:   apiGzipObjects,
 			Value: "off",
 		},
+		config.KV{
+			Key:   apiRequestsRate,
+			Value: "0",
+		},
+		config.KV{
+			Key:   apiRequestsRateAPI,
+			Value: "",
+		},
+		config.KV{
+			Key:   apiRequestsMaxAPI,
+			Value: "",
+		},
+		config.KV{
+			Key:   apiCopyConcurrency,
+			Value: "1",
+		},
 	}
 )
 
@@ -149,6 +174,19 @@ type Config struct {
 	DeleteCleanupInterval       time.Duration `json:"delete_cleanup_interval"`
 	DisableODirect              bool          `json:"disable_odirect"`
 	GzipObjects                 bool          `json:"gzip_objects"`
+
+	// Requests per second across the cluster, overall and per
+	// API name, zero or missing means unlimited.
+	RequestsRate    int            `json:"requests_rate"`
+	RequestsRateAPI map[string]int `json:"requests_rate_api"`
+
+	// Concurrent requests across the cluster per
+	// API name, missing means only RequestsMax applies.
+	RequestsMaxAPI map[string]int `json:"requests_max_api"`
+
+	// Parts copied in parallel by a server side copy
+	// of a large object, one copies sequentially.
+	CopyConcurrency int `json:"copy_concurrency"`
 }
 
 // UnmarshalJSON - Validate SS and RRS parity when unmarshalling JSON.
@@ -256,6 +294,32 @@ func LookupConfig(kvs config.KVS) (cfg Config, err error) {
 
 	gzipObjects := env.Get(EnvAPIGzipObjects, kvs.Get(apiGzipObjects)) == config.EnableOn
 
+	requestsRate, err := strconv.Atoi(env.Get(EnvAPIRequestsRate, kvs.GetWithDefault(apiRequestsRate, DefaultKVS)))
+	if err != nil {
+		return cfg, err
+	}
+	if requestsRate < 0 {
+		return cfg, errors.New("invalid API requests rate value")
+	}
+
+	requestsRateAPI, err := ParseAPILimits(env.Get(EnvAPIRequestsRateAPI, kvs.Get(apiRequestsRateAPI)))
+	if err != nil {
+		return cfg, fmt.Errorf("invalid API requests rate per API value: %w", err)
+	}
+
+	requestsMaxAPI, err := ParseAPILimits(env.Get(EnvAPIRequestsMaxAPI, kvs.Get(apiRequestsMaxAPI)))
+	if err != nil {
+		return cfg, fmt.Errorf("invalid API requests max per API value: %w", err)
+	}
+
+	copyConcurrency, err := strconv.Atoi(env.Get(EnvAPICopyConcurrency, kvs.GetWithDefault(apiCopyConcurrency, DefaultKVS)))
+	if err != nil {
+		return cfg, err
+	}
+	if copyConcurrency < 1 {
+		return cfg, errors.New("invalid API copy concurrency value")
+	}
+
 	return Config{
 		RequestsMax:                 requestsMax,
 		RequestsDeadline:            requestsDeadline,
@@ -271,5 +335,32 @@ func LookupConfig(kvs config.KVS) (cfg Config, err error) {
 		DeleteCleanupInterval:       deleteCleanupInterval,
 		DisableODirect:              disableODirect,
 		GzipObjects:                 gzipObjects,
+		RequestsRate:                requestsRate,
+		RequestsRateAPI:             requestsRateAPI,
+		RequestsMaxAPI:              requestsMaxAPI,
+		CopyConcurrency:             copyConcurrency,
 	}, nil
 }
+
+// ParseAPILimits parses a comma separated list of limits per API
+// name, e.g. "listobjectsv2=200,putobject=2000". API names are
+// case insensitive and returned in lower case.
+func ParseAPILimits(s string) (map[string]int, error) {
+	limits := make(map[string]int)
+	for _, entry := range strings.Split(s, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		i := strings.Index(entry, "=")
+		if i <= 0 {
+			return nil, fmt.Errorf("expected api=limit, got %q", entry)
+		}
+		limit, err := strconv.Atoi(entry[i+1:])
+		if err != nil || limit <= 0 {
+			return nil, fmt.Errorf("invalid limit for %s: %q", entry[:i], entry[i+1:])
+		}
+		limits[strings.ToLower(entry[:i])] = limit
+	}
+	return limits, nil
+}
@@ -98,6 +98,30 @@ var (
 			Optional:    true,
 			Type:        "duration",
 		},
+		config.HelpKV{
+			Key:         apiRequestsRate,
+			Description: `set the maximum number of requests per second, excess requests fail with "SlowDown"` + defaultHelpPostfix(apiRequestsRate),
+			Optional:    true,
+			Type:        "number",
+		},
+		config.HelpKV{
+			Key:         apiRequestsRateAPI,
+			Description: `set comma separated list of maximum requests per second by API e.g. "listobjectsv2=100,putobject=1000"`,
+			Optional:    true,
+			Type:        "csv",
+		},
+		config.HelpKV{
+			Key:         apiRequestsMaxAPI,
+			Description: `set comma separated list of maximum concurrent requests by API e.g. "listobjectsv2=200,putobject=2000"`,
+			Optional:    true,
+			Type:        "csv",
+		},
+		config.HelpKV{
+			Key:         apiCopyConcurrency,
+			Description: `set the number of parts copied in parallel by a server side copy of a large object` + defaultHelpPostfix(apiCopyConcurrency),
+			Optional:    true,
+			Type:        "number",
+		},
 		config.HelpKV{
 			Key:         apiDisableODirect,
 			Description: "set to disable O_DIRECT for reads under special conditions. NOTE: it is not recommended to disable O_DIRECT without prior testing." + defaultHelpPostfix(apiDisableODirect),
@@ -0,0 +1,301 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package auditfilter
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/minio/minio/internal/config"
+	"github.com/minio/minio/internal/logger/message/audit"
+	"github.com/minio/pkg/env"
+	"github.com/minio/pkg/wildcard"
+)
+
+// Audit filter related keys
+const (
+	DropFields  = "drop_fields"
+	HashFields  = "hash_fields"
+	HashObjects = "hash_objects"
+	SampleAPIs  = "sample_apis"
+	SampleRate  = "sample_rate"
+
+	EnvDropFields  = "MINIO_AUDIT_FILTER_DROP_FIELDS"
+	EnvHashFields  = "MINIO_AUDIT_FILTER_HASH_FIELDS"
+	EnvHashObjects = "MINIO_AUDIT_FILTER_HASH_OBJECTS"
+	EnvSampleAPIs  = "MINIO_AUDIT_FILTER_SAMPLE_APIS"
+	EnvSampleRate  = "MINIO_AUDIT_FILTER_SAMPLE_RATE"
+)
+
+// DefaultKVS - default KV config for audit filter settings
+var DefaultKVS = config.KVS{
+	config.KV{
+		Key:   DropFields,
+		Value: "",
+	},
+	config.KV{
+		Key:   HashFields,
+		Value: "",
+	},
+	config.KV{
+		Key:   HashObjects,
+		Value: "",
+	},
+	config.KV{
+		Key:   SampleAPIs,
+		Value: "GetObject,HeadObject,ListObjectsV1,ListObjectsV2,ListObjectVersions",
+	},
+	config.KV{
+		Key:   SampleRate,
+		Value: "1",
+	},
+}
+
+// Names of the audit entry fields which may be dropped or hashed,
+// the map fields also accept a single key e.g. 'requestHeader.Authorization'.
+const (
+	fieldRemoteHost = "remotehost"
+	fieldUserAgent  = "userAgent"
+	fieldObject     = "object"
+	fieldError      = "error"
+	fieldReqClaims  = "requestClaims"
+	fieldReqQuery   = "requestQuery"
+	fieldReqHeader  = "requestHeader"
+	fieldRespHeader = "responseHeader"
+	fieldTags       = "tags"
+)
+
+// field is an audit entry field, key is empty for the
+// whole field or the key of a single map entry.
+type field struct {
+	name string
+	key  string
+}
+
+func parseFields(key, value string) ([]field, error) {
+	var fields []field
+	for _, s := range strings.Split(value, config.ValueSeparator) {
+		if s = strings.TrimSpace(s); s == "" {
+			continue
+		}
+		f := field{name: s}
+		if i := strings.IndexByte(s, '.'); i >= 0 {
+			f = field{name: s[:i], key: s[i+1:]}
+		}
+		switch f.name {
+		case fieldRemoteHost, fieldUserAgent, fieldObject, fieldError:
+			if f.key != "" {
+				return nil, fmt.Errorf("'audit_filter:%s' value invalid: field '%s' has no keys", key, f.name)
+			}
+		case fieldReqClaims, fieldReqQuery, fieldReqHeader, fieldRespHeader, fieldTags:
+		default:
+			return nil, fmt.Errorf("'audit_filter:%s' value invalid: unknown field '%s'", key, f.name)
+		}
+		fields = append(fields, f)
+	}
+	return fields, nil
+}
+
+// Config represents the redaction and the sampling
+// applied to the audit entries before they are sent.
+type Config struct {
+	// Fields removed from the audit entries.
+	DropFields []field `json:"-"`
+
+	// Fields replaced by their keyed hash.
+	HashFields []field `json:"-"`
+
+	// Patterns of the 'bucket/object' names
+	// replaced by their keyed hash.
+	HashObjects []string `json:"hashObjects"`
+
+	// Read APIs logged at the sample rate,
+	// between 0 and 1.
+	SampleAPIs map[string]bool `json:"sampleAPIs"`
+	SampleRate float64         `json:"sampleRate"`
+}
+
+// Enabled returns whether the audit entries are modified or sampled.
+func (cfg Config) Enabled() bool {
+	return len(cfg.DropFields) > 0 || len(cfg.HashFields) > 0 ||
+		len(cfg.HashObjects) > 0 || (len(cfg.SampleAPIs) > 0 && cfg.SampleRate < 1)
+}
+
+// Filter redacts the audit entry, it returns false if the
+// entry is sampled out and must not be sent. Failed requests
+// are never sampled out.
+func (cfg Config) Filter(entry *audit.Entry) bool {
+	if cfg.SampleRate < 1 && cfg.SampleAPIs[entry.API.Name] &&
+		entry.API.StatusCode < http.StatusBadRequest && rand.Float64() >= cfg.SampleRate {
+		return false
+	}
+
+	// The hashes are keyed by the deployment ID, values
+	// with few possibilities such as IP addresses cannot
+	// be recovered by hashing all of them.
+	hash := func(s string) string {
+		if s == "" {
+			return s
+		}
+		mac := hmac.New(sha256.New, []byte(entry.DeploymentID))
+		mac.Write([]byte(s))
+		return hex.EncodeToString(mac.Sum(nil))
+	}
+
+	if len(cfg.HashObjects) > 0 {
+		hashObject := func(object string) string {
+			for _, pattern := range cfg.HashObjects {
+				if wildcard.Match(pattern, entry.API.Bucket+"/"+object) {
+					return hash(object)
+				}
+			}
+			return object
+		}
+		entry.API.Object = hashObject(entry.API.Object)
+		for i := range entry.API.Objects {
+			entry.API.Objects[i].ObjectName = hashObject(entry.API.Objects[i].ObjectName)
+		}
+	}
+	for _, f := range cfg.HashFields {
+		f.apply(entry, hash)
+	}
+	for _, f := range cfg.DropFields {
+		f.apply(entry, nil)
+	}
+	return true
+}
+
+// apply replaces the field values of the entry by their hash,
+// or removes the field if hash is nil.
+func (f field) apply(entry *audit.Entry, hash func(string) string) {
+	str := func(s *string) {
+		if hash == nil {
+			*s = ""
+		} else {
+			*s = hash(*s)
+		}
+	}
+	strMap := func(m map[string]string) {
+		for k, v := range m {
+			if f.key != "" && !strings.EqualFold(k, f.key) {
+				continue
+			}
+			if hash == nil {
+				delete(m, k)
+			} else {
+				m[k] = hash(v)
+			}
+		}
+	}
+	anyMap := func(m map[string]interface{}) {
+		for k, v := range m {
+			if f.key != "" && !strings.EqualFold(k, f.key) {
+				continue
+			}
+			if hash == nil {
+				delete(m, k)
+			} else {
+				m[k] = hash(fmt.Sprint(v))
+			}
+		}
+	}
+
+	switch f.name {
+	case fieldRemoteHost:
+		str(&entry.RemoteHost)
+	case fieldUserAgent:
+		str(&entry.UserAgent)
+	case fieldError:
+		str(&entry.Error)
+	case fieldObject:
+		str(&entry.API.Object)
+		for i := range entry.API.Objects {
+			str(&entry.API.Objects[i].ObjectName)
+		}
+		if hash == nil {
+			entry.API.Objects = nil
+		}
+	case fieldReqClaims:
+		anyMap(entry.ReqClaims)
+	case fieldReqQuery:
+		strMap(entry.ReqQuery)
+	case fieldReqHeader:
+		strMap(entry.ReqHeader)
+	case fieldRespHeader:
+		strMap(entry.RespHeader)
+	case fieldTags:
+		anyMap(entry.Tags)
+	}
+}
+
+// isReadAPI returns whether the API only reads, only the
+// requests of such APIs may be sampled.
+func isReadAPI(api string) bool {
+	for _, prefix := range []string{"Get", "Head", "List", "Select"} {
+		if strings.HasPrefix(api, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// LookupConfig - lookup config and override with valid environment settings if any.
+func LookupConfig(kvs config.KVS) (cfg Config, err error) {
+	if err = config.CheckValidKeys(config.AuditFilterSubSys, kvs, DefaultKVS); err != nil {
+		return cfg, err
+	}
+
+	cfg.DropFields, err = parseFields(DropFields, env.Get(EnvDropFields, kvs.GetWithDefault(DropFields, DefaultKVS)))
+	if err != nil {
+		return cfg, err
+	}
+	cfg.HashFields, err = parseFields(HashFields, env.Get(EnvHashFields, kvs.GetWithDefault(HashFields, DefaultKVS)))
+	if err != nil {
+		return cfg, err
+	}
+	for _, pattern := range strings.Split(env.Get(EnvHashObjects, kvs.GetWithDefault(HashObjects, DefaultKVS)), config.ValueSeparator) {
+		if pattern = strings.TrimSpace(pattern); pattern != "" {
+			cfg.HashObjects = append(cfg.HashObjects, pattern)
+		}
+	}
+
+	cfg.SampleAPIs = make(map[string]bool)
+	for _, api := range strings.Split(env.Get(EnvSampleAPIs, kvs.GetWithDefault(SampleAPIs, DefaultKVS)), config.ValueSeparator) {
+		if api = strings.TrimSpace(api); api == "" {
+			continue
+		}
+		if !isReadAPI(api) {
+			return cfg, fmt.Errorf("'audit_filter:sample_apis' value invalid: '%s' is not a read API", api)
+		}
+		cfg.SampleAPIs[api] = true
+	}
+	cfg.SampleRate, err = strconv.ParseFloat(env.Get(EnvSampleRate, kvs.GetWithDefault(SampleRate, DefaultKVS)), 64)
+	if err != nil {
+		return cfg, fmt.Errorf("'audit_filter:sample_rate' value invalid: %w", err)
+	}
+	if cfg.SampleRate <= 0 || cfg.SampleRate > 1 {
+		return cfg, fmt.Errorf("'audit_filter:sample_rate' value invalid: must be greater than 0 and at most 1")
+	}
+	return cfg, nil
+}
@@ -0,0 +1,111 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package auditfilter
+
+import (
+	"testing"
+
+	"github.com/minio/minio/internal/config"
+	"github.com/minio/minio/internal/logger/message/audit"
+)
+
+func TestLookupConfig(t *testing.T) {
+	testCases := []struct {
+		kvs     config.KVS
+		success bool
+	}{
+		{config.KVS{}, true},
+		{config.KVS{{Key: DropFields, Value: "remotehost,requestQuery.X-Amz-Credential"}}, true},
+		{config.KVS{{Key: HashFields, Value: "unknown"}}, false},
+		{config.KVS{{Key: HashFields, Value: "remotehost.key"}}, false},
+		{config.KVS{{Key: SampleAPIs, Value: "GetObject,PutObject"}}, false},
+		{config.KVS{{Key: SampleRate, Value: "0.1"}}, true},
+		{config.KVS{{Key: SampleRate, Value: "0"}}, false},
+		{config.KVS{{Key: SampleRate, Value: "1.5"}}, false},
+	}
+	for i, testCase := range testCases {
+		_, err := LookupConfig(testCase.kvs)
+		if testCase.success && err != nil {
+			t.Errorf("Test %d: unexpected error: %v", i+1, err)
+		}
+		if !testCase.success && err == nil {
+			t.Errorf("Test %d: expected an error", i+1)
+		}
+	}
+}
+
+func TestFilter(t *testing.T) {
+	cfg, err := LookupConfig(config.KVS{
+		{Key: DropFields, Value: "requestQuery.X-Amz-Signature,requestHeader"},
+		{Key: HashFields, Value: "remotehost"},
+		{Key: HashObjects, Value: "private/*"},
+		{Key: SampleAPIs, Value: "GetObject"},
+		{Key: SampleRate, Value: "0.000001"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	entry := audit.Entry{DeploymentID: "deployment", RemoteHost: "10.0.0.1"}
+	entry.API.Name = "PutObject"
+	entry.API.Bucket = "private"
+	entry.API.Object = "secret.txt"
+	entry.ReqQuery = map[string]string{"X-Amz-Signature": "sig", "versionId": "1"}
+	entry.ReqHeader = map[string]string{"Authorization": "auth"}
+	if !cfg.Filter(&entry) {
+		t.Fatal("expected write request to be logged")
+	}
+	if entry.RemoteHost == "10.0.0.1" || len(entry.RemoteHost) != 64 {
+		t.Errorf("expected hashed remote host, got %s", entry.RemoteHost)
+	}
+	if entry.API.Object == "secret.txt" {
+		t.Error("expected hashed object name")
+	}
+	if _, ok := entry.ReqQuery["X-Amz-Signature"]; ok || entry.ReqQuery["versionId"] != "1" {
+		t.Errorf("unexpected request query %v", entry.ReqQuery)
+	}
+	if len(entry.ReqHeader) != 0 {
+		t.Errorf("expected no request headers, got %v", entry.ReqHeader)
+	}
+
+	other := audit.Entry{DeploymentID: "deployment", RemoteHost: "10.0.0.1"}
+	other.API.Name = "GetObject"
+	other.API.Bucket = "public"
+	other.API.Object = "file.txt"
+	other.API.StatusCode = 404
+	if !cfg.Filter(&other) {
+		t.Fatal("expected failed request to be logged")
+	}
+	if other.RemoteHost != entry.RemoteHost {
+		t.Error("expected the same hash of the same remote host")
+	}
+	if other.API.Object != "file.txt" {
+		t.Errorf("unexpected hashed object name %s", other.API.Object)
+	}
+
+	other.API.StatusCode = 200
+	logged := 0
+	for i := 0; i < 100; i++ {
+		if cfg.Filter(&other) {
+			logged++
+		}
+	}
+	if logged == 100 {
+		t.Error("expected sampled read requests")
+	}
+}
@@ -0,0 +1,60 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package auditfilter
+
+import "github.com/minio/minio/internal/config"
+
+var (
+	defaultHelpPostfix = func(key string) string {
+		return config.DefaultHelpPostfix(DefaultKVS, key)
+	}
+
+	// Help provides help for audit filter config
+	Help = config.HelpKVS{
+		config.HelpKV{
+			Key:         DropFields,
+			Type:        "csv",
+			Description: "audit fields removed from the audit logs e.g. 'remotehost,requestQuery.X-Amz-Credential'",
+			Optional:    true,
+		},
+		config.HelpKV{
+			Key:         HashFields,
+			Type:        "csv",
+			Description: "audit fields replaced by their hash in the audit logs e.g. 'userAgent,requestHeader.X-Forwarded-For'",
+			Optional:    true,
+		},
+		config.HelpKV{
+			Key:         HashObjects,
+			Type:        "csv",
+			Description: "patterns of 'bucket/object' names replaced by their hash in the audit logs e.g. 'medical/*'",
+			Optional:    true,
+		},
+		config.HelpKV{
+			Key:         SampleAPIs,
+			Type:        "csv",
+			Description: "read APIs logged at the sample rate" + defaultHelpPostfix(SampleAPIs),
+			Optional:    true,
+		},
+		config.HelpKV{
+			Key:         SampleRate,
+			Type:        "number",
+			Description: "fraction of the successful requests of the sampled APIs logged, between 0 and 1" + defaultHelpPostfix(SampleRate),
+			Optional:    true,
+		},
+	}
+)
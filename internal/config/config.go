@@ -84,11 +84,17 @@ const (
 	LoggerWebhookSubSys  = "logger_webhook"
 	AuditWebhookSubSys   = "audit_webhook"
 	AuditKafkaSubSys     = "audit_kafka"
+	AuditFilterSubSys    = "audit_filter"
 	HealSubSys           = "heal"
 	ScannerSubSys        = "scanner"
 	CrawlerSubSys        = "crawler"
 	SubnetSubSys         = "subnet"
 	CallhomeSubSys       = "callhome"
+	UsageExportSubSys    = "usage_export"
+	EventDeliverySubSys  = "event_delivery"
+	PackSubSys           = "pack"
+	DriveSubSys          = "drive"
+	MetricsAuthSubSys    = "metrics_auth"
 
 	// Add new constants here if you add new fields to config.
 )
@@ -105,6 +111,8 @@ const (
 	NotifyPostgresSubSys = "notify_postgres"
 	NotifyRedisSubSys    = "notify_redis"
 	NotifyWebhookSubSys  = "notify_webhook"
+	NotifyPubSubSubSys   = "notify_pubsub"
+	NotifyPulsarSubSys   = "notify_pulsar"
 
 	// Add new constants here if you add new fields to config.
 )
@@ -121,6 +129,8 @@ var NotifySubSystems = set.CreateStringSet(
 	NotifyPostgresSubSys,
 	NotifyRedisSubSys,
 	NotifyWebhookSubSys,
+	NotifyPubSubSubSys,
+	NotifyPulsarSubSys,
 )
 
 // LoggerSubSystems - all sub-systems related to logger
@@ -143,6 +153,7 @@ var SubSystems = set.CreateStringSet(
 	LoggerWebhookSubSys,
 	AuditWebhookSubSys,
 	AuditKafkaSubSys,
+	AuditFilterSubSys,
 	PolicyOPASubSys,
 	PolicyPluginSubSys,
 	IdentityLDAPSubSys,
@@ -161,8 +172,15 @@ var SubSystems = set.CreateStringSet(
 	NotifyPostgresSubSys,
 	NotifyRedisSubSys,
 	NotifyWebhookSubSys,
+	NotifyPubSubSubSys,
+	NotifyPulsarSubSys,
 	SubnetSubSys,
 	CallhomeSubSys,
+	UsageExportSubSys,
+	EventDeliverySubSys,
+	PackSubSys,
+	DriveSubSys,
+	MetricsAuthSubSys,
 )
 
 // SubSystemsDynamic - all sub-systems that have dynamic config.
@@ -173,9 +191,15 @@ var SubSystemsDynamic = set.CreateStringSet(
 	HealSubSys,
 	SubnetSubSys,
 	CallhomeSubSys,
+	UsageExportSubSys,
+	EventDeliverySubSys,
+	PackSubSys,
+	DriveSubSys,
+	MetricsAuthSubSys,
 	LoggerWebhookSubSys,
 	AuditWebhookSubSys,
 	AuditKafkaSubSys,
+	AuditFilterSubSys,
 	StorageClassSubSys,
 )
 
@@ -196,6 +220,12 @@ var SubSystemsSingleTargets = set.CreateStringSet([]string{
 	IdentityPluginSubSys,
 	HealSubSys,
 	ScannerSubSys,
+	UsageExportSubSys,
+	EventDeliverySubSys,
+	PackSubSys,
+	DriveSubSys,
+	MetricsAuthSubSys,
+	AuditFilterSubSys,
 }...)
 
 // Constant separators
@@ -43,13 +43,15 @@ const (
 	// 'podman run -e ENV=value'
 	EnvConfigEnvFile = "MINIO_CONFIG_ENV_FILE"
 
-	EnvBrowser    = "MINIO_BROWSER"
-	EnvDomain     = "MINIO_DOMAIN"
-	EnvPublicIPs  = "MINIO_PUBLIC_IPS"
-	EnvFSOSync    = "MINIO_FS_OSYNC"
-	EnvArgs       = "MINIO_ARGS"
-	EnvVolumes    = "MINIO_VOLUMES"
-	EnvDNSWebhook = "MINIO_DNS_WEBHOOK_ENDPOINT"
+	EnvBrowser       = "MINIO_BROWSER"
+	EnvDomain        = "MINIO_DOMAIN"
+	EnvWebsiteDomain = "MINIO_WEBSITE_DOMAIN"
+	EnvPublicIPs     = "MINIO_PUBLIC_IPS"
+	EnvFSOSync       = "MINIO_FS_OSYNC"
+	EnvFSZeroCopy    = "MINIO_FS_ZERO_COPY"
+	EnvArgs          = "MINIO_ARGS"
+	EnvVolumes       = "MINIO_VOLUMES"
+	EnvDNSWebhook    = "MINIO_DNS_WEBHOOK_ENDPOINT"
 
 	EnvSiteName   = "MINIO_SITE_NAME"
 	EnvSiteRegion = "MINIO_SITE_REGION"
@@ -76,6 +78,20 @@ const (
 	EnvKESClientCert     = "MINIO_KMS_KES_CERT_FILE"
 	EnvKESServerCA       = "MINIO_KMS_KES_CAPATH"
 
+	EnvKMSVaultEndpoint  = "MINIO_KMS_VAULT_ENDPOINT"
+	EnvKMSVaultMount     = "MINIO_KMS_VAULT_MOUNT"
+	EnvKMSVaultNamespace = "MINIO_KMS_VAULT_NAMESPACE"
+	EnvKMSVaultToken     = "MINIO_KMS_VAULT_TOKEN"
+	EnvKMSVaultKeyName   = "MINIO_KMS_VAULT_KEY_NAME"
+	EnvKMSVaultCAPath    = "MINIO_KMS_VAULT_CAPATH"
+
+	EnvKMSAWSRegion       = "MINIO_KMS_AWS_REGION"
+	EnvKMSAWSEndpoint     = "MINIO_KMS_AWS_ENDPOINT"
+	EnvKMSAWSAccessKey    = "MINIO_KMS_AWS_ACCESS_KEY"
+	EnvKMSAWSSecretKey    = "MINIO_KMS_AWS_SECRET_KEY"
+	EnvKMSAWSSessionToken = "MINIO_KMS_AWS_SESSION_TOKEN"
+	EnvKMSAWSKeyName      = "MINIO_KMS_AWS_KEY_NAME"
+
 	EnvEndpoints  = "MINIO_ENDPOINTS"   // legacy
 	EnvWorm       = "MINIO_WORM"        // legacy
 	EnvRegion     = "MINIO_REGION"      // legacy
@@ -0,0 +1,144 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package drive
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/dustin/go-humanize"
+	"github.com/minio/minio/internal/config"
+	"github.com/minio/pkg/env"
+)
+
+// Drive environment variables
+const (
+	NVMeODirect   = "nvme_odirect"
+	HDDODirect    = "hdd_odirect"
+	NVMeReadAhead = "nvme_readahead"
+	HDDReadAhead  = "hdd_readahead"
+
+	EnvNVMeODirect   = "MINIO_DRIVE_NVME_ODIRECT"
+	EnvHDDODirect    = "MINIO_DRIVE_HDD_ODIRECT"
+	EnvNVMeReadAhead = "MINIO_DRIVE_NVME_READAHEAD"
+	EnvHDDReadAhead  = "MINIO_DRIVE_HDD_READAHEAD"
+)
+
+const maxReadAhead = 64 * humanize.MiByte
+
+var configMutex sync.RWMutex
+
+// Config represents the IO settings of the local drives, non-rotational
+// drives use the NVMe settings and rotational drives the HDD settings.
+type Config struct {
+	// use O_DIRECT for the reads and writes of large files.
+	NVMeODirect bool `json:"nvmeODirect"`
+	HDDODirect  bool `json:"hddODirect"`
+
+	// bytes prefetched ahead of streaming reads served through
+	// the page cache, 0 leaves readahead to the kernel.
+	NVMeReadAhead int64 `json:"nvmeReadAhead"`
+	HDDReadAhead  int64 `json:"hddReadAhead"`
+}
+
+// ODirect returns true if O_DIRECT may be used on a drive of the given kind.
+func (opts Config) ODirect(rotational bool) bool {
+	configMutex.RLock()
+	defer configMutex.RUnlock()
+	if rotational {
+		return opts.HDDODirect
+	}
+	return opts.NVMeODirect
+}
+
+// ReadAhead returns the number of bytes prefetched ahead of streaming
+// reads on a drive of the given kind, 0 when left to the kernel.
+func (opts Config) ReadAhead(rotational bool) int64 {
+	configMutex.RLock()
+	defer configMutex.RUnlock()
+	if rotational {
+		return opts.HDDReadAhead
+	}
+	return opts.NVMeReadAhead
+}
+
+// Update updates opts with nopts
+func (opts *Config) Update(nopts Config) {
+	configMutex.Lock()
+	defer configMutex.Unlock()
+
+	opts.NVMeODirect = nopts.NVMeODirect
+	opts.HDDODirect = nopts.HDDODirect
+	opts.NVMeReadAhead = nopts.NVMeReadAhead
+	opts.HDDReadAhead = nopts.HDDReadAhead
+}
+
+// DefaultKVS - default KV config for drive settings
+var DefaultKVS = config.KVS{
+	config.KV{
+		Key:   NVMeODirect,
+		Value: config.EnableOn,
+	},
+	config.KV{
+		Key:   HDDODirect,
+		Value: config.EnableOn,
+	},
+	config.KV{
+		Key:   NVMeReadAhead,
+		Value: "0",
+	},
+	config.KV{
+		Key:   HDDReadAhead,
+		Value: "0",
+	},
+}
+
+func parseReadAhead(s string) (int64, error) {
+	n, err := humanize.ParseBytes(s)
+	if err != nil {
+		return 0, err
+	}
+	if n > maxReadAhead {
+		return 0, fmt.Errorf("must be at most %s", humanize.IBytes(maxReadAhead))
+	}
+	return int64(n), nil
+}
+
+// LookupConfig - lookup config and override with valid environment settings if any.
+func LookupConfig(kvs config.KVS) (cfg Config, err error) {
+	if err = config.CheckValidKeys(config.DriveSubSys, kvs, DefaultKVS); err != nil {
+		return cfg, err
+	}
+	cfg.NVMeODirect, err = config.ParseBool(env.Get(EnvNVMeODirect, kvs.GetWithDefault(NVMeODirect, DefaultKVS)))
+	if err != nil {
+		return cfg, fmt.Errorf("'drive:nvme_odirect' value invalid: %w", err)
+	}
+	cfg.HDDODirect, err = config.ParseBool(env.Get(EnvHDDODirect, kvs.GetWithDefault(HDDODirect, DefaultKVS)))
+	if err != nil {
+		return cfg, fmt.Errorf("'drive:hdd_odirect' value invalid: %w", err)
+	}
+	cfg.NVMeReadAhead, err = parseReadAhead(env.Get(EnvNVMeReadAhead, kvs.GetWithDefault(NVMeReadAhead, DefaultKVS)))
+	if err != nil {
+		return cfg, fmt.Errorf("'drive:nvme_readahead' value invalid: %w", err)
+	}
+	cfg.HDDReadAhead, err = parseReadAhead(env.Get(EnvHDDReadAhead, kvs.GetWithDefault(HDDReadAhead, DefaultKVS)))
+	if err != nil {
+		return cfg, fmt.Errorf("'drive:hdd_readahead' value invalid: %w", err)
+	}
+	return cfg, nil
+}
@@ -0,0 +1,67 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package drive
+
+import (
+	"testing"
+
+	"github.com/minio/minio/internal/config"
+)
+
+func TestLookupConfig(t *testing.T) {
+	testCases := []struct {
+		kvs     config.KVS
+		cfg     Config
+		success bool
+	}{
+		{DefaultKVS, Config{NVMeODirect: true, HDDODirect: true}, true},
+		{
+			config.KVS{
+				config.KV{Key: HDDODirect, Value: config.EnableOff},
+				config.KV{Key: HDDReadAhead, Value: "8MiB"},
+				config.KV{Key: NVMeReadAhead, Value: "128KiB"},
+			},
+			Config{NVMeODirect: true, NVMeReadAhead: 128 << 10, HDDReadAhead: 8 << 20},
+			true,
+		},
+		{config.KVS{config.KV{Key: NVMeODirect, Value: "maybe"}}, Config{}, false},
+		{config.KVS{config.KV{Key: HDDReadAhead, Value: "1GiB"}}, Config{}, false},
+		{config.KVS{config.KV{Key: NVMeReadAhead, Value: "-1"}}, Config{}, false},
+	}
+	for i, tc := range testCases {
+		cfg, err := LookupConfig(tc.kvs)
+		if tc.success != (err == nil) {
+			t.Errorf("test %d: expected success %v, got %v", i+1, tc.success, err)
+			continue
+		}
+		if tc.success && cfg != tc.cfg {
+			t.Errorf("test %d: expected %+v, got %+v", i+1, tc.cfg, cfg)
+		}
+	}
+}
+
+func TestConfigDriveKind(t *testing.T) {
+	var cfg Config
+	cfg.Update(Config{NVMeODirect: true, HDDReadAhead: 4 << 20})
+	if !cfg.ODirect(false) || cfg.ODirect(true) {
+		t.Errorf("unexpected O_DIRECT settings %+v", cfg)
+	}
+	if cfg.ReadAhead(false) != 0 || cfg.ReadAhead(true) != 4<<20 {
+		t.Errorf("unexpected readahead settings %+v", cfg)
+	}
+}
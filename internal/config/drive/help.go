@@ -0,0 +1,55 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package drive
+
+import "github.com/minio/minio/internal/config"
+
+// Help template for drive settings.
+var (
+	defaultHelpPostfix = func(key string) string {
+		return config.DefaultHelpPostfix(DefaultKVS, key)
+	}
+
+	// Help provides help for config values
+	Help = config.HelpKVS{
+		config.HelpKV{
+			Key:         NVMeODirect,
+			Description: `use O_DIRECT for large reads and writes on non-rotational drives` + defaultHelpPostfix(NVMeODirect),
+			Optional:    true,
+			Type:        "on|off",
+		},
+		config.HelpKV{
+			Key:         HDDODirect,
+			Description: `use O_DIRECT for large reads and writes on rotational drives` + defaultHelpPostfix(HDDODirect),
+			Optional:    true,
+			Type:        "on|off",
+		},
+		config.HelpKV{
+			Key:         NVMeReadAhead,
+			Description: `bytes prefetched ahead of streaming reads on non-rotational drives e.g. "1MiB", "0" leaves it to the kernel` + defaultHelpPostfix(NVMeReadAhead),
+			Optional:    true,
+			Type:        "string",
+		},
+		config.HelpKV{
+			Key:         HDDReadAhead,
+			Description: `bytes prefetched ahead of streaming reads on rotational drives e.g. "8MiB", "0" leaves it to the kernel` + defaultHelpPostfix(HDDReadAhead),
+			Optional:    true,
+			Type:        "string",
+		},
+	}
+)
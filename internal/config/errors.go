@@ -43,6 +43,12 @@ var (
 		"Can only accept `on` and `off` values. To enable O_SYNC for fs backend, set this value to `on`",
 	)
 
+	ErrInvalidFSZeroCopyValue = newErrFn(
+		"Invalid zero copy value",
+		"Please check the passed value",
+		"Can only accept `on` and `off` values. To serve plain objects with sendfile for fs backend, set this value to `on`",
+	)
+
 	ErrOverlappingDomainValue = newErrFn(
 		"Overlapping domain values",
 		"Please check the passed value",
@@ -186,6 +192,8 @@ var (
 		"Please check the value",
 		`MINIO_STORAGE_CLASS_STANDARD: Format "EC:<Default_Parity_Standard_Class>" (e.g. "EC:3"). This sets the number of parity disks for MinIO server in Standard mode. Objects are stored in Standard mode, if storage class is not defined in Put request
 MINIO_STORAGE_CLASS_RRS: Format "EC:<Default_Parity_Reduced_Redundancy_Class>" (e.g. "EC:3"). This sets the number of parity disks for MinIO server in Reduced Redundancy mode. Objects are stored in Reduced Redundancy mode, if Put request specifies RRS storage class
+MINIO_STORAGE_CLASS_INLINE_BLOCK: Format "<size>" (e.g. "256KiB"). This sets the max erasure shard size stored inline in object metadata, up to 1MiB
+MINIO_STORAGE_CLASS_STANDARD_BITROT, MINIO_STORAGE_CLASS_RRS_BITROT: One of "highwayhash", "xxhash64" or "blake3". This sets the bitrot checksum algorithm of new objects of the storage class
 Refer to the link https://github.com/minio/minio/tree/master/docs/erasure/storage-class for more information`,
 	)
 
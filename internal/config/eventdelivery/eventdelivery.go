@@ -0,0 +1,144 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+package eventdelivery
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/minio/minio/internal/config"
+	"github.com/minio/minio/internal/event"
+	"github.com/minio/pkg/env"
+)
+
+// Event delivery related keys
+const (
+	MaxAttempts      = "max_attempts"
+	Backoff          = "backoff"
+	MaxBackoff       = "max_backoff"
+	DeadLetterTarget = "dead_letter_target"
+	DeadLetterBucket = "dead_letter_bucket"
+
+	EnvMaxAttempts      = "MINIO_EVENT_DELIVERY_MAX_ATTEMPTS"
+	EnvBackoff          = "MINIO_EVENT_DELIVERY_BACKOFF"
+	EnvMaxBackoff       = "MINIO_EVENT_DELIVERY_MAX_BACKOFF"
+	EnvDeadLetterTarget = "MINIO_EVENT_DELIVERY_DEAD_LETTER_TARGET"
+	EnvDeadLetterBucket = "MINIO_EVENT_DELIVERY_DEAD_LETTER_BUCKET"
+)
+
+// maxAttemptsLimit is the largest number of delivery attempts.
+const maxAttemptsLimit = 100
+
+// DefaultKVS - default KV config for event delivery settings
+var DefaultKVS = config.KVS{
+	config.KV{
+		Key:   MaxAttempts,
+		Value: "1",
+	},
+	config.KV{
+		Key:   Backoff,
+		Value: "1s",
+	},
+	config.KV{
+		Key:   MaxBackoff,
+		Value: "1m",
+	},
+	config.KV{
+		Key:   DeadLetterTarget,
+		Value: "",
+	},
+	config.KV{
+		Key:   DeadLetterBucket,
+		Value: "",
+	},
+}
+
+// Config represents the delivery policy of the events
+// a notification target failed to accept.
+type Config struct {
+	// Number of times an event is delivered to a target
+	// before it is dead-lettered, 1 disables retries.
+	MaxAttempts int `json:"maxAttempts"`
+
+	// Delay before the first retry, doubled for
+	// every further retry up to MaxBackoff.
+	Backoff    time.Duration `json:"backoff"`
+	MaxBackoff time.Duration `json:"maxBackoff"`
+
+	// Target the undelivered events are sent to, if any.
+	DeadLetterTarget *event.TargetID `json:"deadLetterTarget,omitempty"`
+
+	// Bucket and prefix the undelivered events are
+	// written to, if the bucket is set.
+	DeadLetterBucket string `json:"deadLetterBucket"`
+	DeadLetterPrefix string `json:"deadLetterPrefix"`
+}
+
+// NextBackoff returns the delay before the retry
+// following the one delayed by backoff.
+func (cfg Config) NextBackoff(backoff time.Duration) time.Duration {
+	if backoff *= 2; backoff > cfg.MaxBackoff {
+		return cfg.MaxBackoff
+	}
+	return backoff
+}
+
+// LookupConfig - lookup config and override with valid environment settings if any.
+func LookupConfig(kvs config.KVS) (cfg Config, err error) {
+	if err = config.CheckValidKeys(config.EventDeliverySubSys, kvs, DefaultKVS); err != nil {
+		return cfg, err
+	}
+
+	cfg.MaxAttempts, err = strconv.Atoi(env.Get(EnvMaxAttempts, kvs.GetWithDefault(MaxAttempts, DefaultKVS)))
+	if err != nil {
+		return cfg, fmt.Errorf("'event_delivery:max_attempts' value invalid: %w", err)
+	}
+	if cfg.MaxAttempts < 1 || cfg.MaxAttempts > maxAttemptsLimit {
+		return cfg, fmt.Errorf("'event_delivery:max_attempts' value invalid: must be between 1 and %d", maxAttemptsLimit)
+	}
+	cfg.Backoff, err = time.ParseDuration(env.Get(EnvBackoff, kvs.GetWithDefault(Backoff, DefaultKVS)))
+	if err != nil {
+		return cfg, fmt.Errorf("'event_delivery:backoff' value invalid: %w", err)
+	}
+	if cfg.Backoff <= 0 {
+		return cfg, errors.New("'event_delivery:backoff' value invalid: must be positive")
+	}
+	cfg.MaxBackoff, err = time.ParseDuration(env.Get(EnvMaxBackoff, kvs.GetWithDefault(MaxBackoff, DefaultKVS)))
+	if err != nil {
+		return cfg, fmt.Errorf("'event_delivery:max_backoff' value invalid: %w", err)
+	}
+	if cfg.MaxBackoff < cfg.Backoff {
+		return cfg, errors.New("'event_delivery:max_backoff' value invalid: must not be less than backoff")
+	}
+	if arn := env.Get(EnvDeadLetterTarget, kvs.GetWithDefault(DeadLetterTarget, DefaultKVS)); arn != "" {
+		parsedARN, err := event.ParseARN(arn)
+		if err != nil {
+			return cfg, fmt.Errorf("'event_delivery:dead_letter_target' value invalid: %w", err)
+		}
+		cfg.DeadLetterTarget = &parsedARN.TargetID
+	}
+	dest := strings.Trim(env.Get(EnvDeadLetterBucket, kvs.GetWithDefault(DeadLetterBucket, DefaultKVS)), "/")
+	if i := strings.IndexByte(dest, '/'); i >= 0 {
+		cfg.DeadLetterBucket, cfg.DeadLetterPrefix = dest[:i], dest[i+1:]
+	} else {
+		cfg.DeadLetterBucket = dest
+	}
+	return cfg, nil
+}
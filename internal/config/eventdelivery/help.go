@@ -0,0 +1,59 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+package eventdelivery
+
+import "github.com/minio/minio/internal/config"
+
+var (
+	defaultHelpPostfix = func(key string) string {
+		return config.DefaultHelpPostfix(DefaultKVS, key)
+	}
+
+	// Help provides help for event delivery config
+	Help = config.HelpKVS{
+		config.HelpKV{
+			Key:         MaxAttempts,
+			Type:        "number",
+			Description: "number of attempts to deliver an event to a target, 1 disables retries" + defaultHelpPostfix(MaxAttempts),
+			Optional:    true,
+		},
+		config.HelpKV{
+			Key:         Backoff,
+			Type:        "duration",
+			Description: "delay before the first retry, doubled for every further retry" + defaultHelpPostfix(Backoff),
+			Optional:    true,
+		},
+		config.HelpKV{
+			Key:         MaxBackoff,
+			Type:        "duration",
+			Description: "maximum delay between two retries" + defaultHelpPostfix(MaxBackoff),
+			Optional:    true,
+		},
+		config.HelpKV{
+			Key:         DeadLetterTarget,
+			Type:        "string",
+			Description: "ARN of the notification target undelivered events are sent to e.g. 'arn:minio:sqs::1:webhook'",
+			Optional:    true,
+		},
+		config.HelpKV{
+			Key:         DeadLetterBucket,
+			Type:        "string",
+			Description: "bucket and optional prefix undelivered events are written to e.g. 'dlq/events'",
+			Optional:    true,
+		},
+	}
+)
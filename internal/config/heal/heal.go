@@ -25,19 +25,30 @@ import (
 	"sync"
 	"time"
 
+	"github.com/dustin/go-humanize"
 	"github.com/minio/minio/internal/config"
 	"github.com/minio/pkg/env"
 )
 
 // Compression environment variables
 const (
-	Bitrot  = "bitrotscan"
-	Sleep   = "max_sleep"
-	IOCount = "max_io"
+	Bitrot         = "bitrotscan"
+	Sleep          = "max_sleep"
+	IOCount        = "max_io"
+	DriveWorkers   = "drive_workers"
+	DriveBandwidth = "drive_bandwidth"
+	ScrubInterval  = "scrub_interval"
+	ScrubBandwidth = "scrub_bandwidth"
+	DriveHotswap   = "drive_hotswap"
 
-	EnvBitrot  = "MINIO_HEAL_BITROTSCAN"
-	EnvSleep   = "MINIO_HEAL_MAX_SLEEP"
-	EnvIOCount = "MINIO_HEAL_MAX_IO"
+	EnvBitrot         = "MINIO_HEAL_BITROTSCAN"
+	EnvSleep          = "MINIO_HEAL_MAX_SLEEP"
+	EnvIOCount        = "MINIO_HEAL_MAX_IO"
+	EnvDriveWorkers   = "MINIO_HEAL_DRIVE_WORKERS"
+	EnvDriveBandwidth = "MINIO_HEAL_DRIVE_BANDWIDTH"
+	EnvScrubInterval  = "MINIO_HEAL_SCRUB_INTERVAL"
+	EnvScrubBandwidth = "MINIO_HEAL_SCRUB_BANDWIDTH"
+	EnvDriveHotswap   = "MINIO_HEAL_DRIVE_HOTSWAP"
 )
 
 var configMutex sync.RWMutex
@@ -51,6 +62,19 @@ type Config struct {
 	Sleep   time.Duration `json:"sleep"`
 	IOCount int           `json:"iocount"`
 
+	// number of objects healed in parallel on each drive being healed.
+	DriveWorkers int `json:"driveWorkers"`
+	// maximum bytes per second healed on each drive being healed, 0 for no limit.
+	DriveBandwidth uint64 `json:"driveBandwidth"`
+
+	// minimum duration between the start of two bitrot scrubs of a drive, 0 to disable scrubbing.
+	ScrubInterval time.Duration `json:"scrubInterval"`
+	// maximum bytes per second verified on each drive being scrubbed, 0 for no limit.
+	ScrubBandwidth uint64 `json:"scrubBandwidth"`
+
+	// format and heal local drives replaced while the server is running.
+	DriveHotswap bool `json:"driveHotswap"`
+
 	// Cached value from Bitrot field
 	cache struct {
 		// -1: bitrot enabled, 0: bitrot disabled, > 0: bitrot cycle
@@ -103,6 +127,49 @@ func (opts Config) Wait(currentIO func() int, systemIO func() int) {
 	}
 }
 
+// GetDriveWorkers returns the number of objects healed in parallel on
+// each drive being healed.
+func (opts Config) GetDriveWorkers() int {
+	configMutex.RLock()
+	defer configMutex.RUnlock()
+	if opts.DriveWorkers <= 0 {
+		return 1
+	}
+	return opts.DriveWorkers
+}
+
+// GetDriveBandwidth returns the maximum bytes per second healed on
+// each drive being healed, 0 when not limited.
+func (opts Config) GetDriveBandwidth() uint64 {
+	configMutex.RLock()
+	defer configMutex.RUnlock()
+	return opts.DriveBandwidth
+}
+
+// GetScrubInterval returns the minimum duration between the start of two
+// bitrot scrubs of a drive, 0 when scrubbing is disabled.
+func (opts Config) GetScrubInterval() time.Duration {
+	configMutex.RLock()
+	defer configMutex.RUnlock()
+	return opts.ScrubInterval
+}
+
+// GetScrubBandwidth returns the maximum bytes per second verified on
+// each drive being scrubbed, 0 when not limited.
+func (opts Config) GetScrubBandwidth() uint64 {
+	configMutex.RLock()
+	defer configMutex.RUnlock()
+	return opts.ScrubBandwidth
+}
+
+// GetDriveHotswap returns true when local drives replaced while the
+// server is running are formatted and healed automatically.
+func (opts Config) GetDriveHotswap() bool {
+	configMutex.RLock()
+	defer configMutex.RUnlock()
+	return opts.DriveHotswap
+}
+
 // Update updates opts with nopts
 func (opts *Config) Update(nopts Config) {
 	configMutex.Lock()
@@ -111,6 +178,11 @@ func (opts *Config) Update(nopts Config) {
 	opts.Bitrot = nopts.Bitrot
 	opts.IOCount = nopts.IOCount
 	opts.Sleep = nopts.Sleep
+	opts.DriveWorkers = nopts.DriveWorkers
+	opts.DriveBandwidth = nopts.DriveBandwidth
+	opts.ScrubInterval = nopts.ScrubInterval
+	opts.ScrubBandwidth = nopts.ScrubBandwidth
+	opts.DriveHotswap = nopts.DriveHotswap
 
 	opts.cache.bitrotCycle, _ = parseBitrotConfig(nopts.Bitrot)
 }
@@ -129,10 +201,54 @@ var DefaultKVS = config.KVS{
 		Key:   IOCount,
 		Value: "100",
 	},
+	config.KV{
+		Key:   DriveWorkers,
+		Value: "1",
+	},
+	config.KV{
+		Key:   DriveBandwidth,
+		Value: "0",
+	},
+	config.KV{
+		Key:   ScrubInterval,
+		Value: "0",
+	},
+	config.KV{
+		Key:   ScrubBandwidth,
+		Value: "50MiB",
+	},
+	config.KV{
+		Key:   DriveHotswap,
+		Value: config.EnableOff,
+	},
 }
 
 const minimumBitrotCycleInMonths = 1
 
+const minimumScrubInterval = 24 * time.Hour
+
+// parseScrubInterval parses a number of days such as "30d" or a duration,
+// "0" disables scrubbing.
+func parseScrubInterval(s string) (time.Duration, error) {
+	var d time.Duration
+	if days := strings.TrimSuffix(s, "d"); days != s {
+		n, err := strconv.Atoi(days)
+		if err != nil {
+			return 0, err
+		}
+		d = time.Duration(n) * 24 * time.Hour
+	} else {
+		var err error
+		if d, err = time.ParseDuration(s); err != nil {
+			return 0, err
+		}
+	}
+	if d < 0 || (d > 0 && d < minimumScrubInterval) {
+		return 0, fmt.Errorf("minimum scrub interval is %s", minimumScrubInterval)
+	}
+	return d, nil
+}
+
 func parseBitrotConfig(s string) (time.Duration, error) {
 	// Try to parse as a boolean
 	enabled, err := config.ParseBool(s)
@@ -180,5 +296,28 @@ func LookupConfig(kvs config.KVS) (cfg Config, err error) {
 	if err != nil {
 		return cfg, fmt.Errorf("'heal:max_io' value invalid: %w", err)
 	}
+	cfg.DriveWorkers, err = strconv.Atoi(env.Get(EnvDriveWorkers, kvs.GetWithDefault(DriveWorkers, DefaultKVS)))
+	if err != nil {
+		return cfg, fmt.Errorf("'heal:drive_workers' value invalid: %w", err)
+	}
+	if cfg.DriveWorkers < 1 {
+		return cfg, errors.New("'heal:drive_workers' value invalid: must be at least 1")
+	}
+	cfg.DriveBandwidth, err = humanize.ParseBytes(env.Get(EnvDriveBandwidth, kvs.GetWithDefault(DriveBandwidth, DefaultKVS)))
+	if err != nil {
+		return cfg, fmt.Errorf("'heal:drive_bandwidth' value invalid: %w", err)
+	}
+	cfg.ScrubInterval, err = parseScrubInterval(env.Get(EnvScrubInterval, kvs.GetWithDefault(ScrubInterval, DefaultKVS)))
+	if err != nil {
+		return cfg, fmt.Errorf("'heal:scrub_interval' value invalid: %w", err)
+	}
+	cfg.ScrubBandwidth, err = humanize.ParseBytes(env.Get(EnvScrubBandwidth, kvs.GetWithDefault(ScrubBandwidth, DefaultKVS)))
+	if err != nil {
+		return cfg, fmt.Errorf("'heal:scrub_bandwidth' value invalid: %w", err)
+	}
+	cfg.DriveHotswap, err = config.ParseBool(env.Get(EnvDriveHotswap, kvs.GetWithDefault(DriveHotswap, DefaultKVS)))
+	if err != nil {
+		return cfg, fmt.Errorf("'heal:drive_hotswap' value invalid: %w", err)
+	}
 	return cfg, nil
 }
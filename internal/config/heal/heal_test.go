@@ -0,0 +1,49 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package heal
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseScrubInterval(t *testing.T) {
+	testCases := []struct {
+		s       string
+		d       time.Duration
+		success bool
+	}{
+		{"0", 0, true},
+		{"30d", 30 * 24 * time.Hour, true},
+		{"720h", 720 * time.Hour, true},
+		{"1h", 0, false},
+		{"-1d", 0, false},
+		{"xd", 0, false},
+		{"monthly", 0, false},
+	}
+	for _, tc := range testCases {
+		d, err := parseScrubInterval(tc.s)
+		if tc.success != (err == nil) {
+			t.Errorf("%q: expected success %v, got %v", tc.s, tc.success, err)
+			continue
+		}
+		if d != tc.d {
+			t.Errorf("%q: expected %s, got %s", tc.s, tc.d, d)
+		}
+	}
+}
@@ -45,5 +45,35 @@ var (
 			Optional:    true,
 			Type:        "int",
 		},
+		config.HelpKV{
+			Key:         DriveWorkers,
+			Description: `number of objects healed in parallel on each drive being healed` + defaultHelpPostfix(DriveWorkers),
+			Optional:    true,
+			Type:        "int",
+		},
+		config.HelpKV{
+			Key:         DriveBandwidth,
+			Description: `maximum bytes per second healed on each drive being healed e.g. "100MiB", 0 for no limit` + defaultHelpPostfix(DriveBandwidth),
+			Optional:    true,
+			Type:        "string",
+		},
+		config.HelpKV{
+			Key:         ScrubInterval,
+			Description: `verify the bitrot hashes of all the objects on each drive once per interval e.g. "30d", 0 to disable` + defaultHelpPostfix(ScrubInterval),
+			Optional:    true,
+			Type:        "duration",
+		},
+		config.HelpKV{
+			Key:         ScrubBandwidth,
+			Description: `maximum bytes per second verified on each drive being scrubbed e.g. "50MiB", 0 for no limit` + defaultHelpPostfix(ScrubBandwidth),
+			Optional:    true,
+			Type:        "string",
+		},
+		config.HelpKV{
+			Key:         DriveHotswap,
+			Description: `format and heal local drives replaced while the server is running` + defaultHelpPostfix(DriveHotswap),
+			Optional:    true,
+			Type:        "on|off",
+		},
 	}
 )
@@ -19,6 +19,8 @@ package ldap
 
 import (
 	"crypto/x509"
+	"fmt"
+	"strconv"
 	"time"
 
 	"github.com/minio/minio/internal/config"
@@ -32,6 +34,9 @@ const (
 
 	minLDAPExpiry time.Duration = 15 * time.Minute
 	maxLDAPExpiry time.Duration = 365 * 24 * time.Hour
+
+	// Maximum number of levels of nested groups resolved.
+	maxGroupNestedDepth = 16
 )
 
 // Config contains AD/LDAP server connectivity information.
@@ -51,6 +56,10 @@ type Config struct {
 	GroupSearchBaseDistNames []string `json:"-"` // Generated field
 	GroupSearchFilter        string   `json:"groupSearchFilter"`
 
+	// Number of levels of parent groups resolved for
+	// nested group memberships, zero disables it.
+	GroupNestedDepth int `json:"groupNestedDepth"`
+
 	// Lookup bind LDAP service account
 	LookupBindDN       string `json:"lookupBindDN"`
 	LookupBindPassword string `json:"lookupBindPassword"`
@@ -60,6 +69,7 @@ type Config struct {
 	serverInsecure    bool          // allows plain text connection to LDAP server
 	serverStartTLS    bool          // allows using StartTLS connection to LDAP server
 	rootCAs           *x509.CertPool
+	groupCache        *groupCache // parent groups of nested groups
 }
 
 // Clone returns a cloned copy of LDAP config.
@@ -76,6 +86,7 @@ func (l *Config) Clone() Config {
 		GroupSearchBaseDistName:   l.GroupSearchBaseDistName,
 		GroupSearchBaseDistNames:  l.GroupSearchBaseDistNames,
 		GroupSearchFilter:         l.GroupSearchFilter,
+		GroupNestedDepth:          l.GroupNestedDepth,
 		LookupBindDN:              l.LookupBindDN,
 		LookupBindPassword:        l.LookupBindPassword,
 		stsExpiryDuration:         l.stsExpiryDuration,
@@ -83,6 +94,7 @@ func (l *Config) Clone() Config {
 		serverInsecure:            l.serverInsecure,
 		serverStartTLS:            l.serverStartTLS,
 		rootCAs:                   l.rootCAs,
+		groupCache:                l.groupCache,
 	}
 	return cfg
 }
@@ -96,6 +108,8 @@ const (
 	UserDNSearchFilter = "user_dn_search_filter"
 	GroupSearchFilter  = "group_search_filter"
 	GroupSearchBaseDN  = "group_search_base_dn"
+	GroupNestedDepth   = "group_nested_depth"
+	GroupCacheExpiry   = "group_cache_expiry"
 	TLSSkipVerify      = "tls_skip_verify"
 	ServerInsecure     = "server_insecure"
 	ServerStartTLS     = "server_starttls"
@@ -109,6 +123,8 @@ const (
 	EnvUserDNSearchFilter = "MINIO_IDENTITY_LDAP_USER_DN_SEARCH_FILTER"
 	EnvGroupSearchFilter  = "MINIO_IDENTITY_LDAP_GROUP_SEARCH_FILTER"
 	EnvGroupSearchBaseDN  = "MINIO_IDENTITY_LDAP_GROUP_SEARCH_BASE_DN"
+	EnvGroupNestedDepth   = "MINIO_IDENTITY_LDAP_GROUP_NESTED_DEPTH"
+	EnvGroupCacheExpiry   = "MINIO_IDENTITY_LDAP_GROUP_CACHE_EXPIRY"
 	EnvLookupBindDN       = "MINIO_IDENTITY_LDAP_LOOKUP_BIND_DN"
 	EnvLookupBindPassword = "MINIO_IDENTITY_LDAP_LOOKUP_BIND_PASSWORD"
 )
@@ -144,6 +160,14 @@ var (
 			Key:   GroupSearchBaseDN,
 			Value: "",
 		},
+		config.KV{
+			Key:   GroupNestedDepth,
+			Value: "0",
+		},
+		config.KV{
+			Key:   GroupCacheExpiry,
+			Value: "5m",
+		},
 		config.KV{
 			Key:   TLSSkipVerify,
 			Value: config.EnableOff,
@@ -226,6 +250,22 @@ func Lookup(kvs config.KVS, rootCAs *x509.CertPool) (l Config, err error) {
 	l.GroupSearchFilter = env.Get(EnvGroupSearchFilter, kvs.Get(GroupSearchFilter))
 	l.GroupSearchBaseDistName = env.Get(EnvGroupSearchBaseDN, kvs.Get(GroupSearchBaseDN))
 
+	// Nested groups configuration
+	if v := env.Get(EnvGroupNestedDepth, kvs.GetWithDefault(GroupNestedDepth, DefaultKVS)); v != "" {
+		l.GroupNestedDepth, err = strconv.Atoi(v)
+		if err != nil {
+			return l, err
+		}
+		if l.GroupNestedDepth < 0 || l.GroupNestedDepth > maxGroupNestedDepth {
+			return l, fmt.Errorf("%s must be between 0 and %d", GroupNestedDepth, maxGroupNestedDepth)
+		}
+	}
+	cacheExpiry, err := time.ParseDuration(env.Get(EnvGroupCacheExpiry, kvs.GetWithDefault(GroupCacheExpiry, DefaultKVS)))
+	if err != nil {
+		return l, err
+	}
+	l.groupCache = newGroupCache(cacheExpiry)
+
 	// Validate and test configuration.
 	valResult := l.Validate()
 	if !valResult.IsOk() {
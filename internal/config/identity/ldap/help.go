@@ -70,6 +70,18 @@ var (
 			Optional:    true,
 			Type:        "list",
 		},
+		config.HelpKV{
+			Key:         GroupNestedDepth,
+			Description: `levels of parent groups resolved for nested group memberships, "0" disables it` + defaultHelpPostfix(GroupNestedDepth),
+			Optional:    true,
+			Type:        "number",
+		},
+		config.HelpKV{
+			Key:         GroupCacheExpiry,
+			Description: `duration for which the parent groups of a group are cached, "0s" disables caching` + defaultHelpPostfix(GroupCacheExpiry),
+			Optional:    true,
+			Type:        "duration",
+		},
 		config.HelpKV{
 			Key:         TLSSkipVerify,
 			Description: `trust server TLS without verification` + defaultHelpPostfix(TLSSkipVerify),
@@ -122,6 +122,11 @@ func (l *Config) searchForUserGroups(conn *ldap.Conn, username, bindDN string) (
 		}
 	}
 
+	if l.GroupNestedDepth > 0 {
+		return l.searchForNestedGroups(groups, func(groupDN string) ([]string, error) {
+			return l.searchForParentGroups(conn, groupDN)
+		})
+	}
 	return groups, nil
 }
 
@@ -0,0 +1,155 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+package ldap
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	ldap "github.com/go-ldap/ldap/v3"
+	"github.com/minio/minio-go/v7/pkg/set"
+)
+
+// groupCache caches the parent groups of LDAP groups, so
+// that nested memberships shared by many users are not
+// searched again on every login and periodic refresh.
+type groupCache struct {
+	mu      sync.Mutex
+	expiry  time.Duration
+	entries map[string]groupCacheEntry
+}
+
+type groupCacheEntry struct {
+	parents []string
+	expires time.Time
+}
+
+// newGroupCache returns a cache keeping entries for expiry,
+// a nil cache which caches nothing is returned for a zero expiry.
+func newGroupCache(expiry time.Duration) *groupCache {
+	if expiry <= 0 {
+		return nil
+	}
+	return &groupCache{
+		expiry:  expiry,
+		entries: make(map[string]groupCacheEntry),
+	}
+}
+
+// get returns the cached parent groups of groupDN.
+func (c *groupCache) get(groupDN string, now time.Time) ([]string, bool) {
+	if c == nil {
+		return nil, false
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	e, ok := c.entries[groupDN]
+	if !ok {
+		return nil, false
+	}
+	if now.After(e.expires) {
+		delete(c.entries, groupDN)
+		return nil, false
+	}
+	return e.parents, true
+}
+
+// set caches the parent groups of groupDN.
+func (c *groupCache) set(groupDN string, parents []string, now time.Time) {
+	if c == nil {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.entries[groupDN] = groupCacheEntry{
+		parents: parents,
+		expires: now.Add(c.expiry),
+	}
+}
+
+// groupName returns the value of the first RDN of groupDN,
+// substituted for "%s" in the group search filter when
+// searching for the parent groups of a group.
+func groupName(groupDN string) string {
+	dn, err := ldap.ParseDN(groupDN)
+	if err != nil || len(dn.RDNs) == 0 || len(dn.RDNs[0].Attributes) == 0 {
+		return groupDN
+	}
+	return dn.RDNs[0].Attributes[0].Value
+}
+
+// searchForParentGroups returns the groups groupDN is a direct
+// member of, the group search filter is evaluated with the
+// group DN for "%d" as it is for user DNs.
+func (l *Config) searchForParentGroups(conn *ldap.Conn, groupDN string) ([]string, error) {
+	now := time.Now()
+	if parents, ok := l.groupCache.get(groupDN, now); ok {
+		return parents, nil
+	}
+
+	var parents []string
+	for _, groupSearchBase := range l.GroupSearchBaseDistNames {
+		filter := strings.ReplaceAll(l.GroupSearchFilter, "%s", ldap.EscapeFilter(groupName(groupDN)))
+		filter = strings.ReplaceAll(filter, "%d", ldap.EscapeFilter(groupDN))
+		searchRequest := ldap.NewSearchRequest(
+			groupSearchBase,
+			ldap.ScopeWholeSubtree, ldap.NeverDerefAliases, 0, 0, false,
+			filter,
+			nil,
+			nil,
+		)
+
+		groups, err := getGroups(conn, searchRequest)
+		if err != nil {
+			return nil, fmt.Errorf("Error finding parent groups of %s: %w", groupDN, err)
+		}
+		parents = append(parents, groups...)
+	}
+
+	l.groupCache.set(groupDN, parents, now)
+	return parents, nil
+}
+
+// searchForNestedGroups adds to groups the groups they are nested
+// in, up to GroupNestedDepth levels. Parent groups are looked up
+// level by level, so cycles in the group hierarchy terminate.
+func (l *Config) searchForNestedGroups(groups []string, lookupParents func(groupDN string) ([]string, error)) ([]string, error) {
+	seen := set.CreateStringSet(groups...)
+	result := append([]string(nil), groups...)
+	level := groups
+	for depth := 0; depth < l.GroupNestedDepth && len(level) > 0; depth++ {
+		var next []string
+		for _, groupDN := range level {
+			parents, err := lookupParents(groupDN)
+			if err != nil {
+				return nil, err
+			}
+			for _, parent := range parents {
+				if seen.Contains(parent) {
+					continue
+				}
+				seen.Add(parent)
+				next = append(next, parent)
+				result = append(result, parent)
+			}
+		}
+		level = next
+	}
+	return result, nil
+}
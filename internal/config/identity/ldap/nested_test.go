@@ -0,0 +1,88 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+package ldap
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestSearchForNestedGroups(t *testing.T) {
+	// engineers -> developers -> staff -> engineers
+	parents := map[string][]string{
+		"cn=engineers,ou=groups,dc=min,dc=io":  {"cn=developers,ou=groups,dc=min,dc=io"},
+		"cn=developers,ou=groups,dc=min,dc=io": {"cn=staff,ou=groups,dc=min,dc=io"},
+		"cn=staff,ou=groups,dc=min,dc=io":      {"cn=engineers,ou=groups,dc=min,dc=io"},
+	}
+	lookup := func(groupDN string) ([]string, error) {
+		return parents[groupDN], nil
+	}
+
+	testCases := []struct {
+		depth    int
+		expected []string
+	}{
+		{
+			depth:    1,
+			expected: []string{"cn=engineers,ou=groups,dc=min,dc=io", "cn=developers,ou=groups,dc=min,dc=io"},
+		},
+		{
+			depth: 5,
+			expected: []string{
+				"cn=engineers,ou=groups,dc=min,dc=io",
+				"cn=developers,ou=groups,dc=min,dc=io",
+				"cn=staff,ou=groups,dc=min,dc=io",
+			},
+		},
+	}
+	for i, testCase := range testCases {
+		l := Config{GroupNestedDepth: testCase.depth}
+		groups, err := l.searchForNestedGroups([]string{"cn=engineers,ou=groups,dc=min,dc=io"}, lookup)
+		if err != nil {
+			t.Fatalf("Test %d: unexpected error: %v", i+1, err)
+		}
+		if !reflect.DeepEqual(groups, testCase.expected) {
+			t.Errorf("Test %d: expected %v, got %v", i+1, testCase.expected, groups)
+		}
+	}
+}
+
+func TestGroupCache(t *testing.T) {
+	now := time.Now()
+	c := newGroupCache(time.Minute)
+	c.set("cn=engineers,dc=min,dc=io", []string{"cn=staff,dc=min,dc=io"}, now)
+	if parents, ok := c.get("cn=engineers,dc=min,dc=io", now.Add(30*time.Second)); !ok || len(parents) != 1 {
+		t.Fatalf("expected cached parent groups, got %v", parents)
+	}
+	if _, ok := c.get("cn=engineers,dc=min,dc=io", now.Add(2*time.Minute)); ok {
+		t.Fatal("expected cached parent groups to expire")
+	}
+
+	// A zero expiry disables caching.
+	c = newGroupCache(0)
+	c.set("cn=engineers,dc=min,dc=io", []string{"cn=staff,dc=min,dc=io"}, now)
+	if _, ok := c.get("cn=engineers,dc=min,dc=io", now); ok {
+		t.Fatal("expected nothing to be cached")
+	}
+}
+
+func TestGroupName(t *testing.T) {
+	if name := groupName("cn=engineers,ou=groups,dc=min,dc=io"); name != "engineers" {
+		t.Fatalf("expected engineers, got %s", name)
+	}
+}
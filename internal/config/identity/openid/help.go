@@ -102,6 +102,12 @@ var (
 			Optional:    true,
 			Type:        "on|off",
 		},
+		config.HelpKV{
+			Key:         ClaimTags,
+			Description: `JWT claims propagated to STS sessions as policy variables e.g. "preferred_username,nickname=department"` + defaultHelpPostfix(ClaimTags),
+			Optional:    true,
+			Type:        "csv",
+		},
 		config.HelpKV{
 			Key:         ClaimPrefix,
 			Description: `[DEPRECATED use 'claim_name'] JWT claim namespace prefix e.g. "customer1/"` + defaultHelpPostfix(ClaimPrefix),
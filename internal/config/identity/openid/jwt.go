@@ -24,6 +24,8 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"sort"
+	"strings"
 	"sync"
 	"time"
 
@@ -128,6 +130,7 @@ func updateClaimsExpiry(dsecs string, claims map[string]interface{}) error {
 const (
 	audClaim = "aud"
 	azpClaim = "azp"
+	issClaim = "iss"
 )
 
 // Validate - validates the id_token.
@@ -212,6 +215,73 @@ func (r *Config) Validate(arn arn.ARN, token, accessToken, dsecs string) (map[st
 	return claims, nil
 }
 
+// LookupRoleArn selects the provider for a token presented without
+// a role ARN. The provider hint is the name of a provider
+// configuration or the issuer of a provider, without a hint the
+// provider is selected by the issuer and audience of the token.
+func (r *Config) LookupRoleArn(providerHint, token string) (arn.ARN, error) {
+	names := make([]string, 0, len(r.ProviderCfgs))
+	for name := range r.ProviderCfgs {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	if providerHint != "" {
+		if pCfg, ok := r.ProviderCfgs[providerHint]; ok {
+			return pCfg.roleArnKey(), nil
+		}
+		for _, name := range names {
+			pCfg := r.ProviderCfgs[name]
+			if sameIssuer(pCfg.DiscoveryDoc.Issuer, providerHint) {
+				return pCfg.roleArnKey(), nil
+			}
+		}
+		return arn.ARN{}, fmt.Errorf("OpenID provider %s is not configured", providerHint)
+	}
+
+	var claims jwtgo.MapClaims
+	if _, _, err := new(jwtgo.Parser).ParseUnverified(token, &claims); err != nil {
+		return arn.ARN{}, err
+	}
+	issuer, _ := claims[issClaim].(string)
+	if issuer == "" {
+		return arn.ARN{}, errors.New("STS JWT Token has no `iss` claim, a role ARN or provider must be specified")
+	}
+
+	var matches []*providerCfg
+	for _, name := range names {
+		pCfg := r.ProviderCfgs[name]
+		if sameIssuer(pCfg.DiscoveryDoc.Issuer, issuer) {
+			matches = append(matches, pCfg)
+		}
+	}
+	if len(matches) > 1 {
+		// Providers sharing an issuer are told apart by client ID.
+		audValues, _ := iampolicy.GetValuesFromClaims(claims, audClaim)
+		azpValues, _ := iampolicy.GetValuesFromClaims(claims, azpClaim)
+		var clientMatches []*providerCfg
+		for _, pCfg := range matches {
+			if audValues.Contains(pCfg.ClientID) || azpValues.Contains(pCfg.ClientID) {
+				clientMatches = append(clientMatches, pCfg)
+			}
+		}
+		matches = clientMatches
+	}
+	switch len(matches) {
+	case 0:
+		return arn.ARN{}, fmt.Errorf("No OpenID provider is configured for the issuer %s", issuer)
+	case 1:
+		return matches[0].roleArnKey(), nil
+	}
+	return arn.ARN{}, fmt.Errorf("Multiple OpenID providers are configured for the issuer %s, a role ARN or provider must be specified", issuer)
+}
+
+// sameIssuer returns whether the issuer URLs a and b are equal,
+// ignoring a trailing slash.
+func sameIssuer(a, b string) bool {
+	return a != "" && strings.TrimSuffix(a, "/") == strings.TrimSuffix(b, "/")
+}
+
 func (r *Config) updateUserinfoClaims(arn arn.ARN, accessToken string, claims map[string]interface{}) error {
 	pCfg, ok := r.arnProviderCfgsMap[arn]
 	// If claim user info is enabled, get claims from userInfo
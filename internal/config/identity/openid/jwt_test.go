@@ -164,6 +164,72 @@ func TestJWT(t *testing.T) {
 	}
 }
 
+func TestLookupRoleArn(t *testing.T) {
+	newRoleArn := func(resourceID string) arn.ARN {
+		v, err := arn.NewIAMRoleARN(resourceID, "")
+		if err != nil {
+			t.Fatal(err)
+		}
+		return v
+	}
+
+	workforce := &providerCfg{ClientID: "console", RolePolicy: "consoleAdmin", roleArn: newRoleArn("workforce")}
+	workforce.DiscoveryDoc.Issuer = "https://sso.example.com/"
+	workload := &providerCfg{ClientID: "ci", RolePolicy: "readwrite", roleArn: newRoleArn("workload")}
+	workload.DiscoveryDoc.Issuer = "https://token.ci.example.com"
+	batch := &providerCfg{ClientID: "batch", RolePolicy: "readonly", roleArn: newRoleArn("batch")}
+	batch.DiscoveryDoc.Issuer = "https://token.ci.example.com"
+	cfg := Config{
+		Enabled: true,
+		ProviderCfgs: map[string]*providerCfg{
+			"sso":   workforce,
+			"ci":    workload,
+			"batch": batch,
+		},
+	}
+
+	newToken := func(claims jwtg.MapClaims) string {
+		token, err := jwtg.NewWithClaims(jwtg.SigningMethodHS256, claims).SignedString([]byte("secret"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		return token
+	}
+
+	testCases := []struct {
+		hint       string
+		claims     jwtg.MapClaims
+		expected   arn.ARN
+		shouldPass bool
+	}{
+		{hint: "sso", expected: workforce.roleArn, shouldPass: true},
+		{hint: "https://sso.example.com", expected: workforce.roleArn, shouldPass: true},
+		{hint: "unknown", shouldPass: false},
+		{claims: jwtg.MapClaims{"iss": "https://sso.example.com", "aud": "console"}, expected: workforce.roleArn, shouldPass: true},
+		{claims: jwtg.MapClaims{"iss": "https://token.ci.example.com", "aud": "ci"}, expected: workload.roleArn, shouldPass: true},
+		{claims: jwtg.MapClaims{"iss": "https://token.ci.example.com", "aud": "other", "azp": "batch"}, expected: batch.roleArn, shouldPass: true},
+		{claims: jwtg.MapClaims{"iss": "https://token.ci.example.com", "aud": "other"}, shouldPass: false},
+		{claims: jwtg.MapClaims{"iss": "https://unknown.example.com", "aud": "ci"}, shouldPass: false},
+		{claims: jwtg.MapClaims{"aud": "ci"}, shouldPass: false},
+	}
+	for i, testCase := range testCases {
+		token := ""
+		if testCase.claims != nil {
+			token = newToken(testCase.claims)
+		}
+		roleArn, err := cfg.LookupRoleArn(testCase.hint, token)
+		if testCase.shouldPass && err != nil {
+			t.Fatalf("Test %d: unexpected error: %v", i+1, err)
+		}
+		if !testCase.shouldPass && err == nil {
+			t.Fatalf("Test %d: expected an error, got %s", i+1, roleArn)
+		}
+		if testCase.shouldPass && roleArn != testCase.expected {
+			t.Errorf("Test %d: expected %s, got %s", i+1, testCase.expected, roleArn)
+		}
+	}
+}
+
 func TestDefaultExpiryDuration(t *testing.T) {
 	testCases := []struct {
 		reqURL    string
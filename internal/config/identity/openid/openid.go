@@ -48,6 +48,7 @@ const (
 	ClaimName     = "claim_name"
 	ClaimUserinfo = "claim_userinfo"
 	ClaimPrefix   = "claim_prefix"
+	ClaimTags     = "claim_tags"
 	ClientID      = "client_id"
 	ClientSecret  = "client_secret"
 	RolePolicy    = "role_policy"
@@ -70,6 +71,7 @@ const (
 	EnvIdentityOpenIDClaimName          = "MINIO_IDENTITY_OPENID_CLAIM_NAME"
 	EnvIdentityOpenIDClaimUserInfo      = "MINIO_IDENTITY_OPENID_CLAIM_USERINFO"
 	EnvIdentityOpenIDClaimPrefix        = "MINIO_IDENTITY_OPENID_CLAIM_PREFIX"
+	EnvIdentityOpenIDClaimTags          = "MINIO_IDENTITY_OPENID_CLAIM_TAGS"
 	EnvIdentityOpenIDRolePolicy         = "MINIO_IDENTITY_OPENID_ROLE_POLICY"
 	EnvIdentityOpenIDRedirectURI        = "MINIO_IDENTITY_OPENID_REDIRECT_URI"
 	EnvIdentityOpenIDRedirectURIDynamic = "MINIO_IDENTITY_OPENID_REDIRECT_URI_DYNAMIC"
@@ -120,6 +122,10 @@ var (
 			Key:   ClaimPrefix,
 			Value: "",
 		},
+		config.KV{
+			Key:   ClaimTags,
+			Value: "",
+		},
 		config.KV{
 			Key:   RedirectURI,
 			Value: "",
@@ -315,6 +321,11 @@ func LookupConfig(kvsMap map[string]config.KVS, transport http.RoundTripper, clo
 			return c, errors.New("please specify config_url to enable fetching claims from UserInfo endpoint")
 		}
 
+		p.ClaimTags, err = parseClaimTags(getCfgVal(EnvIdentityOpenIDClaimTags, ClaimTags))
+		if err != nil {
+			return c, err
+		}
+
 		if scopeList := getCfgVal(EnvIdentityOpenIDScopes, Scopes); scopeList != "" {
 			var scopes []string
 			for _, scope := range strings.Split(scopeList, ",") {
@@ -41,6 +41,7 @@ type providerCfg struct {
 	ClaimPrefix        string
 	ClaimName          string
 	ClaimUserinfo      bool
+	ClaimTags          map[string]string
 	RedirectURI        string
 	RedirectURIDynamic bool
 	DiscoveryDoc       DiscoveryDoc
@@ -66,6 +67,15 @@ func newProviderCfgFromConfig(getCfgVal func(env, cfgName string) string) provid
 	}
 }
 
+// roleArnKey returns the ARN the provider is registered with,
+// DummyRoleARN for a provider configured without a role policy.
+func (p *providerCfg) roleArnKey() arn.ARN {
+	if p.RolePolicy == "" {
+		return DummyRoleARN
+	}
+	return p.roleArn
+}
+
 const (
 	keyCloakVendor = "keycloak"
 )
@@ -0,0 +1,98 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package openid
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/minio/minio/internal/arn"
+	"github.com/minio/minio/internal/config"
+	"github.com/minio/pkg/bucket/policy/condition"
+)
+
+// parseClaimTags parses the claims propagated to STS sessions as tags,
+// a comma separated list of "tag=claim" or "claim" when the tag has the
+// name of the claim. Tags are policy variables, so a tag must be named
+// like a supported "jwt:" variable.
+func parseClaimTags(s string) (map[string]string, error) {
+	if s == "" {
+		return nil, nil
+	}
+	supported := make(map[string]bool, len(condition.JWTKeys))
+	for _, key := range condition.JWTKeys {
+		supported[key.Name()] = true
+	}
+
+	tags := make(map[string]string)
+	for _, item := range strings.Split(s, ",") {
+		tag, claim := strings.TrimSpace(item), strings.TrimSpace(item)
+		if i := strings.IndexByte(item, '='); i >= 0 {
+			tag, claim = strings.TrimSpace(item[:i]), strings.TrimSpace(item[i+1:])
+		}
+		if tag == "" || claim == "" {
+			return nil, config.Errorf("invalid claim tag '%s' in '%s'", item, s)
+		}
+		if !supported[tag] {
+			return nil, config.Errorf("claim tag '%s' is not a supported policy variable 'jwt:%s'", tag, tag)
+		}
+		if _, ok := tags[tag]; ok {
+			return nil, config.Errorf("claim tag '%s' is specified more than once", tag)
+		}
+		tags[tag] = claim
+	}
+	return tags, nil
+}
+
+// GetSessionTags returns the session tags of the provider of roleArn
+// taken from the claims of a validated token. A tag of a multi-valued
+// claim, e.g. a list of groups, has all of its values.
+func (r *Config) GetSessionTags(roleArn arn.ARN, claims map[string]interface{}) map[string][]string {
+	pCfg, ok := r.arnProviderCfgsMap[roleArn]
+	if !ok || len(pCfg.ClaimTags) == 0 {
+		return nil
+	}
+
+	tags := make(map[string][]string, len(pCfg.ClaimTags))
+	for tag, claim := range pCfg.ClaimTags {
+		var values []string
+		switch v := claims[claim].(type) {
+		case nil:
+		case string:
+			values = append(values, v)
+		case []interface{}:
+			for _, e := range v {
+				if e != nil {
+					values = append(values, fmt.Sprint(e))
+				}
+			}
+		case []string:
+			values = append(values, v...)
+		case float64:
+			// JSON numbers, formatted without exponent.
+			values = append(values, strconv.FormatFloat(v, 'f', -1, 64))
+		default:
+			values = append(values, fmt.Sprint(v))
+		}
+		if len(values) > 0 {
+			tags[tag] = values
+		}
+	}
+	return tags
+}
@@ -0,0 +1,83 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package openid
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/minio/minio/internal/arn"
+)
+
+func TestParseClaimTags(t *testing.T) {
+	testCases := []struct {
+		s        string
+		tags     map[string]string
+		succeeds bool
+	}{
+		{"", nil, true},
+		{"preferred_username", map[string]string{"preferred_username": "preferred_username"}, true},
+		{"preferred_username, nickname=department", map[string]string{"preferred_username": "preferred_username", "nickname": "department"}, true},
+		{"department", nil, false},
+		{"nickname=", nil, false},
+		{"nickname=team,nickname=department", nil, false},
+	}
+	for i, testCase := range testCases {
+		tags, err := parseClaimTags(testCase.s)
+		if testCase.succeeds != (err == nil) {
+			t.Fatalf("Test %d: expected success %t, got %v", i+1, testCase.succeeds, err)
+		}
+		if err == nil && !reflect.DeepEqual(tags, testCase.tags) {
+			t.Errorf("Test %d: expected %v, got %v", i+1, testCase.tags, tags)
+		}
+	}
+}
+
+func TestGetSessionTags(t *testing.T) {
+	cfg := Config{
+		arnProviderCfgsMap: map[arn.ARN]*providerCfg{
+			DummyRoleARN: {ClaimTags: map[string]string{
+				"preferred_username": "preferred_username",
+				"nickname":           "department",
+				"groups":             "groups",
+				"upn":                "employee_id",
+				"email":              "email",
+			}},
+		},
+	}
+
+	tags := cfg.GetSessionTags(DummyRoleARN, map[string]interface{}{
+		"preferred_username": "alice",
+		"department":         "finance",
+		"groups":             []interface{}{"dev", "staff"},
+		"employee_id":        float64(12345678),
+	})
+	expected := map[string][]string{
+		"preferred_username": {"alice"},
+		"nickname":           {"finance"},
+		"groups":             {"dev", "staff"},
+		"upn":                {"12345678"},
+	}
+	if !reflect.DeepEqual(tags, expected) {
+		t.Errorf("expected %v, got %v", expected, tags)
+	}
+
+	if tags := (&Config{}).GetSessionTags(DummyRoleARN, map[string]interface{}{"preferred_username": "alice"}); tags != nil {
+		t.Errorf("expected no tags without a provider, got %v", tags)
+	}
+}
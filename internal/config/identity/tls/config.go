@@ -18,7 +18,10 @@
 package tls
 
 import (
+	"crypto/x509"
+	"fmt"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/minio/minio/internal/auth"
@@ -41,6 +44,27 @@ const (
 	// clients to obtain temp. credentials with arbitrary policy
 	// permissions - including admin permissions.
 	EnvIdentityTLSSkipVerify = "MINIO_IDENTITY_TLS_SKIP_VERIFY"
+
+	// EnvIdentityTLSCACerts is an environment variable that points to
+	// a PEM file with the CA certificates the client certificates must
+	// be issued by. By default, the CA certificates of the server are
+	// trusted.
+	EnvIdentityTLSCACerts = "MINIO_IDENTITY_TLS_CA_CERTS"
+
+	// EnvIdentityTLSPolicyAttribute is an environment variable that
+	// selects the certificate attribute which names the policies of
+	// the client. By default, the subject common name is used.
+	EnvIdentityTLSPolicyAttribute = "MINIO_IDENTITY_TLS_POLICY_ATTRIBUTE"
+)
+
+// Certificate attributes that can be mapped to policies.
+const (
+	AttributeCommonName         = "cn"
+	AttributeOrganization       = "o"
+	AttributeOrganizationalUnit = "ou"
+	AttributeDNSName            = "dns"
+	AttributeURI                = "uri"
+	AttributeEmail              = "email"
 )
 
 // Config contains the STS TLS configuration for generating temp.
@@ -52,6 +76,46 @@ type Config struct {
 	// certificate verification. It should only be set for
 	// debugging or testing purposes.
 	InsecureSkipVerify bool `json:"skip_verify"`
+
+	// RootCAs, if set, are the only CAs trusted to issue
+	// client certificates.
+	RootCAs *x509.CertPool `json:"-"`
+
+	// PolicyAttribute is the certificate attribute
+	// whose values are the policies of the client.
+	PolicyAttribute string `json:"policy_attribute"`
+}
+
+// PolicyNames returns the policies a client certificate is
+// mapped to by the values of the configured attribute. Values
+// containing the policy separator are skipped: a single value
+// must never name more than one policy.
+func (l Config) PolicyNames(cert *x509.Certificate) []string {
+	var values []string
+	switch l.PolicyAttribute {
+	case AttributeOrganization:
+		values = cert.Subject.Organization
+	case AttributeOrganizationalUnit:
+		values = cert.Subject.OrganizationalUnit
+	case AttributeDNSName:
+		values = cert.DNSNames
+	case AttributeURI:
+		for _, uri := range cert.URIs {
+			values = append(values, uri.String())
+		}
+	case AttributeEmail:
+		values = cert.EmailAddresses
+	default:
+		values = []string{cert.Subject.CommonName}
+	}
+
+	policies := make([]string, 0, len(values))
+	for _, v := range values {
+		if v = strings.TrimSpace(v); v != "" && !strings.Contains(v, ",") {
+			policies = append(policies, v)
+		}
+	}
+	return policies
 }
 
 const (
@@ -99,11 +163,32 @@ func Lookup(kvs config.KVS) (Config, error) {
 	if err != nil {
 		return Config{}, err
 	}
+	if caCerts := env.Get(EnvIdentityTLSCACerts, kvs.Get(caCerts)); caCerts != "" {
+		certs, err := config.ParsePublicCertFile(caCerts)
+		if err != nil {
+			return Config{}, err
+		}
+		cfg.RootCAs = x509.NewCertPool()
+		for _, cert := range certs {
+			cfg.RootCAs.AddCert(cert)
+		}
+	}
+	cfg.PolicyAttribute = strings.ToLower(env.Get(EnvIdentityTLSPolicyAttribute, kvs.Get(policyAttribute)))
+	switch cfg.PolicyAttribute {
+	case "":
+		cfg.PolicyAttribute = AttributeCommonName
+	case AttributeCommonName, AttributeOrganization, AttributeOrganizationalUnit,
+		AttributeDNSName, AttributeURI, AttributeEmail:
+	default:
+		return Config{}, fmt.Errorf("invalid policy attribute %q: must be one of cn, o, ou, dns, uri or email", cfg.PolicyAttribute)
+	}
 	return cfg, nil
 }
 
 const (
-	skipVerify = "skip_verify"
+	skipVerify      = "skip_verify"
+	caCerts         = "ca_certs"
+	policyAttribute = "policy_attribute"
 )
 
 // DefaultKVS is the the default K/V config system for
@@ -113,6 +198,14 @@ var DefaultKVS = config.KVS{
 		Key:   skipVerify,
 		Value: "off",
 	},
+	config.KV{
+		Key:   caCerts,
+		Value: "",
+	},
+	config.KV{
+		Key:   policyAttribute,
+		Value: AttributeCommonName,
+	},
 }
 
 // Help is the help and description for the STS API K/V configuration.
@@ -123,4 +216,16 @@ var Help = config.HelpKVS{
 		Optional:    true,
 		Type:        "on|off",
 	},
+	config.HelpKV{
+		Key:         caCerts,
+		Description: `path to a PEM file of the CAs trusted to issue client certificates (default: the server CAs)`,
+		Optional:    true,
+		Type:        "path",
+	},
+	config.HelpKV{
+		Key:         policyAttribute,
+		Description: `certificate attribute naming the policies of the client: 'cn', 'o', 'ou', 'dns', 'uri' or 'email' (default: 'cn')`,
+		Optional:    true,
+		Type:        "string",
+	},
 }
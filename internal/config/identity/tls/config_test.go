@@ -0,0 +1,103 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package tls
+
+import (
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"net/url"
+	"reflect"
+	"testing"
+
+	"github.com/minio/minio/internal/config"
+)
+
+func TestPolicyNames(t *testing.T) {
+	uri, _ := url.Parse("spiffe://cluster/fleet")
+	cert := &x509.Certificate{
+		Subject: pkix.Name{
+			CommonName:         "machine-1",
+			Organization:       []string{"fleet"},
+			OrganizationalUnit: []string{"readonly", " ", "diagnostics"},
+		},
+		DNSNames: []string{"machine-1.example.com"},
+		URIs:     []*url.URL{uri},
+	}
+
+	testCases := []struct {
+		attribute string
+		policies  []string
+	}{
+		{"", []string{"machine-1"}},
+		{AttributeCommonName, []string{"machine-1"}},
+		{AttributeOrganization, []string{"fleet"}},
+		{AttributeOrganizationalUnit, []string{"readonly", "diagnostics"}},
+		{AttributeDNSName, []string{"machine-1.example.com"}},
+		{AttributeURI, []string{"spiffe://cluster/fleet"}},
+		{AttributeEmail, []string{}},
+	}
+	for _, testCase := range testCases {
+		cfg := Config{PolicyAttribute: testCase.attribute}
+		if policies := cfg.PolicyNames(cert); !reflect.DeepEqual(policies, testCase.policies) {
+			t.Errorf("attribute %q: expected %v, got %v", testCase.attribute, testCase.policies, policies)
+		}
+	}
+}
+
+func TestPolicyNamesSeparator(t *testing.T) {
+	cert := &x509.Certificate{
+		Subject: pkix.Name{
+			CommonName:         "readonly,consoleAdmin",
+			OrganizationalUnit: []string{"readonly,consoleAdmin", "diagnostics"},
+		},
+	}
+	if policies := (Config{PolicyAttribute: AttributeCommonName}).PolicyNames(cert); len(policies) != 0 {
+		t.Errorf("expected a common name with a policy separator to be skipped, got %v", policies)
+	}
+	if policies := (Config{PolicyAttribute: AttributeOrganizationalUnit}).PolicyNames(cert); !reflect.DeepEqual(policies, []string{"diagnostics"}) {
+		t.Errorf("expected only the diagnostics policy, got %v", policies)
+	}
+}
+
+func TestLookupPolicyAttribute(t *testing.T) {
+	t.Setenv(EnvIdentityTLSEnabled, "on")
+
+	cfg, err := Lookup(DefaultKVS)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfg.PolicyAttribute != AttributeCommonName || cfg.RootCAs != nil {
+		t.Errorf("unexpected default config %+v", cfg)
+	}
+
+	t.Setenv(EnvIdentityTLSPolicyAttribute, "OU")
+	if cfg, err = Lookup(DefaultKVS); err != nil || cfg.PolicyAttribute != AttributeOrganizationalUnit {
+		t.Errorf("expected the ou attribute, got %q: %v", cfg.PolicyAttribute, err)
+	}
+
+	t.Setenv(EnvIdentityTLSPolicyAttribute, "serial")
+	if _, err = Lookup(DefaultKVS); err == nil {
+		t.Error("expected an invalid policy attribute to be rejected")
+	}
+
+	t.Setenv(EnvIdentityTLSPolicyAttribute, "")
+	t.Setenv(EnvIdentityTLSCACerts, "non-existent.pem")
+	if _, err = Lookup(config.KVS{}); err == nil {
+		t.Error("expected a missing CA file to be rejected")
+	}
+}
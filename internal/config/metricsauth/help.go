@@ -0,0 +1,49 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package metricsauth
+
+import "github.com/minio/minio/internal/config"
+
+// Help provides help for metrics auth config
+var Help = config.HelpKVS{
+	config.HelpKV{
+		Key:         Tokens,
+		Type:        "csv",
+		Description: "static bearer tokens allowed to scrape metrics, optionally limited to metrics groups e.g. 'token1,token2:capacity+bucket'",
+		Optional:    true,
+		Sensitive:   true,
+	},
+	config.HelpKV{
+		Key:         ClientCerts,
+		Type:        "csv",
+		Description: "subject common names of the client certificates allowed to scrape metrics, optionally limited to metrics groups e.g. 'prometheus,scraper:disk'",
+		Optional:    true,
+	},
+	config.HelpKV{
+		Key:         CACerts,
+		Type:        "path",
+		Description: "path to a PEM file with the only CA certificates trusted to issue the client certificates, required by client_certs",
+		Optional:    true,
+	},
+	config.HelpKV{
+		Key:         ServerName,
+		Type:        "string",
+		Description: "TLS server name (SNI) scrapers connect to, client certificates are only requested for it, required by client_certs e.g. 'metrics.example.com'",
+		Optional:    true,
+	},
+}
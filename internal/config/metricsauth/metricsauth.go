@@ -0,0 +1,196 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package metricsauth
+
+import (
+	"crypto/subtle"
+	"crypto/x509"
+	"fmt"
+	"strings"
+
+	"github.com/minio/minio/internal/config"
+	"github.com/minio/pkg/env"
+)
+
+// Metrics auth related keys
+const (
+	Tokens      = "tokens"
+	ClientCerts = "client_certs"
+	CACerts     = "ca_certs"
+	ServerName  = "server_name"
+
+	EnvTokens      = "MINIO_METRICS_AUTH_TOKENS"
+	EnvClientCerts = "MINIO_METRICS_AUTH_CLIENT_CERTS"
+	EnvCACerts     = "MINIO_METRICS_AUTH_CA_CERTS"
+	EnvServerName  = "MINIO_METRICS_AUTH_SERVER_NAME"
+)
+
+// DefaultKVS - default KV config for metrics auth settings
+var DefaultKVS = config.KVS{
+	config.KV{
+		Key:   Tokens,
+		Value: "",
+	},
+	config.KV{
+		Key:   ClientCerts,
+		Value: "",
+	},
+	config.KV{
+		Key:   CACerts,
+		Value: "",
+	},
+	config.KV{
+		Key:   ServerName,
+		Value: "",
+	},
+}
+
+// Separators of the metrics groups of a grant, e.g. 'token:capacity+bucket'.
+const (
+	groupsSeparator = ":"
+	groupSeparator  = "+"
+)
+
+// Grant allows a static token or the client certificates with a
+// subject common name to scrape metrics.
+type Grant struct {
+	Credential string
+
+	// Metrics groups which may be scraped, all of them if empty.
+	Groups []string
+}
+
+// Allows returns whether the metrics group may be scraped.
+func (g Grant) Allows(group string) bool {
+	if len(g.Groups) == 0 {
+		return true
+	}
+	for _, name := range g.Groups {
+		if name == group {
+			return true
+		}
+	}
+	return false
+}
+
+// Config represents the credentials allowed to scrape metrics
+// in addition to JWT bearer tokens.
+type Config struct {
+	Tokens      []Grant
+	ClientCerts []Grant
+
+	// RootCAs are the only CAs trusted to issue the client
+	// certificates, required when client certificates are allowed.
+	RootCAs *x509.CertPool
+
+	// ServerName is the TLS server name which scrapers connect
+	// to, client certificates are only requested for it.
+	ServerName string
+}
+
+// Token returns the grant of the static bearer token.
+func (cfg Config) Token(token string) (Grant, bool) {
+	var (
+		grant Grant
+		found bool
+	)
+	// All tokens are compared in constant time, so
+	// that the timing does not reveal which matched.
+	for _, g := range cfg.Tokens {
+		if subtle.ConstantTimeCompare([]byte(g.Credential), []byte(token)) == 1 {
+			grant, found = g, true
+		}
+	}
+	return grant, found
+}
+
+// ClientCert returns the grant of the client certificates
+// with the subject common name.
+func (cfg Config) ClientCert(commonName string) (Grant, bool) {
+	for _, g := range cfg.ClientCerts {
+		if g.Credential == commonName {
+			return g, true
+		}
+	}
+	return Grant{}, false
+}
+
+// parseGrants parses the grants of the key, formatted as
+// 'credential' or 'credential:group+group'.
+func parseGrants(key, value string) ([]Grant, error) {
+	var grants []Grant
+	for _, s := range strings.Split(value, config.ValueSeparator) {
+		if s = strings.TrimSpace(s); s == "" {
+			continue
+		}
+		g := Grant{Credential: s}
+		if i := strings.LastIndex(s, groupsSeparator); i >= 0 {
+			g.Credential = s[:i]
+			for _, name := range strings.Split(s[i+1:], groupSeparator) {
+				if name = strings.TrimSpace(name); name != "" {
+					g.Groups = append(g.Groups, name)
+				}
+			}
+			if len(g.Groups) == 0 {
+				return nil, fmt.Errorf("'%s:%s' value invalid: no metrics groups after '%s'", config.MetricsAuthSubSys, key, groupsSeparator)
+			}
+		}
+		if g.Credential == "" {
+			return nil, fmt.Errorf("'%s:%s' value invalid: empty credential", config.MetricsAuthSubSys, key)
+		}
+		grants = append(grants, g)
+	}
+	return grants, nil
+}
+
+// LookupConfig - lookup config and override with valid environment settings if any.
+func LookupConfig(kvs config.KVS) (cfg Config, err error) {
+	if err = config.CheckValidKeys(config.MetricsAuthSubSys, kvs, DefaultKVS); err != nil {
+		return cfg, err
+	}
+	cfg.Tokens, err = parseGrants(Tokens, env.Get(EnvTokens, kvs.GetWithDefault(Tokens, DefaultKVS)))
+	if err != nil {
+		return cfg, err
+	}
+	cfg.ClientCerts, err = parseGrants(ClientCerts, env.Get(EnvClientCerts, kvs.GetWithDefault(ClientCerts, DefaultKVS)))
+	if err != nil {
+		return cfg, err
+	}
+	if caCerts := env.Get(EnvCACerts, kvs.GetWithDefault(CACerts, DefaultKVS)); caCerts != "" {
+		certs, err := config.ParsePublicCertFile(caCerts)
+		if err != nil {
+			return cfg, err
+		}
+		cfg.RootCAs = x509.NewCertPool()
+		for _, cert := range certs {
+			cfg.RootCAs.AddCert(cert)
+		}
+	}
+	// Client certificates are never verified against the CAs of
+	// the server, which may include the system CAs.
+	if len(cfg.ClientCerts) > 0 && cfg.RootCAs == nil {
+		return cfg, fmt.Errorf("'%s:%s' requires '%s' to be set", config.MetricsAuthSubSys, ClientCerts, CACerts)
+	}
+	cfg.ServerName = env.Get(EnvServerName, kvs.GetWithDefault(ServerName, DefaultKVS))
+	// Other clients connecting to the server must not be
+	// asked for a certificate.
+	if len(cfg.ClientCerts) > 0 && cfg.ServerName == "" {
+		return cfg, fmt.Errorf("'%s:%s' requires '%s' to be set", config.MetricsAuthSubSys, ClientCerts, ServerName)
+	}
+	return cfg, nil
+}
@@ -0,0 +1,127 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package metricsauth
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/minio/minio/internal/config"
+)
+
+// writeTestCACert writes a self-signed CA certificate
+// to a PEM file and returns the path of the file.
+func writeTestCACert(t *testing.T) string {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "metrics CA"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		KeyUsage:              x509.KeyUsageCertSign,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	file := filepath.Join(t.TempDir(), "ca.crt")
+	if err = os.WriteFile(file, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return file
+}
+
+func TestLookupConfig(t *testing.T) {
+	caCerts := writeTestCACert(t)
+	testCases := []struct {
+		kvs     config.KVS
+		success bool
+	}{
+		{config.KVS{}, true},
+		{config.KVS{{Key: Tokens, Value: "token1, token2:capacity+bucket"}}, true},
+		{config.KVS{{Key: ClientCerts, Value: "prometheus:disk"}, {Key: CACerts, Value: caCerts}, {Key: ServerName, Value: "metrics.example.com"}}, true},
+		{config.KVS{{Key: CACerts, Value: caCerts}}, true},
+		// Client certificates require dedicated CAs.
+		{config.KVS{{Key: ClientCerts, Value: "prometheus:disk"}}, false},
+		// Client certificates are only requested for a dedicated server name.
+		{config.KVS{{Key: ClientCerts, Value: "prometheus:disk"}, {Key: CACerts, Value: caCerts}}, false},
+		{config.KVS{{Key: ClientCerts, Value: "prometheus"}, {Key: CACerts, Value: caCerts + ".missing"}}, false},
+		{config.KVS{{Key: Tokens, Value: "token1:"}}, false},
+		{config.KVS{{Key: ClientCerts, Value: ":disk"}}, false},
+		{config.KVS{{Key: "unknown", Value: "on"}}, false},
+	}
+	for i, testCase := range testCases {
+		_, err := LookupConfig(testCase.kvs)
+		if testCase.success && err != nil {
+			t.Errorf("Test %d: unexpected error: %v", i+1, err)
+		}
+		if !testCase.success && err == nil {
+			t.Errorf("Test %d: expected an error", i+1)
+		}
+	}
+}
+
+func TestConfigGrants(t *testing.T) {
+	cfg, err := LookupConfig(config.KVS{
+		{Key: Tokens, Value: "token1,to:ken2:capacity+bucket"},
+		{Key: ClientCerts, Value: "prometheus:disk"},
+		{Key: CACerts, Value: writeTestCACert(t)},
+		{Key: ServerName, Value: "metrics.example.com"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfg.RootCAs == nil {
+		t.Error("expected the CA certificates to be loaded")
+	}
+
+	g, ok := cfg.Token("token1")
+	if !ok || len(g.Groups) != 0 || !g.Allows("bucket") {
+		t.Errorf("expected token1 to be allowed all groups, got %+v, %v", g, ok)
+	}
+	g, ok = cfg.Token("to:ken2")
+	if !ok || !reflect.DeepEqual(g.Groups, []string{"capacity", "bucket"}) || !g.Allows("bucket") || g.Allows("disk") {
+		t.Errorf("expected to:ken2 to be allowed the capacity and bucket groups, got %+v, %v", g, ok)
+	}
+	if _, ok = cfg.Token("token3"); ok {
+		t.Error("expected token3 not to be allowed")
+	}
+	g, ok = cfg.ClientCert("prometheus")
+	if !ok || !g.Allows("disk") || g.Allows("capacity") {
+		t.Errorf("expected prometheus to be allowed the disk group, got %+v, %v", g, ok)
+	}
+	if _, ok = cfg.ClientCert("token1"); ok {
+		t.Error("expected tokens not to be allowed as client certificates")
+	}
+}
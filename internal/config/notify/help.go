@@ -589,6 +589,128 @@ var (
 		},
 	}
 
+	HelpPubSub = config.HelpKVS{
+		enableHelp,
+		config.HelpKV{
+			Key:         target.PubSubProjectID,
+			Description: "Google Cloud project ID of the Pub/Sub topic",
+			Type:        "string",
+		},
+		config.HelpKV{
+			Key:         target.PubSubTopic,
+			Description: "Pub/Sub topic ID",
+			Type:        "string",
+		},
+		config.HelpKV{
+			Key:         target.PubSubCredentialsFile,
+			Description: "path to the service account JSON key, defaults to the application default credentials e.g. workload identity",
+			Optional:    true,
+			Type:        "path",
+		},
+		config.HelpKV{
+			Key:         target.PubSubOrderingKey,
+			Description: `set to 'off' to not order the events of an object with the "bucket/object" ordering key, defaults to 'on'`,
+			Optional:    true,
+			Type:        "on|off",
+		},
+		config.HelpKV{
+			Key:         target.PubSubQueueDir,
+			Description: queueDirComment,
+			Optional:    true,
+			Type:        "path",
+		},
+		config.HelpKV{
+			Key:         target.PubSubQueueLimit,
+			Description: queueLimitComment,
+			Optional:    true,
+			Type:        "number",
+		},
+		config.HelpKV{
+			Key:         config.Comment,
+			Description: config.DefaultComment,
+			Optional:    true,
+			Type:        "sentence",
+		},
+	}
+
+	HelpPulsar = config.HelpKVS{
+		enableHelp,
+		config.HelpKV{
+			Key:         target.PulsarBroker,
+			Description: "Pulsar broker or proxy HTTP service URL e.g. 'http://localhost:8080'",
+			Type:        "url",
+			Sensitive:   true,
+		},
+		config.HelpKV{
+			Key:         target.PulsarTopic,
+			Description: "Pulsar topic e.g. 'persistent://tenant/namespace/topic'",
+			Type:        "string",
+		},
+		config.HelpKV{
+			Key:         target.PulsarAuthToken,
+			Description: "JWT token to authenticate with the Pulsar broker",
+			Optional:    true,
+			Type:        "string",
+			Sensitive:   true,
+		},
+		config.HelpKV{
+			Key:         target.PulsarTLSSkipVerify,
+			Description: `trust server TLS without verification, defaults to "on" (verify)`,
+			Optional:    true,
+			Type:        "on|off",
+		},
+		config.HelpKV{
+			Key:         target.PulsarClientTLSCert,
+			Description: "path to client certificate for TLS authentication",
+			Optional:    true,
+			Type:        "path",
+			Sensitive:   true,
+		},
+		config.HelpKV{
+			Key:         target.PulsarClientTLSKey,
+			Description: "path to client key for TLS authentication",
+			Optional:    true,
+			Type:        "path",
+			Sensitive:   true,
+		},
+		config.HelpKV{
+			Key:         target.PulsarBatching,
+			Description: "set to 'on' to let the broker batch the published events",
+			Optional:    true,
+			Type:        "on|off",
+		},
+		config.HelpKV{
+			Key:         target.PulsarBatchingMaxMessages,
+			Description: "maximum number of events in a batch, defaults to '1000'",
+			Optional:    true,
+			Type:        "number",
+		},
+		config.HelpKV{
+			Key:         target.PulsarBatchingMaxPublishDelay,
+			Description: "maximum duration to wait for a batch to fill up, defaults to '10ms'",
+			Optional:    true,
+			Type:        "duration",
+		},
+		config.HelpKV{
+			Key:         target.PulsarQueueDir,
+			Description: queueDirComment,
+			Optional:    true,
+			Type:        "path",
+		},
+		config.HelpKV{
+			Key:         target.PulsarQueueLimit,
+			Description: queueLimitComment,
+			Optional:    true,
+			Type:        "number",
+		},
+		config.HelpKV{
+			Key:         config.Comment,
+			Description: config.DefaultComment,
+			Optional:    true,
+			Type:        "sentence",
+		},
+	}
+
 	HelpES = config.HelpKVS{
 		enableHelp,
 		config.HelpKV{
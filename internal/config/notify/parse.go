@@ -365,6 +365,55 @@ func fetchSubSysTargets(ctx context.Context, cfg config.Config,
 				}
 			}
 		}
+	case config.NotifyPubSubSubSys:
+		pubSubTargets, err := GetNotifyPubSub(cfg[config.NotifyPubSubSubSys])
+		if err != nil {
+			return targetsOffline, err
+		}
+		for id, args := range pubSubTargets {
+			if !args.Enable {
+				continue
+			}
+			newTarget, err := target.NewPubSubTarget(ctx, id, args, logger.LogOnceIf, test)
+			if err != nil {
+				targetsOffline = true
+				if returnOnTargetError {
+					return targetsOffline, err
+				}
+				_ = newTarget.Close()
+			}
+			if err = targetList.Add(newTarget); err != nil {
+				logger.LogIf(context.Background(), err)
+				if returnOnTargetError {
+					return targetsOffline, err
+				}
+			}
+		}
+	case config.NotifyPulsarSubSys:
+		pulsarTargets, err := GetNotifyPulsar(cfg[config.NotifyPulsarSubSys])
+		if err != nil {
+			return targetsOffline, err
+		}
+		for id, args := range pulsarTargets {
+			if !args.Enable {
+				continue
+			}
+			args.TLS.RootCAs = transport.TLSClientConfig.RootCAs
+			newTarget, err := target.NewPulsarTarget(ctx, id, args, logger.LogOnceIf, test)
+			if err != nil {
+				targetsOffline = true
+				if returnOnTargetError {
+					return targetsOffline, err
+				}
+				_ = newTarget.Close()
+			}
+			if err = targetList.Add(newTarget); err != nil {
+				logger.LogIf(context.Background(), err)
+				if returnOnTargetError {
+					return targetsOffline, err
+				}
+			}
+		}
 
 	}
 	return targetsOffline, nil
@@ -413,6 +462,8 @@ var (
 		config.NotifyRedisSubSys:    DefaultRedisKVS,
 		config.NotifyWebhookSubSys:  DefaultWebhookKVS,
 		config.NotifyESSubSys:       DefaultESKVS,
+		config.NotifyPubSubSubSys:   DefaultPubSubKVS,
+		config.NotifyPulsarSubSys:   DefaultPulsarKVS,
 	}
 )
 
@@ -1235,6 +1286,273 @@ func GetNotifyNSQ(nsqKVS map[string]config.KVS) (map[string]target.NSQArgs, erro
 	return nsqTargets, nil
 }
 
+// DefaultPubSubKVS - Google Cloud Pub/Sub KV for config
+var (
+	DefaultPubSubKVS = config.KVS{
+		config.KV{
+			Key:   config.Enable,
+			Value: config.EnableOff,
+		},
+		config.KV{
+			Key:   target.PubSubProjectID,
+			Value: "",
+		},
+		config.KV{
+			Key:   target.PubSubTopic,
+			Value: "",
+		},
+		config.KV{
+			Key:   target.PubSubCredentialsFile,
+			Value: "",
+		},
+		config.KV{
+			Key:   target.PubSubOrderingKey,
+			Value: config.EnableOn,
+		},
+		config.KV{
+			Key:   target.PubSubQueueDir,
+			Value: "",
+		},
+		config.KV{
+			Key:   target.PubSubQueueLimit,
+			Value: "0",
+		},
+	}
+)
+
+// GetNotifyPubSub - returns a map of registered notification 'pubsub' targets
+func GetNotifyPubSub(pubSubKVS map[string]config.KVS) (map[string]target.PubSubArgs, error) {
+	pubSubTargets := make(map[string]target.PubSubArgs)
+	for k, kv := range config.Merge(pubSubKVS, target.EnvPubSubEnable, DefaultPubSubKVS) {
+		enableEnv := target.EnvPubSubEnable
+		if k != config.Default {
+			enableEnv = enableEnv + config.Default + k
+		}
+
+		enabled, err := config.ParseBool(env.Get(enableEnv, kv.Get(config.Enable)))
+		if err != nil {
+			return nil, err
+		}
+		if !enabled {
+			continue
+		}
+
+		projectIDEnv := target.EnvPubSubProjectID
+		if k != config.Default {
+			projectIDEnv = projectIDEnv + config.Default + k
+		}
+		topicEnv := target.EnvPubSubTopic
+		if k != config.Default {
+			topicEnv = topicEnv + config.Default + k
+		}
+		credentialsFileEnv := target.EnvPubSubCredentialsFile
+		if k != config.Default {
+			credentialsFileEnv = credentialsFileEnv + config.Default + k
+		}
+
+		orderingKeyEnv := target.EnvPubSubOrderingKey
+		if k != config.Default {
+			orderingKeyEnv = orderingKeyEnv + config.Default + k
+		}
+		orderingKey, err := config.ParseBool(env.Get(orderingKeyEnv, kv.Get(target.PubSubOrderingKey)))
+		if err != nil {
+			return nil, err
+		}
+
+		queueLimitEnv := target.EnvPubSubQueueLimit
+		if k != config.Default {
+			queueLimitEnv = queueLimitEnv + config.Default + k
+		}
+		queueLimit, err := strconv.ParseUint(env.Get(queueLimitEnv, kv.Get(target.PubSubQueueLimit)), 10, 64)
+		if err != nil {
+			return nil, err
+		}
+		queueDirEnv := target.EnvPubSubQueueDir
+		if k != config.Default {
+			queueDirEnv = queueDirEnv + config.Default + k
+		}
+
+		pubSubArgs := target.PubSubArgs{
+			Enable:          enabled,
+			ProjectID:       env.Get(projectIDEnv, kv.Get(target.PubSubProjectID)),
+			Topic:           env.Get(topicEnv, kv.Get(target.PubSubTopic)),
+			CredentialsFile: env.Get(credentialsFileEnv, kv.Get(target.PubSubCredentialsFile)),
+			OrderingKey:     orderingKey,
+			QueueDir:        env.Get(queueDirEnv, kv.Get(target.PubSubQueueDir)),
+			QueueLimit:      queueLimit,
+		}
+
+		if err = pubSubArgs.Validate(); err != nil {
+			return nil, err
+		}
+
+		pubSubTargets[k] = pubSubArgs
+	}
+	return pubSubTargets, nil
+}
+
+// DefaultPulsarKVS - Pulsar KV for config
+var (
+	DefaultPulsarKVS = config.KVS{
+		config.KV{
+			Key:   config.Enable,
+			Value: config.EnableOff,
+		},
+		config.KV{
+			Key:   target.PulsarBroker,
+			Value: "",
+		},
+		config.KV{
+			Key:   target.PulsarTopic,
+			Value: "",
+		},
+		config.KV{
+			Key:   target.PulsarAuthToken,
+			Value: "",
+		},
+		config.KV{
+			Key:   target.PulsarTLSSkipVerify,
+			Value: config.EnableOff,
+		},
+		config.KV{
+			Key:   target.PulsarClientTLSCert,
+			Value: "",
+		},
+		config.KV{
+			Key:   target.PulsarClientTLSKey,
+			Value: "",
+		},
+		config.KV{
+			Key:   target.PulsarBatching,
+			Value: config.EnableOff,
+		},
+		config.KV{
+			Key:   target.PulsarBatchingMaxMessages,
+			Value: "1000",
+		},
+		config.KV{
+			Key:   target.PulsarBatchingMaxPublishDelay,
+			Value: "10ms",
+		},
+		config.KV{
+			Key:   target.PulsarQueueDir,
+			Value: "",
+		},
+		config.KV{
+			Key:   target.PulsarQueueLimit,
+			Value: "0",
+		},
+	}
+)
+
+// GetNotifyPulsar - returns a map of registered notification 'pulsar' targets
+func GetNotifyPulsar(pulsarKVS map[string]config.KVS) (map[string]target.PulsarArgs, error) {
+	pulsarTargets := make(map[string]target.PulsarArgs)
+	for k, kv := range config.Merge(pulsarKVS, target.EnvPulsarEnable, DefaultPulsarKVS) {
+		enableEnv := target.EnvPulsarEnable
+		if k != config.Default {
+			enableEnv = enableEnv + config.Default + k
+		}
+
+		enabled, err := config.ParseBool(env.Get(enableEnv, kv.Get(config.Enable)))
+		if err != nil {
+			return nil, err
+		}
+		if !enabled {
+			continue
+		}
+
+		brokerEnv := target.EnvPulsarBroker
+		if k != config.Default {
+			brokerEnv = brokerEnv + config.Default + k
+		}
+		broker, err := xnet.ParseHTTPURL(env.Get(brokerEnv, kv.Get(target.PulsarBroker)))
+		if err != nil {
+			return nil, err
+		}
+		topicEnv := target.EnvPulsarTopic
+		if k != config.Default {
+			topicEnv = topicEnv + config.Default + k
+		}
+		authTokenEnv := target.EnvPulsarAuthToken
+		if k != config.Default {
+			authTokenEnv = authTokenEnv + config.Default + k
+		}
+		tlsSkipVerifyEnv := target.EnvPulsarTLSSkipVerify
+		if k != config.Default {
+			tlsSkipVerifyEnv = tlsSkipVerifyEnv + config.Default + k
+		}
+		clientTLSCertEnv := target.EnvPulsarClientTLSCert
+		if k != config.Default {
+			clientTLSCertEnv = clientTLSCertEnv + config.Default + k
+		}
+		clientTLSKeyEnv := target.EnvPulsarClientTLSKey
+		if k != config.Default {
+			clientTLSKeyEnv = clientTLSKeyEnv + config.Default + k
+		}
+
+		batchingEnv := target.EnvPulsarBatching
+		if k != config.Default {
+			batchingEnv = batchingEnv + config.Default + k
+		}
+		batching, err := config.ParseBool(env.Get(batchingEnv, kv.Get(target.PulsarBatching)))
+		if err != nil {
+			return nil, err
+		}
+		batchingMaxMessagesEnv := target.EnvPulsarBatchingMaxMessages
+		if k != config.Default {
+			batchingMaxMessagesEnv = batchingMaxMessagesEnv + config.Default + k
+		}
+		batchingMaxMessages, err := strconv.Atoi(env.Get(batchingMaxMessagesEnv, kv.Get(target.PulsarBatchingMaxMessages)))
+		if err != nil {
+			return nil, err
+		}
+		batchingMaxPublishDelayEnv := target.EnvPulsarBatchingMaxPublishDelay
+		if k != config.Default {
+			batchingMaxPublishDelayEnv = batchingMaxPublishDelayEnv + config.Default + k
+		}
+		batchingMaxPublishDelay, err := time.ParseDuration(env.Get(batchingMaxPublishDelayEnv, kv.Get(target.PulsarBatchingMaxPublishDelay)))
+		if err != nil {
+			return nil, err
+		}
+
+		queueLimitEnv := target.EnvPulsarQueueLimit
+		if k != config.Default {
+			queueLimitEnv = queueLimitEnv + config.Default + k
+		}
+		queueLimit, err := strconv.ParseUint(env.Get(queueLimitEnv, kv.Get(target.PulsarQueueLimit)), 10, 64)
+		if err != nil {
+			return nil, err
+		}
+		queueDirEnv := target.EnvPulsarQueueDir
+		if k != config.Default {
+			queueDirEnv = queueDirEnv + config.Default + k
+		}
+
+		pulsarArgs := target.PulsarArgs{
+			Enable:     enabled,
+			Broker:     *broker,
+			Topic:      env.Get(topicEnv, kv.Get(target.PulsarTopic)),
+			AuthToken:  env.Get(authTokenEnv, kv.Get(target.PulsarAuthToken)),
+			QueueDir:   env.Get(queueDirEnv, kv.Get(target.PulsarQueueDir)),
+			QueueLimit: queueLimit,
+		}
+		pulsarArgs.TLS.SkipVerify = env.Get(tlsSkipVerifyEnv, kv.Get(target.PulsarTLSSkipVerify)) == config.EnableOn
+		pulsarArgs.TLS.ClientTLSCert = env.Get(clientTLSCertEnv, kv.Get(target.PulsarClientTLSCert))
+		pulsarArgs.TLS.ClientTLSKey = env.Get(clientTLSKeyEnv, kv.Get(target.PulsarClientTLSKey))
+		pulsarArgs.Batching.Enable = batching
+		pulsarArgs.Batching.MaxMessages = batchingMaxMessages
+		pulsarArgs.Batching.MaxPublishDelay = batchingMaxPublishDelay
+
+		if err = pulsarArgs.Validate(); err != nil {
+			return nil, err
+		}
+
+		pulsarTargets[k] = pulsarArgs
+	}
+	return pulsarTargets, nil
+}
+
 // DefaultPostgresKVS - default Postgres KV for server config.
 var (
 	DefaultPostgresKVS = config.KVS{
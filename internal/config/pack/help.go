@@ -0,0 +1,72 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package pack
+
+import "github.com/minio/minio/internal/config"
+
+// Help template for small object packing.
+var (
+	defaultHelpPostfix = func(key string) string {
+		return config.DefaultHelpPostfix(DefaultKVS, key)
+	}
+
+	Help = config.HelpKVS{
+		config.HelpKV{
+			Key:         Enable,
+			Type:        "on|off",
+			Description: "set to pack the small objects of the configured buckets into containers" + defaultHelpPostfix(Enable),
+			Optional:    true,
+		},
+		config.HelpKV{
+			Key:         Buckets,
+			Type:        "csv",
+			Description: "comma separated list of unversioned buckets whose small objects are packed",
+			Optional:    true,
+		},
+		config.HelpKV{
+			Key:         MaxObjectSize,
+			Type:        "string",
+			Description: "max size of the objects packed, up to 1MiB e.g. \"64KiB\"" + defaultHelpPostfix(MaxObjectSize),
+			Optional:    true,
+		},
+		config.HelpKV{
+			Key:         ContainerSize,
+			Type:        "string",
+			Description: "size of the containers objects are packed into, up to 128MiB" + defaultHelpPostfix(ContainerSize),
+			Optional:    true,
+		},
+		config.HelpKV{
+			Key:         MinAge,
+			Type:        "duration",
+			Description: "min age of the objects packed e.g. 24h" + defaultHelpPostfix(MinAge),
+			Optional:    true,
+		},
+		config.HelpKV{
+			Key:         CompactRatio,
+			Type:        "number",
+			Description: "fraction of deleted bytes above which a container is compacted" + defaultHelpPostfix(CompactRatio),
+			Optional:    true,
+		},
+		config.HelpKV{
+			Key:         Interval,
+			Type:        "duration",
+			Description: "time duration between packing runs" + defaultHelpPostfix(Interval),
+			Optional:    true,
+		},
+	}
+)
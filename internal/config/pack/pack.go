@@ -0,0 +1,182 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package pack
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/dustin/go-humanize"
+	"github.com/minio/minio/internal/config"
+	"github.com/minio/pkg/env"
+)
+
+// Small object packing related keys
+const (
+	Enable        = "enable"
+	Buckets       = "buckets"
+	MaxObjectSize = "max_object_size"
+	ContainerSize = "container_size"
+	MinAge        = "min_age"
+	CompactRatio  = "compact_ratio"
+	Interval      = "interval"
+
+	EnvEnable        = "MINIO_PACK_ENABLE"
+	EnvBuckets       = "MINIO_PACK_BUCKETS"
+	EnvMaxObjectSize = "MINIO_PACK_MAX_OBJECT_SIZE"
+	EnvContainerSize = "MINIO_PACK_CONTAINER_SIZE"
+	EnvMinAge        = "MINIO_PACK_MIN_AGE"
+	EnvCompactRatio  = "MINIO_PACK_COMPACT_RATIO"
+	EnvInterval      = "MINIO_PACK_INTERVAL"
+)
+
+// Limits of the packing settings.
+const (
+	maxObjectSizeLimit = 1 * humanize.MiByte
+	maxContainerSize   = 128 * humanize.MiByte
+	minInterval        = time.Minute
+)
+
+// DefaultKVS - default KV config for small object packing settings
+var DefaultKVS = config.KVS{
+	config.KV{
+		Key:   Enable,
+		Value: config.EnableOff,
+	},
+	config.KV{
+		Key:   Buckets,
+		Value: "",
+	},
+	config.KV{
+		Key:   MaxObjectSize,
+		Value: "16KiB",
+	},
+	config.KV{
+		Key:   ContainerSize,
+		Value: "8MiB",
+	},
+	config.KV{
+		Key:   MinAge,
+		Value: "1h",
+	},
+	config.KV{
+		Key:   CompactRatio,
+		Value: "0.5",
+	},
+	config.KV{
+		Key:   Interval,
+		Value: "1h",
+	},
+}
+
+// Config represents the small object packing settings.
+type Config struct {
+	// Flag indicating whether objects are packed into containers.
+	Enable bool `json:"enable"`
+
+	// Buckets whose small objects are packed, packed objects
+	// are only served from the buckets listed here.
+	Buckets []string `json:"buckets"`
+
+	// Objects up to this size are packed.
+	MaxObjectSize int64 `json:"maxObjectSize"`
+
+	// Size at which a container is written out.
+	ContainerSize int64 `json:"containerSize"`
+
+	// Objects are only packed once they are older than MinAge.
+	MinAge time.Duration `json:"minAge"`
+
+	// Fraction of the bytes of a container occupied by deleted
+	// objects above which the container is compacted.
+	CompactRatio float64 `json:"compactRatio"`
+
+	// The interval between two packing runs.
+	Interval time.Duration `json:"interval"`
+}
+
+// Packed returns true if the small objects of bucket are packed.
+func (cfg Config) Packed(bucket string) bool {
+	for _, b := range cfg.Buckets {
+		if b == bucket {
+			return true
+		}
+	}
+	return false
+}
+
+// LookupConfig - lookup config and override with valid environment settings if any.
+func LookupConfig(kvs config.KVS) (cfg Config, err error) {
+	if err = config.CheckValidKeys(config.PackSubSys, kvs, DefaultKVS); err != nil {
+		return cfg, err
+	}
+
+	cfg.Enable, err = config.ParseBool(env.Get(EnvEnable, kvs.GetWithDefault(Enable, DefaultKVS)))
+	if err != nil {
+		return cfg, fmt.Errorf("'pack:enable' value invalid: %w", err)
+	}
+	for _, bucket := range strings.Split(env.Get(EnvBuckets, kvs.GetWithDefault(Buckets, DefaultKVS)), ",") {
+		if bucket = strings.TrimSpace(bucket); bucket != "" {
+			cfg.Buckets = append(cfg.Buckets, bucket)
+		}
+	}
+	maxObjectSize, err := humanize.ParseBytes(env.Get(EnvMaxObjectSize, kvs.GetWithDefault(MaxObjectSize, DefaultKVS)))
+	if err != nil {
+		return cfg, fmt.Errorf("'pack:max_object_size' value invalid: %w", err)
+	}
+	if maxObjectSize == 0 || maxObjectSize > maxObjectSizeLimit {
+		return cfg, fmt.Errorf("'pack:max_object_size' value invalid: must be between 1B and %s", humanize.IBytes(maxObjectSizeLimit))
+	}
+	cfg.MaxObjectSize = int64(maxObjectSize)
+	containerSize, err := humanize.ParseBytes(env.Get(EnvContainerSize, kvs.GetWithDefault(ContainerSize, DefaultKVS)))
+	if err != nil {
+		return cfg, fmt.Errorf("'pack:container_size' value invalid: %w", err)
+	}
+	if containerSize < maxObjectSize || containerSize > maxContainerSize {
+		return cfg, fmt.Errorf("'pack:container_size' value invalid: must be between max_object_size and %s", humanize.IBytes(maxContainerSize))
+	}
+	cfg.ContainerSize = int64(containerSize)
+	cfg.MinAge, err = time.ParseDuration(env.Get(EnvMinAge, kvs.GetWithDefault(MinAge, DefaultKVS)))
+	if err != nil {
+		return cfg, fmt.Errorf("'pack:min_age' value invalid: %w", err)
+	}
+	if cfg.MinAge < 0 {
+		return cfg, errors.New("'pack:min_age' value invalid: must not be negative")
+	}
+	cfg.CompactRatio, err = strconv.ParseFloat(env.Get(EnvCompactRatio, kvs.GetWithDefault(CompactRatio, DefaultKVS)), 64)
+	if err != nil {
+		return cfg, fmt.Errorf("'pack:compact_ratio' value invalid: %w", err)
+	}
+	if cfg.CompactRatio <= 0 || cfg.CompactRatio > 1 {
+		return cfg, errors.New("'pack:compact_ratio' value invalid: must be greater than 0 and at most 1")
+	}
+	cfg.Interval, err = time.ParseDuration(env.Get(EnvInterval, kvs.GetWithDefault(Interval, DefaultKVS)))
+	if err != nil {
+		return cfg, fmt.Errorf("'pack:interval' value invalid: %w", err)
+	}
+	if cfg.Interval < minInterval {
+		return cfg, fmt.Errorf("'pack:interval' value invalid: must be at least %s", minInterval)
+	}
+	if cfg.Enable && len(cfg.Buckets) == 0 {
+		return cfg, errors.New("'pack:buckets' must be set to enable packing")
+	}
+	return cfg, nil
+}
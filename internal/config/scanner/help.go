@@ -44,5 +44,23 @@ var (
 			Optional:    true,
 			Type:        "duration",
 		},
+		config.HelpKV{
+			Key:         IOLimit,
+			Description: `maximum number of objects scanned per second on each node, 0 for no limit` + defaultHelpPostfix(IOLimit),
+			Optional:    true,
+			Type:        "number",
+		},
+		config.HelpKV{
+			Key:         Windows,
+			Description: `comma separated daily UTC time windows the scanner runs in e.g. "20:00-06:00", always when empty` + defaultHelpPostfix(Windows),
+			Optional:    true,
+			Type:        "csv",
+		},
+		config.HelpKV{
+			Key:         DisabledPools,
+			Description: `comma separated indexes of the pools not scanned, starting at 0` + defaultHelpPostfix(DisabledPools),
+			Optional:    true,
+			Type:        "csv",
+		},
 	}
 )
@@ -18,7 +18,9 @@
 package scanner
 
 import (
+	"fmt"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/minio/minio/internal/config"
@@ -27,15 +29,21 @@ import (
 
 // Compression environment variables
 const (
-	Delay   = "delay"
-	MaxWait = "max_wait"
-	Cycle   = "cycle"
+	Delay         = "delay"
+	MaxWait       = "max_wait"
+	Cycle         = "cycle"
+	IOLimit       = "io_limit"
+	Windows       = "windows"
+	DisabledPools = "disabled_pools"
 
 	EnvDelay         = "MINIO_SCANNER_DELAY"
 	EnvCycle         = "MINIO_SCANNER_CYCLE"
 	EnvDelayLegacy   = "MINIO_CRAWLER_DELAY"
 	EnvMaxWait       = "MINIO_SCANNER_MAX_WAIT"
 	EnvMaxWaitLegacy = "MINIO_CRAWLER_MAX_WAIT"
+	EnvIOLimit       = "MINIO_SCANNER_IO_LIMIT"
+	EnvWindows       = "MINIO_SCANNER_WINDOWS"
+	EnvDisabledPools = "MINIO_SCANNER_DISABLED_POOLS"
 )
 
 // Config represents the heal settings.
@@ -46,6 +54,136 @@ type Config struct {
 	MaxWait time.Duration
 	// Cycle is the time.Duration between each scanner cycles
 	Cycle time.Duration
+	// IOLimit is the maximum number of objects scanned per second on
+	// each node, 0 for no limit.
+	IOLimit int `json:"ioLimit"`
+	// Windows are the daily time windows the scanner is allowed to run
+	// in, it always runs when there is none.
+	Windows []Window `json:"windows"`
+	// DisabledPools are the indexes of the pools not scanned.
+	DisabledPools []int `json:"disabledPools"`
+}
+
+// Window is a daily time window in UTC, as offsets from midnight. A
+// window ending before it starts spans midnight.
+type Window struct {
+	Start time.Duration `json:"start"`
+	End   time.Duration `json:"end"`
+}
+
+// String returns the window in the HH:MM-HH:MM format.
+func (w Window) String() string {
+	hhmm := func(d time.Duration) string {
+		return fmt.Sprintf("%02d:%02d", int(d.Hours()), int(d.Minutes())%60)
+	}
+	return hhmm(w.Start) + "-" + hhmm(w.End)
+}
+
+func sinceMidnight(t time.Time) time.Duration {
+	t = t.UTC()
+	return time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute +
+		time.Duration(t.Second())*time.Second + time.Duration(t.Nanosecond())
+}
+
+// Contains returns true if t is within the window.
+func (w Window) Contains(t time.Time) bool {
+	d := sinceMidnight(t)
+	if w.Start <= w.End {
+		return d >= w.Start && d < w.End
+	}
+	return d >= w.Start || d < w.End
+}
+
+// Allowed returns true if the scanner is allowed to run at t.
+func (cfg Config) Allowed(t time.Time) bool {
+	if len(cfg.Windows) == 0 {
+		return true
+	}
+	for _, w := range cfg.Windows {
+		if w.Contains(t) {
+			return true
+		}
+	}
+	return false
+}
+
+// NextAllowed returns the duration from t until the scanner is allowed
+// to run, 0 if it is allowed at t.
+func (cfg Config) NextAllowed(t time.Time) time.Duration {
+	if cfg.Allowed(t) {
+		return 0
+	}
+	d := sinceMidnight(t)
+	next := 24 * time.Hour
+	for _, w := range cfg.Windows {
+		wait := w.Start - d
+		if wait < 0 {
+			wait += 24 * time.Hour
+		}
+		if wait < next {
+			next = wait
+		}
+	}
+	return next
+}
+
+// PoolDisabled returns true if the pool at index idx is not scanned.
+func (cfg Config) PoolDisabled(idx int) bool {
+	for _, i := range cfg.DisabledPools {
+		if i == idx {
+			return true
+		}
+	}
+	return false
+}
+
+func parseTimeOfDay(s string) (time.Duration, error) {
+	t, err := time.Parse("15:04", strings.TrimSpace(s))
+	if err != nil {
+		return 0, fmt.Errorf("invalid time of day %q, expected HH:MM", s)
+	}
+	return time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute, nil
+}
+
+// ParseWindows parses comma separated HH:MM-HH:MM time windows.
+func ParseWindows(s string) (windows []Window, err error) {
+	for _, w := range strings.Split(s, ",") {
+		if strings.TrimSpace(w) == "" {
+			continue
+		}
+		bounds := strings.Split(w, "-")
+		if len(bounds) != 2 {
+			return nil, fmt.Errorf("invalid scanner window %q, expected HH:MM-HH:MM", w)
+		}
+		var window Window
+		if window.Start, err = parseTimeOfDay(bounds[0]); err != nil {
+			return nil, err
+		}
+		if window.End, err = parseTimeOfDay(bounds[1]); err != nil {
+			return nil, err
+		}
+		if window.Start == window.End {
+			return nil, fmt.Errorf("invalid scanner window %q, it is empty", w)
+		}
+		windows = append(windows, window)
+	}
+	return windows, nil
+}
+
+// ParsePools parses comma separated pool indexes.
+func ParsePools(s string) (pools []int, err error) {
+	for _, p := range strings.Split(s, ",") {
+		p = strings.TrimSpace(p)
+		if p == "" {
+			continue
+		}
+		idx, err := strconv.Atoi(p)
+		if err != nil || idx < 0 {
+			return nil, fmt.Errorf("invalid pool index %q", p)
+		}
+		pools = append(pools, idx)
+	}
+	return pools, nil
 }
 
 // DefaultKVS - default KV config for heal settings
@@ -62,6 +200,18 @@ var DefaultKVS = config.KVS{
 		Key:   Cycle,
 		Value: "1m",
 	},
+	config.KV{
+		Key:   IOLimit,
+		Value: "0",
+	},
+	config.KV{
+		Key:   Windows,
+		Value: "",
+	},
+	config.KV{
+		Key:   DisabledPools,
+		Value: "",
+	},
 }
 
 // LookupConfig - lookup config and override with valid environment settings if any.
@@ -90,5 +240,21 @@ func LookupConfig(kvs config.KVS) (cfg Config, err error) {
 	if err != nil {
 		return cfg, err
 	}
+
+	cfg.IOLimit, err = strconv.Atoi(env.Get(EnvIOLimit, kvs.GetWithDefault(IOLimit, DefaultKVS)))
+	if err != nil {
+		return cfg, err
+	}
+	if cfg.IOLimit < 0 {
+		return cfg, fmt.Errorf("invalid scanner io_limit %d, must be positive", cfg.IOLimit)
+	}
+	cfg.Windows, err = ParseWindows(env.Get(EnvWindows, kvs.GetWithDefault(Windows, DefaultKVS)))
+	if err != nil {
+		return cfg, err
+	}
+	cfg.DisabledPools, err = ParsePools(env.Get(EnvDisabledPools, kvs.GetWithDefault(DisabledPools, DefaultKVS)))
+	if err != nil {
+		return cfg, err
+	}
 	return cfg, nil
 }
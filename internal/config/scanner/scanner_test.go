@@ -0,0 +1,83 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package scanner
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseWindows(t *testing.T) {
+	if _, err := ParseWindows("25:00-06:00"); err == nil {
+		t.Fatal("expected an error for an invalid time of day")
+	}
+	if _, err := ParseWindows("06:00"); err == nil {
+		t.Fatal("expected an error for a window without end")
+	}
+	if _, err := ParseWindows("06:00-06:00"); err == nil {
+		t.Fatal("expected an error for an empty window")
+	}
+
+	windows, err := ParseWindows("20:00-06:00, 12:00-13:30")
+	if err != nil {
+		t.Fatal(err)
+	}
+	cfg := Config{Windows: windows}
+	if s := windows[1].String(); s != "12:00-13:30" {
+		t.Fatalf("expected 12:00-13:30, got %s", s)
+	}
+
+	day := time.Date(2022, 3, 1, 0, 0, 0, 0, time.UTC)
+	for i, tc := range []struct {
+		at      time.Duration
+		allowed bool
+		next    time.Duration
+	}{
+		{at: 23 * time.Hour, allowed: true},
+		{at: 2 * time.Hour, allowed: true},
+		{at: 6 * time.Hour, next: 6 * time.Hour},
+		{at: 12*time.Hour + 45*time.Minute, allowed: true},
+		{at: 13*time.Hour + 30*time.Minute, next: 6*time.Hour + 30*time.Minute},
+	} {
+		at := day.Add(tc.at)
+		if allowed := cfg.Allowed(at); allowed != tc.allowed {
+			t.Errorf("Test %d: expected allowed %v, got %v", i+1, tc.allowed, allowed)
+		}
+		if next := cfg.NextAllowed(at); next != tc.next {
+			t.Errorf("Test %d: expected next allowed in %v, got %v", i+1, tc.next, next)
+		}
+	}
+
+	if !(Config{}).Allowed(day) {
+		t.Fatal("expected the scanner to be allowed without windows")
+	}
+}
+
+func TestParsePools(t *testing.T) {
+	pools, err := ParsePools("0, 2")
+	if err != nil {
+		t.Fatal(err)
+	}
+	cfg := Config{DisabledPools: pools}
+	if !cfg.PoolDisabled(0) || cfg.PoolDisabled(1) || !cfg.PoolDisabled(2) {
+		t.Fatalf("unexpected disabled pools %v", pools)
+	}
+	if _, err = ParsePools("-1"); err == nil {
+		t.Fatal("expected an error for a negative pool index")
+	}
+}
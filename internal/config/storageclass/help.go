@@ -38,6 +38,24 @@ var (
 			Optional:    true,
 			Type:        "string",
 		},
+		config.HelpKV{
+			Key:         InlineBlock,
+			Description: `max erasure shard size stored inline in object metadata, versioned objects use 1/8th of it e.g. "256KiB", "0" disables inlining` + defaultHelpPostfix(InlineBlock),
+			Optional:    true,
+			Type:        "string",
+		},
+		config.HelpKV{
+			Key:         StandardBitrot,
+			Description: `bitrot checksum algorithm of new objects, one of "highwayhash", "xxhash64" or "blake3"` + defaultHelpPostfix(StandardBitrot),
+			Optional:    true,
+			Type:        "string",
+		},
+		config.HelpKV{
+			Key:         RRSBitrot,
+			Description: `bitrot checksum algorithm of new reduced redundancy objects, defaults to the standard storage class algorithm`,
+			Optional:    true,
+			Type:        "string",
+		},
 		config.HelpKV{
 			Key:         config.Comment,
 			Description: config.DefaultComment,
@@ -24,6 +24,7 @@ import (
 	"strings"
 	"sync"
 
+	"github.com/dustin/go-humanize"
 	"github.com/minio/minio/internal/config"
 	"github.com/minio/pkg/env"
 )
@@ -38,13 +39,22 @@ const (
 
 // Standard constats for config info storage class
 const (
-	ClassStandard = "standard"
-	ClassRRS      = "rrs"
+	ClassStandard  = "standard"
+	ClassRRS       = "rrs"
+	InlineBlock    = "inline_block"
+	StandardBitrot = "standard_bitrot"
+	RRSBitrot      = "rrs_bitrot"
 
 	// Reduced redundancy storage class environment variable
 	RRSEnv = "MINIO_STORAGE_CLASS_RRS"
 	// Standard storage class environment variable
 	StandardEnv = "MINIO_STORAGE_CLASS_STANDARD"
+	// Inline block size environment variable
+	InlineBlockEnv = "MINIO_STORAGE_CLASS_INLINE_BLOCK"
+	// Standard storage class bitrot algorithm environment variable
+	StandardBitrotEnv = "MINIO_STORAGE_CLASS_STANDARD_BITROT"
+	// Reduced redundancy storage class bitrot algorithm environment variable
+	RRSBitrotEnv = "MINIO_STORAGE_CLASS_RRS_BITROT"
 
 	// Supported storage class scheme is EC
 	schemePrefix = "EC"
@@ -54,6 +64,19 @@ const (
 
 	// Default RRS parity is always minimum parity.
 	defaultRRSParity = minParityDisks
+
+	// Default shard size under which object data is inlined in xl.meta.
+	defaultInlineBlock = 128 * humanize.KiByte
+
+	// Max allowed inline block size, same as the erasure block size.
+	maxInlineBlock = 1 * humanize.MiByte
+)
+
+// Supported bitrot checksum algorithms of new objects.
+const (
+	BitrotHighwayHash = "highwayhash"
+	BitrotXXHash64    = "xxhash64"
+	BitrotBLAKE3      = "blake3"
 )
 
 // DefaultKVS - default storage class config
@@ -67,6 +90,18 @@ var (
 			Key:   ClassRRS,
 			Value: "EC:2",
 		},
+		config.KV{
+			Key:   InlineBlock,
+			Value: "128KiB",
+		},
+		config.KV{
+			Key:   StandardBitrot,
+			Value: BitrotHighwayHash,
+		},
+		config.KV{
+			Key:   RRSBitrot,
+			Value: "",
+		},
 	}
 )
 
@@ -82,6 +117,11 @@ var ConfigLock = sync.RWMutex{}
 type Config struct {
 	Standard StorageClass `json:"standard"`
 	RRS      StorageClass `json:"rrs"`
+
+	inlineBlock    int64
+	standardBitrot string
+	rrsBitrot      string
+	initialized    bool
 }
 
 // UnmarshalJSON - Validate SS and RRS parity when unmarshalling JSON.
@@ -160,6 +200,17 @@ func parseStorageClass(storageClassEnv string) (sc StorageClass, err error) {
 	}, nil
 }
 
+// parseBitrot validates a bitrot checksum algorithm, an empty value
+// selects the default algorithm.
+func parseBitrot(algorithm string) (string, error) {
+	switch algorithm = strings.ToLower(strings.TrimSpace(algorithm)); algorithm {
+	case "", BitrotHighwayHash, BitrotXXHash64, BitrotBLAKE3:
+		return algorithm, nil
+	}
+	return "", config.ErrStorageClassValue(nil).Msg(fmt.Sprintf("Unsupported bitrot algorithm %s. Supported algorithms are %s, %s and %s",
+		algorithm, BitrotHighwayHash, BitrotXXHash64, BitrotBLAKE3))
+}
+
 // ValidateParity validate standard storage class parity.
 func ValidateParity(ssParity, setDriveCount int) error {
 	// SS parity disks should be greater than or equal to minParityDisks.
@@ -233,12 +284,57 @@ func (sCfg Config) GetParityForSC(sc string) (parity int) {
 	}
 }
 
+// InlineBlock returns the shard size under which object data is
+// stored inline in xl.meta.
+func (sCfg *Config) InlineBlock() int64 {
+	ConfigLock.RLock()
+	defer ConfigLock.RUnlock()
+	if !sCfg.initialized {
+		return defaultInlineBlock
+	}
+	return sCfg.inlineBlock
+}
+
+// ShouldInline returns true if an object with the given erasure shard
+// size should be stored inline in xl.meta. Versioned objects accumulate
+// versions in the same xl.meta, so they are only inlined under 1/8th
+// of the inline block size.
+func (sCfg *Config) ShouldInline(shardSize int64, versioned bool) bool {
+	if shardSize < 0 {
+		return false
+	}
+	inlineBlock := sCfg.InlineBlock()
+	if versioned {
+		return shardSize < inlineBlock/8
+	}
+	return shardSize < inlineBlock
+}
+
+// BitrotAlgorithm returns the bitrot checksum algorithm of new objects
+// of the storage class sc, reduced redundancy objects use the standard
+// storage class algorithm unless one is configured for them.
+func (sCfg *Config) BitrotAlgorithm(sc string) string {
+	ConfigLock.RLock()
+	defer ConfigLock.RUnlock()
+	if strings.TrimSpace(sc) == RRS && sCfg.rrsBitrot != "" {
+		return sCfg.rrsBitrot
+	}
+	if sCfg.standardBitrot == "" {
+		return BitrotHighwayHash
+	}
+	return sCfg.standardBitrot
+}
+
 // Update update storage-class with new config
 func (sCfg *Config) Update(newCfg Config) {
 	ConfigLock.Lock()
 	defer ConfigLock.Unlock()
 	sCfg.RRS = newCfg.RRS
 	sCfg.Standard = newCfg.Standard
+	sCfg.inlineBlock = newCfg.inlineBlock
+	sCfg.standardBitrot = newCfg.standardBitrot
+	sCfg.rrsBitrot = newCfg.rrsBitrot
+	sCfg.initialized = true
 }
 
 // Enabled returns if etcd is enabled.
@@ -284,5 +380,25 @@ func LookupConfig(kvs config.KVS, setDriveCount int) (cfg Config, err error) {
 		return Config{}, err
 	}
 
+	inlineBlock, err := humanize.ParseBytes(env.Get(InlineBlockEnv, kvs.GetWithDefault(InlineBlock, DefaultKVS)))
+	if err != nil {
+		return Config{}, config.ErrStorageClassValue(err)
+	}
+	if inlineBlock > maxInlineBlock {
+		return Config{}, config.ErrStorageClassValue(nil).Msg(fmt.Sprintf("inline block size %s should be less than or equal to %s",
+			humanize.IBytes(inlineBlock), humanize.IBytes(maxInlineBlock)))
+	}
+	cfg.inlineBlock = int64(inlineBlock)
+
+	cfg.standardBitrot, err = parseBitrot(env.Get(StandardBitrotEnv, kvs.GetWithDefault(StandardBitrot, DefaultKVS)))
+	if err != nil {
+		return Config{}, err
+	}
+	cfg.rrsBitrot, err = parseBitrot(env.Get(RRSBitrotEnv, kvs.Get(RRSBitrot)))
+	if err != nil {
+		return Config{}, err
+	}
+	cfg.initialized = true
+
 	return cfg, nil
 }
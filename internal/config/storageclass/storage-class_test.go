@@ -186,3 +186,89 @@ func TestIsValidStorageClassKind(t *testing.T) {
 		}
 	}
 }
+
+func TestShouldInline(t *testing.T) {
+	kvs := DefaultKVS.Clone()
+	kvs.Set(InlineBlock, "64KiB")
+	cfg, err := LookupConfig(kvs, 16)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		cfg       Config
+		shardSize int64
+		versioned bool
+		want      bool
+	}{
+		// Uninitialized config uses the default 128KiB.
+		{Config{}, 64 << 10, false, true},
+		{Config{}, 128 << 10, false, false},
+		{Config{}, 16 << 10, true, false},
+		{Config{}, 15 << 10, true, true},
+		{Config{}, -1, false, false},
+		{cfg, 32 << 10, false, true},
+		{cfg, 64 << 10, false, false},
+		{cfg, 8 << 10, true, false},
+		{cfg, 7 << 10, true, true},
+	}
+	for i, tt := range tests {
+		if got := tt.cfg.ShouldInline(tt.shardSize, tt.versioned); got != tt.want {
+			t.Errorf("Test %d, expected %t, got %t", i+1, tt.want, got)
+		}
+	}
+
+	kvs.Set(InlineBlock, "0")
+	if cfg, err = LookupConfig(kvs, 16); err != nil {
+		t.Fatal(err)
+	}
+	if cfg.ShouldInline(0, false) {
+		t.Error("expected inlining to be disabled")
+	}
+
+	kvs.Set(InlineBlock, "2MiB")
+	if _, err = LookupConfig(kvs, 16); err == nil {
+		t.Error("expected an error for an inline block bigger than 1MiB")
+	}
+}
+
+func TestBitrotAlgorithm(t *testing.T) {
+	kvs := DefaultKVS.Clone()
+	cfg, err := LookupConfig(kvs, 16)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := cfg.BitrotAlgorithm(STANDARD); got != BitrotHighwayHash {
+		t.Errorf("expected %s by default, got %s", BitrotHighwayHash, got)
+	}
+	if got := cfg.BitrotAlgorithm(RRS); got != BitrotHighwayHash {
+		t.Errorf("expected %s by default for RRS, got %s", BitrotHighwayHash, got)
+	}
+
+	kvs.Set(StandardBitrot, "XXHash64")
+	if cfg, err = LookupConfig(kvs, 16); err != nil {
+		t.Fatal(err)
+	}
+	if got := cfg.BitrotAlgorithm(""); got != BitrotXXHash64 {
+		t.Errorf("expected %s, got %s", BitrotXXHash64, got)
+	}
+	if got := cfg.BitrotAlgorithm(RRS); got != BitrotXXHash64 {
+		t.Errorf("expected RRS to use the standard %s, got %s", BitrotXXHash64, got)
+	}
+
+	kvs.Set(RRSBitrot, BitrotBLAKE3)
+	if cfg, err = LookupConfig(kvs, 16); err != nil {
+		t.Fatal(err)
+	}
+	if got := cfg.BitrotAlgorithm(RRS); got != BitrotBLAKE3 {
+		t.Errorf("expected %s for RRS, got %s", BitrotBLAKE3, got)
+	}
+	if got := cfg.BitrotAlgorithm(STANDARD); got != BitrotXXHash64 {
+		t.Errorf("expected %s, got %s", BitrotXXHash64, got)
+	}
+
+	kvs.Set(RRSBitrot, "md5")
+	if _, err = LookupConfig(kvs, 16); err == nil {
+		t.Error("expected an unsupported bitrot algorithm to be rejected")
+	}
+}
@@ -0,0 +1,66 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package usageexport
+
+import "github.com/minio/minio/internal/config"
+
+var (
+	defaultHelpPostfix = func(key string) string {
+		return config.DefaultHelpPostfix(DefaultKVS, key)
+	}
+
+	// Help provides help for usage export config
+	Help = config.HelpKVS{
+		config.HelpKV{
+			Key:         Enable,
+			Type:        "on|off",
+			Description: "set to periodically export the data usage breakdown to a bucket" + defaultHelpPostfix(Enable),
+			Optional:    true,
+		},
+		config.HelpKV{
+			Key:         Bucket,
+			Type:        "string",
+			Description: "bucket the data usage exports are written to",
+			Optional:    true,
+		},
+		config.HelpKV{
+			Key:         Prefix,
+			Type:        "string",
+			Description: "prefix the data usage exports are written under" + defaultHelpPostfix(Prefix),
+			Optional:    true,
+		},
+		config.HelpKV{
+			Key:         Format,
+			Type:        "csv|parquet",
+			Description: "file format of the data usage exports" + defaultHelpPostfix(Format),
+			Optional:    true,
+		},
+		config.HelpKV{
+			Key:         Frequency,
+			Type:        "duration",
+			Description: "time duration between data usage exports e.g. 24h" + defaultHelpPostfix(Frequency),
+			Optional:    true,
+		},
+		config.HelpKV{
+			Key:         Depth,
+			Type:        "number",
+			Description: "number of prefix levels broken down under each bucket, 0 for buckets only" + defaultHelpPostfix(Depth),
+			Optional:    true,
+		},
+	}
+)
@@ -0,0 +1,139 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package usageexport
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/minio/minio/internal/config"
+	"github.com/minio/pkg/env"
+)
+
+// Usage export related keys
+const (
+	Enable    = "enable"
+	Bucket    = "bucket"
+	Prefix    = "prefix"
+	Format    = "format"
+	Frequency = "frequency"
+	Depth     = "depth"
+
+	EnvEnable    = "MINIO_USAGE_EXPORT_ENABLE"
+	EnvBucket    = "MINIO_USAGE_EXPORT_BUCKET"
+	EnvPrefix    = "MINIO_USAGE_EXPORT_PREFIX"
+	EnvFormat    = "MINIO_USAGE_EXPORT_FORMAT"
+	EnvFrequency = "MINIO_USAGE_EXPORT_FREQUENCY"
+	EnvDepth     = "MINIO_USAGE_EXPORT_DEPTH"
+)
+
+// Supported export formats.
+const (
+	FormatCSV     = "csv"
+	FormatParquet = "parquet"
+)
+
+// maxDepth is the deepest prefix level exported.
+const maxDepth = 16
+
+// DefaultKVS - default KV config for usage export settings
+var DefaultKVS = config.KVS{
+	config.KV{
+		Key:   Enable,
+		Value: config.EnableOff,
+	},
+	config.KV{
+		Key:   Bucket,
+		Value: "",
+	},
+	config.KV{
+		Key:   Prefix,
+		Value: "",
+	},
+	config.KV{
+		Key:   Format,
+		Value: FormatCSV,
+	},
+	config.KV{
+		Key:   Frequency,
+		Value: "24h",
+	},
+	config.KV{
+		Key:   Depth,
+		Value: "0",
+	},
+}
+
+// Config represents the usage export settings.
+type Config struct {
+	// Flag indicating whether usage exports are enabled.
+	Enable bool `json:"enable"`
+
+	// Bucket and prefix the exports are written to.
+	Bucket string `json:"bucket"`
+	Prefix string `json:"prefix"`
+
+	// Format of the exports, csv or parquet.
+	Format string `json:"format"`
+
+	// The interval between two exports.
+	Frequency time.Duration `json:"frequency"`
+
+	// Number of prefix levels broken down under each bucket,
+	// 0 only exports the usage of buckets.
+	Depth int `json:"depth"`
+}
+
+// LookupConfig - lookup config and override with valid environment settings if any.
+func LookupConfig(kvs config.KVS) (cfg Config, err error) {
+	if err = config.CheckValidKeys(config.UsageExportSubSys, kvs, DefaultKVS); err != nil {
+		return cfg, err
+	}
+
+	cfg.Enable, err = config.ParseBool(env.Get(EnvEnable, kvs.GetWithDefault(Enable, DefaultKVS)))
+	if err != nil {
+		return cfg, fmt.Errorf("'usage_export:enable' value invalid: %w", err)
+	}
+	cfg.Bucket = env.Get(EnvBucket, kvs.GetWithDefault(Bucket, DefaultKVS))
+	cfg.Prefix = strings.TrimPrefix(env.Get(EnvPrefix, kvs.GetWithDefault(Prefix, DefaultKVS)), "/")
+	cfg.Format = strings.ToLower(env.Get(EnvFormat, kvs.GetWithDefault(Format, DefaultKVS)))
+	if cfg.Format != FormatCSV && cfg.Format != FormatParquet {
+		return cfg, fmt.Errorf("'usage_export:format' value invalid: %q, must be %s or %s", cfg.Format, FormatCSV, FormatParquet)
+	}
+	cfg.Frequency, err = time.ParseDuration(env.Get(EnvFrequency, kvs.GetWithDefault(Frequency, DefaultKVS)))
+	if err != nil {
+		return cfg, fmt.Errorf("'usage_export:frequency' value invalid: %w", err)
+	}
+	if cfg.Frequency < time.Hour {
+		return cfg, errors.New("'usage_export:frequency' value invalid: must be at least 1h")
+	}
+	cfg.Depth, err = strconv.Atoi(env.Get(EnvDepth, kvs.GetWithDefault(Depth, DefaultKVS)))
+	if err != nil {
+		return cfg, fmt.Errorf("'usage_export:depth' value invalid: %w", err)
+	}
+	if cfg.Depth < 0 || cfg.Depth > maxDepth {
+		return cfg, fmt.Errorf("'usage_export:depth' value invalid: must be between 0 and %d", maxDepth)
+	}
+	if cfg.Enable && cfg.Bucket == "" {
+		return cfg, errors.New("'usage_export:bucket' must be set to enable usage exports")
+	}
+	return cfg, nil
+}
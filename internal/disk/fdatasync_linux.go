@@ -45,3 +45,9 @@ func Fdatasync(f *os.File) error {
 func FadviseDontNeed(f *os.File) error {
 	return unix.Fadvise(int(f.Fd()), 0, 0, unix.FADV_DONTNEED)
 }
+
+// FadviseWillNeed hints the kernel to prefetch length bytes at offset
+// into the page-cache.
+func FadviseWillNeed(f *os.File, offset, length int64) error {
+	return unix.Fadvise(int(f.Fd()), offset, length, unix.FADV_WILLNEED)
+}
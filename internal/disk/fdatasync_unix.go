@@ -34,3 +34,8 @@ func Fdatasync(f *os.File) error {
 func FadviseDontNeed(f *os.File) error {
 	return nil
 }
+
+// FadviseWillNeed is a no-op
+func FadviseWillNeed(f *os.File, offset, length int64) error {
+	return nil
+}
@@ -0,0 +1,54 @@
+//go:build linux
+// +build linux
+
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package disk
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"syscall"
+
+	"golang.org/x/sys/unix"
+)
+
+// IsRotational returns true if path resides on a rotational drive (HDD)
+// as reported by the kernel for the underlying block device.
+func IsRotational(path string) (bool, error) {
+	st := syscall.Stat_t{}
+	if err := syscall.Stat(path, &st); err != nil {
+		return false, err
+	}
+	dev, err := filepath.EvalSymlinks(fmt.Sprintf("/sys/dev/block/%d:%d",
+		unix.Major(uint64(st.Dev)), unix.Minor(uint64(st.Dev))))
+	if err != nil {
+		return false, err
+	}
+	b, err := os.ReadFile(filepath.Join(dev, "queue", "rotational"))
+	if os.IsNotExist(err) {
+		// Partitions share the request queue of their parent device.
+		b, err = os.ReadFile(filepath.Join(dev, "..", "queue", "rotational"))
+	}
+	if err != nil {
+		return false, err
+	}
+	return strings.TrimSpace(string(b)) == "1", nil
+}
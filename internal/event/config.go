@@ -137,14 +137,15 @@ func (ruleList FilterRuleList) Pattern() string {
 	return NewPattern(prefix, suffix)
 }
 
-// S3Key - represents elements inside <S3Key>...</S3Key>
+// S3Key - represents elements inside <S3Key>...</S3Key> and <S3Object>...</S3Object>
 type S3Key struct {
-	RuleList FilterRuleList `xml:"S3Key,omitempty" json:"S3Key,omitempty"`
+	RuleList       FilterRuleList       `xml:"S3Key,omitempty" json:"S3Key,omitempty"`
+	ObjectRuleList ObjectFilterRuleList `xml:"S3Object,omitempty" json:"S3Object,omitempty"`
 }
 
 // MarshalXML implements a custom marshaller to support `omitempty` feature.
 func (s3Key S3Key) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
-	if s3Key.RuleList.isEmpty() {
+	if s3Key.RuleList.isEmpty() && s3Key.ObjectRuleList.isEmpty() {
 		return nil
 	}
 	type s3KeyWrapper S3Key
@@ -220,6 +221,13 @@ func (q *Queue) SetRegion(region string) {
 // ToRulesMap - converts Queue to RulesMap
 func (q Queue) ToRulesMap() RulesMap {
 	pattern := q.Filter.RuleList.Pattern()
+	if filter := q.Filter.ObjectRuleList.Filter(); filter != "" {
+		// If pattern is empty, add '*' wildcard to match all.
+		if pattern == "" {
+			pattern = "*"
+		}
+		pattern += filter
+	}
 	return NewRulesMap(q.Events, pattern, q.ARN.TargetID)
 }
 
@@ -31,6 +31,8 @@ func IsEventError(err error) bool {
 		return true
 	case ErrFilterNameSuffix, *ErrFilterNameSuffix:
 		return true
+	case ErrDuplicateFilterName, *ErrDuplicateFilterName:
+		return true
 	case ErrInvalidFilterValue, *ErrInvalidFilterValue:
 		return true
 	case ErrDuplicateEventName, *ErrDuplicateEventName:
@@ -75,6 +77,15 @@ func (err ErrFilterNameSuffix) Error() string {
 	return "more than one suffix in filter rule"
 }
 
+// ErrDuplicateFilterName - more than one object filter rule of a name error.
+type ErrDuplicateFilterName struct {
+	FilterName string
+}
+
+func (err ErrDuplicateFilterName) Error() string {
+	return fmt.Sprintf("more than one '%v' in object filter rule", err.FilterName)
+}
+
 // ErrInvalidFilterValue - invalid filter value error.
 type ErrInvalidFilterValue struct {
 	FilterValue string
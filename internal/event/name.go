@@ -25,7 +25,8 @@ import (
 // Name - event type enum.
 // Refer http://docs.aws.amazon.com/AmazonS3/latest/dev/NotificationHowTo.html#notification-how-to-event-types-and-destinations
 // for most basic values we have since extend this and its not really much applicable other than a reference point.
-// "s3:Replication:OperationCompletedReplication" is a MinIO extension.
+// "s3:Replication:OperationCompletedReplication" and
+// "s3:BucketQuota:ThresholdCrossed" are MinIO extensions.
 type Name int
 
 // Values of event Name
@@ -57,10 +58,12 @@ const (
 	ObjectReplicationNotTracked
 	ObjectRestorePostInitiated
 	ObjectRestorePostCompleted
+	ObjectRestoreFailed
 	ObjectRestorePostAll
 	ObjectTransitionAll
 	ObjectTransitionFailed
 	ObjectTransitionComplete
+	BucketQuotaThresholdCrossed
 )
 
 // Expand - returns expanded values of abbreviated event type.
@@ -70,6 +73,8 @@ func (name Name) Expand() []Name {
 		return []Name{BucketCreated}
 	case BucketRemoved:
 		return []Name{BucketRemoved}
+	case BucketQuotaThresholdCrossed:
+		return []Name{BucketQuotaThresholdCrossed}
 	case ObjectAccessedAll:
 		return []Name{
 			ObjectAccessedGet, ObjectAccessedHead,
@@ -99,6 +104,7 @@ func (name Name) Expand() []Name {
 		return []Name{
 			ObjectRestorePostInitiated,
 			ObjectRestorePostCompleted,
+			ObjectRestoreFailed,
 		}
 	case ObjectTransitionAll:
 		return []Name{
@@ -117,6 +123,8 @@ func (name Name) String() string {
 		return "s3:BucketCreated:*"
 	case BucketRemoved:
 		return "s3:BucketRemoved:*"
+	case BucketQuotaThresholdCrossed:
+		return "s3:BucketQuota:ThresholdCrossed"
 	case ObjectAccessedAll:
 		return "s3:ObjectAccessed:*"
 	case ObjectAccessedGet:
@@ -167,6 +175,8 @@ func (name Name) String() string {
 		return "s3:ObjectRestore:Post"
 	case ObjectRestorePostCompleted:
 		return "s3:ObjectRestore:Completed"
+	case ObjectRestoreFailed:
+		return "s3:ObjectRestore:Failed"
 	case ObjectTransitionAll:
 		return "s3:ObjectTransition:*"
 	case ObjectTransitionFailed:
@@ -227,6 +237,8 @@ func ParseName(s string) (Name, error) {
 		return BucketCreated, nil
 	case "s3:BucketRemoved:*":
 		return BucketRemoved, nil
+	case "s3:BucketQuota:ThresholdCrossed":
+		return BucketQuotaThresholdCrossed, nil
 	case "s3:ObjectAccessed:*":
 		return ObjectAccessedAll, nil
 	case "s3:ObjectAccessed:Get":
@@ -279,6 +291,8 @@ func ParseName(s string) (Name, error) {
 		return ObjectRestorePostInitiated, nil
 	case "s3:ObjectRestore:Completed":
 		return ObjectRestorePostCompleted, nil
+	case "s3:ObjectRestore:Failed":
+		return ObjectRestoreFailed, nil
 	case "s3:ObjectTransition:Failed":
 		return ObjectTransitionFailed, nil
 	case "s3:ObjectTransition:Complete":
@@ -58,6 +58,7 @@ func TestNameString(t *testing.T) {
 	}{
 		{BucketCreated, "s3:BucketCreated:*"},
 		{BucketRemoved, "s3:BucketRemoved:*"},
+		{BucketQuotaThresholdCrossed, "s3:BucketQuota:ThresholdCrossed"},
 		{ObjectAccessedAll, "s3:ObjectAccessed:*"},
 		{ObjectAccessedGet, "s3:ObjectAccessed:Get"},
 		{ObjectAccessedHead, "s3:ObjectAccessed:Head"},
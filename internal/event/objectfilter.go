@@ -0,0 +1,211 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+package event
+
+import (
+	"encoding/xml"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/minio/pkg/wildcard"
+)
+
+// Object filter rule names, metadata and tag rules are
+// followed by the name of the metadata or tag key.
+const (
+	filterMinSize     = "min-size"
+	filterMaxSize     = "max-size"
+	filterContentType = "content-type"
+	filterMetadata    = "metadata:"
+	filterTag         = "tag:"
+)
+
+// filterSeparator separates the object name pattern of a rule from
+// the object filter rules following it. Filter rule names and values
+// can not contain it.
+const filterSeparator = `\`
+
+// userMetadataPrefix is the prefix of the user metadata keys.
+const userMetadataPrefix = "x-amz-meta-"
+
+// ObjectFilterRule - represents elements inside <S3Object><FilterRule>...</FilterRule></S3Object>,
+// a MinIO extension filtering events by the properties of the object.
+type ObjectFilterRule struct {
+	Name  string `xml:"Name"`
+	Value string `xml:"Value"`
+}
+
+// UnmarshalXML - decodes XML data.
+func (filter *ObjectFilterRule) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	// Make subtype to avoid recursive UnmarshalXML().
+	type objectFilterRule ObjectFilterRule
+	rule := objectFilterRule{}
+	if err := d.DecodeElement(&rule, &start); err != nil {
+		return err
+	}
+
+	switch name := strings.ToLower(rule.Name); {
+	case name == filterMinSize, name == filterMaxSize:
+		if n, err := strconv.ParseInt(rule.Value, 10, 64); err != nil || n < 0 {
+			return &ErrInvalidFilterValue{rule.Value}
+		}
+	case name == filterContentType:
+	case strings.HasPrefix(name, filterMetadata) && len(name) > len(filterMetadata),
+		strings.HasPrefix(name, filterTag) && len(name) > len(filterTag):
+		if err := ValidateFilterRuleValue(rule.Name); err != nil || strings.Contains(rule.Name, "=") {
+			return &ErrInvalidFilterName{rule.Name}
+		}
+	default:
+		return &ErrInvalidFilterName{rule.Name}
+	}
+
+	if err := ValidateFilterRuleValue(rule.Value); err != nil {
+		return err
+	}
+
+	*filter = ObjectFilterRule(rule)
+	return nil
+}
+
+// ObjectFilterRuleList - represents multiple <FilterRule>...</FilterRule> inside <S3Object>
+type ObjectFilterRuleList struct {
+	Rules []ObjectFilterRule `xml:"FilterRule,omitempty"`
+}
+
+// UnmarshalXML - decodes XML data.
+func (ruleList *ObjectFilterRuleList) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	// Make subtype to avoid recursive UnmarshalXML().
+	type objectFilterRuleList ObjectFilterRuleList
+	rules := objectFilterRuleList{}
+	if err := d.DecodeElement(&rules, &start); err != nil {
+		return err
+	}
+
+	names := make(map[string]struct{}, len(rules.Rules))
+	for _, rule := range rules.Rules {
+		name := strings.ToLower(rule.Name)
+		if _, ok := names[name]; ok {
+			return &ErrDuplicateFilterName{rule.Name}
+		}
+		names[name] = struct{}{}
+	}
+
+	*ruleList = ObjectFilterRuleList(rules)
+	return nil
+}
+
+func (ruleList ObjectFilterRuleList) isEmpty() bool {
+	return len(ruleList.Rules) == 0
+}
+
+// Filter - returns the object filter rules in canonical form,
+// to be appended to the object name pattern of the rules.
+func (ruleList ObjectFilterRuleList) Filter() string {
+	if ruleList.isEmpty() {
+		return ""
+	}
+	rules := make([]string, 0, len(ruleList.Rules))
+	for _, rule := range ruleList.Rules {
+		rules = append(rules, strings.ToLower(rule.Name)+"="+rule.Value)
+	}
+	sort.Strings(rules)
+	return filterSeparator + strings.Join(rules, filterSeparator)
+}
+
+// ObjectProperties - the properties of an object matched by
+// the object filter rules.
+type ObjectProperties struct {
+	Name        string
+	Size        int64
+	ContentType string
+
+	// UserMetadata holds the user metadata, with
+	// or without the "X-Amz-Meta-" key prefix.
+	UserMetadata map[string]string
+
+	Tags map[string]string
+}
+
+// splitRule - returns the object name pattern and the object filter
+// of a rule.
+func splitRule(rule string) (pattern, filter string) {
+	if i := strings.Index(rule, filterSeparator); i >= 0 {
+		return rule[:i], rule[i+len(filterSeparator):]
+	}
+	return rule, ""
+}
+
+// matchObjectFilter - returns true if the object properties match
+// all rules of the object filter.
+func matchObjectFilter(filter string, object ObjectProperties) bool {
+	if filter == "" {
+		return true
+	}
+	for _, rule := range strings.Split(filter, filterSeparator) {
+		name, value := rule, ""
+		if i := strings.Index(rule, "="); i >= 0 {
+			name, value = rule[:i], rule[i+1:]
+		}
+		switch {
+		case name == filterMinSize:
+			if n, _ := strconv.ParseInt(value, 10, 64); object.Size < n {
+				return false
+			}
+		case name == filterMaxSize:
+			if n, _ := strconv.ParseInt(value, 10, 64); object.Size > n {
+				return false
+			}
+		case name == filterContentType:
+			if !wildcard.MatchSimple(strings.ToLower(value), strings.ToLower(object.ContentType)) {
+				return false
+			}
+		case strings.HasPrefix(name, filterMetadata):
+			v, ok := lookupUserMetadata(object.UserMetadata, name[len(filterMetadata):])
+			if !ok || !wildcard.MatchSimple(value, v) {
+				return false
+			}
+		case strings.HasPrefix(name, filterTag):
+			// Tag keys are case sensitive, the rule name is not.
+			var found bool
+			for k, v := range object.Tags {
+				if strings.ToLower(k) == name[len(filterTag):] && wildcard.MatchSimple(value, v) {
+					found = true
+					break
+				}
+			}
+			if !found {
+				return false
+			}
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+// lookupUserMetadata - returns the value of the user metadata key,
+// which is matched case insensitively with or without its prefix.
+func lookupUserMetadata(metadata map[string]string, key string) (string, bool) {
+	key = strings.TrimPrefix(strings.ToLower(key), userMetadataPrefix)
+	for k, v := range metadata {
+		if strings.TrimPrefix(strings.ToLower(k), userMetadataPrefix) == key {
+			return v, true
+		}
+	}
+	return "", false
+}
@@ -0,0 +1,116 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+package event
+
+import (
+	"encoding/xml"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestObjectFilterRuleListUnmarshalXML(t *testing.T) {
+	testCases := []struct {
+		data      string
+		expectErr bool
+	}{
+		{`<S3Object><FilterRule><Name>min-size</Name><Value>1024</Value></FilterRule></S3Object>`, false},
+		{`<S3Object><FilterRule><Name>max-size</Name><Value>-1</Value></FilterRule></S3Object>`, true},
+		{`<S3Object><FilterRule><Name>max-size</Name><Value>1MiB</Value></FilterRule></S3Object>`, true},
+		{`<S3Object><FilterRule><Name>content-type</Name><Value>image/*</Value></FilterRule></S3Object>`, false},
+		{`<S3Object><FilterRule><Name>metadata:color</Name><Value>red</Value></FilterRule><FilterRule><Name>tag:team</Name><Value>a*</Value></FilterRule></S3Object>`, false},
+		{`<S3Object><FilterRule><Name>metadata:</Name><Value>red</Value></FilterRule></S3Object>`, true},
+		{`<S3Object><FilterRule><Name>metadata:a=b</Name><Value>red</Value></FilterRule></S3Object>`, true},
+		{`<S3Object><FilterRule><Name>prefix</Name><Value>images/</Value></FilterRule></S3Object>`, true},
+		{`<S3Object><FilterRule><Name>min-size</Name><Value>1</Value></FilterRule><FilterRule><Name>Min-Size</Name><Value>2</Value></FilterRule></S3Object>`, true},
+	}
+
+	for i, testCase := range testCases {
+		var ruleList ObjectFilterRuleList
+		err := xml.Unmarshal([]byte(testCase.data), &ruleList)
+		if expectErr := err != nil; expectErr != testCase.expectErr {
+			t.Fatalf("test %v: error: expected: %v, got: %v", i+1, testCase.expectErr, err)
+		}
+	}
+}
+
+func TestQueueObjectFilterMatch(t *testing.T) {
+	data := `<NotificationConfiguration xmlns="http://s3.amazonaws.com/doc/2006-03-01/">
+<QueueConfiguration>
+   <Id>1</Id>
+   <Filter>
+       <S3Key>
+           <FilterRule><Name>prefix</Name><Value>images/</Value></FilterRule>
+       </S3Key>
+       <S3Object>
+           <FilterRule><Name>min-size</Name><Value>1024</Value></FilterRule>
+           <FilterRule><Name>content-type</Name><Value>image/*</Value></FilterRule>
+           <FilterRule><Name>metadata:Camera</Name><Value>nikon*</Value></FilterRule>
+       </S3Object>
+   </Filter>
+   <Queue>arn:minio:sqs:us-east-1:1:webhook</Queue>
+   <Event>s3:ObjectCreated:Put</Event>
+</QueueConfiguration>
+<QueueConfiguration>
+   <Id>2</Id>
+   <Filter>
+       <S3Object>
+           <FilterRule><Name>tag:Team</Name><Value>video</Value></FilterRule>
+       </S3Object>
+   </Filter>
+   <Queue>arn:minio:sqs:us-east-1:2:amqp</Queue>
+   <Event>s3:ObjectCreated:Put</Event>
+</QueueConfiguration>
+</NotificationConfiguration>`
+
+	var config Config
+	if err := xml.NewDecoder(strings.NewReader(data)).Decode(&config); err != nil {
+		t.Fatal(err)
+	}
+	rulesMap := config.ToRulesMap()
+
+	webhook := TargetID{"1", "webhook"}
+	amqp := TargetID{"2", "amqp"}
+	testCases := []struct {
+		object         ObjectProperties
+		expectedResult TargetIDSet
+	}{
+		{ObjectProperties{Name: "images/a.jpg", Size: 2048, ContentType: "image/jpeg", UserMetadata: map[string]string{"X-Amz-Meta-Camera": "nikon-d850"}}, NewTargetIDSet(webhook)},
+		{ObjectProperties{Name: "images/a.jpg", Size: 512, ContentType: "image/jpeg", UserMetadata: map[string]string{"X-Amz-Meta-Camera": "nikon-d850"}}, NewTargetIDSet()},
+		{ObjectProperties{Name: "images/a.jpg", Size: 2048, ContentType: "text/plain", UserMetadata: map[string]string{"X-Amz-Meta-Camera": "nikon-d850"}}, NewTargetIDSet()},
+		{ObjectProperties{Name: "images/a.jpg", Size: 2048, ContentType: "image/jpeg"}, NewTargetIDSet()},
+		{ObjectProperties{Name: "docs/a.jpg", Size: 2048, ContentType: "image/jpeg", UserMetadata: map[string]string{"X-Amz-Meta-Camera": "nikon-d850"}}, NewTargetIDSet()},
+		{ObjectProperties{Name: "videos/a.mp4", Tags: map[string]string{"team": "video"}}, NewTargetIDSet(amqp)},
+		{ObjectProperties{Name: "images/a.jpg", Size: 2048, ContentType: "image/jpeg", UserMetadata: map[string]string{"x-amz-meta-camera": "nikon"}, Tags: map[string]string{"Team": "video"}}, NewTargetIDSet(webhook, amqp)},
+	}
+
+	for i, testCase := range testCases {
+		result := rulesMap.MatchObject(ObjectCreatedPut, testCase.object)
+		if !reflect.DeepEqual(testCase.expectedResult, result) {
+			t.Fatalf("test %v: result: expected: %v, got: %v", i+1, testCase.expectedResult, result)
+		}
+	}
+
+	// Object filters are kept when the configuration is written back.
+	out, err := xml.Marshal(config)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(out), "<S3Object><FilterRule><Name>tag:Team</Name><Value>video</Value></FilterRule></S3Object>") {
+		t.Fatalf("object filter missing from %s", out)
+	}
+}
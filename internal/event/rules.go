@@ -46,7 +46,8 @@ func NewPattern(prefix, suffix string) (pattern string) {
 	return pattern
 }
 
-// Rules - event rules
+// Rules - event rules keyed by the object name pattern, followed by
+// the object filter of the rule if it has one.
 type Rules map[string]TargetIDSet
 
 // Add - adds pattern and target ID.
@@ -54,10 +55,11 @@ func (rules Rules) Add(pattern string, targetID TargetID) {
 	rules[pattern] = NewTargetIDSet(targetID).Union(rules[pattern])
 }
 
-// MatchSimple - returns true one of the matching object name in rules.
+// MatchSimple - returns true one of the matching object name in rules,
+// object filters are ignored.
 func (rules Rules) MatchSimple(objectName string) bool {
-	for pattern := range rules {
-		if wildcard.MatchSimple(pattern, objectName) {
+	for rule := range rules {
+		if pattern, _ := splitRule(rule); wildcard.MatchSimple(pattern, objectName) {
 			return true
 		}
 	}
@@ -66,10 +68,17 @@ func (rules Rules) MatchSimple(objectName string) bool {
 
 // Match - returns TargetIDSet matching object name in rules.
 func (rules Rules) Match(objectName string) TargetIDSet {
+	return rules.MatchObject(ObjectProperties{Name: objectName})
+}
+
+// MatchObject - returns TargetIDSet matching object name and
+// object filter in rules.
+func (rules Rules) MatchObject(object ObjectProperties) TargetIDSet {
 	targetIDs := NewTargetIDSet()
 
-	for pattern, targetIDSet := range rules {
-		if wildcard.MatchSimple(pattern, objectName) {
+	for rule, targetIDSet := range rules {
+		pattern, filter := splitRule(rule)
+		if wildcard.MatchSimple(pattern, object.Name) && matchObjectFilter(filter, object) {
 			targetIDs = targetIDs.Union(targetIDSet)
 		}
 	}
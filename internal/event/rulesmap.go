@@ -71,6 +71,11 @@ func (rulesMap RulesMap) Match(eventName Name, objectName string) TargetIDSet {
 	return rulesMap[eventName].Match(objectName)
 }
 
+// MatchObject - returns TargetIDSet matching object and event name in rules map.
+func (rulesMap RulesMap) MatchObject(eventName Name, object ObjectProperties) TargetIDSet {
+	return rulesMap[eventName].MatchObject(object)
+}
+
 // NewRulesMap - creates new rules map with given values.
 func NewRulesMap(eventNames []Name, pattern string, targetID TargetID) RulesMap {
 	// If pattern is empty, add '*' wildcard to match all.
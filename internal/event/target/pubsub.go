@@ -0,0 +1,269 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+package target
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"time"
+
+	"google.golang.org/api/googleapi"
+	"google.golang.org/api/option"
+	"google.golang.org/api/pubsub/v1"
+
+	"github.com/minio/minio/internal/event"
+	xnet "github.com/minio/pkg/net"
+)
+
+// Pub/Sub constants
+const (
+	PubSubProjectID       = "project_id"
+	PubSubTopic           = "topic"
+	PubSubCredentialsFile = "credentials_file"
+	PubSubOrderingKey     = "ordering_key"
+	PubSubQueueDir        = "queue_dir"
+	PubSubQueueLimit      = "queue_limit"
+
+	EnvPubSubEnable          = "MINIO_NOTIFY_PUBSUB_ENABLE"
+	EnvPubSubProjectID       = "MINIO_NOTIFY_PUBSUB_PROJECT_ID"
+	EnvPubSubTopic           = "MINIO_NOTIFY_PUBSUB_TOPIC"
+	EnvPubSubCredentialsFile = "MINIO_NOTIFY_PUBSUB_CREDENTIALS_FILE"
+	EnvPubSubOrderingKey     = "MINIO_NOTIFY_PUBSUB_ORDERING_KEY"
+	EnvPubSubQueueDir        = "MINIO_NOTIFY_PUBSUB_QUEUE_DIR"
+	EnvPubSubQueueLimit      = "MINIO_NOTIFY_PUBSUB_QUEUE_LIMIT"
+)
+
+// pubSubMaxOrderingKeyLen is the maximum length in bytes
+// of an ordering key accepted by Pub/Sub.
+const pubSubMaxOrderingKeyLen = 1024
+
+// PubSubArgs - Google Cloud Pub/Sub target arguments.
+type PubSubArgs struct {
+	Enable          bool   `json:"enable"`
+	ProjectID       string `json:"projectID"`
+	Topic           string `json:"topic"`
+	CredentialsFile string `json:"credentialsFile"`
+	OrderingKey     bool   `json:"orderingKey"`
+	QueueDir        string `json:"queueDir"`
+	QueueLimit      uint64 `json:"queueLimit"`
+}
+
+// Validate PubSubArgs fields
+func (p PubSubArgs) Validate() error {
+	if !p.Enable {
+		return nil
+	}
+	if p.ProjectID == "" {
+		return errors.New("empty project ID")
+	}
+	if p.Topic == "" {
+		return errors.New("empty topic")
+	}
+	if p.CredentialsFile != "" && !filepath.IsAbs(p.CredentialsFile) {
+		return errors.New("credentialsFile path should be absolute")
+	}
+	if p.QueueDir != "" {
+		if !filepath.IsAbs(p.QueueDir) {
+			return errors.New("queueDir path should be absolute")
+		}
+	}
+	return nil
+}
+
+// topicName returns the full resource name of the topic.
+func (p PubSubArgs) topicName() string {
+	return "projects/" + p.ProjectID + "/topics/" + p.Topic
+}
+
+// PubSubTarget - Google Cloud Pub/Sub target.
+type PubSubTarget struct {
+	id         event.TargetID
+	args       PubSubArgs
+	service    *pubsub.Service
+	store      Store
+	loggerOnce func(ctx context.Context, err error, id interface{}, errKind ...interface{})
+}
+
+// ID - returns target ID.
+func (target *PubSubTarget) ID() event.TargetID {
+	return target.id
+}
+
+// HasQueueStore - Checks if the queueStore has been configured for the target
+func (target *PubSubTarget) HasQueueStore() bool {
+	return target.store != nil
+}
+
+// IsActive - Return true if target is up and active
+func (target *PubSubTarget) IsActive() (bool, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	_, err := target.service.Projects.Topics.Get(target.args.topicName()).Context(ctx).Do()
+	if err != nil {
+		// A publisher is not necessarily allowed to get the topic,
+		// a response from Pub/Sub means it is reachable though.
+		var gErr *googleapi.Error
+		if errors.As(err, &gErr) && gErr.Code == http.StatusForbidden {
+			return true, nil
+		}
+		if xnet.IsNetworkOrHostDown(err, false) {
+			return false, errNotConnected
+		}
+		return false, err
+	}
+	return true, nil
+}
+
+// Save - saves the events to the store if queuestore is configured,
+// which will be replayed when Pub/Sub is reachable.
+func (target *PubSubTarget) Save(eventData event.Event) error {
+	if target.store != nil {
+		return target.store.Put(eventData)
+	}
+	err := target.send(eventData)
+	if err != nil {
+		if xnet.IsNetworkOrHostDown(err, false) {
+			return errNotConnected
+		}
+	}
+	return err
+}
+
+// send - publishes an event to the Pub/Sub topic.
+func (target *PubSubTarget) send(eventData event.Event) error {
+	objectName, err := url.QueryUnescape(eventData.S3.Object.Key)
+	if err != nil {
+		return err
+	}
+	key := eventData.S3.Bucket.Name + "/" + objectName
+
+	data, err := json.Marshal(event.Log{EventName: eventData.EventName, Key: key, Records: []event.Event{eventData}})
+	if err != nil {
+		return err
+	}
+
+	msg := &pubsub.PubsubMessage{
+		Data: base64.StdEncoding.EncodeToString(data),
+		Attributes: map[string]string{
+			"eventName": eventData.EventName.String(),
+			"key":       key,
+		},
+	}
+	if target.args.OrderingKey {
+		// Events of the same object are delivered in order to
+		// subscriptions with message ordering enabled. Longer
+		// keys are truncated, which only orders more messages.
+		if len(key) > pubSubMaxOrderingKeyLen {
+			key = key[:pubSubMaxOrderingKeyLen]
+		}
+		msg.OrderingKey = key
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	_, err = target.service.Projects.Topics.Publish(target.args.topicName(), &pubsub.PublishRequest{
+		Messages: []*pubsub.PubsubMessage{msg},
+	}).Context(ctx).Do()
+	return err
+}
+
+// Send - reads an event from store and publishes it to Pub/Sub.
+func (target *PubSubTarget) Send(eventKey string) error {
+	eventData, eErr := target.store.Get(eventKey)
+	if eErr != nil {
+		// The last event key in a successful batch will be sent in the channel atmost once by the replayEvents()
+		// Such events will not exist and would've been already been sent successfully.
+		if os.IsNotExist(eErr) {
+			return nil
+		}
+		return eErr
+	}
+
+	if err := target.send(eventData); err != nil {
+		if xnet.IsNetworkOrHostDown(err, false) {
+			return errNotConnected
+		}
+		return err
+	}
+
+	// Delete the event from store.
+	return target.store.Del(eventKey)
+}
+
+// Close - does nothing and available for interface compatibility.
+func (target *PubSubTarget) Close() error {
+	return nil
+}
+
+// NewPubSubTarget - creates new Google Cloud Pub/Sub target.
+//
+// The target authenticates with the service account key in the
+// credentials file if set, otherwise with the application default
+// credentials, e.g. those of the GKE workload identity.
+func NewPubSubTarget(ctx context.Context, id string, args PubSubArgs, loggerOnce func(ctx context.Context, err error, id interface{}, kind ...interface{}), test bool) (*PubSubTarget, error) {
+	var store Store
+	target := &PubSubTarget{
+		id:         event.TargetID{ID: id, Name: "pubsub"},
+		args:       args,
+		loggerOnce: loggerOnce,
+	}
+
+	opts := []option.ClientOption{option.WithScopes(pubsub.PubsubScope)}
+	if args.CredentialsFile != "" {
+		opts = append(opts, option.WithCredentialsFile(args.CredentialsFile))
+	}
+	service, err := pubsub.NewService(ctx, opts...)
+	if err != nil {
+		target.loggerOnce(ctx, err, target.ID())
+		return target, err
+	}
+	target.service = service
+
+	if args.QueueDir != "" {
+		queueDir := filepath.Join(args.QueueDir, storePrefix+"-pubsub-"+id)
+		store = NewQueueStore(queueDir, args.QueueLimit)
+		if err := store.Open(); err != nil {
+			target.loggerOnce(context.Background(), err, target.ID())
+			return target, err
+		}
+		target.store = store
+	}
+
+	_, err = target.IsActive()
+	if err != nil {
+		if target.store == nil || err != errNotConnected {
+			target.loggerOnce(ctx, err, target.ID())
+			return target, err
+		}
+	}
+
+	if target.store != nil && !test {
+		// Replays the events from the store.
+		eventKeyCh := replayEvents(target.store, ctx.Done(), target.loggerOnce, target.ID())
+		// Start replaying events from the store.
+		go sendEvents(target, eventKeyCh, ctx.Done(), target.loggerOnce)
+	}
+
+	return target, nil
+}
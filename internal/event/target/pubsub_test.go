@@ -0,0 +1,65 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+package target
+
+import "testing"
+
+func TestPubSubArgs_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		args    PubSubArgs
+		wantErr bool
+	}{
+		{
+			name:    "disabled",
+			args:    PubSubArgs{Enable: false},
+			wantErr: false,
+		},
+		{
+			name:    "missing_project",
+			args:    PubSubArgs{Enable: true, Topic: "topic"},
+			wantErr: true,
+		},
+		{
+			name:    "missing_topic",
+			args:    PubSubArgs{Enable: true, ProjectID: "project"},
+			wantErr: true,
+		},
+		{
+			name:    "relative_credentials_file",
+			args:    PubSubArgs{Enable: true, ProjectID: "project", Topic: "topic", CredentialsFile: "key.json"},
+			wantErr: true,
+		},
+		{
+			name:    "relative_queue_dir",
+			args:    PubSubArgs{Enable: true, ProjectID: "project", Topic: "topic", QueueDir: "queue"},
+			wantErr: true,
+		},
+		{
+			name:    "OK",
+			args:    PubSubArgs{Enable: true, ProjectID: "project", Topic: "topic", CredentialsFile: "/etc/minio/key.json", OrderingKey: true},
+			wantErr: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.args.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("PubSubArgs.Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
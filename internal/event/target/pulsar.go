@@ -0,0 +1,450 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+package target
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+
+	"github.com/minio/minio/internal/event"
+	xnet "github.com/minio/pkg/net"
+)
+
+// Pulsar constants
+const (
+	PulsarBroker                  = "broker"
+	PulsarTopic                   = "topic"
+	PulsarAuthToken               = "auth_token"
+	PulsarTLSSkipVerify           = "tls_skip_verify"
+	PulsarClientTLSCert           = "client_tls_cert"
+	PulsarClientTLSKey            = "client_tls_key"
+	PulsarBatching                = "batching"
+	PulsarBatchingMaxMessages     = "batching_max_messages"
+	PulsarBatchingMaxPublishDelay = "batching_max_publish_delay"
+	PulsarQueueDir                = "queue_dir"
+	PulsarQueueLimit              = "queue_limit"
+
+	EnvPulsarEnable                  = "MINIO_NOTIFY_PULSAR_ENABLE"
+	EnvPulsarBroker                  = "MINIO_NOTIFY_PULSAR_BROKER"
+	EnvPulsarTopic                   = "MINIO_NOTIFY_PULSAR_TOPIC"
+	EnvPulsarAuthToken               = "MINIO_NOTIFY_PULSAR_AUTH_TOKEN"
+	EnvPulsarTLSSkipVerify           = "MINIO_NOTIFY_PULSAR_TLS_SKIP_VERIFY"
+	EnvPulsarClientTLSCert           = "MINIO_NOTIFY_PULSAR_CLIENT_TLS_CERT"
+	EnvPulsarClientTLSKey            = "MINIO_NOTIFY_PULSAR_CLIENT_TLS_KEY"
+	EnvPulsarBatching                = "MINIO_NOTIFY_PULSAR_BATCHING"
+	EnvPulsarBatchingMaxMessages     = "MINIO_NOTIFY_PULSAR_BATCHING_MAX_MESSAGES"
+	EnvPulsarBatchingMaxPublishDelay = "MINIO_NOTIFY_PULSAR_BATCHING_MAX_PUBLISH_DELAY"
+	EnvPulsarQueueDir                = "MINIO_NOTIFY_PULSAR_QUEUE_DIR"
+	EnvPulsarQueueLimit              = "MINIO_NOTIFY_PULSAR_QUEUE_LIMIT"
+)
+
+// pulsarSendTimeout is the maximum duration to wait for
+// the broker to acknowledge a published event.
+const pulsarSendTimeout = 30 * time.Second
+
+// PulsarArgs - Pulsar target arguments.
+type PulsarArgs struct {
+	Enable    bool     `json:"enable"`
+	Broker    xnet.URL `json:"broker"`
+	Topic     string   `json:"topic"`
+	AuthToken string   `json:"authToken"`
+	TLS       struct {
+		RootCAs       *x509.CertPool `json:"-"`
+		SkipVerify    bool           `json:"skipVerify"`
+		ClientTLSCert string         `json:"clientTLSCert"`
+		ClientTLSKey  string         `json:"clientTLSKey"`
+	} `json:"tls"`
+	Batching struct {
+		Enable          bool          `json:"enable"`
+		MaxMessages     int           `json:"maxMessages"`
+		MaxPublishDelay time.Duration `json:"maxPublishDelay"`
+	} `json:"batching"`
+	QueueDir   string `json:"queueDir"`
+	QueueLimit uint64 `json:"queueLimit"`
+}
+
+// Validate PulsarArgs fields
+func (p PulsarArgs) Validate() error {
+	if !p.Enable {
+		return nil
+	}
+	if p.Broker.IsEmpty() {
+		return errors.New("empty broker")
+	}
+	if _, err := pulsarTopicPath(p.Topic); err != nil {
+		return err
+	}
+	if p.TLS.ClientTLSCert != "" && p.TLS.ClientTLSKey == "" || p.TLS.ClientTLSCert == "" && p.TLS.ClientTLSKey != "" {
+		return errors.New("cert and key must be specified as a pair")
+	}
+	if p.Batching.Enable {
+		if p.Batching.MaxMessages <= 0 {
+			return errors.New("batching max messages should be positive")
+		}
+		if p.Batching.MaxPublishDelay < time.Millisecond {
+			return errors.New("batching max publish delay should be at least 1ms")
+		}
+	}
+	if p.QueueDir != "" {
+		if !filepath.IsAbs(p.QueueDir) {
+			return errors.New("queueDir path should be absolute")
+		}
+	}
+	return nil
+}
+
+// pulsarTopicPath returns the URL path of the topic name, either
+// fully qualified like "persistent://tenant/namespace/topic", or
+// "tenant/namespace/topic" or just "topic" as accepted by Pulsar
+// clients.
+func pulsarTopicPath(topic string) (string, error) {
+	domain, name := "persistent", topic
+	if i := strings.Index(topic, "://"); i >= 0 {
+		domain, name = topic[:i], topic[i+len("://"):]
+	}
+	if domain != "persistent" && domain != "non-persistent" {
+		return "", fmt.Errorf("invalid topic domain %q", domain)
+	}
+	parts := strings.Split(name, "/")
+	switch len(parts) {
+	case 1:
+		parts = []string{"public", "default", parts[0]}
+	case 3:
+	default:
+		return "", fmt.Errorf("invalid topic name %q", topic)
+	}
+	for i, part := range parts {
+		if part == "" {
+			return "", fmt.Errorf("invalid topic name %q", topic)
+		}
+		parts[i] = url.PathEscape(part)
+	}
+	return domain + "/" + strings.Join(parts, "/"), nil
+}
+
+// pulsarMessage is a message sent to the producer endpoint
+// of the Pulsar WebSocket API.
+type pulsarMessage struct {
+	Payload    string            `json:"payload"`
+	Properties map[string]string `json:"properties,omitempty"`
+	Context    string            `json:"context"`
+	Key        string            `json:"key,omitempty"`
+}
+
+// pulsarResponse acknowledges a pulsarMessage.
+type pulsarResponse struct {
+	Result   string `json:"result"`
+	ErrorMsg string `json:"errorMsg"`
+	Context  string `json:"context"`
+}
+
+// PulsarTarget - Pulsar target.
+//
+// Events are published through the WebSocket API of the Pulsar
+// brokers, acknowledgements are matched to the events by their
+// context such that concurrent events can be batched.
+type PulsarTarget struct {
+	id         event.TargetID
+	args       PulsarArgs
+	endpoint   string
+	header     http.Header
+	dialer     *websocket.Dialer
+	store      Store
+	loggerOnce func(ctx context.Context, err error, id interface{}, errKind ...interface{})
+
+	mu      sync.Mutex
+	conn    *websocket.Conn
+	seq     uint64
+	pending map[string]chan error
+}
+
+// ID - returns target ID.
+func (target *PulsarTarget) ID() event.TargetID {
+	return target.id
+}
+
+// HasQueueStore - Checks if the queueStore has been configured for the target
+func (target *PulsarTarget) HasQueueStore() bool {
+	return target.store != nil
+}
+
+// IsActive - Return true if target is up and active
+func (target *PulsarTarget) IsActive() (bool, error) {
+	target.mu.Lock()
+	defer target.mu.Unlock()
+
+	if err := target.connect(); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// connect opens the producer connection if it is not open,
+// target.mu must be held.
+func (target *PulsarTarget) connect() error {
+	if target.conn != nil {
+		return nil
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	conn, resp, err := target.dialer.DialContext(ctx, target.endpoint, target.header)
+	if err != nil {
+		if resp != nil {
+			return fmt.Errorf("connecting to the pulsar producer failed with %v", resp.Status)
+		}
+		if xnet.IsNetworkOrHostDown(err, false) {
+			return errNotConnected
+		}
+		return err
+	}
+	target.conn = conn
+	go target.readResponses(conn)
+	return nil
+}
+
+// readResponses delivers the acknowledgements received on conn
+// to the pending events until conn fails or is closed.
+func (target *PulsarTarget) readResponses(conn *websocket.Conn) {
+	for {
+		var resp pulsarResponse
+		if err := conn.ReadJSON(&resp); err != nil {
+			target.mu.Lock()
+			if target.conn == conn {
+				target.conn = nil
+			}
+			for key, ch := range target.pending {
+				ch <- errNotConnected
+				delete(target.pending, key)
+			}
+			target.mu.Unlock()
+			conn.Close()
+			return
+		}
+
+		var err error
+		if resp.Result != "ok" {
+			err = fmt.Errorf("sending event failed with %s: %s", resp.Result, resp.ErrorMsg)
+		}
+		target.mu.Lock()
+		if ch, ok := target.pending[resp.Context]; ok {
+			ch <- err
+			delete(target.pending, resp.Context)
+		}
+		target.mu.Unlock()
+	}
+}
+
+// Save - saves the events to the store if queuestore is configured,
+// which will be replayed when the pulsar connection is active.
+func (target *PulsarTarget) Save(eventData event.Event) error {
+	if target.store != nil {
+		return target.store.Put(eventData)
+	}
+	return target.send(eventData)
+}
+
+// send - publishes an event to the Pulsar topic.
+func (target *PulsarTarget) send(eventData event.Event) error {
+	objectName, err := url.QueryUnescape(eventData.S3.Object.Key)
+	if err != nil {
+		return err
+	}
+	key := eventData.S3.Bucket.Name + "/" + objectName
+
+	data, err := json.Marshal(event.Log{EventName: eventData.EventName, Key: key, Records: []event.Event{eventData}})
+	if err != nil {
+		return err
+	}
+
+	// Buffered such that the acknowledgement never blocks
+	// when send has given up waiting for it.
+	ackCh := make(chan error, 1)
+
+	target.mu.Lock()
+	if err = target.connect(); err != nil {
+		target.mu.Unlock()
+		return err
+	}
+	target.seq++
+	msgContext := strconv.FormatUint(target.seq, 10)
+	target.pending[msgContext] = ackCh
+	conn := target.conn
+	err = conn.WriteJSON(pulsarMessage{
+		Payload: base64.StdEncoding.EncodeToString(data),
+		Properties: map[string]string{
+			"eventName": eventData.EventName.String(),
+		},
+		Context: msgContext,
+		Key:     key,
+	})
+	if err != nil {
+		delete(target.pending, msgContext)
+		target.mu.Unlock()
+		// readResponses cleans up after the closed connection.
+		conn.Close()
+		if xnet.IsNetworkOrHostDown(err, false) {
+			return errNotConnected
+		}
+		return err
+	}
+	target.mu.Unlock()
+
+	timer := time.NewTimer(pulsarSendTimeout)
+	defer timer.Stop()
+	select {
+	case err = <-ackCh:
+		return err
+	case <-timer.C:
+		target.mu.Lock()
+		delete(target.pending, msgContext)
+		target.mu.Unlock()
+		return errNotConnected
+	}
+}
+
+// Send - reads an event from store and publishes it to Pulsar.
+func (target *PulsarTarget) Send(eventKey string) error {
+	eventData, eErr := target.store.Get(eventKey)
+	if eErr != nil {
+		// The last event key in a successful batch will be sent in the channel atmost once by the replayEvents()
+		// Such events will not exist and would've been already been sent successfully.
+		if os.IsNotExist(eErr) {
+			return nil
+		}
+		return eErr
+	}
+
+	if err := target.send(eventData); err != nil {
+		return err
+	}
+
+	// Delete the event from store.
+	return target.store.Del(eventKey)
+}
+
+// Close - closes the producer connection to the Pulsar broker.
+func (target *PulsarTarget) Close() error {
+	target.mu.Lock()
+	defer target.mu.Unlock()
+
+	if target.conn == nil {
+		return nil
+	}
+	err := target.conn.Close()
+	target.conn = nil
+	return err
+}
+
+// NewPulsarTarget - creates new Pulsar target.
+func NewPulsarTarget(ctx context.Context, id string, args PulsarArgs, loggerOnce func(ctx context.Context, err error, id interface{}, kind ...interface{}), test bool) (*PulsarTarget, error) {
+	var store Store
+	target := &PulsarTarget{
+		id:         event.TargetID{ID: id, Name: "pulsar"},
+		args:       args,
+		header:     make(http.Header),
+		loggerOnce: loggerOnce,
+		pending:    make(map[string]chan error),
+	}
+
+	// The broker is the HTTP service URL, the WebSocket
+	// API is served on the same port.
+	endpoint := url.URL(args.Broker)
+	switch endpoint.Scheme {
+	case "https":
+		endpoint.Scheme = "wss"
+	default:
+		endpoint.Scheme = "ws"
+	}
+	topicPath, err := pulsarTopicPath(args.Topic)
+	if err != nil {
+		return target, err
+	}
+	endpoint.Path = strings.TrimSuffix(endpoint.Path, "/") + "/ws/v2/producer/" + topicPath
+	if args.Batching.Enable {
+		query := url.Values{}
+		query.Set("batchingEnabled", "true")
+		query.Set("batchingMaxMessages", strconv.Itoa(args.Batching.MaxMessages))
+		query.Set("batchingMaxPublishDelay", strconv.FormatInt(args.Batching.MaxPublishDelay.Milliseconds(), 10))
+		endpoint.RawQuery = query.Encode()
+	}
+	target.endpoint = endpoint.String()
+
+	if args.AuthToken != "" {
+		target.header.Set("Authorization", "Bearer "+args.AuthToken)
+	}
+
+	tlsConfig := &tls.Config{
+		RootCAs:            args.TLS.RootCAs,
+		InsecureSkipVerify: args.TLS.SkipVerify,
+	}
+	if args.TLS.ClientTLSCert != "" {
+		cert, err := tls.LoadX509KeyPair(args.TLS.ClientTLSCert, args.TLS.ClientTLSKey)
+		if err != nil {
+			target.loggerOnce(ctx, err, target.ID())
+			return target, err
+		}
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+	target.dialer = &websocket.Dialer{
+		Proxy:            http.ProxyFromEnvironment,
+		HandshakeTimeout: 5 * time.Second,
+		TLSClientConfig:  tlsConfig,
+	}
+
+	if args.QueueDir != "" {
+		queueDir := filepath.Join(args.QueueDir, storePrefix+"-pulsar-"+id)
+		store = NewQueueStore(queueDir, args.QueueLimit)
+		if err := store.Open(); err != nil {
+			target.loggerOnce(context.Background(), err, target.ID())
+			return target, err
+		}
+		target.store = store
+	}
+
+	_, err = target.IsActive()
+	if err != nil {
+		if target.store == nil || err != errNotConnected {
+			target.loggerOnce(ctx, err, target.ID())
+			return target, err
+		}
+	}
+
+	if target.store != nil && !test {
+		// Replays the events from the store.
+		eventKeyCh := replayEvents(target.store, ctx.Done(), target.loggerOnce, target.ID())
+		// Start replaying events from the store.
+		go sendEvents(target, eventKeyCh, ctx.Done(), target.loggerOnce)
+	}
+
+	return target, nil
+}
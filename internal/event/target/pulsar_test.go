@@ -0,0 +1,140 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+package target
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+
+	"github.com/minio/minio/internal/event"
+	xnet "github.com/minio/pkg/net"
+)
+
+func TestPulsarTopicPath(t *testing.T) {
+	testCases := []struct {
+		topic   string
+		path    string
+		wantErr bool
+	}{
+		{topic: "events", path: "persistent/public/default/events"},
+		{topic: "tenant/ns/events", path: "persistent/tenant/ns/events"},
+		{topic: "persistent://tenant/ns/events", path: "persistent/tenant/ns/events"},
+		{topic: "non-persistent://tenant/ns/events", path: "non-persistent/tenant/ns/events"},
+		{topic: "", wantErr: true},
+		{topic: "ns/events", wantErr: true},
+		{topic: "tenant//events", wantErr: true},
+		{topic: "kafka://tenant/ns/events", wantErr: true},
+	}
+	for i, testCase := range testCases {
+		path, err := pulsarTopicPath(testCase.topic)
+		if (err != nil) != testCase.wantErr {
+			t.Fatalf("Test %d: expected error %v, got %v", i, testCase.wantErr, err)
+		}
+		if path != testCase.path {
+			t.Fatalf("Test %d: expected path %q, got %q", i, testCase.path, path)
+		}
+	}
+}
+
+func TestPulsarTarget(t *testing.T) {
+	received := make(chan pulsarMessage, 1)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/ws/v2/producer/persistent/tenant/ns/events" {
+			http.NotFound(w, r)
+			return
+		}
+		if r.Header.Get("Authorization") != "Bearer token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		if r.URL.Query().Get("batchingEnabled") != "true" || r.URL.Query().Get("batchingMaxPublishDelay") != "5" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		conn, err := (&websocket.Upgrader{}).Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		for {
+			var msg pulsarMessage
+			if err := conn.ReadJSON(&msg); err != nil {
+				return
+			}
+			received <- msg
+			if err := conn.WriteJSON(pulsarResponse{Result: "ok", Context: msg.Context}); err != nil {
+				return
+			}
+		}
+	}))
+	defer server.Close()
+
+	broker, err := xnet.ParseHTTPURL(server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	args := PulsarArgs{
+		Enable:    true,
+		Broker:    *broker,
+		Topic:     "persistent://tenant/ns/events",
+		AuthToken: "token",
+	}
+	args.Batching.Enable = true
+	args.Batching.MaxMessages = 10
+	args.Batching.MaxPublishDelay = 5 * time.Millisecond
+	if err = args.Validate(); err != nil {
+		t.Fatal(err)
+	}
+
+	logOnce := func(ctx context.Context, err error, id interface{}, kind ...interface{}) { t.Log(err) }
+	target, err := NewPulsarTarget(context.Background(), "1", args, logOnce, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer target.Close()
+
+	var eventData event.Event
+	eventData.EventName = event.ObjectCreatedPut
+	eventData.S3.Bucket.Name = "bucket"
+	eventData.S3.Object.Key = "dir%2Fobject"
+	if err = target.Save(eventData); err != nil {
+		t.Fatal(err)
+	}
+
+	msg := <-received
+	if msg.Key != "bucket/dir/object" {
+		t.Fatalf("expected key %q, got %q", "bucket/dir/object", msg.Key)
+	}
+	payload, err := base64.StdEncoding.DecodeString(msg.Payload)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var log event.Log
+	if err = json.Unmarshal(payload, &log); err != nil {
+		t.Fatal(err)
+	}
+	if log.EventName != event.ObjectCreatedPut || log.Key != "bucket/dir/object" {
+		t.Fatalf("unexpected event log %v", log)
+	}
+}
@@ -48,6 +48,7 @@ type Store interface {
 // replayEvents - Reads the events from the store and replays.
 func replayEvents(store Store, doneCh <-chan struct{}, loggerOnce func(ctx context.Context, err error, id interface{}, kind ...interface{}), id event.TargetID) <-chan string {
 	eventKeyCh := make(chan string)
+	event.RegisterQueueStore(id, store.List)
 
 	go func() {
 		retryTicker := time.NewTicker(retryInterval)
@@ -103,12 +104,20 @@ func sendEvents(target event.Target, eventKeyCh <-chan string, doneCh <-chan str
 	defer retryTicker.Stop()
 
 	send := func(eventKey string) bool {
+		failed := false
 		for {
 			err := target.Send(eventKey)
 			if err == nil {
+				event.RecordDelivery(target.ID(), nil)
 				break
 			}
 
+			// Record each event failed only once while retrying.
+			if !failed {
+				event.RecordDelivery(target.ID(), err)
+				failed = true
+			}
+
 			if err != errNotConnected && !IsConnResetErr(err) {
 				loggerOnce(context.Background(),
 					fmt.Errorf("target.Send() failed with '%w'", err),
@@ -69,6 +69,8 @@ type TargetIDResult struct {
 	ID TargetID
 	// Stores any error while removing a target or while sending an event.
 	Err error
+	// Event sent by Send.
+	Event Event
 }
 
 // Remove - closes and removes targets by given target IDs.
@@ -85,6 +87,15 @@ func (list *TargetList) Remove(targetIDSet TargetIDSet) {
 	}
 }
 
+// Lookup - returns the target of the target ID, if it exists.
+func (list *TargetList) Lookup(id TargetID) (Target, bool) {
+	list.RLock()
+	defer list.RUnlock()
+
+	target, ok := list.targets[id]
+	return target, ok
+}
+
 // Targets - list all targets
 func (list *TargetList) Targets() []Target {
 	if list == nil {
@@ -134,8 +145,10 @@ func (list *TargetList) Send(event Event, targetIDset TargetIDSet, resCh chan<-
 				wg.Add(1)
 				go func(id TargetID, target Target) {
 					defer wg.Done()
-					tgtRes := TargetIDResult{ID: id}
-					if err := target.Save(event); err != nil {
+					tgtRes := TargetIDResult{ID: id, Event: event}
+					err := target.Save(event)
+					RecordSave(target, err)
+					if err != nil {
 						tgtRes.Err = err
 					}
 					resCh <- tgtRes
@@ -0,0 +1,111 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package event
+
+import (
+	"sync"
+	"time"
+)
+
+// TargetStats - delivery statistics of a target.
+type TargetStats struct {
+	// Number of events waiting in the queue store of the target.
+	QueueLength int
+	// Number of events delivered to the target.
+	EventsSent uint64
+	// Number of events the target failed to accept.
+	EventsFailed uint64
+	// Time of the last event delivered to the target.
+	LastSuccess time.Time
+}
+
+type targetStats struct {
+	TargetStats
+	queueList func() ([]string, error)
+}
+
+var (
+	targetStatsMu  sync.Mutex
+	targetStatsMap = make(map[TargetID]*targetStats)
+)
+
+func getTargetStats(id TargetID) *targetStats {
+	stats, ok := targetStatsMap[id]
+	if !ok {
+		stats = &targetStats{}
+		targetStatsMap[id] = stats
+	}
+	return stats
+}
+
+// RecordDelivery - records the delivery of an event to the target
+// identified by the target ID, err is the error the target failed
+// to accept the event with, if any.
+func RecordDelivery(id TargetID, err error) {
+	targetStatsMu.Lock()
+	defer targetStatsMu.Unlock()
+
+	stats := getTargetStats(id)
+	if err != nil {
+		stats.EventsFailed++
+		return
+	}
+	stats.EventsSent++
+	stats.LastSuccess = time.Now().UTC()
+}
+
+// RecordSave - records the result of saving an event to the target,
+// the events saved to the queue store of a target are recorded once
+// delivered from the queue store.
+func RecordSave(target Target, err error) {
+	if err != nil || !target.HasQueueStore() {
+		RecordDelivery(target.ID(), err)
+	}
+}
+
+// RegisterQueueStore - registers the function listing the events
+// waiting in the queue store of the target identified by the target ID.
+func RegisterQueueStore(id TargetID, list func() ([]string, error)) {
+	targetStatsMu.Lock()
+	defer targetStatsMu.Unlock()
+
+	getTargetStats(id).queueList = list
+}
+
+// Stats - returns the delivery statistics of the targets in the list.
+func (list *TargetList) Stats() map[TargetID]TargetStats {
+	stats := make(map[TargetID]TargetStats)
+	for _, target := range list.Targets() {
+		targetStatsMu.Lock()
+		s, ok := targetStatsMap[target.ID()]
+		var ts TargetStats
+		var queueList func() ([]string, error)
+		if ok {
+			ts, queueList = s.TargetStats, s.queueList
+		}
+		targetStatsMu.Unlock()
+
+		if queueList != nil && target.HasQueueStore() {
+			if names, err := queueList(); err == nil {
+				ts.QueueLength = len(names)
+			}
+		}
+		stats[target.ID()] = ts
+	}
+	return stats
+}
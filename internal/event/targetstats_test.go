@@ -0,0 +1,59 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package event
+
+import (
+	"testing"
+)
+
+func TestTargetListStats(t *testing.T) {
+	okID := TargetID{"stats-ok", "testcase"}
+	errID := TargetID{"stats-err", "testcase"}
+
+	targetList := NewTargetList()
+	if err := targetList.Add(&ExampleTarget{okID, false, false}, &ExampleTarget{errID, true, false}); err != nil {
+		t.Fatal(err)
+	}
+
+	resCh := make(chan TargetIDResult)
+	for i := 0; i < 3; i++ {
+		targetList.Send(Event{}, NewTargetIDSet(okID, errID), resCh)
+		for j := 0; j < 2; j++ {
+			<-resCh
+		}
+	}
+
+	stats := targetList.Stats()
+	if len(stats) != 2 {
+		t.Fatalf("expected stats of 2 targets, got %d", len(stats))
+	}
+	if st := stats[okID]; st.EventsSent != 3 || st.EventsFailed != 0 || st.LastSuccess.IsZero() {
+		t.Errorf("unexpected stats of %v: %+v", okID, st)
+	}
+	if st := stats[errID]; st.EventsSent != 0 || st.EventsFailed != 3 || !st.LastSuccess.IsZero() {
+		t.Errorf("unexpected stats of %v: %+v", errID, st)
+	}
+
+	// The queue length is only reported for targets with a queue store.
+	RegisterQueueStore(okID, func() ([]string, error) {
+		return []string{"a", "b"}, nil
+	})
+	if st := targetList.Stats()[okID]; st.QueueLength != 0 {
+		t.Errorf("expected no queue length, got %d", st.QueueLength)
+	}
+}
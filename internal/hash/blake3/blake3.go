@@ -0,0 +1,282 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+// Package blake3 implements the BLAKE3 hash function as specified
+// in https://github.com/BLAKE3-team/BLAKE3-specs.
+package blake3
+
+import (
+	"encoding/binary"
+	"hash"
+	"math/bits"
+)
+
+const (
+	// Size is the size of a BLAKE3 checksum in bytes.
+	Size = 32
+
+	// BlockSize is the block size of BLAKE3 in bytes.
+	BlockSize = 64
+
+	chunkLen = 1024
+
+	flagChunkStart = 1 << 0
+	flagChunkEnd   = 1 << 1
+	flagParent     = 1 << 2
+	flagRoot       = 1 << 3
+)
+
+var iv = [8]uint32{
+	0x6A09E667, 0xBB67AE85, 0x3C6EF372, 0xA54FF53A,
+	0x510E527F, 0x9B05688C, 0x1F83D9AB, 0x5BE0CD19,
+}
+
+var msgPermutation = [16]int{2, 6, 3, 10, 7, 0, 4, 13, 1, 11, 12, 5, 9, 14, 15, 8}
+
+func g(state *[16]uint32, a, b, c, d int, mx, my uint32) {
+	state[a] += state[b] + mx
+	state[d] = bits.RotateLeft32(state[d]^state[a], -16)
+	state[c] += state[d]
+	state[b] = bits.RotateLeft32(state[b]^state[c], -12)
+	state[a] += state[b] + my
+	state[d] = bits.RotateLeft32(state[d]^state[a], -8)
+	state[c] += state[d]
+	state[b] = bits.RotateLeft32(state[b]^state[c], -7)
+}
+
+func round(state *[16]uint32, m *[16]uint32) {
+	// Mix the columns.
+	g(state, 0, 4, 8, 12, m[0], m[1])
+	g(state, 1, 5, 9, 13, m[2], m[3])
+	g(state, 2, 6, 10, 14, m[4], m[5])
+	g(state, 3, 7, 11, 15, m[6], m[7])
+	// Mix the diagonals.
+	g(state, 0, 5, 10, 15, m[8], m[9])
+	g(state, 1, 6, 11, 12, m[10], m[11])
+	g(state, 2, 7, 8, 13, m[12], m[13])
+	g(state, 3, 4, 9, 14, m[14], m[15])
+}
+
+func permute(m *[16]uint32) {
+	var permuted [16]uint32
+	for i := range permuted {
+		permuted[i] = m[msgPermutation[i]]
+	}
+	*m = permuted
+}
+
+func compress(cv *[8]uint32, blockWords *[16]uint32, counter uint64, blockLen, flags uint32) [16]uint32 {
+	state := [16]uint32{
+		cv[0], cv[1], cv[2], cv[3], cv[4], cv[5], cv[6], cv[7],
+		iv[0], iv[1], iv[2], iv[3],
+		uint32(counter), uint32(counter >> 32), blockLen, flags,
+	}
+	block := *blockWords
+	for i := 0; i < 7; i++ {
+		round(&state, &block)
+		if i < 6 {
+			permute(&block)
+		}
+	}
+	for i := 0; i < 8; i++ {
+		state[i] ^= state[i+8]
+		state[i+8] ^= cv[i]
+	}
+	return state
+}
+
+func first8Words(words [16]uint32) (cv [8]uint32) {
+	copy(cv[:], words[:8])
+	return cv
+}
+
+func wordsFromBytes(b *[BlockSize]byte) (words [16]uint32) {
+	for i := range words {
+		words[i] = binary.LittleEndian.Uint32(b[4*i:])
+	}
+	return words
+}
+
+// output is the state just prior to the last compression of a chunk
+// or a parent node, which is the root compression if it is the last.
+type output struct {
+	inputCV    [8]uint32
+	blockWords [16]uint32
+	counter    uint64
+	blockLen   uint32
+	flags      uint32
+}
+
+func (o *output) chainingValue() [8]uint32 {
+	return first8Words(compress(&o.inputCV, &o.blockWords, o.counter, o.blockLen, o.flags))
+}
+
+func (o *output) rootBytes(out []byte) {
+	var block [BlockSize]byte
+	for counter := uint64(0); len(out) > 0; counter++ {
+		words := compress(&o.inputCV, &o.blockWords, counter, o.blockLen, o.flags|flagRoot)
+		for i, w := range words {
+			binary.LittleEndian.PutUint32(block[4*i:], w)
+		}
+		out = out[copy(out, block[:]):]
+	}
+}
+
+type chunkState struct {
+	cv               [8]uint32
+	chunkCounter     uint64
+	block            [BlockSize]byte
+	blockLen         int
+	blocksCompressed int
+	flags            uint32
+}
+
+func newChunkState(key [8]uint32, chunkCounter uint64, flags uint32) chunkState {
+	return chunkState{cv: key, chunkCounter: chunkCounter, flags: flags}
+}
+
+func (c *chunkState) len() int {
+	return BlockSize*c.blocksCompressed + c.blockLen
+}
+
+func (c *chunkState) startFlag() uint32 {
+	if c.blocksCompressed == 0 {
+		return flagChunkStart
+	}
+	return 0
+}
+
+func (c *chunkState) update(p []byte) {
+	for len(p) > 0 {
+		// The last block of a chunk is compressed by output,
+		// so a full block is only compressed once more input comes.
+		if c.blockLen == BlockSize {
+			words := wordsFromBytes(&c.block)
+			c.cv = first8Words(compress(&c.cv, &words, c.chunkCounter, BlockSize, c.flags|c.startFlag()))
+			c.blocksCompressed++
+			c.block = [BlockSize]byte{}
+			c.blockLen = 0
+		}
+		n := copy(c.block[c.blockLen:], p)
+		c.blockLen += n
+		p = p[n:]
+	}
+}
+
+func (c *chunkState) output() output {
+	return output{
+		inputCV:    c.cv,
+		blockWords: wordsFromBytes(&c.block),
+		counter:    c.chunkCounter,
+		blockLen:   uint32(c.blockLen),
+		flags:      c.flags | c.startFlag() | flagChunkEnd,
+	}
+}
+
+func parentOutput(left, right [8]uint32, key [8]uint32, flags uint32) output {
+	o := output{
+		inputCV:  key,
+		blockLen: BlockSize,
+		flags:    flags | flagParent,
+	}
+	copy(o.blockWords[:8], left[:])
+	copy(o.blockWords[8:], right[:])
+	return o
+}
+
+// digest computes a BLAKE3 checksum, it implements hash.Hash.
+type digest struct {
+	chunk   chunkState
+	key     [8]uint32
+	cvStack [54][8]uint32 // Space for 54 subtree chaining values, enough for 2^64 bytes.
+	cvLen   int
+	flags   uint32
+}
+
+// New returns a new hash.Hash computing the BLAKE3 checksum
+// with the default output size of 32 bytes.
+func New() hash.Hash {
+	d := &digest{key: iv}
+	d.Reset()
+	return d
+}
+
+// Sum256 returns the BLAKE3 checksum of the data.
+func Sum256(data []byte) (sum [Size]byte) {
+	d := &digest{key: iv}
+	d.Reset()
+	d.Write(data)
+	d.finalize(sum[:])
+	return sum
+}
+
+func (d *digest) Size() int { return Size }
+
+func (d *digest) BlockSize() int { return BlockSize }
+
+func (d *digest) Reset() {
+	d.chunk = newChunkState(d.key, 0, d.flags)
+	d.cvLen = 0
+}
+
+func (d *digest) addChunkChainingValue(cv [8]uint32, totalChunks uint64) {
+	// Merge the completed subtrees, whose number is given by the
+	// trailing zero bits of the total number of chunks.
+	for totalChunks&1 == 0 {
+		d.cvLen--
+		o := parentOutput(d.cvStack[d.cvLen], cv, d.key, d.flags)
+		cv = o.chainingValue()
+		totalChunks >>= 1
+	}
+	d.cvStack[d.cvLen] = cv
+	d.cvLen++
+}
+
+func (d *digest) Write(p []byte) (int, error) {
+	n := len(p)
+	for len(p) > 0 {
+		// A chunk is finalized only once more input comes,
+		// as the last chunk must be compressed as the root.
+		if d.chunk.len() == chunkLen {
+			o := d.chunk.output()
+			totalChunks := d.chunk.chunkCounter + 1
+			d.addChunkChainingValue(o.chainingValue(), totalChunks)
+			d.chunk = newChunkState(d.key, totalChunks, d.flags)
+		}
+		take := chunkLen - d.chunk.len()
+		if take > len(p) {
+			take = len(p)
+		}
+		d.chunk.update(p[:take])
+		p = p[take:]
+	}
+	return n, nil
+}
+
+func (d *digest) finalize(out []byte) {
+	o := d.chunk.output()
+	for i := d.cvLen - 1; i >= 0; i-- {
+		o = parentOutput(d.cvStack[i], o.chainingValue(), d.key, d.flags)
+	}
+	o.rootBytes(out)
+}
+
+func (d *digest) Sum(b []byte) []byte {
+	var sum [Size]byte
+	d.finalize(sum[:])
+	return append(b, sum[:]...)
+}
@@ -0,0 +1,80 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package blake3
+
+import (
+	"bytes"
+	"encoding/hex"
+	"testing"
+)
+
+// Test vectors from https://github.com/BLAKE3-team/BLAKE3/blob/master/test_vectors/test_vectors.json,
+// the input is a sequence of bytes repeating 0, 1, ..., 250.
+var testVectors = []struct {
+	inputLen int
+	hash     string
+}{
+	{0, "af1349b9f5f9a1a6a0404dea36dcc9499bcb25c9adc112b7cc9a93cae41f3262"},
+	{1, "2d3adedff11b61f14c886e35afa036736dcd87a74d27b5c1510225d0f592e213"},
+	{1024, "42214739f095a406f3fc83deb889744ac00df831c10daa55189b5d121c855af7"},
+	{1025, "d00278ae47eb27b34faecf67b4fe263f82d5412916c1ffd97c8cb7fb814b8444"},
+	{2048, "e776b6028c7cd22a4d0ba182a8bf62205d2ef576467e838ed6f2529b85fba24a"},
+	{3072, "b98cb0ff3623be03326b373de6b9095218513e64f1ee2edd2525c7ad1e5cffd2"},
+}
+
+func testInput(n int) []byte {
+	in := make([]byte, n)
+	for i := range in {
+		in[i] = byte(i % 251)
+	}
+	return in
+}
+
+func TestSum256(t *testing.T) {
+	for _, v := range testVectors {
+		sum := Sum256(testInput(v.inputLen))
+		if got := hex.EncodeToString(sum[:]); got != v.hash {
+			t.Errorf("input length %d: expected %s, got %s", v.inputLen, v.hash, got)
+		}
+	}
+}
+
+func TestDigestWrite(t *testing.T) {
+	in := testInput(64 << 10)
+	want := Sum256(in)
+
+	h := New()
+	for _, n := range []int{1, 63, 64, 65, 1023, 1024, 1025, 4096} {
+		h.Reset()
+		for p := in; len(p) > 0; {
+			m := n
+			if m > len(p) {
+				m = len(p)
+			}
+			h.Write(p[:m])
+			p = p[m:]
+		}
+		if got := h.Sum(nil); !bytes.Equal(got, want[:]) {
+			t.Errorf("writes of %d bytes: expected %x, got %x", n, want, got)
+		}
+		// Sum must not change the state of the digest.
+		if got := h.Sum(nil); !bytes.Equal(got, want[:]) {
+			t.Errorf("writes of %d bytes: expected %x after Sum, got %x", n, want, got)
+		}
+	}
+}
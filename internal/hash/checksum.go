@@ -0,0 +1,267 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package hash
+
+import (
+	"crypto/sha1"
+	"encoding/base64"
+	"errors"
+	"hash"
+	"hash/crc32"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/minio/minio/internal/hash/sha256"
+	xhttp "github.com/minio/minio/internal/http"
+)
+
+// ChecksumType is the algorithm of an additional checksum
+// of the content, as defined by the S3 API.
+type ChecksumType int
+
+// List of all supported checksum algorithms.
+const (
+	ChecksumNone ChecksumType = iota
+	ChecksumCRC32
+	ChecksumCRC32C
+	ChecksumSHA1
+	ChecksumSHA256
+)
+
+// ErrInvalidChecksum is returned when a checksum header or
+// algorithm is malformed, or more than one checksum is sent.
+var ErrInvalidChecksum = errors.New("invalid checksum")
+
+var checksumTypes = []ChecksumType{ChecksumCRC32, ChecksumCRC32C, ChecksumSHA1, ChecksumSHA256}
+
+var crc32cTable = crc32.MakeTable(crc32.Castagnoli)
+
+// NewChecksumType returns the checksum type of an algorithm
+// name, like "CRC32C". It returns ChecksumNone for unknown names.
+func NewChecksumType(alg string) ChecksumType {
+	for _, t := range checksumTypes {
+		if strings.EqualFold(alg, t.String()) {
+			return t
+		}
+	}
+	return ChecksumNone
+}
+
+// String returns the algorithm name of t.
+func (t ChecksumType) String() string {
+	switch t {
+	case ChecksumCRC32:
+		return "CRC32"
+	case ChecksumCRC32C:
+		return "CRC32C"
+	case ChecksumSHA1:
+		return "SHA1"
+	case ChecksumSHA256:
+		return "SHA256"
+	}
+	return ""
+}
+
+// Key returns the header carrying checksums of type t.
+func (t ChecksumType) Key() string {
+	switch t {
+	case ChecksumCRC32:
+		return xhttp.AmzChecksumCRC32
+	case ChecksumCRC32C:
+		return xhttp.AmzChecksumCRC32C
+	case ChecksumSHA1:
+		return xhttp.AmzChecksumSHA1
+	case ChecksumSHA256:
+		return xhttp.AmzChecksumSHA256
+	}
+	return ""
+}
+
+// IsSet returns true if t is a supported algorithm.
+func (t ChecksumType) IsSet() bool {
+	return t != ChecksumNone
+}
+
+// Hasher returns a hash computing checksums of type t.
+func (t ChecksumType) Hasher() hash.Hash {
+	switch t {
+	case ChecksumCRC32:
+		return crc32.NewIEEE()
+	case ChecksumCRC32C:
+		return crc32.New(crc32cTable)
+	case ChecksumSHA1:
+		return sha1.New()
+	case ChecksumSHA256:
+		return sha256.New()
+	}
+	return nil
+}
+
+// RawByteLen returns the size of checksums of type t.
+func (t ChecksumType) RawByteLen() int {
+	switch t {
+	case ChecksumCRC32, ChecksumCRC32C:
+		return crc32.Size
+	case ChecksumSHA1:
+		return sha1.Size
+	case ChecksumSHA256:
+		return 32
+	}
+	return 0
+}
+
+// Checksum is an additional, base64 encoded checksum of some content.
+//
+// An empty Encoded value requests the checksum to be computed,
+// or to be read from the trailing headers if Trailing is set.
+type Checksum struct {
+	Type     ChecksumType
+	Encoded  string
+	Trailing bool
+}
+
+// NewChecksumString returns the checksum of type t with the
+// base64 encoded value, or nil if the value is malformed.
+func NewChecksumString(t ChecksumType, value string) *Checksum {
+	c := &Checksum{Type: t, Encoded: value}
+	if !c.Valid() {
+		return nil
+	}
+	return c
+}
+
+// ParseChecksum parses a checksum in the format returned by
+// Checksum.String, it returns nil for malformed values.
+//
+// The values of composite checksums of multipart objects end
+// with "-<number of parts>", this suffix is accepted too.
+func ParseChecksum(s string) *Checksum {
+	tokens := strings.SplitN(s, ":", 2)
+	if len(tokens) != 2 {
+		return nil
+	}
+	t := NewChecksumType(tokens[0])
+	value := tokens[1]
+	if i := strings.LastIndexByte(value, '-'); i > 0 {
+		if n, err := strconv.Atoi(value[i+1:]); err == nil && n > 0 {
+			if NewChecksumString(t, value[:i]) == nil {
+				return nil
+			}
+			return &Checksum{Type: t, Encoded: value}
+		}
+	}
+	return NewChecksumString(t, value)
+}
+
+// ChecksumFromHeaders returns the checksum requested by the
+// headers h, or nil if no additional checksum is requested.
+//
+// The checksum value is either sent as x-amz-checksum-<algorithm>
+// header or, if announced by the x-amz-trailer header, as trailing
+// header. A checksum algorithm without value requests the checksum
+// to be computed. It returns ErrInvalidChecksum if the headers are
+// malformed or more than one checksum is sent.
+func ChecksumFromHeaders(h http.Header) (*Checksum, error) {
+	var c *Checksum
+	for _, t := range checksumTypes {
+		v, ok := h[t.Key()]
+		if !ok {
+			continue
+		}
+		if c != nil || len(v) != 1 {
+			return nil, ErrInvalidChecksum
+		}
+		if c = NewChecksumString(t, v[0]); c == nil {
+			return nil, ErrInvalidChecksum
+		}
+	}
+
+	if trailer := h.Get(xhttp.AmzTrailer); trailer != "" {
+		t := ChecksumNone
+		for _, tt := range checksumTypes {
+			if strings.EqualFold(trailer, tt.Key()) {
+				t = tt
+			}
+		}
+		if c != nil || t == ChecksumNone {
+			return nil, ErrInvalidChecksum
+		}
+		return &Checksum{Type: t, Trailing: true}, nil
+	}
+	if c != nil {
+		return c, nil
+	}
+
+	alg := h.Get(xhttp.AmzSDKChecksumAlgorithm)
+	if alg == "" {
+		alg = h.Get(xhttp.AmzChecksumAlgorithm)
+	}
+	if alg == "" {
+		return nil, nil
+	}
+	t := NewChecksumType(alg)
+	if t == ChecksumNone {
+		return nil, ErrInvalidChecksum
+	}
+	return &Checksum{Type: t}, nil
+}
+
+// Valid returns true if c has a supported type and a
+// well-formed value.
+func (c *Checksum) Valid() bool {
+	if c == nil || !c.Type.IsSet() {
+		return false
+	}
+	raw, err := base64.StdEncoding.DecodeString(c.Encoded)
+	return err == nil && len(raw) == c.Type.RawByteLen()
+}
+
+// Raw returns the decoded value of c, or nil if c is not valid.
+func (c *Checksum) Raw() []byte {
+	if !c.Valid() {
+		return nil
+	}
+	raw, _ := base64.StdEncoding.DecodeString(c.Encoded)
+	return raw
+}
+
+// String returns c as "<algorithm>:<value>", the format
+// parsed by ParseChecksum.
+func (c *Checksum) String() string {
+	return c.Type.String() + ":" + c.Encoded
+}
+
+// CompositeChecksum returns the checksum of a multipart object
+// from the checksums of its parts, which must all be of type t.
+// As for S3, it is the checksum of the concatenated raw checksums
+// of the parts, suffixed by "-<number of parts>".
+func CompositeChecksum(t ChecksumType, parts []*Checksum) (*Checksum, error) {
+	h := t.Hasher()
+	if h == nil {
+		return nil, ErrInvalidChecksum
+	}
+	for _, part := range parts {
+		if part == nil || part.Type != t || !part.Valid() {
+			return nil, ErrInvalidChecksum
+		}
+		h.Write(part.Raw())
+	}
+	encoded := base64.StdEncoding.EncodeToString(h.Sum(nil))
+	return &Checksum{Type: t, Encoded: encoded + "-" + strconv.Itoa(len(parts))}, nil
+}
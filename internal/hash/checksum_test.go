@@ -0,0 +1,110 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package hash
+
+import (
+	"bytes"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"testing"
+)
+
+// CRC32C of "abcd".
+const abcdCRC32C = "ksgKMQ=="
+
+func TestChecksumFromHeaders(t *testing.T) {
+	testCases := []struct {
+		header   http.Header
+		expected *Checksum
+		err      bool
+	}{
+		{http.Header{}, nil, false},
+		{http.Header{"X-Amz-Checksum-Crc32c": {abcdCRC32C}}, &Checksum{Type: ChecksumCRC32C, Encoded: abcdCRC32C}, false},
+		{http.Header{"X-Amz-Checksum-Crc32c": {"invalid"}}, nil, true},
+		{http.Header{"X-Amz-Checksum-Sha256": {abcdCRC32C}}, nil, true},
+		{http.Header{"X-Amz-Checksum-Crc32c": {abcdCRC32C}, "X-Amz-Checksum-Crc32": {abcdCRC32C}}, nil, true},
+		{http.Header{"X-Amz-Trailer": {"x-amz-checksum-sha256"}}, &Checksum{Type: ChecksumSHA256, Trailing: true}, false},
+		{http.Header{"X-Amz-Trailer": {"x-amz-meta-a"}}, nil, true},
+		{http.Header{"X-Amz-Sdk-Checksum-Algorithm": {"SHA1"}}, &Checksum{Type: ChecksumSHA1}, false},
+		{http.Header{"X-Amz-Checksum-Algorithm": {"MD5"}}, nil, true},
+	}
+	for i, testCase := range testCases {
+		c, err := ChecksumFromHeaders(testCase.header)
+		if (err != nil) != testCase.err {
+			t.Errorf("case %d: unexpected error %v", i+1, err)
+			continue
+		}
+		if (c == nil) != (testCase.expected == nil) || (c != nil && *c != *testCase.expected) {
+			t.Errorf("case %d: expected %+v, got %+v", i+1, testCase.expected, c)
+		}
+	}
+}
+
+func TestParseChecksum(t *testing.T) {
+	c := &Checksum{Type: ChecksumCRC32C, Encoded: abcdCRC32C}
+	if parsed := ParseChecksum(c.String()); parsed == nil || *parsed != *c {
+		t.Errorf("expected %+v, got %+v", c, parsed)
+	}
+	composite, err := CompositeChecksum(ChecksumCRC32C, []*Checksum{c, c})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if parsed := ParseChecksum(composite.String()); parsed == nil || *parsed != *composite {
+		t.Errorf("expected %+v, got %+v", composite, parsed)
+	}
+	if _, err = CompositeChecksum(ChecksumSHA256, []*Checksum{c}); err == nil {
+		t.Error("expected parts of another type to be rejected")
+	}
+	for _, s := range []string{"", "CRC32C", "MD5:" + abcdCRC32C, "CRC32C:invalid", "CRC32C:invalid-2"} {
+		if ParseChecksum(s) != nil {
+			t.Errorf("expected %q to be rejected", s)
+		}
+	}
+}
+
+func TestHashReaderChecksum(t *testing.T) {
+	testCases := []struct {
+		checksum Checksum
+		trailer  http.Header
+		err      error
+	}{
+		{Checksum{Type: ChecksumCRC32C, Encoded: abcdCRC32C}, nil, nil},
+		{Checksum{Type: ChecksumCRC32C, Encoded: "AAAAAA=="}, nil, ChecksumMismatch{Want: "AAAAAA==", Got: abcdCRC32C}},
+		{Checksum{Type: ChecksumCRC32C}, nil, nil},
+		{Checksum{Type: ChecksumCRC32C, Trailing: true}, http.Header{"X-Amz-Checksum-Crc32c": {abcdCRC32C}}, nil},
+		{Checksum{Type: ChecksumCRC32C, Trailing: true}, http.Header{}, ErrInvalidChecksum},
+	}
+	for i, testCase := range testCases {
+		r, err := NewReader(bytes.NewReader([]byte("abcd")), 4, "", "", 4)
+		if err != nil {
+			t.Fatal(err)
+		}
+		c := testCase.checksum
+		if err = r.AddChecksum(&c, testCase.trailer); err != nil {
+			t.Fatal(err)
+		}
+		if _, err = io.Copy(ioutil.Discard, r); err != testCase.err {
+			t.Errorf("case %d: expected error %v, got %v", i+1, testCase.err, err)
+			continue
+		}
+		if err == nil && (c.Encoded != abcdCRC32C || c.Trailing) {
+			t.Errorf("case %d: unexpected checksum %+v", i+1, c)
+		}
+	}
+}
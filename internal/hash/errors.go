@@ -48,3 +48,14 @@ type ErrSizeMismatch struct {
 func (e ErrSizeMismatch) Error() string {
 	return fmt.Sprintf("Size mismatch: got %d, want %d", e.Got, e.Want)
 }
+
+// ChecksumMismatch - when an additional checksum of the content,
+// like x-amz-checksum-crc32c, does not match with what was sent.
+type ChecksumMismatch struct {
+	Want string
+	Got  string
+}
+
+func (e ChecksumMismatch) Error() string {
+	return "Bad checksum: Expected " + e.Want + " does not match calculated " + e.Got
+}
@@ -24,6 +24,7 @@ import (
 	"errors"
 	"hash"
 	"io"
+	"net/http"
 
 	"github.com/minio/minio/internal/etag"
 	"github.com/minio/minio/internal/hash/sha256"
@@ -47,6 +48,10 @@ type Reader struct {
 	contentSHA256 []byte
 
 	sha256 hash.Hash
+
+	contentHash   *Checksum
+	contentHasher hash.Hash
+	trailer       http.Header
 }
 
 // NewReader returns a new Reader that wraps src and computes
@@ -142,6 +147,10 @@ func (r *Reader) Read(p []byte) (int, error) {
 		r.sha256.Write(p[:n])
 	}
 
+	if r.contentHasher != nil {
+		r.contentHasher.Write(p[:n])
+	}
+
 	if err == io.EOF { // Verify content SHA256, if set.
 		if r.sha256 != nil {
 			if sum := r.sha256.Sum(nil); !bytes.Equal(r.contentSHA256, sum) {
@@ -151,6 +160,11 @@ func (r *Reader) Read(p []byte) (int, error) {
 				}
 			}
 		}
+		if r.contentHasher != nil {
+			if err := r.verifyChecksum(); err != nil {
+				return n, err
+			}
+		}
 	}
 	if err != nil && err != io.EOF {
 		if v, ok := err.(etag.VerifyError); ok {
@@ -223,6 +237,51 @@ func (r *Reader) SHA256HexString() string {
 	return hex.EncodeToString(r.contentSHA256)
 }
 
+// AddChecksum makes the Reader compute the additional checksum c
+// of the content and verify it once all content is read. A checksum
+// sent as trailing header is looked up in trailer at that time.
+// If c has no value, it is set to the computed checksum.
+func (r *Reader) AddChecksum(c *Checksum, trailer http.Header) error {
+	if r.bytesRead > 0 {
+		return errors.New("hash: already read from hash reader")
+	}
+	if c == nil {
+		return nil
+	}
+	if r.contentHasher = c.Type.Hasher(); r.contentHasher == nil {
+		return ErrInvalidChecksum
+	}
+	r.contentHash = c
+	r.trailer = trailer
+	return nil
+}
+
+// ContentChecksum returns the additional checksum added by
+// AddChecksum, it is only complete once all content is read.
+func (r *Reader) ContentChecksum() *Checksum {
+	return r.contentHash
+}
+
+func (r *Reader) verifyChecksum() error {
+	c := r.contentHash
+	if c.Trailing {
+		value := r.trailer.Get(c.Type.Key())
+		if NewChecksumString(c.Type, value) == nil {
+			return ErrInvalidChecksum
+		}
+		c.Encoded, c.Trailing = value, false
+	}
+	sum := base64.StdEncoding.EncodeToString(r.contentHasher.Sum(nil))
+	if c.Encoded == "" {
+		c.Encoded = sum
+		return nil
+	}
+	if c.Encoded != sum {
+		return ChecksumMismatch{Want: c.Encoded, Got: sum}
+	}
+	return nil
+}
+
 var _ io.Closer = (*Reader)(nil) // compiler check
 
 // Close and release resources.
@@ -0,0 +1,73 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+package http
+
+import (
+	"context"
+	"net"
+	"sync"
+	"sync/atomic"
+)
+
+// DialStats counts the connections opened by a DialContext.
+type DialStats struct {
+	opened uint64
+	failed uint64
+	open   int64
+}
+
+// Wrap returns a DialContext counting the connections dialed by dial
+// in s until they are closed.
+func (s *DialStats) Wrap(dial DialContext) DialContext {
+	return func(ctx context.Context, network, addr string) (net.Conn, error) {
+		conn, err := dial(ctx, network, addr)
+		if err != nil {
+			atomic.AddUint64(&s.failed, 1)
+			return nil, err
+		}
+		atomic.AddUint64(&s.opened, 1)
+		atomic.AddInt64(&s.open, 1)
+		return &statsConn{Conn: conn, stats: s}, nil
+	}
+}
+
+// Opened returns the number of connections dialed successfully.
+func (s *DialStats) Opened() uint64 {
+	return atomic.LoadUint64(&s.opened)
+}
+
+// Failed returns the number of failed dials.
+func (s *DialStats) Failed() uint64 {
+	return atomic.LoadUint64(&s.failed)
+}
+
+// Open returns the number of connections not closed yet.
+func (s *DialStats) Open() int64 {
+	return atomic.LoadInt64(&s.open)
+}
+
+// statsConn decrements the open connections of its stats on close.
+type statsConn struct {
+	net.Conn
+	stats *DialStats
+	once  sync.Once
+}
+
+func (c *statsConn) Close() error {
+	c.once.Do(func() { atomic.AddInt64(&c.stats.open, -1) })
+	return c.Conn.Close()
+}
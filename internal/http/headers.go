@@ -42,6 +42,20 @@ const (
 	Range              = "Range"
 )
 
+// CORS HTTP header constants
+const (
+	Origin                        = "Origin"
+	Vary                          = "Vary"
+	AccessControlRequestMethod    = "Access-Control-Request-Method"
+	AccessControlRequestHeaders   = "Access-Control-Request-Headers"
+	AccessControlAllowOrigin      = "Access-Control-Allow-Origin"
+	AccessControlAllowMethods     = "Access-Control-Allow-Methods"
+	AccessControlAllowHeaders     = "Access-Control-Allow-Headers"
+	AccessControlAllowCredentials = "Access-Control-Allow-Credentials"
+	AccessControlExposeHeaders    = "Access-Control-Expose-Headers"
+	AccessControlMaxAge           = "Access-Control-Max-Age"
+)
+
 // Non standard S3 HTTP response constants
 const (
 	XCache       = "X-Cache"
@@ -97,6 +111,21 @@ const (
 	// Multipart parts count
 	AmzMpPartsCount = "x-amz-mp-parts-count"
 
+	// S3 object attributes
+	AmzObjectAttributes = "X-Amz-Object-Attributes"
+	AmzMaxParts         = "X-Amz-Max-Parts"
+	AmzPartNumberMarker = "X-Amz-Part-Number-Marker"
+
+	// S3 additional checksums
+	AmzChecksumAlgorithm    = "X-Amz-Checksum-Algorithm"
+	AmzSDKChecksumAlgorithm = "X-Amz-Sdk-Checksum-Algorithm"
+	AmzChecksumCRC32        = "X-Amz-Checksum-Crc32"
+	AmzChecksumCRC32C       = "X-Amz-Checksum-Crc32c"
+	AmzChecksumSHA1         = "X-Amz-Checksum-Sha1"
+	AmzChecksumSHA256       = "X-Amz-Checksum-Sha256"
+	AmzChecksumMode         = "X-Amz-Checksum-Mode"
+	AmzTrailer              = "X-Amz-Trailer"
+
 	// Object date/time of expiration
 	AmzExpiration = "x-amz-expiration"
 
@@ -138,6 +167,9 @@ const (
 	// Response request id.
 	AmzRequestID = "x-amz-request-id"
 
+	// Request id provided by clients, echoed in responses.
+	RequestID = "X-Request-ID"
+
 	// Deployment id.
 	MinioDeploymentID = "x-minio-deployment-id"
 
@@ -150,6 +182,12 @@ const (
 	// Create special flag to force create a bucket
 	MinIOForceCreate = "x-minio-force-create"
 
+	// Header requests that the body is appended to an existing object
+	MinIOAppendObject = "x-minio-append-object"
+
+	// Query parameter of presigned URLs limiting the size of the uploaded body
+	MinIOMaxContentLength = "x-minio-max-content-length"
+
 	// Header indicates if the mtime should be preserved by client
 	MinIOSourceMTime = "x-minio-source-mtime"
 
@@ -196,6 +234,8 @@ const (
 	PartNumber = "partNumber"
 
 	UploadID = "uploadId"
+
+	AccessPoint = "accessPoint"
 )
 
 // http headers sent to webhook targets
@@ -203,3 +243,12 @@ const (
 	// Reports the version of MinIO server
 	MinIOVersion = "x-minio-version"
 )
+
+// http headers sent to object transform endpoints
+const (
+	MinIOTransformAccessPoint = "X-Minio-Transform-Access-Point"
+	MinIOTransformBucket      = "X-Minio-Transform-Bucket"
+	MinIOTransformObject      = "X-Minio-Transform-Object"
+	MinIOTransformVersionID   = "X-Minio-Transform-Version-Id"
+	MinIOTransformPayload     = "X-Minio-Transform-Payload"
+)
@@ -31,6 +31,8 @@ import (
 	"time"
 
 	humanize "github.com/dustin/go-humanize"
+	"golang.org/x/net/http2"
+	"golang.org/x/net/http2/h2c"
 )
 
 var (
@@ -111,8 +113,23 @@ func (srv *Server) Start(ctx context.Context) (err error) {
 		handler.ServeHTTP(w, r)
 	})
 
+	// Accept HTTP/2 with prior knowledge on plain connections, HTTP/2
+	// over TLS is negotiated by the TLS listener. Upgrades from HTTP/1.1
+	// are not supported as they buffer the request body in memory.
+	var h http.Handler = wrappedHandler
+	if tlsConfig == nil {
+		h2cHandler := h2c.NewHandler(wrappedHandler, &http2.Server{IdleTimeout: srv.IdleTimeout})
+		h = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.Method == "PRI" && r.URL.Path == "*" && r.Proto == "HTTP/2.0" {
+				h2cHandler.ServeHTTP(w, r)
+				return
+			}
+			wrappedHandler.ServeHTTP(w, r)
+		})
+	}
+
 	srv.listenerMutex.Lock()
-	srv.Handler = wrappedHandler
+	srv.Handler = h
 	srv.listener = listener
 	srv.listenerMutex.Unlock()
 
@@ -207,6 +224,12 @@ func (srv *Server) UseBaseContext(ctx context.Context) *Server {
 	return srv
 }
 
+// UseConnState configure a callback invoked on every client connection state change
+func (srv *Server) UseConnState(f func(net.Conn, http.ConnState)) *Server {
+	srv.ConnState = f
+	return srv
+}
+
 // UseCustomLogger use customized logger for this HTTP *Server
 func (srv *Server) UseCustomLogger(l *log.Logger) *Server {
 	srv.ErrorLog = l
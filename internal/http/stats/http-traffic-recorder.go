@@ -18,20 +18,45 @@
 package stats
 
 import (
+	"bufio"
+	"context"
+	"errors"
 	"io"
+	"net"
 	"net/http"
 )
 
+// Throttle limits the rate of the traffic through a meter.
+type Throttle interface {
+	// Burst returns the maximum number of bytes passed at once.
+	Burst() int
+	// WaitN blocks until n bytes may pass or ctx is done.
+	WaitN(ctx context.Context, n int) error
+}
+
 // IncomingTrafficMeter counts the incoming bytes from the underlying request.Body.
 type IncomingTrafficMeter struct {
 	countBytes int64
 	io.ReadCloser
+
+	// Throttle, if set, limits the rate of the reads
+	// until Ctx is done.
+	Throttle Throttle
+	Ctx      context.Context
 }
 
 // Read calls the underlying Read and counts the transferred bytes.
 func (r *IncomingTrafficMeter) Read(p []byte) (n int, err error) {
+	if r.Throttle != nil && len(p) > r.Throttle.Burst() {
+		p = p[:r.Throttle.Burst()]
+	}
 	n, err = r.ReadCloser.Read(p)
 	r.countBytes += int64(n)
+	if r.Throttle != nil && n > 0 {
+		if werr := r.Throttle.WaitN(r.Ctx, n); werr != nil {
+			return n, werr
+		}
+	}
 
 	return n, err
 }
@@ -46,12 +71,50 @@ type OutgoingTrafficMeter struct {
 	countBytes int64
 	// wrapper for underlying http.ResponseWriter.
 	http.ResponseWriter
+
+	// Throttle, if set, limits the rate of the writes
+	// until Ctx is done.
+	Throttle Throttle
+	Ctx      context.Context
 }
 
 // Write calls the underlying write and counts the output bytes
 func (w *OutgoingTrafficMeter) Write(p []byte) (n int, err error) {
-	n, err = w.ResponseWriter.Write(p)
-	w.countBytes += int64(n)
+	if w.Throttle == nil {
+		n, err = w.ResponseWriter.Write(p)
+		w.countBytes += int64(n)
+		return n, err
+	}
+	for len(p) > 0 {
+		chunk := p
+		if len(chunk) > w.Throttle.Burst() {
+			chunk = chunk[:w.Throttle.Burst()]
+		}
+		if err = w.Throttle.WaitN(w.Ctx, len(chunk)); err != nil {
+			return n, err
+		}
+		var m int
+		m, err = w.ResponseWriter.Write(chunk)
+		n += m
+		w.countBytes += int64(m)
+		if err != nil {
+			return n, err
+		}
+		p = p[m:]
+	}
+	return n, nil
+}
+
+// ReadFrom implements io.ReaderFrom, unthrottled responses are
+// passed to the underlying writer so that they may use sendfile.
+func (w *OutgoingTrafficMeter) ReadFrom(r io.Reader) (n int64, err error) {
+	rf, ok := w.ResponseWriter.(io.ReaderFrom)
+	if !ok || w.Throttle != nil {
+		// Hide ReadFrom to avoid calling ourselves again.
+		return io.Copy(struct{ io.Writer }{w}, r)
+	}
+	n, err = rf.ReadFrom(r)
+	w.countBytes += n
 	return n, err
 }
 
@@ -60,6 +123,16 @@ func (w *OutgoingTrafficMeter) Flush() {
 	w.ResponseWriter.(http.Flusher).Flush()
 }
 
+// Hijack calls the underlying Hijack, the traffic
+// of hijacked connections is not counted.
+func (w *OutgoingTrafficMeter) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	hj, ok := w.ResponseWriter.(http.Hijacker)
+	if !ok {
+		return nil, nil, errors.New("response writer does not support hijacking")
+	}
+	return hj.Hijack()
+}
+
 // BytesWritten returns the number of transferred bytes
 func (w *OutgoingTrafficMeter) BytesWritten() int64 {
 	return w.countBytes
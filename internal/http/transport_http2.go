@@ -0,0 +1,68 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+package http
+
+import (
+	"context"
+	"crypto/tls"
+	"net"
+	"net/http"
+	"time"
+
+	"golang.org/x/net/http2"
+)
+
+// NewHTTP2Transport returns a transport multiplexing the requests to
+// each host over a single persistent HTTP/2 connection, opening more
+// only when the streams of the connection are exhausted. Connections
+// are established with TLS if tlsConfig is not nil, with HTTP/2 prior
+// knowledge otherwise, within dialTimeout. A connection idle for
+// readIdleTimeout is health checked with a ping, and closed if no
+// reply is received within dialTimeout, a new one is dialed by the
+// next request.
+func NewHTTP2Transport(dial DialContext, tlsConfig *tls.Config, dialTimeout, readIdleTimeout time.Duration) http.RoundTripper {
+	tr := &http2.Transport{
+		AllowHTTP:       tlsConfig == nil,
+		ReadIdleTimeout: readIdleTimeout,
+		PingTimeout:     dialTimeout,
+		// Go net/http automatically unzip if content-type is
+		// gzip disable this feature, as we are always interested
+		// in raw stream.
+		DisableCompression: true,
+	}
+	if tlsConfig != nil {
+		tlsConfig = tlsConfig.Clone()
+		tlsConfig.NextProtos = []string{http2.NextProtoTLS}
+		tr.TLSClientConfig = tlsConfig
+	}
+	tr.DialTLS = func(network, addr string, cfg *tls.Config) (net.Conn, error) {
+		ctx, cancel := context.WithTimeout(context.Background(), dialTimeout)
+		defer cancel()
+
+		conn, err := dial(ctx, network, addr)
+		if err != nil || tlsConfig == nil {
+			return conn, err
+		}
+		tlsConn := tls.Client(conn, cfg)
+		if err = tlsConn.HandshakeContext(ctx); err != nil {
+			conn.Close()
+			return nil, err
+		}
+		return tlsConn, nil
+	}
+	return tr
+}
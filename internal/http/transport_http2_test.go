@@ -0,0 +1,93 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+package http
+
+import (
+	"context"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestHTTP2Transport(t *testing.T) {
+	addr := "127.0.0.1:" + getNextPort()
+	server := NewServer([]string{addr}).
+		UseHandler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Write([]byte(r.Proto))
+		})).
+		UseShutdownTimeout(DefaultShutdownTimeout)
+	errCh := make(chan error, 1)
+	go func() { errCh <- server.Start(context.Background()) }()
+	defer server.Shutdown()
+
+	// Wait for the server to listen.
+	for retry := 0; ; retry++ {
+		conn, err := net.Dial("tcp", addr)
+		if err == nil {
+			conn.Close()
+			break
+		}
+		select {
+		case err = <-errCh:
+			t.Fatal(err)
+		case <-time.After(100 * time.Millisecond):
+		}
+		if retry == 50 {
+			t.Fatal(err)
+		}
+	}
+
+	var stats DialStats
+	clients := map[string]*http.Client{
+		"HTTP/2.0": {Transport: NewHTTP2Transport(stats.Wrap(NewInternodeDialContext(time.Second)), nil, time.Second, 15*time.Second)},
+		"HTTP/1.1": {},
+	}
+	for proto, client := range clients {
+		var wg sync.WaitGroup
+		for i := 0; i < 10; i++ {
+			wg.Add(1)
+			go func(client *http.Client, proto string) {
+				defer wg.Done()
+				resp, err := client.Get("http://" + addr)
+				if err != nil {
+					t.Error(err)
+					return
+				}
+				defer resp.Body.Close()
+				b, err := ioutil.ReadAll(resp.Body)
+				if err != nil {
+					t.Error(err)
+					return
+				}
+				if string(b) != proto {
+					t.Errorf("expected %s, got %s", proto, string(b))
+				}
+			}(client, proto)
+		}
+		wg.Wait()
+	}
+
+	if stats.Opened() != 1 {
+		t.Errorf("expected a single multiplexed connection, got %d", stats.Opened())
+	}
+	if stats.Open() != 1 {
+		t.Errorf("expected the connection to be kept open, got %d", stats.Open())
+	}
+}
@@ -46,6 +46,17 @@ func (w *WriteOnCloser) Write(p []byte) (int, error) {
 	return w.Writer.Write(p)
 }
 
+// ReadFrom implements io.ReaderFrom. It hands the reader to the
+// underlying writer when that supports io.ReaderFrom, so the data
+// can be sent with sendfile or splice where available.
+func (w *WriteOnCloser) ReadFrom(r io.Reader) (int64, error) {
+	w.hasWritten = true
+	if rf, ok := w.Writer.(io.ReaderFrom); ok {
+		return rf.ReadFrom(r)
+	}
+	return io.Copy(w.Writer, r)
+}
+
 // Close closes the WriteOnCloser. It behaves like io.Closer.
 func (w *WriteOnCloser) Close() error {
 	if !w.hasWritten {
@@ -0,0 +1,343 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package kms
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/minio/kes"
+)
+
+// AWSConfig contains the configuration of a KMS using
+// the AWS Key Management Service directly.
+type AWSConfig struct {
+	// Region is the AWS region of the keys.
+	Region string
+
+	// Endpoint is the HTTP endpoint of AWS KMS,
+	// https://kms.<region>.amazonaws.com if empty.
+	Endpoint string
+
+	// AccessKey, SecretKey and SessionToken are
+	// the AWS credentials signing the requests.
+	AccessKey    string
+	SecretKey    string
+	SessionToken string
+
+	// DefaultKeyID is the ID, ARN or alias of the key
+	// used when no explicit key ID is specified for a
+	// cryptographic operation.
+	DefaultKeyID string
+
+	// RootCAs is a set of root CA certificates
+	// to verify the AWS KMS TLS certificate.
+	RootCAs *x509.CertPool
+
+	// Observer, if set, is called with the endpoint,
+	// the latency and the error of every request sent
+	// to AWS KMS.
+	Observer func(endpoint string, latency time.Duration, err error)
+}
+
+// NewAWS returns a new KMS generating and decrypting
+// data encryption keys with AWS KMS.
+func NewAWS(config AWSConfig) (KMS, error) {
+	if config.Region == "" {
+		return nil, errors.New("kms: no AWS region")
+	}
+	if config.AccessKey == "" || config.SecretKey == "" {
+		return nil, errors.New("kms: no AWS credentials")
+	}
+	endpoint := config.Endpoint
+	if endpoint == "" {
+		endpoint = "https://kms." + config.Region + ".amazonaws.com"
+	}
+	return &awsClient{
+		endpoint:     strings.TrimSuffix(endpoint, "/"),
+		region:       config.Region,
+		accessKey:    config.AccessKey,
+		secretKey:    config.SecretKey,
+		sessionToken: config.SessionToken,
+		defaultKeyID: config.DefaultKeyID,
+		observer:     config.Observer,
+		client: &http.Client{
+			Timeout: kmsRequestTimeout,
+			Transport: &http.Transport{
+				Proxy: http.ProxyFromEnvironment,
+				TLSClientConfig: &tls.Config{
+					MinVersion:         tls.VersionTLS12,
+					RootCAs:            config.RootCAs,
+					ClientSessionCache: tls.NewLRUClientSessionCache(tlsClientSessionCacheSize),
+				},
+			},
+		},
+	}, nil
+}
+
+type awsClient struct {
+	endpoint     string
+	region       string
+	accessKey    string
+	secretKey    string
+	sessionToken string
+	defaultKeyID string
+	client       *http.Client
+	observer     func(endpoint string, latency time.Duration, err error)
+}
+
+var _ KMS = (*awsClient)(nil) // compiler check
+
+// awsError is an error response of AWS KMS.
+type awsError struct {
+	Type    string `json:"__type"`
+	Message string `json:"message"`
+}
+
+func (e awsError) Error() string {
+	return fmt.Sprintf("kms: AWS: %s: %s", e.Type, e.Message)
+}
+
+// do calls the AWS KMS action with the JSON body in, and
+// decodes the response into out.
+func (c *awsClient) do(action string, in, out interface{}) (err error) {
+	if c.observer != nil {
+		defer func(now time.Time) {
+			c.observer(c.endpoint, time.Since(now), err)
+		}(time.Now())
+	}
+
+	data, err := json.Marshal(in)
+	if err != nil {
+		return err
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), kmsRequestTimeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.endpoint+"/", bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/x-amz-json-1.1")
+	req.Header.Set("X-Amz-Target", "TrentService."+action)
+	if c.sessionToken != "" {
+		req.Header.Set("X-Amz-Security-Token", c.sessionToken)
+	}
+	signV4(req, data, "kms", c.region, c.accessKey, c.secretKey, time.Now().UTC())
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		var aErr awsError
+		if err = json.NewDecoder(io.LimitReader(resp.Body, 1<<20)).Decode(&aErr); err != nil || aErr.Type == "" {
+			return fmt.Errorf("kms: AWS: %d %s", resp.StatusCode, http.StatusText(resp.StatusCode))
+		}
+		// The type may be prefixed by a namespace.
+		if i := strings.LastIndexByte(aErr.Type, '#'); i >= 0 {
+			aErr.Type = aErr.Type[i+1:]
+		}
+		switch aErr.Type {
+		case "NotFoundException":
+			return kes.ErrKeyNotFound
+		case "AccessDeniedException":
+			return kes.ErrNotAllowed
+		}
+		return aErr
+	}
+	return json.NewDecoder(resp.Body).Decode(out)
+}
+
+// Stat returns the current AWS KMS status containing the
+// AWS KMS endpoint and the default key ID.
+func (c *awsClient) Stat() (Status, error) {
+	var response struct{}
+	if err := c.do("ListKeys", map[string]int{"Limit": 1}, &response); err != nil {
+		return Status{}, err
+	}
+	return Status{
+		Name:       "AWS",
+		Endpoints:  []string{c.endpoint},
+		DefaultKey: c.defaultKeyID,
+	}, nil
+}
+
+// CreateKey is not supported, AWS KMS generates the IDs of
+// the keys it creates.
+func (c *awsClient) CreateKey(string) error {
+	return errors.New("kms: creating keys is not supported by AWS KMS, keys must be created with AWS")
+}
+
+// GenerateKey generates a new data encryption key using
+// the AWS KMS key referenced by the key ID.
+//
+// The default key ID will be used if keyID is empty.
+//
+// The context is the encryption context of the generated
+// DEK, the same context must be provided when the generated
+// key should be decrypted.
+func (c *awsClient) GenerateKey(keyID string, ctx Context) (DEK, error) {
+	if keyID == "" {
+		keyID = c.defaultKeyID
+	}
+	var response struct {
+		CiphertextBlob []byte
+		Plaintext      []byte
+	}
+	err := c.do("GenerateDataKey", struct {
+		KeyId             string
+		KeySpec           string
+		EncryptionContext map[string]string `json:",omitempty"`
+	}{
+		KeyId:             keyID,
+		KeySpec:           "AES_256",
+		EncryptionContext: ctx, // Not its canonical text, a JSON object.
+	}, &response)
+	if err != nil {
+		return DEK{}, err
+	}
+	return DEK{
+		KeyID:      keyID,
+		Plaintext:  response.Plaintext,
+		Ciphertext: response.CiphertextBlob,
+	}, nil
+}
+
+// DecryptKey decrypts the ciphertext with the AWS KMS key
+// referenced by the key ID. The context must match the
+// context value used to generate the ciphertext.
+func (c *awsClient) DecryptKey(keyID string, ciphertext []byte, ctx Context) ([]byte, error) {
+	var response struct {
+		Plaintext []byte
+	}
+	err := c.do("Decrypt", struct {
+		KeyId             string
+		CiphertextBlob    []byte
+		EncryptionContext map[string]string `json:",omitempty"`
+	}{
+		KeyId:             keyID,
+		CiphertextBlob:    ciphertext,
+		EncryptionContext: ctx, // Not its canonical text, a JSON object.
+	}, &response)
+	if err != nil {
+		return nil, err
+	}
+	return response.Plaintext, nil
+}
+
+func (c *awsClient) DecryptAll(_ context.Context, keyID string, ciphertexts [][]byte, contexts []Context) ([][]byte, error) {
+	plaintexts := make([][]byte, 0, len(ciphertexts))
+	for i := range ciphertexts {
+		plaintext, err := c.DecryptKey(keyID, ciphertexts[i], contexts[i])
+		if err != nil {
+			return nil, err
+		}
+		plaintexts = append(plaintexts, plaintext)
+	}
+	return plaintexts, nil
+}
+
+// signV4 signs req, whose body is body, with the AWS signature
+// version 4 of the service in the region at time t. All headers
+// of req are signed.
+func signV4(req *http.Request, body []byte, service, region, accessKey, secretKey string, t time.Time) {
+	amzDate := t.Format("20060102T150405Z")
+	req.Header.Set("X-Amz-Date", amzDate)
+
+	headers := map[string]string{"host": req.URL.Host}
+	for k, v := range req.Header {
+		headers[strings.ToLower(k)] = strings.TrimSpace(strings.Join(v, ","))
+	}
+	names := make([]string, 0, len(headers))
+	for k := range headers {
+		names = append(names, k)
+	}
+	sort.Strings(names)
+	var canonicalHeaders strings.Builder
+	for _, k := range names {
+		canonicalHeaders.WriteString(k + ":" + headers[k] + "\n")
+	}
+	signedHeaders := strings.Join(names, ";")
+
+	query := req.URL.Query()
+	keys := make([]string, 0, len(query))
+	for k := range query {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	var params []string
+	for _, k := range keys {
+		values := query[k]
+		sort.Strings(values)
+		for _, v := range values {
+			params = append(params, awsURIEncode(k)+"="+awsURIEncode(v))
+		}
+	}
+	uri := req.URL.EscapedPath()
+	if uri == "" {
+		uri = "/"
+	}
+	bodySum := sha256.Sum256(body)
+	canonicalRequest := strings.Join([]string{
+		req.Method,
+		uri,
+		strings.Join(params, "&"),
+		canonicalHeaders.String(),
+		signedHeaders,
+		hex.EncodeToString(bodySum[:]),
+	}, "\n")
+
+	scope := strings.Join([]string{t.Format("20060102"), region, service, "aws4_request"}, "/")
+	requestSum := sha256.Sum256([]byte(canonicalRequest))
+	stringToSign := strings.Join([]string{"AWS4-HMAC-SHA256", amzDate, scope, hex.EncodeToString(requestSum[:])}, "\n")
+
+	key := []byte("AWS4" + secretKey)
+	for _, s := range []string{t.Format("20060102"), region, service, "aws4_request"} {
+		key = hmacSHA256(key, s)
+	}
+	signature := hex.EncodeToString(hmacSHA256(key, stringToSign))
+	req.Header.Set("Authorization", "AWS4-HMAC-SHA256 Credential="+accessKey+"/"+scope+", SignedHeaders="+signedHeaders+", Signature="+signature)
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	h := hmac.New(sha256.New, key)
+	h.Write([]byte(data))
+	return h.Sum(nil)
+}
+
+// awsURIEncode encodes s as required by the AWS signature,
+// spaces are encoded as %20 rather than +.
+func awsURIEncode(s string) string {
+	return strings.ReplaceAll(url.QueryEscape(s), "+", "%20")
+}
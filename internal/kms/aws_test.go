@@ -0,0 +1,107 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package kms
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/minio/kes"
+)
+
+// Tests the signature with the example of the AWS documentation.
+func TestSignV4(t *testing.T) {
+	req, err := http.NewRequest(http.MethodGet, "https://iam.amazonaws.com/?Action=ListUsers&Version=2010-05-08", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded; charset=utf-8")
+	signV4(req, nil, "iam", "us-east-1", "AKIDEXAMPLE", "wJalrXUtnFEMI/K7MDENG+bPxRfiCYEXAMPLEKEY", time.Date(2015, 8, 30, 12, 36, 0, 0, time.UTC))
+
+	want := "AWS4-HMAC-SHA256 Credential=AKIDEXAMPLE/20150830/us-east-1/iam/aws4_request, SignedHeaders=content-type;host;x-amz-date, Signature=5d672d79c15b13162d9279b0855cfba6789a8edb4c82c400e06b5924a6f2b5d7"
+	if got := req.Header.Get("Authorization"); got != want {
+		t.Errorf("expected authorization %q, got %q", want, got)
+	}
+}
+
+func TestAWS(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !strings.HasPrefix(r.Header.Get("Authorization"), "AWS4-HMAC-SHA256 Credential=access/") {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		var req struct {
+			KeyId             string
+			CiphertextBlob    []byte
+			EncryptionContext map[string]string
+		}
+		json.NewDecoder(r.Body).Decode(&req)
+		if req.KeyId != "alias/minio" {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(`{"__type":"NotFoundException","message":"Alias is not found."}`))
+			return
+		}
+		if req.EncryptionContext["bucket"] != "object" {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(`{"__type":"InvalidCiphertextException"}`))
+			return
+		}
+		switch r.Header.Get("X-Amz-Target") {
+		case "TrentService.GenerateDataKey":
+			json.NewEncoder(w).Encode(map[string][]byte{"CiphertextBlob": []byte("ciphertext"), "Plaintext": []byte("plaintext")})
+		case "TrentService.Decrypt":
+			json.NewEncoder(w).Encode(map[string][]byte{"Plaintext": []byte(strings.Replace(string(req.CiphertextBlob), "cipher", "plain", 1))})
+		}
+	}))
+	defer server.Close()
+
+	KMS, err := NewAWS(AWSConfig{
+		Region:       "us-east-1",
+		Endpoint:     server.URL,
+		AccessKey:    "access",
+		SecretKey:    "secret",
+		DefaultKeyID: "alias/minio",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := Context{"bucket": "object"}
+	dek, err := KMS.GenerateKey("", ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if dek.KeyID != "alias/minio" || string(dek.Plaintext) != "plaintext" || string(dek.Ciphertext) != "ciphertext" {
+		t.Fatalf("unexpected DEK %+v", dek)
+	}
+	plaintext, err := KMS.DecryptKey("alias/minio", dek.Ciphertext, ctx)
+	if err != nil || !bytes.Equal(plaintext, dek.Plaintext) {
+		t.Errorf("unable to decrypt the DEK: %v", err)
+	}
+	if _, err = KMS.DecryptKey("alias/minio", dek.Ciphertext, Context{"bucket": "other"}); err == nil {
+		t.Error("expected the DEK not to be decrypted with another context")
+	}
+	if _, err = KMS.GenerateKey("alias/unknown", ctx); !errors.Is(err, kes.ErrKeyNotFound) {
+		t.Errorf("expected an unknown key not to be found, got %v", err)
+	}
+}
@@ -22,7 +22,9 @@ import (
 	"crypto/tls"
 	"crypto/x509"
 	"errors"
+	"net/http"
 	"strings"
+	"sync/atomic"
 	"time"
 
 	"github.com/minio/kes"
@@ -30,6 +32,10 @@ import (
 
 const (
 	tlsClientSessionCacheSize = 100
+
+	// interval and timeout of the health checks of the KES endpoints.
+	kesHealthCheckInterval = 10 * time.Second
+	kesHealthCheckTimeout  = 5 * time.Second
 )
 
 // Config contains various KMS-related configuration
@@ -52,6 +58,11 @@ type Config struct {
 	// RootCAs is a set of root CA certificates
 	// to verify the KMS server TLS certificate.
 	RootCAs *x509.CertPool
+
+	// Observer, if set, is called with the endpoint,
+	// the latency and the error of every request sent
+	// to a KMS server.
+	Observer func(endpoint string, latency time.Duration, err error)
 }
 
 // NewWithConfig returns a new KMS using the given
@@ -71,39 +82,158 @@ func NewWithConfig(config Config) (KMS, error) {
 	})
 	client.Endpoints = endpoints
 
-	var bulkAvailable bool
-	_, policy, err := client.DescribeSelf(context.Background())
+	c := &kesClient{
+		client:       client,
+		defaultKeyID: config.DefaultKeyID,
+		observer:     config.Observer,
+	}
+	for _, endpoint := range endpoints {
+		// All endpoint clients share the connection pool of client.
+		c.endpoints = append(c.endpoints, &kesEndpoint{
+			endpoint: endpoint,
+			client: &kes.Client{
+				Endpoints:  []string{endpoint},
+				HTTPClient: client.HTTPClient,
+			},
+		})
+	}
+
+	var policy *kes.Policy
+	err := c.do(context.Background(), func(client *kes.Client) (err error) {
+		_, policy, err = client.DescribeSelf(context.Background())
+		return err
+	})
 	if err == nil {
 		const BulkAPI = "/v1/key/bulk/decrypt/"
 		for _, allow := range policy.Allow {
 			if strings.HasPrefix(allow, BulkAPI) {
-				bulkAvailable = true
+				c.bulkAvailable = true
 				break
 			}
 		}
 	}
-	return &kesClient{
-		client:        client,
-		defaultKeyID:  config.DefaultKeyID,
-		bulkAvailable: bulkAvailable,
-	}, nil
+	go c.healthCheck(kesHealthCheckInterval)
+	return c, nil
 }
 
 type kesClient struct {
 	defaultKeyID string
 	client       *kes.Client
+	endpoints    []*kesEndpoint
+	observer     func(endpoint string, latency time.Duration, err error)
+
+	// next is incremented by every request to
+	// spread the requests over the endpoints.
+	next uint32
 
 	bulkAvailable bool
 }
 
-var _ KMS = (*kesClient)(nil) // compiler check
+// kesEndpoint is a KES server endpoint and its health.
+type kesEndpoint struct {
+	endpoint string
+	client   *kes.Client
+	offline  int32 // set to 1 once a request or health check failed
+}
+
+func (e *kesEndpoint) online() bool {
+	return atomic.LoadInt32(&e.offline) == 0
+}
+
+func (e *kesEndpoint) setOnline(online bool) {
+	if online {
+		atomic.StoreInt32(&e.offline, 0)
+	} else {
+		atomic.StoreInt32(&e.offline, 1)
+	}
+}
+
+var (
+	_ KMS           = (*kesClient)(nil) // compiler check
+	_ HealthChecker = (*kesClient)(nil) // compiler check
+)
+
+// unavailable returns true if err indicates that a KES endpoint
+// could not serve a request, which may then succeed on another one.
+func unavailable(err error) bool {
+	if err == nil {
+		return false
+	}
+	var kesErr kes.Error
+	if errors.As(err, &kesErr) {
+		return kesErr.Status() >= http.StatusInternalServerError
+	}
+	return true
+}
+
+// do sends a request to the KES endpoints until one of them serves it.
+// The endpoints which are online are tried first, the offline ones are
+// tried last since they may have recovered since their last check.
+func (c *kesClient) do(ctx context.Context, fn func(client *kes.Client) error) (err error) {
+	n := len(c.endpoints)
+	start := int(atomic.AddUint32(&c.next, 1))
+	tried := make([]bool, n)
+	for _, online := range []bool{true, false} {
+		for i := 0; i < n; i++ {
+			idx := (start + i) % n
+			e := c.endpoints[idx]
+			if tried[idx] || e.online() != online {
+				continue
+			}
+			tried[idx] = true
+
+			now := time.Now()
+			err = fn(e.client)
+			if c.observer != nil {
+				c.observer(e.endpoint, time.Since(now), err)
+			}
+			if !unavailable(err) {
+				e.setOnline(true)
+				return err
+			}
+			e.setOnline(false)
+			if ctx.Err() != nil {
+				return err
+			}
+		}
+	}
+	return err
+}
+
+// healthCheck periodically checks whether the KES
+// endpoints are online.
+func (c *kesClient) healthCheck(interval time.Duration) {
+	t := time.NewTicker(interval)
+	defer t.Stop()
+	for range t.C {
+		for _, e := range c.endpoints {
+			ctx, cancel := context.WithTimeout(context.Background(), kesHealthCheckTimeout)
+			_, err := e.client.Version(ctx)
+			cancel()
+			e.setOnline(!unavailable(err))
+		}
+	}
+}
+
+// Health returns whether each KES endpoint is online.
+func (c *kesClient) Health() map[string]bool {
+	health := make(map[string]bool, len(c.endpoints))
+	for _, e := range c.endpoints {
+		health[e.endpoint] = e.online()
+	}
+	return health
+}
 
 // Stat returns the current KES status containing a
 // list of KES endpoints and the default key ID.
 func (c *kesClient) Stat() (Status, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
-	if _, err := c.client.Version(ctx); err != nil {
+	err := c.do(ctx, func(client *kes.Client) error {
+		_, err := client.Version(ctx)
+		return err
+	})
+	if err != nil {
 		return Status{}, err
 	}
 	endpoints := make([]string, len(c.client.Endpoints))
@@ -121,7 +251,9 @@ func (c *kesClient) Stat() (Status, error) {
 // If the a key with the same keyID already exists then
 // CreateKey returns kes.ErrKeyExists.
 func (c *kesClient) CreateKey(keyID string) error {
-	return c.client.CreateKey(context.Background(), keyID)
+	return c.do(context.Background(), func(client *kes.Client) error {
+		return client.CreateKey(context.Background(), keyID)
+	})
 }
 
 // GenerateKey generates a new data encryption key using
@@ -140,7 +272,11 @@ func (c *kesClient) GenerateKey(keyID string, ctx Context) (DEK, error) {
 	if err != nil {
 		return DEK{}, err
 	}
-	dek, err := c.client.GenerateKey(context.Background(), keyID, ctxBytes)
+	var dek kes.DEK
+	err = c.do(context.Background(), func(client *kes.Client) (err error) {
+		dek, err = client.GenerateKey(context.Background(), keyID, ctxBytes)
+		return err
+	})
 	if err != nil {
 		return DEK{}, err
 	}
@@ -159,7 +295,12 @@ func (c *kesClient) DecryptKey(keyID string, ciphertext []byte, ctx Context) ([]
 	if err != nil {
 		return nil, err
 	}
-	return c.client.Decrypt(context.Background(), keyID, ciphertext, ctxBytes)
+	var plaintext []byte
+	err = c.do(context.Background(), func(client *kes.Client) (err error) {
+		plaintext, err = client.Decrypt(context.Background(), keyID, ciphertext, ctxBytes)
+		return err
+	})
+	return plaintext, err
 }
 
 func (c *kesClient) DecryptAll(ctx context.Context, keyID string, ciphertexts [][]byte, contexts []Context) ([][]byte, error) {
@@ -175,7 +316,11 @@ func (c *kesClient) DecryptAll(ctx context.Context, keyID string, ciphertexts []
 				Context:    bCtx,
 			})
 		}
-		PCPs, err := c.client.DecryptAll(ctx, keyID, CCPs...)
+		var PCPs []kes.PCP
+		err := c.do(ctx, func(client *kes.Client) (err error) {
+			PCPs, err = client.DecryptAll(ctx, keyID, CCPs...)
+			return err
+		})
 		if err != nil {
 			return nil, err
 		}
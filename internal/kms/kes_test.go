@@ -0,0 +1,101 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package kms
+
+import (
+	"crypto/x509"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestKESFailover(t *testing.T) {
+	online := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case strings.HasSuffix(r.URL.Path, "/unknown"):
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"message":"key does not exist"}`))
+		case strings.HasPrefix(r.URL.Path, "/v1/key/generate/"):
+			w.Write([]byte(`{"plaintext":"cGxhaW50ZXh0","ciphertext":"Y2lwaGVydGV4dA=="}`))
+		case strings.HasPrefix(r.URL.Path, "/v1/key/decrypt/"):
+			w.Write([]byte(`{"plaintext":"cGxhaW50ZXh0"}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer online.Close()
+	sealed := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer sealed.Close()
+	offline := httptest.NewTLSServer(http.NotFoundHandler())
+	offline.Close()
+
+	rootCAs := x509.NewCertPool()
+	rootCAs.AddCert(online.Certificate())
+	rootCAs.AddCert(sealed.Certificate())
+
+	var mu sync.Mutex
+	errs := map[string]int{}
+	KMS, err := NewWithConfig(Config{
+		Endpoints:    []string{offline.URL, sealed.URL, online.URL},
+		DefaultKeyID: "my-key",
+		RootCAs:      rootCAs,
+		Observer: func(endpoint string, _ time.Duration, err error) {
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				errs[endpoint]++
+			}
+		},
+	})
+	if err != nil {
+		t.Fatalf("Failed to initialize KMS: %v", err)
+	}
+
+	for i := 0; i < 10; i++ {
+		key, err := KMS.GenerateKey("", Context{})
+		if err != nil {
+			t.Fatalf("Failed to generate key: %v", err)
+		}
+		if string(key.Plaintext) != "plaintext" || key.KeyID != "my-key" {
+			t.Fatalf("Unexpected key %+v", key)
+		}
+		if _, err = KMS.DecryptKey(key.KeyID, key.Ciphertext, Context{}); err != nil {
+			t.Fatalf("Failed to decrypt key: %v", err)
+		}
+	}
+	if _, err = KMS.DecryptKey("unknown", []byte("ciphertext"), Context{}); err == nil || err.Error() != "key does not exist" {
+		t.Fatalf("Expected key not found error, got %v", err)
+	}
+
+	health := KMS.(HealthChecker).Health()
+	if health[offline.URL] || health[sealed.URL] || !health[online.URL] {
+		t.Fatalf("Unexpected endpoint health %v", health)
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	// Offline endpoints are only retried once no online endpoint is left.
+	if errs[offline.URL] != 1 || errs[sealed.URL] != 1 {
+		t.Fatalf("Expected one failed request per unavailable endpoint, got %v", errs)
+	}
+}
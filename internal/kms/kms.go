@@ -59,6 +59,13 @@ type KMS interface {
 	DecryptAll(ctx context.Context, keyID string, ciphertext [][]byte, context []Context) ([][]byte, error)
 }
 
+// HealthChecker is implemented by KMS clients which
+// track the health of their server endpoints.
+type HealthChecker interface {
+	// Health returns whether each endpoint is online.
+	Health() map[string]bool
+}
+
 // Status describes the current state of a KMS.
 type Status struct {
 	Name      string   // The name of the KMS
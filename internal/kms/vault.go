@@ -0,0 +1,314 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package kms
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/minio/kes"
+)
+
+// kmsRequestTimeout is the timeout of the requests sent
+// to the KMS servers which are not accessed through KES.
+const kmsRequestTimeout = 10 * time.Second
+
+// VaultConfig contains the configuration of a KMS using the
+// transit secrets engine of a HashiCorp Vault server directly.
+type VaultConfig struct {
+	// Endpoint is the HTTP endpoint of the Vault server.
+	Endpoint string
+
+	// Mount is the path the transit secrets engine
+	// is mounted at, "transit" if empty.
+	Mount string
+
+	// Namespace is the Vault enterprise namespace
+	// of the transit secrets engine, if any.
+	Namespace string
+
+	// Token is the Vault token authenticating
+	// the requests.
+	Token string
+
+	// DefaultKeyID is the name of the transit key used
+	// when no explicit key ID is specified for a
+	// cryptographic operation.
+	DefaultKeyID string
+
+	// RootCAs is a set of root CA certificates
+	// to verify the Vault server TLS certificate.
+	RootCAs *x509.CertPool
+
+	// Observer, if set, is called with the endpoint,
+	// the latency and the error of every request sent
+	// to the Vault server.
+	Observer func(endpoint string, latency time.Duration, err error)
+}
+
+// NewVault returns a new KMS generating and decrypting data
+// encryption keys with the transit secrets engine of Vault.
+func NewVault(config VaultConfig) (KMS, error) {
+	if config.Endpoint == "" {
+		return nil, errors.New("kms: no Vault endpoint")
+	}
+	if config.Token == "" {
+		return nil, errors.New("kms: no Vault token")
+	}
+	mount := config.Mount
+	if mount == "" {
+		mount = "transit"
+	}
+	return &vaultClient{
+		endpoint:     strings.TrimSuffix(config.Endpoint, "/"),
+		mount:        strings.Trim(mount, "/"),
+		namespace:    config.Namespace,
+		token:        config.Token,
+		defaultKeyID: config.DefaultKeyID,
+		observer:     config.Observer,
+		client: &http.Client{
+			Timeout: kmsRequestTimeout,
+			Transport: &http.Transport{
+				Proxy: http.ProxyFromEnvironment,
+				TLSClientConfig: &tls.Config{
+					MinVersion:         tls.VersionTLS12,
+					RootCAs:            config.RootCAs,
+					ClientSessionCache: tls.NewLRUClientSessionCache(tlsClientSessionCacheSize),
+				},
+			},
+		},
+	}, nil
+}
+
+type vaultClient struct {
+	endpoint     string
+	mount        string
+	namespace    string
+	token        string
+	defaultKeyID string
+	client       *http.Client
+	observer     func(endpoint string, latency time.Duration, err error)
+}
+
+var _ KMS = (*vaultClient)(nil) // compiler check
+
+// vaultError is an error response of the Vault server.
+type vaultError struct {
+	status int
+	errors []string
+}
+
+func (e vaultError) Error() string {
+	return fmt.Sprintf("kms: Vault: %d %s: %s", e.status, http.StatusText(e.status), strings.Join(e.errors, ", "))
+}
+
+// do sends a request with the JSON body in to the path of the
+// Vault API, and decodes the data of the response into out.
+func (c *vaultClient) do(ctx context.Context, method, apiPath string, in, out interface{}) (err error) {
+	if c.observer != nil {
+		defer func(now time.Time) {
+			c.observer(c.endpoint, time.Since(now), err)
+		}(time.Now())
+	}
+
+	var body io.Reader
+	if in != nil {
+		data, err := json.Marshal(in)
+		if err != nil {
+			return err
+		}
+		body = bytes.NewReader(data)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, c.endpoint+path.Join("/v1", apiPath), body)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("X-Vault-Token", c.token)
+	if c.namespace != "" {
+		req.Header.Set("X-Vault-Namespace", c.namespace)
+	}
+	if in != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= http.StatusBadRequest {
+		vErr := vaultError{status: resp.StatusCode}
+		var response struct {
+			Errors []string `json:"errors"`
+		}
+		if json.NewDecoder(io.LimitReader(resp.Body, 1<<20)).Decode(&response) == nil {
+			vErr.errors = response.Errors
+		}
+		for _, msg := range vErr.errors {
+			if strings.Contains(msg, "not found") {
+				return kes.ErrKeyNotFound
+			}
+		}
+		switch resp.StatusCode {
+		case http.StatusNotFound:
+			return kes.ErrKeyNotFound
+		case http.StatusForbidden:
+			return kes.ErrNotAllowed
+		}
+		return vErr
+	}
+	if out == nil {
+		io.Copy(ioutil.Discard, resp.Body)
+		return nil
+	}
+	var response struct {
+		Data json.RawMessage `json:"data"`
+	}
+	if err = json.NewDecoder(resp.Body).Decode(&response); err != nil {
+		return err
+	}
+	return json.Unmarshal(response.Data, out)
+}
+
+// Stat returns the current Vault status containing the
+// Vault endpoint and the default key ID.
+func (c *vaultClient) Stat() (Status, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), kmsRequestTimeout)
+	defer cancel()
+	// Sealed and uninitialized servers respond with an error status.
+	if err := c.do(ctx, http.MethodGet, "sys/health?standbyok=true", nil, nil); err != nil {
+		return Status{}, err
+	}
+	return Status{
+		Name:       "Vault",
+		Endpoints:  []string{c.endpoint},
+		DefaultKey: c.defaultKeyID,
+	}, nil
+}
+
+// CreateKey creates a new transit key with the given key ID.
+//
+// If the a key with the same keyID already exists then
+// CreateKey returns kes.ErrKeyExists.
+func (c *vaultClient) CreateKey(keyID string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), kmsRequestTimeout)
+	defer cancel()
+	keyPath := path.Join(c.mount, "keys", url.PathEscape(keyID))
+	switch err := c.do(ctx, http.MethodGet, keyPath, nil, nil); {
+	case err == nil:
+		return kes.ErrKeyExists
+	case !errors.Is(err, kes.ErrKeyNotFound):
+		return err
+	}
+	return c.do(ctx, http.MethodPost, keyPath, map[string]string{"type": "aes256-gcm96"}, nil)
+}
+
+// GenerateKey generates a new data encryption key and encrypts
+// it with the transit key referenced by the key ID.
+//
+// The default key ID will be used if keyID is empty.
+//
+// The context is encrypted along with the data encryption key,
+// the same context must be provided when the generated key
+// should be decrypted.
+func (c *vaultClient) GenerateKey(keyID string, ctx Context) (DEK, error) {
+	if keyID == "" {
+		keyID = c.defaultKeyID
+	}
+	ctxBytes, err := ctx.MarshalText()
+	if err != nil {
+		return DEK{}, err
+	}
+	plaintext := make([]byte, 32)
+	if _, err = io.ReadFull(rand.Reader, plaintext); err != nil {
+		return DEK{}, err
+	}
+
+	reqCtx, cancel := context.WithTimeout(context.Background(), kmsRequestTimeout)
+	defer cancel()
+	var response struct {
+		Ciphertext string `json:"ciphertext"`
+	}
+	err = c.do(reqCtx, http.MethodPost, path.Join(c.mount, "encrypt", url.PathEscape(keyID)), map[string]string{
+		"plaintext": base64.StdEncoding.EncodeToString(append(append([]byte{}, plaintext...), ctxBytes...)),
+	}, &response)
+	if err != nil {
+		return DEK{}, err
+	}
+	return DEK{
+		KeyID:      keyID,
+		Plaintext:  plaintext,
+		Ciphertext: []byte(response.Ciphertext),
+	}, nil
+}
+
+// DecryptKey decrypts the ciphertext with the transit key
+// referenced by the key ID. The context must match the
+// context value used to generate the ciphertext.
+func (c *vaultClient) DecryptKey(keyID string, ciphertext []byte, ctx Context) ([]byte, error) {
+	ctxBytes, err := ctx.MarshalText()
+	if err != nil {
+		return nil, err
+	}
+
+	reqCtx, cancel := context.WithTimeout(context.Background(), kmsRequestTimeout)
+	defer cancel()
+	var response struct {
+		Plaintext string `json:"plaintext"`
+	}
+	err = c.do(reqCtx, http.MethodPost, path.Join(c.mount, "decrypt", url.PathEscape(keyID)), map[string]string{
+		"ciphertext": string(ciphertext),
+	}, &response)
+	if err != nil {
+		return nil, err
+	}
+	plaintext, err := base64.StdEncoding.DecodeString(response.Plaintext)
+	if err != nil {
+		return nil, err
+	}
+	if len(plaintext) < 32 || !bytes.Equal(plaintext[32:], ctxBytes) {
+		return nil, errors.New("kms: invalid ciphertext or context")
+	}
+	return plaintext[:32], nil
+}
+
+func (c *vaultClient) DecryptAll(_ context.Context, keyID string, ciphertexts [][]byte, contexts []Context) ([][]byte, error) {
+	plaintexts := make([][]byte, 0, len(ciphertexts))
+	for i := range ciphertexts {
+		plaintext, err := c.DecryptKey(keyID, ciphertexts[i], contexts[i])
+		if err != nil {
+			return nil, err
+		}
+		plaintexts = append(plaintexts, plaintext)
+	}
+	return plaintexts, nil
+}
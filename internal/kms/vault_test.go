@@ -0,0 +1,104 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package kms
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/minio/kes"
+)
+
+func TestVault(t *testing.T) {
+	// A transit engine "encrypting" with base64.
+	keys := map[string]bool{"my-key": true}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-Vault-Token") != "token" || r.Header.Get("X-Vault-Namespace") != "ns" {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		var req map[string]string
+		json.NewDecoder(r.Body).Decode(&req)
+		parts := strings.Split(strings.TrimPrefix(r.URL.Path, "/v1/kv-transit/"), "/")
+		if len(parts) != 2 {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		op, key := parts[0], parts[1]
+		if !keys[key] && !(op == "keys" && r.Method == http.MethodPost) {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(`{"errors":["encryption key not found"]}`))
+			return
+		}
+		switch op {
+		case "keys":
+			keys[key] = true
+			w.WriteHeader(http.StatusNoContent)
+		case "encrypt":
+			json.NewEncoder(w).Encode(map[string]interface{}{"data": map[string]string{"ciphertext": "vault:v1:" + req["plaintext"]}})
+		case "decrypt":
+			json.NewEncoder(w).Encode(map[string]interface{}{"data": map[string]string{"plaintext": strings.TrimPrefix(req["ciphertext"], "vault:v1:")}})
+		}
+	}))
+	defer server.Close()
+
+	KMS, err := NewVault(VaultConfig{
+		Endpoint:     server.URL,
+		Mount:        "kv-transit",
+		Namespace:    "ns",
+		Token:        "token",
+		DefaultKeyID: "my-key",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = KMS.CreateKey("my-key"); !errors.Is(err, kes.ErrKeyExists) {
+		t.Errorf("expected the existing key not to be created, got %v", err)
+	}
+	if err = KMS.CreateKey("new-key"); err != nil || !keys["new-key"] {
+		t.Errorf("unable to create a key: %v", err)
+	}
+
+	ctx := Context{"bucket": "object"}
+	dek, err := KMS.GenerateKey("", ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if dek.KeyID != "my-key" || len(dek.Plaintext) != 32 || !bytes.HasPrefix(dek.Ciphertext, []byte("vault:v1:")) {
+		t.Fatalf("unexpected DEK %+v", dek)
+	}
+	if sealed, _ := base64.StdEncoding.DecodeString(strings.TrimPrefix(string(dek.Ciphertext), "vault:v1:")); !bytes.HasSuffix(sealed, []byte(`{"bucket":"object"}`)) {
+		t.Errorf("expected the context to be encrypted with the DEK")
+	}
+	plaintext, err := KMS.DecryptKey("my-key", dek.Ciphertext, ctx)
+	if err != nil || !bytes.Equal(plaintext, dek.Plaintext) {
+		t.Errorf("unable to decrypt the DEK: %v", err)
+	}
+	if _, err = KMS.DecryptKey("my-key", dek.Ciphertext, Context{"bucket": "other"}); err == nil {
+		t.Error("expected the DEK not to be decrypted with another context")
+	}
+	if _, err = KMS.GenerateKey("unknown", ctx); !errors.Is(err, kes.ErrKeyNotFound) {
+		t.Errorf("expected an unknown key not to be found, got %v", err)
+	}
+}
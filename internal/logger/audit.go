@@ -24,9 +24,11 @@ import (
 	"io"
 	"net/http"
 	"strconv"
+	"sync"
 	"time"
 
 	"github.com/klauspost/compress/gzhttp"
+	"github.com/minio/minio/internal/config/auditfilter"
 	xhttp "github.com/minio/minio/internal/http"
 	"github.com/minio/minio/internal/logger/message/audit"
 )
@@ -82,6 +84,25 @@ func (lrw *ResponseWriter) Write(p []byte) (int, error) {
 	return n, err
 }
 
+// ReadFrom implements io.ReaderFrom, it passes the reader to the
+// underlying writer unless the response body has to be recorded.
+func (lrw *ResponseWriter) ReadFrom(r io.Reader) (int64, error) {
+	if !lrw.headersLogged {
+		lrw.WriteHeader(http.StatusOK)
+	}
+	rf, ok := lrw.ResponseWriter.(io.ReaderFrom)
+	if !ok || (lrw.LogErrBody && lrw.StatusCode >= http.StatusBadRequest) || lrw.LogAllBody {
+		// Hide ReadFrom to avoid calling ourselves again.
+		return io.Copy(struct{ io.Writer }{lrw}, r)
+	}
+	n, err := rf.ReadFrom(r)
+	lrw.bytesWritten += int(n)
+	if lrw.TimeToFirstByte == 0 {
+		lrw.TimeToFirstByte = time.Now().UTC().Sub(lrw.StartTime)
+	}
+	return n, err
+}
+
 // Write the headers into the given buffer
 func (lrw *ResponseWriter) writeHeaders(w io.Writer, statusCode int, headers http.Header) {
 	n, _ := fmt.Fprintf(w, "%d %s\n", statusCode, http.StatusText(statusCode))
@@ -126,6 +147,11 @@ func (lrw *ResponseWriter) Size() int {
 	return lrw.bytesWritten
 }
 
+// BodySize - returns the number of bytes written, without the headers
+func (lrw *ResponseWriter) BodySize() int {
+	return lrw.bytesWritten - lrw.headers.Len()
+}
+
 const contextAuditKey = contextKeyType("audit-entry")
 
 // SetAuditEntry sets Audit info in the context.
@@ -155,6 +181,25 @@ func GetAuditEntry(ctx context.Context) *audit.Entry {
 	return nil
 }
 
+var (
+	auditFilterMu sync.RWMutex
+	auditFilter   auditfilter.Config
+)
+
+// SetAuditFilter sets the redaction and the sampling
+// applied to the audit logs.
+func SetAuditFilter(cfg auditfilter.Config) {
+	auditFilterMu.Lock()
+	defer auditFilterMu.Unlock()
+	auditFilter = cfg
+}
+
+func getAuditFilter() auditfilter.Config {
+	auditFilterMu.RLock()
+	defer auditFilterMu.RUnlock()
+	return auditFilter
+}
+
 // AuditLog - logs audit logs to all audit targets.
 func AuditLog(ctx context.Context, w http.ResponseWriter, r *http.Request, reqClaims map[string]interface{}, filterKeys ...string) {
 	auditTgts := AuditTargets()
@@ -231,6 +276,10 @@ func AuditLog(ctx context.Context, w http.ResponseWriter, r *http.Request, reqCl
 		}
 	}
 
+	if filter := getAuditFilter(); filter.Enabled() && !filter.Filter(&entry) {
+		return
+	}
+
 	// Send audit logs only to http targets.
 	for _, t := range auditTgts {
 		if err := t.Send(entry, string(All)); err != nil {
@@ -20,8 +20,10 @@ package logger
 import (
 	"crypto/tls"
 	"errors"
+	"fmt"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/minio/pkg/env"
 	xnet "github.com/minio/pkg/net"
@@ -44,6 +46,11 @@ const (
 	ClientKey  = "client_key"
 	QueueSize  = "queue_size"
 
+	BatchSize    = "batch_size"
+	BatchTimeout = "batch_timeout"
+	Compression  = "compression"
+	Workers      = "workers"
+
 	KafkaBrokers       = "brokers"
 	KafkaTopic         = "topic"
 	KafkaTLS           = "tls"
@@ -56,6 +63,9 @@ const (
 	KafkaClientTLSCert = "client_tls_cert"
 	KafkaClientTLSKey  = "client_tls_key"
 	KafkaVersion       = "version"
+	KafkaBatchSize     = "batch_size"
+	KafkaBatchTimeout  = "batch_timeout"
+	KafkaCompression   = "compression"
 
 	EnvLoggerWebhookEnable     = "MINIO_LOGGER_WEBHOOK_ENABLE"
 	EnvLoggerWebhookEndpoint   = "MINIO_LOGGER_WEBHOOK_ENDPOINT"
@@ -71,6 +81,11 @@ const (
 	EnvAuditWebhookClientKey  = "MINIO_AUDIT_WEBHOOK_CLIENT_KEY"
 	EnvAuditWebhookQueueSize  = "MINIO_AUDIT_WEBHOOK_QUEUE_SIZE"
 
+	EnvAuditWebhookBatchSize    = "MINIO_AUDIT_WEBHOOK_BATCH_SIZE"
+	EnvAuditWebhookBatchTimeout = "MINIO_AUDIT_WEBHOOK_BATCH_TIMEOUT"
+	EnvAuditWebhookCompression  = "MINIO_AUDIT_WEBHOOK_COMPRESSION"
+	EnvAuditWebhookWorkers      = "MINIO_AUDIT_WEBHOOK_WORKERS"
+
 	EnvKafkaEnable        = "MINIO_AUDIT_KAFKA_ENABLE"
 	EnvKafkaBrokers       = "MINIO_AUDIT_KAFKA_BROKERS"
 	EnvKafkaTopic         = "MINIO_AUDIT_KAFKA_TOPIC"
@@ -84,6 +99,9 @@ const (
 	EnvKafkaClientTLSCert = "MINIO_AUDIT_KAFKA_CLIENT_TLS_CERT"
 	EnvKafkaClientTLSKey  = "MINIO_AUDIT_KAFKA_CLIENT_TLS_KEY"
 	EnvKafkaVersion       = "MINIO_AUDIT_KAFKA_VERSION"
+	EnvKafkaBatchSize     = "MINIO_AUDIT_KAFKA_BATCH_SIZE"
+	EnvKafkaBatchTimeout  = "MINIO_AUDIT_KAFKA_BATCH_TIMEOUT"
+	EnvKafkaCompression   = "MINIO_AUDIT_KAFKA_COMPRESSION"
 )
 
 // Default KVS for loggerHTTP and loggerAuditHTTP
@@ -140,6 +158,22 @@ var (
 			Key:   QueueSize,
 			Value: "100000",
 		},
+		config.KV{
+			Key:   BatchSize,
+			Value: "1",
+		},
+		config.KV{
+			Key:   BatchTimeout,
+			Value: "1s",
+		},
+		config.KV{
+			Key:   Compression,
+			Value: "none",
+		},
+		config.KV{
+			Key:   Workers,
+			Value: "1",
+		},
 	}
 
 	DefaultAuditKafkaKVS = config.KVS{
@@ -195,6 +229,18 @@ var (
 			Key:   KafkaVersion,
 			Value: "",
 		},
+		config.KV{
+			Key:   KafkaBatchSize,
+			Value: "1",
+		},
+		config.KV{
+			Key:   KafkaBatchTimeout,
+			Value: "1s",
+		},
+		config.KV{
+			Key:   KafkaCompression,
+			Value: "none",
+		},
 	}
 )
 
@@ -337,11 +383,47 @@ func GetAuditKafka(kafkaKVS map[string]config.KVS) (map[string]kafka.Config, err
 			versionEnv = versionEnv + config.Default + k
 		}
 
+		batchSizeEnv := EnvKafkaBatchSize
+		if k != config.Default {
+			batchSizeEnv = batchSizeEnv + config.Default + k
+		}
+		batchSize, err := strconv.Atoi(env.Get(batchSizeEnv, kv.Get(KafkaBatchSize)))
+		if err != nil {
+			return nil, err
+		}
+		if batchSize < 1 {
+			return nil, config.Errorf("kafka 'batch_size' must be at least 1")
+		}
+
+		batchTimeoutEnv := EnvKafkaBatchTimeout
+		if k != config.Default {
+			batchTimeoutEnv = batchTimeoutEnv + config.Default + k
+		}
+		batchTimeout, err := time.ParseDuration(env.Get(batchTimeoutEnv, kv.Get(KafkaBatchTimeout)))
+		if err != nil {
+			return nil, err
+		}
+		if batchTimeout <= 0 {
+			return nil, config.Errorf("kafka 'batch_timeout' must be positive")
+		}
+
+		compressionEnv := EnvKafkaCompression
+		if k != config.Default {
+			compressionEnv = compressionEnv + config.Default + k
+		}
+		compression := env.Get(compressionEnv, kv.Get(KafkaCompression))
+		if _, err = kafka.ParseCompression(compression); err != nil {
+			return nil, config.Errorf("kafka 'compression' %v", err)
+		}
+
 		kafkaArgs := kafka.Config{
-			Enabled: enabled,
-			Brokers: brokers,
-			Topic:   env.Get(topicEnv, kv.Get(KafkaTopic)),
-			Version: env.Get(versionEnv, kv.Get(KafkaVersion)),
+			Enabled:      enabled,
+			Brokers:      brokers,
+			Topic:        env.Get(topicEnv, kv.Get(KafkaTopic)),
+			Version:      env.Get(versionEnv, kv.Get(KafkaVersion)),
+			BatchSize:    batchSize,
+			BatchTimeout: batchTimeout,
+			Compression:  compression,
 		}
 
 		tlsEnableEnv := EnvKafkaTLS
@@ -565,14 +647,25 @@ func lookupAuditWebhookConfig(scfg config.Config, cfg Config) (Config, error) {
 		if queueSize <= 0 {
 			return cfg, errors.New("invalid queue_size value")
 		}
-		cfg.AuditWebhook[target] = http.Config{
+		webhookCfg := http.Config{
 			Enabled:    true,
+			Name:       target,
 			Endpoint:   env.Get(endpointEnv, ""),
 			AuthToken:  env.Get(authTokenEnv, ""),
 			ClientCert: env.Get(clientCertEnv, ""),
 			ClientKey:  env.Get(clientKeyEnv, ""),
 			QueueSize:  queueSize,
 		}
+		err = lookupAuditWebhookDelivery(&webhookCfg, func(key, envName string) string {
+			if target != config.Default {
+				envName = envName + config.Default + target
+			}
+			return env.Get(envName, DefaultAuditWebhookKVS.Get(key))
+		})
+		if err != nil {
+			return cfg, err
+		}
+		cfg.AuditWebhook[target] = webhookCfg
 	}
 
 	for starget, kv := range scfg[config.AuditWebhookSubSys] {
@@ -608,19 +701,62 @@ func lookupAuditWebhookConfig(scfg config.Config, cfg Config) (Config, error) {
 			return cfg, errors.New("invalid queue_size value")
 		}
 
-		cfg.AuditWebhook[starget] = http.Config{
+		webhookCfg := http.Config{
 			Enabled:    true,
+			Name:       starget,
 			Endpoint:   kv.Get(Endpoint),
 			AuthToken:  kv.Get(AuthToken),
 			ClientCert: kv.Get(ClientCert),
 			ClientKey:  kv.Get(ClientKey),
 			QueueSize:  queueSize,
 		}
+		err = lookupAuditWebhookDelivery(&webhookCfg, func(key, _ string) string {
+			return kv.GetWithDefault(key, DefaultAuditWebhookKVS)
+		})
+		if err != nil {
+			return cfg, err
+		}
+		cfg.AuditWebhook[starget] = webhookCfg
 	}
 
 	return cfg, nil
 }
 
+// lookupAuditWebhookDelivery sets the batching, compression and
+// concurrency of the audit webhook, get returns the value of the
+// config key or of the environment variable.
+func lookupAuditWebhookDelivery(cfg *http.Config, get func(key, envName string) string) (err error) {
+	cfg.BatchSize, err = strconv.Atoi(get(BatchSize, EnvAuditWebhookBatchSize))
+	if err != nil {
+		return err
+	}
+	if cfg.BatchSize < 1 {
+		return errors.New("invalid batch_size value")
+	}
+	cfg.BatchTimeout, err = time.ParseDuration(get(BatchTimeout, EnvAuditWebhookBatchTimeout))
+	if err != nil {
+		return err
+	}
+	if cfg.BatchTimeout <= 0 {
+		return errors.New("invalid batch_timeout value")
+	}
+	switch compression := get(Compression, EnvAuditWebhookCompression); compression {
+	case "", "none":
+	case "gzip":
+		cfg.Compress = true
+	default:
+		return fmt.Errorf("invalid compression value '%s', expected 'none' or 'gzip'", compression)
+	}
+	cfg.Workers, err = strconv.Atoi(get(Workers, EnvAuditWebhookWorkers))
+	if err != nil {
+		return err
+	}
+	if cfg.Workers < 1 {
+		return errors.New("invalid workers value")
+	}
+	return nil
+}
+
 // LookupConfigForSubSys - lookup logger config, override with ENVs if set, for the given sub-system
 func LookupConfigForSubSys(scfg config.Config, subSys string) (cfg Config, err error) {
 	switch subSys {
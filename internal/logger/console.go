@@ -18,7 +18,6 @@
 package logger
 
 import (
-	"encoding/json"
 	"fmt"
 	"os"
 	"strings"
@@ -34,17 +33,17 @@ const ConsoleLoggerTgt = "console+http"
 
 // Logger interface describes the methods that need to be implemented to satisfy the interface requirements.
 type Logger interface {
-	json(msg string, args ...interface{})
+	structured(msg string, args ...interface{})
 	quiet(msg string, args ...interface{})
 	pretty(msg string, args ...interface{})
 }
 
 func consoleLog(console Logger, msg string, args ...interface{}) {
 	switch {
-	case jsonFlag:
-		// Strip escape control characters from json message
+	case IsStructured():
+		// Strip escape control characters from structured message
 		msg = ansiRE.ReplaceAllLiteralString(msg, "")
-		console.json(msg, args...)
+		console.structured(msg, args...)
 	case quietFlag:
 		console.quiet(msg+"\n", args...)
 	default:
@@ -61,7 +60,7 @@ func Fatal(err error, msg string, data ...interface{}) {
 func fatal(err error, msg string, data ...interface{}) {
 	var errMsg string
 	if msg != "" {
-		errMsg = errorFmtFunc(fmt.Sprintf(msg, data...), err, jsonFlag)
+		errMsg = errorFmtFunc(fmt.Sprintf(msg, data...), err, IsStructured())
 	} else {
 		errMsg = err.Error()
 	}
@@ -72,14 +71,14 @@ var fatalMessage fatalMsg
 
 type fatalMsg struct{}
 
-func (f fatalMsg) json(msg string, args ...interface{}) {
+func (f fatalMsg) structured(msg string, args ...interface{}) {
 	var message string
 	if msg != "" {
 		message = fmt.Sprintf(msg, args...)
 	} else {
 		message = fmt.Sprint(args...)
 	}
-	logJSON, err := json.Marshal(&log.Entry{
+	logLine, err := FormatEntry(log.Entry{
 		Level:   FatalLvl.String(),
 		Message: message,
 		Time:    time.Now().UTC(),
@@ -88,7 +87,7 @@ func (f fatalMsg) json(msg string, args ...interface{}) {
 	if err != nil {
 		panic(err)
 	}
-	fmt.Println(string(logJSON))
+	fmt.Println(logLine)
 
 	os.Exit(1)
 }
@@ -150,14 +149,14 @@ type infoMsg struct{}
 
 var info infoMsg
 
-func (i infoMsg) json(msg string, args ...interface{}) {
+func (i infoMsg) structured(msg string, args ...interface{}) {
 	var message string
 	if msg != "" {
 		message = fmt.Sprintf(msg, args...)
 	} else {
 		message = fmt.Sprint(args...)
 	}
-	logJSON, err := json.Marshal(&log.Entry{
+	logLine, err := FormatEntry(log.Entry{
 		Level:   InformationLvl.String(),
 		Message: message,
 		Time:    time.Now().UTC(),
@@ -165,7 +164,7 @@ func (i infoMsg) json(msg string, args ...interface{}) {
 	if err != nil {
 		panic(err)
 	}
-	fmt.Println(string(logJSON))
+	fmt.Println(logLine)
 }
 
 func (i infoMsg) quiet(msg string, args ...interface{}) {
@@ -182,14 +181,14 @@ type errorMsg struct{}
 
 var errorm errorMsg
 
-func (i errorMsg) json(msg string, args ...interface{}) {
+func (i errorMsg) structured(msg string, args ...interface{}) {
 	var message string
 	if msg != "" {
 		message = fmt.Sprintf(msg, args...)
 	} else {
 		message = fmt.Sprint(args...)
 	}
-	logJSON, err := json.Marshal(&log.Entry{
+	logLine, err := FormatEntry(log.Entry{
 		Level:   ErrorLvl.String(),
 		Message: message,
 		Time:    time.Now().UTC(),
@@ -198,7 +197,7 @@ func (i errorMsg) json(msg string, args ...interface{}) {
 	if err != nil {
 		panic(err)
 	}
-	fmt.Println(string(logJSON))
+	fmt.Println(logLine)
 }
 
 func (i errorMsg) quiet(msg string, args ...interface{}) {
@@ -0,0 +1,155 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package logger
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/minio/minio/internal/logger/message/log"
+)
+
+// FormatEntry - formats the log entry as a single line
+// in the structured output format of the logs.
+func FormatEntry(entry log.Entry) (string, error) {
+	switch logFormat {
+	case FormatLogfmt:
+		return formatLogfmt(entry), nil
+	case FormatCompact:
+		return formatCompact(entry), nil
+	default:
+		logJSON, err := json.Marshal(&entry)
+		if err != nil {
+			return "", err
+		}
+		return string(logJSON), nil
+	}
+}
+
+// entryError returns the error message of the entry
+// if it differs from the entry message.
+func entryError(entry log.Entry) string {
+	if entry.Trace == nil || entry.Trace.Message == entry.Message {
+		return ""
+	}
+	return entry.Trace.Message
+}
+
+// formatLogfmt formats the entry as logfmt key=value pairs,
+// the pairs with empty values are omitted.
+func formatLogfmt(entry log.Entry) string {
+	var sb strings.Builder
+	add := func(key, value string) {
+		if value == "" {
+			return
+		}
+		if sb.Len() > 0 {
+			sb.WriteByte(' ')
+		}
+		sb.WriteString(key)
+		sb.WriteByte('=')
+		sb.WriteString(logfmtValue(value))
+	}
+
+	add("time", entry.Time.UTC().Format(time.RFC3339Nano))
+	add("level", entry.Level)
+	add("kind", entry.LogKind)
+	add("deploymentid", entry.DeploymentID)
+	if entry.API != nil {
+		add("api", entry.API.Name)
+		if entry.API.Args != nil {
+			add("bucket", entry.API.Args.Bucket)
+			add("object", entry.API.Args.Object)
+			add("versionId", entry.API.Args.VersionID)
+			if len(entry.API.Args.Objects) > 0 {
+				add("objects", strconv.Itoa(len(entry.API.Args.Objects)))
+			}
+		}
+	}
+	add("requestID", entry.RequestID)
+	add("remotehost", entry.RemoteHost)
+	add("host", entry.Host)
+	add("userAgent", entry.UserAgent)
+	add("msg", entry.Message)
+	add("error", entryError(entry))
+	if entry.Trace != nil {
+		add("source", strings.Join(entry.Trace.Source, ","))
+		keys := make([]string, 0, len(entry.Trace.Variables))
+		for key := range entry.Trace.Variables {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			add("tag."+key, fmt.Sprint(entry.Trace.Variables[key]))
+		}
+	}
+	return sb.String()
+}
+
+// logfmtValue quotes the value if it contains spaces,
+// quotes, equal signs or control characters.
+func logfmtValue(value string) string {
+	for _, r := range value {
+		if r <= ' ' || r == '"' || r == '=' || r == 0x7f {
+			return strconv.Quote(value)
+		}
+	}
+	return value
+}
+
+// formatCompact formats the entry as space separated fields in
+// a fixed order: time, level, kind, API, request ID, remote host,
+// quoted message and source. Empty fields are written as '-'.
+func formatCompact(entry log.Entry) string {
+	field := func(value string) string {
+		if value == "" {
+			return "-"
+		}
+		return strings.ReplaceAll(value, " ", "_")
+	}
+
+	api := ""
+	if entry.API != nil {
+		api = entry.API.Name
+	}
+	msg := entry.Message
+	if errMsg := entryError(entry); errMsg != "" {
+		if msg != "" {
+			msg += ": "
+		}
+		msg += errMsg
+	}
+	source := ""
+	if entry.Trace != nil && len(entry.Trace.Source) > 0 {
+		source = entry.Trace.Source[0]
+	}
+	return strings.Join([]string{
+		entry.Time.UTC().Format(time.RFC3339Nano),
+		field(entry.Level),
+		field(entry.LogKind),
+		field(api),
+		field(entry.RequestID),
+		field(entry.RemoteHost),
+		strconv.Quote(msg),
+		field(source),
+	}, " ")
+}
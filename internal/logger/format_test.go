@@ -0,0 +1,73 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package logger
+
+import (
+	"testing"
+	"time"
+
+	"github.com/minio/minio/internal/logger/message/log"
+)
+
+func TestFormatEntry(t *testing.T) {
+	defer func(format string, quiet bool) { logFormat, quietFlag = format, quiet }(logFormat, quietFlag)
+
+	entry := log.Entry{
+		Level:     ErrorLvl.String(),
+		LogKind:   string(Minio),
+		Time:      time.Date(2022, 6, 1, 10, 0, 0, 0, time.UTC),
+		API:       &log.API{Name: "PutObject", Args: &log.Args{Bucket: "bucket", Object: "dir/object"}},
+		RequestID: "16F5D4C8A9B3E2A1",
+		Message:   "",
+		Trace: &log.Trace{
+			Message:   `drive "/mnt/disk1" is offline`,
+			Source:    []string{"[cmd/xl-storage.go:120:xlStorage.ReadFile()]"},
+			Variables: map[string]interface{}{"drive": "/mnt/disk1"},
+		},
+	}
+
+	testCases := []struct {
+		format   string
+		expected string
+	}{
+		{
+			format:   FormatLogfmt,
+			expected: `time=2022-06-01T10:00:00Z level=ERROR kind=MINIO api=PutObject bucket=bucket object=dir/object requestID=16F5D4C8A9B3E2A1 error="drive \"/mnt/disk1\" is offline" source=[cmd/xl-storage.go:120:xlStorage.ReadFile()] tag.drive=/mnt/disk1`,
+		},
+		{
+			format:   FormatCompact,
+			expected: `2022-06-01T10:00:00Z ERROR MINIO PutObject 16F5D4C8A9B3E2A1 - "drive \"/mnt/disk1\" is offline" [cmd/xl-storage.go:120:xlStorage.ReadFile()]`,
+		},
+	}
+	for _, testCase := range testCases {
+		if err := SetFormat(testCase.format); err != nil {
+			t.Fatal(err)
+		}
+		line, err := FormatEntry(entry)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if line != testCase.expected {
+			t.Errorf("%s: expected\n%s\ngot\n%s", testCase.format, testCase.expected, line)
+		}
+	}
+
+	if err := SetFormat("yaml"); err == nil {
+		t.Error("expected an error for an unknown format")
+	}
+}
@@ -113,6 +113,30 @@ var (
 			Optional:    true,
 			Type:        "number",
 		},
+		config.HelpKV{
+			Key:         BatchSize,
+			Description: "number of audit logs sent in a single request, default '1'",
+			Optional:    true,
+			Type:        "number",
+		},
+		config.HelpKV{
+			Key:         BatchTimeout,
+			Description: "maximum delay before sending an incomplete batch, default '1s'",
+			Optional:    true,
+			Type:        "duration",
+		},
+		config.HelpKV{
+			Key:         Compression,
+			Description: "compression of the requests 'none' or 'gzip', default 'none'",
+			Optional:    true,
+			Type:        "string",
+		},
+		config.HelpKV{
+			Key:         Workers,
+			Description: "number of requests sent concurrently, default '1'",
+			Optional:    true,
+			Type:        "number",
+		},
 		config.HelpKV{
 			Key:         config.Comment,
 			Description: config.DefaultComment,
@@ -136,7 +160,7 @@ var (
 		},
 		config.HelpKV{
 			Key:         KafkaTopic,
-			Description: "Kafka topic used for bucket notifications",
+			Description: "Kafka topic used for audit logs",
 			Optional:    true,
 			Type:        "string",
 		},
@@ -204,6 +228,24 @@ var (
 			Optional:    true,
 			Type:        "string",
 		},
+		config.HelpKV{
+			Key:         KafkaBatchSize,
+			Description: "number of audit logs sent in a single request, default '1'",
+			Optional:    true,
+			Type:        "number",
+		},
+		config.HelpKV{
+			Key:         KafkaBatchTimeout,
+			Description: "maximum delay before sending an incomplete batch, default '1s'",
+			Optional:    true,
+			Type:        "duration",
+		},
+		config.HelpKV{
+			Key:         KafkaCompression,
+			Description: "compression codec of the messages 'none', 'gzip', 'snappy', 'lz4' or 'zstd', default 'none'",
+			Optional:    true,
+			Type:        "string",
+		},
 		config.HelpKV{
 			Key:         config.Comment,
 			Description: config.DefaultComment,
@@ -77,10 +77,19 @@ func (level Level) String() string {
 	return lvlStr
 }
 
+// Output formats of the logs.
+const (
+	FormatText    = "text"
+	FormatJSON    = "json"
+	FormatLogfmt  = "logfmt"
+	FormatCompact = "compact"
+)
+
 // quietFlag: Hide startup messages if enabled
-// jsonFlag: Display in JSON format, if enabled
+// logFormat: Display in this output format
 var (
-	quietFlag, jsonFlag, anonFlag bool
+	quietFlag, anonFlag bool
+	logFormat           = FormatText
 	// Custom function to format error
 	errorFmtFunc func(string, error, bool) string
 )
@@ -92,19 +101,40 @@ func EnableQuiet() {
 
 // EnableJSON - outputs logs in json format.
 func EnableJSON() {
-	jsonFlag = true
+	logFormat = FormatJSON
 	quietFlag = true
 }
 
+// SetFormat - outputs logs in the format, the structured
+// formats turn the quiet option on like the json format.
+func SetFormat(format string) error {
+	switch format {
+	case FormatText:
+	case FormatJSON, FormatLogfmt, FormatCompact:
+		quietFlag = true
+	default:
+		return fmt.Errorf("unknown log format '%s', expected one of %s, %s, %s or %s",
+			format, FormatText, FormatJSON, FormatLogfmt, FormatCompact)
+	}
+	logFormat = format
+	return nil
+}
+
 // EnableAnonymous - turns anonymous flag
 // to avoid printing sensitive information.
 func EnableAnonymous() {
 	anonFlag = true
 }
 
-// IsJSON - returns true if jsonFlag is true
+// IsJSON - returns true if the logs are output in json format
 func IsJSON() bool {
-	return jsonFlag
+	return logFormat == FormatJSON
+}
+
+// IsStructured - returns true if the logs are output in a
+// structured format, one line per log entry
+func IsStructured() bool {
+	return logFormat != FormatText
 }
 
 // IsQuiet - returns true if quietFlag is true
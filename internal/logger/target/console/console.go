@@ -18,7 +18,6 @@
 package console
 
 import (
-	"encoding/json"
 	"fmt"
 	"strconv"
 	"strings"
@@ -53,12 +52,12 @@ func (c *Target) Send(e interface{}, logKind string) error {
 	if !ok {
 		return fmt.Errorf("Uexpected log entry structure %#v", e)
 	}
-	if logger.IsJSON() {
-		logJSON, err := json.Marshal(&entry)
+	if logger.IsStructured() {
+		logLine, err := logger.FormatEntry(entry)
 		if err != nil {
 			return err
 		}
-		fmt.Println(string(logJSON))
+		fmt.Println(logLine)
 		return nil
 	}
 
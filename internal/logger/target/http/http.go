@@ -19,13 +19,16 @@ package http
 
 import (
 	"bytes"
+	"compress/gzip"
 	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
 	"net/http"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	xhttp "github.com/minio/minio/internal/http"
@@ -47,6 +50,17 @@ type Config struct {
 	QueueSize  int               `json:"queueSize"`
 	Transport  http.RoundTripper `json:"-"`
 
+	// Number of log entries sent in a single request, sent
+	// after at most BatchTimeout when the batch is not full.
+	BatchSize    int           `json:"batchSize"`
+	BatchTimeout time.Duration `json:"batchTimeout"`
+
+	// Compress the requests with gzip.
+	Compress bool `json:"compress"`
+
+	// Number of requests sent concurrently.
+	Workers int `json:"workers"`
+
 	// Custom logger
 	LogOnce func(ctx context.Context, err error, id interface{}, errKind ...interface{}) `json:"-"`
 }
@@ -57,6 +71,12 @@ type Config struct {
 // buffer is full, new logs are just ignored and an error
 // is returned to the caller.
 type Target struct {
+	// Number of log entries sent, failed and dropped,
+	// must be accessed atomically and kept 64-bit aligned.
+	totalMessages   int64
+	failedMessages  int64
+	droppedMessages int64
+
 	wg     sync.WaitGroup
 	doneCh chan struct{}
 
@@ -114,7 +134,7 @@ func (h *Target) Init() error {
 			h.config.Endpoint, resp.Status)
 	}
 
-	go h.startHTTPLogger()
+	h.startHTTPLogger()
 	return nil
 }
 
@@ -125,21 +145,52 @@ func acceptedResponseStatusCode(code int) bool {
 	return acceptedStatusCodeMap[code]
 }
 
-func (h *Target) logEntry(entry interface{}) {
-	logJSON, err := json.Marshal(&entry)
-	if err != nil {
+// logEntries sends the log entries in a single request, the
+// JSON entries of a batch are separated by newlines.
+func (h *Target) logEntries(entries []interface{}) {
+	var buf bytes.Buffer
+	var w io.Writer = &buf
+	var zw *gzip.Writer
+	if h.config.Compress {
+		zw = gzip.NewWriter(&buf)
+		w = zw
+	}
+	enc := json.NewEncoder(w)
+	for _, entry := range entries {
+		if err := enc.Encode(&entry); err != nil {
+			atomic.AddInt64(&h.failedMessages, int64(len(entries)))
+			return
+		}
+	}
+	if zw != nil {
+		zw.Close()
+	}
+
+	if err := h.send(buf.Bytes(), len(entries) > 1); err != nil {
+		atomic.AddInt64(&h.failedMessages, int64(len(entries)))
+		h.config.LogOnce(context.Background(), err, h.config.Endpoint)
 		return
 	}
+	atomic.AddInt64(&h.totalMessages, int64(len(entries)))
+}
 
+func (h *Target) send(body []byte, batch bool) error {
 	ctx, cancel := context.WithTimeout(context.Background(), webhookCallTimeout)
+	defer cancel()
+
 	req, err := http.NewRequestWithContext(ctx, http.MethodPost,
-		h.config.Endpoint, bytes.NewReader(logJSON))
+		h.config.Endpoint, bytes.NewReader(body))
 	if err != nil {
-		h.config.LogOnce(ctx, fmt.Errorf("%s returned '%w', please check your endpoint configuration", h.config.Endpoint, err), h.config.Endpoint)
-		cancel()
-		return
+		return fmt.Errorf("%s returned '%w', please check your endpoint configuration", h.config.Endpoint, err)
+	}
+	if batch {
+		req.Header.Set(xhttp.ContentType, "application/x-ndjson")
+	} else {
+		req.Header.Set(xhttp.ContentType, "application/json")
+	}
+	if h.config.Compress {
+		req.Header.Set(xhttp.ContentEncoding, "gzip")
 	}
-	req.Header.Set(xhttp.ContentType, "application/json")
 	req.Header.Set(xhttp.MinIOVersion, xhttp.GlobalMinIOVersion)
 	req.Header.Set(xhttp.MinioDeploymentID, xhttp.GlobalDeploymentID)
 
@@ -153,10 +204,8 @@ func (h *Target) logEntry(entry interface{}) {
 
 	client := http.Client{Transport: h.config.Transport}
 	resp, err := client.Do(req)
-	cancel()
 	if err != nil {
-		h.config.LogOnce(ctx, fmt.Errorf("%s returned '%w', please check your endpoint configuration", h.config.Endpoint, err), h.config.Endpoint)
-		return
+		return fmt.Errorf("%s returned '%w', please check your endpoint configuration", h.config.Endpoint, err)
 	}
 
 	// Drain any response.
@@ -165,25 +214,77 @@ func (h *Target) logEntry(entry interface{}) {
 	if !acceptedResponseStatusCode(resp.StatusCode) {
 		switch resp.StatusCode {
 		case http.StatusForbidden:
-			h.config.LogOnce(ctx, fmt.Errorf("%s returned '%s', please check if your auth token is correctly set", h.config.Endpoint, resp.Status), h.config.Endpoint)
+			return fmt.Errorf("%s returned '%s', please check if your auth token is correctly set", h.config.Endpoint, resp.Status)
 		default:
-			h.config.LogOnce(ctx, fmt.Errorf("%s returned '%s', please check your endpoint configuration", h.config.Endpoint, resp.Status), h.config.Endpoint)
+			return fmt.Errorf("%s returned '%s', please check your endpoint configuration", h.config.Endpoint, resp.Status)
 		}
 	}
+	return nil
 }
 
 func (h *Target) startHTTPLogger() {
-	// Create a routine which sends json logs received
+	batchSize := h.config.BatchSize
+	if batchSize < 1 {
+		batchSize = 1
+	}
+	workers := h.config.Workers
+	if workers < 1 {
+		workers = 1
+	}
+
+	// Batches are handed over to the workers without buffering, the
+	// log entries queue up in the internal channel while all the
+	// workers are busy and are dropped once the channel is full.
+	batchCh := make(chan []interface{})
+	for i := 0; i < workers; i++ {
+		h.wg.Add(1)
+		go func() {
+			defer h.wg.Done()
+			for batch := range batchCh {
+				h.logEntries(batch)
+			}
+		}()
+	}
+
+	// Create a routine which batches json logs received
 	// from an internal channel.
 	h.wg.Add(1)
 	go func() {
 		defer h.wg.Done()
+		defer close(batchCh)
+
+		// Partial batches are sent every batch timeout.
+		var flushCh <-chan time.Time
+		if batchSize > 1 && h.config.BatchTimeout > 0 {
+			ticker := time.NewTicker(h.config.BatchTimeout)
+			defer ticker.Stop()
+			flushCh = ticker.C
+		}
 
+		batch := make([]interface{}, 0, batchSize)
 		for {
 			select {
-			case entry := <-h.logCh:
-				h.logEntry(entry)
+			case entry, ok := <-h.logCh:
+				if !ok {
+					if len(batch) > 0 {
+						batchCh <- batch
+					}
+					return
+				}
+				batch = append(batch, entry)
+				if len(batch) >= batchSize {
+					batchCh <- batch
+					batch = make([]interface{}, 0, batchSize)
+				}
+			case <-flushCh:
+				if len(batch) > 0 {
+					batchCh <- batch
+					batch = make([]interface{}, 0, batchSize)
+				}
 			case <-h.doneCh:
+				if len(batch) > 0 {
+					batchCh <- batch
+				}
 				return
 			}
 		}
@@ -216,6 +317,7 @@ func (h *Target) Send(entry interface{}, errKind string) error {
 	default:
 		// log channel is full, do not wait and return
 		// an error immediately to the caller
+		atomic.AddInt64(&h.droppedMessages, 1)
 		return errors.New("log buffer full")
 	}
 
@@ -229,6 +331,16 @@ func (h *Target) Cancel() {
 	h.wg.Wait()
 }
 
+// Stats - returns the delivery statistics of the target
+func (h *Target) Stats() types.TargetStats {
+	return types.TargetStats{
+		QueueLength:     len(h.logCh),
+		TotalMessages:   atomic.LoadInt64(&h.totalMessages),
+		FailedMessages:  atomic.LoadInt64(&h.failedMessages),
+		DroppedMessages: atomic.LoadInt64(&h.droppedMessages),
+	}
+}
+
 // Type - returns type of the target
 func (h *Target) Type() types.TargetType {
 	return types.TargetHTTP
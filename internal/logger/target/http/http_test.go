@@ -0,0 +1,102 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package http
+
+import (
+	"bufio"
+	"compress/gzip"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestTargetBatches(t *testing.T) {
+	var (
+		mu       sync.Mutex
+		probes   int
+		requests int
+		entries  int
+	)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// The request sent by Init is not compressed.
+		if r.Header.Get("Content-Encoding") != "gzip" {
+			mu.Lock()
+			probes++
+			mu.Unlock()
+			return
+		}
+		zr, err := gzip.NewReader(r.Body)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		var n int
+		for scanner := bufio.NewScanner(zr); scanner.Scan(); {
+			n++
+		}
+		mu.Lock()
+		requests++
+		entries += n
+		mu.Unlock()
+	}))
+	defer server.Close()
+
+	target := New(Config{
+		Enabled:      true,
+		Endpoint:     server.URL,
+		QueueSize:    100,
+		BatchSize:    10,
+		BatchTimeout: 50 * time.Millisecond,
+		Compress:     true,
+		Workers:      2,
+		LogOnce: func(ctx context.Context, err error, id interface{}, errKind ...interface{}) {
+			t.Error(err)
+		},
+	})
+	if err := target.Init(); err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < 25; i++ {
+		if err := target.Send(map[string]int{"entry": i}, ""); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// The last incomplete batch is sent after the batch timeout.
+	deadline := time.Now().Add(5 * time.Second)
+	for target.Stats().TotalMessages < 25 && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+	}
+	target.Cancel()
+
+	stats := target.Stats()
+	if stats.TotalMessages != 25 || stats.FailedMessages != 0 || stats.DroppedMessages != 0 {
+		t.Fatalf("unexpected stats %+v", stats)
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	if entries != 25 {
+		t.Errorf("expected 25 entries, got %d", entries)
+	}
+	if probes != 1 || requests < 3 {
+		t.Errorf("expected 1 uncompressed request and at least 3 batches, got %d and %d", probes, requests)
+	}
+}
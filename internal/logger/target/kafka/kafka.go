@@ -23,8 +23,11 @@ import (
 	"crypto/x509"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"net"
+	"strings"
 	"sync"
+	"time"
 
 	"github.com/Shopify/sarama"
 	saramatls "github.com/Shopify/sarama/tools/tls"
@@ -67,44 +70,74 @@ func (h *Target) Send(entry interface{}, errKind string) error {
 	return nil
 }
 
-func (h *Target) logEntry(entry interface{}) {
-	logJSON, err := json.Marshal(&entry)
+// message returns the kafka message of the audit entry, nil
+// for other log entries.
+func (h *Target) message(entry interface{}) *sarama.ProducerMessage {
+	ae, ok := entry.(audit.Entry)
+	if !ok {
+		return nil
+	}
+	logJSON, err := json.Marshal(&ae)
 	if err != nil {
-		return
+		return nil
 	}
+	return &sarama.ProducerMessage{
+		Topic: h.kconfig.Topic,
+		Key:   sarama.StringEncoder(ae.RequestID),
+		Value: sarama.ByteEncoder(logJSON),
+	}
+}
 
-	ae, ok := entry.(audit.Entry)
-	if ok {
-		msg := sarama.ProducerMessage{
-			Topic: h.kconfig.Topic,
-			Key:   sarama.StringEncoder(ae.RequestID),
-			Value: sarama.ByteEncoder(logJSON),
-		}
-
-		_, _, err = h.producer.SendMessage(&msg)
-		if err != nil {
-			h.kconfig.LogOnce(context.Background(), err, h.kconfig.Topic)
-			return
-		}
+func (h *Target) logEntries(msgs []*sarama.ProducerMessage) {
+	if len(msgs) == 0 {
+		return
+	}
+	if err := h.producer.SendMessages(msgs); err != nil {
+		h.kconfig.LogOnce(context.Background(), err, h.kconfig.Topic)
 	}
 }
 
 func (h *Target) startKakfaLogger() {
-	// Create a routine which sends json logs received
-	// from an internal channel.
-	h.wg.Add(1)
-	go func() {
-		defer h.wg.Done()
-
-		for {
-			select {
-			case entry := <-h.logCh:
-				h.logEntry(entry)
-			case <-h.doneCh:
+	defer h.wg.Done()
+
+	batchSize := h.kconfig.BatchSize
+	if batchSize < 1 {
+		batchSize = 1
+	}
+
+	// Partial batches are sent every batch timeout.
+	var flushCh <-chan time.Time
+	if batchSize > 1 && h.kconfig.BatchTimeout > 0 {
+		ticker := time.NewTicker(h.kconfig.BatchTimeout)
+		defer ticker.Stop()
+		flushCh = ticker.C
+	}
+
+	batch := make([]*sarama.ProducerMessage, 0, batchSize)
+	for {
+		select {
+		case entry, ok := <-h.logCh:
+			if !ok {
+				h.logEntries(batch)
 				return
 			}
+			if msg := h.message(entry); msg != nil {
+				batch = append(batch, msg)
+			}
+			if len(batch) >= batchSize {
+				h.logEntries(batch)
+				batch = make([]*sarama.ProducerMessage, 0, batchSize)
+			}
+		case <-flushCh:
+			if len(batch) > 0 {
+				h.logEntries(batch)
+				batch = make([]*sarama.ProducerMessage, 0, batchSize)
+			}
+		case <-h.doneCh:
+			h.logEntries(batch)
+			return
 		}
-	}()
+	}
 }
 
 // Config - kafka target arguments.
@@ -113,7 +146,17 @@ type Config struct {
 	Brokers []xnet.Host `json:"brokers"`
 	Topic   string      `json:"topic"`
 	Version string      `json:"version"`
-	TLS     struct {
+
+	// Number of audit entries sent in a single request, sent
+	// after at most BatchTimeout when the batch is not full.
+	BatchSize    int           `json:"batchSize"`
+	BatchTimeout time.Duration `json:"batchTimeout"`
+
+	// Compression codec of the messages, one of
+	// none, gzip, snappy, lz4 or zstd.
+	Compression string `json:"compression"`
+
+	TLS struct {
 		Enable        bool               `json:"enable"`
 		RootCAs       *x509.CertPool     `json:"-"`
 		SkipVerify    bool               `json:"skipVerify"`
@@ -147,6 +190,26 @@ func (k Config) pingBrokers() error {
 	return err
 }
 
+// ParseCompression returns the compression codec of name,
+// no compression if name is empty.
+func ParseCompression(name string) (sarama.CompressionCodec, error) {
+	for _, codec := range []sarama.CompressionCodec{
+		sarama.CompressionNone,
+		sarama.CompressionGZIP,
+		sarama.CompressionSnappy,
+		sarama.CompressionLZ4,
+		sarama.CompressionZSTD,
+	} {
+		if strings.EqualFold(name, codec.String()) {
+			return codec, nil
+		}
+	}
+	if name == "" {
+		return sarama.CompressionNone, nil
+	}
+	return sarama.CompressionNone, fmt.Errorf("unknown compression codec '%s'", name)
+}
+
 // Endpoint - return kafka target
 func (h *Target) Endpoint() string {
 	return "kafka"
@@ -199,6 +262,11 @@ func (h *Target) Init() error {
 	sconfig.Net.TLS.Config.ClientAuth = h.kconfig.TLS.ClientAuth
 	sconfig.Net.TLS.Config.RootCAs = h.kconfig.TLS.RootCAs
 
+	sconfig.Producer.Compression, err = ParseCompression(h.kconfig.Compression)
+	if err != nil {
+		return err
+	}
+
 	sconfig.Producer.RequiredAcks = sarama.WaitForAll
 	sconfig.Producer.Retry.Max = 10
 	sconfig.Producer.Return.Successes = true
@@ -216,6 +284,9 @@ func (h *Target) Init() error {
 	}
 
 	h.producer = producer
+	// Create a routine which sends json logs received
+	// from an internal channel.
+	h.wg.Add(1)
 	go h.startKakfaLogger()
 	return nil
 }
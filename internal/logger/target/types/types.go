@@ -27,3 +27,15 @@ const (
 	TargetHTTP
 	TargetKafka
 )
+
+// TargetStats contains the delivery statistics of a target.
+type TargetStats struct {
+	// Number of log entries waiting to be sent.
+	QueueLength int
+	// Number of log entries sent to the target.
+	TotalMessages int64
+	// Number of log entries the target failed to accept.
+	FailedMessages int64
+	// Number of log entries dropped as the queue was full.
+	DroppedMessages int64
+}
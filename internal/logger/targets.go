@@ -70,6 +70,18 @@ func AuditTargets() []Target {
 	return res
 }
 
+// AuditTargetStats returns the delivery statistics of the
+// audit targets reporting them, by target name.
+func AuditTargetStats() map[string]types.TargetStats {
+	stats := make(map[string]types.TargetStats)
+	for _, t := range AuditTargets() {
+		if st, ok := t.(interface{ Stats() types.TargetStats }); ok {
+			stats[t.String()] = st.Stats()
+		}
+	}
+	return stats
+}
+
 // auditTargets is the list of enabled audit loggers
 // Must be immutable at all times.
 // Can be swapped to another while holding swapMu
@@ -31,6 +31,7 @@ import (
 
 	xhttp "github.com/minio/minio/internal/http"
 	"github.com/minio/minio/internal/logger"
+	"github.com/minio/minio/internal/tracing"
 	xnet "github.com/minio/pkg/net"
 )
 
@@ -127,10 +128,16 @@ func (c *Client) Call(ctx context.Context, method string, values url.Values, bod
 	if !c.IsOnline() {
 		return nil, &NetworkError{Err: &url.Error{Op: method, URL: c.url.String(), Err: restError("remote server offline")}}
 	}
+	ctx, span := tracing.Start(ctx, "internode"+method, tracing.KindClient)
+	if span != nil {
+		span.SetAttributes("net.peer.name", c.url.Host)
+		defer func() { span.Finish(err) }()
+	}
 	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.url.String()+method+querySep+values.Encode(), body)
 	if err != nil {
 		return nil, &NetworkError{err}
 	}
+	tracing.Inject(ctx, req.Header)
 	if c.newAuthToken != nil {
 		req.Header.Set("Authorization", "Bearer "+c.newAuthToken(req.URL.RawQuery))
 	}
@@ -0,0 +1,381 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package avro
+
+import (
+	"bufio"
+	"bytes"
+	"compress/flate"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"io"
+	"io/ioutil"
+	"math"
+	"math/big"
+	"time"
+
+	"github.com/bcicen/jstream"
+	"github.com/klauspost/compress/s2"
+	"github.com/klauspost/compress/zstd"
+	jsonfmt "github.com/minio/minio/internal/s3select/json"
+	"github.com/minio/minio/internal/s3select/sql"
+)
+
+var magic = []byte{'O', 'b', 'j', 1}
+
+const (
+	syncSize = 16
+
+	// maxBlockSize bounds the memory used for a single block or value.
+	maxBlockSize = 128 << 20
+)
+
+// Reader implements reading records from an avro object container file.
+type Reader struct {
+	io.Closer
+	r      *bufio.Reader
+	schema *schema
+	codec  string
+	sync   [syncSize]byte
+
+	block     *bytes.Reader // decoded objects of the current block.
+	remaining int64         // objects left in the current block.
+}
+
+// NewReader creates a Reader from an io.ReadCloser.
+func NewReader(rc io.ReadCloser, _ *ReaderArgs) (r *Reader, err error) {
+	r = &Reader{Closer: rc, r: bufio.NewReader(rc)}
+	if err = r.readHeader(); err != nil {
+		return nil, errAvroParsingError(err)
+	}
+	return r, nil
+}
+
+func (r *Reader) readHeader() error {
+	var hdr [4]byte
+	if _, err := io.ReadFull(r.r, hdr[:]); err != nil {
+		return err
+	}
+	if !bytes.Equal(hdr[:], magic) {
+		return errors.New("not an avro object container file")
+	}
+
+	meta, err := readMap(r.r, func(br byteReader) (interface{}, error) {
+		return readBytes(br)
+	})
+	if err != nil {
+		return err
+	}
+	metaValue := func(key string) []byte {
+		for _, kv := range meta {
+			if kv.Key == key {
+				return kv.Value.([]byte)
+			}
+		}
+		return nil
+	}
+	schemaJSON := metaValue("avro.schema")
+	if len(schemaJSON) == 0 {
+		return errors.New("avro.schema missing from file metadata")
+	}
+	if r.schema, err = parseSchema(schemaJSON); err != nil {
+		return err
+	}
+	r.codec = "null"
+	if codec := metaValue("avro.codec"); len(codec) > 0 {
+		r.codec = string(codec)
+	}
+	switch r.codec {
+	case "null", "deflate", "snappy", "zstandard":
+	default:
+		return fmt.Errorf("unsupported avro codec %q", r.codec)
+	}
+
+	_, err = io.ReadFull(r.r, r.sync[:])
+	return err
+}
+
+// nextBlock reads and decompresses the next data block.
+func (r *Reader) nextBlock() error {
+	if _, err := r.r.Peek(1); err != nil {
+		return err
+	}
+	count, err := readLong(r.r)
+	if err != nil {
+		return err
+	}
+	size, err := readLong(r.r)
+	if err != nil {
+		return err
+	}
+	if count < 0 || size < 0 || size > maxBlockSize {
+		return errors.New("invalid avro data block")
+	}
+	data := make([]byte, size)
+	if _, err = io.ReadFull(r.r, data); err != nil {
+		return err
+	}
+	var sync [syncSize]byte
+	if _, err = io.ReadFull(r.r, sync[:]); err != nil {
+		return err
+	}
+	if sync != r.sync {
+		return errors.New("avro sync marker mismatch")
+	}
+
+	switch r.codec {
+	case "deflate":
+		data, err = ioutil.ReadAll(flate.NewReader(bytes.NewReader(data)))
+	case "snappy":
+		// Snappy blocks are followed by the CRC32 of the uncompressed data.
+		if len(data) < 4 {
+			return errors.New("invalid avro snappy block")
+		}
+		crc := binary.BigEndian.Uint32(data[len(data)-4:])
+		if data, err = s2.Decode(nil, data[:len(data)-4]); err == nil && crc32.ChecksumIEEE(data) != crc {
+			err = errors.New("avro snappy block checksum mismatch")
+		}
+	case "zstandard":
+		var dec *zstd.Decoder
+		if dec, err = zstd.NewReader(nil); err == nil {
+			data, err = dec.DecodeAll(data, nil)
+			dec.Close()
+		}
+	}
+	if err != nil {
+		return err
+	}
+
+	r.block = bytes.NewReader(data)
+	r.remaining = count
+	return nil
+}
+
+func (r *Reader) Read(dst sql.Record) (rec sql.Record, rerr error) {
+	for r.remaining == 0 {
+		if err := r.nextBlock(); err != nil {
+			if err == io.EOF {
+				return nil, err
+			}
+			return nil, errAvroParsingError(err)
+		}
+	}
+
+	v, err := decode(r.block, r.schema)
+	if err != nil {
+		return nil, errAvroParsingError(err)
+	}
+	r.remaining--
+
+	kvs, ok := v.(jstream.KVS)
+	if !ok {
+		kvs = jstream.KVS{{Key: "_1", Value: v}}
+	}
+
+	// Reuse destination if we can.
+	dstRec, ok := dst.(*jsonfmt.Record)
+	if !ok {
+		dstRec = &jsonfmt.Record{}
+	}
+	dstRec.SelectFormat = sql.SelectFmtAvro
+	dstRec.KVS = kvs
+	return dstRec, nil
+}
+
+type byteReader interface {
+	io.Reader
+	io.ByteReader
+}
+
+// readLong reads a zig-zag encoded variable length integer.
+func readLong(br byteReader) (int64, error) {
+	u, err := binary.ReadUvarint(br)
+	if err != nil {
+		if err == io.EOF {
+			err = io.ErrUnexpectedEOF
+		}
+		return 0, err
+	}
+	return int64(u>>1) ^ -int64(u&1), nil
+}
+
+func readBytes(br byteReader) ([]byte, error) {
+	n, err := readLong(br)
+	if err != nil {
+		return nil, err
+	}
+	if n < 0 || n > maxBlockSize {
+		return nil, errors.New("invalid avro bytes length")
+	}
+	b := make([]byte, n)
+	_, err = io.ReadFull(br, b)
+	return b, err
+}
+
+// readBlocks reads the blocks of an array or map, calling fn for each item.
+func readBlocks(br byteReader, fn func() error) error {
+	for {
+		n, err := readLong(br)
+		if err != nil {
+			return err
+		}
+		if n == 0 {
+			return nil
+		}
+		if n < 0 {
+			// A negative count is followed by the block size in bytes.
+			n = -n
+			if _, err = readLong(br); err != nil {
+				return err
+			}
+		}
+		for ; n > 0; n-- {
+			if err = fn(); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+func readMap(br byteReader, value func(br byteReader) (interface{}, error)) (jstream.KVS, error) {
+	kvs := jstream.KVS{}
+	err := readBlocks(br, func() error {
+		k, err := readBytes(br)
+		if err != nil {
+			return err
+		}
+		v, err := value(br)
+		if err != nil {
+			return err
+		}
+		kvs = append(kvs, jstream.KV{Key: string(k), Value: v})
+		return nil
+	})
+	return kvs, err
+}
+
+// decode reads one value of schema s, returning it in the form
+// used by JSON records.
+func decode(br byteReader, s *schema) (interface{}, error) {
+	switch s.typ {
+	case typeNull:
+		return nil, nil
+	case typeBoolean:
+		b, err := br.ReadByte()
+		return b != 0, err
+	case typeInt, typeLong:
+		v, err := readLong(br)
+		if err != nil {
+			return nil, err
+		}
+		return convertFromLogicalType(s, v), nil
+	case typeFloat:
+		var b [4]byte
+		_, err := io.ReadFull(br, b[:])
+		return float64(math.Float32frombits(binary.LittleEndian.Uint32(b[:]))), err
+	case typeDouble:
+		var b [8]byte
+		_, err := io.ReadFull(br, b[:])
+		return math.Float64frombits(binary.LittleEndian.Uint64(b[:])), err
+	case typeBytes, typeString:
+		b, err := readBytes(br)
+		if err != nil {
+			return nil, err
+		}
+		return convertFromLogicalType(s, b), nil
+	case typeFixed:
+		b := make([]byte, s.size)
+		if _, err := io.ReadFull(br, b); err != nil {
+			return nil, err
+		}
+		return convertFromLogicalType(s, b), nil
+	case typeEnum:
+		i, err := readLong(br)
+		if err != nil {
+			return nil, err
+		}
+		if i < 0 || i >= int64(len(s.symbols)) {
+			return nil, fmt.Errorf("invalid symbol index %d for enum %q", i, s.name)
+		}
+		return s.symbols[i], nil
+	case typeUnion:
+		i, err := readLong(br)
+		if err != nil {
+			return nil, err
+		}
+		if i < 0 || i >= int64(len(s.branches)) {
+			return nil, fmt.Errorf("invalid union branch %d", i)
+		}
+		return decode(br, s.branches[i])
+	case typeArray:
+		var items []interface{}
+		err := readBlocks(br, func() error {
+			v, err := decode(br, s.items)
+			items = append(items, v)
+			return err
+		})
+		return items, err
+	case typeMap:
+		return readMap(br, func(br byteReader) (interface{}, error) {
+			return decode(br, s.items)
+		})
+	case typeRecord:
+		kvs := make(jstream.KVS, 0, len(s.fields))
+		for _, f := range s.fields {
+			v, err := decode(br, f.schema)
+			if err != nil {
+				return nil, err
+			}
+			kvs = append(kvs, jstream.KV{Key: f.name, Value: v})
+		}
+		return kvs, nil
+	}
+	return nil, fmt.Errorf("unsupported avro type %d", s.typ)
+}
+
+// convertFromLogicalType - converts values based on the avro logical
+// type annotation of the schema, unknown annotations are ignored.
+func convertFromLogicalType(s *schema, v interface{}) interface{} {
+	switch val := v.(type) {
+	case int64:
+		switch s.logicalType {
+		case "date":
+			return sql.FormatSQLTimestamp(time.Unix(60*60*24*val, 0).UTC())
+		case "timestamp-millis":
+			return sql.FormatSQLTimestamp(time.Unix(0, 0).Add(time.Duration(val) * time.Millisecond).UTC())
+		case "timestamp-micros":
+			return sql.FormatSQLTimestamp(time.Unix(0, 0).Add(time.Duration(val) * time.Microsecond).UTC())
+		}
+		return val
+	case []byte:
+		if s.logicalType == "decimal" {
+			// Decimals are two's-complement big-endian unscaled integers.
+			unscaled := new(big.Int).SetBytes(val)
+			if len(val) > 0 && val[0]&0x80 != 0 {
+				unscaled.Sub(unscaled, new(big.Int).Lsh(big.NewInt(1), uint(len(val))*8))
+			}
+			f, _ := new(big.Float).Quo(new(big.Float).SetInt(unscaled),
+				new(big.Float).SetInt(new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(s.scale)), nil))).Float64()
+			return f
+		}
+		return string(val)
+	}
+	return v
+}
@@ -0,0 +1,175 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package avro
+
+import (
+	"bytes"
+	"compress/flate"
+	"encoding/binary"
+	"io"
+	"io/ioutil"
+	"math"
+	"testing"
+
+	"github.com/bcicen/jstream"
+	jsonfmt "github.com/minio/minio/internal/s3select/json"
+)
+
+const testSchema = `{
+  "type": "record", "name": "Event", "namespace": "test",
+  "fields": [
+    {"name": "id", "type": "long"},
+    {"name": "name", "type": ["null", "string"]},
+    {"name": "score", "type": "double"},
+    {"name": "level", "type": {"type": "enum", "name": "Level", "symbols": ["LOW", "HIGH"]}},
+    {"name": "tags", "type": {"type": "array", "items": "string"}},
+    {"name": "attrs", "type": {"type": "map", "values": "int"}},
+    {"name": "ts", "type": {"type": "long", "logicalType": "timestamp-millis"}}
+  ]
+}`
+
+func appendLong(b []byte, v int64) []byte {
+	var buf [binary.MaxVarintLen64]byte
+	n := binary.PutUvarint(buf[:], uint64(v<<1^v>>63))
+	return append(b, buf[:n]...)
+}
+
+func appendString(b []byte, s string) []byte {
+	return append(appendLong(b, int64(len(s))), s...)
+}
+
+func appendDouble(b []byte, f float64) []byte {
+	var buf [8]byte
+	binary.LittleEndian.PutUint64(buf[:], math.Float64bits(f))
+	return append(b, buf[:]...)
+}
+
+func encodeEvent(id int64, name string, score float64, level int64, tags []string, attrs map[string]int64, ts int64) []byte {
+	var b []byte
+	b = appendLong(b, id)
+	if name == "" {
+		b = appendLong(b, 0)
+	} else {
+		b = appendString(appendLong(b, 1), name)
+	}
+	b = appendDouble(b, score)
+	b = appendLong(b, level)
+	if len(tags) > 0 {
+		b = appendLong(b, int64(len(tags)))
+		for _, t := range tags {
+			b = appendString(b, t)
+		}
+	}
+	b = appendLong(b, 0)
+	if len(attrs) > 0 {
+		// Use a negative block count, followed by the block size.
+		var block []byte
+		for k, v := range attrs {
+			block = appendLong(appendString(block, k), v)
+		}
+		b = append(appendLong(appendLong(b, -int64(len(attrs))), int64(len(block))), block...)
+	}
+	b = appendLong(b, 0)
+	return appendLong(b, ts)
+}
+
+func writeContainer(codec string, blocks ...[][]byte) []byte {
+	sync := []byte("0123456789abcdef")
+	b := []byte("Obj\x01")
+	b = appendLong(b, 2)
+	b = appendString(appendString(b, "avro.schema"), testSchema)
+	b = appendString(appendString(b, "avro.codec"), codec)
+	b = appendLong(b, 0)
+	b = append(b, sync...)
+	for _, objects := range blocks {
+		data := bytes.Join(objects, nil)
+		if codec == "deflate" {
+			var buf bytes.Buffer
+			w, _ := flate.NewWriter(&buf, flate.BestSpeed)
+			w.Write(data)
+			w.Close()
+			data = buf.Bytes()
+		}
+		b = appendLong(appendLong(b, int64(len(objects))), int64(len(data)))
+		b = append(append(b, data...), sync...)
+	}
+	return b
+}
+
+func TestAvroReader(t *testing.T) {
+	for _, codec := range []string{"null", "deflate"} {
+		t.Run(codec, func(t *testing.T) {
+			file := writeContainer(codec,
+				[][]byte{
+					encodeEvent(1, "first", 1.5, 0, []string{"a", "b"}, map[string]int64{"k": 7}, 1000),
+					encodeEvent(2, "", -2, 1, nil, nil, 0),
+				},
+				[][]byte{
+					encodeEvent(3, "third", 0, 1, nil, nil, 0),
+				},
+			)
+
+			r, err := NewReader(ioutil.NopCloser(bytes.NewReader(file)), &ReaderArgs{})
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer r.Close()
+
+			var records []jstream.KVS
+			for {
+				rec, err := r.Read(nil)
+				if err == io.EOF {
+					break
+				}
+				if err != nil {
+					t.Fatal(err)
+				}
+				records = append(records, rec.(*jsonfmt.Record).KVS)
+			}
+			if len(records) != 3 {
+				t.Fatalf("expected 3 records, got %d", len(records))
+			}
+
+			first := records[0]
+			if first[0].Value != int64(1) || first[1].Value != "first" || first[2].Value != 1.5 || first[3].Value != "LOW" {
+				t.Errorf("unexpected record %v", first)
+			}
+			if tags := first[4].Value.([]interface{}); len(tags) != 2 || tags[1] != "b" {
+				t.Errorf("unexpected tags %v", tags)
+			}
+			if attrs := first[5].Value.(jstream.KVS); len(attrs) != 1 || attrs[0].Key != "k" || attrs[0].Value != int64(7) {
+				t.Errorf("unexpected attrs %v", attrs)
+			}
+			if first[6].Value != "1970-01-01T00:00:01Z" {
+				t.Errorf("unexpected timestamp %v", first[6].Value)
+			}
+			if second := records[1]; second[1].Value != nil || second[3].Value != "HIGH" {
+				t.Errorf("unexpected record %v", second)
+			}
+			if third := records[2]; third[0].Value != int64(3) {
+				t.Errorf("unexpected record %v", third)
+			}
+		})
+	}
+}
+
+func TestAvroReaderInvalid(t *testing.T) {
+	if _, err := NewReader(ioutil.NopCloser(bytes.NewReader([]byte("PAR1"))), &ReaderArgs{}); err == nil {
+		t.Fatal("expected error for non avro input")
+	}
+}
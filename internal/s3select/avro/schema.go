@@ -0,0 +1,203 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package avro
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// schemaType - avro schema type.
+type schemaType int
+
+const (
+	typeNull schemaType = iota
+	typeBoolean
+	typeInt
+	typeLong
+	typeFloat
+	typeDouble
+	typeBytes
+	typeString
+	typeRecord
+	typeEnum
+	typeArray
+	typeMap
+	typeUnion
+	typeFixed
+)
+
+var primitiveTypes = map[string]schemaType{
+	"null":    typeNull,
+	"boolean": typeBoolean,
+	"int":     typeInt,
+	"long":    typeLong,
+	"float":   typeFloat,
+	"double":  typeDouble,
+	"bytes":   typeBytes,
+	"string":  typeString,
+}
+
+// field - a field of a record schema.
+type field struct {
+	name   string
+	schema *schema
+}
+
+// schema - parsed avro schema, see
+// https://avro.apache.org/docs/current/spec.html#schemas
+type schema struct {
+	typ         schemaType
+	name        string
+	logicalType string
+	scale       int
+	size        int       // fixed
+	symbols     []string  // enum
+	fields      []field   // record
+	items       *schema   // array and map values
+	branches    []*schema // union
+}
+
+// schemaParser resolves named types while parsing a schema.
+type schemaParser struct {
+	named map[string]*schema
+}
+
+func parseSchema(data []byte) (*schema, error) {
+	var v interface{}
+	if err := json.Unmarshal(data, &v); err != nil {
+		return nil, err
+	}
+	p := schemaParser{named: make(map[string]*schema)}
+	return p.parse(v, "")
+}
+
+func fullName(name, namespace string) string {
+	if strings.Contains(name, ".") || namespace == "" {
+		return name
+	}
+	return namespace + "." + name
+}
+
+func (p *schemaParser) parse(v interface{}, namespace string) (*schema, error) {
+	switch t := v.(type) {
+	case string:
+		if typ, ok := primitiveTypes[t]; ok {
+			return &schema{typ: typ}, nil
+		}
+		if s, ok := p.named[fullName(t, namespace)]; ok {
+			return s, nil
+		}
+		if s, ok := p.named[t]; ok {
+			return s, nil
+		}
+		return nil, fmt.Errorf("unknown avro type %q", t)
+	case []interface{}:
+		s := &schema{typ: typeUnion}
+		for _, b := range t {
+			bs, err := p.parse(b, namespace)
+			if err != nil {
+				return nil, err
+			}
+			s.branches = append(s.branches, bs)
+		}
+		return s, nil
+	case map[string]interface{}:
+		return p.parseComplex(t, namespace)
+	}
+	return nil, fmt.Errorf("invalid avro schema %v", v)
+}
+
+func (p *schemaParser) parseComplex(m map[string]interface{}, namespace string) (*schema, error) {
+	typeName, ok := m["type"].(string)
+	if !ok {
+		// The type itself is a complex schema.
+		return p.parse(m["type"], namespace)
+	}
+
+	s := &schema{}
+	s.logicalType, _ = m["logicalType"].(string)
+	if scale, ok := m["scale"].(float64); ok {
+		s.scale = int(scale)
+	}
+
+	if typ, ok := primitiveTypes[typeName]; ok {
+		s.typ = typ
+		return s, nil
+	}
+
+	switch typeName {
+	case "record", "error", "enum", "fixed":
+		name, _ := m["name"].(string)
+		if ns, ok := m["namespace"].(string); ok {
+			namespace = ns
+		}
+		s.name = fullName(name, namespace)
+		if i := strings.LastIndexByte(s.name, '.'); i >= 0 {
+			namespace = s.name[:i]
+		}
+		p.named[s.name] = s
+	}
+
+	switch typeName {
+	case "record", "error":
+		s.typ = typeRecord
+		fields, _ := m["fields"].([]interface{})
+		for _, f := range fields {
+			fm, ok := f.(map[string]interface{})
+			if !ok {
+				return nil, fmt.Errorf("invalid field in record %q", s.name)
+			}
+			name, _ := fm["name"].(string)
+			fs, err := p.parse(fm["type"], namespace)
+			if err != nil {
+				return nil, err
+			}
+			s.fields = append(s.fields, field{name: name, schema: fs})
+		}
+	case "enum":
+		s.typ = typeEnum
+		symbols, _ := m["symbols"].([]interface{})
+		for _, sym := range symbols {
+			str, _ := sym.(string)
+			s.symbols = append(s.symbols, str)
+		}
+	case "fixed":
+		s.typ = typeFixed
+		size, _ := m["size"].(float64)
+		s.size = int(size)
+	case "array":
+		s.typ = typeArray
+		items, err := p.parse(m["items"], namespace)
+		if err != nil {
+			return nil, err
+		}
+		s.items = items
+	case "map":
+		s.typ = typeMap
+		values, err := p.parse(m["values"], namespace)
+		if err != nil {
+			return nil, err
+		}
+		s.items = values
+	default:
+		return p.parse(typeName, namespace)
+	}
+	return s, nil
+}
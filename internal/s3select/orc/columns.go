@@ -0,0 +1,285 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package orc
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"math"
+	"math/big"
+	"time"
+
+	"github.com/bcicen/jstream"
+	"github.com/minio/minio/internal/s3select/sql"
+)
+
+// timestampBase is the epoch of ORC timestamps, 2015-01-01 00:00:00 UTC.
+const timestampBase = 1420070400
+
+// column reads the values of one column, in the form used by JSON
+// records, one row at a time.
+type column interface {
+	next() (interface{}, error)
+}
+
+// baseColumn tracks the optional PRESENT stream of a column.
+type baseColumn struct {
+	present *boolRLEReader
+}
+
+func (c baseColumn) isNull() (bool, error) {
+	if c.present == nil {
+		return false, nil
+	}
+	present, err := c.present.next()
+	return !present, err
+}
+
+type boolColumn struct {
+	baseColumn
+	data *boolRLEReader
+}
+
+func (c *boolColumn) next() (interface{}, error) {
+	if null, err := c.isNull(); null || err != nil {
+		return nil, err
+	}
+	return c.data.next()
+}
+
+type byteColumn struct {
+	baseColumn
+	data *byteRLEReader
+}
+
+func (c *byteColumn) next() (interface{}, error) {
+	if null, err := c.isNull(); null || err != nil {
+		return nil, err
+	}
+	b, err := c.data.next()
+	return int64(int8(b)), err
+}
+
+type intColumn struct {
+	baseColumn
+	data *intReader
+	date bool
+}
+
+func (c *intColumn) next() (interface{}, error) {
+	if null, err := c.isNull(); null || err != nil {
+		return nil, err
+	}
+	v, err := c.data.next()
+	if err != nil {
+		return nil, err
+	}
+	if c.date {
+		return sql.FormatSQLTimestamp(time.Unix(60*60*24*v, 0).UTC()), nil
+	}
+	return v, nil
+}
+
+type floatColumn struct {
+	baseColumn
+	data   *bytes.Reader
+	double bool
+}
+
+func (c *floatColumn) next() (interface{}, error) {
+	if null, err := c.isNull(); null || err != nil {
+		return nil, err
+	}
+	if c.double {
+		var b [8]byte
+		_, err := io.ReadFull(c.data, b[:])
+		return math.Float64frombits(binary.LittleEndian.Uint64(b[:])), err
+	}
+	var b [4]byte
+	_, err := io.ReadFull(c.data, b[:])
+	return float64(math.Float32frombits(binary.LittleEndian.Uint32(b[:]))), err
+}
+
+type stringColumn struct {
+	baseColumn
+
+	// Direct encoding.
+	lengths *intReader
+	data    *bytes.Reader
+
+	// Dictionary encoding.
+	dictionary []string
+	indexes    *intReader
+}
+
+func (c *stringColumn) next() (interface{}, error) {
+	if null, err := c.isNull(); null || err != nil {
+		return nil, err
+	}
+	if c.indexes != nil {
+		i, err := c.indexes.next()
+		if err != nil {
+			return nil, err
+		}
+		if i < 0 || i >= int64(len(c.dictionary)) {
+			return nil, errInvalidRLE
+		}
+		return c.dictionary[i], nil
+	}
+	n, err := c.lengths.next()
+	if err != nil {
+		return nil, err
+	}
+	b := make([]byte, n)
+	_, err = io.ReadFull(c.data, b)
+	return string(b), err
+}
+
+type timestampColumn struct {
+	baseColumn
+	seconds *intReader
+	nanos   *intReader
+}
+
+func (c *timestampColumn) next() (interface{}, error) {
+	if null, err := c.isNull(); null || err != nil {
+		return nil, err
+	}
+	secs, err := c.seconds.next()
+	if err != nil {
+		return nil, err
+	}
+	nanos, err := c.nanos.next()
+	if err != nil {
+		return nil, err
+	}
+	// The low 3 bits hold the number of trailing decimal zeros removed.
+	if zeros := nanos & 7; zeros != 0 {
+		nanos >>= 3
+		for i := int64(0); i <= zeros; i++ {
+			nanos *= 10
+		}
+	} else {
+		nanos >>= 3
+	}
+	return sql.FormatSQLTimestamp(time.Unix(timestampBase+secs, nanos).UTC()), nil
+}
+
+type decimalColumn struct {
+	baseColumn
+	data   *bytes.Reader
+	scales *intReader
+}
+
+func (c *decimalColumn) next() (interface{}, error) {
+	if null, err := c.isNull(); null || err != nil {
+		return nil, err
+	}
+	// The unscaled value is an unbounded zigzag encoded varint.
+	unscaled := new(big.Int)
+	var shift uint
+	for {
+		b, err := c.data.ReadByte()
+		if err != nil {
+			return nil, err
+		}
+		unscaled.Or(unscaled, new(big.Int).Lsh(big.NewInt(int64(b&0x7f)), shift))
+		shift += 7
+		if b < 0x80 {
+			break
+		}
+	}
+	negative := unscaled.Bit(0) == 1
+	unscaled.Rsh(unscaled, 1)
+	if negative {
+		unscaled.Add(unscaled, big.NewInt(1))
+		unscaled.Neg(unscaled)
+	}
+	scale, err := c.scales.next()
+	if err != nil {
+		return nil, err
+	}
+	f, _ := new(big.Float).Quo(new(big.Float).SetInt(unscaled),
+		new(big.Float).SetInt(new(big.Int).Exp(big.NewInt(10), big.NewInt(scale), nil))).Float64()
+	return f, nil
+}
+
+type structColumn struct {
+	baseColumn
+	names    []string
+	children []column
+}
+
+func (c *structColumn) next() (interface{}, error) {
+	if null, err := c.isNull(); null || err != nil {
+		return nil, err
+	}
+	kvs := make(jstream.KVS, 0, len(c.children))
+	for i, child := range c.children {
+		v, err := child.next()
+		if err != nil {
+			return nil, err
+		}
+		kvs = append(kvs, jstream.KV{Key: c.names[i], Value: v})
+	}
+	return kvs, nil
+}
+
+// listColumn reads lists, which have a single child, and maps,
+// which have a key and a value child.
+type listColumn struct {
+	baseColumn
+	lengths  *intReader
+	children []column
+}
+
+func (c *listColumn) next() (interface{}, error) {
+	if null, err := c.isNull(); null || err != nil {
+		return nil, err
+	}
+	n, err := c.lengths.next()
+	if err != nil {
+		return nil, err
+	}
+	if len(c.children) == 1 {
+		items := make([]interface{}, 0, n)
+		for i := int64(0); i < n; i++ {
+			v, err := c.children[0].next()
+			if err != nil {
+				return nil, err
+			}
+			items = append(items, v)
+		}
+		return items, nil
+	}
+	kvs := make(jstream.KVS, 0, n)
+	for i := int64(0); i < n; i++ {
+		k, err := c.children[0].next()
+		if err != nil {
+			return nil, err
+		}
+		v, err := c.children[1].next()
+		if err != nil {
+			return nil, err
+		}
+		kvs = append(kvs, jstream.KV{Key: fmt.Sprint(k), Value: v})
+	}
+	return kvs, nil
+}
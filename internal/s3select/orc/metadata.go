@@ -0,0 +1,303 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package orc
+
+import (
+	"encoding/binary"
+	"errors"
+)
+
+// The ORC file tail is encoded with protocol buffers, only the
+// messages and fields needed to read the data are decoded here, see
+// https://orc.apache.org/specification/ORCv1/
+
+// compressionKind - CompressionKind of the PostScript.
+type compressionKind uint64
+
+const (
+	compressionNone compressionKind = iota
+	compressionZlib
+	compressionSnappy
+	compressionLZO
+	compressionLZ4
+	compressionZstd
+)
+
+// typeKind - Type.Kind of the Footer.
+type typeKind uint64
+
+const (
+	kindBoolean typeKind = iota
+	kindByte
+	kindShort
+	kindInt
+	kindLong
+	kindFloat
+	kindDouble
+	kindString
+	kindBinary
+	kindTimestamp
+	kindList
+	kindMap
+	kindStruct
+	kindUnion
+	kindDecimal
+	kindDate
+	kindVarchar
+	kindChar
+	kindTimestampInstant
+)
+
+// streamKind - Stream.Kind of the StripeFooter.
+type streamKind uint64
+
+const (
+	streamPresent streamKind = iota
+	streamData
+	streamLength
+	streamDictionaryData
+	streamDictionaryCount
+	streamSecondary
+	streamRowIndex
+)
+
+// encodingKind - ColumnEncoding.Kind of the StripeFooter.
+type encodingKind uint64
+
+const (
+	encodingDirect encodingKind = iota
+	encodingDictionary
+	encodingDirectV2
+	encodingDictionaryV2
+)
+
+type postScript struct {
+	footerLength         uint64
+	compression          compressionKind
+	compressionBlockSize uint64
+	magic                string
+}
+
+type stripeInformation struct {
+	offset       uint64
+	indexLength  uint64
+	dataLength   uint64
+	footerLength uint64
+	numberOfRows uint64
+}
+
+type orcType struct {
+	kind       typeKind
+	subtypes   []uint32
+	fieldNames []string
+	scale      uint64
+}
+
+type footer struct {
+	stripes      []stripeInformation
+	types        []orcType
+	numberOfRows uint64
+}
+
+type stream struct {
+	kind   streamKind
+	column uint64
+	length uint64
+}
+
+type columnEncoding struct {
+	kind           encodingKind
+	dictionarySize uint64
+}
+
+type stripeFooter struct {
+	streams []stream
+	columns []columnEncoding
+}
+
+var errInvalidProtobuf = errors.New("invalid protobuf message")
+
+// walkMessage calls fn for each field of the protobuf message in buf.
+// For varint fields v holds the value, for length delimited fields b
+// holds the content.
+func walkMessage(buf []byte, fn func(num uint64, v uint64, b []byte) error) error {
+	for len(buf) > 0 {
+		key, n := binary.Uvarint(buf)
+		if n <= 0 {
+			return errInvalidProtobuf
+		}
+		buf = buf[n:]
+		num := key >> 3
+		var v uint64
+		var b []byte
+		switch key & 7 {
+		case 0:
+			v, n = binary.Uvarint(buf)
+			if n <= 0 {
+				return errInvalidProtobuf
+			}
+			buf = buf[n:]
+		case 1:
+			if len(buf) < 8 {
+				return errInvalidProtobuf
+			}
+			v = binary.LittleEndian.Uint64(buf)
+			buf = buf[8:]
+		case 2:
+			l, n := binary.Uvarint(buf)
+			if n <= 0 || uint64(len(buf)-n) < l {
+				return errInvalidProtobuf
+			}
+			b = buf[n : n+int(l)]
+			buf = buf[n+int(l):]
+		case 5:
+			if len(buf) < 4 {
+				return errInvalidProtobuf
+			}
+			v = uint64(binary.LittleEndian.Uint32(buf))
+			buf = buf[4:]
+		default:
+			return errInvalidProtobuf
+		}
+		if err := fn(num, v, b); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// appendUints appends a repeated integer field, which may be packed.
+func appendUints(dst []uint32, v uint64, b []byte) ([]uint32, error) {
+	if b == nil {
+		return append(dst, uint32(v)), nil
+	}
+	for len(b) > 0 {
+		u, n := binary.Uvarint(b)
+		if n <= 0 {
+			return nil, errInvalidProtobuf
+		}
+		dst = append(dst, uint32(u))
+		b = b[n:]
+	}
+	return dst, nil
+}
+
+func parsePostScript(buf []byte) (ps postScript, err error) {
+	err = walkMessage(buf, func(num uint64, v uint64, b []byte) error {
+		switch num {
+		case 1:
+			ps.footerLength = v
+		case 2:
+			ps.compression = compressionKind(v)
+		case 3:
+			ps.compressionBlockSize = v
+		case 8000:
+			ps.magic = string(b)
+		}
+		return nil
+	})
+	return ps, err
+}
+
+func parseFooter(buf []byte) (f footer, err error) {
+	err = walkMessage(buf, func(num uint64, v uint64, b []byte) error {
+		switch num {
+		case 3:
+			var si stripeInformation
+			if err := walkMessage(b, func(num uint64, v uint64, b []byte) error {
+				switch num {
+				case 1:
+					si.offset = v
+				case 2:
+					si.indexLength = v
+				case 3:
+					si.dataLength = v
+				case 4:
+					si.footerLength = v
+				case 5:
+					si.numberOfRows = v
+				}
+				return nil
+			}); err != nil {
+				return err
+			}
+			f.stripes = append(f.stripes, si)
+		case 4:
+			var t orcType
+			if err := walkMessage(b, func(num uint64, v uint64, b []byte) (err error) {
+				switch num {
+				case 1:
+					t.kind = typeKind(v)
+				case 2:
+					t.subtypes, err = appendUints(t.subtypes, v, b)
+				case 3:
+					t.fieldNames = append(t.fieldNames, string(b))
+				case 6:
+					t.scale = v
+				}
+				return err
+			}); err != nil {
+				return err
+			}
+			f.types = append(f.types, t)
+		case 6:
+			f.numberOfRows = v
+		}
+		return nil
+	})
+	return f, err
+}
+
+func parseStripeFooter(buf []byte) (sf stripeFooter, err error) {
+	err = walkMessage(buf, func(num uint64, v uint64, b []byte) error {
+		switch num {
+		case 1:
+			var s stream
+			if err := walkMessage(b, func(num uint64, v uint64, b []byte) error {
+				switch num {
+				case 1:
+					s.kind = streamKind(v)
+				case 2:
+					s.column = v
+				case 3:
+					s.length = v
+				}
+				return nil
+			}); err != nil {
+				return err
+			}
+			sf.streams = append(sf.streams, s)
+		case 2:
+			var ce columnEncoding
+			if err := walkMessage(b, func(num uint64, v uint64, b []byte) error {
+				switch num {
+				case 1:
+					ce.kind = encodingKind(v)
+				case 2:
+					ce.dictionarySize = v
+				}
+				return nil
+			}); err != nil {
+				return err
+			}
+			sf.columns = append(sf.columns, ce)
+		}
+		return nil
+	})
+	return sf, err
+}
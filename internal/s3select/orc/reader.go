@@ -0,0 +1,346 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package orc
+
+import (
+	"bytes"
+	"compress/flate"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+
+	"github.com/bcicen/jstream"
+	"github.com/klauspost/compress/s2"
+	"github.com/klauspost/compress/zstd"
+	jsonfmt "github.com/minio/minio/internal/s3select/json"
+	"github.com/minio/minio/internal/s3select/sql"
+	"github.com/pierrec/lz4"
+)
+
+const (
+	magic = "ORC"
+
+	// maxTailSize is the largest postscript and footer read, the footer
+	// of files with a large number of stripes or columns may be bigger.
+	maxTailSize = 16 << 20
+)
+
+// Reader implements reading records from ORC input.
+type Reader struct {
+	io.Closer
+	r    io.ReadSeeker
+	size int64
+	ps   postScript
+	f    footer
+
+	stripe    int           // index of the next stripe to read.
+	root      *structColumn // column reader of the current stripe.
+	remaining uint64        // rows left in the current stripe.
+}
+
+// NewReader creates a Reader from an io.ReadSeekCloser.
+func NewReader(rsc io.ReadSeekCloser, _ *ReaderArgs) (r *Reader, err error) {
+	r = &Reader{Closer: rsc, r: rsc}
+	if err = r.readTail(); err != nil {
+		return nil, errORCParsingError(err)
+	}
+	return r, nil
+}
+
+func (r *Reader) readAt(off, length int64) ([]byte, error) {
+	if off < 0 || length < 0 || off+length > r.size {
+		return nil, errors.New("ORC section out of file bounds")
+	}
+	if _, err := r.r.Seek(off, io.SeekStart); err != nil {
+		return nil, err
+	}
+	buf := make([]byte, length)
+	_, err := io.ReadFull(r.r, buf)
+	return buf, err
+}
+
+func (r *Reader) readTail() error {
+	size, err := r.r.Seek(0, io.SeekEnd)
+	if err != nil {
+		return err
+	}
+	r.size = size
+	if size <= int64(len(magic)) {
+		return errors.New("not an ORC file")
+	}
+	last, err := r.readAt(size-1, 1)
+	if err != nil {
+		return err
+	}
+	psLen := int64(last[0])
+	if psLen+1 > size {
+		return errors.New("invalid ORC postscript length")
+	}
+	psBuf, err := r.readAt(size-1-psLen, psLen)
+	if err != nil {
+		return err
+	}
+	if r.ps, err = parsePostScript(psBuf); err != nil {
+		return err
+	}
+	if r.ps.magic != magic {
+		return errors.New("not an ORC file")
+	}
+	switch r.ps.compression {
+	case compressionNone, compressionZlib, compressionSnappy, compressionLZ4, compressionZstd:
+	default:
+		return fmt.Errorf("unsupported ORC compression kind %d", r.ps.compression)
+	}
+
+	footerLen := int64(r.ps.footerLength)
+	if footerLen > maxTailSize || footerLen+psLen+1 > size {
+		return errors.New("invalid ORC footer length")
+	}
+	footerBuf, err := r.readAt(size-1-psLen-footerLen, footerLen)
+	if err != nil {
+		return err
+	}
+	if footerBuf, err = r.decompress(footerBuf); err != nil {
+		return err
+	}
+	if r.f, err = parseFooter(footerBuf); err != nil {
+		return err
+	}
+	if len(r.f.types) == 0 || r.f.types[0].kind != kindStruct {
+		return errors.New("ORC file schema must be a struct")
+	}
+	return nil
+}
+
+// decompress decodes the compression chunks of a stream.
+func (r *Reader) decompress(buf []byte) ([]byte, error) {
+	if r.ps.compression == compressionNone {
+		return buf, nil
+	}
+	var out []byte
+	for len(buf) > 0 {
+		if len(buf) < 3 {
+			return nil, errors.New("invalid ORC compression chunk")
+		}
+		h := int(buf[0]) | int(buf[1])<<8 | int(buf[2])<<16
+		original, length := h&1 == 1, h>>1
+		buf = buf[3:]
+		if length > len(buf) {
+			return nil, errors.New("invalid ORC compression chunk length")
+		}
+		chunk := buf[:length]
+		buf = buf[length:]
+		if original {
+			out = append(out, chunk...)
+			continue
+		}
+		var err error
+		switch r.ps.compression {
+		case compressionZlib:
+			chunk, err = ioutil.ReadAll(flate.NewReader(bytes.NewReader(chunk)))
+		case compressionSnappy:
+			chunk, err = s2.Decode(nil, chunk)
+		case compressionLZ4:
+			dst := make([]byte, r.ps.compressionBlockSize)
+			var n int
+			n, err = lz4.UncompressBlock(chunk, dst)
+			chunk = dst[:n]
+		case compressionZstd:
+			var dec *zstd.Decoder
+			if dec, err = zstd.NewReader(nil); err == nil {
+				chunk, err = dec.DecodeAll(chunk, nil)
+				dec.Close()
+			}
+		}
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, chunk...)
+	}
+	return out, nil
+}
+
+// openStripe reads the streams of stripe and prepares the column readers.
+func (r *Reader) openStripe(si stripeInformation) error {
+	sfBuf, err := r.readAt(int64(si.offset+si.indexLength+si.dataLength), int64(si.footerLength))
+	if err != nil {
+		return err
+	}
+	if sfBuf, err = r.decompress(sfBuf); err != nil {
+		return err
+	}
+	sf, err := parseStripeFooter(sfBuf)
+	if err != nil {
+		return err
+	}
+	if len(sf.columns) < len(r.f.types) {
+		return errors.New("ORC stripe is missing column encodings")
+	}
+
+	type streamKey struct {
+		column uint64
+		kind   streamKind
+	}
+	streams := make(map[streamKey]*bytes.Reader)
+	offset := si.offset
+	for _, s := range sf.streams {
+		if s.kind != streamRowIndex && s.column < uint64(len(r.f.types)) {
+			buf, err := r.readAt(int64(offset), int64(s.length))
+			if err != nil {
+				return err
+			}
+			if buf, err = r.decompress(buf); err != nil {
+				return err
+			}
+			streams[streamKey{s.column, s.kind}] = bytes.NewReader(buf)
+		}
+		offset += s.length
+	}
+
+	var build func(id uint32) (column, error)
+	build = func(id uint32) (column, error) {
+		if int(id) >= len(r.f.types) {
+			return nil, errors.New("invalid ORC column id")
+		}
+		t := r.f.types[id]
+		get := func(kind streamKind) *bytes.Reader {
+			if s, ok := streams[streamKey{uint64(id), kind}]; ok {
+				return s
+			}
+			return bytes.NewReader(nil)
+		}
+		enc := sf.columns[id]
+		base := baseColumn{}
+		if s, ok := streams[streamKey{uint64(id), streamPresent}]; ok {
+			base.present = &boolRLEReader{bytes: byteRLEReader{r: s}}
+		}
+
+		switch t.kind {
+		case kindBoolean:
+			return &boolColumn{baseColumn: base, data: &boolRLEReader{bytes: byteRLEReader{r: get(streamData)}}}, nil
+		case kindByte:
+			return &byteColumn{baseColumn: base, data: &byteRLEReader{r: get(streamData)}}, nil
+		case kindShort, kindInt, kindLong, kindDate:
+			return &intColumn{baseColumn: base, data: newIntReader(get(streamData), true, enc.kind), date: t.kind == kindDate}, nil
+		case kindFloat, kindDouble:
+			return &floatColumn{baseColumn: base, data: get(streamData), double: t.kind == kindDouble}, nil
+		case kindString, kindVarchar, kindChar, kindBinary:
+			c := &stringColumn{baseColumn: base}
+			if enc.kind == encodingDictionary || enc.kind == encodingDictionaryV2 {
+				lengths := newIntReader(get(streamLength), false, enc.kind)
+				dict := get(streamDictionaryData)
+				for i := uint64(0); i < enc.dictionarySize; i++ {
+					n, err := lengths.next()
+					if err != nil {
+						return nil, unexpectedEOF(err)
+					}
+					b := make([]byte, n)
+					if _, err = io.ReadFull(dict, b); err != nil {
+						return nil, unexpectedEOF(err)
+					}
+					c.dictionary = append(c.dictionary, string(b))
+				}
+				c.indexes = newIntReader(get(streamData), false, enc.kind)
+			} else {
+				c.lengths = newIntReader(get(streamLength), false, enc.kind)
+				c.data = get(streamData)
+			}
+			return c, nil
+		case kindTimestamp, kindTimestampInstant:
+			return &timestampColumn{
+				baseColumn: base,
+				seconds:    newIntReader(get(streamData), true, enc.kind),
+				nanos:      newIntReader(get(streamSecondary), false, enc.kind),
+			}, nil
+		case kindDecimal:
+			return &decimalColumn{
+				baseColumn: base,
+				data:       get(streamData),
+				scales:     newIntReader(get(streamSecondary), true, enc.kind),
+			}, nil
+		case kindStruct:
+			c := &structColumn{baseColumn: base, names: t.fieldNames}
+			for _, sub := range t.subtypes {
+				child, err := build(sub)
+				if err != nil {
+					return nil, err
+				}
+				c.children = append(c.children, child)
+			}
+			if len(c.names) != len(c.children) {
+				return nil, errors.New("invalid ORC struct type")
+			}
+			return c, nil
+		case kindList, kindMap:
+			c := &listColumn{baseColumn: base, lengths: newIntReader(get(streamLength), false, enc.kind)}
+			for _, sub := range t.subtypes {
+				child, err := build(sub)
+				if err != nil {
+					return nil, err
+				}
+				c.children = append(c.children, child)
+			}
+			if (t.kind == kindList && len(c.children) != 1) || (t.kind == kindMap && len(c.children) != 2) {
+				return nil, errors.New("invalid ORC list or map type")
+			}
+			return c, nil
+		}
+		return nil, fmt.Errorf("unsupported ORC type kind %d", t.kind)
+	}
+
+	root, err := build(0)
+	if err != nil {
+		return err
+	}
+	r.root = root.(*structColumn)
+	r.remaining = si.numberOfRows
+	return nil
+}
+
+func (r *Reader) Read(dst sql.Record) (rec sql.Record, rerr error) {
+	for r.remaining == 0 {
+		if r.stripe >= len(r.f.stripes) {
+			return nil, io.EOF
+		}
+		if err := r.openStripe(r.f.stripes[r.stripe]); err != nil {
+			return nil, errORCParsingError(err)
+		}
+		r.stripe++
+	}
+
+	v, err := r.root.next()
+	if err != nil {
+		return nil, errORCParsingError(unexpectedEOF(err))
+	}
+	r.remaining--
+
+	kvs, ok := v.(jstream.KVS)
+	if !ok {
+		kvs = jstream.KVS{}
+	}
+
+	// Reuse destination if we can.
+	dstRec, ok := dst.(*jsonfmt.Record)
+	if !ok {
+		dstRec = &jsonfmt.Record{}
+	}
+	dstRec.SelectFormat = sql.SelectFmtORC
+	dstRec.KVS = kvs
+	return dstRec, nil
+}
@@ -0,0 +1,188 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package orc
+
+import (
+	"bytes"
+	"compress/flate"
+	"encoding/binary"
+	"io"
+	"reflect"
+	"testing"
+
+	jsonfmt "github.com/minio/minio/internal/s3select/json"
+)
+
+// Examples from the ORC specification of the integer run length
+// encoding version 2.
+func TestIntRLEv2(t *testing.T) {
+	testCases := []struct {
+		encoded []byte
+		values  []int64
+	}{
+		// Short repeat
+		{[]byte{0x0a, 0x27, 0x10}, []int64{10000, 10000, 10000, 10000, 10000}},
+		// Direct
+		{[]byte{0x5e, 0x03, 0x5c, 0xa1, 0xab, 0x1e, 0xde, 0xad, 0xbe, 0xef}, []int64{23713, 43806, 57005, 48879}},
+		// Patched base
+		{
+			[]byte{
+				0x8e, 0x13, 0x2b, 0x21, 0x07, 0xd0, 0x1e, 0x00, 0x14, 0x70, 0x28, 0x32, 0x3c, 0x46, 0x50, 0x5a,
+				0x64, 0x6e, 0x78, 0x82, 0x8c, 0x96, 0xa0, 0xaa, 0xb4, 0xbe, 0xfc, 0xe8,
+			},
+			[]int64{2030, 2000, 2020, 1000000, 2040, 2050, 2060, 2070, 2080, 2090, 2100, 2110, 2120, 2130, 2140, 2150, 2160, 2170, 2180, 2190},
+		},
+		// Delta
+		{[]byte{0xc6, 0x09, 0x02, 0x02, 0x22, 0x42, 0x42, 0x46}, []int64{2, 3, 5, 7, 11, 13, 17, 19, 23, 29}},
+	}
+
+	for i, testCase := range testCases {
+		ir := newIntReader(bytes.NewReader(testCase.encoded), false, encodingDirectV2)
+		var values []int64
+		for {
+			v, err := ir.next()
+			if err == io.EOF {
+				break
+			}
+			if err != nil {
+				t.Fatalf("case %d: %v", i+1, err)
+			}
+			values = append(values, v)
+		}
+		if !reflect.DeepEqual(values, testCase.values) {
+			t.Errorf("case %d: expected %v, got %v", i+1, testCase.values, values)
+		}
+	}
+}
+
+func appendVarint(b []byte, v uint64) []byte {
+	var buf [binary.MaxVarintLen64]byte
+	return append(b, buf[:binary.PutUvarint(buf[:], v)]...)
+}
+
+func appendField(b []byte, num, v uint64) []byte {
+	return appendVarint(appendVarint(b, num<<3), v)
+}
+
+func appendBytesField(b []byte, num uint64, data []byte) []byte {
+	return append(appendVarint(appendVarint(b, num<<3|2), uint64(len(data))), data...)
+}
+
+// compress encodes buf as a single ZLIB compressed chunk.
+func compress(kind compressionKind, buf []byte) []byte {
+	if kind == compressionNone {
+		return buf
+	}
+	var out bytes.Buffer
+	w, _ := flate.NewWriter(&out, flate.BestSpeed)
+	w.Write(buf)
+	w.Close()
+	h := out.Len() << 1
+	return append([]byte{byte(h), byte(h >> 8), byte(h >> 16)}, out.Bytes()...)
+}
+
+// writeTestFile writes a struct<id:bigint,name:string> with the
+// rows (1, "a"), (2, NULL), (3, "ccc").
+func writeTestFile(kind compressionKind) []byte {
+	file := []byte(magic)
+
+	streams := []struct {
+		kind   streamKind
+		column uint64
+		data   []byte
+	}{
+		{streamData, 1, []byte{0xfd, 2, 4, 6}},
+		{streamPresent, 2, []byte{0xff, 0xa0}},
+		{streamLength, 2, []byte{0xfe, 1, 3}},
+		{streamData, 2, []byte("accc")},
+	}
+	var sf []byte
+	var dataLength uint64
+	for _, s := range streams {
+		data := compress(kind, s.data)
+		file = append(file, data...)
+		dataLength += uint64(len(data))
+		sf = appendBytesField(sf, 1, appendField(appendField(appendField(nil, 1, uint64(s.kind)), 2, s.column), 3, uint64(len(data))))
+	}
+	for i := 0; i < 3; i++ {
+		sf = appendBytesField(sf, 2, appendField(nil, 1, uint64(encodingDirect)))
+	}
+	sf = compress(kind, sf)
+	file = append(file, sf...)
+
+	var footer []byte
+	stripe := appendField(nil, 1, uint64(len(magic)))
+	stripe = appendField(stripe, 2, 0)
+	stripe = appendField(stripe, 3, dataLength)
+	stripe = appendField(stripe, 4, uint64(len(sf)))
+	stripe = appendField(stripe, 5, 3)
+	footer = appendBytesField(footer, 3, stripe)
+	root := appendField(nil, 1, uint64(kindStruct))
+	root = appendBytesField(root, 2, []byte{1, 2})
+	root = appendBytesField(appendBytesField(root, 3, []byte("id")), 3, []byte("name"))
+	footer = appendBytesField(footer, 4, root)
+	footer = appendBytesField(footer, 4, appendField(nil, 1, uint64(kindLong)))
+	footer = appendBytesField(footer, 4, appendField(nil, 1, uint64(kindString)))
+	footer = appendField(footer, 6, 3)
+	footer = compress(kind, footer)
+	file = append(file, footer...)
+
+	ps := appendField(nil, 1, uint64(len(footer)))
+	ps = appendField(ps, 2, uint64(kind))
+	ps = appendField(ps, 3, 256*1024)
+	ps = appendBytesField(ps, 8000, []byte(magic))
+	file = append(file, ps...)
+	return append(file, byte(len(ps)))
+}
+
+type nopReadSeekCloser struct {
+	*bytes.Reader
+}
+
+func (nopReadSeekCloser) Close() error { return nil }
+
+func TestORCReader(t *testing.T) {
+	for _, kind := range []compressionKind{compressionNone, compressionZlib} {
+		file := writeTestFile(kind)
+		r, err := NewReader(nopReadSeekCloser{bytes.NewReader(file)}, &ReaderArgs{})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		var rows [][]interface{}
+		for {
+			rec, err := r.Read(nil)
+			if err == io.EOF {
+				break
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			var row []interface{}
+			for _, kv := range rec.(*jsonfmt.Record).KVS {
+				row = append(row, kv.Value)
+			}
+			rows = append(rows, row)
+		}
+
+		expected := [][]interface{}{{int64(1), "a"}, {int64(2), nil}, {int64(3), "ccc"}}
+		if !reflect.DeepEqual(rows, expected) {
+			t.Errorf("compression %d: expected %v, got %v", kind, expected, rows)
+		}
+	}
+}
@@ -0,0 +1,417 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package orc
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"io"
+)
+
+var errInvalidRLE = errors.New("invalid run length encoding")
+
+// byteRLEReader decodes the byte run length encoding.
+type byteRLEReader struct {
+	r      *bytes.Reader
+	values []byte
+}
+
+func (br *byteRLEReader) next() (byte, error) {
+	if len(br.values) == 0 {
+		h, err := br.r.ReadByte()
+		if err != nil {
+			return 0, err
+		}
+		if h < 0x80 {
+			// A run of h+3 copies of the next byte.
+			v, err := br.r.ReadByte()
+			if err != nil {
+				return 0, unexpectedEOF(err)
+			}
+			br.values = bytes.Repeat([]byte{v}, int(h)+3)
+		} else {
+			// 256-h literal bytes.
+			br.values = make([]byte, 256-int(h))
+			if _, err = io.ReadFull(br.r, br.values); err != nil {
+				return 0, unexpectedEOF(err)
+			}
+		}
+	}
+	v := br.values[0]
+	br.values = br.values[1:]
+	return v, nil
+}
+
+// boolRLEReader decodes bits, most significant first, from
+// the byte run length encoding.
+type boolRLEReader struct {
+	bytes byteRLEReader
+	cur   byte
+	bits  int
+}
+
+func (br *boolRLEReader) next() (bool, error) {
+	if br.bits == 0 {
+		b, err := br.bytes.next()
+		if err != nil {
+			return false, err
+		}
+		br.cur, br.bits = b, 8
+	}
+	br.bits--
+	return br.cur&(1<<uint(br.bits)) != 0, nil
+}
+
+// intReader decodes integer run length encodings version 1 and 2.
+type intReader struct {
+	r      *bytes.Reader
+	signed bool
+	v2     bool
+	values []int64
+}
+
+func newIntReader(r *bytes.Reader, signed bool, kind encodingKind) *intReader {
+	return &intReader{
+		r:      r,
+		signed: signed,
+		v2:     kind == encodingDirectV2 || kind == encodingDictionaryV2,
+	}
+}
+
+func (ir *intReader) next() (int64, error) {
+	if len(ir.values) == 0 {
+		if ir.r.Len() == 0 {
+			return 0, io.EOF
+		}
+		var err error
+		if ir.v2 {
+			err = ir.readRunV2()
+		} else {
+			err = ir.readRunV1()
+		}
+		if err != nil {
+			return 0, unexpectedEOF(err)
+		}
+	}
+	v := ir.values[0]
+	ir.values = ir.values[1:]
+	return v, nil
+}
+
+func unexpectedEOF(err error) error {
+	if err == io.EOF {
+		return io.ErrUnexpectedEOF
+	}
+	return err
+}
+
+func zigzag(u uint64) int64 {
+	return int64(u>>1) ^ -int64(u&1)
+}
+
+func (ir *intReader) readVarint() (int64, error) {
+	u, err := binary.ReadUvarint(ir.r)
+	if err != nil {
+		return 0, err
+	}
+	if ir.signed {
+		return zigzag(u), nil
+	}
+	return int64(u), nil
+}
+
+func (ir *intReader) readRunV1() error {
+	h, err := ir.r.ReadByte()
+	if err != nil {
+		return err
+	}
+	if h < 0x80 {
+		// A run of h+3 values starting at base, incremented by delta.
+		delta, err := ir.r.ReadByte()
+		if err != nil {
+			return err
+		}
+		base, err := ir.readVarint()
+		if err != nil {
+			return err
+		}
+		for i := 0; i < int(h)+3; i++ {
+			ir.values = append(ir.values, base+int64(i)*int64(int8(delta)))
+		}
+		return nil
+	}
+	for i := 0; i < 256-int(h); i++ {
+		v, err := ir.readVarint()
+		if err != nil {
+			return err
+		}
+		ir.values = append(ir.values, v)
+	}
+	return nil
+}
+
+// decodeBitWidth maps the 5 bit encoded width of RLEv2 to a bit width.
+func decodeBitWidth(n byte) int {
+	switch {
+	case n <= 23:
+		return int(n) + 1
+	case n == 24:
+		return 26
+	case n == 25:
+		return 28
+	case n == 26:
+		return 30
+	case n == 27:
+		return 32
+	case n == 28:
+		return 40
+	case n == 29:
+		return 48
+	case n == 30:
+		return 56
+	}
+	return 64
+}
+
+// closestFixedBits rounds a bit width up to one that can be encoded.
+func closestFixedBits(n int) int {
+	switch {
+	case n == 0:
+		return 1
+	case n <= 24:
+		return n
+	case n <= 26:
+		return 26
+	case n <= 28:
+		return 28
+	case n <= 30:
+		return 30
+	case n <= 32:
+		return 32
+	case n <= 40:
+		return 40
+	case n <= 48:
+		return 48
+	case n <= 56:
+		return 56
+	}
+	return 64
+}
+
+// readBitPacked reads n big endian values of width bits each.
+func (ir *intReader) readBitPacked(n, width int) ([]uint64, error) {
+	values := make([]uint64, n)
+	var cur byte
+	var avail int
+	for i := range values {
+		var v uint64
+		for need := width; need > 0; {
+			if avail == 0 {
+				b, err := ir.r.ReadByte()
+				if err != nil {
+					return nil, err
+				}
+				cur, avail = b, 8
+			}
+			take := need
+			if take > avail {
+				take = avail
+			}
+			v = v<<uint(take) | uint64(cur>>uint(avail-take))&(1<<uint(take)-1)
+			avail -= take
+			need -= take
+		}
+		values[i] = v
+	}
+	return values, nil
+}
+
+func (ir *intReader) readBigEndian(n int) (uint64, error) {
+	var v uint64
+	for i := 0; i < n; i++ {
+		b, err := ir.r.ReadByte()
+		if err != nil {
+			return 0, err
+		}
+		v = v<<8 | uint64(b)
+	}
+	return v, nil
+}
+
+func (ir *intReader) readRunV2() error {
+	h, err := ir.r.ReadByte()
+	if err != nil {
+		return err
+	}
+	switch h >> 6 {
+	case 0:
+		return ir.readShortRepeat(h)
+	case 1:
+		return ir.readDirect(h)
+	case 2:
+		return ir.readPatchedBase(h)
+	}
+	return ir.readDelta(h)
+}
+
+func (ir *intReader) readShortRepeat(h byte) error {
+	width := int(h>>3&7) + 1
+	count := int(h&7) + 3
+	u, err := ir.readBigEndian(width)
+	if err != nil {
+		return err
+	}
+	v := int64(u)
+	if ir.signed {
+		v = zigzag(u)
+	}
+	for i := 0; i < count; i++ {
+		ir.values = append(ir.values, v)
+	}
+	return nil
+}
+
+func (ir *intReader) readHeaderLength(h byte) (int, error) {
+	b, err := ir.r.ReadByte()
+	if err != nil {
+		return 0, err
+	}
+	return (int(h&1)<<8 | int(b)) + 1, nil
+}
+
+func (ir *intReader) readDirect(h byte) error {
+	width := decodeBitWidth(h >> 1 & 0x1f)
+	n, err := ir.readHeaderLength(h)
+	if err != nil {
+		return err
+	}
+	values, err := ir.readBitPacked(n, width)
+	if err != nil {
+		return err
+	}
+	for _, u := range values {
+		v := int64(u)
+		if ir.signed {
+			v = zigzag(u)
+		}
+		ir.values = append(ir.values, v)
+	}
+	return nil
+}
+
+func (ir *intReader) readPatchedBase(h byte) error {
+	width := decodeBitWidth(h >> 1 & 0x1f)
+	n, err := ir.readHeaderLength(h)
+	if err != nil {
+		return err
+	}
+	b3, err := ir.r.ReadByte()
+	if err != nil {
+		return err
+	}
+	b4, err := ir.r.ReadByte()
+	if err != nil {
+		return err
+	}
+	baseWidth := int(b3>>5) + 1
+	patchWidth := decodeBitWidth(b3 & 0x1f)
+	patchGapWidth := int(b4>>5) + 1
+	patchListLength := int(b4 & 0x1f)
+
+	// The base value is stored with its sign in the most significant bit.
+	u, err := ir.readBigEndian(baseWidth)
+	if err != nil {
+		return err
+	}
+	signBit := uint64(1) << uint(baseWidth*8-1)
+	base := int64(u &^ signBit)
+	if u&signBit != 0 {
+		base = -base
+	}
+
+	values, err := ir.readBitPacked(n, width)
+	if err != nil {
+		return err
+	}
+	patches, err := ir.readBitPacked(patchListLength, closestFixedBits(patchGapWidth+patchWidth))
+	if err != nil {
+		return err
+	}
+
+	pos := 0
+	for _, p := range patches {
+		pos += int(p >> uint(patchWidth))
+		if pos >= n {
+			return errInvalidRLE
+		}
+		values[pos] |= (p & (1<<uint(patchWidth) - 1)) << uint(width)
+	}
+	for _, v := range values {
+		ir.values = append(ir.values, base+int64(v))
+	}
+	return nil
+}
+
+func (ir *intReader) readDelta(h byte) error {
+	width := 0
+	if w := h >> 1 & 0x1f; w != 0 {
+		width = decodeBitWidth(w)
+	}
+	n, err := ir.readHeaderLength(h)
+	if err != nil {
+		return err
+	}
+	base, err := ir.readVarint()
+	if err != nil {
+		return err
+	}
+	du, err := binary.ReadUvarint(ir.r)
+	if err != nil {
+		return err
+	}
+	delta := zigzag(du)
+
+	ir.values = append(ir.values, base)
+	if n == 1 {
+		return nil
+	}
+	v := base + delta
+	ir.values = append(ir.values, v)
+	if width == 0 {
+		// Fixed delta for the whole run.
+		for i := 2; i < n; i++ {
+			v += delta
+			ir.values = append(ir.values, v)
+		}
+		return nil
+	}
+	deltas, err := ir.readBitPacked(n-2, width)
+	if err != nil {
+		return err
+	}
+	for _, d := range deltas {
+		if delta < 0 {
+			v -= int64(d)
+		} else {
+			v += int64(d)
+		}
+		ir.values = append(ir.values, v)
+	}
+	return nil
+}
@@ -34,8 +34,10 @@ import (
 	"github.com/klauspost/compress/s2"
 	"github.com/klauspost/compress/zstd"
 	gzip "github.com/klauspost/pgzip"
+	"github.com/minio/minio/internal/s3select/avro"
 	"github.com/minio/minio/internal/s3select/csv"
 	"github.com/minio/minio/internal/s3select/json"
+	"github.com/minio/minio/internal/s3select/orc"
 	"github.com/minio/minio/internal/s3select/parquet"
 	"github.com/minio/minio/internal/s3select/simdj"
 	"github.com/minio/minio/internal/s3select/sql"
@@ -54,6 +56,8 @@ const (
 	csvFormat     = "csv"
 	jsonFormat    = "json"
 	parquetFormat = "parquet"
+	avroFormat    = "avro"
+	orcFormat     = "orc"
 )
 
 // CompressionType - represents value inside <CompressionType/> in request XML.
@@ -117,6 +121,8 @@ type InputSerialization struct {
 	CSVArgs         csv.ReaderArgs     `xml:"CSV"`
 	JSONArgs        json.ReaderArgs    `xml:"JSON"`
 	ParquetArgs     parquet.ReaderArgs `xml:"Parquet"`
+	AvroArgs        avro.ReaderArgs    `xml:"Avro"`
+	ORCArgs         orc.ReaderArgs     `xml:"ORC"`
 	unmarshaled     bool
 	format          string
 }
@@ -157,6 +163,22 @@ func (input *InputSerialization) UnmarshalXML(d *xml.Decoder, start xml.StartEle
 		parsedInput.format = parquetFormat
 		found++
 	}
+	if !parsedInput.AvroArgs.IsEmpty() {
+		if parsedInput.CompressionType != "" && parsedInput.CompressionType != noneType {
+			return errInvalidRequestParameter(fmt.Errorf("CompressionType must be NONE for Avro format"))
+		}
+
+		parsedInput.format = avroFormat
+		found++
+	}
+	if !parsedInput.ORCArgs.IsEmpty() {
+		if parsedInput.CompressionType != "" && parsedInput.CompressionType != noneType {
+			return errInvalidRequestParameter(fmt.Errorf("CompressionType must be NONE for ORC format"))
+		}
+
+		parsedInput.format = orcFormat
+		found++
+	}
 
 	if found != 1 {
 		return errInvalidDataSource(nil)
@@ -361,7 +383,7 @@ func (s3Select *S3Select) getProgress() (bytesScanned, bytesProcessed int64) {
 }
 
 // Open - opens S3 object by using callback for SQL selection query.
-// Currently CSV, JSON and Apache Parquet formats are supported.
+// Currently CSV, JSON, Apache Parquet, Apache Avro and Apache ORC formats are supported.
 func (s3Select *S3Select) Open(rsc io.ReadSeekCloser) error {
 	offset, length, err := s3Select.ScanRange.StartLen()
 	if err != nil {
@@ -450,6 +472,22 @@ func (s3Select *S3Select) Open(rsc io.ReadSeekCloser) error {
 		var err error
 		s3Select.recordReader, err = parquet.NewParquetReader(rsc, &s3Select.Input.ParquetArgs)
 		return err
+	case avroFormat:
+		if offset != 0 || length != -1 {
+			// Offsets do not make sense in avro container files.
+			return errors.New("avro format does not support offsets")
+		}
+		var err error
+		s3Select.recordReader, err = avro.NewReader(rsc, &s3Select.Input.AvroArgs)
+		return err
+	case orcFormat:
+		if offset != 0 || length != -1 {
+			// Offsets do not make sense in ORC files.
+			return errors.New("orc format does not support offsets")
+		}
+		var err error
+		s3Select.recordReader, err = orc.NewReader(rsc, &s3Select.Input.ORCArgs)
+		return err
 	}
 
 	return fmt.Errorf("unknown input format '%v'", s3Select.Input.format)
@@ -38,6 +38,10 @@ const (
 	SelectFmtSIMDJSON
 	// SelectFmtParquet - Parquet format
 	SelectFmtParquet
+	// SelectFmtAvro - Avro format
+	SelectFmtAvro
+	// SelectFmtORC - ORC format
+	SelectFmtORC
 )
 
 // WriteCSVOpts - encapsulates options for Select CSV output
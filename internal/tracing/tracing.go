@@ -0,0 +1,267 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+// Package tracing records the spans of a request across the
+// nodes of a deployment and propagates their context with
+// the W3C traceparent header.
+package tracing
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/binary"
+	"encoding/hex"
+	"math"
+	"net/http"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// TraceparentHeader is the W3C trace context header
+// carrying the trace ID and the parent span ID.
+const TraceparentHeader = "traceparent"
+
+// SpanKind is the OTLP kind of a span.
+type SpanKind int
+
+// Span kinds as defined by OTLP.
+const (
+	KindInternal SpanKind = 1
+	KindServer   SpanKind = 2
+	KindClient   SpanKind = 3
+)
+
+// SpanContext identifies a span within a trace.
+type SpanContext struct {
+	TraceID [16]byte
+	SpanID  [8]byte
+	Sampled bool
+}
+
+// IsValid returns true if both the trace and the span ID are set.
+func (sc SpanContext) IsValid() bool {
+	return sc.TraceID != [16]byte{} && sc.SpanID != [8]byte{}
+}
+
+// Traceparent returns sc encoded as traceparent header value.
+func (sc SpanContext) Traceparent() string {
+	flags := "00"
+	if sc.Sampled {
+		flags = "01"
+	}
+	return "00-" + hex.EncodeToString(sc.TraceID[:]) + "-" + hex.EncodeToString(sc.SpanID[:]) + "-" + flags
+}
+
+// ParseTraceparent parses a traceparent header value, only
+// the version 00 format is understood.
+func ParseTraceparent(s string) (sc SpanContext, ok bool) {
+	fields := strings.Split(strings.TrimSpace(s), "-")
+	if len(fields) != 4 || fields[0] != "00" {
+		return sc, false
+	}
+	if len(fields[1]) != 32 || len(fields[2]) != 16 || len(fields[3]) != 2 {
+		return sc, false
+	}
+	if _, err := hex.Decode(sc.TraceID[:], []byte(fields[1])); err != nil {
+		return sc, false
+	}
+	if _, err := hex.Decode(sc.SpanID[:], []byte(fields[2])); err != nil {
+		return sc, false
+	}
+	var flags [1]byte
+	if _, err := hex.Decode(flags[:], []byte(fields[3])); err != nil {
+		return sc, false
+	}
+	sc.Sampled = flags[0]&0x01 == 0x01
+	return sc, sc.IsValid()
+}
+
+// Span is a single timed operation of a trace. All methods
+// may be called on a nil span, which records nothing, so that
+// callers do not have to check whether tracing is enabled.
+type Span struct {
+	Name     string
+	Kind     SpanKind
+	Context  SpanContext
+	ParentID [8]byte
+	Start    time.Time
+	End      time.Time
+
+	mu         sync.Mutex
+	attributes map[string]string
+	err        string
+}
+
+// SetAttributes sets the attributes given as key value pairs.
+func (s *Span) SetAttributes(kv ...string) {
+	if s == nil {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.attributes == nil {
+		s.attributes = make(map[string]string, len(kv)/2)
+	}
+	for i := 0; i+1 < len(kv); i += 2 {
+		s.attributes[kv[i]] = kv[i+1]
+	}
+}
+
+// Attributes returns a copy of the attributes of the span.
+func (s *Span) Attributes() map[string]string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	attrs := make(map[string]string, len(s.attributes))
+	for k, v := range s.attributes {
+		attrs[k] = v
+	}
+	return attrs
+}
+
+// Err returns the error message the span ended with, if any.
+func (s *Span) Err() string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.err
+}
+
+// Finish ends the span, marking it as failed if err is not
+// nil, and hands it to the exporter.
+func (s *Span) Finish(err error) {
+	if s == nil {
+		return
+	}
+	s.mu.Lock()
+	s.End = time.Now()
+	if err != nil {
+		s.err = err.Error()
+	}
+	s.mu.Unlock()
+	if e, ok := exporter.Load().(func(*Span)); ok {
+		e(s)
+	}
+}
+
+var (
+	enabled     int32
+	exporter    atomic.Value // func(*Span)
+	sampleRatio uint64       // math.Float64bits of the ratio
+)
+
+// Enable starts recording spans, finished spans are passed to
+// export. Requests without a sampled parent are sampled with
+// the given ratio in [0, 1].
+func Enable(export func(*Span), ratio float64) {
+	exporter.Store(export)
+	atomic.StoreUint64(&sampleRatio, math.Float64bits(ratio))
+	atomic.StoreInt32(&enabled, 1)
+}
+
+// Enabled returns true if spans are recorded.
+func Enabled() bool {
+	return atomic.LoadInt32(&enabled) == 1
+}
+
+type (
+	spanCtxKey   struct{}
+	remoteCtxKey struct{}
+)
+
+// FromContext returns the current span of ctx, if any.
+func FromContext(ctx context.Context) *Span {
+	span, _ := ctx.Value(spanCtxKey{}).(*Span)
+	return span
+}
+
+// Extract returns ctx with the span context of the traceparent
+// header in h, if present and valid, as remote parent.
+func Extract(ctx context.Context, h http.Header) context.Context {
+	sc, ok := ParseTraceparent(h.Get(TraceparentHeader))
+	if !ok {
+		return ctx
+	}
+	return context.WithValue(ctx, remoteCtxKey{}, sc)
+}
+
+// Inject sets the traceparent header in h to the current span of ctx.
+func Inject(ctx context.Context, h http.Header) {
+	if span := FromContext(ctx); span != nil {
+		h.Set(TraceparentHeader, span.Context.Traceparent())
+	}
+}
+
+// StartRoot starts the span of an incoming request. The span
+// continues the trace of the remote parent extracted from the
+// request, if any, and starts a new sampled trace otherwise.
+// A nil span is returned if the request is not sampled.
+func StartRoot(ctx context.Context, name string, kind SpanKind) (context.Context, *Span) {
+	if !Enabled() {
+		return ctx, nil
+	}
+	if remote, ok := ctx.Value(remoteCtxKey{}).(SpanContext); ok {
+		if !remote.Sampled {
+			return ctx, nil
+		}
+		return start(ctx, name, kind, remote)
+	}
+	if ratio := math.Float64frombits(atomic.LoadUint64(&sampleRatio)); ratio < 1 {
+		var b [8]byte
+		rand.Read(b[:])
+		if float64(binary.BigEndian.Uint64(b[:])>>11)/(1<<53) >= ratio {
+			return ctx, nil
+		}
+	}
+	return start(ctx, name, kind, SpanContext{Sampled: true})
+}
+
+// StartRemote starts the span of an incoming request only if
+// it is part of a sampled trace, e.g. an inter-node call made
+// on behalf of a traced request.
+func StartRemote(ctx context.Context, name string, kind SpanKind) (context.Context, *Span) {
+	if _, ok := ctx.Value(remoteCtxKey{}).(SpanContext); !ok {
+		return ctx, nil
+	}
+	return StartRoot(ctx, name, kind)
+}
+
+// Start starts a child span of the current span of ctx. A nil
+// span is returned if ctx has no span, operations which are not
+// part of a traced request are not recorded.
+func Start(ctx context.Context, name string, kind SpanKind) (context.Context, *Span) {
+	parent := FromContext(ctx)
+	if parent == nil {
+		return ctx, nil
+	}
+	return start(ctx, name, kind, parent.Context)
+}
+
+func start(ctx context.Context, name string, kind SpanKind, parent SpanContext) (context.Context, *Span) {
+	span := &Span{
+		Name:     name,
+		Kind:     kind,
+		Context:  parent,
+		ParentID: parent.SpanID,
+		Start:    time.Now(),
+	}
+	if span.Context.TraceID == [16]byte{} {
+		rand.Read(span.Context.TraceID[:])
+	}
+	rand.Read(span.Context.SpanID[:])
+	return context.WithValue(ctx, spanCtxKey{}, span), span
+}
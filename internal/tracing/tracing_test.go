@@ -0,0 +1,116 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package tracing
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+)
+
+func TestParseTraceparent(t *testing.T) {
+	testCases := []struct {
+		value   string
+		ok      bool
+		sampled bool
+	}{
+		{"00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01", true, true},
+		{"00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-00", true, false},
+		{"01-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01", false, false},
+		{"00-00000000000000000000000000000000-00f067aa0ba902b7-01", false, false},
+		{"00-4bf92f3577b34da6a3ce929d0e0e4736-0000000000000000-01", false, false},
+		{"00-4bf92f3577b34da6a3ce929d0e0e473-00f067aa0ba902b7-01", false, false},
+		{"00-4bf92f3577b34da6a3ce929d0e0e47zz-00f067aa0ba902b7-01", false, false},
+		{"", false, false},
+	}
+	for i, tc := range testCases {
+		sc, ok := ParseTraceparent(tc.value)
+		if ok != tc.ok {
+			t.Fatalf("Test %d: expected ok %v, got %v", i+1, tc.ok, ok)
+		}
+		if !ok {
+			continue
+		}
+		if sc.Sampled != tc.sampled {
+			t.Fatalf("Test %d: expected sampled %v, got %v", i+1, tc.sampled, sc.Sampled)
+		}
+		if got := sc.Traceparent(); got != tc.value {
+			t.Fatalf("Test %d: expected %s, got %s", i+1, tc.value, got)
+		}
+	}
+}
+
+func TestSpans(t *testing.T) {
+	if _, span := StartRoot(context.Background(), "disabled", KindServer); span != nil {
+		t.Fatal("expected no span while tracing is disabled")
+	}
+
+	var finished []*Span
+	Enable(func(s *Span) { finished = append(finished, s) }, 1)
+
+	// Operations outside of a request are not recorded.
+	if _, span := Start(context.Background(), "background", KindInternal); span != nil {
+		t.Fatal("expected no span without a parent")
+	}
+	if _, span := StartRemote(context.Background(), "internode", KindServer); span != nil {
+		t.Fatal("expected no span without a remote parent")
+	}
+
+	h := http.Header{}
+	h.Set(TraceparentHeader, "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+	ctx, root := StartRoot(Extract(context.Background(), h), "s3.PutObject", KindServer)
+	if root.Context.TraceID != [16]byte{0x4b, 0xf9, 0x2f, 0x35, 0x77, 0xb3, 0x4d, 0xa6, 0xa3, 0xce, 0x92, 0x9d, 0x0e, 0x0e, 0x47, 0x36} {
+		t.Fatalf("expected the trace of the remote parent, got %x", root.Context.TraceID)
+	}
+	if root.ParentID != [8]byte{0x00, 0xf0, 0x67, 0xaa, 0x0b, 0xa9, 0x02, 0xb7} {
+		t.Fatalf("expected the remote parent, got %x", root.ParentID)
+	}
+
+	_, child := Start(ctx, "erasure.encode", KindInternal)
+	child.SetAttributes("erasure.data_blocks", "4")
+	child.Finish(errors.New("write quorum"))
+	root.Finish(nil)
+
+	if len(finished) != 2 {
+		t.Fatalf("expected 2 finished spans, got %d", len(finished))
+	}
+	if child.Context.TraceID != root.Context.TraceID || child.ParentID != root.Context.SpanID {
+		t.Fatal("expected the child span to be part of the root span")
+	}
+	if child.Err() != "write quorum" || child.Attributes()["erasure.data_blocks"] != "4" {
+		t.Fatalf("unexpected child span %s %v", child.Err(), child.Attributes())
+	}
+
+	out := http.Header{}
+	Inject(ctx, out)
+	if sc, ok := ParseTraceparent(out.Get(TraceparentHeader)); !ok || sc.SpanID != root.Context.SpanID {
+		t.Fatalf("unexpected injected traceparent %q", out.Get(TraceparentHeader))
+	}
+
+	// Unsampled remote parents are honored.
+	h.Set(TraceparentHeader, "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-00")
+	if _, span := StartRoot(Extract(context.Background(), h), "s3.GetObject", KindServer); span != nil {
+		t.Fatal("expected no span for an unsampled parent")
+	}
+
+	Enable(func(s *Span) { finished = append(finished, s) }, 0)
+	if _, span := StartRoot(context.Background(), "s3.GetObject", KindServer); span != nil {
+		t.Fatal("expected no span with a sample ratio of 0")
+	}
+}